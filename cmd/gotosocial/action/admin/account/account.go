@@ -121,11 +121,6 @@ var List action.GTSAction = func(ctx context.Context) error {
 		return err
 	}
 
-	users, err := state.DB.GetAllUsers(ctx)
-	if err != nil {
-		return err
-	}
-
 	fmtBool := func(b *bool) string {
 		if b == nil {
 			return "unknown"
@@ -145,9 +140,27 @@ var List action.GTSAction = func(ctx context.Context) error {
 
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
 	fmt.Fprintln(w, "user\taccount\tapproved\tadmin\tmoderator\tsuspended\tconfirmed")
-	for _, u := range users {
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", u.Account.Username, u.AccountID, fmtBool(u.Approved), fmtBool(u.Admin), fmtBool(u.Moderator), fmtDate(u.Account.SuspendedAt), fmtDate(u.ConfirmedAt))
+
+	// Page through users rather than
+	// loading them all into memory.
+	var maxID string
+	for {
+		users, err := state.DB.GetUsersPage(ctx, maxID, 100)
+		if err != nil {
+			return err
+		}
+
+		if len(users) == 0 {
+			break
+		}
+
+		for _, u := range users {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", u.Account.Username, u.AccountID, fmtBool(u.Approved), fmtBool(u.Admin), fmtBool(u.Moderator), fmtDate(u.Account.SuspendedAt), fmtDate(u.ConfirmedAt))
+		}
+
+		maxID = users[len(users)-1].ID
 	}
+
 	return w.Flush()
 }
 
@@ -0,0 +1,203 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+func initState(ctx context.Context) (*state.State, error) {
+	var state state.State
+	state.Caches.Init()
+	state.Caches.Start()
+
+	// Set the state DB connection
+	dbConn, err := bundb.NewBunDBService(ctx, &state)
+	if err != nil {
+		return nil, fmt.Errorf("error creating dbConn: %w", err)
+	}
+	state.DB = dbConn
+
+	return &state, nil
+}
+
+func stopState(state *state.State) error {
+	err := state.DB.Close()
+	state.Caches.Stop()
+	return err
+}
+
+// getDomain returns the punified domain
+// flag value, ensuring it's not this domain.
+func getDomain() (string, error) {
+	domain := strings.TrimSpace(config.GetAdminDomain())
+	if domain == "" {
+		return "", errors.New("no domain given")
+	}
+
+	domain, err := util.Punify(domain)
+	if err != nil {
+		return "", fmt.Errorf("error punifying domain %s: %w", domain, err)
+	}
+
+	if domain == config.GetHost() || domain == config.GetAccountDomain() {
+		return "", errors.New("provided domain was this domain, but must be a remote domain")
+	}
+
+	return domain, nil
+}
+
+// warnBlocklistMode logs a warning if the instance
+// is not running in allowlist federation mode, since
+// domain allows created or removed via the CLI will
+// not have any block side effects (un)done.
+func warnBlocklistMode(ctx context.Context) {
+	if config.GetInstanceFederationMode() == config.InstanceFederationModeAllowlist {
+		return
+	}
+
+	log.Warn(ctx,
+		"instance is not running in allowlist federation mode; "+
+			"side effects of any existing domain block for this domain "+
+			"will not be processed, use the admin API to process them",
+	)
+}
+
+// Allow creates a domain allow for the given
+// domain, which will be permitted to federate
+// with this instance in allowlist federation mode.
+var Allow action.GTSAction = func(ctx context.Context) error {
+	state, err := initState(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		// Ensure state gets stopped on return.
+		if err := stopState(state); err != nil {
+			log.Error(ctx, err)
+		}
+	}()
+
+	domain, err := getDomain()
+	if err != nil {
+		return err
+	}
+
+	allow, err := state.DB.GetDomainAllow(ctx, domain)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+
+	if allow != nil {
+		return fmt.Errorf("domain %s is already allowed", domain)
+	}
+
+	// Allows created via the CLI are
+	// attributed to the instance account.
+	instanceAcct, err := state.DB.GetInstanceAccount(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	warnBlocklistMode(ctx)
+
+	return state.DB.CreateDomainAllow(ctx, &gtsmodel.DomainAllow{
+		ID:                 id.NewULID(),
+		Domain:             domain,
+		CreatedByAccountID: instanceAcct.ID,
+		PrivateComment:     text.SanitizeToPlaintext(config.GetAdminDomainPrivateComment()),
+		Obfuscate:          util.Ptr(false),
+	})
+}
+
+// Unallow removes the domain allow for the given
+// domain, so that it will no longer be permitted to
+// federate with this instance in allowlist federation mode.
+var Unallow action.GTSAction = func(ctx context.Context) error {
+	state, err := initState(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		// Ensure state gets stopped on return.
+		if err := stopState(state); err != nil {
+			log.Error(ctx, err)
+		}
+	}()
+
+	domain, err := getDomain()
+	if err != nil {
+		return err
+	}
+
+	if _, err := state.DB.GetDomainAllow(ctx, domain); err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			return fmt.Errorf("domain %s is not allowed", domain)
+		}
+		return err
+	}
+
+	warnBlocklistMode(ctx)
+
+	return state.DB.DeleteDomainAllow(ctx, domain)
+}
+
+// ListAllows lists all existing domain allows.
+var ListAllows action.GTSAction = func(ctx context.Context) error {
+	state, err := initState(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		// Ensure state gets stopped on return.
+		if err := stopState(state); err != nil {
+			log.Error(ctx, err)
+		}
+	}()
+
+	allows, err := state.DB.GetDomainAllows(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	fmt.Fprintln(w, "domain\tcreated\tprivate comment")
+	for _, a := range allows {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", a.Domain, a.CreatedAt.Format("2006-01-02"), a.PrivateComment)
+	}
+	return w.Flush()
+}
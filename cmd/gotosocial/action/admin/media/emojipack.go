@@ -0,0 +1,185 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+	"github.com/superseriousbusiness/gotosocial/internal/emojipack"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
+)
+
+// setupEmojiPack initializes the state needed for
+// emoji pack commands, returning a packer along with
+// a function to call to stop everything when done.
+func setupEmojiPack(ctx context.Context) (*emojipack.Packer, func() error, error) {
+	var state state.State
+
+	state.Caches.Init()
+	state.Caches.Start()
+
+	// Only the scheduler is
+	// needed for this CLI action.
+	state.Workers.StartScheduler()
+
+	dbService, err := bundb.NewBunDBService(ctx, &state)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating dbservice: %w", err)
+	}
+	state.DB = dbService
+
+	//nolint:contextcheck
+	storage, err := gtsstorage.AutoConfig()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating storage backend: %w", err)
+	}
+	state.Storage = storage
+
+	//nolint:contextcheck
+	manager := media.NewManager(&state)
+
+	shutdown := func() error {
+		errs := gtserror.NewMultiError(1)
+
+		if err := dbService.Close(); err != nil {
+			errs.Appendf("error stopping database: %w", err)
+		}
+
+		state.Workers.Scheduler.Stop()
+		state.Caches.Stop()
+
+		return errs.Combine()
+	}
+
+	return emojipack.New(&state, manager), shutdown, nil
+}
+
+// ExportEmojiPack exports local emojis to a Pleroma-compatible
+// emoji pack zip archive at the configured path.
+var ExportEmojiPack action.GTSAction = func(ctx context.Context) error {
+	path := config.GetAdminTransPath()
+	if path == "" {
+		return errors.New("no path set")
+	}
+
+	packer, shutdown, err := setupEmojiPack(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err := shutdown(); err != nil {
+			log.Error(ctx, err)
+		}
+	}()
+
+	emojis, err := packer.LocalEmojis(ctx, config.GetAdminEmojiPackCategory())
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("error creating %s: %w", path, err)
+	}
+
+	if err := packer.Export(ctx, file, emojis); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("error closing %s: %w", path, err)
+	}
+
+	log.Infof(ctx, "exported %d emojis to %s", len(emojis), path)
+	return nil
+}
+
+// ImportEmojiPack imports local emojis from a Pleroma-compatible
+// emoji pack zip archive at the configured path.
+var ImportEmojiPack action.GTSAction = func(ctx context.Context) error {
+	path := config.GetAdminTransPath()
+	if path == "" {
+		return errors.New("no path set")
+	}
+
+	onConflict, err := emojipack.ParseOnConflict(config.GetAdminEmojiPackOnConflict())
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("error opening %s: %w", path, err)
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("error statting %s: %w", path, err)
+	}
+
+	packer, shutdown, err := setupEmojiPack(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err := shutdown(); err != nil {
+			log.Error(ctx, err)
+		}
+	}()
+
+	result, err := packer.Import(ctx,
+		file,
+		stat.Size(),
+		config.GetAdminEmojiPackCategory(),
+		onConflict,
+	)
+	if err != nil {
+		return err
+	}
+
+	for shortcode, reason := range result.Failed {
+		log.Warnf(ctx, "could not import emoji %s: %s", shortcode, reason)
+	}
+
+	log.Infof(ctx,
+		"imported %d emojis, replaced %d, skipped %d, failed %d",
+		len(result.Imported), len(result.Replaced),
+		len(result.Skipped), len(result.Failed),
+	)
+
+	if len(result.Skipped) > 0 {
+		log.Infof(ctx, "skipped existing emojis: %s", strings.Join(result.Skipped, ", "))
+	}
+
+	return nil
+}
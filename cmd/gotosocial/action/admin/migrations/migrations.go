@@ -0,0 +1,119 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action"
+	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/uptrace/bun/migrate"
+)
+
+// Status prints all known database migrations,
+// and whether / in which group they were applied.
+var Status action.GTSAction = func(ctx context.Context) error {
+	return withMigrator(ctx, func(m *bundb.Migrator) error {
+		ms, err := m.Status(ctx)
+		if err != nil {
+			return fmt.Errorf("error getting migration status: %w", err)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+		fmt.Fprintln(w, "migration\tgroup\tmigrated at")
+		for _, m := range ms {
+			if !m.IsApplied() {
+				fmt.Fprintf(w, "%s\t-\tpending\n", m)
+				continue
+			}
+			fmt.Fprintf(w, "%s\t%d\t%s\n", m, m.GroupID, m.MigratedAt.Format("2006-01-02 15:04:05"))
+		}
+		return w.Flush()
+	})
+}
+
+// Up applies all pending database migrations as a new group.
+var Up action.GTSAction = func(ctx context.Context) error {
+	return withMigrator(ctx, func(m *bundb.Migrator) error {
+		group, err := m.Up(ctx)
+		if err != nil {
+			return fmt.Errorf("error applying migrations (%s): %w", group, err)
+		}
+
+		logGroup(ctx, "applied", group)
+		return nil
+	})
+}
+
+// Down rolls back the most recently applied group of
+// database migrations, ie., those applied by the last
+// upgrade, so that a previous version can be run again.
+var Down action.GTSAction = func(ctx context.Context) error {
+	return withMigrator(ctx, func(m *bundb.Migrator) error {
+		group, err := m.Down(ctx)
+		if err != nil {
+			return fmt.Errorf("error rolling back migrations (%s): %w", group, err)
+		}
+
+		logGroup(ctx, "rolled back", group)
+		return nil
+	})
+}
+
+// Redo rolls back the most recently applied group
+// of database migrations, then applies them again.
+var Redo action.GTSAction = func(ctx context.Context) error {
+	return withMigrator(ctx, func(m *bundb.Migrator) error {
+		group, err := m.Redo(ctx)
+		if err != nil {
+			return fmt.Errorf("error redoing migrations (%s): %w", group, err)
+		}
+
+		logGroup(ctx, "reapplied", group)
+		return nil
+	})
+}
+
+// withMigrator opens a new database migrator,
+// passes it to fn, then closes it on return.
+func withMigrator(ctx context.Context, fn func(*bundb.Migrator) error) error {
+	m, err := bundb.NewMigrator(ctx)
+	if err != nil {
+		return fmt.Errorf("error creating migrator: %w", err)
+	}
+
+	if err := fn(m); err != nil {
+		_ = m.Close()
+		return err
+	}
+
+	return m.Close()
+}
+
+func logGroup(ctx context.Context, did string, group *migrate.MigrationGroup) {
+	if group.IsZero() {
+		log.Infof(ctx, "no migrations %s", did)
+		return
+	}
+
+	log.Infof(ctx, "%s %s", did, group)
+}
@@ -0,0 +1,67 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/migrations"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type MigrationsTestSuite struct {
+	suite.Suite
+}
+
+func (suite *MigrationsTestSuite) SetupTest() {
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+
+	if config.GetDbType() != "sqlite" {
+		suite.T().Skip("migrations tests run against a fresh sqlite database")
+	}
+
+	config.SetDbAddress(filepath.Join(suite.T().TempDir(), "sqlite.db"))
+}
+
+func (suite *MigrationsTestSuite) TestActions() {
+	ctx := context.Background()
+
+	suite.NoError(migrations.Status(ctx))
+	suite.NoError(migrations.Up(ctx))
+	suite.NoError(migrations.Status(ctx))
+	suite.NoError(migrations.Redo(ctx))
+	suite.NoError(migrations.Down(ctx))
+
+	// Rolling back past the earliest
+	// reversible migration is refused.
+	suite.ErrorIs(migrations.Down(ctx), bundb.ErrIrreversible)
+	suite.ErrorIs(migrations.Redo(ctx), bundb.ErrIrreversible)
+
+	suite.NoError(migrations.Up(ctx))
+	suite.NoError(migrations.Status(ctx))
+}
+
+func TestMigrationsTestSuite(t *testing.T) {
+	suite.Run(t, new(MigrationsTestSuite))
+}
@@ -21,6 +21,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/rand"
 	"net/http"
 	"os"
 	"os/signal"
@@ -45,6 +46,7 @@ import (
 
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+	"github.com/superseriousbusiness/gotosocial/internal/disposable"
 	"github.com/superseriousbusiness/gotosocial/internal/email"
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
 	"github.com/superseriousbusiness/gotosocial/internal/federation/federatingdb"
@@ -145,6 +147,16 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	// Set DB on state.
 	state.DB = dbService
 
+	if config.GetCacheInvalidationBus() == config.CacheInvalidationBusPostgres {
+		// Propagate cache invalidations to / from
+		// other processes sharing this database.
+		bus, err := bundb.NewPostgresCacheBus(ctx)
+		if err != nil {
+			return fmt.Errorf("error creating cache invalidation bus: %w", err)
+		}
+		state.Caches.StartBus(bus)
+	}
+
 	// Ensure necessary database instance prerequisites exist.
 	if err := dbService.CreateInstanceAccount(ctx); err != nil {
 		return fmt.Errorf("error creating instance account: %s", err)
@@ -174,6 +186,9 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		BlockRanges:           config.MustParseIPPrefixes(config.GetHTTPClientBlockIPs()),
 		Timeout:               config.GetHTTPClientTimeout(),
 		TLSInsecureSkipVerify: config.GetHTTPClientTLSInsecureSkipVerify(),
+		MaxIdleConnsPerHost:   config.GetHTTPClientMaxIdleConnsPerHost(),
+		IdleConnTimeout:       config.GetHTTPClientIdleConnTimeout(),
+		DisableHTTP2:          config.GetHTTPClientDisableHTTP2(),
 	})
 
 	// Build handlers used in later initializations.
@@ -265,6 +280,13 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	state.Workers.Client.Init(messages.ClientMsgIndices())
 	state.Workers.Federator.Init(messages.FederatorMsgIndices())
 	state.Workers.Delivery.Init(client)
+	state.Workers.Delivery.Paused = func(ctx context.Context, host string) bool {
+		paused, err := state.DB.IsDomainPaused(ctx, host)
+		if err != nil {
+			log.Errorf(ctx, "error checking domain pause for %s: %v", host, err)
+		}
+		return paused
+	}
 	state.Workers.Client.Process = processor.Workers().ProcessFromClientAPI
 	state.Workers.Federator.Process = processor.Workers().ProcessFromFediAPI
 
@@ -276,8 +298,142 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		return fmt.Errorf("error scheduling poll expiries: %w", err)
 	}
 
+	// Schedule any delayed follow request auto-accepts.
+	if err := processor.Account().ScheduleAllFollowAutoAccepts(ctx); err != nil {
+		return fmt.Errorf("error scheduling follow auto-accepts: %w", err)
+	}
+
+	// Requeue processing of any imports that
+	// were interrupted by a previous shutdown.
+	if err := processor.Account().ImportsResume(ctx); err != nil {
+		return fmt.Errorf("error resuming imports: %w", err)
+	}
+
+	// Requeue processing of any account deletes
+	// that were interrupted by a previous shutdown.
+	if err := processor.Account().DeletesResume(ctx); err != nil {
+		return fmt.Errorf("error resuming account deletes: %w", err)
+	}
+
+	// Fail processing of any media uploads that
+	// were interrupted by a previous shutdown.
+	if err := processor.Media().FailInterrupted(ctx); err != nil {
+		return fmt.Errorf("error failing interrupted media uploads: %w", err)
+	}
+
+	// Add a task to the scheduler to resolve moved
+	// accounts that are still followed by local
+	// accounts, in case their Moves were missed.
+	// Frequency = 24 * hours
+	if !state.Workers.Scheduler.AddRecurring(
+		"@movesresolve",           // id
+		time.Now().Add(time.Hour), // start
+		24*time.Hour,              // freq
+		func(ctx context.Context, start time.Time) {
+			log.Info(ctx, "starting moves resolve")
+			resolved, cleaned, errs := processor.Admin().ResolveMoves(ctx, "")
+			if errs != nil {
+				log.Errorf(ctx, "error(s) resolving moves: %v", errs.Combine())
+			}
+			log.Infof(ctx, "finished moves resolve after %s: queued %d move(s), and %d stale moved account(s) for deletion", time.Since(start), resolved, cleaned)
+		},
+	) {
+		return fmt.Errorf("error scheduling moves resolve")
+	}
+
+	// Add a task to the scheduler to regenerate
+	// local account stats from scratch, fixing
+	// any drift in the counts kept up to date
+	// on writes, since these are never
+	// regenerated when read.
+	// Frequency = 24 * hours
+	if !state.Workers.Scheduler.AddRecurring(
+		"@accountstatsreconcile",  // id
+		time.Now().Add(time.Hour), // start
+		24*time.Hour,              // freq
+		func(ctx context.Context, start time.Time) {
+			log.Info(ctx, "starting account stats reconcile")
+			n, err := processor.Admin().AccountStatsReconcile(ctx)
+			if err != nil {
+				log.Errorf(ctx, "error reconciling account stats: %v", err)
+			}
+			log.Infof(ctx, "finished account stats reconcile after %s; fixed %d", time.Since(start), n)
+		},
+	) {
+		return fmt.Errorf("error scheduling account stats reconcile")
+	}
+
+	// Add a task to the scheduler to send email
+	// digests of missed notifications to users
+	// who have opted in to receiving them.
+	// Frequency = 1 * hour
+	if !state.Workers.Scheduler.AddRecurring(
+		"@emaildigests",           // id
+		time.Now().Add(time.Hour), // start
+		time.Hour,                 // freq
+		func(ctx context.Context, start time.Time) {
+			n, err := processor.User().SendEmailDigests(ctx, start)
+			if err != nil {
+				log.Errorf(ctx, "error(s) sending email digests: %v", err)
+			}
+			log.Infof(ctx, "finished sending email digests after %s; sent %d", time.Since(start), n)
+		},
+	) {
+		return fmt.Errorf("error scheduling email digests")
+	}
+
+	// Add a task to the scheduler to fetch the
+	// configured list of disposable email domains,
+	// if set, starting now to populate it at boot.
+	// Frequency = 24 * hours
+	if url := config.GetAccountsDisposableEmailListURL(); url != "" {
+		if !state.Workers.Scheduler.AddRecurring(
+			"@disposablerefresh", // id
+			time.Now(),           // start
+			24*time.Hour,         // freq
+			func(ctx context.Context, start time.Time) {
+				n, err := disposable.Refresh(ctx, client, url)
+				if err != nil {
+					log.Errorf(ctx, "error refreshing disposable email domains: %v", err)
+					return
+				}
+				log.Infof(ctx, "refreshed disposable email domains; %d known", n)
+			},
+		) {
+			return fmt.Errorf("error scheduling disposable email domains refresh")
+		}
+	}
+
+	// Add a task to the scheduler to refresh remote
+	// accounts that haven't been fetched in a while,
+	// if enabled. First run is at a random point
+	// within the next day, to spread load out.
+	// Frequency = 24 * hours
+	if days := config.GetAccountsRemoteRefreshDays(); days > 0 {
+		start := time.Now().Add(time.Duration(rand.Int63n(int64(24 * time.Hour)))) //nolint:gosec
+		if !state.Workers.Scheduler.AddRecurring(
+			"@accountsrefresh", // id
+			start,              // start
+			24*time.Hour,       // freq
+			func(ctx context.Context, start time.Time) {
+				log.Info(ctx, "starting stale accounts refresh")
+				fetchedBefore := start.Add(-24 * time.Hour * time.Duration(days))
+				n, err := federator.RefreshStaleAccounts(ctx,
+					fetchedBefore,
+					config.GetAccountsRemoteRefreshPerDomain(),
+				)
+				if err != nil {
+					log.Errorf(ctx, "error refreshing stale accounts: %v", err)
+				}
+				log.Infof(ctx, "finished stale accounts refresh after %s; refreshed %d", time.Since(start), n)
+			},
+		) {
+			return fmt.Errorf("error scheduling accounts refresh")
+		}
+	}
+
 	// Initialize metrics.
-	if err := metrics.Initialize(state.DB); err != nil {
+	if err := metrics.Initialize(state.DB, &state.Caches); err != nil {
 		return fmt.Errorf("error initializing metrics: %w", err)
 	}
 
@@ -342,8 +498,17 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	// attach global middlewares which are used for every request
 	route.AttachGlobalMiddleware(middlewares...)
 
-	// attach global no route / 404 handler to the router
+	// attach global no route / 404 handler to the router,
+	// serving any admin-defined redirects for unknown paths
 	route.AttachNoRouteHandler(func(c *gin.Context) {
+		if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+			target, errWithCode := processor.InstanceRedirectGet(c.Request.Context(), c.Request.URL.Path)
+			if errWithCode == nil {
+				c.Redirect(http.StatusFound, target)
+				return
+			}
+		}
+
 		apiutil.ErrorHandler(c, gtserror.NewErrorNotFound(errors.New(http.StatusText(http.StatusNotFound))), processor.InstanceGetV1)
 	})
 
@@ -395,12 +560,25 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	fsThrottle := middleware.Throttle(cpuMultiplier, retryAfter)  // fileserver / web templates / emojis
 	pkThrottle := middleware.Throttle(cpuMultiplier, retryAfter)  // throttle public key endpoint separately
 
+	// load shedding of low-priority
+	// client api requests when overloaded
+	clShed := middleware.LoadShed(
+		config.GetAdvancedLoadSheddingLatency(),
+		config.GetAdvancedLoadSheddingQueueDepth(),
+		retryAfter,
+		"/api/v1/timelines/public",
+		"/api/v1/timelines/tag/",
+		"/api/v1/accounts/search",
+		"/api/:"+apiutil.APIVersionKey+"/search",
+	)
+
 	gzip := middleware.Gzip() // applied to all except fileserver
 
 	// these should be routed in order;
 	// apply throttling *after* rate limiting
+	// and load shedding
 	authModule.Route(route, clLimit, clThrottle, gzip)
-	clientModule.Route(route, clLimit, clThrottle, gzip)
+	clientModule.Route(route, clLimit, clShed, clThrottle, gzip)
 	metricsModule.Route(route, clLimit, clThrottle, gzip)
 	healthModule.Route(route, clLimit, clThrottle)
 	fileserverModule.Route(route, fsMainLimit, fsThrottle)
@@ -410,6 +588,7 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	activityPubModule.Route(route, s2sLimit, s2sThrottle, gzip)
 	activityPubModule.RoutePublicKey(route, s2sLimit, pkThrottle, gzip)
 	webModule.Route(route, fsMainLimit, fsThrottle, gzip)
+	webModule.RouteModeration(route, authModule.Session(), fsMainLimit, fsThrottle, gzip)
 
 	// Finally start the main http server!
 	if err := route.Start(); err != nil {
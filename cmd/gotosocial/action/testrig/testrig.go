@@ -200,7 +200,7 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	processor := testrig.NewTestProcessor(state, federator, emailSender, mediaManager)
 
 	// Initialize metrics.
-	if err := metrics.Initialize(state.DB); err != nil {
+	if err := metrics.Initialize(state.DB, &state.Caches); err != nil {
 		return fmt.Errorf("error initializing metrics: %w", err)
 	}
 
@@ -254,8 +254,17 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	// attach global middlewares which are used for every request
 	route.AttachGlobalMiddleware(middlewares...)
 
-	// attach global no route / 404 handler to the router
+	// attach global no route / 404 handler to the router,
+	// serving any admin-defined redirects for unknown paths
 	route.AttachNoRouteHandler(func(c *gin.Context) {
+		if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+			target, errWithCode := processor.InstanceRedirectGet(c.Request.Context(), c.Request.URL.Path)
+			if errWithCode == nil {
+				c.Redirect(http.StatusFound, target)
+				return
+			}
+		}
+
 		apiutil.ErrorHandler(c, gtserror.NewErrorNotFound(errors.New(http.StatusText(http.StatusNotFound))), processor.InstanceGetV1)
 	})
 
@@ -302,6 +311,7 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	activityPubModule.Route(route)
 	activityPubModule.RoutePublicKey(route)
 	webModule.Route(route)
+	webModule.RouteModeration(route, authModule.Session())
 
 	// Create background cleaner.
 	cleaner := cleaner.New(state)
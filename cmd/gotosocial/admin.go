@@ -20,8 +20,10 @@ package main
 import (
 	"github.com/spf13/cobra"
 	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/account"
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/domain"
 	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/media"
 	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/media/prune"
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/migrations"
 	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/trans"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 )
@@ -149,7 +151,56 @@ func adminCommands() *cobra.Command {
 	adminCmd.AddCommand(adminAccountCmd)
 
 	/*
-	   ADMIN IMPORT/EXPORT COMMANDS
+	   ADMIN DOMAIN COMMANDS
+	*/
+
+	adminDomainCmd := &cobra.Command{
+		Use:   "domain",
+		Short: "admin commands related to federating domains",
+	}
+
+	adminDomainAllowCmd := &cobra.Command{
+		Use:   "allow",
+		Short: "create a domain allow, permitting the domain to federate with this instance in allowlist federation mode",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), domain.Allow)
+		},
+	}
+	config.AddAdminDomainAllow(adminDomainAllowCmd)
+	adminDomainCmd.AddCommand(adminDomainAllowCmd)
+
+	adminDomainUnallowCmd := &cobra.Command{
+		Use:   "unallow",
+		Short: "remove a domain allow created previously",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), domain.Unallow)
+		},
+	}
+	config.AddAdminDomain(adminDomainUnallowCmd)
+	adminDomainCmd.AddCommand(adminDomainUnallowCmd)
+
+	adminDomainListAllowsCmd := &cobra.Command{
+		Use:   "list-allows",
+		Short: "list all existing domain allows",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), domain.ListAllows)
+		},
+	}
+	adminDomainCmd.AddCommand(adminDomainListAllowsCmd)
+
+	adminCmd.AddCommand(adminDomainCmd)
+
+	/*
+	   ADMIN IMPORT/EXPORT/BACKUP/RESTORE COMMANDS
 	*/
 
 	adminExportCmd := &cobra.Command{
@@ -178,6 +229,32 @@ func adminCommands() *cobra.Command {
 	config.AddAdminTrans(adminImportCmd)
 	adminCmd.AddCommand(adminImportCmd)
 
+	adminBackupCmd := &cobra.Command{
+		Use:   "backup",
+		Short: "back up instance-critical data from the database to file at the given path",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), trans.Backup)
+		},
+	}
+	config.AddAdminTrans(adminBackupCmd)
+	adminCmd.AddCommand(adminBackupCmd)
+
+	adminRestoreCmd := &cobra.Command{
+		Use:   "restore",
+		Short: "restore instance-critical data from a backup file onto a fresh database",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), trans.Restore)
+		},
+	}
+	config.AddAdminTrans(adminRestoreCmd)
+	adminCmd.AddCommand(adminRestoreCmd)
+
 	/*
 		ADMIN MEDIA COMMANDS
 	*/
@@ -217,6 +294,36 @@ func adminCommands() *cobra.Command {
 	config.AddAdminMediaList(adminMediaListEmojisLocalCmd)
 	adminMediaCmd.AddCommand(adminMediaListEmojisLocalCmd)
 
+	/*
+		ADMIN MEDIA EMOJI PACK COMMANDS
+	*/
+
+	adminMediaEmojiPackExportCmd := &cobra.Command{
+		Use:   "export-emoji-pack",
+		Short: "export local emojis to a pleroma-compatible emoji pack zip file",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), media.ExportEmojiPack)
+		},
+	}
+	config.AddAdminEmojiPackExport(adminMediaEmojiPackExportCmd)
+	adminMediaCmd.AddCommand(adminMediaEmojiPackExportCmd)
+
+	adminMediaEmojiPackImportCmd := &cobra.Command{
+		Use:   "import-emoji-pack",
+		Short: "import local emojis from a pleroma-compatible emoji pack zip file",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), media.ImportEmojiPack)
+		},
+	}
+	config.AddAdminEmojiPackImport(adminMediaEmojiPackImportCmd)
+	adminMediaCmd.AddCommand(adminMediaEmojiPackImportCmd)
+
 	/*
 		ADMIN MEDIA PRUNE COMMANDS
 	*/
@@ -266,7 +373,79 @@ func adminCommands() *cobra.Command {
 
 	adminMediaCmd.AddCommand(adminMediaPruneCmd)
 
+	adminMediaDedupeCmd := &cobra.Command{
+		Use:   "dedupe",
+		Short: "move media attachment files to content-addressed storage paths, storing identical files only once",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), prune.Dedupe)
+		},
+	}
+	config.AddAdminMediaPrune(adminMediaDedupeCmd)
+	adminMediaCmd.AddCommand(adminMediaDedupeCmd)
+
 	adminCmd.AddCommand(adminMediaCmd)
 
+	/*
+		ADMIN MIGRATIONS COMMANDS
+	*/
+
+	adminMigrationsCmd := &cobra.Command{
+		Use:   "migrations",
+		Short: "admin commands related to database migrations",
+	}
+
+	adminMigrationsStatusCmd := &cobra.Command{
+		Use:   "status",
+		Short: "list all database migrations, and whether they have been applied",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), migrations.Status)
+		},
+	}
+	adminMigrationsCmd.AddCommand(adminMigrationsStatusCmd)
+
+	adminMigrationsUpCmd := &cobra.Command{
+		Use:   "up",
+		Short: "apply all pending database migrations",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), migrations.Up)
+		},
+	}
+	adminMigrationsCmd.AddCommand(adminMigrationsUpCmd)
+
+	adminMigrationsDownCmd := &cobra.Command{
+		Use:   "down",
+		Short: "roll back the most recently applied group of database migrations, ie., those applied by the last upgrade",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), migrations.Down)
+		},
+	}
+	adminMigrationsCmd.AddCommand(adminMigrationsDownCmd)
+
+	adminMigrationsRedoCmd := &cobra.Command{
+		Use:   "redo",
+		Short: "roll back the most recently applied group of database migrations, then apply them again",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), migrations.Redo)
+		},
+	}
+	adminMigrationsCmd.AddCommand(adminMigrationsRedoCmd)
+
+	adminCmd.AddCommand(adminMigrationsCmd)
+
 	return adminCmd
 }
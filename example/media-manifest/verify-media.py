@@ -0,0 +1,97 @@
+#!/usr/bin/env python3
+import argparse
+import hashlib
+import json
+import os
+import pathlib
+import sys
+import urllib.request
+
+def sha256sum(path):
+	h = hashlib.sha256()
+	with open(path, "rb") as f:
+		for chunk in iter(lambda: f.read(1 << 16), b""):
+			h.update(chunk)
+	return h.hexdigest()
+
+def fetch_manifest(instance, token):
+	req = urllib.request.Request(
+		instance.rstrip("/")+"/api/v1/user/media_manifest",
+		headers={"Authorization": "Bearer "+token, "Accept": "application/json"},
+	)
+	with urllib.request.urlopen(req) as resp:
+		return json.load(resp)
+
+def download(url, dest):
+	tmp = dest.with_suffix(dest.suffix+".part")
+	with urllib.request.urlopen(url) as resp, open(tmp, "wb") as f:
+		for chunk in iter(lambda: resp.read(1 << 16), b""):
+			f.write(chunk)
+	os.replace(tmp, dest)
+
+def main():
+	cli = argparse.ArgumentParser(
+		prog="verify-media",
+		description="""Verify a local copy of your GoToSocial media against the media
+		manifest served at /api/v1/user/media_manifest, and (re-)download any files that
+		are missing or whose SHA-256 checksum doesn't match.
+
+		The manifest is fetched from your instance using the access token in the
+		GTS_TOKEN environment variable, unless --manifest is given, in which case a
+		previously saved copy of the manifest is used instead.
+		""",
+		epilog="Be gay, do backups. Trans rights!"
+	)
+	cli.add_argument("destination", type=pathlib.Path, help="directory containing (or to download) your media files")
+	cli.add_argument("--instance", help="base URL of your instance, eg., https://example.org")
+	cli.add_argument("--manifest", type=pathlib.Path, help="use this saved manifest file instead of fetching it")
+	cli.add_argument("--check-only", action="store_true", help="only report problems, don't download anything")
+	args = cli.parse_args()
+
+	if args.manifest:
+		with open(args.manifest) as f:
+			manifest = json.load(f)
+	else:
+		token = os.environ.get("GTS_TOKEN")
+		if not args.instance or not token:
+			cli.error("--instance and GTS_TOKEN must be set when not using --manifest")
+		manifest = fetch_manifest(args.instance, token)
+
+	args.destination.mkdir(parents=True, exist_ok=True)
+
+	ok, fixed, failed = 0, 0, 0
+	for file in manifest["files"]:
+		if file.get("missing"):
+			print(f"{file['filename']}: missing on the server, cannot verify", file=sys.stderr)
+			failed += 1
+			continue
+
+		dest = args.destination / file["filename"]
+		if dest.exists() and sha256sum(dest) == file["sha256"]:
+			ok += 1
+			continue
+
+		if args.check_only:
+			print(f"{file['filename']}: missing or checksum mismatch", file=sys.stderr)
+			failed += 1
+			continue
+
+		try:
+			download(file["url"], dest)
+		except Exception as e:
+			print(f"{file['filename']}: download failed: {e}", file=sys.stderr)
+			failed += 1
+			continue
+
+		if sha256sum(dest) != file["sha256"]:
+			print(f"{file['filename']}: checksum mismatch after download", file=sys.stderr)
+			failed += 1
+			continue
+
+		fixed += 1
+
+	print(f"{ok} ok, {fixed} downloaded, {failed} failed, of {manifest['files_count']} files")
+	sys.exit(1 if failed else 0)
+
+if __name__ == "__main__":
+	main()
@@ -1079,6 +1079,70 @@ func ExtractSensitive(withSensitive WithSensitive) bool {
 	return false
 }
 
+// ExtractInteractionPolicy extracts the (GoToSocial-style)
+// "interactionPolicy" property from the given Statusable,
+// if it's set, returning the URIs permitted to reply to
+// and announce the Statusable.
+//
+// Since go-fed/activity doesn't know about this property,
+// it's parsed from the Statusable's unknown properties.
+// Returns nil if no interaction policy is set at all.
+func ExtractInteractionPolicy(statusable Statusable) *gtsmodel.InteractionPolicy {
+	withUnknown, ok := statusable.(WithUnknownProperties)
+	if !ok {
+		return nil
+	}
+
+	raw, ok := withUnknown.GetUnknownProperties()["interactionPolicy"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	policy := &gtsmodel.InteractionPolicy{
+		CanReply:    extractInteractionRule(raw["canReply"]),
+		CanAnnounce: extractInteractionRule(raw["canAnnounce"]),
+	}
+
+	if policy.CanReply == nil && policy.CanAnnounce == nil {
+		// Nothing we
+		// understand.
+		return nil
+	}
+
+	return policy
+}
+
+// extractInteractionRule extracts permitted URIs from one
+// rule of an interaction policy, eg., "canReply". URIs under
+// "approvalRequired" are included alongside those under
+// "always", since an interaction from them will be handled
+// by the remote rather than silently dropped.
+//
+// Returns nil if the rule is not set, or an empty (non-nil)
+// slice if it's set but permits nobody beyond the defaults.
+func extractInteractionRule(v interface{}) []string {
+	rule, ok := v.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	uris := []string{}
+	for _, key := range []string{"always", "approvalRequired"} {
+		switch t := rule[key].(type) {
+		case string:
+			uris = append(uris, t)
+		case []interface{}:
+			for _, entry := range t {
+				if uri, ok := entry.(string); ok {
+					uris = append(uris, uri)
+				}
+			}
+		}
+	}
+
+	return uris
+}
+
 // ExtractSharedInbox extracts the sharedInbox URI property
 // from an Actor. Returns nil if this property is not set.
 func ExtractSharedInbox(withEndpoints WithEndpoints) *url.URL {
@@ -0,0 +1,81 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ap_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+)
+
+type ExtractInteractionPolicyTestSuite struct {
+	APTestSuite
+}
+
+func (suite *ExtractInteractionPolicyTestSuite) TestExtractInteractionPolicy() {
+	t, _ := suite.jsonToType(`{
+  "@context": [
+    "https://www.w3.org/ns/activitystreams",
+    "https://gotosocial.org/ns"
+  ],
+  "id": "https://example.org/users/someone/statuses/01J0ZN1Q1XN8W2D2KBTE8QHQD8",
+  "type": "Note",
+  "attributedTo": "https://example.org/users/someone",
+  "to": "https://www.w3.org/ns/activitystreams#Public",
+  "content": "hello world",
+  "interactionPolicy": {
+    "canReply": {
+      "always": [
+        "https://example.org/users/someone/followers",
+        "https://example.org/users/someone_else"
+      ],
+      "approvalRequired": "https://example.org/users/another_one"
+    },
+    "canAnnounce": {
+      "always": []
+    }
+  }
+}`)
+
+	statusable, ok := t.(ap.Statusable)
+	if !ok {
+		suite.FailNow("type not Statusable")
+	}
+
+	policy := ap.ExtractInteractionPolicy(statusable)
+	if policy == nil {
+		suite.FailNow("expected interaction policy")
+	}
+
+	suite.Equal([]string{
+		"https://example.org/users/someone/followers",
+		"https://example.org/users/someone_else",
+		"https://example.org/users/another_one",
+	}, policy.CanReply)
+	suite.NotNil(policy.CanAnnounce)
+	suite.Empty(policy.CanAnnounce)
+}
+
+func (suite *ExtractInteractionPolicyTestSuite) TestExtractNoInteractionPolicy() {
+	suite.Nil(ap.ExtractInteractionPolicy(suite.noteWithMentions1))
+}
+
+func TestExtractInteractionPolicyTestSuite(t *testing.T) {
+	suite.Run(t, &ExtractInteractionPolicyTestSuite{})
+}
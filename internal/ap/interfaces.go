@@ -518,6 +518,12 @@ type WithInReplyTo interface {
 	SetActivityStreamsInReplyTo(vocab.ActivityStreamsInReplyToProperty)
 }
 
+// WithUnknownProperties represents an activity with properties
+// that go-fed/activity doesn't know about, eg., interactionPolicy.
+type WithUnknownProperties interface {
+	GetUnknownProperties() map[string]interface{}
+}
+
 // WithSensitive represents an activity with ActivityStreamsSensitiveProperty
 type WithSensitive interface {
 	GetActivityStreamsSensitive() vocab.ActivityStreamsSensitiveProperty
@@ -499,6 +499,28 @@ func SetDiscoverable(with WithDiscoverable, discoverable bool) {
 	discoverProp.Set(discoverable)
 }
 
+// GetIndexable returns the boolean contained in the (Mastodon-style)
+// 'indexable' property of 'with', indicating whether the author allows
+// search engines to index the object. Since go-fed/activity doesn't
+// know about this property, it's read from the unknown properties.
+//
+// Returns default 'false' if property unusable or not set.
+func GetIndexable(with WithUnknownProperties) bool {
+	indexable, _ := with.GetUnknownProperties()["indexable"].(bool)
+	return indexable
+}
+
+// SetIndexable sets the given boolean on the 'indexable' property of 'with'.
+func SetIndexable(with WithUnknownProperties, indexable bool) {
+	unknown := with.GetUnknownProperties()
+	if unknown == nil {
+		// Only possible for types
+		// not built via constructor.
+		return
+	}
+	unknown["indexable"] = indexable
+}
+
 // GetManuallyApprovesFollowers returns the boolean contained in the ManuallyApprovesFollowers property of 'with'.
 //
 // Returns default 'true' if property unusable or not set.
@@ -213,6 +213,10 @@ var emptydest = &struct{}{}
 // type, failing if not parseable as JSON or not
 // resolveable as one of our known AS types.
 //
+// Any JSON-LD '@context' entries are only matched
+// against the vocabularies compiled into the streams
+// library, so context documents are never fetched.
+//
 // NOTE: this function handles closing
 // given body when it is finished with.
 //
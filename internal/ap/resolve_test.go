@@ -20,7 +20,9 @@ package ap_test
 import (
 	"bytes"
 	"context"
+	"errors"
 	"io"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -80,6 +82,49 @@ func (suite *ResolveTestSuite) TestResolveNonAPJSONAsAccountable() {
 	suite.Nil(accountable)
 }
 
+// fetchCountingTransport is an http.RoundTripper
+// which counts, and fails, all attempted requests.
+type fetchCountingTransport struct{ count int }
+
+func (t *fetchCountingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	t.count++
+	return nil, errors.New("no network fetches allowed")
+}
+
+func (suite *ResolveTestSuite) TestResolveRemoteContextNoFetch() {
+	// Replace the default transport so
+	// any attempt to fetch a context
+	// document can be detected.
+	transport := new(fetchCountingTransport)
+	oldTransport := http.DefaultTransport
+	http.DefaultTransport = transport
+	defer func() { http.DefaultTransport = oldTransport }()
+
+	b := []byte(`{
+  "@context": [
+    "https://www.w3.org/ns/activitystreams",
+    "https://w3id.org/security/v1",
+    "https://unreachable.example.org/schemas/litepub-0.1.jsonld",
+    {
+      "toot": "http://joinmastodon.org/ns#",
+      "sensitive": "as:sensitive"
+    }
+  ],
+  "id": "https://unreachable.example.org/objects/01",
+  "type": "Note",
+  "attributedTo": "https://unreachable.example.org/users/someone",
+  "content": "hello world",
+  "to": "https://www.w3.org/ns/activitystreams#Public"
+}`)
+
+	statusable, err := ap.ResolveStatusable(
+		context.Background(), io.NopCloser(bytes.NewReader(b)),
+	)
+	suite.NoError(err)
+	suite.NotNil(statusable)
+	suite.Zero(transport.count)
+}
+
 func TestResolveTestSuite(t *testing.T) {
 	suite.Run(t, &ResolveTestSuite{})
 }
@@ -29,8 +29,7 @@ import (
 )
 
 type Auth struct {
-	routerSession *gtsmodel.RouterSession
-	sessionName   string
+	session gin.HandlerFunc
 
 	auth *auth.Module
 }
@@ -47,21 +46,26 @@ func (a *Auth) Route(r *router.Router, m ...gin.HandlerFunc) {
 			Directives: []string{"private", "max-age=120"},
 			Vary:       []string{"Accept", "Accept-Encoding"},
 		})
-		sessionMiddleware = middleware.Session(a.sessionName, a.routerSession.Auth, a.routerSession.Crypt)
 	)
 	authGroup.Use(m...)
 	oauthGroup.Use(m...)
-	authGroup.Use(ccMiddleware, sessionMiddleware)
-	oauthGroup.Use(ccMiddleware, sessionMiddleware)
+	authGroup.Use(ccMiddleware, a.session)
+	oauthGroup.Use(ccMiddleware, a.session)
 
 	a.auth.RouteAuth(authGroup.Handle)
 	a.auth.RouteOauth(oauthGroup.Handle)
 }
 
+// Session returns the session middleware used by
+// the 'auth' and 'oauth' groups, so that it can be
+// shared with other modules that use session auth.
+func (a *Auth) Session() gin.HandlerFunc {
+	return a.session
+}
+
 func NewAuth(db db.DB, p *processing.Processor, idp oidc.IDP, routerSession *gtsmodel.RouterSession, sessionName string) *Auth {
 	return &Auth{
-		routerSession: routerSession,
-		sessionName:   sessionName,
-		auth:          auth.New(db, p, idp),
+		session: middleware.Session(sessionName, routerSession.Auth, routerSession.Crypt),
+		auth:    auth.New(db, p, idp),
 	}
 }
@@ -18,8 +18,6 @@
 package auth
 
 import (
-	"context"
-	"errors"
 	"fmt"
 	"net/http"
 
@@ -29,7 +27,6 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
-	"golang.org/x/crypto/bcrypt"
 )
 
 // signIn just wraps a form-submitted username (we want an email) and password
@@ -92,7 +89,7 @@ func (m *Module) SignInPOSTHandler(c *gin.Context) {
 		return
 	}
 
-	userid, errWithCode := m.ValidatePassword(c.Request.Context(), form.Email, form.Password)
+	user, errWithCode := m.processor.User().PasswordCheck(c.Request.Context(), form.Email, form.Password)
 	if errWithCode != nil {
 		// don't clear session here, so the user can just press back and try again
 		// if they accidentally gave the wrong password or something
@@ -100,7 +97,7 @@ func (m *Module) SignInPOSTHandler(c *gin.Context) {
 		return
 	}
 
-	s.Set(sessionUserID, userid)
+	s.Set(sessionUserID, user.ID)
 	if err := s.Save(); err != nil {
 		err := fmt.Errorf("error saving user id onto session: %s", err)
 		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
@@ -108,39 +105,3 @@ func (m *Module) SignInPOSTHandler(c *gin.Context) {
 
 	c.Redirect(http.StatusFound, "/oauth"+OauthAuthorizePath)
 }
-
-// ValidatePassword takes an email address and a password.
-// The goal is to authenticate the password against the one for that email
-// address stored in the database. If OK, we return the userid (a ulid) for that user,
-// so that it can be used in further Oauth flows to generate a token/retreieve an oauth client from the db.
-func (m *Module) ValidatePassword(ctx context.Context, email string, password string) (string, gtserror.WithCode) {
-	if email == "" || password == "" {
-		err := errors.New("email or password was not provided")
-		return incorrectPassword(err)
-	}
-
-	user, err := m.db.GetUserByEmailAddress(ctx, email)
-	if err != nil {
-		err := fmt.Errorf("user %s was not retrievable from db during oauth authorization attempt: %s", email, err)
-		return incorrectPassword(err)
-	}
-
-	if user.EncryptedPassword == "" {
-		err := fmt.Errorf("encrypted password for user %s was empty for some reason", user.Email)
-		return incorrectPassword(err)
-	}
-
-	if err := bcrypt.CompareHashAndPassword([]byte(user.EncryptedPassword), []byte(password)); err != nil {
-		err := fmt.Errorf("password hash didn't match for user %s during sign in attempt: %s", user.Email, err)
-		return incorrectPassword(err)
-	}
-
-	return user.ID, nil
-}
-
-// incorrectPassword wraps the given error in a gtserror.WithCode, and returns
-// only a generic 'safe' error message to the user, to not give any info away.
-func incorrectPassword(err error) (string, gtserror.WithCode) {
-	safeErr := fmt.Errorf("password/email combination was incorrect")
-	return "", gtserror.NewErrorUnauthorized(err, safeErr.Error(), oauth.HelpfulAdvice)
-}
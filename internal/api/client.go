@@ -26,27 +26,34 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/apps"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/blocks"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/bookmarks"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/compatibility"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/conversations"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/customemojis"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/delegates"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/favourites"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/featuredtags"
 	filtersV1 "github.com/superseriousbusiness/gotosocial/internal/api/client/filters/v1"
 	filtersV2 "github.com/superseriousbusiness/gotosocial/internal/api/client/filters/v2"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/followrequests"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/imports"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/instance"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/invites"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/lists"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/markers"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/media"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/mutes"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/notifications"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/oembed"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/polls"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/preferences"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/reports"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/search"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/severedrelationships"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/statuses"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/streaming"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/timelines"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/user"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/middleware"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
@@ -58,32 +65,38 @@ type Client struct {
 	processor *processing.Processor
 	db        db.DB
 
-	accounts       *accounts.Module       // api/v1/accounts
-	admin          *admin.Module          // api/v1/admin
-	apps           *apps.Module           // api/v1/apps
-	blocks         *blocks.Module         // api/v1/blocks
-	bookmarks      *bookmarks.Module      // api/v1/bookmarks
-	conversations  *conversations.Module  // api/v1/conversations
-	customEmojis   *customemojis.Module   // api/v1/custom_emojis
-	favourites     *favourites.Module     // api/v1/favourites
-	featuredTags   *featuredtags.Module   // api/v1/featured_tags
-	filtersV1      *filtersV1.Module      // api/v1/filters
-	filtersV2      *filtersV2.Module      // api/v2/filters
-	followRequests *followrequests.Module // api/v1/follow_requests
-	instance       *instance.Module       // api/v1/instance
-	lists          *lists.Module          // api/v1/lists
-	markers        *markers.Module        // api/v1/markers
-	media          *media.Module          // api/v1/media, api/v2/media
-	mutes          *mutes.Module          // api/v1/mutes
-	notifications  *notifications.Module  // api/v1/notifications
-	polls          *polls.Module          // api/v1/polls
-	preferences    *preferences.Module    // api/v1/preferences
-	reports        *reports.Module        // api/v1/reports
-	search         *search.Module         // api/v1/search, api/v2/search
-	statuses       *statuses.Module       // api/v1/statuses
-	streaming      *streaming.Module      // api/v1/streaming
-	timelines      *timelines.Module      // api/v1/timelines
-	user           *user.Module           // api/v1/user
+	accounts             *accounts.Module             // api/v1/accounts
+	admin                *admin.Module                // api/v1/admin
+	apps                 *apps.Module                 // api/v1/apps
+	blocks               *blocks.Module               // api/v1/blocks
+	bookmarks            *bookmarks.Module            // api/v1/bookmarks
+	compatibility        *compatibility.Module        // api/v1/compatibility
+	conversations        *conversations.Module        // api/v1/conversations
+	customEmojis         *customemojis.Module         // api/v1/custom_emojis
+	delegates            *delegates.Module            // api/v1/delegates, api/v1/delegations
+	favourites           *favourites.Module           // api/v1/favourites
+	featuredTags         *featuredtags.Module         // api/v1/featured_tags
+	filtersV1            *filtersV1.Module            // api/v1/filters
+	filtersV2            *filtersV2.Module            // api/v2/filters
+	followRequests       *followrequests.Module       // api/v1/follow_requests
+	imports              *imports.Module              // api/v1/imports
+	instance             *instance.Module             // api/v1/instance
+	invites              *invites.Module              // api/v1/invites
+	lists                *lists.Module                // api/v1/lists
+	markers              *markers.Module              // api/v1/markers
+	media                *media.Module                // api/v1/media, api/v2/media
+	mutes                *mutes.Module                // api/v1/mutes
+	notifications        *notifications.Module        // api/v1/notifications
+	oembed               *oembed.Module               // api/oembed
+	polls                *polls.Module                // api/v1/polls
+	preferences          *preferences.Module          // api/v1/preferences
+	reports              *reports.Module              // api/v1/reports
+	search               *search.Module               // api/v1/search, api/v2/search
+	severedRelationships *severedrelationships.Module // api/v1/severed_relationships
+	statuses             *statuses.Module             // api/v1/statuses
+	streaming            *streaming.Module            // api/v1/streaming
+	timelines            *timelines.Module            // api/v1/timelines
+	user                 *user.Module                 // api/v1/user
 }
 
 func (c *Client) Route(r *router.Router, m ...gin.HandlerFunc) {
@@ -94,37 +107,61 @@ func (c *Client) Route(r *router.Router, m ...gin.HandlerFunc) {
 	apiGroup.Use(m...)
 	apiGroup.Use(
 		middleware.TokenCheck(c.db, c.processor.OAuthValidateBearerToken),
+		// Stricter rate limit for requests to the public
+		// API without a valid token, must come *after*
+		// TokenCheck. Other routes that clients use before
+		// they have a token (eg., instance info, registering
+		// an app) only get the usual rate limit.
+		middleware.RateLimitUnauthenticated(
+			config.GetAdvancedRateLimitUnauthenticatedRequests(),
+			config.GetAdvancedRateLimitExceptions(),
+			"/api"+accounts.BasePathWithID,
+			"/api"+accounts.StatusesPath,
+			"/api"+timelines.PublicTimeline,
+		),
 		middleware.CacheControl(middleware.CacheControlConfig{
 			// Never cache client api responses.
 			Directives: []string{"no-store"},
 		}),
+		// Annotate responses of deprecated endpoints.
+		middleware.Deprecation(),
 	)
 
 	// for each client api module, pass it the Handle function
-	// so that the module can attach its routes to this group
-	h := apiGroup.Handle
+	// so that the module can attach its routes to this group,
+	// recording each route for the compatibility endpoint
+	h := func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes {
+		c.compatibility.Record(method, path)
+		return apiGroup.Handle(method, path, f...)
+	}
 	c.accounts.Route(h)
 	c.admin.Route(h)
 	c.apps.Route(h)
 	c.blocks.Route(h)
 	c.bookmarks.Route(h)
+	c.compatibility.Route(h)
 	c.conversations.Route(h)
 	c.customEmojis.Route(h)
+	c.delegates.Route(h)
 	c.favourites.Route(h)
 	c.featuredTags.Route(h)
 	c.filtersV1.Route(h)
 	c.filtersV2.Route(h)
 	c.followRequests.Route(h)
+	c.imports.Route(h)
 	c.instance.Route(h)
+	c.invites.Route(h)
 	c.lists.Route(h)
 	c.markers.Route(h)
 	c.media.Route(h)
 	c.mutes.Route(h)
 	c.notifications.Route(h)
+	c.oembed.Route(h)
 	c.polls.Route(h)
 	c.preferences.Route(h)
 	c.reports.Route(h)
 	c.search.Route(h)
+	c.severedRelationships.Route(h)
 	c.statuses.Route(h)
 	c.streaming.Route(h)
 	c.timelines.Route(h)
@@ -136,31 +173,37 @@ func NewClient(state *state.State, p *processing.Processor) *Client {
 		processor: p,
 		db:        state.DB,
 
-		accounts:       accounts.New(p),
-		admin:          admin.New(state, p),
-		apps:           apps.New(p),
-		blocks:         blocks.New(p),
-		bookmarks:      bookmarks.New(p),
-		conversations:  conversations.New(p),
-		customEmojis:   customemojis.New(p),
-		favourites:     favourites.New(p),
-		featuredTags:   featuredtags.New(p),
-		filtersV1:      filtersV1.New(p),
-		filtersV2:      filtersV2.New(p),
-		followRequests: followrequests.New(p),
-		instance:       instance.New(p),
-		lists:          lists.New(p),
-		markers:        markers.New(p),
-		media:          media.New(p),
-		mutes:          mutes.New(p),
-		notifications:  notifications.New(p),
-		polls:          polls.New(p),
-		preferences:    preferences.New(p),
-		reports:        reports.New(p),
-		search:         search.New(p),
-		statuses:       statuses.New(p),
-		streaming:      streaming.New(p, time.Second*30, 4096),
-		timelines:      timelines.New(p),
-		user:           user.New(p),
+		accounts:             accounts.New(p),
+		admin:                admin.New(state, p),
+		apps:                 apps.New(p),
+		blocks:               blocks.New(p),
+		bookmarks:            bookmarks.New(p),
+		compatibility:        compatibility.New(p),
+		conversations:        conversations.New(p),
+		customEmojis:         customemojis.New(p),
+		delegates:            delegates.New(p),
+		favourites:           favourites.New(p),
+		featuredTags:         featuredtags.New(p),
+		filtersV1:            filtersV1.New(p),
+		filtersV2:            filtersV2.New(p),
+		followRequests:       followrequests.New(p),
+		imports:              imports.New(p),
+		instance:             instance.New(p),
+		invites:              invites.New(p),
+		lists:                lists.New(p),
+		markers:              markers.New(p),
+		media:                media.New(p),
+		mutes:                mutes.New(p),
+		notifications:        notifications.New(p),
+		oembed:               oembed.New(p),
+		polls:                polls.New(p),
+		preferences:          preferences.New(p),
+		reports:              reports.New(p),
+		search:               search.New(p),
+		severedRelationships: severedrelationships.New(p),
+		statuses:             statuses.New(p),
+		streaming:            streaming.New(p, time.Second*30, 4096),
+		timelines:            timelines.New(p),
+		user:                 user.New(p),
 	}
 }
@@ -71,7 +71,6 @@ func (suite *AccountStandardTestSuite) SetupSuite() {
 	suite.testClients = testrig.NewTestClients()
 	suite.testApplications = testrig.NewTestApplications()
 	suite.testUsers = testrig.NewTestUsers()
-	suite.testAccounts = testrig.NewTestAccounts()
 	suite.testAttachments = testrig.NewTestAttachments()
 	suite.testStatuses = testrig.NewTestStatuses()
 }
@@ -83,6 +82,10 @@ func (suite *AccountStandardTestSuite) SetupTest() {
 	testrig.InitTestConfig()
 	testrig.InitTestLog()
 
+	// Fresh accounts for each test, as the authed
+	// account is modified in place by updates.
+	suite.testAccounts = testrig.NewTestAccounts()
+
 	suite.db = testrig.NewTestDB(&suite.state)
 	suite.state.DB = suite.db
 	suite.storage = testrig.NewInMemoryStorage()
@@ -70,7 +70,12 @@ import (
 //			description: >-
 //				Unprocessable. Your account creation request cannot be processed
 //				because either too many accounts have been created on this instance
-//				in the last 24h, or the pending account backlog is full.
+//				in the last 24h, the pending account backlog is full, or the given
+//				invite code is not valid.
+//		'429':
+//			description: >-
+//				Too many requests. Too many sign-ups have been made from your
+//				IP address, or with your email domain, in the last 24h.
 //		'500':
 //			description: internal server error
 func (m *Module) AccountCreatePOSTHandler(c *gin.Context) {
@@ -23,6 +23,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 )
@@ -55,6 +56,8 @@ import (
 //			description: The requested account.
 //			schema:
 //				"$ref": "#/definitions/account"
+//		'304':
+//			description: Not modified, the response matches the ETag given in the If-None-Match header.
 //		'400':
 //			description: bad request
 //		'401':
@@ -66,7 +69,17 @@ import (
 //		'500':
 //			description: internal server error
 func (m *Module) AccountGETHandler(c *gin.Context) {
-	authed, err := oauth.Authed(c, true, true, true, true)
+	var authed *oauth.Auth
+	var err error
+
+	if config.GetInstanceExposePublicAPI() {
+		// If the public API is allowed to be exposed, still check if we
+		// can extract various authentication properties, but don't require them.
+		authed, err = oauth.Authed(c, false, false, false, false)
+	} else {
+		authed, err = oauth.Authed(c, true, true, true, true)
+	}
+
 	if err != nil {
 		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
 		return
@@ -90,5 +103,5 @@ func (m *Module) AccountGETHandler(c *gin.Context) {
 		return
 	}
 
-	apiutil.JSON(c, http.StatusOK, acctInfo)
+	apiutil.JSONWithETag(c, http.StatusOK, acctInfo)
 }
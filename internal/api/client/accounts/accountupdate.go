@@ -20,6 +20,7 @@ package accounts
 import (
 	"errors"
 	"fmt"
+	"mime/multipart"
 	"net/http"
 	"slices"
 	"strconv"
@@ -75,12 +76,12 @@ import (
 //	-
 //		name: avatar
 //		in: formData
-//		description: Avatar of the user.
+//		description: Avatar of the user. Submit an empty value to remove the current avatar.
 //		type: file
 //	-
 //		name: header
 //		in: formData
-//		description: Header of the user.
+//		description: Header of the user. Submit an empty value to remove the current header.
 //		type: file
 //	-
 //		name: locked
@@ -108,6 +109,54 @@ import (
 //		description: Default content type to use for authored statuses (text/plain or text/markdown).
 //		type: string
 //	-
+//		name: source[spam_filter_mode]
+//		in: formData
+//		description: >-
+//			Spam filter mode to use for messages sent to this account from other instances
+//			(off, log, or drop). Use empty string to unset, and use the instance setting instead.
+//		type: string
+//	-
+//		name: source[email_digest]
+//		in: formData
+//		description: >-
+//			How often to send an email digest of missed mentions, follows, and follow requests
+//			(daily or weekly). Use empty string to unset, and stop sending digests.
+//		type: string
+//	-
+//		name: source[notifications_snooze]
+//		in: formData
+//		description: >-
+//			Snooze all notifications (quiet mode) for this many seconds from now, max 30 days.
+//			Snoozed notifications are still stored, but not pushed. Use 0 to stop snoozing.
+//		type: integer
+//	-
+//		name: source[snooze_allow_follows]
+//		in: formData
+//		description: Still push follow and follow request notifications while snoozed.
+//		type: boolean
+//	-
+//		name: source[snooze_allow_direct]
+//		in: formData
+//		description: Still push notifications of mentions in direct messages while snoozed.
+//		type: boolean
+//	-
+//		name: source[follow_auto_accept_delay]
+//		in: formData
+//		description: >-
+//			When the account is unlocked, wait this many minutes (max 7 days) before automatically
+//			accepting new follows, during which they can still be manually rejected. Use 0 to accept immediately.
+//		type: integer
+//	-
+//		name: source[hide_from_local_timeline]
+//		in: formData
+//		description: Keep public posts off this instance's local public timeline.
+//		type: boolean
+//	-
+//		name: source[hide_from_federated_timeline]
+//		in: formData
+//		description: Keep public posts off this instance's federated public timeline.
+//		type: boolean
+//	-
 //		name: theme
 //		in: formData
 //		description: >-
@@ -133,6 +182,21 @@ import (
 //		description: Hide the account's following/followers collections.
 //		type: boolean
 //	-
+//		name: hide_statuses_logged_out
+//		in: formData
+//		description: >-
+//			Hide the account's statuses from logged-out viewers of the web view and API.
+//			Statuses are still federated, and visible to other accounts as usual.
+//		type: boolean
+//	-
+//		name: noindex
+//		in: formData
+//		description: >-
+//			Ask search engines not to index the account's profile and statuses web pages.
+//			This adds a "noindex" robots meta tag to those pages, leaves the account's
+//			statuses out of the sitemap, and disallows the profile in robots.txt.
+//		type: boolean
+//	-
 //		name: fields_attributes[0][name]
 //		in: formData
 //		description: Name of 1st profile field to be added to this account's profile.
@@ -282,6 +346,15 @@ func parseUpdateAccountForm(c *gin.Context) (*apimodel.UpdateCredentialsRequest,
 		if err != nil {
 			return nil, fmt.Errorf("custom json binding failed: %w", err)
 		}
+
+		// Images can't be uploaded as JSON,
+		// but they can be removed using "".
+		if form.RemoveAvatar, err = parseRemoveImageFromJSON("avatar", form.JSONAvatar); err != nil {
+			return nil, err
+		}
+		if form.RemoveHeader, err = parseRemoveImageFromJSON("header", form.JSONHeader); err != nil {
+			return nil, err
+		}
 	case binding.MIMEPOSTForm:
 		// Bind with default form binding first.
 		if err := c.ShouldBindWith(form, binding.FormPost); err != nil {
@@ -309,6 +382,20 @@ func parseUpdateAccountForm(c *gin.Context) (*apimodel.UpdateCredentialsRequest,
 		return nil, err
 	}
 
+	if c.ContentType() != binding.MIMEJSON {
+		// An image form value submitted empty
+		// (rather than as a file), indicates
+		// that the image should be removed.
+		if isEmptyImageFormValue(c, form.Avatar, "avatar") {
+			form.Avatar = nil
+			form.RemoveAvatar = true
+		}
+		if isEmptyImageFormValue(c, form.Header, "header") {
+			form.Header = nil
+			form.RemoveHeader = true
+		}
+	}
+
 	if form == nil ||
 		(form.Discoverable == nil &&
 			form.Bot == nil &&
@@ -316,22 +403,65 @@ func parseUpdateAccountForm(c *gin.Context) (*apimodel.UpdateCredentialsRequest,
 			form.Note == nil &&
 			form.Avatar == nil &&
 			form.Header == nil &&
+			!form.RemoveAvatar &&
+			!form.RemoveHeader &&
 			form.Locked == nil &&
 			form.Source.Privacy == nil &&
 			form.Source.Sensitive == nil &&
 			form.Source.Language == nil &&
 			form.Source.StatusContentType == nil &&
+			form.Source.SpamFilterMode == nil &&
+			form.Source.EmailDigest == nil &&
+			form.Source.NotificationsSnooze == nil &&
+			form.Source.SnoozeAllowFollows == nil &&
+			form.Source.SnoozeAllowDirect == nil &&
+			form.Source.FollowAutoAcceptDelay == nil &&
+			form.Source.HideFromLocalTimeline == nil &&
+			form.Source.HideFromFederatedTimeline == nil &&
 			form.FieldsAttributes == nil &&
 			form.Theme == nil &&
 			form.CustomCSS == nil &&
 			form.EnableRSS == nil &&
-			form.HideCollections == nil) {
+			form.HideCollections == nil &&
+			form.HideStatusesLoggedOut == nil &&
+			form.NoIndex == nil) {
 		return nil, errors.New("empty form submitted")
 	}
 
 	return form, nil
 }
 
+// parseRemoveImageFromJSON returns whether the given
+// JSON image value indicates that the image should be
+// removed, ie., it was set to the empty string.
+func parseRemoveImageFromJSON(name string, value *string) (bool, error) {
+	if value == nil {
+		// Not set.
+		return false, nil
+	}
+
+	if *value != "" {
+		err := fmt.Errorf("%s must be uploaded using %s, or set to an empty value to remove it", name, binding.MIMEMultipartPOSTForm)
+		return false, err
+	}
+
+	return true, nil
+}
+
+// isEmptyImageFormValue returns whether the given image
+// key was submitted as an empty form value, rather than
+// as a file. Note that gin will still bind an empty
+// FileHeader to the form in this case.
+func isEmptyImageFormValue(c *gin.Context, image *multipart.FileHeader, key string) bool {
+	if image != nil && image.Size != 0 {
+		// Actual file provided.
+		return false
+	}
+
+	values, ok := c.Request.PostForm[key]
+	return ok && (len(values) == 0 || values[0] == "")
+}
+
 func parseFieldsAttributesFromJSON(jsonFieldsAttributes *map[string]apimodel.UpdateField) (*[]apimodel.UpdateField, error) {
 	if jsonFieldsAttributes == nil {
 		// Nothing set, nothing to do.
@@ -25,11 +25,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/accounts"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
@@ -385,6 +387,55 @@ func (suite *AccountUpdateTestSuite) TestUpdateAccountWithImageFormData() {
 	suite.NotEqual("http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/header/small/01PFPMWK2FF0D9WMHEJHR07C3Q.jpg", apimodelAccount.HeaderStatic)
 }
 
+func (suite *AccountUpdateTestSuite) TestUpdateAccountRemoveImagesFormData() {
+	data := map[string][]string{
+		"avatar": {""},
+		"header": {""},
+	}
+
+	apimodelAccount, err := suite.updateAccountFromFormData(data, http.StatusOK, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// An empty URL is legal *only* in the test environment, which may have no default avatars.
+	suite.True(apimodelAccount.Avatar == "" || strings.HasPrefix(apimodelAccount.Avatar, "http://localhost:8080/assets/default_avatars/"))
+	suite.NotEqual("http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/header/original/01PFPMWK2FF0D9WMHEJHR07C3Q.jpg", apimodelAccount.Header)
+
+	// Removed media should be gone from the database.
+	for _, attachment := range []string{
+		"local_account_1_avatar",
+		"local_account_1_header",
+	} {
+		_, err := suite.db.GetAttachmentByID(context.Background(), suite.testAttachments[attachment].ID)
+		suite.ErrorIs(err, db.ErrNoEntries)
+	}
+}
+
+func (suite *AccountUpdateTestSuite) TestUpdateAccountRemoveAvatarJSON() {
+	data := `{"avatar": ""}`
+
+	apimodelAccount, err := suite.updateAccountFromJSON(data, http.StatusOK, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// An empty URL is legal *only* in the test environment, which may have no default avatars.
+	suite.True(apimodelAccount.Avatar == "" || strings.HasPrefix(apimodelAccount.Avatar, "http://localhost:8080/assets/default_avatars/"))
+
+	// Header should be untouched.
+	suite.Equal("http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/header/original/01PFPMWK2FF0D9WMHEJHR07C3Q.jpg", apimodelAccount.Header)
+}
+
+func (suite *AccountUpdateTestSuite) TestUpdateAccountAvatarNotEmptyJSON() {
+	data := `{"avatar": "http://example.org/some-image.png"}`
+
+	_, err := suite.updateAccountFromJSON(data, http.StatusBadRequest, `{"error":"Bad Request: avatar must be uploaded using multipart/form-data, or set to an empty value to remove it"}`)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
 func (suite *AccountUpdateTestSuite) TestUpdateAccountEmptyForm() {
 	data := make(map[string][]string)
 
@@ -55,6 +55,8 @@ import (
 //			description: Result of the lookup.
 //			schema:
 //				"$ref": "#/definitions/account"
+//		'304':
+//			description: Not modified, the response matches the ETag given in the If-None-Match header.
 //		'400':
 //			description: bad request
 //		'401':
@@ -96,5 +98,5 @@ func (m *Module) AccountLookupGETHandler(c *gin.Context) {
 		return
 	}
 
-	apiutil.JSON(c, http.StatusOK, account)
+	apiutil.JSONWithETag(c, http.StatusOK, account)
 }
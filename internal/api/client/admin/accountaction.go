@@ -53,7 +53,10 @@ import (
 //	-
 //		name: type
 //		in: formData
-//		description: Type of action to be taken, currently only supports `suspend`.
+//		description: >-
+//			Type of action to be taken. One of `disable`, `silence`, `sensitize`, `suspend`, or `warn`.
+//			For compatibility with the Mastodon API, `sensitive` and `none` are accepted as aliases of
+//			`sensitize` and `warn` respectively. Only `disable` and `warn` are restricted to local accounts.
 //		type: string
 //		required: true
 //	-
@@ -61,6 +64,14 @@ import (
 //		in: formData
 //		description: Optional text describing why this action was taken.
 //		type: string
+//	-
+//		name: send_email_notification
+//		in: formData
+//		description: >-
+//			Send an email to the target account's user to inform them of the action,
+//			including the text given in `text`. Only applies to local accounts.
+//		type: boolean
+//		default: false
 //
 //	security:
 //	- OAuth2 Bearer:
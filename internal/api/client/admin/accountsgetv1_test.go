@@ -0,0 +1,80 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type AccountsGetV1TestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *AccountsGetV1TestSuite) getAccounts(query string) ([]*apimodel.AdminAccountInfo, int) {
+	recorder := httptest.NewRecorder()
+
+	path := admin.AccountsV1Path + "?" + query
+	ctx := suite.newContext(recorder, http.MethodGet, nil, path, "application/json")
+
+	suite.adminModule.AccountsGETV1Handler(ctx)
+
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if recorder.Code != http.StatusOK {
+		return nil, recorder.Code
+	}
+
+	accounts := []*apimodel.AdminAccountInfo{}
+	if err := json.Unmarshal(b, &accounts); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return accounts, recorder.Code
+}
+
+func (suite *AccountsGetV1TestSuite) TestAccountsGetPending() {
+	accounts, code := suite.getAccounts("local=true&pending=true")
+	suite.Equal(http.StatusOK, code)
+
+	usernames := make([]string, 0, len(accounts))
+	for _, account := range accounts {
+		suite.False(account.Approved)
+		usernames = append(usernames, account.Username)
+	}
+	suite.Equal([]string{"weed_lord420"}, usernames)
+}
+
+func (suite *AccountsGetV1TestSuite) TestAccountsGetPendingAndActive() {
+	_, code := suite.getAccounts("pending=true&active=true")
+	suite.Equal(http.StatusBadRequest, code)
+}
+
+func TestAccountsGetV1TestSuite(t *testing.T) {
+	suite.Run(t, &AccountsGetV1TestSuite{})
+}
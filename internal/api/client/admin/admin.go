@@ -27,46 +27,72 @@ import (
 )
 
 const (
-	BasePath                = "/v1/admin"
-	EmojiPath               = BasePath + "/custom_emojis"
-	EmojiPathWithID         = EmojiPath + "/:" + IDKey
-	EmojiCategoriesPath     = EmojiPath + "/categories"
-	DomainBlocksPath        = BasePath + "/domain_blocks"
-	DomainBlocksPathWithID  = DomainBlocksPath + "/:" + IDKey
-	DomainAllowsPath        = BasePath + "/domain_allows"
-	DomainAllowsPathWithID  = DomainAllowsPath + "/:" + IDKey
-	DomainKeysExpirePath    = BasePath + "/domain_keys_expire"
-	HeaderAllowsPath        = BasePath + "/header_allows"
-	HeaderAllowsPathWithID  = HeaderAllowsPath + "/:" + IDKey
-	HeaderBlocksPath        = BasePath + "/header_blocks"
-	HeaderBlocksPathWithID  = HeaderBlocksPath + "/:" + IDKey
-	AccountsV1Path          = BasePath + "/accounts"
-	AccountsV2Path          = "/v2/admin/accounts"
-	AccountsPathWithID      = AccountsV1Path + "/:" + IDKey
-	AccountsActionPath      = AccountsPathWithID + "/action"
-	AccountsApprovePath     = AccountsPathWithID + "/approve"
-	AccountsRejectPath      = AccountsPathWithID + "/reject"
-	MediaCleanupPath        = BasePath + "/media_cleanup"
-	MediaRefetchPath        = BasePath + "/media_refetch"
-	ReportsPath             = BasePath + "/reports"
-	ReportsPathWithID       = ReportsPath + "/:" + IDKey
-	ReportsResolvePath      = ReportsPathWithID + "/resolve"
-	EmailPath               = BasePath + "/email"
-	EmailTestPath           = EmailPath + "/test"
-	InstanceRulesPath       = BasePath + "/instance/rules"
-	InstanceRulesPathWithID = InstanceRulesPath + "/:" + IDKey
-	DebugPath               = BasePath + "/debug"
-	DebugAPUrlPath          = DebugPath + "/apurl"
-	DebugClearCachesPath    = DebugPath + "/caches/clear"
+	BasePath                         = "/v1/admin"
+	EmojiPath                        = BasePath + "/custom_emojis"
+	EmojiPathWithID                  = EmojiPath + "/:" + IDKey
+	EmojiCategoriesPath              = EmojiPath + "/categories"
+	EmojiCategoryPathWithID          = EmojiCategoriesPath + "/:" + IDKey
+	EmojiPackPath                    = EmojiPath + "/pack"
+	DomainBlocksPath                 = BasePath + "/domain_blocks"
+	DomainBlocksPathWithID           = DomainBlocksPath + "/:" + IDKey
+	DomainAllowsPath                 = BasePath + "/domain_allows"
+	DomainAllowsPathWithID           = DomainAllowsPath + "/:" + IDKey
+	DomainPausesPath                 = BasePath + "/domain_pauses"
+	DomainPausesPathWithID           = DomainPausesPath + "/:" + IDKey
+	DomainPermissionDraftsPath       = BasePath + "/domain_permission_drafts"
+	DomainPermissionDraftsPathWithID = DomainPermissionDraftsPath + "/:" + IDKey
+	DomainPermissionDraftAcceptPath  = DomainPermissionDraftsPathWithID + "/accept"
+	DomainPermissionDraftRemovePath  = DomainPermissionDraftsPathWithID + "/remove"
+	DomainKeysExpirePath             = BasePath + "/domain_keys_expire"
+	DomainMovesResolvePath           = BasePath + "/domain_moves_resolve"
+	EmailDomainBlocksPath            = BasePath + "/email_domain_blocks"
+	EmailDomainBlocksPathWithID      = EmailDomainBlocksPath + "/:" + IDKey
+	HeaderAllowsPath                 = BasePath + "/header_allows"
+	HeaderAllowsPathWithID           = HeaderAllowsPath + "/:" + IDKey
+	HeaderBlocksPath                 = BasePath + "/header_blocks"
+	HeaderBlocksPathWithID           = HeaderBlocksPath + "/:" + IDKey
+	AccountsV1Path                   = BasePath + "/accounts"
+	AccountsV2Path                   = "/v2/admin/accounts"
+	AccountsPathWithID               = AccountsV1Path + "/:" + IDKey
+	AccountsActionPath               = AccountsPathWithID + "/action"
+	AccountsApprovePath              = AccountsPathWithID + "/approve"
+	AccountsRejectPath               = AccountsPathWithID + "/reject"
+	AccountsVerifyPath               = AccountsPathWithID + "/verify"
+	AccountsUnverifyPath             = AccountsPathWithID + "/unverify"
+	BulkActionsPath                  = BasePath + "/bulk_actions"
+	MediaCleanupPath                 = BasePath + "/media_cleanup"
+	MediaRefetchPath                 = BasePath + "/media_refetch"
+	MediaReconcilePath               = BasePath + "/media_reconcile"
+	ReportsPath                      = BasePath + "/reports"
+	ReportsPathWithID                = ReportsPath + "/:" + IDKey
+	ReportsResolvePath               = ReportsPathWithID + "/resolve"
+	EmailPath                        = BasePath + "/email"
+	EmailTestPath                    = EmailPath + "/test"
+	InstanceRulesPath                = BasePath + "/instance/rules"
+	InstanceRulesPathWithID          = InstanceRulesPath + "/:" + IDKey
+	InstanceStatsPath                = BasePath + "/instance/stats"
+	InvitesPath                      = BasePath + "/invites"
+	InvitesPathWithID                = InvitesPath + "/:" + IDKey
+	ModerationNotesPath              = BasePath + "/moderation_notes"
+	ModerationNotesPathWithID        = ModerationNotesPath + "/:" + IDKey
+	RedirectsPath                    = BasePath + "/redirects"
+	RedirectsPathWithID              = RedirectsPath + "/:" + IDKey
+	ConversionReportPath             = BasePath + "/conversion_report"
+	DebugPath                        = BasePath + "/debug"
+	DebugAPUrlPath                   = DebugPath + "/apurl"
+	DebugClearCachesPath             = DebugPath + "/caches/clear"
 
 	IDKey                 = "id"
+	CategoryKey           = "category"
 	FilterQueryKey        = "filter"
 	MaxShortcodeDomainKey = "max_shortcode_domain"
 	MinShortcodeDomainKey = "min_shortcode_domain"
 	LimitKey              = "limit"
 	DomainQueryKey        = "domain"
+	PermissionTypeKey     = "permission_type"
 	ResolvedKey           = "resolved"
 	AccountIDKey          = "account_id"
+	ReportIDKey           = "report_id"
 	TargetAccountIDKey    = "target_account_id"
 	MaxIDKey              = "max_id"
 	SinceIDKey            = "since_id"
@@ -93,6 +119,9 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodGet, EmojiPathWithID, m.EmojiGETHandler)
 	attachHandler(http.MethodPatch, EmojiPathWithID, m.EmojiPATCHHandler)
 	attachHandler(http.MethodGet, EmojiCategoriesPath, m.EmojiCategoriesGETHandler)
+	attachHandler(http.MethodPatch, EmojiCategoryPathWithID, m.EmojiCategoryPATCHHandler)
+	attachHandler(http.MethodGet, EmojiPackPath, m.EmojiPackExportGETHandler)
+	attachHandler(http.MethodPost, EmojiPackPath, m.EmojiPackImportPOSTHandler)
 
 	// domain block stuff
 	attachHandler(http.MethodPost, DomainBlocksPath, m.DomainBlocksPOSTHandler)
@@ -106,6 +135,31 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodGet, DomainAllowsPathWithID, m.DomainAllowGETHandler)
 	attachHandler(http.MethodDelete, DomainAllowsPathWithID, m.DomainAllowDELETEHandler)
 
+	// domain pause stuff
+	attachHandler(http.MethodPost, DomainPausesPath, m.DomainPausesPOSTHandler)
+	attachHandler(http.MethodGet, DomainPausesPath, m.DomainPausesGETHandler)
+	attachHandler(http.MethodGet, DomainPausesPathWithID, m.DomainPauseGETHandler)
+	attachHandler(http.MethodDelete, DomainPausesPathWithID, m.DomainPauseDELETEHandler)
+
+	// domain permission draft stuff
+	attachHandler(http.MethodPost, DomainPermissionDraftsPath, m.DomainPermissionDraftsPOSTHandler)
+	attachHandler(http.MethodGet, DomainPermissionDraftsPath, m.DomainPermissionDraftsGETHandler)
+	attachHandler(http.MethodGet, DomainPermissionDraftsPathWithID, m.DomainPermissionDraftGETHandler)
+	attachHandler(http.MethodPost, DomainPermissionDraftAcceptPath, m.DomainPermissionDraftAcceptPOSTHandler)
+	attachHandler(http.MethodPost, DomainPermissionDraftRemovePath, m.DomainPermissionDraftRemovePOSTHandler)
+
+	// email domain block stuff
+	attachHandler(http.MethodPost, EmailDomainBlocksPath, m.EmailDomainBlocksPOSTHandler)
+	attachHandler(http.MethodGet, EmailDomainBlocksPath, m.EmailDomainBlocksGETHandler)
+	attachHandler(http.MethodGet, EmailDomainBlocksPathWithID, m.EmailDomainBlockGETHandler)
+	attachHandler(http.MethodDelete, EmailDomainBlocksPathWithID, m.EmailDomainBlockDELETEHandler)
+
+	// redirect stuff
+	attachHandler(http.MethodPost, RedirectsPath, m.RedirectsPOSTHandler)
+	attachHandler(http.MethodGet, RedirectsPath, m.RedirectsGETHandler)
+	attachHandler(http.MethodGet, RedirectsPathWithID, m.RedirectGETHandler)
+	attachHandler(http.MethodDelete, RedirectsPathWithID, m.RedirectDELETEHandler)
+
 	// header filtering administration routes
 	attachHandler(http.MethodGet, HeaderAllowsPathWithID, m.HeaderFilterAllowGET)
 	attachHandler(http.MethodGet, HeaderBlocksPathWithID, m.HeaderFilterBlockGET)
@@ -118,6 +172,7 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 
 	// domain maintenance stuff
 	attachHandler(http.MethodPost, DomainKeysExpirePath, m.DomainKeysExpirePOSTHandler)
+	attachHandler(http.MethodPost, DomainMovesResolvePath, m.DomainMovesResolvePOSTHandler)
 
 	// accounts stuff
 	attachHandler(http.MethodGet, AccountsV1Path, m.AccountsGETV1Handler)
@@ -126,10 +181,15 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodPost, AccountsActionPath, m.AccountActionPOSTHandler)
 	attachHandler(http.MethodPost, AccountsApprovePath, m.AccountApprovePOSTHandler)
 	attachHandler(http.MethodPost, AccountsRejectPath, m.AccountRejectPOSTHandler)
+	attachHandler(http.MethodPost, AccountsVerifyPath, m.AccountVerifyPOSTHandler)
+	attachHandler(http.MethodPost, AccountsUnverifyPath, m.AccountUnverifyPOSTHandler)
+	attachHandler(http.MethodPost, BulkActionsPath, m.BulkActionPOSTHandler)
 
 	// media stuff
 	attachHandler(http.MethodPost, MediaCleanupPath, m.MediaCleanupPOSTHandler)
 	attachHandler(http.MethodPost, MediaRefetchPath, m.MediaRefetchPOSTHandler)
+	attachHandler(http.MethodPost, MediaReconcilePath, m.MediaReconcilePOSTHandler)
+	attachHandler(http.MethodGet, MediaReconcilePath, m.MediaReconcileGETHandler)
 
 	// reports stuff
 	attachHandler(http.MethodGet, ReportsPath, m.ReportsGETHandler)
@@ -146,6 +206,21 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodPatch, InstanceRulesPathWithID, m.RulePATCHHandler)
 	attachHandler(http.MethodDelete, InstanceRulesPathWithID, m.RuleDELETEHandler)
 
+	// instance stats stuff
+	attachHandler(http.MethodGet, InstanceStatsPath, m.InstanceStatsGETHandler)
+
+	// invites stuff
+	attachHandler(http.MethodGet, InvitesPath, m.InvitesGETHandler)
+	attachHandler(http.MethodDelete, InvitesPathWithID, m.InviteDELETEHandler)
+
+	// moderation notes stuff
+	attachHandler(http.MethodGet, ModerationNotesPath, m.ModerationNotesGETHandler)
+	attachHandler(http.MethodPost, ModerationNotesPath, m.ModerationNotePOSTHandler)
+	attachHandler(http.MethodDelete, ModerationNotesPathWithID, m.ModerationNoteDELETEHandler)
+
+	// conversion report stuff
+	attachHandler(http.MethodGet, ConversionReportPath, m.ConversionReportGETHandler)
+
 	// debug stuff
 	if debug.DEBUG {
 		attachHandler(http.MethodGet, DebugAPUrlPath, m.DebugAPUrlHandler)
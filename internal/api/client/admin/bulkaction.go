@@ -0,0 +1,149 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// BulkActionPOSTHandler swagger:operation POST /api/v1/admin/bulk_actions adminBulkAction
+//
+// Take one admin action on many accounts and/or domains at once, listed in a CSV file.
+//
+// The first field of each line of the CSV file should be either the URI of an account
+// (eg., `https://example.org/users/someone`), or a domain (eg., `example.org`). Further
+// fields on each line are ignored, as are empty lines, and lines starting with `#`.
+//
+// Supported action types are:
+//
+// - `suspend`: suspend an account, or block a domain.
+// - `silence`: silence an account.
+// - `unblock`: remove the block on a domain.
+//
+// Actions are processed in the same way as their single-target equivalents.
+// Use `dry_run` to check what would be done for each target, without doing it.
+//
+// The response is a multi-status report, with one entry for each target, giving
+// the HTTP status code and message of the result for that target.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	consumes:
+//	- multipart/form-data
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: file
+//		in: formData
+//		description: CSV file of account URIs and/or domains.
+//		type: file
+//		required: true
+//	-
+//		name: type
+//		in: formData
+//		description: Type of admin action to take.
+//		type: string
+//		enum:
+//			- suspend
+//			- silence
+//			- unblock
+//		required: true
+//	-
+//		name: text
+//		in: formData
+//		description: Text describing why the action was taken.
+//		type: string
+//	-
+//		name: dry_run
+//		in: formData
+//		description: If true, check each target, but don't actually take any actions.
+//		type: boolean
+//		default: false
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin
+//
+//	responses:
+//		'207':
+//			description: Multi-status report of the result for each target.
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'406':
+//			description: not acceptable
+//		'422':
+//			description: unprocessable entity
+//		'500':
+//			description: internal server error
+func (m *Module) BulkActionPOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if !*authed.User.Admin {
+		err := fmt.Errorf("user %s not an admin", authed.User.ID)
+		apiutil.ErrorHandler(c, gtserror.NewErrorForbidden(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if authed.Account.IsMoving() {
+		apiutil.ForbiddenAfterMove(c)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := new(apimodel.AdminBulkActionRequest)
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	multiStatus, errWithCode := m.processor.Admin().BulkAction(
+		c.Request.Context(),
+		authed.Account,
+		form,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusMultiStatus, multiStatus)
+}
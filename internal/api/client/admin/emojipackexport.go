@@ -0,0 +1,111 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// EmojiPackExportGETHandler swagger:operation GET /api/v1/admin/custom_emojis/pack emojiPackExport
+//
+// Export local custom emojis as an emoji pack.
+//
+// The pack is a zip archive containing the original image of each emoji,
+// named after its shortcode, along with a pack.json manifest mapping
+// shortcodes to file names. This format is compatible with Pleroma emoji packs.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	produces:
+//	- application/zip
+//
+//	parameters:
+//	-
+//		name: category
+//		in: query
+//		description: >-
+//			Only export emojis in the category with this name.
+//			If not set, all local emojis will be exported.
+//		type: string
+//		required: false
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin
+//
+//	responses:
+//		'200':
+//			description: Emoji pack zip archive.
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'404':
+//			description: category not found
+//		'500':
+//			description: internal server error
+func (m *Module) EmojiPackExportGETHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if !*authed.User.Admin {
+		err := fmt.Errorf("user %s not an admin", authed.User.ID)
+		apiutil.ErrorHandler(c, gtserror.NewErrorForbidden(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	filename := config.GetHost() + "-emojis.zip"
+	c.Header("Content-Type", apiutil.AppZip)
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+
+	errWithCode := m.processor.Admin().EmojiPackExport(
+		c.Request.Context(),
+		c.Writer,
+		c.Query(CategoryKey),
+	)
+	if errWithCode == nil {
+		return
+	}
+
+	if !c.Writer.Written() {
+		// Nothing written yet, we can
+		// still return a proper error.
+		c.Writer.Header().Del("Content-Type")
+		c.Writer.Header().Del("Content-Disposition")
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	// Too late to change the response,
+	// the best we can do is log this.
+	log.Errorf(c.Request.Context(), "error exporting emoji pack: %v", errWithCode)
+}
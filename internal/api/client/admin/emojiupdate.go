@@ -100,6 +100,8 @@ import (
 //		description: >-
 //			Category in which to place the emoji.
 //			If a category with the given name doesn't exist yet, it will be created.
+//			For the `copy` action type, if this is not set at all, the copy is placed
+//			in the same category as the emoji it was copied from.
 //		type: string
 //		maximumLength: 64
 //
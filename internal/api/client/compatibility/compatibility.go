@@ -0,0 +1,134 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package compatibility
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+const (
+	// BasePath is the base path for serving the compatibility API, minus the 'api' prefix
+	BasePath = "/v1/compatibility"
+)
+
+type Module struct {
+	processor *processing.Processor
+
+	// endpoints attached to the
+	// client API, by Record().
+	endpoints []endpoint
+
+	// compatibility response, built
+	// from endpoints on first request.
+	compatibility     *apimodel.Compatibility
+	compatibilityOnce sync.Once
+}
+
+type endpoint struct {
+	method string
+	path   string
+}
+
+func New(processor *processing.Processor) *Module {
+	return &Module{
+		processor: processor,
+	}
+}
+
+func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
+	attachHandler(http.MethodGet, BasePath, m.CompatibilityGETHandler)
+}
+
+// Record records an endpoint with given method and
+// path (minus the 'api' prefix) as attached to the
+// client API, to be described by the compatibility
+// endpoint. It must be called before serving starts.
+func (m *Module) Record(method string, path string) {
+	m.endpoints = append(m.endpoints, endpoint{
+		method: method,
+		path:   path,
+	})
+}
+
+// getCompatibility returns the compatibility
+// response, building it from recorded endpoints
+// and apiutil.Deprecations on first call.
+func (m *Module) getCompatibility() *apimodel.Compatibility {
+	m.compatibilityOnce.Do(func() {
+		versions := make(map[string][]apimodel.CompatibilityEndpoint)
+
+		for _, e := range m.endpoints {
+			// Get API version from path, eg., "/v1/accounts".
+			version, _, _ := strings.Cut(strings.TrimPrefix(e.path, "/"), "/")
+
+			if version == ":"+apiutil.APIVersionKey {
+				// Endpoints accepting an API version
+				// parameter are implemented for both.
+				for _, version := range []string{apiutil.APIv1, apiutil.APIv2} {
+					path := strings.Replace(e.path, ":"+apiutil.APIVersionKey, version, 1)
+					versions[version] = append(versions[version], toAPIEndpoint(e.method, path))
+				}
+				continue
+			}
+
+			versions[version] = append(versions[version], toAPIEndpoint(e.method, e.path))
+		}
+
+		for _, endpoints := range versions {
+			sort.Slice(endpoints, func(i, j int) bool {
+				if endpoints[i].Path != endpoints[j].Path {
+					return endpoints[i].Path < endpoints[j].Path
+				}
+				return endpoints[i].Method < endpoints[j].Method
+			})
+		}
+
+		m.compatibility = &apimodel.Compatibility{
+			Versions: versions,
+		}
+	})
+
+	return m.compatibility
+}
+
+func toAPIEndpoint(method string, path string) apimodel.CompatibilityEndpoint {
+	apiEndpoint := apimodel.CompatibilityEndpoint{
+		Method: method,
+		Path:   "/api" + path,
+	}
+
+	if d, ok := apiutil.GetDeprecation(method, path, ""); ok {
+		apiEndpoint.Deprecated = true
+		apiEndpoint.DeprecatedAt = util.FormatISO8601(d.Since)
+		if !d.Sunset.IsZero() {
+			apiEndpoint.Sunset = util.FormatISO8601(d.Sunset)
+		}
+		apiEndpoint.Successor = d.Successor
+	}
+
+	return apiEndpoint
+}
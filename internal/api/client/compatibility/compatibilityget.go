@@ -0,0 +1,62 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package compatibility
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+// CompatibilityGETHandler swagger:operation GET /api/v1/compatibility compatibilityGet
+//
+// Describe the client API endpoints implemented by this instance, by API version.
+//
+// Clients can use this to detect whether features are supported, rather than
+// probing endpoints. Deprecated endpoints are marked as such, along with their
+// successor and scheduled removal (sunset), if any. Requests to deprecated
+// endpoints are also served with `Deprecation`, `Sunset`, and `Link` headers.
+//
+//	---
+//	tags:
+//	- instance
+//
+//	produces:
+//	- application/json
+//
+//	responses:
+//		'200':
+//			description: Implemented client API endpoints.
+//			schema:
+//				"$ref": "#/definitions/compatibility"
+//		'304':
+//			description: Not modified, the response matches the ETag given in the If-None-Match header.
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal error
+func (m *Module) CompatibilityGETHandler(c *gin.Context) {
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSONWithETag(c, http.StatusOK, m.getCompatibility())
+}
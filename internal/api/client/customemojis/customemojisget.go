@@ -30,6 +30,9 @@ import (
 //
 // Get an array of custom emojis available on the instance.
 //
+// Emojis are grouped by category, in picker order (by category position, then name),
+// with uncategorized emojis last. Within each category, emojis are sorted by shortcode.
+//
 //	---
 //	tags:
 //	- custom_emojis
@@ -48,6 +51,8 @@ import (
 //				type: array
 //				items:
 //					"$ref": "#/definitions/emoji"
+//		'304':
+//			description: Not modified, the response matches the ETag given in the If-None-Match header.
 //		'401':
 //			description: unauthorized
 //		'406':
@@ -71,5 +76,5 @@ func (m *Module) CustomEmojisGETHandler(c *gin.Context) {
 		return
 	}
 
-	apiutil.JSON(c, http.StatusOK, emojis)
+	apiutil.JSONWithETag(c, http.StatusOK, emojis)
 }
@@ -0,0 +1,64 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package delegates
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+)
+
+const (
+	// DelegatesBasePath is the base path for managing
+	// the delegates of a shared account, minus the 'api' prefix.
+	DelegatesBasePath = "/v1/delegates"
+	// DelegatesBasePathWithID is the delegates path with the ID key in it.
+	DelegatesBasePathWithID = DelegatesBasePath + "/:" + apiutil.IDKey
+	// DelegatesAuditPath is for viewing statuses posted by delegates.
+	DelegatesAuditPath = DelegatesBasePath + "/audit"
+	// DelegationsBasePath is the base path for managing the
+	// shared accounts an account is a delegate of, minus the 'api' prefix.
+	DelegationsBasePath = "/v1/delegations"
+	// DelegationsBasePathWithID is the delegations path with the ID key in it.
+	DelegationsBasePathWithID = DelegationsBasePath + "/:" + apiutil.IDKey
+	// DelegationsAcceptPath is for accepting a delegation invitation.
+	DelegationsAcceptPath = DelegationsBasePathWithID + "/accept"
+)
+
+type Module struct {
+	processor *processing.Processor
+}
+
+func New(processor *processing.Processor) *Module {
+	return &Module{
+		processor: processor,
+	}
+}
+
+func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
+	attachHandler(http.MethodGet, DelegatesBasePath, m.DelegatesGETHandler)
+	attachHandler(http.MethodPost, DelegatesBasePath, m.DelegatePOSTHandler)
+	attachHandler(http.MethodGet, DelegatesAuditPath, m.DelegatesAuditGETHandler)
+	attachHandler(http.MethodPut, DelegatesBasePathWithID, m.DelegatePUTHandler)
+	attachHandler(http.MethodDelete, DelegatesBasePathWithID, m.DelegateDELETEHandler)
+	attachHandler(http.MethodGet, DelegationsBasePath, m.DelegationsGETHandler)
+	attachHandler(http.MethodPost, DelegationsAcceptPath, m.DelegationAcceptPOSTHandler)
+	attachHandler(http.MethodDelete, DelegationsBasePathWithID, m.DelegationDELETEHandler)
+}
@@ -42,6 +42,8 @@ import (
 //			description: "Instance information."
 //			schema:
 //				"$ref": "#/definitions/instanceV1"
+//		'304':
+//			description: Not modified, the response matches the ETag given in the If-None-Match header.
 //		'406':
 //			description: not acceptable
 //		'500':
@@ -58,7 +60,7 @@ func (m *Module) InstanceInformationGETHandlerV1(c *gin.Context) {
 		return
 	}
 
-	apiutil.JSON(c, http.StatusOK, instance)
+	apiutil.JSONWithETag(c, http.StatusOK, instance)
 }
 
 // InstanceInformationGETHandlerV2 swagger:operation GET /api/v2/instance instanceGetV2
@@ -77,6 +79,8 @@ func (m *Module) InstanceInformationGETHandlerV1(c *gin.Context) {
 //			description: "Instance information."
 //			schema:
 //				"$ref": "#/definitions/instanceV2"
+//		'304':
+//			description: Not modified, the response matches the ETag given in the If-None-Match header.
 //		'406':
 //			description: not acceptable
 //		'500':
@@ -93,5 +97,5 @@ func (m *Module) InstanceInformationGETHandlerV2(c *gin.Context) {
 		return
 	}
 
-	apiutil.JSON(c, http.StatusOK, instance)
+	apiutil.JSONWithETag(c, http.StatusOK, instance)
 }
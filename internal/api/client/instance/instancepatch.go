@@ -90,6 +90,13 @@ import (
 //		maxLength: 5000
 //		allowEmptyValue: true
 //	-
+//		name: privacy_policy
+//		in: formData
+//		description: Privacy policy of the instance.
+//		type: string
+//		maxLength: 5000
+//		allowEmptyValue: true
+//	-
 //		name: thumbnail
 //		in: formData
 //		description: Thumbnail image to use for the instance.
@@ -104,6 +111,24 @@ import (
 //		in: formData
 //		description: Header image to use for the instance.
 //		type: file
+//	-
+//		name: landing_page_blocks
+//		in: formData
+//		description: >-
+//			Comma-separated, ordered list of blocks to show on the instance landing page.
+//			Valid blocks are short_description, about, contact, register, featured_accounts, what_is_this, and apps.
+//			Empty string resets the landing page to its default layout.
+//		type: string
+//		allowEmptyValue: true
+//	-
+//		name: featured_accounts
+//		in: formData
+//		description: >-
+//			Comma-separated, ordered list of usernames of local accounts to
+//			show in the featured_accounts block of the landing page, max 8.
+//			Empty string unsets featured accounts.
+//		type: string
+//		allowEmptyValue: true
 //
 //	security:
 //	- OAuth2 Bearer:
@@ -176,9 +201,12 @@ func validateInstanceUpdate(form *apimodel.InstanceSettingsUpdateRequest) error
 		form.ShortDescription == nil &&
 		form.Description == nil &&
 		form.Terms == nil &&
+		form.PrivacyPolicy == nil &&
 		form.Avatar == nil &&
 		form.AvatarDescription == nil &&
-		form.Header == nil {
+		form.Header == nil &&
+		form.LandingPageBlocks == nil &&
+		form.FeaturedAccounts == nil {
 		return errors.New("empty form submitted")
 	}
 
@@ -24,10 +24,13 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/instance"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
@@ -113,7 +116,9 @@ func (suite *InstancePatchTestSuite) TestInstancePatch1() {
       "image_matrix_limit": 16777216,
       "video_size_limit": 41943040,
       "video_frame_rate_limit": 60,
-      "video_matrix_limit": 16777216
+      "video_matrix_limit": 16777216,
+      "resumable_upload_min_size": 5242880,
+      "resumable_upload_min_part_size": 4096
     },
     "polls": {
       "max_options": 6,
@@ -177,7 +182,13 @@ func (suite *InstancePatchTestSuite) TestInstancePatch1() {
     }
   ],
   "terms": "<p>This is where a list of terms and conditions might go.</p><p>For example:</p><p>If you want to sign up on this instance, you oughta know that we:</p><ol><li>Will sell your data to whoever offers.</li><li>Secure the server with password <code>password</code> wherever possible.</li></ol>",
-  "terms_text": "This is where a list of terms and conditions might go.\n\nFor example:\n\nIf you want to sign up on this instance, you oughta know that we:\n\n1. Will sell your data to whoever offers.\n2. Secure the server with password `+"`"+`password`+"`"+` wherever possible."
+  "terms_text": "This is where a list of terms and conditions might go.\n\nFor example:\n\nIf you want to sign up on this instance, you oughta know that we:\n\n1. Will sell your data to whoever offers.\n2. Secure the server with password `+"`"+`password`+"`"+` wherever possible.",
+  "landing_page_blocks": [
+    "short_description",
+    "what_is_this",
+    "register",
+    "apps"
+  ]
 }`, dst.String())
 }
 
@@ -234,7 +245,9 @@ func (suite *InstancePatchTestSuite) TestInstancePatch2() {
       "image_matrix_limit": 16777216,
       "video_size_limit": 41943040,
       "video_frame_rate_limit": 60,
-      "video_matrix_limit": 16777216
+      "video_matrix_limit": 16777216,
+      "resumable_upload_min_size": 5242880,
+      "resumable_upload_min_part_size": 4096
     },
     "polls": {
       "max_options": 6,
@@ -298,7 +311,13 @@ func (suite *InstancePatchTestSuite) TestInstancePatch2() {
     }
   ],
   "terms": "<p>This is where a list of terms and conditions might go.</p><p>For example:</p><p>If you want to sign up on this instance, you oughta know that we:</p><ol><li>Will sell your data to whoever offers.</li><li>Secure the server with password <code>password</code> wherever possible.</li></ol>",
-  "terms_text": "This is where a list of terms and conditions might go.\n\nFor example:\n\nIf you want to sign up on this instance, you oughta know that we:\n\n1. Will sell your data to whoever offers.\n2. Secure the server with password `+"`"+`password`+"`"+` wherever possible."
+  "terms_text": "This is where a list of terms and conditions might go.\n\nFor example:\n\nIf you want to sign up on this instance, you oughta know that we:\n\n1. Will sell your data to whoever offers.\n2. Secure the server with password `+"`"+`password`+"`"+` wherever possible.",
+  "landing_page_blocks": [
+    "short_description",
+    "what_is_this",
+    "register",
+    "apps"
+  ]
 }`, dst.String())
 }
 
@@ -355,7 +374,9 @@ func (suite *InstancePatchTestSuite) TestInstancePatch3() {
       "image_matrix_limit": 16777216,
       "video_size_limit": 41943040,
       "video_frame_rate_limit": 60,
-      "video_matrix_limit": 16777216
+      "video_matrix_limit": 16777216,
+      "resumable_upload_min_size": 5242880,
+      "resumable_upload_min_part_size": 4096
     },
     "polls": {
       "max_options": 6,
@@ -419,7 +440,13 @@ func (suite *InstancePatchTestSuite) TestInstancePatch3() {
     }
   ],
   "terms": "<p>This is where a list of terms and conditions might go.</p><p>For example:</p><p>If you want to sign up on this instance, you oughta know that we:</p><ol><li>Will sell your data to whoever offers.</li><li>Secure the server with password <code>password</code> wherever possible.</li></ol>",
-  "terms_text": "This is where a list of terms and conditions might go.\n\nFor example:\n\nIf you want to sign up on this instance, you oughta know that we:\n\n1. Will sell your data to whoever offers.\n2. Secure the server with password `+"`"+`password`+"`"+` wherever possible."
+  "terms_text": "This is where a list of terms and conditions might go.\n\nFor example:\n\nIf you want to sign up on this instance, you oughta know that we:\n\n1. Will sell your data to whoever offers.\n2. Secure the server with password `+"`"+`password`+"`"+` wherever possible.",
+  "landing_page_blocks": [
+    "short_description",
+    "what_is_this",
+    "register",
+    "apps"
+  ]
 }`, dst.String())
 }
 
@@ -527,7 +554,9 @@ func (suite *InstancePatchTestSuite) TestInstancePatch6() {
       "image_matrix_limit": 16777216,
       "video_size_limit": 41943040,
       "video_frame_rate_limit": 60,
-      "video_matrix_limit": 16777216
+      "video_matrix_limit": 16777216,
+      "resumable_upload_min_size": 5242880,
+      "resumable_upload_min_part_size": 4096
     },
     "polls": {
       "max_options": 6,
@@ -591,7 +620,13 @@ func (suite *InstancePatchTestSuite) TestInstancePatch6() {
     }
   ],
   "terms": "<p>This is where a list of terms and conditions might go.</p><p>For example:</p><p>If you want to sign up on this instance, you oughta know that we:</p><ol><li>Will sell your data to whoever offers.</li><li>Secure the server with password <code>password</code> wherever possible.</li></ol>",
-  "terms_text": "This is where a list of terms and conditions might go.\n\nFor example:\n\nIf you want to sign up on this instance, you oughta know that we:\n\n1. Will sell your data to whoever offers.\n2. Secure the server with password `+"`"+`password`+"`"+` wherever possible."
+  "terms_text": "This is where a list of terms and conditions might go.\n\nFor example:\n\nIf you want to sign up on this instance, you oughta know that we:\n\n1. Will sell your data to whoever offers.\n2. Secure the server with password `+"`"+`password`+"`"+` wherever possible.",
+  "landing_page_blocks": [
+    "short_description",
+    "what_is_this",
+    "register",
+    "apps"
+  ]
 }`, dst.String())
 }
 
@@ -670,7 +705,9 @@ func (suite *InstancePatchTestSuite) TestInstancePatch8() {
       "image_matrix_limit": 16777216,
       "video_size_limit": 41943040,
       "video_frame_rate_limit": 60,
-      "video_matrix_limit": 16777216
+      "video_matrix_limit": 16777216,
+      "resumable_upload_min_size": 5242880,
+      "resumable_upload_min_part_size": 4096
     },
     "polls": {
       "max_options": 6,
@@ -736,7 +773,13 @@ func (suite *InstancePatchTestSuite) TestInstancePatch8() {
     }
   ],
   "terms": "<p>This is where a list of terms and conditions might go.</p><p>For example:</p><p>If you want to sign up on this instance, you oughta know that we:</p><ol><li>Will sell your data to whoever offers.</li><li>Secure the server with password <code>password</code> wherever possible.</li></ol>",
-  "terms_text": "This is where a list of terms and conditions might go.\n\nFor example:\n\nIf you want to sign up on this instance, you oughta know that we:\n\n1. Will sell your data to whoever offers.\n2. Secure the server with password `+"`"+`password`+"`"+` wherever possible."
+  "terms_text": "This is where a list of terms and conditions might go.\n\nFor example:\n\nIf you want to sign up on this instance, you oughta know that we:\n\n1. Will sell your data to whoever offers.\n2. Secure the server with password `+"`"+`password`+"`"+` wherever possible.",
+  "landing_page_blocks": [
+    "short_description",
+    "what_is_this",
+    "register",
+    "apps"
+  ]
 }`, dst.String())
 
 	// extra bonus: check the v2 model thumbnail after the patch
@@ -828,7 +871,9 @@ func (suite *InstancePatchTestSuite) TestInstancePatch9() {
       "image_matrix_limit": 16777216,
       "video_size_limit": 41943040,
       "video_frame_rate_limit": 60,
-      "video_matrix_limit": 16777216
+      "video_matrix_limit": 16777216,
+      "resumable_upload_min_size": 5242880,
+      "resumable_upload_min_part_size": 4096
     },
     "polls": {
       "max_options": 6,
@@ -892,10 +937,110 @@ func (suite *InstancePatchTestSuite) TestInstancePatch9() {
     }
   ],
   "terms": "<p>This is where a list of terms and conditions might go.</p><p>For example:</p><p>If you want to sign up on this instance, you oughta know that we:</p><ol><li>Will sell your data to whoever offers.</li><li>Secure the server with password <code>password</code> wherever possible.</li></ol>",
-  "terms_text": "This is where a list of terms and conditions might go.\n\nFor example:\n\nIf you want to sign up on this instance, you oughta know that we:\n\n1. Will sell your data to whoever offers.\n2. Secure the server with password `+"`"+`password`+"`"+` wherever possible."
+  "terms_text": "This is where a list of terms and conditions might go.\n\nFor example:\n\nIf you want to sign up on this instance, you oughta know that we:\n\n1. Will sell your data to whoever offers.\n2. Secure the server with password `+"`"+`password`+"`"+` wherever possible.",
+  "landing_page_blocks": [
+    "short_description",
+    "what_is_this",
+    "register",
+    "apps"
+  ]
 }`, dst.String())
 }
 
+func (suite *InstancePatchTestSuite) TestInstancePatchLandingPage() {
+	code, b := suite.instancePatch("", "", map[string][]string{
+		"landing_page_blocks": {"featured_accounts, short_description,contact"},
+		"featured_accounts":   {"the_mighty_zork,1happyturtle"},
+	})
+
+	if expectedCode := http.StatusOK; code != expectedCode {
+		suite.FailNowf("wrong status code", "expected %d but got %d", expectedCode, code)
+	}
+
+	i := new(apimodel.InstanceV1)
+	if err := json.Unmarshal(b, i); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal([]string{"featured_accounts", "short_description", "contact"}, i.LandingPageBlocks)
+	if suite.Len(i.FeaturedAccounts, 2) {
+		suite.Equal("the_mighty_zork", i.FeaturedAccounts[0].Username)
+		suite.Equal("1happyturtle", i.FeaturedAccounts[1].Username)
+	}
+
+	// Empty values should reset to
+	// default layout + unset accounts.
+	code, b = suite.instancePatch("", "", map[string][]string{
+		"landing_page_blocks": {""},
+		"featured_accounts":   {""},
+	})
+
+	if expectedCode := http.StatusOK; code != expectedCode {
+		suite.FailNowf("wrong status code", "expected %d but got %d", expectedCode, code)
+	}
+
+	i = new(apimodel.InstanceV1)
+	if err := json.Unmarshal(b, i); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(gtsmodel.DefaultLandingPageBlocks, i.LandingPageBlocks)
+	suite.Empty(i.FeaturedAccounts)
+}
+
+func (suite *InstancePatchTestSuite) TestInstancePatchLandingPageInvalid() {
+	for _, test := range []struct {
+		fields   map[string][]string
+		expected string
+	}{
+		{
+			fields:   map[string][]string{"landing_page_blocks": {"short_description,nonsense"}},
+			expected: `{"error":"Bad Request: landing page block 'nonsense' was not recognized, valid options are 'short_description', 'about', 'contact', 'register', 'featured_accounts', 'what_is_this', 'apps'"}`,
+		},
+		{
+			fields:   map[string][]string{"landing_page_blocks": {"apps,apps"}},
+			expected: `{"error":"Bad Request: landing page block 'apps' was given more than once"}`,
+		},
+		{
+			fields:   map[string][]string{"featured_accounts": {"admin,someone_who_does_not_exist"}},
+			expected: `{"error":"Bad Request: db error getting selected featured account with username someone_who_does_not_exist: sql: no rows in result set"}`,
+		},
+		{
+			fields:   map[string][]string{"featured_accounts": {"admin,admin"}},
+			expected: `{"error":"Bad Request: selected featured account admin was given more than once"}`,
+		},
+	} {
+		code, b := suite.instancePatch("", "", test.fields)
+		suite.Equal(http.StatusBadRequest, code)
+		suite.Equal(test.expected, string(b))
+	}
+}
+
+func (suite *InstancePatchTestSuite) TestInstancePatchPrivacyPolicy() {
+	code, b := suite.instancePatch("", "", map[string][]string{
+		"privacy_policy": {"We keep **nothing** about you that we don't need to."},
+	})
+
+	if expectedCode := http.StatusOK; code != expectedCode {
+		suite.FailNowf("wrong status code", "expected %d but got %d", expectedCode, code)
+	}
+
+	i := new(apimodel.InstanceV1)
+	if err := json.Unmarshal(b, i); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal("<p>We keep <strong>nothing</strong> about you that we don't need to.</p>", i.PrivacyPolicy)
+	suite.Equal("We keep **nothing** about you that we don't need to.", i.PrivacyPolicyRaw)
+
+	// Too long privacy policy should be refused.
+	code, b = suite.instancePatch("", "", map[string][]string{
+		"privacy_policy": {strings.Repeat("a", 5001)},
+	})
+	suite.Equal(http.StatusBadRequest, code)
+	suite.Equal(`{"error":"Bad Request: privacy policy should be no more than 5000 chars but given privacy policy was 5001"}`, string(b))
+}
+
 func TestInstancePatchTestSuite(t *testing.T) {
 	suite.Run(t, &InstancePatchTestSuite{})
 }
@@ -34,6 +34,12 @@ import (
 //
 // Upload a new media attachment.
 //
+// With `v1`, the media is fully processed before the request returns.
+//
+// With `v2`, a partial attachment with a null `url` is returned immediately
+// with status code 202, and the media is processed in the background. Clients
+// should poll `GET /api/v1/media/{id}` until it returns 200 and a non-null `url`.
+//
 //	---
 //	tags:
 //	- media
@@ -84,6 +90,10 @@ import (
 //			description: The newly-created media attachment.
 //			schema:
 //				"$ref": "#/definitions/attachment"
+//		'202':
+//			description: The newly-created media attachment, still being processed (`v2` only).
+//			schema:
+//				"$ref": "#/definitions/attachment"
 //		'400':
 //			description: bad request
 //		'401':
@@ -129,21 +139,26 @@ func (m *Module) MediaCreatePOSTHandler(c *gin.Context) {
 		return
 	}
 
+	if apiVersion == apiutil.APIv2 {
+		// The v2 media API processes media asynchronously:
+		// return the partial attachment straight away, and
+		// let the client poll /api/v1/media/:id for the URL.
+		apiAttachment, errWithCode := m.processor.Media().CreateAsync(c.Request.Context(), authed.Account, form)
+		if errWithCode != nil {
+			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
+		apiutil.JSON(c, http.StatusAccepted, apiAttachment)
+		return
+	}
+
 	apiAttachment, errWithCode := m.processor.Media().Create(c.Request.Context(), authed.Account, form)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
 	}
 
-	if apiVersion == apiutil.APIv2 {
-		// the mastodon v2 media API specifies that the URL should be null
-		// and that the client should call /api/v1/media/:id to get the URL
-		//
-		// so even though we have the URL already, remove it now to comply
-		// with the api
-		apiAttachment.URL = nil
-	}
-
 	apiutil.JSON(c, http.StatusOK, apiAttachment)
 }
 
@@ -153,23 +168,34 @@ func validateCreateMedia(form *apimodel.AttachmentRequest) error {
 		return errors.New("no attachment given")
 	}
 
-	maxVideoSize := config.GetMediaVideoMaxSize()
-	maxImageSize := config.GetMediaImageMaxSize()
-	minDescriptionChars := config.GetMediaDescriptionMinChars()
-	maxDescriptionChars := config.GetMediaDescriptionMaxChars()
+	if err := validateMediaSize(form.File.Size); err != nil {
+		return err
+	}
 
-	// a very superficial check to see if no size limits are exceeded
-	// we still don't actually know which media types we're dealing with but the other handlers will go into more detail there
-	maxSize := maxVideoSize
-	if maxImageSize > maxSize {
+	return validateMediaDescription(form.Description)
+}
+
+// validateMediaSize does a very superficial check to see that no size
+// limits are exceeded. We still don't actually know which media type
+// we're dealing with, but processing will go into more detail there.
+func validateMediaSize(size int64) error {
+	maxSize := config.GetMediaVideoMaxSize()
+	if maxImageSize := config.GetMediaImageMaxSize(); maxImageSize > maxSize {
 		maxSize = maxImageSize
 	}
 
-	if form.File.Size > int64(maxSize) {
-		return fmt.Errorf("file size limit exceeded: limit is %d bytes but attachment was %d bytes", maxSize, form.File.Size)
+	if size > int64(maxSize) {
+		return fmt.Errorf("file size limit exceeded: limit is %d bytes but attachment was %d bytes", maxSize, size)
 	}
 
-	if length := len([]rune(form.Description)); length > maxDescriptionChars {
+	return nil
+}
+
+func validateMediaDescription(description string) error {
+	minDescriptionChars := config.GetMediaDescriptionMinChars()
+	maxDescriptionChars := config.GetMediaDescriptionMaxChars()
+
+	if length := len([]rune(description)); length > maxDescriptionChars {
 		return fmt.Errorf("image description length must be between %d and %d characters (inclusive), but provided image description was %d chars", minDescriptionChars, maxDescriptionChars, length)
 	}
 
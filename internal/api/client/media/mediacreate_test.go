@@ -19,6 +19,7 @@ package media_test
 
 import (
 	"bytes"
+	"context"
 	"crypto/rand"
 	"encoding/base64"
 	"encoding/json"
@@ -249,17 +250,8 @@ func (suite *MediaCreateTestSuite) TestMediaCreateSuccessfulV2() {
 	// do the actual request
 	suite.mediaModule.MediaCreatePOSTHandler(ctx)
 
-	// check what's in storage *after* the request
-	var storageKeysAfterRequest []string
-	if err := suite.storage.WalkKeys(ctx, func(key string) error {
-		storageKeysAfterRequest = append(storageKeysAfterRequest, key)
-		return nil
-	}); err != nil {
-		panic(err)
-	}
-
 	// check response
-	suite.EqualValues(http.StatusOK, recorder.Code)
+	suite.EqualValues(http.StatusAccepted, recorder.Code)
 
 	result := recorder.Result()
 	defer result.Body.Close()
@@ -271,8 +263,38 @@ func (suite *MediaCreateTestSuite) TestMediaCreateSuccessfulV2() {
 	err = json.Unmarshal(b, attachmentReply)
 	suite.NoError(err)
 
+	// only a placeholder so far
+	suite.NotEmpty(attachmentReply.ID)
 	suite.Equal("this is a test image -- a cool background from somewhere", *attachmentReply.Description)
-	suite.Equal("image", attachmentReply.Type)
+	suite.Equal("unknown", attachmentReply.Type)
+	suite.Nil(attachmentReply.URL)
+	suite.Nil(attachmentReply.PreviewURL)
+
+	// the placeholder should be pollable, but not ready
+	account := suite.testAccounts["local_account_1"]
+	polled, errWithCode := suite.processor.Media().Get(context.Background(), account, attachmentReply.ID)
+	suite.NoError(errWithCode)
+	suite.Nil(polled.URL)
+
+	// processing should have been queued; run it
+	process, ok := suite.state.Workers.Media.Queue.Pop()
+	suite.True(ok)
+	process(context.Background())
+
+	// check what's in storage *after* processing
+	var storageKeysAfterRequest []string
+	if err := suite.storage.WalkKeys(ctx, func(key string) error {
+		storageKeysAfterRequest = append(storageKeysAfterRequest, key)
+		return nil
+	}); err != nil {
+		panic(err)
+	}
+
+	// now the attachment should be fully processed
+	polled, errWithCode = suite.processor.Media().Get(context.Background(), account, attachmentReply.ID)
+	suite.NoError(errWithCode)
+
+	suite.Equal("image", polled.Type)
 	suite.EqualValues(apimodel.MediaMeta{
 		Original: apimodel.MediaDimensions{
 			Width:  1920,
@@ -290,11 +312,10 @@ func (suite *MediaCreateTestSuite) TestMediaCreateSuccessfulV2() {
 			X: -0.5,
 			Y: 0.5,
 		},
-	}, *attachmentReply.Meta)
-	suite.Equal("LiBzRk#6V[WF_NvzV@WY_3rqV@a$", *attachmentReply.Blurhash)
-	suite.NotEmpty(attachmentReply.ID)
-	suite.Nil(attachmentReply.URL)
-	suite.NotEmpty(attachmentReply.PreviewURL)
+	}, *polled.Meta)
+	suite.Equal("LiBzRk#6V[WF_NvzV@WY_3rqV@a$", *polled.Blurhash)
+	suite.NotNil(polled.URL)
+	suite.NotEmpty(polled.PreviewURL)
 	suite.Equal(len(storageKeysBeforeRequest)+2, len(storageKeysAfterRequest)) // 2 images should be added to storage: the original and the thumbnail
 }
 
@@ -0,0 +1,468 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// MediaUploadPOSTHandler swagger:operation POST /api/v2/media/uploads mediaUploadCreate
+//
+// Start a resumable upload of a large media attachment.
+//
+// Resumable uploads let clients on flaky connections upload large media in parts,
+// picking back up where they left off rather than starting again from zero. They
+// can only be used for media of at least `media_attachments.resumable_upload_min_size`
+// bytes, as given in the instance configuration; smaller media should be uploaded
+// with `POST /api/v2/media` instead.
+//
+// The parts of the media should then be sent in order with
+// `PATCH /api/v2/media/uploads/{id}`, and the upload finished with
+// `POST /api/v2/media/uploads/{id}/finish`. Uploads that aren't finished
+// before `expires_at` are discarded. Each account may only have a limited
+// number of unfinished uploads at once.
+//
+//	---
+//	tags:
+//	- media
+//
+//	consumes:
+//	- application/json
+//	- application/x-www-form-urlencoded
+//	- multipart/form-data
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: size
+//		in: formData
+//		description: Total size in bytes of the media to be uploaded.
+//		type: integer
+//		required: true
+//	-
+//		name: description
+//		in: formData
+//		description: >-
+//			Image or media description to use as alt-text on the attachment.
+//			This is very useful for users of screenreaders!
+//			May or may not be required, depending on your instance settings.
+//		type: string
+//	-
+//		name: focus
+//		in: formData
+//		description: >-
+//			Focus of the media file.
+//			If present, it should be in the form of two comma-separated floats between -1 and 1.
+//			For example: `-0.5,0.25`.
+//		type: string
+//		default: "0,0"
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:media
+//
+//	responses:
+//		'201':
+//			description: The newly-started upload.
+//			schema:
+//				"$ref": "#/definitions/mediaUpload"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'406':
+//			description: not acceptable
+//		'429':
+//			description: too many unfinished uploads
+//		'500':
+//			description: internal server error
+func (m *Module) MediaUploadPOSTHandler(c *gin.Context) {
+	if _, errWithCode := apiutil.ParseAPIVersion(
+		c.Param(apiutil.APIVersionKey),
+		apiutil.APIv2,
+	); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if authed.Account.IsMoving() {
+		apiutil.ForbiddenAfterMove(c)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.MediaUploadRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if err := validateCreateMediaUpload(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	upload, errWithCode := m.processor.Media().UploadCreate(c.Request.Context(), authed.Account, form)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusCreated, upload)
+}
+
+// MediaUploadGETHandler swagger:operation GET /api/v2/media/uploads/{id} mediaUploadGet
+//
+// Get the current state of a resumable upload.
+//
+// After being cut off partway through sending a part, clients can use
+// this to find out the `offset` from which to resume the upload.
+//
+//	---
+//	tags:
+//	- media
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: id
+//		description: ID of the upload.
+//		type: string
+//		in: path
+//		required: true
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:media
+//
+//	responses:
+//		'200':
+//			description: The requested upload.
+//			schema:
+//				"$ref": "#/definitions/mediaUpload"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) MediaUploadGETHandler(c *gin.Context) {
+	if _, errWithCode := apiutil.ParseAPIVersion(
+		c.Param(apiutil.APIVersionKey),
+		apiutil.APIv2,
+	); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	uploadID, errWithCode := apiutil.ParseID(c.Param(IDKey))
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	upload, errWithCode := m.processor.Media().UploadGet(c.Request.Context(), authed.Account, uploadID)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, upload)
+}
+
+// MediaUploadPATCHHandler swagger:operation PATCH /api/v2/media/uploads/{id} mediaUploadAppend
+//
+// Send the next part of a resumable upload.
+//
+// The request body is the raw bytes of the part, and the `Content-Range` header must give
+// its position in the media, eg., `Content-Range: bytes 0-1048575/10485760` for the first
+// MiB of a 10MiB file. Parts must be sent in order: if the part doesn't start at the
+// upload's current `offset`, status code 409 is returned, and nothing is written.
+// Every part but the last must be at least `media_attachments.resumable_upload_min_part_size`
+// bytes, as given in the instance configuration.
+//
+// If the connection drops partway through a part, whatever was received is kept, as
+// long as it's at least the min part size; the client should then fetch the upload
+// to find out from which `offset` to carry on.
+//
+//	---
+//	tags:
+//	- media
+//
+//	consumes:
+//	- application/octet-stream
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: id
+//		description: ID of the upload.
+//		type: string
+//		in: path
+//		required: true
+//	-
+//		name: Content-Range
+//		description: Position of the part in the media, eg., `bytes 0-1048575/10485760`.
+//		type: string
+//		in: header
+//		required: true
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:media
+//
+//	responses:
+//		'200':
+//			description: The upload, with its new offset.
+//			schema:
+//				"$ref": "#/definitions/mediaUpload"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'409':
+//			description: part does not start at the upload's current offset
+//		'500':
+//			description: internal server error
+func (m *Module) MediaUploadPATCHHandler(c *gin.Context) {
+	if _, errWithCode := apiutil.ParseAPIVersion(
+		c.Param(apiutil.APIVersionKey),
+		apiutil.APIv2,
+	); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	uploadID, errWithCode := apiutil.ParseID(c.Param(IDKey))
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	start, end, err := parseContentRange(c.GetHeader("Content-Range"))
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	upload, errWithCode := m.processor.Media().UploadAppend(
+		c.Request.Context(),
+		authed.Account,
+		uploadID,
+		start,
+		end-start+1,
+		c.Request.Body,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, upload)
+}
+
+// MediaUploadFinishPOSTHandler swagger:operation POST /api/v2/media/uploads/{id}/finish mediaUploadFinish
+//
+// Finish a resumable upload, once all parts of the media have been sent.
+//
+// As with `POST /api/v2/media`, a partial attachment with a null `url` is returned
+// with status code 202, and the media is processed in the background. Clients
+// should poll `GET /api/v1/media/{id}` until it returns 200 and a non-null `url`.
+//
+//	---
+//	tags:
+//	- media
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: id
+//		description: ID of the upload.
+//		type: string
+//		in: path
+//		required: true
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:media
+//
+//	responses:
+//		'202':
+//			description: The newly-created media attachment, still being processed.
+//			schema:
+//				"$ref": "#/definitions/attachment"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'422':
+//			description: not all parts of the media have been sent
+//		'500':
+//			description: internal server error
+func (m *Module) MediaUploadFinishPOSTHandler(c *gin.Context) {
+	if _, errWithCode := apiutil.ParseAPIVersion(
+		c.Param(apiutil.APIVersionKey),
+		apiutil.APIv2,
+	); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if authed.Account.IsMoving() {
+		apiutil.ForbiddenAfterMove(c)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	uploadID, errWithCode := apiutil.ParseID(c.Param(IDKey))
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiAttachment, errWithCode := m.processor.Media().UploadFinish(c.Request.Context(), authed.Account, uploadID)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusAccepted, apiAttachment)
+}
+
+func validateCreateMediaUpload(form *apimodel.MediaUploadRequest) error {
+	minSize := int64(config.GetMediaResumableUploadMinSize())
+	if form.Size < minSize {
+		return fmt.Errorf("resumable uploads must be at least %d bytes; upload smaller media in one go with POST /api/v2/media", minSize)
+	}
+
+	if err := validateMediaSize(form.Size); err != nil {
+		return err
+	}
+
+	return validateMediaDescription(form.Description)
+}
+
+// parseContentRange parses the first and last byte
+// positions from a Content-Range header value of the
+// form `bytes <first>-<last>/<size>`. The size isn't
+// needed, since it's fixed when starting an upload.
+func parseContentRange(value string) (first int64, last int64, err error) {
+	if value == "" {
+		return 0, 0, errors.New("Content-Range header not set")
+	}
+
+	spec, ok := strings.CutPrefix(value, "bytes ")
+	if !ok {
+		return 0, 0, fmt.Errorf("Content-Range %s not in bytes", value)
+	}
+
+	rng, _, ok := strings.Cut(spec, "/")
+	if !ok {
+		return 0, 0, fmt.Errorf("Content-Range %s malformed", value)
+	}
+
+	firstStr, lastStr, ok := strings.Cut(rng, "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("Content-Range %s malformed", value)
+	}
+
+	first, err = strconv.ParseInt(firstStr, 10, 64)
+	if err != nil || first < 0 {
+		return 0, 0, fmt.Errorf("Content-Range %s malformed", value)
+	}
+
+	last, err = strconv.ParseInt(lastStr, 10, 64)
+	if err != nil || last < first {
+		return 0, 0, fmt.Errorf("Content-Range %s malformed", value)
+	}
+
+	return first, last, nil
+}
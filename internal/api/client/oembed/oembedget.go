@@ -0,0 +1,112 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package oembed
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+// OEmbedGETHandler swagger:operation GET /api/oembed oEmbedGet
+//
+// Get an oEmbed response for a public status on this instance, for embedding the status in other sites.
+//
+// The returned HTML embeds the status as a sandboxed iframe of its embeddable web page, `/embed/statuses/{id}`.
+//
+// Only public statuses by local accounts can be embedded.
+//
+// See: https://oembed.com/
+//
+//	---
+//	tags:
+//	- oembed
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: url
+//		type: string
+//		description: Web URL or ActivityPub URI of the status to embed.
+//		in: query
+//		required: true
+//	-
+//		name: maxwidth
+//		type: integer
+//		description: Maximum width in pixels of the embed.
+//		in: query
+//	-
+//		name: maxheight
+//		type: integer
+//		description: Maximum height in pixels of the embed.
+//		in: query
+//	-
+//		name: format
+//		type: string
+//		description: Format of the response. Only `json` is supported.
+//		in: query
+//
+//	responses:
+//		'200':
+//			schema:
+//				"$ref": "#/definitions/oEmbed"
+//		'400':
+//			description: bad request
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+//		'501':
+//			description: requested format not implemented
+func (m *Module) OEmbedGETHandler(c *gin.Context) {
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.OEmbedRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if form.Format != "" && form.Format != "json" {
+		// The oEmbed spec says to
+		// use 501 for unsupported
+		// formats, eg., "xml".
+		const text = "only json format is supported"
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotImplemented(errors.New(text), text), m.processor.InstanceGetV1)
+		return
+	}
+
+	resp, errWithCode := m.processor.Status().OEmbedGet(c.Request.Context(), form)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, resp)
+}
@@ -102,6 +102,10 @@ import (
 //
 //			Arbitrary string queries may include the following operators:
 //			- `from:localuser`, `from:remoteuser@instance.tld`: restrict results to statuses created by the specified account.
+//			- `from:me`: restrict results to statuses created by the requesting account.
+//			- `in:library`, `in:faves`, `in:bookmarks`: restrict results to statuses in the requesting account's library (the default: statuses they created, were replied to or mentioned in, faved, or bookmarked), or only to statuses they faved or bookmarked.
+//
+//			A query consisting only of operators will match all statuses they allow, newest first.
 //		in: query
 //		required: true
 //	-
@@ -70,6 +70,9 @@ const (
 
 	// SourcePath is used for fetching source of a post.
 	SourcePath = BasePathWithID + "/source"
+
+	// RefreshPath is used for re-fetching a remote post from its origin.
+	RefreshPath = BasePathWithID + "/refresh"
 )
 
 type Module struct {
@@ -114,4 +117,7 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	// history/edit stuff
 	attachHandler(http.MethodGet, HistoryPath, m.StatusHistoryGETHandler)
 	attachHandler(http.MethodGet, SourcePath, m.StatusSourceGETHandler)
+
+	// refresh remote status
+	attachHandler(http.MethodPost, RefreshPath, m.StatusRefreshPOSTHandler)
 }
@@ -186,6 +186,15 @@ import (
 //		description: This status can be liked/faved.
 //		in: formData
 //		type: boolean
+//	-
+//		name: indexable
+//		x-go-name: Indexable
+//		description: >-
+//			Search engines may index the web view of this status.
+//			Defaults to the discoverable setting of the account.
+//			Only applies to public statuses.
+//		in: formData
+//		type: boolean
 //
 //	produces:
 //	- application/json
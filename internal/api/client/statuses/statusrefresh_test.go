@@ -0,0 +1,89 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package statuses_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/statuses"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type StatusRefreshTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusRefreshTestSuite) refresh(targetStatusID string) (int, string) {
+	t := suite.testTokens["local_account_1"]
+	oauthToken := oauth.DBTokenToToken(t)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauthToken)
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	path := fmt.Sprintf("http://localhost:8080/api%s", strings.ReplaceAll(statuses.RefreshPath, ":id", targetStatusID))
+	ctx.Request = httptest.NewRequest(http.MethodPost, path, nil)
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   apiutil.IDKey,
+			Value: targetStatusID,
+		},
+	}
+
+	suite.statusModule.StatusRefreshPOSTHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return recorder.Code, string(b)
+}
+
+func (suite *StatusRefreshTestSuite) TestRefreshLocalStatus() {
+	targetStatus := suite.testStatuses["local_account_2_status_1"]
+
+	code, body := suite.refresh(targetStatus.ID)
+	suite.Equal(http.StatusUnprocessableEntity, code)
+	suite.Equal(`{"error":"Unprocessable Entity: target status is local, nothing to refresh"}`, body)
+}
+
+func (suite *StatusRefreshTestSuite) TestRefreshNotFound() {
+	code, body := suite.refresh("01HZZZZZZZZZZZZZZZZZZZZZZZ")
+	suite.Equal(http.StatusNotFound, code)
+	suite.Equal(`{"error":"Not Found: target status not found"}`, body)
+}
+
+func TestStatusRefreshTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusRefreshTestSuite))
+}
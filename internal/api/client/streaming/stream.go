@@ -385,12 +385,20 @@ func (m *Module) writeToWSConn(
 			l.Trace("writing websocket ping")
 
 			// Wrapped context time-out, send a keep-alive "ping".
-			if err := wsConn.WriteControl(websocket.PingMessage, nil, time.Time{}); err != nil {
+			if err := wsConn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeTimeout)); err != nil {
 				l.Debugf("error writing websocket ping: %v", err)
 				break
 			}
 
 		case !ok:
+			if stream.Dropped() {
+				// Client wasn't keeping up with messages,
+				// let it know why we're closing the conn.
+				l.Info("client too slow, dropping websocket connection")
+				closeMsg := websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "client too slow")
+				_ = wsConn.WriteControl(websocket.CloseMessage, closeMsg, time.Now().Add(writeTimeout))
+			}
+
 			// Stream was
 			// closed.
 			return
@@ -398,6 +406,12 @@ func (m *Module) writeToWSConn(
 
 		l.Trace("writing websocket message: %+v", msg)
 
+		// Don't let a slow client hold up the write indefinitely.
+		if err := wsConn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+			l.Debugf("error setting websocket write deadline: %v", err)
+			break
+		}
+
 		// Received a new message from the processor.
 		if err := wsConn.WriteJSON(msg); err != nil {
 			l.Debugf("error writing websocket message: %v", err)
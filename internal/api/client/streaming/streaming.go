@@ -33,6 +33,10 @@ const (
 	StreamTagKey        = "tag"                    // name of tag being requested
 	AccessTokenQueryKey = "access_token"           // oauth access token
 	AccessTokenHeader   = "Sec-Websocket-Protocol" //nolint:gosec
+
+	// writeTimeout is the maximum time to wait for a single
+	// websocket write to complete before giving up on a client.
+	writeTimeout = 30 * time.Second
 )
 
 type Module struct {
@@ -84,6 +84,17 @@ import (
 //		default: 20
 //		in: query
 //		required: false
+//	-
+//		name: fields
+//		type: string
+//		description: >-
+//			Comma-separated list of optional status fields to include in the response,
+//			any of `card`, `poll`, `emojis`. When set, optional fields not in the list are
+//			omitted (`card` and `poll` will be null, `emojis` will be empty). When not set,
+//			all optional fields are included, unless the request has the header
+//			`Prefer: return=minimal`, in which case all of them are omitted.
+//		in: query
+//		required: false
 //
 //	security:
 //	- OAuth2 Bearer:
@@ -135,8 +146,14 @@ func (m *Module) ListTimelineGETHandler(c *gin.Context) {
 		return
 	}
 
+	ctx, errWithCode := apiutil.StatusFieldsContext(c)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
 	resp, errWithCode := m.processor.Timeline().ListTimelineGet(
-		c.Request.Context(),
+		ctx,
 		authed,
 		targetListID,
 		c.Query(apiutil.MaxIDKey),
@@ -86,6 +86,33 @@ import (
 //		default: false
 //		in: query
 //		required: false
+//	-
+//		name: remote
+//		type: boolean
+//		description: >-
+//			Show only statuses posted by remote accounts.
+//			If both local and remote are set, statuses from both are shown.
+//		default: false
+//		in: query
+//		required: false
+//	-
+//		name: only_media
+//		type: boolean
+//		description: Show only statuses with media attachments.
+//		default: false
+//		in: query
+//		required: false
+//	-
+//		name: fields
+//		type: string
+//		description: >-
+//			Comma-separated list of optional status fields to include in the response,
+//			any of `card`, `poll`, `emojis`. When set, optional fields not in the list are
+//			omitted (`card` and `poll` will be null, `emojis` will be empty). When not set,
+//			all optional fields are included, unless the request has the header
+//			`Prefer: return=minimal`, in which case all of them are omitted.
+//		in: query
+//		required: false
 //
 //	security:
 //	- OAuth2 Bearer:
@@ -111,7 +138,7 @@ func (m *Module) PublicTimelineGETHandler(c *gin.Context) {
 	var authed *oauth.Auth
 	var err error
 
-	if config.GetInstanceExposePublicTimeline() {
+	if config.GetInstanceExposePublicTimeline() || config.GetInstanceExposePublicAPI() {
 		// If the public timeline is allowed to be exposed, still check if we
 		// can extract various authentication properties, but don't require them.
 		authed, err = oauth.Authed(c, false, false, false, false)
@@ -148,14 +175,34 @@ func (m *Module) PublicTimelineGETHandler(c *gin.Context) {
 		return
 	}
 
+	remote, errWithCode := apiutil.ParseRemote(c.Query(apiutil.RemoteKey), false)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	onlyMedia, errWithCode := apiutil.ParseOnlyMedia(c.Query(apiutil.OnlyMediaKey), false)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	ctx, errWithCode := apiutil.StatusFieldsContext(c)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
 	resp, errWithCode := m.processor.Timeline().PublicTimelineGet(
-		c.Request.Context(),
+		ctx,
 		authed.Account,
 		c.Query(apiutil.MaxIDKey),
 		c.Query(apiutil.SinceIDKey),
 		c.Query(apiutil.MinIDKey),
 		limit,
 		local,
+		remote,
+		onlyMedia,
 	)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
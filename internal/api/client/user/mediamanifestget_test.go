@@ -0,0 +1,84 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/user"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type MediaManifestGetTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *MediaManifestGetTestSuite) TestMediaManifestGET() {
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Request = httptest.NewRequest(http.MethodGet, "http://localhost:8080/api"+user.MediaManifestPath, nil)
+	ctx.Request.Header.Set("accept", "application/json")
+
+	suite.userModule.MediaManifestGETHandler(ctx)
+
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	manifest := new(apimodel.UserMediaManifest)
+	if err := json.Unmarshal(b, manifest); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Should match media_count from usage endpoint.
+	suite.Equal(5, manifest.FilesCount)
+	suite.Len(manifest.Files, 5)
+
+	urls := make(map[string]string)
+	for _, attachment := range testrig.NewTestAttachments() {
+		urls[attachment.ID] = attachment.URL
+	}
+
+	var size int64
+	for _, file := range manifest.Files {
+		suite.False(file.Missing)
+		suite.Len(file.SHA256, 64)
+		suite.NotZero(file.Size)
+		suite.Equal(urls[file.ID], file.URL)
+		size += file.Size
+	}
+	suite.Equal(size, manifest.FilesSize)
+}
+
+func TestMediaManifestGetTestSuite(t *testing.T) {
+	suite.Run(t, &MediaManifestGetTestSuite{})
+}
@@ -31,6 +31,10 @@ const (
 	PasswordChangePath = BasePath + "/password_change"
 	// EmailChangePath is the path for POSTing an email address change request.
 	EmailChangePath = BasePath + "/email_change"
+	// UsagePath is the path for GETting a summary of user data usage.
+	UsagePath = BasePath + "/usage"
+	// MediaManifestPath is the path for GETting a manifest of user media files.
+	MediaManifestPath = BasePath + "/media_manifest"
 )
 
 type Module struct {
@@ -47,4 +51,6 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodGet, BasePath, m.UserGETHandler)
 	attachHandler(http.MethodPost, PasswordChangePath, m.PasswordChangePOSTHandler)
 	attachHandler(http.MethodPost, EmailChangePath, m.EmailChangePOSTHandler)
+	attachHandler(http.MethodGet, UsagePath, m.UsageGETHandler)
+	attachHandler(http.MethodGet, MediaManifestPath, m.MediaManifestGETHandler)
 }
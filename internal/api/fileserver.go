@@ -39,7 +39,7 @@ func useFSCacheMiddleware(grp *gin.RouterGroup) {
 	// the same URL (since ULIDs are generated per piece of media),
 	// so we can prevent clients having to fetch files repeatedly.
 	//
-	// If we *are* using non-proxying s3, however (ie., not serving
+	// If we *are* using non-proxying object storage, however (ie., not serving
 	// from here) the max age must be set dynamically within the
 	// request handler, based on how long the signed URL has left
 	// to live before it expires. This ensures that clients won't
@@ -50,7 +50,18 @@ func useFSCacheMiddleware(grp *gin.RouterGroup) {
 	//
 	// - https://developer.mozilla.org/en-US/docs/Web/HTTP/Caching#avoiding_revalidation
 	// - https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Cache-Control#immutable
-	servingFromHere := config.GetStorageBackend() == "local" || config.GetStorageS3Proxy()
+	var servingFromHere bool
+	switch config.GetStorageBackend() {
+	case "s3":
+		servingFromHere = config.GetStorageS3Proxy()
+	case "gcs":
+		servingFromHere = config.GetStorageGCSProxy()
+	case "azure":
+		servingFromHere = config.GetStorageAzureProxy()
+	default:
+		servingFromHere = true
+	}
+
 	if !servingFromHere {
 		return
 	}
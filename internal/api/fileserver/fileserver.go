@@ -29,8 +29,12 @@ const (
 	AccountIDKey = "account_id"
 	// MediaTypeKey is the url key for media type (usually something like attachment or header etc)
 	MediaTypeKey = "media_type"
-	// MediaSizeKey is the url key for the desired media size--original/small/static
+	// MediaSizeKey is the url key for the desired media size--original/medium/small/static
 	MediaSizeKey = "media_size"
+	// SizeKey is the query key for overriding the media size in the url path
+	SizeKey = "size"
+	// WidthKey is the query key for the width in pixels an image will be displayed at
+	WidthKey = "width"
 	// FileNameKey is the actual filename being sought. Will usually be a UUID then something like .jpeg
 	FileNameKey = "file_name"
 	// FileServePath is the fileserve path minus the 'fileserver/:account_id/:media_type' prefix.
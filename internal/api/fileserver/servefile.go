@@ -69,6 +69,26 @@ func (m *Module) ServeFile(c *gin.Context) {
 		return
 	}
 
+	// Callers holding one URL for a media
+	// attachment can ask for another size
+	// of it, eg., "?size=medium", or tell
+	// us how wide they'll display it, eg.,
+	// "?width=640", to get the smallest
+	// suitable size of an image.
+	if size := c.Query(SizeKey); size != "" {
+		mediaSize = size
+	}
+
+	var width int
+	if w := c.Query(WidthKey); w != "" {
+		width, err = strconv.Atoi(w)
+		if err != nil || width <= 0 {
+			err := fmt.Errorf("invalid %s in request: %s", WidthKey, w)
+			apiutil.ErrorHandler(c, gtserror.NewErrorNotFound(err), m.processor.InstanceGetV1)
+			return
+		}
+	}
+
 	fileName := c.Param(FileNameKey)
 	if fileName == "" {
 		err := fmt.Errorf("missing %s from request", FileNameKey)
@@ -84,6 +104,7 @@ func (m *Module) ServeFile(c *gin.Context) {
 		MediaType: mediaType,
 		MediaSize: mediaSize,
 		FileName:  fileName,
+		Width:     width,
 	})
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
@@ -93,8 +114,14 @@ func (m *Module) ServeFile(c *gin.Context) {
 	if content.URL != nil {
 		// This is a non-local, non-proxied S3 file we're redirecting to. Derive
 		// the max-age value from how long the link has left until it expires.
+		// Links into a public bucket are the same for everyone, so shared
+		// caches may keep them too.
+		cacheability := "private"
+		if content.URL.Public {
+			cacheability = "public"
+		}
 		maxAge := int(time.Until(content.URL.Expiry).Seconds())
-		c.Header("Cache-Control", "private, max-age="+strconv.Itoa(maxAge)+", immutable")
+		c.Header("Cache-Control", cacheability+", max-age="+strconv.Itoa(maxAge)+", immutable")
 		c.Redirect(http.StatusFound, content.URL.String())
 		return
 	}
@@ -59,14 +59,16 @@ type Account struct {
 	// example: https://example.org/media/some_user/avatar/original/avatar.jpeg
 	Avatar string `json:"avatar"`
 	// Web location of a static version of the account's avatar.
-	// Only relevant when the account's main avatar is a video or a gif.
+	// Never animated, so clients can use this to avoid showing
+	// an animated avatar to users who've disabled animations.
 	// example: https://example.org/media/some_user/avatar/static/avatar.png
 	AvatarStatic string `json:"avatar_static"`
 	// Web location of the account's header image.
 	// example: https://example.org/media/some_user/header/original/header.jpeg
 	Header string `json:"header"`
 	// Web location of a static version of the account's header.
-	// Only relevant when the account's main header is a video or a gif.
+	// Never animated, so clients can use this to avoid showing
+	// an animated header to users who've disabled animations.
 	// example: https://example.org/media/some_user/header/static/header.png
 	HeaderStatic string `json:"header_static"`
 	// Number of accounts following this account, according to our instance.
@@ -98,9 +100,20 @@ type Account struct {
 	// Account has opted to hide their followers/following collections.
 	// Key/value omitted if false.
 	HideCollections bool `json:"hide_collections,omitempty"`
+	// Account has opted to hide their statuses from logged-out viewers.
+	// Key/value omitted if false.
+	HideStatusesLoggedOut bool `json:"hide_statuses_logged_out,omitempty"`
+	// Account has opted out of search engine
+	// indexing of their profile and statuses.
+	// Key/value omitted if false.
+	NoIndex bool `json:"noindex,omitempty"`
 	// Role of the account on this instance.
 	// Key/value omitted for remote accounts.
 	Role *AccountRole `json:"role,omitempty"`
+	// Roles of the account shown as badges on its profile,
+	// eg., a "verified" badge set by an admin of this instance.
+	// Key/value omitted if the account has no such roles.
+	Roles []AccountDisplayRole `json:"roles,omitempty"`
 	// If set, indicates that this account is currently inactive, and has migrated to the given account.
 	// Key/value omitted for accounts that haven't moved, and for suspended accounts.
 	Moved *Account `json:"moved,omitempty"`
@@ -148,6 +161,12 @@ type AccountCreateRequest struct {
 	// example: en
 	// Required: true
 	Locale string `form:"locale" json:"locale" xml:"locale" binding:"required"`
+	// Invite code to sign up with, if any. Depending on instance
+	// configuration, a valid invite code allows signing up while
+	// registration is closed, and skips manual approval.
+	// swagger:parameters
+	// example: Ab3dE6gH
+	InviteCode string `form:"invite_code" json:"invite_code" xml:"invite_code"`
 	// The IP of the sign up request, will not be parsed from the form.
 	// swagger:parameters
 	// swagger:ignore
@@ -170,6 +189,18 @@ type UpdateCredentialsRequest struct {
 	Avatar *multipart.FileHeader `form:"avatar" json:"-"`
 	// Header image encoded using multipart/form-data
 	Header *multipart.FileHeader `form:"header" json:"-"`
+	// Avatar as submitted in JSON. Only an empty
+	// value (ie., remove avatar) is supported here.
+	JSONAvatar *string `form:"-" json:"avatar"`
+	// Header as submitted in JSON. Only an empty
+	// value (ie., remove header) is supported here.
+	JSONHeader *string `form:"-" json:"header"`
+	// Remove the current avatar; set when
+	// avatar is submitted with an empty value.
+	RemoveAvatar bool `form:"-" json:"-"`
+	// Remove the current header; set when
+	// header is submitted with an empty value.
+	RemoveHeader bool `form:"-" json:"-"`
 	// Require manual approval of follow requests.
 	Locked *bool `form:"locked" json:"locked"`
 	// New Source values for this account.
@@ -188,6 +219,10 @@ type UpdateCredentialsRequest struct {
 	EnableRSS *bool `form:"enable_rss" json:"enable_rss"`
 	// Hide this account's following/followers collections.
 	HideCollections *bool `form:"hide_collections" json:"hide_collections"`
+	// Hide this account's statuses from logged-out viewers of the web view and API.
+	HideStatusesLoggedOut *bool `form:"hide_statuses_logged_out" json:"hide_statuses_logged_out"`
+	// Ask search engines not to index this account's profile and statuses.
+	NoIndex *bool `form:"noindex" json:"noindex"`
 }
 
 // UpdateSource is to be used specifically in an UpdateCredentialsRequest.
@@ -202,6 +237,26 @@ type UpdateSource struct {
 	Language *string `form:"language" json:"language"`
 	// Default format for authored statuses (text/plain or text/markdown).
 	StatusContentType *string `form:"status_content_type" json:"status_content_type"`
+	// Spam filter mode override for messages sent to this account (off, log, drop).
+	// Use empty string to unset, and use the instance setting.
+	SpamFilterMode *string `form:"spam_filter_mode" json:"spam_filter_mode"`
+	// How often to send an email digest of missed notifications (daily, weekly).
+	// Use empty string to unset, and stop sending digests.
+	EmailDigest *string `form:"email_digest" json:"email_digest"`
+	// Snooze all notifications (quiet mode) for this many seconds from now.
+	// Use 0 to stop snoozing notifications. Max 30 days.
+	NotificationsSnooze *int `form:"notifications_snooze" json:"notifications_snooze"`
+	// Still push follow and follow request notifications while snoozed.
+	SnoozeAllowFollows *bool `form:"snooze_allow_follows" json:"snooze_allow_follows"`
+	// Still push notifications of mentions in direct messages while snoozed.
+	SnoozeAllowDirect *bool `form:"snooze_allow_direct" json:"snooze_allow_direct"`
+	// Delay automatic acceptance of follows by this many minutes when the
+	// account is unlocked. Use 0 to accept immediately. Max 7 days.
+	FollowAutoAcceptDelay *int `form:"follow_auto_accept_delay" json:"follow_auto_accept_delay"`
+	// Keep public statuses off the local public timeline.
+	HideFromLocalTimeline *bool `form:"hide_from_local_timeline" json:"hide_from_local_timeline"`
+	// Keep public statuses off the federated public timeline.
+	HideFromFederatedTimeline *bool `form:"hide_from_federated_timeline" json:"hide_from_federated_timeline"`
 }
 
 // UpdateField is to be used specifically in an UpdateCredentialsRequest.
@@ -262,6 +317,29 @@ type AccountRole struct {
 	Name AccountRoleName `json:"name"`
 }
 
+// AccountDisplayRole models a role of an account that
+// is shown as a badge on its profile, rather than one
+// granting any privileges.
+//
+// swagger:model accountDisplayRole
+type AccountDisplayRole struct {
+	// ID of the role.
+	// example: verified
+	ID string `json:"id"`
+	// Name of the role, to show on its badge.
+	// example: Verified
+	Name string `json:"name"`
+	// Color of the role's badge, as a hex code. Empty to use the default.
+	Color string `json:"color"`
+}
+
+// AccountDisplayRoleVerified is the display role
+// of accounts marked as verified by an admin.
+var AccountDisplayRoleVerified = AccountDisplayRole{
+	ID:   "verified",
+	Name: "Verified",
+}
+
 // AccountRoleName represent the name of the role of an account.
 //
 // swagger:type string
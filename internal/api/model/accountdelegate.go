@@ -0,0 +1,67 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// AccountDelegate models a delegate of a (shared) local
+// account: another local account whose user may act as it.
+//
+// swagger:model accountDelegate
+type AccountDelegate struct {
+	// The id of the delegation.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	ID string `json:"id"`
+	// When the delegate was invited (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// The shared account the delegate may act as.
+	Account *Account `json:"account"`
+	// The delegate account.
+	Delegate *Account `json:"delegate"`
+	// Delegate may post (and delete posts) as the shared account.
+	CanPost bool `json:"can_post"`
+	// Delegate may read and send direct messages as the shared account.
+	CanManageDMs bool `json:"can_manage_dms"`
+	// Delegate has accepted the invitation, and may act as the shared account.
+	Accepted bool `json:"accepted"`
+}
+
+// AccountDelegateRequest models a request to invite
+// a delegate, or update a delegate's permissions.
+//
+// swagger:ignore
+type AccountDelegateRequest struct {
+	// ID of the local account to invite as a delegate.
+	// Ignored when updating a delegate.
+	AccountID string `form:"account_id" json:"account_id" xml:"account_id"`
+	// Delegate may post (and delete posts) as the shared account.
+	CanPost *bool `form:"can_post" json:"can_post" xml:"can_post"`
+	// Delegate may read and send direct messages as the shared account.
+	CanManageDMs *bool `form:"can_manage_dms" json:"can_manage_dms" xml:"can_manage_dms"`
+}
+
+// DelegatedStatus models a status of a shared
+// account posted by one of its delegates.
+//
+// swagger:model delegatedStatus
+type DelegatedStatus struct {
+	// The status.
+	Status *Status `json:"status"`
+	// The delegate account that posted the status.
+	// Null if the delegate account has since been deleted.
+	PostedBy *Account `json:"posted_by"`
+}
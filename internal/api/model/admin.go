@@ -17,6 +17,8 @@
 
 package model
 
+import "mime/multipart"
+
 // AdminAccountInfo models the admin view of an account's details.
 //
 // swagger:model adminAccountInfo
@@ -170,14 +172,35 @@ type AdminEmoji struct {
 type AdminActionRequest struct {
 	// Category of the target entity.
 	Category string `form:"-" json:"-" xml:"-"`
-	// Type of admin action to take. One of disable, silence, suspend.
+	// Type of admin action to take. One of disable, silence, sensitize, suspend, warn.
 	Type string `form:"type" json:"type" xml:"type"`
 	// Text describing why an action was taken.
 	Text string `form:"text" json:"text" xml:"text"`
+	// Send an email to the target account's user to explain what happened (local accounts only).
+	SendEmail bool `form:"send_email_notification" json:"send_email_notification" xml:"send_email_notification"`
 	// ID of the target entity.
 	TargetID string `form:"-" json:"-" xml:"-"`
 }
 
+// AdminBulkActionRequest models a request
+// to take one admin action on many targets,
+// provided as a CSV file.
+//
+// swagger:ignore
+type AdminBulkActionRequest struct {
+	// CSV file of targets, one per line. The first field
+	// of each line should be either the URI of an account,
+	// or a domain. Any further fields on a line are ignored.
+	File *multipart.FileHeader `form:"file" binding:"required"`
+	// Type of admin action to take. One of suspend, silence, unblock.
+	Type string `form:"type" binding:"required"`
+	// Text describing why the action was taken.
+	Text string `form:"text"`
+	// If true, only check what would be done for
+	// each target, without actually doing it.
+	DryRun bool `form:"dry_run"`
+}
+
 // AdminActionResponse models the server
 // response to an admin action.
 //
@@ -198,6 +221,40 @@ type MediaCleanupRequest struct {
 	RemoteCacheDays *int `form:"remote_cache_days" json:"remote_cache_days" xml:"remote_cache_days"`
 }
 
+// AdminMediaReconcileRequest models admin media reconciliation parameters.
+//
+// swagger:parameters mediaReconcile
+type AdminMediaReconcileRequest struct {
+	// Remove orphaned files, and mark remote media with missing files as
+	// uncached. If false, orphans are only reported.
+	// in: formData
+	Delete bool `form:"delete" json:"delete" xml:"delete"`
+}
+
+// AdminMediaReconciliation models the outcome of reconciling
+// media storage with the database, which may still be running.
+//
+// swagger:model adminMediaReconciliation
+type AdminMediaReconciliation struct {
+	// Time this reconciliation started (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	StartedAt string `json:"started_at"`
+	// Time this reconciliation finished (ISO 8601 Datetime).
+	// Empty while still running.
+	// example: 2021-07-30T09:25:02+00:00
+	FinishedAt string `json:"finished_at,omitempty"`
+	// Whether orphans were removed, rather than only reported.
+	Delete bool `json:"delete"`
+	// Error that stopped this reconciliation early, if any.
+	Error string `json:"error,omitempty"`
+	// Storage paths of files with no corresponding attachment or emoji.
+	OrphanedFiles []string `json:"orphaned_files"`
+	// IDs of attachments whose files are missing from storage.
+	MissingAttachments []string `json:"missing_attachments"`
+	// IDs of emojis whose files are missing from storage.
+	MissingEmojis []string `json:"missing_emojis"`
+}
+
 // AdminSendTestEmailRequest models a test email send request (woah).
 type AdminSendTestEmailRequest struct {
 	// Email address to send the test email to.
@@ -280,3 +337,158 @@ type AdminAccountRejectRequest struct {
 	// them that their sign-up has been rejected.
 	SendEmail bool `form:"send_email" json:"send_email"`
 }
+
+// AdminEmailDomainBlock represents a block on sign-ups
+// from email addresses at a given domain (and its subdomains).
+//
+// swagger:model adminEmailDomainBlock
+type AdminEmailDomainBlock struct {
+	// The ID of the email domain block.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+	// The blocked email domain, in punycode.
+	// example: throwaway.example.org
+	Domain string `json:"domain"`
+	// Time at which the email domain block was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	CreatedAt string `json:"created_at"`
+	// The ID of the admin account that created this email domain block.
+	// example: 01FBW2758ZB6PBR200YPDDJK4C
+	// readonly: true
+	CreatedBy string `json:"created_by"`
+}
+
+// AdminEmailDomainBlockCreateRequest models
+// a request to create an email domain block.
+//
+// swagger:ignore
+type AdminEmailDomainBlockCreateRequest struct {
+	// The email domain to block sign-ups from.
+	Domain string `form:"domain" json:"domain"`
+}
+
+// AdminRedirect represents an admin-defined redirect
+// from a path on this instance to an external URL.
+//
+// swagger:model adminRedirect
+type AdminRedirect struct {
+	// The ID of the redirect.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+	// Path on this instance to redirect from.
+	// example: /donate
+	Path string `json:"path"`
+	// URL to redirect to.
+	// example: https://example.org/donate
+	TargetURL string `json:"target_url"`
+	// Time at which the redirect was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	CreatedAt string `json:"created_at"`
+	// The ID of the admin account that created this redirect.
+	// example: 01FBW2758ZB6PBR200YPDDJK4C
+	// readonly: true
+	CreatedBy string `json:"created_by"`
+}
+
+// AdminRedirectCreateRequest models
+// a request to create a redirect.
+//
+// swagger:ignore
+type AdminRedirectCreateRequest struct {
+	// Path on this instance to redirect from.
+	Path string `form:"path" json:"path"`
+	// URL to redirect to.
+	TargetURL string `form:"target_url" json:"target_url"`
+}
+
+// AdminConversionReportEntry represents one inbound
+// ActivityPub property that was not mapped when
+// converting an object received from a remote instance.
+//
+// swagger:model adminConversionReportEntry
+type AdminConversionReportEntry struct {
+	// Software (and version) of the remote instance, if known.
+	// example: mastodon 4.2.8
+	Software string `json:"software"`
+	// ActivityPub type of the converted object.
+	// example: Note
+	Type string `json:"type"`
+	// Top-level property of the object that was not mapped.
+	// example: quoteUri
+	Property string `json:"property"`
+	// Number of times this property has been seen unmapped.
+	// example: 12
+	Count int `json:"count"`
+	// URI of the last object this property was seen on.
+	// example: https://example.org/users/someone/statuses/01FBW21XJA09XYX51KV5JVBW0F
+	LastURI string `json:"last_uri"`
+}
+
+// AdminInstanceStats models operational statistics about this instance.
+//
+// swagger:model adminInstanceStats
+type AdminInstanceStats struct {
+	// Local users, counted by state.
+	Users AdminInstanceStatsUsers `json:"users"`
+	// Local statuses created per day (UTC) over the last 30 days,
+	// keyed by date in the format YYYY-MM-DD. Days on which no
+	// statuses were created are included with a count of 0.
+	// example: {"2024-06-22": 12, "2024-06-23": 7}
+	StatusesPerDay map[string]int `json:"statuses_per_day"`
+	// Current depth of each worker queue, keyed by queue name.
+	// example: {"client": 0, "delivery": 3, "dereference": 0, "federator": 1, "media": 0}
+	QueueDepths map[string]int `json:"queue_depths"`
+	// Media storage usage.
+	Media AdminInstanceStatsMedia `json:"media"`
+	// Federation peers.
+	Peers AdminInstanceStatsPeers `json:"peers"`
+	// Time at which these stats were aggregated (ISO 8601 Datetime).
+	// Stats other than queue depths may be up to a few minutes old.
+	// example: 2024-06-23T12:00:00.000Z
+	ComputedAt string `json:"computed_at"`
+}
+
+// AdminInstanceStatsUsers models local user counts by state.
+// Each user is counted in only one state.
+//
+// swagger:model adminInstanceStatsUsers
+type AdminInstanceStatsUsers struct {
+	// Users that are approved, confirmed, and not disabled or suspended.
+	Active int `json:"active"`
+	// Users awaiting approval by a moderator.
+	Pending int `json:"pending"`
+	// Approved users who have not yet confirmed their email address.
+	Unconfirmed int `json:"unconfirmed"`
+	// Users that have been disabled.
+	Disabled int `json:"disabled"`
+	// Users whose accounts have been suspended.
+	Suspended int `json:"suspended"`
+}
+
+// AdminInstanceStatsMedia models media storage usage, including thumbnails.
+//
+// swagger:model adminInstanceStatsMedia
+type AdminInstanceStatsMedia struct {
+	// Number of media attachments uploaded by local accounts.
+	LocalCount int `json:"local_count"`
+	// Storage used by local media attachments, in bytes.
+	LocalStorage int64 `json:"local_storage"`
+	// Number of cached media attachments from remote accounts.
+	RemoteCount int `json:"remote_count"`
+	// Storage used by cached remote media attachments, in bytes.
+	RemoteStorage int64 `json:"remote_storage"`
+}
+
+// AdminInstanceStatsPeers models federation peer counts.
+//
+// swagger:model adminInstanceStatsPeers
+type AdminInstanceStatsPeers struct {
+	// Number of other instances known to this instance.
+	Total int `json:"total"`
+	// Number of known instances that are suspended (domain blocked).
+	Suspended int `json:"suspended"`
+}
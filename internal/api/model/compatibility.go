@@ -0,0 +1,51 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// Compatibility describes the client API surface
+// implemented by this instance, so that clients can
+// detect support for features rather than probing.
+//
+// swagger:model compatibility
+type Compatibility struct {
+	// Implemented client API endpoints, keyed by API version (eg., "v1", "v2").
+	Versions map[string][]CompatibilityEndpoint `json:"versions"`
+}
+
+// CompatibilityEndpoint describes an implemented client API endpoint.
+//
+// swagger:model compatibilityEndpoint
+type CompatibilityEndpoint struct {
+	// HTTP method of the endpoint.
+	// example: GET
+	Method string `json:"method"`
+	// Path of the endpoint, with path parameters prefixed by a colon.
+	// example: /api/v1/accounts/:id
+	Path string `json:"path"`
+	// Endpoint is deprecated, and may be removed in future.
+	Deprecated bool `json:"deprecated"`
+	// When the endpoint was deprecated (ISO 8601 Datetime), if deprecated.
+	// example: 2022-11-14T00:00:00.000Z
+	DeprecatedAt string `json:"deprecated_at,omitempty"`
+	// When the endpoint is scheduled to be removed (ISO 8601 Datetime), if scheduled.
+	// example: 2025-01-01T00:00:00.000Z
+	Sunset string `json:"sunset,omitempty"`
+	// Path of the endpoint superseding this one, if any.
+	// example: /api/v2/instance
+	Successor string `json:"successor,omitempty"`
+}
@@ -48,4 +48,8 @@ type GetContentRequestForm struct {
 	MediaSize string
 	// Filename of the content
 	FileName string
+	// Width in pixels the content will be
+	// displayed at, if known, to select a
+	// suitably sized rendition of images.
+	Width int
 }
@@ -26,3 +26,43 @@ type Context struct {
 	// Children in the thread.
 	Descendants []Status `json:"descendants"`
 }
+
+// WebContext models the tree around a given status,
+// with children paged and nested for the web view.
+//
+// swagger:ignore
+type WebContext struct {
+	// Parents in the thread.
+	Ancestors []Status
+	// Children in this page of the thread,
+	// nested under their parents where the
+	// parents are also on this page.
+	Descendants []*WebReply
+	// Total number of (visible)
+	// children in the thread.
+	DescendantsCount int
+	// ID of child after which the previous page
+	// of children starts. Empty for first page.
+	PrevAfter string
+	// Whether there is a previous page of children.
+	HasPrev bool
+	// ID of child after which the next page of
+	// children starts. Empty if no next page.
+	NextAfter string
+}
+
+// WebReply models one child in a WebContext.
+//
+// swagger:ignore
+type WebReply struct {
+	// The child status.
+	Status *Status
+	// Depth of this child below the
+	// thread's target status, from 1.
+	Depth int
+	// Whether replies to this child should
+	// be rendered collapsed by default.
+	Collapsed bool
+	// Replies to this child on this page.
+	Replies []*WebReply
+}
@@ -61,6 +61,10 @@ type DomainPermission struct {
 	// Time at which the permission entry was created (ISO 8601 Datetime).
 	// example: 2021-07-30T09:20:25+00:00
 	CreatedAt string `json:"created_at,omitempty"`
+	// Type of the permission entry (block or allow).
+	// Only set for domain permission drafts.
+	// example: block
+	PermissionType string `json:"permission_type,omitempty"`
 }
 
 // DomainPermissionRequest is the form submitted as a POST to create a new domain permission entry (allow/block).
@@ -87,6 +91,68 @@ type DomainPermissionRequest struct {
 	PublicComment string `form:"public_comment" json:"public_comment" xml:"public_comment"`
 }
 
+// DomainPermissionDraftRequest is the form submitted as a POST to /api/v1/admin/domain_permission_drafts to create a draft domain permission.
+//
+// swagger:ignore
+type DomainPermissionDraftRequest struct {
+	// Type of the draft permission entry (block or allow).
+	// example: block
+	PermissionType string `form:"permission_type" json:"permission_type" xml:"permission_type"`
+	// Domain for which this draft permission should apply.
+	// example: example.org
+	Domain string `form:"domain" json:"domain" xml:"domain"`
+	// Obfuscate the domain name when displaying this permission entry publicly.
+	// example: false
+	Obfuscate bool `form:"obfuscate" json:"obfuscate" xml:"obfuscate"`
+	// Private comment for other admins on why this draft was created.
+	// example: don't like 'em!!!!
+	PrivateComment string `form:"private_comment" json:"private_comment" xml:"private_comment"`
+	// Public comment on why this permission entry was created.
+	// Will only be visible publicly once the draft is accepted.
+	// example: foss dorks 😫
+	PublicComment string `form:"public_comment" json:"public_comment" xml:"public_comment"`
+}
+
+// DomainPause represents a temporary federation pause applied to one domain.
+//
+// swagger:model domainPause
+type DomainPause struct {
+	// The ID of the domain pause entry.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+	// The hostname of the paused domain.
+	// example: example.org
+	Domain string `json:"domain"`
+	// Incoming activities from this domain are also refused while the pause is in place.
+	// example: false
+	PauseIngestion bool `json:"pause_ingestion"`
+	// Private comment for this pause entry, visible to this instance's admins only.
+	// example: down for maintenance until tuesday
+	PrivateComment string `json:"private_comment,omitempty"`
+	// ID of the account that created this domain pause entry.
+	// example: 01FBW2758ZB6PBR200YPDDJK4C
+	CreatedBy string `json:"created_by"`
+	// Time at which the pause entry was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+}
+
+// DomainPauseRequest is the form submitted as a POST to /api/v1/admin/domain_pauses to pause federation with a domain.
+//
+// swagger:ignore
+type DomainPauseRequest struct {
+	// Domain for which federation should be paused.
+	// example: example.org
+	Domain string `form:"domain" json:"domain" xml:"domain"`
+	// Also refuse incoming activities from the domain while paused.
+	// example: false
+	PauseIngestion bool `form:"pause_ingestion" json:"pause_ingestion" xml:"pause_ingestion"`
+	// Private comment for other admins on why this pause was created.
+	// example: down for maintenance until tuesday
+	PrivateComment string `form:"private_comment" json:"private_comment" xml:"private_comment"`
+}
+
 // DomainKeysExpireRequest is the form submitted as a POST to /api/v1/admin/domain_keys_expire to expire a domain's public keys.
 //
 // swagger:parameters domainKeysExpire
@@ -94,3 +160,12 @@ type DomainKeysExpireRequest struct {
 	// hostname/domain to expire keys for.
 	Domain string `form:"domain" json:"domain" xml:"domain"`
 }
+
+// DomainMovesResolveRequest is the form submitted as a POST to /api/v1/admin/domain_moves_resolve
+// to merge local follows of moved accounts on a domain over to their Move targets.
+//
+// swagger:parameters domainMovesResolve
+type DomainMovesResolveRequest struct {
+	// hostname/domain to resolve moved accounts for.
+	Domain string `form:"domain" json:"domain" xml:"domain"`
+}
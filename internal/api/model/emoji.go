@@ -54,6 +54,33 @@ type EmojiCreateRequest struct {
 	CategoryName string `form:"category"`
 }
 
+// EmojiPackImportRequest represents a request to import
+// an emoji pack archive, made through the admin API.
+//
+// swagger:ignore
+type EmojiPackImportRequest struct {
+	// Emoji pack zip archive, containing images and a pack.json manifest.
+	File *multipart.FileHeader `form:"file" validation:"required"`
+	// Category in which to place the imported emojis. Will be uncategorized by default.
+	CategoryName string `form:"category"`
+	// What to do when an emoji shortcode already exists. One of skip (default) or replace.
+	OnConflict string `form:"on_conflict"`
+}
+
+// EmojiPackImportResult summarizes the outcome of an emoji pack import.
+//
+// swagger:model emojiPackImportResult
+type EmojiPackImportResult struct {
+	// Shortcodes of newly created emojis.
+	Imported []string `json:"imported"`
+	// Shortcodes of existing emojis whose image was replaced.
+	Replaced []string `json:"replaced"`
+	// Shortcodes of existing emojis that were left untouched.
+	Skipped []string `json:"skipped"`
+	// Shortcodes of emojis that could not be imported, mapped to the reason why.
+	Failed map[string]string `json:"failed"`
+}
+
 // EmojiUpdateRequest represents a request to update a custom emoji, made through the admin API.
 //
 // swagger:ignore
@@ -25,4 +25,18 @@ type EmojiCategory struct {
 	ID string `json:"id"`
 	// The name of the custom emoji category.
 	Name string `json:"name"`
+	// Position of the category in emoji pickers, lowest first.
+	// Categories with the same position are ordered by name.
+	Position int `json:"position"`
+}
+
+// EmojiCategoryUpdateRequest represents a request to update
+// a custom emoji category, made through the admin API.
+//
+// swagger:ignore
+type EmojiCategoryUpdateRequest struct {
+	// New name for the category. Must be unique.
+	Name *string `form:"name" json:"name" xml:"name"`
+	// New position for the category in emoji pickers, lowest first.
+	Position *int `form:"position" json:"position" xml:"position"`
 }
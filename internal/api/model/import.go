@@ -0,0 +1,87 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import "mime/multipart"
+
+// Import represents one upload of data to be
+// imported into the requesting account, and
+// the progress of processing it in the background.
+//
+// swagger:model import
+type Import struct {
+	// The ID of the import.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	ID string `json:"id"`
+	// Type of data being imported.
+	// example: following
+	Type string `json:"type"`
+	// Time at which this import was uploaded (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// Time at which processing of this import finished (ISO 8601 Datetime).
+	// Null if rows of this import are still being processed.
+	// example: 2021-07-30T09:20:25+00:00
+	FinishedAt *string `json:"finished_at"`
+	// Total number of rows in this import.
+	// example: 10
+	Total int `json:"total"`
+	// Number of rows not yet processed.
+	// example: 2
+	Pending int `json:"pending"`
+	// Number of rows processed successfully.
+	// example: 7
+	Done int `json:"done"`
+	// Number of rows that could not be processed.
+	// example: 1
+	Failed int `json:"failed"`
+	// Status of each row of this import, in the order they
+	// appeared in the uploaded data. Only included when
+	// viewing one import.
+	Rows []ImportRow `json:"rows,omitempty"`
+}
+
+// ImportRow represents the status
+// of processing one row of an Import.
+//
+// swagger:model importRow
+type ImportRow struct {
+	// Line number of this row in the uploaded data, starting at 1.
+	// example: 3
+	Line int `json:"line"`
+	// Target of this row, eg., the address of an account to follow.
+	// example: someone@example.org
+	Target string `json:"target"`
+	// Processing status of this row: pending, done, or failed.
+	// example: failed
+	Status string `json:"status"`
+	// Reason why processing this row failed, if it did.
+	// example: account could not be found
+	Error string `json:"error,omitempty"`
+}
+
+// ImportRequest is the form submitted as a POST
+// to /api/v1/imports to upload data to be imported.
+//
+// swagger:ignore
+type ImportRequest struct {
+	// The CSV file to import.
+	Data *multipart.FileHeader `form:"data" binding:"required"`
+	// Type of data being imported.
+	Type string `form:"type" binding:"required"`
+}
@@ -35,12 +35,20 @@ type InstanceSettingsUpdateRequest struct {
 	Description *string `form:"description" json:"description" xml:"description"`
 	// Terms and conditions of the instance, max 5,000 chars. HTML formatting accepted.
 	Terms *string `form:"terms" json:"terms" xml:"terms"`
+	// Privacy policy of the instance, max 5,000 chars. Markdown formatting accepted.
+	PrivacyPolicy *string `form:"privacy_policy" json:"privacy_policy" xml:"privacy_policy"`
 	// Image to use as the instance thumbnail.
 	Avatar *multipart.FileHeader `form:"thumbnail" json:"thumbnail" xml:"thumbnail"`
 	// Image description for the instance avatar.
 	AvatarDescription *string `form:"thumbnail_description" json:"thumbnail_description" xml:"thumbnail_description"`
 	// Image to use as the instance header.
 	Header *multipart.FileHeader `form:"header" json:"header" xml:"header"`
+	// Comma-separated, ordered list of blocks to show on the landing page.
+	// Empty string resets to the default landing page layout.
+	LandingPageBlocks *string `form:"landing_page_blocks" json:"landing_page_blocks" xml:"landing_page_blocks"`
+	// Comma-separated, ordered list of usernames of local accounts to feature on the landing page.
+	// Empty string unsets featured accounts.
+	FeaturedAccounts *string `form:"featured_accounts" json:"featured_accounts" xml:"featured_accounts"`
 }
 
 // InstanceConfigurationAccounts models instance account config parameters.
@@ -115,6 +123,16 @@ type InstanceConfigurationMediaAttachments struct {
 	//
 	// example: 16777216
 	VideoMatrixLimit int `json:"video_matrix_limit"`
+	// Min size in bytes of media that may be uploaded in parts
+	// with the resumable upload API (`POST /api/v2/media/uploads`).
+	//
+	// example: 5242880
+	ResumableUploadMinSize int `json:"resumable_upload_min_size"`
+	// Min size in bytes of each part of a resumable upload,
+	// except for the last part.
+	//
+	// example: 1048576
+	ResumableUploadMinPartSize int `json:"resumable_upload_min_part_size"`
 }
 
 // InstanceConfigurationPolls models instance poll config parameters.
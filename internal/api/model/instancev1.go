@@ -102,6 +102,15 @@ type InstanceV1 struct {
 	Terms string `json:"terms,omitempty"`
 	// Raw (unparsed) version of terms.
 	TermsRaw string `json:"terms_text,omitempty"`
+	// Privacy policy for accounts on this instance.
+	PrivacyPolicy string `json:"privacy_policy,omitempty"`
+	// Raw (unparsed) version of privacy policy.
+	PrivacyPolicyRaw string `json:"privacy_policy_text,omitempty"`
+	// Ordered list of blocks shown on the instance landing page.
+	// example: ["short_description","featured_accounts","register"]
+	LandingPageBlocks []string `json:"landing_page_blocks"`
+	// Local accounts featured on the instance landing page.
+	FeaturedAccounts []*Account `json:"featured_accounts,omitempty"`
 }
 
 // InstanceV1URLs models instance-relevant URLs for client application consumption.
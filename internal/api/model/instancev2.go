@@ -72,6 +72,10 @@ type InstanceV2 struct {
 	Terms string `json:"terms,omitempty"`
 	// Raw (unparsed) version of terms.
 	TermsText string `json:"terms_text,omitempty"`
+	// Privacy policy for accounts on this instance.
+	PrivacyPolicy string `json:"privacy_policy,omitempty"`
+	// Raw (unparsed) version of privacy policy.
+	PrivacyPolicyText string `json:"privacy_policy_text,omitempty"`
 }
 
 // Usage data for this instance.
@@ -0,0 +1,68 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// Invite represents an invite code which can
+// be used to sign up for an account on this instance.
+//
+// swagger:model invite
+type Invite struct {
+	// The ID of the invite.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	ID string `json:"id"`
+	// The code to give at sign-up to use this invite.
+	// example: Ab3dE6gH
+	Code string `json:"code"`
+	// Link to the sign-up page with this invite code filled in.
+	// example: https://example.org/signup?invite_code=Ab3dE6gH
+	URL string `json:"url"`
+	// Maximum number of times this invite can be used.
+	// Null if the invite can be used an unlimited number of times.
+	// example: 5
+	MaxUses *int `json:"max_uses"`
+	// Number of times this invite has been used so far.
+	// example: 1
+	Uses int `json:"uses"`
+	// Time at which this invite expires (ISO 8601 Datetime).
+	// Null if the invite does not expire.
+	// example: 2021-07-30T09:20:25+00:00
+	ExpiresAt *string `json:"expires_at"`
+	// Time at which this invite was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// Whether this invite can still be used to sign up,
+	// ie., it's not expired, used up, or revoked.
+	// example: true
+	Usable bool `json:"usable"`
+	// The account that created this invite.
+	// Only included in admin views of invites.
+	Account *Account `json:"account,omitempty"`
+}
+
+// InviteCreateRequest is the form submitted as a POST
+// to /api/v1/invites to create a new invite code.
+//
+// swagger:ignore
+type InviteCreateRequest struct {
+	// Maximum number of times the invite can be used.
+	// 0 or not set means unlimited uses.
+	MaxUses int `form:"max_uses" json:"max_uses" xml:"max_uses"`
+	// Number of seconds from now after which the
+	// invite expires. 0 or not set means no expiry.
+	ExpiresIn int `form:"expires_in" json:"expires_in" xml:"expires_in"`
+}
@@ -0,0 +1,56 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// ModerationNote represents a private note left by an
+// admin or moderator on an account or a report. Notes are
+// only visible to admins and moderators of this instance.
+//
+// swagger:model moderationNote
+type ModerationNote struct {
+	// The ID of the note.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	ID string `json:"id"`
+	// Time at which this note was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// The admin or moderator account that wrote this note.
+	Author *Account `json:"author"`
+	// ID of the account this note is about, if any.
+	// example: 01GQ4PHNT622DQ9X95XQX4KKNR
+	AccountID string `json:"account_id,omitempty"`
+	// ID of the report this note is about, if any.
+	// example: 01GP3AWY4CRDVRNZKW0TEAMB5R
+	ReportID string `json:"report_id,omitempty"`
+	// Plaintext content of the note.
+	// example: Spoke to this user about their posting frequency; they agreed to tone it down.
+	Content string `json:"content"`
+}
+
+// ModerationNoteCreateRequest is the form submitted as a POST
+// to /api/v1/admin/moderation_notes to create a new note.
+//
+// swagger:ignore
+type ModerationNoteCreateRequest struct {
+	// ID of the account to leave the note on.
+	AccountID string `form:"account_id" json:"account_id" xml:"account_id"`
+	// ID of the report to leave the note on.
+	ReportID string `form:"report_id" json:"report_id" xml:"report_id"`
+	// Plaintext content of the note.
+	Content string `form:"content" json:"content" xml:"content"`
+}
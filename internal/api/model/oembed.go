@@ -0,0 +1,78 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// OEmbed represents an oEmbed response for a status,
+// which other sites can use to embed the status.
+//
+// See: https://oembed.com/#section2.3
+//
+// swagger:model oEmbed
+type OEmbed struct {
+	// Type of the oEmbed resource. Always "rich".
+	// example: rich
+	Type string `json:"type"`
+	// Version of the oEmbed spec used. Always "1.0".
+	// example: 1.0
+	Version string `json:"version"`
+	// Title of the embedded status.
+	// example: Post by @the_mighty_zork@example.org
+	Title string `json:"title"`
+	// Display name of the author of the status.
+	// example: big jeff
+	AuthorName string `json:"author_name"`
+	// Web URL of the profile of the author of the status.
+	// example: https://example.org/@the_mighty_zork
+	AuthorURL string `json:"author_url"`
+	// Title of this instance.
+	// example: GoToSocial Example Instance
+	ProviderName string `json:"provider_name"`
+	// URL of this instance.
+	// example: https://example.org
+	ProviderURL string `json:"provider_url"`
+	// Suggested number of seconds
+	// to cache this response for.
+	// example: 86400
+	CacheAge int `json:"cache_age"`
+	// HTML for embedding the status, as a sandboxed iframe.
+	HTML string `json:"html"`
+	// Width in pixels of the embedded iframe.
+	// example: 400
+	Width int `json:"width"`
+	// Height in pixels of the embedded iframe.
+	// example: 400
+	Height int `json:"height"`
+}
+
+// OEmbedRequest models an oEmbed request.
+//
+// swagger:ignore
+type OEmbedRequest struct {
+	// URL of the status to embed.
+	URL string `form:"url"`
+	// Maximum width in pixels of
+	// the embed. 0 for no maximum.
+	MaxWidth int `form:"maxwidth"`
+	// Maximum height in pixels of
+	// the embed. 0 for no maximum.
+	MaxHeight int `form:"maxheight"`
+	// Requested format of the
+	// response. Only "json" is
+	// supported. Can be empty.
+	Format string `form:"format"`
+}
@@ -0,0 +1,60 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// SeveredRelationship represents a follow between the requesting
+// account and a remote account that was removed because of a
+// domain block put in place by this instance's admins.
+//
+// swagger:model severedRelationship
+type SeveredRelationship struct {
+	// The ID of the severed relationship record.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	ID string `json:"id"`
+	// The domain that was blocked, causing the relationship to be severed.
+	// example: example.org
+	Domain string `json:"domain"`
+	// The remote account that was part of the relationship.
+	Account *Account `json:"account"`
+	// Direction of the severed follow: `following` if the requesting account
+	// followed the remote account, `followed_by` if the remote account
+	// followed the requesting account.
+	// example: following
+	Direction string `json:"direction"`
+	// Time at which the relationship was severed (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// Time at which the relationship was restored (ISO 8601 Datetime), if it has been.
+	// example: 2021-07-30T09:20:25+00:00
+	RestoredAt *string `json:"restored_at"`
+	// Whether this relationship can still be restored by the requesting account.
+	// Only follows *from* the requesting account can be restored.
+	// example: true
+	Restorable bool `json:"restorable"`
+}
+
+// SeveredRelationshipsRestoreRequest is the form submitted as a POST to
+// /api/v1/severed_relationships/restore to restore follows severed by a
+// domain block that has since been removed.
+//
+// swagger:ignore
+type SeveredRelationshipsRestoreRequest struct {
+	// Domain for which severed follows should be restored.
+	// example: example.org
+	Domain string `form:"domain" json:"domain" xml:"domain"`
+}
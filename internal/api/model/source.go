@@ -43,4 +43,36 @@ type Source struct {
 	//
 	// Omitted from json if empty / not set.
 	AlsoKnownAsURIs []string `json:"also_known_as_uris,omitempty"`
+	// Spam filter mode override for messages sent to this
+	// account from other instances: off, log, or drop.
+	//
+	// Omitted from json if empty / not set (instance setting is used).
+	SpamFilterMode string `json:"spam_filter_mode,omitempty"`
+	// How often this account is sent an email digest
+	// of missed notifications: daily, or weekly.
+	//
+	// Omitted from json if empty / not set (no digests are sent).
+	EmailDigest string `json:"email_digest,omitempty"`
+	// Time until which notifications to this account are
+	// snoozed (quiet mode), in ISO 8601 Datetime format.
+	//
+	// Omitted from json if empty / not set (not snoozed).
+	NotificationsSnoozedUntil string `json:"notifications_snoozed_until,omitempty"`
+	// Follow and follow request notifications are
+	// still pushed while notifications are snoozed.
+	SnoozeAllowFollows bool `json:"snooze_allow_follows"`
+	// Notifications of mentions in direct messages
+	// are still pushed while notifications are snoozed.
+	SnoozeAllowDirect bool `json:"snooze_allow_direct"`
+	// Minutes to wait before automatically accepting
+	// follows while this account is unlocked, during
+	// which follows can still be manually rejected.
+	// 0 means follows are accepted immediately.
+	FollowAutoAcceptDelay int `json:"follow_auto_accept_delay"`
+	// Public statuses by this account are kept
+	// off this instance's local public timeline.
+	HideFromLocalTimeline bool `json:"hide_from_local_timeline"`
+	// Public statuses by this account are kept
+	// off this instance's federated public timeline.
+	HideFromFederatedTimeline bool `json:"hide_from_federated_timeline"`
 }
@@ -124,6 +124,12 @@ type Status struct {
 	//
 	// swagger:ignore
 	Local bool `json:"-"`
+
+	// Author allows search engines
+	// to index this status' web view.
+	//
+	// swagger:ignore
+	Indexable bool `json:"-"`
 }
 
 /*
@@ -236,6 +242,9 @@ type AdvancedVisibilityFlagsForm struct {
 	Replyable *bool `form:"replyable" json:"replyable" xml:"replyable"`
 	// This status can be liked/faved.
 	Likeable *bool `form:"likeable" json:"likeable" xml:"likeable"`
+	// Search engines may index the web view of this status.
+	// Defaults to the discoverable setting of the account.
+	Indexable *bool `form:"indexable" json:"indexable" xml:"indexable"`
 }
 
 // StatusContentType is the content type with which to parse the submitted status.
@@ -252,6 +261,26 @@ const (
 	StatusContentTypeDefault                    = StatusContentTypePlain
 )
 
+// StatusOptionalField is the name of an optional, comparatively
+// expensive to serialize sub-object of a status. Lightweight clients
+// can ask for these to be left out of responses from some endpoints.
+type StatusOptionalField string
+
+// Optional status fields. Omitted cards and polls
+// serialize as null, omitted emojis as an empty array.
+const (
+	StatusFieldCard   StatusOptionalField = "card"
+	StatusFieldPoll   StatusOptionalField = "poll"
+	StatusFieldEmojis StatusOptionalField = "emojis"
+)
+
+// StatusOptionalFields contains all optional status fields.
+var StatusOptionalFields = []StatusOptionalField{
+	StatusFieldCard,
+	StatusFieldPoll,
+	StatusFieldEmojis,
+}
+
 // StatusSource represents the source text of a
 // status as submitted to the API when it was created.
 //
@@ -95,3 +95,113 @@ type EmailChangeRequest struct {
 	// required: true
 	NewEmail string `form:"new_email" json:"new_email" xml:"new_email" validation:"required"`
 }
+
+// UserUsage models a summary of the data
+// stored on this instance for one user.
+//
+// swagger:model userUsage
+type UserUsage struct {
+	// Number of media attachments uploaded by this user.
+	// example: 12
+	MediaCount int `json:"media_count"`
+	// Storage used by media attachments uploaded by this user, in bytes, including thumbnails.
+	// example: 5242880
+	MediaStorage int64 `json:"media_storage"`
+	// Statuses created by this user, counted by visibility.
+	Statuses UserUsageStatuses `json:"statuses"`
+	// Number of accounts following this user.
+	// example: 42
+	FollowersCount int `json:"followers_count"`
+	// Number of accounts followed by this user.
+	// example: 69
+	FollowingCount int `json:"following_count"`
+	// Number of pending follow requests targeting this user.
+	// example: 1
+	FollowRequestsCount int `json:"follow_requests_count"`
+	// Number of entries of each type that this
+	// user can export via the client API.
+	Exports UserUsageExports `json:"exports"`
+}
+
+// UserUsageStatuses models status counts by visibility.
+//
+// swagger:model userUsageStatuses
+type UserUsageStatuses struct {
+	// Total number of statuses, including boosts.
+	Total int `json:"total"`
+	// Number of public statuses.
+	Public int `json:"public"`
+	// Number of unlisted statuses.
+	Unlisted int `json:"unlisted"`
+	// Number of followers-only statuses.
+	Private int `json:"private"`
+	// Number of mutuals-only statuses.
+	MutualsOnly int `json:"mutuals_only"`
+	// Number of direct statuses.
+	Direct int `json:"direct"`
+}
+
+// UserUsageExports models the number of entries
+// of each type that a user can export.
+//
+// swagger:model userUsageExports
+type UserUsageExports struct {
+	// Number of followed accounts, exportable via /api/v1/accounts/{id}/following.
+	Following int `json:"following"`
+	// Number of lists, exportable via /api/v1/lists.
+	Lists int `json:"lists"`
+	// Number of blocked accounts, exportable via /api/v1/blocks.
+	Blocks int `json:"blocks"`
+	// Number of muted accounts, exportable via /api/v1/mutes.
+	Mutes int `json:"mutes"`
+}
+
+// UserMediaManifest lists the media files stored on this instance
+// for one user, along with their checksums, so that downloaded or
+// exported copies of the files can be verified for completeness.
+//
+// swagger:model userMediaManifest
+type UserMediaManifest struct {
+	// When the manifest was generated (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	GeneratedAt string `json:"generated_at"`
+	// Number of files listed in the manifest.
+	// example: 12
+	FilesCount int `json:"files_count"`
+	// Combined size of all listed files, in bytes.
+	// example: 5242880
+	FilesSize int64 `json:"files_size"`
+	// The listed media files.
+	Files []UserMediaManifestFile `json:"files"`
+}
+
+// UserMediaManifestFile models one
+// media file in a UserMediaManifest.
+//
+// swagger:model userMediaManifestFile
+type UserMediaManifestFile struct {
+	// ID of the media attachment.
+	// example: 01FC31DZT1AYWDZ8XTCRWRBYRK
+	ID string `json:"id"`
+	// ID of the status the media is attached to, if any.
+	// example: 01FVW7JHQFSFK166WWKR8CBA6M
+	StatusID string `json:"status_id,omitempty"`
+	// URL at which the original media file can be downloaded.
+	// example: https://example.org/fileserver/01FC31DZT1AYWDZ8XTCRWRBYRK/attachment/original/01FC31DZT1AYWDZ8XTCRWRBYRK.jpg
+	URL string `json:"url"`
+	// Suggested file name for a downloaded copy of the file.
+	// example: 01FC31DZT1AYWDZ8XTCRWRBYRK.jpg
+	Filename string `json:"filename"`
+	// MIME content type of the file.
+	// example: image/jpeg
+	ContentType string `json:"content_type"`
+	// Size of the file in bytes.
+	// example: 62529
+	Size int64 `json:"size"`
+	// Hex-encoded SHA-256 checksum of the file.
+	// Empty if the file is missing from storage.
+	// example: 9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08
+	SHA256 string `json:"sha256"`
+	// The file is known to the database but missing from storage.
+	Missing bool `json:"missing,omitempty"`
+}
@@ -0,0 +1,103 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package util
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Deprecation describes the deprecation
+// of a client API endpoint, see Deprecations.
+type Deprecation struct {
+	// Since is when the endpoint was deprecated,
+	// usually following its deprecation in Mastodon.
+	Since time.Time
+
+	// Sunset is when the endpoint is scheduled to
+	// be removed, or zero if no removal is scheduled.
+	Sunset time.Time
+
+	// Successor is the path of the endpoint
+	// that supersedes this one, if any.
+	Successor string
+}
+
+// Deprecations are the deprecated client API endpoints,
+// keyed by method and route relative to the 'api' prefix,
+// with any API version parameter filled in, eg., "GET /v1/instance".
+//
+// Deprecated endpoints are served with Deprecation, Sunset and
+// successor-version Link headers, and reported as deprecated by
+// the compatibility endpoint. To deprecate an endpoint, add it here.
+var Deprecations = map[string]Deprecation{
+	// Deprecated in Mastodon 3.0.0.
+	http.MethodGet + " /v1/search": {
+		Since:     date(2019, time.October, 3),
+		Successor: "/api/v2/search",
+	},
+
+	// Deprecated in Mastodon 3.1.3.
+	http.MethodPost + " /v1/media": {
+		Since:     date(2020, time.April, 5),
+		Successor: "/api/v2/media",
+	},
+
+	// Deprecated in Mastodon 4.0.0.
+	http.MethodGet + " /v1/instance": {
+		Since:     date(2022, time.November, 14),
+		Successor: "/api/v2/instance",
+	},
+	http.MethodGet + " /v1/filters": {
+		Since:     date(2022, time.November, 14),
+		Successor: "/api/v2/filters",
+	},
+	http.MethodPost + " /v1/filters": {
+		Since:     date(2022, time.November, 14),
+		Successor: "/api/v2/filters",
+	},
+	http.MethodGet + " /v1/filters/:id": {
+		Since:     date(2022, time.November, 14),
+		Successor: "/api/v2/filters/:id",
+	},
+	http.MethodPut + " /v1/filters/:id": {
+		Since:     date(2022, time.November, 14),
+		Successor: "/api/v2/filters/:id",
+	},
+	http.MethodDelete + " /v1/filters/:id": {
+		Since:     date(2022, time.November, 14),
+		Successor: "/api/v2/filters/:id",
+	},
+}
+
+// GetDeprecation returns the deprecation of the
+// endpoint with given method and route (relative
+// to the 'api' prefix), with given API version
+// filled in for the API version parameter, if any.
+func GetDeprecation(method string, route string, version string) (Deprecation, bool) {
+	if version != "" {
+		route = strings.Replace(route, ":"+APIVersionKey, version, 1)
+	}
+	d, ok := Deprecations[method+" "+route]
+	return d, ok
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
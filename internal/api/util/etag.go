@@ -0,0 +1,118 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package util
+
+import (
+	// nolint:gosec
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// JSONWithETag is like JSON(), but additionally sets a strong ETag
+// header generated from the encoded response body, and responds
+// 304 Not Modified without a body if the request's If-None-Match
+// header matches it. Useful for endpoints that clients poll often.
+//
+// As client API responses are otherwise set to never be stored,
+// this also sets a Cache-Control header allowing clients to store
+// the response, as long as they revalidate it before each reuse.
+func JSONWithETag(c *gin.Context, code int, data any) {
+	// Acquire buffer.
+	buf := getBuf()
+	defer putBuf(buf)
+
+	// Wrap buffer in JSON encoder.
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+
+	// Encode JSON data into byte buffer.
+	if err := enc.Encode(data); err != nil {
+		// This will always be a JSON error, we
+		// can't really add any more useful context.
+		log.Error(c.Request.Context(), err)
+
+		// Any error returned here is unrecoverable,
+		// set Internal Server Error JSON response.
+		Data(c,
+			http.StatusInternalServerError,
+			AppJSON,
+			StatusInternalServerErrorJSON,
+		)
+		return
+	}
+
+	// Drop new-line added by encoder.
+	if buf.B[len(buf.B)-1] == '\n' {
+		buf.B = buf.B[:len(buf.B)-1]
+	}
+
+	// Generate strong ETag
+	// from response bytes.
+	eTag := generateETag(buf.B)
+
+	c.Header("ETag", eTag)
+	c.Header("Cache-Control", "private, no-cache")
+
+	if code == http.StatusOK &&
+		eTagMatches(c.GetHeader("If-None-Match"), eTag) {
+		// Client already has latest
+		// version, no need to send it.
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	Data(c, code, AppJSON, buf.B)
+}
+
+// generateETag returns a quoted
+// strong ETag for the given bytes.
+func generateETag(b []byte) string {
+	// nolint:gosec
+	sum := sha1.Sum(b)
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
+
+// eTagMatches returns whether the given If-None-Match header
+// value matches the given ETag, using the weak comparison
+// required for If-None-Match as per RFC 9110 section 13.1.2.
+func eTagMatches(ifNoneMatch string, eTag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+
+	if strings.TrimSpace(ifNoneMatch) == "*" {
+		return true
+	}
+
+	eTag = strings.TrimPrefix(eTag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		candidate = strings.TrimPrefix(candidate, "W/")
+		if candidate == eTag {
+			return true
+		}
+	}
+
+	return false
+}
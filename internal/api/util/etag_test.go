@@ -0,0 +1,95 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package util
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestETagMatches(t *testing.T) {
+	const eTag = `"abc123"`
+
+	tests := []struct {
+		ifNoneMatch string
+		match       bool
+	}{
+		{ifNoneMatch: "", match: false},
+		{ifNoneMatch: `"abc123"`, match: true},
+		{ifNoneMatch: `W/"abc123"`, match: true},
+		{ifNoneMatch: `"def456", "abc123"`, match: true},
+		{ifNoneMatch: `"def456"`, match: false},
+		{ifNoneMatch: `abc123`, match: false},
+		{ifNoneMatch: `*`, match: true},
+	}
+
+	for _, tt := range tests {
+		if match := eTagMatches(tt.ifNoneMatch, eTag); match != tt.match {
+			t.Errorf("If-None-Match %q: expected match %v, got %v", tt.ifNoneMatch, tt.match, match)
+		}
+	}
+}
+
+func TestJSONWithETag(t *testing.T) {
+	data := map[string]string{"hello": "world"}
+
+	// First request without If-None-Match.
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	JSONWithETag(c, http.StatusOK, data)
+	c.Writer.WriteHeaderNow()
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+
+	eTag := rec.Header().Get("ETag")
+	if eTag == "" {
+		t.Fatal("expected ETag header to be set")
+	}
+
+	if body := rec.Body.String(); body != `{"hello":"world"}` {
+		t.Fatalf("unexpected body %s", body)
+	}
+
+	// Second request with matching If-None-Match.
+	rec = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	c.Request.Header.Set("If-None-Match", eTag)
+
+	JSONWithETag(c, http.StatusOK, data)
+	c.Writer.WriteHeaderNow()
+
+	if rec.Code != http.StatusNotModified {
+		t.Fatalf("expected status 304, got %d", rec.Code)
+	}
+
+	if rec.Header().Get("ETag") != eTag {
+		t.Fatal("expected same ETag header on 304 response")
+	}
+
+	if rec.Body.Len() != 0 {
+		t.Fatalf("expected empty body, got %s", rec.Body.String())
+	}
+}
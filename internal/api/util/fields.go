@@ -0,0 +1,113 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+// StatusFieldsContext parses the optional `fields` query parameter
+// and `Prefer` header of the given request, and returns the request
+// context wrapped with the optional status fields to omit from the
+// response, if any. See ParseOmitStatusFields() for details.
+func StatusFieldsContext(c *gin.Context) (context.Context, gtserror.WithCode) {
+	fields, set := c.GetQuery(FieldsKey)
+	omit, errWithCode := ParseOmitStatusFields(fields, set, c.GetHeader("Prefer"))
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	ctx := c.Request.Context()
+	if len(omit) == 0 {
+		return ctx, nil
+	}
+
+	return gtscontext.SetOmitFields(ctx, omit), nil
+}
+
+// ParseOmitStatusFields returns the names of the optional status
+// fields to omit from a response, given the value of the `fields`
+// query parameter (and whether it was set), and the `Prefer` header.
+//
+// When `fields` is set, it's treated as a comma-separated list of the
+// optional status fields to *include*, and all others will be omitted.
+// An empty `fields` value therefore omits all optional status fields.
+//
+// When `fields` is not set, a `Prefer: return=minimal` header (RFC 7240)
+// omits all optional status fields. Otherwise nothing is omitted.
+func ParseOmitStatusFields(fields string, set bool, prefer string) ([]string, gtserror.WithCode) {
+	if !set {
+		if !preferMinimal(prefer) {
+			// Include everything.
+			return nil, nil
+		}
+
+		// Include nothing optional.
+		fields = ""
+	}
+
+	var include []string
+	for _, field := range strings.Split(fields, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		if !slices.Contains(
+			apimodel.StatusOptionalFields,
+			apimodel.StatusOptionalField(field),
+		) {
+			const text = "unrecognized field %s: must be one of card, poll, emojis"
+			err := fmt.Errorf(text, field)
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		include = append(include, field)
+	}
+
+	omit := make([]string, 0, len(apimodel.StatusOptionalFields))
+	for _, field := range apimodel.StatusOptionalFields {
+		if !slices.Contains(include, string(field)) {
+			omit = append(omit, string(field))
+		}
+	}
+
+	return omit, nil
+}
+
+// preferMinimal returns whether the given
+// Prefer header value contains return=minimal.
+func preferMinimal(prefer string) bool {
+	for _, pref := range strings.Split(prefer, ",") {
+		// Strip any preference parameters.
+		pref, _, _ = strings.Cut(pref, ";")
+		pref = strings.ReplaceAll(pref, " ", "")
+		if strings.EqualFold(pref, "return=minimal") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package util
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseOmitStatusFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields string
+		set    bool
+		prefer string
+		omit   []string
+		err    bool
+	}{
+		{name: "nothing set", omit: nil},
+		{name: "prefer representation", prefer: "return=representation", omit: nil},
+		{name: "prefer minimal", prefer: "return=minimal", omit: []string{"card", "poll", "emojis"}},
+		{name: "prefer minimal with others", prefer: "wait=10, return=minimal", omit: []string{"card", "poll", "emojis"}},
+		{name: "empty fields", set: true, omit: []string{"card", "poll", "emojis"}},
+		{name: "include poll", fields: "poll", set: true, omit: []string{"card", "emojis"}},
+		{name: "include poll and emojis", fields: "poll, emojis", set: true, omit: []string{"card"}},
+		{name: "include all", fields: "card,poll,emojis", set: true, omit: []string{}},
+		{name: "fields override prefer", fields: "emojis", set: true, prefer: "return=minimal", omit: []string{"card", "poll"}},
+		{name: "unknown field", fields: "poll,reactions", set: true, err: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			omit, errWithCode := ParseOmitStatusFields(tt.fields, tt.set, tt.prefer)
+			if tt.err {
+				if errWithCode == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+
+			if errWithCode != nil {
+				t.Fatalf("unexpected error: %v", errWithCode)
+			}
+
+			if !slices.Equal(tt.omit, omit) {
+				t.Fatalf("expected omit %v, got %v", tt.omit, omit)
+			}
+		})
+	}
+}
@@ -26,10 +26,12 @@ const (
 	appXMLText        = `text/xml` // AppXML is only *recommended* in RFC7303
 	AppXMLXRD         = `application/xrd+xml`
 	AppRSSXML         = `application/rss+xml`
+	AppAtomXML        = `application/atom+xml`
 	AppActivityJSON   = `application/activity+json`
 	appActivityLDJSON = `application/ld+json` // without profile
 	AppActivityLDJSON = appActivityLDJSON + `; profile="https://www.w3.org/ns/activitystreams"`
 	AppJRDJSON        = `application/jrd+json` // https://www.rfc-editor.org/rfc/rfc7033#section-10.2
+	AppZip            = `application/zip`
 	AppForm           = `application/x-www-form-urlencoded`
 	MultipartForm     = `multipart/form-data`
 	TextXML           = `text/xml`
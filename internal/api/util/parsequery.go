@@ -34,13 +34,16 @@ const (
 
 	/* Common keys */
 
-	IDKey       = "id"
-	LimitKey    = "limit"
-	LocalKey    = "local"
-	MaxIDKey    = "max_id"
-	SinceIDKey  = "since_id"
-	MinIDKey    = "min_id"
-	UsernameKey = "username"
+	IDKey        = "id"
+	LimitKey     = "limit"
+	LocalKey     = "local"
+	RemoteKey    = "remote"
+	OnlyMediaKey = "only_media"
+	MaxIDKey     = "max_id"
+	SinceIDKey   = "since_id"
+	MinIDKey     = "min_id"
+	UsernameKey  = "username"
+	FieldsKey    = "fields"
 
 	/* AP endpoint keys */
 
@@ -63,7 +66,10 @@ const (
 
 	/* Web endpoint keys */
 
-	WebStatusIDKey = "status"
+	WebStatusIDKey       = "status"
+	WebRepliesAfterKey   = "after"
+	WebExcludeRepliesKey = "exclude_replies"
+	WebTagKey            = "tag"
 
 	/* Domain permission keys */
 
@@ -89,6 +95,8 @@ const (
 	AdminPermissionsKey = "permissions"
 	AdminRoleIDsKey     = "role_ids[]"
 	AdminInvitedByKey   = "invited_by"
+	AdminResolvedKey    = "resolved"
+	AdminOutstandingKey = "outstanding"
 )
 
 /*
@@ -132,6 +140,14 @@ func ParseLocal(value string, defaultValue bool) (bool, gtserror.WithCode) {
 	return parseBool(value, defaultValue, LocalKey)
 }
 
+func ParseRemote(value string, defaultValue bool) (bool, gtserror.WithCode) {
+	return parseBool(value, defaultValue, RemoteKey)
+}
+
+func ParseOnlyMedia(value string, defaultValue bool) (bool, gtserror.WithCode) {
+	return parseBool(value, defaultValue, OnlyMediaKey)
+}
+
 func ParseSearchExcludeUnreviewed(value string, defaultValue bool) (bool, gtserror.WithCode) {
 	return parseBool(value, defaultValue, SearchExcludeUnreviewedKey)
 }
@@ -148,6 +164,10 @@ func ParseSearchResolve(value string, defaultValue bool) (bool, gtserror.WithCod
 	return parseBool(value, defaultValue, SearchResolveKey)
 }
 
+func ParseWebExcludeReplies(value string, defaultValue bool) (bool, gtserror.WithCode) {
+	return parseBool(value, defaultValue, WebExcludeRepliesKey)
+}
+
 func ParseDomainPermissionExport(value string, defaultValue bool) (bool, gtserror.WithCode) {
 	return parseBool(value, defaultValue, DomainPermissionExportKey)
 }
@@ -188,6 +208,14 @@ func ParseAdminStaff(value string, defaultValue bool) (bool, gtserror.WithCode)
 	return parseBool(value, defaultValue, AdminStaffKey)
 }
 
+func ParseAdminResolved(value string, defaultValue bool) (bool, gtserror.WithCode) {
+	return parseBool(value, defaultValue, AdminResolvedKey)
+}
+
+func ParseAdminOutstanding(value string, defaultValue bool) (bool, gtserror.WithCode) {
+	return parseBool(value, defaultValue, AdminOutstandingKey)
+}
+
 /*
 	Parse functions for *REQUIRED* parameters.
 */
@@ -18,10 +18,14 @@
 package util
 
 import (
+	"html/template"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/i18n"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
 )
 
 // WebPage encapsulates variables for
@@ -55,6 +59,12 @@ type WebPage struct {
 	// eg., "account": *Account etc.
 	// Can be nil.
 	Extra map[string]any
+
+	// HTTP status code to serve
+	// the page with, eg., to show
+	// a form again with errors.
+	// Defaults to 200 OK if unset.
+	Code int
 }
 
 // TemplateWebPage renders the given HTML template and
@@ -78,7 +88,12 @@ func TemplateWebPage(
 		obj[k] = v
 	}
 
-	templatePage(c, page.Template, http.StatusOK, obj)
+	code := page.Code
+	if code == 0 {
+		code = http.StatusOK
+	}
+
+	templatePage(c, page.Template, code, obj)
 }
 
 // templateErrorPage renders the given
@@ -94,10 +109,12 @@ func templateErrorPage(
 	const errorTmpl = "error.tmpl"
 
 	obj := map[string]any{
-		"instance":  instance,
-		"code":      code,
-		"error":     err,
-		"requestID": requestID,
+		"instance":      instance,
+		"code":          code,
+		"error":         err,
+		"requestID":     requestID,
+		"customMessage": customErrorMessage(config.GetWebErrorPageErrorMessage()),
+		"contactURL":    config.GetWebErrorPageContactURL(),
 	}
 
 	templatePage(c, errorTmpl, code, obj)
@@ -113,16 +130,30 @@ func template404Page(
 	const notFoundTmpl = "404.tmpl"
 
 	obj := map[string]any{
-		"instance":  instance,
-		"requestID": requestID,
+		"instance":      instance,
+		"requestID":     requestID,
+		"customMessage": customErrorMessage(config.GetWebErrorPageNotFoundMessage()),
+		"contactURL":    config.GetWebErrorPageContactURL(),
 	}
 
 	templatePage(c, notFoundTmpl, http.StatusNotFound, obj)
 }
 
+// customErrorMessage sanitizes the given admin-configured
+// error page message to HTML, returning nil if not set.
+func customErrorMessage(msg string) any {
+	if msg == "" {
+		return nil
+	}
+
+	/* #nosec G203 */
+	return template.HTML(text.SanitizeToHTML(msg))
+}
+
 // render the given template inside
 // "page.tmpl" with the provided
-// code and template object.
+// code and template object, in the
+// locale negotiated from the request.
 func templatePage(
 	c *gin.Context,
 	template string,
@@ -131,5 +162,11 @@ func templatePage(
 ) {
 	const pageTmpl = "page.tmpl"
 	obj["pageContent"] = template
+	obj["locale"] = i18n.Negotiate(c.GetHeader("Accept-Language"))
+
+	// Rendered page differs depending on
+	// Accept-Language, so tell caches that.
+	c.Writer.Header().Add("Vary", "Accept-Language")
+
 	c.HTML(code, pageTmpl, obj)
 }
@@ -74,6 +74,15 @@ func (m *Module) WebfingerGETRequest(c *gin.Context) {
 		return
 	}
 
+	// Normalize the requested host as punycode
+	// in case the resource contains an IDN domain.
+	requestedHost, err = util.Punify(requestedHost)
+	if err != nil {
+		err := fmt.Errorf("bad webfinger request with resource query %s: %w", resourceQuery, err)
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
 	if requestedHost != config.GetHost() && requestedHost != config.GetAccountDomain() {
 		err := fmt.Errorf("requested host %s does not belong to this instance", requestedHost)
 		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
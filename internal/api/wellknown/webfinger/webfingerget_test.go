@@ -27,6 +27,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -241,6 +242,34 @@ func (suite *WebfingerGetTestSuite) TestFingerUserWithDifferentAccountDomainByAc
 }`, resp)
 }
 
+func (suite *WebfingerGetTestSuite) TestFingerUserWithIDNAccountDomain() {
+	// Account domain is stored punified in config, but
+	// the request uses the unicode form of the domain.
+	targetAccount := suite.funkifyAccountDomain("gts.xn--bcher-kva.example", "xn--bcher-kva.example")
+	requestPath := fmt.Sprintf("/%s?resource=acct:%s@%s", webfinger.WebfingerBasePath, targetAccount.Username, url.QueryEscape("BÜCHER.example"))
+
+	resp := suite.finger(requestPath)
+	suite.Equal(`{
+  "subject": "acct:new_account_domain_user@xn--bcher-kva.example",
+  "aliases": [
+    "http://gts.xn--bcher-kva.example/users/new_account_domain_user",
+    "http://gts.xn--bcher-kva.example/@new_account_domain_user"
+  ],
+  "links": [
+    {
+      "rel": "http://webfinger.net/rel/profile-page",
+      "type": "text/html",
+      "href": "http://gts.xn--bcher-kva.example/@new_account_domain_user"
+    },
+    {
+      "rel": "self",
+      "type": "application/activity+json",
+      "href": "http://gts.xn--bcher-kva.example/users/new_account_domain_user"
+    }
+  ]
+}`, resp)
+}
+
 func (suite *WebfingerGetTestSuite) TestFingerUserWithoutAcct() {
 	// Leave out the 'acct:' part in the request path;
 	// the handler should be generous + still work OK.
@@ -0,0 +1,288 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// Invalidation is a single cache invalidation event,
+// as sent between GoToSocial processes over a Bus.
+//
+// Invalidations are either by index key(s), in which
+// case Keys is set, or by the indexed fields of values
+// invalidated by the publisher, in which case Values is
+// set, so that invalidate hooks can be run for them by
+// subscribers. Both may be set at once.
+type Invalidation struct {
+	// Origin identifies the process
+	// that published this invalidation.
+	Origin string `json:"o"`
+
+	// Cache is the name
+	// of the target cache.
+	Cache string `json:"c"`
+
+	// Keys contains the raw parts of
+	// each key to invalidate, by index.
+	Keys map[string][][]any `json:"k,omitempty"`
+
+	// Values contains the indexed fields of each
+	// value invalidated by publisher, by field name.
+	Values []map[string]any `json:"v,omitempty"`
+}
+
+// Bus provides a pluggable means of propagating cache
+// invalidations between multiple GoToSocial processes
+// sharing the same database, such that in-memory caches
+// never serve results that were changed by another process.
+type Bus interface {
+	// Publish sends the given invalidation
+	// to all subscribers of the bus (this
+	// may include the publishing process).
+	Publish(ctx context.Context, msg *Invalidation) error
+
+	// Subscribe passes all invalidations received on the
+	// bus to the given function, blocking until context is
+	// cancelled, or returning on any connection error.
+	Subscribe(ctx context.Context, fn func(*Invalidation)) error
+}
+
+// publisher queues invalidations to be published on
+// a Bus in the background, so cache calls never block
+// on the bus, and in order, so they can't overtake one
+// another. Publishing can be held, eg. while a database
+// transaction is ongoing, see Caches{}.HoldBus().
+type publisher struct {
+	bus    Bus
+	origin string
+
+	// mu protects
+	// queue and holds.
+	mu    sync.Mutex
+	queue []*Invalidation
+	holds int
+
+	// signal wakes run() when there
+	// may be invalidations to publish.
+	signal chan struct{}
+}
+
+// publish sets the invalidation origin and cache name, before
+// queueing it to be published on the bus by run(), once no holds
+// are left. Invalidations are always published in queued order.
+func (p *publisher) publish(name string, msg *Invalidation) {
+	msg.Origin = p.origin
+	msg.Cache = name
+
+	p.mu.Lock()
+	p.queue = append(p.queue, msg)
+	held := p.holds > 0
+	p.mu.Unlock()
+
+	if !held {
+		p.notify()
+	}
+}
+
+// hold holds publishing of queued invalidations
+// until the returned release function is called.
+func (p *publisher) hold() func() {
+	p.mu.Lock()
+	p.holds++
+	p.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			p.mu.Lock()
+			p.holds--
+			held := p.holds > 0
+			p.mu.Unlock()
+
+			if !held {
+				p.notify()
+			}
+		})
+	}
+}
+
+// notify wakes run(), without blocking
+// if it has already been woken.
+func (p *publisher) notify() {
+	select {
+	case p.signal <- struct{}{}:
+	default:
+	}
+}
+
+// run publishes queued invalidations on
+// the bus until context is cancelled,
+// logging (and dropping) on any error.
+func (p *publisher) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.signal:
+		}
+
+		p.mu.Lock()
+		if p.holds > 0 {
+			// Still held, the
+			// last release will
+			// notify us again.
+			p.mu.Unlock()
+			continue
+		}
+		queue := p.queue
+		p.queue = nil
+		p.mu.Unlock()
+
+		for _, msg := range queue {
+			pctx, cncl := context.WithTimeout(ctx, 10*time.Second)
+			if err := p.bus.Publish(pctx, msg); err != nil {
+				log.Errorf(pctx, "error publishing %s cache invalidation: %v", msg.Cache, err)
+			}
+			cncl()
+		}
+	}
+}
+
+// StartBus will begin publishing invalidations of the
+// caches that are shared between GoToSocial processes
+// over the given Bus, and invalidating those same caches
+// on receipt of invalidations published by other processes.
+//
+// NOTE: this must be called after Init() and before the
+// caches are in use, as this is not thread-safe.
+func (c *Caches) StartBus(bus Bus) {
+	// Generate a random origin ID for this
+	// process so we can skip our own messages.
+	origin := make([]byte, 16)
+	if _, err := rand.Read(origin); err != nil {
+		panic(err)
+	}
+
+	c.origin = hex.EncodeToString(origin)
+	c.pub = &publisher{
+		bus:    bus,
+		origin: c.origin,
+		signal: make(chan struct{}, 1),
+	}
+
+	// Register the shared caches with the bus.
+	for name, setBus := range c.busCaches() {
+		setBus(c.pub, name)
+	}
+
+	var ctx context.Context
+	ctx, c.stopBus = context.WithCancel(context.Background())
+
+	// Start publishing
+	// in the background.
+	go c.pub.run(ctx)
+
+	go func() {
+		for {
+			// Subscribe to the bus, passing received
+			// invalidations to our handler func. This
+			// blocks until context cancel or error.
+			err := bus.Subscribe(ctx, c.onInvalidation)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(5 * time.Second):
+			}
+
+			// Our caches may have missed invalidations
+			// while disconnected, so clear them before
+			// attempting to resubscribe.
+			log.Errorf(ctx, "cache bus subscription error, resubscribing: %v", err)
+			c.clearBusCaches()
+		}
+	}()
+
+	log.Info(nil, "started cache invalidation bus")
+}
+
+// HoldBus holds publishing of cache invalidations over any
+// Bus until the returned release function is called, eg. so
+// that invalidations made during a database transaction are
+// only published once it commits, and other processes don't
+// reload the old values before they can see the new ones.
+// Invalidations are still applied locally straight away.
+func (c *Caches) HoldBus() (release func()) {
+	if c.pub == nil {
+		// No bus.
+		return func() {}
+	}
+	return c.pub.hold()
+}
+
+// busCaches returns the setBus functions
+// of all caches shared over a Bus, by name.
+func (c *Caches) busCaches() map[string]func(*publisher, string) {
+	return map[string]func(*publisher, string){
+		"account": c.GTS.Account.setBus,
+		"block":   c.GTS.Block.setBus,
+		"user":    c.GTS.User.setBus,
+	}
+}
+
+// clearBusCaches clears all
+// caches shared over a Bus.
+func (c *Caches) clearBusCaches() {
+	c.GTS.Account.Clear()
+	c.GTS.Block.Clear()
+	c.GTS.User.Clear()
+
+	// Also clear the caches that would
+	// usually be cascade invalidated by
+	// invalidation hooks of the above.
+	c.GTS.BlockIDs.Clear()
+	c.Visibility.Clear()
+}
+
+// onInvalidation handles an invalidation
+// received over the Bus from another process.
+func (c *Caches) onInvalidation(msg *Invalidation) {
+	if msg.Origin == c.origin {
+		// Published by us,
+		// already handled.
+		return
+	}
+
+	switch msg.Cache {
+	case "account":
+		c.GTS.Account.onInvalidation(msg)
+	case "block":
+		c.GTS.Block.onInvalidation(msg)
+	case "user":
+		c.GTS.User.onInvalidation(msg)
+	default:
+		log.Warnf(nil, "unknown cache in invalidation: %s", msg.Cache)
+	}
+}
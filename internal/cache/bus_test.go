@@ -0,0 +1,301 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cache_test
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/cache"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// memBus is a simple in-memory cache.Bus,
+// which round-trips invalidations through
+// JSON as would happen in a real transport.
+type memBus struct {
+	mu   sync.Mutex
+	subs []func(*cache.Invalidation)
+	sent []*cache.Invalidation
+	wg   sync.WaitGroup
+}
+
+func (b *memBus) Publish(_ context.Context, msg *cache.Invalidation) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	b.mu.Lock()
+	subs := b.subs
+	b.sent = append(b.sent, msg)
+	b.mu.Unlock()
+	for _, fn := range subs {
+		var msg cache.Invalidation
+		if err := json.Unmarshal(payload, &msg); err != nil {
+			return err
+		}
+		fn(&msg)
+	}
+	return nil
+}
+
+func (b *memBus) Subscribe(ctx context.Context, fn func(*cache.Invalidation)) error {
+	b.mu.Lock()
+	b.subs = append(b.subs, fn)
+	b.mu.Unlock()
+	b.wg.Done()
+	<-ctx.Done()
+	return nil
+}
+
+// published returns a copy of all
+// invalidations published so far.
+func (b *memBus) published() []*cache.Invalidation {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return slices.Clone(b.sent)
+}
+
+// newBusCaches returns n caches
+// subscribed to the same memBus.
+func newBusCaches(t *testing.T, n int) ([]*cache.Caches, *memBus) {
+	config.Reset()
+
+	bus := new(memBus)
+	bus.wg.Add(n)
+
+	caches := make([]*cache.Caches, n)
+	for i := range caches {
+		caches[i] = new(cache.Caches)
+		caches[i].Init()
+		caches[i].Start()
+		caches[i].StartBus(bus)
+		t.Cleanup(caches[i].Stop)
+	}
+
+	// Wait on subscriptions.
+	bus.wg.Wait()
+
+	return caches, bus
+}
+
+// eventually fails the test if cond
+// doesn't return true within a second,
+// as invalidations are published async.
+func eventually(t *testing.T, cond func() bool, msg string) {
+	t.Helper()
+	for i := 0; i < 100; i++ {
+		if cond() {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal(msg)
+}
+
+func TestBusStoreBlock(t *testing.T) {
+	caches, bus := newBusCaches(t, 2)
+	a, b := caches[0], caches[1]
+
+	block := &gtsmodel.Block{
+		ID:              "01J1PC1XJ2S4ZT9GQ9N1C2C6W1",
+		URI:             "http://localhost:8080/blocks/01J1PC1XJ2S4ZT9GQ9N1C2C6W1",
+		AccountID:       "01F8MH1H7YV1Z7D2C8K2730QBF",
+		TargetAccountID: "01F8MH5ZK5VRH73AKHQM6Y9VNX",
+	}
+
+	var loads int
+	loadBlock := func(c *cache.Caches) (*gtsmodel.Block, error) {
+		return c.GTS.Block.LoadOne("AccountID,TargetAccountID", func() (*gtsmodel.Block, error) {
+			loads++
+			return nil, db.ErrNoEntries
+		}, block.AccountID, block.TargetAccountID)
+	}
+
+	// Cache negative result in b.
+	for range 2 {
+		if _, err := loadBlock(b); err != db.ErrNoEntries {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if loads != 1 {
+		t.Fatalf("expected 1 load, got %d", loads)
+	}
+
+	// Store block in a, this
+	// should invalidate in b.
+	if err := a.GTS.Block.Store(block, func() error {
+		return nil
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	eventually(t, func() bool {
+		if _, err := loadBlock(b); err != db.ErrNoEntries {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return loads == 2
+	}, "negative result should be invalidated in b")
+
+	// Only keys should have been published,
+	// as nothing was invalidated in a.
+	sent := bus.published()
+	if len(sent) != 1 {
+		t.Fatalf("expected 1 invalidation, got %d", len(sent))
+	}
+	if len(sent[0].Keys) == 0 || len(sent[0].Values) != 0 {
+		t.Fatalf("expected only keys, got %+v", sent[0])
+	}
+}
+
+func TestBusInvalidateAccount(t *testing.T) {
+	caches, _ := newBusCaches(t, 2)
+	a, b := caches[0], caches[1]
+
+	account := &gtsmodel.Account{
+		ID:       "01F8MH1H7YV1Z7D2C8K2730QBF",
+		URI:      "http://localhost:8080/users/the_mighty_zork",
+		Username: "the_mighty_zork",
+	}
+
+	// Cache account in b only.
+	b.GTS.Account.Put(account)
+
+	// Invalidate account
+	// by its ID in a.
+	a.GTS.Account.Invalidate("ID", account.ID)
+
+	eventually(t, func() bool {
+		_, ok := b.GTS.Account.GetOne("ID", account.ID)
+		return !ok
+	}, "account should be invalidated in b")
+	if _, ok := b.GTS.Account.GetOne("Username,Domain", account.Username, ""); ok {
+		t.Fatal("account should be invalidated in b")
+	}
+}
+
+func TestBusInvalidateBlockHook(t *testing.T) {
+	caches, _ := newBusCaches(t, 2)
+	a, b := caches[0], caches[1]
+
+	block := &gtsmodel.Block{
+		ID:              "01J1PC1XJ2S4ZT9GQ9N1C2C6W1",
+		URI:             "http://localhost:8080/blocks/01J1PC1XJ2S4ZT9GQ9N1C2C6W1",
+		AccountID:       "01F8MH1H7YV1Z7D2C8K2730QBF",
+		TargetAccountID: "01F8MH5ZK5VRH73AKHQM6Y9VNX",
+	}
+
+	var loads int
+	loadBlockIDs := func() {
+		_, _ = b.GTS.BlockIDs.Load(block.AccountID, func() ([]string, error) {
+			loads++
+			return []string{block.ID}, nil
+		})
+	}
+
+	// Cache block ID list in b only.
+	loadBlockIDs()
+
+	// Cache and invalidate block in a,
+	// b should invalidate the block ID
+	// list via invalidate hook, despite
+	// never having had block cached.
+	a.GTS.Block.Put(block)
+	a.GTS.Block.Invalidate("ID", block.ID)
+
+	eventually(t, func() bool {
+		loadBlockIDs()
+		return loads == 2
+	}, "block ID list should be invalidated in b")
+}
+
+func TestBusInvalidateOrder(t *testing.T) {
+	caches, bus := newBusCaches(t, 2)
+	a := caches[0]
+
+	ids := []string{
+		"01F8MH1H7YV1Z7D2C8K2730QBF",
+		"01F8MH5ZK5VRH73AKHQM6Y9VNX",
+		"01F8MH17FWEB39HZJ76B6VXSKF",
+		"01F8MH0BBE4FHXPH513MBVFHB0",
+		"01F8MHBQCBTDKN6X5VHGMMN4MA",
+	}
+
+	// Invalidate accounts in order in a.
+	for _, id := range ids {
+		a.GTS.Account.Invalidate("ID", id)
+	}
+
+	// Invalidations should be published in the
+	// same order, as otherwise a stale value could
+	// be reloaded after a more recent invalidation.
+	eventually(t, func() bool {
+		return len(bus.published()) == len(ids)
+	}, "all invalidations should be published")
+	for i, msg := range bus.published() {
+		if got := msg.Keys["ID"][0][0]; got != ids[i] {
+			t.Fatalf("invalidation %d: expected %s, got %v", i, ids[i], got)
+		}
+	}
+}
+
+func TestBusHold(t *testing.T) {
+	caches, bus := newBusCaches(t, 2)
+	a, b := caches[0], caches[1]
+
+	account := &gtsmodel.Account{
+		ID:       "01F8MH1H7YV1Z7D2C8K2730QBF",
+		URI:      "http://localhost:8080/users/the_mighty_zork",
+		Username: "the_mighty_zork",
+	}
+
+	// Cache account in both.
+	a.GTS.Account.Put(account)
+	b.GTS.Account.Put(account)
+
+	// Invalidate account in a while
+	// held, eg. during a transaction.
+	release := a.HoldBus()
+	a.GTS.Account.Invalidate("ID", account.ID)
+
+	// It should be invalidated
+	// locally, but not published.
+	if _, ok := a.GTS.Account.GetOne("ID", account.ID); ok {
+		t.Fatal("account should be invalidated in a")
+	}
+	time.Sleep(100 * time.Millisecond)
+	if n := len(bus.published()); n != 0 {
+		t.Fatalf("expected no invalidations published, got %d", n)
+	}
+	if _, ok := b.GTS.Account.GetOne("ID", account.ID); !ok {
+		t.Fatal("account should still be cached in b")
+	}
+
+	// Once released, eg. on commit, it's published.
+	release()
+	eventually(t, func() bool {
+		_, ok := b.GTS.Account.GetOne("ID", account.ID)
+		return !ok
+	}, "account should be invalidated in b")
+}
@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/cache/headerfilter"
+	"github.com/superseriousbusiness/gotosocial/internal/cache/redirect"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
 
@@ -38,10 +39,26 @@ type Caches struct {
 	// the block []headerfilter.Filter cache.
 	BlockHeaderFilters headerfilter.Cache
 
+	// Redirects provides access to the
+	// admin-defined redirects cache.
+	Redirects redirect.Cache
+
 	// Visibility provides access to the item visibility
 	// cache. (used by the visibility filter).
 	Visibility VisibilityCache
 
+	// origin identifies this process
+	// on any configured invalidation bus.
+	origin string
+
+	// pub publishes invalidations on
+	// any configured invalidation bus.
+	pub *publisher
+
+	// stopBus cancels any running
+	// invalidation bus subscription.
+	stopBus func()
+
 	// prevent pass-by-value.
 	_ nocopy
 }
@@ -62,6 +79,7 @@ func (c *Caches) Init() {
 	c.initClient()
 	c.initDomainAllow()
 	c.initDomainBlock()
+	c.initDomainPause()
 	c.initEmoji()
 	c.initEmojiCategory()
 	c.initFilter()
@@ -115,6 +133,11 @@ func (c *Caches) Start() {
 func (c *Caches) Stop() {
 	log.Infof(nil, "stop: %p", c)
 
+	if c.stopBus != nil {
+		// Stop bus subscription.
+		c.stopBus()
+	}
+
 	tryUntil("stopping webfinger cache", 5, c.GTS.Webfinger.Stop)
 }
 
@@ -62,6 +62,13 @@ type GTSCaches struct {
 	// DomainBlock provides access to the domain block database cache.
 	DomainBlock *domain.Cache
 
+	// DomainPause provides access to the domain pause database cache.
+	DomainPause *domain.Cache
+
+	// DomainPauseIngestion provides access to the domain
+	// pause (with paused ingestion) database cache.
+	DomainPauseIngestion *domain.Cache
+
 	// Emoji provides access to the gtsmodel Emoji database cache.
 	Emoji StructCache[*gtsmodel.Emoji]
 
@@ -431,6 +438,11 @@ func (c *Caches) initDomainBlock() {
 	c.GTS.DomainBlock = new(domain.Cache)
 }
 
+func (c *Caches) initDomainPause() {
+	c.GTS.DomainPause = new(domain.Cache)
+	c.GTS.DomainPauseIngestion = new(domain.Cache)
+}
+
 func (c *Caches) initEmoji() {
 	// Calculate maximum cache size.
 	cap := calculateResultCacheMax(
@@ -722,6 +734,7 @@ func (c *Caches) initInstance() {
 		// See internal/db/bundb/instance.go.
 		i2.DomainBlock = nil
 		i2.ContactAccount = nil
+		i2.FeaturedAccounts = nil
 
 		return i1
 	}
@@ -0,0 +1,82 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package redirect
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Cache provides a means of caching all admin-defined
+// redirects in memory by path, to reduce load on an
+// underlying storage mechanism, e.g. a database. As the
+// redirects are checked for every unmatched request, the
+// whole (small) set is kept, so that misses are cached too.
+//
+// The .Clear() function can be used to invalidate the cache,
+// e.g. when an entry is added / deleted from the database.
+type Cache struct {
+	// current redirects by path.
+	ptr atomic.Pointer[map[string]*gtsmodel.Redirect]
+}
+
+// Get returns a copy of the redirect for exactly the given
+// path, or nil if there isn't one. If the cache is not
+// currently loaded, the provided load function is used
+// to hydrate it with all redirects.
+func (c *Cache) Get(path string, load func() ([]*gtsmodel.Redirect, error)) (*gtsmodel.Redirect, error) {
+	// Load ptr value.
+	ptr := c.ptr.Load()
+
+	if ptr == nil {
+		// Cache is not hydrated.
+		// Load redirects from callback.
+		redirects, err := load()
+		if err != nil {
+			return nil, fmt.Errorf("error reloading cache: %w", err)
+		}
+
+		// Index the redirects by path.
+		m := make(map[string]*gtsmodel.Redirect, len(redirects))
+		for _, redirect := range redirects {
+			m[redirect.Path] = redirect
+		}
+
+		// Store the new
+		// redirects map.
+		ptr = &m
+		c.ptr.Store(ptr)
+	}
+
+	redirect, ok := (*ptr)[path]
+	if !ok {
+		return nil, nil
+	}
+
+	// Return a copy, so the cached
+	// redirect can't be changed.
+	redirect2 := new(gtsmodel.Redirect)
+	*redirect2 = *redirect
+	return redirect2, nil
+}
+
+// Clear will drop the currently loaded redirects,
+// triggering a reload on next call to .Get().
+func (c *Cache) Clear() { c.ptr.Store(nil) }
@@ -0,0 +1,79 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package redirect_test
+
+import (
+	"testing"
+
+	"github.com/superseriousbusiness/gotosocial/internal/cache/redirect"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+func TestCache(t *testing.T) {
+	var c redirect.Cache
+
+	var loads int
+	loader := func() ([]*gtsmodel.Redirect, error) {
+		loads++
+		return []*gtsmodel.Redirect{
+			{Path: "/donate", TargetURL: "https://example.org/donate"},
+			{Path: "/matrix", TargetURL: "https://example.org/matrix"},
+		}, nil
+	}
+
+	r, err := c.Get("/donate", loader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r == nil || r.TargetURL != "https://example.org/donate" {
+		t.Fatalf("unexpected redirect for /donate: %+v", r)
+	}
+
+	// Misses shouldn't cause a reload.
+	for _, path := range []string{"/wp-login.php", "/donate/", "/"} {
+		r, err := c.Get(path, loader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r != nil {
+			t.Fatalf("unexpected redirect for %s: %+v", path, r)
+		}
+	}
+
+	if loads != 1 {
+		t.Fatalf("expected 1 load, got %d", loads)
+	}
+
+	// Changing a returned redirect
+	// mustn't change the cached one.
+	r.TargetURL = "https://example.org/changed"
+	r, _ = c.Get("/donate", loader)
+	if r.TargetURL != "https://example.org/donate" {
+		t.Fatalf("cached redirect was changed: %+v", r)
+	}
+
+	// Clearing triggers a reload.
+	c.Clear()
+	if _, err := c.Get("/matrix", loader); err != nil {
+		t.Fatal(err)
+	}
+
+	if loads != 2 {
+		t.Fatalf("expected 2 loads, got %d", loads)
+	}
+}
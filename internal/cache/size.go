@@ -259,16 +259,24 @@ func sizeofAccountNote() uintptr {
 
 func sizeofAccountSettings() uintptr {
 	return uintptr(size.Of(&gtsmodel.AccountSettings{
-		AccountID:         exampleID,
-		CreatedAt:         exampleTime,
-		UpdatedAt:         exampleTime,
-		Privacy:           gtsmodel.VisibilityFollowersOnly,
-		Sensitive:         util.Ptr(true),
-		Language:          "fr",
-		StatusContentType: "text/plain",
-		CustomCSS:         exampleText,
-		EnableRSS:         util.Ptr(true),
-		HideCollections:   util.Ptr(false),
+		AccountID:                 exampleID,
+		CreatedAt:                 exampleTime,
+		UpdatedAt:                 exampleTime,
+		Privacy:                   gtsmodel.VisibilityFollowersOnly,
+		Sensitive:                 util.Ptr(true),
+		Language:                  "fr",
+		StatusContentType:         "text/plain",
+		CustomCSS:                 exampleText,
+		EnableRSS:                 util.Ptr(true),
+		HideCollections:           util.Ptr(false),
+		NotificationsSnoozedUntil: exampleTime,
+		SnoozeAllowFollows:        util.Ptr(false),
+		SnoozeAllowDirect:         util.Ptr(false),
+		FollowAutoAcceptDelay:     60,
+		HideStatusesLoggedOut:     util.Ptr(false),
+		HideFromLocalTimeline:     util.Ptr(false),
+		HideFromFederatedTimeline: util.Ptr(false),
+		NoIndex:                   util.Ptr(false),
 	}))
 }
 
@@ -349,6 +357,7 @@ func sizeofEmojiCategory() uintptr {
 		Name:      exampleUsername,
 		CreatedAt: exampleTime,
 		UpdatedAt: exampleTime,
+		Position:  1,
 	}))
 }
 
@@ -423,6 +432,8 @@ func sizeofInstance() uintptr {
 		ContactEmail:           exampleUsername,
 		ContactAccountUsername: exampleUsername,
 		ContactAccountID:       exampleID,
+		LandingPageBlocks:      []string{exampleTextSmall, exampleTextSmall, exampleTextSmall},
+		FeaturedAccountIDs:     []string{exampleID, exampleID},
 	}))
 }
 
@@ -597,6 +608,7 @@ func sizeofStatus() uintptr {
 		Boostable:                func() *bool { ok := true; return &ok }(),
 		Replyable:                func() *bool { ok := true; return &ok }(),
 		Likeable:                 func() *bool { ok := true; return &ok }(),
+		Indexable:                func() *bool { ok := false; return &ok }(),
 		ActivityStreamsType:      ap.ObjectNote,
 	}))
 }
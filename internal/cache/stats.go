@@ -0,0 +1,119 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cache
+
+import "sync/atomic"
+
+// Stats contains lookup statistics
+// for a single cache, see Caches{}.Stats().
+type Stats struct {
+	// Name of the cache,
+	// e.g. "Account".
+	Name string
+
+	// Hits is the total number of
+	// lookups answered from the cache.
+	Hits uint64
+
+	// Misses is the total number of lookups
+	// that had to be loaded (e.g. from the db).
+	Misses uint64
+
+	// Len is the current number
+	// of entries in the cache.
+	Len int
+}
+
+// lookups counts the
+// hits + misses of a cache.
+type lookups struct {
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+// count adds the given hits + misses.
+func (l *lookups) count(hits, misses int) {
+	if hits > 0 {
+		l.hits.Add(uint64(hits))
+	}
+	if misses > 0 {
+		l.misses.Add(uint64(misses))
+	}
+}
+
+// stats returns the current counts as Stats{}.
+func (l *lookups) stats(name string, len int) Stats {
+	return Stats{
+		Name:   name,
+		Hits:   l.hits.Load(),
+		Misses: l.misses.Load(),
+		Len:    len,
+	}
+}
+
+// Stats returns lookup statistics for each of
+// the available (struct and slice) caches. This
+// is used to expose cache hit / miss ratios.
+func (c *Caches) Stats() []Stats {
+	return []Stats{
+		c.GTS.Account.stats("Account"),
+		c.GTS.AccountNote.stats("AccountNote"),
+		c.GTS.AccountSettings.stats("AccountSettings"),
+		c.GTS.AccountStats.stats("AccountStats"),
+		c.GTS.Application.stats("Application"),
+		c.GTS.Block.stats("Block"),
+		c.GTS.BlockIDs.stats("BlockIDs"),
+		c.GTS.BoostOfIDs.stats("BoostOfIDs"),
+		c.GTS.Client.stats("Client"),
+		c.GTS.Emoji.stats("Emoji"),
+		c.GTS.EmojiCategory.stats("EmojiCategory"),
+		c.GTS.Filter.stats("Filter"),
+		c.GTS.FilterKeyword.stats("FilterKeyword"),
+		c.GTS.FilterStatus.stats("FilterStatus"),
+		c.GTS.Follow.stats("Follow"),
+		c.GTS.FollowIDs.stats("FollowIDs"),
+		c.GTS.FollowRequest.stats("FollowRequest"),
+		c.GTS.FollowRequestIDs.stats("FollowRequestIDs"),
+		c.GTS.InReplyToIDs.stats("InReplyToIDs"),
+		c.GTS.Instance.stats("Instance"),
+		c.GTS.List.stats("List"),
+		c.GTS.ListEntry.stats("ListEntry"),
+		c.GTS.Marker.stats("Marker"),
+		c.GTS.Media.stats("Media"),
+		c.GTS.Mention.stats("Mention"),
+		c.GTS.Move.stats("Move"),
+		c.GTS.Notification.stats("Notification"),
+		c.GTS.Poll.stats("Poll"),
+		c.GTS.PollVote.stats("PollVote"),
+		c.GTS.PollVoteIDs.stats("PollVoteIDs"),
+		c.GTS.Report.stats("Report"),
+		c.GTS.Status.stats("Status"),
+		c.GTS.StatusBookmark.stats("StatusBookmark"),
+		c.GTS.StatusBookmarkIDs.stats("StatusBookmarkIDs"),
+		c.GTS.StatusFave.stats("StatusFave"),
+		c.GTS.StatusFaveIDs.stats("StatusFaveIDs"),
+		c.GTS.Tag.stats("Tag"),
+		c.GTS.ThreadMute.stats("ThreadMute"),
+		c.GTS.Token.stats("Token"),
+		c.GTS.Tombstone.stats("Tombstone"),
+		c.GTS.User.stats("User"),
+		c.GTS.UserMute.stats("UserMute"),
+		c.GTS.UserMuteIDs.stats("UserMuteIDs"),
+		c.Visibility.stats("Visibility"),
+	}
+}
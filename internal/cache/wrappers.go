@@ -18,7 +18,9 @@
 package cache
 
 import (
+	"reflect"
 	"slices"
+	"strings"
 
 	"codeberg.org/gruf/go-cache/v3/simple"
 	"codeberg.org/gruf/go-structr"
@@ -28,6 +30,7 @@ import (
 // functions for fetching + caching slices of objects (e.g. IDs).
 type SliceCache[T any] struct {
 	cache simple.Cache[string, []T]
+	looks lookups
 }
 
 // Init initializes the cache with given length + capacity.
@@ -45,6 +48,7 @@ func (c *SliceCache[T]) Load(key string, load func() ([]T, error)) ([]T, error)
 		var err error
 
 		// Not cached, load!
+		c.looks.count(0, 1)
 		data, err = load()
 		if err != nil {
 			return nil, err
@@ -52,6 +56,8 @@ func (c *SliceCache[T]) Load(key string, load func() ([]T, error)) ([]T, error)
 
 		// Store the data.
 		c.cache.Set(key, data)
+	} else {
+		c.looks.count(1, 0)
 	}
 
 	// Return data clone for safety.
@@ -83,6 +89,11 @@ func (c *SliceCache[T]) Cap() int {
 	return c.cache.Cap()
 }
 
+// stats returns lookup Stats{} for cache under name.
+func (c *SliceCache[T]) stats(name string) Stats {
+	return c.looks.stats(name, c.cache.Len())
+}
+
 // StructCache wraps a structr.Cache{} to simple index caching
 // by name (also to ease update to library version that introduced
 // this). (in the future it may be worth embedding these indexes by
@@ -91,15 +102,37 @@ func (c *SliceCache[T]) Cap() int {
 type StructCache[StructType any] struct {
 	cache structr.Cache[StructType]
 	index map[string]*structr.Index
+
+	// multi tracks indices
+	// supporting multiple values.
+	multi map[string]bool
+
+	// invalid is the invalidate
+	// hook provided at Init().
+	invalid func(StructType)
+
+	// pub is set when invalidations
+	// are published over a Bus, under
+	// name. See Caches{}.StartBus().
+	pub  *publisher
+	name string
+
+	// looks counts lookup
+	// hits + misses.
+	looks lookups
 }
 
 // Init initializes the cache with given structr.CacheConfig{}.
 func (c *StructCache[T]) Init(config structr.CacheConfig[T]) {
 	c.index = make(map[string]*structr.Index, len(config.Indices))
+	c.multi = make(map[string]bool, len(config.Indices))
+	c.invalid = config.Invalidate
+	c.pub = nil
 	c.cache = structr.Cache[T]{}
 	c.cache.Init(config)
 	for _, cfg := range config.Indices {
 		c.index[cfg.Fields] = c.cache.Index(cfg.Fields)
+		c.multi[cfg.Fields] = cfg.Multiple
 	}
 }
 
@@ -107,7 +140,13 @@ func (c *StructCache[T]) Init(config structr.CacheConfig[T]) {
 // Note: this also handles conversion of the untyped (any) keys to structr.Key{} via structr.Index{}.
 func (c *StructCache[T]) GetOne(index string, key ...any) (T, bool) {
 	i := c.index[index]
-	return c.cache.GetOne(i, i.Key(key...))
+	value, ok := c.cache.GetOne(i, i.Key(key...))
+	if ok {
+		c.looks.count(1, 0)
+	} else {
+		c.looks.count(0, 1)
+	}
+	return value, ok
 }
 
 // Get calls structr.Cache{}.Get(), using a cached structr.Index{} by 'index' name.
@@ -126,7 +165,17 @@ func (c *StructCache[T]) Put(values ...T) {
 // Note: this also handles conversion of the untyped (any) keys to structr.Key{} via structr.Index{}.
 func (c *StructCache[T]) LoadOne(index string, load func() (T, error), key ...any) (T, error) {
 	i := c.index[index]
-	return c.cache.LoadOne(i, i.Key(key...), load)
+	loaded := false
+	value, err := c.cache.LoadOne(i, i.Key(key...), func() (T, error) {
+		loaded = true
+		return load()
+	})
+	if loaded {
+		c.looks.count(0, 1)
+	} else {
+		c.looks.count(1, 0)
+	}
+	return value, err
 }
 
 // LoadIDs calls structr.Cache{}.Load(), using a cached structr.Index{} by 'index' name. Note: this also handles
@@ -149,25 +198,89 @@ func (c *StructCache[T]) LoadIDs(index string, ids []string, load func([]string)
 	}
 
 	// Pass loader callback with wrapper onto main cache load function.
-	return c.cache.Load(i, keys, func(uncached []structr.Key) ([]T, error) {
+	misses := 0
+	values, err := c.cache.Load(i, keys, func(uncached []structr.Key) ([]T, error) {
+		misses = len(uncached)
 		uncachedIDs := make([]string, len(uncached))
 		for i := range uncached {
 			uncachedIDs[i] = uncached[i].Values()[0].(string)
 		}
 		return load(uncachedIDs)
 	})
+	c.looks.count(len(ids)-misses, misses)
+	return values, err
 }
 
 // Store: see structr.Cache{}.Store().
 func (c *StructCache[T]) Store(value T, store func() error) error {
-	return c.cache.Store(value, store)
+	if err := c.cache.Store(value, store); err != nil {
+		return err
+	}
+
+	if c.pub != nil {
+		// Other processes may have negative results
+		// cached under this value's keys, so publish
+		// invalidation of only the keys; nothing was
+		// invalidated that hooks need to know about.
+		c.pub.publish(c.name, &Invalidation{
+			Keys: c.keys([]T{value}),
+		})
+	}
+
+	return nil
+}
+
+// StoreAll is like Store(), but for multiple values stored at once by
+// the given store callback, eg. in a single bulk database insert. On
+// success all values are put in the cache, on error all values are
+// still passed to the invalidate hook, as with Store().
+func (c *StructCache[T]) StoreAll(values []T, store func() error) error {
+	if err := store(); err != nil {
+		if c.invalid != nil {
+			for _, value := range values {
+				c.invalid(value)
+			}
+		}
+		return err
+	}
+
+	// Put values in the cache, this
+	// also passes each to invalidate
+	// hook to invalidate related items.
+	c.cache.Put(values...)
+
+	if c.pub != nil {
+		// Other processes may have negative results
+		// cached under these values' keys, see Store().
+		c.pub.publish(c.name, &Invalidation{
+			Keys: c.keys(values),
+		})
+	}
+
+	return nil
 }
 
 // Invalidate calls structr.Cache{}.Invalidate(), using a cached structr.Index{} by 'index' name.
 // Note: this also handles conversion of the untyped (any) keys to structr.Key{} via structr.Index{}.
 func (c *StructCache[T]) Invalidate(index string, key ...any) {
 	i := c.index[index]
-	c.cache.Invalidate(i, i.Key(key...))
+	k := i.Key(key...)
+
+	if c.pub == nil {
+		// Only local invalidation.
+		c.cache.Invalidate(i, k)
+		return
+	}
+
+	// Gather the values about to be invalidated,
+	// so their fields can be published for hooks.
+	values := c.cache.Get(i, k)
+	c.cache.Invalidate(i, k)
+
+	c.pub.publish(c.name, &Invalidation{
+		Keys:   map[string][][]any{index: {key}},
+		Values: c.fields(values),
+	})
 }
 
 // InvalidateIDs calls structr.Cache{}.Invalidate(), using a cached structr.Index{} by 'index' name. Note: this also
@@ -189,8 +302,27 @@ func (c *StructCache[T]) InvalidateIDs(index string, ids []string) {
 		keys[x] = i.Key(id)
 	}
 
-	// Pass to main invalidate func.
+	if c.pub == nil {
+		// Only local invalidation.
+		c.cache.Invalidate(i, keys...)
+		return
+	}
+
+	// Gather the values about to be invalidated,
+	// so their fields can be published for hooks.
+	values := c.cache.Get(i, keys...)
 	c.cache.Invalidate(i, keys...)
+
+	// Convert IDs to raw key parts.
+	parts := make([][]any, len(ids))
+	for x, id := range ids {
+		parts[x] = []any{id}
+	}
+
+	c.pub.publish(c.name, &Invalidation{
+		Keys:   map[string][][]any{index: parts},
+		Values: c.fields(values),
+	})
 }
 
 // Trim: see structr.Cache{}.Trim().
@@ -212,3 +344,163 @@ func (c *StructCache[T]) Len() int {
 func (c *StructCache[T]) Cap() int {
 	return c.cache.Cap()
 }
+
+// stats returns lookup Stats{} for cache under name.
+func (c *StructCache[T]) stats(name string) Stats {
+	return c.looks.stats(name, c.cache.Len())
+}
+
+// setBus sets the publisher used to publish invalidations of this
+// cache over a Bus. Note this requires StructType to be a pointer
+// to a struct, as values are rebuilt from their indexed fields.
+func (c *StructCache[T]) setBus(pub *publisher, name string) {
+	if t := reflect.TypeFor[T](); t.Kind() != reflect.Pointer ||
+		t.Elem().Kind() != reflect.Struct {
+		panic("bus requires struct pointer cache type")
+	}
+	c.pub = pub
+	c.name = name
+}
+
+// onInvalidation handles an invalidation received over a Bus,
+// invalidating the given keys, and all keys of the given values,
+// before passing each (partially populated) value to invalidate
+// hook. Note that this never publishes invalidations in return.
+func (c *StructCache[T]) onInvalidation(msg *Invalidation) {
+	for index, raw := range msg.Keys {
+		i := c.index[index]
+		if i == nil {
+			continue
+		}
+
+		names := strings.Split(index, ",")
+
+		// Convert all raw key parts
+		// to their expected field types.
+		keys := make([][]any, 0, len(raw))
+		for _, parts := range raw {
+			if parts, ok := convertParts[T](names, parts); ok {
+				keys = append(keys, parts)
+			}
+		}
+
+		// Invalidate keys in main cache.
+		c.cache.Invalidate(i, i.Keys(keys...)...)
+	}
+
+	for _, fields := range msg.Values {
+		// Rebuild value from indexed fields.
+		value := c.value(fields)
+		rvalue := reflect.ValueOf(value).Elem()
+
+		for name, i := range c.index {
+			if c.multi[name] {
+				// Skip multiple value indices, as
+				// these would invalidate more than
+				// just this particular value.
+				continue
+			}
+
+			// Gather the key parts for index from value.
+			parts := make([]any, 0, strings.Count(name, ",")+1)
+			for _, field := range strings.Split(name, ",") {
+				parts = append(parts, rvalue.FieldByName(field).Interface())
+			}
+
+			// Invalidate value key in main cache.
+			c.cache.Invalidate(i, i.Key(parts...))
+		}
+
+		if c.invalid != nil {
+			// Pass to invalidate hook in
+			// case value wasn't cached here.
+			c.invalid(value)
+		}
+	}
+}
+
+// keys returns the raw parts of the keys of given
+// values under each index that doesn't support
+// multiple values, by index name.
+func (c *StructCache[T]) keys(values []T) map[string][][]any {
+	keys := make(map[string][][]any, len(c.index))
+	for _, value := range values {
+		rvalue := reflect.ValueOf(value).Elem()
+		for name := range c.index {
+			if c.multi[name] {
+				// Skip multiple value indices, as
+				// these would invalidate more than
+				// just these particular values.
+				continue
+			}
+			parts := make([]any, 0, strings.Count(name, ",")+1)
+			for _, field := range strings.Split(name, ",") {
+				parts = append(parts, rvalue.FieldByName(field).Interface())
+			}
+			keys[name] = append(keys[name], parts)
+		}
+	}
+	return keys
+}
+
+// fields returns the indexed fields
+// of given values, mapped by field name.
+func (c *StructCache[T]) fields(values []T) []map[string]any {
+	if len(values) == 0 {
+		return nil
+	}
+
+	out := make([]map[string]any, 0, len(values))
+	for _, value := range values {
+		rvalue := reflect.ValueOf(value).Elem()
+		fields := make(map[string]any)
+		for name := range c.index {
+			for _, field := range strings.Split(name, ",") {
+				fields[field] = rvalue.FieldByName(field).Interface()
+			}
+		}
+		out = append(out, fields)
+	}
+
+	return out
+}
+
+// value rebuilds a new value of StructType
+// from the given (indexed) fields by name.
+func (c *StructCache[T]) value(fields map[string]any) T {
+	rvalue := reflect.New(reflect.TypeFor[T]().Elem())
+	for name, raw := range fields {
+		field := rvalue.Elem().FieldByName(name)
+		if !field.IsValid() || raw == nil {
+			continue
+		}
+		if v := reflect.ValueOf(raw); v.CanConvert(field.Type()) {
+			field.Set(v.Convert(field.Type()))
+		}
+	}
+	return rvalue.Interface().(T)
+}
+
+// convertParts converts the given raw key parts
+// (e.g. as decoded from JSON) to the types of the
+// named fields of StructType, returning false if
+// this was not possible.
+func convertParts[T any](names []string, parts []any) ([]any, bool) {
+	if len(names) != len(parts) {
+		return nil, false
+	}
+	rtype := reflect.TypeFor[T]().Elem()
+	out := make([]any, len(parts))
+	for x, name := range names {
+		field, ok := rtype.FieldByName(name)
+		if !ok || parts[x] == nil {
+			return nil, false
+		}
+		v := reflect.ValueOf(parts[x])
+		if !v.CanConvert(field.Type) {
+			return nil, false
+		}
+		out[x] = v.Convert(field.Type).Interface()
+	}
+	return out, true
+}
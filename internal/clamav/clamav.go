@@ -0,0 +1,163 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package clamav provides a minimal client for scanning
+// data with a ClamAV daemon (clamd), using its INSTREAM
+// command over a unix or tcp socket.
+//
+// See: https://docs.clamav.net/manual/Usage/Scanning.html#clamd
+package clamav
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// chunkSize is the size of the
+// chunks data is streamed to clamd in.
+const chunkSize = 32 * 1024
+
+// Result is the result of scanning data.
+type Result struct {
+	// Infected is set if a virus was found.
+	Infected bool
+
+	// Virus is the name of the
+	// signature that matched, if any.
+	Virus string
+}
+
+// Client scans data with the clamd listening at an address.
+type Client struct {
+	network string
+	address string
+	timeout time.Duration
+}
+
+// New returns a new client for the clamd listening at given
+// address, which is either the path of a unix socket (if it
+// contains a slash), or a host:port to connect to via tcp.
+// Each scan will time out after the given timeout, if > 0.
+func New(address string, timeout time.Duration) *Client {
+	network := "tcp"
+	if strings.Contains(address, "/") {
+		network = "unix"
+	}
+
+	return &Client{
+		network: network,
+		address: address,
+		timeout: timeout,
+	}
+}
+
+// Scan streams the data read from r to clamd to be scanned, and returns
+// the result. An error is returned if clamd could not be reached, or if
+// it was unable to scan the data (eg., because it's over its stream limit).
+func (c *Client) Scan(ctx context.Context, r io.Reader) (Result, error) {
+	if c.timeout > 0 {
+		var cncl context.CancelFunc
+		ctx, cncl = context.WithTimeout(ctx, c.timeout)
+		defer cncl()
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, c.network, c.address)
+	if err != nil {
+		return Result{}, fmt.Errorf("error connecting to clamd: %w", err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return Result{}, fmt.Errorf("error setting deadline: %w", err)
+		}
+	}
+
+	if err := writeStream(conn, r); err != nil {
+		return Result{}, err
+	}
+
+	// Reply is null-terminated, since we used the 'z' command prefix.
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && !(errors.Is(err, io.EOF) && reply != "") {
+		return Result{}, fmt.Errorf("error reading reply from clamd: %w", err)
+	}
+
+	return parseReply(strings.TrimSuffix(reply, "\x00"))
+}
+
+// writeStream writes the INSTREAM command to w, followed by the data
+// read from r as length-prefixed chunks, ending with an empty chunk.
+func writeStream(w io.Writer, r io.Reader) error {
+	if _, err := io.WriteString(w, "zINSTREAM\x00"); err != nil {
+		return fmt.Errorf("error writing command to clamd: %w", err)
+	}
+
+	buf := make([]byte, 4+chunkSize)
+
+	for {
+		n, err := r.Read(buf[4:])
+		if n > 0 {
+			binary.BigEndian.PutUint32(buf[:4], uint32(n)) // #nosec G115 n <= chunkSize
+			if _, err := w.Write(buf[:4+n]); err != nil {
+				return fmt.Errorf("error writing data to clamd: %w", err)
+			}
+		}
+
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return fmt.Errorf("error reading data: %w", err)
+		}
+	}
+
+	// Zero length chunk marks the end of the stream.
+	if _, err := w.Write([]byte{0, 0, 0, 0}); err != nil {
+		return fmt.Errorf("error writing end of stream to clamd: %w", err)
+	}
+
+	return nil
+}
+
+// parseReply parses a clamd INSTREAM reply, which
+// looks like "stream: OK", "stream: Eicar-Signature FOUND",
+// or "INSTREAM size limit exceeded. ERROR".
+func parseReply(reply string) (Result, error) {
+	status := strings.TrimPrefix(reply, "stream: ")
+
+	switch {
+	case status == "OK":
+		return Result{}, nil
+
+	case strings.HasSuffix(status, " FOUND"):
+		return Result{
+			Infected: true,
+			Virus:    strings.TrimSuffix(status, " FOUND"),
+		}, nil
+
+	default:
+		return Result{}, fmt.Errorf("clamd could not scan data: %s", reply)
+	}
+}
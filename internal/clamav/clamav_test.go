@@ -0,0 +1,122 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package clamav_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/clamav"
+)
+
+type ClamAVTestSuite struct {
+	suite.Suite
+}
+
+// fakeClamd serves one INSTREAM request on a unix socket,
+// replying with the given reply, and sending the received
+// data on the returned channel.
+func (suite *ClamAVTestSuite) fakeClamd(reply string) (string, <-chan []byte) {
+	address := filepath.Join(suite.T().TempDir(), "clamd.ctl")
+
+	l, err := net.Listen("unix", address)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.T().Cleanup(func() { l.Close() })
+
+	received := make(chan []byte, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		cmd := make([]byte, len("zINSTREAM\x00"))
+		if _, err := io.ReadFull(conn, cmd); err != nil || string(cmd) != "zINSTREAM\x00" {
+			return
+		}
+
+		var data bytes.Buffer
+		for {
+			var size uint32
+			if err := binary.Read(conn, binary.BigEndian, &size); err != nil {
+				return
+			}
+			if size == 0 {
+				break
+			}
+			if _, err := io.CopyN(&data, conn, int64(size)); err != nil {
+				return
+			}
+		}
+
+		received <- data.Bytes()
+		_, _ = io.WriteString(conn, reply+"\x00")
+	}()
+
+	return address, received
+}
+
+func (suite *ClamAVTestSuite) TestScanClean() {
+	address, received := suite.fakeClamd("stream: OK")
+
+	// Big enough to be sent in several chunks.
+	data := bytes.Repeat([]byte("hello world "), 10000)
+
+	result, err := clamav.New(address, time.Minute).Scan(context.Background(), bytes.NewReader(data))
+	suite.NoError(err)
+	suite.False(result.Infected)
+	suite.Empty(result.Virus)
+	suite.Equal(data, <-received)
+}
+
+func (suite *ClamAVTestSuite) TestScanInfected() {
+	address, _ := suite.fakeClamd("stream: Eicar-Signature FOUND")
+
+	result, err := clamav.New(address, time.Minute).Scan(context.Background(), bytes.NewReader([]byte("eicar")))
+	suite.NoError(err)
+	suite.True(result.Infected)
+	suite.Equal("Eicar-Signature", result.Virus)
+}
+
+func (suite *ClamAVTestSuite) TestScanError() {
+	address, _ := suite.fakeClamd("INSTREAM size limit exceeded. ERROR")
+
+	_, err := clamav.New(address, time.Minute).Scan(context.Background(), bytes.NewReader([]byte("data")))
+	suite.EqualError(err, "clamd could not scan data: INSTREAM size limit exceeded. ERROR")
+}
+
+func (suite *ClamAVTestSuite) TestScanUnreachable() {
+	address := filepath.Join(suite.T().TempDir(), "nothing-here.ctl")
+
+	_, err := clamav.New(address, time.Minute).Scan(context.Background(), bytes.NewReader([]byte("data")))
+	suite.ErrorContains(err, "error connecting to clamd")
+}
+
+func TestClamAVTestSuite(t *testing.T) {
+	suite.Run(t, new(ClamAVTestSuite))
+}
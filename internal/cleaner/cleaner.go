@@ -34,9 +34,10 @@ const (
 )
 
 type Cleaner struct {
-	state *state.State
-	emoji Emoji
-	media Media
+	state     *state.State
+	emoji     Emoji
+	media     Media
+	retention Retention
 }
 
 func New(state *state.State) *Cleaner {
@@ -44,6 +45,7 @@ func New(state *state.State) *Cleaner {
 	c.state = state
 	c.emoji.Cleaner = c
 	c.media.Cleaner = c
+	c.retention.Cleaner = c
 	return c
 }
 
@@ -57,6 +59,11 @@ func (c *Cleaner) Media() *Media {
 	return &c.media
 }
 
+// Retention returns the retention set of cleaner utilities.
+func (c *Cleaner) Retention() *Retention {
+	return &c.retention
+}
+
 // haveFiles returns whether all of the provided files exist within current storage.
 func (c *Cleaner) haveFiles(ctx context.Context, files ...string) (bool, error) {
 	for _, file := range files {
@@ -149,8 +156,7 @@ func (c *Cleaner) ScheduleJobs() error {
 
 	fn := func(ctx context.Context, start time.Time) {
 		log.Info(ctx, "starting media clean")
-		c.Media().All(ctx, config.GetMediaRemoteCacheDays())
-		c.Emoji().All(ctx, config.GetMediaRemoteCacheDays())
+		c.scheduledClean(ctx, config.GetMediaRemoteCacheDays())
 		log.Infof(ctx, "finished media clean after %s", time.Since(start))
 	}
 
@@ -169,5 +175,86 @@ func (c *Cleaner) ScheduleJobs() error {
 		panic("failed to schedule @mediacleanup")
 	}
 
+	var (
+		localStatusDays     = config.GetRetentionLocalStatusDays()
+		remoteStatusDays    = config.GetRetentionRemoteStatusDays()
+		notificationDays    = config.GetRetentionNotificationDays()
+		readNotifDays       = config.GetRetentionReadNotificationDays()
+		maxNotifsPerAccount = config.GetRetentionNotificationMaxPerAccount()
+		unconfirmedUserDays = config.GetRetentionUnconfirmedUserDays()
+		archiveRemote       = config.GetRetentionRemoteStatusArchive()
+	)
+
+	if localStatusDays <= 0 &&
+		remoteStatusDays <= 0 &&
+		notificationDays <= 0 &&
+		readNotifDays <= 0 &&
+		maxNotifsPerAccount <= 0 &&
+		unconfirmedUserDays <= 0 {
+		// No retention
+		// policy set.
+		return nil
+	}
+
+	retentionFn := func(ctx context.Context, start time.Time) {
+		if config.GetRetentionDryRun() {
+			// Only log what would be deleted.
+			ctx = gtscontext.SetDryRun(ctx)
+		}
+
+		log.Info(ctx, "starting retention clean")
+		c.Retention().All(ctx,
+			localStatusDays,
+			remoteStatusDays,
+			notificationDays,
+			readNotifDays,
+			maxNotifsPerAccount,
+			unconfirmedUserDays,
+			archiveRemote,
+		)
+		log.Infof(ctx, "finished retention clean after %s", time.Since(start))
+	}
+
+	log.Infof(nil,
+		"scheduling retention clean to run every %s, starting from %s; next clean will run at %s",
+		cleanupEvery, cleanupFromStr, firstCleanupAt,
+	)
+
+	// Schedule the retention cleaning alongside media cleaning.
+	if !c.state.Workers.Scheduler.AddRecurring(
+		"@retentioncleanup",
+		firstCleanupAt,
+		cleanupEvery,
+		retentionFn,
+	) {
+		panic("failed to schedule @retentioncleanup")
+	}
+
 	return nil
 }
+
+// scheduledClean performs the scheduled media and emoji
+// clean. Unlike Media.All() and Emoji.All(), which will
+// uncache ALL remote media when given maxRemoteDays = 0,
+// here that value indicates that remote media should be
+// cached indefinitely, as documented for the config value.
+func (c *Cleaner) scheduledClean(ctx context.Context, maxRemoteDays int) {
+	if maxRemoteDays > 0 {
+		c.Media().All(ctx, maxRemoteDays)
+		c.Emoji().All(ctx, maxRemoteDays)
+		return
+	}
+
+	log.Info(ctx, "media-remote-cache-days is 0, skipping remote uncache")
+
+	c.Media().LogPruneBlobRefs(ctx)
+	c.Media().LogPruneOrphaned(ctx)
+	c.Media().LogPruneUnused(ctx)
+	c.Media().LogFixCacheStates(ctx)
+
+	c.Emoji().LogFixBroken(ctx)
+	c.Emoji().LogPruneUnused(ctx)
+	c.Emoji().LogFixCacheStates(ctx)
+
+	_ = c.state.Storage.Storage.Clean(ctx)
+}
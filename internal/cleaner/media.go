@@ -20,6 +20,8 @@ package cleaner
 import (
 	"context"
 	"errors"
+	"path"
+	"strings"
 	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -30,6 +32,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/paging"
 	"github.com/superseriousbusiness/gotosocial/internal/regexes"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 )
 
@@ -42,9 +45,12 @@ type Media struct{ *Cleaner }
 func (m *Media) All(ctx context.Context, maxRemoteDays int) {
 	t := time.Now().Add(-24 * time.Hour * time.Duration(maxRemoteDays))
 	m.LogUncacheRemote(ctx, t)
+	m.LogPruneBlobRefs(ctx)
 	m.LogPruneOrphaned(ctx)
 	m.LogPruneUnused(ctx)
 	m.LogFixCacheStates(ctx)
+	m.LogDedupe(ctx)
+	m.LogPruneExpiredUploads(ctx)
 	_ = m.state.Storage.Storage.Clean(ctx)
 }
 
@@ -58,6 +64,16 @@ func (m *Media) LogUncacheRemote(ctx context.Context, olderThan time.Time) {
 	}
 }
 
+// LogPruneBlobRefs performs Media.PruneBlobRefs(...), logging the start and outcome.
+func (m *Media) LogPruneBlobRefs(ctx context.Context) {
+	log.Info(ctx, "start")
+	if n, err := m.PruneBlobRefs(ctx); err != nil {
+		log.Error(ctx, err)
+	} else {
+		log.Infof(ctx, "pruned: %d", n)
+	}
+}
+
 // LogPruneOrphaned performs Media.PruneOrphaned(...), logging the start and outcome.
 func (m *Media) LogPruneOrphaned(ctx context.Context) {
 	log.Info(ctx, "start")
@@ -88,13 +104,95 @@ func (m *Media) LogFixCacheStates(ctx context.Context) {
 	}
 }
 
+// LogDedupe performs Media.Dedupe(...), logging the start and outcome.
+func (m *Media) LogDedupe(ctx context.Context) {
+	log.Info(ctx, "start")
+	if n, err := m.Dedupe(ctx); err != nil {
+		log.Error(ctx, err)
+	} else {
+		log.Infof(ctx, "deduped: %d", n)
+	}
+}
+
+// LogPruneExpiredUploads performs Media.PruneExpiredUploads(...), logging the start and outcome.
+func (m *Media) LogPruneExpiredUploads(ctx context.Context) {
+	log.Info(ctx, "start")
+	if n, err := m.PruneExpiredUploads(ctx); err != nil {
+		log.Error(ctx, err)
+	} else {
+		log.Infof(ctx, "pruned: %d", n)
+	}
+}
+
+// PruneBlobRefs will delete pins of content-addressed files left behind in the database
+// by interrupted media processing, so that the files can be pruned once orphaned.
+// Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
+func (m *Media) PruneBlobRefs(ctx context.Context) (int, error) {
+	if gtscontext.DryRun(ctx) {
+		// Dry run, do nothing.
+		return 0, nil
+	}
+
+	// Media processing never takes this long, so
+	// older pins must have been left behind.
+	olderThan := time.Now().Add(-24 * time.Hour)
+	return media.PruneBlobRefs(ctx, m.state, olderThan)
+}
+
 // PruneOrphaned will delete orphaned files from storage (i.e. media missing a database entry).
 // Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
 func (m *Media) PruneOrphaned(ctx context.Context) (int, error) {
+	files, err := m.orphanedFiles(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if gtscontext.DryRun(ctx) {
+		// Dry run, do nothing.
+		return len(files), nil
+	}
+
+	// Delete all orphaned files from storage. Content-addressed
+	// files are checked again as they're removed, since they may
+	// have been put back in use since storage was walked.
+	return media.DeleteFiles(ctx, m.state, "", files...)
+}
+
+// orphanedFiles walks storage, returning the paths of all
+// files that are missing a corresponding database entry.
+func (m *Media) orphanedFiles(ctx context.Context) ([]string, error) {
 	var files []string
 
-	// All media files in storage will have path fitting: {$account}/{$type}/{$size}/{$id}.{$ext}
+	// All media files in storage will have path fitting: {$account}/{$type}/{$size}/{$id}.{$ext},
+	// or for content-addressed media attachment files: blobs/{$hash_prefix}/{$hash}.{$ext}
 	if err := m.state.Storage.WalkKeys(ctx, func(path string) error {
+		if strings.HasPrefix(path, "quarantine/") {
+			// Infected media kept in quarantine
+			// is left for admins to deal with.
+			return nil
+		}
+
+		if strings.HasPrefix(path, "uploads/") {
+			// Parts of unfinished uploads are
+			// pruned once the upload expires.
+			return nil
+		}
+
+		if regexes.BlobPath.MatchString(path) {
+			// Check whether this blob is still in use.
+			inUse, err := media.BlobInUse(ctx, m.state, path, "")
+			if err != nil {
+				return gtserror.Newf("error checking blob references: %w", err)
+			}
+
+			if !inUse {
+				// Add this orphaned entry.
+				files = append(files, path)
+			}
+
+			return nil
+		}
+
 		// Check for our expected fileserver path format.
 		if !regexes.FilePath.MatchString(path) {
 			log.Warn(ctx, "unexpected storage item: %s", path)
@@ -114,11 +212,36 @@ func (m *Media) PruneOrphaned(ctx context.Context) (int, error) {
 
 		return nil
 	}); err != nil {
-		return 0, gtserror.Newf("error walking storage: %w", err)
+		return nil, gtserror.Newf("error walking storage: %w", err)
+	}
+
+	return files, nil
+}
+
+// PruneExpiredUploads will delete all unfinished resumable uploads that have expired, along
+// with their parts in storage. Context will be checked for `gtscontext.DryRun()` in order
+// to actually perform the action.
+func (m *Media) PruneExpiredUploads(ctx context.Context) (int, error) {
+	uploads, err := m.state.DB.GetMediaUploadsExpiredBefore(ctx, time.Now())
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return 0, gtserror.Newf("error getting expired uploads: %w", err)
 	}
 
-	// Delete all orphaned files from storage.
-	return m.removeFiles(ctx, files...)
+	if gtscontext.DryRun(ctx) {
+		// Dry run, do nothing.
+		return len(uploads), nil
+	}
+
+	var total int
+
+	for _, upload := range uploads {
+		if err := media.DeleteUpload(ctx, m.state, upload); err != nil {
+			return total, err
+		}
+		total++
+	}
+
+	return total, nil
 }
 
 // PruneUnused will delete all unused media attachments from the database and storage driver.
@@ -170,6 +293,55 @@ func (m *Media) PruneUnused(ctx context.Context) (int, error) {
 	return total, nil
 }
 
+// Dedupe will move the files of all cached media attachments still stored at per-attachment
+// storage paths to content-addressed paths, so identical files are only stored once.
+// Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
+func (m *Media) Dedupe(ctx context.Context) (int, error) {
+	var (
+		total int
+		page  paging.Page
+	)
+
+	// Set page select limit.
+	page.Limit = selectLimit
+
+	for {
+		// Fetch the next batch of media attachments to next maxID.
+		attachments, err := m.state.DB.GetAttachments(ctx, &page)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return total, gtserror.Newf("error getting attachments: %w", err)
+		}
+
+		// Get current max ID.
+		maxID := page.Max.Value
+
+		// If no attachments or the same group is returned, we reached the end.
+		if len(attachments) == 0 || maxID == attachments[len(attachments)-1].ID {
+			break
+		}
+
+		// Use last ID as the next 'maxID' value.
+		maxID = attachments[len(attachments)-1].ID
+		page.Max = paging.MaxID(maxID)
+
+		for _, media := range attachments {
+			// Check / dedupe media attachment files.
+			deduped, err := m.dedupe(ctx, media)
+			if err != nil {
+				return total, err
+			}
+
+			if deduped {
+				// Update
+				// count.
+				total++
+			}
+		}
+	}
+
+	return total, nil
+}
+
 // UncacheRemote will uncache all remote media attachments older than given input time.
 // Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
 func (m *Media) UncacheRemote(ctx context.Context, olderThan time.Time) (int, error) {
@@ -420,10 +592,7 @@ func (m *Media) fixCacheState(ctx context.Context, media *gtsmodel.MediaAttachme
 	case !*media.Cached && exist:
 		// Remove files if we don't expect them to exist.
 		l.Debug("cached=false exists=true => deleting")
-		_, err := m.removeFiles(ctx,
-			media.Thumbnail.Path,
-			media.File.Path,
-		)
+		_, err := m.removeMediaFiles(ctx, media)
 		return true, err
 
 	default:
@@ -549,10 +718,7 @@ func (m *Media) uncache(ctx context.Context, media *gtsmodel.MediaAttachment) er
 	}
 
 	// Remove media and thumbnail.
-	_, err := m.removeFiles(ctx,
-		media.File.Path,
-		media.Thumbnail.Path,
-	)
+	_, err := m.removeMediaFiles(ctx, media)
 	if err != nil {
 		return gtserror.Newf("error removing media files: %w", err)
 	}
@@ -573,20 +739,139 @@ func (m *Media) delete(ctx context.Context, media *gtsmodel.MediaAttachment) err
 		return nil
 	}
 
+	// Delete media attachment entirely from the database first,
+	// so a concurrent delete of other media sharing its files
+	// doesn't still see it, and both leave the files behind.
+	log.Debugf(ctx, "deleting media attachment: %s", media.ID)
+	if err := m.state.DB.DeleteAttachment(ctx, media.ID); err != nil {
+		return gtserror.Newf("error deleting media: %w", err)
+	}
+
 	// Remove media and thumbnail.
-	_, err := m.removeFiles(ctx,
-		media.File.Path,
-		media.Thumbnail.Path,
-	)
+	_, err := m.removeMediaFiles(ctx, media)
 	if err != nil {
 		return gtserror.Newf("error removing media files: %w", err)
 	}
 
-	// Delete media attachment entirely from the database.
-	log.Debugf(ctx, "deleting media attachment: %s", media.ID)
-	if err := m.state.DB.DeleteAttachment(ctx, media.ID); err != nil {
-		return gtserror.Newf("error deleting media: %w", err)
+	return nil
+}
+
+func (m *Media) dedupe(ctx context.Context, attachment *gtsmodel.MediaAttachment) (bool, error) {
+	if !*attachment.Cached {
+		// Nothing stored.
+		return false, nil
 	}
 
-	return nil
+	// Start a log entry for media.
+	l := log.WithContext(ctx).
+		WithField("media", attachment.ID)
+
+	var (
+		oldPaths []string
+		columns  []string
+	)
+
+	for _, file := range []struct {
+		path   *string
+		column string
+	}{
+		{&attachment.File.Path, "file_path"},
+		{&attachment.Thumbnail.Path, "thumbnail_path"},
+	} {
+		oldPath := *file.path
+		if oldPath == "" || regexes.BlobPath.MatchString(oldPath) {
+			// Nothing to
+			// do for this.
+			continue
+		}
+
+		oldPaths = append(oldPaths, oldPath)
+		columns = append(columns, file.column)
+
+		if gtscontext.DryRun(ctx) {
+			// Dry run, just count.
+			continue
+		}
+
+		// Copy file to its content-addressed path.
+		newRef, err := m.copyToBlob(ctx, oldPath)
+		if storage.IsNotFound(err) {
+			// Leave this to FixCacheStates().
+			l.Warnf("missing file %s, skipping", oldPath)
+			return false, nil
+		} else if err != nil {
+			return false, err
+		}
+
+		// Keep the new file pinned
+		// until attachment is updated.
+		defer media.UnpinBlob(ctx, m.state, newRef)
+
+		l.Debugf("moving %s => %s", oldPath, newRef.Path)
+		*file.path = newRef.Path
+	}
+
+	if len(columns) == 0 {
+		// Already deduped.
+		return false, nil
+	}
+
+	if gtscontext.DryRun(ctx) {
+		// Dry run, do nothing.
+		return true, nil
+	}
+
+	// Update attachment to point to new paths before
+	// removing the old files, so it never references
+	// a missing file, even if interrupted.
+	if err := m.state.DB.UpdateAttachment(ctx, attachment, columns...); err != nil {
+		return false, gtserror.Newf("error updating media: %w", err)
+	}
+
+	// Old per-attachment paths are never shared.
+	if _, err := m.removeFiles(ctx, oldPaths...); err != nil {
+		return false, gtserror.Newf("error removing old media files: %w", err)
+	}
+
+	return true, nil
+}
+
+// copyToBlob copies the file at given storage
+// path to its content-addressed storage path.
+// Returns the ref pinning the path, see media.PutBlob().
+func (m *Media) copyToBlob(ctx context.Context, oldPath string) (*gtsmodel.BlobRef, error) {
+	rc, err := m.state.Storage.GetStream(ctx, oldPath)
+	if err != nil {
+		return nil, gtserror.Newf("error opening %s: %w", oldPath, err)
+	}
+	defer rc.Close()
+
+	// Use same extension as original path.
+	ext := strings.TrimPrefix(path.Ext(oldPath), ".")
+
+	newRef, _, err := media.PutBlob(ctx, m.state, rc, ext)
+	if err != nil {
+		return nil, gtserror.Newf("error copying %s: %w", oldPath, err)
+	}
+
+	return newRef, nil
+}
+
+// removeMediaFiles removes the file, thumbnail and medium rendition of the
+// given media attachment from storage, except content-addressed files
+// still in use by other media attachments.
+func (m *Media) removeMediaFiles(ctx context.Context, attachment *gtsmodel.MediaAttachment) (int, error) {
+	paths := []string{
+		attachment.File.Path,
+		attachment.Thumbnail.Path,
+		media.MediumPath(attachment),
+	}
+
+	if gtscontext.DryRun(ctx) {
+		// Dry run, just count.
+		files, err := media.UnsharedFiles(ctx, m.state, attachment.ID, paths...)
+		return len(files), err
+	}
+
+	return media.DeleteFiles(ctx, m.state, attachment.ID, paths...)
 }
@@ -31,11 +31,14 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/regexes"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/transport"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -382,6 +385,8 @@ func (suite *MediaTestSuite) TestUncacheAndRecache() {
 		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
 	}
 
+	var filePaths []string
+
 	for _, original := range []*gtsmodel.MediaAttachment{
 		testStatusAttachment,
 		testHeader,
@@ -397,16 +402,218 @@ func (suite *MediaTestSuite) TestUncacheAndRecache() {
 		// recachedAttachment should be basically the same as the old attachment
 		suite.True(*recachedAttachment.Cached)
 		suite.Equal(original.ID, recachedAttachment.ID)
-		suite.Equal(original.File.Path, recachedAttachment.File.Path)           // file should be stored in the same place
-		suite.Equal(original.Thumbnail.Path, recachedAttachment.Thumbnail.Path) // as should the thumbnail
-		suite.EqualValues(original.FileMeta, recachedAttachment.FileMeta)       // and the filemeta should be the same
+		suite.Regexp(regexes.BlobPath, recachedAttachment.File.Path)      // file should be stored by content hash
+		suite.Regexp(regexes.BlobPath, recachedAttachment.Thumbnail.Path) // as should the thumbnail
+		suite.EqualValues(original.FileMeta, recachedAttachment.FileMeta) // and the filemeta should be the same
 
 		// recached files should be back in storage
 		_, err = suite.storage.Get(ctx, recachedAttachment.File.Path)
 		suite.NoError(err)
 		_, err = suite.storage.Get(ctx, recachedAttachment.Thumbnail.Path)
 		suite.NoError(err)
+
+		filePaths = append(filePaths, recachedAttachment.File.Path)
+	}
+
+	// both were recached from the same data,
+	// so they should share a single stored file
+	suite.Equal(filePaths[0], filePaths[1])
+}
+
+func (suite *MediaTestSuite) TestDedupe() {
+	ctx := context.Background()
+	testAttachment := suite.testAttachments["admin_account_status_1_attachment_1"]
+
+	// dry run shouldn't change anything
+	totalDeduped, err := suite.cleaner.Media().Dedupe(gtscontext.SetDryRun(ctx))
+	suite.NoError(err)
+	suite.Positive(totalDeduped)
+
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, testAttachment.ID)
+	suite.NoError(err)
+	suite.Equal(testAttachment.File.Path, dbAttachment.File.Path)
+
+	// now do it for real
+	totalDeduped, err = suite.cleaner.Media().Dedupe(ctx)
+	suite.NoError(err)
+	suite.Positive(totalDeduped)
+
+	dbAttachment, err = suite.db.GetAttachmentByID(ctx, testAttachment.ID)
+	suite.NoError(err)
+	suite.Regexp(regexes.BlobPath, dbAttachment.File.Path)
+	suite.Regexp(regexes.BlobPath, dbAttachment.Thumbnail.Path)
+
+	// files should have moved to their new paths
+	_, err = suite.storage.Get(ctx, dbAttachment.File.Path)
+	suite.NoError(err)
+	_, err = suite.storage.Get(ctx, dbAttachment.Thumbnail.Path)
+	suite.NoError(err)
+	_, err = suite.storage.Get(ctx, testAttachment.File.Path)
+	suite.True(storage.IsNotFound(err))
+	_, err = suite.storage.Get(ctx, testAttachment.Thumbnail.Path)
+	suite.True(storage.IsNotFound(err))
+
+	// deduping again should do nothing
+	totalDeduped, err = suite.cleaner.Media().Dedupe(ctx)
+	suite.NoError(err)
+	suite.Zero(totalDeduped)
+}
+
+func (suite *MediaTestSuite) TestDeleteSharedWithProcessing() {
+	ctx := context.Background()
+
+	// store test attachments by content hash
+	_, err := suite.cleaner.Media().Dedupe(ctx)
+	suite.NoError(err)
+
+	attachment, err := suite.db.GetAttachmentByID(ctx, suite.testAttachments["admin_account_status_1_attachment_1"].ID)
+	suite.NoError(err)
+
+	// another attachment with the same content is mid-processing:
+	// it's not cached yet, but its placeholder points at the file
+	processing := new(gtsmodel.MediaAttachment)
+	*processing = *attachment
+	processing.ID = "01J3C7N6FJ6AR7W4JAN3TQCVMJ"
+	processing.Cached = util.Ptr(false)
+	processing.Processing = gtsmodel.ProcessingStatusProcessing
+	suite.NoError(suite.db.PutAttachment(ctx, processing))
+
+	// deleting the first attachment should leave the shared files alone
+	removed, err := media.DeleteFiles(ctx, &suite.state, attachment.ID, attachment.File.Path, attachment.Thumbnail.Path)
+	suite.NoError(err)
+	suite.Zero(removed)
+	suite.NoError(suite.db.DeleteAttachment(ctx, attachment.ID))
+
+	// and they're not orphaned
+	_, err = suite.cleaner.Media().PruneOrphaned(ctx)
+	suite.NoError(err)
+	_, err = suite.storage.Get(ctx, attachment.File.Path)
+	suite.NoError(err)
+	_, err = suite.storage.Get(ctx, attachment.Thumbnail.Path)
+	suite.NoError(err)
+
+	// once processing fails the files are no longer in use
+	processing.Processing = gtsmodel.ProcessingStatusError
+	suite.NoError(suite.db.UpdateAttachment(ctx, processing, "processing"))
+
+	_, err = suite.cleaner.Media().PruneOrphaned(ctx)
+	suite.NoError(err)
+	_, err = suite.storage.Get(ctx, attachment.File.Path)
+	suite.True(storage.IsNotFound(err))
+	_, err = suite.storage.Get(ctx, attachment.Thumbnail.Path)
+	suite.True(storage.IsNotFound(err))
+}
+
+func (suite *MediaTestSuite) TestDeleteSharedWithPinned() {
+	ctx := context.Background()
+
+	// store test attachments by content hash
+	_, err := suite.cleaner.Media().Dedupe(ctx)
+	suite.NoError(err)
+
+	attachment, err := suite.db.GetAttachmentByID(ctx, suite.testAttachments["admin_account_status_1_attachment_1"].ID)
+	suite.NoError(err)
+
+	b, err := suite.storage.Get(ctx, attachment.File.Path)
+	suite.NoError(err)
+
+	// media with the same content is being processed, and
+	// has found the file in storage, but isn't stored yet
+	ref, _, err := media.PutBlob(ctx, &suite.state, bytes.NewReader(b), "jpg")
+	suite.NoError(err)
+	suite.Equal(attachment.File.Path, ref.Path)
+	path := ref.Path
+
+	// deleting the attachment should leave the shared file alone
+	removed, err := media.DeleteFiles(ctx, &suite.state, attachment.ID, attachment.File.Path)
+	suite.NoError(err)
+	suite.Zero(removed)
+	suite.NoError(suite.db.DeleteAttachment(ctx, attachment.ID))
+
+	// and it's not orphaned
+	_, err = suite.cleaner.Media().PruneOrphaned(ctx)
+	suite.NoError(err)
+	_, err = suite.storage.Get(ctx, path)
+	suite.NoError(err)
+
+	// until processing gives up on it
+	media.UnpinBlob(ctx, &suite.state, ref)
+
+	_, err = suite.cleaner.Media().PruneOrphaned(ctx)
+	suite.NoError(err)
+	_, err = suite.storage.Get(ctx, path)
+	suite.True(storage.IsNotFound(err))
+}
+
+func (suite *MediaTestSuite) TestPutSharedWhileDeleting() {
+	ctx := context.Background()
+
+	// store test attachments by content hash
+	_, err := suite.cleaner.Media().Dedupe(ctx)
+	suite.NoError(err)
+
+	attachment, err := suite.db.GetAttachmentByID(ctx, suite.testAttachments["admin_account_status_1_attachment_1"].ID)
+	suite.NoError(err)
+
+	b, err := suite.storage.Get(ctx, attachment.File.Path)
+	suite.NoError(err)
+
+	// another process has started removing the file
+	mark := &gtsmodel.BlobRef{
+		ID:       "01J3HB0GZ4W6FJ0M7TK2V3D8QY",
+		Path:     attachment.File.Path,
+		Deleting: util.Ptr(true),
+	}
+	suite.NoError(suite.db.PutBlobRef(ctx, mark))
+
+	// so media with the same content waits for it
+	put := make(chan *gtsmodel.BlobRef)
+	go func() {
+		ref, _, err := media.PutBlob(ctx, &suite.state, bytes.NewReader(b), "jpg")
+		suite.NoError(err)
+		put <- ref
+	}()
+
+	select {
+	case <-put:
+		suite.FailNow("put didn't wait for remove")
+	case <-time.After(500 * time.Millisecond):
 	}
+
+	// rather than relying on the file it removes
+	suite.NoError(suite.storage.Delete(ctx, attachment.File.Path))
+	suite.NoError(suite.db.DeleteBlobRefByID(ctx, mark.ID))
+
+	ref := <-put
+	suite.Equal(attachment.File.Path, ref.Path)
+	_, err = suite.storage.Get(ctx, ref.Path)
+	suite.NoError(err)
+	media.UnpinBlob(ctx, &suite.state, ref)
+}
+
+func (suite *MediaTestSuite) TestPruneBlobRefs() {
+	ctx := context.Background()
+
+	ref, _, err := media.PutBlob(ctx, &suite.state, bytes.NewReader([]byte("hello world")), "txt")
+	suite.NoError(err)
+
+	// fresh pins are left alone
+	pruned, err := suite.cleaner.Media().PruneBlobRefs(ctx)
+	suite.NoError(err)
+	suite.Zero(pruned)
+
+	inUse, err := media.BlobInUse(ctx, &suite.state, ref.Path, "")
+	suite.NoError(err)
+	suite.True(inUse)
+
+	// pins left behind long enough are pruned
+	pruned, err = media.PruneBlobRefs(ctx, &suite.state, time.Now().Add(25*time.Hour))
+	suite.NoError(err)
+	suite.Equal(1, pruned)
+
+	inUse, err = media.BlobInUse(ctx, &suite.state, ref.Path, "")
+	suite.NoError(err)
+	suite.False(inUse)
 }
 
 func (suite *MediaTestSuite) TestUncacheOneNonExistent() {
@@ -426,3 +633,49 @@ func (suite *MediaTestSuite) TestUncacheOneNonExistent() {
 	suite.NoError(err)
 	suite.Equal(3, totalUncached)
 }
+
+func (suite *MediaTestSuite) TestPruneExpiredUploads() {
+	ctx := context.Background()
+
+	putUpload := func(expiresAt time.Time) *gtsmodel.MediaUpload {
+		upload := &gtsmodel.MediaUpload{
+			ID:        id.NewULID(),
+			ExpiresAt: expiresAt,
+			AccountID: suite.testAccounts["local_account_1"].ID,
+			Size:      10,
+			Received:  5,
+			Parts:     1,
+		}
+		if err := suite.db.PutMediaUpload(ctx, upload); err != nil {
+			suite.FailNow(err.Error())
+		}
+		if _, err := suite.storage.Put(ctx, media.UploadPartPath(upload.ID, 0), []byte("hello")); err != nil {
+			suite.FailNow(err.Error())
+		}
+		return upload
+	}
+
+	expired := putUpload(time.Now().Add(-time.Minute))
+	unexpired := putUpload(time.Now().Add(time.Hour))
+
+	// Parts of uploads aren't orphans.
+	pruned, err := suite.cleaner.Media().PruneOrphaned(ctx)
+	suite.NoError(err)
+	suite.Zero(pruned)
+
+	pruned, err = suite.cleaner.Media().PruneExpiredUploads(ctx)
+	suite.NoError(err)
+	suite.Equal(1, pruned)
+
+	_, err = suite.db.GetMediaUploadByID(ctx, expired.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+	has, err := suite.storage.Has(ctx, media.UploadPartPath(expired.ID, 0))
+	suite.NoError(err)
+	suite.False(has)
+
+	_, err = suite.db.GetMediaUploadByID(ctx, unexpired.ID)
+	suite.NoError(err)
+	has, err = suite.storage.Has(ctx, media.UploadPartPath(unexpired.ID, 0))
+	suite.NoError(err)
+	suite.True(has)
+}
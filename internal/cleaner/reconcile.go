@@ -0,0 +1,231 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cleaner
+
+import (
+	"context"
+	"errors"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+// Reconciliation describes where storage
+// and the database have drifted apart.
+type Reconciliation struct {
+	// Storage paths of files with no
+	// corresponding attachment / emoji.
+	OrphanedFiles []string
+
+	// IDs of attachments marked as
+	// cached with files missing.
+	MissingAttachments []string
+
+	// IDs of emojis marked as
+	// cached with files missing.
+	MissingEmojis []string
+}
+
+// Reconcile scans the storage backend for files with no corresponding attachment
+// or emoji in the database, and the database for cached attachments and emojis
+// whose files are missing from storage, e.g. after a crash mid-processing.
+//
+// Orphaned files are removed, and remote attachments / emojis with missing files
+// are marked as uncached so that they're refetched when next needed. Local ones
+// with missing files can't be recovered, so they're only ever reported.
+//
+// Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
+func (c *Cleaner) Reconcile(ctx context.Context) (*Reconciliation, error) {
+	var (
+		rec Reconciliation
+		err error
+	)
+
+	// Gather files missing a database entry.
+	rec.OrphanedFiles, err = c.Media().orphanedFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Gather attachments missing their files.
+	rec.MissingAttachments, err = c.Media().missingFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Gather emojis missing their files.
+	rec.MissingEmojis, err = c.Emoji().missingFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if gtscontext.DryRun(ctx) {
+		// Dry run, do nothing.
+		return &rec, nil
+	}
+
+	// Remove orphaned files from storage. The scans for missing
+	// files above can take a while, during which content-addressed
+	// files may have been put back in use, so these are checked
+	// again as they're removed.
+	if _, err := media.DeleteFiles(ctx, c.state, "", rec.OrphanedFiles...); err != nil {
+		return nil, err
+	}
+
+	return &rec, nil
+}
+
+// missingFiles returns the IDs of all attachments marked as cached
+// but with files missing from storage, uncaching any remote ones.
+func (m *Media) missingFiles(ctx context.Context) ([]string, error) {
+	var (
+		ids  []string
+		page paging.Page
+	)
+
+	// Set page select limit.
+	page.Limit = selectLimit
+
+	for {
+		// Fetch the next batch of media attachments up to next max ID.
+		attachments, err := m.state.DB.GetAttachments(ctx, &page)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.Newf("error getting attachments: %w", err)
+		}
+
+		// Get current max ID.
+		maxID := page.Max.Value
+
+		// If no attachments or the same group is returned, we reached the end.
+		if len(attachments) == 0 || maxID == attachments[len(attachments)-1].ID {
+			break
+		}
+
+		// Use last ID as the next 'maxID' value.
+		maxID = attachments[len(attachments)-1].ID
+		page.Max = paging.MaxID(maxID)
+
+		for _, media := range attachments {
+			if !*media.Cached {
+				// Not expected
+				// in storage.
+				continue
+			}
+
+			// Check whether files exist.
+			exist, err := m.haveFiles(ctx,
+				media.Thumbnail.Path,
+				media.File.Path,
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			if exist {
+				continue
+			}
+
+			log.Debugf(ctx, "missing files for media: %s", media.ID)
+			ids = append(ids, media.ID)
+
+			if media.RemoteURL == "" {
+				// Can't recover
+				// local media.
+				continue
+			}
+
+			if err := m.uncache(ctx, media); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return ids, nil
+}
+
+// missingFiles returns the IDs of all emojis marked as cached but
+// with files missing from storage, uncaching any remote ones.
+func (e *Emoji) missingFiles(ctx context.Context) ([]string, error) {
+	var (
+		ids  []string
+		page paging.Page
+	)
+
+	// Set page select limit.
+	page.Limit = selectLimit
+
+	for {
+		// Fetch the next batch of emoji to next max ID.
+		emojis, err := e.state.DB.GetEmojis(ctx, &page)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.Newf("error getting emojis: %w", err)
+		}
+
+		// Get current max ID.
+		maxID := page.Max.Value
+
+		// If no emoji or the same group is returned, we reached end.
+		if len(emojis) == 0 || maxID == emojis[len(emojis)-1].ID {
+			break
+		}
+
+		// Use last ID as the next 'maxID'.
+		maxID = emojis[len(emojis)-1].ID
+		page.Max = paging.MaxID(maxID)
+
+		for _, emoji := range emojis {
+			if !*emoji.Cached {
+				// Not expected
+				// in storage.
+				continue
+			}
+
+			// Check whether files exist.
+			exist, err := e.haveFiles(ctx,
+				emoji.ImageStaticPath,
+				emoji.ImagePath,
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			if exist {
+				continue
+			}
+
+			log.Debugf(ctx, "missing files for emoji: %s", emoji.ID)
+			ids = append(ids, emoji.ID)
+
+			if emoji.IsLocal() {
+				// Can't recover
+				// local emoji.
+				continue
+			}
+
+			if err := e.uncache(ctx, emoji); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return ids, nil
+}
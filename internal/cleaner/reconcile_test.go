@@ -0,0 +1,105 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cleaner_test
+
+import (
+	"context"
+	"os"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+)
+
+// orphanPath is a fileserver-style path with
+// no corresponding attachment in the database.
+const orphanPath = "01GJQJ1YD9QCHCE12GG0EYHVNW/attachment/original/01GJQJ2AYM1VKSRW96YVAJ3NK3.gif"
+
+func (suite *MediaTestSuite) setupReconcile(ctx context.Context) {
+	// Add a big orphan panda to storage.
+	b, err := os.ReadFile("../media/test/big-panda.gif")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if _, err := suite.storage.Put(ctx, orphanPath, b); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Lose the files of one local and one remote attachment.
+	for _, key := range []string{
+		"local_account_1_unattached_1",
+		"remote_account_1_status_1_attachment_1",
+	} {
+		attachment := suite.testAttachments[key]
+		if err := suite.storage.Delete(ctx, attachment.File.Path); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+}
+
+func (suite *MediaTestSuite) TestReconcileDry() {
+	ctx := context.Background()
+	suite.setupReconcile(ctx)
+
+	local := suite.testAttachments["local_account_1_unattached_1"]
+	remote := suite.testAttachments["remote_account_1_status_1_attachment_1"]
+
+	rec, err := suite.cleaner.Reconcile(gtscontext.SetDryRun(ctx))
+	suite.NoError(err)
+	suite.Contains(rec.OrphanedFiles, orphanPath)
+	suite.Contains(rec.MissingAttachments, local.ID)
+	suite.Contains(rec.MissingAttachments, remote.ID)
+
+	// Orphan should still be in storage.
+	hasKey, err := suite.storage.Has(ctx, orphanPath)
+	suite.NoError(err)
+	suite.True(hasKey)
+
+	// Remote attachment should still be marked cached.
+	dbRemote, err := suite.db.GetAttachmentByID(ctx, remote.ID)
+	suite.NoError(err)
+	suite.True(*dbRemote.Cached)
+}
+
+func (suite *MediaTestSuite) TestReconcile() {
+	ctx := context.Background()
+	suite.setupReconcile(ctx)
+
+	local := suite.testAttachments["local_account_1_unattached_1"]
+	remote := suite.testAttachments["remote_account_1_status_1_attachment_1"]
+
+	rec, err := suite.cleaner.Reconcile(ctx)
+	suite.NoError(err)
+	suite.Contains(rec.OrphanedFiles, orphanPath)
+	suite.Contains(rec.MissingAttachments, local.ID)
+	suite.Contains(rec.MissingAttachments, remote.ID)
+
+	// Orphan should be gone from storage.
+	hasKey, err := suite.storage.Has(ctx, orphanPath)
+	suite.NoError(err)
+	suite.False(hasKey)
+
+	// Remote attachment should now be uncached, to be refetched.
+	dbRemote, err := suite.db.GetAttachmentByID(ctx, remote.ID)
+	suite.NoError(err)
+	suite.False(*dbRemote.Cached)
+
+	// Local attachment can't be recovered, so is left alone.
+	dbLocal, err := suite.db.GetAttachmentByID(ctx, local.ID)
+	suite.NoError(err)
+	suite.True(*dbLocal.Cached)
+}
@@ -0,0 +1,482 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cleaner
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+)
+
+// retentionQueueLimit is the most deletes queued for the
+// workers by one run of a retention util that queues them,
+// so the first run against a large backlog doesn't swamp the
+// worker queues. Anything left over is caught on the next run.
+const retentionQueueLimit = 1000
+
+// Retention encompasses a set of
+// data retention policy cleanup utils.
+type Retention struct{ *Cleaner }
+
+// All will execute all cleaner.Retention utilities synchronously, including output logging.
+// Each utility is skipped if its given number of days to retain data for is 0 or less.
+// Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
+//
+// If archiveRemote is set, remote statuses are archived before being removed.
+//
+// Read notifications are removed after readNotificationDays, and at most
+// maxNotificationsPerAccount notifications are kept per account, if set.
+func (r *Retention) All(ctx context.Context, localStatusDays, remoteStatusDays, notificationDays, readNotificationDays, maxNotificationsPerAccount, unconfirmedUserDays int, archiveRemote bool) {
+	now := time.Now()
+
+	if localStatusDays > 0 {
+		t := now.Add(-24 * time.Hour * time.Duration(localStatusDays))
+		r.LogLocalStatuses(ctx, t)
+	}
+
+	if remoteStatusDays > 0 {
+		t := now.Add(-24 * time.Hour * time.Duration(remoteStatusDays))
+		r.LogRemoteStatuses(ctx, t, archiveRemote)
+	}
+
+	if notificationDays > 0 {
+		t := now.Add(-24 * time.Hour * time.Duration(notificationDays))
+		r.LogNotifications(ctx, t)
+	}
+
+	if readNotificationDays > 0 {
+		t := now.Add(-24 * time.Hour * time.Duration(readNotificationDays))
+		r.LogReadNotifications(ctx, t)
+	}
+
+	if maxNotificationsPerAccount > 0 {
+		r.LogNotificationsOverLimit(ctx, maxNotificationsPerAccount)
+	}
+
+	if unconfirmedUserDays > 0 {
+		t := now.Add(-24 * time.Hour * time.Duration(unconfirmedUserDays))
+		r.LogUnconfirmedUsers(ctx, t)
+	}
+}
+
+// LogLocalStatuses performs Retention.LocalStatuses(...), logging the start and outcome.
+func (r *Retention) LogLocalStatuses(ctx context.Context, olderThan time.Time) {
+	log.Infof(ctx, "start older than: %s", olderThan.Format(time.Stamp))
+	if n, err := r.LocalStatuses(ctx, olderThan, retentionQueueLimit); err != nil {
+		log.Error(ctx, err)
+	} else {
+		log.Infof(ctx, "deleted: %d", n)
+	}
+}
+
+// LogRemoteStatuses performs Retention.RemoteStatuses(...), logging the start and outcome.
+func (r *Retention) LogRemoteStatuses(ctx context.Context, olderThan time.Time, archive bool) {
+	log.Infof(ctx, "start older than: %s", olderThan.Format(time.Stamp))
+	if n, err := r.RemoteStatuses(ctx, olderThan, archive, retentionQueueLimit); err != nil {
+		log.Error(ctx, err)
+	} else {
+		log.Infof(ctx, "removed: %d", n)
+	}
+}
+
+// LogNotifications performs Retention.Notifications(...), logging the start and outcome.
+func (r *Retention) LogNotifications(ctx context.Context, olderThan time.Time) {
+	log.Infof(ctx, "start older than: %s", olderThan.Format(time.Stamp))
+	if n, err := r.Notifications(ctx, olderThan); err != nil {
+		log.Error(ctx, err)
+	} else {
+		log.Infof(ctx, "deleted: %d", n)
+	}
+}
+
+// LogReadNotifications performs Retention.ReadNotifications(...), logging the start and outcome.
+func (r *Retention) LogReadNotifications(ctx context.Context, olderThan time.Time) {
+	log.Infof(ctx, "start older than: %s", olderThan.Format(time.Stamp))
+	if n, err := r.ReadNotifications(ctx, olderThan); err != nil {
+		log.Error(ctx, err)
+	} else {
+		log.Infof(ctx, "deleted: %d", n)
+	}
+}
+
+// LogNotificationsOverLimit performs Retention.NotificationsOverLimit(...), logging the start and outcome.
+func (r *Retention) LogNotificationsOverLimit(ctx context.Context, maxPerAccount int) {
+	log.Infof(ctx, "start keeping at most: %d", maxPerAccount)
+	if n, err := r.NotificationsOverLimit(ctx, maxPerAccount); err != nil {
+		log.Error(ctx, err)
+	} else {
+		log.Infof(ctx, "deleted: %d", n)
+	}
+}
+
+// LogUnconfirmedUsers performs Retention.UnconfirmedUsers(...), logging the start and outcome.
+func (r *Retention) LogUnconfirmedUsers(ctx context.Context, olderThan time.Time) {
+	log.Infof(ctx, "start older than: %s", olderThan.Format(time.Stamp))
+	if n, err := r.UnconfirmedUsers(ctx, olderThan, retentionQueueLimit); err != nil {
+		log.Error(ctx, err)
+	} else {
+		log.Infof(ctx, "deleted: %d", n)
+	}
+}
+
+// LocalStatuses will delete all local statuses older than given input time, except
+// for boosts, and statuses that are pinned by their author or bookmarked by anyone.
+// Deletes are federated out as usual. At most limit deletes are queued, any further statuses
+// are left for the next call. Context will be checked for `gtscontext.DryRun()` in order to
+// actually perform the action, else statuses to delete are just logged.
+func (r *Retention) LocalStatuses(ctx context.Context, olderThan time.Time, limit int) (int, error) {
+	var total int
+
+	// Statuses are paged by ID, so generate a
+	// max ID corresponding to the cutoff time.
+	maxID, err := id.NewULIDFromTime(olderThan)
+	if err != nil {
+		return total, gtserror.Newf("error generating max id: %w", err)
+	}
+
+	for {
+		// Fetch the next batch of expirable statuses older than max ID.
+		statuses, err := r.state.DB.GetExpirableLocalStatuses(ctx, maxID, selectLimit)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return total, gtserror.Newf("error getting local statuses: %w", err)
+		}
+
+		// If no statuses are returned, we reached the end.
+		if len(statuses) == 0 {
+			break
+		}
+
+		// Use last ID as the next 'maxID' value.
+		maxID = statuses[len(statuses)-1].ID
+
+		for _, status := range statuses {
+			if total >= limit {
+				// Leave the rest
+				// for next time.
+				return total, nil
+			}
+
+			if status.Account == nil {
+				// Can't process a delete
+				// without the status author.
+				log.Warnf(ctx, "status %s has no account", status.URI)
+				continue
+			}
+
+			if gtscontext.DryRun(ctx) {
+				log.Infof(ctx, "dry run: would delete status %s", status.URI)
+				total++
+				continue
+			}
+
+			// Process the delete asynchronously,
+			// as if requested by status author.
+			r.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+				APObjectType:   ap.ObjectNote,
+				APActivityType: ap.ActivityDelete,
+				GTSModel:       status,
+				Origin:         status.Account,
+				Target:         status.Account,
+			})
+			total++
+		}
+	}
+
+	return total, nil
+}
+
+// RemoteStatuses will remove all remote statuses older than given input time, which no
+// local account has interacted with, from the database. If archive is set, a compressed
+// copy of each status is stored in the status archives table first. Removal is local only
+// and isn't federated. At most limit removals are queued, any further statuses are left for
+// the next call. Context will be checked for `gtscontext.DryRun()` in order to actually
+// perform the action, else statuses to remove are just logged.
+func (r *Retention) RemoteStatuses(ctx context.Context, olderThan time.Time, archive bool, limit int) (int, error) {
+	var total int
+
+	// Removals are processed on
+	// behalf of our instance account.
+	instanceAcct, err := r.state.DB.GetInstanceAccount(ctx, "")
+	if err != nil {
+		return total, gtserror.Newf("db error getting instance account: %w", err)
+	}
+
+	// Statuses are paged by ID, so generate a
+	// max ID corresponding to the cutoff time.
+	maxID, err := id.NewULIDFromTime(olderThan)
+	if err != nil {
+		return total, gtserror.Newf("error generating max id: %w", err)
+	}
+
+	for {
+		// Fetch the next batch of expirable statuses older than max ID.
+		statuses, err := r.state.DB.GetExpirableRemoteStatuses(ctx, maxID, selectLimit)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return total, gtserror.Newf("error getting remote statuses: %w", err)
+		}
+
+		// If no statuses are returned, we reached the end.
+		if len(statuses) == 0 {
+			break
+		}
+
+		// Use last ID as the next 'maxID' value.
+		maxID = statuses[len(statuses)-1].ID
+
+		for _, status := range statuses {
+			if total >= limit {
+				// Leave the rest
+				// for next time.
+				return total, nil
+			}
+
+			if status.Account == nil {
+				// Can't process a delete
+				// without the status author.
+				log.Warnf(ctx, "status %s has no account", status.URI)
+				continue
+			}
+
+			if gtscontext.DryRun(ctx) {
+				log.Infof(ctx, "dry run: would remove status %s", status.URI)
+				total++
+				continue
+			}
+
+			if archive {
+				if err := r.state.DB.ArchiveStatus(ctx, status); err != nil {
+					return total, gtserror.Newf("error archiving status %s: %w", status.URI, err)
+				}
+			}
+
+			// Process the delete asynchronously, as
+			// if the author had deleted it remotely.
+			r.state.Workers.Federator.Queue.Push(&messages.FromFediAPI{
+				APObjectType:   ap.ObjectNote,
+				APActivityType: ap.ActivityDelete,
+				GTSModel:       status,
+				Receiving:      instanceAcct,
+				Requesting:     status.Account,
+			})
+			total++
+		}
+	}
+
+	return total, nil
+}
+
+// Notifications will delete all notifications older than given input time. Context
+// will be checked for `gtscontext.DryRun()` in order to actually perform the action.
+func (r *Retention) Notifications(ctx context.Context, olderThan time.Time) (int, error) {
+	return r.notifications(ctx, olderThan, false)
+}
+
+// ReadNotifications will delete all notifications marked as read that are older than given
+// input time. Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
+func (r *Retention) ReadNotifications(ctx context.Context, olderThan time.Time) (int, error) {
+	return r.notifications(ctx, olderThan, true)
+}
+
+// NotificationsOverLimit will delete the oldest notifications of each account targeted by more
+// than maxPerAccount notifications, until only maxPerAccount remain. Context will be checked
+// for `gtscontext.DryRun()` in order to actually perform the action.
+func (r *Retention) NotificationsOverLimit(ctx context.Context, maxPerAccount int) (int, error) {
+	var total int
+
+	accountIDs, err := r.state.DB.GetAccountIDsWithNotificationsOver(ctx, maxPerAccount)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return total, gtserror.Newf("error getting accounts over limit: %w", err)
+	}
+
+	for _, accountID := range accountIDs {
+		// Skip the newest notifications we want to keep.
+		offset := maxPerAccount
+
+		for {
+			// Fetch the next batch of notification IDs beyond the limit.
+			notifIDs, err := r.state.DB.GetAccountNotificationIDsOver(ctx, accountID, offset, selectLimit)
+			if err != nil && !errors.Is(err, db.ErrNoEntries) {
+				return total, gtserror.Newf("error getting notifications for account %s: %w", accountID, err)
+			}
+
+			// If no notifications are returned, we reached the end.
+			if len(notifIDs) == 0 {
+				break
+			}
+
+			if gtscontext.DryRun(ctx) {
+				// Dry run, nothing gets deleted
+				// so skip past this batch instead.
+				offset += len(notifIDs)
+				total += len(notifIDs)
+				continue
+			}
+
+			for _, notifID := range notifIDs {
+				if err := r.state.DB.DeleteNotificationByID(ctx, notifID); err != nil &&
+					!errors.Is(err, db.ErrNoEntries) {
+					return total, gtserror.Newf("error deleting notification %s: %w", notifID, err)
+				}
+				total++
+			}
+		}
+	}
+
+	return total, nil
+}
+
+// notifications deletes all notifications older than given input
+// time, or only those marked as read if onlyRead is set.
+func (r *Retention) notifications(ctx context.Context, olderThan time.Time, onlyRead bool) (int, error) {
+	var total int
+
+	// Notifications are paged by ID, so generate
+	// a max ID corresponding to the cutoff time.
+	maxID, err := id.NewULIDFromTime(olderThan)
+	if err != nil {
+		return total, gtserror.Newf("error generating max id: %w", err)
+	}
+
+	for {
+		// Fetch the next batch of notification IDs older than max ID.
+		notifIDs, err := r.state.DB.GetNotificationIDsBefore(ctx, maxID, onlyRead, selectLimit)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return total, gtserror.Newf("error getting notifications: %w", err)
+		}
+
+		// If no notifications are returned, we reached the end.
+		if len(notifIDs) == 0 {
+			break
+		}
+
+		// Use last ID as the next 'maxID' value.
+		maxID = notifIDs[len(notifIDs)-1]
+
+		if gtscontext.DryRun(ctx) {
+			// Dry run, just update count.
+			total += len(notifIDs)
+			continue
+		}
+
+		for _, notifID := range notifIDs {
+			if err := r.state.DB.DeleteNotificationByID(ctx, notifID); err != nil &&
+				!errors.Is(err, db.ErrNoEntries) {
+				return total, gtserror.Newf("error deleting notification %s: %w", notifID, err)
+			}
+			total++
+		}
+	}
+
+	return total, nil
+}
+
+// UnconfirmedUsers will delete the accounts of all users created before given input time, who
+// never confirmed their email address. Admin and moderator users are never deleted. Deletes are
+// processed as if requested by the account itself, removing its media, tokens, applications etc.
+// At most limit deletes are queued, any further users are left for the next call. Context will
+// be checked for `gtscontext.DryRun()` in order to actually perform the action.
+func (r *Retention) UnconfirmedUsers(ctx context.Context, olderThan time.Time, limit int) (int, error) {
+	var total int
+
+	var maxID string
+
+	for {
+		// Fetch the next page of users older than max ID.
+		users, err := r.state.DB.GetUsersPage(ctx, maxID, selectLimit)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return total, gtserror.Newf("error getting users: %w", err)
+		}
+
+		// If no users are returned, we reached the end.
+		if len(users) == 0 {
+			break
+		}
+
+		// Use last ID as the next 'maxID' value.
+		maxID = users[len(users)-1].ID
+
+		for _, user := range users {
+			if total >= limit {
+				// Leave the rest
+				// for next time.
+				return total, nil
+			}
+
+			if !expiredUnconfirmed(user, olderThan) {
+				continue
+			}
+
+			account, err := r.state.DB.GetAccountByID(
+				gtscontext.SetBarebones(ctx),
+				user.AccountID,
+			)
+			if err != nil && !errors.Is(err, db.ErrNoEntries) {
+				return total, gtserror.Newf("error getting account %s: %w", user.AccountID, err)
+			}
+
+			if account == nil || account.IsSuspended() {
+				// Account is already gone,
+				// (or is being deleted).
+				continue
+			}
+
+			if gtscontext.DryRun(ctx) {
+				log.Infof(ctx, "dry run: would delete unconfirmed user %s", user.ID)
+				total++
+				continue
+			}
+
+			// Process the delete asynchronously, as if
+			// the user had deleted their account (see
+			// user.Processor{}.DeleteSelf()).
+			r.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+				APObjectType:   ap.ObjectProfile,
+				APActivityType: ap.ActivityDelete,
+				Origin:         account,
+				Target:         account,
+			})
+			total++
+		}
+	}
+
+	return total, nil
+}
+
+// expiredUnconfirmed returns whether the given user never
+// confirmed their email address, was created before the
+// given time, and isn't a member of instance staff.
+func expiredUnconfirmed(user *gtsmodel.User, olderThan time.Time) bool {
+	if !user.ConfirmedAt.IsZero() {
+		return false
+	}
+
+	if *user.Admin || *user.Moderator {
+		return false
+	}
+
+	return user.CreatedAt.Before(olderThan)
+}
@@ -0,0 +1,301 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cleaner_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+func (suite *CleanerTestSuite) TestRetentionLocalStatuses() {
+	ctx := context.Background()
+
+	deleted, err := suite.cleaner.Retention().LocalStatuses(ctx, time.Now(), 100)
+	suite.NoError(err)
+	suite.NotZero(deleted)
+
+	// Deletes should have been queued for
+	// processing, check each status deleted.
+	for i := 0; i < deleted; i++ {
+		msg, ok := suite.state.Workers.Client.Queue.Pop()
+		if !ok {
+			suite.FailNow("expected queued status delete")
+		}
+
+		status, ok := msg.GTSModel.(*gtsmodel.Status)
+		if !ok {
+			suite.FailNow("expected queued status model")
+		}
+
+		suite.True(*status.Local)
+		suite.Empty(status.BoostOfID)
+		suite.True(status.PinnedAt.IsZero())
+
+		// Bookmarked statuses should be kept.
+		suite.NotEqual("01F8MH75CBF9JFX4ZAD54N0W0R", status.ID)
+		suite.NotEqual("01F8MHAMCHF6Y650WCRSCP4WMY", status.ID)
+	}
+
+	// Nothing else should have been queued.
+	_, ok := suite.state.Workers.Client.Queue.Pop()
+	suite.False(ok)
+}
+
+func (suite *CleanerTestSuite) TestRetentionLocalStatusesDryRun() {
+	ctx := gtscontext.SetDryRun(context.Background())
+
+	deleted, err := suite.cleaner.Retention().LocalStatuses(ctx, time.Now(), 100)
+	suite.NoError(err)
+	suite.NotZero(deleted)
+
+	// Nothing should have been queued.
+	_, ok := suite.state.Workers.Client.Queue.Pop()
+	suite.False(ok)
+}
+
+func (suite *CleanerTestSuite) TestRetentionLocalStatusesLimit() {
+	ctx := context.Background()
+
+	// Only as many deletes as
+	// the limit should be queued.
+	deleted, err := suite.cleaner.Retention().LocalStatuses(ctx, time.Now(), 2)
+	suite.NoError(err)
+	suite.Equal(2, deleted)
+
+	for i := 0; i < deleted; i++ {
+		_, ok := suite.state.Workers.Client.Queue.Pop()
+		suite.True(ok)
+	}
+
+	// Nothing else should have been queued.
+	_, ok := suite.state.Workers.Client.Queue.Pop()
+	suite.False(ok)
+}
+
+func (suite *CleanerTestSuite) TestRetentionRemoteStatuses() {
+	ctx := context.Background()
+
+	removed, err := suite.cleaner.Retention().RemoteStatuses(ctx, time.Now(), true, 100)
+	suite.NoError(err)
+	suite.NotZero(removed)
+
+	// Deletes should have been queued for
+	// processing, check each status removed.
+	for i := 0; i < removed; i++ {
+		msg, ok := suite.state.Workers.Federator.Queue.Pop()
+		if !ok {
+			suite.FailNow("expected queued status delete")
+		}
+
+		status, ok := msg.GTSModel.(*gtsmodel.Status)
+		if !ok {
+			suite.FailNow("expected queued status model")
+		}
+
+		suite.False(*status.Local)
+		suite.Equal(status.AccountID, msg.Requesting.ID)
+		suite.NotNil(msg.Receiving)
+
+		// Status should have been archived first.
+		archive := &gtsmodel.StatusArchive{}
+		err := suite.state.DB.GetByID(ctx, status.ID, archive)
+		suite.NoError(err)
+		suite.Equal(status.URI, archive.URI)
+	}
+
+	// Nothing else should have been queued.
+	_, ok := suite.state.Workers.Federator.Queue.Pop()
+	suite.False(ok)
+}
+
+func (suite *CleanerTestSuite) TestRetentionRemoteStatusesDryRun() {
+	ctx := gtscontext.SetDryRun(context.Background())
+
+	removed, err := suite.cleaner.Retention().RemoteStatuses(ctx, time.Now(), true, 100)
+	suite.NoError(err)
+	suite.NotZero(removed)
+
+	// Nothing should have been queued.
+	_, ok := suite.state.Workers.Federator.Queue.Pop()
+	suite.False(ok)
+}
+
+func (suite *CleanerTestSuite) TestRetentionNotifications() {
+	suite.testRetentionNotifications(context.Background())
+}
+
+func (suite *CleanerTestSuite) TestRetentionNotificationsDryRun() {
+	suite.testRetentionNotifications(gtscontext.SetDryRun(context.Background()))
+}
+
+func (suite *CleanerTestSuite) testRetentionNotifications(ctx context.Context) {
+	notifs := testrig.NewTestNotifications()
+
+	now := time.Now()
+	deleted, err := suite.cleaner.Retention().Notifications(ctx, now)
+	suite.NoError(err)
+	suite.Equal(len(notifs), deleted)
+
+	maxID, err := id.NewULIDFromTime(now)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	remaining, err := suite.state.DB.GetNotificationIDsBefore(ctx, maxID, false, len(notifs)+1)
+	suite.NoError(err)
+
+	if gtscontext.DryRun(ctx) {
+		// Nothing should be deleted.
+		suite.Len(remaining, len(notifs))
+	} else {
+		suite.Empty(remaining)
+	}
+}
+
+func (suite *CleanerTestSuite) TestRetentionReadNotifications() {
+	ctx := context.Background()
+	notifs := testrig.NewTestNotifications()
+
+	// Mark the notifications of one account as read.
+	notif := notifs["local_account_1_like"]
+	if err := suite.state.DB.MarkNotificationsRead(ctx, notif.TargetAccountID, notif.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	now := time.Now()
+	deleted, err := suite.cleaner.Retention().ReadNotifications(ctx, now)
+	suite.NoError(err)
+	suite.Equal(1, deleted)
+
+	// Only the read notification should be gone.
+	_, err = suite.state.DB.GetNotificationByID(ctx, notif.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	maxID, err := id.NewULIDFromTime(now)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	remaining, err := suite.state.DB.GetNotificationIDsBefore(ctx, maxID, false, len(notifs)+1)
+	suite.NoError(err)
+	suite.Len(remaining, len(notifs)-1)
+}
+
+func (suite *CleanerTestSuite) TestRetentionNotificationsOverLimit() {
+	suite.testRetentionNotificationsOverLimit(context.Background())
+}
+
+func (suite *CleanerTestSuite) TestRetentionNotificationsOverLimitDryRun() {
+	suite.testRetentionNotificationsOverLimit(gtscontext.SetDryRun(context.Background()))
+}
+
+func (suite *CleanerTestSuite) testRetentionNotificationsOverLimit(ctx context.Context) {
+	// Only admin_account has more than one notification.
+	accountID := testrig.NewTestAccounts()["admin_account"].ID
+
+	deleted, err := suite.cleaner.Retention().NotificationsOverLimit(ctx, 1)
+	suite.NoError(err)
+	suite.Equal(1, deleted)
+
+	remaining, err := suite.state.DB.GetAccountNotificationIDsOver(ctx, accountID, 0, 10)
+	suite.NoError(err)
+
+	if gtscontext.DryRun(ctx) {
+		// Nothing should be deleted.
+		suite.Len(remaining, 2)
+	} else {
+		// Newest should be kept.
+		suite.Equal([]string{"01HTM9TETMB3YQCBKZ7KD4KV02"}, remaining)
+	}
+}
+
+func (suite *CleanerTestSuite) TestRetentionUnconfirmedUsers() {
+	suite.testRetentionUnconfirmedUsers(context.Background())
+}
+
+func (suite *CleanerTestSuite) TestRetentionUnconfirmedUsersDryRun() {
+	suite.testRetentionUnconfirmedUsers(gtscontext.SetDryRun(context.Background()))
+}
+
+func (suite *CleanerTestSuite) TestRetentionUnconfirmedUsersLimit() {
+	ctx := context.Background()
+
+	// With no deletes left to queue
+	// this run, nothing is deleted.
+	deleted, err := suite.cleaner.Retention().UnconfirmedUsers(ctx, time.Now(), 0)
+	suite.NoError(err)
+	suite.Zero(deleted)
+
+	_, ok := suite.state.Workers.Client.Queue.Pop()
+	suite.False(ok)
+}
+
+func (suite *CleanerTestSuite) testRetentionUnconfirmedUsers(ctx context.Context) {
+	unconfirmed := testrig.NewTestUsers()["unconfirmed_account"]
+
+	// Nothing is old enough yet.
+	deleted, err := suite.cleaner.Retention().UnconfirmedUsers(ctx, unconfirmed.CreatedAt, 100)
+	suite.NoError(err)
+	suite.Zero(deleted)
+
+	// Only the single unconfirmed user should be deleted.
+	deleted, err = suite.cleaner.Retention().UnconfirmedUsers(ctx, time.Now(), 100)
+	suite.NoError(err)
+	suite.Equal(1, deleted)
+
+	msg, ok := suite.state.Workers.Client.Queue.Pop()
+	if gtscontext.DryRun(ctx) {
+		// Nothing should have been queued.
+		suite.False(ok)
+		return
+	}
+
+	// The delete should have been queued for processing,
+	// in the same way as if the user deleted themselves.
+	if !ok {
+		suite.FailNow("expected queued account delete")
+	}
+	suite.Equal(ap.ObjectProfile, msg.APObjectType)
+	suite.Equal(ap.ActivityDelete, msg.APActivityType)
+	suite.Equal(unconfirmed.AccountID, msg.Origin.ID)
+	suite.Equal(unconfirmed.AccountID, msg.Target.ID)
+
+	// Nothing else should have been queued.
+	_, ok = suite.state.Workers.Client.Queue.Pop()
+	suite.False(ok)
+
+	// Once the account is suspended by the
+	// delete, the user is no longer counted.
+	account, err := suite.state.DB.GetAccountByID(ctx, unconfirmed.AccountID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	account.SuspendedAt = time.Now()
+	suite.NoError(suite.state.DB.UpdateAccount(ctx, account, "suspended_at"))
+
+	deleted, err = suite.cleaner.Retention().UnconfirmedUsers(ctx, time.Now(), 100)
+	suite.NoError(err)
+	suite.Zero(deleted)
+}
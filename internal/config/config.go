@@ -69,6 +69,7 @@ type Configuration struct {
 	DbTLSMode                string        `name:"db-tls-mode" usage:"Database tls mode"`
 	DbTLSCACert              string        `name:"db-tls-ca-cert" usage:"Path to CA cert for db tls connection"`
 	DbMaxOpenConnsMultiplier int           `name:"db-max-open-conns-multiplier" usage:"Multiplier to use per cpu for max open database connections. 0 or less is normalized to 1."`
+	DbReplicaDSNs            []string      `name:"db-replica-dsns" usage:"Postgres only: connection strings of read-only replicas, to spread read-only queries across"`
 	DbSqliteJournalMode      string        `name:"db-sqlite-journal-mode" usage:"Sqlite only: see https://www.sqlite.org/pragma.html#pragma_journal_mode"`
 	DbSqliteSynchronous      string        `name:"db-sqlite-synchronous" usage:"Sqlite only: see https://www.sqlite.org/pragma.html#pragma_synchronous"`
 	DbSqliteCacheSize        bytesize.Size `name:"db-sqlite-cache-size" usage:"Sqlite only: see https://www.sqlite.org/pragma.html#pragma_cache_size"`
@@ -77,39 +78,92 @@ type Configuration struct {
 	WebTemplateBaseDir string `name:"web-template-base-dir" usage:"Basedir for html templating files for rendering pages and composing emails."`
 	WebAssetBaseDir    string `name:"web-asset-base-dir" usage:"Directory to serve static assets from, accessible at example.org/assets/"`
 
-	InstanceFederationMode         string             `name:"instance-federation-mode" usage:"Set instance federation mode."`
-	InstanceFederationSpamFilter   bool               `name:"instance-federation-spam-filter" usage:"Enable basic spam filter heuristics for messages coming from other instances, and drop messages identified as spam"`
-	InstanceExposePeers            bool               `name:"instance-expose-peers" usage:"Allow unauthenticated users to query /api/v1/instance/peers?filter=open"`
-	InstanceExposeSuspended        bool               `name:"instance-expose-suspended" usage:"Expose suspended instances via web UI, and allow unauthenticated users to query /api/v1/instance/peers?filter=suspended"`
-	InstanceExposeSuspendedWeb     bool               `name:"instance-expose-suspended-web" usage:"Expose list of suspended instances as webpage on /about/suspended"`
-	InstanceExposePublicTimeline   bool               `name:"instance-expose-public-timeline" usage:"Allow unauthenticated users to query /api/v1/timelines/public"`
-	InstanceDeliverToSharedInboxes bool               `name:"instance-deliver-to-shared-inboxes" usage:"Deliver federated messages to shared inboxes, if they're available."`
-	InstanceInjectMastodonVersion  bool               `name:"instance-inject-mastodon-version" usage:"This injects a Mastodon compatible version in /api/v1/instance to help Mastodon clients that use that version for feature detection"`
-	InstanceLanguages              language.Languages `name:"instance-languages" usage:"BCP47 language tags for the instance. Used to indicate the preferred languages of instance residents (in order from most-preferred to least-preferred)."`
-
-	AccountsRegistrationOpen bool `name:"accounts-registration-open" usage:"Allow anyone to submit an account signup request. If false, server will be invite-only."`
-	AccountsReasonRequired   bool `name:"accounts-reason-required" usage:"Do new account signups require a reason to be submitted on registration?"`
-	AccountsAllowCustomCSS   bool `name:"accounts-allow-custom-css" usage:"Allow accounts to enable custom CSS for their profile pages and statuses."`
-	AccountsCustomCSSLength  int  `name:"accounts-custom-css-length" usage:"Maximum permitted length (characters) of custom CSS for accounts."`
-
-	MediaImageMaxSize        bytesize.Size `name:"media-image-max-size" usage:"Max size of accepted images in bytes"`
-	MediaVideoMaxSize        bytesize.Size `name:"media-video-max-size" usage:"Max size of accepted videos in bytes"`
-	MediaDescriptionMinChars int           `name:"media-description-min-chars" usage:"Min required chars for an image description"`
-	MediaDescriptionMaxChars int           `name:"media-description-max-chars" usage:"Max permitted chars for an image description"`
-	MediaRemoteCacheDays     int           `name:"media-remote-cache-days" usage:"Number of days to locally cache media from remote instances. If set to 0, remote media will be kept indefinitely."`
-	MediaEmojiLocalMaxSize   bytesize.Size `name:"media-emoji-local-max-size" usage:"Max size in bytes of emojis uploaded to this instance via the admin API."`
-	MediaEmojiRemoteMaxSize  bytesize.Size `name:"media-emoji-remote-max-size" usage:"Max size in bytes of emojis to download from other instances."`
-	MediaCleanupFrom         string        `name:"media-cleanup-from" usage:"Time of day from which to start running media cleanup/prune jobs. Should be in the format 'hh:mm:ss', eg., '15:04:05'."`
-	MediaCleanupEvery        time.Duration `name:"media-cleanup-every" usage:"Period to elapse between cleanups, starting from media-cleanup-at."`
-
-	StorageBackend       string `name:"storage-backend" usage:"Storage backend to use for media attachments"`
-	StorageLocalBasePath string `name:"storage-local-base-path" usage:"Full path to an already-created directory where gts should store/retrieve media files. Subfolders will be created within this dir."`
-	StorageS3Endpoint    string `name:"storage-s3-endpoint" usage:"S3 Endpoint URL (e.g 'minio.example.org:9000')"`
-	StorageS3AccessKey   string `name:"storage-s3-access-key" usage:"S3 Access Key"`
-	StorageS3SecretKey   string `name:"storage-s3-secret-key" usage:"S3 Secret Key"`
-	StorageS3UseSSL      bool   `name:"storage-s3-use-ssl" usage:"Use SSL for S3 connections. Only set this to 'false' when testing locally"`
-	StorageS3BucketName  string `name:"storage-s3-bucket" usage:"Place blobs in this bucket"`
-	StorageS3Proxy       bool   `name:"storage-s3-proxy" usage:"Proxy S3 contents through GoToSocial instead of redirecting to a presigned URL"`
+	WebErrorPageNotFoundMessage string `name:"web-error-page-not-found-message" usage:"Custom message to show on 404 Not Found web pages, in place of the default explanation. May contain basic HTML."`
+	WebErrorPageErrorMessage    string `name:"web-error-page-error-message" usage:"Custom message to show on other error web pages, eg., 500 Internal Server Error. May contain basic HTML."`
+	WebErrorPageContactURL      string `name:"web-error-page-contact-url" usage:"URL to link to from error web pages for contacting the instance admins, eg., a support page or a mailto: link."`
+
+	InstanceFederationMode                           string             `name:"instance-federation-mode" usage:"Set instance federation mode."`
+	InstanceFederationSpamFilter                     bool               `name:"instance-federation-spam-filter" usage:"DEPRECATED: use instance-federation-spam-filter-mode instead. Enable basic spam filter heuristics for messages coming from other instances, and drop messages identified as spam"`
+	InstanceFederationSpamFilterMode                 string             `name:"instance-federation-spam-filter-mode" usage:"Spam filter mode for messages coming from other instances: 'off', 'log' (only log messages identified as spam), or 'drop' (drop messages identified as spam). If not set, falls back to instance-federation-spam-filter."`
+	InstanceFederationQuarantineMismatchedStatuses   bool               `name:"instance-federation-quarantine-mismatched-statuses" usage:"Quarantine federated statuses that are re-delivered via Create with content differing from the stored version, hiding them until reviewed."`
+	InstanceFederationDereferenceBudget              int                `name:"instance-federation-dereference-budget" usage:"Maximum number of remote fetches (accounts, statuses, collection pages, media) that may be made while dereferencing for a single request or activity. 0 or less means no limit."`
+	InstanceDomainPermissionDraftsRequireSecondAdmin bool               `name:"instance-domain-permission-drafts-require-second-admin" usage:"Require domain permission drafts to be accepted by a different admin than the one who created them."`
+	InstanceExposePeers                              bool               `name:"instance-expose-peers" usage:"Allow unauthenticated users to query /api/v1/instance/peers?filter=open"`
+	InstanceExposeSuspended                          bool               `name:"instance-expose-suspended" usage:"Expose suspended instances via web UI, and allow unauthenticated users to query /api/v1/instance/peers?filter=suspended"`
+	InstanceExposeSuspendedWeb                       bool               `name:"instance-expose-suspended-web" usage:"Expose list of suspended instances as webpage on /about/suspended"`
+	InstanceExposePublicTimeline                     bool               `name:"instance-expose-public-timeline" usage:"Allow unauthenticated users to query /api/v1/timelines/public"`
+	InstanceExposePublicTimelineWeb                  bool               `name:"instance-expose-public-timeline-web" usage:"Allow unauthenticated users to view the local and federated public timelines as web pages at /local and /public."`
+	InstanceExposePublicAPI                          bool               `name:"instance-expose-public-api" usage:"Allow unauthenticated, read-only access to the public timeline, and to public account info + statuses via the client API."`
+	InstanceExposeTagFeeds                           []string           `name:"instance-expose-tag-feeds" usage:"Hashtags to serve public RSS and Atom feeds of local posts for, at /tags/:name.rss and /tags/:name.atom. Use '*' for all hashtags."`
+	InstanceDefaultTheme                             string             `name:"instance-default-theme" usage:"Filename of the CSS theme (from web-asset-base-dir/themes) to use for web pages, when an account has not selected a theme of its own. Empty to use the plain default style."`
+	InstanceDeliverToSharedInboxes                   bool               `name:"instance-deliver-to-shared-inboxes" usage:"Deliver federated messages to shared inboxes, if they're available."`
+	InstanceInjectMastodonVersion                    bool               `name:"instance-inject-mastodon-version" usage:"This injects a Mastodon compatible version in /api/v1/instance to help Mastodon clients that use that version for feature detection"`
+	InstanceLanguages                                language.Languages `name:"instance-languages" usage:"BCP47 language tags for the instance. Used to indicate the preferred languages of instance residents (in order from most-preferred to least-preferred)."`
+
+	AccountsRegistrationOpen                  bool   `name:"accounts-registration-open" usage:"Allow anyone to submit an account signup request. If false, server will be invite-only."`
+	AccountsReasonRequired                    bool   `name:"accounts-reason-required" usage:"Do new account signups require a reason to be submitted on registration?"`
+	AccountsRegistrationIPDailyLimit          int    `name:"accounts-registration-ip-daily-limit" usage:"Maximum number of account signups permitted from a single IP address in 24 hours. If set to 0, there is no limit."`
+	AccountsRegistrationEmailDomainDailyLimit int    `name:"accounts-registration-email-domain-daily-limit" usage:"Maximum number of account signups permitted with email addresses at a single domain in 24 hours. If set to 0, there is no limit."`
+	AccountsDisposableEmailMode               string `name:"accounts-disposable-email-mode" usage:"What to do with account signups using an email address at a known disposable email domain: 'reject' (refuse the signup), 'flag' (accept the signup but warn moderators), or 'allow' (do nothing)."`
+	AccountsDisposableEmailListURL            string `name:"accounts-disposable-email-list-url" usage:"URL of a newline-separated list of disposable email domains to fetch daily, in addition to the bundled list. If empty, only the bundled list is used."`
+	AccountsInvitesEnabled                    bool   `name:"accounts-invites-enabled" usage:"Allow local users to create invite codes which others can use to sign up. Admins can always create invite codes."`
+	AccountsInvitesBypassApproval             bool   `name:"accounts-invites-bypass-approval" usage:"Automatically approve sign-ups made with a valid invite code, instead of leaving them for an admin to approve."`
+	AccountsInvitesBypassClosedRegistration   bool   `name:"accounts-invites-bypass-closed-registration" usage:"Allow sign-ups made with a valid invite code even when accounts-registration-open is false."`
+	AccountsAllowCustomCSS                    bool   `name:"accounts-allow-custom-css" usage:"Allow accounts to enable custom CSS for their profile pages and statuses."`
+	AccountsCustomCSSLength                   int    `name:"accounts-custom-css-length" usage:"Maximum permitted length (characters) of custom CSS for accounts."`
+	AccountsDefaultNoIndex                    bool   `name:"accounts-default-noindex" usage:"Opt new accounts out of search engine indexing of their profile and statuses by default. Accounts can change this in their settings."`
+	AccountsRemoteRefreshDays                 int    `name:"accounts-remote-refresh-days" usage:"Number of days after which remote accounts are re-fetched in the background, if nothing else has refreshed them. If set to 0, background refreshing is disabled."`
+	AccountsRemoteRefreshPerDomain            int    `name:"accounts-remote-refresh-per-domain" usage:"Maximum number of remote accounts to refresh concurrently per remote domain during background refreshing."`
+
+	MediaImageMaxSize                 bytesize.Size `name:"media-image-max-size" usage:"Max size of accepted images in bytes"`
+	MediaVideoMaxSize                 bytesize.Size `name:"media-video-max-size" usage:"Max size of accepted videos in bytes"`
+	MediaImageQuality                 int           `name:"media-image-quality" usage:"JPEG quality (1-100) to use when encoding thumbnails, and downscaled or reoriented images"`
+	MediaImageMaxResolution           int           `name:"media-image-max-resolution" usage:"Max width or height in pixels of stored images; larger images will be downscaled to fit. If set to 0, images will be stored at their original resolution."`
+	MediaDescriptionMinChars          int           `name:"media-description-min-chars" usage:"Min required chars for an image description"`
+	MediaDescriptionMaxChars          int           `name:"media-description-max-chars" usage:"Max permitted chars for an image description"`
+	MediaRemoteCacheDays              int           `name:"media-remote-cache-days" usage:"Number of days to locally cache media from remote instances. If set to 0, remote media will be kept indefinitely."`
+	MediaRemoteStripMetadata          bool          `name:"media-remote-strip-metadata" usage:"Strip EXIF/XMP metadata from cached remote media, as is always done for local uploads. If false, remote media is cached as-is."`
+	MediaEmojiLocalMaxSize            bytesize.Size `name:"media-emoji-local-max-size" usage:"Max size in bytes of emojis uploaded to this instance via the admin API."`
+	MediaEmojiRemoteMaxSize           bytesize.Size `name:"media-emoji-remote-max-size" usage:"Max size in bytes of emojis to download from other instances."`
+	MediaCleanupFrom                  string        `name:"media-cleanup-from" usage:"Time of day from which to start running media cleanup/prune jobs. Should be in the format 'hh:mm:ss', eg., '15:04:05'."`
+	MediaCleanupEvery                 time.Duration `name:"media-cleanup-every" usage:"Period to elapse between cleanups, starting from media-cleanup-at."`
+	MediaClamAVAddress                string        `name:"media-clamav-address" usage:"Address of a ClamAV daemon to scan uploaded media with; either a unix socket path, or host:port for tcp. If empty, uploads will not be scanned."`
+	MediaClamAVAction                 string        `name:"media-clamav-action" usage:"What to do with uploaded media found to be infected by ClamAV: reject (discard it), or quarantine (reject the upload, but keep the file in storage for admin review)."`
+	MediaClamAVTimeout                time.Duration `name:"media-clamav-timeout" usage:"Maximum time to wait for ClamAV to scan an uploaded file."`
+	MediaFFmpegPath                   string        `name:"media-ffmpeg-path" usage:"Path to an ffmpeg binary, used to convert large animated GIFs to soundless looping MP4 (gifv), and to extract video frames for thumbnails. If empty, GIFs are stored as-is."`
+	MediaGIFConvertMinSize            bytesize.Size `name:"media-gif-convert-min-size" usage:"Min size in bytes of animated GIFs to convert to MP4, when media-ffmpeg-path is set."`
+	MediaResumableUploadMinSize       bytesize.Size `name:"media-resumable-upload-min-size" usage:"Min size in bytes of media that may be uploaded in chunks with the resumable upload API. Smaller media should be uploaded in one request."`
+	MediaResumableUploadMinPartSize   bytesize.Size `name:"media-resumable-upload-min-part-size" usage:"Min size in bytes of each part of a resumable upload, except the last. Keeps uploads from being split into countless tiny parts."`
+	MediaResumableUploadExpiry        time.Duration `name:"media-resumable-upload-expiry" usage:"Time after which unfinished resumable uploads are discarded."`
+	MediaResumableUploadMaxPerAccount int           `name:"media-resumable-upload-max-per-account" usage:"Max number of unfinished resumable uploads each account may have at once."`
+	MediaEncodeConcurrency            int           `name:"media-encode-concurrency" usage:"Max number of images to decode and encode (thumbnails, blurhashes, etc) at once when processing media. 0 or less is normalized to the number of cpus."`
+
+	RetentionLocalStatusDays           int  `name:"retention-local-status-days" usage:"Number of days after which local statuses are deleted, unless pinned or bookmarked. If set to 0, local statuses will be kept indefinitely."`
+	RetentionNotificationDays          int  `name:"retention-notification-days" usage:"Number of days after which notifications are deleted. If set to 0, notifications will be kept indefinitely."`
+	RetentionReadNotificationDays      int  `name:"retention-read-notification-days" usage:"Number of days after which notifications that have been marked as read are deleted. If set to 0, read notifications are treated like any other notification."`
+	RetentionNotificationMaxPerAccount int  `name:"retention-notification-max-per-account" usage:"Maximum number of notifications to keep per account, the oldest are deleted first. If set to 0, there is no limit."`
+	RetentionRemoteStatusDays          int  `name:"retention-remote-status-days" usage:"Number of days after which remote statuses with no local interactions are removed from the database. If set to 0, remote statuses will be kept indefinitely."`
+	RetentionRemoteStatusArchive       bool `name:"retention-remote-status-archive" usage:"Move expired remote statuses into a compressed archive table instead of deleting them outright."`
+	RetentionUnconfirmedUserDays       int  `name:"retention-unconfirmed-user-days" usage:"Number of days after which users who never confirmed their email address are deleted. If set to 0, unconfirmed users will be kept indefinitely."`
+	RetentionDryRun                    bool `name:"retention-dry-run" usage:"Only log what the retention cleaner would delete, without deleting anything."`
+
+	StorageBackend            string `name:"storage-backend" usage:"Storage backend to use for media attachments"`
+	StorageLocalBasePath      string `name:"storage-local-base-path" usage:"Full path to an already-created directory where gts should store/retrieve media files. Subfolders will be created within this dir."`
+	StorageS3Endpoint         string `name:"storage-s3-endpoint" usage:"S3 Endpoint URL (e.g 'minio.example.org:9000')"`
+	StorageS3AccessKey        string `name:"storage-s3-access-key" usage:"S3 Access Key"`
+	StorageS3SecretKey        string `name:"storage-s3-secret-key" usage:"S3 Secret Key"`
+	StorageS3UseSSL           bool   `name:"storage-s3-use-ssl" usage:"Use SSL for S3 connections. Only set this to 'false' when testing locally"`
+	StorageS3BucketName       string `name:"storage-s3-bucket" usage:"Place blobs in this bucket"`
+	StorageS3Proxy            bool   `name:"storage-s3-proxy" usage:"Proxy S3 contents through GoToSocial instead of redirecting to a presigned URL"`
+	StorageS3BucketPublic     bool   `name:"storage-s3-bucket-public" usage:"Bucket contents are publicly readable, so redirect to plain object URLs instead of short-lived presigned URLs. Has no effect if storage-s3-proxy is set"`
+	StorageGCSBucket          string `name:"storage-gcs-bucket" usage:"Google Cloud Storage bucket to place blobs in"`
+	StorageGCSCredentialsFile string `name:"storage-gcs-credentials-file" usage:"Path to the JSON key file of a Google Cloud service account with access to the bucket"`
+	StorageGCSProxy           bool   `name:"storage-gcs-proxy" usage:"Proxy Google Cloud Storage contents through GoToSocial instead of redirecting to a signed URL"`
+	StorageAzureAccountName   string `name:"storage-azure-account-name" usage:"Azure storage account name"`
+	StorageAzureAccountKey    string `name:"storage-azure-account-key" usage:"Azure storage account access key"`
+	StorageAzureContainer     string `name:"storage-azure-container" usage:"Azure Blob container to place blobs in"`
+	StorageAzureEndpoint      string `name:"storage-azure-endpoint" usage:"Azure Blob service endpoint URL. If empty, the public Azure endpoint for the storage account is used"`
+	StorageAzureProxy         bool   `name:"storage-azure-proxy" usage:"Proxy Azure Blob contents through GoToSocial instead of redirecting to a SAS URL"`
 
 	StatusesMaxChars           int `name:"statuses-max-chars" usage:"Max permitted characters for posted statuses, including content warning"`
 	StatusesPollMaxOptions     int `name:"statuses-poll-max-options" usage:"Max amount of options permitted on a poll"`
@@ -165,6 +219,17 @@ type Configuration struct {
 	AdvancedCSPExtraURIs         []string      `name:"advanced-csp-extra-uris" usage:"Additional URIs to allow when building content-security-policy for media + images."`
 	AdvancedHeaderFilterMode     string        `name:"advanced-header-filter-mode" usage:"Set incoming request header filtering mode."`
 
+	AdvancedRateLimitUnauthenticatedRequests int `name:"advanced-rate-limit-unauthenticated-requests" usage:"Amount of requests to the read-only public client API without a valid access token to permit within a 5 minute window. 0 or less turns this (stricter) rate limit off."`
+
+	AdvancedStreamingMaxConnections           int `name:"advanced-streaming-max-connections" usage:"Max number of simultaneous streaming API connections across all accounts. 0 or less means no limit."`
+	AdvancedStreamingMaxConnectionsPerAccount int `name:"advanced-streaming-max-connections-per-account" usage:"Max number of simultaneous streaming API connections per account. 0 or less means no limit."`
+	AdvancedStreamingQueueSize                int `name:"advanced-streaming-queue-size" usage:"Number of messages to queue per streaming API connection; clients that fall further behind than this are disconnected."`
+
+	AdvancedLoadSheddingLatency    time.Duration `name:"advanced-load-shedding-latency" usage:"Average request latency above which low-priority requests (public timelines, search) are rejected with 503. 0 turns latency-based shedding off."`
+	AdvancedLoadSheddingQueueDepth int           `name:"advanced-load-shedding-queue-depth" usage:"Number of in-flight client API requests above which low-priority requests (public timelines, search) are rejected with 503. 0 or less turns queue-based shedding off."`
+
+	AdvancedConversionReport bool `name:"advanced-conversion-report" usage:"Record inbound ActivityPub properties that aren't mapped when converting statuses and accounts, viewable via the admin API."`
+
 	// HTTPClient configuration vars.
 	HTTPClient HTTPClientConfiguration `name:"http-client"`
 
@@ -172,13 +237,18 @@ type Configuration struct {
 	Cache CacheConfiguration `name:"cache"`
 
 	// TODO: move these elsewhere, these are more ephemeral vs long-running flags like above
-	AdminAccountUsername     string `name:"username" usage:"the username to create/delete/etc"`
-	AdminAccountEmail        string `name:"email" usage:"the email address of this account"`
-	AdminAccountPassword     string `name:"password" usage:"the password to set for this account"`
-	AdminTransPath           string `name:"path" usage:"the path of the file to import from/export to"`
-	AdminMediaPruneDryRun    bool   `name:"dry-run" usage:"perform a dry run and only log number of items eligible for pruning"`
-	AdminMediaListLocalOnly  bool   `name:"local-only" usage:"list only local attachments/emojis; if specified then remote-only cannot also be true"`
-	AdminMediaListRemoteOnly bool   `name:"remote-only" usage:"list only remote attachments/emojis; if specified then local-only cannot also be true"`
+	AdminAccountUsername      string `name:"username" usage:"the username to create/delete/etc"`
+	AdminAccountEmail         string `name:"email" usage:"the email address of this account"`
+	AdminAccountPassword      string `name:"password" usage:"the password to set for this account"`
+	AdminTransPath            string `name:"path" usage:"the path of the file to import from/export to"`
+	AdminMediaPruneDryRun     bool   `name:"dry-run" usage:"perform a dry run and only log number of items eligible for pruning"`
+	AdminMediaListLocalOnly   bool   `name:"local-only" usage:"list only local attachments/emojis; if specified then remote-only cannot also be true"`
+	AdminMediaListRemoteOnly  bool   `name:"remote-only" usage:"list only remote attachments/emojis; if specified then local-only cannot also be true"`
+	AdminDomain               string `name:"domain" usage:"the domain to allow/unallow"`
+	AdminDomainPrivateComment string `name:"private-comment" usage:"private comment to store with the domain allow"`
+	AdminEmojiPackCategory    string `name:"category" usage:"only export emojis in this category, or put imported emojis in this category"`
+	AdminEmojiPackOnConflict  string `name:"on-conflict" usage:"what to do when an imported emoji shortcode already exists: skip or replace"`
+	TestrigSeedProfile        string `name:"profile" usage:"size of the dataset to seed the instance with: small or large"`
 
 	RequestIDHeader string `name:"request-id-header" usage:"Header to extract the Request ID from. Eg.,'X-Request-Id'."`
 }
@@ -188,10 +258,14 @@ type HTTPClientConfiguration struct {
 	BlockIPs              []string      `name:"block-ips"`
 	Timeout               time.Duration `name:"timeout"`
 	TLSInsecureSkipVerify bool          `name:"tls-insecure-skip-verify"`
+	MaxIdleConnsPerHost   int           `name:"max-idle-conns-per-host"`
+	IdleConnTimeout       time.Duration `name:"idle-conn-timeout"`
+	DisableHTTP2          bool          `name:"disable-http2"`
 }
 
 type CacheConfiguration struct {
 	MemoryTarget              bytesize.Size `name:"memory-target"`
+	InvalidationBus           string        `name:"invalidation-bus"`
 	AccountMemRatio           float64       `name:"account-mem-ratio"`
 	AccountNoteMemRatio       float64       `name:"account-note-mem-ratio"`
 	AccountSettingsMemRatio   float64       `name:"account-settings-mem-ratio"`
@@ -29,4 +29,26 @@ const (
 	RequestHeaderFilterModeAllow    = "allow"
 	RequestHeaderFilterModeBlock    = "block"
 	RequestHeaderFilterModeDisabled = ""
+
+	// Spam filter mode determines what this instance
+	// does with incoming messages identified as spam.
+	SpamFilterModeOff  = "off"
+	SpamFilterModeLog  = "log"
+	SpamFilterModeDrop = "drop"
+
+	// Disposable email mode determines what this instance
+	// does with sign-ups using a disposable email domain.
+	DisposableEmailModeReject = "reject"
+	DisposableEmailModeFlag   = "flag"
+	DisposableEmailModeAllow  = "allow"
+
+	// ClamAV action determines what this instance does
+	// with uploaded media found to be infected by ClamAV.
+	MediaClamAVActionReject     = "reject"
+	MediaClamAVActionQuarantine = "quarantine"
+
+	// Cache invalidation bus determines how this instance
+	// propagates cache invalidations to other processes.
+	CacheInvalidationBusNone     = ""
+	CacheInvalidationBusPostgres = "postgres"
 )
@@ -50,6 +50,7 @@ var Defaults = Configuration{
 	DbTLSMode:                "disable",
 	DbTLSCACert:              "",
 	DbMaxOpenConnsMultiplier: 8,
+	DbReplicaDSNs:            []string{},
 	DbSqliteJournalMode:      "WAL",
 	DbSqliteSynchronous:      "NORMAL",
 	DbSqliteCacheSize:        8 * bytesize.MiB,
@@ -58,33 +59,65 @@ var Defaults = Configuration{
 	WebTemplateBaseDir: "./web/template/",
 	WebAssetBaseDir:    "./web/assets/",
 
-	InstanceFederationMode:         InstanceFederationModeDefault,
-	InstanceFederationSpamFilter:   false,
-	InstanceExposePeers:            false,
-	InstanceExposeSuspended:        false,
-	InstanceExposeSuspendedWeb:     false,
-	InstanceDeliverToSharedInboxes: true,
-	InstanceLanguages:              make(language.Languages, 0),
-
-	AccountsRegistrationOpen: false,
-	AccountsReasonRequired:   true,
-	AccountsAllowCustomCSS:   false,
-	AccountsCustomCSSLength:  10000,
-
-	MediaImageMaxSize:        10 * bytesize.MiB,
-	MediaVideoMaxSize:        40 * bytesize.MiB,
-	MediaDescriptionMinChars: 0,
-	MediaDescriptionMaxChars: 1500,
-	MediaRemoteCacheDays:     7,
-	MediaEmojiLocalMaxSize:   50 * bytesize.KiB,
-	MediaEmojiRemoteMaxSize:  100 * bytesize.KiB,
-	MediaCleanupFrom:         "00:00",        // Midnight.
-	MediaCleanupEvery:        24 * time.Hour, // 1/day.
-
-	StorageBackend:       "local",
-	StorageLocalBasePath: "/gotosocial/storage",
-	StorageS3UseSSL:      true,
-	StorageS3Proxy:       false,
+	InstanceFederationMode:                           InstanceFederationModeDefault,
+	InstanceFederationSpamFilter:                     false,
+	InstanceFederationSpamFilterMode:                 "",
+	InstanceFederationQuarantineMismatchedStatuses:   false,
+	InstanceFederationDereferenceBudget:              1000,
+	InstanceDomainPermissionDraftsRequireSecondAdmin: false,
+	InstanceExposePeers:                              false,
+	InstanceExposeSuspended:                          false,
+	InstanceExposeSuspendedWeb:                       false,
+	InstanceExposePublicTimelineWeb:                  false,
+	InstanceExposeTagFeeds:                           []string{},
+	InstanceDefaultTheme:                             "",
+	InstanceDeliverToSharedInboxes:                   true,
+	InstanceLanguages:                                make(language.Languages, 0),
+
+	AccountsRegistrationOpen:                  false,
+	AccountsReasonRequired:                    true,
+	AccountsRegistrationIPDailyLimit:          5,
+	AccountsRegistrationEmailDomainDailyLimit: 0,
+	AccountsDisposableEmailMode:               DisposableEmailModeFlag,
+	AccountsDisposableEmailListURL:            "",
+	AccountsInvitesEnabled:                    false,
+	AccountsInvitesBypassApproval:             false,
+	AccountsInvitesBypassClosedRegistration:   true,
+	AccountsAllowCustomCSS:                    false,
+	AccountsCustomCSSLength:                   10000,
+	AccountsDefaultNoIndex:                    false,
+	AccountsRemoteRefreshDays:                 30,
+	AccountsRemoteRefreshPerDomain:            2,
+
+	MediaImageMaxSize:                 10 * bytesize.MiB,
+	MediaVideoMaxSize:                 40 * bytesize.MiB,
+	MediaImageQuality:                 70,
+	MediaImageMaxResolution:           0,
+	MediaDescriptionMinChars:          0,
+	MediaDescriptionMaxChars:          1500,
+	MediaRemoteCacheDays:              7,
+	MediaRemoteStripMetadata:          true,
+	MediaEmojiLocalMaxSize:            50 * bytesize.KiB,
+	MediaEmojiRemoteMaxSize:           100 * bytesize.KiB,
+	MediaCleanupFrom:                  "00:00",        // Midnight.
+	MediaCleanupEvery:                 24 * time.Hour, // 1/day.
+	MediaClamAVAddress:                "",
+	MediaClamAVAction:                 MediaClamAVActionReject,
+	MediaClamAVTimeout:                30 * time.Second,
+	MediaFFmpegPath:                   "",
+	MediaGIFConvertMinSize:            1 * bytesize.MiB,
+	MediaResumableUploadMinSize:       5 * bytesize.MiB,
+	MediaResumableUploadMinPartSize:   1 * bytesize.MiB,
+	MediaResumableUploadExpiry:        24 * time.Hour,
+	MediaResumableUploadMaxPerAccount: 4,
+
+	StorageBackend:        "local",
+	StorageLocalBasePath:  "/gotosocial/storage",
+	StorageS3UseSSL:       true,
+	StorageS3Proxy:        false,
+	StorageS3BucketPublic: false,
+	StorageGCSProxy:       false,
+	StorageAzureProxy:     false,
 
 	StatusesMaxChars:           5000,
 	StatusesPollMaxOptions:     6,
@@ -136,6 +169,17 @@ var Defaults = Configuration{
 	AdvancedCSPExtraURIs:         []string{},
 	AdvancedHeaderFilterMode:     RequestHeaderFilterModeDisabled,
 
+	AdvancedRateLimitUnauthenticatedRequests: 100, // 1 per 3 seconds per 5 minutes
+
+	AdvancedStreamingMaxConnections:           0,
+	AdvancedStreamingMaxConnectionsPerAccount: 10,
+	AdvancedStreamingQueueSize:                50,
+
+	AdvancedLoadSheddingLatency:    time.Second * 2,
+	AdvancedLoadSheddingQueueDepth: 100,
+
+	AdvancedConversionReport: false,
+
 	Cache: CacheConfiguration{
 		// Rough memory target that the total
 		// size of all State.Caches will attempt
@@ -208,6 +252,9 @@ var Defaults = Configuration{
 		BlockIPs:              make([]string, 0),
 		Timeout:               10 * time.Second,
 		TLSInsecureSkipVerify: false,
+		MaxIdleConnsPerHost:   0, // auto
+		IdleConnTimeout:       90 * time.Second,
+		DisableHTTP2:          false,
 	},
 
 	AdminMediaPruneDryRun: true,
@@ -52,6 +52,7 @@ func (s *ConfigState) AddGlobalFlags(cmd *cobra.Command) {
 		cmd.PersistentFlags().String(DbTLSModeFlag(), cfg.DbTLSMode, fieldtag("DbTLSMode", "usage"))
 		cmd.PersistentFlags().String(DbTLSCACertFlag(), cfg.DbTLSCACert, fieldtag("DbTLSCACert", "usage"))
 		cmd.PersistentFlags().Int(DbMaxOpenConnsMultiplierFlag(), cfg.DbMaxOpenConnsMultiplier, fieldtag("DbMaxOpenConnsMultiplier", "usage"))
+		cmd.PersistentFlags().StringSlice(DbReplicaDSNsFlag(), cfg.DbReplicaDSNs, fieldtag("DbReplicaDSNs", "usage"))
 		cmd.PersistentFlags().String(DbSqliteJournalModeFlag(), cfg.DbSqliteJournalMode, fieldtag("DbSqliteJournalMode", "usage"))
 		cmd.PersistentFlags().String(DbSqliteSynchronousFlag(), cfg.DbSqliteSynchronous, fieldtag("DbSqliteSynchronous", "usage"))
 		cmd.PersistentFlags().Uint64(DbSqliteCacheSizeFlag(), uint64(cfg.DbSqliteCacheSize), fieldtag("DbSqliteCacheSize", "usage"))
@@ -62,6 +63,9 @@ func (s *ConfigState) AddGlobalFlags(cmd *cobra.Command) {
 		cmd.PersistentFlags().StringSlice(HTTPClientBlockIPsFlag(), cfg.HTTPClient.BlockIPs, "no usage string")
 		cmd.PersistentFlags().Duration(HTTPClientTimeoutFlag(), cfg.HTTPClient.Timeout, "no usage string")
 		cmd.PersistentFlags().Bool(HTTPClientTLSInsecureSkipVerifyFlag(), cfg.HTTPClient.TLSInsecureSkipVerify, "no usage string")
+		cmd.PersistentFlags().Int(HTTPClientMaxIdleConnsPerHostFlag(), cfg.HTTPClient.MaxIdleConnsPerHost, "no usage string")
+		cmd.PersistentFlags().Duration(HTTPClientIdleConnTimeoutFlag(), cfg.HTTPClient.IdleConnTimeout, "no usage string")
+		cmd.PersistentFlags().Bool(HTTPClientDisableHTTP2Flag(), cfg.HTTPClient.DisableHTTP2, "no usage string")
 	})
 }
 
@@ -81,31 +85,75 @@ func (s *ConfigState) AddServerFlags(cmd *cobra.Command) {
 		// Template
 		cmd.Flags().String(WebTemplateBaseDirFlag(), cfg.WebTemplateBaseDir, fieldtag("WebTemplateBaseDir", "usage"))
 		cmd.Flags().String(WebAssetBaseDirFlag(), cfg.WebAssetBaseDir, fieldtag("WebAssetBaseDir", "usage"))
+		cmd.Flags().String(WebErrorPageNotFoundMessageFlag(), cfg.WebErrorPageNotFoundMessage, fieldtag("WebErrorPageNotFoundMessage", "usage"))
+		cmd.Flags().String(WebErrorPageErrorMessageFlag(), cfg.WebErrorPageErrorMessage, fieldtag("WebErrorPageErrorMessage", "usage"))
+		cmd.Flags().String(WebErrorPageContactURLFlag(), cfg.WebErrorPageContactURL, fieldtag("WebErrorPageContactURL", "usage"))
 
 		// Instance
 		cmd.Flags().String(InstanceFederationModeFlag(), cfg.InstanceFederationMode, fieldtag("InstanceFederationMode", "usage"))
 		cmd.Flags().Bool(InstanceFederationSpamFilterFlag(), cfg.InstanceFederationSpamFilter, fieldtag("InstanceFederationSpamFilter", "usage"))
+		cmd.Flags().String(InstanceFederationSpamFilterModeFlag(), cfg.InstanceFederationSpamFilterMode, fieldtag("InstanceFederationSpamFilterMode", "usage"))
+		cmd.Flags().Bool(InstanceFederationQuarantineMismatchedStatusesFlag(), cfg.InstanceFederationQuarantineMismatchedStatuses, fieldtag("InstanceFederationQuarantineMismatchedStatuses", "usage"))
+		cmd.Flags().Int(InstanceFederationDereferenceBudgetFlag(), cfg.InstanceFederationDereferenceBudget, fieldtag("InstanceFederationDereferenceBudget", "usage"))
+		cmd.Flags().Bool(InstanceDomainPermissionDraftsRequireSecondAdminFlag(), cfg.InstanceDomainPermissionDraftsRequireSecondAdmin, fieldtag("InstanceDomainPermissionDraftsRequireSecondAdmin", "usage"))
 		cmd.Flags().Bool(InstanceExposePeersFlag(), cfg.InstanceExposePeers, fieldtag("InstanceExposePeers", "usage"))
 		cmd.Flags().Bool(InstanceExposeSuspendedFlag(), cfg.InstanceExposeSuspended, fieldtag("InstanceExposeSuspended", "usage"))
 		cmd.Flags().Bool(InstanceExposeSuspendedWebFlag(), cfg.InstanceExposeSuspendedWeb, fieldtag("InstanceExposeSuspendedWeb", "usage"))
+		cmd.Flags().Bool(InstanceExposePublicTimelineWebFlag(), cfg.InstanceExposePublicTimelineWeb, fieldtag("InstanceExposePublicTimelineWeb", "usage"))
+		cmd.Flags().Bool(InstanceExposePublicAPIFlag(), cfg.InstanceExposePublicAPI, fieldtag("InstanceExposePublicAPI", "usage"))
+		cmd.Flags().StringSlice(InstanceExposeTagFeedsFlag(), cfg.InstanceExposeTagFeeds, fieldtag("InstanceExposeTagFeeds", "usage"))
+		cmd.Flags().String(InstanceDefaultThemeFlag(), cfg.InstanceDefaultTheme, fieldtag("InstanceDefaultTheme", "usage"))
 		cmd.Flags().Bool(InstanceDeliverToSharedInboxesFlag(), cfg.InstanceDeliverToSharedInboxes, fieldtag("InstanceDeliverToSharedInboxes", "usage"))
 		cmd.Flags().StringSlice(InstanceLanguagesFlag(), cfg.InstanceLanguages.TagStrs(), fieldtag("InstanceLanguages", "usage"))
 
 		// Accounts
 		cmd.Flags().Bool(AccountsRegistrationOpenFlag(), cfg.AccountsRegistrationOpen, fieldtag("AccountsRegistrationOpen", "usage"))
 		cmd.Flags().Bool(AccountsReasonRequiredFlag(), cfg.AccountsReasonRequired, fieldtag("AccountsReasonRequired", "usage"))
+		cmd.Flags().Int(AccountsRegistrationIPDailyLimitFlag(), cfg.AccountsRegistrationIPDailyLimit, fieldtag("AccountsRegistrationIPDailyLimit", "usage"))
+		cmd.Flags().Int(AccountsRegistrationEmailDomainDailyLimitFlag(), cfg.AccountsRegistrationEmailDomainDailyLimit, fieldtag("AccountsRegistrationEmailDomainDailyLimit", "usage"))
+		cmd.Flags().String(AccountsDisposableEmailModeFlag(), cfg.AccountsDisposableEmailMode, fieldtag("AccountsDisposableEmailMode", "usage"))
+		cmd.Flags().String(AccountsDisposableEmailListURLFlag(), cfg.AccountsDisposableEmailListURL, fieldtag("AccountsDisposableEmailListURL", "usage"))
+		cmd.Flags().Bool(AccountsInvitesEnabledFlag(), cfg.AccountsInvitesEnabled, fieldtag("AccountsInvitesEnabled", "usage"))
+		cmd.Flags().Bool(AccountsInvitesBypassApprovalFlag(), cfg.AccountsInvitesBypassApproval, fieldtag("AccountsInvitesBypassApproval", "usage"))
+		cmd.Flags().Bool(AccountsInvitesBypassClosedRegistrationFlag(), cfg.AccountsInvitesBypassClosedRegistration, fieldtag("AccountsInvitesBypassClosedRegistration", "usage"))
 		cmd.Flags().Bool(AccountsAllowCustomCSSFlag(), cfg.AccountsAllowCustomCSS, fieldtag("AccountsAllowCustomCSS", "usage"))
+		cmd.Flags().Bool(AccountsDefaultNoIndexFlag(), cfg.AccountsDefaultNoIndex, fieldtag("AccountsDefaultNoIndex", "usage"))
+		cmd.Flags().Int(AccountsRemoteRefreshDaysFlag(), cfg.AccountsRemoteRefreshDays, fieldtag("AccountsRemoteRefreshDays", "usage"))
+		cmd.Flags().Int(AccountsRemoteRefreshPerDomainFlag(), cfg.AccountsRemoteRefreshPerDomain, fieldtag("AccountsRemoteRefreshPerDomain", "usage"))
 
 		// Media
 		cmd.Flags().Uint64(MediaImageMaxSizeFlag(), uint64(cfg.MediaImageMaxSize), fieldtag("MediaImageMaxSize", "usage"))
 		cmd.Flags().Uint64(MediaVideoMaxSizeFlag(), uint64(cfg.MediaVideoMaxSize), fieldtag("MediaVideoMaxSize", "usage"))
+		cmd.Flags().Int(MediaImageQualityFlag(), cfg.MediaImageQuality, fieldtag("MediaImageQuality", "usage"))
+		cmd.Flags().Int(MediaImageMaxResolutionFlag(), cfg.MediaImageMaxResolution, fieldtag("MediaImageMaxResolution", "usage"))
 		cmd.Flags().Int(MediaDescriptionMinCharsFlag(), cfg.MediaDescriptionMinChars, fieldtag("MediaDescriptionMinChars", "usage"))
 		cmd.Flags().Int(MediaDescriptionMaxCharsFlag(), cfg.MediaDescriptionMaxChars, fieldtag("MediaDescriptionMaxChars", "usage"))
 		cmd.Flags().Int(MediaRemoteCacheDaysFlag(), cfg.MediaRemoteCacheDays, fieldtag("MediaRemoteCacheDays", "usage"))
+		cmd.Flags().Bool(MediaRemoteStripMetadataFlag(), cfg.MediaRemoteStripMetadata, fieldtag("MediaRemoteStripMetadata", "usage"))
 		cmd.Flags().Uint64(MediaEmojiLocalMaxSizeFlag(), uint64(cfg.MediaEmojiLocalMaxSize), fieldtag("MediaEmojiLocalMaxSize", "usage"))
 		cmd.Flags().Uint64(MediaEmojiRemoteMaxSizeFlag(), uint64(cfg.MediaEmojiRemoteMaxSize), fieldtag("MediaEmojiRemoteMaxSize", "usage"))
 		cmd.Flags().String(MediaCleanupFromFlag(), cfg.MediaCleanupFrom, fieldtag("MediaCleanupFrom", "usage"))
 		cmd.Flags().Duration(MediaCleanupEveryFlag(), cfg.MediaCleanupEvery, fieldtag("MediaCleanupEvery", "usage"))
+		cmd.Flags().String(MediaClamAVAddressFlag(), cfg.MediaClamAVAddress, fieldtag("MediaClamAVAddress", "usage"))
+		cmd.Flags().String(MediaClamAVActionFlag(), cfg.MediaClamAVAction, fieldtag("MediaClamAVAction", "usage"))
+		cmd.Flags().Duration(MediaClamAVTimeoutFlag(), cfg.MediaClamAVTimeout, fieldtag("MediaClamAVTimeout", "usage"))
+		cmd.Flags().String(MediaFFmpegPathFlag(), cfg.MediaFFmpegPath, fieldtag("MediaFFmpegPath", "usage"))
+		cmd.Flags().Uint64(MediaGIFConvertMinSizeFlag(), uint64(cfg.MediaGIFConvertMinSize), fieldtag("MediaGIFConvertMinSize", "usage"))
+		cmd.Flags().Uint64(MediaResumableUploadMinSizeFlag(), uint64(cfg.MediaResumableUploadMinSize), fieldtag("MediaResumableUploadMinSize", "usage"))
+		cmd.Flags().Uint64(MediaResumableUploadMinPartSizeFlag(), uint64(cfg.MediaResumableUploadMinPartSize), fieldtag("MediaResumableUploadMinPartSize", "usage"))
+		cmd.Flags().Duration(MediaResumableUploadExpiryFlag(), cfg.MediaResumableUploadExpiry, fieldtag("MediaResumableUploadExpiry", "usage"))
+		cmd.Flags().Int(MediaResumableUploadMaxPerAccountFlag(), cfg.MediaResumableUploadMaxPerAccount, fieldtag("MediaResumableUploadMaxPerAccount", "usage"))
+		cmd.Flags().Int(MediaEncodeConcurrencyFlag(), cfg.MediaEncodeConcurrency, fieldtag("MediaEncodeConcurrency", "usage"))
+
+		// Retention
+		cmd.Flags().Int(RetentionLocalStatusDaysFlag(), cfg.RetentionLocalStatusDays, fieldtag("RetentionLocalStatusDays", "usage"))
+		cmd.Flags().Int(RetentionNotificationDaysFlag(), cfg.RetentionNotificationDays, fieldtag("RetentionNotificationDays", "usage"))
+		cmd.Flags().Int(RetentionReadNotificationDaysFlag(), cfg.RetentionReadNotificationDays, fieldtag("RetentionReadNotificationDays", "usage"))
+		cmd.Flags().Int(RetentionNotificationMaxPerAccountFlag(), cfg.RetentionNotificationMaxPerAccount, fieldtag("RetentionNotificationMaxPerAccount", "usage"))
+		cmd.Flags().Int(RetentionRemoteStatusDaysFlag(), cfg.RetentionRemoteStatusDays, fieldtag("RetentionRemoteStatusDays", "usage"))
+		cmd.Flags().Bool(RetentionRemoteStatusArchiveFlag(), cfg.RetentionRemoteStatusArchive, fieldtag("RetentionRemoteStatusArchive", "usage"))
+		cmd.Flags().Int(RetentionUnconfirmedUserDaysFlag(), cfg.RetentionUnconfirmedUserDays, fieldtag("RetentionUnconfirmedUserDays", "usage"))
+		cmd.Flags().Bool(RetentionDryRunFlag(), cfg.RetentionDryRun, fieldtag("RetentionDryRun", "usage"))
 
 		// Storage
 		cmd.Flags().String(StorageBackendFlag(), cfg.StorageBackend, fieldtag("StorageBackend", "usage"))
@@ -158,6 +206,13 @@ func (s *ConfigState) AddServerFlags(cmd *cobra.Command) {
 		cmd.Flags().Int(AdvancedSenderMultiplierFlag(), cfg.AdvancedSenderMultiplier, fieldtag("AdvancedSenderMultiplier", "usage"))
 		cmd.Flags().StringSlice(AdvancedCSPExtraURIsFlag(), cfg.AdvancedCSPExtraURIs, fieldtag("AdvancedCSPExtraURIs", "usage"))
 		cmd.Flags().String(AdvancedHeaderFilterModeFlag(), cfg.AdvancedHeaderFilterMode, fieldtag("AdvancedHeaderFilterMode", "usage"))
+		cmd.Flags().Int(AdvancedRateLimitUnauthenticatedRequestsFlag(), cfg.AdvancedRateLimitUnauthenticatedRequests, fieldtag("AdvancedRateLimitUnauthenticatedRequests", "usage"))
+		cmd.Flags().Int(AdvancedStreamingMaxConnectionsFlag(), cfg.AdvancedStreamingMaxConnections, fieldtag("AdvancedStreamingMaxConnections", "usage"))
+		cmd.Flags().Int(AdvancedStreamingMaxConnectionsPerAccountFlag(), cfg.AdvancedStreamingMaxConnectionsPerAccount, fieldtag("AdvancedStreamingMaxConnectionsPerAccount", "usage"))
+		cmd.Flags().Int(AdvancedStreamingQueueSizeFlag(), cfg.AdvancedStreamingQueueSize, fieldtag("AdvancedStreamingQueueSize", "usage"))
+		cmd.Flags().Duration(AdvancedLoadSheddingLatencyFlag(), cfg.AdvancedLoadSheddingLatency, fieldtag("AdvancedLoadSheddingLatency", "usage"))
+		cmd.Flags().Int(AdvancedLoadSheddingQueueDepthFlag(), cfg.AdvancedLoadSheddingQueueDepth, fieldtag("AdvancedLoadSheddingQueueDepth", "usage"))
+		cmd.Flags().Bool(AdvancedConversionReportFlag(), cfg.AdvancedConversionReport, fieldtag("AdvancedConversionReport", "usage"))
 
 		cmd.Flags().String(RequestIDHeaderFlag(), cfg.RequestIDHeader, fieldtag("RequestIDHeader", "usage"))
 	})
@@ -224,3 +279,47 @@ func AddAdminMediaPrune(cmd *cobra.Command) {
 	usage := fieldtag("AdminMediaPruneDryRun", "usage")
 	cmd.Flags().Bool(name, true, usage)
 }
+
+// AddAdminEmojiPackExport attaches flags pertaining to emoji pack export.
+func AddAdminEmojiPackExport(cmd *cobra.Command) {
+	AddAdminTrans(cmd)
+
+	name := AdminEmojiPackCategoryFlag()
+	usage := fieldtag("AdminEmojiPackCategory", "usage")
+	cmd.Flags().String(name, "", usage)
+}
+
+// AddAdminEmojiPackImport attaches flags pertaining to emoji pack import.
+func AddAdminEmojiPackImport(cmd *cobra.Command) {
+	AddAdminEmojiPackExport(cmd)
+
+	name := AdminEmojiPackOnConflictFlag()
+	usage := fieldtag("AdminEmojiPackOnConflict", "usage")
+	cmd.Flags().String(name, "skip", usage)
+}
+
+// AddAdminDomain attaches flags pertaining to admin domain commands.
+func AddAdminDomain(cmd *cobra.Command) {
+	name := AdminDomainFlag()
+	usage := fieldtag("AdminDomain", "usage")
+	cmd.Flags().String(name, "", usage) // REQUIRED
+	if err := cmd.MarkFlagRequired(name); err != nil {
+		panic(err)
+	}
+}
+
+// AddAdminDomainAllow attaches flags pertaining to admin domain allow creation.
+func AddAdminDomainAllow(cmd *cobra.Command) {
+	AddAdminDomain(cmd)
+
+	name := AdminDomainPrivateCommentFlag()
+	usage := fieldtag("AdminDomainPrivateComment", "usage")
+	cmd.Flags().String(name, "", usage)
+}
+
+// AddTestrigSeed attaches flags pertaining to seeding test data.
+func AddTestrigSeed(cmd *cobra.Command) {
+	name := TestrigSeedProfileFlag()
+	usage := fieldtag("TestrigSeedProfile", "usage")
+	cmd.Flags().String(name, "small", usage)
+}
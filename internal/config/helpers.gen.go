@@ -600,6 +600,31 @@ func GetDbMaxOpenConnsMultiplier() int { return global.GetDbMaxOpenConnsMultipli
 // SetDbMaxOpenConnsMultiplier safely sets the value for global configuration 'DbMaxOpenConnsMultiplier' field
 func SetDbMaxOpenConnsMultiplier(v int) { global.SetDbMaxOpenConnsMultiplier(v) }
 
+// GetDbReplicaDSNs safely fetches the Configuration value for state's 'DbReplicaDSNs' field
+func (st *ConfigState) GetDbReplicaDSNs() (v []string) {
+	st.mutex.RLock()
+	v = st.config.DbReplicaDSNs
+	st.mutex.RUnlock()
+	return
+}
+
+// SetDbReplicaDSNs safely sets the Configuration value for state's 'DbReplicaDSNs' field
+func (st *ConfigState) SetDbReplicaDSNs(v []string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.DbReplicaDSNs = v
+	st.reloadToViper()
+}
+
+// DbReplicaDSNsFlag returns the flag name for the 'DbReplicaDSNs' field
+func DbReplicaDSNsFlag() string { return "db-replica-dsns" }
+
+// GetDbReplicaDSNs safely fetches the value for global configuration 'DbReplicaDSNs' field
+func GetDbReplicaDSNs() []string { return global.GetDbReplicaDSNs() }
+
+// SetDbReplicaDSNs safely sets the value for global configuration 'DbReplicaDSNs' field
+func SetDbReplicaDSNs(v []string) { global.SetDbReplicaDSNs(v) }
+
 // GetDbSqliteJournalMode safely fetches the Configuration value for state's 'DbSqliteJournalMode' field
 func (st *ConfigState) GetDbSqliteJournalMode() (v string) {
 	st.mutex.RLock()
@@ -750,6 +775,81 @@ func GetWebAssetBaseDir() string { return global.GetWebAssetBaseDir() }
 // SetWebAssetBaseDir safely sets the value for global configuration 'WebAssetBaseDir' field
 func SetWebAssetBaseDir(v string) { global.SetWebAssetBaseDir(v) }
 
+// GetWebErrorPageNotFoundMessage safely fetches the Configuration value for state's 'WebErrorPageNotFoundMessage' field
+func (st *ConfigState) GetWebErrorPageNotFoundMessage() (v string) {
+	st.mutex.RLock()
+	v = st.config.WebErrorPageNotFoundMessage
+	st.mutex.RUnlock()
+	return
+}
+
+// SetWebErrorPageNotFoundMessage safely sets the Configuration value for state's 'WebErrorPageNotFoundMessage' field
+func (st *ConfigState) SetWebErrorPageNotFoundMessage(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.WebErrorPageNotFoundMessage = v
+	st.reloadToViper()
+}
+
+// WebErrorPageNotFoundMessageFlag returns the flag name for the 'WebErrorPageNotFoundMessage' field
+func WebErrorPageNotFoundMessageFlag() string { return "web-error-page-not-found-message" }
+
+// GetWebErrorPageNotFoundMessage safely fetches the value for global configuration 'WebErrorPageNotFoundMessage' field
+func GetWebErrorPageNotFoundMessage() string { return global.GetWebErrorPageNotFoundMessage() }
+
+// SetWebErrorPageNotFoundMessage safely sets the value for global configuration 'WebErrorPageNotFoundMessage' field
+func SetWebErrorPageNotFoundMessage(v string) { global.SetWebErrorPageNotFoundMessage(v) }
+
+// GetWebErrorPageErrorMessage safely fetches the Configuration value for state's 'WebErrorPageErrorMessage' field
+func (st *ConfigState) GetWebErrorPageErrorMessage() (v string) {
+	st.mutex.RLock()
+	v = st.config.WebErrorPageErrorMessage
+	st.mutex.RUnlock()
+	return
+}
+
+// SetWebErrorPageErrorMessage safely sets the Configuration value for state's 'WebErrorPageErrorMessage' field
+func (st *ConfigState) SetWebErrorPageErrorMessage(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.WebErrorPageErrorMessage = v
+	st.reloadToViper()
+}
+
+// WebErrorPageErrorMessageFlag returns the flag name for the 'WebErrorPageErrorMessage' field
+func WebErrorPageErrorMessageFlag() string { return "web-error-page-error-message" }
+
+// GetWebErrorPageErrorMessage safely fetches the value for global configuration 'WebErrorPageErrorMessage' field
+func GetWebErrorPageErrorMessage() string { return global.GetWebErrorPageErrorMessage() }
+
+// SetWebErrorPageErrorMessage safely sets the value for global configuration 'WebErrorPageErrorMessage' field
+func SetWebErrorPageErrorMessage(v string) { global.SetWebErrorPageErrorMessage(v) }
+
+// GetWebErrorPageContactURL safely fetches the Configuration value for state's 'WebErrorPageContactURL' field
+func (st *ConfigState) GetWebErrorPageContactURL() (v string) {
+	st.mutex.RLock()
+	v = st.config.WebErrorPageContactURL
+	st.mutex.RUnlock()
+	return
+}
+
+// SetWebErrorPageContactURL safely sets the Configuration value for state's 'WebErrorPageContactURL' field
+func (st *ConfigState) SetWebErrorPageContactURL(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.WebErrorPageContactURL = v
+	st.reloadToViper()
+}
+
+// WebErrorPageContactURLFlag returns the flag name for the 'WebErrorPageContactURL' field
+func WebErrorPageContactURLFlag() string { return "web-error-page-contact-url" }
+
+// GetWebErrorPageContactURL safely fetches the value for global configuration 'WebErrorPageContactURL' field
+func GetWebErrorPageContactURL() string { return global.GetWebErrorPageContactURL() }
+
+// SetWebErrorPageContactURL safely sets the value for global configuration 'WebErrorPageContactURL' field
+func SetWebErrorPageContactURL(v string) { global.SetWebErrorPageContactURL(v) }
+
 // GetInstanceFederationMode safely fetches the Configuration value for state's 'InstanceFederationMode' field
 func (st *ConfigState) GetInstanceFederationMode() (v string) {
 	st.mutex.RLock()
@@ -800,6 +900,124 @@ func GetInstanceFederationSpamFilter() bool { return global.GetInstanceFederatio
 // SetInstanceFederationSpamFilter safely sets the value for global configuration 'InstanceFederationSpamFilter' field
 func SetInstanceFederationSpamFilter(v bool) { global.SetInstanceFederationSpamFilter(v) }
 
+// GetInstanceFederationSpamFilterMode safely fetches the Configuration value for state's 'InstanceFederationSpamFilterMode' field
+func (st *ConfigState) GetInstanceFederationSpamFilterMode() (v string) {
+	st.mutex.RLock()
+	v = st.config.InstanceFederationSpamFilterMode
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceFederationSpamFilterMode safely sets the Configuration value for state's 'InstanceFederationSpamFilterMode' field
+func (st *ConfigState) SetInstanceFederationSpamFilterMode(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceFederationSpamFilterMode = v
+	st.reloadToViper()
+}
+
+// InstanceFederationSpamFilterModeFlag returns the flag name for the 'InstanceFederationSpamFilterMode' field
+func InstanceFederationSpamFilterModeFlag() string { return "instance-federation-spam-filter-mode" }
+
+// GetInstanceFederationSpamFilterMode safely fetches the value for global configuration 'InstanceFederationSpamFilterMode' field
+func GetInstanceFederationSpamFilterMode() string {
+	return global.GetInstanceFederationSpamFilterMode()
+}
+
+// SetInstanceFederationSpamFilterMode safely sets the value for global configuration 'InstanceFederationSpamFilterMode' field
+func SetInstanceFederationSpamFilterMode(v string) { global.SetInstanceFederationSpamFilterMode(v) }
+
+// GetInstanceFederationQuarantineMismatchedStatuses safely fetches the Configuration value for state's 'InstanceFederationQuarantineMismatchedStatuses' field
+func (st *ConfigState) GetInstanceFederationQuarantineMismatchedStatuses() (v bool) {
+	st.mutex.RLock()
+	v = st.config.InstanceFederationQuarantineMismatchedStatuses
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceFederationQuarantineMismatchedStatuses safely sets the Configuration value for state's 'InstanceFederationQuarantineMismatchedStatuses' field
+func (st *ConfigState) SetInstanceFederationQuarantineMismatchedStatuses(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceFederationQuarantineMismatchedStatuses = v
+	st.reloadToViper()
+}
+
+// InstanceFederationQuarantineMismatchedStatusesFlag returns the flag name for the 'InstanceFederationQuarantineMismatchedStatuses' field
+func InstanceFederationQuarantineMismatchedStatusesFlag() string {
+	return "instance-federation-quarantine-mismatched-statuses"
+}
+
+// GetInstanceFederationQuarantineMismatchedStatuses safely fetches the value for global configuration 'InstanceFederationQuarantineMismatchedStatuses' field
+func GetInstanceFederationQuarantineMismatchedStatuses() bool {
+	return global.GetInstanceFederationQuarantineMismatchedStatuses()
+}
+
+// SetInstanceFederationQuarantineMismatchedStatuses safely sets the value for global configuration 'InstanceFederationQuarantineMismatchedStatuses' field
+func SetInstanceFederationQuarantineMismatchedStatuses(v bool) {
+	global.SetInstanceFederationQuarantineMismatchedStatuses(v)
+}
+
+// GetInstanceFederationDereferenceBudget safely fetches the Configuration value for state's 'InstanceFederationDereferenceBudget' field
+func (st *ConfigState) GetInstanceFederationDereferenceBudget() (v int) {
+	st.mutex.RLock()
+	v = st.config.InstanceFederationDereferenceBudget
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceFederationDereferenceBudget safely sets the Configuration value for state's 'InstanceFederationDereferenceBudget' field
+func (st *ConfigState) SetInstanceFederationDereferenceBudget(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceFederationDereferenceBudget = v
+	st.reloadToViper()
+}
+
+// InstanceFederationDereferenceBudgetFlag returns the flag name for the 'InstanceFederationDereferenceBudget' field
+func InstanceFederationDereferenceBudgetFlag() string {
+	return "instance-federation-dereference-budget"
+}
+
+// GetInstanceFederationDereferenceBudget safely fetches the value for global configuration 'InstanceFederationDereferenceBudget' field
+func GetInstanceFederationDereferenceBudget() int {
+	return global.GetInstanceFederationDereferenceBudget()
+}
+
+// SetInstanceFederationDereferenceBudget safely sets the value for global configuration 'InstanceFederationDereferenceBudget' field
+func SetInstanceFederationDereferenceBudget(v int) { global.SetInstanceFederationDereferenceBudget(v) }
+
+// GetInstanceDomainPermissionDraftsRequireSecondAdmin safely fetches the Configuration value for state's 'InstanceDomainPermissionDraftsRequireSecondAdmin' field
+func (st *ConfigState) GetInstanceDomainPermissionDraftsRequireSecondAdmin() (v bool) {
+	st.mutex.RLock()
+	v = st.config.InstanceDomainPermissionDraftsRequireSecondAdmin
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceDomainPermissionDraftsRequireSecondAdmin safely sets the Configuration value for state's 'InstanceDomainPermissionDraftsRequireSecondAdmin' field
+func (st *ConfigState) SetInstanceDomainPermissionDraftsRequireSecondAdmin(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceDomainPermissionDraftsRequireSecondAdmin = v
+	st.reloadToViper()
+}
+
+// InstanceDomainPermissionDraftsRequireSecondAdminFlag returns the flag name for the 'InstanceDomainPermissionDraftsRequireSecondAdmin' field
+func InstanceDomainPermissionDraftsRequireSecondAdminFlag() string {
+	return "instance-domain-permission-drafts-require-second-admin"
+}
+
+// GetInstanceDomainPermissionDraftsRequireSecondAdmin safely fetches the value for global configuration 'InstanceDomainPermissionDraftsRequireSecondAdmin' field
+func GetInstanceDomainPermissionDraftsRequireSecondAdmin() bool {
+	return global.GetInstanceDomainPermissionDraftsRequireSecondAdmin()
+}
+
+// SetInstanceDomainPermissionDraftsRequireSecondAdmin safely sets the value for global configuration 'InstanceDomainPermissionDraftsRequireSecondAdmin' field
+func SetInstanceDomainPermissionDraftsRequireSecondAdmin(v bool) {
+	global.SetInstanceDomainPermissionDraftsRequireSecondAdmin(v)
+}
+
 // GetInstanceExposePeers safely fetches the Configuration value for state's 'InstanceExposePeers' field
 func (st *ConfigState) GetInstanceExposePeers() (v bool) {
 	st.mutex.RLock()
@@ -900,6 +1118,106 @@ func GetInstanceExposePublicTimeline() bool { return global.GetInstanceExposePub
 // SetInstanceExposePublicTimeline safely sets the value for global configuration 'InstanceExposePublicTimeline' field
 func SetInstanceExposePublicTimeline(v bool) { global.SetInstanceExposePublicTimeline(v) }
 
+// GetInstanceExposePublicTimelineWeb safely fetches the Configuration value for state's 'InstanceExposePublicTimelineWeb' field
+func (st *ConfigState) GetInstanceExposePublicTimelineWeb() (v bool) {
+	st.mutex.RLock()
+	v = st.config.InstanceExposePublicTimelineWeb
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceExposePublicTimelineWeb safely sets the Configuration value for state's 'InstanceExposePublicTimelineWeb' field
+func (st *ConfigState) SetInstanceExposePublicTimelineWeb(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceExposePublicTimelineWeb = v
+	st.reloadToViper()
+}
+
+// InstanceExposePublicTimelineWebFlag returns the flag name for the 'InstanceExposePublicTimelineWeb' field
+func InstanceExposePublicTimelineWebFlag() string { return "instance-expose-public-timeline-web" }
+
+// GetInstanceExposePublicTimelineWeb safely fetches the value for global configuration 'InstanceExposePublicTimelineWeb' field
+func GetInstanceExposePublicTimelineWeb() bool { return global.GetInstanceExposePublicTimelineWeb() }
+
+// SetInstanceExposePublicTimelineWeb safely sets the value for global configuration 'InstanceExposePublicTimelineWeb' field
+func SetInstanceExposePublicTimelineWeb(v bool) { global.SetInstanceExposePublicTimelineWeb(v) }
+
+// GetInstanceExposePublicAPI safely fetches the Configuration value for state's 'InstanceExposePublicAPI' field
+func (st *ConfigState) GetInstanceExposePublicAPI() (v bool) {
+	st.mutex.RLock()
+	v = st.config.InstanceExposePublicAPI
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceExposePublicAPI safely sets the Configuration value for state's 'InstanceExposePublicAPI' field
+func (st *ConfigState) SetInstanceExposePublicAPI(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceExposePublicAPI = v
+	st.reloadToViper()
+}
+
+// InstanceExposePublicAPIFlag returns the flag name for the 'InstanceExposePublicAPI' field
+func InstanceExposePublicAPIFlag() string { return "instance-expose-public-api" }
+
+// GetInstanceExposePublicAPI safely fetches the value for global configuration 'InstanceExposePublicAPI' field
+func GetInstanceExposePublicAPI() bool { return global.GetInstanceExposePublicAPI() }
+
+// SetInstanceExposePublicAPI safely sets the value for global configuration 'InstanceExposePublicAPI' field
+func SetInstanceExposePublicAPI(v bool) { global.SetInstanceExposePublicAPI(v) }
+
+// GetInstanceExposeTagFeeds safely fetches the Configuration value for state's 'InstanceExposeTagFeeds' field
+func (st *ConfigState) GetInstanceExposeTagFeeds() (v []string) {
+	st.mutex.RLock()
+	v = st.config.InstanceExposeTagFeeds
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceExposeTagFeeds safely sets the Configuration value for state's 'InstanceExposeTagFeeds' field
+func (st *ConfigState) SetInstanceExposeTagFeeds(v []string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceExposeTagFeeds = v
+	st.reloadToViper()
+}
+
+// InstanceExposeTagFeedsFlag returns the flag name for the 'InstanceExposeTagFeeds' field
+func InstanceExposeTagFeedsFlag() string { return "instance-expose-tag-feeds" }
+
+// GetInstanceExposeTagFeeds safely fetches the value for global configuration 'InstanceExposeTagFeeds' field
+func GetInstanceExposeTagFeeds() []string { return global.GetInstanceExposeTagFeeds() }
+
+// SetInstanceExposeTagFeeds safely sets the value for global configuration 'InstanceExposeTagFeeds' field
+func SetInstanceExposeTagFeeds(v []string) { global.SetInstanceExposeTagFeeds(v) }
+
+// GetInstanceDefaultTheme safely fetches the Configuration value for state's 'InstanceDefaultTheme' field
+func (st *ConfigState) GetInstanceDefaultTheme() (v string) {
+	st.mutex.RLock()
+	v = st.config.InstanceDefaultTheme
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceDefaultTheme safely sets the Configuration value for state's 'InstanceDefaultTheme' field
+func (st *ConfigState) SetInstanceDefaultTheme(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceDefaultTheme = v
+	st.reloadToViper()
+}
+
+// InstanceDefaultThemeFlag returns the flag name for the 'InstanceDefaultTheme' field
+func InstanceDefaultThemeFlag() string { return "instance-default-theme" }
+
+// GetInstanceDefaultTheme safely fetches the value for global configuration 'InstanceDefaultTheme' field
+func GetInstanceDefaultTheme() string { return global.GetInstanceDefaultTheme() }
+
+// SetInstanceDefaultTheme safely sets the value for global configuration 'InstanceDefaultTheme' field
+func SetInstanceDefaultTheme(v string) { global.SetInstanceDefaultTheme(v) }
+
 // GetInstanceDeliverToSharedInboxes safely fetches the Configuration value for state's 'InstanceDeliverToSharedInboxes' field
 func (st *ConfigState) GetInstanceDeliverToSharedInboxes() (v bool) {
 	st.mutex.RLock()
@@ -1025,280 +1343,1073 @@ func GetAccountsReasonRequired() bool { return global.GetAccountsReasonRequired(
 // SetAccountsReasonRequired safely sets the value for global configuration 'AccountsReasonRequired' field
 func SetAccountsReasonRequired(v bool) { global.SetAccountsReasonRequired(v) }
 
-// GetAccountsAllowCustomCSS safely fetches the Configuration value for state's 'AccountsAllowCustomCSS' field
-func (st *ConfigState) GetAccountsAllowCustomCSS() (v bool) {
+// GetAccountsRegistrationIPDailyLimit safely fetches the Configuration value for state's 'AccountsRegistrationIPDailyLimit' field
+func (st *ConfigState) GetAccountsRegistrationIPDailyLimit() (v int) {
 	st.mutex.RLock()
-	v = st.config.AccountsAllowCustomCSS
+	v = st.config.AccountsRegistrationIPDailyLimit
 	st.mutex.RUnlock()
 	return
 }
 
-// SetAccountsAllowCustomCSS safely sets the Configuration value for state's 'AccountsAllowCustomCSS' field
-func (st *ConfigState) SetAccountsAllowCustomCSS(v bool) {
+// SetAccountsRegistrationIPDailyLimit safely sets the Configuration value for state's 'AccountsRegistrationIPDailyLimit' field
+func (st *ConfigState) SetAccountsRegistrationIPDailyLimit(v int) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.AccountsAllowCustomCSS = v
+	st.config.AccountsRegistrationIPDailyLimit = v
 	st.reloadToViper()
 }
 
-// AccountsAllowCustomCSSFlag returns the flag name for the 'AccountsAllowCustomCSS' field
-func AccountsAllowCustomCSSFlag() string { return "accounts-allow-custom-css" }
+// AccountsRegistrationIPDailyLimitFlag returns the flag name for the 'AccountsRegistrationIPDailyLimit' field
+func AccountsRegistrationIPDailyLimitFlag() string { return "accounts-registration-ip-daily-limit" }
 
-// GetAccountsAllowCustomCSS safely fetches the value for global configuration 'AccountsAllowCustomCSS' field
-func GetAccountsAllowCustomCSS() bool { return global.GetAccountsAllowCustomCSS() }
+// GetAccountsRegistrationIPDailyLimit safely fetches the value for global configuration 'AccountsRegistrationIPDailyLimit' field
+func GetAccountsRegistrationIPDailyLimit() int { return global.GetAccountsRegistrationIPDailyLimit() }
 
-// SetAccountsAllowCustomCSS safely sets the value for global configuration 'AccountsAllowCustomCSS' field
-func SetAccountsAllowCustomCSS(v bool) { global.SetAccountsAllowCustomCSS(v) }
+// SetAccountsRegistrationIPDailyLimit safely sets the value for global configuration 'AccountsRegistrationIPDailyLimit' field
+func SetAccountsRegistrationIPDailyLimit(v int) { global.SetAccountsRegistrationIPDailyLimit(v) }
 
-// GetAccountsCustomCSSLength safely fetches the Configuration value for state's 'AccountsCustomCSSLength' field
-func (st *ConfigState) GetAccountsCustomCSSLength() (v int) {
+// GetAccountsRegistrationEmailDomainDailyLimit safely fetches the Configuration value for state's 'AccountsRegistrationEmailDomainDailyLimit' field
+func (st *ConfigState) GetAccountsRegistrationEmailDomainDailyLimit() (v int) {
 	st.mutex.RLock()
-	v = st.config.AccountsCustomCSSLength
+	v = st.config.AccountsRegistrationEmailDomainDailyLimit
 	st.mutex.RUnlock()
 	return
 }
 
-// SetAccountsCustomCSSLength safely sets the Configuration value for state's 'AccountsCustomCSSLength' field
-func (st *ConfigState) SetAccountsCustomCSSLength(v int) {
+// SetAccountsRegistrationEmailDomainDailyLimit safely sets the Configuration value for state's 'AccountsRegistrationEmailDomainDailyLimit' field
+func (st *ConfigState) SetAccountsRegistrationEmailDomainDailyLimit(v int) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.AccountsCustomCSSLength = v
+	st.config.AccountsRegistrationEmailDomainDailyLimit = v
 	st.reloadToViper()
 }
 
-// AccountsCustomCSSLengthFlag returns the flag name for the 'AccountsCustomCSSLength' field
-func AccountsCustomCSSLengthFlag() string { return "accounts-custom-css-length" }
+// AccountsRegistrationEmailDomainDailyLimitFlag returns the flag name for the 'AccountsRegistrationEmailDomainDailyLimit' field
+func AccountsRegistrationEmailDomainDailyLimitFlag() string {
+	return "accounts-registration-email-domain-daily-limit"
+}
 
-// GetAccountsCustomCSSLength safely fetches the value for global configuration 'AccountsCustomCSSLength' field
-func GetAccountsCustomCSSLength() int { return global.GetAccountsCustomCSSLength() }
+// GetAccountsRegistrationEmailDomainDailyLimit safely fetches the value for global configuration 'AccountsRegistrationEmailDomainDailyLimit' field
+func GetAccountsRegistrationEmailDomainDailyLimit() int {
+	return global.GetAccountsRegistrationEmailDomainDailyLimit()
+}
 
-// SetAccountsCustomCSSLength safely sets the value for global configuration 'AccountsCustomCSSLength' field
-func SetAccountsCustomCSSLength(v int) { global.SetAccountsCustomCSSLength(v) }
+// SetAccountsRegistrationEmailDomainDailyLimit safely sets the value for global configuration 'AccountsRegistrationEmailDomainDailyLimit' field
+func SetAccountsRegistrationEmailDomainDailyLimit(v int) {
+	global.SetAccountsRegistrationEmailDomainDailyLimit(v)
+}
 
-// GetMediaImageMaxSize safely fetches the Configuration value for state's 'MediaImageMaxSize' field
-func (st *ConfigState) GetMediaImageMaxSize() (v bytesize.Size) {
+// GetAccountsDisposableEmailMode safely fetches the Configuration value for state's 'AccountsDisposableEmailMode' field
+func (st *ConfigState) GetAccountsDisposableEmailMode() (v string) {
 	st.mutex.RLock()
-	v = st.config.MediaImageMaxSize
+	v = st.config.AccountsDisposableEmailMode
 	st.mutex.RUnlock()
 	return
 }
 
-// SetMediaImageMaxSize safely sets the Configuration value for state's 'MediaImageMaxSize' field
-func (st *ConfigState) SetMediaImageMaxSize(v bytesize.Size) {
+// SetAccountsDisposableEmailMode safely sets the Configuration value for state's 'AccountsDisposableEmailMode' field
+func (st *ConfigState) SetAccountsDisposableEmailMode(v string) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.MediaImageMaxSize = v
+	st.config.AccountsDisposableEmailMode = v
 	st.reloadToViper()
 }
 
-// MediaImageMaxSizeFlag returns the flag name for the 'MediaImageMaxSize' field
-func MediaImageMaxSizeFlag() string { return "media-image-max-size" }
+// AccountsDisposableEmailModeFlag returns the flag name for the 'AccountsDisposableEmailMode' field
+func AccountsDisposableEmailModeFlag() string { return "accounts-disposable-email-mode" }
 
-// GetMediaImageMaxSize safely fetches the value for global configuration 'MediaImageMaxSize' field
-func GetMediaImageMaxSize() bytesize.Size { return global.GetMediaImageMaxSize() }
+// GetAccountsDisposableEmailMode safely fetches the value for global configuration 'AccountsDisposableEmailMode' field
+func GetAccountsDisposableEmailMode() string { return global.GetAccountsDisposableEmailMode() }
 
-// SetMediaImageMaxSize safely sets the value for global configuration 'MediaImageMaxSize' field
-func SetMediaImageMaxSize(v bytesize.Size) { global.SetMediaImageMaxSize(v) }
+// SetAccountsDisposableEmailMode safely sets the value for global configuration 'AccountsDisposableEmailMode' field
+func SetAccountsDisposableEmailMode(v string) { global.SetAccountsDisposableEmailMode(v) }
 
-// GetMediaVideoMaxSize safely fetches the Configuration value for state's 'MediaVideoMaxSize' field
-func (st *ConfigState) GetMediaVideoMaxSize() (v bytesize.Size) {
+// GetAccountsDisposableEmailListURL safely fetches the Configuration value for state's 'AccountsDisposableEmailListURL' field
+func (st *ConfigState) GetAccountsDisposableEmailListURL() (v string) {
 	st.mutex.RLock()
-	v = st.config.MediaVideoMaxSize
+	v = st.config.AccountsDisposableEmailListURL
 	st.mutex.RUnlock()
 	return
 }
 
-// SetMediaVideoMaxSize safely sets the Configuration value for state's 'MediaVideoMaxSize' field
-func (st *ConfigState) SetMediaVideoMaxSize(v bytesize.Size) {
+// SetAccountsDisposableEmailListURL safely sets the Configuration value for state's 'AccountsDisposableEmailListURL' field
+func (st *ConfigState) SetAccountsDisposableEmailListURL(v string) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.MediaVideoMaxSize = v
+	st.config.AccountsDisposableEmailListURL = v
 	st.reloadToViper()
 }
 
-// MediaVideoMaxSizeFlag returns the flag name for the 'MediaVideoMaxSize' field
-func MediaVideoMaxSizeFlag() string { return "media-video-max-size" }
+// AccountsDisposableEmailListURLFlag returns the flag name for the 'AccountsDisposableEmailListURL' field
+func AccountsDisposableEmailListURLFlag() string { return "accounts-disposable-email-list-url" }
 
-// GetMediaVideoMaxSize safely fetches the value for global configuration 'MediaVideoMaxSize' field
-func GetMediaVideoMaxSize() bytesize.Size { return global.GetMediaVideoMaxSize() }
+// GetAccountsDisposableEmailListURL safely fetches the value for global configuration 'AccountsDisposableEmailListURL' field
+func GetAccountsDisposableEmailListURL() string { return global.GetAccountsDisposableEmailListURL() }
 
-// SetMediaVideoMaxSize safely sets the value for global configuration 'MediaVideoMaxSize' field
-func SetMediaVideoMaxSize(v bytesize.Size) { global.SetMediaVideoMaxSize(v) }
+// SetAccountsDisposableEmailListURL safely sets the value for global configuration 'AccountsDisposableEmailListURL' field
+func SetAccountsDisposableEmailListURL(v string) { global.SetAccountsDisposableEmailListURL(v) }
 
-// GetMediaDescriptionMinChars safely fetches the Configuration value for state's 'MediaDescriptionMinChars' field
-func (st *ConfigState) GetMediaDescriptionMinChars() (v int) {
+// GetAccountsInvitesEnabled safely fetches the Configuration value for state's 'AccountsInvitesEnabled' field
+func (st *ConfigState) GetAccountsInvitesEnabled() (v bool) {
 	st.mutex.RLock()
-	v = st.config.MediaDescriptionMinChars
+	v = st.config.AccountsInvitesEnabled
 	st.mutex.RUnlock()
 	return
 }
 
-// SetMediaDescriptionMinChars safely sets the Configuration value for state's 'MediaDescriptionMinChars' field
-func (st *ConfigState) SetMediaDescriptionMinChars(v int) {
+// SetAccountsInvitesEnabled safely sets the Configuration value for state's 'AccountsInvitesEnabled' field
+func (st *ConfigState) SetAccountsInvitesEnabled(v bool) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.MediaDescriptionMinChars = v
+	st.config.AccountsInvitesEnabled = v
 	st.reloadToViper()
 }
 
-// MediaDescriptionMinCharsFlag returns the flag name for the 'MediaDescriptionMinChars' field
-func MediaDescriptionMinCharsFlag() string { return "media-description-min-chars" }
+// AccountsInvitesEnabledFlag returns the flag name for the 'AccountsInvitesEnabled' field
+func AccountsInvitesEnabledFlag() string { return "accounts-invites-enabled" }
 
-// GetMediaDescriptionMinChars safely fetches the value for global configuration 'MediaDescriptionMinChars' field
-func GetMediaDescriptionMinChars() int { return global.GetMediaDescriptionMinChars() }
+// GetAccountsInvitesEnabled safely fetches the value for global configuration 'AccountsInvitesEnabled' field
+func GetAccountsInvitesEnabled() bool { return global.GetAccountsInvitesEnabled() }
 
-// SetMediaDescriptionMinChars safely sets the value for global configuration 'MediaDescriptionMinChars' field
-func SetMediaDescriptionMinChars(v int) { global.SetMediaDescriptionMinChars(v) }
+// SetAccountsInvitesEnabled safely sets the value for global configuration 'AccountsInvitesEnabled' field
+func SetAccountsInvitesEnabled(v bool) { global.SetAccountsInvitesEnabled(v) }
 
-// GetMediaDescriptionMaxChars safely fetches the Configuration value for state's 'MediaDescriptionMaxChars' field
-func (st *ConfigState) GetMediaDescriptionMaxChars() (v int) {
+// GetAccountsInvitesBypassApproval safely fetches the Configuration value for state's 'AccountsInvitesBypassApproval' field
+func (st *ConfigState) GetAccountsInvitesBypassApproval() (v bool) {
 	st.mutex.RLock()
-	v = st.config.MediaDescriptionMaxChars
+	v = st.config.AccountsInvitesBypassApproval
 	st.mutex.RUnlock()
 	return
 }
 
-// SetMediaDescriptionMaxChars safely sets the Configuration value for state's 'MediaDescriptionMaxChars' field
-func (st *ConfigState) SetMediaDescriptionMaxChars(v int) {
+// SetAccountsInvitesBypassApproval safely sets the Configuration value for state's 'AccountsInvitesBypassApproval' field
+func (st *ConfigState) SetAccountsInvitesBypassApproval(v bool) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.MediaDescriptionMaxChars = v
+	st.config.AccountsInvitesBypassApproval = v
 	st.reloadToViper()
 }
 
-// MediaDescriptionMaxCharsFlag returns the flag name for the 'MediaDescriptionMaxChars' field
-func MediaDescriptionMaxCharsFlag() string { return "media-description-max-chars" }
+// AccountsInvitesBypassApprovalFlag returns the flag name for the 'AccountsInvitesBypassApproval' field
+func AccountsInvitesBypassApprovalFlag() string { return "accounts-invites-bypass-approval" }
 
-// GetMediaDescriptionMaxChars safely fetches the value for global configuration 'MediaDescriptionMaxChars' field
-func GetMediaDescriptionMaxChars() int { return global.GetMediaDescriptionMaxChars() }
+// GetAccountsInvitesBypassApproval safely fetches the value for global configuration 'AccountsInvitesBypassApproval' field
+func GetAccountsInvitesBypassApproval() bool { return global.GetAccountsInvitesBypassApproval() }
 
-// SetMediaDescriptionMaxChars safely sets the value for global configuration 'MediaDescriptionMaxChars' field
-func SetMediaDescriptionMaxChars(v int) { global.SetMediaDescriptionMaxChars(v) }
+// SetAccountsInvitesBypassApproval safely sets the value for global configuration 'AccountsInvitesBypassApproval' field
+func SetAccountsInvitesBypassApproval(v bool) { global.SetAccountsInvitesBypassApproval(v) }
 
-// GetMediaRemoteCacheDays safely fetches the Configuration value for state's 'MediaRemoteCacheDays' field
-func (st *ConfigState) GetMediaRemoteCacheDays() (v int) {
+// GetAccountsInvitesBypassClosedRegistration safely fetches the Configuration value for state's 'AccountsInvitesBypassClosedRegistration' field
+func (st *ConfigState) GetAccountsInvitesBypassClosedRegistration() (v bool) {
 	st.mutex.RLock()
-	v = st.config.MediaRemoteCacheDays
+	v = st.config.AccountsInvitesBypassClosedRegistration
 	st.mutex.RUnlock()
 	return
 }
 
-// SetMediaRemoteCacheDays safely sets the Configuration value for state's 'MediaRemoteCacheDays' field
-func (st *ConfigState) SetMediaRemoteCacheDays(v int) {
+// SetAccountsInvitesBypassClosedRegistration safely sets the Configuration value for state's 'AccountsInvitesBypassClosedRegistration' field
+func (st *ConfigState) SetAccountsInvitesBypassClosedRegistration(v bool) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.MediaRemoteCacheDays = v
+	st.config.AccountsInvitesBypassClosedRegistration = v
 	st.reloadToViper()
 }
 
-// MediaRemoteCacheDaysFlag returns the flag name for the 'MediaRemoteCacheDays' field
-func MediaRemoteCacheDaysFlag() string { return "media-remote-cache-days" }
+// AccountsInvitesBypassClosedRegistrationFlag returns the flag name for the 'AccountsInvitesBypassClosedRegistration' field
+func AccountsInvitesBypassClosedRegistrationFlag() string {
+	return "accounts-invites-bypass-closed-registration"
+}
 
-// GetMediaRemoteCacheDays safely fetches the value for global configuration 'MediaRemoteCacheDays' field
-func GetMediaRemoteCacheDays() int { return global.GetMediaRemoteCacheDays() }
+// GetAccountsInvitesBypassClosedRegistration safely fetches the value for global configuration 'AccountsInvitesBypassClosedRegistration' field
+func GetAccountsInvitesBypassClosedRegistration() bool {
+	return global.GetAccountsInvitesBypassClosedRegistration()
+}
 
-// SetMediaRemoteCacheDays safely sets the value for global configuration 'MediaRemoteCacheDays' field
-func SetMediaRemoteCacheDays(v int) { global.SetMediaRemoteCacheDays(v) }
+// SetAccountsInvitesBypassClosedRegistration safely sets the value for global configuration 'AccountsInvitesBypassClosedRegistration' field
+func SetAccountsInvitesBypassClosedRegistration(v bool) {
+	global.SetAccountsInvitesBypassClosedRegistration(v)
+}
 
-// GetMediaEmojiLocalMaxSize safely fetches the Configuration value for state's 'MediaEmojiLocalMaxSize' field
+// GetAccountsAllowCustomCSS safely fetches the Configuration value for state's 'AccountsAllowCustomCSS' field
+func (st *ConfigState) GetAccountsAllowCustomCSS() (v bool) {
+	st.mutex.RLock()
+	v = st.config.AccountsAllowCustomCSS
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAccountsAllowCustomCSS safely sets the Configuration value for state's 'AccountsAllowCustomCSS' field
+func (st *ConfigState) SetAccountsAllowCustomCSS(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsAllowCustomCSS = v
+	st.reloadToViper()
+}
+
+// AccountsAllowCustomCSSFlag returns the flag name for the 'AccountsAllowCustomCSS' field
+func AccountsAllowCustomCSSFlag() string { return "accounts-allow-custom-css" }
+
+// GetAccountsAllowCustomCSS safely fetches the value for global configuration 'AccountsAllowCustomCSS' field
+func GetAccountsAllowCustomCSS() bool { return global.GetAccountsAllowCustomCSS() }
+
+// SetAccountsAllowCustomCSS safely sets the value for global configuration 'AccountsAllowCustomCSS' field
+func SetAccountsAllowCustomCSS(v bool) { global.SetAccountsAllowCustomCSS(v) }
+
+// GetAccountsCustomCSSLength safely fetches the Configuration value for state's 'AccountsCustomCSSLength' field
+func (st *ConfigState) GetAccountsCustomCSSLength() (v int) {
+	st.mutex.RLock()
+	v = st.config.AccountsCustomCSSLength
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAccountsCustomCSSLength safely sets the Configuration value for state's 'AccountsCustomCSSLength' field
+func (st *ConfigState) SetAccountsCustomCSSLength(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsCustomCSSLength = v
+	st.reloadToViper()
+}
+
+// AccountsCustomCSSLengthFlag returns the flag name for the 'AccountsCustomCSSLength' field
+func AccountsCustomCSSLengthFlag() string { return "accounts-custom-css-length" }
+
+// GetAccountsCustomCSSLength safely fetches the value for global configuration 'AccountsCustomCSSLength' field
+func GetAccountsCustomCSSLength() int { return global.GetAccountsCustomCSSLength() }
+
+// SetAccountsCustomCSSLength safely sets the value for global configuration 'AccountsCustomCSSLength' field
+func SetAccountsCustomCSSLength(v int) { global.SetAccountsCustomCSSLength(v) }
+
+// GetAccountsDefaultNoIndex safely fetches the Configuration value for state's 'AccountsDefaultNoIndex' field
+func (st *ConfigState) GetAccountsDefaultNoIndex() (v bool) {
+	st.mutex.RLock()
+	v = st.config.AccountsDefaultNoIndex
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAccountsDefaultNoIndex safely sets the Configuration value for state's 'AccountsDefaultNoIndex' field
+func (st *ConfigState) SetAccountsDefaultNoIndex(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsDefaultNoIndex = v
+	st.reloadToViper()
+}
+
+// AccountsDefaultNoIndexFlag returns the flag name for the 'AccountsDefaultNoIndex' field
+func AccountsDefaultNoIndexFlag() string { return "accounts-default-noindex" }
+
+// GetAccountsDefaultNoIndex safely fetches the value for global configuration 'AccountsDefaultNoIndex' field
+func GetAccountsDefaultNoIndex() bool { return global.GetAccountsDefaultNoIndex() }
+
+// SetAccountsDefaultNoIndex safely sets the value for global configuration 'AccountsDefaultNoIndex' field
+func SetAccountsDefaultNoIndex(v bool) { global.SetAccountsDefaultNoIndex(v) }
+
+// GetAccountsRemoteRefreshDays safely fetches the Configuration value for state's 'AccountsRemoteRefreshDays' field
+func (st *ConfigState) GetAccountsRemoteRefreshDays() (v int) {
+	st.mutex.RLock()
+	v = st.config.AccountsRemoteRefreshDays
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAccountsRemoteRefreshDays safely sets the Configuration value for state's 'AccountsRemoteRefreshDays' field
+func (st *ConfigState) SetAccountsRemoteRefreshDays(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsRemoteRefreshDays = v
+	st.reloadToViper()
+}
+
+// AccountsRemoteRefreshDaysFlag returns the flag name for the 'AccountsRemoteRefreshDays' field
+func AccountsRemoteRefreshDaysFlag() string { return "accounts-remote-refresh-days" }
+
+// GetAccountsRemoteRefreshDays safely fetches the value for global configuration 'AccountsRemoteRefreshDays' field
+func GetAccountsRemoteRefreshDays() int { return global.GetAccountsRemoteRefreshDays() }
+
+// SetAccountsRemoteRefreshDays safely sets the value for global configuration 'AccountsRemoteRefreshDays' field
+func SetAccountsRemoteRefreshDays(v int) { global.SetAccountsRemoteRefreshDays(v) }
+
+// GetAccountsRemoteRefreshPerDomain safely fetches the Configuration value for state's 'AccountsRemoteRefreshPerDomain' field
+func (st *ConfigState) GetAccountsRemoteRefreshPerDomain() (v int) {
+	st.mutex.RLock()
+	v = st.config.AccountsRemoteRefreshPerDomain
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAccountsRemoteRefreshPerDomain safely sets the Configuration value for state's 'AccountsRemoteRefreshPerDomain' field
+func (st *ConfigState) SetAccountsRemoteRefreshPerDomain(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsRemoteRefreshPerDomain = v
+	st.reloadToViper()
+}
+
+// AccountsRemoteRefreshPerDomainFlag returns the flag name for the 'AccountsRemoteRefreshPerDomain' field
+func AccountsRemoteRefreshPerDomainFlag() string { return "accounts-remote-refresh-per-domain" }
+
+// GetAccountsRemoteRefreshPerDomain safely fetches the value for global configuration 'AccountsRemoteRefreshPerDomain' field
+func GetAccountsRemoteRefreshPerDomain() int { return global.GetAccountsRemoteRefreshPerDomain() }
+
+// SetAccountsRemoteRefreshPerDomain safely sets the value for global configuration 'AccountsRemoteRefreshPerDomain' field
+func SetAccountsRemoteRefreshPerDomain(v int) { global.SetAccountsRemoteRefreshPerDomain(v) }
+
+// GetMediaImageMaxSize safely fetches the Configuration value for state's 'MediaImageMaxSize' field
+func (st *ConfigState) GetMediaImageMaxSize() (v bytesize.Size) {
+	st.mutex.RLock()
+	v = st.config.MediaImageMaxSize
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaImageMaxSize safely sets the Configuration value for state's 'MediaImageMaxSize' field
+func (st *ConfigState) SetMediaImageMaxSize(v bytesize.Size) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaImageMaxSize = v
+	st.reloadToViper()
+}
+
+// MediaImageMaxSizeFlag returns the flag name for the 'MediaImageMaxSize' field
+func MediaImageMaxSizeFlag() string { return "media-image-max-size" }
+
+// GetMediaImageMaxSize safely fetches the value for global configuration 'MediaImageMaxSize' field
+func GetMediaImageMaxSize() bytesize.Size { return global.GetMediaImageMaxSize() }
+
+// SetMediaImageMaxSize safely sets the value for global configuration 'MediaImageMaxSize' field
+func SetMediaImageMaxSize(v bytesize.Size) { global.SetMediaImageMaxSize(v) }
+
+// GetMediaVideoMaxSize safely fetches the Configuration value for state's 'MediaVideoMaxSize' field
+func (st *ConfigState) GetMediaVideoMaxSize() (v bytesize.Size) {
+	st.mutex.RLock()
+	v = st.config.MediaVideoMaxSize
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaVideoMaxSize safely sets the Configuration value for state's 'MediaVideoMaxSize' field
+func (st *ConfigState) SetMediaVideoMaxSize(v bytesize.Size) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaVideoMaxSize = v
+	st.reloadToViper()
+}
+
+// MediaVideoMaxSizeFlag returns the flag name for the 'MediaVideoMaxSize' field
+func MediaVideoMaxSizeFlag() string { return "media-video-max-size" }
+
+// GetMediaVideoMaxSize safely fetches the value for global configuration 'MediaVideoMaxSize' field
+func GetMediaVideoMaxSize() bytesize.Size { return global.GetMediaVideoMaxSize() }
+
+// SetMediaVideoMaxSize safely sets the value for global configuration 'MediaVideoMaxSize' field
+func SetMediaVideoMaxSize(v bytesize.Size) { global.SetMediaVideoMaxSize(v) }
+
+// GetMediaImageQuality safely fetches the Configuration value for state's 'MediaImageQuality' field
+func (st *ConfigState) GetMediaImageQuality() (v int) {
+	st.mutex.RLock()
+	v = st.config.MediaImageQuality
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaImageQuality safely sets the Configuration value for state's 'MediaImageQuality' field
+func (st *ConfigState) SetMediaImageQuality(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaImageQuality = v
+	st.reloadToViper()
+}
+
+// MediaImageQualityFlag returns the flag name for the 'MediaImageQuality' field
+func MediaImageQualityFlag() string { return "media-image-quality" }
+
+// GetMediaImageQuality safely fetches the value for global configuration 'MediaImageQuality' field
+func GetMediaImageQuality() int { return global.GetMediaImageQuality() }
+
+// SetMediaImageQuality safely sets the value for global configuration 'MediaImageQuality' field
+func SetMediaImageQuality(v int) { global.SetMediaImageQuality(v) }
+
+// GetMediaImageMaxResolution safely fetches the Configuration value for state's 'MediaImageMaxResolution' field
+func (st *ConfigState) GetMediaImageMaxResolution() (v int) {
+	st.mutex.RLock()
+	v = st.config.MediaImageMaxResolution
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaImageMaxResolution safely sets the Configuration value for state's 'MediaImageMaxResolution' field
+func (st *ConfigState) SetMediaImageMaxResolution(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaImageMaxResolution = v
+	st.reloadToViper()
+}
+
+// MediaImageMaxResolutionFlag returns the flag name for the 'MediaImageMaxResolution' field
+func MediaImageMaxResolutionFlag() string { return "media-image-max-resolution" }
+
+// GetMediaImageMaxResolution safely fetches the value for global configuration 'MediaImageMaxResolution' field
+func GetMediaImageMaxResolution() int { return global.GetMediaImageMaxResolution() }
+
+// SetMediaImageMaxResolution safely sets the value for global configuration 'MediaImageMaxResolution' field
+func SetMediaImageMaxResolution(v int) { global.SetMediaImageMaxResolution(v) }
+
+// GetMediaDescriptionMinChars safely fetches the Configuration value for state's 'MediaDescriptionMinChars' field
+func (st *ConfigState) GetMediaDescriptionMinChars() (v int) {
+	st.mutex.RLock()
+	v = st.config.MediaDescriptionMinChars
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaDescriptionMinChars safely sets the Configuration value for state's 'MediaDescriptionMinChars' field
+func (st *ConfigState) SetMediaDescriptionMinChars(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaDescriptionMinChars = v
+	st.reloadToViper()
+}
+
+// MediaDescriptionMinCharsFlag returns the flag name for the 'MediaDescriptionMinChars' field
+func MediaDescriptionMinCharsFlag() string { return "media-description-min-chars" }
+
+// GetMediaDescriptionMinChars safely fetches the value for global configuration 'MediaDescriptionMinChars' field
+func GetMediaDescriptionMinChars() int { return global.GetMediaDescriptionMinChars() }
+
+// SetMediaDescriptionMinChars safely sets the value for global configuration 'MediaDescriptionMinChars' field
+func SetMediaDescriptionMinChars(v int) { global.SetMediaDescriptionMinChars(v) }
+
+// GetMediaDescriptionMaxChars safely fetches the Configuration value for state's 'MediaDescriptionMaxChars' field
+func (st *ConfigState) GetMediaDescriptionMaxChars() (v int) {
+	st.mutex.RLock()
+	v = st.config.MediaDescriptionMaxChars
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaDescriptionMaxChars safely sets the Configuration value for state's 'MediaDescriptionMaxChars' field
+func (st *ConfigState) SetMediaDescriptionMaxChars(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaDescriptionMaxChars = v
+	st.reloadToViper()
+}
+
+// MediaDescriptionMaxCharsFlag returns the flag name for the 'MediaDescriptionMaxChars' field
+func MediaDescriptionMaxCharsFlag() string { return "media-description-max-chars" }
+
+// GetMediaDescriptionMaxChars safely fetches the value for global configuration 'MediaDescriptionMaxChars' field
+func GetMediaDescriptionMaxChars() int { return global.GetMediaDescriptionMaxChars() }
+
+// SetMediaDescriptionMaxChars safely sets the value for global configuration 'MediaDescriptionMaxChars' field
+func SetMediaDescriptionMaxChars(v int) { global.SetMediaDescriptionMaxChars(v) }
+
+// GetMediaRemoteCacheDays safely fetches the Configuration value for state's 'MediaRemoteCacheDays' field
+func (st *ConfigState) GetMediaRemoteCacheDays() (v int) {
+	st.mutex.RLock()
+	v = st.config.MediaRemoteCacheDays
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaRemoteCacheDays safely sets the Configuration value for state's 'MediaRemoteCacheDays' field
+func (st *ConfigState) SetMediaRemoteCacheDays(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaRemoteCacheDays = v
+	st.reloadToViper()
+}
+
+// MediaRemoteCacheDaysFlag returns the flag name for the 'MediaRemoteCacheDays' field
+func MediaRemoteCacheDaysFlag() string { return "media-remote-cache-days" }
+
+// GetMediaRemoteCacheDays safely fetches the value for global configuration 'MediaRemoteCacheDays' field
+func GetMediaRemoteCacheDays() int { return global.GetMediaRemoteCacheDays() }
+
+// SetMediaRemoteCacheDays safely sets the value for global configuration 'MediaRemoteCacheDays' field
+func SetMediaRemoteCacheDays(v int) { global.SetMediaRemoteCacheDays(v) }
+
+// GetMediaRemoteStripMetadata safely fetches the Configuration value for state's 'MediaRemoteStripMetadata' field
+func (st *ConfigState) GetMediaRemoteStripMetadata() (v bool) {
+	st.mutex.RLock()
+	v = st.config.MediaRemoteStripMetadata
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaRemoteStripMetadata safely sets the Configuration value for state's 'MediaRemoteStripMetadata' field
+func (st *ConfigState) SetMediaRemoteStripMetadata(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaRemoteStripMetadata = v
+	st.reloadToViper()
+}
+
+// MediaRemoteStripMetadataFlag returns the flag name for the 'MediaRemoteStripMetadata' field
+func MediaRemoteStripMetadataFlag() string { return "media-remote-strip-metadata" }
+
+// GetMediaRemoteStripMetadata safely fetches the value for global configuration 'MediaRemoteStripMetadata' field
+func GetMediaRemoteStripMetadata() bool { return global.GetMediaRemoteStripMetadata() }
+
+// SetMediaRemoteStripMetadata safely sets the value for global configuration 'MediaRemoteStripMetadata' field
+func SetMediaRemoteStripMetadata(v bool) { global.SetMediaRemoteStripMetadata(v) }
+
+// GetMediaEmojiLocalMaxSize safely fetches the Configuration value for state's 'MediaEmojiLocalMaxSize' field
 func (st *ConfigState) GetMediaEmojiLocalMaxSize() (v bytesize.Size) {
 	st.mutex.RLock()
-	v = st.config.MediaEmojiLocalMaxSize
+	v = st.config.MediaEmojiLocalMaxSize
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaEmojiLocalMaxSize safely sets the Configuration value for state's 'MediaEmojiLocalMaxSize' field
+func (st *ConfigState) SetMediaEmojiLocalMaxSize(v bytesize.Size) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaEmojiLocalMaxSize = v
+	st.reloadToViper()
+}
+
+// MediaEmojiLocalMaxSizeFlag returns the flag name for the 'MediaEmojiLocalMaxSize' field
+func MediaEmojiLocalMaxSizeFlag() string { return "media-emoji-local-max-size" }
+
+// GetMediaEmojiLocalMaxSize safely fetches the value for global configuration 'MediaEmojiLocalMaxSize' field
+func GetMediaEmojiLocalMaxSize() bytesize.Size { return global.GetMediaEmojiLocalMaxSize() }
+
+// SetMediaEmojiLocalMaxSize safely sets the value for global configuration 'MediaEmojiLocalMaxSize' field
+func SetMediaEmojiLocalMaxSize(v bytesize.Size) { global.SetMediaEmojiLocalMaxSize(v) }
+
+// GetMediaEmojiRemoteMaxSize safely fetches the Configuration value for state's 'MediaEmojiRemoteMaxSize' field
+func (st *ConfigState) GetMediaEmojiRemoteMaxSize() (v bytesize.Size) {
+	st.mutex.RLock()
+	v = st.config.MediaEmojiRemoteMaxSize
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaEmojiRemoteMaxSize safely sets the Configuration value for state's 'MediaEmojiRemoteMaxSize' field
+func (st *ConfigState) SetMediaEmojiRemoteMaxSize(v bytesize.Size) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaEmojiRemoteMaxSize = v
+	st.reloadToViper()
+}
+
+// MediaEmojiRemoteMaxSizeFlag returns the flag name for the 'MediaEmojiRemoteMaxSize' field
+func MediaEmojiRemoteMaxSizeFlag() string { return "media-emoji-remote-max-size" }
+
+// GetMediaEmojiRemoteMaxSize safely fetches the value for global configuration 'MediaEmojiRemoteMaxSize' field
+func GetMediaEmojiRemoteMaxSize() bytesize.Size { return global.GetMediaEmojiRemoteMaxSize() }
+
+// SetMediaEmojiRemoteMaxSize safely sets the value for global configuration 'MediaEmojiRemoteMaxSize' field
+func SetMediaEmojiRemoteMaxSize(v bytesize.Size) { global.SetMediaEmojiRemoteMaxSize(v) }
+
+// GetMediaCleanupFrom safely fetches the Configuration value for state's 'MediaCleanupFrom' field
+func (st *ConfigState) GetMediaCleanupFrom() (v string) {
+	st.mutex.RLock()
+	v = st.config.MediaCleanupFrom
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaCleanupFrom safely sets the Configuration value for state's 'MediaCleanupFrom' field
+func (st *ConfigState) SetMediaCleanupFrom(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaCleanupFrom = v
+	st.reloadToViper()
+}
+
+// MediaCleanupFromFlag returns the flag name for the 'MediaCleanupFrom' field
+func MediaCleanupFromFlag() string { return "media-cleanup-from" }
+
+// GetMediaCleanupFrom safely fetches the value for global configuration 'MediaCleanupFrom' field
+func GetMediaCleanupFrom() string { return global.GetMediaCleanupFrom() }
+
+// SetMediaCleanupFrom safely sets the value for global configuration 'MediaCleanupFrom' field
+func SetMediaCleanupFrom(v string) { global.SetMediaCleanupFrom(v) }
+
+// GetMediaCleanupEvery safely fetches the Configuration value for state's 'MediaCleanupEvery' field
+func (st *ConfigState) GetMediaCleanupEvery() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.MediaCleanupEvery
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaCleanupEvery safely sets the Configuration value for state's 'MediaCleanupEvery' field
+func (st *ConfigState) SetMediaCleanupEvery(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaCleanupEvery = v
+	st.reloadToViper()
+}
+
+// MediaCleanupEveryFlag returns the flag name for the 'MediaCleanupEvery' field
+func MediaCleanupEveryFlag() string { return "media-cleanup-every" }
+
+// GetMediaCleanupEvery safely fetches the value for global configuration 'MediaCleanupEvery' field
+func GetMediaCleanupEvery() time.Duration { return global.GetMediaCleanupEvery() }
+
+// SetMediaCleanupEvery safely sets the value for global configuration 'MediaCleanupEvery' field
+func SetMediaCleanupEvery(v time.Duration) { global.SetMediaCleanupEvery(v) }
+
+// GetMediaClamAVAddress safely fetches the Configuration value for state's 'MediaClamAVAddress' field
+func (st *ConfigState) GetMediaClamAVAddress() (v string) {
+	st.mutex.RLock()
+	v = st.config.MediaClamAVAddress
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaClamAVAddress safely sets the Configuration value for state's 'MediaClamAVAddress' field
+func (st *ConfigState) SetMediaClamAVAddress(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaClamAVAddress = v
+	st.reloadToViper()
+}
+
+// MediaClamAVAddressFlag returns the flag name for the 'MediaClamAVAddress' field
+func MediaClamAVAddressFlag() string { return "media-clamav-address" }
+
+// GetMediaClamAVAddress safely fetches the value for global configuration 'MediaClamAVAddress' field
+func GetMediaClamAVAddress() string { return global.GetMediaClamAVAddress() }
+
+// SetMediaClamAVAddress safely sets the value for global configuration 'MediaClamAVAddress' field
+func SetMediaClamAVAddress(v string) { global.SetMediaClamAVAddress(v) }
+
+// GetMediaClamAVAction safely fetches the Configuration value for state's 'MediaClamAVAction' field
+func (st *ConfigState) GetMediaClamAVAction() (v string) {
+	st.mutex.RLock()
+	v = st.config.MediaClamAVAction
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaClamAVAction safely sets the Configuration value for state's 'MediaClamAVAction' field
+func (st *ConfigState) SetMediaClamAVAction(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaClamAVAction = v
+	st.reloadToViper()
+}
+
+// MediaClamAVActionFlag returns the flag name for the 'MediaClamAVAction' field
+func MediaClamAVActionFlag() string { return "media-clamav-action" }
+
+// GetMediaClamAVAction safely fetches the value for global configuration 'MediaClamAVAction' field
+func GetMediaClamAVAction() string { return global.GetMediaClamAVAction() }
+
+// SetMediaClamAVAction safely sets the value for global configuration 'MediaClamAVAction' field
+func SetMediaClamAVAction(v string) { global.SetMediaClamAVAction(v) }
+
+// GetMediaClamAVTimeout safely fetches the Configuration value for state's 'MediaClamAVTimeout' field
+func (st *ConfigState) GetMediaClamAVTimeout() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.MediaClamAVTimeout
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaClamAVTimeout safely sets the Configuration value for state's 'MediaClamAVTimeout' field
+func (st *ConfigState) SetMediaClamAVTimeout(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaClamAVTimeout = v
+	st.reloadToViper()
+}
+
+// MediaClamAVTimeoutFlag returns the flag name for the 'MediaClamAVTimeout' field
+func MediaClamAVTimeoutFlag() string { return "media-clamav-timeout" }
+
+// GetMediaClamAVTimeout safely fetches the value for global configuration 'MediaClamAVTimeout' field
+func GetMediaClamAVTimeout() time.Duration { return global.GetMediaClamAVTimeout() }
+
+// SetMediaClamAVTimeout safely sets the value for global configuration 'MediaClamAVTimeout' field
+func SetMediaClamAVTimeout(v time.Duration) { global.SetMediaClamAVTimeout(v) }
+
+// GetMediaFFmpegPath safely fetches the Configuration value for state's 'MediaFFmpegPath' field
+func (st *ConfigState) GetMediaFFmpegPath() (v string) {
+	st.mutex.RLock()
+	v = st.config.MediaFFmpegPath
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaFFmpegPath safely sets the Configuration value for state's 'MediaFFmpegPath' field
+func (st *ConfigState) SetMediaFFmpegPath(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaFFmpegPath = v
+	st.reloadToViper()
+}
+
+// MediaFFmpegPathFlag returns the flag name for the 'MediaFFmpegPath' field
+func MediaFFmpegPathFlag() string { return "media-ffmpeg-path" }
+
+// GetMediaFFmpegPath safely fetches the value for global configuration 'MediaFFmpegPath' field
+func GetMediaFFmpegPath() string { return global.GetMediaFFmpegPath() }
+
+// SetMediaFFmpegPath safely sets the value for global configuration 'MediaFFmpegPath' field
+func SetMediaFFmpegPath(v string) { global.SetMediaFFmpegPath(v) }
+
+// GetMediaGIFConvertMinSize safely fetches the Configuration value for state's 'MediaGIFConvertMinSize' field
+func (st *ConfigState) GetMediaGIFConvertMinSize() (v bytesize.Size) {
+	st.mutex.RLock()
+	v = st.config.MediaGIFConvertMinSize
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaGIFConvertMinSize safely sets the Configuration value for state's 'MediaGIFConvertMinSize' field
+func (st *ConfigState) SetMediaGIFConvertMinSize(v bytesize.Size) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaGIFConvertMinSize = v
+	st.reloadToViper()
+}
+
+// MediaGIFConvertMinSizeFlag returns the flag name for the 'MediaGIFConvertMinSize' field
+func MediaGIFConvertMinSizeFlag() string { return "media-gif-convert-min-size" }
+
+// GetMediaGIFConvertMinSize safely fetches the value for global configuration 'MediaGIFConvertMinSize' field
+func GetMediaGIFConvertMinSize() bytesize.Size { return global.GetMediaGIFConvertMinSize() }
+
+// SetMediaGIFConvertMinSize safely sets the value for global configuration 'MediaGIFConvertMinSize' field
+func SetMediaGIFConvertMinSize(v bytesize.Size) { global.SetMediaGIFConvertMinSize(v) }
+
+// GetMediaResumableUploadMinSize safely fetches the Configuration value for state's 'MediaResumableUploadMinSize' field
+func (st *ConfigState) GetMediaResumableUploadMinSize() (v bytesize.Size) {
+	st.mutex.RLock()
+	v = st.config.MediaResumableUploadMinSize
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaResumableUploadMinSize safely sets the Configuration value for state's 'MediaResumableUploadMinSize' field
+func (st *ConfigState) SetMediaResumableUploadMinSize(v bytesize.Size) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaResumableUploadMinSize = v
+	st.reloadToViper()
+}
+
+// MediaResumableUploadMinSizeFlag returns the flag name for the 'MediaResumableUploadMinSize' field
+func MediaResumableUploadMinSizeFlag() string { return "media-resumable-upload-min-size" }
+
+// GetMediaResumableUploadMinSize safely fetches the value for global configuration 'MediaResumableUploadMinSize' field
+func GetMediaResumableUploadMinSize() bytesize.Size { return global.GetMediaResumableUploadMinSize() }
+
+// SetMediaResumableUploadMinSize safely sets the value for global configuration 'MediaResumableUploadMinSize' field
+func SetMediaResumableUploadMinSize(v bytesize.Size) { global.SetMediaResumableUploadMinSize(v) }
+
+// GetMediaResumableUploadMinPartSize safely fetches the Configuration value for state's 'MediaResumableUploadMinPartSize' field
+func (st *ConfigState) GetMediaResumableUploadMinPartSize() (v bytesize.Size) {
+	st.mutex.RLock()
+	v = st.config.MediaResumableUploadMinPartSize
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaResumableUploadMinPartSize safely sets the Configuration value for state's 'MediaResumableUploadMinPartSize' field
+func (st *ConfigState) SetMediaResumableUploadMinPartSize(v bytesize.Size) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaResumableUploadMinPartSize = v
+	st.reloadToViper()
+}
+
+// MediaResumableUploadMinPartSizeFlag returns the flag name for the 'MediaResumableUploadMinPartSize' field
+func MediaResumableUploadMinPartSizeFlag() string { return "media-resumable-upload-min-part-size" }
+
+// GetMediaResumableUploadMinPartSize safely fetches the value for global configuration 'MediaResumableUploadMinPartSize' field
+func GetMediaResumableUploadMinPartSize() bytesize.Size {
+	return global.GetMediaResumableUploadMinPartSize()
+}
+
+// SetMediaResumableUploadMinPartSize safely sets the value for global configuration 'MediaResumableUploadMinPartSize' field
+func SetMediaResumableUploadMinPartSize(v bytesize.Size) {
+	global.SetMediaResumableUploadMinPartSize(v)
+}
+
+// GetMediaResumableUploadExpiry safely fetches the Configuration value for state's 'MediaResumableUploadExpiry' field
+func (st *ConfigState) GetMediaResumableUploadExpiry() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.MediaResumableUploadExpiry
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaResumableUploadExpiry safely sets the Configuration value for state's 'MediaResumableUploadExpiry' field
+func (st *ConfigState) SetMediaResumableUploadExpiry(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaResumableUploadExpiry = v
+	st.reloadToViper()
+}
+
+// MediaResumableUploadExpiryFlag returns the flag name for the 'MediaResumableUploadExpiry' field
+func MediaResumableUploadExpiryFlag() string { return "media-resumable-upload-expiry" }
+
+// GetMediaResumableUploadExpiry safely fetches the value for global configuration 'MediaResumableUploadExpiry' field
+func GetMediaResumableUploadExpiry() time.Duration { return global.GetMediaResumableUploadExpiry() }
+
+// SetMediaResumableUploadExpiry safely sets the value for global configuration 'MediaResumableUploadExpiry' field
+func SetMediaResumableUploadExpiry(v time.Duration) { global.SetMediaResumableUploadExpiry(v) }
+
+// GetMediaResumableUploadMaxPerAccount safely fetches the Configuration value for state's 'MediaResumableUploadMaxPerAccount' field
+func (st *ConfigState) GetMediaResumableUploadMaxPerAccount() (v int) {
+	st.mutex.RLock()
+	v = st.config.MediaResumableUploadMaxPerAccount
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaResumableUploadMaxPerAccount safely sets the Configuration value for state's 'MediaResumableUploadMaxPerAccount' field
+func (st *ConfigState) SetMediaResumableUploadMaxPerAccount(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaResumableUploadMaxPerAccount = v
+	st.reloadToViper()
+}
+
+// MediaResumableUploadMaxPerAccountFlag returns the flag name for the 'MediaResumableUploadMaxPerAccount' field
+func MediaResumableUploadMaxPerAccountFlag() string { return "media-resumable-upload-max-per-account" }
+
+// GetMediaResumableUploadMaxPerAccount safely fetches the value for global configuration 'MediaResumableUploadMaxPerAccount' field
+func GetMediaResumableUploadMaxPerAccount() int { return global.GetMediaResumableUploadMaxPerAccount() }
+
+// SetMediaResumableUploadMaxPerAccount safely sets the value for global configuration 'MediaResumableUploadMaxPerAccount' field
+func SetMediaResumableUploadMaxPerAccount(v int) { global.SetMediaResumableUploadMaxPerAccount(v) }
+
+// GetMediaEncodeConcurrency safely fetches the Configuration value for state's 'MediaEncodeConcurrency' field
+func (st *ConfigState) GetMediaEncodeConcurrency() (v int) {
+	st.mutex.RLock()
+	v = st.config.MediaEncodeConcurrency
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaEncodeConcurrency safely sets the Configuration value for state's 'MediaEncodeConcurrency' field
+func (st *ConfigState) SetMediaEncodeConcurrency(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaEncodeConcurrency = v
+	st.reloadToViper()
+}
+
+// MediaEncodeConcurrencyFlag returns the flag name for the 'MediaEncodeConcurrency' field
+func MediaEncodeConcurrencyFlag() string { return "media-encode-concurrency" }
+
+// GetMediaEncodeConcurrency safely fetches the value for global configuration 'MediaEncodeConcurrency' field
+func GetMediaEncodeConcurrency() int { return global.GetMediaEncodeConcurrency() }
+
+// SetMediaEncodeConcurrency safely sets the value for global configuration 'MediaEncodeConcurrency' field
+func SetMediaEncodeConcurrency(v int) { global.SetMediaEncodeConcurrency(v) }
+
+// GetRetentionLocalStatusDays safely fetches the Configuration value for state's 'RetentionLocalStatusDays' field
+func (st *ConfigState) GetRetentionLocalStatusDays() (v int) {
+	st.mutex.RLock()
+	v = st.config.RetentionLocalStatusDays
+	st.mutex.RUnlock()
+	return
+}
+
+// SetRetentionLocalStatusDays safely sets the Configuration value for state's 'RetentionLocalStatusDays' field
+func (st *ConfigState) SetRetentionLocalStatusDays(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.RetentionLocalStatusDays = v
+	st.reloadToViper()
+}
+
+// RetentionLocalStatusDaysFlag returns the flag name for the 'RetentionLocalStatusDays' field
+func RetentionLocalStatusDaysFlag() string { return "retention-local-status-days" }
+
+// GetRetentionLocalStatusDays safely fetches the value for global configuration 'RetentionLocalStatusDays' field
+func GetRetentionLocalStatusDays() int { return global.GetRetentionLocalStatusDays() }
+
+// SetRetentionLocalStatusDays safely sets the value for global configuration 'RetentionLocalStatusDays' field
+func SetRetentionLocalStatusDays(v int) { global.SetRetentionLocalStatusDays(v) }
+
+// GetRetentionNotificationDays safely fetches the Configuration value for state's 'RetentionNotificationDays' field
+func (st *ConfigState) GetRetentionNotificationDays() (v int) {
+	st.mutex.RLock()
+	v = st.config.RetentionNotificationDays
 	st.mutex.RUnlock()
 	return
 }
 
-// SetMediaEmojiLocalMaxSize safely sets the Configuration value for state's 'MediaEmojiLocalMaxSize' field
-func (st *ConfigState) SetMediaEmojiLocalMaxSize(v bytesize.Size) {
+// SetRetentionNotificationDays safely sets the Configuration value for state's 'RetentionNotificationDays' field
+func (st *ConfigState) SetRetentionNotificationDays(v int) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.MediaEmojiLocalMaxSize = v
+	st.config.RetentionNotificationDays = v
 	st.reloadToViper()
 }
 
-// MediaEmojiLocalMaxSizeFlag returns the flag name for the 'MediaEmojiLocalMaxSize' field
-func MediaEmojiLocalMaxSizeFlag() string { return "media-emoji-local-max-size" }
+// RetentionNotificationDaysFlag returns the flag name for the 'RetentionNotificationDays' field
+func RetentionNotificationDaysFlag() string { return "retention-notification-days" }
 
-// GetMediaEmojiLocalMaxSize safely fetches the value for global configuration 'MediaEmojiLocalMaxSize' field
-func GetMediaEmojiLocalMaxSize() bytesize.Size { return global.GetMediaEmojiLocalMaxSize() }
+// GetRetentionNotificationDays safely fetches the value for global configuration 'RetentionNotificationDays' field
+func GetRetentionNotificationDays() int { return global.GetRetentionNotificationDays() }
 
-// SetMediaEmojiLocalMaxSize safely sets the value for global configuration 'MediaEmojiLocalMaxSize' field
-func SetMediaEmojiLocalMaxSize(v bytesize.Size) { global.SetMediaEmojiLocalMaxSize(v) }
+// SetRetentionNotificationDays safely sets the value for global configuration 'RetentionNotificationDays' field
+func SetRetentionNotificationDays(v int) { global.SetRetentionNotificationDays(v) }
 
-// GetMediaEmojiRemoteMaxSize safely fetches the Configuration value for state's 'MediaEmojiRemoteMaxSize' field
-func (st *ConfigState) GetMediaEmojiRemoteMaxSize() (v bytesize.Size) {
+// GetRetentionReadNotificationDays safely fetches the Configuration value for state's 'RetentionReadNotificationDays' field
+func (st *ConfigState) GetRetentionReadNotificationDays() (v int) {
 	st.mutex.RLock()
-	v = st.config.MediaEmojiRemoteMaxSize
+	v = st.config.RetentionReadNotificationDays
 	st.mutex.RUnlock()
 	return
 }
 
-// SetMediaEmojiRemoteMaxSize safely sets the Configuration value for state's 'MediaEmojiRemoteMaxSize' field
-func (st *ConfigState) SetMediaEmojiRemoteMaxSize(v bytesize.Size) {
+// SetRetentionReadNotificationDays safely sets the Configuration value for state's 'RetentionReadNotificationDays' field
+func (st *ConfigState) SetRetentionReadNotificationDays(v int) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.MediaEmojiRemoteMaxSize = v
+	st.config.RetentionReadNotificationDays = v
 	st.reloadToViper()
 }
 
-// MediaEmojiRemoteMaxSizeFlag returns the flag name for the 'MediaEmojiRemoteMaxSize' field
-func MediaEmojiRemoteMaxSizeFlag() string { return "media-emoji-remote-max-size" }
+// RetentionReadNotificationDaysFlag returns the flag name for the 'RetentionReadNotificationDays' field
+func RetentionReadNotificationDaysFlag() string { return "retention-read-notification-days" }
 
-// GetMediaEmojiRemoteMaxSize safely fetches the value for global configuration 'MediaEmojiRemoteMaxSize' field
-func GetMediaEmojiRemoteMaxSize() bytesize.Size { return global.GetMediaEmojiRemoteMaxSize() }
+// GetRetentionReadNotificationDays safely fetches the value for global configuration 'RetentionReadNotificationDays' field
+func GetRetentionReadNotificationDays() int { return global.GetRetentionReadNotificationDays() }
 
-// SetMediaEmojiRemoteMaxSize safely sets the value for global configuration 'MediaEmojiRemoteMaxSize' field
-func SetMediaEmojiRemoteMaxSize(v bytesize.Size) { global.SetMediaEmojiRemoteMaxSize(v) }
+// SetRetentionReadNotificationDays safely sets the value for global configuration 'RetentionReadNotificationDays' field
+func SetRetentionReadNotificationDays(v int) { global.SetRetentionReadNotificationDays(v) }
 
-// GetMediaCleanupFrom safely fetches the Configuration value for state's 'MediaCleanupFrom' field
-func (st *ConfigState) GetMediaCleanupFrom() (v string) {
+// GetRetentionNotificationMaxPerAccount safely fetches the Configuration value for state's 'RetentionNotificationMaxPerAccount' field
+func (st *ConfigState) GetRetentionNotificationMaxPerAccount() (v int) {
 	st.mutex.RLock()
-	v = st.config.MediaCleanupFrom
+	v = st.config.RetentionNotificationMaxPerAccount
 	st.mutex.RUnlock()
 	return
 }
 
-// SetMediaCleanupFrom safely sets the Configuration value for state's 'MediaCleanupFrom' field
-func (st *ConfigState) SetMediaCleanupFrom(v string) {
+// SetRetentionNotificationMaxPerAccount safely sets the Configuration value for state's 'RetentionNotificationMaxPerAccount' field
+func (st *ConfigState) SetRetentionNotificationMaxPerAccount(v int) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.MediaCleanupFrom = v
+	st.config.RetentionNotificationMaxPerAccount = v
 	st.reloadToViper()
 }
 
-// MediaCleanupFromFlag returns the flag name for the 'MediaCleanupFrom' field
-func MediaCleanupFromFlag() string { return "media-cleanup-from" }
+// RetentionNotificationMaxPerAccountFlag returns the flag name for the 'RetentionNotificationMaxPerAccount' field
+func RetentionNotificationMaxPerAccountFlag() string { return "retention-notification-max-per-account" }
 
-// GetMediaCleanupFrom safely fetches the value for global configuration 'MediaCleanupFrom' field
-func GetMediaCleanupFrom() string { return global.GetMediaCleanupFrom() }
+// GetRetentionNotificationMaxPerAccount safely fetches the value for global configuration 'RetentionNotificationMaxPerAccount' field
+func GetRetentionNotificationMaxPerAccount() int {
+	return global.GetRetentionNotificationMaxPerAccount()
+}
 
-// SetMediaCleanupFrom safely sets the value for global configuration 'MediaCleanupFrom' field
-func SetMediaCleanupFrom(v string) { global.SetMediaCleanupFrom(v) }
+// SetRetentionNotificationMaxPerAccount safely sets the value for global configuration 'RetentionNotificationMaxPerAccount' field
+func SetRetentionNotificationMaxPerAccount(v int) { global.SetRetentionNotificationMaxPerAccount(v) }
 
-// GetMediaCleanupEvery safely fetches the Configuration value for state's 'MediaCleanupEvery' field
-func (st *ConfigState) GetMediaCleanupEvery() (v time.Duration) {
+// GetRetentionRemoteStatusDays safely fetches the Configuration value for state's 'RetentionRemoteStatusDays' field
+func (st *ConfigState) GetRetentionRemoteStatusDays() (v int) {
 	st.mutex.RLock()
-	v = st.config.MediaCleanupEvery
+	v = st.config.RetentionRemoteStatusDays
 	st.mutex.RUnlock()
 	return
 }
 
-// SetMediaCleanupEvery safely sets the Configuration value for state's 'MediaCleanupEvery' field
-func (st *ConfigState) SetMediaCleanupEvery(v time.Duration) {
+// SetRetentionRemoteStatusDays safely sets the Configuration value for state's 'RetentionRemoteStatusDays' field
+func (st *ConfigState) SetRetentionRemoteStatusDays(v int) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.MediaCleanupEvery = v
+	st.config.RetentionRemoteStatusDays = v
 	st.reloadToViper()
 }
 
-// MediaCleanupEveryFlag returns the flag name for the 'MediaCleanupEvery' field
-func MediaCleanupEveryFlag() string { return "media-cleanup-every" }
+// RetentionRemoteStatusDaysFlag returns the flag name for the 'RetentionRemoteStatusDays' field
+func RetentionRemoteStatusDaysFlag() string { return "retention-remote-status-days" }
 
-// GetMediaCleanupEvery safely fetches the value for global configuration 'MediaCleanupEvery' field
-func GetMediaCleanupEvery() time.Duration { return global.GetMediaCleanupEvery() }
+// GetRetentionRemoteStatusDays safely fetches the value for global configuration 'RetentionRemoteStatusDays' field
+func GetRetentionRemoteStatusDays() int { return global.GetRetentionRemoteStatusDays() }
 
-// SetMediaCleanupEvery safely sets the value for global configuration 'MediaCleanupEvery' field
-func SetMediaCleanupEvery(v time.Duration) { global.SetMediaCleanupEvery(v) }
+// SetRetentionRemoteStatusDays safely sets the value for global configuration 'RetentionRemoteStatusDays' field
+func SetRetentionRemoteStatusDays(v int) { global.SetRetentionRemoteStatusDays(v) }
+
+// GetRetentionRemoteStatusArchive safely fetches the Configuration value for state's 'RetentionRemoteStatusArchive' field
+func (st *ConfigState) GetRetentionRemoteStatusArchive() (v bool) {
+	st.mutex.RLock()
+	v = st.config.RetentionRemoteStatusArchive
+	st.mutex.RUnlock()
+	return
+}
+
+// SetRetentionRemoteStatusArchive safely sets the Configuration value for state's 'RetentionRemoteStatusArchive' field
+func (st *ConfigState) SetRetentionRemoteStatusArchive(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.RetentionRemoteStatusArchive = v
+	st.reloadToViper()
+}
+
+// RetentionRemoteStatusArchiveFlag returns the flag name for the 'RetentionRemoteStatusArchive' field
+func RetentionRemoteStatusArchiveFlag() string { return "retention-remote-status-archive" }
+
+// GetRetentionRemoteStatusArchive safely fetches the value for global configuration 'RetentionRemoteStatusArchive' field
+func GetRetentionRemoteStatusArchive() bool { return global.GetRetentionRemoteStatusArchive() }
+
+// SetRetentionRemoteStatusArchive safely sets the value for global configuration 'RetentionRemoteStatusArchive' field
+func SetRetentionRemoteStatusArchive(v bool) { global.SetRetentionRemoteStatusArchive(v) }
+
+// GetRetentionUnconfirmedUserDays safely fetches the Configuration value for state's 'RetentionUnconfirmedUserDays' field
+func (st *ConfigState) GetRetentionUnconfirmedUserDays() (v int) {
+	st.mutex.RLock()
+	v = st.config.RetentionUnconfirmedUserDays
+	st.mutex.RUnlock()
+	return
+}
+
+// SetRetentionUnconfirmedUserDays safely sets the Configuration value for state's 'RetentionUnconfirmedUserDays' field
+func (st *ConfigState) SetRetentionUnconfirmedUserDays(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.RetentionUnconfirmedUserDays = v
+	st.reloadToViper()
+}
+
+// RetentionUnconfirmedUserDaysFlag returns the flag name for the 'RetentionUnconfirmedUserDays' field
+func RetentionUnconfirmedUserDaysFlag() string { return "retention-unconfirmed-user-days" }
+
+// GetRetentionUnconfirmedUserDays safely fetches the value for global configuration 'RetentionUnconfirmedUserDays' field
+func GetRetentionUnconfirmedUserDays() int { return global.GetRetentionUnconfirmedUserDays() }
+
+// SetRetentionUnconfirmedUserDays safely sets the value for global configuration 'RetentionUnconfirmedUserDays' field
+func SetRetentionUnconfirmedUserDays(v int) { global.SetRetentionUnconfirmedUserDays(v) }
+
+// GetRetentionDryRun safely fetches the Configuration value for state's 'RetentionDryRun' field
+func (st *ConfigState) GetRetentionDryRun() (v bool) {
+	st.mutex.RLock()
+	v = st.config.RetentionDryRun
+	st.mutex.RUnlock()
+	return
+}
+
+// SetRetentionDryRun safely sets the Configuration value for state's 'RetentionDryRun' field
+func (st *ConfigState) SetRetentionDryRun(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.RetentionDryRun = v
+	st.reloadToViper()
+}
+
+// RetentionDryRunFlag returns the flag name for the 'RetentionDryRun' field
+func RetentionDryRunFlag() string { return "retention-dry-run" }
+
+// GetRetentionDryRun safely fetches the value for global configuration 'RetentionDryRun' field
+func GetRetentionDryRun() bool { return global.GetRetentionDryRun() }
+
+// SetRetentionDryRun safely sets the value for global configuration 'RetentionDryRun' field
+func SetRetentionDryRun(v bool) { global.SetRetentionDryRun(v) }
 
 // GetStorageBackend safely fetches the Configuration value for state's 'StorageBackend' field
 func (st *ConfigState) GetStorageBackend() (v string) {
@@ -1416,89 +2527,314 @@ func (st *ConfigState) SetStorageS3SecretKey(v string) {
 	st.reloadToViper()
 }
 
-// StorageS3SecretKeyFlag returns the flag name for the 'StorageS3SecretKey' field
-func StorageS3SecretKeyFlag() string { return "storage-s3-secret-key" }
+// StorageS3SecretKeyFlag returns the flag name for the 'StorageS3SecretKey' field
+func StorageS3SecretKeyFlag() string { return "storage-s3-secret-key" }
+
+// GetStorageS3SecretKey safely fetches the value for global configuration 'StorageS3SecretKey' field
+func GetStorageS3SecretKey() string { return global.GetStorageS3SecretKey() }
+
+// SetStorageS3SecretKey safely sets the value for global configuration 'StorageS3SecretKey' field
+func SetStorageS3SecretKey(v string) { global.SetStorageS3SecretKey(v) }
+
+// GetStorageS3UseSSL safely fetches the Configuration value for state's 'StorageS3UseSSL' field
+func (st *ConfigState) GetStorageS3UseSSL() (v bool) {
+	st.mutex.RLock()
+	v = st.config.StorageS3UseSSL
+	st.mutex.RUnlock()
+	return
+}
+
+// SetStorageS3UseSSL safely sets the Configuration value for state's 'StorageS3UseSSL' field
+func (st *ConfigState) SetStorageS3UseSSL(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StorageS3UseSSL = v
+	st.reloadToViper()
+}
+
+// StorageS3UseSSLFlag returns the flag name for the 'StorageS3UseSSL' field
+func StorageS3UseSSLFlag() string { return "storage-s3-use-ssl" }
+
+// GetStorageS3UseSSL safely fetches the value for global configuration 'StorageS3UseSSL' field
+func GetStorageS3UseSSL() bool { return global.GetStorageS3UseSSL() }
+
+// SetStorageS3UseSSL safely sets the value for global configuration 'StorageS3UseSSL' field
+func SetStorageS3UseSSL(v bool) { global.SetStorageS3UseSSL(v) }
+
+// GetStorageS3BucketName safely fetches the Configuration value for state's 'StorageS3BucketName' field
+func (st *ConfigState) GetStorageS3BucketName() (v string) {
+	st.mutex.RLock()
+	v = st.config.StorageS3BucketName
+	st.mutex.RUnlock()
+	return
+}
+
+// SetStorageS3BucketName safely sets the Configuration value for state's 'StorageS3BucketName' field
+func (st *ConfigState) SetStorageS3BucketName(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StorageS3BucketName = v
+	st.reloadToViper()
+}
+
+// StorageS3BucketNameFlag returns the flag name for the 'StorageS3BucketName' field
+func StorageS3BucketNameFlag() string { return "storage-s3-bucket" }
+
+// GetStorageS3BucketName safely fetches the value for global configuration 'StorageS3BucketName' field
+func GetStorageS3BucketName() string { return global.GetStorageS3BucketName() }
+
+// SetStorageS3BucketName safely sets the value for global configuration 'StorageS3BucketName' field
+func SetStorageS3BucketName(v string) { global.SetStorageS3BucketName(v) }
+
+// GetStorageS3Proxy safely fetches the Configuration value for state's 'StorageS3Proxy' field
+func (st *ConfigState) GetStorageS3Proxy() (v bool) {
+	st.mutex.RLock()
+	v = st.config.StorageS3Proxy
+	st.mutex.RUnlock()
+	return
+}
+
+// SetStorageS3Proxy safely sets the Configuration value for state's 'StorageS3Proxy' field
+func (st *ConfigState) SetStorageS3Proxy(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StorageS3Proxy = v
+	st.reloadToViper()
+}
+
+// StorageS3ProxyFlag returns the flag name for the 'StorageS3Proxy' field
+func StorageS3ProxyFlag() string { return "storage-s3-proxy" }
+
+// GetStorageS3Proxy safely fetches the value for global configuration 'StorageS3Proxy' field
+func GetStorageS3Proxy() bool { return global.GetStorageS3Proxy() }
+
+// SetStorageS3Proxy safely sets the value for global configuration 'StorageS3Proxy' field
+func SetStorageS3Proxy(v bool) { global.SetStorageS3Proxy(v) }
+
+// GetStorageS3BucketPublic safely fetches the Configuration value for state's 'StorageS3BucketPublic' field
+func (st *ConfigState) GetStorageS3BucketPublic() (v bool) {
+	st.mutex.RLock()
+	v = st.config.StorageS3BucketPublic
+	st.mutex.RUnlock()
+	return
+}
+
+// SetStorageS3BucketPublic safely sets the Configuration value for state's 'StorageS3BucketPublic' field
+func (st *ConfigState) SetStorageS3BucketPublic(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StorageS3BucketPublic = v
+	st.reloadToViper()
+}
+
+// StorageS3BucketPublicFlag returns the flag name for the 'StorageS3BucketPublic' field
+func StorageS3BucketPublicFlag() string { return "storage-s3-bucket-public" }
+
+// GetStorageS3BucketPublic safely fetches the value for global configuration 'StorageS3BucketPublic' field
+func GetStorageS3BucketPublic() bool { return global.GetStorageS3BucketPublic() }
+
+// SetStorageS3BucketPublic safely sets the value for global configuration 'StorageS3BucketPublic' field
+func SetStorageS3BucketPublic(v bool) { global.SetStorageS3BucketPublic(v) }
+
+// GetStorageGCSBucket safely fetches the Configuration value for state's 'StorageGCSBucket' field
+func (st *ConfigState) GetStorageGCSBucket() (v string) {
+	st.mutex.RLock()
+	v = st.config.StorageGCSBucket
+	st.mutex.RUnlock()
+	return
+}
+
+// SetStorageGCSBucket safely sets the Configuration value for state's 'StorageGCSBucket' field
+func (st *ConfigState) SetStorageGCSBucket(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StorageGCSBucket = v
+	st.reloadToViper()
+}
+
+// StorageGCSBucketFlag returns the flag name for the 'StorageGCSBucket' field
+func StorageGCSBucketFlag() string { return "storage-gcs-bucket" }
+
+// GetStorageGCSBucket safely fetches the value for global configuration 'StorageGCSBucket' field
+func GetStorageGCSBucket() string { return global.GetStorageGCSBucket() }
+
+// SetStorageGCSBucket safely sets the value for global configuration 'StorageGCSBucket' field
+func SetStorageGCSBucket(v string) { global.SetStorageGCSBucket(v) }
+
+// GetStorageGCSCredentialsFile safely fetches the Configuration value for state's 'StorageGCSCredentialsFile' field
+func (st *ConfigState) GetStorageGCSCredentialsFile() (v string) {
+	st.mutex.RLock()
+	v = st.config.StorageGCSCredentialsFile
+	st.mutex.RUnlock()
+	return
+}
+
+// SetStorageGCSCredentialsFile safely sets the Configuration value for state's 'StorageGCSCredentialsFile' field
+func (st *ConfigState) SetStorageGCSCredentialsFile(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StorageGCSCredentialsFile = v
+	st.reloadToViper()
+}
+
+// StorageGCSCredentialsFileFlag returns the flag name for the 'StorageGCSCredentialsFile' field
+func StorageGCSCredentialsFileFlag() string { return "storage-gcs-credentials-file" }
+
+// GetStorageGCSCredentialsFile safely fetches the value for global configuration 'StorageGCSCredentialsFile' field
+func GetStorageGCSCredentialsFile() string { return global.GetStorageGCSCredentialsFile() }
+
+// SetStorageGCSCredentialsFile safely sets the value for global configuration 'StorageGCSCredentialsFile' field
+func SetStorageGCSCredentialsFile(v string) { global.SetStorageGCSCredentialsFile(v) }
+
+// GetStorageGCSProxy safely fetches the Configuration value for state's 'StorageGCSProxy' field
+func (st *ConfigState) GetStorageGCSProxy() (v bool) {
+	st.mutex.RLock()
+	v = st.config.StorageGCSProxy
+	st.mutex.RUnlock()
+	return
+}
+
+// SetStorageGCSProxy safely sets the Configuration value for state's 'StorageGCSProxy' field
+func (st *ConfigState) SetStorageGCSProxy(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StorageGCSProxy = v
+	st.reloadToViper()
+}
+
+// StorageGCSProxyFlag returns the flag name for the 'StorageGCSProxy' field
+func StorageGCSProxyFlag() string { return "storage-gcs-proxy" }
+
+// GetStorageGCSProxy safely fetches the value for global configuration 'StorageGCSProxy' field
+func GetStorageGCSProxy() bool { return global.GetStorageGCSProxy() }
+
+// SetStorageGCSProxy safely sets the value for global configuration 'StorageGCSProxy' field
+func SetStorageGCSProxy(v bool) { global.SetStorageGCSProxy(v) }
+
+// GetStorageAzureAccountName safely fetches the Configuration value for state's 'StorageAzureAccountName' field
+func (st *ConfigState) GetStorageAzureAccountName() (v string) {
+	st.mutex.RLock()
+	v = st.config.StorageAzureAccountName
+	st.mutex.RUnlock()
+	return
+}
+
+// SetStorageAzureAccountName safely sets the Configuration value for state's 'StorageAzureAccountName' field
+func (st *ConfigState) SetStorageAzureAccountName(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StorageAzureAccountName = v
+	st.reloadToViper()
+}
+
+// StorageAzureAccountNameFlag returns the flag name for the 'StorageAzureAccountName' field
+func StorageAzureAccountNameFlag() string { return "storage-azure-account-name" }
+
+// GetStorageAzureAccountName safely fetches the value for global configuration 'StorageAzureAccountName' field
+func GetStorageAzureAccountName() string { return global.GetStorageAzureAccountName() }
+
+// SetStorageAzureAccountName safely sets the value for global configuration 'StorageAzureAccountName' field
+func SetStorageAzureAccountName(v string) { global.SetStorageAzureAccountName(v) }
+
+// GetStorageAzureAccountKey safely fetches the Configuration value for state's 'StorageAzureAccountKey' field
+func (st *ConfigState) GetStorageAzureAccountKey() (v string) {
+	st.mutex.RLock()
+	v = st.config.StorageAzureAccountKey
+	st.mutex.RUnlock()
+	return
+}
+
+// SetStorageAzureAccountKey safely sets the Configuration value for state's 'StorageAzureAccountKey' field
+func (st *ConfigState) SetStorageAzureAccountKey(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StorageAzureAccountKey = v
+	st.reloadToViper()
+}
+
+// StorageAzureAccountKeyFlag returns the flag name for the 'StorageAzureAccountKey' field
+func StorageAzureAccountKeyFlag() string { return "storage-azure-account-key" }
 
-// GetStorageS3SecretKey safely fetches the value for global configuration 'StorageS3SecretKey' field
-func GetStorageS3SecretKey() string { return global.GetStorageS3SecretKey() }
+// GetStorageAzureAccountKey safely fetches the value for global configuration 'StorageAzureAccountKey' field
+func GetStorageAzureAccountKey() string { return global.GetStorageAzureAccountKey() }
 
-// SetStorageS3SecretKey safely sets the value for global configuration 'StorageS3SecretKey' field
-func SetStorageS3SecretKey(v string) { global.SetStorageS3SecretKey(v) }
+// SetStorageAzureAccountKey safely sets the value for global configuration 'StorageAzureAccountKey' field
+func SetStorageAzureAccountKey(v string) { global.SetStorageAzureAccountKey(v) }
 
-// GetStorageS3UseSSL safely fetches the Configuration value for state's 'StorageS3UseSSL' field
-func (st *ConfigState) GetStorageS3UseSSL() (v bool) {
+// GetStorageAzureContainer safely fetches the Configuration value for state's 'StorageAzureContainer' field
+func (st *ConfigState) GetStorageAzureContainer() (v string) {
 	st.mutex.RLock()
-	v = st.config.StorageS3UseSSL
+	v = st.config.StorageAzureContainer
 	st.mutex.RUnlock()
 	return
 }
 
-// SetStorageS3UseSSL safely sets the Configuration value for state's 'StorageS3UseSSL' field
-func (st *ConfigState) SetStorageS3UseSSL(v bool) {
+// SetStorageAzureContainer safely sets the Configuration value for state's 'StorageAzureContainer' field
+func (st *ConfigState) SetStorageAzureContainer(v string) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.StorageS3UseSSL = v
+	st.config.StorageAzureContainer = v
 	st.reloadToViper()
 }
 
-// StorageS3UseSSLFlag returns the flag name for the 'StorageS3UseSSL' field
-func StorageS3UseSSLFlag() string { return "storage-s3-use-ssl" }
+// StorageAzureContainerFlag returns the flag name for the 'StorageAzureContainer' field
+func StorageAzureContainerFlag() string { return "storage-azure-container" }
 
-// GetStorageS3UseSSL safely fetches the value for global configuration 'StorageS3UseSSL' field
-func GetStorageS3UseSSL() bool { return global.GetStorageS3UseSSL() }
+// GetStorageAzureContainer safely fetches the value for global configuration 'StorageAzureContainer' field
+func GetStorageAzureContainer() string { return global.GetStorageAzureContainer() }
 
-// SetStorageS3UseSSL safely sets the value for global configuration 'StorageS3UseSSL' field
-func SetStorageS3UseSSL(v bool) { global.SetStorageS3UseSSL(v) }
+// SetStorageAzureContainer safely sets the value for global configuration 'StorageAzureContainer' field
+func SetStorageAzureContainer(v string) { global.SetStorageAzureContainer(v) }
 
-// GetStorageS3BucketName safely fetches the Configuration value for state's 'StorageS3BucketName' field
-func (st *ConfigState) GetStorageS3BucketName() (v string) {
+// GetStorageAzureEndpoint safely fetches the Configuration value for state's 'StorageAzureEndpoint' field
+func (st *ConfigState) GetStorageAzureEndpoint() (v string) {
 	st.mutex.RLock()
-	v = st.config.StorageS3BucketName
+	v = st.config.StorageAzureEndpoint
 	st.mutex.RUnlock()
 	return
 }
 
-// SetStorageS3BucketName safely sets the Configuration value for state's 'StorageS3BucketName' field
-func (st *ConfigState) SetStorageS3BucketName(v string) {
+// SetStorageAzureEndpoint safely sets the Configuration value for state's 'StorageAzureEndpoint' field
+func (st *ConfigState) SetStorageAzureEndpoint(v string) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.StorageS3BucketName = v
+	st.config.StorageAzureEndpoint = v
 	st.reloadToViper()
 }
 
-// StorageS3BucketNameFlag returns the flag name for the 'StorageS3BucketName' field
-func StorageS3BucketNameFlag() string { return "storage-s3-bucket" }
+// StorageAzureEndpointFlag returns the flag name for the 'StorageAzureEndpoint' field
+func StorageAzureEndpointFlag() string { return "storage-azure-endpoint" }
 
-// GetStorageS3BucketName safely fetches the value for global configuration 'StorageS3BucketName' field
-func GetStorageS3BucketName() string { return global.GetStorageS3BucketName() }
+// GetStorageAzureEndpoint safely fetches the value for global configuration 'StorageAzureEndpoint' field
+func GetStorageAzureEndpoint() string { return global.GetStorageAzureEndpoint() }
 
-// SetStorageS3BucketName safely sets the value for global configuration 'StorageS3BucketName' field
-func SetStorageS3BucketName(v string) { global.SetStorageS3BucketName(v) }
+// SetStorageAzureEndpoint safely sets the value for global configuration 'StorageAzureEndpoint' field
+func SetStorageAzureEndpoint(v string) { global.SetStorageAzureEndpoint(v) }
 
-// GetStorageS3Proxy safely fetches the Configuration value for state's 'StorageS3Proxy' field
-func (st *ConfigState) GetStorageS3Proxy() (v bool) {
+// GetStorageAzureProxy safely fetches the Configuration value for state's 'StorageAzureProxy' field
+func (st *ConfigState) GetStorageAzureProxy() (v bool) {
 	st.mutex.RLock()
-	v = st.config.StorageS3Proxy
+	v = st.config.StorageAzureProxy
 	st.mutex.RUnlock()
 	return
 }
 
-// SetStorageS3Proxy safely sets the Configuration value for state's 'StorageS3Proxy' field
-func (st *ConfigState) SetStorageS3Proxy(v bool) {
+// SetStorageAzureProxy safely sets the Configuration value for state's 'StorageAzureProxy' field
+func (st *ConfigState) SetStorageAzureProxy(v bool) {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
-	st.config.StorageS3Proxy = v
+	st.config.StorageAzureProxy = v
 	st.reloadToViper()
 }
 
-// StorageS3ProxyFlag returns the flag name for the 'StorageS3Proxy' field
-func StorageS3ProxyFlag() string { return "storage-s3-proxy" }
+// StorageAzureProxyFlag returns the flag name for the 'StorageAzureProxy' field
+func StorageAzureProxyFlag() string { return "storage-azure-proxy" }
 
-// GetStorageS3Proxy safely fetches the value for global configuration 'StorageS3Proxy' field
-func GetStorageS3Proxy() bool { return global.GetStorageS3Proxy() }
+// GetStorageAzureProxy safely fetches the value for global configuration 'StorageAzureProxy' field
+func GetStorageAzureProxy() bool { return global.GetStorageAzureProxy() }
 
-// SetStorageS3Proxy safely sets the value for global configuration 'StorageS3Proxy' field
-func SetStorageS3Proxy(v bool) { global.SetStorageS3Proxy(v) }
+// SetStorageAzureProxy safely sets the value for global configuration 'StorageAzureProxy' field
+func SetStorageAzureProxy(v bool) { global.SetStorageAzureProxy(v) }
 
 // GetStatusesMaxChars safely fetches the Configuration value for state's 'StatusesMaxChars' field
 func (st *ConfigState) GetStatusesMaxChars() (v int) {
@@ -2625,6 +3961,193 @@ func GetAdvancedHeaderFilterMode() string { return global.GetAdvancedHeaderFilte
 // SetAdvancedHeaderFilterMode safely sets the value for global configuration 'AdvancedHeaderFilterMode' field
 func SetAdvancedHeaderFilterMode(v string) { global.SetAdvancedHeaderFilterMode(v) }
 
+// GetAdvancedRateLimitUnauthenticatedRequests safely fetches the Configuration value for state's 'AdvancedRateLimitUnauthenticatedRequests' field
+func (st *ConfigState) GetAdvancedRateLimitUnauthenticatedRequests() (v int) {
+	st.mutex.RLock()
+	v = st.config.AdvancedRateLimitUnauthenticatedRequests
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedRateLimitUnauthenticatedRequests safely sets the Configuration value for state's 'AdvancedRateLimitUnauthenticatedRequests' field
+func (st *ConfigState) SetAdvancedRateLimitUnauthenticatedRequests(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedRateLimitUnauthenticatedRequests = v
+	st.reloadToViper()
+}
+
+// AdvancedRateLimitUnauthenticatedRequestsFlag returns the flag name for the 'AdvancedRateLimitUnauthenticatedRequests' field
+func AdvancedRateLimitUnauthenticatedRequestsFlag() string {
+	return "advanced-rate-limit-unauthenticated-requests"
+}
+
+// GetAdvancedRateLimitUnauthenticatedRequests safely fetches the value for global configuration 'AdvancedRateLimitUnauthenticatedRequests' field
+func GetAdvancedRateLimitUnauthenticatedRequests() int {
+	return global.GetAdvancedRateLimitUnauthenticatedRequests()
+}
+
+// SetAdvancedRateLimitUnauthenticatedRequests safely sets the value for global configuration 'AdvancedRateLimitUnauthenticatedRequests' field
+func SetAdvancedRateLimitUnauthenticatedRequests(v int) {
+	global.SetAdvancedRateLimitUnauthenticatedRequests(v)
+}
+
+// GetAdvancedStreamingMaxConnections safely fetches the Configuration value for state's 'AdvancedStreamingMaxConnections' field
+func (st *ConfigState) GetAdvancedStreamingMaxConnections() (v int) {
+	st.mutex.RLock()
+	v = st.config.AdvancedStreamingMaxConnections
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedStreamingMaxConnections safely sets the Configuration value for state's 'AdvancedStreamingMaxConnections' field
+func (st *ConfigState) SetAdvancedStreamingMaxConnections(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedStreamingMaxConnections = v
+	st.reloadToViper()
+}
+
+// AdvancedStreamingMaxConnectionsFlag returns the flag name for the 'AdvancedStreamingMaxConnections' field
+func AdvancedStreamingMaxConnectionsFlag() string { return "advanced-streaming-max-connections" }
+
+// GetAdvancedStreamingMaxConnections safely fetches the value for global configuration 'AdvancedStreamingMaxConnections' field
+func GetAdvancedStreamingMaxConnections() int { return global.GetAdvancedStreamingMaxConnections() }
+
+// SetAdvancedStreamingMaxConnections safely sets the value for global configuration 'AdvancedStreamingMaxConnections' field
+func SetAdvancedStreamingMaxConnections(v int) { global.SetAdvancedStreamingMaxConnections(v) }
+
+// GetAdvancedStreamingMaxConnectionsPerAccount safely fetches the Configuration value for state's 'AdvancedStreamingMaxConnectionsPerAccount' field
+func (st *ConfigState) GetAdvancedStreamingMaxConnectionsPerAccount() (v int) {
+	st.mutex.RLock()
+	v = st.config.AdvancedStreamingMaxConnectionsPerAccount
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedStreamingMaxConnectionsPerAccount safely sets the Configuration value for state's 'AdvancedStreamingMaxConnectionsPerAccount' field
+func (st *ConfigState) SetAdvancedStreamingMaxConnectionsPerAccount(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedStreamingMaxConnectionsPerAccount = v
+	st.reloadToViper()
+}
+
+// AdvancedStreamingMaxConnectionsPerAccountFlag returns the flag name for the 'AdvancedStreamingMaxConnectionsPerAccount' field
+func AdvancedStreamingMaxConnectionsPerAccountFlag() string {
+	return "advanced-streaming-max-connections-per-account"
+}
+
+// GetAdvancedStreamingMaxConnectionsPerAccount safely fetches the value for global configuration 'AdvancedStreamingMaxConnectionsPerAccount' field
+func GetAdvancedStreamingMaxConnectionsPerAccount() int {
+	return global.GetAdvancedStreamingMaxConnectionsPerAccount()
+}
+
+// SetAdvancedStreamingMaxConnectionsPerAccount safely sets the value for global configuration 'AdvancedStreamingMaxConnectionsPerAccount' field
+func SetAdvancedStreamingMaxConnectionsPerAccount(v int) {
+	global.SetAdvancedStreamingMaxConnectionsPerAccount(v)
+}
+
+// GetAdvancedStreamingQueueSize safely fetches the Configuration value for state's 'AdvancedStreamingQueueSize' field
+func (st *ConfigState) GetAdvancedStreamingQueueSize() (v int) {
+	st.mutex.RLock()
+	v = st.config.AdvancedStreamingQueueSize
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedStreamingQueueSize safely sets the Configuration value for state's 'AdvancedStreamingQueueSize' field
+func (st *ConfigState) SetAdvancedStreamingQueueSize(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedStreamingQueueSize = v
+	st.reloadToViper()
+}
+
+// AdvancedStreamingQueueSizeFlag returns the flag name for the 'AdvancedStreamingQueueSize' field
+func AdvancedStreamingQueueSizeFlag() string { return "advanced-streaming-queue-size" }
+
+// GetAdvancedStreamingQueueSize safely fetches the value for global configuration 'AdvancedStreamingQueueSize' field
+func GetAdvancedStreamingQueueSize() int { return global.GetAdvancedStreamingQueueSize() }
+
+// SetAdvancedStreamingQueueSize safely sets the value for global configuration 'AdvancedStreamingQueueSize' field
+func SetAdvancedStreamingQueueSize(v int) { global.SetAdvancedStreamingQueueSize(v) }
+
+// GetAdvancedLoadSheddingLatency safely fetches the Configuration value for state's 'AdvancedLoadSheddingLatency' field
+func (st *ConfigState) GetAdvancedLoadSheddingLatency() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.AdvancedLoadSheddingLatency
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedLoadSheddingLatency safely sets the Configuration value for state's 'AdvancedLoadSheddingLatency' field
+func (st *ConfigState) SetAdvancedLoadSheddingLatency(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedLoadSheddingLatency = v
+	st.reloadToViper()
+}
+
+// AdvancedLoadSheddingLatencyFlag returns the flag name for the 'AdvancedLoadSheddingLatency' field
+func AdvancedLoadSheddingLatencyFlag() string { return "advanced-load-shedding-latency" }
+
+// GetAdvancedLoadSheddingLatency safely fetches the value for global configuration 'AdvancedLoadSheddingLatency' field
+func GetAdvancedLoadSheddingLatency() time.Duration { return global.GetAdvancedLoadSheddingLatency() }
+
+// SetAdvancedLoadSheddingLatency safely sets the value for global configuration 'AdvancedLoadSheddingLatency' field
+func SetAdvancedLoadSheddingLatency(v time.Duration) { global.SetAdvancedLoadSheddingLatency(v) }
+
+// GetAdvancedLoadSheddingQueueDepth safely fetches the Configuration value for state's 'AdvancedLoadSheddingQueueDepth' field
+func (st *ConfigState) GetAdvancedLoadSheddingQueueDepth() (v int) {
+	st.mutex.RLock()
+	v = st.config.AdvancedLoadSheddingQueueDepth
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedLoadSheddingQueueDepth safely sets the Configuration value for state's 'AdvancedLoadSheddingQueueDepth' field
+func (st *ConfigState) SetAdvancedLoadSheddingQueueDepth(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedLoadSheddingQueueDepth = v
+	st.reloadToViper()
+}
+
+// AdvancedLoadSheddingQueueDepthFlag returns the flag name for the 'AdvancedLoadSheddingQueueDepth' field
+func AdvancedLoadSheddingQueueDepthFlag() string { return "advanced-load-shedding-queue-depth" }
+
+// GetAdvancedLoadSheddingQueueDepth safely fetches the value for global configuration 'AdvancedLoadSheddingQueueDepth' field
+func GetAdvancedLoadSheddingQueueDepth() int { return global.GetAdvancedLoadSheddingQueueDepth() }
+
+// SetAdvancedLoadSheddingQueueDepth safely sets the value for global configuration 'AdvancedLoadSheddingQueueDepth' field
+func SetAdvancedLoadSheddingQueueDepth(v int) { global.SetAdvancedLoadSheddingQueueDepth(v) }
+
+// GetAdvancedConversionReport safely fetches the Configuration value for state's 'AdvancedConversionReport' field
+func (st *ConfigState) GetAdvancedConversionReport() (v bool) {
+	st.mutex.RLock()
+	v = st.config.AdvancedConversionReport
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedConversionReport safely sets the Configuration value for state's 'AdvancedConversionReport' field
+func (st *ConfigState) SetAdvancedConversionReport(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedConversionReport = v
+	st.reloadToViper()
+}
+
+// AdvancedConversionReportFlag returns the flag name for the 'AdvancedConversionReport' field
+func AdvancedConversionReportFlag() string { return "advanced-conversion-report" }
+
+// GetAdvancedConversionReport safely fetches the value for global configuration 'AdvancedConversionReport' field
+func GetAdvancedConversionReport() bool { return global.GetAdvancedConversionReport() }
+
+// SetAdvancedConversionReport safely sets the value for global configuration 'AdvancedConversionReport' field
+func SetAdvancedConversionReport(v bool) { global.SetAdvancedConversionReport(v) }
+
 // GetHTTPClientAllowIPs safely fetches the Configuration value for state's 'HTTPClient.AllowIPs' field
 func (st *ConfigState) GetHTTPClientAllowIPs() (v []string) {
 	st.mutex.RLock()
@@ -2725,6 +4248,81 @@ func GetHTTPClientTLSInsecureSkipVerify() bool { return global.GetHTTPClientTLSI
 // SetHTTPClientTLSInsecureSkipVerify safely sets the value for global configuration 'HTTPClient.TLSInsecureSkipVerify' field
 func SetHTTPClientTLSInsecureSkipVerify(v bool) { global.SetHTTPClientTLSInsecureSkipVerify(v) }
 
+// GetHTTPClientMaxIdleConnsPerHost safely fetches the Configuration value for state's 'HTTPClient.MaxIdleConnsPerHost' field
+func (st *ConfigState) GetHTTPClientMaxIdleConnsPerHost() (v int) {
+	st.mutex.RLock()
+	v = st.config.HTTPClient.MaxIdleConnsPerHost
+	st.mutex.RUnlock()
+	return
+}
+
+// SetHTTPClientMaxIdleConnsPerHost safely sets the Configuration value for state's 'HTTPClient.MaxIdleConnsPerHost' field
+func (st *ConfigState) SetHTTPClientMaxIdleConnsPerHost(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.HTTPClient.MaxIdleConnsPerHost = v
+	st.reloadToViper()
+}
+
+// HTTPClientMaxIdleConnsPerHostFlag returns the flag name for the 'HTTPClient.MaxIdleConnsPerHost' field
+func HTTPClientMaxIdleConnsPerHostFlag() string { return "httpclient-max-idle-conns-per-host" }
+
+// GetHTTPClientMaxIdleConnsPerHost safely fetches the value for global configuration 'HTTPClient.MaxIdleConnsPerHost' field
+func GetHTTPClientMaxIdleConnsPerHost() int { return global.GetHTTPClientMaxIdleConnsPerHost() }
+
+// SetHTTPClientMaxIdleConnsPerHost safely sets the value for global configuration 'HTTPClient.MaxIdleConnsPerHost' field
+func SetHTTPClientMaxIdleConnsPerHost(v int) { global.SetHTTPClientMaxIdleConnsPerHost(v) }
+
+// GetHTTPClientIdleConnTimeout safely fetches the Configuration value for state's 'HTTPClient.IdleConnTimeout' field
+func (st *ConfigState) GetHTTPClientIdleConnTimeout() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.HTTPClient.IdleConnTimeout
+	st.mutex.RUnlock()
+	return
+}
+
+// SetHTTPClientIdleConnTimeout safely sets the Configuration value for state's 'HTTPClient.IdleConnTimeout' field
+func (st *ConfigState) SetHTTPClientIdleConnTimeout(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.HTTPClient.IdleConnTimeout = v
+	st.reloadToViper()
+}
+
+// HTTPClientIdleConnTimeoutFlag returns the flag name for the 'HTTPClient.IdleConnTimeout' field
+func HTTPClientIdleConnTimeoutFlag() string { return "httpclient-idle-conn-timeout" }
+
+// GetHTTPClientIdleConnTimeout safely fetches the value for global configuration 'HTTPClient.IdleConnTimeout' field
+func GetHTTPClientIdleConnTimeout() time.Duration { return global.GetHTTPClientIdleConnTimeout() }
+
+// SetHTTPClientIdleConnTimeout safely sets the value for global configuration 'HTTPClient.IdleConnTimeout' field
+func SetHTTPClientIdleConnTimeout(v time.Duration) { global.SetHTTPClientIdleConnTimeout(v) }
+
+// GetHTTPClientDisableHTTP2 safely fetches the Configuration value for state's 'HTTPClient.DisableHTTP2' field
+func (st *ConfigState) GetHTTPClientDisableHTTP2() (v bool) {
+	st.mutex.RLock()
+	v = st.config.HTTPClient.DisableHTTP2
+	st.mutex.RUnlock()
+	return
+}
+
+// SetHTTPClientDisableHTTP2 safely sets the Configuration value for state's 'HTTPClient.DisableHTTP2' field
+func (st *ConfigState) SetHTTPClientDisableHTTP2(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.HTTPClient.DisableHTTP2 = v
+	st.reloadToViper()
+}
+
+// HTTPClientDisableHTTP2Flag returns the flag name for the 'HTTPClient.DisableHTTP2' field
+func HTTPClientDisableHTTP2Flag() string { return "httpclient-disable-http2" }
+
+// GetHTTPClientDisableHTTP2 safely fetches the value for global configuration 'HTTPClient.DisableHTTP2' field
+func GetHTTPClientDisableHTTP2() bool { return global.GetHTTPClientDisableHTTP2() }
+
+// SetHTTPClientDisableHTTP2 safely sets the value for global configuration 'HTTPClient.DisableHTTP2' field
+func SetHTTPClientDisableHTTP2(v bool) { global.SetHTTPClientDisableHTTP2(v) }
+
 // GetCacheMemoryTarget safely fetches the Configuration value for state's 'Cache.MemoryTarget' field
 func (st *ConfigState) GetCacheMemoryTarget() (v bytesize.Size) {
 	st.mutex.RLock()
@@ -2750,6 +4348,31 @@ func GetCacheMemoryTarget() bytesize.Size { return global.GetCacheMemoryTarget()
 // SetCacheMemoryTarget safely sets the value for global configuration 'Cache.MemoryTarget' field
 func SetCacheMemoryTarget(v bytesize.Size) { global.SetCacheMemoryTarget(v) }
 
+// GetCacheInvalidationBus safely fetches the Configuration value for state's 'Cache.InvalidationBus' field
+func (st *ConfigState) GetCacheInvalidationBus() (v string) {
+	st.mutex.RLock()
+	v = st.config.Cache.InvalidationBus
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheInvalidationBus safely sets the Configuration value for state's 'Cache.InvalidationBus' field
+func (st *ConfigState) SetCacheInvalidationBus(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.InvalidationBus = v
+	st.reloadToViper()
+}
+
+// CacheInvalidationBusFlag returns the flag name for the 'Cache.InvalidationBus' field
+func CacheInvalidationBusFlag() string { return "cache-invalidation-bus" }
+
+// GetCacheInvalidationBus safely fetches the value for global configuration 'Cache.InvalidationBus' field
+func GetCacheInvalidationBus() string { return global.GetCacheInvalidationBus() }
+
+// SetCacheInvalidationBus safely sets the value for global configuration 'Cache.InvalidationBus' field
+func SetCacheInvalidationBus(v string) { global.SetCacheInvalidationBus(v) }
+
 // GetCacheAccountMemRatio safely fetches the Configuration value for state's 'Cache.AccountMemRatio' field
 func (st *ConfigState) GetCacheAccountMemRatio() (v float64) {
 	st.mutex.RLock()
@@ -4050,6 +5673,131 @@ func GetAdminMediaListRemoteOnly() bool { return global.GetAdminMediaListRemoteO
 // SetAdminMediaListRemoteOnly safely sets the value for global configuration 'AdminMediaListRemoteOnly' field
 func SetAdminMediaListRemoteOnly(v bool) { global.SetAdminMediaListRemoteOnly(v) }
 
+// GetAdminDomain safely fetches the Configuration value for state's 'AdminDomain' field
+func (st *ConfigState) GetAdminDomain() (v string) {
+	st.mutex.RLock()
+	v = st.config.AdminDomain
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdminDomain safely sets the Configuration value for state's 'AdminDomain' field
+func (st *ConfigState) SetAdminDomain(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminDomain = v
+	st.reloadToViper()
+}
+
+// AdminDomainFlag returns the flag name for the 'AdminDomain' field
+func AdminDomainFlag() string { return "domain" }
+
+// GetAdminDomain safely fetches the value for global configuration 'AdminDomain' field
+func GetAdminDomain() string { return global.GetAdminDomain() }
+
+// SetAdminDomain safely sets the value for global configuration 'AdminDomain' field
+func SetAdminDomain(v string) { global.SetAdminDomain(v) }
+
+// GetAdminDomainPrivateComment safely fetches the Configuration value for state's 'AdminDomainPrivateComment' field
+func (st *ConfigState) GetAdminDomainPrivateComment() (v string) {
+	st.mutex.RLock()
+	v = st.config.AdminDomainPrivateComment
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdminDomainPrivateComment safely sets the Configuration value for state's 'AdminDomainPrivateComment' field
+func (st *ConfigState) SetAdminDomainPrivateComment(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminDomainPrivateComment = v
+	st.reloadToViper()
+}
+
+// AdminDomainPrivateCommentFlag returns the flag name for the 'AdminDomainPrivateComment' field
+func AdminDomainPrivateCommentFlag() string { return "private-comment" }
+
+// GetAdminDomainPrivateComment safely fetches the value for global configuration 'AdminDomainPrivateComment' field
+func GetAdminDomainPrivateComment() string { return global.GetAdminDomainPrivateComment() }
+
+// SetAdminDomainPrivateComment safely sets the value for global configuration 'AdminDomainPrivateComment' field
+func SetAdminDomainPrivateComment(v string) { global.SetAdminDomainPrivateComment(v) }
+
+// GetAdminEmojiPackCategory safely fetches the Configuration value for state's 'AdminEmojiPackCategory' field
+func (st *ConfigState) GetAdminEmojiPackCategory() (v string) {
+	st.mutex.RLock()
+	v = st.config.AdminEmojiPackCategory
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdminEmojiPackCategory safely sets the Configuration value for state's 'AdminEmojiPackCategory' field
+func (st *ConfigState) SetAdminEmojiPackCategory(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminEmojiPackCategory = v
+	st.reloadToViper()
+}
+
+// AdminEmojiPackCategoryFlag returns the flag name for the 'AdminEmojiPackCategory' field
+func AdminEmojiPackCategoryFlag() string { return "category" }
+
+// GetAdminEmojiPackCategory safely fetches the value for global configuration 'AdminEmojiPackCategory' field
+func GetAdminEmojiPackCategory() string { return global.GetAdminEmojiPackCategory() }
+
+// SetAdminEmojiPackCategory safely sets the value for global configuration 'AdminEmojiPackCategory' field
+func SetAdminEmojiPackCategory(v string) { global.SetAdminEmojiPackCategory(v) }
+
+// GetAdminEmojiPackOnConflict safely fetches the Configuration value for state's 'AdminEmojiPackOnConflict' field
+func (st *ConfigState) GetAdminEmojiPackOnConflict() (v string) {
+	st.mutex.RLock()
+	v = st.config.AdminEmojiPackOnConflict
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdminEmojiPackOnConflict safely sets the Configuration value for state's 'AdminEmojiPackOnConflict' field
+func (st *ConfigState) SetAdminEmojiPackOnConflict(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminEmojiPackOnConflict = v
+	st.reloadToViper()
+}
+
+// AdminEmojiPackOnConflictFlag returns the flag name for the 'AdminEmojiPackOnConflict' field
+func AdminEmojiPackOnConflictFlag() string { return "on-conflict" }
+
+// GetAdminEmojiPackOnConflict safely fetches the value for global configuration 'AdminEmojiPackOnConflict' field
+func GetAdminEmojiPackOnConflict() string { return global.GetAdminEmojiPackOnConflict() }
+
+// SetAdminEmojiPackOnConflict safely sets the value for global configuration 'AdminEmojiPackOnConflict' field
+func SetAdminEmojiPackOnConflict(v string) { global.SetAdminEmojiPackOnConflict(v) }
+
+// GetTestrigSeedProfile safely fetches the Configuration value for state's 'TestrigSeedProfile' field
+func (st *ConfigState) GetTestrigSeedProfile() (v string) {
+	st.mutex.RLock()
+	v = st.config.TestrigSeedProfile
+	st.mutex.RUnlock()
+	return
+}
+
+// SetTestrigSeedProfile safely sets the Configuration value for state's 'TestrigSeedProfile' field
+func (st *ConfigState) SetTestrigSeedProfile(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.TestrigSeedProfile = v
+	st.reloadToViper()
+}
+
+// TestrigSeedProfileFlag returns the flag name for the 'TestrigSeedProfile' field
+func TestrigSeedProfileFlag() string { return "profile" }
+
+// GetTestrigSeedProfile safely fetches the value for global configuration 'TestrigSeedProfile' field
+func GetTestrigSeedProfile() string { return global.GetTestrigSeedProfile() }
+
+// SetTestrigSeedProfile safely sets the value for global configuration 'TestrigSeedProfile' field
+func SetTestrigSeedProfile(v string) { global.SetTestrigSeedProfile(v) }
+
 // GetRequestIDHeader safely fetches the Configuration value for state's 'RequestIDHeader' field
 func (st *ConfigState) GetRequestIDHeader() (v string) {
 	st.mutex.RLock()
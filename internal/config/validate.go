@@ -19,6 +19,8 @@ package config
 
 import (
 	"fmt"
+	"net/url"
+	"strings"
 
 	"github.com/miekg/dns"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
@@ -98,6 +100,64 @@ func Validate() error {
 		)
 	}
 
+	// `instance-federation-spam-filter-mode` should
+	// be "off", "log", "drop", or unset. When unset,
+	// fall back to the deprecated bool setting.
+	switch spamMode := GetInstanceFederationSpamFilterMode(); spamMode {
+	case SpamFilterModeOff, SpamFilterModeLog, SpamFilterModeDrop:
+		// No problem.
+
+	case "":
+		if GetInstanceFederationSpamFilter() {
+			SetInstanceFederationSpamFilterMode(SpamFilterModeDrop)
+		} else {
+			SetInstanceFederationSpamFilterMode(SpamFilterModeOff)
+		}
+
+	default:
+		errf(
+			"%s must be set to one of off, log, or drop, provided value was %s",
+			InstanceFederationSpamFilterModeFlag(), spamMode,
+		)
+	}
+
+	// `accounts-disposable-email-mode` should
+	// be "reject", "flag", or "allow".
+	switch disposableMode := GetAccountsDisposableEmailMode(); disposableMode {
+	case DisposableEmailModeReject, DisposableEmailModeFlag, DisposableEmailModeAllow:
+		// No problem.
+
+	case "":
+		errf("%s must be set", AccountsDisposableEmailModeFlag())
+
+	default:
+		errf(
+			"%s must be set to one of reject, flag, or allow, provided value was %s",
+			AccountsDisposableEmailModeFlag(), disposableMode,
+		)
+	}
+
+	// `cache-invalidation-bus` should be
+	// unset, or "postgres" when using postgres.
+	switch bus := GetCacheInvalidationBus(); bus {
+	case CacheInvalidationBusNone:
+		// No problem.
+
+	case CacheInvalidationBusPostgres:
+		if dbType := GetDbType(); !strings.EqualFold(dbType, "postgres") {
+			errf(
+				"%s %s requires %s to be postgres, provided value was %s",
+				CacheInvalidationBusFlag(), bus, DbTypeFlag(), dbType,
+			)
+		}
+
+	default:
+		errf(
+			"%s must be either unset or postgres, provided value was %s",
+			CacheInvalidationBusFlag(), bus,
+		)
+	}
+
 	// Parse `instance-languages`, and
 	// set enriched version into config.
 	parsedLangs, err := language.InitLangs(GetInstanceLanguages().TagStrs())
@@ -118,6 +178,40 @@ func Validate() error {
 		errf("%s must be set", WebAssetBaseDirFlag())
 	}
 
+	// `web-error-page-contact-url` must be a
+	// link that browsers can actually follow.
+	if contactURL := GetWebErrorPageContactURL(); contactURL != "" {
+		u, err := url.Parse(contactURL)
+		if err != nil {
+			errf("%s could not be parsed as a url: %v", WebErrorPageContactURLFlag(), err)
+		} else if u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "mailto" {
+			errf("%s must be an http, https, or mailto url, provided value was %s", WebErrorPageContactURLFlag(), contactURL)
+		}
+	}
+
+	// `media-image-quality` must be a valid JPEG quality.
+	if q := GetMediaImageQuality(); q < 1 || q > 100 {
+		errf("%s must be between 1 and 100, provided value was %d", MediaImageQualityFlag(), q)
+	}
+
+	// `media-clamav-action` should
+	// be "reject" or "quarantine".
+	switch clamAVAction := GetMediaClamAVAction(); clamAVAction {
+	case MediaClamAVActionReject, MediaClamAVActionQuarantine:
+		// No problem.
+
+	default:
+		errf(
+			"%s must be set to one of reject or quarantine, provided value was %s",
+			MediaClamAVActionFlag(), clamAVAction,
+		)
+	}
+
+	// `media-image-max-resolution` can't be negative.
+	if r := GetMediaImageMaxResolution(); r < 0 {
+		errf("%s must be 0 or greater, provided value was %d", MediaImageMaxResolutionFlag(), r)
+	}
+
 	// Custom / LE TLS settings.
 	//
 	// Only one of custom certs or LE can be set,
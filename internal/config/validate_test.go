@@ -111,6 +111,24 @@ func (suite *ConfigValidateTestSuite) TestValidateConfigNoWebAssetBaseDir() {
 	suite.EqualError(err, "web-asset-base-dir must be set")
 }
 
+func (suite *ConfigValidateTestSuite) TestValidateConfigBadWebErrorPageContactURL() {
+	testrig.InitTestConfig()
+
+	config.SetWebErrorPageContactURL("javascript:alert(1)")
+
+	err := config.Validate()
+	suite.EqualError(err, "web-error-page-contact-url must be an http, https, or mailto url, provided value was javascript:alert(1)")
+}
+
+func (suite *ConfigValidateTestSuite) TestValidateConfigBadCacheInvalidationBus() {
+	testrig.InitTestConfig()
+
+	config.SetCacheInvalidationBus("carrier-pigeon")
+
+	err := config.Validate()
+	suite.EqualError(err, "cache-invalidation-bus must be either unset or postgres, provided value was carrier-pigeon")
+}
+
 func (suite *ConfigValidateTestSuite) TestValidateConfigNoProtocolOrHost() {
 	testrig.InitTestConfig()
 
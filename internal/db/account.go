@@ -20,6 +20,7 @@ package db
 import (
 	"context"
 	"net/netip"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/paging"
@@ -99,6 +100,22 @@ type Account interface {
 	// GetAccountsUsingEmoji fetches all account models using emoji with given ID stored in their 'emojis' column.
 	GetAccountsUsingEmoji(ctx context.Context, emojiID string) ([]*gtsmodel.Account, error)
 
+	// GetMovedAccounts returns a slice of remote, non-suspended accounts that have
+	// set movedTo, and which are still followed by one or more local accounts, arranged
+	// by ID. If domain is set, only accounts from the given domain will be returned.
+	GetMovedAccounts(ctx context.Context, domain string, maxID string, limit int) ([]*gtsmodel.Account, error)
+
+	// GetStaleMovedAccounts returns a slice of remote, non-suspended accounts that have
+	// set movedTo, whose Move to that account has succeeded, and which are no longer
+	// followed or follow requested by any local accounts, arranged by ID. Ie., accounts
+	// whose local relationships have all been moved over to the Move target. If domain
+	// is set, only accounts from the given domain will be returned.
+	GetStaleMovedAccounts(ctx context.Context, domain string, maxID string, limit int) ([]*gtsmodel.Account, error)
+
+	// GetStaleAccounts returns a slice of remote, non-suspended accounts that
+	// were last fetched before the given time, arranged by ID.
+	GetStaleAccounts(ctx context.Context, fetchedBefore time.Time, maxID string, limit int) ([]*gtsmodel.Account, error)
+
 	// GetAccountStatuses is a shortcut for getting the most recent statuses. accountID is optional, if not provided
 	// then all statuses will be returned. If limit is set to 0, the size of the returned slice will not be limited. This can
 	// be very memory intensive so you probably shouldn't do this!
@@ -121,6 +138,13 @@ type Account interface {
 	// In the case of no statuses, this function will return db.ErrNoEntries.
 	GetAccountWebStatuses(ctx context.Context, accountID string, limit int, maxID string) ([]*gtsmodel.Status, error)
 
+	// GetAccountFeedStatuses is similar to GetAccountWebStatuses, but it's specifically for returning
+	// statuses for the RSS feed of an account. If excludeReplies is false, the account's public replies
+	// are included too. If tagID is set, only statuses using that tag are returned.
+	//
+	// In the case of no statuses, this function will return db.ErrNoEntries.
+	GetAccountFeedStatuses(ctx context.Context, accountID string, limit int, excludeReplies bool, tagID string) ([]*gtsmodel.Status, error)
+
 	// SetAccountHeaderOrAvatar sets the header or avatar for the given accountID to the given media attachment.
 	SetAccountHeaderOrAvatar(ctx context.Context, mediaAttachment *gtsmodel.MediaAttachment, accountID string) error
 
@@ -137,8 +161,18 @@ type Account interface {
 	// Update local account settings.
 	UpdateAccountSettings(ctx context.Context, settings *gtsmodel.AccountSettings, columns ...string) error
 
+	// GetEmailDigestAccountIDs returns the IDs of local accounts that have opted
+	// in to email digests with the given frequency, and which haven't been sent
+	// an email digest since sentBefore.
+	GetEmailDigestAccountIDs(ctx context.Context, digest string, sentBefore time.Time) ([]string, error)
+
+	// GetNoIndexAccountUsernames returns the usernames of unsuspended
+	// local accounts that have opted out of search engine indexing.
+	GetNoIndexAccountUsernames(ctx context.Context) ([]string, error)
+
 	// PopulateAccountStats gets (or creates and gets) account stats for
-	// the given account, and attaches them to the account model.
+	// the given account, and attaches them to the account model. Existing
+	// stats are returned as stored, they are never regenerated here.
 	PopulateAccountStats(ctx context.Context, account *gtsmodel.Account) error
 
 	// RegenerateAccountStats creates, upserts, and returns stats
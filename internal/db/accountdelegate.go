@@ -0,0 +1,59 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+// AccountDelegate handles getting/creation/deletion of
+// delegates allowed to act as (shared) local accounts.
+type AccountDelegate interface {
+	// GetAccountDelegateByID gets one account delegate by its db id.
+	GetAccountDelegateByID(ctx context.Context, id string) (*gtsmodel.AccountDelegate, error)
+
+	// GetAccountDelegate gets the account delegate linking
+	// the given shared account and delegate account, if any.
+	GetAccountDelegate(ctx context.Context, accountID string, delegateAccountID string) (*gtsmodel.AccountDelegate, error)
+
+	// GetAccountDelegates gets all delegates (including pending
+	// invitations) of the given shared account, oldest first.
+	GetAccountDelegates(ctx context.Context, accountID string) ([]*gtsmodel.AccountDelegate, error)
+
+	// GetAccountDelegations gets all account delegates (including
+	// pending invitations) with the given delegate account, ie.,
+	// the shared accounts that it may act as, oldest first.
+	GetAccountDelegations(ctx context.Context, delegateAccountID string) ([]*gtsmodel.AccountDelegate, error)
+
+	// PutAccountDelegate puts the given account delegate in the database.
+	PutAccountDelegate(ctx context.Context, delegate *gtsmodel.AccountDelegate) error
+
+	// UpdateAccountDelegate updates the given account delegate in the database,
+	// only updating the given columns, or all columns if none are given.
+	UpdateAccountDelegate(ctx context.Context, delegate *gtsmodel.AccountDelegate, columns ...string) error
+
+	// DeleteAccountDelegateByID deletes one account delegate by its db id.
+	DeleteAccountDelegateByID(ctx context.Context, id string) error
+
+	// GetDelegatedStatuses gets a page of the statuses of the given shared account
+	// that were posted by its delegates, newest first, for auditing purposes.
+	GetDelegatedStatuses(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.Status, error)
+}
@@ -0,0 +1,42 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// AccountDeletion handles tracking the progress of account deletes.
+type AccountDeletion interface {
+	// GetAccountDeletionByAccountID gets the in-progress delete of the given account.
+	GetAccountDeletionByAccountID(ctx context.Context, accountID string) (*gtsmodel.AccountDeletion, error)
+
+	// GetAccountDeletions gets all in-progress account deletes, oldest first.
+	GetAccountDeletions(ctx context.Context) ([]*gtsmodel.AccountDeletion, error)
+
+	// PutAccountDeletion puts the given account delete in the database.
+	PutAccountDeletion(ctx context.Context, deletion *gtsmodel.AccountDeletion) error
+
+	// UpdateAccountDeletion updates the given account delete, optionally limited to the given columns.
+	UpdateAccountDeletion(ctx context.Context, deletion *gtsmodel.AccountDeletion, columns ...string) error
+
+	// DeleteAccountDeletionByID deletes the account delete with the given ID, ie., once it has finished.
+	DeleteAccountDeletionByID(ctx context.Context, id string) error
+}
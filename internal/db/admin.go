@@ -19,9 +19,11 @@ package db
 
 import (
 	"context"
+	"net"
 	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 )
 
 // Admin contains functions related to instance administration (new signups etc).
@@ -33,7 +35,7 @@ type Admin interface {
 	// IsEmailAvailable checks whether a given email address for a new account is available to be used on our domain.
 	// Return an error if:
 	// A) the email is already associated with an account
-	// B) we block signups from this email domain
+	// B) we block signups from this email domain, a parent domain of it, or one of its MX hosts
 	// C) something went wrong in the db
 	IsEmailAvailable(ctx context.Context, email string) (bool, error)
 
@@ -68,6 +70,41 @@ type Admin interface {
 	// the number of pending sign-ups sitting in the backlog.
 	CountUnhandledSignups(ctx context.Context) (int, error)
 
+	// PutSignupAttempt stores the given sign-up attempt.
+	PutSignupAttempt(ctx context.Context, attempt *gtsmodel.SignupAttempt) error
+
+	// CountSignupAttemptsByIPSince counts the number of sign-up
+	// attempts made from the given IP since the given time.
+	CountSignupAttemptsByIPSince(ctx context.Context, ip net.IP, since time.Time) (int, error)
+
+	// CountSignupAttemptsByEmailDomainSince counts the number of sign-up
+	// attempts made with an email address at the given (punycode)
+	// domain since the given time.
+	CountSignupAttemptsByEmailDomainSince(ctx context.Context, domain string, since time.Time) (int, error)
+
+	// DeleteSignupAttemptsBefore deletes all
+	// sign-up attempts made before the given time.
+	DeleteSignupAttemptsBefore(ctx context.Context, before time.Time) error
+
+	/*
+		EMAIL DOMAIN BLOCK FUNCS
+	*/
+
+	// GetEmailDomainBlockByID returns the email domain block with the given ID.
+	GetEmailDomainBlockByID(ctx context.Context, id string) (*gtsmodel.EmailDomainBlock, error)
+
+	// GetEmailDomainBlockByDomain returns the email domain block for exactly the given domain.
+	GetEmailDomainBlockByDomain(ctx context.Context, domain string) (*gtsmodel.EmailDomainBlock, error)
+
+	// GetEmailDomainBlocks returns a page of email domain blocks, newest first.
+	GetEmailDomainBlocks(ctx context.Context, page *paging.Page) ([]*gtsmodel.EmailDomainBlock, error)
+
+	// PutEmailDomainBlock puts one email domain block in the database.
+	PutEmailDomainBlock(ctx context.Context, block *gtsmodel.EmailDomainBlock) error
+
+	// DeleteEmailDomainBlockByID deletes the email domain block with the given ID.
+	DeleteEmailDomainBlockByID(ctx context.Context, id string) error
+
 	/*
 		ACTION FUNCS
 	*/
@@ -0,0 +1,49 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// BlobRef handles references to content-addressed media files in storage.
+type BlobRef interface {
+	// PutBlobRef puts the given blob ref in the database.
+	PutBlobRef(ctx context.Context, ref *gtsmodel.BlobRef) error
+
+	// DeleteBlobRefByID deletes the blob ref with the given ID.
+	DeleteBlobRefByID(ctx context.Context, id string) error
+
+	// DeleteBlobRefsOlderThan deletes all blob refs created before
+	// the given time, ie., left behind by interrupted processes,
+	// returning the number deleted.
+	DeleteBlobRefsOlderThan(ctx context.Context, t time.Time) (int, error)
+
+	// IsBlobPathInUse returns whether the given storage path is pinned by a blob ref, or
+	// referenced as file or thumbnail by any media attachment other than the one with
+	// excludeID. Attachments count whether cached, or not cached because they're still
+	// being processed.
+	IsBlobPathInUse(ctx context.Context, path string, excludeID string) (bool, error)
+
+	// IsBlobPathDeleting returns whether the given storage path is
+	// marked as being removed by a blob ref created after given time.
+	IsBlobPathDeleting(ctx context.Context, path string, since time.Time) (bool, error)
+}
@@ -41,7 +41,7 @@ import (
 )
 
 type accountDB struct {
-	db    *bun.DB
+	db    *WrappedDB
 	state *state.State
 }
 
@@ -308,13 +308,32 @@ func (a *accountDB) GetAccounts(
 		// lazyLoadUsers only loads the users
 		// slice if it's required by params.
 		lazyLoadUsers = func() (err error) {
-			if users == nil {
-				users, err = a.state.DB.GetAllUsers(gtscontext.SetBarebones(ctx))
+			if users != nil {
+				return nil
+			}
+
+			// Page through all local users,
+			// barebones, to avoid populating
+			// each of their accounts in turn.
+			var maxID string
+			users = make([]*gtsmodel.User, 0)
+			for {
+				page, err := a.state.DB.GetUsersPage(
+					gtscontext.SetBarebones(ctx),
+					maxID,
+					usersPageLimit,
+				)
 				if err != nil {
 					return fmt.Errorf("error getting users: %w", err)
 				}
+
+				if len(page) == 0 {
+					return nil
+				}
+
+				users = append(users, page...)
+				maxID = page[len(page)-1].ID
 			}
-			return nil
 		}
 
 		// Get paging params.
@@ -442,7 +461,34 @@ func (a *accountDB) GetAccounts(
 		useAccountIDIn = true
 	}
 
-	// TODO: invitedBy
+	if invitedBy != "" {
+		// Get only accounts that signed
+		// up using invites by given account.
+		if err := lazyLoadUsers(); err != nil {
+			return nil, err
+		}
+
+		invites, err := a.state.DB.GetInvites(
+			gtscontext.SetBarebones(ctx),
+			invitedBy,
+			false,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error getting invites: %w", err)
+		}
+
+		inviteIDs := make(map[string]struct{}, len(invites))
+		for _, invite := range invites {
+			inviteIDs[invite.ID] = struct{}{}
+		}
+
+		for _, user := range users {
+			if _, ok := inviteIDs[user.InviteID]; ok {
+				accountIDIn = append(accountIDIn, user.AccountID)
+			}
+		}
+		useAccountIDIn = true
+	}
 
 	if username != "" {
 		q = q.Where("? = ?", bun.Ident("account.username"), username)
@@ -702,6 +748,10 @@ func (a *accountDB) PopulateAccount(ctx context.Context, account *gtsmodel.Accou
 }
 
 func (a *accountDB) PutAccount(ctx context.Context, account *gtsmodel.Account) error {
+	a.db.onRollback(func() {
+		a.state.Caches.GTS.Account.Invalidate("ID", account.ID)
+	})
+
 	return a.state.Caches.GTS.Account.Store(account, func() error {
 		// It is safe to run this database transaction within cache.Store
 		// as the cache does not attempt a mutex lock until AFTER hook.
@@ -874,6 +924,209 @@ func (a *accountDB) GetAccountsUsingEmoji(ctx context.Context, emojiID string) (
 	return a.GetAccountsByIDs(ctx, accountIDs)
 }
 
+func (a *accountDB) GetMovedAccounts(ctx context.Context, domain string, maxID string, limit int) ([]*gtsmodel.Account, error) {
+	// Ensure reasonable
+	if limit < 0 {
+		limit = 0
+	}
+
+	// Make educated guess for slice size
+	accountIDs := make([]string, 0, limit)
+
+	// Subquery to select local
+	// follows targeting account.
+	followsQ := a.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("follows"), bun.Ident("follow")).
+		Join(
+			"JOIN ? AS ? ON ? = ?",
+			bun.Ident("accounts"), bun.Ident("follower"),
+			bun.Ident("follower.id"), bun.Ident("follow.account_id"),
+		).
+		ColumnExpr("1").
+		Where("? = ?", bun.Ident("follow.target_account_id"), bun.Ident("account.id")).
+		Where("? IS NULL", bun.Ident("follower.domain"))
+
+	q := a.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("accounts"), bun.Ident("account")).
+		// Select just the account ID.
+		Column("account.id").
+		// Select only remote, non-suspended
+		// accounts that have moved somewhere.
+		Where("? IS NOT NULL", bun.Ident("account.domain")).
+		Where("? IS NOT NULL", bun.Ident("account.moved_to_uri")).
+		Where("? IS NULL", bun.Ident("account.suspended_at")).
+		// Which still have local followers.
+		Where("EXISTS (?)", followsQ).
+		Order("account.id DESC")
+
+	if domain != "" {
+		// Normalize the domain as punycode.
+		var err error
+		domain, err = util.Punify(domain)
+		if err != nil {
+			return nil, gtserror.Newf("error punifying domain %s: %w", domain, err)
+		}
+
+		q = q.Where("? = ?", bun.Ident("account.domain"), domain)
+	}
+
+	if maxID == "" {
+		maxID = id.Highest
+	}
+	q = q.Where("? < ?", bun.Ident("account.id"), maxID)
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx, &accountIDs); err != nil {
+		return nil, err
+	}
+
+	// Catch case of no accounts early.
+	if len(accountIDs) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	// Convert account IDs into account objects.
+	return a.GetAccountsByIDs(ctx, accountIDs)
+}
+
+func (a *accountDB) GetStaleAccounts(ctx context.Context, fetchedBefore time.Time, maxID string, limit int) ([]*gtsmodel.Account, error) {
+	// Ensure reasonable
+	if limit < 0 {
+		limit = 0
+	}
+
+	// Make educated guess for slice size
+	accountIDs := make([]string, 0, limit)
+
+	q := a.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("accounts"), bun.Ident("account")).
+		// Select just the account ID.
+		Column("account.id").
+		// Select only remote, non-suspended accounts.
+		Where("? IS NOT NULL", bun.Ident("account.domain")).
+		Where("? IS NULL", bun.Ident("account.suspended_at")).
+		// Which haven't been fetched recently.
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("? IS NULL", bun.Ident("account.fetched_at")).
+				WhereOr("? < ?", bun.Ident("account.fetched_at"), fetchedBefore)
+		}).
+		Order("account.id DESC")
+
+	if maxID == "" {
+		maxID = id.Highest
+	}
+	q = q.Where("? < ?", bun.Ident("account.id"), maxID)
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx, &accountIDs); err != nil {
+		return nil, err
+	}
+
+	// Catch case of no accounts early.
+	if len(accountIDs) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	// Convert account IDs into account objects.
+	return a.GetAccountsByIDs(ctx, accountIDs)
+}
+
+func (a *accountDB) GetStaleMovedAccounts(ctx context.Context, domain string, maxID string, limit int) ([]*gtsmodel.Account, error) {
+	// Ensure reasonable
+	if limit < 0 {
+		limit = 0
+	}
+
+	// Make educated guess for slice size
+	accountIDs := make([]string, 0, limit)
+
+	// Subquery to select succeeded
+	// moves to account's moved to URI.
+	movesQ := a.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("moves"), bun.Ident("move")).
+		ColumnExpr("1").
+		Where("? = ?", bun.Ident("move.origin_uri"), bun.Ident("account.uri")).
+		Where("? = ?", bun.Ident("move.target_uri"), bun.Ident("account.moved_to_uri")).
+		Where("? IS NOT NULL", bun.Ident("move.succeeded_at"))
+
+	// Subquery to select local follows
+	// (or follow requests) targeting account.
+	localQ := func(table string) *bun.SelectQuery {
+		return a.db.
+			NewSelect().
+			TableExpr("? AS ?", bun.Ident(table), bun.Ident("follow")).
+			Join(
+				"JOIN ? AS ? ON ? = ?",
+				bun.Ident("accounts"), bun.Ident("follower"),
+				bun.Ident("follower.id"), bun.Ident("follow.account_id"),
+			).
+			ColumnExpr("1").
+			Where("? = ?", bun.Ident("follow.target_account_id"), bun.Ident("account.id")).
+			Where("? IS NULL", bun.Ident("follower.domain"))
+	}
+
+	q := a.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("accounts"), bun.Ident("account")).
+		// Select just the account ID.
+		Column("account.id").
+		// Select only remote, non-suspended
+		// accounts that have moved somewhere.
+		Where("? IS NOT NULL", bun.Ident("account.domain")).
+		Where("? IS NOT NULL", bun.Ident("account.moved_to_uri")).
+		Where("? IS NULL", bun.Ident("account.suspended_at")).
+		// Whose Move has succeeded.
+		Where("EXISTS (?)", movesQ).
+		// And which have no local
+		// followers left behind.
+		Where("NOT EXISTS (?)", localQ("follows")).
+		Where("NOT EXISTS (?)", localQ("follow_requests")).
+		Order("account.id DESC")
+
+	if domain != "" {
+		// Normalize the domain as punycode.
+		var err error
+		domain, err = util.Punify(domain)
+		if err != nil {
+			return nil, gtserror.Newf("error punifying domain %s: %w", domain, err)
+		}
+
+		q = q.Where("? = ?", bun.Ident("account.domain"), domain)
+	}
+
+	if maxID == "" {
+		maxID = id.Highest
+	}
+	q = q.Where("? < ?", bun.Ident("account.id"), maxID)
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx, &accountIDs); err != nil {
+		return nil, err
+	}
+
+	// Catch case of no accounts early.
+	if len(accountIDs) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	// Convert account IDs into account objects.
+	return a.GetAccountsByIDs(ctx, accountIDs)
+}
+
 func (a *accountDB) GetAccountFaves(ctx context.Context, accountID string) ([]*gtsmodel.StatusFave, error) {
 	faves := new([]*gtsmodel.StatusFave)
 
@@ -925,27 +1178,7 @@ func (a *accountDB) GetAccountStatuses(ctx context.Context, accountID string, li
 	}
 
 	if mediaOnly {
-		// Attachments are stored as a json object; this
-		// implementation differs between SQLite and Postgres,
-		// so we have to be thorough to cover all eventualities
-		q = q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
-			switch a.db.Dialect().Name() {
-			case dialect.PG:
-				return q.
-					Where("? IS NOT NULL", bun.Ident("status.attachments")).
-					Where("? != '{}'", bun.Ident("status.attachments"))
-			case dialect.SQLite:
-				return q.
-					Where("? IS NOT NULL", bun.Ident("status.attachments")).
-					Where("? != ''", bun.Ident("status.attachments")).
-					Where("? != 'null'", bun.Ident("status.attachments")).
-					Where("? != '{}'", bun.Ident("status.attachments")).
-					Where("? != '[]'", bun.Ident("status.attachments"))
-			default:
-				log.Panic(ctx, "db dialect was neither pg nor sqlite")
-				return q
-			}
-		})
+		q = whereStatusHasAttachments(q)
 	}
 
 	if publicOnly {
@@ -1073,6 +1306,59 @@ func (a *accountDB) GetAccountWebStatuses(ctx context.Context, accountID string,
 	return a.state.DB.GetStatusesByIDs(ctx, statusIDs)
 }
 
+func (a *accountDB) GetAccountFeedStatuses(ctx context.Context, accountID string, limit int, excludeReplies bool, tagID string) ([]*gtsmodel.Status, error) {
+	// Ensure reasonable
+	if limit < 0 {
+		limit = 0
+	}
+
+	// Make educated guess for slice size
+	statusIDs := make([]string, 0, limit)
+
+	q := a.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		// Select only IDs from table
+		Column("status.id").
+		Where("? = ?", bun.Ident("status.account_id"), accountID).
+		// Don't show boosts.
+		Where("? IS NULL", bun.Ident("status.boost_of_id")).
+		// Only Public statuses.
+		Where("? = ?", bun.Ident("status.visibility"), gtsmodel.VisibilityPublic).
+		// Don't show local-only statuses in the feed.
+		Where("? = ?", bun.Ident("status.federated"), true)
+
+	if excludeReplies {
+		q = q.Where("? IS NULL", bun.Ident("status.in_reply_to_uri"))
+	}
+
+	if tagID != "" {
+		// Only statuses with the given tag.
+		q = q.Join(
+			"INNER JOIN ? AS ? ON ? = ?",
+			bun.Ident("status_to_tags"), bun.Ident("status_to_tag"),
+			bun.Ident("status_to_tag.status_id"), bun.Ident("status.id"),
+		).Where("? = ?", bun.Ident("status_to_tag.tag_id"), tagID)
+	}
+
+	if limit > 0 {
+		// limit amount of statuses returned
+		q = q.Limit(limit)
+	}
+
+	q = q.Order("status.id DESC")
+
+	if err := q.Scan(ctx, &statusIDs); err != nil {
+		return nil, err
+	}
+
+	if len(statusIDs) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	return a.state.DB.GetStatusesByIDs(ctx, statusIDs)
+}
+
 func (a *accountDB) GetAccountSettings(
 	ctx context.Context,
 	accountID string,
@@ -1100,6 +1386,10 @@ func (a *accountDB) PutAccountSettings(
 	ctx context.Context,
 	settings *gtsmodel.AccountSettings,
 ) error {
+	a.db.onRollback(func() {
+		a.state.Caches.GTS.AccountSettings.Invalidate("AccountID", settings.AccountID)
+	})
+
 	return a.state.Caches.GTS.AccountSettings.Store(settings, func() error {
 		if _, err := a.db.
 			NewInsert().
@@ -1138,6 +1428,52 @@ func (a *accountDB) UpdateAccountSettings(
 	})
 }
 
+func (a *accountDB) GetEmailDigestAccountIDs(
+	ctx context.Context,
+	digest string,
+	sentBefore time.Time,
+) ([]string, error) {
+	var accountIDs []string
+
+	if err := a.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("account_settings"), bun.Ident("account_settings")).
+		Column("account_settings.account_id").
+		Where("? = ?", bun.Ident("account_settings.email_digest"), digest).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("? IS NULL", bun.Ident("account_settings.email_digest_sent_at")).
+				WhereOr("? <= ?", bun.Ident("account_settings.email_digest_sent_at"), sentBefore)
+		}).
+		Scan(ctx, &accountIDs); err != nil {
+		return nil, err
+	}
+
+	return accountIDs, nil
+}
+
+func (a *accountDB) GetNoIndexAccountUsernames(ctx context.Context) ([]string, error) {
+	var usernames []string
+
+	if err := a.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("accounts"), bun.Ident("account")).
+		Column("account.username").
+		Join(
+			"JOIN ? AS ? ON ? = ?",
+			bun.Ident("account_settings"), bun.Ident("account_settings"),
+			bun.Ident("account_settings.account_id"), bun.Ident("account.id"),
+		).
+		Where("? = ?", bun.Ident("account_settings.no_index"), true).
+		Where("? IS NULL", bun.Ident("account.suspended_at")).
+		Order("account.username ASC").
+		Scan(ctx, &usernames); err != nil {
+		return nil, err
+	}
+
+	return usernames, nil
+}
+
 func (a *accountDB) PopulateAccountStats(ctx context.Context, account *gtsmodel.Account) error {
 	// Fetch stats from db cache with loader callback.
 	stats, err := a.state.Caches.GTS.AccountStats.LoadOne(
@@ -1169,30 +1505,12 @@ func (a *accountDB) PopulateAccountStats(ctx context.Context, account *gtsmodel.
 
 	// We have a stats, attach
 	// it to the account.
+	//
+	// Stats are kept up to date on writes,
+	// and any drift for local accounts is
+	// fixed by a periodic reconciliation
+	// job, so no need to regenerate here.
 	account.Stats = stats
-
-	// Check if this is a local
-	// stats by looking at the
-	// account they pertain to.
-	if account.IsRemote() {
-		// Account is remote. Updating
-		// stats for remote accounts is
-		// handled in the dereferencer.
-		//
-		// Nothing more to do!
-		return nil
-	}
-
-	// Stats account is local, check
-	// if we need to regenerate.
-	const statsFreshness = 48 * time.Hour
-	expiry := stats.RegeneratedAt.Add(statsFreshness)
-	if time.Now().After(expiry) {
-		// Stats have expired, regenerate them.
-		return a.RegenerateAccountStats(ctx, account)
-	}
-
-	// Stats are still fresh.
 	return nil
 }
 
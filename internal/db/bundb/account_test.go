@@ -753,18 +753,21 @@ func (suite *AccountTestSuite) TestAccountStatsAll() {
 		}
 
 		// Get stats for a third time, they
-		// should get regenerated now, but
-		// only for local accounts.
+		// should not get regenerated on read.
 		if err := suite.db.PopulateAccountStats(ctx, account); err != nil {
 			suite.FailNow(err.Error())
 		}
 		stats3 := account.Stats
 		suite.NotNil(stats3)
-		if account.IsLocal() {
-			suite.True(stats3.RegeneratedAt.After(stats.RegeneratedAt))
-		} else {
-			suite.False(stats3.RegeneratedAt.After(stats.RegeneratedAt))
+		suite.False(stats3.RegeneratedAt.After(stats.RegeneratedAt))
+
+		// Explicitly regenerating them should work though.
+		if err := suite.db.RegenerateAccountStats(ctx, account); err != nil {
+			suite.FailNow(err.Error())
 		}
+		stats4 := account.Stats
+		suite.NotNil(stats4)
+		suite.True(stats4.RegeneratedAt.After(stats3.RegeneratedAt))
 
 		// Now delete the stats.
 		if err := suite.db.DeleteAccountStats(ctx, account.ID); err != nil {
@@ -773,6 +776,189 @@ func (suite *AccountTestSuite) TestAccountStatsAll() {
 	}
 }
 
+func (suite *AccountTestSuite) TestGetMovedAccounts() {
+	var (
+		ctx           = context.Background()
+		movedAccount  = suite.testAccounts["remote_account_1"]
+		localAccount  = suite.testAccounts["local_account_1"]
+		movedToURI    = "http://new-domain.example.org/users/foss_satan"
+		movedAccountD = movedAccount.Domain
+	)
+
+	// Nothing has moved yet.
+	_, err := suite.db.GetMovedAccounts(ctx, "", "", 0)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Set remote account as moved.
+	movedAccount.MovedToURI = movedToURI
+	if err := suite.db.UpdateAccount(ctx, movedAccount, "moved_to_uri"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Account has moved but has no local
+	// followers, so it shouldn't be returned.
+	_, err = suite.db.GetMovedAccounts(ctx, "", "", 0)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Follow moved account from local account.
+	if err := suite.db.PutFollow(ctx, &gtsmodel.Follow{
+		ID:              "01HZJ2XWPNH1QYZ2E0MM3T4B3G",
+		URI:             "http://localhost:8080/users/the_mighty_zork/follow/01HZJ2XWPNH1QYZ2E0MM3T4B3G",
+		AccountID:       localAccount.ID,
+		TargetAccountID: movedAccount.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	accounts, err := suite.db.GetMovedAccounts(ctx, "", "", 0)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(accounts, 1)
+	suite.Equal(movedAccount.ID, accounts[0].ID)
+
+	// Select by domain.
+	accounts, err = suite.db.GetMovedAccounts(ctx, movedAccountD, "", 0)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(accounts, 1)
+
+	// Select by other domain.
+	_, err = suite.db.GetMovedAccounts(ctx, "example.org", "", 0)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *AccountTestSuite) TestGetStaleMovedAccounts() {
+	var (
+		ctx          = context.Background()
+		movedAccount = suite.testAccounts["remote_account_1"]
+		localAccount = suite.testAccounts["local_account_1"]
+		movedToURI   = "http://new-domain.example.org/users/foss_satan"
+	)
+
+	// Set remote account as moved.
+	movedAccount.MovedToURI = movedToURI
+	if err := suite.db.UpdateAccount(ctx, movedAccount, "moved_to_uri"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Account has moved, but the Move
+	// hasn't succeeded, so it's not stale.
+	_, err := suite.db.GetStaleMovedAccounts(ctx, "", "", 0)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Store succeeded Move.
+	if err := suite.db.PutMove(ctx, &gtsmodel.Move{
+		ID:          "01HZJ3B7M3YV1RX9Q3ZP8T2KAW",
+		OriginURI:   movedAccount.URI,
+		TargetURI:   movedToURI,
+		URI:         movedAccount.URI + "/moves/01HZJ3B7M3YV1RX9Q3ZP8T2KAW",
+		AttemptedAt: time.Now(),
+		SucceededAt: time.Now(),
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	accounts, err := suite.db.GetStaleMovedAccounts(ctx, "", "", 0)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(accounts, 1)
+	suite.Equal(movedAccount.ID, accounts[0].ID)
+
+	// Select by other domain.
+	_, err = suite.db.GetStaleMovedAccounts(ctx, "example.org", "", 0)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Follow moved account from local account.
+	if err := suite.db.PutFollow(ctx, &gtsmodel.Follow{
+		ID:              "01HZJ2XWPNH1QYZ2E0MM3T4B3G",
+		URI:             "http://localhost:8080/users/the_mighty_zork/follow/01HZJ2XWPNH1QYZ2E0MM3T4B3G",
+		AccountID:       localAccount.ID,
+		TargetAccountID: movedAccount.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Account is still followed
+	// locally, so it's not stale.
+	_, err = suite.db.GetStaleMovedAccounts(ctx, "", "", 0)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *AccountTestSuite) TestGetStaleAccounts() {
+	var (
+		ctx           = context.Background()
+		fetchedBefore = time.Now().Add(-24 * time.Hour)
+		freshAccount  = suite.testAccounts["remote_account_1"]
+	)
+
+	// Gather all remote, non-suspended
+	// test accounts: none have been fetched.
+	expect := make(map[string]struct{})
+	for _, account := range suite.testAccounts {
+		if !account.IsLocal() && !account.IsSuspended() {
+			expect[account.ID] = struct{}{}
+		}
+	}
+
+	// Mark one remote account as recently fetched.
+	freshAccount.FetchedAt = time.Now()
+	if err := suite.db.UpdateAccount(ctx, freshAccount, "fetched_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+	delete(expect, freshAccount.ID)
+
+	// Page through stale accounts, 2 at a time.
+	var (
+		maxID string
+		got   = make(map[string]struct{})
+	)
+	for {
+		accounts, err := suite.db.GetStaleAccounts(ctx, fetchedBefore, maxID, 2)
+		if errors.Is(err, db.ErrNoEntries) {
+			break
+		} else if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		for _, account := range accounts {
+			got[account.ID] = struct{}{}
+		}
+		maxID = accounts[len(accounts)-1].ID
+	}
+
+	suite.NotEmpty(got)
+	suite.Equal(expect, got)
+}
+
+func (suite *AccountTestSuite) TestGetNoIndexAccountUsernames() {
+	ctx := context.Background()
+
+	// No test account has opted out of indexing.
+	usernames, err := suite.db.GetNoIndexAccountUsernames(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(usernames)
+
+	// Opt two accounts out.
+	for _, key := range []string{"local_account_2", "local_account_1"} {
+		settings := suite.testAccounts[key].Settings
+		settings.NoIndex = util.Ptr(true)
+		if err := suite.db.UpdateAccountSettings(ctx, settings, "no_index"); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	usernames, err = suite.db.GetNoIndexAccountUsernames(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal([]string{"1happyturtle", "the_mighty_zork"}, usernames)
+}
+
 func TestAccountTestSuite(t *testing.T) {
 	suite.Run(t, new(AccountTestSuite))
 }
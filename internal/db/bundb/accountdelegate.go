@@ -0,0 +1,231 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type accountDelegateDB struct {
+	db    *WrappedDB
+	state *state.State
+}
+
+func (a *accountDelegateDB) GetAccountDelegateByID(ctx context.Context, id string) (*gtsmodel.AccountDelegate, error) {
+	return a.getAccountDelegate(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.Where("? = ?", bun.Ident("account_delegate.id"), id)
+	})
+}
+
+func (a *accountDelegateDB) GetAccountDelegate(ctx context.Context, accountID string, delegateAccountID string) (*gtsmodel.AccountDelegate, error) {
+	return a.getAccountDelegate(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.
+			Where("? = ?", bun.Ident("account_delegate.account_id"), accountID).
+			Where("? = ?", bun.Ident("account_delegate.delegate_account_id"), delegateAccountID)
+	})
+}
+
+func (a *accountDelegateDB) getAccountDelegate(ctx context.Context, where func(*bun.SelectQuery) *bun.SelectQuery) (*gtsmodel.AccountDelegate, error) {
+	var delegate gtsmodel.AccountDelegate
+
+	if err := where(a.db.
+		NewSelect().
+		Model(&delegate),
+	).Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := a.populateAccountDelegate(ctx, &delegate); err != nil {
+		return nil, err
+	}
+
+	return &delegate, nil
+}
+
+func (a *accountDelegateDB) GetAccountDelegates(ctx context.Context, accountID string) ([]*gtsmodel.AccountDelegate, error) {
+	return a.getAccountDelegates(ctx, "account_id", accountID)
+}
+
+func (a *accountDelegateDB) GetAccountDelegations(ctx context.Context, delegateAccountID string) ([]*gtsmodel.AccountDelegate, error) {
+	return a.getAccountDelegates(ctx, "delegate_account_id", delegateAccountID)
+}
+
+func (a *accountDelegateDB) getAccountDelegates(ctx context.Context, column string, accountID string) ([]*gtsmodel.AccountDelegate, error) {
+	delegates := []*gtsmodel.AccountDelegate{}
+
+	if err := a.db.
+		NewSelect().
+		Model(&delegates).
+		Where("? = ?", bun.Ident("account_delegate."+column), accountID).
+		Order("account_delegate.id ASC").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	errs := gtserror.NewMultiError(len(delegates))
+	for _, delegate := range delegates {
+		if err := a.populateAccountDelegate(ctx, delegate); err != nil {
+			errs.Append(err)
+		}
+	}
+
+	if err := errs.Combine(); err != nil {
+		return nil, err
+	}
+
+	return delegates, nil
+}
+
+func (a *accountDelegateDB) populateAccountDelegate(ctx context.Context, delegate *gtsmodel.AccountDelegate) error {
+	if gtscontext.Barebones(ctx) {
+		// Nothing to do.
+		return nil
+	}
+
+	var (
+		err  error
+		errs = gtserror.NewMultiError(2)
+	)
+
+	if delegate.Account == nil {
+		// Shared account is not set, fetch from the database.
+		delegate.Account, err = a.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			delegate.AccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating account delegate account: %w", err)
+		}
+	}
+
+	if delegate.DelegateAccount == nil {
+		// Delegate account is not set, fetch from the database.
+		delegate.DelegateAccount, err = a.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			delegate.DelegateAccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating account delegate delegate account: %w", err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+func (a *accountDelegateDB) PutAccountDelegate(ctx context.Context, delegate *gtsmodel.AccountDelegate) error {
+	_, err := a.db.
+		NewInsert().
+		Model(delegate).
+		Exec(ctx)
+	return err
+}
+
+func (a *accountDelegateDB) UpdateAccountDelegate(ctx context.Context, delegate *gtsmodel.AccountDelegate, columns ...string) error {
+	delegate.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := a.db.
+		NewUpdate().
+		Model(delegate).
+		Column(columns...).
+		Where("? = ?", bun.Ident("account_delegate.id"), delegate.ID).
+		Exec(ctx)
+	return err
+}
+
+func (a *accountDelegateDB) DeleteAccountDelegateByID(ctx context.Context, id string) error {
+	_, err := a.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("account_delegates"), bun.Ident("account_delegate")).
+		Where("? = ?", bun.Ident("account_delegate.id"), id).
+		Exec(ctx)
+	return err
+}
+
+func (a *accountDelegateDB) GetDelegatedStatuses(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.Status, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		statusIDs = make([]string, 0, limit)
+	)
+
+	q := a.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		Column("status.id").
+		Where("? = ?", bun.Ident("status.account_id"), accountID).
+		Where("? IS NOT NULL", bun.Ident("status.posted_by_account_id"))
+
+	if maxID != "" {
+		// Return only statuses older than maxID.
+		q = q.Where("? < ?", bun.Ident("status.id"), maxID)
+	}
+
+	if minID != "" {
+		// Return only statuses newer than minID.
+		q = q.Where("? > ?", bun.Ident("status.id"), minID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.Order("status.id ASC")
+	} else {
+		// Page down.
+		q = q.Order("status.id DESC")
+	}
+
+	if err := q.Scan(ctx, &statusIDs); err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, err
+	}
+
+	if len(statusIDs) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	// If we're paging up, we still want statuses
+	// to be sorted by ID desc, so reverse slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(statusIDs)
+	}
+
+	return a.state.DB.GetStatusesByIDs(ctx, statusIDs)
+}
@@ -0,0 +1,94 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type accountDeletionDB struct {
+	db    *WrappedDB
+	state *state.State
+}
+
+func (a *accountDeletionDB) GetAccountDeletionByAccountID(ctx context.Context, accountID string) (*gtsmodel.AccountDeletion, error) {
+	deletion := new(gtsmodel.AccountDeletion)
+
+	if err := a.db.
+		NewSelect().
+		Model(deletion).
+		Where("? = ?", bun.Ident("account_deletion.account_id"), accountID).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return deletion, nil
+}
+
+func (a *accountDeletionDB) GetAccountDeletions(ctx context.Context) ([]*gtsmodel.AccountDeletion, error) {
+	var deletions []*gtsmodel.AccountDeletion
+
+	if err := a.db.
+		NewSelect().
+		Model(&deletions).
+		Order("account_deletion.id ASC").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return deletions, nil
+}
+
+func (a *accountDeletionDB) PutAccountDeletion(ctx context.Context, deletion *gtsmodel.AccountDeletion) error {
+	_, err := a.db.
+		NewInsert().
+		Model(deletion).
+		Exec(ctx)
+	return err
+}
+
+func (a *accountDeletionDB) UpdateAccountDeletion(ctx context.Context, deletion *gtsmodel.AccountDeletion, columns ...string) error {
+	deletion.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := a.db.
+		NewUpdate().
+		Model(deletion).
+		Column(columns...).
+		Where("? = ?", bun.Ident("account_deletion.id"), deletion.ID).
+		Exec(ctx)
+	return err
+}
+
+func (a *accountDeletionDB) DeleteAccountDeletionByID(ctx context.Context, id string) error {
+	_, err := a.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("account_deletions"), bun.Ident("account_deletion")).
+		Where("? = ?", bun.Ident("account_deletion.id"), id).
+		Exec(ctx)
+	return err
+}
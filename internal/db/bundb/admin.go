@@ -23,7 +23,9 @@ import (
 	"crypto/rsa"
 	"errors"
 	"fmt"
+	"net"
 	"net/mail"
+	"slices"
 	"strings"
 	"time"
 
@@ -31,10 +33,12 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/disposable"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
@@ -46,7 +50,7 @@ import (
 const rsaKeyBits = 2048
 
 type adminDB struct {
-	db    *bun.DB
+	db    *WrappedDB
 	state *state.State
 }
 
@@ -68,13 +72,17 @@ func (a *adminDB) IsEmailAvailable(ctx context.Context, email string) (bool, err
 	}
 	domain := strings.Split(m.Address, "@")[1] // domain will always be the second part after @
 
-	// check if the email domain is blocked
-	emailDomainBlockedQ := a.db.
-		NewSelect().
-		TableExpr("? AS ?", bun.Ident("email_domain_blocks"), bun.Ident("email_domain_block")).
-		Column("email_domain_block.id").
-		Where("? = ?", bun.Ident("email_domain_block.domain"), domain)
-	emailDomainBlocked, err := exists(ctx, emailDomainBlockedQ)
+	// email domain blocks are stored in punycode
+	punyDomain, err := util.Punify(domain)
+	if err != nil {
+		return false, fmt.Errorf("error punifying email domain %s: %w", domain, err)
+	}
+
+	// check if the email domain (or a parent of it) is blocked, or
+	// if it's served by a blocked mail exchanger; this prevents
+	// throwaway subdomains / vanity domains bypassing blocks
+	domains := append([]string{punyDomain}, lookupMXHosts(ctx, punyDomain)...)
+	emailDomainBlocked, err := a.isEmailDomainBlocked(ctx, domains)
 	if err != nil {
 		return false, err
 	}
@@ -82,6 +90,13 @@ func (a *adminDB) IsEmailAvailable(ctx context.Context, email string) (bool, err
 		return false, fmt.Errorf("email domain %s is blocked", domain)
 	}
 
+	// check if the email domain (or its mail
+	// exchanger) is a known disposable domain
+	if config.GetAccountsDisposableEmailMode() == config.DisposableEmailModeReject &&
+		slices.ContainsFunc(domains, disposable.IsDomain) {
+		return false, fmt.Errorf("email domain %s is a disposable email domain", domain)
+	}
+
 	// check if this email is associated with a user already
 	q := a.db.
 		NewSelect().
@@ -93,140 +108,157 @@ func (a *adminDB) IsEmailAvailable(ctx context.Context, email string) (bool, err
 }
 
 func (a *adminDB) NewSignup(ctx context.Context, newSignup gtsmodel.NewSignup) (*gtsmodel.User, error) {
-	// If something went wrong previously while doing a new
-	// sign up with this username, we might already have an
-	// account, so check first.
-	account, err := a.state.DB.GetAccountByUsernameDomain(ctx, newSignup.Username, "")
-	if err != nil && !errors.Is(err, db.ErrNoEntries) {
-		// Real error occurred.
-		err := gtserror.Newf("error checking for existing account: %w", err)
+	// Do the expensive key generation and password
+	// hashing up front, so we don't hold the db
+	// transaction below open any longer than needed.
+	privKey, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	if err != nil {
+		err := gtserror.Newf("error creating new rsa private key: %w", err)
 		return nil, err
 	}
 
-	// If we didn't yet have an account
-	// with this username, create one now.
-	if account == nil {
-		uris := uris.GenerateURIsForAccount(newSignup.Username)
+	encryptedPassword, err := bcrypt.GenerateFromPassword(
+		[]byte(newSignup.Password),
+		bcrypt.DefaultCost,
+	)
+	if err != nil {
+		err := gtserror.Newf("error hashing password: %w", err)
+		return nil, err
+	}
 
-		accountID, err := id.NewRandomULID()
-		if err != nil {
-			err := gtserror.Newf("error creating new account id: %w", err)
-			return nil, err
-		}
+	var (
+		account *gtsmodel.Account
+		user    *gtsmodel.User
+	)
 
-		privKey, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
-		if err != nil {
-			err := gtserror.Newf("error creating new rsa private key: %w", err)
-			return nil, err
+	// Insert account settings, account and user in one
+	// transaction, so a failure part way through doesn't
+	// leave behind an account without a user (or similar).
+	if err := a.state.DB.RunInTx(ctx, func(tx db.DB) error {
+		// If something went wrong previously while doing a new
+		// sign up with this username, we might already have an
+		// account, so check first.
+		var err error
+		account, err = tx.GetAccountByUsernameDomain(ctx, newSignup.Username, "")
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			// Real error occurred.
+			return gtserror.Newf("error checking for existing account: %w", err)
 		}
 
-		settings := &gtsmodel.AccountSettings{
-			AccountID: accountID,
-			Privacy:   gtsmodel.VisibilityDefault,
+		// If we didn't yet have an account
+		// with this username, create one now.
+		if account == nil {
+			uris := uris.GenerateURIsForAccount(newSignup.Username)
+
+			accountID, err := id.NewRandomULID()
+			if err != nil {
+				return gtserror.Newf("error creating new account id: %w", err)
+			}
+
+			settings := &gtsmodel.AccountSettings{
+				AccountID:                 accountID,
+				Privacy:                   gtsmodel.VisibilityDefault,
+				SnoozeAllowFollows:        util.Ptr(false),
+				SnoozeAllowDirect:         util.Ptr(false),
+				HideStatusesLoggedOut:     util.Ptr(false),
+				HideFromLocalTimeline:     util.Ptr(false),
+				HideFromFederatedTimeline: util.Ptr(false),
+				NoIndex:                   util.Ptr(config.GetAccountsDefaultNoIndex()),
+			}
+
+			// Insert the settings!
+			if err := tx.PutAccountSettings(ctx, settings); err != nil {
+				return err
+			}
+
+			account = &gtsmodel.Account{
+				ID:                    accountID,
+				Username:              newSignup.Username,
+				DisplayName:           newSignup.Username,
+				URI:                   uris.UserURI,
+				URL:                   uris.UserURL,
+				InboxURI:              uris.InboxURI,
+				OutboxURI:             uris.OutboxURI,
+				FollowingURI:          uris.FollowingURI,
+				FollowersURI:          uris.FollowersURI,
+				FeaturedCollectionURI: uris.FeaturedCollectionURI,
+				ActorType:             ap.ActorPerson,
+				PrivateKey:            privKey,
+				PublicKey:             &privKey.PublicKey,
+				PublicKeyURI:          uris.PublicKeyURI,
+				Settings:              settings,
+			}
+
+			// Insert the new account!
+			if err := tx.PutAccount(ctx, account); err != nil {
+				return err
+			}
 		}
 
-		// Insert the settings!
-		if err := a.state.DB.PutAccountSettings(ctx, settings); err != nil {
-			return nil, err
+		// Created or already had an account.
+		// Ensure user not already created.
+		user, err = tx.GetUserByAccountID(ctx, account.ID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			// Real error occurred.
+			return gtserror.Newf("error checking for existing user: %w", err)
 		}
 
-		account = &gtsmodel.Account{
-			ID:                    accountID,
-			Username:              newSignup.Username,
-			DisplayName:           newSignup.Username,
-			URI:                   uris.UserURI,
-			URL:                   uris.UserURL,
-			InboxURI:              uris.InboxURI,
-			OutboxURI:             uris.OutboxURI,
-			FollowingURI:          uris.FollowingURI,
-			FollowersURI:          uris.FollowersURI,
-			FeaturedCollectionURI: uris.FeaturedCollectionURI,
-			ActorType:             ap.ActorPerson,
-			PrivateKey:            privKey,
-			PublicKey:             &privKey.PublicKey,
-			PublicKeyURI:          uris.PublicKeyURI,
-			Settings:              settings,
+		if user != nil {
+			// Already had a user for this
+			// account, just return that.
+			return nil
 		}
 
-		// Insert the new account!
-		if err := a.state.DB.PutAccount(ctx, account); err != nil {
-			return nil, err
+		// Had no user for this account, time to create one!
+		newUserID, err := id.NewRandomULID()
+		if err != nil {
+			return gtserror.Newf("error creating new user id: %w", err)
 		}
-	}
-
-	// Created or already had an account.
-	// Ensure user not already created.
-	user, err := a.state.DB.GetUserByAccountID(ctx, account.ID)
-	if err != nil && !errors.Is(err, db.ErrNoEntries) {
-		// Real error occurred.
-		err := gtserror.Newf("error checking for existing user: %w", err)
-		return nil, err
-	}
-
-	defer func() {
-		// Pin account to (new)
-		// user before returning.
-		user.Account = account
-	}()
-
-	if user != nil {
-		// Already had a user for this
-		// account, just return that.
-		return user, nil
-	}
 
-	// Had no user for this account, time to create one!
-	newUserID, err := id.NewRandomULID()
-	if err != nil {
-		err := gtserror.Newf("error creating new user id: %w", err)
-		return nil, err
-	}
-
-	encryptedPassword, err := bcrypt.GenerateFromPassword(
-		[]byte(newSignup.Password),
-		bcrypt.DefaultCost,
-	)
-	if err != nil {
-		err := gtserror.Newf("error hashing password: %w", err)
-		return nil, err
-	}
+		user = &gtsmodel.User{
+			ID:                     newUserID,
+			AccountID:              account.ID,
+			Account:                account,
+			EncryptedPassword:      string(encryptedPassword),
+			SignUpIP:               newSignup.SignUpIP.To4(),
+			Reason:                 newSignup.Reason,
+			Locale:                 newSignup.Locale,
+			UnconfirmedEmail:       newSignup.Email,
+			CreatedByApplicationID: newSignup.AppID,
+			ExternalID:             newSignup.ExternalID,
+			InviteID:               newSignup.InviteID,
+		}
 
-	user = &gtsmodel.User{
-		ID:                     newUserID,
-		AccountID:              account.ID,
-		Account:                account,
-		EncryptedPassword:      string(encryptedPassword),
-		SignUpIP:               newSignup.SignUpIP.To4(),
-		Reason:                 newSignup.Reason,
-		Locale:                 newSignup.Locale,
-		UnconfirmedEmail:       newSignup.Email,
-		CreatedByApplicationID: newSignup.AppID,
-		ExternalID:             newSignup.ExternalID,
-	}
+		if newSignup.EmailVerified {
+			// Mark given email as confirmed.
+			user.ConfirmedAt = time.Now()
+			user.Email = newSignup.Email
+		}
 
-	if newSignup.EmailVerified {
-		// Mark given email as confirmed.
-		user.ConfirmedAt = time.Now()
-		user.Email = newSignup.Email
-	}
+		if newSignup.Admin {
+			// Make new user mod + admin.
+			user.Moderator = util.Ptr(true)
+			user.Admin = util.Ptr(true)
+		}
 
-	if newSignup.Admin {
-		// Make new user mod + admin.
-		user.Moderator = util.Ptr(true)
-		user.Admin = util.Ptr(true)
-	}
+		if newSignup.PreApproved {
+			// Mark new user as approved.
+			user.Approved = util.Ptr(true)
+		}
 
-	if newSignup.PreApproved {
-		// Mark new user as approved.
-		user.Approved = util.Ptr(true)
-	}
+		// Insert the user!
+		if err := tx.PutUser(ctx, user); err != nil {
+			return gtserror.Newf("db error inserting user: %w", err)
+		}
 
-	// Insert the user!
-	if err := a.state.DB.PutUser(ctx, user); err != nil {
-		err := gtserror.Newf("db error inserting user: %w", err)
+		return nil
+	}); err != nil {
 		return nil, err
 	}
 
+	// Pin account to (new)
+	// user before returning.
+	user.Account = account
 	return user, nil
 }
 
@@ -439,6 +471,203 @@ func (a *adminDB) CountUnhandledSignups(ctx context.Context) (int, error) {
 		Count(ctx)
 }
 
+func (a *adminDB) PutSignupAttempt(ctx context.Context, attempt *gtsmodel.SignupAttempt) error {
+	_, err := a.db.
+		NewInsert().
+		Model(attempt).
+		Exec(ctx)
+	return err
+}
+
+func (a *adminDB) CountSignupAttemptsByIPSince(ctx context.Context, ip net.IP, since time.Time) (int, error) {
+	return a.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("signup_attempts"), bun.Ident("signup_attempt")).
+		Where("? = ?", bun.Ident("signup_attempt.ip"), ip).
+		Where("? > ?", bun.Ident("signup_attempt.created_at"), since).
+		Count(ctx)
+}
+
+func (a *adminDB) CountSignupAttemptsByEmailDomainSince(ctx context.Context, domain string, since time.Time) (int, error) {
+	return a.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("signup_attempts"), bun.Ident("signup_attempt")).
+		Where("? = ?", bun.Ident("signup_attempt.email_domain"), domain).
+		Where("? > ?", bun.Ident("signup_attempt.created_at"), since).
+		Count(ctx)
+}
+
+func (a *adminDB) DeleteSignupAttemptsBefore(ctx context.Context, before time.Time) error {
+	_, err := a.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("signup_attempts"), bun.Ident("signup_attempt")).
+		Where("? < ?", bun.Ident("signup_attempt.created_at"), before).
+		Exec(ctx)
+	return err
+}
+
+/*
+	EMAIL DOMAIN BLOCK FUNCS
+*/
+
+func (a *adminDB) GetEmailDomainBlockByID(ctx context.Context, id string) (*gtsmodel.EmailDomainBlock, error) {
+	return a.getEmailDomainBlock(ctx, "id", id)
+}
+
+func (a *adminDB) GetEmailDomainBlockByDomain(ctx context.Context, domain string) (*gtsmodel.EmailDomainBlock, error) {
+	return a.getEmailDomainBlock(ctx, "domain", domain)
+}
+
+func (a *adminDB) getEmailDomainBlock(ctx context.Context, column string, value any) (*gtsmodel.EmailDomainBlock, error) {
+	block := new(gtsmodel.EmailDomainBlock)
+
+	if err := a.db.
+		NewSelect().
+		Model(block).
+		Where("? = ?", bun.Ident("email_domain_block."+column), value).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return block, nil
+}
+
+func (a *adminDB) GetEmailDomainBlocks(ctx context.Context, page *paging.Page) ([]*gtsmodel.EmailDomainBlock, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		blocks = make([]*gtsmodel.EmailDomainBlock, 0, limit)
+	)
+
+	q := a.db.
+		NewSelect().
+		Model(&blocks)
+
+	if maxID != "" {
+		// Return only blocks older than maxID.
+		q = q.Where("? < ?", bun.Ident("email_domain_block.id"), maxID)
+	}
+
+	if minID != "" {
+		// Return only blocks newer than minID.
+		q = q.Where("? > ?", bun.Ident("email_domain_block.id"), minID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.Order("email_domain_block.id ASC")
+	} else {
+		// Page down.
+		q = q.Order("email_domain_block.id DESC")
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if len(blocks) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	// If we're paging up, we still want blocks
+	// to be sorted by ID desc, so reverse slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(blocks)
+	}
+
+	return blocks, nil
+}
+
+func (a *adminDB) PutEmailDomainBlock(ctx context.Context, block *gtsmodel.EmailDomainBlock) error {
+	_, err := a.db.
+		NewInsert().
+		Model(block).
+		Exec(ctx)
+
+	return err
+}
+
+func (a *adminDB) DeleteEmailDomainBlockByID(ctx context.Context, id string) error {
+	_, err := a.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("email_domain_blocks"), bun.Ident("email_domain_block")).
+		Where("? = ?", bun.Ident("email_domain_block.id"), id).
+		Exec(ctx)
+
+	return err
+}
+
+// isEmailDomainBlocked returns whether any of the
+// given domains, or any of their parents, are blocked.
+func (a *adminDB) isEmailDomainBlocked(ctx context.Context, domains []string) (bool, error) {
+	candidates := make([]string, 0, len(domains)*3)
+	for _, domain := range domains {
+		candidates = append(candidates, domainAndParents(domain)...)
+	}
+
+	q := a.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("email_domain_blocks"), bun.Ident("email_domain_block")).
+		Column("email_domain_block.id").
+		Where("? IN (?)", bun.Ident("email_domain_block.domain"), bun.In(candidates))
+
+	return exists(ctx, q)
+}
+
+// domainAndParents returns the given domain followed by each
+// of its parent domains, eg., "a.mail.example.org" will give
+// ["a.mail.example.org", "mail.example.org", "example.org", "org"].
+func domainAndParents(domain string) []string {
+	var domains []string
+	for {
+		domains = append(domains, domain)
+		i := strings.IndexByte(domain, '.')
+		if i == -1 {
+			return domains
+		}
+		domain = domain[i+1:]
+	}
+}
+
+// mxLookupTimeout is the maximum time to
+// wait for a domain's MX records to resolve.
+const mxLookupTimeout = 5 * time.Second
+
+// lookupMXHosts returns the lowercase hostnames of the mail exchangers
+// for the given domain. Lookup failures are logged and otherwise ignored,
+// since a domain not resolving shouldn't by itself prevent a sign-up.
+func lookupMXHosts(ctx context.Context, domain string) []string {
+	ctx, cancel := context.WithTimeout(ctx, mxLookupTimeout)
+	defer cancel()
+
+	mxs, err := net.DefaultResolver.LookupMX(ctx, domain)
+	if err != nil {
+		log.Debugf(ctx, "error looking up mx records for %s: %v", domain, err)
+	}
+
+	hosts := make([]string, 0, len(mxs))
+	for _, mx := range mxs {
+		host := strings.TrimSuffix(strings.ToLower(mx.Host), ".")
+		if host == "" || host == domain {
+			// Null MX, or domain
+			// handles its own mail.
+			continue
+		}
+		hosts = append(hosts, host)
+	}
+
+	return hosts
+}
+
 /*
 	ACTION FUNCS
 */
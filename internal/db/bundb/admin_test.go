@@ -19,10 +19,16 @@ package bundb_test
 
 import (
 	"context"
+	"net"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
-	gtsmodel "github.com/superseriousbusiness/gotosocial/internal/db/bundb/migrations/20211113114307_init"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -68,6 +74,83 @@ func (suite *AdminTestSuite) TestIsEmailAvailableDomainBlocked() {
 	suite.False(available)
 }
 
+func (suite *AdminTestSuite) TestIsEmailAvailableSubdomainBlocked() {
+	if err := suite.db.PutEmailDomainBlock(context.Background(), &gtsmodel.EmailDomainBlock{
+		ID:                 "01GEEV2R2YC5GRSN96761YJE47",
+		Domain:             "somewhere.com",
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	available, err := suite.db.IsEmailAvailable(context.Background(), "someone@throwaway.mail.SOMEWHERE.com")
+	suite.EqualError(err, "email domain throwaway.mail.SOMEWHERE.com is blocked")
+	suite.False(available)
+
+	// Similar-looking domain should not be blocked.
+	available, err = suite.db.IsEmailAvailable(context.Background(), "someone@elsewhere-somewhere.com")
+	suite.NoError(err)
+	suite.True(available)
+}
+
+func (suite *AdminTestSuite) TestIsEmailAvailableDisposable() {
+	// In flag mode (the test default),
+	// disposable domains are permitted.
+	available, err := suite.db.IsEmailAvailable(context.Background(), "someone@mailinator.com")
+	suite.NoError(err)
+	suite.True(available)
+
+	config.SetAccountsDisposableEmailMode(config.DisposableEmailModeReject)
+
+	available, err = suite.db.IsEmailAvailable(context.Background(), "someone@mailinator.com")
+	suite.EqualError(err, "email domain mailinator.com is a disposable email domain")
+	suite.False(available)
+
+	// Subdomains too.
+	available, err = suite.db.IsEmailAvailable(context.Background(), "someone@whatever.Mailinator.com")
+	suite.EqualError(err, "email domain whatever.Mailinator.com is a disposable email domain")
+	suite.False(available)
+}
+
+func (suite *AdminTestSuite) TestGetEmailDomainBlocks() {
+	ctx := context.Background()
+
+	for _, block := range []*gtsmodel.EmailDomainBlock{
+		{ID: "01GEEV2R2YC5GRSN96761YJE47", Domain: "a.example.org"},
+		{ID: "01GEEV2R2YC5GRSN96761YJE48", Domain: "b.example.org"},
+		{ID: "01GEEV2R2YC5GRSN96761YJE49", Domain: "c.example.org"},
+	} {
+		block.CreatedByAccountID = suite.testAccounts["admin_account"].ID
+		if err := suite.db.PutEmailDomainBlock(ctx, block); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	// Page down from the top.
+	blocks, err := suite.db.GetEmailDomainBlocks(ctx, &paging.Page{Limit: 2})
+	suite.NoError(err)
+	suite.Len(blocks, 2)
+	suite.Equal("c.example.org", blocks[0].Domain)
+	suite.Equal("b.example.org", blocks[1].Domain)
+
+	// Page down from the last block.
+	blocks, err = suite.db.GetEmailDomainBlocks(ctx, &paging.Page{
+		Max:   paging.MaxID("01GEEV2R2YC5GRSN96761YJE48"),
+		Limit: 2,
+	})
+	suite.NoError(err)
+	suite.Len(blocks, 1)
+	suite.Equal("a.example.org", blocks[0].Domain)
+
+	// Delete one and make sure it's gone.
+	if err := suite.db.DeleteEmailDomainBlockByID(ctx, "01GEEV2R2YC5GRSN96761YJE48"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err = suite.db.GetEmailDomainBlockByDomain(ctx, "b.example.org")
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
 func (suite *AdminTestSuite) TestCreateInstanceAccount() {
 	// reinitialize db caches to clear
 	suite.state.Caches.Init()
@@ -92,6 +175,57 @@ func (suite *AdminTestSuite) TestCreateInstanceAccount() {
 	suite.NotNil(acct)
 }
 
+func (suite *AdminTestSuite) TestSignupAttempts() {
+	var (
+		ctx   = context.Background()
+		ip    = net.ParseIP("192.0.2.1").To4()
+		now   = time.Now()
+		since = now.Add(-24 * time.Hour)
+	)
+
+	for i, createdAt := range []time.Time{
+		now.Add(-1 * time.Hour),
+		now.Add(-2 * time.Hour),
+		now.Add(-48 * time.Hour),
+	} {
+		if err := suite.db.PutSignupAttempt(ctx, &gtsmodel.SignupAttempt{
+			ID:          id.NewULID(),
+			CreatedAt:   createdAt,
+			IP:          ip,
+			EmailDomain: []string{"example.org", "example.org", "example.com"}[i],
+		}); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	// Only the two attempts in the last
+	// 24 hours should count towards the IP.
+	count, err := suite.db.CountSignupAttemptsByIPSince(ctx, ip, since)
+	suite.NoError(err)
+	suite.Equal(2, count)
+
+	count, err = suite.db.CountSignupAttemptsByIPSince(ctx, net.ParseIP("192.0.2.2").To4(), since)
+	suite.NoError(err)
+	suite.Zero(count)
+
+	count, err = suite.db.CountSignupAttemptsByEmailDomainSince(ctx, "example.org", since)
+	suite.NoError(err)
+	suite.Equal(2, count)
+
+	count, err = suite.db.CountSignupAttemptsByEmailDomainSince(ctx, "example.com", since)
+	suite.NoError(err)
+	suite.Zero(count)
+
+	// Prune the old one; the rest should remain.
+	if err := suite.db.DeleteSignupAttemptsBefore(ctx, since); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	count, err = suite.db.CountSignupAttemptsByIPSince(ctx, ip, time.Time{})
+	suite.NoError(err)
+	suite.Equal(2, count)
+}
+
 func TestAdminTestSuite(t *testing.T) {
 	suite.Run(t, new(AdminTestSuite))
 }
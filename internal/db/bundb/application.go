@@ -27,7 +27,7 @@ import (
 )
 
 type applicationDB struct {
-	db    *bun.DB
+	db    *WrappedDB
 	state *state.State
 }
 
@@ -27,7 +27,7 @@ import (
 )
 
 type basicDB struct {
-	db *bun.DB
+	db *WrappedDB
 }
 
 func (b *basicDB) Put(ctx context.Context, i interface{}) error {
@@ -0,0 +1,121 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type blobRefDB struct {
+	db    *WrappedDB
+	state *state.State
+}
+
+func (b *blobRefDB) PutBlobRef(ctx context.Context, ref *gtsmodel.BlobRef) error {
+	_, err := b.db.
+		NewInsert().
+		Model(ref).
+		Exec(ctx)
+	return err
+}
+
+func (b *blobRefDB) DeleteBlobRefByID(ctx context.Context, id string) error {
+	_, err := b.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("blob_refs"), bun.Ident("blob_ref")).
+		Where("? = ?", bun.Ident("blob_ref.id"), id).
+		Exec(ctx)
+	return err
+}
+
+func (b *blobRefDB) DeleteBlobRefsOlderThan(ctx context.Context, t time.Time) (int, error) {
+	res, err := b.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("blob_refs"), bun.Ident("blob_ref")).
+		Where("? < ?", bun.Ident("blob_ref.created_at"), t).
+		Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := res.RowsAffected()
+	return int(n), err
+}
+
+func (b *blobRefDB) IsBlobPathInUse(ctx context.Context, path string, excludeID string) (bool, error) {
+	// Subquery to select attachments
+	// referencing path, other than excluded.
+	attachmentsQ := b.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("media_attachments"), bun.Ident("media_attachment")).
+		Column("media_attachment.id").
+		Where("? != ?", bun.Ident("media_attachment.id"), excludeID).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			// Uncached attachments still being processed aren't
+			// marked as cached until they're done, but they may
+			// already have their files in storage.
+			return q.
+				Where("? = ?", bun.Ident("media_attachment.cached"), true).
+				WhereOr("? IN (?)", bun.Ident("media_attachment.processing"), bun.In([]gtsmodel.ProcessingStatus{
+					gtsmodel.ProcessingStatusReceived,
+					gtsmodel.ProcessingStatusProcessing,
+				}))
+		}).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("? = ?", bun.Ident("media_attachment.file_path"), path).
+				WhereOr("? = ?", bun.Ident("media_attachment.thumbnail_path"), path)
+		})
+
+	// Subquery to select
+	// pins of path.
+	pinsQ := b.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("blob_refs"), bun.Ident("blob_ref")).
+		Column("blob_ref.id").
+		Where("? = ?", bun.Ident("blob_ref.path"), path).
+		Where("? = ?", bun.Ident("blob_ref.deleting"), false)
+
+	// Check both in one query, so
+	// the answer is from one snapshot.
+	var inUse bool
+	if err := b.db.
+		NewSelect().
+		ColumnExpr("EXISTS (?) OR EXISTS (?)", attachmentsQ, pinsQ).
+		Scan(ctx, &inUse); err != nil {
+		return false, err
+	}
+
+	return inUse, nil
+}
+
+func (b *blobRefDB) IsBlobPathDeleting(ctx context.Context, path string, since time.Time) (bool, error) {
+	return exists(ctx, b.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("blob_refs"), bun.Ident("blob_ref")).
+		Column("blob_ref.id").
+		Where("? = ?", bun.Ident("blob_ref.path"), path).
+		Where("? = ?", bun.Ident("blob_ref.deleting"), true).
+		Where("? > ?", bun.Ident("blob_ref.created_at"), since),
+	)
+}
@@ -53,21 +53,29 @@ import (
 // DBService satisfies the DB interface
 type DBService struct {
 	db.Account
+	db.AccountDelegate
+	db.AccountDeletion
 	db.Admin
 	db.Application
 	db.Basic
+	db.BlobRef
 	db.Domain
 	db.Emoji
 	db.HeaderFilter
+	db.Import
 	db.Instance
+	db.Invite
 	db.Filter
 	db.List
 	db.Marker
 	db.Media
+	db.MediaUpload
 	db.Mention
+	db.ModerationNote
 	db.Move
 	db.Notification
 	db.Poll
+	db.Redirect
 	db.Relationship
 	db.Report
 	db.Rule
@@ -81,224 +89,411 @@ type DBService struct {
 	db.Timeline
 	db.User
 	db.Tombstone
-	db *bun.DB
+	db    *WrappedDB
+	state *state.State
 }
 
-// GetDB returns the underlying database connection pool.
-// Should only be used in testing + exceptional circumstance.
-func (dbService *DBService) DB() *bun.DB {
-	return dbService.db
-}
-
-func doMigration(ctx context.Context, db *bun.DB) error {
-	migrator := migrate.NewMigrator(db, migrations.Migrations)
-
-	if err := migrator.Init(ctx); err != nil {
-		return err
-	}
-
-	group, err := migrator.Migrate(ctx)
-	if err != nil && !strings.Contains(err.Error(), "no migrations") {
-		return err
-	}
-
-	if group == nil || group.ID == 0 {
-		log.Info(ctx, "there are no new migrations to run")
-		return nil
-	}
-
-	log.Infof(ctx, "MIGRATED DATABASE TO %s", group)
-
-	if db.Dialect().Name() == dialect.SQLite {
-		log.Info(ctx,
-			"running ANALYZE to update table and index statistics; this will take somewhere between "+
-				"1-10 minutes, or maybe longer depending on your hardware and database size, please be patient",
-		)
-		_, err := db.ExecContext(ctx, "ANALYZE")
-		if err != nil {
-			log.Warnf(ctx, "ANALYZE failed, query planner may make poor life choices: %s", err)
-		}
-	}
-	return nil
-}
-
-// NewBunDBService returns a bunDB derived from the provided config, which implements the go-fed DB interface.
-// Under the hood, it uses https://github.com/uptrace/bun to create and maintain a database connection.
-func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
-	var db *bun.DB
-	var err error
-	t := strings.ToLower(config.GetDbType())
-
-	switch t {
-	case "postgres":
-		db, err = pgConn(ctx)
-		if err != nil {
-			return nil, err
-		}
-	case "sqlite":
-		db, err = sqliteConn(ctx)
-		if err != nil {
-			return nil, err
-		}
-	default:
-		return nil, fmt.Errorf("database type %s not supported for bundb", t)
-	}
-
-	// Add database query hooks.
-	db.AddQueryHook(queryHook{})
-	if config.GetTracingEnabled() {
-		db.AddQueryHook(tracing.InstrumentBun())
-	}
-	if config.GetMetricsEnabled() {
-		db.AddQueryHook(metrics.InstrumentBun())
-	}
-
-	// table registration is needed for many-to-many, see:
-	// https://bun.uptrace.dev/orm/many-to-many-relation/
-	for _, t := range []interface{}{
-		&gtsmodel.AccountToEmoji{},
-		&gtsmodel.StatusToEmoji{},
-		&gtsmodel.StatusToTag{},
-		&gtsmodel.ThreadToStatus{},
-	} {
-		db.RegisterModel(t)
-	}
-
-	// perform any pending database migrations: this includes
-	// the very first 'migration' on startup which just creates
-	// necessary tables
-	if err := doMigration(ctx, db); err != nil {
-		return nil, fmt.Errorf("db migration error: %s", err)
-	}
-
-	ps := &DBService{
+// newDBService returns a new DBService with
+// all of its sub-services using the given db.
+func newDBService(wdb *WrappedDB, state *state.State) *DBService {
+	return &DBService{
 		Account: &accountDB{
-			db:    db,
+			db:    wdb,
+			state: state,
+		},
+		AccountDelegate: &accountDelegateDB{
+			db:    wdb,
+			state: state,
+		},
+		AccountDeletion: &accountDeletionDB{
+			db:    wdb,
 			state: state,
 		},
 		Admin: &adminDB{
-			db:    db,
+			db:    wdb,
 			state: state,
 		},
 		Application: &applicationDB{
-			db:    db,
+			db:    wdb,
 			state: state,
 		},
 		Basic: &basicDB{
-			db: db,
+			db: wdb,
+		},
+		BlobRef: &blobRefDB{
+			db:    wdb,
+			state: state,
 		},
 		Domain: &domainDB{
-			db:    db,
+			db:    wdb,
 			state: state,
 		},
 		Emoji: &emojiDB{
-			db:    db,
+			db:    wdb,
 			state: state,
 		},
 		HeaderFilter: &headerFilterDB{
-			db:    db,
+			db:    wdb,
+			state: state,
+		},
+		Import: &importDB{
+			db:    wdb,
 			state: state,
 		},
 		Instance: &instanceDB{
-			db:    db,
+			db:    wdb,
+			state: state,
+		},
+		Invite: &inviteDB{
+			db:    wdb,
 			state: state,
 		},
 		Filter: &filterDB{
-			db:    db,
+			db:    wdb,
 			state: state,
 		},
 		List: &listDB{
-			db:    db,
+			db:    wdb,
 			state: state,
 		},
 		Marker: &markerDB{
-			db:    db,
+			db:    wdb,
 			state: state,
 		},
 		Media: &mediaDB{
-			db:    db,
+			db:    wdb,
+			state: state,
+		},
+		MediaUpload: &mediaUploadDB{
+			db:    wdb,
 			state: state,
 		},
 		Mention: &mentionDB{
-			db:    db,
+			db:    wdb,
+			state: state,
+		},
+		ModerationNote: &moderationNoteDB{
+			db:    wdb,
 			state: state,
 		},
 		Move: &moveDB{
-			db:    db,
+			db:    wdb,
 			state: state,
 		},
 		Notification: &notificationDB{
-			db:    db,
+			db:    wdb,
 			state: state,
 		},
 		Poll: &pollDB{
-			db:    db,
+			db:    wdb,
+			state: state,
+		},
+		Redirect: &redirectDB{
+			db:    wdb,
 			state: state,
 		},
 		Relationship: &relationshipDB{
-			db:    db,
+			db:    wdb,
 			state: state,
 		},
 		Report: &reportDB{
-			db:    db,
+			db:    wdb,
 			state: state,
 		},
 		Rule: &ruleDB{
-			db:    db,
+			db:    wdb,
 			state: state,
 		},
 		Search: &searchDB{
-			db:    db,
+			db:    wdb,
 			state: state,
 		},
 		Session: &sessionDB{
-			db: db,
+			db: wdb,
 		},
 		Status: &statusDB{
-			db:    db,
+			db:    wdb,
 			state: state,
 		},
 		StatusBookmark: &statusBookmarkDB{
-			db:    db,
+			db:    wdb,
 			state: state,
 		},
 		StatusFave: &statusFaveDB{
-			db:    db,
+			db:    wdb,
 			state: state,
 		},
 		Tag: &tagDB{
-			db:    db,
+			db:    wdb,
 			state: state,
 		},
 		Thread: &threadDB{
-			db:    db,
+			db:    wdb,
 			state: state,
 		},
 		Timeline: &timelineDB{
-			db:    db,
+			db:    wdb,
 			state: state,
 		},
 		User: &userDB{
-			db:    db,
+			db:    wdb,
 			state: state,
 		},
 		Tombstone: &tombstoneDB{
-			db:    db,
+			db:    wdb,
 			state: state,
 		},
-		db: db,
+		db:    wdb,
+		state: state,
+	}
+}
+
+// RunInTx implements db.DB.
+func (dbService *DBService) RunInTx(ctx context.Context, fn func(tx db.DB) error) error {
+	var txdb *WrappedDB
+
+	if dbService.db.tx == nil {
+		// Other processes can't see changes made in the
+		// transaction until it commits, so hold publishing
+		// any cache invalidations made meanwhile until then.
+		release := dbService.state.Caches.HoldBus()
+		defer release()
+	}
+
+	err := dbService.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Wrap the transaction so all
+		// sub-services write through it.
+		txdb = dbService.db.withTx(tx)
+		return fn(newDBService(txdb, dbService.state))
+	})
+
+	switch {
+	case txdb == nil:
+		// Never got as far as
+		// beginning transaction.
+
+	case err != nil:
+		// Transaction was rolled back, so
+		// drop anything cached during it.
+		txdb.rolledBack()
+
+	case dbService.db.tx != nil:
+		// Nested transaction was released into
+		// its parent, so a rollback of the parent
+		// must also drop anything cached during it.
+		dbService.db.rollbacks = append(
+			dbService.db.rollbacks,
+			txdb.rollbacks...,
+		)
+	}
+
+	return err
+}
+
+// GetDB returns the underlying database connection pool.
+// Should only be used in testing + exceptional circumstance.
+func (dbService *DBService) DB() *bun.DB {
+	return dbService.db.DB
+}
+
+func doMigration(ctx context.Context, db *bun.DB) error {
+	migrator := newMigrator(db)
+
+	if err := migrator.Init(ctx); err != nil {
+		return err
+	}
+
+	group, err := migrator.Migrate(ctx)
+	if err != nil && !strings.Contains(err.Error(), "no migrations") {
+		return err
 	}
 
+	if group == nil || group.ID == 0 {
+		log.Info(ctx, "there are no new migrations to run")
+		return nil
+	}
+
+	log.Infof(ctx, "MIGRATED DATABASE TO %s", group)
+
+	analyze(ctx, db)
+	return nil
+}
+
+// newMigrator returns a bun migrator for our registered migrations.
+// Migrations are only marked as applied once they succeed, so that
+// a failed migration is not recorded and can be safely retried.
+func newMigrator(db *bun.DB) *migrate.Migrator {
+	return migrate.NewMigrator(db,
+		migrations.Migrations,
+		migrate.WithMarkAppliedOnSuccess(true),
+	)
+}
+
+// analyze runs ANALYZE on SQLite databases, to update table and
+// index statistics after migrations. This is a no-op for Postgres.
+func analyze(ctx context.Context, db *bun.DB) {
+	if db.Dialect().Name() != dialect.SQLite {
+		return
+	}
+
+	log.Info(ctx,
+		"running ANALYZE to update table and index statistics; this will take somewhere between "+
+			"1-10 minutes, or maybe longer depending on your hardware and database size, please be patient",
+	)
+	_, err := db.ExecContext(ctx, "ANALYZE")
+	if err != nil {
+		log.Warnf(ctx, "ANALYZE failed, query planner may make poor life choices: %s", err)
+	}
+}
+
+// NewBunDBService returns a bunDB derived from the provided config, which implements the go-fed DB interface.
+// Under the hood, it uses https://github.com/uptrace/bun to create and maintain a database connection.
+func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
+	db, err := bunDBConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// perform any pending database migrations: this includes
+	// the very first 'migration' on startup which just creates
+	// necessary tables
+	if err := doMigration(ctx, db); err != nil {
+		return nil, fmt.Errorf("db migration error: %s", err)
+	}
+
+	// Open any read-only replicas, and
+	// wrap them up with the primary db.
+	replicas, err := replicaConns(ctx)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	wdb := NewWrappedDB(db, replicas...)
+
+	ps := newDBService(wdb, state)
+
 	// we can confidently return this useable service now
 	return ps, nil
 }
 
+// bunDBConn opens a new bun database connection
+// derived from the provided config, with query
+// hooks and many-to-many models registered.
+func bunDBConn(ctx context.Context) (*bun.DB, error) {
+	var db *bun.DB
+	var err error
+	t := strings.ToLower(config.GetDbType())
+
+	switch t {
+	case "postgres":
+		db, err = pgConn(ctx)
+		if err != nil {
+			return nil, err
+		}
+	case "sqlite":
+		db, err = sqliteConn(ctx)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("database type %s not supported for bundb", t)
+	}
+
+	prepareDB(db)
+	return db, nil
+}
+
+// replicaConns opens connections to any configured read-only
+// Postgres replicas, prepared the same way as the primary.
+func replicaConns(ctx context.Context) ([]*bun.DB, error) {
+	dsns := config.GetDbReplicaDSNs()
+	if len(dsns) == 0 {
+		return nil, nil
+	}
+
+	if t := strings.ToLower(config.GetDbType()); t != "postgres" {
+		return nil, fmt.Errorf("%s is only supported for postgres, not %s", config.DbReplicaDSNsFlag(), t)
+	}
+
+	replicas := make([]*bun.DB, 0, len(dsns))
+	for i, dsn := range dsns {
+		db, err := pgReplicaConn(ctx, dsn)
+		if err != nil {
+			for _, replica := range replicas {
+				_ = replica.Close()
+			}
+			return nil, fmt.Errorf("error connecting to replica %d: %w", i, err)
+		}
+
+		prepareDB(db)
+		replicas = append(replicas, db)
+	}
+
+	return replicas, nil
+}
+
+// prepareDB adds query hooks to, and registers
+// many-to-many models with, the given bun.DB.
+func prepareDB(db *bun.DB) {
+	// Add database query hooks.
+	db.AddQueryHook(queryHook{})
+	if config.GetTracingEnabled() {
+		db.AddQueryHook(tracing.InstrumentBun())
+	}
+	if config.GetMetricsEnabled() {
+		db.AddQueryHook(metrics.InstrumentBun())
+		db.AddQueryHook(metricsHook{})
+	}
+
+	// table registration is needed for many-to-many, see:
+	// https://bun.uptrace.dev/orm/many-to-many-relation/
+	for _, t := range []interface{}{
+		&gtsmodel.AccountToEmoji{},
+		&gtsmodel.StatusToEmoji{},
+		&gtsmodel.StatusToTag{},
+		&gtsmodel.ThreadToStatus{},
+	} {
+		db.RegisterModel(t)
+	}
+}
+
 func pgConn(ctx context.Context) (*bun.DB, error) {
 	opts, err := deriveBunDBPGOptions() //nolint:contextcheck
 	if err != nil {
 		return nil, fmt.Errorf("could not create bundb postgres options: %w", err)
 	}
 
+	db, err := pgOpen(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info(ctx, "connected to POSTGRES database")
+	return db, nil
+}
+
+// pgReplicaConn connects to a read-only
+// Postgres replica at the given DSN.
+func pgReplicaConn(ctx context.Context, dsn string) (*bun.DB, error) {
+	opts, err := pgx.ParseConfig(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse postgres replica dsn: %w", err)
+	}
+
+	if opts.RuntimeParams["application_name"] == "" {
+		opts.RuntimeParams["application_name"] = config.GetApplicationName()
+	}
+
+	db, err := pgOpen(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof(ctx, "connected to POSTGRES replica %s:%d", opts.Host, opts.Port)
+	return db, nil
+}
+
+func pgOpen(ctx context.Context, opts *pgx.ConnConfig) (*bun.DB, error) {
 	cfg := stdlib.RegisterConnConfig(opts)
 
 	sqldb, err := sql.Open("pgx-gts", cfg)
@@ -320,7 +515,6 @@ func pgConn(ctx context.Context) (*bun.DB, error) {
 		return nil, fmt.Errorf("postgres ping: %w", err)
 	}
 
-	log.Info(ctx, "connected to POSTGRES database")
 	return db, nil
 }
 
@@ -19,11 +19,17 @@ package bundb_test
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/sqlitedialect"
 )
 
 type BundbNewTestSuite struct {
@@ -46,6 +52,137 @@ func (suite *BundbNewTestSuite) TestCreateNewSqliteDBNoAddress() {
 	suite.Nil(db)
 }
 
+func (suite *BundbNewTestSuite) TestCreateNewSqliteDBWithReplicas() {
+	// replicas are only supported for postgres
+	config.SetDbReplicaDSNs([]string{"postgres://gotosocial@replica.example.org/gotosocial"})
+	db, err := bundb.NewBunDBService(context.Background(), nil)
+	suite.EqualError(err, "db-replica-dsns is only supported for postgres, not sqlite")
+	suite.Nil(db)
+}
+
+func (suite *BundbNewTestSuite) TestWrappedDBSelectRoundRobin() {
+	newDB := func() *bun.DB {
+		sqldb, err := sql.Open("sqlite-gts", ":memory:")
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		return bun.NewDB(sqldb, sqlitedialect.New())
+	}
+
+	var (
+		primary  = newDB()
+		replica1 = newDB()
+		replica2 = newDB()
+	)
+
+	// With no replicas, replica selects go to primary.
+	wdb := bundb.NewWrappedDB(primary)
+	suite.Same(primary, wdb.NewReplicaSelect().DB())
+	suite.Same(primary, wdb.NewReplicaSelect().DB())
+
+	// With replicas, replica selects are spread
+	// across them, and other queries, including
+	// plain selects, go to primary.
+	wdb = bundb.NewWrappedDB(primary, replica1, replica2)
+	first := wdb.NewReplicaSelect().DB()
+	second := wdb.NewReplicaSelect().DB()
+	suite.NotSame(primary, first)
+	suite.NotSame(primary, second)
+	suite.NotSame(first, second)
+	suite.Same(first, wdb.NewReplicaSelect().DB())
+	suite.Same(primary, wdb.NewSelect().DB())
+	suite.Same(primary, wdb.NewSelect().DB())
+	suite.Same(primary, wdb.NewInsert().DB())
+
+	suite.NoError(wdb.Close())
+}
+
+func (suite *BundbNewTestSuite) newTxMention() *gtsmodel.Mention {
+	m := suite.testMentions["local_user_2_mention_zork"]
+	return &gtsmodel.Mention{
+		ID:               "01J6ZBXRQE2E4PGN1AW5CC0VQ4",
+		StatusID:         m.StatusID,
+		OriginAccountID:  m.OriginAccountID,
+		OriginAccountURI: m.OriginAccountURI,
+		TargetAccountID:  m.TargetAccountID,
+	}
+}
+
+func (suite *BundbNewTestSuite) TestRunInTxCommit() {
+	ctx := context.Background()
+	mention := suite.newTxMention()
+
+	err := suite.db.RunInTx(ctx, func(tx db.DB) error {
+		return tx.PutMention(ctx, mention)
+	})
+	suite.NoError(err)
+
+	// Mention should have been committed.
+	dbMention, err := suite.db.GetMention(ctx, mention.ID)
+	suite.NoError(err)
+	suite.Equal(mention.ID, dbMention.ID)
+}
+
+func (suite *BundbNewTestSuite) TestRunInTxRollback() {
+	ctx := context.Background()
+	mention := suite.newTxMention()
+
+	// Warm the caches for the models the mention is
+	// populated with, so that getting it within the tx
+	// doesn't need a second connection (which the test
+	// sqlite db would block on until the tx finished).
+	if _, err := suite.db.GetStatusByID(ctx, mention.StatusID); err != nil {
+		suite.FailNow(err.Error())
+	}
+	if _, err := suite.db.GetAccountByID(ctx, mention.OriginAccountID); err != nil {
+		suite.FailNow(err.Error())
+	}
+	if _, err := suite.db.GetAccountByID(ctx, mention.TargetAccountID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	err := suite.db.RunInTx(ctx, func(tx db.DB) error {
+		if err := tx.PutMention(ctx, mention); err != nil {
+			return err
+		}
+
+		// Mention should be visible within the tx.
+		if _, err := tx.GetMention(ctx, mention.ID); err != nil {
+			return err
+		}
+
+		return errors.New("oopsie")
+	})
+	suite.EqualError(err, "oopsie")
+
+	// Mention should have been rolled back,
+	// and not still be served from the cache.
+	_, err = suite.db.GetMention(ctx, mention.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *BundbNewTestSuite) TestRunInTxNestedRollback() {
+	ctx := context.Background()
+	mention := suite.newTxMention()
+
+	err := suite.db.RunInTx(ctx, func(tx db.DB) error {
+		// Put mention in a nested tx that succeeds.
+		if err := tx.RunInTx(ctx, func(tx db.DB) error {
+			return tx.PutMention(ctx, mention)
+		}); err != nil {
+			return err
+		}
+
+		// Then fail the outer tx.
+		return errors.New("oopsie")
+	})
+	suite.EqualError(err, "oopsie")
+
+	// Nested insert should have been rolled back with the outer tx.
+	_, err = suite.db.GetMention(ctx, mention.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
 func TestBundbNewTestSuite(t *testing.T) {
 	suite.Run(t, new(BundbNewTestSuite))
 }
@@ -0,0 +1,120 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/superseriousbusiness/gotosocial/internal/cache"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/uptrace/bun"
+)
+
+// cacheBusChannel is the Postgres notification
+// channel over which invalidations are sent.
+const cacheBusChannel = "gotosocial_cache_invalidation"
+
+// pgCacheBus implements cache.Bus
+// using Postgres LISTEN / NOTIFY.
+type pgCacheBus struct {
+	db   *bun.DB
+	opts *pgx.ConnConfig
+}
+
+// NewPostgresCacheBus returns a new cache.Bus that propagates cache
+// invalidations between processes connected to the configured Postgres
+// database, using LISTEN / NOTIFY. Note this opens its own connections
+// to the database, separate to those in use by NewBunDBService().
+func NewPostgresCacheBus(ctx context.Context) (cache.Bus, error) {
+	opts, err := deriveBunDBPGOptions() //nolint:contextcheck
+	if err != nil {
+		return nil, fmt.Errorf("could not create bundb postgres options: %w", err)
+	}
+
+	db, err := pgOpen(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info(ctx, "connected POSTGRES cache invalidation bus")
+	return &pgCacheBus{
+		db:   db,
+		opts: opts,
+	}, nil
+}
+
+func (b *pgCacheBus) Publish(ctx context.Context, msg *cache.Invalidation) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return gtserror.Newf("error encoding invalidation: %w", err)
+	}
+
+	// Note that postgres limits notification payloads to
+	// 8000 bytes, though invalidations should be far smaller.
+	if _, err := b.db.ExecContext(ctx,
+		"SELECT pg_notify(?, ?)",
+		cacheBusChannel, string(payload),
+	); err != nil {
+		return gtserror.Newf("error sending notification: %w", err)
+	}
+
+	return nil
+}
+
+func (b *pgCacheBus) Subscribe(ctx context.Context, fn func(*cache.Invalidation)) error {
+	// Open a dedicated connection to listen on, as
+	// this will be held for lifetime of subscription.
+	conn, err := pgx.ConnectConfig(ctx, b.opts)
+	if err != nil {
+		return gtserror.Newf("error connecting: %w", err)
+	}
+
+	defer func() {
+		// Use a fresh context, as ctx
+		// is usually cancelled by now.
+		_ = conn.Close(context.Background())
+	}()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+cacheBusChannel); err != nil {
+		return gtserror.Newf("error listening: %w", err)
+	}
+
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				// Subscription stopped.
+				return nil
+			}
+			return gtserror.Newf("error waiting for notification: %w", err)
+		}
+
+		var msg cache.Invalidation
+
+		if err := json.Unmarshal([]byte(n.Payload), &msg); err != nil {
+			log.Errorf(ctx, "error decoding invalidation: %v", err)
+			continue
+		}
+
+		fn(&msg)
+	}
+}
@@ -31,7 +31,7 @@ import (
 )
 
 type domainDB struct {
-	db    *bun.DB
+	db    *WrappedDB
 	state *state.State
 }
 
@@ -227,6 +227,175 @@ func (d *domainDB) DeleteDomainBlock(ctx context.Context, domain string) error {
 	return nil
 }
 
+func (d *domainDB) CreateDomainPause(ctx context.Context, pause *gtsmodel.DomainPause) error {
+	// Normalize the domain as punycode
+	var err error
+	pause.Domain, err = util.Punify(pause.Domain)
+	if err != nil {
+		return err
+	}
+
+	// Attempt to store domain pause in DB
+	if _, err := d.db.NewInsert().
+		Model(pause).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// Clear the domain pause caches (for later reload)
+	d.state.Caches.GTS.DomainPause.Clear()
+	d.state.Caches.GTS.DomainPauseIngestion.Clear()
+
+	return nil
+}
+
+func (d *domainDB) GetDomainPause(ctx context.Context, domain string) (*gtsmodel.DomainPause, error) {
+	// Normalize the domain as punycode
+	domain, err := util.Punify(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check for easy case, domain referencing *us*
+	if domain == "" || domain == config.GetAccountDomain() ||
+		domain == config.GetHost() {
+		return nil, db.ErrNoEntries
+	}
+
+	var pause gtsmodel.DomainPause
+
+	// Look for pause matching domain in DB
+	q := d.db.
+		NewSelect().
+		Model(&pause).
+		Where("? = ?", bun.Ident("domain_pause.domain"), domain)
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &pause, nil
+}
+
+func (d *domainDB) GetDomainPauses(ctx context.Context) ([]*gtsmodel.DomainPause, error) {
+	pauses := []*gtsmodel.DomainPause{}
+
+	if err := d.db.
+		NewSelect().
+		Model(&pauses).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return pauses, nil
+}
+
+func (d *domainDB) GetDomainPauseByID(ctx context.Context, id string) (*gtsmodel.DomainPause, error) {
+	var pause gtsmodel.DomainPause
+
+	q := d.db.
+		NewSelect().
+		Model(&pause).
+		Where("? = ?", bun.Ident("domain_pause.id"), id)
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &pause, nil
+}
+
+func (d *domainDB) DeleteDomainPause(ctx context.Context, domain string) error {
+	// Normalize the domain as punycode
+	domain, err := util.Punify(domain)
+	if err != nil {
+		return err
+	}
+
+	// Attempt to delete domain pause
+	if _, err := d.db.NewDelete().
+		Model((*gtsmodel.DomainPause)(nil)).
+		Where("? = ?", bun.Ident("domain_pause.domain"), domain).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// Clear the domain pause caches (for later reload)
+	d.state.Caches.GTS.DomainPause.Clear()
+	d.state.Caches.GTS.DomainPauseIngestion.Clear()
+
+	return nil
+}
+
+func (d *domainDB) CreateDomainPermissionDraft(ctx context.Context, draft *gtsmodel.DomainPermissionDraft) error {
+	// Normalize the domain as punycode
+	var err error
+	draft.Domain, err = util.Punify(draft.Domain)
+	if err != nil {
+		return err
+	}
+
+	// Attempt to store domain permission draft in DB
+	_, err = d.db.NewInsert().
+		Model(draft).
+		Exec(ctx)
+	return err
+}
+
+func (d *domainDB) GetDomainPermissionDraftByID(ctx context.Context, id string) (*gtsmodel.DomainPermissionDraft, error) {
+	var draft gtsmodel.DomainPermissionDraft
+
+	q := d.db.
+		NewSelect().
+		Model(&draft).
+		Where("? = ?", bun.Ident("domain_permission_draft.id"), id)
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &draft, nil
+}
+
+func (d *domainDB) GetDomainPermissionDrafts(
+	ctx context.Context,
+	permType gtsmodel.DomainPermissionType,
+	domain string,
+) ([]*gtsmodel.DomainPermissionDraft, error) {
+	drafts := []*gtsmodel.DomainPermissionDraft{}
+
+	q := d.db.
+		NewSelect().
+		Model(&drafts).
+		Order("domain_permission_draft.id DESC")
+
+	if permType != gtsmodel.DomainPermissionUnknown {
+		q = q.Where("? = ?", bun.Ident("domain_permission_draft.permission_type"), permType)
+	}
+
+	if domain != "" {
+		// Normalize the domain as punycode
+		var err error
+		domain, err = util.Punify(domain)
+		if err != nil {
+			return nil, err
+		}
+
+		q = q.Where("? = ?", bun.Ident("domain_permission_draft.domain"), domain)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return drafts, nil
+}
+
+func (d *domainDB) DeleteDomainPermissionDraft(ctx context.Context, id string) error {
+	_, err := d.db.NewDelete().
+		Model((*gtsmodel.DomainPermissionDraft)(nil)).
+		Where("? = ?", bun.Ident("domain_permission_draft.id"), id).
+		Exec(ctx)
+	return err
+}
+
 func (d *domainDB) IsDomainBlocked(ctx context.Context, domain string) (bool, error) {
 	// Normalize the domain as punycode
 	domain, err := util.Punify(domain)
@@ -328,3 +497,63 @@ func (d *domainDB) AreURIsBlocked(ctx context.Context, uris []*url.URL) (bool, e
 	}
 	return false, nil
 }
+
+func (d *domainDB) IsDomainPaused(ctx context.Context, domain string) (bool, error) {
+	// Normalize the domain as punycode
+	domain, err := util.Punify(domain)
+	if err != nil {
+		return false, err
+	}
+
+	// Domain referencing *us* cannot be paused.
+	if domain == "" || domain == config.GetAccountDomain() ||
+		domain == config.GetHost() {
+		return false, nil
+	}
+
+	// Check the cache for a domain pause (hydrating the cache with callback if necessary)
+	return d.state.Caches.GTS.DomainPause.Matches(domain, func() ([]string, error) {
+		var domains []string
+
+		// Scan list of all paused domains from DB
+		q := d.db.NewSelect().
+			Table("domain_pauses").
+			Column("domain")
+		if err := q.Scan(ctx, &domains); err != nil {
+			return nil, err
+		}
+
+		return domains, nil
+	})
+}
+
+func (d *domainDB) IsDomainIngestionPaused(ctx context.Context, domain string) (bool, error) {
+	// Normalize the domain as punycode
+	domain, err := util.Punify(domain)
+	if err != nil {
+		return false, err
+	}
+
+	// Domain referencing *us* cannot be paused.
+	if domain == "" || domain == config.GetAccountDomain() ||
+		domain == config.GetHost() {
+		return false, nil
+	}
+
+	// Check the cache for a domain pause with ingestion
+	// paused (hydrating the cache with callback if necessary)
+	return d.state.Caches.GTS.DomainPauseIngestion.Matches(domain, func() ([]string, error) {
+		var domains []string
+
+		// Scan list of all ingestion paused domains from DB
+		q := d.db.NewSelect().
+			Table("domain_pauses").
+			Column("domain").
+			Where("? = ?", bun.Ident("pause_ingestion"), true)
+		if err := q.Scan(ctx, &domains); err != nil {
+			return nil, err
+		}
+
+		return domains, nil
+	})
+}
@@ -24,7 +24,9 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 type DomainTestSuite struct {
@@ -274,6 +276,130 @@ func (suite *DomainTestSuite) TestIsOtherDomainBlockedWildcardAndExplicit() {
 	}
 }
 
+func (suite *DomainTestSuite) TestIsDomainPaused() {
+	ctx := context.Background()
+
+	domainPause := &gtsmodel.DomainPause{
+		ID:                 "01HZ3Q9N1BC3D9W6QZ2S1K4H7E",
+		Domain:             "sleepy.apples",
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+		PauseIngestion:     util.Ptr(false),
+	}
+
+	// no domain pause exists for the given domain yet
+	paused, err := suite.db.IsDomainPaused(ctx, domainPause.Domain)
+	suite.NoError(err)
+	suite.False(paused)
+
+	err = suite.db.CreateDomainPause(ctx, domainPause)
+	suite.NoError(err)
+
+	// domain + subdomains should now be paused
+	for _, domain := range []string{
+		"sleepy.apples",
+		"very.sleepy.apples",
+	} {
+		paused, err = suite.db.IsDomainPaused(ctx, domain)
+		suite.NoError(err)
+		suite.True(paused)
+	}
+
+	// ingestion should not be paused
+	paused, err = suite.db.IsDomainIngestionPaused(ctx, domainPause.Domain)
+	suite.NoError(err)
+	suite.False(paused)
+
+	// pausing should not block the domain
+	blocked, err := suite.db.IsDomainBlocked(ctx, domainPause.Domain)
+	suite.NoError(err)
+	suite.False(blocked)
+
+	// lift the pause again
+	err = suite.db.DeleteDomainPause(ctx, domainPause.Domain)
+	suite.NoError(err)
+
+	paused, err = suite.db.IsDomainPaused(ctx, domainPause.Domain)
+	suite.NoError(err)
+	suite.False(paused)
+}
+
+func (suite *DomainTestSuite) TestIsDomainIngestionPaused() {
+	ctx := context.Background()
+
+	domainPause := &gtsmodel.DomainPause{
+		ID:                 "01HZ3QBW0ZP6H3Z7N3A4F6E1XR",
+		Domain:             "sleepy.apples",
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+		PauseIngestion:     util.Ptr(true),
+	}
+
+	err := suite.db.CreateDomainPause(ctx, domainPause)
+	suite.NoError(err)
+
+	paused, err := suite.db.IsDomainPaused(ctx, domainPause.Domain)
+	suite.NoError(err)
+	suite.True(paused)
+
+	paused, err = suite.db.IsDomainIngestionPaused(ctx, domainPause.Domain)
+	suite.NoError(err)
+	suite.True(paused)
+
+	paused, err = suite.db.IsDomainIngestionPaused(ctx, "awake.apples")
+	suite.NoError(err)
+	suite.False(paused)
+}
+
+func (suite *DomainTestSuite) TestDomainPermissionDrafts() {
+	ctx := context.Background()
+
+	for _, draft := range []*gtsmodel.DomainPermissionDraft{
+		{
+			ID:                 "01J0Z3A7W3Y6M0N2B8V4C5X1QK",
+			PermissionType:     gtsmodel.DomainPermissionBlock,
+			Domain:             "draft.apples",
+			CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+		},
+		{
+			ID:                 "01J0Z3AS6F2D7H9K4M1N8P3R5T",
+			PermissionType:     gtsmodel.DomainPermissionAllow,
+			Domain:             "draft.apples",
+			CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+		},
+	} {
+		err := suite.db.CreateDomainPermissionDraft(ctx, draft)
+		suite.NoError(err)
+	}
+
+	// drafts should not block anything
+	blocked, err := suite.db.IsDomainBlocked(ctx, "draft.apples")
+	suite.NoError(err)
+	suite.False(blocked)
+
+	// newest first when unfiltered
+	drafts, err := suite.db.GetDomainPermissionDrafts(ctx, gtsmodel.DomainPermissionUnknown, "")
+	suite.NoError(err)
+	suite.Len(drafts, 2)
+	suite.Equal("01J0Z3AS6F2D7H9K4M1N8P3R5T", drafts[0].ID)
+
+	// filter by type
+	drafts, err = suite.db.GetDomainPermissionDrafts(ctx, gtsmodel.DomainPermissionBlock, "")
+	suite.NoError(err)
+	suite.Len(drafts, 1)
+	suite.Equal(gtsmodel.DomainPermissionBlock, drafts[0].PermissionType)
+
+	// filter by domain
+	drafts, err = suite.db.GetDomainPermissionDrafts(ctx, gtsmodel.DomainPermissionUnknown, "other.apples")
+	suite.NoError(err)
+	suite.Empty(drafts)
+
+	// delete one
+	err = suite.db.DeleteDomainPermissionDraft(ctx, "01J0Z3A7W3Y6M0N2B8V4C5X1QK")
+	suite.NoError(err)
+
+	_, err = suite.db.GetDomainPermissionDraftByID(ctx, "01J0Z3A7W3Y6M0N2B8V4C5X1QK")
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
 func TestDomainTestSuite(t *testing.T) {
 	suite.Run(t, new(DomainTestSuite))
 }
@@ -38,7 +38,7 @@ import (
 )
 
 type emojiDB struct {
-	db    *bun.DB
+	db    *WrappedDB
 	state *state.State
 }
 
@@ -483,6 +483,25 @@ func (e *emojiDB) PutEmojiCategory(ctx context.Context, emojiCategory *gtsmodel.
 	})
 }
 
+func (e *emojiDB) UpdateEmojiCategory(ctx context.Context, emojiCategory *gtsmodel.EmojiCategory, columns ...string) error {
+	emojiCategory.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column, ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	// Update the emoji category model in the database.
+	return e.state.Caches.GTS.EmojiCategory.Store(emojiCategory, func() error {
+		_, err := e.db.
+			NewUpdate().
+			Model(emojiCategory).
+			Where("? = ?", bun.Ident("emoji_category.id"), emojiCategory.ID).
+			Column(columns...).
+			Exec(ctx)
+		return err
+	})
+}
+
 func (e *emojiDB) GetEmojiCategories(ctx context.Context) ([]*gtsmodel.EmojiCategory, error) {
 	emojiCategoryIDs := []string{}
 
@@ -490,7 +509,7 @@ func (e *emojiDB) GetEmojiCategories(ctx context.Context) ([]*gtsmodel.EmojiCate
 		NewSelect().
 		TableExpr("? AS ?", bun.Ident("emoji_categories"), bun.Ident("emoji_category")).
 		Column("emoji_category.id").
-		Order("emoji_category.name ASC")
+		Order("emoji_category.position ASC", "emoji_category.name ASC")
 
 	if err := q.Scan(ctx, &emojiCategoryIDs); err != nil {
 		return nil, err
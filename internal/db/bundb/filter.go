@@ -32,7 +32,7 @@ import (
 )
 
 type filterDB struct {
-	db    *bun.DB
+	db    *WrappedDB
 	state *state.State
 }
 
@@ -29,7 +29,7 @@ import (
 )
 
 type headerFilterDB struct {
-	db    *bun.DB
+	db    *WrappedDB
 	state *state.State
 }
 
@@ -19,11 +19,14 @@ package bundb
 
 import (
 	"context"
+	"runtime"
+	"strings"
 	"time"
 
 	"codeberg.org/gruf/go-kv"
 	"codeberg.org/gruf/go-logger/v2/level"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/metrics"
 	"github.com/uptrace/bun"
 )
 
@@ -54,3 +57,62 @@ func (queryHook) AfterQuery(ctx context.Context, event *bun.QueryEvent) {
 		log.Printf("level=TRACE duration=%s query=%s", dur, event.Query)
 	}
 }
+
+// metricsHook implements bun.QueryHook, recording the
+// duration + any error of each query, by the lookup
+// (i.e. bundb function) that performed the query.
+type metricsHook struct{}
+
+func (metricsHook) BeforeQuery(ctx context.Context, _ *bun.QueryEvent) context.Context {
+	return ctx // do nothing
+}
+
+// AfterQuery records the time taken to query, and any error, by lookup and operation.
+func (metricsHook) AfterQuery(ctx context.Context, event *bun.QueryEvent) {
+	dur := time.Since(event.StartTime)
+	metrics.DBQuery(ctx, queryLookup(), event.Operation(), dur, event.Err)
+}
+
+// bundbPkgPrefix is the function name
+// prefix of all functions in this package.
+const bundbPkgPrefix = "github.com/superseriousbusiness/gotosocial/internal/db/bundb."
+
+// queryLookup returns the name of the first function in this
+// package found on the calling stack (excluding query hooks
+// and WrappedDB{}), e.g. "relationshipDB.getBlock", or
+// "unknown" if none could be found.
+func queryLookup() string {
+	var pcs [32]uintptr
+
+	// Skip runtime.Callers,
+	// this and AfterQuery.
+	n := runtime.Callers(3, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+
+	for {
+		frame, more := frames.Next()
+
+		name, ok := strings.CutPrefix(frame.Function, bundbPkgPrefix)
+		if ok && !strings.HasPrefix(name, "(*WrappedDB)") {
+			return trimFuncName(name)
+		}
+
+		if !more {
+			return "unknown"
+		}
+	}
+}
+
+// trimFuncName trims a runtime function name down to
+// "type.method" or "func", dropping pointer receiver
+// syntax and any suffixes of closures / generic types,
+// e.g. "(*relationshipDB).getBlock.func1" => "relationshipDB.getBlock".
+func trimFuncName(name string) string {
+	name = strings.Replace(name, "(*", "", 1)
+	name = strings.Replace(name, ")", "", 1)
+	name = strings.Replace(name, "[...]", "", -1)
+	if i := strings.Index(name, ".func"); i > 0 {
+		name = name[:i]
+	}
+	return name
+}
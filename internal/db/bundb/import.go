@@ -0,0 +1,189 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type importDB struct {
+	db    *WrappedDB
+	state *state.State
+}
+
+func (i *importDB) GetImportByID(ctx context.Context, id string) (*gtsmodel.Import, error) {
+	var imp gtsmodel.Import
+
+	if err := i.db.
+		NewSelect().
+		Model(&imp).
+		Where("? = ?", bun.Ident("import.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := i.populateImport(ctx, &imp); err != nil {
+		return nil, err
+	}
+
+	return &imp, nil
+}
+
+func (i *importDB) GetImports(ctx context.Context, accountID string) ([]*gtsmodel.Import, error) {
+	imps := []*gtsmodel.Import{}
+
+	if err := i.db.
+		NewSelect().
+		Model(&imps).
+		Where("? = ?", bun.Ident("import.account_id"), accountID).
+		Order("import.id DESC").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	errs := gtserror.NewMultiError(len(imps))
+	for _, imp := range imps {
+		if err := i.populateImport(ctx, imp); err != nil {
+			errs.Append(err)
+		}
+	}
+
+	if err := errs.Combine(); err != nil {
+		return nil, err
+	}
+
+	return imps, nil
+}
+
+func (i *importDB) populateImport(ctx context.Context, imp *gtsmodel.Import) error {
+	if gtscontext.Barebones(ctx) || imp.Account != nil {
+		// Nothing to do.
+		return nil
+	}
+
+	var err error
+	imp.Account, err = i.state.DB.GetAccountByID(
+		gtscontext.SetBarebones(ctx),
+		imp.AccountID,
+	)
+	if err != nil {
+		return gtserror.Newf("error populating import account: %w", err)
+	}
+
+	return nil
+}
+
+func (i *importDB) GetUnfinishedImportIDs(ctx context.Context) ([]string, error) {
+	var ids []string
+
+	if err := i.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("imports"), bun.Ident("import")).
+		Column("import.id").
+		Where("? IS NULL", bun.Ident("import.finished_at")).
+		Order("import.id ASC").
+		Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func (i *importDB) PutImport(ctx context.Context, imp *gtsmodel.Import, rows []*gtsmodel.ImportRow) error {
+	return i.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.
+			NewInsert().
+			Model(imp).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		if len(rows) == 0 {
+			// Nothing
+			// else to do.
+			return nil
+		}
+
+		_, err := tx.
+			NewInsert().
+			Model(&rows).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (i *importDB) UpdateImport(ctx context.Context, imp *gtsmodel.Import, columns ...string) error {
+	imp.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := i.db.
+		NewUpdate().
+		Model(imp).
+		Column(columns...).
+		Where("? = ?", bun.Ident("import.id"), imp.ID).
+		Exec(ctx)
+	return err
+}
+
+func (i *importDB) GetImportRows(ctx context.Context, importID string, status gtsmodel.ImportRowStatus) ([]*gtsmodel.ImportRow, error) {
+	rows := []*gtsmodel.ImportRow{}
+
+	q := i.db.
+		NewSelect().
+		Model(&rows).
+		Where("? = ?", bun.Ident("import_row.import_id"), importID).
+		Order("import_row.line ASC")
+
+	if status != "" {
+		q = q.Where("? = ?", bun.Ident("import_row.status"), status)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func (i *importDB) UpdateImportRow(ctx context.Context, row *gtsmodel.ImportRow, columns ...string) error {
+	row.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := i.db.
+		NewUpdate().
+		Model(row).
+		Column(columns...).
+		Where("? = ?", bun.Ident("import_row.id"), row.ID).
+		Exec(ctx)
+	return err
+}
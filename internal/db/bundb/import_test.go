@@ -0,0 +1,131 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+type ImportTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *ImportTestSuite) TestPutGetImport() {
+	ctx := context.Background()
+
+	imp := &gtsmodel.Import{
+		ID:        id.NewULID(),
+		AccountID: suite.testAccounts["local_account_1"].ID,
+		Type:      gtsmodel.ImportTypeFollowing,
+	}
+
+	rows := []*gtsmodel.ImportRow{
+		{
+			ID:       id.NewULID(),
+			ImportID: imp.ID,
+			Line:     2,
+			Target:   "foss_satan@fossbros-anonymous.io",
+			Status:   gtsmodel.ImportRowStatusPending,
+		},
+		{
+			ID:       id.NewULID(),
+			ImportID: imp.ID,
+			Line:     1,
+			Target:   "admin",
+			Status:   gtsmodel.ImportRowStatusPending,
+		},
+		{
+			ID:       id.NewULID(),
+			ImportID: imp.ID,
+			Line:     3,
+			Target:   "not an address",
+			Status:   gtsmodel.ImportRowStatusFailed,
+			Error:    "invalid account address",
+		},
+	}
+
+	if err := suite.db.PutImport(ctx, imp, rows); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Import should be unfinished.
+	unfinished, err := suite.db.GetUnfinishedImportIDs(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal([]string{imp.ID}, unfinished)
+
+	// Pending rows should come back in line order.
+	pending, err := suite.db.GetImportRows(ctx, imp.ID, gtsmodel.ImportRowStatusPending)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if suite.Len(pending, 2) {
+		suite.Equal(1, pending[0].Line)
+		suite.Equal(2, pending[1].Line)
+	}
+
+	// Process the rows + finish the import.
+	for _, row := range pending {
+		row.Status = gtsmodel.ImportRowStatusDone
+		if err := suite.db.UpdateImportRow(ctx, row, "status", "error"); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	imp.FinishedAt = time.Now()
+	if err := suite.db.UpdateImport(ctx, imp, "finished_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	unfinished, err = suite.db.GetUnfinishedImportIDs(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(unfinished)
+
+	all, err := suite.db.GetImportRows(ctx, imp.ID, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if suite.Len(all, 3) {
+		suite.Equal(gtsmodel.ImportRowStatusDone, all[0].Status)
+		suite.Equal(gtsmodel.ImportRowStatusDone, all[1].Status)
+		suite.Equal(gtsmodel.ImportRowStatusFailed, all[2].Status)
+		suite.Equal("invalid account address", all[2].Error)
+	}
+
+	imps, err := suite.db.GetImports(ctx, imp.AccountID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if suite.Len(imps, 1) {
+		suite.True(imps[0].Finished())
+		suite.NotNil(imps[0].Account)
+	}
+}
+
+func TestImportTestSuite(t *testing.T) {
+	suite.Run(t, new(ImportTestSuite))
+}
@@ -31,10 +31,11 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
 )
 
 type instanceDB struct {
-	db    *bun.DB
+	db    *WrappedDB
 	state *state.State
 }
 
@@ -183,7 +184,7 @@ func (i *instanceDB) getInstance(ctx context.Context, lookup string, dbQuery fun
 func (i *instanceDB) PopulateInstance(ctx context.Context, instance *gtsmodel.Instance) error {
 	var (
 		err  error
-		errs = gtserror.NewMultiError(2)
+		errs = gtserror.NewMultiError(3)
 	)
 
 	if instance.DomainBlockID != "" && instance.DomainBlock == nil {
@@ -208,6 +209,17 @@ func (i *instanceDB) PopulateInstance(ctx context.Context, instance *gtsmodel.In
 		}
 	}
 
+	if !instance.FeaturedAccountsPopulated() {
+		// Instance featured accounts are not set, fetch from database.
+		instance.FeaturedAccounts, err = i.state.DB.GetAccountsByIDs(
+			gtscontext.SetBarebones(ctx),
+			instance.FeaturedAccountIDs,
+		)
+		if err != nil {
+			errs.Appendf("error populating instance featured accounts: %w", err)
+		}
+	}
+
 	return errs.Combine()
 }
 
@@ -410,3 +422,145 @@ func (i *instanceDB) GetInstanceModerators(ctx context.Context) ([]*gtsmodel.Acc
 
 	return i.state.DB.GetAccountsByIDs(ctx, accountIDs)
 }
+
+func (i *instanceDB) GetInstanceStats(ctx context.Context, statusesSince time.Time) (*gtsmodel.InstanceStats, error) {
+	stats := &gtsmodel.InstanceStats{
+		StatusesSince: statusesSince,
+		ComputedAt:    time.Now(),
+	}
+
+	// Count local users grouped by state, where
+	// each user is counted only once, in order of
+	// precedence: suspended, disabled, pending,
+	// unconfirmed, and finally active.
+	var userRows []struct {
+		State string
+		Count int
+	}
+
+	if err := i.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("users"), bun.Ident("user")).
+		Join("JOIN ? AS ? ON ? = ?",
+			bun.Ident("accounts"), bun.Ident("account"),
+			bun.Ident("account.id"), bun.Ident("user.account_id"),
+		).
+		ColumnExpr("CASE"+
+			" WHEN ? IS NOT NULL THEN 'suspended'"+
+			" WHEN ? = ? THEN 'disabled'"+
+			" WHEN ? = ? THEN 'pending'"+
+			" WHEN ? IS NULL THEN 'unconfirmed'"+
+			" ELSE 'active' END AS ?",
+			bun.Ident("account.suspended_at"),
+			bun.Ident("user.disabled"), true,
+			bun.Ident("user.approved"), false,
+			bun.Ident("user.confirmed_at"),
+			bun.Ident("state"),
+		).
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("count")).
+		GroupExpr("?", bun.Ident("state")).
+		Scan(ctx, &userRows); err != nil {
+		return nil, gtserror.Newf("error counting users: %w", err)
+	}
+
+	for _, row := range userRows {
+		switch row.State {
+		case "suspended":
+			stats.UsersSuspended = row.Count
+		case "disabled":
+			stats.UsersDisabled = row.Count
+		case "pending":
+			stats.UsersPending = row.Count
+		case "unconfirmed":
+			stats.UsersUnconfirmed = row.Count
+		case "active":
+			stats.UsersActive = row.Count
+		}
+	}
+
+	// Count local statuses grouped by day of creation.
+	var daySQL string
+	switch i.db.Dialect().Name() {
+	case dialect.SQLite:
+		daySQL = "DATE(?)"
+	case dialect.PG:
+		daySQL = "TO_CHAR(? AT TIME ZONE 'UTC', 'YYYY-MM-DD')"
+	default:
+		log.Panicf(ctx, "db conn %s was neither pg nor sqlite", i.db)
+	}
+
+	var statusRows []struct {
+		Day   string
+		Count int
+	}
+
+	if err := i.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		ColumnExpr(daySQL+" AS ?", bun.Ident("status.created_at"), bun.Ident("day")).
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("count")).
+		Where("? = ?", bun.Ident("status.local"), true).
+		Where("? >= ?", bun.Ident("status.created_at"), statusesSince).
+		GroupExpr("?", bun.Ident("day")).
+		Scan(ctx, &statusRows); err != nil {
+		return nil, gtserror.Newf("error counting statuses: %w", err)
+	}
+
+	stats.StatusesPerDay = make(map[string]int, len(statusRows))
+	for _, row := range statusRows {
+		stats.StatusesPerDay[row.Day] = row.Count
+	}
+
+	// Sum cached media storage, grouped by whether
+	// the media is local (no remote URL) or remote.
+	var mediaRows []struct {
+		Origin string
+		Count  int
+		Size   int64
+	}
+
+	if err := i.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("media_attachments"), bun.Ident("media_attachment")).
+		ColumnExpr("CASE WHEN ? IS NULL THEN 'local' ELSE 'remote' END AS ?",
+			bun.Ident("media_attachment.remote_url"),
+			bun.Ident("origin"),
+		).
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("count")).
+		ColumnExpr("COALESCE(SUM(? + ?), 0) AS ?",
+			bun.Ident("media_attachment.file_file_size"),
+			bun.Ident("media_attachment.thumbnail_file_size"),
+			bun.Ident("size"),
+		).
+		Where("? = ?", bun.Ident("media_attachment.cached"), true).
+		GroupExpr("?", bun.Ident("origin")).
+		Scan(ctx, &mediaRows); err != nil {
+		return nil, gtserror.Newf("error summing media: %w", err)
+	}
+
+	for _, row := range mediaRows {
+		switch row.Origin {
+		case "local":
+			stats.LocalMediaCount = row.Count
+			stats.LocalMediaStorage = row.Size
+		case "remote":
+			stats.RemoteMediaCount = row.Count
+			stats.RemoteMediaStorage = row.Size
+		}
+	}
+
+	// Count known peers, and how many of them are suspended.
+	if err := i.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("instances"), bun.Ident("instance")).
+		ColumnExpr("COUNT(*)").
+		ColumnExpr("COALESCE(SUM(CASE WHEN ? IS NOT NULL THEN 1 ELSE 0 END), 0)",
+			bun.Ident("instance.suspended_at"),
+		).
+		Where("? != ?", bun.Ident("instance.domain"), config.GetHost()).
+		Scan(ctx, &stats.PeersTotal, &stats.PeersSuspended); err != nil {
+		return nil, gtserror.Newf("error counting peers: %w", err)
+	}
+
+	return stats, nil
+}
@@ -20,6 +20,7 @@ package bundb_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
@@ -62,6 +63,66 @@ func (suite *InstanceTestSuite) TestCountInstanceDomains() {
 	suite.Equal(2, count)
 }
 
+func (suite *InstanceTestSuite) TestGetInstanceStats() {
+	stats, err := suite.db.GetInstanceStats(context.Background(), time.Time{})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Work out expected user counts
+	// using the same order of precedence.
+	var expect gtsmodel.InstanceStats
+	for key, user := range suite.testUsers {
+		account := suite.testAccounts[key]
+		switch {
+		case !account.SuspendedAt.IsZero():
+			expect.UsersSuspended++
+		case *user.Disabled:
+			expect.UsersDisabled++
+		case !*user.Approved:
+			expect.UsersPending++
+		case user.ConfirmedAt.IsZero():
+			expect.UsersUnconfirmed++
+		default:
+			expect.UsersActive++
+		}
+	}
+	suite.Equal(expect.UsersActive, stats.UsersActive)
+	suite.Equal(expect.UsersPending, stats.UsersPending)
+	suite.Equal(expect.UsersUnconfirmed, stats.UsersUnconfirmed)
+	suite.Equal(expect.UsersDisabled, stats.UsersDisabled)
+	suite.Equal(expect.UsersSuspended, stats.UsersSuspended)
+
+	// Statuses per day should add up to all local statuses.
+	var statuses int
+	for _, count := range stats.StatusesPerDay {
+		statuses += count
+	}
+	suite.Equal(19, statuses)
+
+	for _, attachment := range suite.testAttachments {
+		if !*attachment.Cached {
+			continue
+		}
+
+		size := int64(attachment.File.FileSize + attachment.Thumbnail.FileSize)
+		if attachment.RemoteURL == "" {
+			expect.LocalMediaCount++
+			expect.LocalMediaStorage += size
+		} else {
+			expect.RemoteMediaCount++
+			expect.RemoteMediaStorage += size
+		}
+	}
+	suite.Equal(expect.LocalMediaCount, stats.LocalMediaCount)
+	suite.Equal(expect.LocalMediaStorage, stats.LocalMediaStorage)
+	suite.Equal(expect.RemoteMediaCount, stats.RemoteMediaCount)
+	suite.Equal(expect.RemoteMediaStorage, stats.RemoteMediaStorage)
+
+	suite.Equal(2, stats.PeersTotal)
+	suite.Zero(stats.PeersSuspended)
+}
+
 func (suite *InstanceTestSuite) TestGetInstanceOK() {
 	instance, err := suite.db.GetInstance(context.Background(), "localhost:8080")
 	suite.NoError(err)
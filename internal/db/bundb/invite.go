@@ -0,0 +1,179 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type inviteDB struct {
+	db    *WrappedDB
+	state *state.State
+}
+
+func (i *inviteDB) GetInviteByID(ctx context.Context, id string) (*gtsmodel.Invite, error) {
+	return i.getInvite(ctx, "id", id)
+}
+
+func (i *inviteDB) GetInviteByCode(ctx context.Context, code string) (*gtsmodel.Invite, error) {
+	return i.getInvite(ctx, "code", code)
+}
+
+func (i *inviteDB) getInvite(ctx context.Context, column string, value any) (*gtsmodel.Invite, error) {
+	var invite gtsmodel.Invite
+
+	if err := i.db.
+		NewSelect().
+		Model(&invite).
+		Where("? = ?", bun.Ident("invite."+column), value).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := i.populateInvite(ctx, &invite); err != nil {
+		return nil, err
+	}
+
+	return &invite, nil
+}
+
+func (i *inviteDB) GetInvites(ctx context.Context, accountID string, outstandingOnly bool) ([]*gtsmodel.Invite, error) {
+	invites := []*gtsmodel.Invite{}
+
+	q := i.db.
+		NewSelect().
+		Model(&invites).
+		Order("invite.id DESC")
+
+	if accountID != "" {
+		q = q.Where("? = ?", bun.Ident("invite.account_id"), accountID)
+	}
+
+	if outstandingOnly {
+		// Only select invites which
+		// are still usable right now.
+		now := time.Now()
+		q = q.
+			Where("? IS NULL", bun.Ident("invite.revoked_at")).
+			Where("(? IS NULL OR ? > ?)", bun.Ident("invite.expires_at"), bun.Ident("invite.expires_at"), now).
+			Where("(? = 0 OR ? < ?)", bun.Ident("invite.max_uses"), bun.Ident("invite.uses"), bun.Ident("invite.max_uses"))
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	errs := gtserror.NewMultiError(len(invites))
+	for _, invite := range invites {
+		if err := i.populateInvite(ctx, invite); err != nil {
+			errs.Append(err)
+		}
+	}
+
+	if err := errs.Combine(); err != nil {
+		return nil, err
+	}
+
+	return invites, nil
+}
+
+func (i *inviteDB) populateInvite(ctx context.Context, invite *gtsmodel.Invite) error {
+	if gtscontext.Barebones(ctx) || invite.Account != nil {
+		// Nothing to do.
+		return nil
+	}
+
+	var err error
+	invite.Account, err = i.state.DB.GetAccountByID(
+		gtscontext.SetBarebones(ctx),
+		invite.AccountID,
+	)
+	if err != nil {
+		return gtserror.Newf("error populating invite account: %w", err)
+	}
+
+	return nil
+}
+
+func (i *inviteDB) PutInvite(ctx context.Context, invite *gtsmodel.Invite) error {
+	_, err := i.db.
+		NewInsert().
+		Model(invite).
+		Exec(ctx)
+	return err
+}
+
+func (i *inviteDB) UpdateInvite(ctx context.Context, invite *gtsmodel.Invite, columns ...string) error {
+	invite.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := i.db.
+		NewUpdate().
+		Model(invite).
+		Column(columns...).
+		Where("? = ?", bun.Ident("invite.id"), invite.ID).
+		Exec(ctx)
+	return err
+}
+
+func (i *inviteDB) UseInvite(ctx context.Context, id string) error {
+	now := time.Now()
+
+	// Increment uses in the same statement
+	// that checks usability, so that two
+	// concurrent sign-ups can't both take
+	// the last remaining use of an invite.
+	res, err := i.db.
+		NewUpdate().
+		Model((*gtsmodel.Invite)(nil)).
+		Set("? = ? + 1", bun.Ident("uses"), bun.Ident("uses")).
+		Set("? = ?", bun.Ident("updated_at"), now).
+		Where("? = ?", bun.Ident("invite.id"), id).
+		Where("? IS NULL", bun.Ident("invite.revoked_at")).
+		Where("(? IS NULL OR ? > ?)", bun.Ident("invite.expires_at"), bun.Ident("invite.expires_at"), now).
+		Where("(? = 0 OR ? < ?)", bun.Ident("invite.max_uses"), bun.Ident("invite.uses"), bun.Ident("invite.max_uses")).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		// Invite doesn't exist
+		// or is no longer usable.
+		return db.ErrNoEntries
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+type InviteTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *InviteTestSuite) putInvite(code string, maxUses int, expiresAt time.Time) *gtsmodel.Invite {
+	invite := &gtsmodel.Invite{
+		ID:        id.NewULID(),
+		AccountID: suite.testAccounts["admin_account"].ID,
+		Code:      code,
+		MaxUses:   maxUses,
+		ExpiresAt: expiresAt,
+	}
+
+	if err := suite.db.PutInvite(context.Background(), invite); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return invite
+}
+
+func (suite *InviteTestSuite) TestUseInvite() {
+	ctx := context.Background()
+	invite := suite.putInvite("abcdefgh", 2, time.Time{})
+
+	// Two uses should be fine.
+	for i := 0; i < 2; i++ {
+		if err := suite.db.UseInvite(ctx, invite.ID); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	// Third should be refused.
+	err := suite.db.UseInvite(ctx, invite.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	dbInvite, err := suite.db.GetInviteByCode(ctx, "abcdefgh")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(2, dbInvite.Uses)
+	suite.False(dbInvite.Usable())
+	suite.NotNil(dbInvite.Account)
+}
+
+func (suite *InviteTestSuite) TestGetInvitesOutstanding() {
+	ctx := context.Background()
+
+	usable := suite.putInvite("usable", 0, time.Now().Add(time.Hour))
+	suite.putInvite("expired", 0, time.Now().Add(-time.Hour))
+	revoked := suite.putInvite("revoked", 0, time.Time{})
+
+	revoked.RevokedAt = time.Now()
+	if err := suite.db.UpdateInvite(ctx, revoked, "revoked_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	outstanding, err := suite.db.GetInvites(ctx, "", true)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if suite.Len(outstanding, 1) {
+		suite.Equal(usable.ID, outstanding[0].ID)
+	}
+
+	all, err := suite.db.GetInvites(ctx, usable.AccountID, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(all, 3)
+
+	none, err := suite.db.GetInvites(ctx, suite.testAccounts["local_account_1"].ID, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(none)
+}
+
+func TestInviteTestSuite(t *testing.T) {
+	suite.Run(t, new(InviteTestSuite))
+}
@@ -35,7 +35,7 @@ import (
 )
 
 type listDB struct {
-	db    *bun.DB
+	db    *WrappedDB
 	state *state.State
 }
 
@@ -30,7 +30,7 @@ import (
 )
 
 type markerDB struct {
-	db    *bun.DB
+	db    *WrappedDB
 	state *state.State
 }
 
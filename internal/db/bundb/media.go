@@ -34,7 +34,7 @@ import (
 )
 
 type mediaDB struct {
-	db    *bun.DB
+	db    *WrappedDB
 	state *state.State
 }
 
@@ -295,3 +295,68 @@ func (m *mediaDB) GetCachedAttachmentsOlderThan(ctx context.Context, olderThan t
 
 	return m.GetAttachmentsByIDs(ctx, attachmentIDs)
 }
+
+func (m *mediaDB) GetAccountAttachmentsUsage(ctx context.Context, accountID string) (int, int64, error) {
+	var (
+		count int
+		size  int64
+	)
+
+	if err := m.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("media_attachments"), bun.Ident("media_attachment")).
+		ColumnExpr("COUNT(*)").
+		ColumnExpr("COALESCE(SUM(? + ?), 0)",
+			bun.Ident("media_attachment.file_file_size"),
+			bun.Ident("media_attachment.thumbnail_file_size"),
+		).
+		Where("? = ?", bun.Ident("media_attachment.account_id"), accountID).
+		Where("? = ?", bun.Ident("media_attachment.cached"), true).
+		Scan(ctx, &count, &size); err != nil {
+		return 0, 0, err
+	}
+
+	return count, size, nil
+}
+
+func (m *mediaDB) GetAccountAttachments(ctx context.Context, accountID string) ([]*gtsmodel.MediaAttachment, error) {
+	var attachmentIDs []string
+
+	if err := m.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("media_attachments"), bun.Ident("media_attachment")).
+		Column("media_attachment.id").
+		Where("? = ?", bun.Ident("media_attachment.account_id"), accountID).
+		Where("? = ?", bun.Ident("media_attachment.cached"), true).
+		Order("media_attachment.id ASC").
+		Scan(ctx, &attachmentIDs); err != nil {
+		return nil, err
+	}
+
+	if len(attachmentIDs) == 0 {
+		return nil, nil
+	}
+
+	return m.GetAttachmentsByIDs(ctx, attachmentIDs)
+}
+
+func (m *mediaDB) GetLocalAttachmentsProcessing(ctx context.Context) ([]*gtsmodel.MediaAttachment, error) {
+	var attachmentIDs []string
+
+	if err := m.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("media_attachments"), bun.Ident("media_attachment")).
+		Column("media_attachment.id").
+		Where("? IS NULL", bun.Ident("media_attachment.remote_url")).
+		Where("? = ?", bun.Ident("media_attachment.processing"), gtsmodel.ProcessingStatusProcessing).
+		Order("media_attachment.id ASC").
+		Scan(ctx, &attachmentIDs); err != nil {
+		return nil, err
+	}
+
+	if len(attachmentIDs) == 0 {
+		return nil, nil
+	}
+
+	return m.GetAttachmentsByIDs(ctx, attachmentIDs)
+}
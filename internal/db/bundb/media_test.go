@@ -19,6 +19,7 @@ package bundb_test
 
 import (
 	"context"
+	"slices"
 	"testing"
 	"time"
 
@@ -50,6 +51,48 @@ func (suite *MediaTestSuite) TestGetCachedAttachmentsOlderThan() {
 	suite.Len(attachments, 3)
 }
 
+func (suite *MediaTestSuite) TestGetAccountAttachmentsUsage() {
+	accountID := suite.testAccounts["local_account_1"].ID
+
+	var (
+		expectCount int
+		expectSize  int64
+	)
+	for _, attachment := range suite.testAttachments {
+		if attachment.AccountID == accountID && *attachment.Cached {
+			expectCount++
+			expectSize += int64(attachment.File.FileSize + attachment.Thumbnail.FileSize)
+		}
+	}
+
+	count, size, err := suite.db.GetAccountAttachmentsUsage(context.Background(), accountID)
+	suite.NoError(err)
+	suite.NotZero(count)
+	suite.Equal(expectCount, count)
+	suite.Equal(expectSize, size)
+}
+
+func (suite *MediaTestSuite) TestGetAccountAttachments() {
+	accountID := suite.testAccounts["local_account_1"].ID
+
+	var expectIDs []string
+	for _, attachment := range suite.testAttachments {
+		if attachment.AccountID == accountID && *attachment.Cached {
+			expectIDs = append(expectIDs, attachment.ID)
+		}
+	}
+	slices.Sort(expectIDs)
+
+	attachments, err := suite.db.GetAccountAttachments(context.Background(), accountID)
+	suite.NoError(err)
+
+	ids := make([]string, 0, len(attachments))
+	for _, attachment := range attachments {
+		ids = append(ids, attachment.ID)
+	}
+	suite.Equal(expectIDs, ids)
+}
+
 func TestMediaTestSuite(t *testing.T) {
 	suite.Run(t, new(MediaTestSuite))
 }
@@ -0,0 +1,110 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type mediaUploadDB struct {
+	db    *WrappedDB
+	state *state.State
+}
+
+func (m *mediaUploadDB) GetMediaUploadByID(ctx context.Context, id string) (*gtsmodel.MediaUpload, error) {
+	upload := new(gtsmodel.MediaUpload)
+
+	if err := m.db.
+		NewSelect().
+		Model(upload).
+		Where("? = ?", bun.Ident("media_upload.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return upload, nil
+}
+
+func (m *mediaUploadDB) GetAccountMediaUploads(ctx context.Context, accountID string) ([]*gtsmodel.MediaUpload, error) {
+	var uploads []*gtsmodel.MediaUpload
+
+	if err := m.db.
+		NewSelect().
+		Model(&uploads).
+		Where("? = ?", bun.Ident("media_upload.account_id"), accountID).
+		Order("media_upload.id ASC").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return uploads, nil
+}
+
+func (m *mediaUploadDB) GetMediaUploadsExpiredBefore(ctx context.Context, t time.Time) ([]*gtsmodel.MediaUpload, error) {
+	var uploads []*gtsmodel.MediaUpload
+
+	if err := m.db.
+		NewSelect().
+		Model(&uploads).
+		Where("? < ?", bun.Ident("media_upload.expires_at"), t).
+		Order("media_upload.id ASC").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return uploads, nil
+}
+
+func (m *mediaUploadDB) PutMediaUpload(ctx context.Context, upload *gtsmodel.MediaUpload) error {
+	_, err := m.db.
+		NewInsert().
+		Model(upload).
+		Exec(ctx)
+	return err
+}
+
+func (m *mediaUploadDB) UpdateMediaUpload(ctx context.Context, upload *gtsmodel.MediaUpload, columns ...string) error {
+	upload.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := m.db.
+		NewUpdate().
+		Model(upload).
+		Column(columns...).
+		Where("? = ?", bun.Ident("media_upload.id"), upload.ID).
+		Exec(ctx)
+	return err
+}
+
+func (m *mediaUploadDB) DeleteMediaUploadByID(ctx context.Context, id string) error {
+	_, err := m.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("media_uploads"), bun.Ident("media_upload")).
+		Where("? = ?", bun.Ident("media_upload.id"), id).
+		Exec(ctx)
+	return err
+}
@@ -33,7 +33,7 @@ import (
 )
 
 type mentionDB struct {
-	db    *bun.DB
+	db    *WrappedDB
 	state *state.State
 }
 
@@ -152,6 +152,10 @@ func (m *mentionDB) PopulateMention(ctx context.Context, mention *gtsmodel.Menti
 }
 
 func (m *mentionDB) PutMention(ctx context.Context, mention *gtsmodel.Mention) error {
+	m.db.onRollback(func() {
+		m.state.Caches.GTS.Mention.Invalidate("ID", mention.ID)
+	})
+
 	return m.state.Caches.GTS.Mention.Store(mention, func() error {
 		_, err := m.db.NewInsert().Model(mention).Exec(ctx)
 		return err
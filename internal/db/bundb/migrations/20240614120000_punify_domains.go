@@ -0,0 +1,162 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// Tables containing a domain column that
+			// should always be stored normalized as
+			// lowercase punycode. Accounts and instances
+			// are handled separately below, since they
+			// have uniqueness constraints on domain.
+			for _, table := range []string{
+				"domain_blocks",
+				"domain_allows",
+				"domain_pauses",
+				"email_domain_blocks",
+			} {
+				domains, err := nonPunyDomains(ctx, tx, table)
+				if err != nil {
+					return err
+				}
+
+				for domain, punyDomain := range domains {
+					if _, err := tx.
+						NewUpdate().
+						Table(table).
+						Set("? = ?", bun.Ident("domain"), punyDomain).
+						Where("? = ?", bun.Ident("domain"), domain).
+						Exec(ctx); err != nil {
+						return err
+					}
+				}
+			}
+
+			// Normalize account domains, taking care not to
+			// clash with an account already stored under the
+			// normalized username / domain combination.
+			domains, err := nonPunyDomains(ctx, tx, "accounts")
+			if err != nil {
+				return err
+			}
+
+			for domain, punyDomain := range domains {
+				log.Infof(ctx, "normalizing account domain %s to %s", domain, punyDomain)
+
+				if _, err := tx.
+					NewUpdate().
+					Table("accounts").
+					Set("? = ?", bun.Ident("domain"), punyDomain).
+					Where("? = ?", bun.Ident("domain"), domain).
+					Where("? NOT IN (?)", bun.Ident("username"), tx.
+						NewSelect().
+						Table("accounts").
+						Column("username").
+						Where("? = ?", bun.Ident("domain"), punyDomain),
+					).
+					Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			// Normalize instance domains, skipping
+			// any which already exist in normalized form.
+			domains, err = nonPunyDomains(ctx, tx, "instances")
+			if err != nil {
+				return err
+			}
+
+			for domain, punyDomain := range domains {
+				exists, err := tx.
+					NewSelect().
+					Table("instances").
+					Where("? = ?", bun.Ident("domain"), punyDomain).
+					Exists(ctx)
+				if err != nil {
+					return err
+				}
+
+				if exists {
+					log.Warnf(ctx, "instance %s already exists as %s, skipping", domain, punyDomain)
+					continue
+				}
+
+				if _, err := tx.
+					NewUpdate().
+					Table("instances").
+					Set("? = ?", bun.Ident("domain"), punyDomain).
+					Where("? = ?", bun.Ident("domain"), domain).
+					Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
+
+// nonPunyDomains returns a map of all distinct domains in the given
+// table which are not already stored in normalized punycode form,
+// to their normalized form. Domains which cannot be punified are skipped.
+func nonPunyDomains(ctx context.Context, tx bun.Tx, table string) (map[string]string, error) {
+	var domains []string
+	if err := tx.
+		NewSelect().
+		Table(table).
+		Column("domain").
+		Distinct().
+		Where("? IS NOT NULL", bun.Ident("domain")).
+		Scan(ctx, &domains); err != nil {
+		return nil, err
+	}
+
+	nonPuny := make(map[string]string)
+	for _, domain := range domains {
+		punyDomain, err := util.Punify(domain)
+		if err != nil {
+			log.Warnf(ctx, "skipping unnormalizable domain %s in %s: %v", domain, table, err)
+			continue
+		}
+
+		if punyDomain != domain {
+			nonPuny[domain] = punyDomain
+		}
+	}
+
+	return nonPuny, nil
+}
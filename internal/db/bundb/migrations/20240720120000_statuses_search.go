@@ -0,0 +1,160 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+)
+
+// Adds a full-text search index of statuses.
+//
+// Rather than indexing the HTML in statuses.content,
+// statuses_search keeps a plaintext copy of each status'
+// content and content warning, kept up to date by bundb,
+// and the index is built over that. On SQLite its explicit
+// integer primary key keys the FTS5 table, which VACUUM
+// leaves as-is, unlike an implicit rowid.
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			log.Info(ctx, "building full-text search index for statuses, please wait and don't interrupt it (this may take a few minutes)")
+
+			var stmts []string
+			switch tx.Dialect().Name() {
+			case dialect.SQLite:
+				stmts = []string{
+					`CREATE TABLE IF NOT EXISTS "statuses_search" ("id" INTEGER PRIMARY KEY, "status_id" CHAR(26) NOT NULL UNIQUE, "text" TEXT NOT NULL)`,
+
+					// External content FTS5 table over
+					// statuses_search, keyed on its id.
+					`CREATE VIRTUAL TABLE IF NOT EXISTS "statuses_search_fts" USING fts5("text", content='statuses_search', content_rowid='id')`,
+
+					// Triggers to keep the FTS5 table in
+					// sync with inserts, deletes + updates.
+					`CREATE TRIGGER IF NOT EXISTS "statuses_search_insert" AFTER INSERT ON "statuses_search" BEGIN
+						INSERT INTO "statuses_search_fts" ("rowid", "text") VALUES (new."id", new."text");
+					END`,
+					`CREATE TRIGGER IF NOT EXISTS "statuses_search_delete" AFTER DELETE ON "statuses_search" BEGIN
+						INSERT INTO "statuses_search_fts" ("statuses_search_fts", "rowid", "text") VALUES ('delete', old."id", old."text");
+					END`,
+					`CREATE TRIGGER IF NOT EXISTS "statuses_search_update" AFTER UPDATE ON "statuses_search" BEGIN
+						INSERT INTO "statuses_search_fts" ("statuses_search_fts", "rowid", "text") VALUES ('delete', old."id", old."text");
+						INSERT INTO "statuses_search_fts" ("rowid", "text") VALUES (new."id", new."text");
+					END`,
+				}
+
+			case dialect.PG:
+				stmts = []string{
+					`CREATE TABLE IF NOT EXISTS "statuses_search" ("status_id" CHAR(26) NOT NULL PRIMARY KEY, "text" TEXT NOT NULL)`,
+
+					// GIN index over the same tsvector
+					// expression used when searching.
+					`CREATE INDEX IF NOT EXISTS "statuses_search_idx" ON "statuses_search" USING GIN (to_tsvector('simple', "text"))`,
+				}
+			}
+
+			for _, stmt := range stmts {
+				if _, err := tx.ExecContext(ctx, stmt); err != nil {
+					return err
+				}
+			}
+
+			// Fill in the search text of existing statuses,
+			// a batch at a time in order of (descending) ID.
+			const batchSize = 500
+			maxID := "ZZZZZZZZZZZZZZZZZZZZZZZZZZ"
+
+			for {
+				var batch []struct {
+					ID             string `bun:"id"`
+					Content        string `bun:"content"`
+					ContentWarning string `bun:"content_warning"`
+				}
+
+				if err := tx.NewSelect().
+					Table("statuses").
+					Column("id", "content", "content_warning").
+					Where("? IS NULL", bun.Ident("boost_of_id")).
+					Where("? < ?", bun.Ident("id"), maxID).
+					Order("id DESC").
+					Limit(batchSize).
+					Scan(ctx, &batch); err != nil {
+					return err
+				}
+
+				if len(batch) == 0 {
+					break
+				}
+
+				for _, status := range batch {
+					if _, err := tx.ExecContext(ctx,
+						"INSERT INTO ? (?, ?) VALUES (?, ?) ON CONFLICT (?) DO NOTHING",
+						bun.Ident("statuses_search"), bun.Ident("status_id"), bun.Ident("text"),
+						status.ID, text.SearchText(status.Content, status.ContentWarning),
+						bun.Ident("status_id"),
+					); err != nil {
+						return err
+					}
+				}
+
+				maxID = batch[len(batch)-1].ID
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			var stmts []string
+			switch tx.Dialect().Name() {
+			case dialect.SQLite:
+				stmts = []string{
+					`DROP TRIGGER IF EXISTS "statuses_search_insert"`,
+					`DROP TRIGGER IF EXISTS "statuses_search_delete"`,
+					`DROP TRIGGER IF EXISTS "statuses_search_update"`,
+					`DROP TABLE IF EXISTS "statuses_search_fts"`,
+					`DROP TABLE IF EXISTS "statuses_search"`,
+				}
+
+			case dialect.PG:
+				stmts = []string{
+					`DROP INDEX IF EXISTS "statuses_search_idx"`,
+					`DROP TABLE IF EXISTS "statuses_search"`,
+				}
+			}
+
+			for _, stmt := range stmts {
+				if _, err := tx.ExecContext(ctx, stmt); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
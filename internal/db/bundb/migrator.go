@@ -0,0 +1,163 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
+)
+
+// EarliestReversibleMigration is the name of the earliest
+// migration that can be rolled back. Migrations before it
+// have no down migration, so rolling back never goes past it.
+const EarliestReversibleMigration = "20240603120000"
+
+// ErrIrreversible is returned when rolling back would have
+// to go past the earliest reversible migration.
+var ErrIrreversible = errors.New("migrations before " + EarliestReversibleMigration + " cannot be rolled back")
+
+// Migrator provides admin tooling with access to the
+// database migrations, for checking which have been
+// applied, and applying or rolling back migrations.
+//
+// Migrations are applied and rolled back in groups:
+// each run of Up() applies all pending migrations as
+// one group (as happens on server startup), and each
+// run of Down() rolls back the most recent group, as
+// far back as the earliest reversible migration.
+type Migrator struct {
+	db       *bun.DB
+	migrator *migrate.Migrator
+}
+
+// NewMigrator returns a new Migrator for the configured database.
+// Unlike NewBunDBService(), this does not run pending migrations.
+func NewMigrator(ctx context.Context) (*Migrator, error) {
+	db, err := bunDBConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	migrator := newMigrator(db)
+
+	// Ensure migration tables exist.
+	if err := migrator.Init(ctx); err != nil {
+		_ = db.Close()
+		return nil, gtserror.Newf("error initializing migrations: %w", err)
+	}
+
+	return &Migrator{
+		db:       db,
+		migrator: migrator,
+	}, nil
+}
+
+// Status returns all known migrations in
+// order, with their applied group (if any).
+func (m *Migrator) Status(ctx context.Context) (migrate.MigrationSlice, error) {
+	return m.migrator.MigrationsWithStatus(ctx)
+}
+
+// Up applies all pending migrations as a new migration
+// group, returning it. If there are no pending migrations
+// the returned group will be empty. On failure, the group
+// contains migrations applied so far, the last of which
+// failed and is not recorded as applied.
+func (m *Migrator) Up(ctx context.Context) (*migrate.MigrationGroup, error) {
+	group, err := m.migrator.Migrate(ctx)
+	if err != nil {
+		return group, err
+	}
+
+	if !group.IsZero() {
+		analyze(ctx, m.db)
+	}
+
+	return group, nil
+}
+
+// Down rolls back the most recently applied migration
+// group, returning the migrations rolled back. If no
+// migrations have been applied the returned group will
+// be empty. Only those migrations in the group from the
+// earliest reversible migration on are rolled back, and
+// if there are none of those ErrIrreversible is returned.
+// On failure, the migration that failed and those before
+// it are still recorded as applied, so Down can be retried.
+func (m *Migrator) Down(ctx context.Context) (*migrate.MigrationGroup, error) {
+	ms, err := m.migrator.MigrationsWithStatus(ctx)
+	if err != nil {
+		return nil, gtserror.Newf("error getting migrations: %w", err)
+	}
+
+	lastGroup := ms.LastGroup()
+	if lastGroup.IsZero() {
+		// Nothing applied.
+		return lastGroup, nil
+	}
+
+	// Only roll back the reversible part of the group, eg.,
+	// on a database created before down migrations existed,
+	// or created from scratch in a single group.
+	group := &migrate.MigrationGroup{ID: lastGroup.ID}
+	for _, migration := range lastGroup.Migrations {
+		if migration.Name >= EarliestReversibleMigration {
+			group.Migrations = append(group.Migrations, migration)
+		}
+	}
+
+	if len(group.Migrations) == 0 {
+		return group, ErrIrreversible
+	}
+
+	// Roll back in reverse order, marking each as unapplied
+	// only once successful, as with applying migrations.
+	for i := len(group.Migrations) - 1; i >= 0; i-- {
+		migration := &group.Migrations[i]
+
+		if migration.Down != nil {
+			if err := migration.Down(ctx, m.db); err != nil {
+				return group, gtserror.Newf("error rolling back %s: %w", migration, err)
+			}
+		}
+
+		if err := m.migrator.MarkUnapplied(ctx, migration); err != nil {
+			return group, gtserror.Newf("error marking %s unapplied: %w", migration, err)
+		}
+	}
+
+	return group, nil
+}
+
+// Redo rolls back the most recently applied migration
+// group, then applies all pending migrations again.
+func (m *Migrator) Redo(ctx context.Context) (*migrate.MigrationGroup, error) {
+	if _, err := m.Down(ctx); err != nil {
+		return nil, gtserror.Newf("error rolling back: %w", err)
+	}
+	return m.Up(ctx)
+}
+
+// Close closes the underlying database connection.
+func (m *Migrator) Close() error {
+	return m.db.Close()
+}
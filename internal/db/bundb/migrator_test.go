@@ -0,0 +1,134 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+	"github.com/uptrace/bun/migrate"
+)
+
+type MigratorTestSuite struct {
+	suite.Suite
+}
+
+func (suite *MigratorTestSuite) SetupTest() {
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+
+	if config.GetDbType() != "sqlite" {
+		suite.T().Skip("migrator tests run against a fresh sqlite database")
+	}
+
+	// Start from an empty database on disk, so that
+	// each connection of the migrator shares it.
+	config.SetDbAddress(filepath.Join(suite.T().TempDir(), "sqlite.db"))
+}
+
+func (suite *MigratorTestSuite) TestUpDownUp() {
+	ctx := context.Background()
+
+	m, err := bundb.NewMigrator(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	defer m.Close()
+
+	// Everything is applied in one group.
+	group, err := m.Up(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	all := group.Migrations
+	suite.NotEmpty(all)
+	suite.Empty(suite.status(m).Unapplied())
+
+	// Only the reversible migrations are rolled back.
+	group, err = m.Down(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	var reversible int
+	for _, migration := range all {
+		if migration.Name >= bundb.EarliestReversibleMigration {
+			reversible++
+		}
+	}
+	suite.Len(group.Migrations, reversible)
+
+	for _, migration := range suite.status(m) {
+		suite.Equal(
+			migration.Name < bundb.EarliestReversibleMigration,
+			migration.IsApplied(),
+			migration.Name,
+		)
+	}
+
+	// Going any further back is refused.
+	_, err = m.Down(ctx)
+	suite.ErrorIs(err, bundb.ErrIrreversible)
+	suite.Len(suite.status(m).Unapplied(), reversible)
+
+	// Everything can be applied again, in a new group.
+	group, err = m.Up(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(group.Migrations, reversible)
+	suite.Empty(suite.status(m).Unapplied())
+
+	// Redo rolls back and reapplies the new group.
+	group, err = m.Redo(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(group.Migrations, reversible)
+	suite.Empty(suite.status(m).Unapplied())
+}
+
+func (suite *MigratorTestSuite) TestDownNothingApplied() {
+	ctx := context.Background()
+
+	m, err := bundb.NewMigrator(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	defer m.Close()
+
+	group, err := m.Down(ctx)
+	suite.NoError(err)
+	suite.True(group.IsZero())
+}
+
+func (suite *MigratorTestSuite) status(m *bundb.Migrator) migrate.MigrationSlice {
+	ms, err := m.Status(context.Background())
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	return ms
+}
+
+func TestMigratorTestSuite(t *testing.T) {
+	suite.Run(t, new(MigratorTestSuite))
+}
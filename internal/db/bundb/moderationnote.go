@@ -0,0 +1,142 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type moderationNoteDB struct {
+	db    *WrappedDB
+	state *state.State
+}
+
+func (m *moderationNoteDB) GetModerationNoteByID(ctx context.Context, id string) (*gtsmodel.ModerationNote, error) {
+	var note gtsmodel.ModerationNote
+
+	if err := m.db.
+		NewSelect().
+		Model(&note).
+		Where("? = ?", bun.Ident("moderation_note.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := m.populateModerationNote(ctx, &note); err != nil {
+		return nil, err
+	}
+
+	return &note, nil
+}
+
+func (m *moderationNoteDB) GetModerationNotes(ctx context.Context, targetAccountID string, reportID string) ([]*gtsmodel.ModerationNote, error) {
+	notes := []*gtsmodel.ModerationNote{}
+
+	q := m.db.
+		NewSelect().
+		Model(&notes).
+		Order("moderation_note.id ASC")
+
+	if targetAccountID != "" {
+		q = q.Where("? = ?", bun.Ident("moderation_note.target_account_id"), targetAccountID)
+	}
+
+	if reportID != "" {
+		q = q.Where("? = ?", bun.Ident("moderation_note.report_id"), reportID)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	errs := gtserror.NewMultiError(len(notes))
+	for _, note := range notes {
+		if err := m.populateModerationNote(ctx, note); err != nil {
+			errs.Append(err)
+		}
+	}
+
+	if err := errs.Combine(); err != nil {
+		return nil, err
+	}
+
+	return notes, nil
+}
+
+func (m *moderationNoteDB) populateModerationNote(ctx context.Context, note *gtsmodel.ModerationNote) error {
+	if gtscontext.Barebones(ctx) {
+		// Nothing to do.
+		return nil
+	}
+
+	var (
+		err  error
+		errs = gtserror.NewMultiError(2)
+	)
+
+	if note.AuthorAccount == nil {
+		// Author account is not set, fetch from the database.
+		note.AuthorAccount, err = m.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			note.AuthorAccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating moderation note author account: %w", err)
+		}
+	}
+
+	if note.TargetAccountID != "" && note.TargetAccount == nil {
+		// Target account is not set, fetch from the database.
+		note.TargetAccount, err = m.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			note.TargetAccountID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			// Target account may have since been
+			// deleted; that's fine, the note stays.
+			errs.Appendf("error populating moderation note target account: %w", err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+func (m *moderationNoteDB) PutModerationNote(ctx context.Context, note *gtsmodel.ModerationNote) error {
+	_, err := m.db.
+		NewInsert().
+		Model(note).
+		Exec(ctx)
+	return err
+}
+
+func (m *moderationNoteDB) DeleteModerationNoteByID(ctx context.Context, id string) error {
+	_, err := m.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("moderation_notes"), bun.Ident("moderation_note")).
+		Where("? = ?", bun.Ident("moderation_note.id"), id).
+		Exec(ctx)
+	return err
+}
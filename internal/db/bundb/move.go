@@ -32,7 +32,7 @@ import (
 )
 
 type moveDB struct {
-	db    *bun.DB
+	db    *WrappedDB
 	state *state.State
 }
 
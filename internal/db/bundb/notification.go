@@ -34,7 +34,7 @@ import (
 )
 
 type notificationDB struct {
-	db    *bun.DB
+	db    *WrappedDB
 	state *state.State
 }
 
@@ -304,6 +304,96 @@ func (n *notificationDB) DeleteNotificationByID(ctx context.Context, id string)
 	return err
 }
 
+func (n *notificationDB) GetNotificationIDsBefore(ctx context.Context, maxID string, onlyRead bool, limit int) ([]string, error) {
+	var notifIDs []string
+
+	q := n.db.
+		NewSelect().
+		Column("id").
+		Table("notifications").
+		Where("? < ?", bun.Ident("id"), maxID)
+
+	if onlyRead {
+		q = q.Where("? = ?", bun.Ident("read"), true)
+	}
+
+	if err := q.
+		Order("id DESC").
+		Limit(limit).
+		Scan(ctx, &notifIDs); err != nil {
+		return nil, err
+	}
+
+	return notifIDs, nil
+}
+
+func (n *notificationDB) GetAccountIDsWithNotificationsOver(ctx context.Context, count int) ([]string, error) {
+	var accountIDs []string
+
+	if err := n.db.
+		NewSelect().
+		Column("target_account_id").
+		Table("notifications").
+		Group("target_account_id").
+		Having("COUNT(*) > ?", count).
+		Scan(ctx, &accountIDs); err != nil {
+		return nil, err
+	}
+
+	return accountIDs, nil
+}
+
+func (n *notificationDB) GetAccountNotificationIDsOver(ctx context.Context, accountID string, offset int, limit int) ([]string, error) {
+	var notifIDs []string
+
+	if err := n.db.
+		NewSelect().
+		Column("id").
+		Table("notifications").
+		Where("? = ?", bun.Ident("target_account_id"), accountID).
+		Order("id DESC").
+		Offset(offset).
+		Limit(limit).
+		Scan(ctx, &notifIDs); err != nil {
+		return nil, err
+	}
+
+	return notifIDs, nil
+}
+
+func (n *notificationDB) MarkNotificationsRead(ctx context.Context, accountID string, maxID string) error {
+	var notifIDs []string
+
+	// Select IDs of unread notifications
+	// first, so we know what to invalidate.
+	if err := n.db.
+		NewSelect().
+		Column("id").
+		Table("notifications").
+		Where("? = ?", bun.Ident("target_account_id"), accountID).
+		Where("? <= ?", bun.Ident("id"), maxID).
+		Where("? = ?", bun.Ident("read"), false).
+		Scan(ctx, &notifIDs); err != nil {
+		return err
+	}
+
+	if len(notifIDs) == 0 {
+		// Nothing to do.
+		return nil
+	}
+
+	// Invalidate all cached notifications by IDs on return.
+	defer n.state.Caches.GTS.Notification.InvalidateIDs("ID", notifIDs)
+
+	_, err := n.db.
+		NewUpdate().
+		Table("notifications").
+		Set("? = ?", bun.Ident("read"), true).
+		Where("? IN (?)", bun.Ident("id"), bun.In(notifIDs)).
+		Exec(ctx)
+	return err
+}
+
 func (n *notificationDB) DeleteNotifications(ctx context.Context, types []string, targetAccountID string, originAccountID string) error {
 	if targetAccountID == "" && originAccountID == "" {
 		return errors.New("DeleteNotifications: one of targetAccountID or originAccountID must be set")
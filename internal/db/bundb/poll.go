@@ -34,7 +34,7 @@ import (
 )
 
 type pollDB struct {
-	db    *bun.DB
+	db    *WrappedDB
 	state *state.State
 }
 
@@ -138,6 +138,10 @@ func (p *pollDB) PopulatePoll(ctx context.Context, poll *gtsmodel.Poll) error {
 }
 
 func (p *pollDB) PutPoll(ctx context.Context, poll *gtsmodel.Poll) error {
+	p.db.onRollback(func() {
+		p.state.Caches.GTS.Poll.Invalidate("ID", poll.ID)
+	})
+
 	// Ensure vote slice
 	// is non nil and set.
 	poll.CheckVotes()
@@ -556,7 +560,7 @@ func (p *pollDB) DeletePollVotesByAccountID(ctx context.Context, accountID strin
 }
 
 // newSelectPollVotes returns a new select query for all rows in the poll_votes table with poll_id = pollID.
-func newSelectPollVotes(db *bun.DB, pollID string) *bun.SelectQuery {
+func newSelectPollVotes(db *WrappedDB, pollID string) *bun.SelectQuery {
 	return db.NewSelect().
 		TableExpr("?", bun.Ident("poll_votes")).
 		ColumnExpr("?", bun.Ident("id")).
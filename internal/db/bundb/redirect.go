@@ -0,0 +1,111 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type redirectDB struct {
+	db    *WrappedDB
+	state *state.State
+}
+
+func (r *redirectDB) GetRedirectByID(ctx context.Context, id string) (*gtsmodel.Redirect, error) {
+	return r.getRedirect(ctx, "id", id)
+}
+
+func (r *redirectDB) GetRedirectByPath(ctx context.Context, path string) (*gtsmodel.Redirect, error) {
+	redirect, err := r.state.Caches.Redirects.Get(path, func() ([]*gtsmodel.Redirect, error) {
+		redirects, err := r.GetRedirects(ctx)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, err
+		}
+		return redirects, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if redirect == nil {
+		return nil, db.ErrNoEntries
+	}
+
+	return redirect, nil
+}
+
+func (r *redirectDB) getRedirect(ctx context.Context, column string, value any) (*gtsmodel.Redirect, error) {
+	redirect := new(gtsmodel.Redirect)
+
+	if err := r.db.
+		NewSelect().
+		Model(redirect).
+		Where("? = ?", bun.Ident("redirect."+column), value).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return redirect, nil
+}
+
+func (r *redirectDB) GetRedirects(ctx context.Context) ([]*gtsmodel.Redirect, error) {
+	var redirects []*gtsmodel.Redirect
+
+	if err := r.db.
+		NewSelect().
+		Model(&redirects).
+		Order("redirect.path ASC").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if len(redirects) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	return redirects, nil
+}
+
+func (r *redirectDB) PutRedirect(ctx context.Context, redirect *gtsmodel.Redirect) error {
+	if _, err := r.db.
+		NewInsert().
+		Model(redirect).
+		Exec(ctx); err != nil {
+		return err
+	}
+	r.state.Caches.Redirects.Clear()
+	return nil
+}
+
+func (r *redirectDB) DeleteRedirectByID(ctx context.Context, id string) error {
+	if _, err := r.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("redirects"), bun.Ident("redirect")).
+		Where("? = ?", bun.Ident("redirect.id"), id).
+		Exec(ctx); err != nil {
+		return err
+	}
+	r.state.Caches.Redirects.Clear()
+	return nil
+}
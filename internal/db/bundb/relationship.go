@@ -32,7 +32,7 @@ import (
 )
 
 type relationshipDB struct {
-	db    *bun.DB
+	db    *WrappedDB
 	state *state.State
 }
 
@@ -284,7 +284,7 @@ func (r *relationshipDB) GetAccountBlockIDs(ctx context.Context, accountID strin
 }
 
 // newSelectFollowRequests returns a new select query for all rows in the follow_requests table with target_account_id = accountID.
-func newSelectFollowRequests(db *bun.DB, accountID string) *bun.SelectQuery {
+func newSelectFollowRequests(db *WrappedDB, accountID string) *bun.SelectQuery {
 	return db.NewSelect().
 		TableExpr("?", bun.Ident("follow_requests")).
 		ColumnExpr("?", bun.Ident("id")).
@@ -293,7 +293,7 @@ func newSelectFollowRequests(db *bun.DB, accountID string) *bun.SelectQuery {
 }
 
 // newSelectFollowRequesting returns a new select query for all rows in the follow_requests table with account_id = accountID.
-func newSelectFollowRequesting(db *bun.DB, accountID string) *bun.SelectQuery {
+func newSelectFollowRequesting(db *WrappedDB, accountID string) *bun.SelectQuery {
 	return db.NewSelect().
 		TableExpr("?", bun.Ident("follow_requests")).
 		ColumnExpr("?", bun.Ident("id")).
@@ -302,7 +302,7 @@ func newSelectFollowRequesting(db *bun.DB, accountID string) *bun.SelectQuery {
 }
 
 // newSelectFollows returns a new select query for all rows in the follows table with account_id = accountID.
-func newSelectFollows(db *bun.DB, accountID string) *bun.SelectQuery {
+func newSelectFollows(db *WrappedDB, accountID string) *bun.SelectQuery {
 	return db.NewSelect().
 		Table("follows").
 		Column("id").
@@ -312,7 +312,7 @@ func newSelectFollows(db *bun.DB, accountID string) *bun.SelectQuery {
 
 // newSelectLocalFollows returns a new select query for all rows in the follows table with
 // account_id = accountID where the corresponding account ID has a NULL domain (i.e. is local).
-func newSelectLocalFollows(db *bun.DB, accountID string) *bun.SelectQuery {
+func newSelectLocalFollows(db *WrappedDB, accountID string) *bun.SelectQuery {
 	return db.NewSelect().
 		Table("follows").
 		Column("id").
@@ -329,7 +329,7 @@ func newSelectLocalFollows(db *bun.DB, accountID string) *bun.SelectQuery {
 }
 
 // newSelectFollowers returns a new select query for all rows in the follows table with target_account_id = accountID.
-func newSelectFollowers(db *bun.DB, accountID string) *bun.SelectQuery {
+func newSelectFollowers(db *WrappedDB, accountID string) *bun.SelectQuery {
 	return db.NewSelect().
 		Table("follows").
 		Column("id").
@@ -339,7 +339,7 @@ func newSelectFollowers(db *bun.DB, accountID string) *bun.SelectQuery {
 
 // newSelectLocalFollowers returns a new select query for all rows in the follows table with
 // target_account_id = accountID where the corresponding account ID has a NULL domain (i.e. is local).
-func newSelectLocalFollowers(db *bun.DB, accountID string) *bun.SelectQuery {
+func newSelectLocalFollowers(db *WrappedDB, accountID string) *bun.SelectQuery {
 	return db.NewSelect().
 		Table("follows").
 		Column("id").
@@ -356,7 +356,7 @@ func newSelectLocalFollowers(db *bun.DB, accountID string) *bun.SelectQuery {
 }
 
 // newSelectBlocks returns a new select query for all rows in the blocks table with account_id = accountID.
-func newSelectBlocks(db *bun.DB, accountID string) *bun.SelectQuery {
+func newSelectBlocks(db *WrappedDB, accountID string) *bun.SelectQuery {
 	return db.NewSelect().
 		TableExpr("?", bun.Ident("blocks")).
 		ColumnExpr("?", bun.Ident("id")).
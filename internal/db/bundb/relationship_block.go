@@ -19,6 +19,7 @@ package bundb
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"slices"
 
@@ -214,6 +215,18 @@ func (r *relationshipDB) PutBlock(ctx context.Context, block *gtsmodel.Block) er
 	})
 }
 
+func (r *relationshipDB) PutBlocks(ctx context.Context, blocks []*gtsmodel.Block) error {
+	if len(blocks) == 0 {
+		return nil
+	}
+
+	return r.state.Caches.GTS.Block.StoreAll(blocks, func() error {
+		return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return bulkInsert(ctx, tx, blocks)
+		})
+	})
+}
+
 func (r *relationshipDB) DeleteBlockByID(ctx context.Context, id string) error {
 	// Load block into cache before attempting a delete,
 	// as we need it cached in order to trigger the invalidate
@@ -263,11 +276,12 @@ func (r *relationshipDB) DeleteBlockByURI(ctx context.Context, uri string) error
 }
 
 func (r *relationshipDB) DeleteAccountBlocks(ctx context.Context, accountID string) error {
-	var blockIDs []string
+	var blocks []*gtsmodel.Block
 
-	// Get full list of IDs.
-	if err := r.db.NewSelect().
-		Column("id").
+	// Delete all incoming / outgoing blocks in a single
+	// query, returning only the minimum fields we need
+	// for cache invalidation (i.e. no full model loads).
+	if _, err := r.db.NewDelete().
 		Table("blocks").
 		WhereOr("? = ? OR ? = ?",
 			bun.Ident("account_id"),
@@ -275,28 +289,43 @@ func (r *relationshipDB) DeleteAccountBlocks(ctx context.Context, accountID stri
 			bun.Ident("target_account_id"),
 			accountID,
 		).
-		Scan(ctx, &blockIDs); err != nil {
+		Returning("?, ?, ?",
+			bun.Ident("id"),
+			bun.Ident("account_id"),
+			bun.Ident("target_account_id"),
+		).
+		Exec(ctx, &blocks); err != nil &&
+		!errors.Is(err, sql.ErrNoRows) {
 		return err
 	}
 
-	defer func() {
-		// Invalidate all account's incoming / outoing blocks on return.
-		r.state.Caches.GTS.Block.Invalidate("AccountID", accountID)
-		r.state.Caches.GTS.Block.Invalidate("TargetAccountID", accountID)
-	}()
+	// Invalidate all account's incoming / outoing blocks.
+	r.state.Caches.GTS.Block.Invalidate("AccountID", accountID)
+	r.state.Caches.GTS.Block.Invalidate("TargetAccountID", accountID)
 
-	// Load all blocks into cache, this *really* isn't great
-	// but it is the only way we can ensure we invalidate all
-	// related caches correctly (e.g. visibility).
-	_, err := r.GetAccountBlocks(ctx, accountID, nil)
-	if err != nil && !errors.Is(err, db.ErrNoEntries) {
-		return err
+	// Gather the set of every account
+	// on either side of a deleted block.
+	accountIDs := make(map[string]struct{}, len(blocks)+1)
+	accountIDs[accountID] = struct{}{}
+
+	for _, block := range blocks {
+		// Invalidate any block still cached under ID
+		// (this triggers usual invalidate hooks if so).
+		r.state.Caches.GTS.Block.Invalidate("ID", block.ID)
+
+		accountIDs[block.AccountID] = struct{}{}
+		accountIDs[block.TargetAccountID] = struct{}{}
 	}
 
-	// Finally delete all from DB.
-	_, err = r.db.NewDelete().
-		Table("blocks").
-		Where("? IN (?)", bun.Ident("id"), bun.In(blockIDs)).
-		Exec(ctx)
-	return err
+	// The invalidate hooks above only run for blocks
+	// that were actually cached, so manually perform
+	// the same invalidation as OnInvalidateBlock()
+	// for each involved account by key alone.
+	for id := range accountIDs {
+		r.state.Caches.Visibility.Invalidate("ItemID", id)
+		r.state.Caches.Visibility.Invalidate("RequesterID", id)
+		r.state.Caches.GTS.BlockIDs.Invalidate(id)
+	}
+
+	return nil
 }
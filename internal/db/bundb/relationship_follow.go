@@ -225,6 +225,18 @@ func (r *relationshipDB) PutFollow(ctx context.Context, follow *gtsmodel.Follow)
 	})
 }
 
+func (r *relationshipDB) PutFollows(ctx context.Context, follows []*gtsmodel.Follow) error {
+	if len(follows) == 0 {
+		return nil
+	}
+
+	return r.state.Caches.GTS.Follow.StoreAll(follows, func() error {
+		return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return bulkInsert(ctx, tx, follows)
+		})
+	})
+}
+
 func (r *relationshipDB) UpdateFollow(ctx context.Context, follow *gtsmodel.Follow, columns ...string) error {
 	follow.UpdatedAt = time.Now()
 	if len(columns) > 0 {
@@ -0,0 +1,117 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/uptrace/bun"
+)
+
+func (r *relationshipDB) PutSeveredRelationships(ctx context.Context, severed []*gtsmodel.SeveredRelationship) error {
+	if len(severed) == 0 {
+		// Nothing to do.
+		return nil
+	}
+
+	_, err := r.db.
+		NewInsert().
+		Model(&severed).
+		Exec(ctx)
+	return err
+}
+
+func (r *relationshipDB) GetSeveredRelationships(
+	ctx context.Context,
+	localAccountID string,
+	domain string,
+) ([]*gtsmodel.SeveredRelationship, error) {
+	severed := []*gtsmodel.SeveredRelationship{}
+
+	q := r.db.
+		NewSelect().
+		Model(&severed).
+		Where("? = ?", bun.Ident("severed_relationship.local_account_id"), localAccountID).
+		Order("severed_relationship.id DESC")
+
+	if domain != "" {
+		// Normalize the domain as punycode
+		var err error
+		domain, err = util.Punify(domain)
+		if err != nil {
+			return nil, err
+		}
+
+		q = q.Where("? = ?", bun.Ident("severed_relationship.domain"), domain)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// Only barebones models were requested.
+		return severed, nil
+	}
+
+	// Populate the account fields where possible.
+	errs := gtserror.NewMultiError(len(severed))
+	for _, s := range severed {
+		var err error
+
+		s.LocalAccount, err = r.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			s.LocalAccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating severed relationship local account: %w", err)
+		}
+
+		s.RemoteAccount, err = r.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			s.RemoteAccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating severed relationship remote account: %w", err)
+		}
+	}
+
+	if err := errs.Combine(); err != nil {
+		return nil, err
+	}
+
+	return severed, nil
+}
+
+func (r *relationshipDB) UpdateSeveredRelationship(
+	ctx context.Context,
+	severed *gtsmodel.SeveredRelationship,
+	columns ...string,
+) error {
+	_, err := r.db.
+		NewUpdate().
+		Model(severed).
+		Column(columns...).
+		Where("? = ?", bun.Ident("severed_relationship.id"), severed.ID).
+		Exec(ctx)
+	return err
+}
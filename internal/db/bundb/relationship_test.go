@@ -417,6 +417,105 @@ func (suite *RelationshipTestSuite) TestIsBlocked() {
 	suite.True(blocked)
 }
 
+func (suite *RelationshipTestSuite) TestPutBlocks() {
+	ctx := context.Background()
+
+	account := suite.testAccounts["local_account_1"]
+	targets := []*gtsmodel.Account{
+		suite.testAccounts["remote_account_2"],
+		suite.testAccounts["remote_account_3"],
+		suite.testAccounts["remote_account_4"],
+	}
+
+	blocks := make([]*gtsmodel.Block, 0, len(targets))
+	for _, target := range targets {
+		// Check (and cache) that no block exists yet.
+		blocked, err := suite.db.IsBlocked(ctx, account.ID, target.ID)
+		suite.NoError(err)
+		suite.False(blocked)
+
+		blockID := id.NewULID()
+		blocks = append(blocks, &gtsmodel.Block{
+			ID:              blockID,
+			URI:             account.URI + "/blocks/" + blockID,
+			AccountID:       account.ID,
+			TargetAccountID: target.ID,
+		})
+	}
+
+	if err := suite.db.PutBlocks(ctx, blocks); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// All blocks should now exist, despite
+	// having been previously cached as not.
+	for _, target := range targets {
+		blocked, err := suite.db.IsBlocked(ctx, account.ID, target.ID)
+		suite.NoError(err)
+		suite.True(blocked)
+	}
+
+	blockIDs, err := suite.db.GetAccountBlockIDs(ctx, account.ID, nil)
+	suite.NoError(err)
+	for _, block := range blocks {
+		suite.Contains(blockIDs, block.ID)
+	}
+
+	// Putting any of them again
+	// should fail, and insert none.
+	err = suite.db.PutBlocks(ctx, blocks)
+	suite.ErrorIs(err, db.ErrAlreadyExists)
+}
+
+func (suite *RelationshipTestSuite) TestPutFollows() {
+	ctx := context.Background()
+
+	account := suite.testAccounts["local_account_1"]
+	targets := []*gtsmodel.Account{
+		suite.testAccounts["remote_account_2"],
+		suite.testAccounts["remote_account_3"],
+		suite.testAccounts["remote_account_4"],
+	}
+
+	follows := make([]*gtsmodel.Follow, 0, len(targets))
+	for _, target := range targets {
+		// Check (and cache) that no follow exists yet.
+		following, err := suite.db.IsFollowing(ctx, account.ID, target.ID)
+		suite.NoError(err)
+		suite.False(following)
+
+		followID := id.NewULID()
+		follows = append(follows, &gtsmodel.Follow{
+			ID:              followID,
+			URI:             account.URI + "/follows/" + followID,
+			AccountID:       account.ID,
+			TargetAccountID: target.ID,
+			ShowReblogs:     util.Ptr(true),
+			Notify:          util.Ptr(false),
+		})
+	}
+
+	// Get following IDs to populate the cache.
+	followIDsBefore, err := suite.db.GetAccountFollowIDs(ctx, account.ID, nil)
+	suite.NoError(err)
+
+	if err := suite.db.PutFollows(ctx, follows); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// All follows should now exist, despite
+	// having been previously cached as not.
+	for _, target := range targets {
+		following, err := suite.db.IsFollowing(ctx, account.ID, target.ID)
+		suite.NoError(err)
+		suite.True(following)
+	}
+
+	followIDs, err := suite.db.GetAccountFollowIDs(ctx, account.ID, nil)
+	suite.NoError(err)
+	suite.Len(followIDs, len(followIDsBefore)+len(follows))
+}
+
 func (suite *RelationshipTestSuite) TestDeleteBlockByID() {
 	ctx := context.Background()
 
@@ -510,6 +609,41 @@ func (suite *RelationshipTestSuite) TestDeleteAccountBlocks() {
 	suite.Nil(block)
 }
 
+func (suite *RelationshipTestSuite) TestDeleteAccountBlocksByTarget() {
+	ctx := context.Background()
+
+	// put a block in first
+	account1 := suite.testAccounts["local_account_1"].ID
+	account2 := suite.testAccounts["local_account_2"].ID
+	if err := suite.db.PutBlock(ctx, &gtsmodel.Block{
+		ID:              "01G202BCSXXJZ70BHB5KCAHH8C",
+		URI:             "http://localhost:8080/some_block_uri_1",
+		AccountID:       account1,
+		TargetAccountID: account2,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// make sure the block is cached as blocked
+	blocked, err := suite.db.IsBlocked(ctx, account1, account2)
+	suite.NoError(err)
+	suite.True(blocked)
+
+	// delete the block by targetAccountID
+	err = suite.db.DeleteAccountBlocks(ctx, account2)
+	suite.NoError(err)
+
+	// block should be gone
+	block, err := suite.db.GetBlock(ctx, account1, account2)
+	suite.ErrorIs(err, db.ErrNoEntries)
+	suite.Nil(block)
+
+	// and no longer reported as blocked
+	blocked, err = suite.db.IsBlocked(ctx, account1, account2)
+	suite.NoError(err)
+	suite.False(blocked)
+}
+
 func (suite *RelationshipTestSuite) TestDeleteAccountMutes() {
 	ctx := context.Background()
 
@@ -32,7 +32,7 @@ import (
 )
 
 type reportDB struct {
-	db    *bun.DB
+	db    *WrappedDB
 	state *state.State
 }
 
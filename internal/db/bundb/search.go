@@ -21,6 +21,7 @@ import (
 	"context"
 	"strings"
 
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
@@ -56,8 +57,11 @@ import (
 //
 // This isn't ideal, of course, but at least we could cover the most common use case of
 // a caller paging down through results.
+//
+// Search results don't need to reflect the very latest writes, so all
+// queries here are replica selects, served from a replica if there is one.
 type searchDB struct {
-	db    *bun.DB
+	db    *WrappedDB
 	state *state.State
 }
 
@@ -91,7 +95,7 @@ func (s *searchDB) SearchForAccounts(
 	)
 
 	q := s.db.
-		NewSelect().
+		NewReplicaSelect().
 		TableExpr("? AS ?", bun.Ident("accounts"), bun.Ident("account")).
 		// Select only IDs from table.
 		Column("account.id").
@@ -192,7 +196,7 @@ func (s *searchDB) SearchForAccounts(
 // of accounts that are followed by the given accountID.
 func (s *searchDB) followedAccounts(accountID string) *bun.SelectQuery {
 	return s.db.
-		NewSelect().
+		NewReplicaSelect().
 		TableExpr("? AS ?", bun.Ident("follows"), bun.Ident("follow")).
 		Column("follow.target_account_id").
 		Where("? = ?", bun.Ident("follow.account_id"), accountID)
@@ -204,7 +208,7 @@ func (s *searchDB) followedAccounts(accountID string) *bun.SelectQuery {
 // in the concatenation.
 func (s *searchDB) accountText(following bool) *bun.SelectQuery {
 	var (
-		accountText = s.db.NewSelect()
+		accountText = s.db.NewReplicaSelect()
 		query       string
 		args        []interface{}
 	)
@@ -260,15 +264,19 @@ func (s *searchDB) accountText(following bool) *bun.SelectQuery {
 //	SELECT "status"."id"
 //	FROM "statuses" AS "status"
 //	WHERE ("status"."boost_of_id" IS NULL)
-//	AND (("status"."account_id" = '01F8MH1H7YV1Z7D2C8K2730QBF') OR ("status"."in_reply_to_account_id" = '01F8MH1H7YV1Z7D2C8K2730QBF'))
+//	AND (("status"."account_id" = '01F8MH1H7YV1Z7D2C8K2730QBF') OR ("status"."in_reply_to_account_id" = '01F8MH1H7YV1Z7D2C8K2730QBF')
+//	OR ("status"."id" IN (SELECT "status_id" FROM "mentions" WHERE ("target_account_id" = '01F8MH1H7YV1Z7D2C8K2730QBF')))
+//	OR ("status"."id" IN (SELECT "status_id" FROM "status_faves" WHERE ("account_id" = '01F8MH1H7YV1Z7D2C8K2730QBF')))
+//	OR ("status"."id" IN (SELECT "status_id" FROM "status_bookmarks" WHERE ("account_id" = '01F8MH1H7YV1Z7D2C8K2730QBF'))))
 //	AND ("status"."id" < 'ZZZZZZZZZZZZZZZZZZZZZZZZZZ')
-//	AND ((SELECT "status"."content" || COALESCE("status"."content_warning", '') AS "status_text") LIKE '%hello%' ESCAPE '\')
+//	AND ("status"."id" IN (SELECT "status_id" FROM "statuses_search" WHERE ("id" IN (SELECT "rowid" FROM "statuses_search_fts" WHERE ("statuses_search_fts" MATCH '"hello"')))))
 //	ORDER BY "status"."id" DESC LIMIT 10
 func (s *searchDB) SearchForStatuses(
 	ctx context.Context,
 	requestingAccountID string,
 	query string,
 	fromAccountID string,
+	scope db.SearchScope,
 	maxID string,
 	minID string,
 	limit int,
@@ -286,18 +294,15 @@ func (s *searchDB) SearchForStatuses(
 	)
 
 	q := s.db.
-		NewSelect().
+		NewReplicaSelect().
 		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
 		// Select only IDs from table
 		Column("status.id").
 		// Ignore boosts.
 		Where("? IS NULL", bun.Ident("status.boost_of_id")).
-		// Select only statuses created by
-		// accountID or replying to accountID.
+		// Select only statuses in requester's scope.
 		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
-			return q.
-				Where("? = ?", bun.Ident("status.account_id"), requestingAccountID).
-				WhereOr("? = ?", bun.Ident("status.in_reply_to_account_id"), requestingAccountID)
+			return s.whereStatusInScope(q, requestingAccountID, scope)
 		})
 	if fromAccountID != "" {
 		q = q.Where("? = ?", bun.Ident("status.account_id"), fromAccountID)
@@ -317,18 +322,22 @@ func (s *searchDB) SearchForStatuses(
 		frontToBack = false
 	}
 
-	// Select status text as subquery.
-	statusTextSubq := s.statusText()
-
-	// Search using LIKE for matches of query
-	// string within statusText subquery.
-	q = whereLike(q, statusTextSubq, query)
+	// Search for matches of query using
+	// the full-text status search index.
+	q = s.whereStatusMatch(q, query)
 
 	if limit > 0 {
 		// Limit amount of statuses returned.
 		q = q.Limit(limit)
 	}
 
+	if offset > 0 && maxID == id.Highest && minID == "" {
+		// Skip already-seen results, only
+		// when not already paging by ID, as
+		// that skips them just by itself.
+		q = q.Offset(offset)
+	}
+
 	if frontToBack {
 		// Page down.
 		q = q.Order("status.id DESC")
@@ -370,32 +379,142 @@ func (s *searchDB) SearchForStatuses(
 	return statuses, nil
 }
 
-// statusText returns a subquery that selects a concatenation
-// of status content and content warning as "status_text".
-func (s *searchDB) statusText() *bun.SelectQuery {
-	statusText := s.db.NewSelect()
+func (s *searchDB) RebuildStatusesSearch(ctx context.Context) error {
+	return s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewDelete().
+			Table("statuses_search").
+			Where("1 = 1").
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		// Page through statuses in batches,
+		// in order of (descending) status ID.
+		const batchSize = 500
+		maxID := id.Highest
+
+		for {
+			var statuses []*gtsmodel.Status
+
+			if err := tx.NewSelect().
+				Model(&statuses).
+				Column("id", "content", "content_warning").
+				Where("? IS NULL", bun.Ident("boost_of_id")).
+				Where("? < ?", bun.Ident("id"), maxID).
+				Order("id DESC").
+				Limit(batchSize).
+				Scan(ctx); err != nil {
+				return err
+			}
+
+			if len(statuses) == 0 {
+				return nil
+			}
+
+			for _, status := range statuses {
+				if err := putStatusSearch(ctx, tx, status); err != nil {
+					return err
+				}
+			}
+
+			maxID = statuses[len(statuses)-1].ID
+		}
+	})
+}
+
+// whereStatusInScope adds where clauses to the given select
+// query restricting statuses to those in accountID's scope.
+func (s *searchDB) whereStatusInScope(q *bun.SelectQuery, accountID string, scope db.SearchScope) *bun.SelectQuery {
+	// Each subquery below selects from a
+	// covering (account_id, status_id)
+	// index, avoiding any table scans.
+	faves := s.db.
+		NewReplicaSelect().
+		Table("status_faves").
+		Column("status_id").
+		Where("? = ?", bun.Ident("account_id"), accountID)
+
+	bookmarks := s.db.
+		NewReplicaSelect().
+		Table("status_bookmarks").
+		Column("status_id").
+		Where("? = ?", bun.Ident("account_id"), accountID)
+
+	switch scope {
+	case db.SearchScopeFaves:
+		return q.Where("? IN (?)", bun.Ident("status.id"), faves)
+
+	case db.SearchScopeBookmarks:
+		return q.Where("? IN (?)", bun.Ident("status.id"), bookmarks)
+
+	default: // db.SearchScopeLibrary
+		mentions := s.db.
+			NewReplicaSelect().
+			Table("mentions").
+			Column("status_id").
+			Where("? = ?", bun.Ident("target_account_id"), accountID)
+
+		return q.
+			Where("? = ?", bun.Ident("status.account_id"), accountID).
+			WhereOr("? = ?", bun.Ident("status.in_reply_to_account_id"), accountID).
+			WhereOr("? IN (?)", bun.Ident("status.id"), mentions).
+			WhereOr("? IN (?)", bun.Ident("status.id"), faves).
+			WhereOr("? IN (?)", bun.Ident("status.id"), bookmarks)
+	}
+}
+
+// whereStatusMatch adds a where clause to the given
+// select query matching statuses against the full-text
+// search index over the plaintext of statuses kept in
+// statuses_search (FTS5 on SQLite, tsvector on Postgres).
+//
+// If query contains nothing to match on, q is returned as-is.
+func (s *searchDB) whereStatusMatch(q *bun.SelectQuery, query string) *bun.SelectQuery {
+	terms := strings.Fields(query)
+	if len(terms) == 0 {
+		return q
+	}
 
-	// SQLite and Postgres use different
-	// syntaxes for concatenation.
 	switch d := s.db.Dialect().Name(); d {
 
 	case dialect.SQLite:
-		statusText = statusText.ColumnExpr(
-			"? || COALESCE(?, ?) AS ?",
-			bun.Ident("status.content"), bun.Ident("status.content_warning"), "",
-			bun.Ident("status_text"))
+		// Quote each term so that FTS5 treats it
+		// as a string rather than query syntax,
+		// escaping quotes by doubling them up.
+		for i, term := range terms {
+			terms[i] = `"` + strings.ReplaceAll(term, `"`, `""`) + `"`
+		}
+
+		q = q.Where("? IN (?)", bun.Ident("status.id"), s.db.
+			NewReplicaSelect().
+			Table("statuses_search").
+			Column("status_id").
+			Where("? IN (?)", bun.Ident("id"), s.db.
+				NewReplicaSelect().
+				Table("statuses_search_fts").
+				Column("rowid").
+				Where("? MATCH ?", bun.Ident("statuses_search_fts"), strings.Join(terms, " ")),
+			),
+		)
 
 	case dialect.PG:
-		statusText = statusText.ColumnExpr(
-			"CONCAT(?, COALESCE(?, ?)) AS ?",
-			bun.Ident("status.content"), bun.Ident("status.content_warning"), "",
-			bun.Ident("status_text"))
+		// Expression must match the one used
+		// in statuses_search_idx to make use of it.
+		q = q.Where("? IN (?)", bun.Ident("status.id"), s.db.
+			NewReplicaSelect().
+			Table("statuses_search").
+			Column("status_id").
+			Where(
+				"to_tsvector('simple', ?) @@ plainto_tsquery('simple', ?)",
+				bun.Ident("text"), strings.Join(terms, " "),
+			),
+		)
 
 	default:
 		log.Panicf(nil, "db conn %s was neither pg nor sqlite", d)
 	}
 
-	return statusText
+	return q
 }
 
 // Query example (SQLite):
@@ -424,7 +543,7 @@ func (s *searchDB) SearchForTags(
 	)
 
 	q := s.db.
-		NewSelect().
+		NewReplicaSelect().
 		TableExpr("? AS ?", bun.Ident("tags"), bun.Ident("tag")).
 		// Select only IDs from table
 		Column("tag.id")
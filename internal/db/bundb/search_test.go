@@ -23,6 +23,9 @@ import (
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun/dialect"
 )
 
 type SearchTestSuite struct {
@@ -107,19 +110,212 @@ func (suite *SearchTestSuite) TestSearchAccountsFossAny() {
 func (suite *SearchTestSuite) TestSearchStatuses() {
 	testAccount := suite.testAccounts["local_account_1"]
 
-	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, "hello", "", "", "", 10, 0)
+	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, "hello", "", "", "", "", 10, 0)
+	suite.NoError(err)
+	suite.Len(statuses, 2)
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesFaved() {
+	testAccount := suite.testAccounts["local_account_1"]
+
+	// Admin status 1 is faved and bookmarked by local_account_1.
+	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, "world", "", "", "", "", 10, 0)
+	suite.NoError(err)
+	if suite.Len(statuses, 1) {
+		suite.Equal(suite.testStatuses["admin_account_status_1"].ID, statuses[0].ID)
+	}
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesMultipleTerms() {
+	testAccount := suite.testAccounts["local_account_1"]
+
+	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, "hi zork direct", "", "", "", "", 10, 0)
+	suite.NoError(err)
+	if suite.Len(statuses, 1) {
+		suite.Equal(suite.testStatuses["local_account_2_status_6"].ID, statuses[0].ID)
+	}
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesQuotes() {
+	testAccount := suite.testAccounts["local_account_1"]
+
+	// Query syntax should be treated as plain
+	// text, not as "hello" OR "turtles".
+	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, `hello" OR "turtles`, "", "", "", "", 10, 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesOffset() {
+	testAccount := suite.testAccounts["local_account_1"]
+
+	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, "hello", "", "", "", "", 1, 0)
 	suite.NoError(err)
 	suite.Len(statuses, 1)
+
+	next, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, "hello", "", "", "", "", 1, 1)
+	suite.NoError(err)
+	if suite.Len(next, 1) {
+		suite.NotEqual(statuses[0].ID, next[0].ID)
+	}
+
+	none, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, "hello", "", "", "", "", 1, 2)
+	suite.NoError(err)
+	suite.Empty(none)
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesOffsetWithMaxID() {
+	testAccount := suite.testAccounts["local_account_1"]
+
+	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, "hello", "", "", "", "", 1, 0)
+	suite.NoError(err)
+	if !suite.Len(statuses, 1) {
+		suite.FailNow("")
+	}
+
+	// The offset is ignored when paging by ID,
+	// so as not to skip over any statuses.
+	next, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, "hello", "", "", statuses[0].ID, "", 1, 1)
+	suite.NoError(err)
+	if suite.Len(next, 1) {
+		suite.Less(next[0].ID, statuses[0].ID)
+	}
+}
+
+// putSearchStatus puts a new copy of local_account_1_status_1
+// with the given ID and content, for testing the search index.
+func (suite *SearchTestSuite) putSearchStatus(id string, content string) *gtsmodel.Status {
+	status := new(gtsmodel.Status)
+	*status = *suite.testStatuses["local_account_1_status_1"]
+	status.ID = id
+	status.URI = "http://localhost:8080/users/the_mighty_zork/statuses/" + id
+	status.URL = "http://localhost:8080/@the_mighty_zork/statuses/" + id
+	status.Content = content
+	status.ContentWarning = ""
+	if err := suite.db.PutStatus(context.Background(), status); err != nil {
+		suite.FailNow(err.Error())
+	}
+	return status
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesMarkupNotIndexed() {
+	testAccount := suite.testAccounts["local_account_1"]
+	status := suite.putSearchStatus(
+		"01J3E4RVDAH1PNRHY4CW0S4ZDS",
+		`<p>flibbertigibbet <span class="invisible">everywhere</span></p>`,
+	)
+
+	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, "flibbertigibbet everywhere", "", "", "", "", 10, 0)
+	suite.NoError(err)
+	if suite.Len(statuses, 1) {
+		suite.Equal(status.ID, statuses[0].ID)
+	}
+
+	// Tag names and attributes aren't searchable.
+	statuses, err = suite.db.SearchForStatuses(context.Background(), testAccount.ID, "flibbertigibbet invisible", "", "", "", "", 10, 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesUpdatedAndDeleted() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+	status := suite.putSearchStatus("01J3E4RVDAH1PNRHY4CW0S4ZDS", "<p>flibbertigibbet</p>")
+
+	status.Content = "<p>gobbledygook</p>"
+	suite.NoError(suite.db.UpdateStatus(ctx, status, "content"))
+
+	statuses, err := suite.db.SearchForStatuses(ctx, testAccount.ID, "flibbertigibbet", "", "", "", "", 10, 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, "gobbledygook", "", "", "", "", 10, 0)
+	suite.NoError(err)
+	suite.Len(statuses, 1)
+
+	suite.NoError(suite.db.DeleteStatusByID(ctx, status.ID))
+
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, "gobbledygook", "", "", "", "", 10, 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesAfterVacuum() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	dbService, ok := suite.db.(*bundb.DBService)
+	if !ok {
+		panic("db was not *bundb.DBService")
+	}
+	if dbService.DB().Dialect().Name() != dialect.SQLite {
+		suite.T().Skip("VACUUM only renumbers rowids on SQLite")
+	}
+
+	status := suite.putSearchStatus("01J3E4RVDAH1PNRHY4CW0S4ZDS", "<p>flibbertigibbet</p>")
+
+	// Leave a gap in the rowids before the new
+	// status, which VACUUM is then free to close.
+	suite.NoError(suite.db.DeleteStatusByID(ctx, suite.testStatuses["local_account_1_status_2"].ID))
+	_, err := dbService.DB().ExecContext(ctx, "VACUUM")
+	suite.NoError(err)
+
+	statuses, err := suite.db.SearchForStatuses(ctx, testAccount.ID, "flibbertigibbet", "", "", "", "", 10, 0)
+	suite.NoError(err)
+	if suite.Len(statuses, 1) {
+		suite.Equal(status.ID, statuses[0].ID)
+	}
 }
 
 func (suite *SearchTestSuite) TestSearchStatusesFromAccount() {
 	testAccount := suite.testAccounts["local_account_1"]
 	fromAccount := suite.testAccounts["local_account_2"]
 
-	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, "hi", fromAccount.ID, "", "", 10, 0)
+	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, "hi", fromAccount.ID, "", "", "", 10, 0)
+	suite.NoError(err)
+	if suite.Len(statuses, 3) {
+		for _, status := range statuses {
+			suite.Equal(fromAccount.ID, status.AccountID)
+		}
+	}
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesScopeFaves() {
+	testAccount := suite.testAccounts["local_account_1"]
+
+	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, "", "", db.SearchScopeFaves, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Len(statuses, 4)
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesScopeBookmarks() {
+	testAccount := suite.testAccounts["local_account_1"]
+
+	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, "hello", "", db.SearchScopeBookmarks, "", "", 10, 0)
 	suite.NoError(err)
 	if suite.Len(statuses, 1) {
-		suite.Equal(fromAccount.ID, statuses[0].AccountID)
+		suite.Equal(suite.testStatuses["admin_account_status_1"].ID, statuses[0].ID)
+	}
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesOwnNoQuery() {
+	testAccount := suite.testAccounts["local_account_1"]
+
+	// Page through own statuses using maxID.
+	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, "", testAccount.ID, "", "", "", 4, 0)
+	suite.NoError(err)
+	if !suite.Len(statuses, 4) {
+		suite.FailNow("")
+	}
+
+	next, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, "", testAccount.ID, "", statuses[len(statuses)-1].ID, "", 4, 0)
+	suite.NoError(err)
+	if suite.Len(next, 3) {
+		suite.Less(next[0].ID, statuses[len(statuses)-1].ID)
+	}
+
+	for _, status := range append(statuses, next...) {
+		suite.Equal(testAccount.ID, status.AccountID)
 	}
 }
 
@@ -24,11 +24,10 @@ import (
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
-	"github.com/uptrace/bun"
 )
 
 type sessionDB struct {
-	db *bun.DB
+	db *WrappedDB
 }
 
 func (s *sessionDB) GetSession(ctx context.Context) (*gtsmodel.RouterSession, error) {
@@ -18,7 +18,10 @@
 package bundb
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/json"
 	"errors"
 	"slices"
 	"time"
@@ -28,13 +31,15 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/uptrace/bun"
 )
 
 type statusDB struct {
-	db    *bun.DB
+	db    *WrappedDB
 	state *state.State
 }
 
@@ -313,6 +318,14 @@ func (s *statusDB) PopulateStatus(ctx context.Context, status *gtsmodel.Status)
 }
 
 func (s *statusDB) PutStatus(ctx context.Context, status *gtsmodel.Status) error {
+	s.db.onRollback(func() {
+		s.state.Caches.GTS.Status.Invalidate("ID", status.ID)
+		for _, a := range status.Attachments {
+			// Drop status ID set on attachments below.
+			s.state.Caches.GTS.Media.Invalidate("ID", a.ID)
+		}
+	})
+
 	return s.state.Caches.GTS.Status.Store(status, func() error {
 		// It is safe to run this database transaction within cache.Store
 		// as the cache does not attempt a mutex lock until AFTER hook.
@@ -383,6 +396,11 @@ func (s *statusDB) PutStatus(ctx context.Context, status *gtsmodel.Status) error
 				}
 			}
 
+			// Keep status searchable by its plaintext.
+			if err := putStatusSearch(ctx, tx, status); err != nil {
+				return err
+			}
+
 			// Finally, insert the status
 			_, err := tx.NewInsert().Model(status).Exec(ctx)
 			return err
@@ -466,6 +484,15 @@ func (s *statusDB) UpdateStatus(ctx context.Context, status *gtsmodel.Status, co
 				}
 			}
 
+			// Update searchable plaintext if content changed.
+			if len(columns) == 0 ||
+				slices.Contains(columns, "content") ||
+				slices.Contains(columns, "content_warning") {
+				if err := putStatusSearch(ctx, tx, status); err != nil {
+					return err
+				}
+			}
+
 			// Finally, update the status
 			_, err := tx.
 				NewUpdate().
@@ -526,6 +553,15 @@ func (s *statusDB) DeleteStatusByID(ctx context.Context, id string) error {
 			return err
 		}
 
+		// Delete the status' searchable plaintext.
+		if _, err := tx.
+			NewDelete().
+			Table("statuses_search").
+			Where("? = ?", bun.Ident("status_id"), id).
+			Exec(ctx); err != nil {
+			return err
+		}
+
 		// delete the status itself
 		if _, err := tx.
 			NewDelete().
@@ -556,6 +592,180 @@ func (s *statusDB) GetStatusesUsingEmoji(ctx context.Context, emojiID string) ([
 	return s.GetStatusesByIDs(ctx, statusIDs)
 }
 
+func (s *statusDB) GetExpirableLocalStatuses(ctx context.Context, maxID string, limit int) ([]*gtsmodel.Status, error) {
+	var statusIDs []string
+
+	// SELECT local non-boost, non-pinned
+	// statuses that nobody has bookmarked.
+	q := s.db.NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		Column("status.id").
+		Where("? = ?", bun.Ident("status.local"), true).
+		Where("? < ?", bun.Ident("status.id"), maxID).
+		Where("? IS NULL", bun.Ident("status.boost_of_id")).
+		Where("? IS NULL", bun.Ident("status.pinned_at")).
+		Where("NOT EXISTS (?)", s.db.NewSelect().
+			TableExpr("? AS ?", bun.Ident("status_bookmarks"), bun.Ident("status_bookmark")).
+			Column("status_bookmark.id").
+			Where("? = ?", bun.Ident("status_bookmark.status_id"), bun.Ident("status.id")),
+		).
+		Order("status.id DESC").
+		Limit(limit)
+
+	if err := q.Scan(ctx, &statusIDs); err != nil {
+		return nil, err
+	}
+
+	// Convert status IDs into status objects.
+	return s.GetStatusesByIDs(ctx, statusIDs)
+}
+
+func (s *statusDB) GetIndexableStatuses(ctx context.Context, limit int) ([]*gtsmodel.Status, error) {
+	var statusIDs []string
+
+	// SELECT local public statuses
+	// which authors allow to be indexed.
+	q := s.db.NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		Column("status.id").
+		Where("? = ?", bun.Ident("status.local"), true).
+		Where("? = ?", bun.Ident("status.visibility"), gtsmodel.VisibilityPublic).
+		Where("? = ?", bun.Ident("status.indexable"), true).
+		Where("? IS NULL", bun.Ident("status.boost_of_id")).
+		Where("? IS NULL", bun.Ident("status.quarantined_at")).
+		Where("? IN (?)", bun.Ident("status.account_id"), s.db.NewSelect().
+			TableExpr("? AS ?", bun.Ident("accounts"), bun.Ident("account")).
+			Column("account.id").
+			Where("? IS NULL", bun.Ident("account.suspended_at")),
+		).
+		Where("? NOT IN (?)", bun.Ident("status.account_id"), s.db.NewSelect().
+			TableExpr("? AS ?", bun.Ident("account_settings"), bun.Ident("account_settings")).
+			Column("account_settings.account_id").
+			Where("? = ?", bun.Ident("account_settings.hide_statuses_logged_out"), true).
+			WhereOr("? = ?", bun.Ident("account_settings.no_index"), true),
+		).
+		Order("status.id DESC").
+		Limit(limit)
+
+	if err := q.Scan(ctx, &statusIDs); err != nil {
+		return nil, err
+	}
+
+	// Convert status IDs into status objects.
+	return s.GetStatusesByIDs(ctx, statusIDs)
+}
+
+func (s *statusDB) GetExpirableRemoteStatuses(ctx context.Context, maxID string, limit int) ([]*gtsmodel.Status, error) {
+	var statusIDs []string
+
+	// Subquery to select IDs of all local accounts.
+	localAccountIDs := s.db.NewSelect().
+		TableExpr("? AS ?", bun.Ident("accounts"), bun.Ident("account")).
+		Column("account.id").
+		Where("? IS NULL", bun.Ident("account.domain"))
+
+	// SELECT remote statuses that no
+	// local account has interacted with.
+	q := s.db.NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		Column("status.id").
+		Where("? = ?", bun.Ident("status.local"), false).
+		Where("? < ?", bun.Ident("status.id"), maxID).
+		Where("NOT EXISTS (?)", s.db.NewSelect().
+			TableExpr("? AS ?", bun.Ident("status_faves"), bun.Ident("status_fave")).
+			Column("status_fave.id").
+			Where("? = ?", bun.Ident("status_fave.status_id"), bun.Ident("status.id")).
+			Where("? IN (?)", bun.Ident("status_fave.account_id"), localAccountIDs),
+		).
+		Where("NOT EXISTS (?)", s.db.NewSelect().
+			TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("local_status")).
+			Column("local_status.id").
+			Where("? = ?", bun.Ident("local_status.local"), true).
+			WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+				return q.
+					Where("? = ?", bun.Ident("local_status.boost_of_id"), bun.Ident("status.id")).
+					WhereOr("? = ?", bun.Ident("local_status.in_reply_to_id"), bun.Ident("status.id"))
+			}),
+		).
+		Where("NOT EXISTS (?)", s.db.NewSelect().
+			TableExpr("? AS ?", bun.Ident("status_bookmarks"), bun.Ident("status_bookmark")).
+			Column("status_bookmark.id").
+			Where("? = ?", bun.Ident("status_bookmark.status_id"), bun.Ident("status.id")),
+		).
+		Where("NOT EXISTS (?)", s.db.NewSelect().
+			TableExpr("? AS ?", bun.Ident("mentions"), bun.Ident("mention")).
+			Column("mention.id").
+			Where("? = ?", bun.Ident("mention.status_id"), bun.Ident("status.id")).
+			Where("? IN (?)", bun.Ident("mention.target_account_id"), localAccountIDs),
+		).
+		Where("NOT EXISTS (?)", s.db.NewSelect().
+			TableExpr("? AS ?", bun.Ident("poll_votes"), bun.Ident("poll_vote")).
+			Column("poll_vote.id").
+			Where("? = ?", bun.Ident("poll_vote.poll_id"), bun.Ident("status.poll_id")).
+			Where("? IN (?)", bun.Ident("poll_vote.account_id"), localAccountIDs),
+		).
+		Order("status.id DESC").
+		Limit(limit)
+
+	if err := q.Scan(ctx, &statusIDs); err != nil {
+		return nil, err
+	}
+
+	// Convert status IDs into status objects.
+	return s.GetStatusesByIDs(ctx, statusIDs)
+}
+
+func (s *statusDB) ArchiveStatus(ctx context.Context, status *gtsmodel.Status) error {
+	// Select the raw status row, so the archive
+	// holds exactly what was stored in the db,
+	// without any of the populated model fields.
+	row := make(map[string]interface{})
+	if err := s.db.NewSelect().
+		Table("statuses").
+		Where("? = ?", bun.Ident("id"), status.ID).
+		Scan(ctx, &row); err != nil {
+		return err
+	}
+
+	for k, v := range row {
+		// Some drivers return text
+		// columns as bytes, which JSON
+		// would otherwise base64 encode.
+		if b, ok := v.([]byte); ok {
+			row[k] = string(b)
+		}
+	}
+
+	b, err := json.Marshal(row)
+	if err != nil {
+		return gtserror.Newf("error encoding status row: %w", err)
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(b); err != nil {
+		return gtserror.Newf("error compressing status row: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return gtserror.Newf("error compressing status row: %w", err)
+	}
+
+	archive := &gtsmodel.StatusArchive{
+		ID:        status.ID,
+		URI:       status.URI,
+		AccountID: status.AccountID,
+		Data:      buf.Bytes(),
+	}
+
+	if _, err := s.db.NewInsert().
+		Model(archive).
+		Exec(ctx); err != nil && !errors.Is(err, db.ErrAlreadyExists) {
+		return err
+	}
+
+	return nil
+}
+
 func (s *statusDB) GetStatusParents(ctx context.Context, status *gtsmodel.Status) ([]*gtsmodel.Status, error) {
 	var parents []*gtsmodel.Status
 
@@ -615,6 +825,31 @@ func (s *statusDB) CountStatusReplies(ctx context.Context, statusID string) (int
 	return len(statusIDs), err
 }
 
+func (s *statusDB) CountAccountStatusesByVisibility(ctx context.Context, accountID string) (map[gtsmodel.Visibility]int, error) {
+	var rows []struct {
+		Visibility gtsmodel.Visibility
+		Count      int
+	}
+
+	if err := s.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		Column("status.visibility").
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("count")).
+		Where("? = ?", bun.Ident("status.account_id"), accountID).
+		Group("status.visibility").
+		Scan(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[gtsmodel.Visibility]int, len(rows))
+	for _, row := range rows {
+		counts[row.Visibility] = row.Count
+	}
+
+	return counts, nil
+}
+
 func (s *statusDB) getStatusReplyIDs(ctx context.Context, statusID string) ([]string, error) {
 	return s.state.Caches.GTS.InReplyToIDs.Load(statusID, func() ([]string, error) {
 		var statusIDs []string
@@ -642,6 +877,66 @@ func (s *statusDB) GetStatusBoosts(ctx context.Context, statusID string) ([]*gts
 	return s.GetStatusesByIDs(ctx, statusIDs)
 }
 
+func (s *statusDB) GetStatusBoostsPage(ctx context.Context, statusID string, page *paging.Page) ([]*gtsmodel.Status, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		statusIDs = make([]string, 0, limit)
+	)
+
+	// Select only the page of boost IDs we need,
+	// using the (boost_of_id, id) index, rather than
+	// loading every boost of the status at once.
+	q := s.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		Column("status.id").
+		Where("? = ?", bun.Ident("status.boost_of_id"), statusID)
+
+	if maxID != "" {
+		// Return only boosts older than maxID.
+		q = q.Where("? < ?", bun.Ident("status.id"), maxID)
+	}
+
+	if minID != "" {
+		// Return only boosts newer than minID.
+		q = q.Where("? > ?", bun.Ident("status.id"), minID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.Order("status.id ASC")
+	} else {
+		// Page down.
+		q = q.Order("status.id DESC")
+	}
+
+	if err := q.Scan(ctx, &statusIDs); err != nil {
+		return nil, err
+	}
+
+	if len(statusIDs) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	// If we're paging up, we still want boosts
+	// to be sorted by ID desc, so reverse slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(statusIDs)
+	}
+
+	return s.GetStatusesByIDs(ctx, statusIDs)
+}
+
 func (s *statusDB) IsStatusBoostedBy(ctx context.Context, statusID string, accountID string) (bool, error) {
 	boost, err := s.GetStatusBoost(
 		gtscontext.SetBarebones(ctx),
@@ -677,3 +972,21 @@ func (s *statusDB) getStatusBoostIDs(ctx context.Context, statusID string) ([]st
 		return statusIDs, nil
 	})
 }
+
+// putStatusSearch inserts (or updates) the plaintext of
+// status' content and content warning in statuses_search,
+// over which statuses are full-text searched. Boosts have
+// no content of their own, so are never searchable.
+func putStatusSearch(ctx context.Context, tx bun.IDB, status *gtsmodel.Status) error {
+	if status.BoostOfID != "" {
+		return nil
+	}
+
+	_, err := tx.ExecContext(ctx,
+		"INSERT INTO ? (?, ?) VALUES (?, ?) ON CONFLICT (?) DO UPDATE SET ? = ?",
+		bun.Ident("statuses_search"), bun.Ident("status_id"), bun.Ident("text"),
+		status.ID, text.SearchText(status.Content, status.ContentWarning),
+		bun.Ident("status_id"), bun.Ident("text"), bun.Ident("excluded.text"),
+	)
+	return err
+}
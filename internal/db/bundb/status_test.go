@@ -18,13 +18,20 @@
 package bundb_test
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/json"
+	"io"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 type StatusTestSuite struct {
@@ -277,6 +284,147 @@ func (suite *StatusTestSuite) TestPutPopulatedStatus() {
 	)
 }
 
+func (suite *StatusTestSuite) TestCountAccountStatusesByVisibility() {
+	accountID := suite.testAccounts["local_account_1"].ID
+
+	expect := make(map[gtsmodel.Visibility]int)
+	for _, status := range suite.testStatuses {
+		if status.AccountID == accountID {
+			expect[status.Visibility]++
+		}
+	}
+
+	counts, err := suite.db.CountAccountStatusesByVisibility(context.Background(), accountID)
+	suite.NoError(err)
+	suite.NotEmpty(counts)
+	suite.Equal(expect, counts)
+}
+
+func (suite *StatusTestSuite) TestGetExpirableRemoteStatuses() {
+	statuses, err := suite.db.GetExpirableRemoteStatuses(context.Background(), id.Highest, 100)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	ids := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		suite.False(*status.Local)
+		ids = append(ids, status.ID)
+	}
+
+	// No local interactions.
+	suite.Contains(ids, suite.testStatuses["remote_account_1_status_1"].ID)
+
+	// Voted on by local accounts.
+	suite.NotContains(ids, suite.testStatuses["remote_account_1_status_2"].ID)
+
+	// Mentions a local account.
+	suite.NotContains(ids, suite.testStatuses["remote_account_2_status_1"].ID)
+}
+
+func (suite *StatusTestSuite) TestGetStatusBoostsPage() {
+	ctx := context.Background()
+	testStatus := suite.testStatuses["local_account_1_status_1"]
+	testBoost := suite.testStatuses["admin_account_status_4"]
+
+	boosts, err := suite.db.GetStatusBoostsPage(ctx, testStatus.ID, &paging.Page{Limit: 10})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if suite.Len(boosts, 1) {
+		suite.Equal(testBoost.ID, boosts[0].ID)
+		suite.NotNil(boosts[0].Account)
+	}
+
+	// Nothing newer than the only boost.
+	boosts, err = suite.db.GetStatusBoostsPage(ctx, testStatus.ID, &paging.Page{
+		Min:   paging.MinID(testBoost.ID),
+		Limit: 10,
+	})
+	suite.ErrorIs(err, db.ErrNoEntries)
+	suite.Empty(boosts)
+}
+
+func (suite *StatusTestSuite) TestGetIndexableStatuses() {
+	ctx := context.Background()
+
+	// Nothing is indexable in the test models.
+	statuses, err := suite.db.GetIndexableStatuses(ctx, 100)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(statuses)
+
+	// Mark local public status indexable.
+	status := suite.testStatuses["local_account_1_status_1"]
+	status.Indexable = util.Ptr(true)
+	if err := suite.db.UpdateStatus(ctx, status, "indexable"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	statuses, err = suite.db.GetIndexableStatuses(ctx, 100)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if suite.Len(statuses, 1) {
+		suite.Equal(status.ID, statuses[0].ID)
+	}
+
+	// Opt the author out of indexing,
+	// status should now be left out.
+	settings := suite.testAccounts["local_account_1"].Settings
+	settings.NoIndex = util.Ptr(true)
+	if err := suite.db.UpdateAccountSettings(ctx, settings, "no_index"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	statuses, err = suite.db.GetIndexableStatuses(ctx, 100)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(statuses)
+}
+
+func (suite *StatusTestSuite) TestArchiveStatus() {
+	ctx := context.Background()
+	status := suite.testStatuses["remote_account_1_status_1"]
+
+	if err := suite.db.ArchiveStatus(ctx, status); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Archiving twice should be a no-op.
+	if err := suite.db.ArchiveStatus(ctx, status); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	archive := &gtsmodel.StatusArchive{}
+	if err := suite.db.GetByID(ctx, status.ID, archive); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(status.URI, archive.URI)
+	suite.Equal(status.AccountID, archive.AccountID)
+
+	zr, err := gzip.NewReader(bytes.NewReader(archive.Data))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	b, err := io.ReadAll(zr)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	row := make(map[string]interface{})
+	if err := json.Unmarshal(b, &row); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(status.ID, row["id"])
+	suite.Equal(status.URI, row["uri"])
+	suite.Equal(status.Content, row["content"])
+}
+
 func TestStatusTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusTestSuite))
 }
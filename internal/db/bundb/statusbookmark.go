@@ -33,7 +33,7 @@ import (
 )
 
 type statusBookmarkDB struct {
-	db    *bun.DB
+	db    *WrappedDB
 	state *state.State
 }
 
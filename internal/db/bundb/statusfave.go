@@ -29,13 +29,14 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/uptrace/bun"
 )
 
 type statusFaveDB struct {
-	db    *bun.DB
+	db    *WrappedDB
 	state *state.State
 }
 
@@ -113,6 +114,70 @@ func (s *statusFaveDB) GetStatusFaves(ctx context.Context, statusID string) ([]*
 		return nil, err
 	}
 
+	return s.getStatusFavesByIDs(ctx, faveIDs)
+}
+
+func (s *statusFaveDB) GetStatusFavesPage(ctx context.Context, statusID string, page *paging.Page) ([]*gtsmodel.StatusFave, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		faveIDs = make([]string, 0, limit)
+	)
+
+	// Select only the page of fave IDs we need,
+	// using the (status_id, id) index, rather than
+	// loading every fave of the status at once.
+	q := s.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("status_faves"), bun.Ident("status_fave")).
+		Column("status_fave.id").
+		Where("? = ?", bun.Ident("status_fave.status_id"), statusID)
+
+	if maxID != "" {
+		// Return only faves older than maxID.
+		q = q.Where("? < ?", bun.Ident("status_fave.id"), maxID)
+	}
+
+	if minID != "" {
+		// Return only faves newer than minID.
+		q = q.Where("? > ?", bun.Ident("status_fave.id"), minID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.Order("status_fave.id ASC")
+	} else {
+		// Page down.
+		q = q.Order("status_fave.id DESC")
+	}
+
+	if err := q.Scan(ctx, &faveIDs); err != nil {
+		return nil, err
+	}
+
+	if len(faveIDs) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	// If we're paging up, we still want faves
+	// to be sorted by ID desc, so reverse slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(faveIDs)
+	}
+
+	return s.getStatusFavesByIDs(ctx, faveIDs)
+}
+
+func (s *statusFaveDB) getStatusFavesByIDs(ctx context.Context, faveIDs []string) ([]*gtsmodel.StatusFave, error) {
 	// Load all fave IDs via cache loader callbacks.
 	faves, err := s.state.Caches.GTS.StatusFave.LoadIDs("ID",
 		faveIDs,
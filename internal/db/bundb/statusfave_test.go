@@ -25,6 +25,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 )
 
 type StatusFaveTestSuite struct {
@@ -58,6 +59,30 @@ func (suite *StatusFaveTestSuite) TestGetStatusFavesNone() {
 	suite.Empty(faves)
 }
 
+func (suite *StatusFaveTestSuite) TestGetStatusFavesPage() {
+	ctx := context.Background()
+	testStatus := suite.testStatuses["admin_account_status_1"]
+	testFave := suite.testFaves["local_account_1_admin_account_status_1"]
+
+	faves, err := suite.db.GetStatusFavesPage(ctx, testStatus.ID, &paging.Page{Limit: 1})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if suite.Len(faves, 1) {
+		suite.Equal(testFave.ID, faves[0].ID)
+		suite.NotNil(faves[0].Account)
+	}
+
+	// Nothing older than the only fave.
+	faves, err = suite.db.GetStatusFavesPage(ctx, testStatus.ID, &paging.Page{
+		Max:   paging.MaxID(testFave.ID),
+		Limit: 1,
+	})
+	suite.ErrorIs(err, db.ErrNoEntries)
+	suite.Empty(faves)
+}
+
 func (suite *StatusFaveTestSuite) TestGetStatusFaveByAccountID() {
 	testAccount := suite.testAccounts["local_account_1"]
 	testStatus := suite.testStatuses["admin_account_status_1"]
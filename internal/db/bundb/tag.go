@@ -28,7 +28,7 @@ import (
 )
 
 type tagDB struct {
-	db    *bun.DB
+	db    *WrappedDB
 	state *state.State
 }
 
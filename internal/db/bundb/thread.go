@@ -28,7 +28,7 @@ import (
 )
 
 type threadDB struct {
-	db    *bun.DB
+	db    *WrappedDB
 	state *state.State
 }
 
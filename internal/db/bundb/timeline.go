@@ -34,7 +34,7 @@ import (
 )
 
 type timelineDB struct {
-	db    *bun.DB
+	db    *WrappedDB
 	state *state.State
 }
 
@@ -158,7 +158,7 @@ func (t *timelineDB) GetHomeTimeline(ctx context.Context, accountID string, maxI
 	return t.state.DB.GetStatusesByIDs(ctx, statusIDs)
 }
 
-func (t *timelineDB) GetPublicTimeline(ctx context.Context, maxID string, sinceID string, minID string, limit int, local bool) ([]*gtsmodel.Status, error) {
+func (t *timelineDB) GetPublicTimeline(ctx context.Context, maxID string, sinceID string, minID string, limit int, local bool, remote bool, onlyMedia bool) ([]*gtsmodel.Status, error) {
 	// Ensure reasonable
 	if limit < 0 {
 		limit = 0
@@ -170,8 +170,12 @@ func (t *timelineDB) GetPublicTimeline(ctx context.Context, maxID string, sinceI
 		frontToBack = true
 	)
 
+	// Not user-specific, so being a
+	// little behind is fine; a replica
+	// can serve this if there is one.
+	// Can be served from a replica.
 	q := t.db.
-		NewSelect().
+		NewReplicaSelect().
 		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
 		// Public only.
 		Where("? = ?", bun.Ident("status.visibility"), gtsmodel.VisibilityPublic).
@@ -208,9 +212,20 @@ func (t *timelineDB) GetPublicTimeline(ctx context.Context, maxID string, sinceI
 		frontToBack = false
 	}
 
-	if local {
+	// Like Mastodon, local + remote
+	// together means no restriction.
+	switch {
+	case local && !remote:
 		// return only statuses posted by local account havers
-		q = q.Where("? = ?", bun.Ident("status.local"), local)
+		q = q.Where("? = ?", bun.Ident("status.local"), true)
+	case remote && !local:
+		// return only statuses posted by remote accounts
+		q = q.Where("? = ?", bun.Ident("status.local"), false)
+	}
+
+	if onlyMedia {
+		// return only statuses with media attached
+		q = whereStatusHasAttachments(q)
 	}
 
 	if limit > 0 {
@@ -448,8 +463,9 @@ func (t *timelineDB) GetTagTimeline(
 		frontToBack = true
 	)
 
+	// Can be served from a replica.
 	q := t.db.
-		NewSelect().
+		NewReplicaSelect().
 		TableExpr("? AS ?", bun.Ident("status_to_tags"), bun.Ident("status_to_tag")).
 		Column("status_to_tag.status_id").
 		// Join with statuses for filtering.
@@ -524,3 +540,57 @@ func (t *timelineDB) GetTagTimeline(
 	// Return status IDs loaded from cache + db.
 	return t.state.DB.GetStatusesByIDs(ctx, statusIDs)
 }
+
+func (t *timelineDB) GetLocalTagTimeline(
+	ctx context.Context,
+	tagID string,
+	limit int,
+) ([]*gtsmodel.Status, error) {
+	// Ensure reasonable
+	if limit < 0 {
+		limit = 0
+	}
+
+	// Make educated guess for slice size
+	statusIDs := make([]string, 0, limit)
+
+	// Can be served from a replica.
+	q := t.db.
+		NewReplicaSelect().
+		TableExpr("? AS ?", bun.Ident("status_to_tags"), bun.Ident("status_to_tag")).
+		Column("status_to_tag.status_id").
+		// Join with statuses for filtering.
+		Join(
+			"INNER JOIN ? AS ? ON ? = ?",
+			bun.Ident("statuses"), bun.Ident("status"),
+			bun.Ident("status.id"), bun.Ident("status_to_tag.status_id"),
+		).
+		// This tag only.
+		Where("? = ?", bun.Ident("status_to_tag.tag_id"), tagID).
+		// Public only.
+		Where("? = ?", bun.Ident("status.visibility"), gtsmodel.VisibilityPublic).
+		// Local only.
+		Where("? = ?", bun.Ident("status.local"), true).
+		// Don't show local-only statuses.
+		Where("? = ?", bun.Ident("status.federated"), true).
+		// Don't show boosts.
+		Where("? IS NULL", bun.Ident("status.boost_of_id"))
+
+	if limit > 0 {
+		// limit amount of statuses returned
+		q = q.Limit(limit)
+	}
+
+	q = q.Order("status_to_tag.status_id DESC")
+
+	if err := q.Scan(ctx, &statusIDs); err != nil {
+		return nil, err
+	}
+
+	if len(statusIDs) == 0 {
+		return nil, nil
+	}
+
+	// Return status IDs loaded from cache + db.
+	return t.state.DB.GetStatusesByIDs(ctx, statusIDs)
+}
@@ -115,7 +115,7 @@ func (suite *TimelineTestSuite) checkStatuses(statuses []*gtsmodel.Status, maxID
 func (suite *TimelineTestSuite) TestGetPublicTimeline() {
 	ctx := context.Background()
 
-	s, err := suite.db.GetPublicTimeline(ctx, "", "", "", 20, false)
+	s, err := suite.db.GetPublicTimeline(ctx, "", "", "", 20, false, false, false)
 	if err != nil {
 		suite.FailNow(err.Error())
 	}
@@ -137,7 +137,7 @@ func (suite *TimelineTestSuite) TestGetPublicTimelineWithFutureStatus() {
 		suite.FailNow(err.Error())
 	}
 
-	s, err := suite.db.GetPublicTimeline(ctx, "", "", "", 20, false)
+	s, err := suite.db.GetPublicTimeline(ctx, "", "", "", 20, false, false, false)
 	if err != nil {
 		suite.FailNow(err.Error())
 	}
@@ -146,6 +146,36 @@ func (suite *TimelineTestSuite) TestGetPublicTimelineWithFutureStatus() {
 	suite.checkStatuses(s, id.Highest, id.Lowest, suite.publicCount())
 }
 
+func (suite *TimelineTestSuite) TestGetPublicTimelineRemoteOnlyMedia() {
+	ctx := context.Background()
+
+	remote, err := suite.db.GetPublicTimeline(ctx, "", "", "", 20, false, true, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotEmpty(remote)
+	for _, s := range remote {
+		suite.False(*s.Local)
+	}
+
+	media, err := suite.db.GetPublicTimeline(ctx, "", "", "", 20, false, false, true)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotEmpty(media)
+	for _, s := range media {
+		suite.NotEmpty(s.AttachmentIDs)
+	}
+
+	// Local + remote together should
+	// be the same as neither being set.
+	both, err := suite.db.GetPublicTimeline(ctx, "", "", "", 20, true, true, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.checkStatuses(both, id.Highest, id.Lowest, suite.publicCount())
+}
+
 func (suite *TimelineTestSuite) TestGetHomeTimeline() {
 	var (
 		ctx            = context.Background()
@@ -323,6 +353,21 @@ func (suite *TimelineTestSuite) TestGetTagTimelineNoParams() {
 	suite.Equal("01F8MH75CBF9JFX4ZAD54N0W0R", s[0].ID)
 }
 
+func (suite *TimelineTestSuite) TestGetLocalTagTimeline() {
+	var (
+		ctx = context.Background()
+		tag = suite.testTags["welcome"]
+	)
+
+	s, err := suite.db.GetLocalTagTimeline(ctx, tag.ID, 20)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.checkStatuses(s, id.Highest, id.Lowest, 1)
+	suite.Equal("01F8MH75CBF9JFX4ZAD54N0W0R", s[0].ID)
+}
+
 func TestTimelineTestSuite(t *testing.T) {
 	suite.Run(t, new(TimelineTestSuite))
 }
@@ -27,7 +27,7 @@ import (
 )
 
 type tombstoneDB struct {
-	db    *bun.DB
+	db    *WrappedDB
 	state *state.State
 }
 
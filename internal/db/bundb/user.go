@@ -31,7 +31,7 @@ import (
 )
 
 type userDB struct {
-	db    *bun.DB
+	db    *WrappedDB
 	state *state.State
 }
 
@@ -163,14 +163,28 @@ func (u *userDB) PopulateUser(ctx context.Context, user *gtsmodel.User) error {
 	return errs.Combine()
 }
 
-func (u *userDB) GetAllUsers(ctx context.Context) ([]*gtsmodel.User, error) {
+// usersPageLimit is the page size used when
+// paging through all local users internally.
+const usersPageLimit = 200
+
+func (u *userDB) GetUsersPage(ctx context.Context, maxID string, limit int) ([]*gtsmodel.User, error) {
 	var userIDs []string
 
-	// Scan all user IDs into slice.
-	if err := u.db.NewSelect().
-		Table("users").
-		Column("id").
-		Scan(ctx, &userIDs); err != nil {
+	q := u.db.NewSelect().
+		TableExpr("? AS ?", bun.Ident("users"), bun.Ident("user")).
+		Column("user.id").
+		Order("user.id DESC")
+
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("user.id"), maxID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	// Scan page of user IDs into slice.
+	if err := q.Scan(ctx, &userIDs); err != nil {
 		return nil, err
 	}
 
@@ -179,6 +193,10 @@ func (u *userDB) GetAllUsers(ctx context.Context) ([]*gtsmodel.User, error) {
 }
 
 func (u *userDB) PutUser(ctx context.Context, user *gtsmodel.User) error {
+	u.db.onRollback(func() {
+		u.state.Caches.GTS.User.Invalidate("ID", user.ID)
+	})
+
 	return u.state.Caches.GTS.User.Store(user, func() error {
 		_, err := u.db.
 			NewInsert().
@@ -29,10 +29,29 @@ type UserTestSuite struct {
 	BunDBStandardTestSuite
 }
 
-func (suite *UserTestSuite) TestGetAllUsers() {
-	users, err := suite.db.GetAllUsers(context.Background())
+func (suite *UserTestSuite) TestGetUsersPage() {
+	// Fetch all users in one page.
+	users, err := suite.db.GetUsersPage(context.Background(), "", 0)
 	suite.NoError(err)
 	suite.Len(users, len(suite.testUsers))
+
+	// Page through users two at a time.
+	var (
+		maxID string
+		paged []*gtsmodel.User
+	)
+	for {
+		page, err := suite.db.GetUsersPage(context.Background(), maxID, 2)
+		suite.NoError(err)
+		if len(page) == 0 {
+			break
+		}
+		suite.LessOrEqual(len(page), 2)
+		paged = append(paged, page...)
+		maxID = page[len(page)-1].ID
+	}
+
+	suite.Equal(users, paged)
 }
 
 func (suite *UserTestSuite) TestGetUser() {
@@ -134,6 +134,32 @@ func notExists(ctx context.Context, query *bun.SelectQuery) (bool, error) {
 	return !exists, err
 }
 
+// bulkInsertBatch is the max number of models to insert
+// per statement in bulkInsert(). This keeps us well within
+// the limit on bind parameters per query that some database
+// backends (ie., older SQLite versions) impose.
+const bulkInsertBatch = 100
+
+// bulkInsert inserts all the given models using the given
+// bun.IDB (usually a transaction), in as few INSERT statements
+// as possible, batching up to bulkInsertBatch models at a time.
+func bulkInsert[T any](ctx context.Context, tx bun.IDB, models []T) error {
+	for len(models) > 0 {
+		n := min(len(models), bulkInsertBatch)
+		batch := models[:n]
+		models = models[n:]
+
+		if _, err := tx.
+			NewInsert().
+			Model(&batch).
+			Exec(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // loadPagedIDs loads a page of IDs from given SliceCache by `key`, resorting to `loadDESC` if required. Uses `page` to sort + page resulting IDs.
 // NOTE: IDs returned from `cache` / `loadDESC` MUST be in descending order, otherwise paging will not work correctly / return things out of order.
 func loadPagedIDs(cache *cache.SliceCache[string], key string, page *paging.Page, loadDESC func() ([]string, error)) ([]string, error) {
@@ -207,6 +233,32 @@ func parseWhere(w db.Where) (query string, args []interface{}) {
 	return
 }
 
+// whereStatusHasAttachments extends a status query with a
+// where clause requiring the status to have media attachments.
+func whereStatusHasAttachments(query *bun.SelectQuery) *bun.SelectQuery {
+	// Attachments are stored as a json object; this
+	// implementation differs between SQLite and Postgres,
+	// so we have to be thorough to cover all eventualities
+	return query.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+		switch d := q.Dialect().Name(); d {
+		case dialect.PG:
+			return q.
+				Where("? IS NOT NULL", bun.Ident("status.attachments")).
+				Where("? != '{}'", bun.Ident("status.attachments"))
+		case dialect.SQLite:
+			return q.
+				Where("? IS NOT NULL", bun.Ident("status.attachments")).
+				Where("? != ''", bun.Ident("status.attachments")).
+				Where("? != 'null'", bun.Ident("status.attachments")).
+				Where("? != '{}'", bun.Ident("status.attachments")).
+				Where("? != '[]'", bun.Ident("status.attachments"))
+		default:
+			log.Panicf(nil, "db conn %s was neither pg nor sqlite", d)
+			return q
+		}
+	})
+}
+
 // whereArrayIsNullOrEmpty extends a query with a where clause requiring an array to be null or empty.
 // (The empty check varies by dialect; only PG has direct support for SQL array types.)
 func whereArrayIsNullOrEmpty(query *bun.SelectQuery, subject interface{}) *bun.SelectQuery {
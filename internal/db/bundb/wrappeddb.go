@@ -0,0 +1,204 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync/atomic"
+
+	"github.com/uptrace/bun"
+)
+
+// WrappedDB wraps a primary bun.DB connection pool
+// with any number of read-only replica connection
+// pools. All queries run against the primary, except
+// for SELECT queries created with NewReplicaSelect(),
+// which are spread round-robin across the replicas
+// (if there are any) to take load off the primary.
+//
+// Queries within a transaction always run against
+// the primary, as they're created from the bun.Tx.
+//
+// A WrappedDB may also be bound to a transaction (see
+// withTx()), in which case all queries created from it
+// run within that transaction, including selects.
+type WrappedDB struct {
+	*bun.DB
+
+	replicas []*bun.DB
+	next     atomic.Uint32
+
+	// tx is set when bound to
+	// an ongoing transaction.
+	tx *bun.Tx
+
+	// rollbacks are callbacks to run
+	// if the bound tx gets rolled back.
+	rollbacks []func()
+}
+
+// NewWrappedDB returns a new WrappedDB around
+// the given primary and read-only replica pools.
+func NewWrappedDB(primary *bun.DB, replicas ...*bun.DB) *WrappedDB {
+	return &WrappedDB{
+		DB:       primary,
+		replicas: replicas,
+	}
+}
+
+// NewSelect returns a new select query against the
+// primary, so it always sees the latest writes.
+func (db *WrappedDB) NewSelect() *bun.SelectQuery {
+	if db.tx != nil {
+		return db.tx.NewSelect()
+	}
+	return db.DB.NewSelect()
+}
+
+// NewReplicaSelect returns a new select query against
+// the next read-only replica pool if any are set, else
+// against the primary. Replicas may lag behind the
+// primary, so only use this for read-only paths where
+// slightly out of date results are fine, eg., public
+// timelines and search, never to read back a write.
+func (db *WrappedDB) NewReplicaSelect() *bun.SelectQuery {
+	if db.tx != nil {
+		return db.tx.NewSelect()
+	}
+	return db.reader().NewSelect()
+}
+
+// NewInsert returns a new insert query.
+func (db *WrappedDB) NewInsert() *bun.InsertQuery {
+	if db.tx != nil {
+		return db.tx.NewInsert()
+	}
+	return db.DB.NewInsert()
+}
+
+// NewUpdate returns a new update query.
+func (db *WrappedDB) NewUpdate() *bun.UpdateQuery {
+	if db.tx != nil {
+		return db.tx.NewUpdate()
+	}
+	return db.DB.NewUpdate()
+}
+
+// NewDelete returns a new delete query.
+func (db *WrappedDB) NewDelete() *bun.DeleteQuery {
+	if db.tx != nil {
+		return db.tx.NewDelete()
+	}
+	return db.DB.NewDelete()
+}
+
+// NewRaw returns a new raw query.
+func (db *WrappedDB) NewRaw(query string, args ...interface{}) *bun.RawQuery {
+	if db.tx != nil {
+		return db.tx.NewRaw(query, args...)
+	}
+	return db.DB.NewRaw(query, args...)
+}
+
+// NewCreateTable returns a new create table query.
+func (db *WrappedDB) NewCreateTable() *bun.CreateTableQuery {
+	if db.tx != nil {
+		return db.tx.NewCreateTable()
+	}
+	return db.DB.NewCreateTable()
+}
+
+// NewDropTable returns a new drop table query.
+func (db *WrappedDB) NewDropTable() *bun.DropTableQuery {
+	if db.tx != nil {
+		return db.tx.NewDropTable()
+	}
+	return db.DB.NewDropTable()
+}
+
+// ExecContext executes the given query.
+func (db *WrappedDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if db.tx != nil {
+		return db.tx.ExecContext(ctx, query, args...)
+	}
+	return db.DB.ExecContext(ctx, query, args...)
+}
+
+// RunInTx runs fn in a new transaction, or if
+// already bound to one, in a nested savepoint.
+func (db *WrappedDB) RunInTx(
+	ctx context.Context,
+	opts *sql.TxOptions,
+	fn func(ctx context.Context, tx bun.Tx) error,
+) error {
+	if db.tx != nil {
+		return db.tx.RunInTx(ctx, opts, fn)
+	}
+	return db.DB.RunInTx(ctx, opts, fn)
+}
+
+// withTx returns a copy of db bound to the given
+// transaction, such that all queries created from
+// it will run within that transaction.
+func (db *WrappedDB) withTx(tx bun.Tx) *WrappedDB {
+	return &WrappedDB{
+		DB: db.DB,
+		tx: &tx,
+	}
+}
+
+// onRollback registers fn to be called if the transaction
+// this db is bound to is rolled back, e.g. to invalidate
+// models that were cached as part of the transaction.
+// This is a no-op when not bound to a transaction.
+func (db *WrappedDB) onRollback(fn func()) {
+	if db.tx != nil {
+		db.rollbacks = append(db.rollbacks, fn)
+	}
+}
+
+// rolledBack calls all registered onRollback functions.
+func (db *WrappedDB) rolledBack() {
+	for _, fn := range db.rollbacks {
+		fn()
+	}
+	db.rollbacks = nil
+}
+
+// Close closes the primary and all replica pools.
+func (db *WrappedDB) Close() error {
+	errs := make([]error, 0, 1+len(db.replicas))
+	errs = append(errs, db.DB.Close())
+	for _, replica := range db.replicas {
+		errs = append(errs, replica.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// reader returns the pool to use for the
+// next read-only query, round-robin over
+// replicas, falling back to the primary.
+func (db *WrappedDB) reader() *bun.DB {
+	if len(db.replicas) == 0 {
+		return db.DB
+	}
+	i := db.next.Add(1)
+	return db.replicas[int(i)%len(db.replicas)]
+}
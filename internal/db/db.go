@@ -17,6 +17,8 @@
 
 package db
 
+import "context"
+
 const (
 	// DBTypePostgres represents an underlying POSTGRES database type.
 	DBTypePostgres string = "POSTGRES"
@@ -25,21 +27,29 @@ const (
 // DB provides methods for interacting with an underlying database or other storage mechanism.
 type DB interface {
 	Account
+	AccountDelegate
+	AccountDeletion
 	Admin
 	Application
 	Basic
+	BlobRef
 	Domain
 	Emoji
 	HeaderFilter
+	Import
 	Instance
+	Invite
 	Filter
 	List
 	Marker
 	Media
+	MediaUpload
 	Mention
+	ModerationNote
 	Move
 	Notification
 	Poll
+	Redirect
 	Relationship
 	Report
 	Rule
@@ -53,4 +63,15 @@ type DB interface {
 	Timeline
 	User
 	Tombstone
+
+	// RunInTx runs the given function within a single database
+	// transaction, passing it a DB that is bound to that transaction.
+	// If the function returns an error, the transaction is rolled
+	// back and any cached models written through tx are invalidated,
+	// otherwise the transaction is committed.
+	//
+	// All reads and writes that should be part of the transaction
+	// must be done through tx, not through the parent DB. Calling
+	// RunInTx on tx itself runs fn within a nested savepoint.
+	RunInTx(ctx context.Context, fn func(tx DB) error) error
 }
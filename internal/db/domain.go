@@ -60,6 +60,42 @@ type Domain interface {
 	// DeleteDomainBlock deletes an instance-level domain block with the given domain, if it exists.
 	DeleteDomainBlock(ctx context.Context, domain string) error
 
+	/*
+		Pause storage + retrieval functions.
+	*/
+
+	// CreateDomainPause puts the given instance-level domain pause into the database.
+	CreateDomainPause(ctx context.Context, pause *gtsmodel.DomainPause) error
+
+	// GetDomainPause returns one instance-level domain pause with the given domain, if it exists.
+	GetDomainPause(ctx context.Context, domain string) (*gtsmodel.DomainPause, error)
+
+	// GetDomainPauseByID returns one instance-level domain pause with the given id, if it exists.
+	GetDomainPauseByID(ctx context.Context, id string) (*gtsmodel.DomainPause, error)
+
+	// GetDomainPauses returns all instance-level domain pauses currently in place on this instance.
+	GetDomainPauses(ctx context.Context) ([]*gtsmodel.DomainPause, error)
+
+	// DeleteDomainPause deletes an instance-level domain pause with the given domain, if it exists.
+	DeleteDomainPause(ctx context.Context, domain string) error
+
+	/*
+		Permission draft storage + retrieval functions.
+	*/
+
+	// CreateDomainPermissionDraft puts the given domain permission draft into the database.
+	CreateDomainPermissionDraft(ctx context.Context, draft *gtsmodel.DomainPermissionDraft) error
+
+	// GetDomainPermissionDraftByID returns one domain permission draft with the given id, if it exists.
+	GetDomainPermissionDraftByID(ctx context.Context, id string) (*gtsmodel.DomainPermissionDraft, error)
+
+	// GetDomainPermissionDrafts returns all domain permission drafts, optionally
+	// filtered by permission type (if not DomainPermissionUnknown) and domain (if not "").
+	GetDomainPermissionDrafts(ctx context.Context, permType gtsmodel.DomainPermissionType, domain string) ([]*gtsmodel.DomainPermissionDraft, error)
+
+	// DeleteDomainPermissionDraft deletes the domain permission draft with the given id, if it exists.
+	DeleteDomainPermissionDraft(ctx context.Context, id string) error
+
 	/*
 		Block/allow checking functions.
 	*/
@@ -78,4 +114,14 @@ type Domain interface {
 	// AreURIsBlocked calls IsURIBlocked for each URI.
 	// Will return true if even one of the given URIs is blocked.
 	AreURIsBlocked(ctx context.Context, uris []*url.URL) (bool, error)
+
+	/*
+		Pause checking functions.
+	*/
+
+	// IsDomainPaused checks if deliveries to domain (or a parent domain) are currently paused.
+	IsDomainPaused(ctx context.Context, domain string) (bool, error)
+
+	// IsDomainIngestionPaused checks if incoming activities from domain (or a parent domain) are currently paused.
+	IsDomainIngestionPaused(ctx context.Context, domain string) (bool, error)
 }
@@ -78,6 +78,10 @@ type Emoji interface {
 	// PutEmojiCategory puts one new emoji category in the database.
 	PutEmojiCategory(ctx context.Context, emojiCategory *gtsmodel.EmojiCategory) error
 
+	// UpdateEmojiCategory updates the given columns of one emoji category.
+	// If no columns are specified, every column is updated.
+	UpdateEmojiCategory(ctx context.Context, emojiCategory *gtsmodel.EmojiCategory, columns ...string) error
+
 	// GetEmojiCategoriesByIDs gets emoji categories for given IDs.
 	GetEmojiCategoriesByIDs(ctx context.Context, ids []string) ([]*gtsmodel.EmojiCategory, error)
 
@@ -0,0 +1,50 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Import handles getting/creation/updating of imports and their rows.
+type Import interface {
+	// GetImportByID gets one import by its db id.
+	GetImportByID(ctx context.Context, id string) (*gtsmodel.Import, error)
+
+	// GetImports gets imports uploaded by the given account, newest first.
+	GetImports(ctx context.Context, accountID string) ([]*gtsmodel.Import, error)
+
+	// GetUnfinishedImportIDs gets the IDs of all imports which
+	// still have rows left to process, oldest first.
+	GetUnfinishedImportIDs(ctx context.Context) ([]string, error)
+
+	// PutImport puts the given import, and all of its rows, in the database.
+	PutImport(ctx context.Context, imp *gtsmodel.Import, rows []*gtsmodel.ImportRow) error
+
+	// UpdateImport updates the given import, optionally limited to the given columns.
+	UpdateImport(ctx context.Context, imp *gtsmodel.Import, columns ...string) error
+
+	// GetImportRows gets rows of the given import in line order,
+	// optionally limited to rows with the given status (if not "").
+	GetImportRows(ctx context.Context, importID string, status gtsmodel.ImportRowStatus) ([]*gtsmodel.ImportRow, error)
+
+	// UpdateImportRow updates the given import row, optionally limited to the given columns.
+	UpdateImportRow(ctx context.Context, row *gtsmodel.ImportRow, columns ...string) error
+}
@@ -19,6 +19,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
@@ -34,6 +35,11 @@ type Instance interface {
 	// CountInstanceDomains returns the number of known instances known that the given domain federates with.
 	CountInstanceDomains(ctx context.Context, domain string) (int, error)
 
+	// GetInstanceStats aggregates operational statistics about this instance:
+	// local user counts by state, local statuses created per day since the
+	// given time, media storage usage, and federation peer counts.
+	GetInstanceStats(ctx context.Context, statusesSince time.Time) (*gtsmodel.InstanceStats, error)
+
 	// GetInstance returns the instance entry for the given domain, if it exists.
 	GetInstance(ctx context.Context, domain string) (*gtsmodel.Instance, error)
 
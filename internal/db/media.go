@@ -51,4 +51,16 @@ type Media interface {
 	// GetCachedAttachmentsOlderThan gets limit n remote attachments (including avatars and headers) older than
 	// the given time. These will be returned in order of attachment.created_at descending (i.e. newest to oldest).
 	GetCachedAttachmentsOlderThan(ctx context.Context, olderThan time.Time, limit int) ([]*gtsmodel.MediaAttachment, error)
+
+	// GetAccountAttachmentsUsage returns the number of cached media attachments owned by
+	// the given account ID, and their combined size in bytes, including thumbnails.
+	GetAccountAttachmentsUsage(ctx context.Context, accountID string) (count int, size int64, err error)
+
+	// GetAccountAttachments returns all cached media attachments
+	// owned by the given account ID, ordered by ID ascending.
+	GetAccountAttachments(ctx context.Context, accountID string) ([]*gtsmodel.MediaAttachment, error)
+
+	// GetLocalAttachmentsProcessing returns all local media attachments
+	// stored as placeholders which are still marked as being processed.
+	GetLocalAttachmentsProcessing(ctx context.Context) ([]*gtsmodel.MediaAttachment, error)
 }
@@ -0,0 +1,47 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// MediaUpload handles getting/creation/deletion of unfinished resumable media uploads.
+type MediaUpload interface {
+	// GetMediaUploadByID gets one unfinished upload by its db id.
+	GetMediaUploadByID(ctx context.Context, id string) (*gtsmodel.MediaUpload, error)
+
+	// GetAccountMediaUploads gets all unfinished uploads of the given account, oldest first.
+	GetAccountMediaUploads(ctx context.Context, accountID string) ([]*gtsmodel.MediaUpload, error)
+
+	// GetMediaUploadsExpiredBefore gets all unfinished uploads
+	// which expired before the given time, oldest first.
+	GetMediaUploadsExpiredBefore(ctx context.Context, t time.Time) ([]*gtsmodel.MediaUpload, error)
+
+	// PutMediaUpload puts the given unfinished upload in the database.
+	PutMediaUpload(ctx context.Context, upload *gtsmodel.MediaUpload) error
+
+	// UpdateMediaUpload updates the given unfinished upload, optionally limited to the given columns.
+	UpdateMediaUpload(ctx context.Context, upload *gtsmodel.MediaUpload, columns ...string) error
+
+	// DeleteMediaUploadByID deletes the upload with the given ID, ie., once it's finished or expired.
+	DeleteMediaUploadByID(ctx context.Context, id string) error
+}
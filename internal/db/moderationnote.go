@@ -0,0 +1,40 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// ModerationNote handles getting/creation/deletion of moderation notes.
+type ModerationNote interface {
+	// GetModerationNoteByID gets one moderation note by its db id.
+	GetModerationNoteByID(ctx context.Context, id string) (*gtsmodel.ModerationNote, error)
+
+	// GetModerationNotes gets moderation notes on the given target
+	// account and/or report, oldest first. Empty parameters are ignored.
+	GetModerationNotes(ctx context.Context, targetAccountID string, reportID string) ([]*gtsmodel.ModerationNote, error)
+
+	// PutModerationNote puts the given moderation note in the database.
+	PutModerationNote(ctx context.Context, note *gtsmodel.ModerationNote) error
+
+	// DeleteModerationNoteByID deletes one moderation note by its db id.
+	DeleteModerationNoteByID(ctx context.Context, id string) error
+}
@@ -40,6 +40,24 @@ type Notification interface {
 	// Since not all notifications are about a status, statusID can be an empty string.
 	GetNotification(ctx context.Context, notificationType gtsmodel.NotificationType, targetAccountID string, originAccountID string, statusID string) (*gtsmodel.Notification, error)
 
+	// GetNotificationIDsBefore returns up to limit IDs of notifications (for all accounts)
+	// with ID lower than maxID, ordered ID descending. If onlyRead is set, only notifications
+	// marked as read are returned. Used when enforcing notification retention.
+	GetNotificationIDsBefore(ctx context.Context, maxID string, onlyRead bool, limit int) ([]string, error)
+
+	// GetAccountIDsWithNotificationsOver returns the IDs of all accounts
+	// targeted by more than the given count of notifications.
+	GetAccountIDsWithNotificationsOver(ctx context.Context, count int) ([]string, error)
+
+	// GetAccountNotificationIDsOver returns up to limit IDs of notifications targeting
+	// the given account, skipping the newest offset notifications, ordered ID descending.
+	// Used when enforcing a maximum number of notifications per account.
+	GetAccountNotificationIDsOver(ctx context.Context, accountID string, offset int, limit int) ([]string, error)
+
+	// MarkNotificationsRead marks all notifications targeting the
+	// given account with ID lower than or equal to maxID as read.
+	MarkNotificationsRead(ctx context.Context, accountID string, maxID string) error
+
 	// PopulateNotification ensures that the notification's struct fields are populated.
 	PopulateNotification(ctx context.Context, notif *gtsmodel.Notification) error
 
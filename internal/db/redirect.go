@@ -0,0 +1,42 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Redirect handles getting/creation/deletion of admin-defined redirects.
+type Redirect interface {
+	// GetRedirectByID gets one redirect by its db id.
+	GetRedirectByID(ctx context.Context, id string) (*gtsmodel.Redirect, error)
+
+	// GetRedirectByPath gets the redirect for exactly the given path.
+	GetRedirectByPath(ctx context.Context, path string) (*gtsmodel.Redirect, error)
+
+	// GetRedirects gets all redirects, sorted by path.
+	GetRedirects(ctx context.Context) ([]*gtsmodel.Redirect, error)
+
+	// PutRedirect puts the given redirect in the database.
+	PutRedirect(ctx context.Context, redirect *gtsmodel.Redirect) error
+
+	// DeleteRedirectByID deletes the redirect with the given ID.
+	DeleteRedirectByID(ctx context.Context, id string) error
+}
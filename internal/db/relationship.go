@@ -47,6 +47,10 @@ type Relationship interface {
 	// PutBlock attempts to place the given account block in the database.
 	PutBlock(ctx context.Context, block *gtsmodel.Block) error
 
+	// PutBlocks attempts to place all the given account blocks in the database
+	// at once, in bulk. Either all blocks are inserted, or none of them are.
+	PutBlocks(ctx context.Context, blocks []*gtsmodel.Block) error
+
 	// DeleteBlockByID removes block with given ID from the database.
 	DeleteBlockByID(ctx context.Context, id string) error
 
@@ -95,6 +99,10 @@ type Relationship interface {
 	// PutFollow attempts to place the given account follow in the database.
 	PutFollow(ctx context.Context, follow *gtsmodel.Follow) error
 
+	// PutFollows attempts to place all the given account follows in the database
+	// at once, in bulk. Either all follows are inserted, or none of them are.
+	PutFollows(ctx context.Context, follows []*gtsmodel.Follow) error
+
 	// UpdateFollow updates one follow by ID.
 	UpdateFollow(ctx context.Context, follow *gtsmodel.Follow, columns ...string) error
 
@@ -208,4 +216,14 @@ type Relationship interface {
 
 	// GetAccountMutes returns all mutes originating from the given account, with given optional paging parameters.
 	GetAccountMutes(ctx context.Context, accountID string, paging *paging.Page) ([]*gtsmodel.UserMute, error)
+
+	// PutSeveredRelationships inserts the given records of follows severed by a domain block.
+	PutSeveredRelationships(ctx context.Context, severed []*gtsmodel.SeveredRelationship) error
+
+	// GetSeveredRelationships returns all follows severed by a domain block involving the
+	// given local account, newest first, optionally filtered by domain (if not "").
+	GetSeveredRelationships(ctx context.Context, localAccountID string, domain string) ([]*gtsmodel.SeveredRelationship, error)
+
+	// UpdateSeveredRelationship updates the given severed relationship record.
+	UpdateSeveredRelationship(ctx context.Context, severed *gtsmodel.SeveredRelationship, columns ...string) error
 }
@@ -23,13 +23,37 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
+// SearchScope restricts which of a requesting
+// account's statuses are searched through.
+type SearchScope string
+
+const (
+	// SearchScopeLibrary searches statuses created by, in reply to,
+	// mentioning, faved by, or bookmarked by the requesting account.
+	SearchScopeLibrary SearchScope = "library"
+
+	// SearchScopeFaves searches only statuses
+	// faved by the requesting account.
+	SearchScopeFaves SearchScope = "faves"
+
+	// SearchScopeBookmarks searches only statuses
+	// bookmarked by the requesting account.
+	SearchScopeBookmarks SearchScope = "bookmarks"
+)
+
 type Search interface {
 	// SearchForAccounts uses the given query text to search for accounts that accountID follows.
 	SearchForAccounts(ctx context.Context, accountID string, query string, maxID string, minID string, limit int, following bool, offset int) ([]*gtsmodel.Account, error)
 
-	// SearchForStatuses uses the given query text to search for statuses created by requestingAccountID, or in reply to requestingAccountID.
+	// SearchForStatuses uses the given query text to full-text search for statuses in requestingAccountID's
+	// given scope; if scope is empty, SearchScopeLibrary is used. If query is empty, all statuses in scope match.
 	// If fromAccountID is used, the results are restricted to statuses created by fromAccountID.
-	SearchForStatuses(ctx context.Context, requestingAccountID string, query string, fromAccountID string, maxID string, minID string, limit int, offset int) ([]*gtsmodel.Status, error)
+	SearchForStatuses(ctx context.Context, requestingAccountID string, query string, fromAccountID string, scope SearchScope, maxID string, minID string, limit int, offset int) ([]*gtsmodel.Status, error)
+
+	// RebuildStatusesSearch rebuilds the plaintext of all statuses
+	// that they're full-text searched on from scratch, for when
+	// statuses were inserted other than with PutStatus().
+	RebuildStatusesSearch(ctx context.Context) error
 
 	// SearchForTags searches for tags that start with the given query text (case insensitive).
 	SearchForTags(ctx context.Context, query string, maxID string, minID string, limit int, offset int) ([]*gtsmodel.Tag, error)
@@ -21,6 +21,7 @@ import (
 	"context"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 )
 
 // Status contains functions for getting statuses, creating statuses, and checking various other fields on statuses.
@@ -58,6 +59,24 @@ type Status interface {
 	// GetStatusesUsingEmoji fetches all status models using emoji with given ID stored in their 'emojis' column.
 	GetStatusesUsingEmoji(ctx context.Context, emojiID string) ([]*gtsmodel.Status, error)
 
+	// GetExpirableLocalStatuses fetches up to limit local statuses with ID lower than maxID, ordered DESC by ID,
+	// which are not boosts, not pinned, and not bookmarked by anyone. Used when enforcing status retention.
+	GetExpirableLocalStatuses(ctx context.Context, maxID string, limit int) ([]*gtsmodel.Status, error)
+
+	// GetExpirableRemoteStatuses fetches up to limit remote statuses with ID lower than maxID, ordered DESC by ID,
+	// which no local account has faved, boosted, bookmarked, replied to, voted on, or been mentioned in.
+	// Used when enforcing remote status retention.
+	GetExpirableRemoteStatuses(ctx context.Context, maxID string, limit int) ([]*gtsmodel.Status, error)
+
+	// GetIndexableStatuses fetches up to limit local, public, non-boost statuses ordered DESC by ID, which
+	// are marked indexable by authors who are neither suspended, hiding their statuses from logged-out
+	// viewers, nor opted out of indexing. Used when generating the instance sitemap.
+	GetIndexableStatuses(ctx context.Context, limit int) ([]*gtsmodel.Status, error)
+
+	// ArchiveStatus stores a compressed copy of the given status' database row in the status archives table.
+	// It does not remove the status itself. Archiving an already-archived status is a no-op.
+	ArchiveStatus(ctx context.Context, status *gtsmodel.Status) error
+
 	// GetStatusReplies returns the *direct* (i.e. in_reply_to_id column) replies to this status ID, ordered DESC by ID.
 	GetStatusReplies(ctx context.Context, statusID string) ([]*gtsmodel.Status, error)
 
@@ -67,6 +86,13 @@ type Status interface {
 	// GetStatusBoosts returns all statuses whose boost_of_id column refer to given status ID.
 	GetStatusBoosts(ctx context.Context, statusID string) ([]*gtsmodel.Status, error)
 
+	// GetStatusBoostsPage returns a page of statuses whose boost_of_id column refer to given status ID, ordered DESC by ID.
+	GetStatusBoostsPage(ctx context.Context, statusID string, page *paging.Page) ([]*gtsmodel.Status, error)
+
+	// CountAccountStatusesByVisibility returns the number of statuses created
+	// by the given account ID (including boosts), keyed by their visibility.
+	CountAccountStatusesByVisibility(ctx context.Context, accountID string) (map[gtsmodel.Visibility]int, error)
+
 	// CountStatusBoosts returns the number of stored boosts for status ID.
 	CountStatusBoosts(ctx context.Context, statusID string) (int, error)
 
@@ -21,6 +21,7 @@ import (
 	"context"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 )
 
 type StatusFave interface {
@@ -34,6 +35,9 @@ type StatusFave interface {
 	// This slice will be unfiltered, not taking account of blocks and whatnot, so filter it before serving it back to a user.
 	GetStatusFaves(ctx context.Context, statusID string) ([]*gtsmodel.StatusFave, error)
 
+	// GetStatusFavesPage returns a page of faves/likes of the status with given ID, ordered DESC by ID.
+	GetStatusFavesPage(ctx context.Context, statusID string, page *paging.Page) ([]*gtsmodel.StatusFave, error)
+
 	// PopulateStatusFave ensures that all sub-models of a fave are populated (account, status, etc).
 	PopulateStatusFave(ctx context.Context, statusFave *gtsmodel.StatusFave) error
 
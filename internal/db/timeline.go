@@ -33,8 +33,12 @@ type Timeline interface {
 	// GetPublicTimeline fetches the account's PUBLIC timeline -- ie., posts and replies that are public.
 	// It will use the given filters and try to return as many statuses as possible up to the limit.
 	//
+	// If local is set, only statuses from local accounts will be returned, and if remote is set,
+	// only statuses from remote accounts will be. If both are set, neither applies. If onlyMedia
+	// is set, only statuses with media attachments will be returned.
+	//
 	// Statuses should be returned in descending order of when they were created (newest first).
-	GetPublicTimeline(ctx context.Context, maxID string, sinceID string, minID string, limit int, local bool) ([]*gtsmodel.Status, error)
+	GetPublicTimeline(ctx context.Context, maxID string, sinceID string, minID string, limit int, local bool, remote bool, onlyMedia bool) ([]*gtsmodel.Status, error)
 
 	// GetFavedTimeline fetches the account's FAVED timeline -- ie., posts and replies that the requesting account has faved.
 	// It will use the given filters and try to return as many statuses as possible up to the limit.
@@ -52,4 +56,9 @@ type Timeline interface {
 	// GetTagTimeline returns a slice of public-visibility statuses that use the given tagID.
 	// Statuses should be returned in descending order of when they were created (newest first).
 	GetTagTimeline(ctx context.Context, tagID string, maxID string, sinceID string, minID string, limit int) ([]*gtsmodel.Status, error)
+
+	// GetLocalTagTimeline returns a slice of public-visibility, federated statuses
+	// by local accounts that use the given tagID, excluding boosts.
+	// Statuses should be returned in descending order of when they were created (newest first).
+	GetLocalTagTimeline(ctx context.Context, tagID string, limit int) ([]*gtsmodel.Status, error)
 }
@@ -25,8 +25,9 @@ import (
 
 // User contains functions related to user getting/setting/creation.
 type User interface {
-	// GetAllUsers returns all local user accounts, or an error if something goes wrong.
-	GetAllUsers(ctx context.Context) ([]*gtsmodel.User, error)
+	// GetUsersPage returns up to limit local user accounts with IDs lower than maxID,
+	// ordered newest first. An empty maxID means start from the most recent user.
+	GetUsersPage(ctx context.Context, maxID string, limit int) ([]*gtsmodel.User, error)
 
 	// GetUserByID returns one user with the given ID, or an error if something goes wrong.
 	GetUserByID(ctx context.Context, id string) (*gtsmodel.User, error)
@@ -0,0 +1,147 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package disposable
+
+import (
+	"bufio"
+	"context"
+	_ "embed"
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+	"strings"
+	"sync/atomic"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// maxListSize is the maximum number of
+// bytes we'll read from a remote list.
+const maxListSize = 8 * 1024 * 1024
+
+var (
+	//go:embed domains.txt
+	bundled string
+
+	// domains is the current set of known disposable
+	// email domains, swapped out in full on refresh.
+	domains atomic.Pointer[map[string]struct{}]
+)
+
+func init() {
+	set := make(map[string]struct{})
+	parse(strings.NewReader(bundled), set)
+	domains.Store(&set)
+}
+
+// Doer is the subset of an HTTP client needed to refresh the list.
+type Doer interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// IsDomain returns whether the given email domain, or
+// any parent domain of it, is a known disposable domain.
+func IsDomain(domain string) bool {
+	domain, err := util.Punify(domain)
+	if err != nil {
+		return false
+	}
+
+	set := *domains.Load()
+	for domain != "" {
+		if _, ok := set[domain]; ok {
+			return true
+		}
+
+		// Move up to the parent domain.
+		_, domain, _ = strings.Cut(domain, ".")
+	}
+
+	return false
+}
+
+// IsEmail returns whether the domain of the given email
+// address is a known disposable domain. Unparseable
+// addresses are not considered disposable.
+func IsEmail(address string) bool {
+	m, err := mail.ParseAddress(address)
+	if err != nil {
+		return false
+	}
+	at := strings.LastIndexByte(m.Address, '@')
+	return IsDomain(m.Address[at+1:])
+}
+
+// Refresh fetches the newline-separated list of domains at
+// url using the given client, and replaces the current set
+// of disposable domains with the fetched domains plus the
+// bundled domains. On error the current set is left as-is.
+func Refresh(ctx context.Context, client Doer, url string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, gtserror.Newf("error creating request: %w", err)
+	}
+
+	rsp, err := client.Do(req)
+	if err != nil {
+		return 0, gtserror.Newf("error doing request: %w", err)
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusOK {
+		return 0, gtserror.Newf("unexpected response status %s", rsp.Status)
+	}
+
+	set := make(map[string]struct{})
+	parse(strings.NewReader(bundled), set)
+	if err := parse(io.LimitReader(rsp.Body, maxListSize), set); err != nil {
+		return 0, gtserror.Newf("error reading list: %w", err)
+	}
+
+	domains.Store(&set)
+	return len(set), nil
+}
+
+// parse reads domains from r into set, one
+// per line, skipping blanks and '#' comments.
+func parse(r io.Reader, set map[string]struct{}) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		domain, err := util.Punify(line)
+		if err != nil {
+			// Skip bad entries rather
+			// than rejecting whole list.
+			continue
+		}
+
+		set[domain] = struct{}{}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error scanning: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package disposable_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/superseriousbusiness/gotosocial/internal/disposable"
+)
+
+type listClient struct {
+	status int
+	body   string
+}
+
+func (c *listClient) Do(r *http.Request) (*http.Response, error) {
+	return &http.Response{
+		Status:     http.StatusText(c.status),
+		StatusCode: c.status,
+		Body:       io.NopCloser(strings.NewReader(c.body)),
+		Request:    r,
+	}, nil
+}
+
+func TestIsEmail(t *testing.T) {
+	for _, test := range []struct {
+		in  string
+		out bool
+	}{
+		{in: "someone@mailinator.com", out: true},
+		{in: "Someone <someone@MAILINATOR.com>", out: true},
+		{in: "someone@eu.mailinator.com", out: true},
+		{in: "someone@notmailinator.com", out: false},
+		{in: "someone@example.org", out: false},
+		{in: "not an email address", out: false},
+	} {
+		if out := disposable.IsEmail(test.in); out != test.out {
+			t.Errorf("IsEmail(%q): expected %v, got %v", test.in, test.out, out)
+		}
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	ctx := context.Background()
+
+	// Failed refresh should leave list as-is.
+	if _, err := disposable.Refresh(ctx, &listClient{
+		status: http.StatusNotFound,
+	}, "https://example.org/list.txt"); err == nil {
+		t.Fatal("expected error refreshing from 404")
+	}
+
+	if !disposable.IsDomain("mailinator.com") {
+		t.Fatal("expected bundled domain to still be disposable")
+	}
+
+	n, err := disposable.Refresh(ctx, &listClient{
+		status: http.StatusOK,
+		body:   "# a comment\n\nthrowaway.example\n  Another-Throwaway.example  \n",
+	}, "https://example.org/list.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n < 2 {
+		t.Fatalf("expected at least 2 domains, got %d", n)
+	}
+
+	for _, domain := range []string{
+		"throwaway.example",
+		"another-throwaway.example",
+		"sub.throwaway.example",
+		"mailinator.com",
+	} {
+		if !disposable.IsDomain(domain) {
+			t.Errorf("expected %s to be disposable after refresh", domain)
+		}
+	}
+
+	if disposable.IsDomain("example") {
+		t.Error("expected parent of listed domain not to be disposable")
+	}
+}
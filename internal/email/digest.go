@@ -0,0 +1,67 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package email
+
+var (
+	digestTemplate = "email_digest.tmpl"
+	digestSubject  = "GoToSocial Notification Digest"
+)
+
+type DigestData struct {
+	// Username to be addressed.
+	Username string
+	// URL of the instance to present to the receiver.
+	InstanceURL string
+	// Name of the instance to present to the receiver.
+	InstanceName string
+	// Period covered by the digest, eg., "day", "week".
+	Period string
+	// Mentions of the receiver that they haven't seen yet.
+	Mentions []DigestMention
+	// Accounts that followed the receiver.
+	Follows []DigestAccount
+	// Accounts that requested to follow the receiver.
+	FollowRequests []DigestAccount
+	// URL of the settings page where the receiver
+	// can change how often they're sent digests.
+	SettingsURL string
+}
+
+// DigestAccount is an account that
+// appears in a notification digest.
+type DigestAccount struct {
+	// Account's @username@domain handle.
+	Acct string
+	// URL of the account's profile.
+	URL string
+}
+
+// DigestMention is a mention that
+// appears in a notification digest.
+type DigestMention struct {
+	// Account that wrote the mentioning status.
+	Account DigestAccount
+	// URL of the mentioning status.
+	URL string
+	// Shortened plaintext content of the mentioning status.
+	Text string
+}
+
+func (s *sender) SendDigestEmail(toAddress string, data DigestData) error {
+	return s.sendTemplate(digestTemplate, digestSubject, data, toAddress)
+}
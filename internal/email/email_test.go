@@ -200,6 +200,32 @@ func (suite *EmailTestSuite) TestTemplateReportClosedLocalAccountNoComment() {
 	suite.Equal("To: user@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial Report Closed\r\nMIME-Version: 1.0\r\nContent-Transfer-Encoding: 8bit\r\nContent-Type: text/plain; charset=\"UTF-8\"\r\n\r\nHello !\r\n\r\nYou recently reported the account @1happyturtle to the moderator(s) of Test Instance (https://example.org).\r\n\r\nThe report you submitted has now been closed.\r\n\r\nThe moderator who closed the report did not leave a comment.\r\n\r\n---\r\n\r\nIf you believe you've been sent this email in error, feel free to ignore it, or contact the administrator of https://example.org.\r\n\r\n", suite.sentEmails["user@example.org"])
 }
 
+func (suite *EmailTestSuite) TestTemplateDigest() {
+	digestData := email.DigestData{
+		Username:     "the_mighty_zork",
+		InstanceURL:  "https://example.org",
+		InstanceName: "Test Instance",
+		Period:       "day",
+		Mentions: []email.DigestMention{
+			{
+				Account: email.DigestAccount{Acct: "@1happyturtle", URL: "https://example.org/@1happyturtle"},
+				URL:     "https://example.org/@1happyturtle/statuses/01FN3VJGFH10KR7S2PB0GFJZYG",
+				Text:    "hey zork here's a link to google",
+			},
+		},
+		FollowRequests: []email.DigestAccount{
+			{Acct: "@foss_satan@fossbros-anonymous.io", URL: "http://fossbros-anonymous.io/@foss_satan"},
+		},
+		SettingsURL: "https://example.org/settings/user/settings",
+	}
+
+	if err := suite.sender.SendDigestEmail("user@example.org", digestData); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(suite.sentEmails, 1)
+	suite.Equal("To: user@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial Notification Digest\r\nMIME-Version: 1.0\r\nContent-Transfer-Encoding: 8bit\r\nContent-Type: text/plain; charset=\"UTF-8\"\r\n\r\nHello the_mighty_zork!\r\n\r\nHere's what you missed on Test Instance in the last day.\r\n\r\nYou were mentioned 1 time(s):\r\n- @1happyturtle: \"hey zork here's a link to google\"\r\n  https://example.org/@1happyturtle/statuses/01FN3VJGFH10KR7S2PB0GFJZYG\r\n\r\n1 account(s) requested to follow you:\r\n- @foss_satan@fossbros-anonymous.io (http://fossbros-anonymous.io/@foss_satan)\r\n\r\nTo catch up, log in to https://example.org.\r\n\r\n---\r\n\r\nYou are receiving this mail because you opted in to email digests on https://example.org. To change how often you receive them, or to stop receiving them, visit https://example.org/settings/user/settings.\r\n\r\n", suite.sentEmails["user@example.org"])
+}
+
 func TestEmailTestSuite(t *testing.T) {
 	suite.Run(t, new(EmailTestSuite))
 }
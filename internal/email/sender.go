@@ -61,6 +61,14 @@ type Sender interface {
 	// SendSignupRejectedEmail sends an email to the given address
 	// that their sign-up request has been rejected by a moderator.
 	SendSignupRejectedEmail(toAddress string, data SignupRejectedData) error
+
+	// SendAccountActionEmail sends an email to the given address
+	// that a moderator has taken action against their account.
+	SendAccountActionEmail(toAddress string, data AccountActionData) error
+
+	// SendDigestEmail sends an email to the given address summarizing
+	// notifications that the user has missed since their last digest.
+	SendDigestEmail(toAddress string, data DigestData) error
 }
 
 // NewSender returns a new email Sender interface with the given configuration, or an error if something goes wrong.
@@ -33,6 +33,9 @@ type NewSignupData struct {
 	SignupUsername string
 	// Reason given on the sign-up form.
 	SignupReason string
+	// Whether the email address is at a
+	// known disposable email domain.
+	SignupDisposableEmail bool
 	// URL to open the sign-up in the settings panel.
 	SignupURL string
 }
@@ -0,0 +1,138 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package emojipack provides export and import of local custom
+// emojis as Pleroma-compatible emoji pack archives: a zip file
+// containing the emoji images alongside a pack.json manifest.
+package emojipack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+)
+
+// ManifestName is the name of the manifest file within a pack.
+const ManifestName = "pack.json"
+
+// Manifest models the pack.json manifest of an emoji pack,
+// as used by Pleroma / Akkoma for their emoji packs.
+type Manifest struct {
+	// Pack contains general information about the pack.
+	Pack PackInfo `json:"pack"`
+
+	// Files maps emoji shortcodes to
+	// image file names within the pack.
+	Files map[string]string `json:"files"`
+
+	// FilesCount is the number of entries in Files.
+	FilesCount int `json:"files_count"`
+}
+
+// PackInfo contains general information about an emoji pack.
+type PackInfo struct {
+	Description string `json:"description,omitempty"`
+	Homepage    string `json:"homepage,omitempty"`
+	License     string `json:"license,omitempty"`
+	ShareFiles  bool   `json:"share-files"`
+	CanDownload bool   `json:"can-download"`
+}
+
+// OnConflict determines what an import does when
+// an emoji in the pack has the same shortcode as an
+// emoji that already exists locally on the instance.
+type OnConflict string
+
+const (
+	// OnConflictSkip leaves the existing emoji untouched.
+	OnConflictSkip OnConflict = "skip"
+
+	// OnConflictReplace replaces the existing
+	// emoji's image with the one in the pack.
+	OnConflictReplace OnConflict = "replace"
+)
+
+// ParseOnConflict parses the given string as an OnConflict
+// value. An empty string is treated as OnConflictSkip.
+func ParseOnConflict(in string) (OnConflict, error) {
+	switch OnConflict(in) {
+	case "", OnConflictSkip:
+		return OnConflictSkip, nil
+	case OnConflictReplace:
+		return OnConflictReplace, nil
+	default:
+		return "", fmt.Errorf("on conflict value %s not recognized, must be one of %s or %s",
+			in, OnConflictSkip, OnConflictReplace)
+	}
+}
+
+// Packer wraps functionality for exporting
+// and importing local custom emoji packs.
+type Packer struct {
+	state *state.State
+	media *media.Manager
+}
+
+// New returns a new Packer using the given
+// state and media manager. The media manager
+// is only required for importing packs.
+func New(state *state.State, mediaManager *media.Manager) *Packer {
+	return &Packer{
+		state: state,
+		media: mediaManager,
+	}
+}
+
+// getOrCreateCategory returns the emoji category
+// with the given name, creating it if necessary.
+func (p *Packer) getOrCreateCategory(
+	ctx context.Context,
+	name string,
+) (*gtsmodel.EmojiCategory, error) {
+	category, err := p.state.DB.GetEmojiCategoryByName(ctx, name)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting emoji category %s: %w", name, err)
+	}
+
+	if category != nil {
+		// We had it already.
+		return category, nil
+	}
+
+	categoryID, err := id.NewRandomULID()
+	if err != nil {
+		return nil, gtserror.Newf("error generating id for emoji category %s: %w", name, err)
+	}
+
+	category = &gtsmodel.EmojiCategory{
+		ID:   categoryID,
+		Name: name,
+	}
+
+	if err := p.state.DB.PutEmojiCategory(ctx, category); err != nil {
+		return nil, gtserror.Newf("db error putting emoji category %s: %w", name, err)
+	}
+
+	return category, nil
+}
@@ -0,0 +1,149 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package emojipack
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// ErrCategoryNotFound is returned from LocalEmojis
+// when the requested emoji category does not exist.
+var ErrCategoryNotFound = errors.New("emoji category not found")
+
+// LocalEmojis returns all local emojis on the instance,
+// optionally only those in the category with the given name.
+func (p *Packer) LocalEmojis(ctx context.Context, category string) ([]*gtsmodel.Emoji, error) {
+	var categoryID string
+
+	if category != "" {
+		c, err := p.state.DB.GetEmojiCategoryByName(ctx, category)
+		if err != nil {
+			if errors.Is(err, db.ErrNoEntries) {
+				return nil, fmt.Errorf("%w: %s", ErrCategoryNotFound, category)
+			}
+			return nil, gtserror.Newf("db error getting emoji category %s: %w", category, err)
+		}
+		categoryID = c.ID
+	}
+
+	// Local emojis can't be disabled,
+	// so just fetch them all in one go.
+	emojis, err := p.state.DB.GetEmojisBy(ctx, "", true, true, "", "", "", 0)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting local emojis: %w", err)
+	}
+
+	if categoryID == "" {
+		return emojis, nil
+	}
+
+	// Filter down to only the requested category.
+	filtered := make([]*gtsmodel.Emoji, 0, len(emojis))
+	for _, emoji := range emojis {
+		if emoji.CategoryID == categoryID {
+			filtered = append(filtered, emoji)
+		}
+	}
+
+	return filtered, nil
+}
+
+// Export writes the given emojis to w as a zip archive
+// containing each emoji's original image, named after
+// its shortcode, along with a pack.json manifest.
+func (p *Packer) Export(ctx context.Context, w io.Writer, emojis []*gtsmodel.Emoji) error {
+	var (
+		zw   = zip.NewWriter(w)
+		host = config.GetHost()
+	)
+
+	manifest := Manifest{
+		Pack: PackInfo{
+			Description: "Custom emojis exported from " + host,
+			Homepage:    config.GetProtocol() + "://" + host,
+			ShareFiles:  true,
+			CanDownload: true,
+		},
+		Files: make(map[string]string, len(emojis)),
+	}
+
+	for _, emoji := range emojis {
+		name := emoji.Shortcode + path.Ext(emoji.ImagePath)
+		if err := p.exportEmoji(ctx, zw, name, emoji); err != nil {
+			return err
+		}
+		manifest.Files[emoji.Shortcode] = name
+	}
+
+	manifest.FilesCount = len(manifest.Files)
+
+	mw, err := zw.Create(ManifestName)
+	if err != nil {
+		return gtserror.Newf("error creating %s: %w", ManifestName, err)
+	}
+
+	enc := json.NewEncoder(mw)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(manifest); err != nil {
+		return gtserror.Newf("error encoding %s: %w", ManifestName, err)
+	}
+
+	if err := zw.Close(); err != nil {
+		return gtserror.Newf("error closing zip writer: %w", err)
+	}
+
+	return nil
+}
+
+// exportEmoji copies the original image of
+// the given emoji from storage into the zip
+// writer, under a file with the given name.
+func (p *Packer) exportEmoji(
+	ctx context.Context,
+	zw *zip.Writer,
+	name string,
+	emoji *gtsmodel.Emoji,
+) error {
+	rc, err := p.state.Storage.GetStream(ctx, emoji.ImagePath)
+	if err != nil {
+		return gtserror.Newf("error getting emoji %s from storage: %w", emoji.Shortcode, err)
+	}
+	defer rc.Close()
+
+	fw, err := zw.Create(name)
+	if err != nil {
+		return gtserror.Newf("error creating %s: %w", name, err)
+	}
+
+	if _, err := io.Copy(fw, rc); err != nil {
+		return gtserror.Newf("error writing %s: %w", name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,266 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package emojipack
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+// maxManifestSize is the maximum size
+// of pack.json that we're willing to read.
+const maxManifestSize = 1 << 20
+
+// ImportResult summarizes the outcome of an emoji pack import.
+type ImportResult struct {
+	// Shortcodes of newly created emojis.
+	Imported []string
+
+	// Shortcodes of existing emojis whose
+	// image was replaced by the pack's image.
+	Replaced []string
+
+	// Shortcodes of existing emojis
+	// that were left untouched.
+	Skipped []string
+
+	// Shortcodes of emojis that could not be
+	// imported, mapped to the reason why.
+	Failed map[string]string
+}
+
+// Import reads an emoji pack zip archive from r, and creates
+// a local emoji for each entry in its pack.json manifest.
+//
+// If category is set, imported emojis are put in this category,
+// creating it if necessary. Emojis whose shortcode already exists
+// on the instance are handled according to onConflict.
+//
+// Problems with individual emojis are recorded in the result
+// rather than aborting the import; an error is only returned
+// if the pack itself can't be read.
+func (p *Packer) Import(
+	ctx context.Context,
+	r io.ReaderAt,
+	size int64,
+	category string,
+	onConflict OnConflict,
+) (*ImportResult, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("error reading emoji pack archive: %w", err)
+	}
+
+	manifest, dir, err := readManifest(zr)
+	if err != nil {
+		return nil, err
+	}
+
+	// Index all archive files by name,
+	// so we can look up manifest entries.
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	var ai *media.AdditionalEmojiInfo
+	if category != "" {
+		if err := validate.EmojiCategory(category); err != nil {
+			return nil, err
+		}
+
+		c, err := p.getOrCreateCategory(ctx, category)
+		if err != nil {
+			return nil, err
+		}
+
+		ai = &media.AdditionalEmojiInfo{
+			CategoryID: &c.ID,
+		}
+	}
+
+	// Process shortcodes in a
+	// predictable (sorted) order.
+	shortcodes := make([]string, 0, len(manifest.Files))
+	for shortcode := range manifest.Files {
+		shortcodes = append(shortcodes, shortcode)
+	}
+	slices.Sort(shortcodes)
+
+	result := &ImportResult{
+		Imported: []string{},
+		Replaced: []string{},
+		Skipped:  []string{},
+		Failed:   make(map[string]string),
+	}
+
+	for _, shortcode := range shortcodes {
+		name := path.Join(dir, manifest.Files[shortcode])
+
+		f, ok := files[name]
+		if !ok {
+			result.Failed[shortcode] = "file " + name + " not found in pack"
+			continue
+		}
+
+		existing, err := p.importEmoji(ctx, shortcode, f, ai, onConflict)
+		switch {
+		case err != nil:
+			result.Failed[shortcode] = err.Error()
+		case existing && onConflict == OnConflictSkip:
+			result.Skipped = append(result.Skipped, shortcode)
+		case existing:
+			result.Replaced = append(result.Replaced, shortcode)
+		default:
+			result.Imported = append(result.Imported, shortcode)
+		}
+	}
+
+	return result, nil
+}
+
+// importEmoji creates a local emoji with the given shortcode
+// from the given archive file, or handles the existing emoji
+// with that shortcode according to onConflict. The returned
+// bool indicates whether an emoji with the shortcode existed.
+func (p *Packer) importEmoji(
+	ctx context.Context,
+	shortcode string,
+	f *zip.File,
+	ai *media.AdditionalEmojiInfo,
+	onConflict OnConflict,
+) (bool, error) {
+	if err := validate.EmojiShortcode(shortcode); err != nil {
+		return false, err
+	}
+
+	maxSize := config.GetMediaEmojiLocalMaxSize()
+	if f.UncompressedSize64 > uint64(maxSize) {
+		return false, fmt.Errorf("emoji image too large: image is %dKB but size limit for custom emojis is %dKB",
+			f.UncompressedSize64/1024, maxSize/1024)
+	}
+
+	existing, err := p.state.DB.GetEmojiByShortcodeDomain(ctx, shortcode, "")
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return false, gtserror.Newf("db error checking for emoji %s: %w", shortcode, err)
+	}
+
+	if existing != nil && onConflict == OnConflictSkip {
+		// Nothing to do.
+		return true, nil
+	}
+
+	// Data function just
+	// reads from the archive.
+	data := func(context.Context) (io.ReadCloser, int64, error) {
+		rc, err := f.Open()
+		return rc, int64(f.UncompressedSize64), err
+	}
+
+	var (
+		emojiID  string
+		emojiURI string
+		refresh  bool
+	)
+
+	if existing != nil {
+		// Replace image of existing emoji.
+		emojiID = existing.ID
+		emojiURI = existing.URI
+		refresh = true
+	} else {
+		// Generate new emoji ID and URI.
+		emojiID, err = id.NewRandomULID()
+		if err != nil {
+			return false, gtserror.Newf("error creating id for new emoji: %w", err)
+		}
+		emojiURI = uris.URIForEmoji(emojiID)
+	}
+
+	// Begin media processing.
+	processing, err := p.media.PreProcessEmoji(ctx,
+		data, shortcode, emojiID, emojiURI, ai, refresh,
+	)
+	if err != nil {
+		return existing != nil, gtserror.Newf("error processing emoji %s: %w", shortcode, err)
+	}
+
+	// Complete processing immediately.
+	if _, err := processing.LoadEmoji(ctx); err != nil {
+		return existing != nil, gtserror.Newf("error loading emoji %s: %w", shortcode, err)
+	}
+
+	return existing != nil, nil
+}
+
+// readManifest finds and decodes the pack.json manifest
+// in the given archive, returning it along with the
+// archive directory it was found in. File names in
+// the manifest are relative to this directory.
+func readManifest(zr *zip.Reader) (*Manifest, string, error) {
+	var mf *zip.File
+
+	for _, f := range zr.File {
+		if path.Base(f.Name) != ManifestName {
+			continue
+		}
+
+		// Prefer the manifest closest to
+		// the root of the archive, as packs
+		// are sometimes zipped in a folder.
+		if mf == nil || len(f.Name) < len(mf.Name) {
+			mf = f
+		}
+	}
+
+	if mf == nil {
+		return nil, "", fmt.Errorf("no %s found in emoji pack archive", ManifestName)
+	}
+
+	rc, err := mf.Open()
+	if err != nil {
+		return nil, "", fmt.Errorf("error opening %s: %w", ManifestName, err)
+	}
+	defer rc.Close()
+
+	var manifest Manifest
+	dec := json.NewDecoder(io.LimitReader(rc, maxManifestSize))
+	if err := dec.Decode(&manifest); err != nil {
+		return nil, "", fmt.Errorf("error decoding %s: %w", ManifestName, err)
+	}
+
+	if len(manifest.Files) == 0 {
+		return nil, "", fmt.Errorf("%s contains no files", ManifestName)
+	}
+
+	return &manifest, path.Dir(mf.Name), nil
+}
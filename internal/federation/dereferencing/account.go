@@ -92,6 +92,9 @@ func accountFresh(
 // whose last_fetched date is beyond a certain interval, the account will be dereferenced. In the case of dereferencing, some low-priority account information
 // may be enqueued for asynchronous fetching, e.g. featured account statuses (pins). An ActivityPub object indicates the account was dereferenced.
 func (d *Dereferencer) GetAccountByURI(ctx context.Context, requestUser string, uri *url.URL) (*gtsmodel.Account, ap.Accountable, error) {
+	// Ensure nested calls share a budget.
+	ctx = withDefaultBudget(ctx)
+
 	// Fetch and dereference account if necessary.
 	account, accountable, err := d.getAccountByURI(ctx,
 		requestUser,
@@ -188,6 +191,9 @@ func (d *Dereferencer) getAccountByURI(ctx context.Context, requestUser string,
 // or a remote model whose last_fetched date is beyond a certain interval, the account will be dereferenced. In the case of dereferencing, some low-priority
 // account information may be enqueued for asynchronous fetching, e.g. featured account statuses (pins). An ActivityPub object indicates the account was dereferenced.
 func (d *Dereferencer) GetAccountByUsernameDomain(ctx context.Context, requestUser string, username string, domain string) (*gtsmodel.Account, ap.Accountable, error) {
+	// Ensure nested calls share a budget.
+	ctx = withDefaultBudget(ctx)
+
 	account, accountable, err := d.getAccountByUsernameDomain(
 		ctx,
 		requestUser,
@@ -218,6 +224,13 @@ func (d *Dereferencer) getAccountByUsernameDomain(
 	username string,
 	domain string,
 ) (*gtsmodel.Account, ap.Accountable, error) {
+	// Normalize the domain as punycode, so that
+	// IDN domains are compared and stored correctly.
+	domain, err := util.Punify(domain)
+	if err != nil {
+		return nil, nil, gtserror.Newf("error punifying domain %s: %w", domain, err)
+	}
+
 	if domain == config.GetHost() || domain == config.GetAccountDomain() {
 		// We do local lookups using an empty domain,
 		// else it will fail the db search below.
@@ -294,6 +307,9 @@ func (d *Dereferencer) RefreshAccount(
 	accountable ap.Accountable,
 	window *FreshnessWindow,
 ) (*gtsmodel.Account, ap.Accountable, error) {
+	// Ensure nested calls share a budget.
+	ctx = withDefaultBudget(ctx)
+
 	// If no incoming data is provided,
 	// check whether account needs refresh.
 	if accountable == nil &&
@@ -346,6 +362,9 @@ func (d *Dereferencer) RefreshAccountAsync(
 	accountable ap.Accountable,
 	window *FreshnessWindow,
 ) {
+	// Ensure nested calls share a budget.
+	ctx = withDefaultBudget(ctx)
+
 	// If no incoming data is provided,
 	// check whether account needs refresh.
 	if accountable == nil &&
@@ -360,8 +379,11 @@ func (d *Dereferencer) RefreshAccountAsync(
 		return
 	}
 
-	// Enqueue a worker function to enrich this account async.
+	// Enqueue a worker function to enrich this account async,
+	// carrying over the budget of the enqueuing request.
+	budget := BudgetFrom(ctx)
 	d.state.Workers.Dereference.Queue.Push(func(ctx context.Context) {
+		ctx = WithBudget(ctx, budget)
 		latest, accountable, err := d.enrichAccountSafely(ctx, requestUser, uri, account, accountable)
 		if err != nil {
 			log.Errorf(ctx, "error enriching remote account: %v", err)
@@ -562,6 +584,9 @@ func (d *Dereferencer) enrichAccount(
 		// We were not given any (partial) ActivityPub
 		// version of this account as a parameter.
 		// Dereference latest version of the account.
+		if err := spend(ctx, "account"); err != nil {
+			return nil, nil, err
+		}
 		rsp, err := tsport.Dereference(ctx, uri)
 		if err != nil {
 			err := gtserror.Newf("error dereferencing %s: %w", uri, err)
@@ -230,6 +230,54 @@ func (suite *AccountTestSuite) TestDereferenceRemoteAccountWithNonMatchingURI()
 	suite.Nil(fetchedAccount)
 }
 
+func (suite *AccountTestSuite) TestRefreshStaleAccounts() {
+	var (
+		ctx           = context.Background()
+		start         = time.Now()
+		fetchedBefore = start.Add(-24 * time.Hour)
+		freshAccount  = suite.testAccounts["remote_account_1"]
+	)
+
+	// Dereference a remote account for the first time,
+	// then mark it as last fetched a couple of days ago.
+	staleAccount, _, err := suite.dereferencer.GetAccountByURI(ctx,
+		suite.testAccounts["local_account_1"].Username,
+		testrig.URLMustParse("https://turnip.farm/users/turniplover6969"),
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	staleAccount.FetchedAt = start.Add(-48 * time.Hour)
+	if err := suite.db.UpdateAccount(ctx, staleAccount, "fetched_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Mark another remote account as recently fetched,
+	// so it should be left alone by the refresh.
+	freshAccount.FetchedAt = start.Add(-time.Hour)
+	if err := suite.db.UpdateAccount(ctx, freshAccount, "fetched_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	updated, err := suite.dereferencer.RefreshStaleAccounts(ctx, fetchedBefore, 2)
+	suite.NoError(err)
+	suite.NotZero(updated)
+
+	// Stale account should now have been refreshed.
+	dbAccount, err := suite.db.GetAccountByID(ctx, staleAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(dbAccount.FetchedAt.After(start))
+
+	// Fresh account should not have been touched.
+	dbAccount, err = suite.db.GetAccountByID(ctx, freshAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(dbAccount.FetchedAt.Before(start))
+}
+
 func TestAccountTestSuite(t *testing.T) {
 	suite.Run(t, new(AccountTestSuite))
 }
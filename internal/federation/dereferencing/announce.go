@@ -41,6 +41,9 @@ func (d *Dereferencer) EnrichAnnounce(
 	boost *gtsmodel.Status,
 	requestUser string,
 ) (*gtsmodel.Status, error) {
+	// Ensure nested calls share a budget.
+	ctx = withDefaultBudget(ctx)
+
 	targetURI := boost.BoostOfURI
 	if targetURI == "" {
 		// We can't do anything.
@@ -104,6 +107,7 @@ func (d *Dereferencer) EnrichAnnounce(
 	boost.Boostable = target.Boostable
 	boost.Replyable = target.Replyable
 	boost.Likeable = target.Likeable
+	boost.InteractionPolicy = target.InteractionPolicy
 
 	// Store the boost wrapper status in database.
 	switch err = d.state.DB.PutStatus(ctx, boost); {
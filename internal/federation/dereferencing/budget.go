@@ -0,0 +1,121 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dereferencing
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/metrics"
+)
+
+// ErrBudgetExhausted is returned (wrapped) when a remote
+// fetch is skipped because the dereference budget for the
+// current request has already been used up.
+var ErrBudgetExhausted = errors.New("dereference budget exhausted")
+
+// budgetKey is the context key
+// under which a *Budget is stored.
+type budgetKey struct{}
+
+// Budget counts remote fetches (accounts, statuses,
+// collection pages, emojis, media) made while dereferencing
+// on behalf of a single request, and places a hard limit on
+// them. This prevents pathological remote content, like an
+// enormous thread or a chain of accounts each pointing at
+// more remote media, from ballooning the cost of ingest.
+type Budget struct {
+	limit  int64
+	used   atomic.Int64
+	warned atomic.Bool
+}
+
+// NewBudget returns a new dereference budget
+// allowing up to limit remote fetches. A limit
+// of 0 or less means no limit, only counting.
+func NewBudget(limit int) *Budget {
+	return &Budget{limit: int64(limit)}
+}
+
+// Used returns the number of fetches
+// counted against this budget so far.
+func (b *Budget) Used() int {
+	return int(b.used.Load())
+}
+
+// Limit returns the configured limit of this budget.
+func (b *Budget) Limit() int {
+	return int(b.limit)
+}
+
+// WithBudget returns a copy of ctx carrying the given
+// dereference budget, which will then be shared by all
+// dereferencer calls made using the returned context.
+func WithBudget(ctx context.Context, budget *Budget) context.Context {
+	return context.WithValue(ctx, budgetKey{}, budget)
+}
+
+// BudgetFrom returns the dereference
+// budget stored in ctx, or nil if unset.
+func BudgetFrom(ctx context.Context) *Budget {
+	budget, _ := ctx.Value(budgetKey{}).(*Budget)
+	return budget
+}
+
+// withDefaultBudget returns ctx with a new budget set from
+// the configured default, if ctx doesn't already carry one.
+// This is called at each exported dereferencer entry point,
+// such that any nested dereferencing shares the same budget.
+func withDefaultBudget(ctx context.Context) context.Context {
+	if BudgetFrom(ctx) != nil {
+		return ctx
+	}
+	limit := config.GetInstanceFederationDereferenceBudget()
+	return WithBudget(ctx, NewBudget(limit))
+}
+
+// spend counts a single remote fetch of given kind
+// (eg., "account", "status") against the budget in
+// ctx, returning a wrapped ErrBudgetExhausted if the
+// fetch would exceed it. A warning is logged the first
+// time any one budget is exhausted.
+func spend(ctx context.Context, kind string) error {
+	budget := BudgetFrom(ctx)
+	if budget == nil {
+		metrics.Dereference(ctx, kind, "fetched")
+		return nil
+	}
+
+	used := budget.used.Add(1)
+	if budget.limit > 0 && used > budget.limit {
+		metrics.Dereference(ctx, kind, "over_budget")
+
+		if budget.warned.CompareAndSwap(false, true) {
+			log.Warnf(ctx, "dereference budget of %d fetches exhausted, skipping further fetches (first skipped: %s)", budget.limit, kind)
+		}
+
+		return gtserror.Newf("skipping %s fetch: %w", kind, ErrBudgetExhausted)
+	}
+
+	metrics.Dereference(ctx, kind, "fetched")
+	return nil
+}
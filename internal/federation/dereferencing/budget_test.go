@@ -0,0 +1,91 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dereferencing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/federation/dereferencing"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type BudgetTestSuite struct {
+	DereferencerStandardTestSuite
+}
+
+func (suite *BudgetTestSuite) TestBudgetSharedAcrossCalls() {
+	fetchingAccount := suite.testAccounts["local_account_1"]
+
+	// Allow only a single remote fetch for this "request".
+	budget := dereferencing.NewBudget(1)
+	ctx := dereferencing.WithBudget(context.Background(), budget)
+
+	// First new account should be fetched fine.
+	group, _, err := suite.dereferencer.GetAccountByURI(ctx,
+		fetchingAccount.Username,
+		testrig.URLMustParse("https://unknown-instance.com/groups/some_group"),
+	)
+	suite.NoError(err)
+	suite.NotNil(group)
+	suite.GreaterOrEqual(budget.Used(), 1)
+
+	// Budget is now spent, so the next new
+	// account using the same context is skipped.
+	service, _, err := suite.dereferencer.GetAccountByURI(ctx,
+		fetchingAccount.Username,
+		testrig.URLMustParse("https://owncast.example.org/federation/user/rgh"),
+	)
+	suite.ErrorIs(err, dereferencing.ErrBudgetExhausted)
+	suite.Nil(service)
+
+	// A fresh context gets a fresh default budget.
+	service, _, err = suite.dereferencer.GetAccountByURI(context.Background(),
+		fetchingAccount.Username,
+		testrig.URLMustParse("https://owncast.example.org/federation/user/rgh"),
+	)
+	suite.NoError(err)
+	suite.NotNil(service)
+}
+
+func (suite *BudgetTestSuite) TestBudgetUnlimited() {
+	fetchingAccount := suite.testAccounts["local_account_1"]
+
+	// A limit of 0 only counts fetches.
+	budget := dereferencing.NewBudget(0)
+	ctx := dereferencing.WithBudget(context.Background(), budget)
+
+	for _, uri := range []string{
+		"https://unknown-instance.com/groups/some_group",
+		"https://owncast.example.org/federation/user/rgh",
+	} {
+		account, _, err := suite.dereferencer.GetAccountByURI(ctx,
+			fetchingAccount.Username,
+			testrig.URLMustParse(uri),
+		)
+		suite.NoError(err)
+		suite.NotNil(account)
+	}
+
+	suite.GreaterOrEqual(budget.Used(), 2)
+}
+
+func TestBudgetTestSuite(t *testing.T) {
+	suite.Run(t, new(BudgetTestSuite))
+}
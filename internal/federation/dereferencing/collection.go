@@ -38,6 +38,10 @@ func (d *Dereferencer) dereferenceCollection(ctx context.Context, username strin
 		return nil, gtserror.Newf("error creating transport: %w", err)
 	}
 
+	if err := spend(ctx, "collection"); err != nil {
+		return nil, err
+	}
+
 	rsp, err := transport.Dereference(ctx, pageIRI)
 	if err != nil {
 		return nil, gtserror.Newf("error dereferencing %s: %w", pageIRI.String(), err)
@@ -66,6 +70,10 @@ func (d *Dereferencer) dereferenceCollectionPage(ctx context.Context, username s
 		return nil, gtserror.Newf("error creating transport: %w", err)
 	}
 
+	if err := spend(ctx, "collection"); err != nil {
+		return nil, err
+	}
+
 	rsp, err := transport.Dereference(ctx, pageIRI)
 	if err != nil {
 		return nil, gtserror.Newf("error deferencing %s: %w", pageIRI.String(), err)
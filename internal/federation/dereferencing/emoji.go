@@ -33,6 +33,9 @@ import (
 )
 
 func (d *Dereferencer) GetRemoteEmoji(ctx context.Context, requestUser string, remoteURL string, shortcode string, domain string, id string, emojiURI string, ai *media.AdditionalEmojiInfo, refresh bool) (*media.ProcessingEmoji, error) {
+	// Ensure nested calls share a budget.
+	ctx = withDefaultBudget(ctx)
+
 	var shortcodeDomain = shortcode + "@" + domain
 
 	// Ensure we have been passed a valid URL.
@@ -59,6 +62,11 @@ func (d *Dereferencer) GetRemoteEmoji(ctx context.Context, requestUser string, r
 			return nil, gtserror.Newf("couldn't create transport: %w", err)
 		}
 
+		// Count emoji fetch against budget.
+		if err := spend(ctx, "emoji"); err != nil {
+			return nil, err
+		}
+
 		// Set the media data function to dereference emoji from URI.
 		data := func(ctx context.Context) (io.ReadCloser, int64, error) {
 			return tsport.DereferenceMedia(ctx, derefURI)
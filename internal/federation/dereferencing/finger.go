@@ -82,6 +82,14 @@ func (d *Dereferencer) fingerRemoteAccount(
 		return "", nil, gtserror.Newf("response username does not match input for %s: %w", target, err)
 	}
 
+	// Normalize the discovered domain as punycode,
+	// since the subject may contain an IDN domain.
+	accDomain, err = util.Punify(accDomain)
+	if err != nil {
+		err = gtserror.Newf("error punifying subject domain for %s: %w", target, err)
+		return "", nil, err
+	}
+
 	// Look through links for the first
 	// one that matches what we need:
 	//
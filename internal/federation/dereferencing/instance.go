@@ -26,6 +26,9 @@ import (
 )
 
 func (d *Dereferencer) GetRemoteInstance(ctx context.Context, username string, remoteInstanceURI *url.URL) (*gtsmodel.Instance, error) {
+	// Ensure nested calls share a budget.
+	ctx = withDefaultBudget(ctx)
+
 	if blocked, err := d.state.DB.IsDomainBlocked(ctx, remoteInstanceURI.Host); blocked || err != nil {
 		return nil, fmt.Errorf("GetRemoteInstance: domain %s is blocked", remoteInstanceURI.Host)
 	}
@@ -35,5 +38,9 @@ func (d *Dereferencer) GetRemoteInstance(ctx context.Context, username string, r
 		return nil, fmt.Errorf("transport err: %s", err)
 	}
 
+	if err := spend(ctx, "instance"); err != nil {
+		return nil, err
+	}
+
 	return transport.DereferenceInstance(ctx, remoteInstanceURI)
 }
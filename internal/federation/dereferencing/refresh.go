@@ -0,0 +1,141 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dereferencing
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// refreshJitter is the maximum random delay
+// before each background account refresh,
+// to avoid hitting remotes in lockstep.
+const refreshJitter = time.Second
+
+// RefreshStaleAccounts refreshes remote accounts which have not
+// been fetched since fetchedBefore, so that their profile info,
+// avatar / header and public keys don't go permanently stale if
+// nothing else causes them to be dereferenced. Up to perDomain
+// accounts from any one remote domain are refreshed concurrently.
+//
+// Returns the number of accounts that were actually updated.
+func (d *Dereferencer) RefreshStaleAccounts(
+	ctx context.Context,
+	fetchedBefore time.Time,
+	perDomain int,
+) (int, error) {
+	var (
+		limit   = 50   // Limit selection to avoid spiking mem/cpu.
+		maxID   string // Start with empty string to select from top.
+		updated atomic.Int64
+	)
+
+	if perDomain < 1 {
+		perDomain = 1
+	}
+
+	for {
+		// Get (next) page of stale accounts.
+		accounts, err := d.state.DB.GetStaleAccounts(ctx, fetchedBefore, maxID, limit)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			// Real db error.
+			return int(updated.Load()), gtserror.Newf("db error getting stale accounts: %w", err)
+		}
+
+		if len(accounts) == 0 {
+			// No accounts left, we're done.
+			return int(updated.Load()), nil
+		}
+
+		// Set next max ID for paging down.
+		maxID = accounts[len(accounts)-1].ID
+
+		// Group page of accounts by domain.
+		byDomain := make(map[string][]*gtsmodel.Account)
+		for _, account := range accounts {
+			byDomain[account.Domain] = append(byDomain[account.Domain], account)
+		}
+
+		// Refresh each domain's accounts with up to
+		// perDomain workers, waiting for the whole page
+		// to finish so limits also hold across pages.
+		var wg sync.WaitGroup
+		for _, domainAccounts := range byDomain {
+			queue := make(chan *gtsmodel.Account, len(domainAccounts))
+			for _, account := range domainAccounts {
+				queue <- account
+			}
+			close(queue)
+
+			workers := min(perDomain, len(domainAccounts))
+			for i := 0; i < workers; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for account := range queue {
+						if d.refreshStaleAccount(ctx, fetchedBefore, account) {
+							updated.Add(1)
+						}
+					}
+				}()
+			}
+		}
+		wg.Wait()
+
+		if err := ctx.Err(); err != nil {
+			return int(updated.Load()), err
+		}
+	}
+}
+
+// refreshStaleAccount refreshes the given stale account after
+// a short random delay, returning whether it was updated.
+func (d *Dereferencer) refreshStaleAccount(
+	ctx context.Context,
+	fetchedBefore time.Time,
+	account *gtsmodel.Account,
+) bool {
+	delay := time.Duration(rand.Int63n(int64(refreshJitter))) //nolint:gosec
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(delay):
+	}
+
+	// Only refresh if still stale, in case
+	// something else refreshed it meanwhile.
+	window := util.Ptr(FreshnessWindow(time.Since(fetchedBefore)))
+
+	_, accountable, err := d.RefreshAccount(ctx, "", account, nil, window)
+	if err != nil {
+		log.Warnf(ctx, "error refreshing stale account %s: %v", account.URI, err)
+		return false
+	}
+
+	return accountable != nil
+}
@@ -20,6 +20,7 @@ package dereferencing
 import (
 	"context"
 	"errors"
+	"net/http"
 	"net/url"
 	"slices"
 	"time"
@@ -78,6 +79,8 @@ func statusFresh(
 // is beyond a certain interval, the status will be dereferenced. In the case of dereferencing, some low-priority status information may be enqueued for asynchronous fetching,
 // e.g. dereferencing the status thread. Param 'syncParent' = true indicates to fetch status ancestors synchronously. An ActivityPub object indicates the status was dereferenced.
 func (d *Dereferencer) GetStatusByURI(ctx context.Context, requestUser string, uri *url.URL) (*gtsmodel.Status, ap.Statusable, error) {
+	// Ensure nested calls share a budget.
+	ctx = withDefaultBudget(ctx)
 
 	// Fetch and dereference / update status if necessary.
 	status, statusable, isNew, err := d.getStatusByURI(ctx,
@@ -192,6 +195,9 @@ func (d *Dereferencer) RefreshStatus(
 	statusable ap.Statusable,
 	window *FreshnessWindow,
 ) (*gtsmodel.Status, ap.Statusable, error) {
+	// Ensure nested calls share a budget.
+	ctx = withDefaultBudget(ctx)
+
 	// If no incoming data is provided,
 	// check whether status needs update.
 	if statusable == nil &&
@@ -239,6 +245,9 @@ func (d *Dereferencer) RefreshStatusAsync(
 	statusable ap.Statusable,
 	window *FreshnessWindow,
 ) {
+	// Ensure nested calls share a budget.
+	ctx = withDefaultBudget(ctx)
+
 	// If no incoming data is provided,
 	// check whether status needs update.
 	if statusable == nil &&
@@ -253,8 +262,11 @@ func (d *Dereferencer) RefreshStatusAsync(
 		return
 	}
 
-	// Enqueue a worker function to re-fetch this status entirely async.
+	// Enqueue a worker function to re-fetch this status entirely async,
+	// carrying over the budget of the enqueuing request.
+	budget := BudgetFrom(ctx)
 	d.state.Workers.Dereference.Queue.Push(func(ctx context.Context) {
+		ctx = WithBudget(ctx, budget)
 		latest, statusable, _, err := d.enrichStatusSafely(ctx,
 			requestUser,
 			uri,
@@ -298,6 +310,22 @@ func (d *Dereferencer) enrichStatusSafely(
 		if err := d.state.DB.PopulateStatus(ctx, status); err != nil {
 			log.Errorf(ctx, "error populating existing status %s: %v", uriStr, err)
 		}
+	} else {
+
+		// This is a new status, check whether we've kept a
+		// tombstone for it, ie., it was previously deleted.
+		// If so don't recreate it, it's gone for good.
+		gone, err := d.state.DB.TombstoneExistsWithURI(ctx, uriStr)
+		if err != nil {
+			err := gtserror.Newf("error checking tombstone for %s: %w", uriStr, err)
+			return nil, nil, isNew, err
+		}
+
+		if gone {
+			err := gtserror.Newf("status %s was deleted", uriStr)
+			err = gtserror.WithStatusCode(err, http.StatusGone)
+			return nil, nil, isNew, gtserror.SetUnretrievable(err)
+		}
 	}
 
 	// Acquire per-URI deref lock, wraping unlock
@@ -392,6 +420,9 @@ func (d *Dereferencer) enrichStatus(
 
 	if apubStatus == nil {
 		// Dereference latest version of the status.
+		if err := spend(ctx, "status"); err != nil {
+			return nil, nil, err
+		}
 		rsp, err := tsport.Dereference(ctx, uri)
 		if err != nil {
 			err := gtserror.Newf("error dereferencing %s: %w", uri, err)
@@ -459,6 +490,11 @@ func (d *Dereferencer) enrichStatus(
 		return nil, nil, gtserror.Newf("error converting statusable to gts model for status %s: %w", uri, err)
 	}
 
+	// Store digest of the content as received, so any later
+	// re-delivery of this status without an Update can be
+	// checked for differing (ie., possibly spoofed) content.
+	latestStatus.ContentDigest = latestStatus.ComputeContentDigest()
+
 	// Ensure final status isn't attempting
 	// to claim being authored by local user.
 	if latestStatus.Account.IsLocal() {
@@ -19,6 +19,7 @@ package dereferencing
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/url"
 
@@ -46,6 +47,10 @@ func (d *Dereferencer) dereferenceThread(
 	statusable ap.Statusable,
 	isNew bool,
 ) {
+	// Async parts of the thread continue
+	// to spend the budget of this request.
+	budget := BudgetFrom(ctx)
+
 	if isNew {
 		// This is a new status that we need the ancestors of in
 		// order to determine visibility. Perform the initial part
@@ -57,6 +62,7 @@ func (d *Dereferencer) dereferenceThread(
 
 		// Enqueue dereferencing remaining status thread, (children), asychronously .
 		d.state.Workers.Dereference.Queue.Push(func(ctx context.Context) {
+			ctx = WithBudget(ctx, budget)
 			if err := d.DereferenceStatusDescendants(ctx, requestUser, uri, statusable); err != nil {
 				log.Error(ctx, err)
 			}
@@ -64,6 +70,7 @@ func (d *Dereferencer) dereferenceThread(
 	} else {
 		// This is an existing status, dereference the WHOLE thread asynchronously.
 		d.state.Workers.Dereference.Queue.Push(func(ctx context.Context) {
+			ctx = WithBudget(ctx, budget)
 			if err := d.DereferenceStatusAncestors(ctx, requestUser, status); err != nil {
 				log.Error(ctx, err)
 			}
@@ -76,6 +83,9 @@ func (d *Dereferencer) dereferenceThread(
 
 // DereferenceStatusAncestors iterates upwards from the given status, using InReplyToURI, to ensure that as many parent statuses as possible are dereferenced.
 func (d *Dereferencer) DereferenceStatusAncestors(ctx context.Context, username string, status *gtsmodel.Status) error {
+	// Ensure nested calls share a budget.
+	ctx = withDefaultBudget(ctx)
+
 	// Start log entry with fields
 	l := log.WithContext(ctx).
 		WithFields(kv.Fields{
@@ -220,6 +230,9 @@ func (d *Dereferencer) DereferenceStatusAncestors(ctx context.Context, username
 
 // DereferenceStatusDescendents iterates downwards from the given status, using its replies, to ensure that as many children statuses as possible are dereferenced.
 func (d *Dereferencer) DereferenceStatusDescendants(ctx context.Context, username string, statusIRI *url.URL, parent ap.Statusable) error {
+	// Ensure nested calls share a budget.
+	ctx = withDefaultBudget(ctx)
+
 	statusIRIStr := statusIRI.String()
 
 	// Start log entry with fields
@@ -328,6 +341,12 @@ stackLoop:
 				//   - any http type error for a new status returns unretrievable
 				_, statusable, _, err := d.getStatusByURI(ctx, username, itemIRI)
 				if err != nil {
+					if errors.Is(err, ErrBudgetExhausted) {
+						// No point walking
+						// the rest of thread.
+						return err
+					}
+
 					l.Errorf("error dereferencing remote status %s: %v", itemIRI, err)
 					continue itemLoop
 				}
@@ -382,6 +401,10 @@ stackLoop:
 				nextURI,
 			)
 			if err != nil {
+				if errors.Is(err, ErrBudgetExhausted) {
+					return err
+				}
+
 				l.Errorf("error dereferencing collection page %q: %s", nextURIStr, err)
 				continue stackLoop
 			}
@@ -48,6 +48,11 @@ func (d *Dereferencer) loadAttachment(
 		return nil, gtserror.Newf("invalid remote media url %q: %v", remoteURL, err)
 	}
 
+	// Count media fetch against budget.
+	if err := spend(ctx, "media"); err != nil {
+		return nil, err
+	}
+
 	// Start pre-processing remote media at remote URL.
 	processing := d.mediaManager.PreProcessMedia(
 		func(ctx context.Context) (io.ReadCloser, int64, error) {
@@ -110,6 +115,11 @@ func (d *Dereferencer) updateAttachment(
 		return nil, gtserror.Newf("invalid remote media url %q: %v", media.RemoteURL, err)
 	}
 
+	// Count media fetch against budget.
+	if err := spend(ctx, "media"); err != nil {
+		return existing, err
+	}
+
 	// Start pre-processing remote media recaching from remote.
 	processing, err := d.mediaManager.PreProcessMediaRecache(
 		ctx,
@@ -33,6 +33,15 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/metrics"
+)
+
+// Outcomes of inbox POST
+// activities, for metrics.
+const (
+	inboundAccepted       = "accepted"
+	inboundDroppedBlocked = "dropped-blocked"
+	inboundDroppedInvalid = "dropped-invalid"
 )
 
 // federatingActor wraps the pub.FederatingActor
@@ -114,11 +123,15 @@ func (f *federatingActor) PostInboxScheme(ctx context.Context, w http.ResponseWr
 	// Resolve the activity, rejecting badly formatted / transient.
 	activity, ok, errWithCode := ap.ResolveIncomingActivity(r)
 	if errWithCode != nil {
+		metrics.InboundActivity(ctx, "unknown", inboundDroppedInvalid)
 		return false, errWithCode
 	} else if !ok { // transient
 		return false, nil
 	}
 
+	// Activity type name, for metrics.
+	activityType := activity.GetTypeName()
+
 	// Set additional context data. Primarily this means
 	// looking at the Activity and seeing which IRIs are
 	// involved in it tangentially.
@@ -139,6 +152,7 @@ func (f *federatingActor) PostInboxScheme(ctx context.Context, w http.ResponseWr
 			// by the receiver. We don't need to return 403 here,
 			// instead, just return 202 accepted but don't do any
 			// further processing of the activity.
+			metrics.InboundActivity(ctx, activityType, inboundDroppedBlocked)
 			return true, nil //nolint
 		}
 
@@ -151,6 +165,7 @@ func (f *federatingActor) PostInboxScheme(ctx context.Context, w http.ResponseWr
 		// Block exists either from this instance against
 		// one or more directly involved actors, or between
 		// receiving account and one of those actors.
+		metrics.InboundActivity(ctx, activityType, inboundDroppedBlocked)
 		const text = "blocked"
 		return false, gtserror.NewErrorForbidden(errors.New(text), text)
 	}
@@ -180,6 +195,7 @@ func (f *federatingActor) PostInboxScheme(ctx context.Context, w http.ResponseWr
 			// Log malformed activities to help debug.
 			l = l.WithField("activity", activity)
 			l.Warnf("malformed incoming activity: %v", err)
+			metrics.InboundActivity(ctx, activityType, inboundDroppedInvalid)
 
 			const text = "malformed incoming activity"
 			return false, gtserror.NewErrorBadRequest(errors.New(text), text)
@@ -190,6 +206,8 @@ func (f *federatingActor) PostInboxScheme(ctx context.Context, w http.ResponseWr
 		return false, gtserror.NewErrorInternalError(err)
 	}
 
+	metrics.InboundActivity(ctx, activityType, inboundAccepted)
+
 	// Side effects are complete. Now delegate determining whether
 	// to do inbox forwarding, as well as the action to do it.
 	if err := f.sideEffectActor.InboxForwarding(ctx, inboxID, activity); err != nil {
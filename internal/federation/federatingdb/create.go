@@ -326,6 +326,21 @@ func (f *federatingDB) createStatusable(
 	statusable ap.Statusable,
 	forwarded bool,
 ) error {
+	if uri := ap.GetJSONLDId(statusable); uri != nil {
+		// Check whether we've kept a tombstone for
+		// this status, ie., it was already deleted,
+		// in which case this is a stale re-delivery.
+		gone, err := f.state.DB.TombstoneExistsWithURI(ctx, uri.String())
+		if err != nil {
+			return gtserror.Newf("error checking tombstone: %w", err)
+		}
+
+		if gone {
+			log.Debugf(ctx, "status %s was deleted; dropping it", uri)
+			return nil
+		}
+	}
+
 	// Check whether this status is both
 	// relevant, and doesn't look like spam.
 	err := f.spamFilter.StatusableOK(ctx,
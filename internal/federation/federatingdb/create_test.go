@@ -45,7 +45,20 @@ func (suite *CreateTestSuite) TestCreateNote() {
 	objProp := create.GetActivityStreamsObject()
 	note := objProp.At(0).GetType().(ap.Statusable)
 
-	err := suite.federatingDB.Create(ctx, create)
+	// ensure a follow exists between requesting and
+	// receiving account, so the DM isn't considered
+	// first contact and dropped by the spam filter.
+	err := suite.db.PutFollow(ctx, &gtsmodel.Follow{
+		ID:              id.NewULID(),
+		URI:             "https://this.is.a.url",
+		AccountID:       requestingAccount.ID,
+		TargetAccountID: receivingAccount.ID,
+		ShowReblogs:     util.Ptr(true),
+		Notify:          util.Ptr(false),
+	})
+	suite.NoError(err)
+
+	err = suite.federatingDB.Create(ctx, create)
 	suite.NoError(err)
 
 	// should be a message heading to the processor now, which we can intercept here
@@ -55,6 +68,25 @@ func (suite *CreateTestSuite) TestCreateNote() {
 	suite.Equal(note, msg.APObject)
 }
 
+func (suite *CreateTestSuite) TestCreateNoteFirstContactDM() {
+	receivingAccount := suite.testAccounts["local_account_1"]
+	requestingAccount := suite.testAccounts["remote_account_1"]
+
+	ctx := createTestContext(receivingAccount, requestingAccount)
+
+	// requesting account has had no contact with
+	// receiving account, so with the spam filter on
+	// this DM should be dropped without error.
+	create := suite.testActivities["dm_for_zork"].Activity
+
+	err := suite.federatingDB.Create(ctx, create)
+	suite.NoError(err)
+
+	// nothing should be heading to the processor
+	_, ok := suite.getFederatorMsg(time.Second)
+	suite.False(ok)
+}
+
 func (suite *CreateTestSuite) TestCreateNoteForward() {
 	receivingAccount := suite.testAccounts["local_account_1"]
 	requestingAccount := suite.testAccounts["remote_account_1"]
@@ -26,6 +26,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 )
@@ -150,6 +151,18 @@ func (f *federatingDB) deleteStatus(
 			return true, nil
 		}
 
+		// Keep a tombstone of the status URI, so that any
+		// re-deliveries or re-dereferences don't recreate it.
+		// This is only done for genuine deletes by the author,
+		// statuses otherwise removed may be fetched again.
+		if err := f.state.DB.PutTombstone(ctx, &gtsmodel.Tombstone{
+			ID:     id.NewULID(),
+			Domain: requesting.Domain,
+			URI:    status.URI,
+		}); err != nil && !errors.Is(err, db.ErrAlreadyExists) {
+			return false, gtserror.Newf("error putting tombstone: %w", err)
+		}
+
 		log.Debugf(ctx, "deleting status: %s", status.URI)
 		f.state.Workers.Federator.Queue.Push(&messages.FromFediAPI{
 			APObjectType:   ap.ObjectNote,
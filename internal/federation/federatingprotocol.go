@@ -231,6 +231,22 @@ func (f *Federator) AuthenticatePostInbox(ctx context.Context, w http.ResponseWr
 		return ctx, false, errWithCode
 	}
 
+	// Check whether ingestion from the requesting
+	// domain has been paused by an admin. If so,
+	// return 503 so that the remote will retry
+	// the delivery at some later point in time.
+	paused, err := f.db.IsDomainIngestionPaused(ctx, pubKeyAuth.OwnerURI.Hostname())
+	if err != nil {
+		err = gtserror.Newf("error checking domain pause: %w", err)
+		return ctx, false, err
+	}
+
+	if paused {
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return ctx, false, nil
+	}
+
 	if pubKeyAuth.Handshaking {
 		// There is a mutal handshake occurring between us and
 		// the owner URI. Return 202 and leave as we can't do
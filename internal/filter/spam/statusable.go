@@ -63,7 +63,7 @@ type preppedMention struct {
 //  1. Receiver follow requester. Return nil.
 //  2. Statusable doesn't mention receiver. Return NotRelevant.
 //
-// If instance-federation-spam-filter = false, then return nil now.
+// If the spam filter mode for receiver is "off", then return nil now.
 // Otherwise check:
 //
 //  3. Receiver is locked and is followed by requester. Return nil.
@@ -71,6 +71,10 @@ type preppedMention struct {
 //  5. Receiver follow (requests) a mentioned account. Return nil.
 //  6. Statusable has a media attachment. Return Spam.
 //  7. Statusable contains non-mention, non-hashtag links. Return Spam.
+//  8. Statusable is a first-contact direct message. Return Spam.
+//
+// If the spam filter mode for receiver is "log", then any Spam
+// result is logged, and nil is returned instead of the error.
 func (f *Filter) StatusableOK(
 	ctx context.Context,
 	receiver *gtsmodel.Account,
@@ -104,14 +108,78 @@ func (f *Filter) StatusableOK(
 	// Receiver is mentioned, but not by someone
 	// they follow. Check if we need to do more
 	// granular spam filtering.
-	if !config.GetInstanceFederationSpamFilter() {
+	mode, err := f.spamFilterMode(ctx, receiver)
+	if err != nil {
+		return err
+	}
+
+	switch mode {
+	case config.SpamFilterModeOff:
 		// Filter is not enabled, allow it
 		// through without further checks.
 		return nil
+
+	case config.SpamFilterModeLog:
+		// Filter is in log-only mode, so just
+		// log anything identified as spam, and
+		// allow it through regardless.
+		err := f.statusableSpam(ctx, receiver, requester, statusable, mentions)
+		if gtserror.IsSpam(err) {
+			log.Infof(ctx,
+				"status %s from %s to %s looks like spam (%v); log-only mode so allowing it",
+				ap.GetJSONLDId(statusable), requester.URI, receiver.URI, err,
+			)
+			return nil
+		}
+		return err
+
+	default:
+		return f.statusableSpam(ctx, receiver, requester, statusable, mentions)
+	}
+}
+
+// spamFilterMode returns the spam filter mode to use for
+// statuses sent to receiver, preferring any override set
+// in the receiver's account settings over the instance mode.
+func (f *Filter) spamFilterMode(
+	ctx context.Context,
+	receiver *gtsmodel.Account,
+) (string, error) {
+	settings := receiver.Settings
+	if settings == nil {
+		var err error
+		settings, err = f.state.DB.GetAccountSettings(ctx, receiver.ID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return "", gtserror.Newf("db error getting account settings: %w", err)
+		}
 	}
 
-	// More granular spam filtering time!
-	//
+	if settings != nil && settings.SpamFilterMode != "" {
+		// Receiver has overridden the mode.
+		return settings.SpamFilterMode, nil
+	}
+
+	if mode := config.GetInstanceFederationSpamFilterMode(); mode != "" {
+		return mode, nil
+	}
+
+	// Fall back to the deprecated bool setting.
+	if config.GetInstanceFederationSpamFilter() {
+		return config.SpamFilterModeDrop, nil
+	}
+	return config.SpamFilterModeOff, nil
+}
+
+// statusableSpam performs the more granular spam filtering
+// heuristics (3 onwards) documented on StatusableOK, returning
+// a Spam error if the statusable looks like spam.
+func (f *Filter) statusableSpam(
+	ctx context.Context,
+	receiver *gtsmodel.Account,
+	requester *gtsmodel.Account,
+	statusable ap.Statusable,
+	mentions []preppedMention,
+) error {
 	// HEURISTIC 3: Does requester follow locked receiver?
 	followedBy, err := f.lockedFollowedBy(ctx, receiver, requester)
 	if err != nil {
@@ -159,10 +227,77 @@ func (f *Filter) StatusableOK(
 		return gtserror.SetSpam(err)
 	}
 
+	// HEURISTIC 8: Is this a direct message from
+	// someone receiver has never had contact with?
+	firstContact, err := f.firstContactDM(ctx, receiver, requester, statusable)
+	if err != nil {
+		return gtserror.Newf("db error checking first contact: %w", err)
+	}
+
+	if firstContact {
+		err := errors.New("status is a first-contact direct message")
+		return gtserror.SetSpam(err)
+	}
+
 	// Looks OK.
 	return nil
 }
 
+// firstContactDM returns true if the statusable
+// is a direct message, and requester has had no
+// previous contact with receiver, ie., no follow
+// (request) in either direction, and the statusable
+// is not a reply to one of receiver's statuses.
+func (f *Filter) firstContactDM(
+	ctx context.Context,
+	receiver *gtsmodel.Account,
+	requester *gtsmodel.Account,
+	statusable ap.Statusable,
+) (bool, error) {
+	visibility, err := ap.ExtractVisibility(statusable, requester.FollowersURI)
+	if err != nil || visibility != gtsmodel.VisibilityDirect {
+		// Not a DM (or we
+		// can't tell), fine.
+		return false, nil
+	}
+
+	// Does requester follow receiver?
+	follows, err := f.state.DB.IsFollowing(ctx, requester.ID, receiver.ID)
+	if err != nil || follows {
+		return false, err
+	}
+
+	// Has either account requested
+	// to follow the other?
+	followRequested, err := f.state.DB.IsFollowRequested(ctx, requester.ID, receiver.ID)
+	if err != nil || followRequested {
+		return false, err
+	}
+
+	followRequested, err = f.state.DB.IsFollowRequested(ctx, receiver.ID, requester.ID)
+	if err != nil || followRequested {
+		return false, err
+	}
+
+	// Is this a reply to one of receiver's statuses?
+	if inReplyTo := ap.ExtractInReplyToURI(statusable); inReplyTo != nil {
+		status, err := f.state.DB.GetStatusByURI(
+			gtscontext.SetBarebones(ctx),
+			inReplyTo.String(),
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return false, err
+		}
+
+		if status != nil && status.AccountID == receiver.ID {
+			// Reply to receiver, fine.
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
 // prepMentions prepares a slice of mentions
 // for spam checking by parsing out the namestring
 // and targetAccountURI values, if present.
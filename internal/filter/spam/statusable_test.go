@@ -25,6 +25,7 @@ import (
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
@@ -617,6 +618,38 @@ const (
       }
     }
   }`
+	// Direct message that mentions only the receiver,
+	// with no links or attachments, and is not a reply.
+	spam9 = `{
+  "@context": [
+    "https://www.w3.org/ns/activitystreams",
+    {
+      "sensitive": "as:sensitive",
+      "toot": "http://joinmastodon.org/ns#"
+    }
+  ],
+  "id": "http://fossbros-anonymous.io/users/foss_satan/statuses/111985188827079599",
+  "type": "Note",
+  "summary": null,
+  "inReplyTo": null,
+  "published": "2024-02-24T07:06:14Z",
+  "url": "http://fossbros-anonymous.io/@foss_satan/111985188827079599",
+  "attributedTo": "http://fossbros-anonymous.io/users/foss_satan",
+  "to": [
+    "http://localhost:8080/users/the_mighty_zork"
+  ],
+  "cc": [],
+  "sensitive": false,
+  "content": "<p><span class=\"h-card\" translate=\"no\"><a href=\"http://localhost:8080/@the_mighty_zork\" class=\"u-url mention\">@<span>the_mighty_zork</span></a></span> hey, got a minute?</p>",
+  "attachment": [],
+  "tag": [
+    {
+      "type": "Mention",
+      "href": "http://localhost:8080/users/the_mighty_zork",
+      "name": "@the_mighty_zork@localhost:8080"
+    }
+  ]
+}`
 )
 
 func (suite *StatusableTestSuite) TestStatusableOK() {
@@ -775,6 +808,97 @@ func (suite *StatusableTestSuite) TestStatusableOK() {
 	}
 }
 
+func (suite *StatusableTestSuite) resolve(message string) ap.Statusable {
+	rc := io.NopCloser(bytes.NewReader([]byte(message)))
+
+	statusable, err := ap.ResolveStatusable(context.Background(), rc)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return statusable
+}
+
+func (suite *StatusableTestSuite) TestStatusableOKLogOnly() {
+	var (
+		ctx       = context.Background()
+		receiver  = suite.testAccounts["local_account_1"]
+		requester = suite.testAccounts["remote_account_1"]
+	)
+
+	config.SetInstanceFederationSpamFilterMode(config.SpamFilterModeLog)
+
+	// Spam should be let through in log-only mode.
+	err := suite.filter.StatusableOK(ctx, receiver, requester, suite.resolve(spam1))
+	suite.NoError(err, "expected not spam, got %+v", err)
+
+	// But relevancy checks still apply.
+	err = suite.filter.StatusableOK(ctx, receiver, requester, suite.resolve(spam6))
+	suite.True(gtserror.IsNotRelevant(err), "expected NotRelevant, got %+v", err)
+}
+
+func (suite *StatusableTestSuite) TestStatusableOKAccountOverride() {
+	var (
+		ctx       = context.Background()
+		receiver  = new(gtsmodel.Account)
+		requester = suite.testAccounts["remote_account_1"]
+	)
+
+	// Copy receiver so we can change settings.
+	*receiver = *suite.testAccounts["local_account_1"]
+	receiver.Settings = &gtsmodel.AccountSettings{
+		AccountID: receiver.ID,
+	}
+
+	// Instance mode is drop,
+	// so this should be spam.
+	config.SetInstanceFederationSpamFilterMode(config.SpamFilterModeDrop)
+	err := suite.filter.StatusableOK(ctx, receiver, requester, suite.resolve(spam1))
+	suite.True(gtserror.IsSpam(err), "expected Spam, got %+v", err)
+
+	// Receiver has turned the
+	// filter off for themself.
+	receiver.Settings.SpamFilterMode = config.SpamFilterModeOff
+	err = suite.filter.StatusableOK(ctx, receiver, requester, suite.resolve(spam1))
+	suite.NoError(err, "expected not spam, got %+v", err)
+
+	// Instance mode is off, but
+	// receiver has turned it on.
+	config.SetInstanceFederationSpamFilterMode(config.SpamFilterModeOff)
+	receiver.Settings.SpamFilterMode = config.SpamFilterModeDrop
+	err = suite.filter.StatusableOK(ctx, receiver, requester, suite.resolve(spam1))
+	suite.True(gtserror.IsSpam(err), "expected Spam, got %+v", err)
+}
+
+func (suite *StatusableTestSuite) TestStatusableOKFirstContactDM() {
+	var (
+		ctx       = context.Background()
+		receiver  = suite.testAccounts["local_account_1"]
+		requester = suite.testAccounts["remote_account_1"]
+	)
+
+	// DM from someone receiver has
+	// never had contact with is spam.
+	err := suite.filter.StatusableOK(ctx, receiver, requester, suite.resolve(spam9))
+	suite.True(gtserror.IsSpam(err), "expected Spam, got %+v", err)
+
+	// Put a follow request in place
+	// from requester to receiver.
+	frID := id.NewULID()
+	if err := suite.state.DB.PutFollowRequest(ctx, &gtsmodel.FollowRequest{
+		ID:              frID,
+		URI:             "http://fossbros-anonymous.io/users/foss_satan/follows/" + frID,
+		AccountID:       requester.ID,
+		TargetAccountID: receiver.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// DM should now be OK.
+	err = suite.filter.StatusableOK(ctx, receiver, requester, suite.resolve(spam9))
+	suite.NoError(err, "expected not spam, got %+v", err)
+}
+
 func TestStatusableTestSuite(t *testing.T) {
 	suite.Run(t, &StatusableTestSuite{})
 }
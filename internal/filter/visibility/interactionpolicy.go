@@ -0,0 +1,126 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package visibility
+
+import (
+	"context"
+	"strings"
+
+	"github.com/superseriousbusiness/activity/pub"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// StatusReplyPermitted checks whether the remote interaction policy set on
+// status (if any) permits requester to reply to it. This does not check
+// visibility, which should be checked separately beforehand.
+func (f *Filter) StatusReplyPermitted(ctx context.Context, requester *gtsmodel.Account, status *gtsmodel.Status) (bool, error) {
+	if status.InteractionPolicy == nil {
+		// No policy set.
+		return true, nil
+	}
+
+	if status.MentionsAccount(requester.ID) {
+		// Mentioned accounts
+		// can always reply.
+		return true, nil
+	}
+
+	return f.interactionPermitted(ctx, requester, status, status.InteractionPolicy.CanReply)
+}
+
+// StatusAnnouncePermitted checks whether the remote interaction policy set on
+// status (if any) permits requester to boost it. This does not check
+// visibility, which should be checked separately beforehand.
+func (f *Filter) StatusAnnouncePermitted(ctx context.Context, requester *gtsmodel.Account, status *gtsmodel.Status) (bool, error) {
+	if status.InteractionPolicy == nil {
+		// No policy set.
+		return true, nil
+	}
+
+	return f.interactionPermitted(ctx, requester, status, status.InteractionPolicy.CanAnnounce)
+}
+
+// interactionPermitted checks whether requester is
+// covered by one of the permitted URIs of a single
+// interaction policy rule set on the given status.
+func (f *Filter) interactionPermitted(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	status *gtsmodel.Status,
+	permitted []string,
+) (bool, error) {
+	if permitted == nil {
+		// Rule not set,
+		// so no limits.
+		return true, nil
+	}
+
+	if requester.ID == status.AccountID {
+		// Authors can always
+		// interact with own status.
+		return true, nil
+	}
+
+	if status.Account == nil {
+		// Ensure status author is populated.
+		author, err := f.state.DB.GetAccountByID(ctx, status.AccountID)
+		if err != nil {
+			return false, gtserror.Newf("error getting status author: %w", err)
+		}
+		status.Account = author
+	}
+
+	for _, uri := range permitted {
+		switch {
+		case pub.IsPublic(uri),
+			strings.EqualFold(uri, requester.URI):
+			return true, nil
+
+		case uri == status.Account.FollowersURI:
+			// Permitted if requester follows author.
+			follows, err := f.state.DB.IsFollowing(ctx,
+				requester.ID,
+				status.AccountID,
+			)
+			if err != nil {
+				return false, gtserror.Newf("error checking follow: %w", err)
+			}
+
+			if follows {
+				return true, nil
+			}
+
+		case uri == status.Account.FollowingURI:
+			// Permitted if author follows requester.
+			follows, err := f.state.DB.IsFollowing(ctx,
+				status.AccountID,
+				requester.ID,
+			)
+			if err != nil {
+				return false, gtserror.Newf("error checking follow: %w", err)
+			}
+
+			if follows {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
@@ -19,13 +19,16 @@ package visibility
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/cache"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 // StatusHomeTimelineable checks if given status should be included on requester's public timeline. Primarily relying on status visibility to requester and the AP visibility setting, and ignoring conversation threads.
@@ -68,6 +71,31 @@ func (f *Filter) StatusPublicTimelineable(ctx context.Context, requester *gtsmod
 	return visibility.Value, nil
 }
 
+// StatusPublicTimelineOptedIn checks whether the author of the given status allows
+// their statuses to appear on the local public timeline (if localOnly), or else on
+// the federated public timeline. Statuses by remote authors are always allowed.
+func (f *Filter) StatusPublicTimelineOptedIn(ctx context.Context, status *gtsmodel.Status, localOnly bool) (bool, error) {
+	if !util.PtrValueOr(status.Local, false) {
+		// Remote author, nothing to check.
+		return true, nil
+	}
+
+	settings, err := f.state.DB.GetAccountSettings(ctx, status.AccountID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// No settings (eg., instance account).
+			return true, nil
+		}
+		return false, gtserror.Newf("error getting account settings for %s: %w", status.AccountID, err)
+	}
+
+	if localOnly {
+		return !util.PtrValueOr(settings.HideFromLocalTimeline, false), nil
+	}
+
+	return !util.PtrValueOr(settings.HideFromFederatedTimeline, false), nil
+}
+
 func (f *Filter) isStatusPublicTimelineable(ctx context.Context, requester *gtsmodel.Account, status *gtsmodel.Status) (bool, error) {
 	if status.CreatedAt.After(time.Now().Add(24 * time.Hour)) {
 		// Statuses made over 1 day in the future we don't show...
@@ -91,6 +119,24 @@ func (f *Filter) isStatusPublicTimelineable(ctx context.Context, requester *gtsm
 		return false, nil
 	}
 
+	if status.Account == nil {
+		// Status author is needed below.
+		status.Account, err = f.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			status.AccountID,
+		)
+		if err != nil {
+			return false, gtserror.Newf("error getting status author %s: %w", status.AccountID, err)
+		}
+	}
+
+	if status.Account.IsSilenced() {
+		// Statuses of silenced accounts
+		// are kept off public timelines.
+		log.Trace(ctx, "status author is silenced")
+		return false, nil
+	}
+
 	for parent := status; parent.InReplyToURI != ""; {
 		// Fetch next parent to lookup.
 		parentID := parent.InReplyToID
@@ -19,12 +19,15 @@ package visibility
 
 import (
 	"context"
+	"errors"
 	"slices"
 
 	"github.com/superseriousbusiness/gotosocial/internal/cache"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 // StatusesVisible calls StatusVisible for each status in the statuses slice, and returns a slice of only statuses which are visible to the requester.
@@ -72,9 +75,38 @@ func (f *Filter) StatusVisible(ctx context.Context, requester *gtsmodel.Account,
 		return false, err
 	}
 
+	if requester == nil && visibility.Value {
+		// This is checked outside of cached visibility,
+		// so that changes to the author's setting apply
+		// to already-cached statuses straight away.
+		return f.isStatusVisibleLoggedOut(ctx, status)
+	}
+
 	return visibility.Value, nil
 }
 
+// isStatusVisibleLoggedOut checks whether the author of the given status,
+// if local, has opted to hide their statuses from logged-out viewers.
+func (f *Filter) isStatusVisibleLoggedOut(ctx context.Context, status *gtsmodel.Status) (bool, error) {
+	// Only local accounts have settings,
+	// so look them up by the author ID.
+	settings, err := f.state.DB.GetAccountSettings(ctx, status.AccountID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// Remote (or instance) account.
+			return true, nil
+		}
+		return false, gtserror.Newf("error getting account settings for %s: %w", status.AccountID, err)
+	}
+
+	if util.PtrValueOr(settings.HideStatusesLoggedOut, false) {
+		log.Trace(ctx, "status author hides statuses from logged-out viewers")
+		return false, nil
+	}
+
+	return true, nil
+}
+
 // isStatusVisible will check if status is visible to requester. It is the "meat" of the logic to Filter{}.StatusVisible() which is called within cache loader callback.
 func (f *Filter) isStatusVisible(ctx context.Context, requester *gtsmodel.Account, status *gtsmodel.Status) (bool, error) {
 	// Ensure that status is fully populated for further processing.
@@ -90,6 +122,13 @@ func (f *Filter) isStatusVisible(ctx context.Context, requester *gtsmodel.Accoun
 		return false, nil
 	}
 
+	if status.IsQuarantined() &&
+		(requester == nil || requester.ID != status.AccountID) {
+		// Quarantined statuses are only visible to their author.
+		log.Trace(ctx, "status is quarantined")
+		return false, nil
+	}
+
 	if status.Visibility == gtsmodel.VisibilityPublic {
 		// This status will be visible to all.
 		return true, nil
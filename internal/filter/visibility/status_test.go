@@ -23,6 +23,7 @@ import (
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 type StatusVisibleTestSuite struct {
@@ -156,6 +157,35 @@ func (suite *StatusVisibleTestSuite) TestStatusNotVisibleIfNotFollowingCached()
 	suite.False(visible)
 }
 
+func (suite *StatusVisibleTestSuite) TestStatusNotVisibleLoggedOutIfHidden() {
+	ctx := context.Background()
+	testStatus := suite.testStatuses["local_account_1_status_1"]
+	testAccount := suite.testAccounts["local_account_2"]
+
+	// Public status should be visible logged-out.
+	visible, err := suite.filter.StatusVisible(ctx, nil, testStatus)
+	suite.NoError(err)
+	suite.True(visible)
+
+	// Hide the author's statuses from logged-out viewers.
+	settings, err := suite.db.GetAccountSettings(ctx, testStatus.AccountID)
+	suite.NoError(err)
+	settings.HideStatusesLoggedOut = util.Ptr(true)
+	err = suite.db.UpdateAccountSettings(ctx, settings, "hide_statuses_logged_out")
+	suite.NoError(err)
+
+	// Status should no longer be visible logged-out,
+	// even though the earlier visibility was cached.
+	visible, err = suite.filter.StatusVisible(ctx, nil, testStatus)
+	suite.NoError(err)
+	suite.False(visible)
+
+	// But should still be visible to logged-in accounts.
+	visible, err = suite.filter.StatusVisible(ctx, testAccount, testStatus)
+	suite.NoError(err)
+	suite.True(visible)
+}
+
 func TestStatusVisibleTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusVisibleTestSuite))
 }
@@ -44,6 +44,8 @@ const (
 	httpSigPubKeyIDKey
 	dryRunKey
 	httpClientSignFnKey
+	omitFieldsKey
+	delegateKey
 )
 
 // DryRun returns whether the "dryrun" context key has been set. This can be
@@ -115,6 +117,21 @@ func SetRequestingAccount(ctx context.Context, acct *gtsmodel.Account) context.C
 	return context.WithValue(ctx, requestingAccountKey, acct)
 }
 
+// Delegate returns the account delegate through which the authorized
+// user is acting as a (shared) local account in the current client API
+// request, if any. When set, the delegate's permissions restrict what
+// may be done, and statuses created are attributed to the delegate.
+func Delegate(ctx context.Context) *gtsmodel.AccountDelegate {
+	delegate, _ := ctx.Value(delegateKey).(*gtsmodel.AccountDelegate)
+	return delegate
+}
+
+// SetDelegate stores the given account delegate value and returns the wrapped
+// context. See Delegate() for further information on the account delegate value.
+func SetDelegate(ctx context.Context, delegate *gtsmodel.AccountDelegate) context.Context {
+	return context.WithValue(ctx, delegateKey, delegate)
+}
+
 // OtherIRIs returns other IRIs which are involved in the current ActivityPub request
 // chain. This usually means: other accounts who are mentioned, CC'd, TO'd, or boosted
 // by the current inbox POST request.
@@ -181,6 +198,20 @@ func SetHTTPSignaturePubKeyID(ctx context.Context, pubKeyID *url.URL) context.Co
 	return context.WithValue(ctx, httpSigPubKeyIDKey, pubKeyID)
 }
 
+// OmitFields returns the names of optional API model fields which the client
+// of the current request has asked to be left out of serialized responses, for
+// example to avoid the cost of converting comparatively expensive sub-objects.
+func OmitFields(ctx context.Context) []string {
+	fields, _ := ctx.Value(omitFieldsKey).([]string)
+	return fields
+}
+
+// SetOmitFields stores the given field names and returns the wrapped context.
+// See OmitFields() for further information on the omitted fields value.
+func SetOmitFields(ctx context.Context, fields []string) context.Context {
+	return context.WithValue(ctx, omitFieldsKey, fields)
+}
+
 // IsFastFail returns whether the "fastfail" context key has been set. This
 // can be used to indicate to an http client, for example, that the result
 // of an outgoing request is time sensitive and so not to bother with retries.
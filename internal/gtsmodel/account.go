@@ -79,6 +79,7 @@ type Account struct {
 	SilencedAt              time.Time        `bun:"type:timestamptz,nullzero"`                                   // When was this account silenced (eg., statuses only visible to followers, not public)?
 	SuspendedAt             time.Time        `bun:"type:timestamptz,nullzero"`                                   // When was this account suspended (eg., don't allow it to log in/post, don't accept media/posts from this account)
 	SuspensionOrigin        string           `bun:"type:CHAR(26),nullzero"`                                      // id of the database entry that caused this account to become suspended -- can be an account ID or a domain block ID
+	VerifiedAt              time.Time        `bun:"type:timestamptz,nullzero"`                                   // When was this (local) account marked as verified by an admin? Unrelated to rel=me verification of profile fields.
 	Settings                *AccountSettings `bun:"-"`                                                           // gtsmodel.AccountSettings for this account.
 	Stats                   *AccountStats    `bun:"-"`                                                           // gtsmodel.AccountStats for this account.
 }
@@ -181,6 +182,24 @@ func (a *Account) IsSuspended() bool {
 	return !a.SuspendedAt.IsZero()
 }
 
+// IsSilenced returns true if account
+// has been silenced by this instance.
+func (a *Account) IsSilenced() bool {
+	return !a.SilencedAt.IsZero()
+}
+
+// IsSensitized returns true if account has
+// been marked as sensitive by this instance.
+func (a *Account) IsSensitized() bool {
+	return !a.SensitizedAt.IsZero()
+}
+
+// IsVerified returns true if account has
+// been marked as verified by an admin.
+func (a *Account) IsVerified() bool {
+	return !a.VerifiedAt.IsZero()
+}
+
 // IsMoving returns true if
 // account is Moving or has Moved.
 func (a *Account) IsMoving() bool {
@@ -0,0 +1,46 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// AccountDelegate links a local account (eg., the shared
+// account of an organization or collective) to another
+// local account whose user may act as it, with permissions
+// on what the delegate may do as the shared account.
+//
+// Delegates are invited by the shared account, and may only
+// act as it once they've accepted the invitation.
+type AccountDelegate struct {
+	ID                string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                                      // id of this item in the database
+	CreatedAt         time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                   // when was item created
+	UpdatedAt         time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                   // when was item last updated
+	AccountID         string    `bun:"type:CHAR(26),nullzero,notnull,unique:account_delegates_account_delegate_uniq"` // ID of the shared account
+	Account           *Account  `bun:"-"`                                                                             // Account corresponding to AccountID
+	DelegateAccountID string    `bun:"type:CHAR(26),nullzero,notnull,unique:account_delegates_account_delegate_uniq"` // ID of the account whose user may act as the shared account
+	DelegateAccount   *Account  `bun:"-"`                                                                             // Account corresponding to DelegateAccountID
+	CanPost           *bool     `bun:",nullzero,notnull,default:false"`                                               // may the delegate post (and delete posts) as the shared account?
+	CanManageDMs      *bool     `bun:"can_manage_dms,nullzero,notnull,default:false"`                                 // may the delegate read and send direct messages as the shared account?
+	AcceptedAt        time.Time `bun:"type:timestamptz,nullzero"`                                                     // when did the delegate accept the invitation; zero while pending
+}
+
+// IsAccepted returns true if the delegate has
+// accepted the invitation to the shared account.
+func (d *AccountDelegate) IsAccepted() bool {
+	return !d.AcceptedAt.IsZero()
+}
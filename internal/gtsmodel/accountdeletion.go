@@ -0,0 +1,72 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// AccountDeletion tracks the progress of deleting
+// an account and all of its statuses, media, follows
+// etc., so that a delete interrupted by eg. a restart
+// can be resumed from the stage it had reached.
+type AccountDeletion struct {
+	ID              string               `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt       time.Time            `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt       time.Time            `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID       string               `bun:"type:CHAR(26),nullzero,notnull,unique"`                       // ID of the account being deleted
+	Account         *Account             `bun:"-"`                                                           // Account corresponding to AccountID
+	Origin          string               `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the account or domain block that originated the delete
+	Stage           AccountDeletionStage `bun:",nullzero,notnull"`                                           // stage the delete has reached
+	StatusesDeleted int                  `bun:",notnull,default:0"`                                          // number of statuses deleted so far
+}
+
+// AccountDeletionStage describes one
+// stage of the account deletion process.
+type AccountDeletionStage int16
+
+const (
+	AccountDeletionStageStatuses      AccountDeletionStage = 1 // deleting statuses (and their media, boosts, etc)
+	AccountDeletionStageFollows       AccountDeletionStage = 2 // deleting follows and follow requests
+	AccountDeletionStageBlocks        AccountDeletionStage = 3 // deleting blocks
+	AccountDeletionStageNotifications AccountDeletionStage = 4 // deleting notifications
+	AccountDeletionStagePeripheral    AccountDeletionStage = 5 // deleting bookmarks, faves, poll votes, stats
+	AccountDeletionStageUser          AccountDeletionStage = 6 // deleting tokens and stubbifying user (local only)
+	AccountDeletionStageStubbify      AccountDeletionStage = 7 // stubbifying the account itself
+)
+
+// String returns a stringified,
+// human readable form of the stage.
+func (s AccountDeletionStage) String() string {
+	switch s {
+	case AccountDeletionStageStatuses:
+		return "statuses"
+	case AccountDeletionStageFollows:
+		return "follows"
+	case AccountDeletionStageBlocks:
+		return "blocks"
+	case AccountDeletionStageNotifications:
+		return "notifications"
+	case AccountDeletionStagePeripheral:
+		return "peripheral"
+	case AccountDeletionStageUser:
+		return "user"
+	case AccountDeletionStageStubbify:
+		return "stubbify"
+	default:
+		return "unknown"
+	}
+}
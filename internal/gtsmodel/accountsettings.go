@@ -21,15 +21,37 @@ import "time"
 
 // AccountSettings models settings / preferences for a local, non-instance account.
 type AccountSettings struct {
-	AccountID         string     `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // AccountID that owns this settings.
-	CreatedAt         time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created.
-	UpdatedAt         time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item was last updated.
-	Privacy           Visibility `bun:",nullzero"`                                                   // Default post privacy for this account
-	Sensitive         *bool      `bun:",nullzero,notnull,default:false"`                             // Set posts from this account to sensitive by default?
-	Language          string     `bun:",nullzero,notnull,default:'en'"`                              // What language does this account post in?
-	StatusContentType string     `bun:",nullzero"`                                                   // What is the default format for statuses posted by this account (only for local accounts).
-	Theme             string     `bun:",nullzero"`                                                   // Preset CSS theme filename selected by this Account (empty string if nothing set).
-	CustomCSS         string     `bun:",nullzero"`                                                   // Custom CSS that should be displayed for this Account's profile and statuses.
-	EnableRSS         *bool      `bun:",nullzero,notnull,default:false"`                             // enable RSS feed subscription for this account's public posts at [URL]/feed
-	HideCollections   *bool      `bun:",nullzero,notnull,default:false"`                             // Hide this account's followers/following collections.
+	AccountID                 string     `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // AccountID that owns this settings.
+	CreatedAt                 time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created.
+	UpdatedAt                 time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item was last updated.
+	Privacy                   Visibility `bun:",nullzero"`                                                   // Default post privacy for this account
+	Sensitive                 *bool      `bun:",nullzero,notnull,default:false"`                             // Set posts from this account to sensitive by default?
+	Language                  string     `bun:",nullzero,notnull,default:'en'"`                              // What language does this account post in?
+	StatusContentType         string     `bun:",nullzero"`                                                   // What is the default format for statuses posted by this account (only for local accounts).
+	Theme                     string     `bun:",nullzero"`                                                   // Preset CSS theme filename selected by this Account (empty string if nothing set).
+	CustomCSS                 string     `bun:",nullzero"`                                                   // Custom CSS that should be displayed for this Account's profile and statuses.
+	EnableRSS                 *bool      `bun:",nullzero,notnull,default:false"`                             // enable RSS feed subscription for this account's public posts at [URL]/feed
+	HideCollections           *bool      `bun:",nullzero,notnull,default:false"`                             // Hide this account's followers/following collections.
+	SpamFilterMode            string     `bun:",nullzero"`                                                   // Override of the instance spam filter mode for messages sent to this account (empty string to use instance setting).
+	EmailDigest               string     `bun:",nullzero"`                                                   // How often to send this account an email digest of missed notifications (empty string for never).
+	EmailDigestSentAt         time.Time  `bun:"type:timestamptz,nullzero"`                                   // When was an email digest last sent to this account.
+	NotificationsSnoozedUntil time.Time  `bun:"type:timestamptz,nullzero"`                                   // Until when notifications to this account are snoozed, ie., quiet mode (zero if not snoozed).
+	SnoozeAllowFollows        *bool      `bun:",nullzero,notnull,default:false"`                             // Still push follow + follow request notifications while snoozed.
+	SnoozeAllowDirect         *bool      `bun:",nullzero,notnull,default:false"`                             // Still push notifications of direct message mentions while snoozed.
+	FollowAutoAcceptDelay     int        `bun:",notnull,default:0"`                                          // Minutes to wait before automatically accepting follows when account is unlocked (0 = accept immediately).
+	HideStatusesLoggedOut     *bool      `bun:",nullzero,notnull,default:false"`                             // Hide this account's statuses from logged-out viewers of the web view and API, while still federating them.
+	HideFromLocalTimeline     *bool      `bun:",nullzero,notnull,default:false"`                             // Keep this account's public statuses off the local public timeline.
+	HideFromFederatedTimeline *bool      `bun:",nullzero,notnull,default:false"`                             // Keep this account's public statuses off the federated public timeline.
+	NoIndex                   *bool      `bun:",nullzero,notnull,default:false"`                             // Ask search engines not to index this account's profile and statuses web views.
 }
+
+// NotificationsSnoozed returns whether notifications
+// to this account are currently snoozed (quiet mode).
+func (s *AccountSettings) NotificationsSnoozed() bool {
+	return time.Now().Before(s.NotificationsSnoozedUntil)
+}
+
+const (
+	EmailDigestDaily  = "daily"  // Send email digests once per day.
+	EmailDigestWeekly = "weekly" // Send email digests once per week.
+)
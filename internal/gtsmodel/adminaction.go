@@ -73,6 +73,10 @@ const (
 	AdminActionSuspend
 	AdminActionUnsuspend
 	AdminActionExpireKeys
+	AdminActionSensitize
+	AdminActionUnsensitize
+	AdminActionWarn
+	AdminActionResolveMoves
 )
 
 func (t AdminActionType) String() string {
@@ -91,6 +95,14 @@ func (t AdminActionType) String() string {
 		return "unsuspend"
 	case AdminActionExpireKeys:
 		return "expire-keys"
+	case AdminActionSensitize:
+		return "sensitize"
+	case AdminActionUnsensitize:
+		return "unsensitize"
+	case AdminActionWarn:
+		return "warn"
+	case AdminActionResolveMoves:
+		return "resolve-moves"
 	default:
 		return "unknown"
 	}
@@ -112,6 +124,19 @@ func NewAdminActionType(in string) AdminActionType {
 		return AdminActionUnsuspend
 	case "expire-keys":
 		return AdminActionExpireKeys
+	case "sensitize", "sensitive":
+		// "sensitive" is the
+		// Mastodon API equivalent.
+		return AdminActionSensitize
+	case "unsensitize":
+		return AdminActionUnsensitize
+	case "warn", "none":
+		// "none" is the Mastodon API
+		// equivalent, which sends a
+		// warning without any action.
+		return AdminActionWarn
+	case "resolve-moves":
+		return AdminActionResolveMoves
 	default:
 		return AdminActionUnknown
 	}
@@ -0,0 +1,36 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// BlobRef is a reference to a content-addressed media file in
+// storage, which isn't (yet) referenced by a media attachment.
+// Refs are kept in the database so they're seen by all instance
+// processes sharing the same storage.
+//
+// A ref either pins the file while media referencing it is still
+// being processed, so that it isn't removed in the meantime, or
+// marks that the file is being removed, so that media wanting to
+// reuse it don't rely on it still being there.
+type BlobRef struct {
+	ID        string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	Path      string    `bun:",nullzero,notnull"`                                           // content-addressed storage path of the file
+	Deleting  *bool     `bun:",nullzero,notnull,default:false"`                             // whether this marks the file as being removed, rather than pinning it
+}
@@ -25,4 +25,5 @@ type EmojiCategory struct {
 	CreatedAt time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
 	UpdatedAt time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
 	Name      string    `bun:",nullzero,notnull,unique"`                                    // name of this category
+	Position  int       `bun:",notnull,default:0"`                                          // position of this category in emoji pickers, lowest first
 }
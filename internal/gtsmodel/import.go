@@ -0,0 +1,71 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// Import represents one upload of data (eg., a CSV
+// of followed accounts) by a local account, to be
+// processed row-by-row in the background.
+type Import struct {
+	ID         string     `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt  time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt  time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID  string     `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the local account that uploaded the import
+	Account    *Account   `bun:"-"`                                                           // Account corresponding to AccountID
+	Type       ImportType `bun:",nullzero,notnull"`                                           // type of data being imported
+	FinishedAt time.Time  `bun:"type:timestamptz,nullzero"`                                   // when did processing of all rows finish, if at all
+}
+
+// Finished returns whether all rows of
+// this import have now been processed.
+func (i *Import) Finished() bool {
+	return !i.FinishedAt.IsZero()
+}
+
+// ImportType describes what kind of data an import contains.
+type ImportType string
+
+const (
+	ImportTypeFollowing ImportType = "following" // accounts to follow
+)
+
+// ImportRow represents one row of an Import,
+// along with the status of processing it.
+type ImportRow struct {
+	ID          string          `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt   time.Time       `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt   time.Time       `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	ImportID    string          `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the import this row belongs to
+	Line        int             `bun:",nullzero,notnull"`                                           // line number of this row in the uploaded data, starting at 1
+	Target      string          `bun:",nullzero"`                                                   // target of this row, eg., the address of an account to follow
+	ShowReblogs *bool           `bun:",nullzero,notnull,default:true"`                              // whether a follow created by this row should show reblogs
+	Notify      *bool           `bun:",nullzero,notnull,default:false"`                             // whether a follow created by this row should notify on new posts
+	Status      ImportRowStatus `bun:",nullzero,notnull"`                                           // processing status of this row
+	Error       string          `bun:",nullzero"`                                                   // reason why processing this row failed, if it did
+}
+
+// ImportRowStatus describes how far along
+// processing of one ImportRow has gotten.
+type ImportRowStatus string
+
+const (
+	ImportRowStatusPending ImportRowStatus = "pending" // row not yet processed
+	ImportRowStatusDone    ImportRowStatus = "done"    // row processed successfully
+	ImportRowStatusFailed  ImportRowStatus = "failed"  // row processing failed, see Error
+)
@@ -36,6 +36,8 @@ type Instance struct {
 	DescriptionText        string       `bun:""`                                                            // Raw text version of long description (before parsing).
 	Terms                  string       `bun:""`                                                            // Terms and conditions of this instance.
 	TermsText              string       `bun:""`                                                            // Raw text version of terms (before parsing).
+	PrivacyPolicy          string       `bun:""`                                                            // Privacy policy of this instance.
+	PrivacyPolicyText      string       `bun:""`                                                            // Raw text version of privacy policy (before parsing).
 	ContactEmail           string       `bun:""`                                                            // Contact email address for this instance
 	ContactAccountUsername string       `bun:",nullzero"`                                                   // Username of the contact account for this instance
 	ContactAccountID       string       `bun:"type:CHAR(26),nullzero"`                                      // Contact account ID in the database for this instance
@@ -43,4 +45,46 @@ type Instance struct {
 	Reputation             int64        `bun:",notnull,default:0"`                                          // Reputation score of this instance
 	Version                string       `bun:",nullzero"`                                                   // Version of the software used on this instance
 	Rules                  []Rule       `bun:"-"`                                                           // List of instance rules
+	LandingPageBlocks      []string     `bun:",array"`                                                      // Ordered blocks to render on the landing page. Empty means DefaultLandingPageBlocks.
+	FeaturedAccountIDs     []string     `bun:"featured_accounts,array"`                                     // IDs of local accounts featured on the landing page.
+	FeaturedAccounts       []*Account   `bun:"-"`                                                           // Accounts corresponding to featuredAccountIDs.
+}
+
+// Landing page blocks that admins can
+// choose from to lay out the landing page.
+const (
+	LandingPageBlockShortDescription = "short_description" // Short description of the instance.
+	LandingPageBlockAbout            = "about"             // Extended description of the instance.
+	LandingPageBlockContact          = "contact"           // Contact account + email.
+	LandingPageBlockRegister         = "register"          // Sign-up call to action.
+	LandingPageBlockFeaturedAccounts = "featured_accounts" // Admin-selected featured accounts.
+	LandingPageBlockWhatIsThis       = "what_is_this"      // Explanation of GoToSocial + the fediverse.
+	LandingPageBlockApps             = "apps"              // Client application suggestions.
+)
+
+// DefaultLandingPageBlocks is the landing page
+// layout used when an admin hasn't set their own.
+var DefaultLandingPageBlocks = []string{
+	LandingPageBlockShortDescription,
+	LandingPageBlockWhatIsThis,
+	LandingPageBlockRegister,
+	LandingPageBlockApps,
+}
+
+// FeaturedAccountsPopulated returns whether featured accounts
+// are populated according to current FeaturedAccountIDs.
+func (i *Instance) FeaturedAccountsPopulated() bool {
+	if len(i.FeaturedAccountIDs) != len(i.FeaturedAccounts) {
+		// this is the quickest indicator.
+		return false
+	}
+
+	// Accounts must be in same order.
+	for x, id := range i.FeaturedAccountIDs {
+		if i.FeaturedAccounts[x] == nil || i.FeaturedAccounts[x].ID != id {
+			return false
+		}
+	}
+
+	return true
 }
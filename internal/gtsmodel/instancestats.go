@@ -0,0 +1,40 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// InstanceStats models operational statistics about this
+// instance, aggregated from other tables in the database.
+// It is not itself stored in the database.
+type InstanceStats struct {
+	UsersActive        int            // Local users that are approved, confirmed, not disabled and not suspended.
+	UsersPending       int            // Local users awaiting approval by a moderator.
+	UsersUnconfirmed   int            // Approved local users who have not yet confirmed their email address.
+	UsersDisabled      int            // Local users that have been disabled.
+	UsersSuspended     int            // Local users whose accounts have been suspended.
+	StatusesPerDay     map[string]int // Local statuses created per day (UTC, "YYYY-MM-DD"), since StatusesSince.
+	StatusesSince      time.Time      // Start of the period covered by StatusesPerDay.
+	LocalMediaCount    int            // Number of cached media attachments uploaded by local accounts.
+	LocalMediaStorage  int64          // Storage used by local media attachments, including thumbnails, in bytes.
+	RemoteMediaCount   int            // Number of cached media attachments from remote accounts.
+	RemoteMediaStorage int64          // Storage used by cached remote media attachments, including thumbnails, in bytes.
+	PeersTotal         int            // Number of other instances known to this instance.
+	PeersSuspended     int            // Number of known instances that are suspended (ie., domain blocked).
+	ComputedAt         time.Time      // When these stats were aggregated.
+}
@@ -0,0 +1,35 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+// InteractionPolicy models the interaction policy
+// that a remote status author has attached to their
+// status, indicating who may reply to or boost it.
+//
+// Each slice contains ActivityPub URIs of the
+// form permitted by the remote policy, ie., the
+// ActivityStreams public URI, actor URIs, or the
+// author's followers / following collection URIs.
+//
+// A nil slice means no policy was set for that
+// interaction type, and it's permitted for anyone
+// who can see the status.
+type InteractionPolicy struct {
+	CanReply    []string `json:"canReply"`
+	CanAnnounce []string `json:"canAnnounce"`
+}
@@ -0,0 +1,55 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// Invite represents an invite code created by a local
+// account, which can be given to someone so that they
+// can sign up for an account on this instance.
+type Invite struct {
+	ID        string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID string    `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the local account that created this invite
+	Account   *Account  `bun:"-"`                                                           // Account corresponding to AccountID
+	Code      string    `bun:",nullzero,notnull,unique"`                                    // code to be given at sign-up to use this invite
+	MaxUses   int       `bun:",notnull,default:0"`                                          // maximum number of times this invite can be used; 0 means unlimited
+	Uses      int       `bun:",notnull,default:0"`                                          // number of times this invite has been used so far
+	ExpiresAt time.Time `bun:"type:timestamptz,nullzero"`                                   // time after which this invite can no longer be used, if set
+	RevokedAt time.Time `bun:"type:timestamptz,nullzero"`                                   // time at which this invite was revoked, if at all
+}
+
+// Expired returns whether this invite has an
+// expiry time set, and that time has passed.
+func (i *Invite) Expired() bool {
+	return !i.ExpiresAt.IsZero() && !time.Now().Before(i.ExpiresAt)
+}
+
+// UsedUp returns whether this invite has a
+// maximum number of uses, and has hit it.
+func (i *Invite) UsedUp() bool {
+	return i.MaxUses > 0 && i.Uses >= i.MaxUses
+}
+
+// Usable returns whether this invite can still
+// be used to sign up, ie., it's not been revoked,
+// it's not expired, and it's not used up.
+func (i *Invite) Usable() bool {
+	return i.RevokedAt.IsZero() && !i.Expired() && !i.UsedUp()
+}
@@ -42,6 +42,9 @@ type MediaAttachment struct {
 	Avatar            *bool            `bun:",nullzero,notnull,default:false"`                             // Is this attachment being used as an avatar?
 	Header            *bool            `bun:",nullzero,notnull,default:false"`                             // Is this attachment being used as a header?
 	Cached            *bool            `bun:",nullzero,notnull,default:false"`                             // Is this attachment currently cached by our instance?
+	ScanStatus        ScanStatus       `bun:",notnull,default:0"`                                          // Result of virus scanning this attachment, if any.
+	ScanResult        string           `bun:",nullzero"`                                                   // Name of the virus found, or scan error message.
+	ScannedAt         time.Time        `bun:"type:timestamptz,nullzero"`                                   // When was this attachment scanned.
 }
 
 // File refers to the metadata for the whole file
@@ -73,6 +76,17 @@ const (
 	ProcessingStatusError      ProcessingStatus = 666 // ProcessingStatusError indicates something went wrong processing the attachment and it won't be tried again--these can be deleted.
 )
 
+// ScanStatus refers to the result of virus scanning the attachment.
+type ScanStatus int
+
+// MediaAttachment scan statuses.
+const (
+	ScanStatusNotScanned ScanStatus = 0 // ScanStatusNotScanned indicates the attachment was not scanned, eg., because scanning is disabled, or it's remote media.
+	ScanStatusClean      ScanStatus = 1 // ScanStatusClean indicates the attachment was scanned and nothing was found.
+	ScanStatusInfected   ScanStatus = 2 // ScanStatusInfected indicates the attachment was scanned and found to be infected.
+	ScanStatusError      ScanStatus = 3 // ScanStatusError indicates the attachment could not be scanned, and was let through.
+)
+
 // FileType refers to the file type of the media attaachment.
 type FileType string
 
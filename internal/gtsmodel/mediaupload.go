@@ -0,0 +1,37 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// MediaUpload is an unfinished resumable media upload. The
+// parts of the media received so far are kept in storage
+// until the upload is either finished, or expires.
+type MediaUpload struct {
+	ID          string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt   time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt   time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	ExpiresAt   time.Time `bun:"type:timestamptz,nullzero,notnull"`                           // when the upload is discarded, if not finished by then
+	AccountID   string    `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the account uploading the media
+	Size        int64     `bun:",notnull"`                                                    // total size of the media in bytes
+	Received    int64     `bun:",notnull,default:0"`                                          // number of bytes received so far
+	Parts       int       `bun:",notnull,default:0"`                                          // number of parts received so far
+	Description string    `bun:",nullzero"`                                                   // description to give the media once finished
+	FocusX      float32   `bun:",notnull,default:0"`                                          // focus X to give the media once finished
+	FocusY      float32   `bun:",notnull,default:0"`                                          // focus Y to give the media once finished
+}
@@ -0,0 +1,37 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// ModerationNote is a private note left by an admin or
+// moderator on an account or a report, so that context
+// about moderation decisions is shared among the mod team.
+//
+// Notes are never shown to the account or report they're about.
+type ModerationNote struct {
+	ID              string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt       time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt       time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AuthorAccountID string    `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the local admin/moderator account that wrote the note
+	AuthorAccount   *Account  `bun:"-"`                                                           // Account corresponding to AuthorAccountID
+	TargetAccountID string    `bun:"type:CHAR(26),nullzero"`                                      // ID of the account this note is about, if any
+	TargetAccount   *Account  `bun:"-"`                                                           // Account corresponding to TargetAccountID
+	ReportID        string    `bun:"type:CHAR(26),nullzero"`                                      // ID of the report this note is about, if any
+	Content         string    `bun:",nullzero,notnull"`                                           // plaintext content of the note
+}
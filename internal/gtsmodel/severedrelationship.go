@@ -0,0 +1,56 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// SeveredRelationship records a follow between a local account and
+// a remote account that was removed as a side effect of a domain
+// block, so that the local account can be told about it, and the
+// follow can be restored if the domain block is lifted later.
+type SeveredRelationship struct {
+	ID              string                       `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt       time.Time                    `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	DomainBlockID   string                       `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the domain block that caused the relationship to be severed
+	Domain          string                       `bun:",nullzero,notnull"`                                           // domain of the domain block that caused the relationship to be severed
+	LocalAccountID  string                       `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the local account that was part of the relationship
+	LocalAccount    *Account                     `bun:"-"`                                                           // Account corresponding to LocalAccountID
+	RemoteAccountID string                       `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the remote account that was part of the relationship
+	RemoteAccount   *Account                     `bun:"-"`                                                           // Account corresponding to RemoteAccountID
+	Direction       SeveredRelationshipDirection `bun:",nullzero,notnull"`                                           // direction of the severed follow, from the local account's point of view
+	ShowReblogs     *bool                        `bun:",nullzero,notnull,default:true"`                              // whether the original follow showed reblogs
+	Notify          *bool                        `bun:",nullzero,notnull,default:false"`                             // whether the original follow notified on new posts
+	RestoredAt      time.Time                    `bun:"type:timestamptz,nullzero"`                                   // when was the relationship restored, if at all
+}
+
+// SeveredRelationshipDirection describes which
+// way round a severed follow went, from the point
+// of view of the local account involved in it.
+type SeveredRelationshipDirection string
+
+const (
+	SeveredRelationshipFollowing  SeveredRelationshipDirection = "following"   // local account followed the remote account
+	SeveredRelationshipFollowedBy SeveredRelationshipDirection = "followed_by" // remote account followed the local account
+)
+
+// Restorable returns whether this severed relationship
+// can be restored by the local account, ie., it was a
+// follow from the local account that's not yet restored.
+func (s *SeveredRelationship) Restorable() bool {
+	return s.Direction == SeveredRelationshipFollowing && s.RestoredAt.IsZero()
+}
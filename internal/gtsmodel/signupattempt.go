@@ -0,0 +1,35 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import (
+	"net"
+	"time"
+)
+
+// SignupAttempt records a single sign-up submitted via the
+// sign-up API, regardless of whether it is later approved or
+// denied. Attempts are counted by IP and by email domain to
+// throttle waves of bot registrations, and are pruned once
+// they're too old to be relevant to any throttle.
+type SignupAttempt struct {
+	ID          string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt   time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	IP          net.IP    `bun:",nullzero"`                                                   // IP address the sign-up originated from.
+	EmailDomain string    `bun:",nullzero,notnull"`                                           // Punycode domain of the email address provided on the sign-up form.
+}
@@ -18,7 +18,10 @@
 package gtsmodel
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"slices"
+	"strconv"
 	"time"
 )
 
@@ -63,12 +66,17 @@ type Status struct {
 	Language                 string             `bun:",nullzero"`                                                   // what language is this status written in?
 	CreatedWithApplicationID string             `bun:"type:CHAR(26),nullzero"`                                      // Which application was used to create this status?
 	CreatedWithApplication   *Application       `bun:"rel:belongs-to"`                                              // application corresponding to createdWithApplicationID
+	PostedByAccountID        string             `bun:"type:CHAR(26),nullzero"`                                      // ID of the delegate account that posted this status on behalf of AccountID, if any (see AccountDelegate)
 	ActivityStreamsType      string             `bun:",nullzero,notnull"`                                           // What is the activitystreams type of this status? See: https://www.w3.org/TR/activitystreams-vocabulary/#object-types. Will probably almost always be Note but who knows!.
 	Text                     string             `bun:""`                                                            // Original text of the status without formatting
 	Federated                *bool              `bun:",notnull"`                                                    // This status will be federated beyond the local timeline(s)
 	Boostable                *bool              `bun:",notnull"`                                                    // This status can be boosted/reblogged
 	Replyable                *bool              `bun:",notnull"`                                                    // This status can be replied to
 	Likeable                 *bool              `bun:",notnull"`                                                    // This status can be liked/faved
+	Indexable                *bool              `bun:",nullzero,notnull,default:false"`                             // Search engines may index this status' web view (inherited from account discoverable by default).
+	InteractionPolicy        *InteractionPolicy `bun:""`                                                            // Remote interaction policy set on this status by its author, if any.
+	ContentDigest            string             `bun:",nullzero"`                                                   // Digest of the content of this (remote) status as last received, see ComputeContentDigest().
+	QuarantinedAt            time.Time          `bun:"type:timestamptz,nullzero"`                                   // Status was re-delivered with content not matching ContentDigest, and quarantined at this time.
 }
 
 // GetID implements timeline.Timelineable{}.
@@ -211,6 +219,43 @@ func (s *Status) IsLocal() bool {
 	return s.Local != nil && *s.Local
 }
 
+// IsQuarantined returns true if this status has been
+// quarantined after being re-delivered with mismatched content.
+func (s *Status) IsQuarantined() bool {
+	return !s.QuarantinedAt.IsZero()
+}
+
+// ComputeContentDigest returns a hex-encoded SHA256 digest of the
+// fields of this status that a remote instance should only change
+// by sending an Update: content, content warning, sensitivity,
+// attachment remote URLs and poll options.
+func (s *Status) ComputeContentDigest() string {
+	h := sha256.New()
+
+	write := func(str string) {
+		h.Write([]byte(str))
+		h.Write([]byte{0})
+	}
+
+	write(s.Content)
+	write(s.ContentWarning)
+	write(strconv.FormatBool(s.Sensitive != nil && *s.Sensitive))
+
+	for _, attachment := range s.Attachments {
+		if attachment != nil {
+			write(attachment.RemoteURL)
+		}
+	}
+
+	if s.Poll != nil {
+		for _, option := range s.Poll.Options {
+			write(option)
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
 // StatusToTag is an intermediate struct to facilitate the many2many relationship between a status and one or more tags.
 type StatusToTag struct {
 	StatusID string  `bun:"type:CHAR(26),unique:statustag,nullzero,notnull"`
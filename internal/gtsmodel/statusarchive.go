@@ -0,0 +1,31 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// StatusArchive is a compressed copy of a remote status
+// row, moved out of the statuses table by the retention
+// cleaner to keep the statuses table small.
+type StatusArchive struct {
+	ID        string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of the archived status
+	CreatedAt time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was the status archived
+	URI       string    `bun:",nullzero,notnull,unique"`                                    // activitypub URI of the archived status
+	AccountID string    `bun:"type:CHAR(26),nullzero,notnull"`                              // id of the account that authored the archived status
+	Data      []byte    `bun:",nullzero,notnull"`                                           // gzipped JSON of the status row, keyed by column name
+}
@@ -83,6 +83,16 @@ type Config struct {
 	// MaxIdleConns: see http.Transport{}.MaxIdleConns.
 	MaxIdleConns int
 
+	// MaxIdleConnsPerHost: see http.Transport{}.MaxIdleConnsPerHost.
+	MaxIdleConnsPerHost int
+
+	// IdleConnTimeout: see http.Transport{}.IdleConnTimeout.
+	IdleConnTimeout time.Duration
+
+	// DisableHTTP2 can be set to true to only
+	// ever use HTTP/1.1 for outgoing requests.
+	DisableHTTP2 bool
+
 	// ReadBufferSize: see http.Transport{}.ReadBufferSize.
 	ReadBufferSize int
 
@@ -137,6 +147,20 @@ func New(cfg Config) *Client {
 		cfg.MaxIdleConns = cfg.MaxOpenConnsPerHost * 10
 	}
 
+	if cfg.MaxIdleConnsPerHost <= 0 {
+		// By default keep as many idle connections
+		// per host as we allow open, so that bursts of
+		// deliveries to one (large) instance can reuse
+		// connections rather than redialing. The Go
+		// default of 2 is far too low for this.
+		cfg.MaxIdleConnsPerHost = cfg.MaxOpenConnsPerHost
+	}
+
+	if cfg.IdleConnTimeout <= 0 {
+		// By default set this to Go's default.
+		cfg.IdleConnTimeout = 90 * time.Second
+	}
+
 	if cfg.MaxBodySize <= 0 {
 		// By default set this to a reasonable 40MB.
 		cfg.MaxBodySize = int64(40 * bytesize.MiB)
@@ -168,13 +192,14 @@ func New(cfg Config) *Client {
 	}
 
 	// Set underlying HTTP client roundtripper.
-	c.client.Transport = &signingtransport{http.Transport{
+	transport := &signingtransport{http.Transport{
 		Proxy:                 http.ProxyFromEnvironment,
-		ForceAttemptHTTP2:     true,
+		ForceAttemptHTTP2:     !cfg.DisableHTTP2,
 		DialContext:           d.DialContext,
 		TLSClientConfig:       tlsClientConfig,
 		MaxIdleConns:          cfg.MaxIdleConns,
-		IdleConnTimeout:       90 * time.Second,
+		MaxIdleConnsPerHost:   cfg.MaxIdleConnsPerHost,
+		IdleConnTimeout:       cfg.IdleConnTimeout,
 		TLSHandshakeTimeout:   10 * time.Second,
 		ExpectContinueTimeout: 1 * time.Second,
 		ReadBufferSize:        cfg.ReadBufferSize,
@@ -182,6 +207,14 @@ func New(cfg Config) *Client {
 		DisableCompression:    cfg.DisableCompression,
 	}}
 
+	if cfg.DisableHTTP2 {
+		// A non-nil, empty map disables HTTP/2
+		// being negotiated during TLS handshakes.
+		transport.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+	}
+
+	c.client.Transport = transport
+
 	// Initiate outgoing bad hosts lookup cache.
 	c.badHosts = cache.NewTTL[string, struct{}](0, 512, 0)
 	c.badHosts.SetTTL(time.Hour, false)
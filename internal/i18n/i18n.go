@@ -0,0 +1,164 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package i18n provides translation catalogs for
+// server-rendered web pages, and negotiation of the
+// best available catalog for a visitor's language.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// DefaultLocale is the locale used when no better
+// match can be negotiated, and which other locales
+// fall back to for untranslated messages.
+const DefaultLocale = "en"
+
+//go:embed locales/*.json
+var localesFS embed.FS
+
+var (
+	// catalogs maps locale
+	// to message catalog.
+	catalogs map[string]map[string]string
+
+	// locales contains supported locales,
+	// with DefaultLocale always first,
+	// in the same order as matcher tags.
+	locales []string
+
+	// matcher is used to negotiate the best
+	// supported locale for a set of tags.
+	matcher language.Matcher
+)
+
+func init() {
+	var err error
+	catalogs, err = loadCatalogs()
+	if err != nil {
+		panic(err)
+	}
+
+	if _, ok := catalogs[DefaultLocale]; !ok {
+		panic("no catalog found for default locale " + DefaultLocale)
+	}
+
+	// Gather supported locales,
+	// default locale first.
+	locales = []string{DefaultLocale}
+	for locale := range catalogs {
+		if locale != DefaultLocale {
+			locales = append(locales, locale)
+		}
+	}
+	slices.Sort(locales[1:])
+
+	tags := make([]language.Tag, len(locales))
+	for i, locale := range locales {
+		tags[i] = language.MustParse(locale)
+	}
+	matcher = language.NewMatcher(tags)
+}
+
+// loadCatalogs parses each embedded "{locale}.json"
+// file into a message catalog for that locale.
+func loadCatalogs() (map[string]map[string]string, error) {
+	entries, err := localesFS.ReadDir("locales")
+	if err != nil {
+		return nil, fmt.Errorf("error reading locales: %w", err)
+	}
+
+	catalogs := make(map[string]map[string]string, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		locale := strings.TrimSuffix(name, ".json")
+
+		// Ensure locale is a valid, canonical BCP47 tag.
+		tag, err := language.Parse(locale)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing locale %s: %w", name, err)
+		} else if tag.String() != locale {
+			return nil, fmt.Errorf("locale %s should be named %s.json", name, tag)
+		}
+
+		b, err := localesFS.ReadFile(path.Join("locales", name))
+		if err != nil {
+			return nil, fmt.Errorf("error reading locale %s: %w", name, err)
+		}
+
+		var catalog map[string]string
+		if err := json.Unmarshal(b, &catalog); err != nil {
+			return nil, fmt.Errorf("error decoding locale %s: %w", name, err)
+		}
+
+		catalogs[locale] = catalog
+	}
+
+	return catalogs, nil
+}
+
+// Locales returns all supported
+// locales, DefaultLocale first.
+func Locales() []string {
+	return slices.Clone(locales)
+}
+
+// Negotiate returns the supported locale best matching
+// the given Accept-Language header value, or DefaultLocale
+// if the header is empty, invalid, or has no good match.
+func Negotiate(acceptLanguage string) string {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return DefaultLocale
+	}
+
+	_, idx, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return DefaultLocale
+	}
+
+	return locales[idx]
+}
+
+// Translate returns the message for key in the given
+// locale, formatted with any args using fmt.Sprintf.
+//
+// Messages missing from locale fall back to the message
+// in DefaultLocale, or the key itself if that's missing too.
+func Translate(locale string, key string, args ...any) string {
+	msg, ok := catalogs[locale][key]
+	if !ok {
+		msg, ok = catalogs[DefaultLocale][key]
+		if !ok {
+			return key
+		}
+	}
+
+	if len(args) == 0 {
+		return msg
+	}
+
+	return fmt.Sprintf(msg, args...)
+}
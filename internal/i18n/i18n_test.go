@@ -0,0 +1,77 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package i18n
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNegotiate(t *testing.T) {
+	for _, test := range []struct {
+		in  string
+		out string
+	}{
+		{in: "", out: "en"},
+		{in: "not a language header;;", out: "en"},
+		{in: "en-US,en;q=0.5", out: "en"},
+		{in: "nl-NL,nl;q=0.9,en;q=0.8", out: "nl"},
+		{in: "de-AT", out: "de"},
+		{in: "fr-FR,de;q=0.5", out: "de"},
+		{in: "ja", out: "en"},
+	} {
+		if out := Negotiate(test.in); out != test.out {
+			t.Errorf("Negotiate(%q): expected %s, got %s", test.in, test.out, out)
+		}
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	if msg := Translate("nl", "profile.heading", "zork"); msg != "Profiel van zork" {
+		t.Errorf("unexpected message %q", msg)
+	}
+
+	// Unknown locale should fall back to default.
+	if msg := Translate("xx", "profile.heading", "zork"); msg != "Profile for zork" {
+		t.Errorf("unexpected message %q", msg)
+	}
+
+	// Unknown key should be returned as-is.
+	if msg := Translate("nl", "not.a.key"); msg != "not.a.key" {
+		t.Errorf("unexpected message %q", msg)
+	}
+}
+
+func TestCatalogs(t *testing.T) {
+	defaultCatalog := catalogs[DefaultLocale]
+
+	for locale, catalog := range catalogs {
+		for key, msg := range catalog {
+			defaultMsg, ok := defaultCatalog[key]
+			if !ok {
+				t.Errorf("%s: key %s not in default locale", locale, key)
+				continue
+			}
+
+			// Translations must take the same args.
+			if n, d := strings.Count(msg, "%s"), strings.Count(defaultMsg, "%s"); n != d {
+				t.Errorf("%s: key %s has %d args, expected %d", locale, key, n, d)
+			}
+		}
+	}
+}
@@ -0,0 +1,384 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/regexes"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// PutBlob writes the contents of the given reader to storage at a
+// content-addressed path (see uris.StoragePathForBlob), with given
+// file extension, returning a ref to the path and number of bytes read.
+//
+// If identical content is already in storage, nothing is written
+// and the existing path is returned, so that media attachments
+// with the same content share a single file. Shared files are
+// reference counted by the attachments pointing to them, see
+// UnsharedFiles() and DeleteFiles().
+//
+// The returned ref pins the path in the database, so that it isn't
+// removed, by this or any other process, before the caller stores a
+// media attachment referencing it. Callers must call UnpinBlob() with
+// the ref once they've done so, or have given up on doing so.
+func PutBlob(ctx context.Context, state *state.State, r io.Reader, ext string) (*gtsmodel.BlobRef, int64, error) {
+	blob, err := spoolBlob(r)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer blob.close(ctx)
+
+	path := uris.StoragePathForBlob(blob.hash, ext)
+	ref, err := blob.put(ctx, state, path)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return ref, blob.size, nil
+}
+
+// spooledBlob is media content spooled
+// to a temporary file, along with its
+// sha256 hash and size in bytes.
+type spooledBlob struct {
+	tmp  *os.File
+	hash string
+	size int64
+}
+
+// spoolBlob copies the contents of the given
+// reader to a temporary file while hashing it.
+// We need the content hash to know where to
+// store the file, and the file may need to
+// be read more than once (eg., to be scanned)
+// before putting it in storage (which may be
+// remote, eg., S3). Callers must call close().
+func spoolBlob(r io.Reader) (*spooledBlob, error) {
+	tmp, err := os.CreateTemp("", "gotosocial-media-*")
+	if err != nil {
+		return nil, gtserror.Newf("error creating temporary file: %w", err)
+	}
+
+	hash := sha256.New()
+
+	sz, err := io.Copy(io.MultiWriter(tmp, hash), r)
+	if err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return nil, gtserror.Newf("error reading media: %w", err)
+	}
+
+	return &spooledBlob{
+		tmp:  tmp,
+		hash: hex.EncodeToString(hash.Sum(nil)),
+		size: sz,
+	}, nil
+}
+
+// reader returns a reader over the
+// contents of the blob from the start.
+func (b *spooledBlob) reader() (io.Reader, error) {
+	if _, err := b.tmp.Seek(0, io.SeekStart); err != nil {
+		return nil, gtserror.Newf("error seeking temporary file: %w", err)
+	}
+	return b.tmp, nil
+}
+
+// put writes the blob to storage at given path,
+// unless the path is already present in storage,
+// returning the ref pinning it. Callers must call
+// UnpinBlob() with the ref when done.
+func (b *spooledBlob) put(ctx context.Context, state *state.State, path string) (*gtsmodel.BlobRef, error) {
+	// Pin the path before checking for it, so that
+	// it can't be removed after we've found it but
+	// before our attachment references it.
+	ref, err := pinBlob(ctx, state, path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.write(ctx, state, path); err != nil {
+		UnpinBlob(ctx, state, ref)
+		return nil, err
+	}
+
+	return ref, nil
+}
+
+// write writes the blob to storage at given path, unless
+// the path is already present in storage. Content-addressed
+// paths must be pinned beforehand, use put() for those.
+func (b *spooledBlob) write(ctx context.Context, state *state.State, path string) error {
+	// A remove of the path that started before we
+	// pinned it may still be in progress, in which
+	// case storage can't be trusted until it's done.
+	if err := awaitBlobDeletes(ctx, state, path); err != nil {
+		return err
+	}
+
+	// Check whether identical content is already stored.
+	have, err := state.Storage.Has(ctx, path)
+	if err != nil {
+		return gtserror.Newf("error checking storage for %s: %w", path, err)
+	}
+
+	if have {
+		log.Debugf(ctx, "deduplicated media at storage path: %s", path)
+		return nil
+	}
+
+	r, err := b.reader()
+	if err != nil {
+		return err
+	}
+
+	// Write the media to storage. It may have been written
+	// by a concurrent process in the meantime, in which case
+	// the existing file has identical content, so that's fine.
+	if _, err := state.Storage.PutStream(ctx, path, r); err != nil &&
+		!storage.IsAlreadyExist(err) {
+		return gtserror.Newf("error writing media to storage: %w", err)
+	}
+
+	return nil
+}
+
+// close closes and removes the temporary file.
+func (b *spooledBlob) close(ctx context.Context) {
+	if err := b.tmp.Close(); err != nil {
+		log.Errorf(ctx, "error closing temporary file: %v", err)
+	}
+	if err := os.Remove(b.tmp.Name()); err != nil {
+		log.Errorf(ctx, "error removing temporary file: %v", err)
+	}
+}
+
+const (
+	// blobDeleteTimeout is the longest a content-addressed file is
+	// marked as being removed for, after which the mark is ignored
+	// (eg., if the process removing the file was interrupted).
+	blobDeleteTimeout = time.Minute
+
+	// blobDeletePoll is how often to check whether
+	// a file is still being removed, when waiting.
+	blobDeletePoll = 100 * time.Millisecond
+)
+
+// pinBlob pins the given storage path, returning the ref pinning it.
+//
+// Checking whether a file is in use (see BlobInUse()) and pinning it
+// are both done in the database, shared by all processes. A remove
+// of a file either sees our pin and keeps the file, or had already
+// marked the file as being removed before we pinned it, in which case
+// we see its mark and wait for it to finish (see awaitBlobDeletes()).
+func pinBlob(ctx context.Context, state *state.State, path string) (*gtsmodel.BlobRef, error) {
+	ref := &gtsmodel.BlobRef{
+		ID:   id.NewULID(),
+		Path: path,
+	}
+
+	if err := state.DB.PutBlobRef(ctx, ref); err != nil {
+		return nil, gtserror.Newf("error pinning %s: %w", path, err)
+	}
+
+	return ref, nil
+}
+
+// UnpinBlob releases the pin on a storage path returned by
+// PutBlob(), once a media attachment referencing the path
+// has been stored, or the path was abandoned. Pins that fail
+// to be released are eventually pruned (see PruneBlobRefs()).
+func UnpinBlob(ctx context.Context, state *state.State, ref *gtsmodel.BlobRef) {
+	// Use a context that isn't canceled, so
+	// pins are released even when giving up.
+	ctx = context.WithoutCancel(ctx)
+
+	if err := state.DB.DeleteBlobRefByID(ctx, ref.ID); err != nil {
+		log.Errorf(ctx, "error unpinning %s: %v", ref.Path, err)
+	}
+}
+
+// PruneBlobRefs removes pins and remove marks of content-addressed
+// files created before given time, ie., left behind by interrupted
+// processes, returning the number pruned.
+func PruneBlobRefs(ctx context.Context, state *state.State, olderThan time.Time) (int, error) {
+	n, err := state.DB.DeleteBlobRefsOlderThan(ctx, olderThan)
+	if err != nil {
+		return 0, gtserror.Newf("error deleting blob refs: %w", err)
+	}
+	return n, nil
+}
+
+// awaitBlobDeletes waits until the given storage
+// path is no longer marked as being removed.
+func awaitBlobDeletes(ctx context.Context, state *state.State, path string) error {
+	for {
+		deleting, err := state.DB.IsBlobPathDeleting(ctx, path,
+			time.Now().Add(-blobDeleteTimeout),
+		)
+		if err != nil {
+			return gtserror.Newf("error checking removes of %s: %w", path, err)
+		}
+
+		if !deleting {
+			return nil
+		}
+
+		log.Debugf(ctx, "waiting for media removal at storage path: %s", path)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(blobDeletePoll):
+		}
+	}
+}
+
+// BlobInUse returns whether the content-addressed file at given
+// storage path is still in use, ie., pinned by media still being
+// processed, or referenced by any media attachment other than the
+// one with excludeID, whether cached or still being processed.
+func BlobInUse(ctx context.Context, state *state.State, path string, excludeID string) (bool, error) {
+	inUse, err := state.DB.IsBlobPathInUse(ctx, path, excludeID)
+	if err != nil {
+		return false, gtserror.Newf("error checking references to %s: %w", path, err)
+	}
+
+	return inUse, nil
+}
+
+// UnsharedFiles filters the given storage paths of the media attachment
+// with given ID, returning only those that can currently be removed from
+// storage, ie., excluding empty paths, and content-addressed files that
+// are still in use by other media (see BlobInUse()). As shared files may
+// come back into use at any time, use DeleteFiles() to actually remove them.
+func UnsharedFiles(ctx context.Context, state *state.State, attachmentID string, paths ...string) ([]string, error) {
+	files := make([]string, 0, len(paths))
+
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+
+		// Only content-addressed
+		// files can be shared.
+		if regexes.BlobPath.MatchString(path) {
+			inUse, err := BlobInUse(ctx, state, path, attachmentID)
+			if err != nil {
+				return nil, err
+			}
+
+			if inUse {
+				log.Debugf(ctx, "keeping shared media at storage path: %s", path)
+				continue
+			}
+		}
+
+		files = append(files, path)
+	}
+
+	return files, nil
+}
+
+// DeleteFiles removes the given storage paths of the media attachment
+// with given ID from storage, skipping any content-addressed files that
+// are still in use by other media (see BlobInUse()). An empty ID may be
+// given for files not belonging to any attachment, eg., orphaned files.
+// When deleting an attachment, remove it from the database first, else
+// concurrent deletes of media sharing its files may all keep them.
+// Returns the number of files removed.
+func DeleteFiles(ctx context.Context, state *state.State, attachmentID string, paths ...string) (int, error) {
+	var (
+		removed int
+		errs    gtserror.MultiError
+	)
+
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+
+		ok, err := deleteFile(ctx, state, attachmentID, path)
+		if err != nil {
+			errs.Append(err)
+		} else if ok {
+			removed++
+		}
+	}
+
+	return removed, errs.Combine()
+}
+
+// deleteFile removes the given storage path of the media attachment with given
+// ID from storage, unless it's a content-addressed file that is still in use.
+func deleteFile(ctx context.Context, state *state.State, attachmentID string, path string) (bool, error) {
+	if regexes.BlobPath.MatchString(path) {
+		// Mark the shared file as being removed before checking
+		// whether it's in use, so that media pinning it meanwhile
+		// wait for us to finish, rather than relying on it.
+		mark := &gtsmodel.BlobRef{
+			ID:       id.NewULID(),
+			Path:     path,
+			Deleting: util.Ptr(true),
+		}
+		if err := state.DB.PutBlobRef(ctx, mark); err != nil {
+			return false, gtserror.Newf("error marking %s as being removed: %w", path, err)
+		}
+		defer func() {
+			if err := state.DB.DeleteBlobRefByID(context.WithoutCancel(ctx), mark.ID); err != nil {
+				log.Errorf(ctx, "error unmarking %s as being removed: %v", path, err)
+			}
+		}()
+
+		// Don't outlive the mark.
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, blobDeleteTimeout)
+		defer cancel()
+
+		inUse, err := BlobInUse(ctx, state, path, attachmentID)
+		if err != nil {
+			return false, err
+		}
+
+		if inUse {
+			log.Debugf(ctx, "keeping shared media at storage path: %s", path)
+			return false, nil
+		}
+	}
+
+	log.Debugf(ctx, "removing file: %s", path)
+	err := state.Storage.Delete(ctx, path)
+	if err != nil && !storage.IsNotFound(err) {
+		return false, gtserror.Newf("error removing %s: %w", path, err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,188 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"image"
+	"math"
+	"strings"
+
+	"github.com/buckket/go-blurhash"
+	"github.com/buckket/go-blurhash/base83"
+)
+
+const (
+	// blurhashX and blurhashY are the number
+	// of components we encode blurhashes with.
+	blurhashX = 4
+	blurhashY = 3
+)
+
+// srgbToLinear is a lookup table of
+// 8-bit sRGB channel values to linear.
+var srgbToLinear = func() (table [256]float64) {
+	for i := range table {
+		v := float64(i) / 255
+		if v <= 0.04045 {
+			table[i] = v / 12.92
+		} else {
+			table[i] = math.Pow((v+0.055)/1.055, 2.4)
+		}
+	}
+	return
+}()
+
+// encodeBlurhash encodes a blurhash of the given image, giving the same
+// result as blurhash.Encode(blurhashX, blurhashY, img), but reading pixel
+// data directly rather than through image.Image{}.At(), which allocates
+// per pixel, and precomputing the cosine basis for each component.
+func encodeBlurhash(img *image.NRGBA) (string, error) {
+	width := img.Rect.Dx()
+	height := img.Rect.Dy()
+
+	// Precompute the basis for each x and y component.
+	var xbasis [blurhashX][]float64
+	for c := range xbasis {
+		xbasis[c] = make([]float64, width)
+		for x := range xbasis[c] {
+			xbasis[c][x] = math.Cos(math.Pi * float64(c) * float64(x) / float64(width))
+		}
+	}
+	var ybasis [blurhashY][]float64
+	for c := range ybasis {
+		ybasis[c] = make([]float64, height)
+		for y := range ybasis[c] {
+			ybasis[c][y] = math.Cos(math.Pi * float64(c) * float64(y) / float64(height))
+		}
+	}
+
+	// Normalisation scale of each component's
+	// factors, applied per pixel as the reference
+	// encoder does so the results are identical.
+	var scales [blurhashX * blurhashY]float64
+	for i := range scales {
+		scales[i] = 2 / float64(width*height)
+	}
+	scales[0] = 1 / float64(width*height)
+
+	var factors [blurhashX * blurhashY][3]float64
+	for y := 0; y < height; y++ {
+		row := img.Pix[y*img.Stride : y*img.Stride+width*4]
+		for x := 0; x < width; x++ {
+			px := row[x*4 : x*4+4 : x*4+4]
+
+			// Premultiply by alpha, as
+			// color.NRGBA{}.RGBA() would.
+			a := uint32(px[3]) * 0x101
+			lr := srgbToLinear[(uint32(px[0])*0x101*a/0xffff)>>8]
+			lg := srgbToLinear[(uint32(px[1])*0x101*a/0xffff)>>8]
+			lb := srgbToLinear[(uint32(px[2])*0x101*a/0xffff)>>8]
+
+			for yc := 0; yc < blurhashY; yc++ {
+				for xc := 0; xc < blurhashX; xc++ {
+					i := xc + yc*blurhashX
+					basis := xbasis[xc][x] * ybasis[yc][y]
+					factors[i][0] += lr * basis * scales[i]
+					factors[i][1] += lg * basis * scales[i]
+					factors[i][2] += lb * basis * scales[i]
+				}
+			}
+		}
+	}
+
+	var maxAC float64
+	for _, f := range factors[1:] {
+		for _, v := range f {
+			maxAC = math.Max(math.Abs(v), maxAC)
+		}
+	}
+	quantMaxAC := int(math.Max(0, math.Min(82, math.Floor(maxAC*166-0.5))))
+	maxValue := (float64(quantMaxAC) + 1) / 166
+
+	var sb strings.Builder
+	sb.Grow(4 + 2*blurhashX*blurhashY)
+
+	// Size flag and quantized max AC component.
+	if err := writeBase83(&sb, (blurhashX-1)+(blurhashY-1)*9, 1); err != nil {
+		return "", err
+	}
+	if err := writeBase83(&sb, quantMaxAC, 1); err != nil {
+		return "", err
+	}
+
+	// DC value.
+	dc := linearToSRGB(factors[0][0])<<16 +
+		linearToSRGB(factors[0][1])<<8 +
+		linearToSRGB(factors[0][2])
+	if err := writeBase83(&sb, dc, 4); err != nil {
+		return "", err
+	}
+
+	// AC values.
+	quant := func(v float64) int {
+		v /= maxValue
+		v = math.Copysign(math.Sqrt(math.Abs(v)), v)
+		return int(math.Max(0, math.Min(18, math.Floor(v*9+9.5))))
+	}
+	for _, f := range factors[1:] {
+		ac := quant(f[0])*19*19 + quant(f[1])*19 + quant(f[2])
+		if err := writeBase83(&sb, ac, 2); err != nil {
+			return "", err
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// writeBase83 writes value base83
+// encoded at length to given builder.
+func writeBase83(sb *strings.Builder, value, length int) error {
+	str, err := base83.Encode(value, length)
+	if err != nil {
+		return err
+	}
+	sb.WriteString(str)
+	return nil
+}
+
+// linearToSRGB converts a linear
+// channel value to 8-bit sRGB.
+func linearToSRGB(value float64) int {
+	v := math.Max(0, math.Min(1, value))
+	if v <= 0.0031308 {
+		return int(v*12.92*255 + 0.5)
+	}
+	return int((1.055*math.Pow(v, 1/2.4)-0.055)*255 + 0.5)
+}
+
+// isValidBlurhash returns whether the given string
+// is a well-formed blurhash, ie., one that clients
+// will be able to decode, such as those provided
+// to us alongside remote media.
+func isValidBlurhash(hash string) bool {
+	if _, _, err := blurhash.Components(hash); err != nil {
+		// Bad length or size flag.
+		return false
+	}
+	for i := 1; i < len(hash); i++ {
+		if _, err := base83.Decode(hash[i : i+1]); err != nil {
+			return false
+		}
+	}
+	return true
+}
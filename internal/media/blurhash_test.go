@@ -0,0 +1,178 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/buckket/go-blurhash"
+	"github.com/disintegration/imaging"
+)
+
+func TestEncodeBlurhash(t *testing.T) {
+	// Semi-transparent gradient, to
+	// check alpha is handled the same.
+	gradient := image.NewNRGBA(image.Rect(0, 0, 40, 30))
+	for y := 0; y < 30; y++ {
+		for x := 0; x < 40; x++ {
+			gradient.SetNRGBA(x, y, color.NRGBA{
+				R: uint8(x * 6),
+				G: uint8(y * 8),
+				B: 200,
+				A: uint8(255 - x*4),
+			})
+		}
+	}
+
+	for _, test := range []struct {
+		name  string
+		path  string
+		image image.Image
+	}{
+		{name: "jpeg", path: "./test/test-jpeg.jpg"},
+		{name: "png no alpha", path: "./test/test-png-noalphachannel.png"},
+		{name: "png alpha", path: "./test/test-png-alphachannel.png"},
+		{name: "rainbow", path: "./test/rainbow-original.png"},
+		{name: "1x1", path: "./test/test-jpeg-1x1px-white.jpg"},
+		{name: "gradient", image: gradient},
+		{name: "blank video frame", image: blankImage(640, 480).image},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			img := test.image
+			if img == nil {
+				var err error
+				img, err = imaging.Open(test.path)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			tiny := imaging.Resize(img, 32, 0, imaging.NearestNeighbor)
+
+			expect, err := blurhash.Encode(blurhashX, blurhashY, tiny)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			hash, err := encodeBlurhash(tiny)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if hash != expect {
+				t.Fatalf("expected %q, got %q", expect, hash)
+			}
+		})
+	}
+}
+
+func TestGTSImageBlurhash(t *testing.T) {
+	// Blurhashes previously generated
+	// for test media, which must not
+	// change with the encoder.
+	for _, test := range []struct {
+		path string
+		hash string
+	}{
+		{"./test/test-jpeg.jpg", "LiBzRk#6V[WF_NvzV@WY_3rqV@a$"},
+		{"./test/test-png-noalphachannel.png", "LFQT7e.A%O%4?co$M}M{_1W9~TxV"},
+		{"./test/test-png-alphachannel.png", "LFQT7e.A%O%4?co$M}M{_1W9~TxV"},
+	} {
+		t.Run(test.path, func(t *testing.T) {
+			f, err := os.Open(test.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			img, err := decodeImage(f, imaging.AutoOrientation(true))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			hash, err := img.Thumbnail().Blurhash()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if hash != test.hash {
+				t.Fatalf("expected %q, got %q", test.hash, hash)
+			}
+		})
+	}
+}
+
+func TestIsValidBlurhash(t *testing.T) {
+	for _, test := range []struct {
+		hash  string
+		valid bool
+	}{
+		{"LiBzRk#6V[WF_NvzV@WY_3rqV@a$", true},
+		{"L00000fQfQfQfQfQfQfQfQfQfQfQ", true},
+		{"000000", true},                   // 1x1 components
+		{"", false},                        // empty
+		{"LiBzR", false},                   // too short
+		{"LiBzRk#6V[WF_NvzV@WY_3r", false}, // length doesn't match size flag
+		{"LiBzRk#6V[WF_NvzV@WY_3rqV@a$$", false},
+		{"LiBzRk#6V[WF_NvzV@WY_3rqV@a\"", false}, // not base83
+		{"LiBzRk#6V[WF_NvzV@WY_3rqV@a/", false},
+	} {
+		if valid := isValidBlurhash(test.hash); valid != test.valid {
+			t.Errorf("%q: expected valid %t, got %t", test.hash, test.valid, valid)
+		}
+	}
+}
+
+func TestAcquireEncode(t *testing.T) {
+	m := &Manager{encoding: make(chan struct{}, 2)}
+	ctx := context.Background()
+
+	release1, err := m.acquireEncode(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	release2, err := m.acquireEncode(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release2()
+
+	// Limit reached, so this should wait
+	// until the context is cancelled.
+	ctx1, cncl := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cncl()
+
+	if _, err := m.acquireEncode(ctx1); err == nil {
+		t.Fatal("expected acquire over limit to wait")
+	}
+
+	// Once released, there's room again.
+	release1()
+
+	release3, err := m.acquireEncode(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	release3()
+}
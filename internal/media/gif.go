@@ -0,0 +1,253 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// gifConvertTimeout is the maximum time
+// to wait for ffmpeg to convert a GIF.
+const gifConvertTimeout = 2 * time.Minute
+
+// convertGIF converts the spooled GIF to a soundless
+// looping MP4, if it's animated and it's at least the
+// configured size, and ffmpeg is configured, returning
+// the converted MP4 spooled in its place. The first frame
+// of the GIF is kept in p.gifFrame for the thumbnail.
+//
+// If the GIF is not to be converted, or conversion
+// fails, nil is returned and the GIF should be kept.
+func (p *ProcessingMedia) convertGIF(ctx context.Context, blob *spooledBlob) (*spooledBlob, error) {
+	ffmpeg := config.GetMediaFFmpegPath()
+	if ffmpeg == "" ||
+		blob.size < int64(config.GetMediaGIFConvertMinSize()) {
+		// Conversion disabled,
+		// or GIF is small enough.
+		return nil, nil
+	}
+
+	r, err := blob.reader()
+	if err != nil {
+		return nil, err
+	}
+
+	animated, err := gifIsAnimated(r)
+	if err != nil {
+		// Leave it to decoding
+		// to deal with bad GIFs.
+		log.Warnf(ctx, "error checking whether gif %s is animated: %v", p.media.ID, err)
+		return nil, nil
+	}
+
+	if !animated {
+		// Nothing to gain.
+		return nil, nil
+	}
+
+	// Decode the first frame now, while we
+	// still have the GIF, to use for the
+	// thumbnail; we can't decode MP4 frames.
+	if r, err = blob.reader(); err != nil {
+		return nil, err
+	}
+
+	frame, err := decodeImage(r)
+	if err != nil {
+		return nil, gtserror.Newf("error decoding gif: %w", err)
+	}
+
+	converted, err := convertGIFToMP4(ctx, ffmpeg, blob)
+	if err != nil {
+		// Not the end of the world,
+		// we can just keep the GIF.
+		log.Errorf(ctx, "error converting gif %s to mp4, keeping gif: %v", p.media.ID, err)
+		return nil, nil
+	}
+
+	p.gifFrame = frame
+	blob.close(ctx)
+	return converted, nil
+}
+
+// convertGIFToMP4 runs ffmpeg at given path to convert
+// the spooled GIF to a soundless, looping MP4, returning
+// the result spooled. The given blob is left as-is.
+func convertGIFToMP4(ctx context.Context, ffmpeg string, blob *spooledBlob) (*spooledBlob, error) {
+	out, err := os.CreateTemp("", "gotosocial-gifv-*.mp4")
+	if err != nil {
+		return nil, gtserror.Newf("error creating temporary file: %w", err)
+	}
+
+	defer func() {
+		_ = out.Close()
+		_ = os.Remove(out.Name())
+	}()
+
+	ctx, cncl := context.WithTimeout(ctx, gifConvertTimeout)
+	defer cncl()
+
+	// #nosec G204 -- ffmpeg path is from admin config.
+	cmd := exec.CommandContext(ctx, ffmpeg,
+		"-hide_banner",
+		"-loglevel", "error",
+		"-nostdin",
+		"-y",
+		"-f", "gif",
+		"-i", blob.tmp.Name(),
+		// No audio, and H.264 in a pixel
+		// format and dimensions (even) that
+		// all browsers can play.
+		"-an",
+		"-c:v", "libx264",
+		"-pix_fmt", "yuv420p",
+		"-vf", "scale=trunc(iw/2)*2:trunc(ih/2)*2",
+		"-movflags", "+faststart",
+		"-f", "mp4",
+		out.Name(),
+	)
+
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, gtserror.Newf("error running ffmpeg: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	converted, err := spoolBlob(out)
+	if err != nil {
+		return nil, err
+	}
+
+	if converted.size == 0 {
+		converted.close(ctx)
+		return nil, errors.New("ffmpeg produced empty output")
+	}
+
+	return converted, nil
+}
+
+// gifIsAnimated returns whether the GIF read
+// from r contains more than one image (frame),
+// by walking the GIF's blocks without decoding.
+func gifIsAnimated(r io.Reader) (bool, error) {
+	br := bufio.NewReader(r)
+
+	// Header (6), and logical screen descriptor (7).
+	hdr := make([]byte, 13)
+	if _, err := io.ReadFull(br, hdr); err != nil {
+		return false, err
+	}
+
+	if !strings.HasPrefix(string(hdr), "GIF8") {
+		return false, errors.New("gif missing header")
+	}
+
+	// Skip global color table, if any.
+	if err := skipGIFColorTable(br, hdr[10]); err != nil {
+		return false, err
+	}
+
+	var images int
+	for {
+		introducer, err := br.ReadByte()
+		if err != nil {
+			return false, err
+		}
+
+		switch introducer {
+		case 0x21: // extension
+			// Skip label, then data.
+			if _, err := br.Discard(1); err != nil {
+				return false, err
+			}
+			if err := skipGIFSubBlocks(br); err != nil {
+				return false, err
+			}
+
+		case 0x2c: // image descriptor
+			images++
+			if images > 1 {
+				return true, nil
+			}
+
+			desc := make([]byte, 9)
+			if _, err := io.ReadFull(br, desc); err != nil {
+				return false, err
+			}
+
+			// Skip local color table, if any, then
+			// LZW minimum code size, then image data.
+			if err := skipGIFColorTable(br, desc[8]); err != nil {
+				return false, err
+			}
+			if _, err := br.Discard(1); err != nil {
+				return false, err
+			}
+			if err := skipGIFSubBlocks(br); err != nil {
+				return false, err
+			}
+
+		case 0x3b: // trailer
+			return false, nil
+
+		default:
+			return false, errors.New("gif invalid block introducer")
+		}
+	}
+}
+
+// skipGIFColorTable skips a color table,
+// if present according to given flags.
+func skipGIFColorTable(br *bufio.Reader, flags byte) error {
+	if flags&0x80 == 0 {
+		return nil
+	}
+	_, err := br.Discard(3 * (1 << ((flags & 0x07) + 1)))
+	return err
+}
+
+// skipGIFSubBlocks skips a sequence of data
+// sub-blocks, up to and including terminator.
+func skipGIFSubBlocks(br *bufio.Reader) error {
+	for {
+		size, err := br.ReadByte()
+		if err != nil {
+			return err
+		}
+
+		if size == 0 {
+			return nil
+		}
+
+		if _, err := br.Discard(int(size)); err != nil {
+			return err
+		}
+	}
+}
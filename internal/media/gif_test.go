@@ -0,0 +1,61 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"bytes"
+	"image"
+	"image/color/palette"
+	"image/gif"
+	"os"
+	"testing"
+)
+
+func TestGIFIsAnimated(t *testing.T) {
+	f, err := os.Open("./test/big-panda.gif")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	animated, err := gifIsAnimated(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !animated {
+		t.Fatal("expected big-panda.gif to be animated")
+	}
+}
+
+func TestGIFIsNotAnimated(t *testing.T) {
+	var buf bytes.Buffer
+	img := image.NewPaletted(image.Rect(0, 0, 4, 4), palette.Plan9)
+	if err := gif.Encode(&buf, img, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	animated, err := gifIsAnimated(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if animated {
+		t.Fatal("expected single frame gif not to be animated")
+	}
+}
@@ -27,7 +27,6 @@ import (
 	"io"
 	"sync"
 
-	"github.com/buckket/go-blurhash"
 	"github.com/disintegration/imaging"
 	"github.com/superseriousbusiness/gotosocial/internal/iotools"
 
@@ -120,6 +119,48 @@ func (m *gtsImage) Thumbnail() *gtsImage {
 	return &gtsImage{image: img}
 }
 
+// ResizeFit returns a copy of gtsImage{} resized to fit within
+// maxRes x maxRes, preserving aspect ratio. If the receiving image
+// already fits within these bounds it is returned unchanged.
+func (m *gtsImage) ResizeFit(maxRes uint32) *gtsImage {
+	if m.Width() <= maxRes && m.Height() <= maxRes {
+		return m
+	}
+
+	// Image is too large, needs to be resized to fit.
+	img := imaging.Fit(m.image, int(maxRes), int(maxRes), imaging.Lanczos)
+	return &gtsImage{image: img}
+}
+
+// Reorient returns a copy of gtsImage{} transformed according to
+// the given EXIF orientation value (1-8), so that it displays the
+// right way up without the orientation tag. If orientation is
+// normal (or unknown) the receiving image is returned unchanged.
+func (m *gtsImage) Reorient(orientation int) *gtsImage {
+	var img image.Image
+
+	switch orientation {
+	case 2: // mirror horizontal
+		img = imaging.FlipH(m.image)
+	case 3: // rotate 180
+		img = imaging.Rotate180(m.image)
+	case 4: // mirror vertical
+		img = imaging.FlipV(m.image)
+	case 5: // mirror horizontal and rotate 270 CW
+		img = imaging.Transpose(m.image)
+	case 6: // rotate 90 CW
+		img = imaging.Rotate270(m.image)
+	case 7: // mirror horizontal and rotate 90 CW
+		img = imaging.Transverse(m.image)
+	case 8: // rotate 270 CW
+		img = imaging.Rotate90(m.image)
+	default:
+		return m
+	}
+
+	return &gtsImage{image: img}
+}
+
 // Blurhash calculates the blurhash for the receiving image data.
 func (m *gtsImage) Blurhash() (string, error) {
 	// for generating blurhashes, it's more cost effective to
@@ -127,7 +168,7 @@ func (m *gtsImage) Blurhash() (string, error) {
 	tiny := imaging.Resize(m.image, 32, 0, imaging.NearestNeighbor)
 
 	// Encode blurhash from resized version
-	return blurhash.Encode(4, 3, tiny)
+	return encodeBlurhash(tiny)
 }
 
 // ToJPEG creates a new streaming JPEG encoder from receiving image, and a size ptr
@@ -20,9 +20,11 @@ package media
 import (
 	"context"
 	"io"
+	"runtime"
 	"time"
 
 	"codeberg.org/gruf/go-iotools"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
@@ -49,11 +51,38 @@ var SupportedEmojiMIMETypes = []string{
 
 type Manager struct {
 	state *state.State
+
+	// encoding bounds the number of
+	// images being decoded / encoded
+	// at once, see acquireEncode().
+	encoding chan struct{}
 }
 
 // NewManager returns a media manager with given state.
 func NewManager(state *state.State) *Manager {
-	return &Manager{state: state}
+	n := config.GetMediaEncodeConcurrency()
+	if n <= 0 {
+		n = runtime.GOMAXPROCS(0)
+	}
+
+	return &Manager{
+		state:    state,
+		encoding: make(chan struct{}, n),
+	}
+}
+
+// acquireEncode blocks until fewer than the configured number
+// of images are being decoded / encoded, so that processing
+// bursts of (large) media doesn't hog the CPU, or until the
+// context is cancelled. On success, the caller must call the
+// returned function once it's done decoding / encoding.
+func (m *Manager) acquireEncode(ctx context.Context) (func(), error) {
+	select {
+	case m.encoding <- struct{}{}:
+		return func() { <-m.encoding }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
 }
 
 // PreProcessMedia begins the process of decoding
@@ -138,7 +167,9 @@ func (m *Manager) PreProcessMedia(
 			attachment.ScheduledStatusID = *ai.ScheduledStatusID
 		}
 
-		if ai.Blurhash != nil {
+		if ai.Blurhash != nil && isValidBlurhash(*ai.Blurhash) {
+			// Only trust well-formed blurhashes,
+			// else we'll generate our own one.
 			attachment.Blurhash = *ai.Blurhash
 		}
 
@@ -21,18 +21,25 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"image"
+	"image/color"
+	"image/png"
 	"io"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
 	"codeberg.org/gruf/go-storage/disk"
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	gtsmodel "github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/storage"
 	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -436,6 +443,85 @@ func (suite *ManagerTestSuite) TestSimpleJpegProcessBlocking() {
 	suite.Equal(processedThumbnailBytesExpected, processedThumbnailBytes)
 }
 
+func (suite *ManagerTestSuite) TestSimpleJpegProcessBlockingRemoteBlurhash() {
+	ctx := context.Background()
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		// load bytes from a test image
+		b, err := os.ReadFile("./test/test-jpeg.jpg")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	for _, test := range []struct {
+		remote string
+		expect string
+	}{
+		// Valid remote blurhash is kept.
+		{"L00000fQfQfQfQfQfQfQfQfQfQfQ", "L00000fQfQfQfQfQfQfQfQfQfQfQ"},
+
+		// Invalid remote blurhashes are replaced.
+		{"not a blurhash", "LiBzRk#6V[WF_NvzV@WY_3rqV@a$"},
+		{"LiBzRk#6V[WF_NvzV@WY_3r", "LiBzRk#6V[WF_NvzV@WY_3rqV@a$"},
+	} {
+		processingMedia := suite.manager.PreProcessMedia(data, accountID, &media.AdditionalMediaInfo{
+			RemoteURL: util.Ptr("http://example.org/media/" + id.NewULID() + ".jpg"),
+			Blurhash:  util.Ptr(test.remote),
+		})
+
+		attachment, err := processingMedia.LoadAttachment(ctx)
+		suite.NoError(err)
+		suite.NotNil(attachment)
+		suite.Equal(test.expect, attachment.Blurhash)
+	}
+}
+
+func (suite *ManagerTestSuite) TestSimpleJpegProcessBlockingMaxResolution() {
+	ctx := context.Background()
+
+	// Downscale anything larger than 800px.
+	config.SetMediaImageMaxResolution(800)
+	defer config.SetMediaImageMaxResolution(0)
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		// load bytes from a test image
+		b, err := os.ReadFile("./test/test-jpeg.jpg")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	// process the media with no additional info provided
+	processingMedia := suite.manager.PreProcessMedia(data, accountID, nil)
+
+	// do a blocking call to fetch the attachment
+	attachment, err := processingMedia.LoadAttachment(ctx)
+	suite.NoError(err)
+	suite.NotNil(attachment)
+
+	// original should have been downscaled to fit
+	suite.EqualValues(gtsmodel.Original{
+		Width: 800, Height: 450, Size: 360000, Aspect: 1.7777777777777777,
+	}, attachment.FileMeta.Original)
+	suite.EqualValues(gtsmodel.Small{
+		Width: 512, Height: 288, Size: 147456, Aspect: 1.7777777777777777,
+	}, attachment.FileMeta.Small)
+	suite.Equal("image/jpeg", attachment.File.ContentType)
+
+	// the downscaled file in storage should be smaller than the original
+	processedFullBytes, err := suite.storage.Get(ctx, attachment.File.Path)
+	suite.NoError(err)
+	suite.Equal(attachment.File.FileSize, len(processedFullBytes))
+	suite.Less(attachment.File.FileSize, 269739)
+}
+
 func (suite *ManagerTestSuite) TestSimpleJpegProcessPartial() {
 	ctx := context.Background()
 
@@ -464,9 +550,9 @@ func (suite *ManagerTestSuite) TestSimpleJpegProcessPartial() {
 	// do a blocking call to fetch the attachment
 	attachment, err := processingMedia.LoadAttachment(ctx)
 
-	// Since we're cutting off the byte stream
-	// halfway through, we should get an error here.
-	suite.EqualError(err, "store: error writing media to storage: scan-data is unbounded; EOI not encountered before EOF")
+	// Since we're cutting off the byte stream halfway
+	// through, we should get an error decoding it here.
+	suite.EqualError(err, "finish: error decoding image: invalid JPEG format: short Huffman data")
 	suite.NotNil(attachment)
 
 	// make sure it's got the stuff set on it that we expect
@@ -997,7 +1083,7 @@ func (suite *ManagerTestSuite) TestPngNoAlphaChannelProcessBlocking() {
 	}, attachment.FileMeta.Small)
 	suite.Equal("image/png", attachment.File.ContentType)
 	suite.Equal("image/jpeg", attachment.Thumbnail.ContentType)
-	suite.Equal(17471, attachment.File.FileSize)
+	suite.Equal(16261, attachment.File.FileSize)
 	suite.Equal("LFQT7e.A%O%4?co$M}M{_1W9~TxV", attachment.Blurhash)
 
 	// now make sure the attachment is in the database
@@ -1068,7 +1154,7 @@ func (suite *ManagerTestSuite) TestPngAlphaChannelProcessBlocking() {
 	}, attachment.FileMeta.Small)
 	suite.Equal("image/png", attachment.File.ContentType)
 	suite.Equal("image/jpeg", attachment.Thumbnail.ContentType)
-	suite.Equal(18904, attachment.File.FileSize)
+	suite.Equal(18324, attachment.File.FileSize)
 	suite.Equal("LFQT7e.A%O%4?co$M}M{_1W9~TxV", attachment.Blurhash)
 
 	// now make sure the attachment is in the database
@@ -1101,6 +1187,73 @@ func (suite *ManagerTestSuite) TestPngAlphaChannelProcessBlocking() {
 	suite.Equal(processedThumbnailBytesExpected, processedThumbnailBytes)
 }
 
+func (suite *ManagerTestSuite) TestPngAlphaChannelAvatarProcessBlocking() {
+	ctx := context.Background()
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		// The alphachannel test image doesn't actually
+		// have any transparent pixels, so draw our own
+		// image with a transparent left half instead.
+		img := image.NewNRGBA(image.Rect(0, 0, 64, 64))
+		for y := 0; y < 64; y++ {
+			for x := 0; x < 64; x++ {
+				var a uint8 = 0xff
+				if x < 32 {
+					a = 0
+				}
+				img.SetNRGBA(x, y, color.NRGBA{R: 0xff, A: a})
+			}
+		}
+
+		buf := new(bytes.Buffer)
+		if err := png.Encode(buf, img); err != nil {
+			panic(err)
+		}
+		return io.NopCloser(buf), int64(buf.Len()), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	// process the media as an avatar
+	processingMedia := suite.manager.PreProcessMedia(data, accountID, &media.AdditionalMediaInfo{
+		Avatar: util.Ptr(true),
+	})
+
+	// do a blocking call to fetch the attachment
+	attachment, err := processingMedia.LoadAttachment(ctx)
+	suite.NoError(err)
+	suite.NotNil(attachment)
+
+	// the thumbnail is the avatar's static variant,
+	// so it should be a png to keep transparency
+	suite.Equal("image/png", attachment.File.ContentType)
+	suite.Equal("image/png", attachment.Thumbnail.ContentType)
+	suite.True(strings.HasSuffix(attachment.Thumbnail.URL, ".png"))
+
+	// make sure the thumbnail is in storage
+	processedThumbnailBytes, err := suite.storage.Get(ctx, attachment.Thumbnail.Path)
+	suite.NoError(err)
+	suite.NotEmpty(processedThumbnailBytes)
+
+	// and that it has actually kept the transparency
+	thumb, err := png.Decode(bytes.NewReader(processedThumbnailBytes))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	var transparent bool
+	bounds := thumb.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y && !transparent; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if _, _, _, a := thumb.At(x, y).RGBA(); a < 0xffff {
+				transparent = true
+				break
+			}
+		}
+	}
+	suite.True(transparent)
+}
+
 func (suite *ManagerTestSuite) TestSimpleJpegProcessBlockingWithCallback() {
 	ctx := context.Background()
 
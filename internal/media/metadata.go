@@ -0,0 +1,324 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"image/jpeg"
+	"io"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+var (
+	// jpegEXIFPrefix prefixes the TIFF data
+	// in an APP1 segment containing EXIF.
+	jpegEXIFPrefix = []byte("Exif\x00\x00")
+
+	// pngSignature is the first 8 bytes of any PNG.
+	pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+	// pngMetadataChunkTypes are the PNG chunk
+	// types that carry metadata rather than
+	// anything needed to display the image.
+	pngMetadataChunkTypes = map[string]bool{
+		"eXIf": true, // EXIF
+		"iTXt": true, // text, including XMP
+		"tEXt": true, // text
+		"zTXt": true, // compressed text
+		"tIME": true, // modification time
+	}
+)
+
+// metadataStripper wraps a reader of image data, and passes
+// through only those parts of it returned by next, which reads
+// the next structural part (eg., segment, or chunk) of the image,
+// until next returns more = false, after which it passes through
+// the remainder of the image as-is.
+//
+// An EXIF orientation value found by next, if any, is kept in
+// orientation, so that it can be applied to the image itself once
+// it's been read, as the metadata it was in will have been dropped.
+type metadataStripper struct {
+	br          *bufio.Reader
+	next        func(br *bufio.Reader) (keep []byte, more bool, err error)
+	buf         []byte
+	more        bool
+	err         error
+	orientation int
+}
+
+func (s *metadataStripper) Read(p []byte) (int, error) {
+	for len(s.buf) == 0 {
+		if s.err != nil {
+			return 0, s.err
+		}
+
+		if !s.more {
+			return s.br.Read(p)
+		}
+
+		s.buf, s.more, s.err = s.next(s.br)
+	}
+
+	n := copy(p, s.buf)
+	s.buf = s.buf[n:]
+	return n, nil
+}
+
+// newJPEGMetadataStripper returns a reader of the JPEG read from r,
+// without any EXIF or XMP (APP1) segments, or IPTC (APP13) segments.
+func newJPEGMetadataStripper(r io.Reader) *metadataStripper {
+	s := &metadataStripper{br: bufio.NewReader(r), more: true}
+	started := false
+
+	s.next = func(br *bufio.Reader) ([]byte, bool, error) {
+		if !started {
+			started = true
+
+			soi := make([]byte, 2)
+			if _, err := io.ReadFull(br, soi); err != nil {
+				return nil, false, err
+			}
+
+			if soi[0] != 0xff || soi[1] != 0xd8 {
+				return nil, false, errors.New("jpeg missing start of image marker")
+			}
+
+			return soi, true, nil
+		}
+
+		b, err := br.ReadByte()
+		if err != nil {
+			return nil, false, err
+		}
+
+		if b != 0xff {
+			return nil, false, errors.New("jpeg missing segment marker")
+		}
+
+		// Skip any fill bytes
+		// before the marker.
+		marker := byte(0xff)
+		for marker == 0xff {
+			if marker, err = br.ReadByte(); err != nil {
+				return nil, false, err
+			}
+		}
+
+		switch {
+		case marker == 0xd9:
+			// End of image, pass through
+			// anything trailing it as-is.
+			return []byte{0xff, marker}, false, nil
+
+		case marker == 0x01 || (marker >= 0xd0 && marker <= 0xd7):
+			// Standalone marker.
+			return []byte{0xff, marker}, true, nil
+		}
+
+		var buf bytes.Buffer
+		buf.Write([]byte{0xff, marker})
+
+		if _, err := io.CopyN(&buf, br, 2); err != nil {
+			return nil, false, err
+		}
+
+		length := int64(binary.BigEndian.Uint16(buf.Bytes()[2:]))
+		if length < 2 {
+			return nil, false, errors.New("jpeg invalid segment length")
+		}
+
+		if _, err := io.CopyN(&buf, br, length-2); err != nil {
+			return nil, false, err
+		}
+
+		switch marker {
+		case 0xda:
+			// Start of scan, everything
+			// after is image data.
+			return buf.Bytes(), false, nil
+
+		case 0xe1:
+			// APP1, EXIF or XMP: drop it, but
+			// take orientation from EXIF first.
+			data := buf.Bytes()[4:]
+			if s.orientation == 0 && bytes.HasPrefix(data, jpegEXIFPrefix) {
+				s.orientation = exifOrientation(data[len(jpegEXIFPrefix):])
+			}
+			return nil, true, nil
+
+		case 0xed:
+			// APP13, IPTC: drop it.
+			return nil, true, nil
+		}
+
+		return buf.Bytes(), true, nil
+	}
+
+	return s
+}
+
+// newPNGMetadataStripper returns a reader of the PNG read
+// from r, without any chunks in pngMetadataChunkTypes.
+func newPNGMetadataStripper(r io.Reader) *metadataStripper {
+	s := &metadataStripper{br: bufio.NewReader(r), more: true}
+	started := false
+
+	s.next = func(br *bufio.Reader) ([]byte, bool, error) {
+		if !started {
+			started = true
+
+			sig := make([]byte, len(pngSignature))
+			if _, err := io.ReadFull(br, sig); err != nil {
+				return nil, false, err
+			}
+
+			if !bytes.Equal(sig, pngSignature) {
+				return nil, false, errors.New("png missing signature")
+			}
+
+			return sig, true, nil
+		}
+
+		// Read chunk length and type.
+		var buf bytes.Buffer
+		if _, err := io.CopyN(&buf, br, 8); err != nil {
+			return nil, false, err
+		}
+
+		length := int64(binary.BigEndian.Uint32(buf.Bytes()))
+		chunkType := string(buf.Bytes()[4:8])
+
+		// Read chunk data and CRC.
+		if _, err := io.CopyN(&buf, br, length+4); err != nil {
+			return nil, false, err
+		}
+
+		if chunkType == "IEND" {
+			// End of image, pass through
+			// anything trailing it as-is.
+			return buf.Bytes(), false, nil
+		}
+
+		if pngMetadataChunkTypes[chunkType] {
+			if chunkType == "eXIf" && s.orientation == 0 {
+				s.orientation = exifOrientation(buf.Bytes()[8 : 8+length])
+			}
+			return nil, true, nil
+		}
+
+		return buf.Bytes(), true, nil
+	}
+
+	return s
+}
+
+// exifOrientation returns the value of the orientation tag
+// (1-8) in IFD0 of the given EXIF TIFF data, or 0 if none.
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 0
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0
+	}
+
+	offset := int(order.Uint32(tiff[4:]))
+	if offset < 8 || offset+2 > len(tiff) {
+		return 0
+	}
+
+	count := int(order.Uint16(tiff[offset:]))
+	for i := 0; i < count; i++ {
+		entry := offset + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 0
+		}
+
+		const (
+			tagOrientation = 0x0112
+			typeShort      = 3
+		)
+
+		if order.Uint16(tiff[entry:]) != tagOrientation ||
+			order.Uint16(tiff[entry+2:]) != typeShort {
+			continue
+		}
+
+		value := int(order.Uint16(tiff[entry+8:]))
+		if value < 1 || value > 8 {
+			return 0
+		}
+
+		return value
+	}
+
+	return 0
+}
+
+// reorientBlob applies the given EXIF orientation to the
+// spooled image with given file extension, returning the
+// re-encoded result spooled in its place. The original
+// spooled blob is closed.
+func reorientBlob(ctx context.Context, blob *spooledBlob, orientation int, ext string) (*spooledBlob, error) {
+	r, err := blob.reader()
+	if err != nil {
+		return nil, err
+	}
+
+	if ext == "png" {
+		r = &pngAncillaryChunkStripper{Reader: r}
+	}
+
+	img, err := decodeImage(r)
+	if err != nil {
+		return nil, gtserror.Newf("error decoding image: %w", err)
+	}
+
+	img = img.Reorient(orientation)
+
+	var enc io.Reader
+	if ext == "png" {
+		enc = img.ToPNG()
+	} else {
+		enc = img.ToJPEG(&jpeg.Options{
+			Quality: config.GetMediaImageQuality(),
+		})
+	}
+
+	reoriented, err := spoolBlob(enc)
+	if err != nil {
+		return nil, gtserror.Newf("error encoding reoriented image: %w", err)
+	}
+
+	blob.close(ctx)
+	return reoriented, nil
+}
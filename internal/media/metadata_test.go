@@ -0,0 +1,112 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/jpeg"
+	"io"
+	"os"
+	"testing"
+)
+
+// exifWithOrientation returns an APP1 segment
+// containing EXIF with the given orientation.
+func exifWithOrientation(orientation uint16) []byte {
+	var tiff bytes.Buffer
+	tiff.WriteString("MM")
+	_ = binary.Write(&tiff, binary.BigEndian, uint16(42))
+	_ = binary.Write(&tiff, binary.BigEndian, uint32(8))
+	_ = binary.Write(&tiff, binary.BigEndian, uint16(1))      // entries
+	_ = binary.Write(&tiff, binary.BigEndian, uint16(0x0112)) // orientation
+	_ = binary.Write(&tiff, binary.BigEndian, uint16(3))      // short
+	_ = binary.Write(&tiff, binary.BigEndian, uint32(1))      // count
+	_ = binary.Write(&tiff, binary.BigEndian, orientation)
+	_ = binary.Write(&tiff, binary.BigEndian, uint16(0)) // padding
+	_ = binary.Write(&tiff, binary.BigEndian, uint32(0)) // next ifd
+	return app1(append([]byte("Exif\x00\x00"), tiff.Bytes()...))
+}
+
+func app1(data []byte) []byte {
+	seg := []byte{0xff, 0xe1, 0, 0}
+	binary.BigEndian.PutUint16(seg[2:], uint16(len(data)+2))
+	return append(seg, data...)
+}
+
+func TestJPEGMetadataStripper(t *testing.T) {
+	var enc bytes.Buffer
+	if err := jpeg.Encode(&enc, image.NewGray(image.Rect(0, 0, 4, 2)), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// Insert EXIF and XMP segments after SOI.
+	var in bytes.Buffer
+	in.Write(enc.Bytes()[:2])
+	in.Write(exifWithOrientation(6))
+	in.Write(app1([]byte("http://ns.adobe.com/xap/1.0/\x00<x:xmpmeta/>")))
+	in.Write(enc.Bytes()[2:])
+
+	stripper := newJPEGMetadataStripper(&in)
+	out, err := io.ReadAll(stripper)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(out, enc.Bytes()) {
+		t.Fatal("expected metadata segments to be stripped")
+	}
+
+	if stripper.orientation != 6 {
+		t.Fatalf("expected orientation 6, got %d", stripper.orientation)
+	}
+
+	img, err := decodeImage(bytes.NewReader(out))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	img = img.Reorient(stripper.orientation)
+	if img.Width() != 2 || img.Height() != 4 {
+		t.Fatalf("expected reoriented image to be 2x4, got %dx%d", img.Width(), img.Height())
+	}
+}
+
+func TestPNGMetadataStripper(t *testing.T) {
+	f, err := os.Open("./test/test-png-alphachannel.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	out, err := io.ReadAll(newPNGMetadataStripper(f))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, chunkType := range []string{"eXIf", "iTXt"} {
+		if bytes.Contains(out, []byte(chunkType)) {
+			t.Fatalf("expected %s chunk to be stripped", chunkType)
+		}
+	}
+
+	if _, err := decodeImage(bytes.NewReader(out)); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -280,6 +280,13 @@ func (p *ProcessingEmoji) store(ctx context.Context) error {
 }
 
 func (p *ProcessingEmoji) finish(ctx context.Context) error {
+	// Wait our turn to decode / encode.
+	release, err := p.mgr.acquireEncode(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
 	// Fetch a stream to the original file in storage.
 	rc, err := p.mgr.state.Storage.GetStream(ctx, p.emoji.ImagePath)
 	if err != nil {
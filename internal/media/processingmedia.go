@@ -22,6 +22,7 @@ import (
 	"context"
 	"image/jpeg"
 	"io"
+	"slices"
 	"time"
 
 	errorsv2 "codeberg.org/gruf/go-errors/v2"
@@ -29,10 +30,11 @@ import (
 	terminator "codeberg.org/superseriousbusiness/exif-terminator"
 	"github.com/disintegration/imaging"
 	"github.com/h2non/filetype"
+	"github.com/superseriousbusiness/gotosocial/internal/clamav"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
-	"github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
@@ -41,13 +43,16 @@ import (
 // currently being processed. It exposes functions
 // for retrieving data from the process.
 type ProcessingMedia struct {
-	media   *gtsmodel.MediaAttachment // processing media attachment details
-	dataFn  DataFunc                  // load-data function, returns media stream
-	recache bool                      // recaching existing (uncached) media
-	done    bool                      // done is set when process finishes with non ctx canceled type error
-	proc    runners.Processor         // proc helps synchronize only a singular running processing instance
-	err     error                     // error stores permanent error value when done
-	mgr     *Manager                  // mgr instance (access to db / storage)
+	media    *gtsmodel.MediaAttachment // processing media attachment details
+	dataFn   DataFunc                  // load-data function, returns media stream
+	recache  bool                      // recaching existing (uncached) media
+	inserted bool                      // inserted is set when a placeholder was already put in the database
+	done     bool                      // done is set when process finishes with non ctx canceled type error
+	proc     runners.Processor         // proc helps synchronize only a singular running processing instance
+	err      error                     // error stores permanent error value when done
+	mgr      *Manager                  // mgr instance (access to db / storage)
+	gifFrame *gtsImage                 // gifFrame is the first frame of an animated GIF converted to MP4, for the thumbnail
+	pinned   []*gtsmodel.BlobRef       // pins of storage paths, unpinned once the attachment is stored
 }
 
 // AttachmentID returns the ID of the underlying
@@ -89,6 +94,52 @@ func (p *ProcessingMedia) LoadAttachment(ctx context.Context) (*gtsmodel.MediaAt
 	return media, err
 }
 
+// Placeholder inserts the not-yet-processed attachment
+// into the database, so that it can be fetched by ID
+// while processing continues asynchronously, e.g. by
+// queueing Process. Once processing finishes, the stored
+// attachment is updated with the outcome, whether that
+// be success or failure.
+//
+// The returned attachment is a copy that is safe to use
+// while processing goes on in the background.
+func (p *ProcessingMedia) Placeholder(ctx context.Context) (*gtsmodel.MediaAttachment, error) {
+	var media *gtsmodel.MediaAttachment
+
+	err := p.proc.Process(func() error {
+		if p.done || p.recache || p.inserted {
+			return gtserror.New("media already processed or stored")
+		}
+
+		// Mark as processing; the URL
+		// etc are not yet usable.
+		p.media.Processing = gtsmodel.ProcessingStatusProcessing
+
+		// The thumbnail isn't known until
+		// processing finishes, but it can't
+		// be left empty, so set placeholders.
+		p.media.Thumbnail.ContentType = p.media.File.ContentType
+		p.media.Thumbnail.Path = uris.StoragePathForAttachment(
+			p.media.AccountID,
+			string(TypeAttachment),
+			string(SizeSmall),
+			p.media.ID,
+			"unknown",
+		)
+
+		if err := p.mgr.state.DB.PutAttachment(ctx, p.media); err != nil {
+			return err
+		}
+
+		p.inserted = true
+		media = new(gtsmodel.MediaAttachment)
+		*media = *p.media
+		return nil
+	})
+
+	return media, err
+}
+
 // Process allows the receiving object to fit the
 // runners.WorkerFunc signature. It performs a
 // (blocking) load and logs on error.
@@ -128,6 +179,10 @@ func (p *ProcessingMedia) load(ctx context.Context) (*gtsmodel.MediaAttachment,
 			p.err = err
 		}()
 
+		// Release pinned storage paths
+		// once the attachment is stored.
+		defer p.unpinAll(ctx)
+
 		// Gather errors as we proceed.
 		var errs = gtserror.NewMultiError(4)
 
@@ -153,15 +208,28 @@ func (p *ProcessingMedia) load(ctx context.Context) (*gtsmodel.MediaAttachment,
 		// jpeg, which is fine, but streaming it to storage
 		// was interrupted halfway through and so it was
 		// never decoded). Try to clean up in this case.
-		if p.media.Type == gtsmodel.FileTypeUnknown {
-			deleteErr := p.mgr.state.Storage.Delete(ctx, p.media.File.Path)
-			if deleteErr != nil && !storage.IsNotFound(deleteErr) {
+		//
+		// Infected media was either never stored, or
+		// deliberately kept in quarantine, so skip it.
+		if p.media.Type == gtsmodel.FileTypeUnknown &&
+			p.media.ScanStatus != gtsmodel.ScanStatusInfected {
+			p.unpin(ctx, p.media.File.Path)
+			_, deleteErr := DeleteFiles(ctx, p.mgr.state, p.media.ID, p.media.File.Path)
+			if deleteErr != nil {
 				errs.Append(deleteErr)
 			}
 		}
 
 		var dbErr error
 		switch {
+		case p.inserted:
+			// Placeholder was inserted already, update it with
+			// the outcome so that anyone polling can see it.
+			if len(errs) != 0 || p.media.Type == gtsmodel.FileTypeUnknown {
+				p.media.Processing = gtsmodel.ProcessingStatusError
+			}
+			dbErr = p.mgr.state.DB.UpdateAttachment(ctx, p.media)
+
 		case !p.recache:
 			// First time caching this attachment, insert it.
 			dbErr = p.mgr.state.DB.PutAttachment(ctx, p.media)
@@ -255,6 +323,16 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 	// this file in storage.
 	store := true
 
+	// Always strip metadata from local uploads,
+	// and from remote media if configured to.
+	strip := p.media.RemoteURL == "" ||
+		config.GetMediaRemoteStripMetadata()
+
+	// stripper strips metadata from
+	// jpeg and png images as they're
+	// streamed, if set.
+	var stripper *metadataStripper
+
 	switch info.Extension {
 	case "mp4":
 		// No problem.
@@ -262,8 +340,20 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 	case "gif":
 		// No problem
 
-	case "jpg", "jpeg", "png", "webp":
-		if fileSize > 0 {
+	case "jpg", "jpeg":
+		if strip {
+			stripper = newJPEGMetadataStripper(r)
+			r = stripper
+		}
+
+	case "png":
+		if strip {
+			stripper = newPNGMetadataStripper(r)
+			r = stripper
+		}
+
+	case "webp":
+		if strip && fileSize > 0 {
 			// A file size was provided so we can clean
 			// exif data from image as we're streaming it.
 			r, err = terminator.Terminate(r, fileSize, info.Extension)
@@ -320,26 +410,82 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 		return nil
 	}
 
-	// File shouldn't already exist in storage at this point,
-	// but we do a check as it's worth logging / cleaning up.
-	if have, _ := p.mgr.state.Storage.Has(ctx, p.media.File.Path); have {
-		log.Warnf(ctx, "media already exists at storage path: %s", p.media.File.Path)
+	// Spool the final reader stream so we
+	// know its content hash, and so it can
+	// be scanned before it's stored.
+	blob, err := spoolBlob(r)
+	if err != nil {
+		return err
+	}
+	defer func() { blob.close(ctx) }()
 
-		// Attempt to remove existing media at storage path (might be broken / out-of-date)
-		if err := p.mgr.state.Storage.Delete(ctx, p.media.File.Path); err != nil {
-			return gtserror.Newf("error removing media from storage: %v", err)
+	if stripper != nil && stripper.orientation > 1 {
+		// The orientation tag was stripped
+		// along with the rest of the metadata,
+		// so apply it to the image itself.
+		reoriented, err := reorientBlob(ctx, blob, stripper.orientation, info.Extension)
+		if err != nil {
+			return err
 		}
+		blob = reoriented
 	}
 
-	// Write the final reader stream to our storage.
-	wroteSize, err := p.mgr.state.Storage.PutStream(ctx, p.media.File.Path, r)
+	// Set actual read size
+	// as authoritative file size.
+	p.media.File.FileSize = int(blob.size)
+
+	// Only scan local uploads; remote media
+	// is the remote instance's business, and
+	// we don't serve it to our users as theirs.
+	if p.media.RemoteURL == "" {
+		if err := p.scan(ctx, blob, info.Extension); err != nil {
+			return err
+		}
+	}
+
+	ext := info.Extension
+	if ext == "gif" && !p.isProfileMedia() {
+		// Large animated GIFs are converted
+		// to much smaller soundless, looping
+		// MP4 (gifv), if configured. Avatars
+		// and headers are always kept as GIF,
+		// since they're displayed as images.
+		converted, err := p.convertGIF(ctx, blob)
+		if err != nil {
+			return err
+		}
+
+		if converted != nil {
+			blob = converted
+			ext = "mp4"
+
+			// Stored file is changing type,
+			// so update the URL, type and size.
+			p.media.URL = uris.URIForAttachment(
+				p.media.AccountID,
+				string(TypeAttachment),
+				string(SizeOriginal),
+				p.media.ID,
+				ext,
+			)
+			p.media.File.ContentType = mimeVideoMp4
+			p.media.File.FileSize = int(blob.size)
+		}
+	}
+
+	// Write the spooled media to our storage,
+	// by content hash, so identical media (eg.,
+	// the same remote attachment on multiple
+	// statuses) is only stored once.
+	path := uris.StoragePathForBlob(blob.hash, ext)
+	ref, err := blob.put(ctx, p.mgr.state, path)
 	if err != nil {
-		return gtserror.Newf("error writing media to storage: %w", err)
+		return err
 	}
+	p.pinned = append(p.pinned, ref)
 
-	// Set actual written size
-	// as authoritative file size.
-	p.media.File.FileSize = int(wroteSize)
+	// Update to the stored path.
+	p.media.File.Path = path
 
 	// We can now consider this cached.
 	p.media.Cached = util.Ptr(true)
@@ -347,9 +493,78 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 	return nil
 }
 
+// scan scans the spooled media with ClamAV, if configured, recording
+// the result on the attachment. Infected media is either rejected, or
+// stored (but not served) in quarantine, depending on configuration;
+// either way ErrInfected is returned. Media that couldn't be scanned,
+// eg., because clamd is unreachable, is let through.
+func (p *ProcessingMedia) scan(ctx context.Context, blob *spooledBlob, ext string) error {
+	address := config.GetMediaClamAVAddress()
+	if address == "" {
+		// Scanning disabled.
+		return nil
+	}
+
+	r, err := blob.reader()
+	if err != nil {
+		return err
+	}
+
+	client := clamav.New(address, config.GetMediaClamAVTimeout())
+	result, err := client.Scan(ctx, r)
+	p.media.ScannedAt = time.Now()
+
+	if err != nil {
+		// Fail open, so uploads still work
+		// when clamd is down or overloaded.
+		log.Errorf(ctx, "error scanning media %s, allowing it through: %v", p.media.ID, err)
+		p.media.ScanStatus = gtsmodel.ScanStatusError
+		p.media.ScanResult = err.Error()
+		return nil
+	}
+
+	if !result.Infected {
+		p.media.ScanStatus = gtsmodel.ScanStatusClean
+		return nil
+	}
+
+	p.media.ScanStatus = gtsmodel.ScanStatusInfected
+	p.media.ScanResult = result.Virus
+
+	log.Warnf(ctx,
+		"media %s uploaded by account %s is infected with %s (action: %s)",
+		p.media.ID, p.media.AccountID, result.Virus, config.GetMediaClamAVAction(),
+	)
+
+	if config.GetMediaClamAVAction() == config.MediaClamAVActionQuarantine {
+		// Keep the file out of the way, for admin
+		// inspection. It's never served, as it's
+		// not marked as cached, nor cleaned up.
+		path := uris.StoragePathForQuarantine(blob.hash, ext)
+		if err := blob.write(ctx, p.mgr.state, path); err != nil {
+			return err
+		}
+		p.media.File.Path = path
+	}
+
+	return gtserror.Newf("%w: %s", ErrInfected, result.Virus)
+}
+
 func (p *ProcessingMedia) finish(ctx context.Context) error {
 	// Make a jolly assumption about thumbnail type.
-	p.media.Thumbnail.ContentType = mimeImageJpeg
+	thumbExt, thumbType := "jpg", mimeImageJpeg
+
+	if p.isProfileMedia() {
+		switch p.media.File.ContentType {
+		case mimeImagePng, mimeImageGif, mimeImageWebp:
+			// The thumbnail of an avatar or header
+			// is served as its static variant, so
+			// keep any transparency of the original.
+			thumbExt, thumbType = "png", mimeImagePng
+		}
+	}
+
+	p.media.Thumbnail.ContentType = thumbType
 
 	// Calculate attachment thumbnail file path
 	p.media.Thumbnail.Path = uris.StoragePathForAttachment(
@@ -357,9 +572,7 @@ func (p *ProcessingMedia) finish(ctx context.Context) error {
 		string(TypeAttachment),
 		string(SizeSmall),
 		p.media.ID,
-		// Always encode attachment
-		// thumbnails as jpg.
-		"jpg",
+		thumbExt,
 	)
 
 	// Calculate attachment thumbnail serve path.
@@ -368,9 +581,7 @@ func (p *ProcessingMedia) finish(ctx context.Context) error {
 		string(TypeAttachment),
 		string(SizeSmall),
 		p.media.ID,
-		// Always encode attachment
-		// thumbnails as jpg.
-		"jpg",
+		thumbExt,
 	)
 
 	// If original file hasn't been stored, there's
@@ -381,6 +592,13 @@ func (p *ProcessingMedia) finish(ctx context.Context) error {
 		return nil
 	}
 
+	// Wait our turn to decode / encode.
+	release, err := p.mgr.acquireEncode(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
 	// Get a stream to the original file for further processing.
 	rc, err := p.mgr.state.Storage.GetStream(ctx, p.media.File.Path)
 	if err != nil {
@@ -426,7 +644,7 @@ func (p *ProcessingMedia) finish(ctx context.Context) error {
 
 	// .mp4 video type
 	case mimeVideoMp4:
-		video, err := decodeVideoFrame(rc)
+		video, err := decodeVideoFrame(ctx, rc)
 		if err != nil {
 			return gtserror.Newf("error decoding video: %w", err)
 		}
@@ -442,6 +660,14 @@ func (p *ProcessingMedia) finish(ctx context.Context) error {
 		// Mark as no longer unknown type now
 		// we know for sure we can decode it.
 		p.media.Type = gtsmodel.FileTypeVideo
+
+		if p.gifFrame != nil {
+			// Converted from an animated GIF, so
+			// use its actual first frame, and
+			// present it as a gifv, as Mastodon does.
+			fullImg = p.gifFrame
+			p.media.Type = gtsmodel.FileTypeGifv
+		}
 	}
 
 	// fullImg should be in-memory by
@@ -450,6 +676,15 @@ func (p *ProcessingMedia) finish(ctx context.Context) error {
 		return gtserror.Newf("error closing file: %w", err)
 	}
 
+	// Downscale oversized images (if configured),
+	// replacing the stored original with the result.
+	if p.media.Type == gtsmodel.FileTypeImage {
+		fullImg, err = p.downscale(ctx, fullImg)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Set full-size dimensions in attachment info.
 	p.media.FileMeta.Original.Width = int(fullImg.Width())
 	p.media.FileMeta.Original.Height = int(fullImg.Height())
@@ -475,29 +710,26 @@ func (p *ProcessingMedia) finish(ctx context.Context) error {
 		p.media.Blurhash = hash
 	}
 
-	// Thumbnail shouldn't already exist in storage at this point,
-	// but we do a check as it's worth logging / cleaning up.
-	if have, _ := p.mgr.state.Storage.Has(ctx, p.media.Thumbnail.Path); have {
-		log.Warnf(ctx, "thumbnail already exists at storage path: %s", p.media.Thumbnail.Path)
-
-		// Attempt to remove existing thumbnail at storage path (might be broken / out-of-date)
-		if err := p.mgr.state.Storage.Delete(ctx, p.media.Thumbnail.Path); err != nil {
-			return gtserror.Newf("error removing thumbnail from storage: %v", err)
-		}
+	// Create a thumbnail encoder stream.
+	var enc io.Reader
+	if thumbType == mimeImagePng {
+		enc = thumbImg.ToPNG()
+	} else {
+		enc = thumbImg.ToJPEG(&jpeg.Options{
+			Quality: config.GetMediaImageQuality(),
+		})
 	}
 
-	// Create a thumbnail JPEG encoder stream.
-	enc := thumbImg.ToJPEG(&jpeg.Options{
-		// Good enough for
-		// a thumbnail.
-		Quality: 70,
-	})
-
-	// Stream-encode the JPEG thumbnail image into storage.
-	sz, err := p.mgr.state.Storage.PutStream(ctx, p.media.Thumbnail.Path, enc)
+	// Stream-encode the thumbnail image into storage.
+	ref, sz, err := PutBlob(ctx, p.mgr.state, enc, thumbExt)
 	if err != nil {
 		return gtserror.Newf("error stream-encoding thumbnail to storage: %w", err)
 	}
+	p.pinned = append(p.pinned, ref)
+	path := ref.Path
+
+	// Update to the stored path.
+	p.media.Thumbnail.Path = path
 
 	// Set thumbnail dimensions in attachment info.
 	p.media.FileMeta.Small = gtsmodel.Small{
@@ -516,3 +748,105 @@ func (p *ProcessingMedia) finish(ctx context.Context) error {
 
 	return nil
 }
+
+// downscale checks the given full-size image against the configured
+// max image resolution, and if it is too large resizes it to fit and
+// re-encodes it over the top of the original file in storage. GIFs are
+// left untouched (as they may be animated), and WebP images are stored
+// as JPEG since we have no WebP encoder available.
+func (p *ProcessingMedia) downscale(ctx context.Context, fullImg *gtsImage) (*gtsImage, error) {
+	maxRes := config.GetMediaImageMaxResolution()
+	if maxRes <= 0 || p.media.File.ContentType == mimeImageGif {
+		// Nothing to do.
+		return fullImg, nil
+	}
+
+	// Resize image to fit within max bounds.
+	resized := fullImg.ResizeFit(uint32(maxRes))
+	if resized == fullImg {
+		// Already small enough.
+		return fullImg, nil
+	}
+
+	// Prepare an encoder stream for the resized
+	// image, keeping PNGs as PNG, and encoding
+	// everything else as JPEG at set quality.
+	var (
+		enc io.Reader
+		ext string
+	)
+	switch p.media.File.ContentType {
+	case mimeImagePng:
+		enc = resized.ToPNG()
+		ext = "png"
+	default:
+		enc = resized.ToJPEG(&jpeg.Options{
+			Quality: config.GetMediaImageQuality(),
+		})
+		ext = "jpg"
+	}
+
+	if p.media.File.ContentType == mimeImageWebp {
+		// Stored file is changing
+		// type, so update the URL.
+		p.media.File.ContentType = mimeImageJpeg
+		p.media.URL = uris.URIForAttachment(
+			p.media.AccountID,
+			string(TypeAttachment),
+			string(SizeOriginal),
+			p.media.ID,
+			"jpg",
+		)
+	}
+
+	// Stream-encode the resized image into storage.
+	ref, sz, err := PutBlob(ctx, p.mgr.state, enc, ext)
+	if err != nil {
+		return nil, gtserror.Newf("error stream-encoding downscaled image to storage: %w", err)
+	}
+	p.pinned = append(p.pinned, ref)
+	path := ref.Path
+
+	// Remove the original file from storage,
+	// unless it's shared with other media.
+	if oldPath := p.media.File.Path; oldPath != path {
+		p.unpin(ctx, oldPath)
+		if _, err := DeleteFiles(ctx, p.mgr.state, p.media.ID, oldPath); err != nil {
+			return nil, gtserror.Newf("error removing original from storage: %w", err)
+		}
+	}
+
+	// Set new stored path and image size.
+	p.media.File.Path = path
+	p.media.File.FileSize = int(sz)
+
+	return resized, nil
+}
+
+// unpin releases our pin on given storage path, if
+// any, eg., before removing a file we stored ourselves.
+func (p *ProcessingMedia) unpin(ctx context.Context, path string) {
+	for i, ref := range p.pinned {
+		if ref.Path == path {
+			p.pinned = slices.Delete(p.pinned, i, i+1)
+			UnpinBlob(ctx, p.mgr.state, ref)
+			return
+		}
+	}
+}
+
+// unpinAll releases all our pinned storage paths.
+func (p *ProcessingMedia) unpinAll(ctx context.Context) {
+	for _, ref := range p.pinned {
+		UnpinBlob(ctx, p.mgr.state, ref)
+	}
+	p.pinned = nil
+}
+
+// isProfileMedia returns whether this media is being
+// used as an account avatar or header, whose thumbnail
+// is served as the static (non-animated) variant.
+func (p *ProcessingMedia) isProfileMedia() bool {
+	return util.PtrValueOr(p.media.Avatar, false) ||
+		util.PtrValueOr(p.media.Header, false)
+}
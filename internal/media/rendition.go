@@ -0,0 +1,194 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"image/jpeg"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/disintegration/imaging"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+)
+
+// mediumMaxSize is the maximum width and
+// height of medium image renditions, in pixels.
+const mediumMaxSize = 1280
+
+// RenditionSize returns the smallest size of the given image attachment
+// that's at least the given width in pixels, falling back to the original
+// if none are. This allows clients to ask for a size to fit their layout.
+func RenditionSize(attachment *gtsmodel.MediaAttachment, width int) Size {
+	switch {
+	case width <= attachment.FileMeta.Small.Width:
+		return SizeSmall
+	case width <= mediumWidth(attachment):
+		return SizeMedium
+	default:
+		return SizeOriginal
+	}
+}
+
+// mediumWidth returns the width of the medium
+// rendition of the given image attachment.
+func mediumWidth(attachment *gtsmodel.MediaAttachment) int {
+	width := attachment.FileMeta.Original.Width
+	height := attachment.FileMeta.Original.Height
+
+	switch {
+	case width <= mediumMaxSize && height <= mediumMaxSize:
+		return width
+	case width >= height:
+		return mediumMaxSize
+	default:
+		return width * mediumMaxSize / height
+	}
+}
+
+// MediumPath returns the storage path of the medium rendition of the
+// given attachment, or an empty string if it can't have one. Medium
+// renditions aren't tracked in the database, so callers removing an
+// attachment's files from storage should include this path too.
+func MediumPath(attachment *gtsmodel.MediaAttachment) string {
+	if attachment.Type != gtsmodel.FileTypeImage ||
+		attachment.Thumbnail.Path == "" {
+		return ""
+	}
+
+	// Medium renditions are encoded
+	// the same way as the thumbnail.
+	ext := strings.TrimPrefix(path.Ext(attachment.Thumbnail.Path), ".")
+
+	return uris.StoragePathForAttachment(
+		attachment.AccountID,
+		string(TypeAttachment),
+		string(SizeMedium),
+		attachment.ID,
+		ext,
+	)
+}
+
+// GetMedium returns the file of the medium rendition of the given
+// cached attachment, generating it and placing it in storage first
+// if necessary. Only images have medium renditions: for other types
+// of media the thumbnail is returned, and for images that are small
+// enough already, the original.
+func (m *Manager) GetMedium(ctx context.Context, attachment *gtsmodel.MediaAttachment) (*gtsmodel.File, error) {
+	if attachment.Type != gtsmodel.FileTypeImage {
+		return &gtsmodel.File{
+			Path:        attachment.Thumbnail.Path,
+			ContentType: attachment.Thumbnail.ContentType,
+			FileSize:    attachment.Thumbnail.FileSize,
+			UpdatedAt:   attachment.Thumbnail.UpdatedAt,
+		}, nil
+	}
+
+	if mediumWidth(attachment) == attachment.FileMeta.Original.Width {
+		// Nothing to resize.
+		return &attachment.File, nil
+	}
+
+	file := &gtsmodel.File{
+		Path:        MediumPath(attachment),
+		ContentType: attachment.Thumbnail.ContentType,
+		UpdatedAt:   attachment.UpdatedAt,
+	}
+
+	// Check whether it's been generated already.
+	entry, err := m.state.Storage.Stat(ctx, file.Path)
+	if err != nil {
+		return nil, gtserror.Newf("error checking storage for %s: %w", file.Path, err)
+	}
+
+	if entry != nil {
+		file.FileSize = int(entry.Size)
+		return file, nil
+	}
+
+	sz, err := m.putMedium(ctx, attachment, file)
+	if err != nil {
+		return nil, err
+	}
+
+	file.FileSize = int(sz)
+	return file, nil
+}
+
+// putMedium generates the medium rendition of the given image
+// attachment from its original, and places it in storage at
+// the given file's path, returning the number of bytes written.
+func (m *Manager) putMedium(ctx context.Context, attachment *gtsmodel.MediaAttachment, file *gtsmodel.File) (int64, error) {
+	// Wait our turn to decode / encode.
+	release, err := m.acquireEncode(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer release()
+
+	rc, err := m.state.Storage.GetStream(ctx, attachment.File.Path)
+	if err != nil {
+		return 0, gtserror.Newf("error opening original: %w", err)
+	}
+	defer rc.Close()
+
+	var r io.Reader = rc
+	if attachment.File.ContentType == mimeImagePng {
+		// Strip ancillary chunks as when processing.
+		r = &pngAncillaryChunkStripper{Reader: rc}
+	}
+
+	img, err := decodeImage(r, imaging.AutoOrientation(true))
+	if err != nil {
+		return 0, gtserror.Newf("error decoding original: %w", err)
+	}
+
+	// Resize, keeping aspect ratio.
+	img = img.ResizeFit(mediumMaxSize)
+
+	var enc io.Reader
+	if file.ContentType == mimeImagePng {
+		enc = img.ToPNG()
+	} else {
+		enc = img.ToJPEG(&jpeg.Options{
+			Quality: config.GetMediaImageQuality(),
+		})
+	}
+
+	sz, err := m.state.Storage.PutStream(ctx, file.Path, enc)
+	if storage.IsAlreadyExist(err) {
+		// Generated by a concurrent
+		// request in the meantime.
+		entry, err := m.state.Storage.Stat(ctx, file.Path)
+		if err != nil {
+			return 0, gtserror.Newf("error checking storage for %s: %w", file.Path, err)
+		} else if entry == nil {
+			return 0, gtserror.Newf("%s missing from storage", file.Path)
+		}
+		return entry.Size, nil
+	} else if err != nil {
+		return 0, gtserror.Newf("error stream-encoding medium rendition to storage: %w", err)
+	}
+
+	return sz, nil
+}
@@ -19,10 +19,15 @@ package media
 
 import (
 	"context"
+	"errors"
 	"io"
 	"time"
 )
 
+// ErrInfected is returned when processing media
+// that a virus scan found to be infected.
+var ErrInfected = errors.New("media is infected")
+
 // mime consts
 const (
 	mimeImage = "image"
@@ -48,6 +53,7 @@ type Size string
 
 const (
 	SizeSmall    Size = "small"    // SizeSmall is the key for small/thumbnail versions of media
+	SizeMedium   Size = "medium"   // SizeMedium is the key for medium versions of image attachments, generated on demand
 	SizeOriginal Size = "original" // SizeOriginal is the key for original/fullsize versions of media and emoji
 	SizeStatic   Size = "static"   // SizeStatic is the key for static (non-animated) versions of emoji
 )
@@ -0,0 +1,54 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+)
+
+// UploadPartPath returns the storage path of the part
+// with given index of an unfinished resumable upload.
+func UploadPartPath(uploadID string, part int) string {
+	return "uploads/" + uploadID + "/" + strconv.Itoa(part)
+}
+
+// DeleteUpload removes the given unfinished resumable upload
+// from the database, along with all of its parts from storage.
+func DeleteUpload(ctx context.Context, state *state.State, upload *gtsmodel.MediaUpload) error {
+	paths := make([]string, upload.Parts)
+	for part := range paths {
+		paths[part] = UploadPartPath(upload.ID, part)
+	}
+
+	// Remove the parts first, so that if this fails
+	// partway the upload is still around to retry.
+	if _, err := DeleteFiles(ctx, state, "", paths...); err != nil {
+		return gtserror.Newf("error removing parts of upload %s: %w", upload.ID, err)
+	}
+
+	if err := state.DB.DeleteMediaUploadByID(ctx, upload.ID); err != nil {
+		return gtserror.Newf("db error deleting upload %s: %w", upload.ID, err)
+	}
+
+	return nil
+}
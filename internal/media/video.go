@@ -18,14 +18,26 @@
 package media
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
 
 	"github.com/abema/go-mp4"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/iotools"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
 
+// videoFrameTimeout is the maximum time to
+// wait for ffmpeg to extract a video frame.
+const videoFrameTimeout = 30 * time.Second
+
 type gtsVideo struct {
 	frame     *gtsImage
 	duration  float32 // in seconds
@@ -34,8 +46,9 @@ type gtsVideo struct {
 }
 
 // decodeVideoFrame decodes and returns an image from a single frame in the given video stream.
-// (note: currently this only returns a blank image resized to fit video dimensions).
-func decodeVideoFrame(r io.Reader) (*gtsVideo, error) {
+// The frame is extracted with ffmpeg when configured, else (or if that fails) this returns
+// a blank image sized to fit the video dimensions.
+func decodeVideoFrame(ctx context.Context, r io.Reader) (*gtsVideo, error) {
 	// Check if video stream supports
 	// seeking, usually when *os.File.
 	rsc, ok := r.(io.ReadSeekCloser)
@@ -133,9 +146,75 @@ func decodeVideoFrame(r io.Reader) (*gtsVideo, error) {
 		return nil, fmt.Errorf("error determining video metadata: %v", empty)
 	}
 
+	if ffmpeg := config.GetMediaFFmpegPath(); ffmpeg != "" {
+		// Try extract the first frame, for
+		// the thumbnail and blurhash.
+		frame, err := extractVideoFrame(ctx, ffmpeg, rsc)
+		if err == nil {
+			video.frame = frame
+			return &video, nil
+		}
+		log.Errorf(ctx, "error extracting video frame, using blank frame: %v", err)
+	}
+
 	// Create new empty "frame" image.
-	// TODO: decode frame from video file.
 	video.frame = blankImage(width, height)
 
 	return &video, nil
 }
+
+// extractVideoFrame runs ffmpeg at given path to
+// decode the first frame of the video read from rs.
+func extractVideoFrame(ctx context.Context, ffmpeg string, rs io.ReadSeeker) (*gtsImage, error) {
+	if _, err := rs.Seek(0, io.SeekStart); err != nil {
+		return nil, gtserror.Newf("error seeking video: %w", err)
+	}
+
+	// MP4 metadata may be at the end of the file,
+	// so ffmpeg needs seekable input, ie., a file.
+	in, err := os.CreateTemp("", "gotosocial-video-*.mp4")
+	if err != nil {
+		return nil, gtserror.Newf("error creating temporary file: %w", err)
+	}
+
+	defer func() {
+		_ = in.Close()
+		_ = os.Remove(in.Name())
+	}()
+
+	if _, err := io.Copy(in, rs); err != nil {
+		return nil, gtserror.Newf("error writing temporary file: %w", err)
+	}
+
+	ctx, cncl := context.WithTimeout(ctx, videoFrameTimeout)
+	defer cncl()
+
+	// #nosec G204 -- ffmpeg path is from admin config.
+	cmd := exec.CommandContext(ctx, ffmpeg,
+		"-hide_banner",
+		"-loglevel", "error",
+		"-nostdin",
+		"-f", "mp4",
+		"-i", in.Name(),
+		"-frames:v", "1",
+		"-f", "image2pipe",
+		"-c:v", "png",
+		"pipe:1",
+	)
+
+	var stdout bytes.Buffer
+	var stderr strings.Builder
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, gtserror.Newf("error running ffmpeg: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	frame, err := decodeImage(&stdout)
+	if err != nil {
+		return nil, gtserror.Newf("error decoding frame: %w", err)
+	}
+
+	return frame, nil
+}
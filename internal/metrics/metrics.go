@@ -21,15 +21,19 @@ package metrics
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/cache"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/technologize/otel-go-contrib/otelginmetrics"
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/extra/bunotel"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/metric"
 	sdk "go.opentelemetry.io/otel/sdk/metric"
@@ -41,7 +45,38 @@ const (
 	serviceName = "GoToSocial"
 )
 
-func Initialize(db db.DB) error {
+// inboundActivities counts activities received
+// via inbox POST, by activity type and outcome.
+// Nil until metrics have been initialized.
+var inboundActivities metric.Int64Counter
+
+// statusDigestMismatches counts federated statuses
+// re-delivered via Create with differing content.
+// Nil until metrics have been initialized.
+var statusDigestMismatches metric.Int64Counter
+
+// dereferences counts remote fetches made while
+// dereferencing, by kind and outcome (fetched or
+// skipped for being over budget).
+// Nil until metrics have been initialized.
+var dereferences metric.Int64Counter
+
+// shedRequests counts low-priority requests
+// rejected by load shedding, by route and reason.
+// Nil until metrics have been initialized.
+var shedRequests metric.Int64Counter
+
+// dbQueryDuration records database query
+// latencies, by lookup and operation.
+// Nil until metrics have been initialized.
+var dbQueryDuration metric.Float64Histogram
+
+// dbQueryErrors counts database query
+// errors, by lookup and operation.
+// Nil until metrics have been initialized.
+var dbQueryErrors metric.Int64Counter
+
+func Initialize(db db.DB, caches *cache.Caches) error {
 	if !config.GetMetricsEnabled() {
 		return nil
 	}
@@ -124,9 +159,171 @@ func Initialize(db db.DB) error {
 		return err
 	}
 
+	inboundActivities, err = meter.Int64Counter(
+		"gotosocial.federation.inbound_activities",
+		metric.WithDescription("Total number of activities received via inbox POST, by activity type and outcome"),
+	)
+	if err != nil {
+		return err
+	}
+
+	statusDigestMismatches, err = meter.Int64Counter(
+		"gotosocial.federation.status_digest_mismatches",
+		metric.WithDescription("Total number of statuses re-delivered via Create with content not matching the stored version"),
+	)
+	if err != nil {
+		return err
+	}
+
+	dereferences, err = meter.Int64Counter(
+		"gotosocial.federation.dereferences",
+		metric.WithDescription("Total number of remote fetches made while dereferencing, by kind and outcome (fetched or over_budget)"),
+	)
+	if err != nil {
+		return err
+	}
+
+	shedRequests, err = meter.Int64Counter(
+		"gotosocial.http.shed_requests",
+		metric.WithDescription("Total number of low-priority requests rejected by load shedding, by route and reason (latency or queue_depth)"),
+	)
+	if err != nil {
+		return err
+	}
+
+	dbQueryDuration, err = meter.Float64Histogram(
+		"gotosocial.db.query.duration",
+		metric.WithDescription("Duration of database queries, by lookup and operation"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return err
+	}
+
+	dbQueryErrors, err = meter.Int64Counter(
+		"gotosocial.db.query.errors",
+		metric.WithDescription("Total number of database query errors (excluding no rows), by lookup and operation"),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.Int64ObservableCounter(
+		"gotosocial.cache.lookups",
+		metric.WithDescription("Total number of cache lookups, by cache and result (hit or miss)"),
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			for _, stats := range caches.Stats() {
+				name := attribute.String("cache", stats.Name)
+				o.Observe(int64(stats.Hits), metric.WithAttributes(name, attribute.String("result", "hit")))
+				o.Observe(int64(stats.Misses), metric.WithAttributes(name, attribute.String("result", "miss")))
+			}
+			return nil
+		}),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.Int64ObservableGauge(
+		"gotosocial.cache.size",
+		metric.WithDescription("Current number of entries in cache, by cache"),
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			for _, stats := range caches.Stats() {
+				o.Observe(int64(stats.Len), metric.WithAttributes(attribute.String("cache", stats.Name)))
+			}
+			return nil
+		}),
+	)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// InboundActivity increments the count of activities
+// of the given type received via inbox POST with the
+// given outcome, eg., "accepted", "dropped-blocked".
+func InboundActivity(ctx context.Context, activityType string, outcome string) {
+	if inboundActivities == nil {
+		// Metrics not enabled.
+		return
+	}
+
+	inboundActivities.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("type", activityType),
+		attribute.String("outcome", outcome),
+	))
+}
+
+// StatusDigestMismatch increments the count of statuses
+// re-delivered via Create with content not matching the
+// stored version, noting whether the status was quarantined.
+func StatusDigestMismatch(ctx context.Context, quarantined bool) {
+	if statusDigestMismatches == nil {
+		// Metrics not enabled.
+		return
+	}
+
+	statusDigestMismatches.Add(ctx, 1, metric.WithAttributes(
+		attribute.Bool("quarantined", quarantined),
+	))
+}
+
+// Dereference increments the count of remote fetches
+// of the given kind (e.g. "account", "status", "media")
+// made while dereferencing, with the given outcome,
+// i.e. "fetched" or "over_budget".
+func Dereference(ctx context.Context, kind string, outcome string) {
+	if dereferences == nil {
+		// Metrics not enabled.
+		return
+	}
+
+	dereferences.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("kind", kind),
+		attribute.String("outcome", outcome),
+	))
+}
+
+// RequestShed increments the count of low-priority
+// requests to the given route (e.g. "/api/v1/timelines/public")
+// rejected by load shedding for the given reason,
+// i.e. "latency" or "queue_depth".
+func RequestShed(ctx context.Context, route string, reason string) {
+	if shedRequests == nil {
+		// Metrics not enabled.
+		return
+	}
+
+	shedRequests.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("route", route),
+		attribute.String("reason", reason),
+	))
+}
+
+// DBQuery records the duration of a database query
+// performed by the given lookup (e.g. "relationshipDB.getBlock")
+// with given operation (e.g. "SELECT"), counting it as an
+// error if err is set and is anything other than no rows.
+func DBQuery(ctx context.Context, lookup string, operation string, duration time.Duration, err error) {
+	if dbQueryDuration == nil {
+		// Metrics not enabled.
+		return
+	}
+
+	attrs := metric.WithAttributes(
+		attribute.String("lookup", lookup),
+		attribute.String("operation", operation),
+	)
+
+	dbQueryDuration.Record(ctx, duration.Seconds(), attrs)
+
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		dbQueryErrors.Add(ctx, 1, attrs)
+	}
+}
+
 func InstrumentGin() gin.HandlerFunc {
 	return otelginmetrics.Middleware(serviceName)
 }
@@ -20,21 +20,35 @@
 package metrics
 
 import (
+	"context"
 	"errors"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/cache"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/uptrace/bun"
 )
 
-func Initialize(db db.DB) error {
+func Initialize(db db.DB, caches *cache.Caches) error {
 	if config.GetMetricsEnabled() {
 		return errors.New("metrics was disabled at build time")
 	}
 	return nil
 }
 
+func InboundActivity(ctx context.Context, activityType string, outcome string) {}
+
+func StatusDigestMismatch(ctx context.Context, quarantined bool) {}
+
+func Dereference(ctx context.Context, kind string, outcome string) {}
+
+func RequestShed(ctx context.Context, route string, reason string) {}
+
+func DBQuery(ctx context.Context, lookup string, operation string, duration time.Duration, err error) {
+}
+
 func InstrumentGin() gin.HandlerFunc {
 	return func(c *gin.Context) {}
 }
@@ -0,0 +1,189 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// ActAsHeader is the request header with which the user
+// of a delegate account can act as a (shared) local account
+// they're an accepted delegate of, by giving the shared
+// account's ID. See gtsmodel.AccountDelegate.
+const ActAsHeader = "X-GoToSocial-Act-As"
+
+// delegateReadRoutes are the client API routes a delegate
+// may always read while acting as a shared account. They
+// don't expose the shared account's direct messages.
+var delegateReadRoutes = map[string]bool{
+	"/api/v1/accounts/verify_credentials": true,
+	"/api/v1/accounts/relationships":      true,
+	"/api/v1/accounts/lookup":             true,
+	"/api/v1/accounts/search":             true,
+	"/api/v1/accounts/:id":                true,
+	"/api/v1/accounts/:id/followers":      true,
+	"/api/v1/accounts/:id/following":      true,
+	"/api/v1/statuses/:id/favourited_by":  true,
+	"/api/v1/statuses/:id/reblogged_by":   true,
+	"/api/v1/timelines/public":            true,
+	"/api/v1/timelines/tag/:tag_name":     true,
+	"/api/v1/instance":                    true,
+	"/api/v2/instance":                    true,
+	"/api/v1/instance/peers":              true,
+	"/api/v1/instance/rules":              true,
+	"/api/v1/custom_emojis":               true,
+	"/api/v1/preferences":                 true,
+	"/api/v1/markers":                     true,
+	"/api/v1/lists":                       true,
+	"/api/v1/lists/:id":                   true,
+	"/api/:api_version/media/:id":         true,
+	"/api/:api_version/media/uploads/:id": true,
+	"/api/:api_version/polls/:id":         true,
+}
+
+// delegateDMReadRoutes are the client API routes a delegate
+// may only read while acting as a shared account if they may
+// also manage its direct messages, as they can expose them.
+var delegateDMReadRoutes = map[string]bool{
+	"/api/v1/accounts/:id/statuses": true,
+	"/api/v1/statuses/:id":          true,
+	"/api/v1/statuses/:id/context":  true,
+	"/api/v1/statuses/:id/history":  true,
+	"/api/v1/statuses/:id/source":   true,
+	"/api/v1/timelines/home":        true,
+	"/api/v1/timelines/list/:id":    true,
+	"/api/v1/notifications":         true,
+	"/api/v1/notifications/:id":     true,
+	"/api/v1/conversations":         true,
+	"/api/v1/bookmarks":             true,
+	"/api/v1/favourites":            true,
+	"/api/:api_version/search":      true,
+	"/api/v1/streaming":             true,
+}
+
+// delegatePostRoutes are the client API routes (and methods)
+// a delegate with permission to post may use while acting as
+// a shared account, beyond those for reading. Direct messages
+// additionally require permission to manage DMs, which is
+// checked when processing the status.
+var delegatePostRoutes = map[string]bool{
+	http.MethodPost + " /api/v1/statuses":                           true,
+	http.MethodDelete + " /api/v1/statuses/:id":                     true,
+	http.MethodPost + " /api/:api_version/media":                    true,
+	http.MethodPut + " /api/:api_version/media/:id":                 true,
+	http.MethodPost + " /api/:api_version/media/uploads":            true,
+	http.MethodPatch + " /api/:api_version/media/uploads/:id":       true,
+	http.MethodPost + " /api/:api_version/media/uploads/:id/finish": true,
+	http.MethodPost + " /api/:api_version/polls/:id/votes":          true,
+}
+
+// delegateNeverPaths are client API route prefixes a delegate may
+// never use while acting as a shared account, even just to read,
+// as they concern the user's own privileges or the delegation itself.
+var delegateNeverPaths = []string{
+	"/api/v1/admin",
+	"/api/v2/admin",
+	"/api/v1/user",
+	"/api/v1/delegates",
+	"/api/v1/delegations",
+}
+
+// delegatePermitted returns whether the given delegate may
+// make a request with given method to the given route. Any
+// route not explicitly allowed is refused, so that new routes
+// aren't opened up to delegates by accident.
+func delegatePermitted(delegate *gtsmodel.AccountDelegate, method string, route string) bool {
+	for _, prefix := range delegateNeverPaths {
+		if strings.HasPrefix(route, prefix) {
+			return false
+		}
+	}
+
+	if method == http.MethodGet || method == http.MethodHead {
+		if delegateDMReadRoutes[route] {
+			// Reading what may include direct
+			// messages needs permission too.
+			return *delegate.CanManageDMs
+		}
+		return delegateReadRoutes[route]
+	}
+
+	return *delegate.CanPost && delegatePostRoutes[method+" "+route]
+}
+
+// actAsDelegate switches the authorized account on the gin context
+// to the shared account with given ID, if the given user's account
+// is an accepted delegate of it, and the delegate is permitted to
+// make the current request. Otherwise it responds with an error and
+// aborts the handler chain, rather than falling back to the user's
+// own account, so that nothing is ever done as the wrong account.
+func actAsDelegate(c *gin.Context, dbConn db.DB, user *gtsmodel.User, accountID string) {
+	ctx := c.Request.Context()
+
+	delegate, err := dbConn.GetAccountDelegate(
+		gtscontext.SetBarebones(ctx),
+		accountID,
+		user.AccountID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting delegate %s of account %s: %w", user.AccountID, accountID, err)
+		respondInternalServerError(c, err)
+		return
+	}
+
+	if delegate == nil || !delegate.IsAccepted() {
+		log.Warnf(ctx, "user %s tried to act as account %s without being its delegate", user.ID, accountID)
+		respondBlocked(c)
+		return
+	}
+
+	// Fetch the fully populated shared account,
+	// as handlers expect it from the gin context.
+	account, err := dbConn.GetAccountByID(ctx, delegate.AccountID)
+	if err != nil {
+		err := gtserror.Newf("db error getting account %s: %w", accountID, err)
+		respondInternalServerError(c, err)
+		return
+	}
+	delegate.Account = account
+
+	if account.IsSuspended() {
+		log.Warnf(ctx, "user %s tried to act as suspended account %s", user.ID, accountID)
+		respondBlocked(c)
+		return
+	}
+
+	if !delegatePermitted(delegate, c.Request.Method, c.FullPath()) {
+		log.Debugf(ctx, "delegate %s not permitted to %s %s as account %s", user.AccountID, c.Request.Method, c.FullPath(), accountID)
+		respondBlocked(c)
+		return
+	}
+
+	c.Request = c.Request.WithContext(gtscontext.SetDelegate(ctx, delegate))
+	c.Set(oauth.SessionAuthorizedAccount, account)
+}
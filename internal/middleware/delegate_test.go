@@ -0,0 +1,70 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package middleware
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+func TestDelegatePermitted(t *testing.T) {
+	var (
+		readOnly = &gtsmodel.AccountDelegate{CanPost: util.Ptr(false), CanManageDMs: util.Ptr(false)}
+		poster   = &gtsmodel.AccountDelegate{CanPost: util.Ptr(true), CanManageDMs: util.Ptr(false)}
+		full     = &gtsmodel.AccountDelegate{CanPost: util.Ptr(true), CanManageDMs: util.Ptr(true)}
+	)
+
+	for _, test := range []struct {
+		delegate *gtsmodel.AccountDelegate
+		method   string
+		route    string
+		expect   bool
+	}{
+		{readOnly, http.MethodGet, "/api/v1/timelines/home", false},
+		{readOnly, http.MethodGet, "/api/v1/notifications", false},
+		{readOnly, http.MethodGet, "/api/v1/statuses/:id", false},
+		{readOnly, http.MethodGet, "/api/v1/timelines/public", true},
+		{readOnly, http.MethodGet, "/api/v1/accounts/verify_credentials", true},
+		{readOnly, http.MethodGet, "/api/v1/blocks", false},
+		{readOnly, http.MethodPost, "/api/:api_version/media/uploads", false},
+		{full, http.MethodGet, "/api/v1/timelines/home", true},
+		{full, http.MethodGet, "/api/v1/notifications", true},
+		{readOnly, http.MethodPost, "/api/v1/statuses", false},
+		{poster, http.MethodPost, "/api/v1/statuses", true},
+		{poster, http.MethodDelete, "/api/v1/statuses/:id", true},
+		{poster, http.MethodPost, "/api/:api_version/media", true},
+		{poster, http.MethodPost, "/api/:api_version/polls/:id/votes", true},
+		{poster, http.MethodPost, "/api/:api_version/media/uploads", true},
+		{poster, http.MethodPatch, "/api/:api_version/media/uploads/:id", true},
+		{poster, http.MethodPost, "/api/:api_version/media/uploads/:id/finish", true},
+		{poster, http.MethodGet, "/api/:api_version/media/uploads/:id", true},
+		{poster, http.MethodPatch, "/api/v1/accounts/update_credentials", false},
+		{poster, http.MethodGet, "/api/v1/conversations", false},
+		{full, http.MethodGet, "/api/v1/conversations", true},
+		{full, http.MethodGet, "/api/v1/admin/accounts", false},
+		{full, http.MethodGet, "/api/v1/delegates", false},
+		{full, http.MethodPost, "/api/v1/user/password_change", false},
+	} {
+		if got := delegatePermitted(test.delegate, test.method, test.route); got != test.expect {
+			t.Errorf("%s %s: expected %t, got %t", test.method, test.route, test.expect, got)
+		}
+	}
+}
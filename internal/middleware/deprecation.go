@@ -0,0 +1,61 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+)
+
+// Deprecation returns a new gin middleware which annotates
+// responses from deprecated client API endpoints (see
+// apiutil.Deprecations) with the Deprecation (RFC 9745),
+// Sunset (RFC 8594), and successor-version Link headers.
+func Deprecation() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		d, ok := apiutil.GetDeprecation(
+			c.Request.Method,
+			strings.TrimPrefix(c.FullPath(), "/api"),
+			c.Param(apiutil.APIVersionKey),
+		)
+		if !ok {
+			return
+		}
+
+		h := c.Writer.Header()
+		h.Set("Deprecation", "@"+strconv.FormatInt(d.Since.Unix(), 10))
+
+		if !d.Sunset.IsZero() {
+			h.Set("Sunset", d.Sunset.UTC().Format(http.TimeFormat))
+		}
+
+		if d.Successor != "" {
+			// Fill in any path
+			// params, eg., IDs.
+			successor := d.Successor
+			for _, p := range c.Params {
+				successor = strings.Replace(successor, ":"+p.Key, p.Value, 1)
+			}
+			h.Add("Link", "<"+successor+">; rel=\"successor-version\"")
+		}
+	}
+}
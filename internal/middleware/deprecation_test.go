@@ -0,0 +1,61 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+)
+
+func TestDeprecation(t *testing.T) {
+	e := gin.New()
+	api := e.Group("/api", middleware.Deprecation())
+	api.Handle("GET", "/v1/instance", func(c *gin.Context) {})
+	api.Handle("GET", "/v2/instance", func(c *gin.Context) {})
+	api.Handle("POST", "/:api_version/media", func(c *gin.Context) {})
+	api.Handle("PUT", "/v1/filters/:id", func(c *gin.Context) {})
+
+	for _, test := range []struct {
+		method      string
+		path        string
+		deprecation string
+		link        string
+	}{
+		{"GET", "/api/v1/instance", "@1668384000", `</api/v2/instance>; rel="successor-version"`},
+		{"GET", "/api/v2/instance", "", ""},
+		{"POST", "/api/v1/media", "@1586044800", `</api/v2/media>; rel="successor-version"`},
+		{"POST", "/api/v2/media", "", ""},
+		{"PUT", "/api/v1/filters/01HX", "@1668384000", `</api/v2/filters/01HX>; rel="successor-version"`},
+	} {
+		rw := httptest.NewRecorder()
+		e.ServeHTTP(rw, httptest.NewRequest(test.method, test.path, nil))
+		if rw.Code != http.StatusOK {
+			t.Fatalf("%s %s: expected status 200, got %d", test.method, test.path, rw.Code)
+		}
+		if got := rw.Header().Get("Deprecation"); got != test.deprecation {
+			t.Errorf("%s %s: expected deprecation %q, got %q", test.method, test.path, test.deprecation, got)
+		}
+		if got := rw.Header().Get("Link"); got != test.link {
+			t.Errorf("%s %s: expected link %q, got %q", test.method, test.path, test.link, got)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/metrics"
+)
+
+const (
+	// shedLatencyWeight is the inverse weight given to each
+	// new sample in the moving average of request latency,
+	// ie., each request contributes 1/shedLatencyWeight.
+	shedLatencyWeight = 16
+
+	// shedLatencyClamp is the multiple of the latency target
+	// at which samples are clamped, so a single very slow
+	// request can't trigger load shedding all on its own.
+	shedLatencyClamp = 4
+
+	// shedProbeEvery determines how many low-priority requests
+	// are shed before one is let through anyway while overloaded,
+	// so that the moving average of latency keeps being updated
+	// and load shedding stops once the instance has recovered.
+	shedProbeEvery = 10
+)
+
+// LoadShed returns a gin middleware that sheds load when the instance
+// is overloaded, by rejecting low-priority requests before they're
+// processed, leaving capacity for more important requests.
+//
+// The instance is considered overloaded when the moving average of the
+// latency of requests passing through this middleware exceeds the given
+// latency, or when the number of requests currently in flight through this
+// middleware exceeds the given queueDepth. Setting either to 0 or less
+// turns off that check; if both are off, a noop middleware is returned.
+//
+// Requests are low-priority when their route (as in gin.Context{}.FullPath())
+// begins with one of the given lowPriority prefixes. Shed requests are
+// aborted with status 503: Service Unavailable, and an appropriate
+// Retry-After header. Websocket requests are neither shed nor measured.
+//
+// Because measuring latency and in-flight requests includes time spent
+// waiting for a throttling token, this middleware should be applied
+// *before* Throttle in the handler chain.
+func LoadShed(
+	latency time.Duration,
+	queueDepth int,
+	retryAfter time.Duration,
+	lowPriority ...string,
+) gin.HandlerFunc {
+	if latency <= 0 && queueDepth <= 0 {
+		// load shedding is disabled, return a noop middleware
+		return func(c *gin.Context) {}
+	}
+
+	s := &loadShedder{
+		latency:       latency,
+		queueDepth:    int64(queueDepth),
+		lowPriority:   lowPriority,
+		retryAfterStr: strconv.FormatUint(uint64(retryAfter/time.Second), 10),
+	}
+
+	return s.handle
+}
+
+// loadShedder keeps track of request
+// load for the LoadShed middleware.
+type loadShedder struct {
+	latency       time.Duration
+	queueDepth    int64
+	lowPriority   []string
+	retryAfterStr string
+
+	inFlight   atomic.Int64  // number of requests in flight
+	avgLatency atomic.Int64  // moving average of latency (ns)
+	shed       atomic.Uint64 // count of low-priority requests while overloaded
+}
+
+func (s *loadShedder) handle(c *gin.Context) {
+	if c.IsWebsocket() {
+		// Long-lived, don't
+		// skew the averages.
+		c.Next()
+		return
+	}
+
+	// Always decrement in-flight counter.
+	defer func() { s.inFlight.Add(-1) }()
+
+	// Increment in-flight count.
+	n := s.inFlight.Add(1)
+
+	if route := c.FullPath(); s.isLowPriority(route) {
+		reason := s.overloaded(n)
+		if reason != "" && s.shed.Add(1)%shedProbeEvery != 0 {
+			metrics.RequestShed(c.Request.Context(), route, reason)
+			c.Header("Retry-After", s.retryAfterStr)
+			apiutil.Data(c,
+				http.StatusServiceUnavailable,
+				apiutil.AppJSON,
+				apiutil.ErrorCapacityExceeded,
+			)
+			c.Abort()
+			return
+		}
+	}
+
+	start := time.Now()
+	c.Next()
+	s.record(time.Since(start))
+}
+
+// isLowPriority returns whether
+// the given route may be shed.
+func (s *loadShedder) isLowPriority(route string) bool {
+	if route == "" {
+		return false
+	}
+
+	for _, prefix := range s.lowPriority {
+		if strings.HasPrefix(route, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// overloaded returns the reason the instance is considered
+// overloaded with n requests in flight, or empty string if not.
+func (s *loadShedder) overloaded(n int64) string {
+	switch {
+	case s.queueDepth > 0 && n > s.queueDepth:
+		return "queue_depth"
+	case s.latency > 0 && time.Duration(s.avgLatency.Load()) > s.latency:
+		return "latency"
+	default:
+		return ""
+	}
+}
+
+// record updates the moving average
+// of latency with the given sample.
+func (s *loadShedder) record(d time.Duration) {
+	if s.latency <= 0 {
+		// Not tracking.
+		return
+	}
+
+	if limit := s.latency * shedLatencyClamp; d > limit {
+		d = limit
+	}
+
+	for {
+		old := s.avgLatency.Load()
+		avg := old + (int64(d)-old)/shedLatencyWeight
+		if s.avgLatency.CompareAndSwap(old, avg) {
+			return
+		}
+	}
+}
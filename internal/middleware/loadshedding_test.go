@@ -0,0 +1,134 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+)
+
+const lowPriorityPath = "/api/v1/timelines/public"
+
+func TestLoadSheddingQueueDepth(t *testing.T) {
+	e := gin.New()
+
+	// Let blocking handlers see
+	// the request being cancelled.
+	e.ContextWithFallback = true
+	e.Use(middleware.LoadShed(0, 2, time.Second*30, lowPriorityPath))
+	e.Handle("GET", "/blocking", blockingHandler())
+	e.Handle("GET", lowPriorityPath, func(c *gin.Context) {})
+
+	// Fill up the queue with two
+	// blocking requests in flight.
+	var (
+		cncls []func()
+		wg    sync.WaitGroup
+	)
+	for i := 0; i < 2; i++ {
+		r := httptest.NewRequest("GET", "/blocking", nil)
+		ctx, cncl := context.WithCancel(r.Context())
+		cncls = append(cncls, cncl)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			e.ServeHTTP(httptest.NewRecorder(), r.WithContext(ctx))
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	// Low-priority request should now be shed.
+	rw := serve(e, lowPriorityPath)
+	if rw.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected status 503, got %d", rw.Code)
+	}
+	if retryAfter := rw.Header().Get("Retry-After"); retryAfter != "30" {
+		t.Fatalf("expected retry-after 30, got %q", retryAfter)
+	}
+
+	// Release the blocking requests,
+	// and wait for them to finish.
+	for _, cncl := range cncls {
+		cncl()
+	}
+	wg.Wait()
+
+	// Low-priority request should now go through.
+	if rw := serve(e, lowPriorityPath); rw.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rw.Code)
+	}
+}
+
+func TestLoadSheddingLatency(t *testing.T) {
+	e := gin.New()
+	e.Use(middleware.LoadShed(10*time.Millisecond, 0, time.Second*30, lowPriorityPath))
+	e.Handle("GET", "/slow", func(c *gin.Context) { time.Sleep(50 * time.Millisecond) })
+	e.Handle("GET", "/fast", func(c *gin.Context) {})
+	e.Handle("GET", lowPriorityPath, func(c *gin.Context) {})
+
+	// Not overloaded yet.
+	if rw := serve(e, lowPriorityPath); rw.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rw.Code)
+	}
+
+	// Push the average latency over the target.
+	for i := 0; i < 10; i++ {
+		serve(e, "/slow")
+	}
+
+	// Low-priority request should now be shed,
+	// but other requests should still go through.
+	if rw := serve(e, lowPriorityPath); rw.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected status 503, got %d", rw.Code)
+	}
+	if rw := serve(e, "/fast"); rw.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rw.Code)
+	}
+
+	// Fast requests bring the average back down.
+	for i := 0; i < 100; i++ {
+		serve(e, "/fast")
+	}
+
+	if rw := serve(e, lowPriorityPath); rw.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rw.Code)
+	}
+}
+
+func TestLoadSheddingDisabled(t *testing.T) {
+	e := gin.New()
+	e.Use(middleware.LoadShed(0, 0, time.Second*30, lowPriorityPath))
+	e.Handle("GET", lowPriorityPath, func(c *gin.Context) {})
+
+	if rw := serve(e, lowPriorityPath); rw.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rw.Code)
+	}
+}
+
+func serve(e *gin.Engine, path string) *httptest.ResponseRecorder {
+	rw := httptest.NewRecorder()
+	e.ServeHTTP(rw, httptest.NewRequest("GET", path, nil))
+	return rw
+}
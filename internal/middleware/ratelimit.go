@@ -21,11 +21,13 @@ import (
 	"net"
 	"net/http"
 	"net/netip"
+	"slices"
 	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/ulule/limiter/v3"
 	"github.com/ulule/limiter/v3/drivers/store/memory"
@@ -157,3 +159,39 @@ func RateLimit(limit int, exceptions []string) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// RateLimitUnauthenticated is like RateLimit, but only applies to requests
+// to the given route paths (as in gin.Context{}.FullPath()) that were not
+// authenticated with a valid oauth token by a preceding TokenCheck middleware.
+// This allows a stricter limit to be placed on unauthenticated requests to
+// public endpoints, on top of RateLimit, without affecting other routes that
+// clients need before they have a token, eg., registering an application.
+//
+// If the given limit is <= 0, then a noop handler will be returned,
+// which performs no additional rate limiting.
+func RateLimitUnauthenticated(limit int, exceptions []string, paths ...string) gin.HandlerFunc {
+	if limit <= 0 || len(paths) == 0 {
+		// Rate limiting is disabled.
+		// Return noop middleware.
+		return func(ctx *gin.Context) {}
+	}
+
+	rateLimit := RateLimit(limit, exceptions)
+
+	return func(c *gin.Context) {
+		if !slices.Contains(paths, c.FullPath()) {
+			// Not a public endpoint,
+			// nothing more to do.
+			return
+		}
+
+		if _, ok := c.Get(oauth.SessionAuthorizedToken); ok {
+			// Request authenticated,
+			// nothing more to do.
+			return
+		}
+
+		// Apply stricter limit.
+		rateLimit(c)
+	}
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
@@ -187,6 +188,80 @@ func (suite *RateLimitTestSuite) TestRateLimit() {
 	}
 }
 
+func (suite *RateLimitTestSuite) TestRateLimitUnauthenticated() {
+	// Suppress warnings about debug mode.
+	gin.SetMode(gin.ReleaseMode)
+
+	const (
+		trustedPlatform = "X-Test-IP"
+		publicPath      = "/api/v1/timelines/public"
+		otherPath       = "/api/v1/instance"
+	)
+
+	e := gin.New()
+	e.TrustedPlatform = trustedPlatform
+
+	// Stand in for TokenCheck.
+	e.Use(func(c *gin.Context) {
+		if c.GetHeader("Authorization") != "" {
+			c.Set(oauth.SessionAuthorizedToken, "token")
+		}
+	})
+	e.Use(middleware.RateLimitUnauthenticated(2, nil, publicPath))
+
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	e.GET(publicPath, ok)
+	e.GET(otherPath, ok)
+
+	request := func(path string, clientIP string, authed bool) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set(trustedPlatform, clientIP)
+		if authed {
+			req.Header.Set("Authorization", "Bearer token")
+		}
+		e.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	// Approximate time when this limiter will reset.
+	resetAt := time.Now().Add(5 * time.Minute)
+
+	// Unauthenticated requests to the public
+	// endpoint are allowed up to the limit.
+	for i := 1; i <= 2; i++ {
+		recorder := request(publicPath, "192.0.2.0", false)
+		suite.Equal(http.StatusOK, recorder.Code)
+		suite.Equal(strconv.Itoa(2-i), recorder.Header().Get("X-RateLimit-Remaining"))
+	}
+
+	// Then they're limited until the reset.
+	recorder := request(publicPath, "192.0.2.0", false)
+	suite.Equal(http.StatusTooManyRequests, recorder.Code)
+	reset, err := util.ParseISO8601(recorder.Header().Get("X-RateLimit-Reset"))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.WithinDuration(resetAt, reset, 10*time.Second)
+
+	// Authenticated requests from the
+	// same IP aren't affected at all.
+	recorder = request(publicPath, "192.0.2.0", true)
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Empty(recorder.Header().Get("X-RateLimit-Limit"))
+
+	// Nor are unauthenticated requests to other
+	// endpoints, eg. those needed to get a token.
+	recorder = request(otherPath, "192.0.2.0", false)
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Empty(recorder.Header().Get("X-RateLimit-Limit"))
+
+	// Nor unauthenticated requests from other IPs.
+	recorder = request(publicPath, "192.0.2.255", false)
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal("1", recorder.Header().Get("X-RateLimit-Remaining"))
+}
+
 func TestRateLimitTestSuite(t *testing.T) {
 	suite.Run(t, new(RateLimitTestSuite))
 }
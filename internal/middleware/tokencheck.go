@@ -42,6 +42,10 @@ import (
 // Next, it will look up the *gtsmodel.Account for the User. If the Account has been suspended, then the
 // middleware will return early. Otherwise, it will set the Account on the gin context too.
 //
+// If the request sets the ActAsHeader, and the User's Account is an accepted delegate of the given
+// shared account, the shared Account is set on the gin context instead, if the delegate is permitted
+// to make this request. Otherwise, the request is aborted with status forbidden.
+//
 // Finally, it will check the client ID of the token to see if a *gtsmodel.Application can be retrieved
 // for that client ID. This will also be set on the gin context.
 //
@@ -117,6 +121,15 @@ func TokenCheck(dbConn db.DB, validateBearerToken func(r *http.Request) (oauth2.
 			}
 
 			c.Set(oauth.SessionAuthorizedAccount, user.Account)
+
+			// Check whether the user is acting as a
+			// shared account they're a delegate of.
+			if accountID := c.Request.Header.Get(ActAsHeader); accountID != "" {
+				actAsDelegate(c, dbConn, user, accountID)
+				if c.IsAborted() {
+					return
+				}
+			}
 		}
 
 		// check for application token
@@ -0,0 +1,351 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// DelegatesGet returns the delegates (including pending
+// invitations) of the requesting (shared) account.
+func (p *Processor) DelegatesGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+) ([]*apimodel.AccountDelegate, gtserror.WithCode) {
+	delegates, err := p.state.DB.GetAccountDelegates(ctx, requester.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting delegates: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiAccountDelegates(ctx, delegates)
+}
+
+// DelegateCreate invites the local account in the given form
+// to be a delegate of the requesting (shared) account, with the
+// given permissions. The delegate may only act as the requesting
+// account once they've accepted, see DelegationAccept().
+func (p *Processor) DelegateCreate(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	form *apimodel.AccountDelegateRequest,
+) (*apimodel.AccountDelegate, gtserror.WithCode) {
+	if form.AccountID == requester.ID {
+		const text = "account cannot be its own delegate"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	target, err := p.state.DB.GetAccountByID(ctx, form.AccountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting account %s: %w", form.AccountID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if target == nil || target.IsRemote() || target.IsInstance() || target.IsSuspended() {
+		err := fmt.Errorf("local account %s not found", form.AccountID)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	existing, err := p.state.DB.GetAccountDelegate(
+		gtscontext.SetBarebones(ctx),
+		requester.ID,
+		target.ID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error checking existing delegate: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if existing != nil {
+		const text = "account is already a delegate, or has been invited"
+		return nil, gtserror.NewErrorConflict(errors.New(text), text)
+	}
+
+	delegate := &gtsmodel.AccountDelegate{
+		ID:                id.NewULID(),
+		AccountID:         requester.ID,
+		Account:           requester,
+		DelegateAccountID: target.ID,
+		DelegateAccount:   target,
+		CanPost:           util.Ptr(util.PtrValueOr(form.CanPost, true)),
+		CanManageDMs:      util.Ptr(util.PtrValueOr(form.CanManageDMs, false)),
+	}
+
+	if err := p.state.DB.PutAccountDelegate(ctx, delegate); err != nil {
+		err := gtserror.Newf("db error putting delegate: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiAccountDelegate(ctx, delegate)
+}
+
+// DelegateUpdate updates the permissions of the delegate with
+// given ID of the requesting (shared) account, from the form.
+func (p *Processor) DelegateUpdate(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	delegateID string,
+	form *apimodel.AccountDelegateRequest,
+) (*apimodel.AccountDelegate, gtserror.WithCode) {
+	delegate, errWithCode := p.getAccountDelegate(ctx, delegateID,
+		func(d *gtsmodel.AccountDelegate) bool { return d.AccountID == requester.ID },
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	var columns []string
+
+	if form.CanPost != nil {
+		delegate.CanPost = form.CanPost
+		columns = append(columns, "can_post")
+	}
+
+	if form.CanManageDMs != nil {
+		delegate.CanManageDMs = form.CanManageDMs
+		columns = append(columns, "can_manage_dms")
+	}
+
+	if len(columns) != 0 {
+		if err := p.state.DB.UpdateAccountDelegate(ctx, delegate, columns...); err != nil {
+			err := gtserror.Newf("db error updating delegate: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return p.apiAccountDelegate(ctx, delegate)
+}
+
+// DelegateRemove removes the delegate with given ID
+// of the requesting (shared) account, or withdraws
+// the invitation if it hasn't been accepted yet.
+func (p *Processor) DelegateRemove(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	delegateID string,
+) (*apimodel.AccountDelegate, gtserror.WithCode) {
+	return p.removeAccountDelegate(ctx, delegateID,
+		func(d *gtsmodel.AccountDelegate) bool { return d.AccountID == requester.ID },
+	)
+}
+
+// DelegationsGet returns the (shared) accounts that the
+// requesting account is a delegate of, or has been invited
+// to be a delegate of.
+func (p *Processor) DelegationsGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+) ([]*apimodel.AccountDelegate, gtserror.WithCode) {
+	delegations, err := p.state.DB.GetAccountDelegations(ctx, requester.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting delegations: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiAccountDelegates(ctx, delegations)
+}
+
+// DelegationAccept accepts the invitation with given ID
+// for the requesting account to be a delegate of a
+// (shared) account, so that it may act as that account.
+func (p *Processor) DelegationAccept(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	delegationID string,
+) (*apimodel.AccountDelegate, gtserror.WithCode) {
+	delegation, errWithCode := p.getAccountDelegate(ctx, delegationID,
+		func(d *gtsmodel.AccountDelegate) bool { return d.DelegateAccountID == requester.ID },
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if !delegation.IsAccepted() {
+		delegation.AcceptedAt = time.Now()
+		if err := p.state.DB.UpdateAccountDelegate(ctx, delegation, "accepted_at"); err != nil {
+			err := gtserror.Newf("db error updating delegation: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return p.apiAccountDelegate(ctx, delegation)
+}
+
+// DelegationRemove removes the requesting account as a delegate
+// of a (shared) account, by the delegation's ID, or declines
+// the invitation if it hasn't been accepted yet.
+func (p *Processor) DelegationRemove(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	delegationID string,
+) (*apimodel.AccountDelegate, gtserror.WithCode) {
+	return p.removeAccountDelegate(ctx, delegationID,
+		func(d *gtsmodel.AccountDelegate) bool { return d.DelegateAccountID == requester.ID },
+	)
+}
+
+// DelegatedStatusesGet returns a page of the requesting (shared)
+// account's statuses that were posted by its delegates, along with
+// which delegate posted each of them, for auditing purposes.
+func (p *Processor) DelegatedStatusesGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	statuses, err := p.state.DB.GetDelegatedStatuses(ctx, requester.ID, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting delegated statuses: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Check for empty response.
+	count := len(statuses)
+	if count == 0 {
+		return util.EmptyPageableResponse(), nil
+	}
+
+	// Get the lowest and highest
+	// ID values, used for paging.
+	lo := statuses[count-1].ID
+	hi := statuses[0].ID
+
+	items := make([]interface{}, 0, count)
+
+	for _, status := range statuses {
+		apiStatus, errWithCode := p.c.GetAPIStatus(ctx, requester, status)
+		if errWithCode != nil {
+			log.Errorf(ctx, "error converting status %s: %v", status.ID, errWithCode)
+			continue
+		}
+
+		// The delegate account may
+		// have since been deleted.
+		var postedBy *apimodel.Account
+		delegate, err := p.state.DB.GetAccountByID(ctx, status.PostedByAccountID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			log.Errorf(ctx, "error getting delegate account %s: %v", status.PostedByAccountID, err)
+		} else if delegate != nil && !delegate.IsSuspended() {
+			postedBy, err = p.converter.AccountToAPIAccountPublic(ctx, delegate)
+			if err != nil {
+				log.Errorf(ctx, "error converting delegate account %s: %v", delegate.ID, err)
+			}
+		}
+
+		items = append(items, &apimodel.DelegatedStatus{
+			Status:   apiStatus,
+			PostedBy: postedBy,
+		})
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/delegates/audit",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// getAccountDelegate gets the account delegate with given
+// ID, if it's visible to the requester according to given
+// function, else returning not found.
+func (p *Processor) getAccountDelegate(
+	ctx context.Context,
+	delegateID string,
+	visible func(*gtsmodel.AccountDelegate) bool,
+) (*gtsmodel.AccountDelegate, gtserror.WithCode) {
+	delegate, err := p.state.DB.GetAccountDelegateByID(ctx, delegateID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting delegate %s: %w", delegateID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if delegate == nil || !visible(delegate) {
+		err := fmt.Errorf("delegate %s not found", delegateID)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	return delegate, nil
+}
+
+// removeAccountDelegate deletes the account delegate
+// with given ID, if it's visible to the requester
+// according to given function, returning it as it was.
+func (p *Processor) removeAccountDelegate(
+	ctx context.Context,
+	delegateID string,
+	visible func(*gtsmodel.AccountDelegate) bool,
+) (*apimodel.AccountDelegate, gtserror.WithCode) {
+	delegate, errWithCode := p.getAccountDelegate(ctx, delegateID, visible)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	apiDelegate, errWithCode := p.apiAccountDelegate(ctx, delegate)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteAccountDelegateByID(ctx, delegate.ID); err != nil {
+		err := gtserror.Newf("db error deleting delegate: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiDelegate, nil
+}
+
+func (p *Processor) apiAccountDelegate(
+	ctx context.Context,
+	delegate *gtsmodel.AccountDelegate,
+) (*apimodel.AccountDelegate, gtserror.WithCode) {
+	apiDelegate, err := p.converter.AccountDelegateToAPIAccountDelegate(ctx, delegate)
+	if err != nil {
+		err := gtserror.Newf("error converting delegate: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiDelegate, nil
+}
+
+func (p *Processor) apiAccountDelegates(
+	ctx context.Context,
+	delegates []*gtsmodel.AccountDelegate,
+) ([]*apimodel.AccountDelegate, gtserror.WithCode) {
+	apiDelegates := make([]*apimodel.AccountDelegate, 0, len(delegates))
+	for _, delegate := range delegates {
+		apiDelegate, errWithCode := p.apiAccountDelegate(ctx, delegate)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		apiDelegates = append(apiDelegates, apiDelegate)
+	}
+
+	return apiDelegates, nil
+}
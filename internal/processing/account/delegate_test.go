@@ -0,0 +1,116 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type DelegateTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *DelegateTestSuite) TestDelegateLifecycle() {
+	var (
+		ctx      = context.Background()
+		shared   = suite.testAccounts["local_account_1"]
+		delegate = suite.testAccounts["local_account_2"]
+	)
+
+	// Invite delegate.
+	apiDelegate, errWithCode := suite.accountProcessor.DelegateCreate(ctx, shared,
+		&apimodel.AccountDelegateRequest{AccountID: delegate.ID},
+	)
+	suite.NoError(errWithCode)
+	suite.Equal(shared.ID, apiDelegate.Account.ID)
+	suite.Equal(delegate.ID, apiDelegate.Delegate.ID)
+	suite.True(apiDelegate.CanPost)
+	suite.False(apiDelegate.CanManageDMs)
+	suite.False(apiDelegate.Accepted)
+
+	// Inviting again should conflict.
+	_, errWithCode = suite.accountProcessor.DelegateCreate(ctx, shared,
+		&apimodel.AccountDelegateRequest{AccountID: delegate.ID},
+	)
+	suite.Equal(http.StatusConflict, errWithCode.Code())
+
+	// Shared account can't accept on the delegate's behalf.
+	_, errWithCode = suite.accountProcessor.DelegationAccept(ctx, shared, apiDelegate.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	// Delegate accepts.
+	apiDelegate, errWithCode = suite.accountProcessor.DelegationAccept(ctx, delegate, apiDelegate.ID)
+	suite.NoError(errWithCode)
+	suite.True(apiDelegate.Accepted)
+
+	// Shared account allows managing DMs.
+	apiDelegate, errWithCode = suite.accountProcessor.DelegateUpdate(ctx, shared, apiDelegate.ID,
+		&apimodel.AccountDelegateRequest{CanManageDMs: util.Ptr(true)},
+	)
+	suite.NoError(errWithCode)
+	suite.True(apiDelegate.CanPost)
+	suite.True(apiDelegate.CanManageDMs)
+
+	// Delegation should be visible from both sides.
+	delegates, errWithCode := suite.accountProcessor.DelegatesGet(ctx, shared)
+	suite.NoError(errWithCode)
+	suite.Len(delegates, 1)
+
+	delegations, errWithCode := suite.accountProcessor.DelegationsGet(ctx, delegate)
+	suite.NoError(errWithCode)
+	suite.Len(delegations, 1)
+
+	// Delegate leaves.
+	_, errWithCode = suite.accountProcessor.DelegationRemove(ctx, delegate, apiDelegate.ID)
+	suite.NoError(errWithCode)
+
+	delegates, errWithCode = suite.accountProcessor.DelegatesGet(ctx, shared)
+	suite.NoError(errWithCode)
+	suite.Empty(delegates)
+}
+
+func (suite *DelegateTestSuite) TestDelegateCreateSelf() {
+	shared := suite.testAccounts["local_account_1"]
+
+	_, errWithCode := suite.accountProcessor.DelegateCreate(context.Background(), shared,
+		&apimodel.AccountDelegateRequest{AccountID: shared.ID},
+	)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func (suite *DelegateTestSuite) TestDelegateCreateRemote() {
+	var (
+		shared = suite.testAccounts["local_account_1"]
+		remote = suite.testAccounts["remote_account_1"]
+	)
+
+	_, errWithCode := suite.accountProcessor.DelegateCreate(context.Background(), shared,
+		&apimodel.AccountDelegateRequest{AccountID: remote.ID},
+	)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func TestDelegateTestSuite(t *testing.T) {
+	suite.Run(t, new(DelegateTestSuite))
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
@@ -40,45 +41,117 @@ const deleteSelectLimit = 50
 
 // Delete deletes an account, and all of that account's statuses, media, follows, notifications, etc etc etc.
 // The origin passed here should be either the ID of the account doing the delete (can be itself), or the ID of a domain block.
+//
+// The delete is processed in stages, with progress stored in the database as it goes, so
+// that a delete interrupted by eg. a restart can be resumed by calling DeletesResume.
 func (p *Processor) Delete(
 	ctx context.Context,
 	account *gtsmodel.Account,
 	origin string,
 ) gtserror.WithCode {
-	l := log.WithContext(ctx).WithFields(kv.Fields{
-		{"username", account.Username},
-		{"domain", account.Domain},
-	}...)
-	l.Trace("beginning account delete process")
-
-	// Delete statuses *before* follows to ensure correct addressing
-	// of any outgoing fedi messages generated by deleting statuses.
-	if err := p.deleteAccountStatuses(ctx, account); err != nil {
-		l.Errorf("continuing after error during account delete: %v", err)
+	// Pick up any previous delete of this account
+	// that never finished, else start a new one.
+	deletion, err := p.state.DB.GetAccountDeletionByAccountID(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting account deletion: %w", err)
+		return gtserror.NewErrorInternalError(err)
 	}
 
-	if err := p.deleteAccountFollows(ctx, account); err != nil {
-		l.Errorf("continuing after error during account delete: %v", err)
+	if deletion == nil {
+		deletion = &gtsmodel.AccountDeletion{
+			ID:        id.NewULID(),
+			AccountID: account.ID,
+			Origin:    origin,
+			Stage:     gtsmodel.AccountDeletionStageStatuses,
+		}
+
+		if err := p.state.DB.PutAccountDeletion(ctx, deletion); err != nil {
+			err := gtserror.Newf("db error putting account deletion: %w", err)
+			return gtserror.NewErrorInternalError(err)
+		}
 	}
 
-	if err := p.deleteAccountBlocks(ctx, account); err != nil {
-		l.Errorf("continuing after error during account delete: %v", err)
+	deletion.Account = account
+	return p.processDeletion(ctx, deletion)
+}
+
+// DeletesResume queues all account deletes which were
+// interrupted by a previous shutdown, to be picked up
+// again from the stage that they had reached.
+func (p *Processor) DeletesResume(ctx context.Context) error {
+	deletions, err := p.state.DB.GetAccountDeletions(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting account deletions: %w", err)
 	}
 
-	if err := p.deleteAccountNotifications(ctx, account); err != nil {
-		l.Errorf("continuing after error during account delete: %v", err)
+	for _, deletion := range deletions {
+		deletion.Account, err = p.state.DB.GetAccountByID(ctx, deletion.AccountID)
+		if err != nil {
+			if !errors.Is(err, db.ErrNoEntries) {
+				return gtserror.Newf("db error getting account %s: %w", deletion.AccountID, err)
+			}
+
+			// Account is already gone,
+			// nothing left to resume.
+			if err := p.state.DB.DeleteAccountDeletionByID(ctx, deletion.ID); err != nil {
+				return gtserror.Newf("db error deleting account deletion: %w", err)
+			}
+			continue
+		}
+
+		p.queueDeletion(deletion)
 	}
 
-	if err := p.deleteAccountPeripheral(ctx, account); err != nil {
-		l.Errorf("continuing after error during account delete: %v", err)
+	if len(deletions) > 0 {
+		log.Infof(ctx, "resumed %d unfinished account delete(s)", len(deletions))
 	}
 
-	if account.IsLocal() {
-		// We delete tokens, applications and clients for
-		// account as one of the last stages during deletion,
-		// as other database models rely on these.
-		if err := p.deleteUserAndTokensForAccount(ctx, account); err != nil {
-			l.Errorf("continuing after error during account delete: %v", err)
+	return nil
+}
+
+// queueDeletion queues the given account
+// delete to be processed in the background.
+func (p *Processor) queueDeletion(deletion *gtsmodel.AccountDeletion) {
+	p.state.Workers.Dereference.Queue.Push(func(ctx context.Context) {
+		if errWithCode := p.processDeletion(ctx, deletion); errWithCode != nil {
+			log.Errorf(ctx, "error processing delete of account %s: %v", deletion.AccountID, errWithCode)
+		}
+	})
+}
+
+// processDeletion works through each remaining stage of the
+// given account delete in turn, storing the stage reached
+// as it goes, then finally stubbifies the deleted account.
+func (p *Processor) processDeletion(
+	ctx context.Context,
+	deletion *gtsmodel.AccountDeletion,
+) gtserror.WithCode {
+	account := deletion.Account
+	l := log.WithContext(ctx).WithFields(kv.Fields{
+		{"username", account.Username},
+		{"domain", account.Domain},
+	}...)
+	l.Tracef("beginning account delete process at stage %s", deletion.Stage)
+
+	for deletion.Stage < gtsmodel.AccountDeletionStageStubbify {
+		err := p.deleteAccountStage(ctx, deletion)
+
+		if ctx.Err() != nil {
+			// We're shutting down. The delete
+			// will be resumed from this stage
+			// when DeletesResume is next called.
+			return gtserror.NewErrorInternalError(ctx.Err())
+		}
+
+		if err != nil {
+			l.Errorf("continuing after error during account delete stage %s: %v", deletion.Stage, err)
+		}
+
+		// Move on to, and store, the next stage.
+		deletion.Stage++
+		if err := p.state.DB.UpdateAccountDeletion(ctx, deletion, "stage"); err != nil {
+			err := gtserror.Newf("db error updating account deletion: %w", err)
+			return gtserror.NewErrorInternalError(err)
 		}
 	}
 
@@ -86,12 +159,53 @@ func (p *Processor) Delete(
 	// stubbify it and update it in the db.
 	// The account will not be deleted, but it
 	// will become completely unusable.
-	columns := stubbifyAccount(account, origin)
+	columns := stubbifyAccount(account, deletion.Origin)
 	if err := p.state.DB.UpdateAccount(ctx, account, columns...); err != nil {
 		return gtserror.NewErrorInternalError(err)
 	}
 
-	l.Info("account delete process complete")
+	// All done, drop the progress tracker.
+	if err := p.state.DB.DeleteAccountDeletionByID(ctx, deletion.ID); err != nil {
+		err := gtserror.Newf("db error deleting account deletion: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	l.Infof("account delete process complete, %d statuses deleted", deletion.StatusesDeleted)
+	return nil
+}
+
+// deleteAccountStage performs the current stage of the given account delete.
+func (p *Processor) deleteAccountStage(ctx context.Context, deletion *gtsmodel.AccountDeletion) error {
+	account := deletion.Account
+
+	switch deletion.Stage {
+
+	// Delete statuses *before* follows to ensure correct addressing
+	// of any outgoing fedi messages generated by deleting statuses.
+	case gtsmodel.AccountDeletionStageStatuses:
+		return p.deleteAccountStatuses(ctx, deletion)
+
+	case gtsmodel.AccountDeletionStageFollows:
+		return p.deleteAccountFollows(ctx, account)
+
+	case gtsmodel.AccountDeletionStageBlocks:
+		return p.deleteAccountBlocks(ctx, account)
+
+	case gtsmodel.AccountDeletionStageNotifications:
+		return p.deleteAccountNotifications(ctx, account)
+
+	case gtsmodel.AccountDeletionStagePeripheral:
+		return p.deleteAccountPeripheral(ctx, account)
+
+	// We delete tokens, applications and clients for
+	// account as one of the last stages during deletion,
+	// as other database models rely on these.
+	case gtsmodel.AccountDeletionStageUser:
+		if account.IsLocal() {
+			return p.deleteUserAndTokensForAccount(ctx, account)
+		}
+	}
+
 	return nil
 }
 
@@ -301,11 +415,12 @@ func (p *Processor) deleteAccountBlocks(ctx context.Context, account *gtsmodel.A
 }
 
 // deleteAccountStatuses iterates through all statuses owned by
-// the given account, passing each discovered status (and boosts
-// thereof) to the processor workers for further processing.
+// the deleted account, passing each discovered status (and boosts
+// thereof) to the processor workers for further processing, and
+// storing the number of statuses deleted after each batch.
 func (p *Processor) deleteAccountStatuses(
 	ctx context.Context,
-	account *gtsmodel.Account,
+	deletion *gtsmodel.AccountDeletion,
 ) error {
 	// We'll select statuses 50 at a time so we don't wreck the db,
 	// and pass them through to the client api worker to handle.
@@ -315,14 +430,19 @@ func (p *Processor) deleteAccountStatuses(
 	// are all attached to statuses.
 
 	var (
+		account  = deletion.Account
 		statuses []*gtsmodel.Status
 		err      error
 		maxID    string
-		msgs     = []*messages.FromClientAPI{}
 	)
 
-statusLoop:
 	for {
+		if err := ctx.Err(); err != nil {
+			// We're shutting down, statuses
+			// deleted so far stay deleted.
+			return err
+		}
+
 		// Page through account's statuses.
 		statuses, err = p.state.DB.GetAccountStatuses(
 			ctx,
@@ -341,12 +461,15 @@ statusLoop:
 		}
 
 		if len(statuses) == 0 {
-			break statusLoop
+			return nil
 		}
 
 		// Update next maxID from last status.
 		maxID = statuses[len(statuses)-1].ID
 
+		// Use this slice to batch delete messages.
+		msgs := make([]*messages.FromClientAPI, 0, len(statuses))
+
 		for _, status := range statuses {
 			// Ensure account is set.
 			status.Account = account
@@ -403,20 +526,24 @@ statusLoop:
 				Target:         account,
 			})
 		}
-	}
 
-	// Process accreted messages in serial.
-	for _, msg := range msgs {
-		if err := p.state.Workers.Client.Process(ctx, msg); err != nil {
-			log.Errorf(
-				ctx,
-				"error processing %s of %s during Delete of account %s: %v",
-				msg.APActivityType, msg.APObjectType, account.ID, err,
-			)
+		// Process this batch of messages in serial.
+		for _, msg := range msgs {
+			if err := p.state.Workers.Client.Process(ctx, msg); err != nil {
+				log.Errorf(
+					ctx,
+					"error processing %s of %s during Delete of account %s: %v",
+					msg.APActivityType, msg.APObjectType, account.ID, err,
+				)
+			}
 		}
-	}
 
-	return nil
+		// Store progress made with this batch.
+		deletion.StatusesDeleted += len(statuses)
+		if err := p.state.DB.UpdateAccountDeletion(ctx, deletion, "statuses_deleted"); err != nil {
+			return gtserror.Newf("db error updating account deletion: %w", err)
+		}
+	}
 }
 
 func (p *Processor) deleteAccountNotifications(ctx context.Context, account *gtsmodel.Account) error {
@@ -466,6 +593,24 @@ func (p *Processor) deleteAccountPeripheral(ctx context.Context, account *gtsmod
 		return gtserror.Newf("error deleting poll votes by account: %w", err)
 	}
 
+	// Delete all delegates of the given account, and
+	// all delegations of the given account to others.
+	delegates, err := p.state.DB.GetAccountDelegates(gtscontext.SetBarebones(ctx), account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting delegates of account: %w", err)
+	}
+
+	delegations, err := p.state.DB.GetAccountDelegations(gtscontext.SetBarebones(ctx), account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting delegations of account: %w", err)
+	}
+
+	for _, delegate := range append(delegates, delegations...) {
+		if err := p.state.DB.DeleteAccountDelegateByID(ctx, delegate.ID); err != nil {
+			return gtserror.Newf("error deleting account delegate: %w", err)
+		}
+	}
+
 	// Delete account stats model.
 	if err := p.state.DB.DeleteAccountStats(ctx, account.ID); err != nil {
 		return gtserror.Newf("error deleting stats for account: %w", err)
@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
@@ -85,6 +86,55 @@ func (suite *AccountDeleteTestSuite) TestAccountDeleteLocal() {
 	suite.Zero(updatedUser.ConfirmationSentAt)
 	suite.Zero(updatedUser.ResetPasswordToken)
 	suite.Zero(updatedUser.ResetPasswordSentAt)
+
+	// Progress tracker should be gone now delete is done.
+	_, err = suite.db.GetAccountDeletionByAccountID(ctx, testAccount.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *AccountDeleteTestSuite) TestAccountDeleteResume() {
+	ctx := context.Background()
+
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_1"]
+
+	// Pretend a previous delete got as far as
+	// notifications before being interrupted.
+	suspensionOrigin := "01GWVP2A8J38Q2J2FDZ6TS8AQG"
+	if err := suite.db.PutAccountDeletion(ctx, &gtsmodel.AccountDeletion{
+		ID:        "01J2FNWCAMD9XBR5S6DSFDPWJ0",
+		AccountID: testAccount.ID,
+		Origin:    suspensionOrigin,
+		Stage:     gtsmodel.AccountDeletionStageNotifications,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Delete with a different origin, the
+	// stored one should be used instead.
+	if err := suite.accountProcessor.Delete(ctx, testAccount, testAccount.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	updatedAccount, err := suite.db.GetAccountByID(ctx, testAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(suspensionOrigin, updatedAccount.SuspensionOrigin)
+
+	// Statuses stage was already passed,
+	// so statuses should not be touched.
+	//
+	// Select them directly, since populating
+	// them would fail now their application
+	// has been deleted along with the user.
+	statuses := []*gtsmodel.Status{}
+	err = suite.db.GetWhere(ctx, []db.Where{{Key: "account_id", Value: testAccount.ID}}, &statuses)
+	suite.NoError(err)
+	suite.NotEmpty(statuses)
+
+	_, err = suite.db.GetAccountDeletionByAccountID(ctx, testAccount.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
 }
 
 func TestAccountDeleteTestSuite(t *testing.T) {
@@ -0,0 +1,190 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+)
+
+// FollowAutoAcceptDelay returns the delay before follow
+// requests targeting the given local account should be
+// automatically accepted, or 0 if the account is locked
+// or has no delay configured (ie., accept immediately).
+func (p *Processor) FollowAutoAcceptDelay(ctx context.Context, account *gtsmodel.Account) (time.Duration, error) {
+	if *account.Locked {
+		// Locked accounts never
+		// auto-accept anything.
+		return 0, nil
+	}
+
+	if account.Settings == nil {
+		var err error
+		account.Settings, err = p.state.DB.GetAccountSettings(ctx, account.ID)
+		if err != nil {
+			return 0, gtserror.Newf("error getting account settings: %w", err)
+		}
+	}
+
+	minutes := account.Settings.FollowAutoAcceptDelay
+	return time.Duration(minutes) * time.Minute, nil
+}
+
+// ScheduleAllFollowAutoAccepts schedules delayed automatic acceptance
+// of all pending follow requests targeting unlocked local accounts
+// that have a follow auto-accept delay set. This should be called
+// on startup, as scheduled tasks are not persisted across restarts.
+func (p *Processor) ScheduleAllFollowAutoAccepts(ctx context.Context) error {
+	var (
+		maxID string
+		errs  gtserror.MultiError
+	)
+
+	for {
+		// Page through local users.
+		users, err := p.state.DB.GetUsersPage(ctx, maxID, 100)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("error getting users: %w", err)
+		}
+
+		if len(users) == 0 {
+			break
+		}
+
+		// Use last ID as the next 'maxID' value.
+		maxID = users[len(users)-1].ID
+
+		for _, user := range users {
+			if user.Account == nil {
+				continue
+			}
+
+			delay, err := p.FollowAutoAcceptDelay(ctx, user.Account)
+			if err != nil {
+				errs.Append(err)
+				continue
+			}
+
+			if delay == 0 {
+				continue
+			}
+
+			// Fetch all pending follow requests targeting this account.
+			followReqs, err := p.state.DB.GetAccountFollowRequests(
+				gtscontext.SetBarebones(ctx),
+				user.AccountID,
+				nil,
+			)
+			if err != nil && !errors.Is(err, db.ErrNoEntries) {
+				errs.Appendf("error getting follow requests for %s: %w", user.AccountID, err)
+				continue
+			}
+
+			for _, followReq := range followReqs {
+				if err := p.ScheduleFollowAutoAccept(ctx, followReq, delay); err != nil {
+					errs.Append(err)
+				}
+			}
+		}
+	}
+
+	return errs.Combine()
+}
+
+// ScheduleFollowAutoAccept schedules the given follow request
+// to be automatically accepted once delay has passed since it
+// was created. Until then, it can still be manually rejected.
+func (p *Processor) ScheduleFollowAutoAccept(
+	ctx context.Context,
+	followReq *gtsmodel.FollowRequest,
+	delay time.Duration,
+) error {
+	at := followReq.CreatedAt.Add(delay)
+
+	// Add the given follow request to the scheduler.
+	ok := p.state.Workers.Scheduler.AddOnce(
+		followReq.ID,
+		at,
+		p.onFollowAutoAccept(followReq.ID),
+	)
+
+	if !ok {
+		// Failed to add the follow request to the scheduler, either it was
+		// starting / stopping or there already exists a task for it.
+		return gtserror.Newf("failed adding follow request %s to scheduler", followReq.ID)
+	}
+
+	atStr := at.Local().Format("Jan _2 2006 15:04:05")
+	log.Infof(ctx, "scheduled follow request auto-accept for %s at '%s'", followReq.ID, atStr)
+	return nil
+}
+
+// onFollowAutoAccept returns a callback function to be used by
+// the scheduler when the given follow request is due to be accepted.
+func (p *Processor) onFollowAutoAccept(followReqID string) func(context.Context, time.Time) {
+	return func(ctx context.Context, now time.Time) {
+		// Get the latest version of follow request from database.
+		followReq, err := p.state.DB.GetFollowRequestByID(ctx, followReqID)
+		if err != nil {
+			if !errors.Is(err, db.ErrNoEntries) {
+				log.Errorf(ctx, "error getting follow request %s from db: %v", followReqID, err)
+			}
+
+			// Follow request was rejected (or
+			// otherwise handled) in the meantime.
+			return
+		}
+
+		if followReq.TargetAccount == nil || *followReq.TargetAccount.Locked {
+			// Account was locked in the meantime,
+			// leave the request for manual approval.
+			return
+		}
+
+		follow, err := p.state.DB.AcceptFollowRequest(
+			ctx,
+			followReq.AccountID,
+			followReq.TargetAccountID,
+		)
+		if err != nil {
+			log.Errorf(ctx, "error accepting follow request %s: %v", followReqID, err)
+			return
+		}
+
+		if follow.Account != nil {
+			// Enqueue the accept exactly as if the
+			// target account had accepted it manually.
+			p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+				APObjectType:   ap.ActivityFollow,
+				APActivityType: ap.ActivityAccept,
+				GTSModel:       follow,
+				Origin:         follow.Account,
+				Target:         follow.TargetAccount,
+			})
+		}
+	}
+}
@@ -88,7 +88,10 @@ func (p *Processor) getFor(ctx context.Context, requestingAccount *gtsmodel.Acco
 		}
 	}
 
-	if targetAccount.Domain != "" {
+	// Only refresh remote accounts for authenticated requesters,
+	// so that unauthenticated requests (allowed when the public
+	// API is exposed) can't be used to trigger dereferencing.
+	if targetAccount.Domain != "" && requestingAccount != nil {
 		targetAccountURI, err := url.Parse(targetAccount.URI)
 		if err != nil {
 			return nil, gtserror.NewErrorInternalError(fmt.Errorf("error parsing url %s: %w", targetAccount.URI, err))
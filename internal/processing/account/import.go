@@ -0,0 +1,577 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+const (
+	// importMaxSize is the maximum size
+	// in bytes of one uploaded import.
+	importMaxSize = 1 << 20 // 1MiB
+
+	// importMaxRows is the maximum number
+	// of rows to accept in one import.
+	importMaxRows = 10000
+
+	// importBulkFollows is the maximum number
+	// of follows to store at once in bulk.
+	importBulkFollows = 500
+)
+
+// ImportCreate parses the uploaded CSV data, and stores it as an import
+// with one pending row per line of data. The import is then queued to
+// have its rows processed in the background. The returned import can
+// be used to track progress of processing via ImportGet.
+func (p *Processor) ImportCreate(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	form *apimodel.ImportRequest,
+) (*apimodel.Import, gtserror.WithCode) {
+	importType := gtsmodel.ImportType(form.Type)
+	if importType != gtsmodel.ImportTypeFollowing {
+		err := fmt.Errorf("import type %s not supported, supported types are: %s", form.Type, gtsmodel.ImportTypeFollowing)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	if form.Data.Size > importMaxSize {
+		err := fmt.Errorf("import data too large, max size is %d bytes", importMaxSize)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	f, err := form.Data.Open()
+	if err != nil {
+		err = gtserror.Newf("error opening import data: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	defer f.Close()
+
+	now := time.Now()
+	imp := &gtsmodel.Import{
+		ID:        id.NewULID(),
+		CreatedAt: now,
+		UpdatedAt: now,
+		AccountID: requester.ID,
+		Account:   requester,
+		Type:      importType,
+	}
+
+	rows, errWithCode := parseFollowingCSV(f, imp.ID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.PutImport(ctx, imp, rows); err != nil {
+		err = gtserror.Newf("db error putting import: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	p.queueImport(imp.ID)
+
+	return p.apiImport(ctx, imp, rows, true)
+}
+
+// ImportsGet returns imports uploaded by the requesting
+// account, newest first, without the status of each row.
+func (p *Processor) ImportsGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+) ([]*apimodel.Import, gtserror.WithCode) {
+	imps, err := p.state.DB.GetImports(ctx, requester.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting imports: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiImps := make([]*apimodel.Import, 0, len(imps))
+	for _, imp := range imps {
+		rows, err := p.state.DB.GetImportRows(ctx, imp.ID, "")
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err = gtserror.Newf("db error getting import rows: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		apiImp, errWithCode := p.apiImport(ctx, imp, rows, false)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		apiImps = append(apiImps, apiImp)
+	}
+
+	return apiImps, nil
+}
+
+// ImportGet returns one import uploaded by the
+// requesting account, with the status of each row.
+func (p *Processor) ImportGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	importID string,
+) (*apimodel.Import, gtserror.WithCode) {
+	imp, err := p.state.DB.GetImportByID(ctx, importID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting import %s: %w", importID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if imp == nil || imp.AccountID != requester.ID {
+		err := fmt.Errorf("import %s not found", importID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	rows, err := p.state.DB.GetImportRows(ctx, imp.ID, "")
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting import rows: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiImport(ctx, imp, rows, true)
+}
+
+// ImportsResume queues all imports which still have
+// pending rows to be processed in the background. This
+// should be called at startup, to pick up processing of
+// any imports that were interrupted by a shutdown.
+func (p *Processor) ImportsResume(ctx context.Context) error {
+	importIDs, err := p.state.DB.GetUnfinishedImportIDs(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting unfinished imports: %w", err)
+	}
+
+	for _, importID := range importIDs {
+		p.queueImport(importID)
+	}
+
+	if len(importIDs) > 0 {
+		log.Infof(ctx, "resumed %d unfinished import(s)", len(importIDs))
+	}
+
+	return nil
+}
+
+// queueImport queues the import with the given
+// ID to have its pending rows processed.
+func (p *Processor) queueImport(importID string) {
+	p.state.Workers.Dereference.Queue.Push(func(ctx context.Context) {
+		if err := p.processImport(ctx, importID); err != nil {
+			log.Errorf(ctx, "error processing import %s: %v", importID, err)
+		}
+	})
+}
+
+// processImport processes each pending row of the import with the
+// given ID in turn, storing the outcome of each row (or batch of rows
+// stored in bulk) as it goes, so that an interrupted import can be
+// picked up where it left off.
+func (p *Processor) processImport(ctx context.Context, importID string) error {
+	imp, err := p.state.DB.GetImportByID(ctx, importID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// Import or its account
+			// has since been deleted.
+			return nil
+		}
+		return gtserror.Newf("db error getting import: %w", err)
+	}
+
+	rows, err := p.state.DB.GetImportRows(ctx, imp.ID, gtsmodel.ImportRowStatusPending)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting import rows: %w", err)
+	}
+
+	// Follows of local, unlocked accounts need no
+	// federation or approval, so these are batched
+	// up and inserted in bulk, along with the rows.
+	var (
+		bulkFollows []*gtsmodel.Follow
+		bulkRows    []*gtsmodel.ImportRow
+		bulkTargets = make(map[string]struct{})
+	)
+
+	flush := func() error {
+		if len(bulkFollows) == 0 {
+			return nil
+		}
+
+		if err := p.state.DB.PutFollows(ctx, bulkFollows); err != nil {
+			// Eg., a follow was created concurrently, just
+			// fall back to processing these rows one by one.
+			log.Debugf(ctx, "error putting import follows in bulk, falling back: %v", err)
+			for _, row := range bulkRows {
+				reason := p.importFollow(ctx, imp.Account, row)
+				if err := p.updateImportRow(ctx, row, reason); err != nil {
+					return err
+				}
+			}
+		} else {
+			for _, row := range bulkRows {
+				if err := p.updateImportRow(ctx, row, nil); err != nil {
+					return err
+				}
+			}
+		}
+
+		bulkFollows = bulkFollows[:0]
+		bulkRows = bulkRows[:0]
+		clear(bulkTargets)
+		return nil
+	}
+
+	for _, row := range rows {
+		if ctx.Err() != nil {
+			// We're shutting down. Remaining rows stay
+			// pending, and will be processed when the
+			// import is resumed on next startup.
+			return nil
+		}
+
+		if imp.Account.IsSuspended() {
+			reason := errors.New("account is suspended")
+			if err := p.updateImportRow(ctx, row, reason); err != nil {
+				return err
+			}
+			continue
+		}
+
+		follow := p.importBulkFollow(ctx, imp.Account, row)
+		if follow != nil {
+			if _, ok := bulkTargets[follow.TargetAccountID]; !ok {
+				bulkFollows = append(bulkFollows, follow)
+				bulkRows = append(bulkRows, row)
+				bulkTargets[follow.TargetAccountID] = struct{}{}
+
+				if len(bulkFollows) >= importBulkFollows {
+					if err := flush(); err != nil {
+						return err
+					}
+				}
+				continue
+			}
+
+			// Target already in this batch, store
+			// the batch so the usual path below just
+			// updates the follow created by it.
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+
+		reason := p.importFollow(ctx, imp.Account, row)
+		if err := p.updateImportRow(ctx, row, reason); err != nil {
+			return err
+		}
+	}
+
+	if err := flush(); err != nil {
+		return err
+	}
+
+	imp.FinishedAt = time.Now()
+	if err := p.state.DB.UpdateImport(ctx, imp, "finished_at"); err != nil {
+		return gtserror.Newf("db error updating import: %w", err)
+	}
+
+	return nil
+}
+
+// updateImportRow stores the outcome of processing the given
+// import row, ie., failed with the given reason, or done if nil.
+func (p *Processor) updateImportRow(ctx context.Context, row *gtsmodel.ImportRow, reason error) error {
+	if reason != nil {
+		row.Status = gtsmodel.ImportRowStatusFailed
+		row.Error = reason.Error()
+	} else {
+		row.Status = gtsmodel.ImportRowStatusDone
+	}
+
+	if err := p.state.DB.UpdateImportRow(ctx, row, "status", "error"); err != nil {
+		return gtserror.Newf("db error updating import row: %w", err)
+	}
+
+	return nil
+}
+
+// importBulkFollow returns a new follow of the account targeted by
+// the given row on behalf of the requester, if that follow can be
+// stored directly without further processing, ie., the target is
+// a local, unlocked account that isn't yet followed or requested,
+// in the same way FollowCreate would accept it straight away. In
+// any other case nil is returned, and the row should be processed
+// through importFollow instead, which will report any reason why.
+func (p *Processor) importBulkFollow(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	row *gtsmodel.ImportRow,
+) *gtsmodel.Follow {
+	username, domain, err := util.ExtractNamestringParts("@" + row.Target)
+	if err != nil {
+		return nil
+	}
+
+	if domain != "" && domain != config.GetHost() && domain != config.GetAccountDomain() {
+		// Remote account.
+		return nil
+	}
+
+	target, err := p.state.DB.GetAccountByUsernameDomain(ctx, username, "")
+	if err != nil {
+		return nil
+	}
+
+	if target.ID == requester.ID || *target.Locked {
+		return nil
+	}
+
+	if _, errWithCode := p.c.GetVisibleTargetAccount(ctx, requester, target.ID); errWithCode != nil {
+		return nil
+	}
+
+	if follows, err := p.state.DB.IsFollowing(ctx, requester.ID, target.ID); err != nil || follows {
+		return nil
+	}
+
+	if requested, err := p.state.DB.IsFollowRequested(ctx, requester.ID, target.ID); err != nil || requested {
+		return nil
+	}
+
+	followID, err := id.NewRandomULID()
+	if err != nil {
+		return nil
+	}
+
+	return &gtsmodel.Follow{
+		ID:              followID,
+		URI:             uris.GenerateURIForFollow(requester.Username, followID),
+		AccountID:       requester.ID,
+		Account:         requester,
+		TargetAccountID: target.ID,
+		TargetAccount:   target,
+		ShowReblogs:     row.ShowReblogs,
+		Notify:          row.Notify,
+	}
+}
+
+// importFollow follows the account targeted by the given row
+// on behalf of the requester. Returned errors are safe to show
+// to the requester as the reason the row couldn't be processed.
+func (p *Processor) importFollow(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	row *gtsmodel.ImportRow,
+) error {
+	username, domain, err := util.ExtractNamestringParts("@" + row.Target)
+	if err != nil {
+		return errors.New("invalid account address")
+	}
+
+	var target *gtsmodel.Account
+	if domain == "" || domain == config.GetHost() || domain == config.GetAccountDomain() {
+		// Local account, just check the database.
+		target, err = p.state.DB.GetAccountByUsernameDomain(ctx, username, "")
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			log.Errorf(ctx, "db error getting account %s: %v", row.Target, err)
+			return errors.New("internal error")
+		}
+	} else {
+		blocked, err := p.state.DB.IsDomainBlocked(ctx, domain)
+		if err != nil {
+			log.Errorf(ctx, "db error checking domain block for %s: %v", domain, err)
+			return errors.New("internal error")
+		}
+
+		if blocked {
+			return errors.New("account domain is blocked by this instance")
+		}
+
+		// Remote account, get or dereference it.
+		target, _, err = p.federator.GetAccountByUsernameDomain(
+			gtscontext.SetFastFail(ctx),
+			requester.Username,
+			username, domain,
+		)
+		if err != nil {
+			log.Debugf(ctx, "error getting account %s: %v", row.Target, err)
+		}
+	}
+
+	if target == nil {
+		return errors.New("account could not be found")
+	}
+
+	if _, errWithCode := p.FollowCreate(
+		ctx,
+		requester,
+		&apimodel.AccountFollowRequest{
+			ID:      target.ID,
+			Reblogs: row.ShowReblogs,
+			Notify:  row.Notify,
+		},
+	); errWithCode != nil {
+		return errors.New(errWithCode.Safe())
+	}
+
+	return nil
+}
+
+// parseFollowingCSV parses the given CSV data into rows of an import
+// of accounts to follow. Data can either be in the format exported
+// by Mastodon (and GoToSocial), with a header row of "Account address",
+// "Show boosts", "Notify on new posts", etc, or a headerless list
+// of account addresses, one per line.
+//
+// Lines which can't be interpreted are included as failed rows,
+// rather than failing the whole import, so that the requester
+// can see exactly which lines were not imported and why.
+func parseFollowingCSV(r io.Reader, importID string) ([]*gtsmodel.ImportRow, gtserror.WithCode) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1 // Allow varying number of fields.
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	var (
+		rows       []*gtsmodel.ImportRow
+		reblogsIdx = -1
+		notifyIdx  = -1
+		first      = true
+	)
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			err := fmt.Errorf("error parsing import data as csv: %w", err)
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		if first {
+			first = false
+
+			if strings.EqualFold(strings.TrimSpace(record[0]), "Account address") {
+				// Header row, note the
+				// indices of the columns
+				// we're interested in.
+				for i, field := range record {
+					switch strings.ToLower(strings.TrimSpace(field)) {
+					case "show boosts":
+						reblogsIdx = i
+					case "notify on new posts":
+						notifyIdx = i
+					}
+				}
+				continue
+			}
+		}
+
+		if len(rows) >= importMaxRows {
+			err := fmt.Errorf("import data has too many rows, max rows is %d", importMaxRows)
+			return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := &gtsmodel.ImportRow{
+			ID:          id.NewULID(),
+			ImportID:    importID,
+			Line:        line,
+			Target:      strings.TrimPrefix(strings.TrimSpace(record[0]), "@"),
+			ShowReblogs: util.Ptr(true),
+			Notify:      util.Ptr(false),
+			Status:      gtsmodel.ImportRowStatusPending,
+		}
+
+		if err := parseImportBool(record, reblogsIdx, row.ShowReblogs); err != nil {
+			row.Status = gtsmodel.ImportRowStatusFailed
+			row.Error = "invalid value for Show boosts"
+		}
+
+		if err := parseImportBool(record, notifyIdx, row.Notify); err != nil {
+			row.Status = gtsmodel.ImportRowStatusFailed
+			row.Error = "invalid value for Notify on new posts"
+		}
+
+		if _, _, err := util.ExtractNamestringParts("@" + row.Target); err != nil {
+			row.Status = gtsmodel.ImportRowStatusFailed
+			row.Error = "invalid account address"
+		}
+
+		rows = append(rows, row)
+	}
+
+	if len(rows) == 0 {
+		err := errors.New("import data contained no rows")
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	return rows, nil
+}
+
+// parseImportBool parses the field at index i of the given
+// record into dst, if the field is present and not empty.
+func parseImportBool(record []string, i int, dst *bool) error {
+	if i < 0 || i >= len(record) {
+		return nil
+	}
+
+	field := strings.TrimSpace(record[i])
+	if field == "" {
+		return nil
+	}
+
+	b, err := strconv.ParseBool(field)
+	if err != nil {
+		return err
+	}
+
+	*dst = b
+	return nil
+}
+
+func (p *Processor) apiImport(
+	ctx context.Context,
+	imp *gtsmodel.Import,
+	rows []*gtsmodel.ImportRow,
+	includeRows bool,
+) (*apimodel.Import, gtserror.WithCode) {
+	apiImp, err := p.converter.ImportToAPIImport(ctx, imp, rows, includeRows)
+	if err != nil {
+		err = gtserror.Newf("error converting import to api: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiImp, nil
+}
@@ -0,0 +1,169 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type ImportTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *ImportTestSuite) importForm(importType string, data string) *apimodel.ImportRequest {
+	var b bytes.Buffer
+	w := multipart.NewWriter(&b)
+
+	fw, err := w.CreateFormFile("data", "following_accounts.csv")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if _, err := fw.Write([]byte(data)); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := w.Close(); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	form, err := multipart.NewReader(&b, w.Boundary()).ReadForm(1 << 20)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return &apimodel.ImportRequest{
+		Data: form.File["data"][0],
+		Type: importType,
+	}
+}
+
+func (suite *ImportTestSuite) TestImportFollowingMastodonCSV() {
+	ctx := context.Background()
+	requester := suite.testAccounts["local_account_1"]
+
+	data := "Account address,Show boosts,Notify on new posts,Languages\n" +
+		"admin@localhost:8080,true,false,\n" +
+		"foss_satan@fossbros-anonymous.io,false,true,en\n" +
+		"definitely not an address,true,false,\n" +
+		"1happyturtle@localhost:8080,maybe,false,\n"
+
+	imp, errWithCode := suite.accountProcessor.ImportCreate(ctx, requester, suite.importForm("following", data))
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Equal("following", imp.Type)
+	suite.Equal(4, imp.Total)
+	suite.Equal(2, imp.Pending)
+	suite.Equal(2, imp.Failed)
+
+	if suite.Len(imp.Rows, 4) {
+		suite.Equal(2, imp.Rows[0].Line)
+		suite.Equal("admin@localhost:8080", imp.Rows[0].Target)
+		suite.Equal("pending", imp.Rows[0].Status)
+
+		suite.Equal("failed", imp.Rows[2].Status)
+		suite.Equal("invalid account address", imp.Rows[2].Error)
+
+		suite.Equal("failed", imp.Rows[3].Status)
+		suite.Equal("invalid value for Show boosts", imp.Rows[3].Error)
+	}
+
+	// Import should be visible to the requester...
+	got, errWithCode := suite.accountProcessor.ImportGet(ctx, requester, imp.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Len(got.Rows, 4)
+
+	// ...but not to anyone else.
+	_, errWithCode = suite.accountProcessor.ImportGet(ctx, suite.testAccounts["admin_account"], imp.ID)
+	suite.NotNil(errWithCode)
+}
+
+func (suite *ImportTestSuite) TestImportFollowingProcess() {
+	ctx := context.Background()
+	requester := suite.testAccounts["local_account_2"]
+	admin := suite.testAccounts["admin_account"]
+
+	// Admin is local and unlocked so is followed in bulk,
+	// then again with notify on, through the usual path.
+	// Zork is already followed, and nobody doesn't exist.
+	data := "Account address,Show boosts,Notify on new posts\n" +
+		"admin@localhost:8080,true,false\n" +
+		"the_mighty_zork@localhost:8080,true,false\n" +
+		"admin@localhost:8080,true,true\n" +
+		"nobody@localhost:8080,true,false\n"
+
+	imp, errWithCode := suite.accountProcessor.ImportCreate(ctx, requester, suite.importForm("following", data))
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Process the queued import (and any
+	// left over by previous tests, whose
+	// imports no longer exist anyway).
+	for {
+		process, ok := suite.state.Workers.Dereference.Queue.Pop()
+		if !ok {
+			break
+		}
+		process(ctx)
+	}
+
+	imp, errWithCode = suite.accountProcessor.ImportGet(ctx, requester, imp.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Equal(0, imp.Pending)
+	suite.Equal(1, imp.Failed)
+	if suite.Len(imp.Rows, 4) {
+		suite.Equal("done", imp.Rows[0].Status)
+		suite.Equal("done", imp.Rows[1].Status)
+		suite.Equal("done", imp.Rows[2].Status)
+		suite.Equal("failed", imp.Rows[3].Status)
+		suite.Equal("account could not be found", imp.Rows[3].Error)
+	}
+
+	follow, err := suite.state.DB.GetFollow(ctx, requester.ID, admin.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(*follow.ShowReblogs)
+	suite.True(*follow.Notify)
+}
+
+func (suite *ImportTestSuite) TestImportUnsupportedType() {
+	ctx := context.Background()
+	requester := suite.testAccounts["local_account_1"]
+
+	_, errWithCode := suite.accountProcessor.ImportCreate(ctx, requester, suite.importForm("bookmarks", "https://example.org/some/status\n"))
+	suite.EqualError(errWithCode, "import type bookmarks not supported, supported types are: following")
+}
+
+func TestImportTestSuite(t *testing.T) {
+	suite.Run(t, new(ImportTestSuite))
+}
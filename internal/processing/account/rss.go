@@ -28,6 +28,8 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 const (
@@ -45,7 +47,15 @@ type GetRSSFeed func() (string, gtserror.WithCode)
 //
 // If the account has not yet posted an RSS-eligible status, the returned last-modified
 // time will be zero, and the GetRSSFeed func will return a valid RSS xml with no items.
-func (p *Processor) GetRSSFeedForUsername(ctx context.Context, username string) (GetRSSFeed, time.Time, gtserror.WithCode) {
+//
+// If excludeReplies is false, the account's public replies are included in the feed.
+// If tag is set, the feed includes only statuses using that hashtag.
+func (p *Processor) GetRSSFeedForUsername(
+	ctx context.Context,
+	username string,
+	excludeReplies bool,
+	tag string,
+) (GetRSSFeed, time.Time, gtserror.WithCode) {
 	var (
 		never = time.Time{}
 	)
@@ -69,6 +79,38 @@ func (p *Processor) GetRSSFeedForUsername(ctx context.Context, username string)
 		return nil, never, gtserror.NewErrorNotFound(err)
 	}
 
+	// RSS readers are always logged-out, so
+	// treat hidden statuses as feed disabled.
+	if util.PtrValueOr(account.Settings.HideStatusesLoggedOut, false) {
+		err = gtserror.New("account hides statuses from logged-out viewers")
+		return nil, never, gtserror.NewErrorNotFound(err)
+	}
+
+	// Look up the tag to filter by, if any.
+	var tagID string
+	if tag != "" {
+		normalized, ok := text.NormalizeHashtag(tag)
+		if !ok {
+			err := gtserror.Newf("invalid hashtag %s", tag)
+			return nil, never, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		t, err := p.state.DB.GetTagByName(ctx, normalized)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err = gtserror.Newf("db error getting tag %s: %w", normalized, err)
+			return nil, never, gtserror.NewErrorInternalError(err)
+		}
+
+		if t == nil {
+			// Tag has never been used, so
+			// there can't be any statuses.
+			err := gtserror.Newf("tag %s not found", normalized)
+			return nil, never, gtserror.NewErrorNotFound(err)
+		}
+
+		tagID = t.ID
+	}
+
 	// Ensure account stats populated.
 	if account.Stats == nil {
 		if err := p.state.DB.PopulateAccountStats(ctx, account); err != nil {
@@ -117,8 +159,8 @@ func (p *Processor) GetRSSFeedForUsername(ctx context.Context, username string)
 		// Reuse the lastPostAt value for feed.Updated.
 		feed.Updated = lastPostAt
 
-		// Retrieve latest statuses as they'd be shown on the web view of the account profile.
-		statuses, err := p.state.DB.GetAccountWebStatuses(ctx, account.ID, rssFeedLength, "")
+		// Retrieve latest statuses eligible for the feed.
+		statuses, err := p.state.DB.GetAccountFeedStatuses(ctx, account.ID, rssFeedLength, excludeReplies, tagID)
 		if err != nil && !errors.Is(err, db.ErrNoEntries) {
 			err = fmt.Errorf("db error getting account web statuses: %w", err)
 			return "", gtserror.NewErrorInternalError(err)
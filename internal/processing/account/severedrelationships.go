@@ -0,0 +1,131 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// SeveredRelationshipsGet returns follows to and from the requesting
+// account that were severed by domain blocks, newest first, optionally
+// filtered by the blocked domain (if not "").
+func (p *Processor) SeveredRelationshipsGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	domain string,
+) ([]*apimodel.SeveredRelationship, gtserror.WithCode) {
+	severed, err := p.state.DB.GetSeveredRelationships(ctx, requester.ID, domain)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting severed relationships: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiSeveredRelationships(ctx, severed)
+}
+
+// SeveredRelationshipsRestore re-follows each remote account on
+// the given domain that the requesting account followed before
+// the domain was blocked. Only follows from the requesting
+// account can be restored, and only once the domain block
+// responsible for severing them has been removed.
+//
+// Returns the severed relationships that were restored.
+func (p *Processor) SeveredRelationshipsRestore(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	domain string,
+) ([]*apimodel.SeveredRelationship, gtserror.WithCode) {
+	blocked, err := p.state.DB.IsDomainBlocked(ctx, domain)
+	if err != nil {
+		err = gtserror.Newf("db error checking domain block for %s: %w", domain, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if blocked {
+		err := fmt.Errorf("domain %s is still blocked by this instance", domain)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	severed, err := p.state.DB.GetSeveredRelationships(ctx, requester.ID, domain)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting severed relationships: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	restored := make([]*gtsmodel.SeveredRelationship, 0, len(severed))
+	for _, s := range severed {
+		if !s.Restorable() {
+			continue
+		}
+
+		// Follow the remote account again, as
+		// it was followed before the severance.
+		if _, errWithCode := p.FollowCreate(
+			ctx,
+			requester,
+			&apimodel.AccountFollowRequest{
+				ID:      s.RemoteAccountID,
+				Reblogs: s.ShowReblogs,
+				Notify:  s.Notify,
+			},
+		); errWithCode != nil {
+			// Remote account may have gone, or have
+			// blocked the requester in the meantime;
+			// just log and move on to the next one.
+			log.Warnf(ctx, "error restoring follow of %s: %v", s.RemoteAccountID, errWithCode)
+			continue
+		}
+
+		s.RestoredAt = time.Now()
+		if err := p.state.DB.UpdateSeveredRelationship(ctx, s, "restored_at"); err != nil {
+			err = gtserror.Newf("db error updating severed relationship: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		restored = append(restored, s)
+	}
+
+	return p.apiSeveredRelationships(ctx, restored)
+}
+
+func (p *Processor) apiSeveredRelationships(
+	ctx context.Context,
+	severed []*gtsmodel.SeveredRelationship,
+) ([]*apimodel.SeveredRelationship, gtserror.WithCode) {
+	apiSevered := make([]*apimodel.SeveredRelationship, 0, len(severed))
+	for _, s := range severed {
+		apiS, err := p.converter.SeveredRelationshipToAPISeveredRelationship(ctx, s)
+		if err != nil {
+			err = gtserror.Newf("error converting severed relationship to api: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		apiSevered = append(apiSevered, apiS)
+	}
+
+	return apiSevered, nil
+}
@@ -97,10 +97,14 @@ func (p *Processor) StatusesGet(
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
-	filters, err := p.state.DB.GetFiltersForAccountID(ctx, requestingAccount.ID)
-	if err != nil {
-		err = gtserror.Newf("couldn't retrieve filters for account %s: %w", requestingAccount.ID, err)
-		return nil, gtserror.NewErrorInternalError(err)
+	var filters []*gtsmodel.Filter
+	if requestingAccount != nil {
+		// Only authenticated requesters have filters.
+		filters, err = p.state.DB.GetFiltersForAccountID(ctx, requestingAccount.ID)
+		if err != nil {
+			err = gtserror.Newf("couldn't retrieve filters for account %s: %w", requestingAccount.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
 	}
 
 	for _, s := range filtered {
@@ -159,6 +163,13 @@ func (p *Processor) WebStatusesGet(
 		return nil, gtserror.NewErrorNotFound(err)
 	}
 
+	if account.Settings != nil &&
+		util.PtrValueOr(account.Settings.HideStatusesLoggedOut, false) {
+		// Account hides statuses from logged-out
+		// viewers, which web viewers always are.
+		return util.EmptyPageableResponse(), nil
+	}
+
 	statuses, err := p.state.DB.GetAccountWebStatuses(ctx, targetAccountID, 10, maxID)
 	if err != nil && !errors.Is(err, db.ErrNoEntries) {
 		return nil, gtserror.NewErrorInternalError(err)
@@ -199,6 +210,18 @@ func (p *Processor) WebStatusesGetPinned(
 	ctx context.Context,
 	targetAccountID string,
 ) ([]*apimodel.Status, gtserror.WithCode) {
+	settings, err := p.state.DB.GetAccountSettings(ctx, targetAccountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if settings != nil &&
+		util.PtrValueOr(settings.HideStatusesLoggedOut, false) {
+		// Account hides statuses from logged-out
+		// viewers, which web viewers always are.
+		return []*apimodel.Status{}, nil
+	}
+
 	statuses, err := p.state.DB.GetAccountPinnedStatuses(ctx, targetAccountID)
 	if err != nil && !errors.Is(err, db.ErrNoEntries) {
 		return nil, gtserror.NewErrorInternalError(err)
@@ -0,0 +1,60 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type StatusesTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *StatusesTestSuite) TestWebStatusesGetPinned() {
+	account := suite.testAccounts["admin_account"]
+
+	statuses, errWithCode := suite.accountProcessor.WebStatusesGetPinned(context.Background(), account.ID)
+	suite.NoError(errWithCode)
+
+	// Most recently pinned first,
+	// each marked as pinned.
+	if suite.Len(statuses, 2) {
+		suite.Equal("01F8MHAAY43M6RJ473VQFCVH37", statuses[0].ID)
+		suite.Equal("01F8MH75CBF9JFX4ZAD54N0W0R", statuses[1].ID)
+	}
+	for _, status := range statuses {
+		suite.True(status.Pinned)
+	}
+}
+
+func (suite *StatusesTestSuite) TestWebStatusesGetPinnedNotPublic() {
+	// This account's only pinned
+	// status is a direct message.
+	account := suite.testAccounts["local_account_2"]
+
+	statuses, errWithCode := suite.accountProcessor.WebStatusesGetPinned(context.Background(), account.ID)
+	suite.NoError(errWithCode)
+	suite.Empty(statuses)
+}
+
+func TestStatusesTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusesTestSuite))
+}
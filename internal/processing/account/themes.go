@@ -147,5 +147,13 @@ func PopulateThemes() *Themes {
 		return cmp.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
 	})
 
+	// Warn if the instance default
+	// theme is not one of the above.
+	if theme := config.GetInstanceDefaultTheme(); theme != "" {
+		if _, ok := themes.ByFileName[theme]; !ok {
+			log.Warnf(nil, "instance-default-theme %s not found in %s", theme, themesAbsFilePath)
+		}
+	}
+
 	return themes
 }
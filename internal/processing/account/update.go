@@ -19,13 +19,16 @@ package account
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
@@ -222,6 +225,23 @@ func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, form
 		log.Tracef(ctx, "new header info for account %s is %+v", account.ID, headerInfo)
 	}
 
+	// IDs of any profile media attachments
+	// removed by this update, to be deleted
+	// once the account update is stored.
+	var removedMediaIDs []string
+
+	if form.RemoveAvatar && account.AvatarMediaAttachmentID != "" {
+		removedMediaIDs = append(removedMediaIDs, account.AvatarMediaAttachmentID)
+		account.AvatarMediaAttachmentID = ""
+		account.AvatarMediaAttachment = nil
+	}
+
+	if form.RemoveHeader && account.HeaderMediaAttachmentID != "" {
+		removedMediaIDs = append(removedMediaIDs, account.HeaderMediaAttachmentID)
+		account.HeaderMediaAttachmentID = ""
+		account.HeaderMediaAttachment = nil
+	}
+
 	if form.Locked != nil {
 		account.Locked = form.Locked
 	}
@@ -254,6 +274,69 @@ func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, form
 
 			account.Settings.StatusContentType = *form.Source.StatusContentType
 		}
+
+		if form.Source.SpamFilterMode != nil {
+			if err := validate.SpamFilterMode(*form.Source.SpamFilterMode); err != nil {
+				return nil, gtserror.NewErrorBadRequest(err, err.Error())
+			}
+
+			account.Settings.SpamFilterMode = *form.Source.SpamFilterMode
+		}
+
+		if form.Source.EmailDigest != nil {
+			if err := validate.EmailDigest(*form.Source.EmailDigest); err != nil {
+				return nil, gtserror.NewErrorBadRequest(err, err.Error())
+			}
+
+			if account.Settings.EmailDigest == "" {
+				// Newly opted in; start counting from
+				// now, rather than including everything
+				// from before the user opted in.
+				account.Settings.EmailDigestSentAt = time.Now()
+			}
+
+			account.Settings.EmailDigest = *form.Source.EmailDigest
+		}
+
+		if form.Source.NotificationsSnooze != nil {
+			seconds := *form.Source.NotificationsSnooze
+			if err := validate.NotificationsSnooze(seconds); err != nil {
+				return nil, gtserror.NewErrorBadRequest(err, err.Error())
+			}
+
+			if seconds == 0 {
+				// Stop snoozing.
+				account.Settings.NotificationsSnoozedUntil = time.Time{}
+			} else {
+				snooze := time.Duration(seconds) * time.Second
+				account.Settings.NotificationsSnoozedUntil = time.Now().Add(snooze)
+			}
+		}
+
+		if form.Source.SnoozeAllowFollows != nil {
+			account.Settings.SnoozeAllowFollows = form.Source.SnoozeAllowFollows
+		}
+
+		if form.Source.SnoozeAllowDirect != nil {
+			account.Settings.SnoozeAllowDirect = form.Source.SnoozeAllowDirect
+		}
+
+		if form.Source.FollowAutoAcceptDelay != nil {
+			minutes := *form.Source.FollowAutoAcceptDelay
+			if err := validate.FollowAutoAcceptDelay(minutes); err != nil {
+				return nil, gtserror.NewErrorBadRequest(err, err.Error())
+			}
+
+			account.Settings.FollowAutoAcceptDelay = minutes
+		}
+
+		if form.Source.HideFromLocalTimeline != nil {
+			account.Settings.HideFromLocalTimeline = form.Source.HideFromLocalTimeline
+		}
+
+		if form.Source.HideFromFederatedTimeline != nil {
+			account.Settings.HideFromFederatedTimeline = form.Source.HideFromFederatedTimeline
+		}
 	}
 
 	if form.Theme != nil {
@@ -277,7 +360,7 @@ func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, form
 		if err := validate.CustomCSS(customCSS); err != nil {
 			return nil, gtserror.NewErrorBadRequest(err, err.Error())
 		}
-		account.Settings.CustomCSS = text.SanitizeToPlaintext(customCSS)
+		account.Settings.CustomCSS = text.SanitizeCustomCSS(customCSS)
 	}
 
 	if form.EnableRSS != nil {
@@ -288,6 +371,14 @@ func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, form
 		account.Settings.HideCollections = form.HideCollections
 	}
 
+	if form.HideStatusesLoggedOut != nil {
+		account.Settings.HideStatusesLoggedOut = form.HideStatusesLoggedOut
+	}
+
+	if form.NoIndex != nil {
+		account.Settings.NoIndex = form.NoIndex
+	}
+
 	if err := p.state.DB.UpdateAccount(ctx, account); err != nil {
 		return nil, gtserror.NewErrorInternalError(fmt.Errorf("could not update account %s: %s", account.ID, err))
 	}
@@ -296,6 +387,14 @@ func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, form
 		return nil, gtserror.NewErrorInternalError(fmt.Errorf("could not update account settings %s: %s", account.ID, err))
 	}
 
+	for _, id := range removedMediaIDs {
+		// Account no longer references removed
+		// media, so it's safe to clean it up now.
+		if err := p.deleteProfileMedia(ctx, id); err != nil {
+			log.Errorf(ctx, "error deleting removed profile media %s: %v", id, err)
+		}
+	}
+
 	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
 		APObjectType:   ap.ActorPerson,
 		APActivityType: ap.ActivityUpdate,
@@ -331,13 +430,16 @@ func (p *Processor) UpdateAvatar(
 	}
 
 	// Process the media attachment and load it immediately.
-	media := p.mediaManager.PreProcessMedia(data, accountID, &media.AdditionalMediaInfo{
+	processing := p.mediaManager.PreProcessMedia(data, accountID, &media.AdditionalMediaInfo{
 		Avatar:      util.Ptr(true),
 		Description: description,
 	})
 
-	attachment, err := media.LoadAttachment(ctx)
-	if err != nil {
+	attachment, err := processing.LoadAttachment(ctx)
+	if errors.Is(err, media.ErrInfected) {
+		const text = "uploaded file was rejected by virus scan"
+		return nil, gtserror.NewErrorUnprocessableEntity(err, text)
+	} else if err != nil {
 		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
 	} else if attachment.Type == gtsmodel.FileTypeUnknown {
 		err = gtserror.Newf("could not process uploaded file with extension %s", attachment.File.ContentType)
@@ -368,13 +470,16 @@ func (p *Processor) UpdateHeader(
 	}
 
 	// Process the media attachment and load it immediately.
-	media := p.mediaManager.PreProcessMedia(data, accountID, &media.AdditionalMediaInfo{
+	processing := p.mediaManager.PreProcessMedia(data, accountID, &media.AdditionalMediaInfo{
 		Header:      util.Ptr(true),
 		Description: description,
 	})
 
-	attachment, err := media.LoadAttachment(ctx)
-	if err != nil {
+	attachment, err := processing.LoadAttachment(ctx)
+	if errors.Is(err, media.ErrInfected) {
+		const text = "uploaded file was rejected by virus scan"
+		return nil, gtserror.NewErrorUnprocessableEntity(err, text)
+	} else if err != nil {
 		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
 	} else if attachment.Type == gtsmodel.FileTypeUnknown {
 		err = gtserror.Newf("could not process uploaded file with extension %s", attachment.File.ContentType)
@@ -383,3 +488,33 @@ func (p *Processor) UpdateHeader(
 
 	return attachment, nil
 }
+
+// deleteProfileMedia deletes the profile media attachment
+// with given ID from the database, along with its files.
+func (p *Processor) deleteProfileMedia(ctx context.Context, attachmentID string) error {
+	attachment, err := p.state.DB.GetAttachmentByID(ctx, attachmentID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// Already gone.
+			return nil
+		}
+		return gtserror.Newf("db error getting attachment: %w", err)
+	}
+
+	// Delete the attachment before its files, so that
+	// shared files are rechecked without it in the way.
+	if err := p.state.DB.DeleteAttachment(ctx, attachmentID); err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error deleting attachment: %w", err)
+	}
+
+	if _, err := media.DeleteFiles(ctx, p.state,
+		attachment.ID,
+		attachment.Thumbnail.Path,
+		attachment.File.Path,
+		media.MediumPath(attachment),
+	); err != nil {
+		return gtserror.Newf("error removing files: %w", err)
+	}
+
+	return nil
+}
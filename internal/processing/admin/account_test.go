@@ -77,6 +77,85 @@ func (suite *AccountTestSuite) TestAccountActionSuspend() {
 	suite.NotZero(targetAcct.SuspendedAt)
 }
 
+func (suite *AccountTestSuite) TestAccountActionSilence() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		request   = &apimodel.AdminActionRequest{
+			Category:  gtsmodel.AdminActionCategoryAccount.String(),
+			Type:      gtsmodel.AdminActionSilence.String(),
+			Text:      "too loud",
+			TargetID:  suite.testAccounts["local_account_1"].ID,
+			SendEmail: true,
+		}
+	)
+
+	actionID, errWithCode := suite.adminProcessor.AccountAction(
+		ctx,
+		adminAcct,
+		request,
+	)
+	suite.NoError(errWithCode)
+	suite.NotEmpty(actionID)
+
+	// Wait for action to finish.
+	if !testrig.WaitFor(func() bool {
+		return suite.adminProcessor.Actions().TotalRunning() == 0
+	}) {
+		suite.FailNow("timed out waiting for admin action(s) to finish")
+	}
+
+	adminAction, err := suite.db.GetAdminAction(ctx, actionID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.NotZero(adminAction.CompletedAt)
+	suite.Empty(adminAction.Errors)
+	suite.True(*adminAction.SendEmail)
+
+	// Ensure target account silenced.
+	targetAcct, err := suite.db.GetAccountByID(ctx, request.TargetID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.True(targetAcct.IsSilenced())
+
+	// Ensure email was sent to the user.
+	user, err := suite.db.GetUserByAccountID(ctx, request.TargetID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	sent, ok := suite.sentEmails[user.Email]
+	if !ok {
+		suite.FailNow("expected email to be sent to silenced user")
+	}
+	suite.Contains(sent, "Your account has been silenced.")
+	suite.Contains(sent, "too loud")
+}
+
+func (suite *AccountTestSuite) TestAccountActionDisableRemote() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		request   = &apimodel.AdminActionRequest{
+			Category: gtsmodel.AdminActionCategoryAccount.String(),
+			Type:     gtsmodel.AdminActionDisable.String(),
+			TargetID: suite.testAccounts["remote_account_1"].ID,
+		}
+	)
+
+	actionID, errWithCode := suite.adminProcessor.AccountAction(
+		ctx,
+		adminAcct,
+		request,
+	)
+	suite.EqualError(errWithCode, "admin action disable is only supported for local accounts")
+	suite.Empty(actionID)
+}
+
 func (suite *AccountTestSuite) TestAccountActionUnsupported() {
 	var (
 		ctx       = context.Background()
@@ -94,7 +173,7 @@ func (suite *AccountTestSuite) TestAccountActionUnsupported() {
 		adminAcct,
 		request,
 	)
-	suite.EqualError(errWithCode, "admin action type pee pee poo poo is not supported for this endpoint, currently supported types are: [\"suspend\"]")
+	suite.EqualError(errWithCode, "admin action type pee pee poo poo is not supported for this endpoint, currently supported types are: [\"disable\" \"silence\" \"sensitize\" \"suspend\" \"warn\"]")
 	suite.Empty(actionID)
 }
 
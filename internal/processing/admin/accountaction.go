@@ -19,14 +19,20 @@ package admin
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/email"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 func (p *Processor) AccountAction(
@@ -36,19 +42,64 @@ func (p *Processor) AccountAction(
 ) (string, gtserror.WithCode) {
 	targetAcct, err := p.state.DB.GetAccountByID(ctx, request.TargetID)
 	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err := fmt.Errorf("account %s not found", request.TargetID)
+			return "", gtserror.NewErrorNotFound(err, err.Error())
+		}
 		err := gtserror.Newf("db error getting target account: %w", err)
 		return "", gtserror.NewErrorInternalError(err)
 	}
 
-	switch gtsmodel.NewAdminActionType(request.Type) {
+	actionType := gtsmodel.NewAdminActionType(request.Type)
+
+	var sideEffects func(context.Context) error
+	switch actionType {
 	case gtsmodel.AdminActionSuspend:
-		return p.accountActionSuspend(ctx, adminAcct, targetAcct, request.Text)
+		sideEffects = func(ctx context.Context) error {
+			return p.accountActionSuspend(ctx, adminAcct, targetAcct)
+		}
+
+	case gtsmodel.AdminActionSilence:
+		sideEffects = func(ctx context.Context) error {
+			return p.accountActionSilence(ctx, targetAcct)
+		}
+
+	case gtsmodel.AdminActionSensitize:
+		sideEffects = func(ctx context.Context) error {
+			return p.accountActionSensitize(ctx, targetAcct)
+		}
+
+	case gtsmodel.AdminActionDisable:
+		if !targetAcct.IsLocal() {
+			err := fmt.Errorf("admin action %s is only supported for local accounts", actionType)
+			return "", gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		sideEffects = func(ctx context.Context) error {
+			return p.accountActionDisable(ctx, targetAcct)
+		}
+
+	case gtsmodel.AdminActionWarn:
+		if !targetAcct.IsLocal() {
+			err := fmt.Errorf("admin action %s is only supported for local accounts", actionType)
+			return "", gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		// A warning consists of only the
+		// email notification; no other
+		// side effects to process.
+		request.SendEmail = true
+		sideEffects = func(context.Context) error { return nil }
 
 	default:
 		// TODO: add more types to this slice when adding
 		//       more types to the switch statement above.
 		supportedTypes := []string{
+			gtsmodel.AdminActionDisable.String(),
+			gtsmodel.AdminActionSilence.String(),
+			gtsmodel.AdminActionSensitize.String(),
 			gtsmodel.AdminActionSuspend.String(),
+			gtsmodel.AdminActionWarn.String(),
 		}
 
 		err := fmt.Errorf(
@@ -58,14 +109,10 @@ func (p *Processor) AccountAction(
 
 		return "", gtserror.NewErrorBadRequest(err, err.Error())
 	}
-}
 
-func (p *Processor) accountActionSuspend(
-	ctx context.Context,
-	adminAcct *gtsmodel.Account,
-	targetAcct *gtsmodel.Account,
-	text string,
-) (string, gtserror.WithCode) {
+	// Emails only make sense for local accounts.
+	sendEmail := request.SendEmail && targetAcct.IsLocal()
+
 	actionID := id.NewULID()
 
 	errWithCode := p.actions.Run(
@@ -75,28 +122,174 @@ func (p *Processor) accountActionSuspend(
 			TargetCategory: gtsmodel.AdminActionCategoryAccount,
 			TargetID:       targetAcct.ID,
 			Target:         targetAcct,
-			Type:           gtsmodel.AdminActionSuspend,
+			Type:           actionType,
 			AccountID:      adminAcct.ID,
-			Text:           text,
+			Text:           request.Text,
+			SendEmail:      &sendEmail,
 		},
 		func(ctx context.Context) gtserror.MultiError {
-			if err := p.state.Workers.Client.Process(
-				ctx,
-				&messages.FromClientAPI{
-					APObjectType:   ap.ActorPerson,
-					APActivityType: ap.ActivityDelete,
-					Origin:         adminAcct,
-					Target:         targetAcct,
-				},
-			); err != nil {
-				errs := gtserror.NewMultiError(1)
+			errs := gtserror.NewMultiError(2)
+
+			if sendEmail {
+				// Send the email *before* processing
+				// side effects, as suspending a local
+				// account removes the user entry.
+				if err := p.emailAccountAction(
+					ctx,
+					targetAcct,
+					actionType,
+					request.Text,
+				); err != nil {
+					errs.Appendf("error emailing account action: %w", err)
+				}
+			}
+
+			if err := sideEffects(ctx); err != nil {
 				errs.Append(err)
-				return errs
 			}
 
-			return nil
+			if len(errs) == 0 {
+				return nil
+			}
+
+			return errs
 		},
 	)
 
 	return actionID, errWithCode
 }
+
+func (p *Processor) accountActionSuspend(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	targetAcct *gtsmodel.Account,
+) error {
+	// Deletion of the account is
+	// processed (and federated)
+	// by the client API worker.
+	return p.state.Workers.Client.Process(
+		ctx,
+		&messages.FromClientAPI{
+			APObjectType:   ap.ActorPerson,
+			APActivityType: ap.ActivityDelete,
+			Origin:         adminAcct,
+			Target:         targetAcct,
+		},
+	)
+}
+
+func (p *Processor) accountActionSilence(
+	ctx context.Context,
+	targetAcct *gtsmodel.Account,
+) error {
+	if targetAcct.IsSilenced() {
+		// Nothing to do.
+		return nil
+	}
+
+	// Silenced accounts are excluded from
+	// the public timelines of this instance
+	// (see the visibility filter). There is
+	// nothing to federate for a silence.
+	targetAcct.SilencedAt = time.Now()
+	if err := p.state.DB.UpdateAccount(
+		ctx,
+		targetAcct,
+		"silenced_at",
+	); err != nil {
+		return gtserror.Newf("db error silencing account: %w", err)
+	}
+
+	return nil
+}
+
+func (p *Processor) accountActionSensitize(
+	ctx context.Context,
+	targetAcct *gtsmodel.Account,
+) error {
+	if targetAcct.IsSensitized() {
+		// Nothing to do.
+		return nil
+	}
+
+	// Statuses of sensitized accounts are
+	// always presented as sensitive by this
+	// instance, and new statuses created by
+	// local sensitized accounts are federated
+	// out with the sensitive flag set.
+	targetAcct.SensitizedAt = time.Now()
+	if err := p.state.DB.UpdateAccount(
+		ctx,
+		targetAcct,
+		"sensitized_at",
+	); err != nil {
+		return gtserror.Newf("db error sensitizing account: %w", err)
+	}
+
+	return nil
+}
+
+func (p *Processor) accountActionDisable(
+	ctx context.Context,
+	targetAcct *gtsmodel.Account,
+) error {
+	user, err := p.state.DB.GetUserByAccountID(ctx, targetAcct.ID)
+	if err != nil {
+		return gtserror.Newf("db error getting user: %w", err)
+	}
+
+	if *user.Disabled {
+		// Nothing to do.
+		return nil
+	}
+
+	// Disabled users are prevented from
+	// signing in, and any existing tokens
+	// are refused by the token middleware.
+	user.Disabled = util.Ptr(true)
+	if err := p.state.DB.UpdateUser(
+		ctx,
+		user,
+		"disabled",
+	); err != nil {
+		return gtserror.Newf("db error disabling user: %w", err)
+	}
+
+	return nil
+}
+
+// emailAccountAction emails the user of the given
+// local account, to inform them of an admin action
+// taken against their account.
+func (p *Processor) emailAccountAction(
+	ctx context.Context,
+	targetAcct *gtsmodel.Account,
+	actionType gtsmodel.AdminActionType,
+	text string,
+) error {
+	user, err := p.state.DB.GetUserByAccountID(ctx, targetAcct.ID)
+	if err != nil {
+		return gtserror.Newf("db error getting user: %w", err)
+	}
+
+	if user.Email == "" {
+		// Nobody to email.
+		return nil
+	}
+
+	instance, err := p.state.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		return gtserror.Newf("db error getting instance: %w", err)
+	}
+
+	return p.emailSender.SendAccountActionEmail(
+		user.Email,
+		email.AccountActionData{
+			Username:     targetAcct.Username,
+			InstanceURL:  instance.URI,
+			InstanceName: instance.Title,
+			ActionType:   actionType.String(),
+			ActionText:   text,
+		},
+	)
+}
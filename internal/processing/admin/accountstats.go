@@ -0,0 +1,101 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// AccountStatsReconcile regenerates stored stats for all local
+// accounts from scratch, fixing any drift between the counts kept
+// up to date on writes and what's actually in the database.
+// Returns the number of accounts whose stats had drifted.
+func (p *Processor) AccountStatsReconcile(ctx context.Context) (int, error) {
+	var (
+		maxID   string
+		drifted int
+	)
+
+	for {
+		// Page through local users.
+		users, err := p.state.DB.GetUsersPage(ctx, maxID, 100)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return drifted, err
+		}
+
+		if len(users) == 0 {
+			break
+		}
+
+		// Use last ID as the next 'maxID' value.
+		maxID = users[len(users)-1].ID
+
+		for _, user := range users {
+			if user.Account == nil {
+				continue
+			}
+
+			ok, err := p.reconcileAccountStats(ctx, user.Account)
+			if err != nil {
+				log.Errorf(ctx, "error reconciling stats for account %s: %v", user.AccountID, err)
+				continue
+			}
+
+			if !ok {
+				drifted++
+			}
+		}
+	}
+
+	return drifted, nil
+}
+
+// reconcileAccountStats regenerates stats for the given
+// account, returning false if they had drifted from stored.
+func (p *Processor) reconcileAccountStats(ctx context.Context, account *gtsmodel.Account) (bool, error) {
+	// Lock on this account since we're changing stats,
+	// so we don't race with any in-progress updates.
+	unlock := p.state.ProcessingLocks.Lock(account.URI)
+	defer unlock()
+
+	// Get current stored stats.
+	account.Stats = nil
+	if err := p.state.DB.PopulateAccountStats(ctx, account); err != nil {
+		return false, err
+	}
+	before := *account.Stats
+
+	// Regenerate stats from scratch.
+	if err := p.state.DB.RegenerateAccountStats(ctx, account); err != nil {
+		return false, err
+	}
+	after := account.Stats
+
+	return util.PtrValueOr(before.StatusesCount, 0) == util.PtrValueOr(after.StatusesCount, 0) &&
+		util.PtrValueOr(before.StatusesPinnedCount, 0) == util.PtrValueOr(after.StatusesPinnedCount, 0) &&
+		util.PtrValueOr(before.FollowersCount, 0) == util.PtrValueOr(after.FollowersCount, 0) &&
+		util.PtrValueOr(before.FollowingCount, 0) == util.PtrValueOr(after.FollowingCount, 0) &&
+		util.PtrValueOr(before.FollowRequestsCount, 0) == util.PtrValueOr(after.FollowRequestsCount, 0) &&
+		before.LastStatusAt.Equal(after.LastStatusAt), nil
+}
@@ -0,0 +1,72 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type AccountStatsTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *AccountStatsTestSuite) TestAccountStatsReconcile() {
+	ctx := context.Background()
+
+	// Generate stats for this account.
+	account := suite.testAccounts["local_account_1"]
+	if err := suite.db.RegenerateAccountStats(ctx, account); err != nil {
+		suite.FailNow(err.Error())
+	}
+	followers := *account.Stats.FollowersCount
+
+	// Introduce some drift.
+	account.Stats.FollowersCount = util.Ptr(followers + 100)
+	if err := suite.db.UpdateAccountStats(ctx, account.Stats, "followers_count"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	drifted, err := suite.adminProcessor.AccountStatsReconcile(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.GreaterOrEqual(drifted, 1)
+
+	// Stats should now be fixed.
+	account.Stats = nil
+	if err := suite.db.PopulateAccountStats(ctx, account); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(followers, *account.Stats.FollowersCount)
+
+	// Nothing should have drifted
+	// on a second reconciliation.
+	drifted, err = suite.adminProcessor.AccountStatsReconcile(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Zero(drifted)
+}
+
+func TestAccountStatsTestSuite(t *testing.T) {
+	suite.Run(t, new(AccountStatsTestSuite))
+}
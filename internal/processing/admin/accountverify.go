@@ -0,0 +1,81 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// AccountVerify marks the local account with given ID as verified
+// (or not, if verified is false), showing a "verified" badge on its
+// profile. This is independent of rel=me verification of profile
+// fields, and is intended for eg., org or staff accounts.
+func (p *Processor) AccountVerify(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	accountID string,
+	verified bool,
+) (*apimodel.AdminAccountInfo, gtserror.WithCode) {
+	account, err := p.state.DB.GetAccountByID(ctx, accountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting account %s: %w", accountID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if account == nil {
+		err := fmt.Errorf("account %s not found", accountID)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	if account.IsRemote() || account.IsInstance() {
+		const text = "only local user accounts can be verified"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if account.IsVerified() != verified {
+		if verified {
+			account.VerifiedAt = time.Now()
+		} else {
+			account.VerifiedAt = time.Time{}
+		}
+
+		if err := p.state.DB.UpdateAccount(ctx, account, "verified_at"); err != nil {
+			err := gtserror.Newf("db error updating account %s: %w", accountID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		log.Infof(ctx, "admin %s set verified=%t on account %s", adminAcct.Username, verified, account.Username)
+	}
+
+	apiAccount, err := p.converter.AccountToAdminAPIAccount(ctx, account)
+	if err != nil {
+		err := gtserror.Newf("error converting account %s to admin api model: %w", accountID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiAccount, nil
+}
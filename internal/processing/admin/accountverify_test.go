@@ -0,0 +1,75 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type AccountVerifyTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *AccountVerifyTestSuite) TestVerifyUnverify() {
+	var (
+		ctx        = context.Background()
+		adminAcct  = suite.testAccounts["admin_account"]
+		targetAcct = suite.testAccounts["local_account_1"]
+	)
+
+	apiAccount, errWithCode := suite.adminProcessor.AccountVerify(ctx, adminAcct, targetAcct.ID, true)
+	suite.NoError(errWithCode)
+	suite.Equal([]apimodel.AccountDisplayRole{apimodel.AccountDisplayRoleVerified}, apiAccount.Account.Roles)
+
+	dbAccount, err := suite.db.GetAccountByID(ctx, targetAcct.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(dbAccount.IsVerified())
+
+	apiAccount, errWithCode = suite.adminProcessor.AccountVerify(ctx, adminAcct, targetAcct.ID, false)
+	suite.NoError(errWithCode)
+	suite.Empty(apiAccount.Account.Roles)
+
+	dbAccount, err = suite.db.GetAccountByID(ctx, targetAcct.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(dbAccount.IsVerified())
+}
+
+func (suite *AccountVerifyTestSuite) TestVerifyRemote() {
+	var (
+		ctx        = context.Background()
+		adminAcct  = suite.testAccounts["admin_account"]
+		targetAcct = suite.testAccounts["remote_account_1"]
+	)
+
+	_, errWithCode := suite.adminProcessor.AccountVerify(ctx, adminAcct, targetAcct.ID, true)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+	suite.Equal("Bad Request: only local user accounts can be verified", errWithCode.Safe())
+}
+
+func TestAccountVerifyTestSuite(t *testing.T) {
+	suite.Run(t, new(AccountVerifyTestSuite))
+}
@@ -38,6 +38,14 @@ type Processor struct {
 	// admin Actions currently
 	// undergoing processing
 	actions *Actions
+
+	// most recently
+	// aggregated stats
+	stats *instanceStats
+
+	// most recent media
+	// reconciliation
+	reconciliation *mediaReconciliation
 }
 
 func (p *Processor) Actions() *Actions {
@@ -65,5 +73,8 @@ func New(
 			r:     make(map[string]*gtsmodel.AdminAction),
 			state: state,
 		},
+
+		stats:          new(instanceStats),
+		reconciliation: new(mediaReconciliation),
 	}
 }
@@ -0,0 +1,287 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// bulkActionMaxTargets is the maximum
+// number of targets accepted in one
+// bulk admin action request.
+const bulkActionMaxTargets = 1000
+
+// BulkAction takes one admin action for each target listed
+// in the provided CSV file. Targets can be account URIs or
+// domains, and supported actions are:
+//
+//   - suspend: suspend an account, or block a domain.
+//   - silence: silence an account.
+//   - unblock: remove the block on a domain.
+//
+// Actions are processed using the same functions (and so
+// the same admin action workers) as their single-target
+// equivalents. If dryRun is set, each target is checked,
+// but no actions are actually taken.
+//
+// A MultiStatus is returned reporting the result of each
+// target, so that the caller can retry failures if desired.
+func (p *Processor) BulkAction(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	form *apimodel.AdminBulkActionRequest,
+) (*apimodel.MultiStatus, gtserror.WithCode) {
+	switch form.Type {
+	case "suspend", "silence", "unblock":
+		// Supported.
+	default:
+		err := fmt.Errorf(
+			"admin action type %s is not supported for this endpoint, "+
+				"currently supported types are: %q",
+			form.Type, []string{"suspend", "silence", "unblock"})
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	file, err := form.File.Open()
+	if err != nil {
+		err = gtserror.Newf("error opening attachment: %w", err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+	defer file.Close()
+
+	targets, errWithCode := parseBulkActionTargets(file)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	entries := make([]apimodel.MultiStatusEntry, 0, len(targets))
+	for _, target := range targets {
+		var entry apimodel.MultiStatusEntry
+		if strings.HasPrefix(target, "http://") ||
+			strings.HasPrefix(target, "https://") {
+			entry = p.bulkActionAccount(ctx, adminAcct, form, target)
+		} else {
+			entry = p.bulkActionDomain(ctx, adminAcct, form, target)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return apimodel.NewMultiStatus(entries), nil
+}
+
+// bulkActionAccount takes the requested
+// bulk action on the account with the given URI.
+func (p *Processor) bulkActionAccount(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	form *apimodel.AdminBulkActionRequest,
+	uri string,
+) apimodel.MultiStatusEntry {
+	if form.Type == "unblock" {
+		const text = "unblock is only supported for domains"
+		return bulkActionEntry(uri, gtserror.NewErrorUnprocessableEntity(errors.New(text), text), "")
+	}
+
+	account, err := p.state.DB.GetAccountByURI(ctx, uri)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting account %s: %w", uri, err)
+		return bulkActionEntry(uri, gtserror.NewErrorInternalError(err), "")
+	}
+
+	if account == nil {
+		err := fmt.Errorf("account %s not found", uri)
+		return bulkActionEntry(uri, gtserror.NewErrorNotFound(err, err.Error()), "")
+	}
+
+	if account.ID == adminAcct.ID {
+		const text = "you cannot take admin actions on your own account"
+		return bulkActionEntry(uri, gtserror.NewErrorUnprocessableEntity(errors.New(text), text), "")
+	}
+
+	if form.DryRun {
+		return bulkActionEntry(uri, nil, "would "+form.Type+" account")
+	}
+
+	_, errWithCode := p.AccountAction(ctx, adminAcct, &apimodel.AdminActionRequest{
+		Type:     form.Type,
+		Text:     form.Text,
+		TargetID: account.ID,
+	})
+
+	message := "suspended account"
+	if form.Type == "silence" {
+		message = "silenced account"
+	}
+
+	return bulkActionEntry(uri, errWithCode, message)
+}
+
+// bulkActionDomain takes the requested
+// bulk action on the given domain.
+func (p *Processor) bulkActionDomain(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	form *apimodel.AdminBulkActionRequest,
+	target string,
+) apimodel.MultiStatusEntry {
+	if form.Type == "silence" {
+		const text = "silence is only supported for accounts"
+		return bulkActionEntry(target, gtserror.NewErrorUnprocessableEntity(errors.New(text), text), "")
+	}
+
+	domain, err := util.Punify(target)
+	if err != nil || !strings.Contains(domain, ".") {
+		err := fmt.Errorf("%s is not a valid domain or account uri", target)
+		return bulkActionEntry(target, gtserror.NewErrorBadRequest(err, err.Error()), "")
+	}
+
+	if domain == config.GetHost() || domain == config.GetAccountDomain() {
+		const text = "you cannot take admin actions on this instance's own domain"
+		return bulkActionEntry(target, gtserror.NewErrorUnprocessableEntity(errors.New(text), text), "")
+	}
+
+	domainBlock, err := p.state.DB.GetDomainBlock(ctx, domain)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting domain block %s: %w", domain, err)
+		return bulkActionEntry(target, gtserror.NewErrorInternalError(err), "")
+	}
+
+	if form.Type == "unblock" {
+		if domainBlock == nil {
+			err := fmt.Errorf("domain %s is not blocked", domain)
+			return bulkActionEntry(target, gtserror.NewErrorNotFound(err, err.Error()), "")
+		}
+
+		if form.DryRun {
+			return bulkActionEntry(target, nil, "would unblock domain")
+		}
+
+		_, _, errWithCode := p.DomainPermissionDelete(
+			ctx,
+			gtsmodel.DomainPermissionBlock,
+			adminAcct,
+			domainBlock.ID,
+		)
+		return bulkActionEntry(target, errWithCode, "unblocked domain")
+	}
+
+	if form.DryRun {
+		if domainBlock != nil {
+			// Block already exists; creating it again
+			// will only retry the side effects.
+			return bulkActionEntry(target, nil, "would retry existing domain block")
+		}
+		return bulkActionEntry(target, nil, "would block domain")
+	}
+
+	_, _, errWithCode := p.DomainPermissionCreate(
+		ctx,
+		gtsmodel.DomainPermissionBlock,
+		adminAcct,
+		domain,
+		false,     // obfuscate
+		"",        // publicComment
+		form.Text, // privateComment
+		"",        // subscriptionID
+	)
+	return bulkActionEntry(target, errWithCode, "blocked domain")
+}
+
+// bulkActionEntry wraps the result of taking one
+// bulk action on the given target in a MultiStatusEntry.
+func bulkActionEntry(target string, errWithCode gtserror.WithCode, message string) apimodel.MultiStatusEntry {
+	if errWithCode != nil {
+		return apimodel.MultiStatusEntry{
+			Resource: target,
+			Message:  errWithCode.Safe(),
+			Status:   errWithCode.Code(),
+		}
+	}
+
+	return apimodel.MultiStatusEntry{
+		Resource: target,
+		Message:  message,
+		Status:   http.StatusOK,
+	}
+}
+
+// parseBulkActionTargets reads targets from
+// the first field of each line of CSV data,
+// skipping empty lines, comments, and headers.
+func parseBulkActionTargets(r io.Reader) ([]string, gtserror.WithCode) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1 // Allow varying number of fields.
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	var targets []string
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			err := fmt.Errorf("error parsing attachment as csv: %w", err)
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		target := strings.TrimSpace(record[0])
+		switch {
+		case target == "":
+			// Nothing to do.
+			continue
+
+		case strings.HasPrefix(target, "#"):
+			// Comment, or Mastodon-style
+			// header row ("#domain").
+			continue
+
+		case strings.EqualFold(target, "uri") ||
+			strings.EqualFold(target, "domain"):
+			// Header row.
+			continue
+		}
+
+		if len(targets) >= bulkActionMaxTargets {
+			err := fmt.Errorf("too many targets, max targets is %d", bulkActionMaxTargets)
+			return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+		}
+
+		targets = append(targets, target)
+	}
+
+	if len(targets) == 0 {
+		err := errors.New("error parsing attachment: 0 targets provided")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	return targets, nil
+}
@@ -0,0 +1,142 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type BulkActionTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *BulkActionTestSuite) bulkActionForm(actionType string, data string, dryRun bool) *apimodel.AdminBulkActionRequest {
+	var b bytes.Buffer
+	w := multipart.NewWriter(&b)
+
+	fw, err := w.CreateFormFile("file", "targets.csv")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if _, err := fw.Write([]byte(data)); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := w.Close(); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	form, err := multipart.NewReader(&b, w.Boundary()).ReadForm(1 << 20)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return &apimodel.AdminBulkActionRequest{
+		File:   form.File["file"][0],
+		Type:   actionType,
+		DryRun: dryRun,
+	}
+}
+
+func (suite *BulkActionTestSuite) TestBulkActionSuspendDryRun() {
+	data := "#domain,#severity\n" +
+		"http://fossbros-anonymous.io/users/foss_satan,\n" +
+		"replyguys.com,suspend\n" +
+		"example.org,suspend\n" +
+		"\n" +
+		"not a domain\n" +
+		"https://unknown.example.org/users/nobody\n"
+
+	multiStatus, errWithCode := suite.adminProcessor.BulkAction(
+		context.Background(),
+		suite.testAccounts["admin_account"],
+		suite.bulkActionForm("suspend", data, true),
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Equal(5, multiStatus.Metadata.Total)
+	suite.Equal(3, multiStatus.Metadata.Success)
+	suite.Equal(2, multiStatus.Metadata.Failure)
+
+	for i, expect := range []struct {
+		status  int
+		message string
+	}{
+		{http.StatusOK, "would suspend account"},
+		{http.StatusOK, "would retry existing domain block"},
+		{http.StatusOK, "would block domain"},
+		{http.StatusBadRequest, "Bad Request: not a domain is not a valid domain or account uri"},
+		{http.StatusNotFound, "Not Found: account https://unknown.example.org/users/nobody not found"},
+	} {
+		suite.Equal(expect.status, multiStatus.Data[i].Status)
+		suite.Equal(expect.message, multiStatus.Data[i].Message)
+	}
+
+	// Nothing should have been done for real.
+	account, err := suite.state.DB.GetAccountByID(context.Background(), suite.testAccounts["remote_account_1"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(account.IsSuspended())
+
+	blocked, err := suite.state.DB.IsDomainBlocked(context.Background(), "example.org")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(blocked)
+}
+
+func (suite *BulkActionTestSuite) TestBulkActionUnsupportedForTarget() {
+	data := "http://fossbros-anonymous.io/users/foss_satan\n"
+
+	multiStatus, errWithCode := suite.adminProcessor.BulkAction(
+		context.Background(),
+		suite.testAccounts["admin_account"],
+		suite.bulkActionForm("unblock", data, false),
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Equal(1, multiStatus.Metadata.Failure)
+	suite.Equal(http.StatusUnprocessableEntity, multiStatus.Data[0].Status)
+	suite.Equal("Unprocessable Entity: unblock is only supported for domains", multiStatus.Data[0].Message)
+}
+
+func (suite *BulkActionTestSuite) TestBulkActionUnknownType() {
+	_, errWithCode := suite.adminProcessor.BulkAction(
+		context.Background(),
+		suite.testAccounts["admin_account"],
+		suite.bulkActionForm("sensitize", "example.org\n", true),
+	)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func TestBulkActionTestSuite(t *testing.T) {
+	suite.Run(t, new(BulkActionTestSuite))
+}
@@ -0,0 +1,52 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+// ConversionReportGet returns all entries in the conversion report
+// of inbound ActivityPub properties that weren't mapped by the converter.
+//
+// Returns a 404 if advanced-conversion-report is not enabled.
+func (p *Processor) ConversionReportGet(ctx context.Context) ([]*apimodel.AdminConversionReportEntry, gtserror.WithCode) {
+	if !config.GetAdvancedConversionReport() {
+		err := errors.New("conversion report not enabled on this instance")
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	entries := p.converter.ConversionReport()
+	apiEntries := make([]*apimodel.AdminConversionReportEntry, 0, len(entries))
+	for _, entry := range entries {
+		apiEntries = append(apiEntries, &apimodel.AdminConversionReportEntry{
+			Software: entry.Software,
+			Type:     entry.Type,
+			Property: entry.Property,
+			Count:    entry.Count,
+			LastURI:  entry.LastURI,
+		})
+	}
+
+	return apiEntries, nil
+}
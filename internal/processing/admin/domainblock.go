@@ -170,7 +170,9 @@ func (p *Processor) skipBlockSideEffects(
 // domainBlockSideEffects processes the side effects of a domain block:
 //
 //  1. Strip most info away from the instance entry for the domain.
-//  2. Pass each account from the domain to the processor for deletion.
+//  2. Record follows between local accounts and accounts from the domain.
+//  3. Pass each account from the domain to the processor for deletion.
+//  4. Notify local accounts whose follows were severed by the block.
 //
 // It should be called asynchronously, since it can take a while when
 // there are many accounts present on the given domain.
@@ -197,10 +199,19 @@ func (p *Processor) domainBlockSideEffects(
 		}
 	}
 
+	// Local accounts that lose follows
+	// or followers because of this block.
+	severedFor := make(map[string]struct{})
+
 	// For each account that belongs to this domain,
+	// record its follows with local accounts, then
 	// process an account delete message to remove
-	// that account's posts, media, etc.
+	// that account's posts, media, follows, etc.
 	if err := p.rangeDomainAccounts(ctx, block.Domain, func(account *gtsmodel.Account) {
+		if err := p.recordSeveredRelationships(ctx, block, account, severedFor); err != nil {
+			errs.Append(err)
+		}
+
 		if err := p.state.Workers.Client.Process(ctx, &messages.FromClientAPI{
 			APObjectType:   ap.ActorPerson,
 			APActivityType: ap.ActivityDelete,
@@ -214,9 +225,118 @@ func (p *Processor) domainBlockSideEffects(
 		errs.Appendf("db error ranging through accounts: %w", err)
 	}
 
+	// Let local accounts know they lost some follows.
+	if err := p.notifySeveredRelationships(ctx, severedFor); err != nil {
+		errs.Append(err)
+	}
+
 	return errs
 }
 
+// recordSeveredRelationships stores a record of each follow
+// between the given remote account and a local account, so
+// that local accounts can see which follows they lost due
+// to the given domain block, and restore them if the block
+// is lifted. It should be called before the remote account
+// is deleted. IDs of local accounts with severed follows
+// are added to severedFor.
+func (p *Processor) recordSeveredRelationships(
+	ctx context.Context,
+	block *gtsmodel.DomainBlock,
+	account *gtsmodel.Account,
+	severedFor map[string]struct{},
+) error {
+	var severed []*gtsmodel.SeveredRelationship
+
+	// Follows from local accounts targeting this account.
+	followers, err := p.state.DB.GetAccountLocalFollowers(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting local followers of %s: %w", account.ID, err)
+	}
+
+	for _, follow := range followers {
+		severed = append(severed, &gtsmodel.SeveredRelationship{
+			ID:              id.NewULID(),
+			DomainBlockID:   block.ID,
+			Domain:          block.Domain,
+			LocalAccountID:  follow.AccountID,
+			RemoteAccountID: account.ID,
+			Direction:       gtsmodel.SeveredRelationshipFollowing,
+			ShowReblogs:     follow.ShowReblogs,
+			Notify:          follow.Notify,
+		})
+	}
+
+	// Follows from this account targeting local accounts.
+	follows, err := p.state.DB.GetAccountFollows(ctx, account.ID, nil)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting follows of %s: %w", account.ID, err)
+	}
+
+	for _, follow := range follows {
+		if follow.TargetAccount == nil || !follow.TargetAccount.IsLocal() {
+			// Only care about
+			// local accounts.
+			continue
+		}
+
+		severed = append(severed, &gtsmodel.SeveredRelationship{
+			ID:              id.NewULID(),
+			DomainBlockID:   block.ID,
+			Domain:          block.Domain,
+			LocalAccountID:  follow.TargetAccountID,
+			RemoteAccountID: account.ID,
+			Direction:       gtsmodel.SeveredRelationshipFollowedBy,
+			ShowReblogs:     follow.ShowReblogs,
+			Notify:          follow.Notify,
+		})
+	}
+
+	if err := p.state.DB.PutSeveredRelationships(ctx, severed); err != nil {
+		return gtserror.Newf("db error putting severed relationships of %s: %w", account.ID, err)
+	}
+
+	for _, s := range severed {
+		severedFor[s.LocalAccountID] = struct{}{}
+	}
+
+	return nil
+}
+
+// notifySeveredRelationships sends a notification from
+// the instance account to each of the given local accounts,
+// to let them know that some of their follows were severed.
+func (p *Processor) notifySeveredRelationships(
+	ctx context.Context,
+	severedFor map[string]struct{},
+) error {
+	if len(severedFor) == 0 {
+		// Nothing to do.
+		return nil
+	}
+
+	instanceAcct, err := p.state.DB.GetInstanceAccount(ctx, "")
+	if err != nil {
+		return gtserror.Newf("db error getting instance account: %w", err)
+	}
+
+	for accountID := range severedFor {
+		notif := &gtsmodel.Notification{
+			ID:               id.NewULID(),
+			NotificationType: gtsmodel.NotificationSeveredRelationships,
+			TargetAccountID:  accountID,
+			OriginAccountID:  instanceAcct.ID,
+			OriginAccount:    instanceAcct,
+		}
+
+		if err := p.state.DB.PutNotification(ctx, notif); err != nil {
+			return gtserror.Newf("db error putting notification for %s: %w", accountID, err)
+		}
+	}
+
+	return nil
+}
+
 func (p *Processor) deleteDomainBlock(
 	ctx context.Context,
 	adminAcct *gtsmodel.Account,
@@ -0,0 +1,226 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+)
+
+// DomainMovesResolve iterates through all
+// moved accounts belonging to the given
+// domain that are still followed by local
+// accounts, and queues their Move to be
+// (re)processed, so that local follows are
+// merged over to the Move target account.
+// Moved accounts left with no local follows
+// are queued to be deleted (see ResolveMoves).
+func (p *Processor) DomainMovesResolve(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	domain string,
+) (string, gtserror.WithCode) {
+	actionID := id.NewULID()
+
+	// Process move resolution asynchronously.
+	if errWithCode := p.actions.Run(
+		ctx,
+		&gtsmodel.AdminAction{
+			ID:             actionID,
+			TargetCategory: gtsmodel.AdminActionCategoryDomain,
+			TargetID:       domain,
+			Type:           gtsmodel.AdminActionResolveMoves,
+			AccountID:      adminAcct.ID,
+		},
+		func(ctx context.Context) gtserror.MultiError {
+			_, _, errs := p.ResolveMoves(ctx, domain)
+			return errs
+		},
+	); errWithCode != nil {
+		return actionID, errWithCode
+	}
+
+	return actionID, nil
+}
+
+// ResolveMoves queues a Move to be processed for
+// each remote account that has moved elsewhere, but
+// which is still followed by local accounts. If domain
+// is empty, accounts from all domains will be checked.
+//
+// The Move itself is processed by the fedi API worker
+// in the same way as a Move received via the inbox, so
+// it will only succeed if the target account is aliased
+// back to the moved account, and it will be rate limited
+// like any other Move.
+//
+// Moved accounts whose Move has succeeded, and which are
+// no longer followed by local accounts, are stale: their
+// local relationships all belong to the Move target now.
+// These are queued to be deleted, like any remote account.
+//
+// Returns the number of Moves queued, and the number
+// of stale moved accounts queued to be deleted.
+func (p *Processor) ResolveMoves(ctx context.Context, domain string) (resolved int, cleaned int, errs gtserror.MultiError) {
+	// Moves are processed on behalf
+	// of our instance account, which
+	// is used to dereference targets.
+	instanceAcct, err := p.state.DB.GetInstanceAccount(ctx, "")
+	if err != nil {
+		errs.Appendf("db error getting instance account: %w", err)
+		return 0, 0, errs
+	}
+
+	resolved = p.rangeMovedAccounts(ctx, domain, p.state.DB.GetMovedAccounts, &errs,
+		func(account *gtsmodel.Account) error {
+			return p.resolveMove(ctx, instanceAcct, account)
+		},
+	)
+
+	cleaned = p.rangeMovedAccounts(ctx, domain, p.state.DB.GetStaleMovedAccounts, &errs,
+		func(account *gtsmodel.Account) error {
+			p.cleanupMove(instanceAcct, account)
+			return nil
+		},
+	)
+
+	return resolved, cleaned, errs
+}
+
+// rangeMovedAccounts pages through the accounts
+// returned by get for the given domain, calling
+// fn for each, and returns the number for which
+// fn succeeded. Any errors are appended to errs.
+func (p *Processor) rangeMovedAccounts(
+	ctx context.Context,
+	domain string,
+	get func(ctx context.Context, domain string, maxID string, limit int) ([]*gtsmodel.Account, error),
+	errs *gtserror.MultiError,
+	fn func(*gtsmodel.Account) error,
+) int {
+	var (
+		limit = 50   // Limit selection to avoid spiking mem/cpu.
+		maxID string // Start with empty string to select from top.
+		count int
+	)
+
+	for {
+		// Get (next) page of moved accounts.
+		accounts, err := get(ctx, domain, maxID, limit)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			// Real db error.
+			errs.Appendf("db error getting moved accounts: %w", err)
+			return count
+		}
+
+		if len(accounts) == 0 {
+			// No accounts left, we're done.
+			return count
+		}
+
+		// Set next max ID for paging down.
+		maxID = accounts[len(accounts)-1].ID
+
+		for _, account := range accounts {
+			if err := fn(account); err != nil {
+				errs.Append(err)
+				continue
+			}
+			count++
+		}
+	}
+}
+
+// resolveMove queues a Move from account
+// to account.MovedToURI to be processed.
+func (p *Processor) resolveMove(
+	ctx context.Context,
+	instanceAcct *gtsmodel.Account,
+	account *gtsmodel.Account,
+) error {
+	origin, err := url.Parse(account.URI)
+	if err != nil {
+		return gtserror.Newf("error parsing account uri %s: %w", account.URI, err)
+	}
+
+	target, err := url.Parse(account.MovedToURI)
+	if err != nil {
+		return gtserror.Newf("error parsing moved to uri %s: %w", account.MovedToURI, err)
+	}
+
+	// If we've stored a Move for this origin
+	// and target before, reuse its URI so that
+	// the existing Move is updated. Else we
+	// generate a new URI for the Move.
+	var moveURI string
+	move, err := p.state.DB.GetMoveByOriginTarget(ctx,
+		account.URI,
+		account.MovedToURI,
+	)
+	switch {
+	case err != nil && !errors.Is(err, db.ErrNoEntries):
+		return gtserror.Newf("db error getting move for %s: %w", account.URI, err)
+	case move != nil:
+		moveURI = move.URI
+	default:
+		moveURI = uris.GenerateURIForMove(instanceAcct.Username, id.NewULID())
+	}
+
+	// Pass to the fedi API worker, as
+	// though we'd received it via inbox.
+	p.state.Workers.Federator.Queue.Push(&messages.FromFediAPI{
+		APObjectType:   ap.ActorPerson,
+		APActivityType: ap.ActivityMove,
+		GTSModel: &gtsmodel.Move{
+			OriginURI: account.URI,
+			Origin:    origin,
+			TargetURI: account.MovedToURI,
+			Target:    target,
+			URI:       moveURI,
+		},
+		Requesting: account,
+		Receiving:  instanceAcct,
+	})
+
+	return nil
+}
+
+// cleanupMove queues the given stale
+// moved account to be deleted, as though
+// we'd received its Delete via inbox.
+func (p *Processor) cleanupMove(
+	instanceAcct *gtsmodel.Account,
+	account *gtsmodel.Account,
+) {
+	p.state.Workers.Federator.Queue.Push(&messages.FromFediAPI{
+		APObjectType:   ap.ActorPerson,
+		APActivityType: ap.ActivityDelete,
+		GTSModel:       account,
+		Requesting:     account,
+		Receiving:      instanceAcct,
+	})
+}
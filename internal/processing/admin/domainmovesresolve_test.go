@@ -0,0 +1,108 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type DomainMovesResolveTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *DomainMovesResolveTestSuite) TestResolveMoves() {
+	var (
+		ctx            = context.Background()
+		staleAccount   = suite.testAccounts["remote_account_1"]
+		movedAccount   = suite.testAccounts["remote_account_2"]
+		localAccount   = suite.testAccounts["local_account_1"]
+		staleTargetURI = "http://new-domain.example.org/users/foss_satan"
+		movedTargetURI = "http://new-domain.example.org/users/some_user"
+	)
+
+	// Set both remote accounts as moved.
+	staleAccount.MovedToURI = staleTargetURI
+	if err := suite.db.UpdateAccount(ctx, staleAccount, "moved_to_uri"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	movedAccount.MovedToURI = movedTargetURI
+	if err := suite.db.UpdateAccount(ctx, movedAccount, "moved_to_uri"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// The stale account's Move has already
+	// succeeded, and it has no local followers.
+	if err := suite.db.PutMove(ctx, &gtsmodel.Move{
+		ID:          "01HZJ3B7M3YV1RX9Q3ZP8T2KAW",
+		OriginURI:   staleAccount.URI,
+		TargetURI:   staleTargetURI,
+		URI:         staleAccount.URI + "/moves/01HZJ3B7M3YV1RX9Q3ZP8T2KAW",
+		AttemptedAt: time.Now(),
+		SucceededAt: time.Now(),
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// The other moved account is still
+	// followed by a local account.
+	if err := suite.db.PutFollow(ctx, &gtsmodel.Follow{
+		ID:              "01HZJ2XWPNH1QYZ2E0MM3T4B3G",
+		URI:             "http://localhost:8080/users/the_mighty_zork/follow/01HZJ2XWPNH1QYZ2E0MM3T4B3G",
+		AccountID:       localAccount.ID,
+		TargetAccountID: movedAccount.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	resolved, cleaned, errs := suite.adminProcessor.ResolveMoves(ctx, "")
+	if errs != nil {
+		suite.FailNow(errs.Combine().Error())
+	}
+
+	// The followed account's Move should
+	// be queued, and the stale account
+	// should be queued for deletion.
+	suite.Equal(1, resolved)
+	suite.Equal(1, cleaned)
+
+	// Stale account should be
+	// stubbified and suspended.
+	if !suite.Eventually(func() bool {
+		account, err := suite.db.GetAccountByID(ctx, staleAccount.ID)
+		return err == nil && !account.SuspendedAt.IsZero()
+	}, 10*time.Second, 100*time.Millisecond) {
+		suite.FailNow("timed out waiting for stale account to be deleted")
+	}
+
+	// The followed account should be left alone.
+	account, err := suite.db.GetAccountByID(ctx, movedAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Zero(account.SuspendedAt)
+}
+
+func TestDomainMovesResolveTestSuite(t *testing.T) {
+	suite.Run(t, new(DomainMovesResolveTestSuite))
+}
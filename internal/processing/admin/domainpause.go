@@ -0,0 +1,169 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+// apiDomainPause is a shortcut for returning the API
+// version of the given domain pause, or an appropriate
+// error if something goes wrong.
+func (p *Processor) apiDomainPause(
+	ctx context.Context,
+	domainPause *gtsmodel.DomainPause,
+) (*apimodel.DomainPause, gtserror.WithCode) {
+	apiDomainPause, err := p.converter.DomainPauseToAPIDomainPause(ctx, domainPause)
+	if err != nil {
+		err := gtserror.NewfAt(3, "error converting domain pause to api model: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiDomainPause, nil
+}
+
+// DomainPauseCreate pauses federation with the given domain.
+//
+// While the pause is in place, deliveries to the domain are
+// held in the delivery queue, and (if pauseIngestion is set)
+// incoming activities from the domain are refused with a
+// retryable error. No other side effects are processed.
+//
+// If a pause already exists for the domain, it is returned as-is.
+func (p *Processor) DomainPauseCreate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	domain string,
+	pauseIngestion bool,
+	privateComment string,
+) (*apimodel.DomainPause, gtserror.WithCode) {
+	// Check if a pause already exists for this domain.
+	domainPause, err := p.state.DB.GetDomainPause(ctx, domain)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		// Something went wrong in the DB.
+		err = gtserror.Newf("db error getting domain pause %s: %w", domain, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if domainPause == nil {
+		// No pause exists yet, create it.
+		domainPause = &gtsmodel.DomainPause{
+			ID:                 id.NewULID(),
+			Domain:             domain,
+			CreatedByAccountID: adminAcct.ID,
+			PrivateComment:     text.SanitizeToPlaintext(privateComment),
+			PauseIngestion:     &pauseIngestion,
+		}
+
+		// Insert the new pause into the database.
+		if err := p.state.DB.CreateDomainPause(ctx, domainPause); err != nil {
+			err = gtserror.Newf("db error putting domain pause %s: %w", domain, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return p.apiDomainPause(ctx, domainPause)
+}
+
+// DomainPausesGet returns all domain pauses currently in place.
+func (p *Processor) DomainPausesGet(
+	ctx context.Context,
+) ([]*apimodel.DomainPause, gtserror.WithCode) {
+	domainPauses, err := p.state.DB.GetDomainPauses(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting domain pauses: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiDomainPauses := make([]*apimodel.DomainPause, 0, len(domainPauses))
+	for _, domainPause := range domainPauses {
+		apiDomainPause, errWithCode := p.apiDomainPause(ctx, domainPause)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		apiDomainPauses = append(apiDomainPauses, apiDomainPause)
+	}
+
+	return apiDomainPauses, nil
+}
+
+// DomainPauseGet returns one domain pause with the given ID.
+func (p *Processor) DomainPauseGet(
+	ctx context.Context,
+	id string,
+) (*apimodel.DomainPause, gtserror.WithCode) {
+	domainPause, err := p.state.DB.GetDomainPauseByID(ctx, id)
+	if err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			// Real error.
+			err = gtserror.Newf("db error getting domain pause: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		// There are just no entries for this ID.
+		err = fmt.Errorf("no domain pause entry exists with ID %s", id)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	return p.apiDomainPause(ctx, domainPause)
+}
+
+// DomainPauseDelete lifts the domain pause with the given ID.
+// Deliveries held in the delivery queue for the domain will be
+// resumed the next time they are checked by a delivery worker.
+func (p *Processor) DomainPauseDelete(
+	ctx context.Context,
+	id string,
+) (*apimodel.DomainPause, gtserror.WithCode) {
+	domainPause, err := p.state.DB.GetDomainPauseByID(ctx, id)
+	if err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			// Real error.
+			err = gtserror.Newf("db error getting domain pause: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		// There are just no entries for this ID.
+		err = fmt.Errorf("no domain pause entry exists with ID %s", id)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	// Prepare the domain pause to return, *before* the deletion goes through.
+	apiDomainPause, errWithCode := p.apiDomainPause(ctx, domainPause)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Delete the original domain pause.
+	if err := p.state.DB.DeleteDomainPause(ctx, domainPause.Domain); err != nil {
+		err = gtserror.Newf("db error deleting domain pause: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiDomainPause, nil
+}
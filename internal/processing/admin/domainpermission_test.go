@@ -20,6 +20,7 @@ package admin_test
 import (
 	"context"
 	"net/http"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -28,6 +29,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -526,6 +528,91 @@ func (suite *DomainBlockTestSuite) TestAllowAndBlockDomain() {
 	})
 }
 
+func (suite *DomainBlockTestSuite) TestBlockSeversRelationships() {
+	var (
+		ctx           = context.Background()
+		localAccount1 = suite.testAccounts["local_account_1"]
+		localAccount2 = suite.testAccounts["local_account_2"]
+		remoteAccount = suite.testAccounts["remote_account_1"]
+		domain        = remoteAccount.Domain
+	)
+
+	// Local account 1 follows the remote
+	// account, and the remote account
+	// follows local account 2.
+	for _, follow := range []*gtsmodel.Follow{
+		{
+			ID:              id.NewULID(),
+			URI:             "http://localhost:8080/users/the_mighty_zork/follow/01J10FQ3NN2J6M6K5Y5S0J1Z3D",
+			AccountID:       localAccount1.ID,
+			TargetAccountID: remoteAccount.ID,
+			ShowReblogs:     util.Ptr(false),
+			Notify:          util.Ptr(true),
+		},
+		{
+			ID:              id.NewULID(),
+			URI:             "http://fossbros-anonymous.io/users/foss_satan/follow/01J10FQFDDJ8X2F0MZ0P4K6Z8Y",
+			AccountID:       remoteAccount.ID,
+			TargetAccountID: localAccount2.ID,
+			ShowReblogs:     util.Ptr(true),
+			Notify:          util.Ptr(false),
+		},
+	} {
+		if err := suite.db.PutFollow(ctx, follow); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	// Block the remote domain.
+	_, actionID := suite.createDomainPerm(gtsmodel.DomainPermissionBlock, domain)
+	suite.awaitAction(actionID)
+
+	// Follows should be gone, but recorded.
+	severed, err := suite.db.GetSeveredRelationships(ctx, localAccount1.ID, domain)
+	suite.NoError(err)
+	suite.Len(severed, 1)
+	suite.Equal(remoteAccount.ID, severed[0].RemoteAccountID)
+	suite.Equal(gtsmodel.SeveredRelationshipFollowing, severed[0].Direction)
+	suite.False(*severed[0].ShowReblogs)
+	suite.True(*severed[0].Notify)
+	suite.True(severed[0].Restorable())
+
+	severed, err = suite.db.GetSeveredRelationships(ctx, localAccount2.ID, domain)
+	suite.NoError(err)
+	suite.Len(severed, 1)
+	suite.Equal(gtsmodel.SeveredRelationshipFollowedBy, severed[0].Direction)
+	suite.False(severed[0].Restorable())
+
+	// Both local accounts should have been notified.
+	for _, account := range []*gtsmodel.Account{localAccount1, localAccount2} {
+		notifs, err := suite.db.GetAccountNotifications(ctx, account.ID, "", "", "", 0, nil)
+		suite.NoError(err)
+		suite.True(slices.ContainsFunc(notifs, func(n *gtsmodel.Notification) bool {
+			return n.NotificationType == gtsmodel.NotificationSeveredRelationships
+		}))
+	}
+
+	// Can't restore while the block is still in place.
+	_, errWithCode := suite.processor.Account().SeveredRelationshipsRestore(ctx, localAccount1, domain)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+
+	// Lift the block, and restore.
+	_, actionID = suite.deleteDomainPerm(gtsmodel.DomainPermissionBlock, domain)
+	suite.awaitAction(actionID)
+
+	restored, errWithCode := suite.processor.Account().SeveredRelationshipsRestore(ctx, localAccount1, domain)
+	suite.NoError(errWithCode)
+	suite.Len(restored, 1)
+	suite.NotNil(restored[0].RestoredAt)
+	suite.False(restored[0].Restorable)
+
+	// There should be a follow request from local account 1 again.
+	followRequest, err := suite.db.GetFollowRequest(ctx, localAccount1.ID, remoteAccount.ID)
+	suite.NoError(err)
+	suite.False(*followRequest.ShowReblogs)
+	suite.True(*followRequest.Notify)
+}
+
 func TestDomainBlockTestSuite(t *testing.T) {
 	suite.Run(t, new(DomainBlockTestSuite))
 }
@@ -0,0 +1,223 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+// getDomainPermissionDraft is a shortcut for fetching
+// the domain permission draft with the given ID, or
+// returning an appropriate error if it can't be found.
+func (p *Processor) getDomainPermissionDraft(
+	ctx context.Context,
+	id string,
+) (*gtsmodel.DomainPermissionDraft, gtserror.WithCode) {
+	draft, err := p.state.DB.GetDomainPermissionDraftByID(ctx, id)
+	if err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			// Real error.
+			err = gtserror.Newf("db error getting domain permission draft: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		// There are just no entries for this ID.
+		err = fmt.Errorf("no domain permission draft exists with ID %s", id)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	return draft, nil
+}
+
+// DomainPermissionDraftCreate creates a draft domain permission
+// of the given type targeting the given domain. Drafts have no
+// side effects until they are accepted with DomainPermissionDraftAccept.
+func (p *Processor) DomainPermissionDraftCreate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	permissionType gtsmodel.DomainPermissionType,
+	domain string,
+	obfuscate bool,
+	publicComment string,
+	privateComment string,
+) (*apimodel.DomainPermission, gtserror.WithCode) {
+	// Ensure known permission type.
+	if permissionType != gtsmodel.DomainPermissionBlock &&
+		permissionType != gtsmodel.DomainPermissionAllow {
+		err := errors.New("permission_type must be either block or allow")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Check if a draft of this type already exists for this domain.
+	existing, err := p.state.DB.GetDomainPermissionDrafts(ctx, permissionType, domain)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting domain permission drafts for %s: %w", domain, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if len(existing) != 0 {
+		err := fmt.Errorf(
+			"a domain %s draft already exists for %s with ID %s",
+			permissionType.String(), domain, existing[0].ID,
+		)
+		return nil, gtserror.NewErrorConflict(err, err.Error())
+	}
+
+	draft := &gtsmodel.DomainPermissionDraft{
+		ID:                 id.NewULID(),
+		PermissionType:     permissionType,
+		Domain:             domain,
+		CreatedByAccountID: adminAcct.ID,
+		PrivateComment:     text.SanitizeToPlaintext(privateComment),
+		PublicComment:      text.SanitizeToPlaintext(publicComment),
+		Obfuscate:          &obfuscate,
+	}
+
+	if err := p.state.DB.CreateDomainPermissionDraft(ctx, draft); err != nil {
+		err = gtserror.Newf("db error putting domain permission draft %s: %w", domain, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiDomainPerm(ctx, draft, false)
+}
+
+// DomainPermissionDraftsGet returns all domain permission
+// drafts, optionally filtered by type (if not unknown)
+// and by domain (if not empty).
+func (p *Processor) DomainPermissionDraftsGet(
+	ctx context.Context,
+	permissionType gtsmodel.DomainPermissionType,
+	domain string,
+) ([]*apimodel.DomainPermission, gtserror.WithCode) {
+	drafts, err := p.state.DB.GetDomainPermissionDrafts(ctx, permissionType, domain)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting domain permission drafts: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiDrafts := make([]*apimodel.DomainPermission, 0, len(drafts))
+	for _, draft := range drafts {
+		apiDraft, errWithCode := p.apiDomainPerm(ctx, draft, false)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		apiDrafts = append(apiDrafts, apiDraft)
+	}
+
+	return apiDrafts, nil
+}
+
+// DomainPermissionDraftGet returns one
+// domain permission draft with the given ID.
+func (p *Processor) DomainPermissionDraftGet(
+	ctx context.Context,
+	id string,
+) (*apimodel.DomainPermission, gtserror.WithCode) {
+	draft, errWithCode := p.getDomainPermissionDraft(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiDomainPerm(ctx, draft, false)
+}
+
+// DomainPermissionDraftAccept puts the domain permission draft
+// with the given ID into effect, by creating a domain permission
+// from it and processing side effects as with DomainPermissionCreate.
+// The draft is removed once the domain permission has been created.
+//
+// If the instance requires a second admin to accept drafts, then
+// the draft cannot be accepted by the admin who created it.
+//
+// Return values for this function are the new (or existing) domain
+// permission, the ID of the admin action resulting from this call,
+// and/or an error if something goes wrong.
+func (p *Processor) DomainPermissionDraftAccept(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	id string,
+) (*apimodel.DomainPermission, string, gtserror.WithCode) {
+	draft, errWithCode := p.getDomainPermissionDraft(ctx, id)
+	if errWithCode != nil {
+		return nil, "", errWithCode
+	}
+
+	if config.GetInstanceDomainPermissionDraftsRequireSecondAdmin() &&
+		draft.CreatedByAccountID == adminAcct.ID {
+		err := errors.New("domain permission draft must be accepted by a different admin than the one who created it")
+		return nil, "", gtserror.NewErrorForbidden(err, err.Error())
+	}
+
+	domainPerm, actionID, errWithCode := p.DomainPermissionCreate(
+		ctx,
+		draft.PermissionType,
+		adminAcct,
+		draft.Domain,
+		*draft.Obfuscate,
+		draft.PublicComment,
+		draft.PrivateComment,
+		draft.SubscriptionID,
+	)
+	if errWithCode != nil {
+		return nil, actionID, errWithCode
+	}
+
+	// Permission is in place now, so the draft can go.
+	if err := p.state.DB.DeleteDomainPermissionDraft(ctx, draft.ID); err != nil {
+		err = gtserror.Newf("db error deleting domain permission draft: %w", err)
+		return nil, actionID, gtserror.NewErrorInternalError(err)
+	}
+
+	return domainPerm, actionID, nil
+}
+
+// DomainPermissionDraftRemove removes the domain permission
+// draft with the given ID, without putting it into effect.
+func (p *Processor) DomainPermissionDraftRemove(
+	ctx context.Context,
+	id string,
+) (*apimodel.DomainPermission, gtserror.WithCode) {
+	draft, errWithCode := p.getDomainPermissionDraft(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Prepare the draft to return, *before* the deletion goes through.
+	apiDraft, errWithCode := p.apiDomainPerm(ctx, draft, false)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteDomainPermissionDraft(ctx, draft.ID); err != nil {
+		err = gtserror.Newf("db error deleting domain permission draft: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiDraft, nil
+}
@@ -0,0 +1,171 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type DomainPermissionDraftTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *DomainPermissionDraftTestSuite) TestCreateAcceptDraft() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		domain    = "fossbros-anonymous.io"
+	)
+
+	draft, errWithCode := suite.adminProcessor.DomainPermissionDraftCreate(
+		ctx,
+		adminAcct,
+		gtsmodel.DomainPermissionBlock,
+		domain,
+		true,
+		"public comment",
+		"private comment",
+	)
+	suite.NoError(errWithCode)
+	suite.Equal("block", draft.PermissionType)
+	suite.True(draft.Obfuscate)
+
+	// Creating a draft should have no side effects.
+	blocked, err := suite.db.IsDomainBlocked(ctx, domain)
+	suite.NoError(err)
+	suite.False(blocked)
+
+	// Another block draft for the same domain should conflict.
+	_, errWithCode = suite.adminProcessor.DomainPermissionDraftCreate(
+		ctx,
+		adminAcct,
+		gtsmodel.DomainPermissionBlock,
+		domain,
+		false,
+		"",
+		"",
+	)
+	suite.Equal(http.StatusConflict, errWithCode.Code())
+
+	// Accept the draft.
+	domainPerm, actionID, errWithCode := suite.adminProcessor.DomainPermissionDraftAccept(ctx, adminAcct, draft.ID)
+	suite.NoError(errWithCode)
+	suite.Empty(domainPerm.PermissionType)
+	suite.Equal("public comment", domainPerm.PublicComment)
+	suite.Equal("private comment", domainPerm.PrivateComment)
+	suite.True(domainPerm.Obfuscate)
+	suite.NotEmpty(actionID)
+
+	// Let side effects finish.
+	if !testrig.WaitFor(func() bool {
+		return suite.adminProcessor.Actions().TotalRunning() == 0
+	}) {
+		suite.FailNow("timed out waiting for admin action(s) to finish")
+	}
+
+	// Domain should now be blocked.
+	blocked, err = suite.db.IsDomainBlocked(ctx, domain)
+	suite.NoError(err)
+	suite.True(blocked)
+
+	// And the draft should be gone.
+	_, errWithCode = suite.adminProcessor.DomainPermissionDraftGet(ctx, draft.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *DomainPermissionDraftTestSuite) TestAcceptDraftSecondAdmin() {
+	var (
+		ctx         = context.Background()
+		adminAcct   = suite.testAccounts["admin_account"]
+		secondAdmin = suite.testAccounts["local_account_1"]
+		domain      = "fossbros-anonymous.io"
+	)
+
+	config.SetInstanceDomainPermissionDraftsRequireSecondAdmin(true)
+
+	draft, errWithCode := suite.adminProcessor.DomainPermissionDraftCreate(
+		ctx,
+		adminAcct,
+		gtsmodel.DomainPermissionAllow,
+		domain,
+		false,
+		"",
+		"",
+	)
+	suite.NoError(errWithCode)
+
+	// Draft creator can't accept their own draft.
+	_, _, errWithCode = suite.adminProcessor.DomainPermissionDraftAccept(ctx, adminAcct, draft.ID)
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+
+	// But someone else can.
+	domainPerm, _, errWithCode := suite.adminProcessor.DomainPermissionDraftAccept(ctx, secondAdmin, draft.ID)
+	suite.NoError(errWithCode)
+	suite.Equal(secondAdmin.ID, domainPerm.CreatedBy)
+
+	blocked, err := suite.db.IsDomainBlocked(ctx, domain)
+	suite.NoError(err)
+	suite.False(blocked)
+
+	allow, err := suite.db.GetDomainAllow(ctx, domain)
+	suite.NoError(err)
+	suite.Equal(domainPerm.ID, allow.ID)
+}
+
+func (suite *DomainPermissionDraftTestSuite) TestRemoveDraft() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		domain    = "fossbros-anonymous.io"
+	)
+
+	draft, errWithCode := suite.adminProcessor.DomainPermissionDraftCreate(
+		ctx,
+		adminAcct,
+		gtsmodel.DomainPermissionBlock,
+		domain,
+		false,
+		"",
+		"",
+	)
+	suite.NoError(errWithCode)
+
+	removed, errWithCode := suite.adminProcessor.DomainPermissionDraftRemove(ctx, draft.ID)
+	suite.NoError(errWithCode)
+	suite.Equal(draft.ID, removed.ID)
+
+	drafts, errWithCode := suite.adminProcessor.DomainPermissionDraftsGet(ctx, gtsmodel.DomainPermissionUnknown, "")
+	suite.NoError(errWithCode)
+	suite.Empty(drafts)
+
+	// Removed draft should have had no effect.
+	blocked, err := suite.db.IsDomainBlocked(ctx, domain)
+	suite.NoError(err)
+	suite.False(blocked)
+}
+
+func TestDomainPermissionDraftTestSuite(t *testing.T) {
+	suite.Run(t, new(DomainPermissionDraftTestSuite))
+}
@@ -0,0 +1,182 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// EmailDomainBlockCreate creates a block on sign-ups from
+// the given email domain and all of its subdomains, marking
+// it as created by the given admin account. If a block
+// already exists for the domain, that block is returned.
+func (p *Processor) EmailDomainBlockCreate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	domain string,
+) (*apimodel.AdminEmailDomainBlock, gtserror.WithCode) {
+	// Normalize the domain, allowing
+	// admins to pass eg. "@example.org".
+	domain = strings.TrimPrefix(strings.TrimSpace(domain), "@")
+	if domain == "" {
+		const text = "no domain provided"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	domain, err := util.Punify(domain)
+	if err != nil || strings.ContainsAny(domain, "@/: ") {
+		const text = "invalid email domain"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	// Check if a block already exists for this domain.
+	block, err := p.state.DB.GetEmailDomainBlockByDomain(ctx, domain)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting email domain block %s: %w", domain, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if block == nil {
+		// No block exists yet, create it.
+		block = &gtsmodel.EmailDomainBlock{
+			ID:                 id.NewULID(),
+			Domain:             domain,
+			CreatedByAccountID: adminAcct.ID,
+			CreatedByAccount:   adminAcct,
+		}
+
+		if err := p.state.DB.PutEmailDomainBlock(ctx, block); err != nil {
+			err := gtserror.Newf("db error putting email domain block %s: %w", domain, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return toAPIEmailDomainBlock(block), nil
+}
+
+// EmailDomainBlockGet returns the email domain block with the given ID.
+func (p *Processor) EmailDomainBlockGet(
+	ctx context.Context,
+	id string,
+) (*apimodel.AdminEmailDomainBlock, gtserror.WithCode) {
+	block, errWithCode := p.getEmailDomainBlock(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return toAPIEmailDomainBlock(block), nil
+}
+
+// EmailDomainBlocksGet returns a page of email domain blocks, newest first.
+func (p *Processor) EmailDomainBlocksGet(
+	ctx context.Context,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	blocks, err := p.state.DB.GetEmailDomainBlocks(ctx, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting email domain blocks: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(blocks)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	// Get the lowest and highest
+	// ID values, used for paging.
+	lo := blocks[count-1].ID
+	hi := blocks[0].ID
+
+	items := make([]interface{}, 0, count)
+	for _, block := range blocks {
+		items = append(items, toAPIEmailDomainBlock(block))
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/admin/email_domain_blocks",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// EmailDomainBlockDelete removes the email domain block
+// with the given ID, returning the block that was removed.
+func (p *Processor) EmailDomainBlockDelete(
+	ctx context.Context,
+	id string,
+) (*apimodel.AdminEmailDomainBlock, gtserror.WithCode) {
+	block, errWithCode := p.getEmailDomainBlock(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteEmailDomainBlockByID(ctx, block.ID); err != nil {
+		err := gtserror.Newf("db error deleting email domain block %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return toAPIEmailDomainBlock(block), nil
+}
+
+// getEmailDomainBlock fetches the email domain block with
+// the given ID, returning 404 if it could not be found.
+func (p *Processor) getEmailDomainBlock(
+	ctx context.Context,
+	id string,
+) (*gtsmodel.EmailDomainBlock, gtserror.WithCode) {
+	block, err := p.state.DB.GetEmailDomainBlockByID(ctx, id)
+
+	switch {
+	// Successfully found.
+	case err == nil:
+		return block, nil
+
+	// Block does not exist with ID.
+	case errors.Is(err, db.ErrNoEntries):
+		const text = "email domain block not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+
+	// Any other error type.
+	default:
+		err := gtserror.Newf("db error getting email domain block %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+}
+
+// toAPIEmailDomainBlock performs a simple conversion
+// of database model EmailDomainBlock to API model.
+func toAPIEmailDomainBlock(block *gtsmodel.EmailDomainBlock) *apimodel.AdminEmailDomainBlock {
+	return &apimodel.AdminEmailDomainBlock{
+		ID:        block.ID,
+		Domain:    block.Domain,
+		CreatedAt: util.FormatISO8601(block.CreatedAt),
+		CreatedBy: block.CreatedByAccountID,
+	}
+}
@@ -342,6 +342,65 @@ func (p *Processor) EmojiCategoriesGet(
 	return apiCategories, nil
 }
 
+// EmojiCategoryUpdate updates the name and / or
+// picker position of the given emoji category.
+func (p *Processor) EmojiCategoryUpdate(
+	ctx context.Context,
+	id string,
+	form *apimodel.EmojiCategoryUpdateRequest,
+) (*apimodel.EmojiCategory, gtserror.WithCode) {
+	category, err := p.state.DB.GetEmojiCategory(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting emoji category %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if category == nil {
+		err := fmt.Errorf("emoji category %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	var columns []string
+
+	if form.Name != nil && *form.Name != category.Name {
+		// Ensure we don't already have a
+		// category with the desired name.
+		existing, err := p.state.DB.GetEmojiCategoryByName(ctx, *form.Name)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error checking for emoji category %s: %w", *form.Name, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if existing != nil {
+			err := fmt.Errorf("emoji category with name %s already exists", *form.Name)
+			return nil, gtserror.NewErrorConflict(err, err.Error())
+		}
+
+		category.Name = *form.Name
+		columns = append(columns, "name")
+	}
+
+	if form.Position != nil {
+		category.Position = *form.Position
+		columns = append(columns, "position")
+	}
+
+	if len(columns) != 0 {
+		if err := p.state.DB.UpdateEmojiCategory(ctx, category, columns...); err != nil {
+			err := gtserror.Newf("db error updating emoji category %s: %w", id, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	apiCategory, err := p.converter.EmojiCategoryToAPIEmojiCategory(ctx, category)
+	if err != nil {
+		err := gtserror.Newf("error converting emoji category to api emoji category: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiCategory, nil
+}
+
 /*
 	UTIL FUNCTIONS
 */
@@ -468,6 +527,13 @@ func (p *Processor) emojiUpdateCopy(
 		ai = &media.AdditionalEmojiInfo{
 			CategoryID: &category.ID,
 		}
+	} else if category == nil && targetEmoji.CategoryID != "" {
+		// No category supplied at all, so keep
+		// the copy in the same category as the
+		// remote emoji it was copied from.
+		ai = &media.AdditionalEmojiInfo{
+			CategoryID: &targetEmoji.CategoryID,
+		}
 	}
 
 	// Begin media processing.
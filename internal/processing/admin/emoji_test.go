@@ -62,6 +62,68 @@ func (suite *EmojiTestSuite) TestUpdateEmojiCategory() {
 	}
 }
 
+func (suite *EmojiTestSuite) TestUpdateEmojiCategoryPosition() {
+	ctx := context.Background()
+
+	// By default, categories are ordered by name.
+	categories, errWithCode := suite.adminProcessor.EmojiCategoriesGet(ctx)
+	suite.NoError(errWithCode)
+	suite.Equal("cute stuff", categories[0].Name)
+	suite.Equal("reactions", categories[1].Name)
+
+	// Move reactions to the front.
+	category, errWithCode := suite.adminProcessor.EmojiCategoryUpdate(ctx,
+		"01GGQ8V4993XK67B2JB396YFB7",
+		&apimodel.EmojiCategoryUpdateRequest{
+			Position: util.Ptr(-1),
+		},
+	)
+	suite.NoError(errWithCode)
+	suite.Equal("reactions", category.Name)
+	suite.Equal(-1, category.Position)
+
+	categories, errWithCode = suite.adminProcessor.EmojiCategoriesGet(ctx)
+	suite.NoError(errWithCode)
+	suite.Equal("reactions", categories[0].Name)
+	suite.Equal("cute stuff", categories[1].Name)
+
+	// Names must stay unique.
+	_, errWithCode = suite.adminProcessor.EmojiCategoryUpdate(ctx,
+		"01GGQ8V4993XK67B2JB396YFB7",
+		&apimodel.EmojiCategoryUpdateRequest{
+			Name: util.Ptr("cute stuff"),
+		},
+	)
+	suite.EqualError(errWithCode, "emoji category with name cute stuff already exists")
+}
+
+func (suite *EmojiTestSuite) TestCopyEmojiKeepsCategory() {
+	ctx := context.Background()
+	testEmoji := new(gtsmodel.Emoji)
+	*testEmoji = *suite.testEmojis["yell"]
+
+	// Put the remote emoji in a category.
+	testEmoji.CategoryID = "01GGQ989PTT9PMRN4FZ1WWK2B9"
+	if err := suite.db.UpdateEmoji(ctx, testEmoji, "category_id"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Copy without specifying a category.
+	emoji, errWithCode := suite.adminProcessor.EmojiUpdate(ctx,
+		testEmoji.ID,
+		&apimodel.EmojiUpdateRequest{
+			Type:      apimodel.EmojiUpdateCopy,
+			Shortcode: util.Ptr("yell_copy"),
+		},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Equal("yell_copy", emoji.Shortcode)
+	suite.Equal("cute stuff", emoji.Category)
+}
+
 func TestEmojiTestSuite(t *testing.T) {
 	suite.Run(t, new(EmojiTestSuite))
 }
@@ -0,0 +1,91 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/emojipack"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+// EmojiPackExport writes all local emojis, or only those in
+// the given category, to w as a Pleroma-compatible emoji pack.
+//
+// Emojis are gathered before anything is written, so if
+// an error is returned and nothing has been written to w
+// yet, the error can still be returned to the caller.
+func (p *Processor) EmojiPackExport(
+	ctx context.Context,
+	w io.Writer,
+	category string,
+) gtserror.WithCode {
+	packer := emojipack.New(p.state, p.mediaManager)
+
+	emojis, err := packer.LocalEmojis(ctx, category)
+	if err != nil {
+		if errors.Is(err, emojipack.ErrCategoryNotFound) {
+			return gtserror.NewErrorNotFound(err, err.Error())
+		}
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if err := packer.Export(ctx, w, emojis); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// EmojiPackImport imports the emoji pack archive provided in the form,
+// creating a local emoji for each emoji in the pack. Emojis in the pack
+// whose shortcode already exists are skipped or replaced as requested.
+func (p *Processor) EmojiPackImport(
+	ctx context.Context,
+	form *apimodel.EmojiPackImportRequest,
+) (*apimodel.EmojiPackImportResult, gtserror.WithCode) {
+	onConflict, err := emojipack.ParseOnConflict(form.OnConflict)
+	if err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	f, err := form.File.Open()
+	if err != nil {
+		err := gtserror.Newf("error opening emoji pack: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	defer f.Close()
+
+	packer := emojipack.New(p.state, p.mediaManager)
+	result, err := packer.Import(ctx, f, form.File.Size, form.CategoryName, onConflict)
+	if err != nil {
+		// Pack couldn't be read at all,
+		// most likely a bad archive.
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	return &apimodel.EmojiPackImportResult{
+		Imported: result.Imported,
+		Replaced: result.Replaced,
+		Skipped:  result.Skipped,
+		Failed:   result.Failed,
+	}, nil
+}
@@ -0,0 +1,195 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/emojipack"
+)
+
+type EmojiPackTestSuite struct {
+	AdminStandardTestSuite
+}
+
+// readPack reads the manifest and files
+// from the given emoji pack zip archive.
+func (suite *EmojiPackTestSuite) readPack(b []byte) (*emojipack.Manifest, map[string][]byte) {
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	files := make(map[string][]byte)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		data, err := io.ReadAll(rc)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		_ = rc.Close()
+
+		files[f.Name] = data
+	}
+
+	manifest := new(emojipack.Manifest)
+	if err := json.Unmarshal(files[emojipack.ManifestName], manifest); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return manifest, files
+}
+
+// writePack writes the given files into an emoji
+// pack zip archive, returned as a multipart file.
+func (suite *EmojiPackTestSuite) writePack(files map[string][]byte) *multipart.FileHeader {
+	var zb bytes.Buffer
+	zw := zip.NewWriter(&zb)
+	for name, data := range files {
+		fw, err := zw.Create(name)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		if _, err := fw.Write(data); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+	if err := zw.Close(); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	var fb bytes.Buffer
+	mw := multipart.NewWriter(&fb)
+	fw, err := mw.CreateFormFile("file", "pack.zip")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if _, err := fw.Write(zb.Bytes()); err != nil {
+		suite.FailNow(err.Error())
+	}
+	if err := mw.Close(); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	form, err := multipart.NewReader(&fb, mw.Boundary()).ReadForm(1 << 20)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return form.File["file"][0]
+}
+
+func (suite *EmojiPackTestSuite) TestEmojiPackExport() {
+	var b bytes.Buffer
+	if errWithCode := suite.adminProcessor.EmojiPackExport(context.Background(), &b, ""); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	manifest, files := suite.readPack(b.Bytes())
+
+	// Only the local emoji should be exported.
+	suite.Equal(map[string]string{"rainbow": "rainbow.png"}, manifest.Files)
+	suite.Equal(1, manifest.FilesCount)
+	suite.NotEmpty(files["rainbow.png"])
+}
+
+func (suite *EmojiPackTestSuite) TestEmojiPackExportUnknownCategory() {
+	var b bytes.Buffer
+	errWithCode := suite.adminProcessor.EmojiPackExport(context.Background(), &b, "does not exist")
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+	suite.Zero(b.Len())
+}
+
+func (suite *EmojiPackTestSuite) TestEmojiPackImport() {
+	ctx := context.Background()
+
+	var b bytes.Buffer
+	if errWithCode := suite.adminProcessor.EmojiPackExport(ctx, &b, ""); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	_, files := suite.readPack(b.Bytes())
+
+	// Build a new pack containing the existing emoji,
+	// a copy of it under a new shortcode, and an emoji
+	// whose image is missing from the archive.
+	manifest, err := json.Marshal(emojipack.Manifest{
+		Files: map[string]string{
+			"rainbow":      "rainbow.png",
+			"rainbow_copy": "rainbow.png",
+			"missing":      "missing.png",
+		},
+		FilesCount: 3,
+	})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	pack := suite.writePack(map[string][]byte{
+		"mypack/" + emojipack.ManifestName: manifest,
+		"mypack/rainbow.png":               files["rainbow.png"],
+	})
+
+	result, errWithCode := suite.adminProcessor.EmojiPackImport(ctx, &apimodel.EmojiPackImportRequest{
+		File:         pack,
+		CategoryName: "imported",
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Equal([]string{"rainbow_copy"}, result.Imported)
+	suite.Empty(result.Replaced)
+	suite.Equal([]string{"rainbow"}, result.Skipped)
+	suite.Len(result.Failed, 1)
+	suite.Contains(result.Failed, "missing")
+
+	// The copy should now exist locally, in the requested category.
+	emoji, err := suite.db.GetEmojiByShortcodeDomain(ctx, "rainbow_copy", "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotNil(emoji.Category)
+	suite.Equal("imported", emoji.Category.Name)
+}
+
+func (suite *EmojiPackTestSuite) TestEmojiPackImportBadConflictValue() {
+	_, errWithCode := suite.adminProcessor.EmojiPackImport(context.Background(), &apimodel.EmojiPackImportRequest{
+		File:       suite.writePack(map[string][]byte{}),
+		OnConflict: "explode",
+	})
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func TestEmojiPackTestSuite(t *testing.T) {
+	suite.Run(t, new(EmojiPackTestSuite))
+}
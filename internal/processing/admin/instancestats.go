@@ -0,0 +1,94 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+const (
+	// instanceStatsDays is the number of days
+	// (including today) of local statuses to
+	// include in per-day status counts.
+	instanceStatsDays = 30
+
+	// instanceStatsTTL is how long aggregated
+	// stats are served before being re-aggregated
+	// from the database on the next request.
+	instanceStatsTTL = 5 * time.Minute
+)
+
+// instanceStats caches the most
+// recently aggregated instance stats.
+type instanceStats struct {
+	stats *gtsmodel.InstanceStats
+	m     sync.Mutex
+}
+
+// InstanceStatsGet returns operational statistics about this instance.
+// Database stats are aggregated at most once per instanceStatsTTL, while
+// worker queue depths are always current.
+func (p *Processor) InstanceStatsGet(ctx context.Context) (*apimodel.AdminInstanceStats, gtserror.WithCode) {
+	stats, err := p.getInstanceStats(ctx)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiStats := p.converter.InstanceStatsToAdminAPIInstanceStats(stats)
+	apiStats.QueueDepths = map[string]int{
+		"client":      p.state.Workers.Client.Queue.Len(),
+		"delivery":    p.state.Workers.Delivery.Queue.Len(),
+		"dereference": p.state.Workers.Dereference.Queue.Len(),
+		"federator":   p.state.Workers.Federator.Queue.Len(),
+		"media":       p.state.Workers.Media.Queue.Len(),
+	}
+
+	return apiStats, nil
+}
+
+// getInstanceStats returns cached instance stats
+// if they're fresh enough, else re-aggregates them.
+func (p *Processor) getInstanceStats(ctx context.Context) (*gtsmodel.InstanceStats, error) {
+	p.stats.m.Lock()
+	defer p.stats.m.Unlock()
+
+	now := time.Now()
+	if p.stats.stats != nil &&
+		now.Sub(p.stats.stats.ComputedAt) < instanceStatsTTL {
+		return p.stats.stats, nil
+	}
+
+	// Count statuses from the start
+	// of the first day in the period.
+	today := now.UTC().Truncate(24 * time.Hour)
+	since := today.AddDate(0, 0, -(instanceStatsDays - 1))
+
+	stats, err := p.state.DB.GetInstanceStats(ctx, since)
+	if err != nil {
+		return nil, gtserror.Newf("db error getting instance stats: %w", err)
+	}
+
+	p.stats.stats = stats
+	return stats, nil
+}
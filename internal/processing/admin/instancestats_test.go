@@ -0,0 +1,57 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type InstanceStatsTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *InstanceStatsTestSuite) TestInstanceStatsGet() {
+	ctx := context.Background()
+
+	stats, errWithCode := suite.adminProcessor.InstanceStatsGet(ctx)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Every day in the period should be present.
+	suite.Len(stats.StatusesPerDay, 30)
+	suite.NotZero(stats.Users.Active)
+	suite.NotZero(stats.Media.LocalCount)
+	suite.Equal(2, stats.Peers.Total)
+	suite.Contains(stats.QueueDepths, "delivery")
+
+	// Stats should be served from
+	// cache on the next request.
+	again, errWithCode := suite.adminProcessor.InstanceStatsGet(ctx)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(stats.ComputedAt, again.ComputedAt)
+}
+
+func TestInstanceStatsTestSuite(t *testing.T) {
+	suite.Run(t, new(InstanceStatsTestSuite))
+}
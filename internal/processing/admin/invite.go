@@ -0,0 +1,98 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// InvitesGet returns invites created by the given account,
+// or by all accounts if accountID is empty. If outstanding
+// is true, only invites that can still be used are returned.
+func (p *Processor) InvitesGet(
+	ctx context.Context,
+	accountID string,
+	outstanding bool,
+) ([]*apimodel.Invite, gtserror.WithCode) {
+	invites, err := p.state.DB.GetInvites(ctx, accountID, outstanding)
+	if err != nil {
+		err := gtserror.Newf("db error getting invites: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiInvites := make([]*apimodel.Invite, 0, len(invites))
+	for _, invite := range invites {
+		apiInvite, errWithCode := p.apiInvite(ctx, invite)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		apiInvites = append(apiInvites, apiInvite)
+	}
+
+	return apiInvites, nil
+}
+
+// InviteRevoke revokes the invite with the given
+// ID, so that it can no longer be used to sign up.
+func (p *Processor) InviteRevoke(
+	ctx context.Context,
+	inviteID string,
+) (*apimodel.Invite, gtserror.WithCode) {
+	invite, err := p.state.DB.GetInviteByID(ctx, inviteID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting invite %s: %w", inviteID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if invite == nil {
+		err := fmt.Errorf("invite %s not found", inviteID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if invite.RevokedAt.IsZero() {
+		invite.RevokedAt = time.Now()
+		if err := p.state.DB.UpdateInvite(ctx, invite, "revoked_at"); err != nil {
+			err := gtserror.Newf("db error updating invite %s: %w", inviteID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return p.apiInvite(ctx, invite)
+}
+
+func (p *Processor) apiInvite(
+	ctx context.Context,
+	invite *gtsmodel.Invite,
+) (*apimodel.Invite, gtserror.WithCode) {
+	apiInvite, err := p.converter.InviteToAdminAPIInvite(ctx, invite)
+	if err != nil {
+		err := gtserror.Newf("error converting invite to api: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiInvite, nil
+}
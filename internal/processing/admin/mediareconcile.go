@@ -0,0 +1,124 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// mediaReconciliation holds the most
+// recent (or running) reconciliation.
+type mediaReconciliation struct {
+	rec     *apimodel.AdminMediaReconciliation
+	running bool
+	m       sync.Mutex
+}
+
+// MediaReconcile starts a non-blocking reconciliation of media storage with the
+// database, finding files with no attachment / emoji, and attachments / emojis
+// with missing files. Orphans are only removed if deleteOrphans is true.
+//
+// Returns a 409 if a reconciliation is already running.
+func (p *Processor) MediaReconcile(ctx context.Context, deleteOrphans bool) (*apimodel.AdminMediaReconciliation, gtserror.WithCode) {
+	p.reconciliation.m.Lock()
+	defer p.reconciliation.m.Unlock()
+
+	if p.reconciliation.running {
+		const text = "a media reconciliation is already running"
+		return nil, gtserror.NewErrorConflict(errors.New(text), text)
+	}
+
+	rec := &apimodel.AdminMediaReconciliation{
+		StartedAt: util.FormatISO8601(time.Now()),
+		Delete:    deleteOrphans,
+	}
+
+	p.reconciliation.rec = rec
+	p.reconciliation.running = true
+
+	// Queue background task performing reconciliation,
+	// running on worker context so it's cancelled on stop.
+	p.state.Workers.Media.Queue.Push(func(ctx context.Context) {
+		if !deleteOrphans {
+			ctx = gtscontext.SetDryRun(ctx)
+		}
+
+		log.Info(ctx, "start")
+		res, err := p.cleaner.Reconcile(ctx)
+
+		p.reconciliation.m.Lock()
+		defer p.reconciliation.m.Unlock()
+
+		if err != nil {
+			log.Errorf(ctx, "error reconciling media: %v", err)
+			rec.Error = err.Error()
+		} else {
+			log.Infof(ctx, "orphaned files: %d, missing attachments: %d, missing emojis: %d",
+				len(res.OrphanedFiles), len(res.MissingAttachments), len(res.MissingEmojis))
+			rec.OrphanedFiles = res.OrphanedFiles
+			rec.MissingAttachments = res.MissingAttachments
+			rec.MissingEmojis = res.MissingEmojis
+		}
+
+		rec.FinishedAt = util.FormatISO8601(time.Now())
+		p.reconciliation.running = false
+	})
+
+	return copyReconciliation(rec), nil
+}
+
+// MediaReconcileGet returns the outcome of the
+// most recent media reconciliation, which may
+// still be running, or a 404 if there's none.
+func (p *Processor) MediaReconcileGet(ctx context.Context) (*apimodel.AdminMediaReconciliation, gtserror.WithCode) {
+	p.reconciliation.m.Lock()
+	defer p.reconciliation.m.Unlock()
+
+	if p.reconciliation.rec == nil {
+		const text = "no media reconciliation has been run"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	return copyReconciliation(p.reconciliation.rec), nil
+}
+
+// copyReconciliation returns a copy of rec, safe to
+// serialize outside of the reconciliation mutex, with
+// nil slices replaced so they serialize as empty lists.
+func copyReconciliation(rec *apimodel.AdminMediaReconciliation) *apimodel.AdminMediaReconciliation {
+	cpy := *rec
+	if cpy.OrphanedFiles == nil {
+		cpy.OrphanedFiles = []string{}
+	}
+	if cpy.MissingAttachments == nil {
+		cpy.MissingAttachments = []string{}
+	}
+	if cpy.MissingEmojis == nil {
+		cpy.MissingEmojis = []string{}
+	}
+	return &cpy
+}
@@ -0,0 +1,180 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+// ModerationNotesGet returns moderation notes on the given
+// account and/or report, oldest first. At least one of
+// accountID or reportID must be set.
+func (p *Processor) ModerationNotesGet(
+	ctx context.Context,
+	accountID string,
+	reportID string,
+) ([]*apimodel.ModerationNote, gtserror.WithCode) {
+	if accountID == "" && reportID == "" {
+		const text = "at least one of account_id or report_id must be set"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	notes, err := p.state.DB.GetModerationNotes(ctx, accountID, reportID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting moderation notes: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiNotes := make([]*apimodel.ModerationNote, 0, len(notes))
+	for _, note := range notes {
+		apiNote, errWithCode := p.apiModerationNote(ctx, note)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		apiNotes = append(apiNotes, apiNote)
+	}
+
+	return apiNotes, nil
+}
+
+// ModerationNoteCreate leaves a new moderation note, authored
+// by the given admin account, on an account or a report.
+func (p *Processor) ModerationNoteCreate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	form *apimodel.ModerationNoteCreateRequest,
+) (*apimodel.ModerationNote, gtserror.WithCode) {
+	if (form.AccountID == "") == (form.ReportID == "") {
+		const text = "exactly one of account_id or report_id must be set"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if err := validate.ModerationNote(form.Content); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	note := &gtsmodel.ModerationNote{
+		ID:              id.NewULID(),
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+		AuthorAccountID: adminAcct.ID,
+		AuthorAccount:   adminAcct,
+		Content:         form.Content,
+	}
+
+	if form.AccountID != "" {
+		account, err := p.state.DB.GetAccountByID(gtscontext.SetBarebones(ctx), form.AccountID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting account %s: %w", form.AccountID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if account == nil {
+			err := fmt.Errorf("account %s not found", form.AccountID)
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+
+		note.TargetAccountID = account.ID
+		note.TargetAccount = account
+	}
+
+	if form.ReportID != "" {
+		report, err := p.state.DB.GetReportByID(gtscontext.SetBarebones(ctx), form.ReportID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting report %s: %w", form.ReportID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if report == nil {
+			err := fmt.Errorf("report %s not found", form.ReportID)
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+
+		note.ReportID = report.ID
+	}
+
+	if err := p.state.DB.PutModerationNote(ctx, note); err != nil {
+		err := gtserror.Newf("db error putting moderation note: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiModerationNote(ctx, note)
+}
+
+// ModerationNoteDelete deletes the moderation note with
+// the given ID. Only the author of a note may delete it.
+func (p *Processor) ModerationNoteDelete(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	noteID string,
+) (*apimodel.ModerationNote, gtserror.WithCode) {
+	note, err := p.state.DB.GetModerationNoteByID(ctx, noteID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting moderation note %s: %w", noteID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if note == nil {
+		err := fmt.Errorf("moderation note %s not found", noteID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if note.AuthorAccountID != adminAcct.ID {
+		err := fmt.Errorf("moderation note %s was not written by account %s", noteID, adminAcct.ID)
+		return nil, gtserror.NewErrorForbidden(err, "only the author of a moderation note can delete it")
+	}
+
+	// Convert before deletion so we
+	// can return the deleted note.
+	apiNote, errWithCode := p.apiModerationNote(ctx, note)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteModerationNoteByID(ctx, note.ID); err != nil {
+		err := gtserror.Newf("db error deleting moderation note %s: %w", noteID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiNote, nil
+}
+
+func (p *Processor) apiModerationNote(
+	ctx context.Context,
+	note *gtsmodel.ModerationNote,
+) (*apimodel.ModerationNote, gtserror.WithCode) {
+	apiNote, err := p.converter.ModerationNoteToAPIModerationNote(ctx, note)
+	if err != nil {
+		err := gtserror.Newf("error converting moderation note to api: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiNote, nil
+}
@@ -0,0 +1,102 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type ModerationNoteTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *ModerationNoteTestSuite) TestModerationNoteCreateGetDelete() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		target    = suite.testAccounts["remote_account_1"]
+		reportID  = "01GP3AWY4CRDVRNZKW0TEAMB5R"
+	)
+
+	accountNote, errWithCode := suite.adminProcessor.ModerationNoteCreate(ctx, adminAcct, &apimodel.ModerationNoteCreateRequest{
+		AccountID: target.ID,
+		Content:   "Reported before for spam, let off with a warning.",
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(target.ID, accountNote.AccountID)
+	suite.Empty(accountNote.ReportID)
+	suite.Equal(adminAcct.ID, accountNote.Author.ID)
+
+	reportNote, errWithCode := suite.adminProcessor.ModerationNoteCreate(ctx, adminAcct, &apimodel.ModerationNoteCreateRequest{
+		ReportID: reportID,
+		Content:  "Looking into this one.",
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(reportID, reportNote.ReportID)
+
+	// Only the account note should be on the account.
+	notes, errWithCode := suite.adminProcessor.ModerationNotesGet(ctx, target.ID, "")
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	if suite.Len(notes, 1) {
+		suite.Equal(accountNote.ID, notes[0].ID)
+		suite.Equal("Reported before for spam, let off with a warning.", notes[0].Content)
+	}
+
+	// Someone else can't delete the note...
+	_, errWithCode = suite.adminProcessor.ModerationNoteDelete(ctx, suite.testAccounts["local_account_1"], accountNote.ID)
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+
+	// ...but the author can.
+	if _, errWithCode := suite.adminProcessor.ModerationNoteDelete(ctx, adminAcct, accountNote.ID); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	notes, errWithCode = suite.adminProcessor.ModerationNotesGet(ctx, target.ID, "")
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Empty(notes)
+}
+
+func (suite *ModerationNoteTestSuite) TestModerationNoteCreateBothTargets() {
+	_, errWithCode := suite.adminProcessor.ModerationNoteCreate(
+		context.Background(),
+		suite.testAccounts["admin_account"],
+		&apimodel.ModerationNoteCreateRequest{
+			AccountID: suite.testAccounts["remote_account_1"].ID,
+			ReportID:  "01GP3AWY4CRDVRNZKW0TEAMB5R",
+			Content:   "Which one is this on?",
+		},
+	)
+	suite.EqualError(errWithCode, "exactly one of account_id or report_id must be set")
+}
+
+func TestModerationNoteTestSuite(t *testing.T) {
+	suite.Run(t, new(ModerationNoteTestSuite))
+}
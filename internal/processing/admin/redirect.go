@@ -0,0 +1,235 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// reservedRedirectPrefixes contains first path segments
+// that are served by GoToSocial itself, and so may not
+// be used as the source path of an admin-defined redirect.
+var reservedRedirectPrefixes = []string{
+	".well-known",
+	"api",
+	"assets",
+	"auth",
+	"fileserver",
+	"livez",
+	"metrics",
+	"nodeinfo",
+	"oauth",
+	"readyz",
+	"settings",
+	"statuses",
+	"tags",
+	"users",
+}
+
+// RedirectCreate creates a redirect from the given path
+// on this instance to the given target URL, marking it
+// as created by the given admin account.
+func (p *Processor) RedirectCreate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	form *apimodel.AdminRedirectCreateRequest,
+) (*apimodel.AdminRedirect, gtserror.WithCode) {
+	redirectPath, err := validateRedirectPath(form.Path)
+	if err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	targetURL, err := validateRedirectTarget(form.TargetURL)
+	if err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Check if a redirect already exists for this path.
+	existing, err := p.state.DB.GetRedirectByPath(ctx, redirectPath)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting redirect %s: %w", redirectPath, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if existing != nil {
+		text := fmt.Sprintf("a redirect already exists for path %s", redirectPath)
+		return nil, gtserror.NewErrorConflict(errors.New(text), text)
+	}
+
+	redirect := &gtsmodel.Redirect{
+		ID:                 id.NewULID(),
+		Path:               redirectPath,
+		TargetURL:          targetURL,
+		CreatedByAccountID: adminAcct.ID,
+		CreatedByAccount:   adminAcct,
+	}
+
+	if err := p.state.DB.PutRedirect(ctx, redirect); err != nil {
+		err := gtserror.Newf("db error putting redirect %s: %w", redirectPath, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return toAPIRedirect(redirect), nil
+}
+
+// RedirectGet returns the redirect with the given ID.
+func (p *Processor) RedirectGet(
+	ctx context.Context,
+	id string,
+) (*apimodel.AdminRedirect, gtserror.WithCode) {
+	redirect, errWithCode := p.getRedirect(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return toAPIRedirect(redirect), nil
+}
+
+// RedirectsGet returns all redirects, sorted by path.
+func (p *Processor) RedirectsGet(
+	ctx context.Context,
+) ([]*apimodel.AdminRedirect, gtserror.WithCode) {
+	redirects, err := p.state.DB.GetRedirects(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting redirects: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiRedirects := make([]*apimodel.AdminRedirect, 0, len(redirects))
+	for _, redirect := range redirects {
+		apiRedirects = append(apiRedirects, toAPIRedirect(redirect))
+	}
+
+	return apiRedirects, nil
+}
+
+// RedirectDelete removes the redirect with the
+// given ID, returning the redirect that was removed.
+func (p *Processor) RedirectDelete(
+	ctx context.Context,
+	id string,
+) (*apimodel.AdminRedirect, gtserror.WithCode) {
+	redirect, errWithCode := p.getRedirect(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteRedirectByID(ctx, redirect.ID); err != nil {
+		err := gtserror.Newf("db error deleting redirect %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return toAPIRedirect(redirect), nil
+}
+
+// getRedirect fetches the redirect with the
+// given ID, returning 404 if it could not be found.
+func (p *Processor) getRedirect(
+	ctx context.Context,
+	id string,
+) (*gtsmodel.Redirect, gtserror.WithCode) {
+	redirect, err := p.state.DB.GetRedirectByID(ctx, id)
+
+	switch {
+	// Successfully found.
+	case err == nil:
+		return redirect, nil
+
+	// Redirect does not exist with ID.
+	case errors.Is(err, db.ErrNoEntries):
+		const text = "redirect not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+
+	// Any other error type.
+	default:
+		err := gtserror.Newf("db error getting redirect %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+}
+
+// validateRedirectPath checks that the given path is
+// a clean, absolute path that doesn't collide with any
+// path served by GoToSocial itself, returning it trimmed.
+func validateRedirectPath(p string) (string, error) {
+	p = strings.TrimSpace(p)
+	switch {
+	case p == "":
+		return "", errors.New("no path provided")
+
+	case !strings.HasPrefix(p, "/") || p == "/":
+		return "", errors.New("path must start with / and not be the root path")
+
+	case strings.ContainsAny(p, "?#:*% \t\n") || path.Clean(p) != p:
+		return "", errors.New("path must be a clean path without query, fragment, or wildcards")
+	}
+
+	// Check first segment against reserved ones.
+	first, _, _ := strings.Cut(p[1:], "/")
+	if strings.HasPrefix(first, "@") {
+		return "", fmt.Errorf("path %s is reserved", p)
+	}
+
+	for _, reserved := range reservedRedirectPrefixes {
+		if strings.EqualFold(first, reserved) {
+			return "", fmt.Errorf("path %s is reserved", p)
+		}
+	}
+
+	return p, nil
+}
+
+// validateRedirectTarget checks that the given target
+// is an absolute http(s) URL, returning it trimmed.
+func validateRedirectTarget(target string) (string, error) {
+	target = strings.TrimSpace(target)
+	if target == "" {
+		return "", errors.New("no target_url provided")
+	}
+
+	u, err := url.Parse(target)
+	if err != nil || u.Host == "" ||
+		(u.Scheme != "https" && u.Scheme != "http") {
+		return "", errors.New("target_url must be an absolute http or https URL")
+	}
+
+	return target, nil
+}
+
+// toAPIRedirect performs a simple conversion
+// of database model Redirect to API model.
+func toAPIRedirect(redirect *gtsmodel.Redirect) *apimodel.AdminRedirect {
+	return &apimodel.AdminRedirect{
+		ID:        redirect.ID,
+		Path:      redirect.Path,
+		TargetURL: redirect.TargetURL,
+		CreatedAt: util.FormatISO8601(redirect.CreatedAt),
+		CreatedBy: redirect.CreatedByAccountID,
+	}
+}
@@ -0,0 +1,120 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+)
+
+type RedirectTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *RedirectTestSuite) TestRedirectCreateGetDelete() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+	)
+
+	redirect, errWithCode := suite.adminProcessor.RedirectCreate(ctx, adminAcct, &apimodel.AdminRedirectCreateRequest{
+		Path:      " /donate ",
+		TargetURL: "https://example.org/donate",
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("/donate", redirect.Path)
+	suite.Equal("https://example.org/donate", redirect.TargetURL)
+	suite.Equal(adminAcct.ID, redirect.CreatedBy)
+
+	// A second redirect for the same path should conflict.
+	_, errWithCode = suite.adminProcessor.RedirectCreate(ctx, adminAcct, &apimodel.AdminRedirectCreateRequest{
+		Path:      "/donate",
+		TargetURL: "https://example.org/elsewhere",
+	})
+	suite.Equal(http.StatusConflict, errWithCode.Code())
+
+	redirects, errWithCode := suite.adminProcessor.RedirectsGet(ctx)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	if suite.Len(redirects, 1) {
+		suite.Equal(redirect.ID, redirects[0].ID)
+	}
+
+	// The redirect should be served by path.
+	byPath, err := suite.state.DB.GetRedirectByPath(ctx, "/donate")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("https://example.org/donate", byPath.TargetURL)
+
+	if _, errWithCode := suite.adminProcessor.RedirectDelete(ctx, redirect.ID); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	_, errWithCode = suite.adminProcessor.RedirectGet(ctx, redirect.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	// And no longer served once deleted.
+	_, err = suite.state.DB.GetRedirectByPath(ctx, "/donate")
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *RedirectTestSuite) TestRedirectCreateInvalid() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+	)
+
+	for _, test := range []struct {
+		path      string
+		targetURL string
+	}{
+		{path: "", targetURL: "https://example.org"},
+		{path: "/", targetURL: "https://example.org"},
+		{path: "donate", targetURL: "https://example.org"},
+		{path: "/donate/", targetURL: "https://example.org"},
+		{path: "/../donate", targetURL: "https://example.org"},
+		{path: "/donate?via=profile", targetURL: "https://example.org"},
+		{path: "/api/v1/donate", targetURL: "https://example.org"},
+		{path: "/users/admin", targetURL: "https://example.org"},
+		{path: "/@admin", targetURL: "https://example.org"},
+		{path: "/donate", targetURL: ""},
+		{path: "/donate", targetURL: "/elsewhere"},
+		{path: "/donate", targetURL: "javascript:alert(1)"},
+	} {
+		_, errWithCode := suite.adminProcessor.RedirectCreate(ctx, adminAcct, &apimodel.AdminRedirectCreateRequest{
+			Path:      test.path,
+			TargetURL: test.targetURL,
+		})
+		if suite.NotNil(errWithCode, "path %q target %q", test.path, test.targetURL) {
+			suite.Equal(http.StatusBadRequest, errWithCode.Code())
+		}
+	}
+}
+
+func TestRedirectTestSuite(t *testing.T) {
+	suite.Run(t, new(RedirectTestSuite))
+}
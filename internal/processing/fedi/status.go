@@ -26,10 +26,12 @@ import (
 
 	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
 )
 
 // StatusGet handles the getting of a fedi/activitypub representation of a local status.
@@ -43,7 +45,27 @@ func (p *Processor) StatusGet(ctx context.Context, requestedUser string, statusI
 
 	status, err := p.state.DB.GetStatusByID(ctx, statusID)
 	if err != nil {
-		return nil, gtserror.NewErrorNotFound(err)
+		if !errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+
+		// Status doesn't exist, check whether we kept a
+		// tombstone of it being deleted, in which case
+		// let the requester know it's gone for good.
+		uri := uris.GenerateURIsForAccount(requestedUser).StatusesURI + "/" + statusID
+		gone, err := p.state.DB.TombstoneExistsWithURI(ctx, uri)
+		if err != nil {
+			err := gtserror.Newf("error checking tombstone for %s: %w", uri, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if gone {
+			const text = "status has been deleted"
+			return nil, gtserror.NewErrorGone(errors.New(text))
+		}
+
+		const text = "status not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text))
 	}
 
 	if status.AccountID != receiver.ID {
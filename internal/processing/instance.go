@@ -19,8 +19,11 @@ package processing
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"sort"
+	"strings"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
@@ -136,6 +139,21 @@ func (p *Processor) InstanceGetRules(ctx context.Context) ([]apimodel.InstanceRu
 	return p.converter.InstanceRulesToAPIRules(i.Rules), nil
 }
 
+// InstanceRedirectGet returns the target URL of the admin-defined
+// redirect for the given path, or a 404 if no such redirect exists.
+func (p *Processor) InstanceRedirectGet(ctx context.Context, path string) (string, gtserror.WithCode) {
+	redirect, err := p.state.DB.GetRedirectByPath(ctx, path)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			return "", gtserror.NewErrorNotFound(err)
+		}
+		err := gtserror.Newf("db error getting redirect for %s: %w", path, err)
+		return "", gtserror.NewErrorInternalError(err)
+	}
+
+	return redirect.TargetURL, nil
+}
+
 func (p *Processor) InstancePatch(ctx context.Context, form *apimodel.InstanceSettingsUpdateRequest) (*apimodel.InstanceV1, gtserror.WithCode) {
 	// Fetch this instance from the db for processing.
 	instance, err := p.getThisInstance(ctx)
@@ -242,6 +260,55 @@ func (p *Processor) InstancePatch(ctx context.Context, form *apimodel.InstanceSe
 		columns = append(columns, []string{"terms", "terms_text"}...)
 	}
 
+	// Validate & update site privacy
+	// policy if set on the form.
+	if form.PrivacyPolicy != nil {
+		privacyPolicy := *form.PrivacyPolicy
+		if err := validate.SitePrivacyPolicy(privacyPolicy); err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		// Parse privacy policy as Markdown,
+		// keep the raw version for later editing.
+		instance.PrivacyPolicyText = privacyPolicy
+		instance.PrivacyPolicy = p.formatter.FromMarkdown(ctx, p.parseMentionFunc, "", "", privacyPolicy).HTML
+		columns = append(columns, []string{"privacy_policy", "privacy_policy_text"}...)
+	}
+
+	// Validate & update landing page
+	// blocks if set on the form.
+	//
+	// Empty string resets to default.
+	if form.LandingPageBlocks != nil {
+		blocks := splitFormList(*form.LandingPageBlocks)
+		if err := validate.LandingPageBlocks(blocks); err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		instance.LandingPageBlocks = blocks
+		columns = append(columns, "landing_page_blocks")
+	}
+
+	// Validate & update featured
+	// accounts if set on the form.
+	//
+	// Empty string unsets featured accounts.
+	if form.FeaturedAccounts != nil {
+		usernames := splitFormList(*form.FeaturedAccounts)
+		if err := validate.FeaturedAccounts(usernames); err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		featuredAccountIDs, err := p.featuredAccountIDsForUsernames(ctx, usernames)
+		if err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		instance.FeaturedAccountIDs = featuredAccountIDs
+		instance.FeaturedAccounts = nil
+		columns = append(columns, "featured_accounts")
+	}
+
 	var updateInstanceAccount bool
 
 	if form.Avatar != nil && form.Avatar.Size != 0 {
@@ -351,6 +418,56 @@ func (p *Processor) contactAccountIDForUsername(ctx context.Context, username st
 	return contactAccount.ID, nil
 }
 
+func (p *Processor) featuredAccountIDsForUsernames(ctx context.Context, usernames []string) ([]string, error) {
+	if len(usernames) == 0 {
+		// Easy: unset
+		// featured accounts.
+		return nil, nil
+	}
+
+	ids := make([]string, 0, len(usernames))
+	for _, username := range usernames {
+		// Make sure local account with the given username exists in the db.
+		account, err := p.state.DB.GetAccountByUsernameDomain(ctx, username, "")
+		if err != nil {
+			err = fmt.Errorf("db error getting selected featured account with username %s: %w", username, err)
+			return nil, err
+		}
+
+		if account.IsInstance() {
+			err := fmt.Errorf("selected featured account %s is the instance account", username)
+			return nil, err
+		}
+
+		if !account.SuspendedAt.IsZero() {
+			err := fmt.Errorf("selected featured account %s is suspended", username)
+			return nil, err
+		}
+
+		if slices.Contains(ids, account.ID) {
+			err := fmt.Errorf("selected featured account %s was given more than once", username)
+			return nil, err
+		}
+
+		ids = append(ids, account.ID)
+	}
+
+	return ids, nil
+}
+
+// splitFormList splits the given comma-separated
+// form value into its trimmed, non-empty parts.
+func splitFormList(value string) []string {
+	var parts []string
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
 func obfuscate(domain string) string {
 	obfuscated := make([]rune, len(domain))
 	for i, r := range domain {
@@ -37,6 +37,15 @@ func (p *Processor) Update(ctx context.Context, markers []*gtsmodel.Marker) (*ap
 			}
 			return nil, gtserror.NewErrorInternalError(err)
 		}
+
+		if marker.Name == gtsmodel.MarkerNameNotifications && marker.LastReadID != "" {
+			// Notifications up to the marker have now been seen,
+			// so mark them as read for the benefit of the cleaner.
+			if err := p.state.DB.MarkNotificationsRead(ctx, marker.AccountID, marker.LastReadID); err != nil {
+				err := gtserror.Newf("db error marking notifications read: %w", err)
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+		}
 	}
 
 	apiMarker, err := p.converter.MarkersToAPIMarker(ctx, markers)
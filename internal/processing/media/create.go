@@ -19,12 +19,16 @@ package media
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/iotools"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 )
 
@@ -42,14 +46,17 @@ func (p *Processor) Create(ctx context.Context, account *gtsmodel.Account, form
 	}
 
 	// process the media attachment and load it immediately
-	media := p.mediaManager.PreProcessMedia(data, account.ID, &media.AdditionalMediaInfo{
+	processing := p.mediaManager.PreProcessMedia(data, account.ID, &media.AdditionalMediaInfo{
 		Description: &form.Description,
 		FocusX:      &focusX,
 		FocusY:      &focusY,
 	})
 
-	attachment, err := media.LoadAttachment(ctx)
-	if err != nil {
+	attachment, err := processing.LoadAttachment(ctx)
+	if errors.Is(err, media.ErrInfected) {
+		const text = "uploaded file was rejected by virus scan"
+		return nil, gtserror.NewErrorUnprocessableEntity(err, text)
+	} else if err != nil {
 		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
 	} else if attachment.Type == gtsmodel.FileTypeUnknown {
 		err = gtserror.Newf("could not process uploaded file with extension %s", attachment.File.ContentType)
@@ -64,3 +71,131 @@ func (p *Processor) Create(ctx context.Context, account *gtsmodel.Account, form
 
 	return &apiAttachment, nil
 }
+
+// CreateAsync creates a new media attachment belonging to the given account, using
+// the request form. Unlike Create, it does not wait for the media to be processed:
+// a placeholder attachment is stored and returned straight away, and processing is
+// left to a background worker. Callers can poll Get until processing has finished.
+func (p *Processor) CreateAsync(ctx context.Context, account *gtsmodel.Account, form *apimodel.AttachmentRequest) (*apimodel.Attachment, gtserror.WithCode) {
+	focusX, focusY, err := parseFocus(form.Focus)
+	if err != nil {
+		err := fmt.Errorf("could not parse focus value %s: %s", form.Focus, err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Multipart form data is cleaned up as soon as
+	// the request finishes, which will be long before
+	// the worker gets to it, so take our own copy.
+	tmp, err := spoolUpload(form)
+	if err != nil {
+		err := gtserror.Newf("error spooling uploaded file: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.createAsync(ctx,
+		account,
+		tmp,
+		form.File.Size,
+		form.Description,
+		focusX,
+		focusY,
+	)
+}
+
+// createAsync stores a placeholder attachment for the media
+// in rc, and queues it for processing in the background. The
+// worker takes ownership of rc, which is closed once read.
+func (p *Processor) createAsync(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	rc io.ReadCloser,
+	size int64,
+	description string,
+	focusX float32,
+	focusY float32,
+) (*apimodel.Attachment, gtserror.WithCode) {
+	data := func(innerCtx context.Context) (io.ReadCloser, int64, error) {
+		return rc, size, nil
+	}
+
+	processing := p.mediaManager.PreProcessMedia(data, account.ID, &media.AdditionalMediaInfo{
+		Description: &description,
+		FocusX:      &focusX,
+		FocusY:      &focusY,
+	})
+
+	// Store a placeholder that can
+	// be polled while processing.
+	attachment, err := processing.Placeholder(ctx)
+	if err != nil {
+		rc.Close()
+		err := gtserror.Newf("error storing placeholder attachment: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Hand over to the worker, which
+	// will close the spooled upload.
+	p.state.Workers.Media.Queue.Push(processing.Process)
+
+	apiAttachment, err := p.converter.AttachmentToAPIAttachment(ctx, attachment)
+	if err != nil {
+		err := fmt.Errorf("error parsing media attachment to frontend type: %s", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Nothing is servable yet.
+	apiAttachment.URL = nil
+	apiAttachment.TextURL = nil
+	apiAttachment.PreviewURL = nil
+
+	return &apiAttachment, nil
+}
+
+// FailInterrupted marks as failed all local media whose processing
+// in the background (see CreateAsync) was interrupted by a previous
+// shutdown. Uploaded files are only kept in temporary files until
+// they've been processed, so processing can't be picked back up, but
+// this way clients polling the placeholders find out it failed. This
+// should be called at startup, before any new media is processed.
+func (p *Processor) FailInterrupted(ctx context.Context) error {
+	attachments, err := p.state.DB.GetLocalAttachmentsProcessing(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting processing attachments: %w", err)
+	}
+
+	for _, attachment := range attachments {
+		attachment.Processing = gtsmodel.ProcessingStatusError
+		if err := p.state.DB.UpdateAttachment(ctx, attachment, "processing"); err != nil {
+			return gtserror.Newf("db error updating attachment %s: %w", attachment.ID, err)
+		}
+	}
+
+	if len(attachments) > 0 {
+		log.Infof(ctx, "failed %d interrupted media upload(s)", len(attachments))
+	}
+
+	return nil
+}
+
+// spoolUpload copies the uploaded file in form to a
+// temporary file, rewound and ready for reading. The
+// temporary file is removed when the reader is closed.
+func spoolUpload(form *apimodel.AttachmentRequest) (io.ReadSeekCloser, error) {
+	f, err := form.File.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	tmp, err := iotools.TempFileSeeker(f)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		tmp.Close()
+		return nil, err
+	}
+
+	return tmp, nil
+}
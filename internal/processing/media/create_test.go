@@ -0,0 +1,76 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type CreateTestSuite struct {
+	MediaStandardTestSuite
+}
+
+func (suite *CreateTestSuite) TestFailInterrupted() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	// A placeholder left behind by
+	// processing cut off by a shutdown.
+	placeholder := new(gtsmodel.MediaAttachment)
+	*placeholder = *suite.testAttachments["local_account_1_unattached_1"]
+	placeholder.ID = "01J3FD1KVCWD3XG7MJ8ANBZ8QS"
+	placeholder.Processing = gtsmodel.ProcessingStatusProcessing
+	if err := suite.db.PutAttachment(ctx, placeholder); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Still being processed as far as the client knows.
+	apiAttachment, errWithCode := suite.mediaProcessor.Get(ctx, testAccount, placeholder.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Nil(apiAttachment.URL)
+
+	suite.NoError(suite.mediaProcessor.FailInterrupted(ctx))
+
+	// Now the client finds out it failed.
+	_, errWithCode = suite.mediaProcessor.Get(ctx, testAccount, placeholder.ID)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, placeholder.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(gtsmodel.ProcessingStatusError, dbAttachment.Processing)
+
+	// Processed media is left alone.
+	dbAttachment, err = suite.db.GetAttachmentByID(ctx, suite.testAttachments["local_account_1_unattached_1"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(gtsmodel.ProcessingStatusProcessed, dbAttachment.Processing)
+}
+
+func TestCreateTestSuite(t *testing.T) {
+	suite.Run(t, &CreateTestSuite{})
+}
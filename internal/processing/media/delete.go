@@ -21,11 +21,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
-	"github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
 )
 
 // Delete deletes the media attachment with the given ID, including all files pertaining to that attachment.
@@ -40,29 +39,25 @@ func (p *Processor) Delete(ctx context.Context, mediaAttachmentID string) gtserr
 		return gtserror.NewErrorInternalError(err)
 	}
 
-	errs := []string{}
-
-	// delete the thumbnail from storage
-	if attachment.Thumbnail.Path != "" {
-		if err := p.state.Storage.Delete(ctx, attachment.Thumbnail.Path); err != nil && !storage.IsNotFound(err) {
-			errs = append(errs, fmt.Sprintf("remove thumbnail at path %s: %s", attachment.Thumbnail.Path, err))
-		}
-	}
-
-	// delete the file from storage
-	if attachment.File.Path != "" {
-		if err := p.state.Storage.Delete(ctx, attachment.File.Path); err != nil && !storage.IsNotFound(err) {
-			errs = append(errs, fmt.Sprintf("remove file at path %s: %s", attachment.File.Path, err))
-		}
-	}
-
-	// delete the attachment
+	// delete the attachment first, so that any
+	// concurrent delete of another attachment
+	// sharing its files doesn't still see it
 	if err := p.state.DB.DeleteAttachment(ctx, mediaAttachmentID); err != nil && !errors.Is(err, db.ErrNoEntries) {
-		errs = append(errs, fmt.Sprintf("remove attachment: %s", err))
+		err := fmt.Errorf("Delete: error removing attachment with id %s: %w", mediaAttachmentID, err)
+		return gtserror.NewErrorInternalError(err)
 	}
 
-	if len(errs) != 0 {
-		return gtserror.NewErrorInternalError(fmt.Errorf("Delete: one or more errors removing attachment with id %s: %s", mediaAttachmentID, strings.Join(errs, "; ")))
+	// delete the thumbnail, file and any medium
+	// rendition from storage,
+	// unless shared with other media attachments
+	if _, err := media.DeleteFiles(ctx, p.state,
+		attachment.ID,
+		attachment.Thumbnail.Path,
+		attachment.File.Path,
+		media.MediumPath(attachment),
+	); err != nil {
+		err := fmt.Errorf("Delete: error removing files of attachment with id %s: %w", mediaAttachmentID, err)
+		return gtserror.NewErrorInternalError(err)
 	}
 
 	return nil
@@ -20,15 +20,21 @@ package media
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
 
 // GetCustomEmojis returns a list of all useable local custom emojis stored on this instance.
 // 'useable' in this context means visible and picker, and not disabled.
+//
+// Emojis are grouped by category in picker order (by position, then name), with
+// uncategorized emojis last. Within each group, emojis are ordered by shortcode.
 func (p *Processor) GetCustomEmojis(ctx context.Context) ([]*apimodel.Emoji, gtserror.WithCode) {
 	emojis, err := p.state.DB.GetUseableEmojis(ctx)
 	if err != nil {
@@ -37,6 +43,11 @@ func (p *Processor) GetCustomEmojis(ctx context.Context) ([]*apimodel.Emoji, gts
 		}
 	}
 
+	// Emojis come sorted by shortcode,
+	// so a stable sort by category keeps
+	// them sorted within each category.
+	slices.SortStableFunc(emojis, compareEmojiCategories)
+
 	apiEmojis := make([]*apimodel.Emoji, 0, len(emojis))
 	for _, gtsEmoji := range emojis {
 		apiEmoji, err := p.converter.EmojiToAPIEmoji(ctx, gtsEmoji)
@@ -49,3 +60,20 @@ func (p *Processor) GetCustomEmojis(ctx context.Context) ([]*apimodel.Emoji, gts
 
 	return apiEmojis, nil
 }
+
+// compareEmojiCategories compares the categories of emojis a and b
+// by picker order, sorting uncategorized emojis after the rest.
+func compareEmojiCategories(a, b *gtsmodel.Emoji) int {
+	switch {
+	case a.Category == nil && b.Category == nil:
+		return 0
+	case a.Category == nil:
+		return 1
+	case b.Category == nil:
+		return -1
+	case a.Category.Position != b.Category.Position:
+		return a.Category.Position - b.Category.Position
+	default:
+		return strings.Compare(a.Category.Name, b.Category.Name)
+	}
+}
@@ -22,6 +22,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
 type GetEmojiTestSuite struct {
@@ -36,6 +38,43 @@ func (suite *GetEmojiTestSuite) TestGetCustomEmojis() {
 	suite.Equal("rainbow", emojis[0].Shortcode)
 }
 
+func (suite *GetEmojiTestSuite) TestGetCustomEmojisPickerOrder() {
+	ctx := context.Background()
+
+	// Add an uncategorized emoji, and one
+	// in a category that sorts before rainbow's.
+	for _, e := range []struct {
+		id         string
+		shortcode  string
+		categoryID string
+	}{
+		{"01J2SBN7J1HFVDW2K8J4C4YQ6X", "aardvark", ""},
+		{"01J2SBNFDDFB0H6Q8AW3Y54GKT", "zebra", "01GGQ989PTT9PMRN4FZ1WWK2B9"},
+	} {
+		emoji := new(gtsmodel.Emoji)
+		*emoji = *testrig.NewTestEmojis()["rainbow"]
+		emoji.ID = e.id
+		emoji.Shortcode = e.shortcode
+		emoji.URI = "http://localhost:8080/emoji/" + e.id
+		emoji.CategoryID = e.categoryID
+		emoji.Category = nil
+		if err := suite.db.PutEmoji(ctx, emoji); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	emojis, err := suite.mediaProcessor.GetCustomEmojis(ctx)
+	suite.NoError(err)
+
+	shortcodes := make([]string, 0, len(emojis))
+	for _, emoji := range emojis {
+		shortcodes = append(shortcodes, emoji.Shortcode)
+	}
+
+	// "cute stuff" < "reactions" < uncategorized.
+	suite.Equal([]string{"zebra", "rainbow", "aardvark"}, shortcodes)
+}
+
 func TestGetEmojiTestSuite(t *testing.T) {
 	suite.Run(t, &GetEmojiTestSuite{})
 }
@@ -85,7 +85,7 @@ func (p *Processor) GetFile(
 	case media.TypeEmoji:
 		return p.getEmojiContent(ctx, wantedMediaID, owningAccountID, mediaSize)
 	case media.TypeAttachment, media.TypeHeader, media.TypeAvatar:
-		return p.getAttachmentContent(ctx, requestingAccount, wantedMediaID, owningAccountID, mediaSize)
+		return p.getAttachmentContent(ctx, requestingAccount, wantedMediaID, owningAccountID, mediaSize, form.Width)
 	default:
 		return nil, gtserror.NewErrorNotFound(fmt.Errorf("media type %s not recognized", mediaType))
 	}
@@ -113,6 +113,8 @@ func parseSize(s string) (media.Size, error) {
 	switch s {
 	case string(media.SizeSmall):
 		return media.SizeSmall, nil
+	case string(media.SizeMedium):
+		return media.SizeMedium, nil
 	case string(media.SizeOriginal):
 		return media.SizeOriginal, nil
 	case string(media.SizeStatic):
@@ -121,7 +123,7 @@ func parseSize(s string) (media.Size, error) {
 	return "", fmt.Errorf("%s not a recognized media.Size", s)
 }
 
-func (p *Processor) getAttachmentContent(ctx context.Context, requestingAccount *gtsmodel.Account, wantedMediaID string, owningAccountID string, mediaSize media.Size) (*apimodel.Content, gtserror.WithCode) {
+func (p *Processor) getAttachmentContent(ctx context.Context, requestingAccount *gtsmodel.Account, wantedMediaID string, owningAccountID string, mediaSize media.Size, width int) (*apimodel.Content, gtserror.WithCode) {
 	// retrieve attachment from the database and do basic checks on it
 	a, err := p.state.DB.GetAttachmentByID(ctx, wantedMediaID)
 	if err != nil {
@@ -210,6 +212,12 @@ func (p *Processor) getAttachmentContent(ctx context.Context, requestingAccount
 		}
 	)
 
+	// If the caller told us how wide the image will
+	// be displayed, pick the smallest suitable size.
+	if width > 0 && a.Type == gtsmodel.FileTypeImage {
+		mediaSize = media.RenditionSize(a, width)
+	}
+
 	// get file information from the attachment depending on the requested media size
 	switch mediaSize {
 	case media.SizeOriginal:
@@ -220,6 +228,16 @@ func (p *Processor) getAttachmentContent(ctx context.Context, requestingAccount
 		attachmentContent.ContentType = a.Thumbnail.ContentType
 		attachmentContent.ContentLength = int64(a.Thumbnail.FileSize)
 		storagePath = a.Thumbnail.Path
+	case media.SizeMedium:
+		// Generated on demand.
+		file, err := p.mediaManager.GetMedium(ctx, a)
+		if err != nil {
+			err = gtserror.Newf("error getting medium rendition of %s: %w", wantedMediaID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		attachmentContent.ContentType = file.ContentType
+		attachmentContent.ContentLength = int64(file.FileSize)
+		storagePath = file.Path
 	default:
 		return nil, gtserror.NewErrorNotFound(fmt.Errorf("media size %s not recognized for attachment", mediaSize))
 	}
@@ -20,6 +20,7 @@ package media_test
 import (
 	"bytes"
 	"context"
+	"image/jpeg"
 	"io"
 	"path"
 	"testing"
@@ -28,6 +29,7 @@ import (
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/regexes"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
@@ -110,8 +112,9 @@ func (suite *GetFileTestSuite) TestGetRemoteFileUncached() {
 	suite.NoError(err)
 	suite.True(*dbAttachment.Cached)
 
-	// the file should be back in storage at the same path as before
-	refreshedBytes, err := suite.storage.Get(ctx, testAttachment.File.Path)
+	// the file should be back in storage at its content-addressed path
+	suite.Regexp(regexes.BlobPath, dbAttachment.File.Path)
+	refreshedBytes, err := suite.storage.Get(ctx, dbAttachment.File.Path)
 	suite.NoError(err)
 	suite.Equal(suite.testRemoteAttachments[testAttachment.RemoteURL].Data, refreshedBytes)
 }
@@ -156,15 +159,16 @@ func (suite *GetFileTestSuite) TestGetRemoteFileUncachedInterrupted() {
 	suite.NoError(content.Content.Close())
 
 	// the attachment should still be updated in the database even though the caller hung up
+	var dbAttachment *gtsmodel.MediaAttachment
 	if !testrig.WaitFor(func() bool {
-		dbAttachment, _ := suite.db.GetAttachmentByID(ctx, testAttachment.ID)
+		dbAttachment, _ = suite.db.GetAttachmentByID(ctx, testAttachment.ID)
 		return *dbAttachment.Cached
 	}) {
 		suite.FailNow("timed out waiting for attachment to be updated")
 	}
 
-	// the file should be back in storage at the same path as before
-	refreshedBytes, err := suite.storage.Get(ctx, testAttachment.File.Path)
+	// the file should be back in storage at its content-addressed path
+	refreshedBytes, err := suite.storage.Get(ctx, dbAttachment.File.Path)
 	suite.NoError(err)
 	suite.Equal(suite.testRemoteAttachments[testAttachment.RemoteURL].Data, refreshedBytes)
 }
@@ -208,6 +212,99 @@ func (suite *GetFileTestSuite) TestGetRemoteFileThumbnailUncached() {
 	suite.EqualValues(testAttachment.Thumbnail.FileSize, content.ContentLength)
 }
 
+func (suite *GetFileTestSuite) TestGetLocalFileMedium() {
+	ctx := context.Background()
+	testAttachment := suite.testAttachments["local_account_1_avatar"]
+	fileName := path.Base(testAttachment.File.Path)
+	requestingAccount := suite.testAccounts["local_account_2"]
+
+	// 1092x1800 original, so a
+	// medium rendition is needed.
+	form := &apimodel.GetContentRequestForm{
+		AccountID: testAttachment.AccountID,
+		MediaType: string(media.TypeAvatar),
+		MediaSize: string(media.SizeMedium),
+		FileName:  fileName,
+	}
+
+	content, errWithCode := suite.mediaProcessor.GetFile(ctx, requestingAccount, form)
+	suite.NoError(errWithCode)
+	suite.NotNil(content)
+	b, err := io.ReadAll(content.Content)
+	suite.NoError(err)
+	suite.NoError(content.Content.Close())
+
+	suite.Equal("image/jpeg", content.ContentType)
+	suite.EqualValues(len(b), content.ContentLength)
+
+	// Should be resized to fit.
+	img, err := jpeg.DecodeConfig(bytes.NewReader(b))
+	suite.NoError(err)
+	suite.Equal(1280, img.Height)
+	suite.InDelta(776, img.Width, 1)
+
+	// Should now be in storage.
+	mediumPath := media.MediumPath(testAttachment)
+	suite.Equal("01F8MH1H7YV1Z7D2C8K2730QBF/attachment/medium/01F8MH58A357CV5K7R7TJMSH6S.jpg", mediumPath)
+	stored, err := suite.storage.Get(ctx, mediumPath)
+	suite.NoError(err)
+	suite.Equal(b, stored)
+
+	// Fetching again should
+	// serve the stored file.
+	content, errWithCode = suite.mediaProcessor.GetFile(ctx, requestingAccount, form)
+	suite.NoError(errWithCode)
+	b, err = io.ReadAll(content.Content)
+	suite.NoError(err)
+	suite.NoError(content.Content.Close())
+	suite.Equal(stored, b)
+	suite.EqualValues(len(stored), content.ContentLength)
+}
+
+func (suite *GetFileTestSuite) TestGetLocalFileWidth() {
+	ctx := context.Background()
+	testAttachment := suite.testAttachments["local_account_1_avatar"]
+	fileName := path.Base(testAttachment.File.Path)
+	requestingAccount := suite.testAccounts["local_account_2"]
+
+	for _, test := range []struct {
+		width  int
+		length int64
+	}{
+		// Thumbnail is 155px wide.
+		{width: 100, length: int64(testAttachment.Thumbnail.FileSize)},
+		{width: 155, length: int64(testAttachment.Thumbnail.FileSize)},
+		// Original is 1092px wide.
+		{width: 2000, length: int64(testAttachment.File.FileSize)},
+	} {
+		content, errWithCode := suite.mediaProcessor.GetFile(ctx, requestingAccount, &apimodel.GetContentRequestForm{
+			AccountID: testAttachment.AccountID,
+			MediaType: string(media.TypeAvatar),
+			MediaSize: string(media.SizeOriginal),
+			FileName:  fileName,
+			Width:     test.width,
+		})
+		suite.NoError(errWithCode)
+		suite.NoError(content.Content.Close())
+		suite.Equal(test.length, content.ContentLength, "width %d", test.width)
+	}
+
+	// In between, should get the medium rendition.
+	content, errWithCode := suite.mediaProcessor.GetFile(ctx, requestingAccount, &apimodel.GetContentRequestForm{
+		AccountID: testAttachment.AccountID,
+		MediaType: string(media.TypeAvatar),
+		MediaSize: string(media.SizeSmall),
+		FileName:  fileName,
+		Width:     640,
+	})
+	suite.NoError(errWithCode)
+	suite.NoError(content.Content.Close())
+
+	has, err := suite.storage.Has(ctx, media.MediumPath(testAttachment))
+	suite.NoError(err)
+	suite.True(has)
+}
+
 func TestGetFileTestSuite(t *testing.T) {
 	suite.Run(t, &GetFileTestSuite{})
 }
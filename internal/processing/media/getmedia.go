@@ -28,6 +28,9 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
+// Get returns the media attachment with the given ID, if owned
+// by account. If the attachment is still being processed, it is
+// returned with a nil URL; if processing failed, an error is.
 func (p *Processor) Get(ctx context.Context, account *gtsmodel.Account, mediaAttachmentID string) (*apimodel.Attachment, gtserror.WithCode) {
 	attachment, err := p.state.DB.GetAttachmentByID(ctx, mediaAttachmentID)
 	if err != nil {
@@ -42,10 +45,23 @@ func (p *Processor) Get(ctx context.Context, account *gtsmodel.Account, mediaAtt
 		return nil, gtserror.NewErrorNotFound(errors.New("attachment not owned by requesting account"))
 	}
 
+	if attachment.Processing == gtsmodel.ProcessingStatusError {
+		const text = "media could not be processed"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
 	a, err := p.converter.AttachmentToAPIAttachment(ctx, attachment)
 	if err != nil {
 		return nil, gtserror.NewErrorNotFound(fmt.Errorf("error converting attachment: %s", err))
 	}
 
+	if attachment.Processing != gtsmodel.ProcessingStatusProcessed {
+		// Still being processed in the
+		// background, nothing is servable yet.
+		a.URL = nil
+		a.TextURL = nil
+		a.PreviewURL = nil
+	}
+
 	return &a, nil
 }
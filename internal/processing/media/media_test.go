@@ -70,6 +70,7 @@ func (suite *MediaStandardTestSuite) SetupTest() {
 
 	testrig.InitTestConfig()
 	testrig.InitTestLog()
+	testrig.StartNoopWorkers(&suite.state)
 
 	suite.db = testrig.NewTestDB(&suite.state)
 	suite.state.DB = suite.db
@@ -86,4 +87,5 @@ func (suite *MediaStandardTestSuite) SetupTest() {
 func (suite *MediaStandardTestSuite) TearDownTest() {
 	testrig.StandardDBTeardown(suite.db)
 	testrig.StandardStorageTeardown(suite.storage)
+	testrig.StopWorkers(&suite.state)
 }
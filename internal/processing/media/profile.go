@@ -21,9 +21,11 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
 )
 
 // DeleteAvatar deletes the account's avatar, if one exists, and returns the updated account.
@@ -66,6 +68,14 @@ func (p *Processor) deleteProfileAttachment(
 		if err := p.Delete(ctx, attachmentID); err != nil {
 			return nil, err
 		}
+
+		// Federate the updated account.
+		p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+			APObjectType:   ap.ActorPerson,
+			APActivityType: ap.ActivityUpdate,
+			GTSModel:       account,
+			Origin:         account,
+		})
 	}
 
 	acctSensitive, err := p.converter.AccountToAPIAccountSensitive(ctx, account)
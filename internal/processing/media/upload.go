@@ -0,0 +1,347 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// uploadFile wraps a temporary file holding
+// (part of) an upload, removing the file once
+// it's been read.
+type uploadFile struct{ *os.File }
+
+func (f uploadFile) Close() error {
+	_ = f.File.Close()
+	return os.Remove(f.File.Name())
+}
+
+// newUploadFile creates a new temporary file for (part of) an upload.
+func newUploadFile() (uploadFile, error) {
+	file, err := os.CreateTemp(os.TempDir(), "gotosocial-upload-")
+	return uploadFile{file}, err
+}
+
+// UploadCreate starts a new resumable upload for the given account,
+// of media with the given total size. The parts of the media can then
+// be sent with UploadAppend, and the upload finished with UploadFinish.
+//
+// Unfinished uploads are stored in the database, and their parts in
+// storage, so that they survive a restart until they expire. Parts
+// of an upload are only written one at a time within this process.
+func (p *Processor) UploadCreate(ctx context.Context, account *gtsmodel.Account, form *apimodel.MediaUploadRequest) (*apimodel.MediaUpload, gtserror.WithCode) {
+	focusX, focusY, err := parseFocus(form.Focus)
+	if err != nil {
+		err := fmt.Errorf("could not parse focus value %s: %s", form.Focus, err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Don't let the account start more
+	// uploads between counting and putting.
+	unlock := p.state.ProcessingLocks.Lock("media-uploads " + account.ID)
+	defer unlock()
+
+	uploads, err := p.state.DB.GetAccountMediaUploads(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting uploads: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	var unfinished int
+	for _, upload := range uploads {
+		if !p.discardExpiredUpload(ctx, upload) {
+			unfinished++
+		}
+	}
+
+	if limit := config.GetMediaResumableUploadMaxPerAccount(); unfinished >= limit {
+		err := fmt.Errorf("reached limit of %d unfinished uploads; finish one, or wait for it to expire", limit)
+		return nil, gtserror.NewErrorTooManyRequests(err, err.Error())
+	}
+
+	upload := &gtsmodel.MediaUpload{
+		ID:          id.NewULID(),
+		ExpiresAt:   time.Now().Add(config.GetMediaResumableUploadExpiry()),
+		AccountID:   account.ID,
+		Size:        form.Size,
+		Description: form.Description,
+		FocusX:      focusX,
+		FocusY:      focusY,
+	}
+
+	if err := p.state.DB.PutMediaUpload(ctx, upload); err != nil {
+		err := gtserror.Newf("db error putting upload: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return uploadToAPI(upload), nil
+}
+
+// UploadGet returns the current state of the given
+// resumable upload, so that a client can find out
+// from which offset to resume after being cut off.
+func (p *Processor) UploadGet(ctx context.Context, account *gtsmodel.Account, uploadID string) (*apimodel.MediaUpload, gtserror.WithCode) {
+	upload, errWithCode := p.getUpload(ctx, account, uploadID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return uploadToAPI(upload), nil
+}
+
+// UploadAppend writes the next part of the given resumable upload from r,
+// which should contain length bytes to be written at offset. If the offset
+// is not where the upload currently stands, a 409 conflict is returned.
+// Every part but the last must be at least the configured min part size.
+//
+// Bytes read from r are kept even if reading fails partway (eg., if the
+// client's connection drops), so the client can resume from the new offset,
+// provided enough was read to make a part of at least the min part size.
+func (p *Processor) UploadAppend(ctx context.Context, account *gtsmodel.Account, uploadID string, offset int64, length int64, r io.Reader) (*apimodel.MediaUpload, gtserror.WithCode) {
+	unlock := p.lockUpload(uploadID)
+	defer unlock()
+
+	upload, errWithCode := p.getUpload(ctx, account, uploadID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if offset != upload.Received {
+		err := fmt.Errorf("upload %s is at offset %d, not %d", upload.ID, upload.Received, offset)
+		return nil, gtserror.NewErrorConflict(err, err.Error())
+	}
+
+	if offset+length > upload.Size {
+		err := fmt.Errorf("part would exceed upload size of %d bytes", upload.Size)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Each part is stored (and later read back) on
+	// its own, so don't allow lots of tiny parts.
+	minPartSize := int64(config.GetMediaResumableUploadMinPartSize())
+	if length < minPartSize && offset+length < upload.Size {
+		err := fmt.Errorf("parts other than the last must be at least %d bytes", minPartSize)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Receive the part into a temporary file first,
+	// so that whatever was read can be kept even if
+	// reading fails partway, as storage can't append.
+	file, err := newUploadFile()
+	if err != nil {
+		err := gtserror.Newf("error creating temporary file: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	defer file.Close()
+
+	n, readErr := io.Copy(file, io.LimitReader(r, length))
+
+	// Only keep what was read if it makes a
+	// whole part, else the client has to send
+	// it all again from the current offset.
+	if n >= minPartSize || (n > 0 && offset+n == upload.Size) {
+		if err := p.putUploadPart(ctx, upload, file); err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		upload.Parts++
+		upload.Received += n
+
+		if err := p.state.DB.UpdateMediaUpload(ctx, upload, "parts", "received"); err != nil {
+			err := gtserror.Newf("db error updating upload %s: %w", upload.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	if readErr != nil {
+		err := gtserror.Newf("error reading part of upload %s: %w", upload.ID, readErr)
+		return nil, gtserror.NewErrorBadRequest(err, "error reading part, resume from current offset")
+	}
+
+	return uploadToAPI(upload), nil
+}
+
+// UploadFinish finishes the given resumable upload, handing the uploaded
+// media over to be processed in the background exactly as CreateAsync does.
+// All parts of the media must have been sent before an upload can be finished.
+func (p *Processor) UploadFinish(ctx context.Context, account *gtsmodel.Account, uploadID string) (*apimodel.Attachment, gtserror.WithCode) {
+	unlock := p.lockUpload(uploadID)
+	defer unlock()
+
+	upload, errWithCode := p.getUpload(ctx, account, uploadID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if upload.Received < upload.Size {
+		err := fmt.Errorf("upload %s is incomplete: %d of %d bytes received", upload.ID, upload.Received, upload.Size)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	// Gather the parts back together in a temporary
+	// file, to be handed over for processing in one.
+	file, err := newUploadFile()
+	if err != nil {
+		err := gtserror.Newf("error creating temporary file: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.getUploadParts(ctx, upload, file); err != nil {
+		_ = file.Close()
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Parts are gathered, so the
+	// upload itself can now go.
+	if err := media.DeleteUpload(ctx, p.state, upload); err != nil {
+		_ = file.Close()
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.createAsync(ctx,
+		account,
+		file,
+		upload.Size,
+		upload.Description,
+		upload.FocusX,
+		upload.FocusY,
+	)
+}
+
+// putUploadPart puts the contents of file in storage
+// as the next part of upload, after its current parts.
+func (p *Processor) putUploadPart(ctx context.Context, upload *gtsmodel.MediaUpload, file uploadFile) error {
+	path := media.UploadPartPath(upload.ID, upload.Parts)
+
+	for i := 0; ; i++ {
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			return gtserror.Newf("error rewinding part: %w", err)
+		}
+
+		_, err := p.state.Storage.PutStream(ctx, path, file)
+		if err == nil {
+			return nil
+		}
+
+		if i > 0 || !storage.IsAlreadyExist(err) {
+			return gtserror.Newf("error putting %s: %w", path, err)
+		}
+
+		// Left behind by an append which failed
+		// to update the database afterwards, so
+		// remove it, then try putting it again.
+		if err := p.state.Storage.Delete(ctx, path); err != nil {
+			return gtserror.Newf("error removing stale %s: %w", path, err)
+		}
+	}
+}
+
+// getUploadParts writes all parts of the upload, in
+// order, from storage to file, then rewinds the file.
+func (p *Processor) getUploadParts(ctx context.Context, upload *gtsmodel.MediaUpload, file uploadFile) error {
+	for part := 0; part < upload.Parts; part++ {
+		path := media.UploadPartPath(upload.ID, part)
+
+		rc, err := p.state.Storage.GetStream(ctx, path)
+		if err != nil {
+			return gtserror.Newf("error getting %s: %w", path, err)
+		}
+
+		_, err = io.Copy(file, rc)
+		_ = rc.Close()
+		if err != nil {
+			return gtserror.Newf("error copying %s: %w", path, err)
+		}
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return gtserror.Newf("error rewinding upload %s: %w", upload.ID, err)
+	}
+
+	return nil
+}
+
+// getUpload fetches the unexpired upload with ID, belonging to account.
+func (p *Processor) getUpload(ctx context.Context, account *gtsmodel.Account, uploadID string) (*gtsmodel.MediaUpload, gtserror.WithCode) {
+	upload, err := p.state.DB.GetMediaUploadByID(ctx, uploadID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting upload %s: %w", uploadID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if upload == nil ||
+		// Don't leak existence
+		// of others' uploads.
+		upload.AccountID != account.ID ||
+		p.discardExpiredUpload(ctx, upload) {
+		err := fmt.Errorf("upload %s not found", uploadID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return upload, nil
+}
+
+// discardExpiredUpload removes the given upload if it has expired, but the
+// media cleaner hasn't got to it (yet), returning whether it had expired.
+func (p *Processor) discardExpiredUpload(ctx context.Context, upload *gtsmodel.MediaUpload) bool {
+	if time.Now().Before(upload.ExpiresAt) {
+		return false
+	}
+
+	log.Debugf(ctx, "discarding expired upload %s", upload.ID)
+	if err := media.DeleteUpload(ctx, p.state, upload); err != nil {
+		// Not the end of the world, the
+		// cleaner will try again later.
+		log.Errorf(ctx, "error discarding expired upload: %v", err)
+	}
+
+	return true
+}
+
+// lockUpload locks the upload with ID against
+// concurrent appends or finishing, returning
+// the function with which to unlock it.
+func (p *Processor) lockUpload(uploadID string) func() {
+	return p.state.ProcessingLocks.Lock("media-upload " + uploadID)
+}
+
+// uploadToAPI converts upload to its API model.
+func uploadToAPI(upload *gtsmodel.MediaUpload) *apimodel.MediaUpload {
+	return &apimodel.MediaUpload{
+		ID:        upload.ID,
+		Size:      upload.Size,
+		Offset:    upload.Received,
+		ExpiresAt: util.FormatISO8601(upload.ExpiresAt),
+	}
+}
@@ -0,0 +1,305 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	mediaprocessing "github.com/superseriousbusiness/gotosocial/internal/processing/media"
+)
+
+type UploadTestSuite struct {
+	MediaStandardTestSuite
+}
+
+func (suite *UploadTestSuite) TestUploadInParts() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	b, err := os.ReadFile("../../../testrig/media/ohyou-original.jpg")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	size := int64(len(b))
+
+	upload, errWithCode := suite.mediaProcessor.UploadCreate(ctx, testAccount, &apimodel.MediaUploadRequest{
+		Size:        size,
+		Description: "oh you",
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(size, upload.Size)
+	suite.Zero(upload.Offset)
+
+	// Can't finish before everything's been sent.
+	_, errWithCode = suite.mediaProcessor.UploadFinish(ctx, testAccount, upload.ID)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+
+	// Send the first half, but pretend the
+	// connection dropped a few bytes short.
+	half := size / 2
+	upload, errWithCode = suite.mediaProcessor.UploadAppend(ctx, testAccount, upload.ID, 0, half, bytes.NewReader(b[:half-10]))
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(half-10, upload.Offset)
+
+	// Sending from the wrong offset is refused.
+	_, errWithCode = suite.mediaProcessor.UploadAppend(ctx, testAccount, upload.ID, half, size-half, bytes.NewReader(b[half:]))
+	suite.Equal(http.StatusConflict, errWithCode.Code())
+
+	// Someone else can't see the upload.
+	_, errWithCode = suite.mediaProcessor.UploadGet(ctx, suite.testAccounts["local_account_2"], upload.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	// Resume from where we were cut off.
+	upload, errWithCode = suite.mediaProcessor.UploadGet(ctx, testAccount, upload.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	upload, errWithCode = suite.mediaProcessor.UploadAppend(ctx, testAccount, upload.ID, upload.Offset, size-upload.Offset, bytes.NewReader(b[upload.Offset:]))
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(size, upload.Offset)
+
+	attachment, errWithCode := suite.mediaProcessor.UploadFinish(ctx, testAccount, upload.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Nil(attachment.URL)
+	suite.Equal("oh you", *attachment.Description)
+
+	// Upload is gone now it's finished.
+	_, errWithCode = suite.mediaProcessor.UploadGet(ctx, testAccount, upload.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	// Processing should have been queued; run it.
+	process, ok := suite.state.Workers.Media.Queue.Pop()
+	suite.True(ok)
+	process(ctx)
+
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachment.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(gtsmodel.ProcessingStatusProcessed, dbAttachment.Processing)
+	suite.Equal(gtsmodel.FileTypeImage, dbAttachment.Type)
+}
+
+func (suite *UploadTestSuite) TestUploadSurvivesRestart() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	b, err := os.ReadFile("../../../testrig/media/ohyou-original.jpg")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	size := int64(len(b))
+	half := size / 2
+
+	upload, errWithCode := suite.mediaProcessor.UploadCreate(ctx, testAccount, &apimodel.MediaUploadRequest{
+		Size: size,
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	_, errWithCode = suite.mediaProcessor.UploadAppend(ctx, testAccount, upload.ID, 0, half, bytes.NewReader(b[:half]))
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Nothing about the upload is kept in
+	// the processor, so a fresh one (as after
+	// a restart) can carry on where it left off.
+	processor := mediaprocessing.New(&suite.state, suite.tc, suite.mediaManager, suite.transportController)
+
+	upload, errWithCode = processor.UploadGet(ctx, testAccount, upload.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(half, upload.Offset)
+
+	_, errWithCode = processor.UploadAppend(ctx, testAccount, upload.ID, half, size-half, bytes.NewReader(b[half:]))
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	attachment, errWithCode := processor.UploadFinish(ctx, testAccount, upload.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// The parts have been cleaned up.
+	for part := 0; part < 2; part++ {
+		has, err := suite.storage.Has(ctx, media.UploadPartPath(upload.ID, part))
+		suite.NoError(err)
+		suite.False(has)
+	}
+
+	process, ok := suite.state.Workers.Media.Queue.Pop()
+	suite.True(ok)
+	process(ctx)
+
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachment.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(gtsmodel.ProcessingStatusProcessed, dbAttachment.Processing)
+}
+
+func (suite *UploadTestSuite) TestUploadExpired() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	upload, errWithCode := suite.mediaProcessor.UploadCreate(ctx, testAccount, &apimodel.MediaUploadRequest{
+		Size: 10,
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Small enough to send in one part.
+	_, errWithCode = suite.mediaProcessor.UploadAppend(ctx, testAccount, upload.ID, 0, 10, bytes.NewReader([]byte("helloworld")))
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.expireUpload(upload.ID)
+
+	// Expired uploads are gone, parts and all.
+	_, errWithCode = suite.mediaProcessor.UploadGet(ctx, testAccount, upload.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	has, err := suite.storage.Has(ctx, media.UploadPartPath(upload.ID, 0))
+	suite.NoError(err)
+	suite.False(has)
+
+	_, err = suite.db.GetMediaUploadByID(ctx, upload.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *UploadTestSuite) TestUploadMinPartSize() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+	config.SetMediaResumableUploadMinPartSize(1000)
+
+	b := make([]byte, 2500)
+	size := int64(len(b))
+
+	upload, errWithCode := suite.mediaProcessor.UploadCreate(ctx, testAccount, &apimodel.MediaUploadRequest{
+		Size: size,
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Parts smaller than the min size are refused.
+	_, errWithCode = suite.mediaProcessor.UploadAppend(ctx, testAccount, upload.ID, 0, 999, bytes.NewReader(b[:999]))
+	suite.EqualError(errWithCode, "parts other than the last must be at least 1000 bytes")
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	// Cut off too short to make a whole part,
+	// so nothing is kept, and nothing is stored.
+	upload, errWithCode = suite.mediaProcessor.UploadAppend(ctx, testAccount, upload.ID, 0, 2000, bytes.NewReader(b[:500]))
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Zero(upload.Offset)
+
+	has, err := suite.storage.Has(ctx, media.UploadPartPath(upload.ID, 0))
+	suite.NoError(err)
+	suite.False(has)
+
+	upload, errWithCode = suite.mediaProcessor.UploadAppend(ctx, testAccount, upload.ID, 0, 2000, bytes.NewReader(b[:2000]))
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(int64(2000), upload.Offset)
+
+	// The last part may be smaller.
+	upload, errWithCode = suite.mediaProcessor.UploadAppend(ctx, testAccount, upload.ID, 2000, 500, bytes.NewReader(b[2000:]))
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(size, upload.Offset)
+}
+
+func (suite *UploadTestSuite) TestUploadLimit() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+	config.SetMediaResumableUploadMaxPerAccount(2)
+
+	create := func(account *gtsmodel.Account) (*apimodel.MediaUpload, gtserror.WithCode) {
+		return suite.mediaProcessor.UploadCreate(ctx, account, &apimodel.MediaUploadRequest{
+			Size: 10,
+		})
+	}
+
+	first, errWithCode := create(testAccount)
+	suite.Nil(errWithCode)
+	_, errWithCode = create(testAccount)
+	suite.Nil(errWithCode)
+
+	// Limit reached.
+	_, errWithCode = create(testAccount)
+	suite.Equal(http.StatusTooManyRequests, errWithCode.Code())
+
+	// Limit is per account.
+	_, errWithCode = create(suite.testAccounts["local_account_2"])
+	suite.Nil(errWithCode)
+
+	// Expired uploads don't count.
+	suite.expireUpload(first.ID)
+	_, errWithCode = create(testAccount)
+	suite.Nil(errWithCode)
+}
+
+// expireUpload sets the upload with ID to have expired just now.
+func (suite *UploadTestSuite) expireUpload(uploadID string) {
+	ctx := context.Background()
+
+	upload, err := suite.db.GetMediaUploadByID(ctx, uploadID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	upload.ExpiresAt = time.Now()
+	if err := suite.db.UpdateMediaUpload(ctx, upload, "expires_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func TestUploadTestSuite(t *testing.T) {
+	suite.Run(t, &UploadTestSuite{})
+}
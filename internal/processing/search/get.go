@@ -119,11 +119,12 @@ func (p *Processor) Get(
 		}...).
 		Debugf("beginning search")
 
-	// todo: Currently we don't support offset for paging;
-	// a caller can page using maxID or minID, but if they
-	// supply an offset greater than 0, return nothing as
-	// though there were no additional results.
-	if req.Offset > 0 {
+	// todo: Currently we only support offset for paging
+	// through statuses; a caller can page using maxID or
+	// minID, but if they supply an offset greater than 0
+	// for any other query type, return nothing as though
+	// there were no additional results.
+	if offset > 0 && queryType != queryTypeStatuses {
 		return p.packageSearchResult(
 			ctx,
 			account,
@@ -221,6 +222,20 @@ func (p *Processor) Get(
 		// caller wants to include blocked accounts too.
 		includeBlockedAccounts = true
 
+		// URI search only ever returns
+		// one result, so there's nothing
+		// further to page through.
+		if offset > 0 {
+			return p.packageSearchResult(
+				ctx,
+				account,
+				nil, nil, nil, // No results.
+				req.APIv1,
+				includeInstanceAccounts,
+				includeBlockedAccounts,
+			)
+		}
+
 		if err := p.byURI(
 			ctx,
 			account,
@@ -834,7 +849,7 @@ func (p *Processor) statusesByText(
 	fromAccountID string,
 	appendStatus func(*gtsmodel.Status),
 ) error {
-	parsed, err := p.parseQuery(ctx, query)
+	parsed, err := p.parseQuery(ctx, requestingAccountID, query)
 	if err != nil {
 		return err
 	}
@@ -850,6 +865,7 @@ func (p *Processor) statusesByText(
 		requestingAccountID,
 		query,
 		fromAccountID,
+		parsed.scope,
 		maxID,
 		minID,
 		limit,
@@ -872,16 +888,23 @@ type parsedQuery struct {
 	query string
 	// fromAccountID is the account from a successfully resolved `from:` operator, if present.
 	fromAccountID string
+	// scope is the statuses scope from an `in:` operator, if present.
+	scope db.SearchScope
 }
 
 // parseQuery parses query text and handles any search operator terms present.
-func (p *Processor) parseQuery(ctx context.Context, query string) (parsed parsedQuery, err error) {
+func (p *Processor) parseQuery(ctx context.Context, requestingAccountID string, query string) (parsed parsedQuery, err error) {
 	queryPartSeparator := " "
 	queryParts := strings.Split(query, queryPartSeparator)
 	nonOperatorQueryParts := make([]string, 0, len(queryParts))
 	for _, queryPart := range queryParts {
 		if arg, hasPrefix := strings.CutPrefix(queryPart, "from:"); hasPrefix {
-			parsed.fromAccountID, err = p.parseFromOperatorArg(ctx, arg)
+			parsed.fromAccountID, err = p.parseFromOperatorArg(ctx, requestingAccountID, arg)
+			if err != nil {
+				return
+			}
+		} else if arg, hasPrefix := strings.CutPrefix(queryPart, "in:"); hasPrefix {
+			parsed.scope, err = parseInOperatorArg(arg)
 			if err != nil {
 				return
 			}
@@ -893,14 +916,31 @@ func (p *Processor) parseQuery(ctx context.Context, query string) (parsed parsed
 	return
 }
 
+// parseInOperatorArg parses the in: operator's argument as a statuses search scope.
+func parseInOperatorArg(arg string) (db.SearchScope, error) {
+	switch scope := db.SearchScope(strings.ToLower(arg)); scope {
+	case db.SearchScopeLibrary, db.SearchScopeFaves, db.SearchScopeBookmarks:
+		return scope, nil
+	default:
+		return "", gtserror.Newf(
+			"the 'in:' search operator argument %s was not recognized, valid options are ['%s', '%s', '%s']",
+			arg, db.SearchScopeLibrary, db.SearchScopeFaves, db.SearchScopeBookmarks,
+		)
+	}
+}
+
 // parseFromOperatorArg attempts to parse the from: operator's argument as an account name,
-// and returns the account ID if possible. Allows specifying an account name with or without a leading @.
-func (p *Processor) parseFromOperatorArg(ctx context.Context, namestring string) (string, error) {
+// and returns the account ID if possible. Allows specifying an account name with or without a leading @,
+// or "me" as shorthand for the requesting account.
+func (p *Processor) parseFromOperatorArg(ctx context.Context, requestingAccountID string, namestring string) (string, error) {
 	if namestring == "" {
 		return "", gtserror.New(
 			"the 'from:' search operator requires an account name, but it wasn't provided",
 		)
 	}
+	if namestring == "me" {
+		return requestingAccountID, nil
+	}
 	if namestring[0] != '@' {
 		namestring = "@" + namestring
 	}
@@ -20,14 +20,15 @@ package status
 import (
 	"context"
 	"errors"
-	"fmt"
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 )
 
 // BoostCreate processes the boost/reblog of target
@@ -73,6 +74,21 @@ func (p *Processor) BoostCreate(
 		return nil, gtserror.NewErrorNotFound(err)
 	}
 
+	// Check any interaction policy set by remote author.
+	permitted, err := p.filter.StatusAnnouncePermitted(ctx,
+		requester,
+		target,
+	)
+	if err != nil {
+		err := gtserror.Newf("error checking boost policy of status %s: %w", target.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if !permitted {
+		const text = "status author does not permit you to boost it"
+		return nil, gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
 	// Status is visible and boostable.
 	boost, err := p.converter.StatusToBoost(ctx,
 		target,
@@ -153,80 +169,74 @@ func (p *Processor) BoostRemove(
 	return p.c.GetAPIStatus(ctx, requester, target)
 }
 
-// StatusBoostedBy returns a slice of accounts that have boosted the given status, filtered according to privacy settings.
-func (p *Processor) StatusBoostedBy(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) ([]*apimodel.Account, gtserror.WithCode) {
-	targetStatus, err := p.state.DB.GetStatusByID(ctx, targetStatusID)
-	if err != nil {
-		wrapped := fmt.Errorf("BoostedBy: error fetching status %s: %s", targetStatusID, err)
-		if !errors.Is(err, db.ErrNoEntries) {
-			return nil, gtserror.NewErrorInternalError(wrapped)
-		}
-		return nil, gtserror.NewErrorNotFound(wrapped)
+// StatusBoostedBy returns a page of accounts that have boosted the given status, filtered according to privacy settings.
+func (p *Processor) StatusBoostedBy(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string, page *paging.Page) (*apimodel.PageableResponse, gtserror.WithCode) {
+	targetStatus, errWithCode := p.c.GetVisibleTargetStatus(ctx,
+		requestingAccount,
+		targetStatusID,
+		nil, // default freshness
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
 	}
 
-	if boostOfID := targetStatus.BoostOfID; boostOfID != "" {
-		// the target status is a boost wrapper, redirect this request to the status it boosts
-		boostedStatus, err := p.state.DB.GetStatusByID(ctx, boostOfID)
-		if err != nil {
-			wrapped := fmt.Errorf("BoostedBy: error fetching status %s: %s", boostOfID, err)
-			if !errors.Is(err, db.ErrNoEntries) {
-				return nil, gtserror.NewErrorInternalError(wrapped)
-			}
-			return nil, gtserror.NewErrorNotFound(wrapped)
-		}
-		targetStatus = boostedStatus
+	// If the target status is a boost wrapper,
+	// redirect this request to the status it boosts.
+	targetStatus, errWithCode = p.c.UnwrapIfBoost(ctx,
+		requestingAccount,
+		targetStatus,
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
 	}
 
-	visible, err := p.filter.StatusVisible(ctx, requestingAccount, targetStatus)
-	if err != nil {
-		err = fmt.Errorf("BoostedBy: error seeing if status %s is visible: %s", targetStatus.ID, err)
-		return nil, gtserror.NewErrorNotFound(err)
-	}
-	if !visible {
-		err = errors.New("BoostedBy: status is not visible")
-		return nil, gtserror.NewErrorNotFound(err)
+	statusBoosts, err := p.state.DB.GetStatusBoostsPage(ctx, targetStatus.ID, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting status boosts: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
 	}
 
-	statusBoosts, err := p.state.DB.GetStatusBoosts(ctx, targetStatus.ID)
-	if err != nil {
-		err = fmt.Errorf("BoostedBy: error seeing who boosted status: %s", err)
-		return nil, gtserror.NewErrorNotFound(err)
+	// Check for empty response.
+	count := len(statusBoosts)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
 	}
 
-	// filter account IDs so the user doesn't see accounts they blocked or which blocked them
-	accountIDs := make([]string, 0, len(statusBoosts))
-	for _, s := range statusBoosts {
-		blocked, err := p.state.DB.IsEitherBlocked(ctx, requestingAccount.ID, s.AccountID)
-		if err != nil {
-			err = fmt.Errorf("BoostedBy: error checking blocks: %s", err)
-			return nil, gtserror.NewErrorNotFound(err)
-		}
-		if !blocked {
-			accountIDs = append(accountIDs, s.AccountID)
-		}
-	}
+	// Get the lowest and highest
+	// ID values, used for paging.
+	lo := statusBoosts[count-1].ID
+	hi := statusBoosts[0].ID
 
-	// TODO: filter other things here? suspended? muted? silenced?
+	// Func to fetch boost author at index, ensuring
+	// that we're only showing the requester accounts
+	// that they don't block, and which don't block them.
+	getIdx := func(i int) *gtsmodel.Account {
+		boost := statusBoosts[i]
 
-	// fetch accounts + create their API representations
-	apiAccounts := make([]*apimodel.Account, 0, len(accountIDs))
-	for _, accountID := range accountIDs {
-		account, err := p.state.DB.GetAccountByID(ctx, accountID)
+		blocked, err := p.state.DB.IsEitherBlocked(ctx, requestingAccount.ID, boost.AccountID)
 		if err != nil {
-			wrapped := fmt.Errorf("BoostedBy: error fetching account %s: %s", accountID, err)
-			if !errors.Is(err, db.ErrNoEntries) {
-				return nil, gtserror.NewErrorInternalError(wrapped)
-			}
-			return nil, gtserror.NewErrorNotFound(wrapped)
+			log.Errorf(ctx, "error checking blocks: %v", err)
+			return nil
 		}
 
-		apiAccount, err := p.converter.AccountToAPIAccountPublic(ctx, account)
-		if err != nil {
-			err = fmt.Errorf("BoostedBy: error converting account to api model: %s", err)
-			return nil, gtserror.NewErrorInternalError(err)
+		if blocked {
+			return nil
 		}
-		apiAccounts = append(apiAccounts, apiAccount)
+
+		return boost.Account
 	}
 
-	return apiAccounts, nil
+	// Get a filtered slice of public API account models.
+	items := p.c.GetVisibleAPIAccountsPaged(ctx,
+		requestingAccount,
+		getIdx,
+		count,
+	)
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/statuses/" + targetStatus.ID + "/reblogged_by",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
 }
@@ -19,9 +19,11 @@ package status_test
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
 type StatusBoostTestSuite struct {
@@ -54,6 +56,31 @@ func (suite *StatusBoostTestSuite) TestBoostOfBoost() {
 	suite.Equal(targetStatus1.ID, boost2.Reblog.ID)
 }
 
+func (suite *StatusBoostTestSuite) TestBoostInteractionPolicy() {
+	ctx := context.Background()
+
+	targetStatus := suite.testStatuses["remote_account_1_status_1"]
+	permittedAccount := suite.testAccounts["admin_account"]
+	forbiddenAccount := suite.testAccounts["local_account_1"]
+
+	// Only permit admin_account to boost.
+	targetStatus.InteractionPolicy = &gtsmodel.InteractionPolicy{
+		CanAnnounce: []string{permittedAccount.URI},
+	}
+	if err := suite.state.DB.UpdateStatus(ctx, targetStatus, "interaction_policy"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	boost, errWithCode := suite.status.BoostCreate(ctx, forbiddenAccount, suite.testApplications["application_1"], targetStatus.ID)
+	suite.Nil(boost)
+	suite.EqualError(errWithCode, "status author does not permit you to boost it")
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+
+	boost, errWithCode = suite.status.BoostCreate(ctx, permittedAccount, suite.testApplications["application_1"], targetStatus.ID)
+	suite.NoError(errWithCode)
+	suite.NotNil(boost)
+}
+
 func TestStatusBoostTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusBoostTestSuite))
 }
@@ -27,6 +27,7 @@ import (
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
@@ -80,6 +81,12 @@ func (p *Processor) Create(
 		Text:                     form.Status,
 	}
 
+	if requester.IsSensitized() {
+		// Account has been marked sensitive by
+		// a moderator, so force sensitive flag.
+		status.Sensitive = util.Ptr(true)
+	}
+
 	if form.Poll != nil {
 		// Update the status AS type to "Question".
 		status.ActivityStreamsType = ap.ActivityQuestion
@@ -121,6 +128,19 @@ func (p *Processor) Create(
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	if d := gtscontext.Delegate(ctx); d != nil {
+		// Posted by a delegate acting as the
+		// requester; check DMs are allowed, and
+		// attribute the status to the delegate.
+		if status.Visibility == gtsmodel.VisibilityDirect && !*d.CanManageDMs {
+			const text = "delegate not permitted to manage direct messages"
+			return nil, gtserror.NewErrorForbidden(errors.New(text), text)
+		}
+		status.PostedByAccountID = d.DelegateAccountID
+	}
+
+	processIndexable(form, util.PtrValueOr(requester.Discoverable, false), status)
+
 	if err := processLanguage(form, requester.Settings.Language, status); err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
 	}
@@ -129,16 +149,28 @@ func (p *Processor) Create(
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
-	if status.Poll != nil {
-		// Try to insert the new status poll in the database.
-		if err := p.state.DB.PutPoll(ctx, status.Poll); err != nil {
-			err := gtserror.Newf("error inserting poll in db: %w", err)
-			return nil, gtserror.NewErrorInternalError(err)
+	// Insert the new status along with its poll and mentions
+	// in one transaction, so a failure part way through
+	// doesn't leave any partial rows behind in the database.
+	if err := p.state.DB.RunInTx(ctx, func(tx db.DB) error {
+		if status.Poll != nil {
+			if err := tx.PutPoll(ctx, status.Poll); err != nil {
+				return gtserror.Newf("error inserting poll in db: %w", err)
+			}
 		}
-	}
 
-	// Insert this new status in the database.
-	if err := p.state.DB.PutStatus(ctx, status); err != nil {
+		for _, mention := range status.Mentions {
+			if err := tx.PutMention(ctx, mention); err != nil {
+				return gtserror.Newf("error inserting mention in db: %w", err)
+			}
+		}
+
+		if err := tx.PutStatus(ctx, status); err != nil {
+			return gtserror.Newf("error inserting status in db: %w", err)
+		}
+
+		return nil
+	}); err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
@@ -190,6 +222,21 @@ func (p *Processor) processInReplyTo(ctx context.Context, requester *gtsmodel.Ac
 		return gtserror.NewErrorForbidden(errors.New(text), text)
 	}
 
+	// Check any interaction policy set by remote author.
+	permitted, err := p.filter.StatusReplyPermitted(ctx,
+		requester,
+		inReplyTo,
+	)
+	if err != nil {
+		err := gtserror.Newf("error checking reply policy of status %s: %w", inReplyTo.ID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if !permitted {
+		const text = "in-reply-to status author does not permit you to reply to it"
+		return gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
 	// Set status fields from inReplyTo.
 	status.InReplyToID = inReplyTo.ID
 	status.InReplyTo = inReplyTo
@@ -272,6 +319,11 @@ func (p *Processor) processMediaIDs(ctx context.Context, form *apimodel.Advanced
 			return gtserror.NewErrorBadRequest(errors.New(text), text)
 		}
 
+		if attachment.Processing != gtsmodel.ProcessingStatusProcessed {
+			text := fmt.Sprintf("media %s has not finished processing", mediaID)
+			return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+		}
+
 		if length := len([]rune(attachment.Description)); length < minChars {
 			text := fmt.Sprintf("media %s description too short, at least %d required", mediaID, minChars)
 			return gtserror.NewErrorBadRequest(errors.New(text), text)
@@ -359,6 +411,22 @@ func processVisibility(form *apimodel.AdvancedStatusCreateForm, accountDefaultVi
 	return nil
 }
 
+// processIndexable sets whether search engines may index the
+// web view of status, taking the account's discoverable setting
+// as default. Only public statuses have a web view to index.
+func processIndexable(form *apimodel.AdvancedStatusCreateForm, accountDiscoverable bool, status *gtsmodel.Status) {
+	indexable := accountDiscoverable
+	if form.Indexable != nil {
+		indexable = *form.Indexable
+	}
+
+	if status.Visibility != gtsmodel.VisibilityPublic {
+		indexable = false
+	}
+
+	status.Indexable = &indexable
+}
+
 func processLanguage(form *apimodel.AdvancedStatusCreateForm, accountDefaultLanguage string, status *gtsmodel.Status) error {
 	if form.Language != "" {
 		status.Language = form.Language
@@ -19,6 +19,7 @@ package status_test
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -26,6 +27,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 type StatusCreateTestSuite struct {
@@ -208,6 +210,34 @@ func (suite *StatusCreateTestSuite) TestProcessMediaDescriptionTooShort() {
 	suite.Nil(apiStatus)
 }
 
+func (suite *StatusCreateTestSuite) TestProcessMediaStillProcessing() {
+	ctx := context.Background()
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	// Mark the attachment as still being processed.
+	attachment := suite.testAttachments["local_account_1_unattached_1"]
+	attachment.Processing = gtsmodel.ProcessingStatusProcessing
+	if err := suite.db.UpdateAttachment(ctx, attachment, "processing"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	statusCreateForm := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      "poopoo peepee",
+			MediaIDs:    []string{attachment.ID},
+			Visibility:  apimodel.VisibilityPublic,
+			Language:    "en",
+			ContentType: apimodel.StatusContentTypePlain,
+		},
+	}
+
+	apiStatus, err := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.EqualError(err, "media 01F8MH8RMYQ6MSNY3JM2XT1CQ5 has not finished processing")
+	suite.Nil(apiStatus)
+}
+
 func (suite *StatusCreateTestSuite) TestProcessLanguageWithScriptPart() {
 	ctx := context.Background()
 
@@ -286,6 +316,87 @@ func (suite *StatusCreateTestSuite) TestProcessReplyToUnthreadedRemoteStatus() {
 	suite.NotEmpty(dbStatus.ThreadID)
 }
 
+func (suite *StatusCreateTestSuite) TestProcessReplyForbiddenByInteractionPolicy() {
+	ctx := context.Background()
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+	inReplyTo := suite.testStatuses["remote_account_1_status_1"]
+
+	// Only permit followers of the
+	// remote author to reply to status.
+	inReplyTo.InteractionPolicy = &gtsmodel.InteractionPolicy{
+		CanReply: []string{suite.testAccounts["remote_account_1"].FollowersURI},
+	}
+	if err := suite.state.DB.UpdateStatus(ctx, inReplyTo, "interaction_policy"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	statusCreateForm := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      "boobies",
+			MediaIDs:    []string{},
+			Poll:        nil,
+			InReplyToID: inReplyTo.ID,
+			Sensitive:   false,
+			SpoilerText: "this is a reply",
+			Visibility:  apimodel.VisibilityPublic,
+			ScheduledAt: "",
+			Language:    "en",
+			ContentType: apimodel.StatusContentTypePlain,
+		},
+	}
+
+	apiStatus, errWithCode := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.Nil(apiStatus)
+	suite.EqualError(errWithCode, "in-reply-to status author does not permit you to reply to it")
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+}
+
+func (suite *StatusCreateTestSuite) TestProcessIndexable() {
+	ctx := context.Background()
+
+	// Discoverable account.
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	for _, test := range []struct {
+		visibility apimodel.Visibility
+		indexable  *bool
+		expect     bool
+	}{
+		// Inherited from account.
+		{apimodel.VisibilityPublic, nil, true},
+		// Explicitly opted out.
+		{apimodel.VisibilityPublic, util.Ptr(false), false},
+		// No web view to index.
+		{apimodel.VisibilityUnlisted, util.Ptr(true), false},
+	} {
+		statusCreateForm := &apimodel.AdvancedStatusCreateForm{
+			StatusCreateRequest: apimodel.StatusCreateRequest{
+				Status:      "search for me",
+				Visibility:  test.visibility,
+				Language:    "en",
+				ContentType: apimodel.StatusContentTypePlain,
+			},
+			AdvancedVisibilityFlagsForm: apimodel.AdvancedVisibilityFlagsForm{
+				Indexable: test.indexable,
+			},
+		}
+
+		apiStatus, errWithCode := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+		if errWithCode != nil {
+			suite.FailNow(errWithCode.Error())
+		}
+
+		dbStatus, err := suite.state.DB.GetStatusByID(ctx, apiStatus.ID)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		suite.Equal(test.expect, *dbStatus.Indexable)
+	}
+}
+
 func TestStatusCreateTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusCreateTestSuite))
 }
@@ -24,8 +24,12 @@ import (
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 )
 
@@ -44,12 +48,31 @@ func (p *Processor) Delete(ctx context.Context, requestingAccount *gtsmodel.Acco
 		return nil, gtserror.NewErrorForbidden(errors.New("status doesn't belong to requesting account"))
 	}
 
+	if d := gtscontext.Delegate(ctx); d != nil &&
+		targetStatus.Visibility == gtsmodel.VisibilityDirect && !*d.CanManageDMs {
+		const text = "delegate not permitted to manage direct messages"
+		return nil, gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
 	// Parse the status to API model BEFORE deleting it.
 	apiStatus, errWithCode := p.c.GetAPIStatus(ctx, requestingAccount, targetStatus)
 	if errWithCode != nil {
 		return nil, errWithCode
 	}
 
+	// Keep a tombstone of the status URI, so that any
+	// re-dereferences by remotes learn it's gone for good.
+	// This is only done for genuine deletes by the author,
+	// statuses otherwise removed aren't tombstoned.
+	if err := p.state.DB.PutTombstone(ctx, &gtsmodel.Tombstone{
+		ID:     id.NewULID(),
+		Domain: config.GetHost(),
+		URI:    targetStatus.URI,
+	}); err != nil && !errors.Is(err, db.ErrAlreadyExists) {
+		err := gtserror.Newf("db error putting tombstone: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
 	// Process delete side effects.
 	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
 		APObjectType:   ap.ObjectNote,
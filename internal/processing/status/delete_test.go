@@ -0,0 +1,49 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type StatusDeleteTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusDeleteTestSuite) TestDeleteKeepsTombstone() {
+	ctx := context.Background()
+
+	requestingAccount := suite.testAccounts["local_account_1"]
+	targetStatus := suite.testStatuses["local_account_1_status_1"]
+
+	apiStatus, errWithCode := suite.status.Delete(ctx, requestingAccount, targetStatus.ID)
+	suite.NoError(errWithCode)
+	suite.Equal(targetStatus.ID, apiStatus.ID)
+
+	// A tombstone should now be kept for the status.
+	gone, err := suite.db.TombstoneExistsWithURI(ctx, targetStatus.URI)
+	suite.NoError(err)
+	suite.True(gone)
+}
+
+func TestStatusDeleteTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusDeleteTestSuite))
+}
@@ -0,0 +1,208 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+)
+
+const (
+	// Default width and height in pixels of the
+	// iframe given in oEmbed responses, used unless
+	// the caller asks for something smaller.
+	embedWidth  = 400
+	embedHeight = 400
+
+	// Suggested number of seconds for
+	// callers to cache oEmbed responses.
+	embedCacheAge = 86400
+)
+
+// EmbedGet gets the given status for rendering in a
+// minimal web page that can be embedded in other sites.
+//
+// Only public statuses by local, unsuspended accounts
+// can be embedded, and boosts can't be embedded at all.
+func (p *Processor) EmbedGet(ctx context.Context, targetStatusID string) (*apimodel.Status, gtserror.WithCode) {
+	targetStatus, errWithCode := p.c.GetVisibleTargetStatus(ctx,
+		nil, // requester
+		targetStatusID,
+		nil, // default freshness
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if errWithCode := embeddable(targetStatus); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	webStatus, err := p.converter.StatusToWebStatus(ctx, targetStatus, nil)
+	if err != nil {
+		err = gtserror.Newf("error converting status: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	return webStatus, nil
+}
+
+// OEmbedGet returns an oEmbed response for the status at the
+// given web URL or ActivityPub URI, which other sites can use
+// to embed the status as an iframe of its embeddable web page.
+//
+// See: https://oembed.com/#section2
+func (p *Processor) OEmbedGet(ctx context.Context, form *apimodel.OEmbedRequest) (*apimodel.OEmbed, gtserror.WithCode) {
+	if form.URL == "" {
+		const text = "url must be set"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if form.MaxWidth < 0 || form.MaxHeight < 0 {
+		const text = "maxwidth and maxheight must not be negative"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	statusURL, err := url.Parse(form.URL)
+	if err != nil {
+		const text = "url could not be parsed"
+		return nil, gtserror.NewErrorBadRequest(err, text)
+	}
+
+	if statusURL.Host != config.GetHost() {
+		// Only statuses on this instance can be embedded.
+		const text = "url does not point to a status on this instance"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	// Drop any query or fragment, as
+	// they're not part of status URLs.
+	statusURL.RawQuery = ""
+	statusURL.Fragment = ""
+
+	targetStatus, errWithCode := p.c.GetVisibleTargetStatusBy(ctx,
+		nil, // requester
+		func() (*gtsmodel.Status, error) {
+			// Try the web URL first, as that's what
+			// people are most likely to be sharing.
+			status, err := p.state.DB.GetStatusByURL(ctx, statusURL.String())
+			if err != nil && !errors.Is(err, db.ErrNoEntries) {
+				return nil, err
+			}
+
+			if status != nil {
+				return status, nil
+			}
+
+			return p.state.DB.GetStatusByURI(ctx, statusURL.String())
+		},
+		nil, // default freshness
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if errWithCode := embeddable(targetStatus); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	instance, err := p.state.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		err := gtserror.Newf("db error getting instance: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Use default dimensions unless
+	// smaller dimensions are requested.
+	width, height := embedWidth, embedHeight
+	if form.MaxWidth != 0 && form.MaxWidth < width {
+		width = form.MaxWidth
+	}
+	if form.MaxHeight != 0 && form.MaxHeight < height {
+		height = form.MaxHeight
+	}
+
+	account := targetStatus.Account
+	authorName := account.DisplayName
+	if authorName == "" {
+		authorName = account.Username
+	}
+
+	title := "Post by @" + account.Username + "@" + config.GetAccountDomain()
+
+	// Embed the status as an iframe sandboxed
+	// without scripts, allowing only links
+	// in the status to be opened in new tabs.
+	iframe := fmt.Sprintf(
+		`<iframe src="%s" class="gotosocial-embed" title="%s" width="%d" height="%d" style="max-width: 100%%; border: 0" sandbox="allow-popups allow-popups-to-escape-sandbox" loading="lazy"></iframe>`,
+		html.EscapeString(uris.GenerateURIForEmbed(targetStatus.ID)),
+		html.EscapeString(title),
+		width,
+		height,
+	)
+
+	return &apimodel.OEmbed{
+		Type:         "rich",
+		Version:      "1.0",
+		Title:        title,
+		AuthorName:   authorName,
+		AuthorURL:    account.URL,
+		ProviderName: instance.Title,
+		ProviderURL:  config.GetProtocol() + "://" + config.GetHost(),
+		CacheAge:     embedCacheAge,
+		HTML:         iframe,
+		Width:        width,
+		Height:       height,
+	}, nil
+}
+
+// embeddable returns a not found error if the given
+// status, which must already be visible to the public,
+// should nevertheless not be embedded in other sites.
+func embeddable(status *gtsmodel.Status) gtserror.WithCode {
+	const text = "target status not found"
+
+	switch {
+	case !status.IsLocal():
+		// Embeds are served from the author's
+		// instance, so only embed local statuses.
+		err := fmt.Errorf("status %s is not local", status.ID)
+		return gtserror.NewErrorNotFound(err, text)
+
+	case status.BoostOfID != "":
+		// Don't embed boost wrappers, as
+		// they have no content of their own.
+		err := fmt.Errorf("status %s is a boost", status.ID)
+		return gtserror.NewErrorNotFound(err, text)
+
+	case status.Account == nil || status.Account.IsSuspended():
+		err := fmt.Errorf("author of status %s is suspended", status.ID)
+		return gtserror.NewErrorNotFound(err, text)
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type StatusEmbedTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusEmbedTestSuite) TestOEmbedGetWebURL() {
+	var (
+		ctx          = context.Background()
+		targetStatus = suite.testStatuses["local_account_1_status_1"]
+	)
+
+	oEmbed, errWithCode := suite.status.OEmbedGet(ctx, &apimodel.OEmbedRequest{
+		URL: targetStatus.URL,
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Equal(&apimodel.OEmbed{
+		Type:         "rich",
+		Version:      "1.0",
+		Title:        "Post by @the_mighty_zork@localhost:8080",
+		AuthorName:   "original zork (he/they)",
+		AuthorURL:    "http://localhost:8080/@the_mighty_zork",
+		ProviderName: "GoToSocial Testrig Instance",
+		ProviderURL:  "http://localhost:8080",
+		CacheAge:     86400,
+		HTML:         `<iframe src="http://localhost:8080/embed/statuses/01F8MHAMCHF6Y650WCRSCP4WMY" class="gotosocial-embed" title="Post by @the_mighty_zork@localhost:8080" width="400" height="400" style="max-width: 100%; border: 0" sandbox="allow-popups allow-popups-to-escape-sandbox" loading="lazy"></iframe>`,
+		Width:        400,
+		Height:       400,
+	}, oEmbed)
+}
+
+func (suite *StatusEmbedTestSuite) TestOEmbedGetURIMaxSize() {
+	var (
+		ctx          = context.Background()
+		targetStatus = suite.testStatuses["local_account_1_status_1"]
+	)
+
+	// Look up by AP URI, and ask for a narrower
+	// but taller embed than the default size.
+	oEmbed, errWithCode := suite.status.OEmbedGet(ctx, &apimodel.OEmbedRequest{
+		URL:       targetStatus.URI,
+		MaxWidth:  300,
+		MaxHeight: 1000,
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Equal(300, oEmbed.Width)
+	suite.Equal(400, oEmbed.Height)
+	suite.Contains(oEmbed.HTML, `width="300" height="400"`)
+}
+
+func (suite *StatusEmbedTestSuite) TestOEmbedGetNotEmbeddable() {
+	ctx := context.Background()
+
+	for _, test := range []struct {
+		url  string
+		code int
+	}{
+		{
+			// Not public.
+			url:  suite.testStatuses["local_account_1_status_2"].URL,
+			code: http.StatusNotFound,
+		},
+		{
+			// Boost wrapper.
+			url:  suite.testStatuses["admin_account_status_4"].URI,
+			code: http.StatusNotFound,
+		},
+		{
+			// Status on another instance.
+			url:  suite.testStatuses["remote_account_1_status_1"].URL,
+			code: http.StatusNotFound,
+		},
+		{
+			// Not a status.
+			url:  "http://localhost:8080/@the_mighty_zork",
+			code: http.StatusNotFound,
+		},
+		{
+			// No URL at all.
+			url:  "",
+			code: http.StatusBadRequest,
+		},
+	} {
+		_, errWithCode := suite.status.OEmbedGet(ctx, &apimodel.OEmbedRequest{
+			URL: test.url,
+		})
+		if suite.NotNil(errWithCode, test.url) {
+			suite.Equal(test.code, errWithCode.Code(), test.url)
+		}
+	}
+}
+
+func (suite *StatusEmbedTestSuite) TestEmbedGet() {
+	var (
+		ctx          = context.Background()
+		targetStatus = suite.testStatuses["local_account_1_status_1"]
+	)
+
+	webStatus, errWithCode := suite.status.EmbedGet(ctx, targetStatus.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(targetStatus.ID, webStatus.ID)
+
+	// Boosts can't be embedded on their own.
+	_, errWithCode = suite.status.EmbedGet(ctx, suite.testStatuses["admin_account_status_4"].ID)
+	if suite.NotNil(errWithCode) {
+		suite.Equal(http.StatusNotFound, errWithCode.Code())
+	}
+}
+
+func TestStatusEmbedTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusEmbedTestSuite))
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 )
 
@@ -148,8 +149,8 @@ func (p *Processor) FaveRemove(ctx context.Context, requestingAccount *gtsmodel.
 	return p.c.GetAPIStatus(ctx, requestingAccount, targetStatus)
 }
 
-// FavedBy returns a slice of accounts that have liked the given status, filtered according to privacy settings.
-func (p *Processor) FavedBy(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) ([]*apimodel.Account, gtserror.WithCode) {
+// FavedBy returns a page of accounts that have liked the given status, filtered according to privacy settings.
+func (p *Processor) FavedBy(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string, page *paging.Page) (*apimodel.PageableResponse, gtserror.WithCode) {
 	targetStatus, errWithCode := p.c.GetVisibleTargetStatus(ctx,
 		requestingAccount,
 		targetStatusID,
@@ -159,36 +160,53 @@ func (p *Processor) FavedBy(ctx context.Context, requestingAccount *gtsmodel.Acc
 		return nil, errWithCode
 	}
 
-	statusFaves, err := p.state.DB.GetStatusFaves(ctx, targetStatus.ID)
-	if err != nil {
-		return nil, gtserror.NewErrorNotFound(fmt.Errorf("FavedBy: error seeing who faved status: %s", err))
+	statusFaves, err := p.state.DB.GetStatusFavesPage(ctx, targetStatus.ID, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting status faves: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
 	}
 
-	// For each fave, ensure that we're only showing
-	// the requester accounts that they don't block,
-	// and which don't block them.
-	apiAccounts := make([]*apimodel.Account, 0, len(statusFaves))
-	for _, fave := range statusFaves {
-		if blocked, err := p.state.DB.IsEitherBlocked(ctx, requestingAccount.ID, fave.AccountID); err != nil {
-			err = fmt.Errorf("FavedBy: error checking blocks: %w", err)
-			return nil, gtserror.NewErrorInternalError(err)
-		} else if blocked {
-			continue
-		}
+	// Check for empty response.
+	count := len(statusFaves)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
 
-		if fave.Account == nil {
-			// Account isn't set for some reason, just skip.
-			log.WithContext(ctx).WithField("fave", fave).Warn("fave had no associated account")
-			continue
-		}
+	// Get the lowest and highest
+	// ID values, used for paging.
+	lo := statusFaves[count-1].ID
+	hi := statusFaves[0].ID
+
+	// Func to fetch fave author at index, ensuring
+	// that we're only showing the requester accounts
+	// that they don't block, and which don't block them.
+	getIdx := func(i int) *gtsmodel.Account {
+		fave := statusFaves[i]
 
-		apiAccount, err := p.converter.AccountToAPIAccountPublic(ctx, fave.Account)
+		blocked, err := p.state.DB.IsEitherBlocked(ctx, requestingAccount.ID, fave.AccountID)
 		if err != nil {
-			err = fmt.Errorf("FavedBy: error converting account %s to frontend representation: %w", fave.AccountID, err)
-			return nil, gtserror.NewErrorInternalError(err)
+			log.Errorf(ctx, "error checking blocks: %v", err)
+			return nil
 		}
-		apiAccounts = append(apiAccounts, apiAccount)
+
+		if blocked {
+			return nil
+		}
+
+		return fave.Account
 	}
 
-	return apiAccounts, nil
+	// Get a filtered slice of public API account models.
+	items := p.c.GetVisibleAPIAccountsPaged(ctx,
+		requestingAccount,
+		getIdx,
+		count,
+	)
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/statuses/" + targetStatus.ID + "/favourited_by",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
 }
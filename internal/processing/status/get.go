@@ -302,10 +302,115 @@ func (p *Processor) ContextGet(ctx context.Context, requestingAccount *gtsmodel.
 	return p.contextGet(ctx, requestingAccount, targetStatusID, convert)
 }
 
+// webReplyCollapseDepth is the depth below a
+// thread's target status, beyond which replies
+// are rendered collapsed by default on the web.
+const webReplyCollapseDepth = 3
+
 // WebContextGet is like ContextGet, but is explicitly
 // for viewing statuses via the unauthenticated web UI.
 //
-// TODO: a more advanced threading model could be implemented here.
-func (p *Processor) WebContextGet(ctx context.Context, targetStatusID string) (*apimodel.Context, gtserror.WithCode) {
-	return p.contextGet(ctx, nil, targetStatusID, p.converter.StatusToWebStatus)
+// Descendants are paged in thread order, starting after
+// the descendant with the given ID (or from the start if
+// empty), and are nested under any parents on the same page.
+func (p *Processor) WebContextGet(
+	ctx context.Context,
+	targetStatusID string,
+	after string,
+	limit int,
+) (*apimodel.WebContext, gtserror.WithCode) {
+	context, errWithCode := p.contextGet(ctx, nil, targetStatusID, p.converter.StatusToWebStatus)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	webContext := PageWebReplies(context.Descendants, targetStatusID, after, limit)
+	webContext.Ancestors = context.Ancestors
+	return webContext, nil
+}
+
+// PageWebReplies pages the given topologically sorted descendants of
+// the target status, starting after the descendant with given ID (or
+// from the start if empty), and nests them under any parents on the
+// same page. The returned WebContext has no ancestors set.
+func PageWebReplies(
+	descendants []apimodel.Status,
+	targetStatusID string,
+	after string,
+	limit int,
+) *apimodel.WebContext {
+	// Calculate the depth of each descendant below
+	// the target. Descendants are topologically sorted,
+	// so parents are always seen before their children.
+	depths := make(map[string]int, len(descendants)+1)
+	depths[targetStatusID] = 0
+	for _, descendant := range descendants {
+		depth := 1
+		if descendant.InReplyToID != nil {
+			if d, ok := depths[*descendant.InReplyToID]; ok {
+				depth = d + 1
+			}
+		}
+		depths[descendant.ID] = depth
+	}
+
+	// Find start of page
+	// after the given ID.
+	var start int
+	if after != "" {
+		for i := range descendants {
+			if descendants[i].ID == after {
+				start = i + 1
+				break
+			}
+		}
+	}
+
+	end := min(start+limit, len(descendants))
+
+	webContext := &apimodel.WebContext{
+		DescendantsCount: len(descendants),
+		HasPrev:          start > 0,
+	}
+
+	if prev := start - limit; prev > 0 {
+		// Previous page starts after
+		// the descendant before it.
+		webContext.PrevAfter = descendants[prev-1].ID
+	}
+
+	if end < len(descendants) {
+		// Next page starts after
+		// last on this page.
+		webContext.NextAfter = descendants[end-1].ID
+	}
+
+	// Nest replies on this page under
+	// their parents, where these are
+	// also on this page.
+	replies := make(map[string]*apimodel.WebReply, end-start)
+	for i := start; i < end; i++ {
+		descendant := &descendants[i]
+		depth := depths[descendant.ID]
+
+		reply := &apimodel.WebReply{
+			Status:    descendant,
+			Depth:     depth,
+			Collapsed: depth >= webReplyCollapseDepth,
+		}
+		replies[descendant.ID] = reply
+
+		var parent *apimodel.WebReply
+		if descendant.InReplyToID != nil {
+			parent = replies[*descendant.InReplyToID]
+		}
+
+		if parent != nil {
+			parent.Replies = append(parent.Replies, reply)
+		} else {
+			webContext.Descendants = append(webContext.Descendants, reply)
+		}
+	}
+
+	return webContext
 }
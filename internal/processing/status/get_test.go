@@ -195,3 +195,106 @@ func (suite *topoSortTestSuite) TestNil() {
 func TestTopoSortTestSuite(t *testing.T) {
 	suite.Run(t, &topoSortTestSuite{})
 }
+
+type webRepliesTestSuite struct {
+	suite.Suite
+}
+
+// webRepliesThread returns topologically sorted
+// replies to target "T", in the following shape:
+//
+//	T
+//	├── A
+//	│   └── B
+//	│       └── C
+//	│           └── D
+//	└── E
+func webRepliesThread() []apimodel.Status {
+	t, a, b, c := "T", "A", "B", "C"
+	return []apimodel.Status{
+		{ID: "A", InReplyToID: &t},
+		{ID: "B", InReplyToID: &a},
+		{ID: "C", InReplyToID: &b},
+		{ID: "D", InReplyToID: &c},
+		{ID: "E", InReplyToID: &t},
+	}
+}
+
+func (suite *webRepliesTestSuite) TestAllOnOnePage() {
+	context := status.PageWebReplies(webRepliesThread(), "T", "", 10)
+
+	suite.Equal(5, context.DescendantsCount)
+	suite.False(context.HasPrev)
+	suite.Empty(context.PrevAfter)
+	suite.Empty(context.NextAfter)
+
+	// A and E at top level.
+	suite.Len(context.Descendants, 2)
+	a, e := context.Descendants[0], context.Descendants[1]
+	suite.Equal("A", a.Status.ID)
+	suite.Equal(1, a.Depth)
+	suite.False(a.Collapsed)
+	suite.Equal("E", e.Status.ID)
+	suite.Equal(1, e.Depth)
+	suite.Empty(e.Replies)
+
+	// B, C, D nested under A.
+	suite.Len(a.Replies, 1)
+	b := a.Replies[0]
+	suite.Equal("B", b.Status.ID)
+	suite.Equal(2, b.Depth)
+	suite.False(b.Collapsed)
+
+	suite.Len(b.Replies, 1)
+	c := b.Replies[0]
+	suite.Equal("C", c.Status.ID)
+	suite.Equal(3, c.Depth)
+	suite.True(c.Collapsed)
+
+	suite.Len(c.Replies, 1)
+	d := c.Replies[0]
+	suite.Equal("D", d.Status.ID)
+	suite.Equal(4, d.Depth)
+	suite.True(d.Collapsed)
+}
+
+func (suite *webRepliesTestSuite) TestPaged() {
+	// Second page, parent
+	// B not on this page.
+	context := status.PageWebReplies(webRepliesThread(), "T", "B", 2)
+
+	suite.True(context.HasPrev)
+	suite.Empty(context.PrevAfter)
+	suite.Equal("D", context.NextAfter)
+
+	suite.Len(context.Descendants, 1)
+	c := context.Descendants[0]
+	suite.Equal("C", c.Status.ID)
+	suite.Equal(3, c.Depth)
+	suite.Len(c.Replies, 1)
+	suite.Equal("D", c.Replies[0].Status.ID)
+
+	// Third (last) page.
+	context = status.PageWebReplies(webRepliesThread(), "T", context.NextAfter, 2)
+
+	suite.True(context.HasPrev)
+	suite.Equal("B", context.PrevAfter)
+	suite.Empty(context.NextAfter)
+
+	suite.Len(context.Descendants, 1)
+	suite.Equal("E", context.Descendants[0].Status.ID)
+}
+
+func (suite *webRepliesTestSuite) TestUnknownAfter() {
+	// Unknown ID starts from first page.
+	context := status.PageWebReplies(webRepliesThread(), "T", "Z", 2)
+
+	suite.False(context.HasPrev)
+	suite.Equal("B", context.NextAfter)
+	suite.Len(context.Descendants, 1)
+	suite.Equal("A", context.Descendants[0].Status.ID)
+}
+
+func TestWebRepliesTestSuite(t *testing.T) {
+	suite.Run(t, &webRepliesTestSuite{})
+}
@@ -0,0 +1,91 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/federation/dereferencing"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+const (
+	// refreshLimit is the number of on-demand refreshes
+	// one account may do within refreshLimitPeriod.
+	refreshLimit       = 10
+	refreshLimitPeriod = 5 * time.Minute
+)
+
+// Refresh re-dereferences the given remote status from its origin
+// on behalf of requestingAccount, updating content, poll tallies
+// and edit state, and returns the up-to-date status.
+//
+// Refreshes are rate limited per requesting account.
+func (p *Processor) Refresh(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) (*apimodel.Status, gtserror.WithCode) {
+	targetStatus, errWithCode := p.c.GetVisibleTargetStatus(ctx,
+		requestingAccount,
+		targetStatusID,
+		nil, // default freshness
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Refresh the boosted status
+	// rather than the boost wrapper.
+	targetStatus, errWithCode = p.c.UnwrapIfBoost(ctx,
+		requestingAccount,
+		targetStatus,
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if targetStatus.IsLocal() {
+		const text = "target status is local, nothing to refresh"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	limit, err := p.refreshLimiter.Get(ctx, requestingAccount.ID)
+	if err != nil {
+		err := gtserror.Newf("error checking refresh limit: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if limit.Reached {
+		const text = "too many status refreshes, try again later"
+		return nil, gtserror.NewErrorTooManyRequests(errors.New(text), text)
+	}
+
+	latest, _, err := p.federator.RefreshStatus(ctx,
+		requestingAccount.Username,
+		targetStatus,
+		nil,
+		dereferencing.Freshest,
+	)
+	if err != nil {
+		err := gtserror.Newf("error refreshing status %s: %w", targetStatus.URI, err)
+		return nil, gtserror.NewErrorInternalError(err, "could not refresh status from its origin")
+	}
+
+	return p.c.GetAPIStatus(ctx, requestingAccount, latest)
+}
@@ -0,0 +1,80 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+// sitemapLimit is the maximum number of
+// statuses to include in the sitemap.
+const sitemapLimit = 1000
+
+// sitemapURLSet is the root
+// element of an XML sitemap.
+//
+// See: https://www.sitemaps.org/protocol.html
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+// sitemapURL is a single
+// entry of an XML sitemap.
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// WebSitemap returns an XML sitemap listing the web URLs of
+// the most recent local, public statuses which their authors
+// have marked as indexable by search engines.
+func (p *Processor) WebSitemap(ctx context.Context) ([]byte, gtserror.WithCode) {
+	statuses, err := p.state.DB.GetIndexableStatuses(ctx, sitemapLimit)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting indexable statuses: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	urlSet := sitemapURLSet{
+		Xmlns: "http://www.sitemaps.org/schemas/sitemap/0.9",
+		URLs:  make([]sitemapURL, 0, len(statuses)),
+	}
+
+	for _, status := range statuses {
+		urlSet.URLs = append(urlSet.URLs, sitemapURL{
+			Loc:     status.URL,
+			LastMod: status.UpdatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+
+	b, err := xml.MarshalIndent(urlSet, "", "  ")
+	if err != nil {
+		err := gtserror.Newf("error marshaling sitemap: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return append([]byte(xml.Header), b...), nil
+}
@@ -26,6 +26,8 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/ulule/limiter/v3"
+	"github.com/ulule/limiter/v3/drivers/store/memory"
 )
 
 type Processor struct {
@@ -39,6 +41,10 @@ type Processor struct {
 	formatter    *text.Formatter
 	parseMention gtsmodel.ParseMentionFunc
 
+	// per-account limiter for
+	// on-demand status refreshes
+	refreshLimiter *limiter.Limiter
+
 	// other processors
 	polls *polls.Processor
 }
@@ -61,6 +67,13 @@ func New(
 		filter:       filter,
 		formatter:    text.NewFormatter(state.DB),
 		parseMention: parseMention,
-		polls:        polls,
+		refreshLimiter: limiter.New(
+			memory.NewStore(),
+			limiter.Rate{
+				Period: refreshLimitPeriod,
+				Limit:  refreshLimit,
+			},
+		),
+		polls: polls,
 	}
 }
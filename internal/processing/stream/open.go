@@ -34,5 +34,14 @@ func (p *Processor) Open(ctx context.Context, account *gtsmodel.Account, streamT
 		{"streamType", streamType},
 	}...)
 	l.Debug("received open stream request")
-	return p.streams.Open(account.ID, streamType), nil
+
+	str, err := p.streams.Open(account.ID, streamType)
+	if err != nil {
+		// Only possible errors
+		// are stream limits.
+		l.Debugf("could not open stream: %v", err)
+		return nil, gtserror.NewErrorTooManyRequests(err, err.Error())
+	}
+
+	return str, nil
 }
@@ -19,9 +19,12 @@ package stream_test
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	streampkg "github.com/superseriousbusiness/gotosocial/internal/stream"
 )
 
 type OpenStreamTestSuite struct {
@@ -35,6 +38,61 @@ func (suite *OpenStreamTestSuite) TestOpenStream() {
 	suite.NoError(errWithCode)
 }
 
+func (suite *OpenStreamTestSuite) TestOpenStreamTooManyForAccount() {
+	var (
+		ctx      = context.Background()
+		account1 = suite.testAccounts["local_account_1"]
+		account2 = suite.testAccounts["local_account_2"]
+		max      = config.GetAdvancedStreamingMaxConnectionsPerAccount()
+		streams  = make([]*streampkg.Stream, 0, max)
+	)
+
+	// Open the max allowed streams for account 1.
+	for i := 0; i < max; i++ {
+		str, errWithCode := suite.streamProcessor.Open(ctx, account1, "user")
+		if errWithCode != nil {
+			suite.FailNow(errWithCode.Error())
+		}
+		streams = append(streams, str)
+	}
+
+	// One more should be refused.
+	_, errWithCode := suite.streamProcessor.Open(ctx, account1, "user")
+	suite.Equal(http.StatusTooManyRequests, errWithCode.Code())
+
+	// Other accounts should be unaffected.
+	_, errWithCode = suite.streamProcessor.Open(ctx, account2, "user")
+	suite.NoError(errWithCode)
+
+	// Closing a stream should free up a slot.
+	streams[0].Close()
+	_, errWithCode = suite.streamProcessor.Open(ctx, account1, "user")
+	suite.NoError(errWithCode)
+}
+
+func (suite *OpenStreamTestSuite) TestSlowStreamDropped() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+
+	str, errWithCode := suite.streamProcessor.Open(ctx, account, "user")
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Send more messages than the stream can queue,
+	// without reading any; this shouldn't block.
+	for i := 0; i <= config.GetAdvancedStreamingQueueSize(); i++ {
+		suite.streamProcessor.Delete(ctx, "01FVW7JHQFSFK166WWKR8CBA6M")
+	}
+
+	// Stream should have been dropped.
+	suite.True(str.Dropped())
+
+	// And no longer be counted.
+	_, errWithCode = suite.streamProcessor.Open(ctx, account, "user")
+	suite.NoError(errWithCode)
+}
+
 func TestOpenStreamTestSuite(t *testing.T) {
 	suite.Run(t, &OpenStreamTestSuite{})
 }
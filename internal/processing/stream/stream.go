@@ -18,6 +18,7 @@
 package stream
 
 import (
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/stream"
@@ -33,6 +34,10 @@ func New(state *state.State, oauthServer oauth.Server) Processor {
 	return Processor{
 		state:       state,
 		oauthServer: oauthServer,
-		streams:     stream.Streams{},
+		streams: stream.Streams{
+			MaxStreams:        config.GetAdvancedStreamingMaxConnections(),
+			MaxAccountStreams: config.GetAdvancedStreamingMaxConnectionsPerAccount(),
+			QueueSize:         config.GetAdvancedStreamingQueueSize(),
+		},
 	}
 }
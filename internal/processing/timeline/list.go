@@ -126,6 +126,10 @@ func ListTimelineStatusPrepare(state *state.State, converter *typeutils.Converte
 		}
 		compiledMutes := usermute.NewCompiledUserMuteList(mutes)
 
+		// Prepared statuses are cached for all further requests, so
+		// ensure no fields requested to be omitted are left out here.
+		ctx = gtscontext.SetOmitFields(ctx, nil)
+
 		return converter.StatusToAPIStatus(ctx, status, requestingAccount, statusfilter.FilterContextHome, filters, compiledMutes)
 	}
 }
@@ -163,7 +167,15 @@ func (p *Processor) ListTimelineGet(ctx context.Context, authed *oauth.Auth, lis
 	)
 
 	for i := range statuses {
-		items[i] = statuses[i]
+		item := statuses[i]
+
+		if status, ok := item.(*apimodel.Status); ok {
+			// Prepared statuses are shared with the timeline
+			// cache, so this returns a copy if fields are omitted.
+			item = p.converter.OmitStatusFields(ctx, status)
+		}
+
+		items[i] = item
 	}
 
 	return util.PackagePageableResponse(util.PageableResponseParams{
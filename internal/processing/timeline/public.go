@@ -41,6 +41,8 @@ func (p *Processor) PublicTimelineGet(
 	minID string,
 	limit int,
 	local bool,
+	remote bool,
+	onlyMedia bool,
 ) (*apimodel.PageableResponse, gtserror.WithCode) {
 	const maxAttempts = 3
 	var (
@@ -75,7 +77,7 @@ outer:
 		// Select slightly more than the limit to try to avoid situations where
 		// we filter out all the entries, and have to make another db call.
 		// It's cheaper to select more in 1 query than it is to do multiple queries.
-		statuses, err := p.state.DB.GetPublicTimeline(ctx, maxID, sinceID, minID, limit+5, local)
+		statuses, err := p.state.DB.GetPublicTimeline(ctx, maxID, sinceID, minID, limit+5, local, remote, onlyMedia)
 		if err != nil && !errors.Is(err, db.ErrNoEntries) {
 			err = gtserror.Newf("db error getting statuses: %w", err)
 			return nil, gtserror.NewErrorInternalError(err)
@@ -108,6 +110,18 @@ outer:
 				continue inner
 			}
 
+			// Check the author hasn't opted out
+			// of this particular public timeline.
+			optedIn, err := p.filter.StatusPublicTimelineOptedIn(ctx, s, local && !remote)
+			if err != nil {
+				log.Errorf(ctx, "error checking public timeline opt-in: %v", err)
+				continue inner
+			}
+
+			if !optedIn {
+				continue inner
+			}
+
 			apiStatus, err := p.converter.StatusToAPIStatus(ctx, s, requester, statusfilter.FilterContextPublic, filters, compiledMutes)
 			if errors.Is(err, statusfilter.ErrHideStatus) {
 				continue
@@ -157,14 +171,99 @@ outer:
 		}
 	}
 
+	extraQueryParams := []string{
+		"local=" + strconv.FormatBool(local),
+	}
+
+	if remote {
+		extraQueryParams = append(extraQueryParams, "remote=true")
+	}
+
+	if onlyMedia {
+		extraQueryParams = append(extraQueryParams, "only_media=true")
+	}
+
+	return util.PackagePageableResponse(util.PageableResponseParams{
+		Items:            items,
+		Path:             "/api/v1/timelines/public",
+		NextMaxIDValue:   nextMaxIDValue,
+		PrevMinIDValue:   prevMinIDValue,
+		Limit:            limit,
+		ExtraQueryParams: extraQueryParams,
+	})
+}
+
+// webTimelineLength is the number of statuses
+// to show per page of web public timelines.
+const webTimelineLength = 20
+
+// WebPublicTimelineGet returns a page of web statuses from the public
+// timeline, for viewing without an account at /public, or, if local is
+// true, only statuses by local accounts, for viewing at /local.
+func (p *Processor) WebPublicTimelineGet(
+	ctx context.Context,
+	maxID string,
+	local bool,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	statuses, err := p.state.DB.GetPublicTimeline(ctx, maxID, "", "", webTimelineLength, local, false, false)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting statuses: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(statuses)
+	if count == 0 {
+		return util.EmptyPageableResponse(), nil
+	}
+
+	var (
+		items = make([]any, 0, count)
+
+		// Set next value before filtering,
+		// so caller can still page properly.
+		nextMaxIDValue = statuses[count-1].ID
+	)
+
+	for _, s := range statuses {
+		// Check status is visible to logged-out
+		// viewers, which web viewers always are.
+		timelineable, err := p.filter.StatusPublicTimelineable(ctx, nil, s)
+		if err != nil {
+			log.Errorf(ctx, "error checking status visibility: %v", err)
+			continue
+		}
+
+		if !timelineable {
+			continue
+		}
+
+		optedIn, err := p.filter.StatusPublicTimelineOptedIn(ctx, s, local)
+		if err != nil {
+			log.Errorf(ctx, "error checking public timeline opt-in: %v", err)
+			continue
+		}
+
+		if !optedIn {
+			continue
+		}
+
+		webStatus, err := p.converter.StatusToWebStatus(ctx, s, nil)
+		if err != nil {
+			log.Errorf(ctx, "error converting to web status: %v", err)
+			continue
+		}
+
+		items = append(items, webStatus)
+	}
+
+	path := "/public"
+	if local {
+		path = "/local"
+	}
+
 	return util.PackagePageableResponse(util.PageableResponseParams{
 		Items:          items,
-		Path:           "/api/v1/timelines/public",
+		Path:           path,
 		NextMaxIDValue: nextMaxIDValue,
-		PrevMinIDValue: prevMinIDValue,
-		Limit:          limit,
-		ExtraQueryParams: []string{
-			"local=" + strconv.FormatBool(local),
-		},
 	})
 }
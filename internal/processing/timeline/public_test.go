@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 )
 
 type PublicTestSuite struct {
@@ -47,6 +48,8 @@ func (suite *PublicTestSuite) TestPublicTimelineGet() {
 		minID,
 		limit,
 		local,
+		false, // remote
+		false, // onlyMedia
 	)
 
 	// We should have some statuses,
@@ -80,6 +83,8 @@ func (suite *PublicTestSuite) TestPublicTimelineGetNotEmpty() {
 		minID,
 		limit,
 		local,
+		false, // remote
+		false, // onlyMedia
 	)
 
 	// We should have a status even though
@@ -91,6 +96,31 @@ func (suite *PublicTestSuite) TestPublicTimelineGetNotEmpty() {
 	suite.Equal(`http://localhost:8080/api/v1/timelines/public?limit=1&min_id=01HE7XJ1CG84TBKH5V9XKBVGF5&local=false`, resp.PrevLink)
 }
 
+func (suite *PublicTestSuite) TestWebPublicTimelineGet() {
+	resp, errWithCode := suite.timeline.WebPublicTimelineGet(context.Background(), "", false)
+	suite.NoError(errWithCode)
+	suite.NotEmpty(resp.Items)
+	suite.Contains(resp.NextLink, "/public?")
+
+	for _, item := range resp.Items {
+		status := item.(*apimodel.Status)
+		suite.Equal(apimodel.VisibilityPublic, status.Visibility)
+	}
+}
+
+func (suite *PublicTestSuite) TestWebPublicTimelineGetLocal() {
+	resp, errWithCode := suite.timeline.WebPublicTimelineGet(context.Background(), "", true)
+	suite.NoError(errWithCode)
+	suite.NotEmpty(resp.Items)
+	suite.Contains(resp.NextLink, "/local?")
+
+	for _, item := range resp.Items {
+		status := item.(*apimodel.Status)
+		suite.Equal(apimodel.VisibilityPublic, status.Visibility)
+		suite.True(status.Local)
+	}
+}
+
 func TestPublicTestSuite(t *testing.T) {
 	suite.Run(t, new(PublicTestSuite))
 }
@@ -0,0 +1,125 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package timeline
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/gorilla/feeds"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// tagFeedLength is the number of
+// statuses to include in tag feeds.
+const tagFeedLength = 20
+
+// TagFeedGet returns a feed of the latest public posts by local
+// accounts using the given hashtag, for serving as RSS or Atom.
+// Only hashtags set in instance-expose-tag-feeds have feeds.
+func (p *Processor) TagFeedGet(ctx context.Context, tagName string) (*feeds.Feed, gtserror.WithCode) {
+	tag, errWithCode := p.getTag(ctx, tagName)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if tag == nil || !*tag.Useable || !*tag.Listable {
+		err := gtserror.New("tag was not found, or not useable/listable on this instance")
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if !p.TagFeedExposed(tag.Name) {
+		err := gtserror.Newf("feed not exposed for tag %s", tag.Name)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	feed := &feeds.Feed{
+		Title:       "#" + tag.Name + " on " + config.GetHost(),
+		Description: "Public posts from " + config.GetHost() + " tagged #" + tag.Name,
+		Link:        &feeds.Link{Href: uris.URIForTag(tag.Name)},
+		// Determinate value for cacheing
+		// if there are no statuses yet.
+		Updated: tag.CreatedAt,
+	}
+
+	statuses, err := p.state.DB.GetLocalTagTimeline(ctx, tag.ID, tagFeedLength)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting statuses: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	for _, status := range statuses {
+		// Skip accounts who don't
+		// want logged-out viewers.
+		if status.Account == nil {
+			status.Account, err = p.state.DB.GetAccountByID(ctx, status.AccountID)
+			if err != nil {
+				err = gtserror.Newf("db error getting status author: %w", err)
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+		}
+
+		if status.Account.IsSuspended() {
+			continue
+		}
+
+		if status.Account.Settings == nil {
+			status.Account.Settings, err = p.state.DB.GetAccountSettings(ctx, status.AccountID)
+			if err != nil {
+				err = gtserror.Newf("db error getting status author settings: %w", err)
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+		}
+
+		if util.PtrValueOr(status.Account.Settings.HideStatusesLoggedOut, false) {
+			continue
+		}
+
+		item, err := p.converter.StatusToRSSItem(ctx, status)
+		if err != nil {
+			err = gtserror.Newf("error converting status to feed item: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if len(feed.Items) == 0 {
+			// Newest first, so
+			// use for updated.
+			feed.Updated = status.CreatedAt
+		}
+
+		feed.Add(item)
+	}
+
+	return feed, nil
+}
+
+// TagFeedExposed returns whether the feed of the
+// given (normalized) hashtag is set to be exposed.
+func (p *Processor) TagFeedExposed(tagName string) bool {
+	exposed := config.GetInstanceExposeTagFeeds()
+	return slices.Contains(exposed, "*") ||
+		slices.ContainsFunc(exposed, func(name string) bool {
+			return strings.EqualFold(strings.TrimPrefix(name, "#"), tagName)
+		})
+}
@@ -0,0 +1,66 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package timeline_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+type TagFeedTestSuite struct {
+	TimelineStandardTestSuite
+}
+
+func (suite *TagFeedTestSuite) TestTagFeedGet() {
+	feed, errWithCode := suite.timeline.TagFeedGet(context.Background(), "welcome")
+	suite.NoError(errWithCode)
+	suite.Equal("#welcome on localhost:8080", feed.Title)
+	suite.Equal("http://localhost:8080/tags/welcome", feed.Link.Href)
+	suite.Len(feed.Items, 1)
+	suite.Equal("http://localhost:8080/@admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R", feed.Items[0].Link.Href)
+}
+
+func (suite *TagFeedTestSuite) TestTagFeedGetNotExposed() {
+	config.SetInstanceExposeTagFeeds([]string{"gardening"})
+
+	_, errWithCode := suite.timeline.TagFeedGet(context.Background(), "welcome")
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *TagFeedTestSuite) TestTagFeedGetWildcard() {
+	config.SetInstanceExposeTagFeeds([]string{"*"})
+
+	feed, errWithCode := suite.timeline.TagFeedGet(context.Background(), "Welcome")
+	suite.NoError(errWithCode)
+	suite.Len(feed.Items, 1)
+}
+
+func (suite *TagFeedTestSuite) TestTagFeedGetUnknownTag() {
+	config.SetInstanceExposeTagFeeds([]string{"*"})
+
+	_, errWithCode := suite.timeline.TagFeedGet(context.Background(), "doesnotexist")
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func TestTagFeedTestSuite(t *testing.T) {
+	suite.Run(t, new(TagFeedTestSuite))
+}
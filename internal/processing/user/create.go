@@ -19,16 +19,23 @@ package user
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/mail"
+	"strings"
 	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/superseriousbusiness/oauth2/v4"
 )
 
@@ -49,29 +56,62 @@ func (p *Processor) Create(
 		regBacklog  = 20
 	)
 
-	// Ensure no more than usersPerDay
-	// have registered in the last 24h.
-	newUsersCount, err := p.state.DB.CountApprovedSignupsSince(ctx, time.Now().Add(-24*time.Hour))
-	if err != nil {
-		err := fmt.Errorf("db error counting new users: %w", err)
-		return nil, gtserror.NewErrorInternalError(err)
+	// If an invite code was given,
+	// make sure it's actually usable.
+	var invite *gtsmodel.Invite
+	if form.InviteCode != "" {
+		var errWithCode gtserror.WithCode
+		invite, errWithCode = p.inviteForSignup(ctx, form.InviteCode)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+	} else if !config.GetAccountsRegistrationOpen() {
+		// Registration is closed
+		// and no invite was given.
+		err := fmt.Errorf("registration is not open for this server")
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
 	}
 
-	if newUsersCount >= usersPerDay {
-		err := fmt.Errorf("this instance has hit its limit of new sign-ups for today; you can try again tomorrow")
-		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	// Invited sign-ups may be approved immediately,
+	// in which case they don't go in the backlog.
+	preApproved := invite != nil && config.GetAccountsInvitesBypassApproval()
+
+	// Invites are limited by their own max uses, so
+	// the daily sign-up limit only applies to others.
+	if invite == nil {
+		// Ensure no more than usersPerDay
+		// have registered in the last 24h.
+		newUsersCount, err := p.state.DB.CountApprovedSignupsSince(ctx, time.Now().Add(-24*time.Hour))
+		if err != nil {
+			err := fmt.Errorf("db error counting new users: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if newUsersCount >= usersPerDay {
+			err := fmt.Errorf("this instance has hit its limit of new sign-ups for today; you can try again tomorrow")
+			return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+		}
 	}
 
-	// Ensure the new users backlog isn't full.
-	backlogLen, err := p.state.DB.CountUnhandledSignups(ctx)
-	if err != nil {
-		err := fmt.Errorf("db error counting registration backlog length: %w", err)
-		return nil, gtserror.NewErrorInternalError(err)
+	if !preApproved {
+		// Ensure the new users backlog isn't full.
+		backlogLen, err := p.state.DB.CountUnhandledSignups(ctx)
+		if err != nil {
+			err := fmt.Errorf("db error counting registration backlog length: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if backlogLen >= regBacklog {
+			err := fmt.Errorf("this instance's sign-up backlog is currently full; you must wait until pending sign-ups are handled by the admin(s)")
+			return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+		}
 	}
 
-	if backlogLen >= regBacklog {
-		err := fmt.Errorf("this instance's sign-up backlog is currently full; you must wait until pending sign-ups are handled by the admin(s)")
-		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	// Ensure this IP / email domain
+	// haven't hit their daily limits.
+	attempt, errWithCode := p.throttleSignup(ctx, form)
+	if errWithCode != nil {
+		return nil, errWithCode
 	}
 
 	emailAvailable, err := p.state.DB.IsEmailAvailable(ctx, form.Email)
@@ -109,20 +149,45 @@ func (p *Processor) Create(
 		}
 	}
 
+	var inviteID string
+	if invite != nil {
+		// Take one use of the invite. This will
+		// fail if it was used up in the meantime.
+		if err := p.state.DB.UseInvite(ctx, invite.ID); err != nil {
+			if errors.Is(err, db.ErrNoEntries) {
+				err := fmt.Errorf("invite code %s has expired or can no longer be used", form.InviteCode)
+				return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+			}
+
+			err := fmt.Errorf("db error using invite: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		inviteID = invite.ID
+	}
+
 	user, err := p.state.DB.NewSignup(ctx, gtsmodel.NewSignup{
-		Username: form.Username,
-		Email:    form.Email,
-		Password: form.Password,
-		Reason:   text.SanitizeToPlaintext(reason),
-		SignUpIP: form.IP,
-		Locale:   form.Locale,
-		AppID:    app.ID,
+		Username:    form.Username,
+		Email:       form.Email,
+		Password:    form.Password,
+		Reason:      text.SanitizeToPlaintext(reason),
+		PreApproved: preApproved,
+		SignUpIP:    form.IP,
+		Locale:      form.Locale,
+		AppID:       app.ID,
+		InviteID:    inviteID,
 	})
 	if err != nil {
 		err := fmt.Errorf("db error creating new signup: %w", err)
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	// Count this sign-up towards the
+	// IP and email domain daily limits.
+	attempt.ID = id.NewULID()
+	if err := p.state.DB.PutSignupAttempt(ctx, attempt); err != nil {
+		log.Errorf(ctx, "db error storing signup attempt: %v", err)
+	}
+
 	// There are side effects for creating a new user+account
 	// (confirmation emails etc), perform these async.
 	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
@@ -138,6 +203,77 @@ func (p *Processor) Create(
 	return user, nil
 }
 
+// throttleSignup checks the sign-up IP and email domain
+// of the given form against the configured daily limits,
+// returning an error if either limit has been reached.
+// If not, it returns a SignupAttempt (without ID) which
+// should be stored once the sign-up has been created.
+func (p *Processor) throttleSignup(
+	ctx context.Context,
+	form *apimodel.AccountCreateRequest,
+) (*gtsmodel.SignupAttempt, gtserror.WithCode) {
+	// Prefer the 4-byte representation of IPv4
+	// addresses so that they're stored consistently.
+	ip := form.IP
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+
+	m, err := mail.ParseAddress(form.Email)
+	if err != nil {
+		err := fmt.Errorf("error parsing email address %s: %w", form.Email, err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Email domains are counted in
+	// lowercase punycode, same as blocks.
+	rawDomain := m.Address[strings.LastIndexByte(m.Address, '@')+1:]
+	domain, err := util.Punify(rawDomain)
+	if err != nil {
+		err := fmt.Errorf("error punifying email domain %s: %w", rawDomain, err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	since := time.Now().Add(-24 * time.Hour)
+
+	// Attempts older than 24h no longer
+	// count towards any limit, clear them.
+	if err := p.state.DB.DeleteSignupAttemptsBefore(ctx, since); err != nil {
+		log.Errorf(ctx, "db error pruning old signup attempts: %v", err)
+	}
+
+	if limit := config.GetAccountsRegistrationIPDailyLimit(); limit > 0 && ip != nil {
+		count, err := p.state.DB.CountSignupAttemptsByIPSince(ctx, ip, since)
+		if err != nil {
+			err := fmt.Errorf("db error counting signups from ip: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if count >= limit {
+			err := fmt.Errorf("too many sign-ups have been made from your IP address today; you can try again tomorrow")
+			return nil, gtserror.NewErrorTooManyRequests(err, err.Error())
+		}
+	}
+
+	if limit := config.GetAccountsRegistrationEmailDomainDailyLimit(); limit > 0 {
+		count, err := p.state.DB.CountSignupAttemptsByEmailDomainSince(ctx, domain, since)
+		if err != nil {
+			err := fmt.Errorf("db error counting signups from email domain: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if count >= limit {
+			err := fmt.Errorf("too many sign-ups have been made with email addresses at %s today; you can try again tomorrow", domain)
+			return nil, gtserror.NewErrorTooManyRequests(err, err.Error())
+		}
+	}
+
+	return &gtsmodel.SignupAttempt{
+		IP:          ip,
+		EmailDomain: domain,
+	}, nil
+}
+
 // TokenForNewUser generates an OAuth Bearer token
 // for a new user (with account) created by Create().
 func (p *Processor) TokenForNewUser(
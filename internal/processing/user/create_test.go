@@ -0,0 +1,156 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+type CreateTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *CreateTestSuite) signup(n int, ip string, emailDomain string) (int, error) {
+	_, errWithCode := suite.user.Create(context.Background(), nil, &apimodel.AccountCreateRequest{
+		Reason:    "a very good reason",
+		Username:  fmt.Sprintf("new_user_%d", n),
+		Email:     fmt.Sprintf("new_user_%d@%s", n, emailDomain),
+		Password:  "password123!",
+		Agreement: true,
+		Locale:    "en-us",
+		IP:        net.ParseIP(ip),
+	})
+	if errWithCode != nil {
+		return errWithCode.Code(), errWithCode
+	}
+	return http.StatusOK, nil
+}
+
+func (suite *CreateTestSuite) TestCreateIPDailyLimit() {
+	config.SetAccountsRegistrationIPDailyLimit(2)
+
+	for i := 0; i < 2; i++ {
+		code, err := suite.signup(i, "192.0.2.1", "example.org")
+		suite.NoError(err)
+		suite.Equal(http.StatusOK, code)
+	}
+
+	// Third from the same IP should be refused.
+	code, err := suite.signup(2, "192.0.2.1", "example.org")
+	suite.EqualError(err, "too many sign-ups have been made from your IP address today; you can try again tomorrow")
+	suite.Equal(http.StatusTooManyRequests, code)
+
+	// Different IP should be fine.
+	code, err = suite.signup(3, "192.0.2.2", "example.org")
+	suite.NoError(err)
+	suite.Equal(http.StatusOK, code)
+}
+
+func (suite *CreateTestSuite) TestCreateEmailDomainDailyLimit() {
+	config.SetAccountsRegistrationEmailDomainDailyLimit(1)
+
+	code, err := suite.signup(0, "192.0.2.1", "example.org")
+	suite.NoError(err)
+	suite.Equal(http.StatusOK, code)
+
+	// Second with the same email domain should be
+	// refused, regardless of case or originating IP.
+	code, err = suite.signup(1, "192.0.2.2", "EXAMPLE.org")
+	suite.EqualError(err, "too many sign-ups have been made with email addresses at example.org today; you can try again tomorrow")
+	suite.Equal(http.StatusTooManyRequests, code)
+
+	// Different domain should be fine.
+	code, err = suite.signup(2, "192.0.2.2", "example.com")
+	suite.NoError(err)
+	suite.Equal(http.StatusOK, code)
+}
+
+func (suite *CreateTestSuite) TestCreateWithInvite() {
+	ctx := context.Background()
+	config.SetAccountsRegistrationOpen(false)
+	config.SetAccountsInvitesBypassApproval(true)
+
+	invite, errWithCode := suite.user.InviteCreate(ctx,
+		suite.testUsers["admin_account"],
+		&apimodel.InviteCreateRequest{MaxUses: 1},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(1, *invite.MaxUses)
+	suite.Nil(invite.ExpiresAt)
+	suite.True(invite.Usable)
+
+	form := func(n int, inviteCode string) *apimodel.AccountCreateRequest {
+		return &apimodel.AccountCreateRequest{
+			Username:   fmt.Sprintf("invited_user_%d", n),
+			Email:      fmt.Sprintf("invited_user_%d@example.org", n),
+			Password:   "password123!",
+			Agreement:  true,
+			Locale:     "en-us",
+			IP:         net.ParseIP("192.0.2.1"),
+			InviteCode: inviteCode,
+		}
+	}
+
+	// Registration is closed, so signing
+	// up without an invite should fail.
+	_, errWithCode = suite.user.Create(ctx, nil, form(0, ""))
+	suite.EqualError(errWithCode, "registration is not open for this server")
+
+	// Bogus invite code should fail too.
+	_, errWithCode = suite.user.Create(ctx, nil, form(0, "not_a_real_code"))
+	suite.EqualError(errWithCode, "invite code not_a_real_code is not valid")
+
+	// Signing up with the invite should work,
+	// and the new user should be approved.
+	user, errWithCode := suite.user.Create(ctx, nil, form(0, invite.Code))
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(invite.ID, user.InviteID)
+	suite.True(*user.Approved)
+
+	// Invite should now be used up.
+	_, errWithCode = suite.user.Create(ctx, nil, form(1, invite.Code))
+	suite.EqualError(errWithCode, "invite code "+invite.Code+" has expired or can no longer be used")
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+}
+
+func (suite *CreateTestSuite) TestInviteCreateNotEnabled() {
+	config.SetAccountsInvitesEnabled(false)
+
+	_, errWithCode := suite.user.InviteCreate(context.Background(),
+		suite.testUsers["local_account_1"],
+		&apimodel.InviteCreateRequest{},
+	)
+	suite.EqualError(errWithCode, "creating invites is not enabled on this instance")
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+}
+
+func TestCreateTestSuite(t *testing.T) {
+	suite.Run(t, new(CreateTestSuite))
+}
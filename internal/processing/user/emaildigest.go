@@ -0,0 +1,272 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/email"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+const (
+	// digestNotifsLimit is the maximum number
+	// of notifications to include in one digest.
+	digestNotifsLimit = 100
+
+	// digestTextLength is the maximum number
+	// of characters of each mentioning status
+	// to include in one digest.
+	digestTextLength = 140
+)
+
+// digestExcludeTypes are the notification
+// types that are not included in digests.
+var digestExcludeTypes = []string{
+	string(gtsmodel.NotificationReblog),
+	string(gtsmodel.NotificationFave),
+	string(gtsmodel.NotificationPoll),
+	string(gtsmodel.NotificationStatus),
+	string(gtsmodel.NotificationSignup),
+	string(gtsmodel.NotificationSeveredRelationships),
+}
+
+// SendEmailDigests sends an email digest of missed mentions,
+// follows, and follow requests to each local user who has
+// opted in to email digests, and who is due one at time now.
+// It returns the number of digest emails that were sent.
+func (p *Processor) SendEmailDigests(ctx context.Context, now time.Time) (int, error) {
+	var (
+		sent int
+		errs gtserror.MultiError
+	)
+
+	for _, digest := range []struct {
+		frequency string
+		period    string
+		interval  time.Duration
+	}{
+		{frequency: gtsmodel.EmailDigestDaily, period: "day", interval: 24 * time.Hour},
+		{frequency: gtsmodel.EmailDigestWeekly, period: "week", interval: 7 * 24 * time.Hour},
+	} {
+		accountIDs, err := p.state.DB.GetEmailDigestAccountIDs(ctx,
+			digest.frequency,
+			now.Add(-digest.interval),
+		)
+		if err != nil {
+			errs.Appendf("db error getting %s email digest accounts: %w", digest.frequency, err)
+			continue
+		}
+
+		for _, accountID := range accountIDs {
+			ok, err := p.sendEmailDigest(ctx,
+				accountID,
+				digest.period,
+				now.Add(-digest.interval),
+				now,
+			)
+			if err != nil {
+				errs.Appendf("error sending email digest to account %s: %w", accountID, err)
+				continue
+			}
+
+			if ok {
+				sent++
+			}
+		}
+	}
+
+	return sent, errs.Combine()
+}
+
+// sendEmailDigest sends an email digest to the user
+// of the given account, covering notifications since
+// the later of the account's last digest, its read
+// notifications marker, or the given fallback time.
+// Returns true if an email was sent, false if the user
+// can't be emailed, or if there was nothing to send.
+func (p *Processor) sendEmailDigest(
+	ctx context.Context,
+	accountID string,
+	period string,
+	since time.Time,
+	now time.Time,
+) (bool, error) {
+	settings, err := p.state.DB.GetAccountSettings(ctx, accountID)
+	if err != nil {
+		return false, gtserror.Newf("db error getting account settings: %w", err)
+	}
+
+	if !settings.EmailDigestSentAt.IsZero() &&
+		settings.EmailDigestSentAt.Before(since) {
+		// Include everything since last
+		// digest, in case any were missed.
+		since = settings.EmailDigestSentAt
+	}
+
+	// Whatever happens below, mark this digest as sent
+	// now so that the account isn't selected again until
+	// the next one is due, even if there was nothing to send.
+	settings.EmailDigestSentAt = now
+	if err := p.state.DB.UpdateAccountSettings(ctx, settings, "email_digest_sent_at"); err != nil {
+		return false, gtserror.Newf("db error updating account settings: %w", err)
+	}
+
+	user, err := p.state.DB.GetUserByAccountID(ctx, accountID)
+	if err != nil {
+		return false, gtserror.Newf("db error getting user: %w", err)
+	}
+
+	if user.ConfirmedAt.IsZero() ||
+		!*user.Approved ||
+		*user.Disabled ||
+		user.Email == "" ||
+		user.Account.IsSuspended() {
+		// Only email users who:
+		// - are confirmed
+		// - are approved
+		// - are not disabled
+		// - have an email address
+		// - are not suspended
+		return false, nil
+	}
+
+	sinceID, err := id.NewULIDFromTime(since)
+	if err != nil {
+		return false, gtserror.Newf("error generating id: %w", err)
+	}
+
+	// Notifications that the user has
+	// already read aren't "missed", so
+	// start from their read marker if
+	// it's more recent than sinceID.
+	marker, err := p.state.DB.GetMarker(ctx, accountID, gtsmodel.MarkerNameNotifications)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return false, gtserror.Newf("db error getting notifications marker: %w", err)
+	}
+
+	if marker != nil && marker.LastReadID > sinceID {
+		sinceID = marker.LastReadID
+	}
+
+	notifs, err := p.state.DB.GetAccountNotifications(ctx,
+		accountID,
+		"",
+		sinceID,
+		"",
+		digestNotifsLimit,
+		digestExcludeTypes,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return false, gtserror.Newf("db error getting notifications: %w", err)
+	}
+
+	data := email.DigestData{
+		Username: user.Account.Username,
+		Period:   period,
+	}
+
+	// Notifications are newest first, but
+	// oldest first reads better in an email.
+	for i := len(notifs) - 1; i >= 0; i-- {
+		notif := notifs[i]
+		digestAccount := email.DigestAccount{
+			Acct: "@" + notif.OriginAccount.Username,
+			URL:  notif.OriginAccount.URL,
+		}
+
+		if notif.OriginAccount.IsRemote() {
+			digestAccount.Acct += "@" + notif.OriginAccount.Domain
+		}
+
+		switch notif.NotificationType {
+		case gtsmodel.NotificationMention:
+			if notif.Status == nil {
+				continue
+			}
+
+			data.Mentions = append(data.Mentions, email.DigestMention{
+				Account: digestAccount,
+				URL:     notif.Status.URL,
+				Text:    digestText(notif.Status),
+			})
+
+		case gtsmodel.NotificationFollow:
+			data.Follows = append(data.Follows, digestAccount)
+
+		case gtsmodel.NotificationFollowRequest:
+			data.FollowRequests = append(data.FollowRequests, digestAccount)
+		}
+	}
+
+	if len(data.Mentions) == 0 &&
+		len(data.Follows) == 0 &&
+		len(data.FollowRequests) == 0 {
+		// Nothing missed,
+		// nothing to send.
+		return false, nil
+	}
+
+	instance, err := p.state.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		return false, gtserror.Newf("db error getting instance: %w", err)
+	}
+	data.InstanceURL = instance.URI
+	data.InstanceName = instance.Title
+	data.SettingsURL = instance.URI + "/settings/user/settings"
+
+	if err := p.emailSender.SendDigestEmail(user.Email, data); err != nil {
+		return false, gtserror.Newf("error sending email: %w", err)
+	}
+
+	log.Debugf(ctx, "sent email digest to account %s", accountID)
+	return true, nil
+}
+
+// digestText returns a shortened, single-line
+// plaintext version of the given status,
+// suitable for including in an email digest.
+func digestText(status *gtsmodel.Status) string {
+	content := status.Content
+	if status.ContentWarning != "" {
+		// Don't reveal content
+		// behind a content warning.
+		content = "CW: " + status.ContentWarning
+	}
+
+	// Collapse all whitespace
+	// (including newlines).
+	content = strings.Join(strings.Fields(
+		text.SanitizeToPlaintext(content),
+	), " ")
+
+	if runes := []rune(content); len(runes) > digestTextLength {
+		content = string(runes[:digestTextLength-1]) + "…"
+	}
+
+	return content
+}
@@ -0,0 +1,224 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+const (
+	// inviteCodeChars are the characters used
+	// in generated invite codes. Lookalikes
+	// (0/O, 1/l/I) are left out so that codes
+	// can be read out or copied by hand.
+	inviteCodeChars  = "23456789abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ"
+	inviteCodeLength = 10
+)
+
+// InviteCreate creates a new invite code
+// on behalf of the given user, as long as
+// they're permitted to create invites.
+func (p *Processor) InviteCreate(
+	ctx context.Context,
+	user *gtsmodel.User,
+	form *apimodel.InviteCreateRequest,
+) (*apimodel.Invite, gtserror.WithCode) {
+	if !config.GetAccountsInvitesEnabled() && !*user.Admin {
+		const text = "creating invites is not enabled on this instance"
+		return nil, gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
+	if form.MaxUses < 0 {
+		const text = "max_uses must be 0 or greater"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if form.ExpiresIn < 0 {
+		const text = "expires_in must be 0 or greater"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	code, err := p.newInviteCode(ctx)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	invite := &gtsmodel.Invite{
+		ID:        id.NewULID(),
+		AccountID: user.AccountID,
+		Account:   user.Account,
+		Code:      code,
+		MaxUses:   form.MaxUses,
+	}
+
+	if form.ExpiresIn > 0 {
+		invite.ExpiresAt = time.Now().Add(time.Duration(form.ExpiresIn) * time.Second)
+	}
+
+	if err := p.state.DB.PutInvite(ctx, invite); err != nil {
+		err := gtserror.Newf("db error putting invite: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiInvite(ctx, invite)
+}
+
+// InvitesGet returns all invites created by the given user.
+func (p *Processor) InvitesGet(
+	ctx context.Context,
+	user *gtsmodel.User,
+) ([]*apimodel.Invite, gtserror.WithCode) {
+	invites, err := p.state.DB.GetInvites(
+		gtscontext.SetBarebones(ctx),
+		user.AccountID,
+		false,
+	)
+	if err != nil {
+		err := gtserror.Newf("db error getting invites: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiInvites := make([]*apimodel.Invite, 0, len(invites))
+	for _, invite := range invites {
+		apiInvite, errWithCode := p.apiInvite(ctx, invite)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		apiInvites = append(apiInvites, apiInvite)
+	}
+
+	return apiInvites, nil
+}
+
+// InviteRevoke revokes the invite with the given ID, so
+// that it can no longer be used. Only the user who created
+// the invite can revoke it through this function.
+func (p *Processor) InviteRevoke(
+	ctx context.Context,
+	user *gtsmodel.User,
+	inviteID string,
+) (*apimodel.Invite, gtserror.WithCode) {
+	invite, err := p.state.DB.GetInviteByID(gtscontext.SetBarebones(ctx), inviteID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting invite %s: %w", inviteID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if invite == nil || invite.AccountID != user.AccountID {
+		// Don't reveal existence of
+		// invites created by others.
+		err := fmt.Errorf("invite %s not found", inviteID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if invite.RevokedAt.IsZero() {
+		invite.RevokedAt = time.Now()
+		if err := p.state.DB.UpdateInvite(ctx, invite, "revoked_at"); err != nil {
+			err := gtserror.Newf("db error updating invite %s: %w", inviteID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return p.apiInvite(ctx, invite)
+}
+
+// inviteForSignup gets the invite corresponding to the
+// given code, returning a 422 error if no such invite
+// exists or if it can no longer be used to sign up.
+func (p *Processor) inviteForSignup(
+	ctx context.Context,
+	code string,
+) (*gtsmodel.Invite, gtserror.WithCode) {
+	invite, err := p.state.DB.GetInviteByCode(gtscontext.SetBarebones(ctx), code)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting invite: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if invite == nil {
+		err := fmt.Errorf("invite code %s is not valid", code)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	if !invite.Usable() {
+		err := fmt.Errorf("invite code %s has expired or can no longer be used", code)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	return invite, nil
+}
+
+// newInviteCode generates a random invite
+// code that's not yet used by any invite.
+func (p *Processor) newInviteCode(ctx context.Context) (string, error) {
+	const attempts = 5
+
+	numChars := big.NewInt(int64(len(inviteCodeChars)))
+	for i := 0; i < attempts; i++ {
+		code := make([]byte, inviteCodeLength)
+		for j := range code {
+			n, err := rand.Int(rand.Reader, numChars)
+			if err != nil {
+				return "", gtserror.Newf("error generating invite code: %w", err)
+			}
+			code[j] = inviteCodeChars[n.Int64()]
+		}
+
+		_, err := p.state.DB.GetInviteByCode(gtscontext.SetBarebones(ctx), string(code))
+		if errors.Is(err, db.ErrNoEntries) {
+			// Code is free to use.
+			return string(code), nil
+		}
+
+		if err != nil {
+			return "", gtserror.Newf("db error checking invite code: %w", err)
+		}
+
+		log.Debug(ctx, "generated invite code already in use, trying again")
+	}
+
+	return "", gtserror.Newf("couldn't generate unused invite code after %d attempts", attempts)
+}
+
+func (p *Processor) apiInvite(
+	ctx context.Context,
+	invite *gtsmodel.Invite,
+) (*apimodel.Invite, gtserror.WithCode) {
+	apiInvite, err := p.converter.InviteToAPIInvite(ctx, invite)
+	if err != nil {
+		err := gtserror.Newf("error converting invite to api: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiInvite, nil
+}
@@ -0,0 +1,102 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"path"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// MediaManifest returns a manifest of all media files stored on
+// this instance for the given (local) account, including the
+// SHA-256 checksum of each original file as currently in storage.
+//
+// Checksums are calculated by streaming each file from storage,
+// so this can take a while for accounts with a lot of media.
+func (p *Processor) MediaManifest(ctx context.Context, account *gtsmodel.Account) (*apimodel.UserMediaManifest, gtserror.WithCode) {
+	attachments, err := p.state.DB.GetAccountAttachments(ctx, account.ID)
+	if err != nil {
+		err := gtserror.Newf("db error getting attachments: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	manifest := &apimodel.UserMediaManifest{
+		GeneratedAt: util.FormatISO8601(time.Now()),
+		Files:       make([]apimodel.UserMediaManifestFile, 0, len(attachments)),
+	}
+
+	for _, attachment := range attachments {
+		file := apimodel.UserMediaManifestFile{
+			ID:          attachment.ID,
+			StatusID:    attachment.StatusID,
+			URL:         attachment.URL,
+			Filename:    path.Base(attachment.URL),
+			ContentType: attachment.File.ContentType,
+		}
+
+		size, sum, err := p.checksum(ctx, attachment.File.Path)
+		switch {
+		case storage.IsNotFound(err):
+			log.Warnf(ctx, "attachment %s missing from storage at %s", attachment.ID, attachment.File.Path)
+			file.Missing = true
+
+		case err != nil:
+			err := gtserror.Newf("error checksumming attachment %s: %w", attachment.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+
+		default:
+			file.Size = size
+			file.SHA256 = sum
+		}
+
+		manifest.Files = append(manifest.Files, file)
+		manifest.FilesSize += file.Size
+	}
+
+	manifest.FilesCount = len(manifest.Files)
+	return manifest, nil
+}
+
+// checksum streams the file at the given storage
+// path, returning its size and hex-encoded SHA-256.
+func (p *Processor) checksum(ctx context.Context, key string) (int64, string, error) {
+	rc, err := p.state.Storage.GetStream(ctx, key)
+	if err != nil {
+		return 0, "", err
+	}
+	defer rc.Close()
+
+	h := sha256.New()
+	n, err := io.Copy(h, rc)
+	if err != nil {
+		return 0, "", err
+	}
+
+	return n, hex.EncodeToString(h.Sum(nil)), nil
+}
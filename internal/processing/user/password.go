@@ -20,6 +20,7 @@ package user
 import (
 	"context"
 
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/validate"
@@ -68,3 +69,36 @@ func (p *Processor) PasswordChange(ctx context.Context, user *gtsmodel.User, old
 
 	return nil
 }
+
+// PasswordCheck checks the given email address and
+// password combination, returning the corresponding
+// user if the combination is correct.
+//
+// The returned error does not leak whether it was
+// the email address or the password that was wrong.
+func (p *Processor) PasswordCheck(ctx context.Context, email string, password string) (*gtsmodel.User, gtserror.WithCode) {
+	const help = "password/email combination was incorrect"
+
+	if email == "" || password == "" {
+		err := gtserror.New("email or password was not provided")
+		return nil, gtserror.NewErrorUnauthorized(err, help)
+	}
+
+	user, err := p.state.DB.GetUserByEmailAddress(gtscontext.SetBarebones(ctx), email)
+	if err != nil {
+		err := gtserror.Newf("db error getting user %s: %w", email, err)
+		return nil, gtserror.NewErrorUnauthorized(err, help)
+	}
+
+	if user.EncryptedPassword == "" {
+		err := gtserror.Newf("encrypted password for user %s was empty", email)
+		return nil, gtserror.NewErrorUnauthorized(err, help)
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.EncryptedPassword), []byte(password)); err != nil {
+		err := gtserror.Newf("password hash didn't match for user %s: %w", email, err)
+		return nil, gtserror.NewErrorUnauthorized(err, help)
+	}
+
+	return user, nil
+}
@@ -86,6 +86,25 @@ func (suite *ChangePasswordTestSuite) TestChangePasswordWeakNew() {
 	suite.NoError(err)
 }
 
+func (suite *ChangePasswordTestSuite) TestPasswordCheckOK() {
+	user, errWithCode := suite.user.PasswordCheck(context.Background(), "admin@example.org", "password")
+	suite.NoError(errWithCode)
+	suite.Equal(suite.testUsers["admin_account"].ID, user.ID)
+}
+
+func (suite *ChangePasswordTestSuite) TestPasswordCheckIncorrect() {
+	user, errWithCode := suite.user.PasswordCheck(context.Background(), "admin@example.org", "ooooopsydoooopsy")
+	suite.Nil(user)
+	suite.Equal(http.StatusUnauthorized, errWithCode.Code())
+	suite.Equal("Unauthorized: password/email combination was incorrect", errWithCode.Safe())
+
+	// Unknown email gives the same safe error.
+	user, errWithCode = suite.user.PasswordCheck(context.Background(), "nobody@example.org", "password")
+	suite.Nil(user)
+	suite.Equal(http.StatusUnauthorized, errWithCode.Code())
+	suite.Equal("Unauthorized: password/email combination was incorrect", errWithCode.Safe())
+}
+
 func TestChangePasswordTestSuite(t *testing.T) {
 	suite.Run(t, &ChangePasswordTestSuite{})
 }
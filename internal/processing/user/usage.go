@@ -0,0 +1,95 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Usage returns a summary of the data stored on
+// this instance for the given (local) account.
+func (p *Processor) Usage(ctx context.Context, account *gtsmodel.Account) (*apimodel.UserUsage, gtserror.WithCode) {
+	usage := new(apimodel.UserUsage)
+
+	// Media storage consumption.
+	count, size, err := p.state.DB.GetAccountAttachmentsUsage(ctx, account.ID)
+	if err != nil {
+		err := gtserror.Newf("db error getting media usage: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	usage.MediaCount = count
+	usage.MediaStorage = size
+
+	// Statuses by visibility.
+	statuses, err := p.state.DB.CountAccountStatusesByVisibility(ctx, account.ID)
+	if err != nil {
+		err := gtserror.Newf("db error counting statuses: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	usage.Statuses = apimodel.UserUsageStatuses{
+		Public:      statuses[gtsmodel.VisibilityPublic],
+		Unlisted:    statuses[gtsmodel.VisibilityUnlocked],
+		Private:     statuses[gtsmodel.VisibilityFollowersOnly],
+		MutualsOnly: statuses[gtsmodel.VisibilityMutualsOnly],
+		Direct:      statuses[gtsmodel.VisibilityDirect],
+	}
+	for _, n := range statuses {
+		usage.Statuses.Total += n
+	}
+
+	// Follower stats.
+	if err := p.state.DB.PopulateAccountStats(ctx, account); err != nil {
+		err := gtserror.Newf("db error getting account stats: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	usage.FollowersCount = *account.Stats.FollowersCount
+	usage.FollowingCount = *account.Stats.FollowingCount
+	usage.FollowRequestsCount = *account.Stats.FollowRequestsCount
+
+	// Exportable data.
+	usage.Exports.Following = usage.FollowingCount
+
+	lists, err := p.state.DB.GetListsForAccountID(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting lists: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	usage.Exports.Lists = len(lists)
+
+	blockIDs, err := p.state.DB.GetAccountBlockIDs(ctx, account.ID, nil)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting blocks: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	usage.Exports.Blocks = len(blockIDs)
+
+	mutes, err := p.state.DB.GetAccountMutes(ctx, account.ID, nil)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting mutes: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	usage.Exports.Mutes = len(mutes)
+
+	return usage, nil
+}
@@ -404,6 +404,72 @@ func (suite *FromClientAPITestSuite) TestProcessCreateStatusReplyMuted() {
 	suite.Nil(notif)
 }
 
+func (suite *FromClientAPITestSuite) TestProcessCreateStatusReplyMutedNotStreamed() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	var (
+		ctx              = context.Background()
+		postingAccount   = suite.testAccounts["admin_account"]
+		receivingAccount = suite.testAccounts["local_account_1"]
+		testList         = suite.testLists["local_account_1_list_1"]
+		streams          = suite.openStreams(ctx, testStructs.Processor, receivingAccount, []string{testList.ID})
+		homeStream       = streams[stream.TimelineHome]
+		listStream       = streams[stream.TimelineList+":"+testList.ID]
+
+		// Admin account posts a reply to turtle.
+		// Normally this would be streamed to zork,
+		// but zork mutes the thread, so it shouldn't.
+		status = suite.newStatus(
+			ctx,
+			testStructs.State,
+			postingAccount,
+			gtsmodel.VisibilityPublic,
+			suite.testStatuses["local_account_2_status_1"],
+			nil,
+		)
+		threadMute = &gtsmodel.ThreadMute{
+			ID:        "01J1BQVYXJ3J6RVVTM3WZJFK8X",
+			ThreadID:  suite.testStatuses["local_account_2_status_1"].ThreadID,
+			AccountID: receivingAccount.ID,
+		}
+	)
+
+	// Store the thread mute before processing new status.
+	if err := testStructs.State.DB.PutThreadMute(ctx, threadMute); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Process the new status.
+	if err := testStructs.Processor.Workers().ProcessFromClientAPI(
+		ctx,
+		&messages.FromClientAPI{
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityCreate,
+			GTSModel:       status,
+			Origin:         postingAccount,
+		},
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Check message NOT in home stream.
+	suite.checkStreamed(
+		homeStream,
+		false,
+		"",
+		"",
+	)
+
+	// Check message NOT in list stream.
+	suite.checkStreamed(
+		listStream,
+		false,
+		"",
+		"",
+	)
+}
+
 func (suite *FromClientAPITestSuite) TestProcessCreateStatusBoostMuted() {
 	testStructs := suite.SetupTestStructs()
 	defer suite.TearDownTestStructs(testStructs)
@@ -868,6 +934,13 @@ func (suite *FromClientAPITestSuite) TestProcessStatusDelete() {
 	}) {
 		suite.FailNow("timed out waiting for status delete")
 	}
+
+	// No tombstone should be kept by processing the delete,
+	// as statuses are also deleted this way by retention and
+	// account deletes; only the status delete API keeps one.
+	gone, err := testStructs.State.DB.TombstoneExistsWithURI(ctx, deletedStatus.URI)
+	suite.NoError(err)
+	suite.False(gone)
 }
 
 func TestFromClientAPITestSuite(t *testing.T) {
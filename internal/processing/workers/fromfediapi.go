@@ -20,17 +20,21 @@ package workers
 import (
 	"context"
 	"errors"
+	"slices"
+	"time"
 
 	"codeberg.org/gruf/go-kv"
 	"codeberg.org/gruf/go-logger/v2/level"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/federation/dereferencing"
-
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/metrics"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/account"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
@@ -172,12 +176,32 @@ func (p *fediAPI) CreateStatus(ctx context.Context, fMsg *messages.FromFediAPI)
 			return gtserror.Newf("cannot cast %T -> ap.Statusable", fMsg.APObject)
 		}
 
+		// Check whether we already have this status stored,
+		// in which case this is a re-delivery of the Create.
+		uri := ap.GetJSONLDId(statusable).String()
+		existing, err := p.state.DB.GetStatusByURI(
+			gtscontext.SetBarebones(ctx),
+			uri,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("db error getting status %s: %w", uri, err)
+		}
+
+		if existing != nil {
+			// Nothing further to do here
+			// beyond verifying redelivery.
+			return p.verifyRedeliveredStatus(ctx,
+				existing,
+				statusable,
+			)
+		}
+
 		// Create bare-bones model to pass
 		// into RefreshStatus(), which it will
 		// further populate and insert as new.
 		bareStatus := new(gtsmodel.Status)
 		bareStatus.Local = util.Ptr(false)
-		bareStatus.URI = ap.GetJSONLDId(statusable).String()
+		bareStatus.URI = uri
 
 		// Call RefreshStatus() to parse and process the provided
 		// statusable model, which it will use to further flesh out
@@ -235,6 +259,63 @@ func (p *fediAPI) CreateStatus(ctx context.Context, fMsg *messages.FromFediAPI)
 	return nil
 }
 
+// verifyRedeliveredStatus checks the content of a status
+// re-delivered via Create against the digest of the version
+// we already have stored. Content should only ever change via
+// an Update, so a mismatch here may indicate an attempt to spoof
+// the status. Mismatches are logged and counted in metrics, and
+// the stored status is quarantined if configured to do so.
+func (p *fediAPI) verifyRedeliveredStatus(
+	ctx context.Context,
+	existing *gtsmodel.Status,
+	statusable ap.Statusable,
+) error {
+	if existing.ContentDigest == "" {
+		// Stored before we recorded
+		// digests, nothing to compare.
+		return nil
+	}
+
+	redelivered, err := p.surface.Converter.ASStatusToStatus(ctx, statusable)
+	if err != nil {
+		return gtserror.Newf("error converting re-delivered status %s: %w", existing.URI, err)
+	}
+
+	if redelivered.ComputeContentDigest() == existing.ContentDigest {
+		// Plain old duplicate
+		// delivery, nothing to do.
+		return nil
+	}
+
+	quarantine := config.GetInstanceFederationQuarantineMismatchedStatuses() &&
+		!existing.IsQuarantined()
+	metrics.StatusDigestMismatch(ctx, quarantine)
+
+	log.Warnf(ctx,
+		"status %s re-delivered with content not matching stored digest (quarantine=%t)",
+		existing.URI, quarantine,
+	)
+
+	if !quarantine {
+		return nil
+	}
+
+	// Mark the stored status as quarantined, this
+	// hides it until it's next updated / refetched.
+	existing.QuarantinedAt = time.Now()
+	if err := p.state.DB.UpdateStatus(ctx, existing, "quarantined_at"); err != nil {
+		return gtserror.Newf("db error quarantining status %s: %w", existing.URI, err)
+	}
+
+	// Remove the status from any
+	// timelines it's already in.
+	if err := p.surface.deleteStatusFromTimelines(ctx, existing.ID); err != nil {
+		log.Errorf(ctx, "error removing quarantined status from timelines: %v", err)
+	}
+
+	return nil
+}
+
 func (p *fediAPI) CreatePollVote(ctx context.Context, fMsg *messages.FromFediAPI) error {
 	// Cast poll vote type from the worker message.
 	vote, ok := fMsg.GTSModel.(*gtsmodel.PollVote)
@@ -290,8 +371,16 @@ func (p *fediAPI) CreateFollowReq(ctx context.Context, fMsg *messages.FromFediAP
 		return gtserror.Newf("error populating follow request: %w", err)
 	}
 
-	if *followRequest.TargetAccount.Locked {
-		// Local account is locked: just notify the follow request.
+	// Check whether the local account wants to
+	// delay auto-accepting follows for a while.
+	delay, err := p.account.FollowAutoAcceptDelay(ctx, followRequest.TargetAccount)
+	if err != nil {
+		log.Errorf(ctx, "error getting follow auto-accept delay: %v", err)
+	}
+
+	if *followRequest.TargetAccount.Locked || delay > 0 {
+		// Local account is locked, or is delaying auto-accept:
+		// just notify the follow request for now.
 		if err := p.surface.notifyFollowRequest(ctx, followRequest); err != nil {
 			log.Errorf(ctx, "error notifying follow request: %v", err)
 		}
@@ -301,6 +390,14 @@ func (p *fediAPI) CreateFollowReq(ctx context.Context, fMsg *messages.FromFediAP
 			log.Errorf(ctx, "error updating account stats: %v", err)
 		}
 
+		if delay > 0 {
+			// Schedule the accept for later, the follow
+			// request can be manually rejected until then.
+			if err := p.account.ScheduleFollowAutoAccept(ctx, followRequest, delay); err != nil {
+				log.Errorf(ctx, "error scheduling follow auto-accept: %v", err)
+			}
+		}
+
 		return nil
 	}
 
@@ -559,6 +656,10 @@ func (p *fediAPI) UpdateStatus(ctx context.Context, fMsg *messages.FromFediAPI)
 	// Cast the updated ActivityPub statusable object .
 	apStatus, _ := fMsg.APObject.(ap.Statusable)
 
+	// Take a copy of the mention IDs from before
+	// the edit, so we only notify newly mentioned.
+	prevMentionIDs := slices.Clone(existing.MentionIDs)
+
 	// Fetch up-to-date attach status attachments, etc.
 	status, _, err := p.federate.RefreshStatus(
 		ctx,
@@ -584,6 +685,11 @@ func (p *fediAPI) UpdateStatus(ctx context.Context, fMsg *messages.FromFediAPI)
 		}
 	}
 
+	// Notify any accounts newly mentioned by the edit.
+	if err := p.surface.notifyNewMentions(ctx, status, prevMentionIDs); err != nil {
+		log.Errorf(ctx, "error notifying new mentions: %v", err)
+	}
+
 	// Push message that the status has been edited to streams.
 	if err := p.surface.timelineStatusUpdate(ctx, status); err != nil {
 		log.Errorf(ctx, "error streaming status edit: %v", err)
@@ -22,13 +22,18 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/url"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/activity/pub"
+	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/stream"
@@ -546,6 +551,65 @@ func (suite *FromFediAPITestSuite) TestProcessFollowRequestUnlocked() {
 	suite.Equal(originAccount.ID, notif.Account.ID)
 }
 
+func (suite *FromFediAPITestSuite) TestProcessFollowRequestUnlockedDelayed() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	ctx := context.Background()
+
+	originAccount := suite.testAccounts["remote_account_1"]
+
+	// target is an unlocked account
+	// that delays auto-accepting follows.
+	targetAccount := suite.testAccounts["local_account_1"]
+	settings, err := testStructs.State.DB.GetAccountSettings(ctx, targetAccount.ID)
+	suite.NoError(err)
+	settings.FollowAutoAcceptDelay = 60
+	err = testStructs.State.DB.UpdateAccountSettings(ctx, settings, "follow_auto_accept_delay")
+	suite.NoError(err)
+	targetAccount.Settings = settings
+
+	followRequest := &gtsmodel.FollowRequest{
+		ID:              "01FGRYAVAWWPP926J175QGM0WV",
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+		AccountID:       originAccount.ID,
+		Account:         originAccount,
+		TargetAccountID: targetAccount.ID,
+		TargetAccount:   targetAccount,
+		ShowReblogs:     util.Ptr(true),
+		URI:             fmt.Sprintf("%s/follows/01FGRYAVAWWPP926J175QGM0WV", originAccount.URI),
+		Notify:          util.Ptr(false),
+	}
+
+	err = testStructs.State.DB.Put(ctx, followRequest)
+	suite.NoError(err)
+
+	err = testStructs.Processor.Workers().ProcessFromFediAPI(ctx, &messages.FromFediAPI{
+		APObjectType:   ap.ActivityFollow,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       followRequest,
+		Receiving:      targetAccount,
+		Requesting:     originAccount,
+	})
+	suite.NoError(err)
+
+	// The follow request should not have been accepted yet.
+	following, err := testStructs.State.DB.IsFollowing(ctx, originAccount.ID, targetAccount.ID)
+	suite.NoError(err)
+	suite.False(following)
+
+	_, err = testStructs.State.DB.GetFollowRequestByID(ctx, followRequest.ID)
+	suite.NoError(err)
+
+	// Accept should instead be scheduled for later.
+	suite.True(testStructs.State.Workers.Scheduler.Cancel(followRequest.ID))
+
+	// And nothing should have been delivered.
+	_, ok := testStructs.State.Workers.Delivery.Queue.Pop()
+	suite.False(ok)
+}
+
 // TestCreateStatusFromIRI checks if a forwarded status can be dereferenced by the processor.
 func (suite *FromFediAPITestSuite) TestCreateStatusFromIRI() {
 	testStructs := suite.SetupTestStructs()
@@ -572,6 +636,243 @@ func (suite *FromFediAPITestSuite) TestCreateStatusFromIRI() {
 	suite.Equal(statusCreator.URI, s.AccountURI)
 }
 
+func (suite *FromFediAPITestSuite) TestCreateStatusFromIRIAfterRemoval() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	var (
+		ctx              = context.Background()
+		receivingAccount = suite.testAccounts["local_account_1"]
+		statusCreator    = suite.testAccounts["remote_account_2"]
+		statusIRI        = testrig.URLMustParse("http://example.org/users/Some_User/statuses/afaba698-5740-4e32-a702-af61aa543bc1")
+	)
+
+	create := func() {
+		err := testStructs.Processor.Workers().ProcessFromFediAPI(ctx, &messages.FromFediAPI{
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityCreate,
+			Receiving:      receivingAccount,
+			Requesting:     statusCreator,
+			APIRI:          statusIRI,
+		})
+		suite.NoError(err)
+	}
+
+	create()
+	status, err := testStructs.State.DB.GetStatusByURI(ctx, statusIRI.String())
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Remove the status locally, as retention does,
+	// without it having been deleted by its author.
+	err = testStructs.Processor.Workers().ProcessFromFediAPI(ctx, &messages.FromFediAPI{
+		APObjectType:   ap.ObjectNote,
+		APActivityType: ap.ActivityDelete,
+		GTSModel:       status,
+		Receiving:      receivingAccount,
+		Requesting:     status.Account,
+	})
+	suite.NoError(err)
+
+	_, err = testStructs.State.DB.GetStatusByURI(ctx, statusIRI.String())
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// No tombstone should have been kept for it.
+	gone, err := testStructs.State.DB.TombstoneExistsWithURI(ctx, statusIRI.String())
+	suite.NoError(err)
+	suite.False(gone)
+
+	// So it can be dereferenced again.
+	create()
+	status, err = testStructs.State.DB.GetStatusByURI(ctx, statusIRI.String())
+	suite.NoError(err)
+	suite.Equal(statusCreator.URI, status.AccountURI)
+}
+
+func (suite *FromFediAPITestSuite) TestCreateStatusRedeliveredWithDifferentContent() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	var (
+		ctx              = context.Background()
+		receivingAccount = suite.testAccounts["local_account_1"]
+		statusCreator    = suite.testAccounts["remote_account_1"]
+		statusURI        = testrig.URLMustParse("http://fossbros-anonymous.io/users/foss_satan/statuses/01J0S2BZPW1DKV8QDHQQ9C6J7A")
+	)
+
+	config.SetInstanceFederationQuarantineMismatchedStatuses(true)
+
+	// Set the creating account's last fetched_at
+	// date to something recent so no refresh is attempted.
+	statusCreator.FetchedAt = time.Now()
+	if err := testStructs.State.DB.UpdateAccount(ctx, statusCreator, "fetched_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	newNote := func(content string) ap.Statusable {
+		return testrig.NewAPNote(
+			statusURI,
+			statusURI,
+			time.Now(),
+			content,
+			"",
+			testrig.URLMustParse(statusCreator.URI),
+			[]*url.URL{testrig.URLMustParse(pub.PublicActivityPubIRI)},
+			nil,
+			false,
+			nil,
+			nil,
+			nil,
+		)
+	}
+
+	deliver := func(statusable ap.Statusable) {
+		err := testStructs.Processor.Workers().ProcessFromFediAPI(ctx, &messages.FromFediAPI{
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityCreate,
+			APObject:       statusable,
+			Receiving:      receivingAccount,
+			Requesting:     statusCreator,
+		})
+		suite.NoError(err)
+	}
+
+	// Deliver the status for the first time.
+	deliver(newNote("<p>hello world</p>"))
+	status, err := testStructs.State.DB.GetStatusByURI(ctx, statusURI.String())
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotEmpty(status.ContentDigest)
+	suite.False(status.IsQuarantined())
+
+	// Deliver an exact duplicate, nothing should change.
+	deliver(newNote("<p>hello world</p>"))
+	status, err = testStructs.State.DB.GetStatusByURI(ctx, statusURI.String())
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(status.IsQuarantined())
+
+	// Re-deliver with different content but no Update.
+	deliver(newNote("<p>send me your passwords</p>"))
+	status, err = testStructs.State.DB.GetStatusByURI(ctx, statusURI.String())
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Stored content should remain the same, but now quarantined.
+	suite.Equal("<p>hello world</p>", status.Content)
+	suite.True(status.IsQuarantined())
+
+	// Quarantined status should not be visible to the receiver.
+	visible, err := visibility.NewFilter(testStructs.State).StatusVisible(ctx, receivingAccount, status)
+	suite.NoError(err)
+	suite.False(visible)
+}
+
+func (suite *FromFediAPITestSuite) TestUpdateStatusNewMention() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	var (
+		ctx              = context.Background()
+		receivingAccount = suite.testAccounts["local_account_1"]
+		newlyMentioned   = suite.testAccounts["local_account_2"]
+		statusCreator    = suite.testAccounts["remote_account_1"]
+		statusURI        = testrig.URLMustParse("http://fossbros-anonymous.io/users/foss_satan/statuses/01J0S2BZPW1DKV8QDHQQ9C6J7B")
+	)
+
+	// Set the creating account's last fetched_at
+	// date to something recent so no refresh is attempted.
+	statusCreator.FetchedAt = time.Now()
+	if err := testStructs.State.DB.UpdateAccount(ctx, statusCreator, "fetched_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	newNote := func(content string, mentioned ...*gtsmodel.Account) ap.Statusable {
+		var mentions []vocab.ActivityStreamsMention
+		for _, account := range mentioned {
+			mentions = append(mentions, testrig.NewAPMention(
+				testrig.URLMustParse(account.URI),
+				"@"+account.Username+"@"+config.GetHost(),
+			))
+		}
+
+		return testrig.NewAPNote(
+			statusURI,
+			statusURI,
+			time.Now(),
+			content,
+			"",
+			testrig.URLMustParse(statusCreator.URI),
+			[]*url.URL{testrig.URLMustParse(pub.PublicActivityPubIRI)},
+			nil,
+			false,
+			mentions,
+			nil,
+			nil,
+		)
+	}
+
+	getMentionNotif := func(target *gtsmodel.Account, statusID string) (*gtsmodel.Notification, error) {
+		return testStructs.State.DB.GetNotification(
+			ctx,
+			gtsmodel.NotificationMention,
+			target.ID,
+			statusCreator.ID,
+			statusID,
+		)
+	}
+
+	// Deliver the status mentioning only the receiving account.
+	if err := testStructs.Processor.Workers().ProcessFromFediAPI(ctx, &messages.FromFediAPI{
+		APObjectType:   ap.ObjectNote,
+		APActivityType: ap.ActivityCreate,
+		APObject:       newNote("<p>hello</p>", receivingAccount),
+		Receiving:      receivingAccount,
+		Requesting:     statusCreator,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	status, err := testStructs.State.DB.GetStatusByURI(ctx, statusURI.String())
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Receiving account gets notified, then dismisses it.
+	notif, err := getMentionNotif(receivingAccount, status.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if err := testStructs.State.DB.DeleteNotificationByID(ctx, notif.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Deliver an edit that also mentions local_account_2.
+	if err := testStructs.Processor.Workers().ProcessFromFediAPI(ctx, &messages.FromFediAPI{
+		APObjectType:   ap.ObjectNote,
+		APActivityType: ap.ActivityUpdate,
+		GTSModel:       status,
+		APObject:       newNote("<p>hello again</p>", receivingAccount, newlyMentioned),
+		Receiving:      receivingAccount,
+		Requesting:     statusCreator,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Newly mentioned account should be notified.
+	_, err = getMentionNotif(newlyMentioned, status.ID)
+	suite.NoError(err)
+
+	// Dismissed notification of the account
+	// mentioned before shouldn't come back.
+	_, err = getMentionNotif(receivingAccount, status.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
 func (suite *FromFediAPITestSuite) TestMoveAccount() {
 	testStructs := suite.SetupTestStructs()
 	defer suite.TearDownTestStructs(testStructs)
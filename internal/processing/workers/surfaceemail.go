@@ -25,6 +25,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/disposable"
 	"github.com/superseriousbusiness/gotosocial/internal/email"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -263,6 +264,10 @@ func (s *Surface) emailAdminNewSignup(ctx context.Context, newUser *gtsmodel.Use
 		SignupURL:      instance.URI + "/settings/admin/accounts/" + newUser.AccountID,
 	}
 
+	if config.GetAccountsDisposableEmailMode() == config.DisposableEmailModeFlag {
+		newSignupData.SignupDisposableEmail = disposable.IsEmail(newUser.UnconfirmedEmail)
+	}
+
 	if err := s.EmailSender.SendNewSignupEmail(toAddresses, newSignupData); err != nil {
 		return gtserror.Newf("error emailing instance moderators: %w", err)
 	}
@@ -20,6 +20,7 @@ package workers
 import (
 	"context"
 	"errors"
+	"slices"
 	"strings"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -39,9 +40,43 @@ func (s *Surface) notifyMentions(
 	ctx context.Context,
 	status *gtsmodel.Status,
 ) error {
-	var errs gtserror.MultiError
+	return s.notifyStatusMentions(ctx, status, status.Mentions)
+}
 
+// notifyNewMentions is like notifyMentions, but only
+// notifies mentions on the given (edited) status that
+// were not in the status' previous set of mention IDs,
+// so already-mentioned accounts aren't notified again.
+func (s *Surface) notifyNewMentions(
+	ctx context.Context,
+	status *gtsmodel.Status,
+	prevMentionIDs []string,
+) error {
+	mentions := make([]*gtsmodel.Mention, 0, len(status.Mentions))
 	for _, mention := range status.Mentions {
+		if !slices.Contains(prevMentionIDs, mention.ID) {
+			mentions = append(mentions, mention)
+		}
+	}
+
+	if len(mentions) == 0 {
+		// Nothing new.
+		return nil
+	}
+
+	return s.notifyStatusMentions(ctx, status, mentions)
+}
+
+// notifyStatusMentions notifies the target
+// of each of the given mentions of status.
+func (s *Surface) notifyStatusMentions(
+	ctx context.Context,
+	status *gtsmodel.Status,
+	mentions []*gtsmodel.Mention,
+) error {
+	var errs gtserror.MultiError
+
+	for _, mention := range mentions {
 		// Set status on the mention (stops
 		// the below function populating it).
 		mention.Status = status
@@ -302,15 +337,25 @@ func (s *Surface) notifyPollClose(ctx context.Context, status *gtsmodel.Status)
 	var errs gtserror.MultiError
 
 	if status.Account.IsLocal() {
-		// Send a notification to the status
-		// author that their poll has closed!
-		if err := s.Notify(ctx,
-			gtsmodel.NotificationPoll,
-			status.Account,
-			status.Account,
-			status.ID,
-		); err != nil {
-			errs.Appendf("error notifying poll author: %w", err)
+		// Ensure author hasn't
+		// muted the thread.
+		muted, err := s.threadMuted(ctx,
+			status,
+			status.AccountID,
+		)
+		if err != nil {
+			errs.Append(err)
+		} else if !muted {
+			// Send a notification to the status
+			// author that their poll has closed!
+			if err := s.Notify(ctx,
+				gtsmodel.NotificationPoll,
+				status.Account,
+				status.Account,
+				status.ID,
+			); err != nil {
+				errs.Appendf("error notifying poll author: %w", err)
+			}
 		}
 	}
 
@@ -321,6 +366,23 @@ func (s *Surface) notifyPollClose(ctx context.Context, status *gtsmodel.Status)
 			continue
 		}
 
+		// Ensure voter hasn't
+		// muted the thread.
+		muted, err := s.threadMuted(ctx,
+			status,
+			vote.AccountID,
+		)
+		if err != nil {
+			errs.Append(err)
+			continue
+		}
+
+		if muted {
+			// Voter doesn't want
+			// notifs for this thread.
+			continue
+		}
+
 		// notify voter that
 		// poll has been closed.
 		if err := s.Notify(ctx,
@@ -468,6 +530,18 @@ func (s *Surface) Notify(
 	// with the state-y stuff.
 	unlock()
 
+	// If the user has snoozed notifications,
+	// don't push this one to them; it's still
+	// stored so they'll see it when they look.
+	snoozed, err := s.notifySnoozed(ctx, notif)
+	if err != nil {
+		return err
+	}
+
+	if snoozed {
+		return nil
+	}
+
 	// Stream notification to the user.
 	filters, err := s.State.DB.GetFiltersForAccountID(ctx, targetAccount.ID)
 	if err != nil {
@@ -491,3 +565,48 @@ func (s *Surface) Notify(
 
 	return nil
 }
+
+// notifySnoozed returns whether the given notification shouldn't be
+// pushed to its target, because they've snoozed notifications (quiet
+// mode), and it's not one of the exceptions they've chosen to allow.
+func (s *Surface) notifySnoozed(
+	ctx context.Context,
+	notif *gtsmodel.Notification,
+) (bool, error) {
+	settings, err := s.State.DB.GetAccountSettings(ctx, notif.TargetAccountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return false, gtserror.Newf("error getting account settings %s: %w", notif.TargetAccountID, err)
+	}
+
+	if settings == nil || !settings.NotificationsSnoozed() {
+		// Not snoozed.
+		return false, nil
+	}
+
+	switch notif.NotificationType {
+
+	// Follows may be allowed through.
+	case gtsmodel.NotificationFollow,
+		gtsmodel.NotificationFollowRequest:
+		return !util.PtrValueOr(settings.SnoozeAllowFollows, false), nil
+
+	// Mentions in direct messages may be allowed through.
+	case gtsmodel.NotificationMention:
+		if !util.PtrValueOr(settings.SnoozeAllowDirect, false) {
+			return true, nil
+		}
+
+		status, err := s.State.DB.GetStatusByID(
+			gtscontext.SetBarebones(ctx),
+			notif.StatusID,
+		)
+		if err != nil {
+			return false, gtserror.Newf("error getting status %s: %w", notif.StatusID, err)
+		}
+
+		return status.Visibility != gtsmodel.VisibilityDirect, nil
+
+	default:
+		return true, nil
+	}
+}
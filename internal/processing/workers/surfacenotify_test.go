@@ -28,6 +28,8 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/workers"
+	"github.com/superseriousbusiness/gotosocial/internal/stream"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 type SurfaceNotifyTestSuite struct {
@@ -110,6 +112,79 @@ func (suite *SurfaceNotifyTestSuite) TestSpamNotifs() {
 	}
 }
 
+func (suite *SurfaceNotifyTestSuite) TestSnoozedNotifs() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	surface := &workers.Surface{
+		State:       testStructs.State,
+		Converter:   testStructs.TypeConverter,
+		Stream:      testStructs.Processor.Stream(),
+		Filter:      visibility.NewFilter(testStructs.State),
+		EmailSender: testStructs.EmailSender,
+	}
+
+	var (
+		ctx           = context.Background()
+		targetAccount = suite.testAccounts["local_account_1"]
+		originAccount = suite.testAccounts["local_account_2"]
+		status        = suite.testStatuses["local_account_1_status_1"]
+		streams       = suite.openStreams(ctx, testStructs.Processor, targetAccount, nil)
+		notifStream   = streams[stream.TimelineNotifications]
+	)
+
+	// Snooze target's notifications,
+	// but still allow through follows.
+	settings, err := testStructs.State.DB.GetAccountSettings(ctx, targetAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	settings.NotificationsSnoozedUntil = time.Now().Add(time.Hour)
+	settings.SnoozeAllowFollows = util.Ptr(true)
+	if err := testStructs.State.DB.UpdateAccountSettings(ctx, settings); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	recv := func() bool {
+		ctx, cncl := context.WithTimeout(ctx, time.Second)
+		defer cncl()
+		_, ok := notifStream.Recv(ctx)
+		return ok
+	}
+
+	// Fave should be stored, but not streamed.
+	if err := surface.Notify(ctx,
+		gtsmodel.NotificationFave,
+		targetAccount,
+		originAccount,
+		status.ID,
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	notif, err := testStructs.State.DB.GetNotification(ctx,
+		gtsmodel.NotificationFave,
+		targetAccount.ID,
+		originAccount.ID,
+		status.ID,
+	)
+	suite.NoError(err)
+	suite.NotNil(notif)
+	suite.False(recv())
+
+	// Follow is an allowed
+	// exception, so streamed.
+	if err := surface.Notify(ctx,
+		gtsmodel.NotificationFollow,
+		targetAccount,
+		originAccount,
+		"",
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(recv())
+}
+
 func TestSurfaceNotifyTestSuite(t *testing.T) {
 	suite.Run(t, new(SurfaceNotifyTestSuite))
 }
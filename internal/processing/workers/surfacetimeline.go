@@ -365,6 +365,19 @@ func (s *Surface) timelineStatus(
 		return false, nil
 	}
 
+	// Check the user hasn't muted this status'
+	// thread before streaming it; it stays in
+	// their timeline, but shouldn't be pushed.
+	muted, err := s.threadMuted(ctx, status, account.ID)
+	if err != nil {
+		return true, err
+	}
+
+	if muted {
+		// Nothing more to do.
+		return true, nil
+	}
+
 	// The status was inserted so stream it to the user.
 	apiStatus, err := s.Converter.StatusToAPIStatus(ctx,
 		status,
@@ -595,6 +608,16 @@ func (s *Surface) timelineStreamStatusUpdate(
 	filters []*gtsmodel.Filter,
 	mutes *usermute.CompiledUserMuteList,
 ) error {
+	muted, err := s.threadMuted(ctx, status, account.ID)
+	if err != nil {
+		return err
+	}
+
+	if muted {
+		// Don't put this status in the stream.
+		return nil
+	}
+
 	apiStatus, err := s.Converter.StatusToAPIStatus(ctx, status, account, statusfilter.FilterContextHome, filters, mutes)
 	if errors.Is(err, statusfilter.ErrHideStatus) {
 		// Don't put this status in the stream.
@@ -607,3 +630,35 @@ func (s *Surface) timelineStreamStatusUpdate(
 	s.Stream.StatusUpdate(ctx, account, apiStatus, streamType)
 	return nil
 }
+
+// threadMuted returns whether the given account has muted the
+// thread of the given status, or of the boosted status for boosts.
+// This is backed by the thread mute cache, so it is cheap to call
+// for each account in the stream fan-out path.
+func (s *Surface) threadMuted(
+	ctx context.Context,
+	status *gtsmodel.Status,
+	accountID string,
+) (bool, error) {
+	muted, err := s.State.DB.IsThreadMutedByAccount(ctx,
+		status.ThreadID,
+		accountID,
+	)
+	if err != nil {
+		return false, gtserror.Newf("error checking status thread mute %s: %w", status.ThreadID, err)
+	}
+
+	if muted || status.BoostOf == nil {
+		return muted, nil
+	}
+
+	muted, err = s.State.DB.IsThreadMutedByAccount(ctx,
+		status.BoostOf.ThreadID,
+		accountID,
+	)
+	if err != nil {
+		return false, gtserror.Newf("error checking status thread mute %s: %w", status.BoostOf.ThreadID, err)
+	}
+
+	return muted, nil
+}
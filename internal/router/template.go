@@ -34,6 +34,7 @@ import (
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/i18n"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/regexes"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
@@ -47,6 +48,11 @@ import (
 // to the template funcMap for use in any template. Use these "include"
 // functions when you need to pass a template through a pipeline.
 // Otherwise, prefer the built-in "template" function.
+//
+// Templates are loaded once per locale supported by the i18n
+// package, with the special functions "t" (translate a message)
+// and "locale" added for that locale. Which set is used to render
+// a page is selected by the "locale" key in the template data.
 func LoadTemplates(engine *gin.Engine) error {
 	templateBaseDir := config.GetWebTemplateBaseDir()
 	if templateBaseDir == "" {
@@ -72,43 +78,95 @@ func LoadTemplates(engine *gin.Engine) error {
 		)
 	}
 
+	// Load one set of templates per supported
+	// locale, each with its own translate func.
+	templateGlob := filepath.Join(templateDirAbs, "*")
+	renderer := make(localizedHTMLRender, len(i18n.Locales()))
+	for _, locale := range i18n.Locales() {
+		tmpl, err := loadLocaleTemplates(templateGlob, locale)
+		if err != nil {
+			return gtserror.Newf("error loading templates for locale %s: %w", locale, err)
+		}
+		renderer[locale] = tmpl
+	}
+
+	// Almost done; teach the
+	// engine how to render.
+	engine.SetFuncMap(funcMap)
+	engine.HTMLRender = renderer
+
+	return nil
+}
+
+// loadLocaleTemplates loads templates matching templateGlob
+// into a new base template, with functions added for
+// rendering in the given locale.
+func loadLocaleTemplates(templateGlob string, locale string) (*template.Template, error) {
 	// Bring base template into scope.
 	tmpl := template.New("base")
 
-	// Set additional "include" functions to render
-	// provided template name using the base template.
-	funcMap["include"] = func(name string, data any) (template.HTML, error) {
-		var buf strings.Builder
-		err := tmpl.ExecuteTemplate(&buf, name, data)
+	localeFuncs := template.FuncMap{
+		// Set additional "include" functions to render
+		// provided template name using the base template.
+		"include": func(name string, data any) (template.HTML, error) {
+			var buf strings.Builder
+			err := tmpl.ExecuteTemplate(&buf, name, data)
 
-		// Template was already escaped by
-		// ExecuteTemplate so we can trust it.
-		return noescape(buf.String()), err
-	}
+			// Template was already escaped by
+			// ExecuteTemplate so we can trust it.
+			return noescape(buf.String()), err
+		},
 
-	funcMap["includeAttr"] = func(name string, data any) (template.HTMLAttr, error) {
-		var buf strings.Builder
-		err := tmpl.ExecuteTemplate(&buf, name, data)
+		"includeAttr": func(name string, data any) (template.HTMLAttr, error) {
+			var buf strings.Builder
+			err := tmpl.ExecuteTemplate(&buf, name, data)
 
-		// Template was already escaped by
-		// ExecuteTemplate so we can trust it.
-		return noescapeAttr(buf.String()), err
+			// Template was already escaped by
+			// ExecuteTemplate so we can trust it.
+			return noescapeAttr(buf.String()), err
+		},
+
+		// Translate the given message key
+		// into this locale, see translate().
+		"t": func(key string, args ...any) template.HTML {
+			return translate(locale, key, args...)
+		},
+
+		// Locale being rendered in, eg.,
+		// for use in an html "lang" attr.
+		"locale": func() string {
+			return locale
+		},
 	}
 
 	// Load functions into the base template, and
 	// associate other templates with base template.
-	templateGlob := filepath.Join(templateDirAbs, "*")
-	tmpl, err = tmpl.Funcs(funcMap).ParseGlob(templateGlob)
-	if err != nil {
-		return gtserror.Newf("error loading templates: %w", err)
-	}
+	return tmpl.
+		Funcs(funcMap).
+		Funcs(localeFuncs).
+		ParseGlob(templateGlob)
+}
 
-	// Almost done; teach the
-	// engine how to render.
-	engine.SetFuncMap(funcMap)
-	engine.HTMLRender = render.HTMLProduction{Template: tmpl}
+// localizedHTMLRender implements gin's render.HTMLRender,
+// rendering with the set of templates for the "locale"
+// key set in template data, or the default locale's.
+type localizedHTMLRender map[string]*template.Template
 
-	return nil
+func (r localizedHTMLRender) Instance(name string, data any) render.Render {
+	tmpl := r[i18n.DefaultLocale]
+
+	if obj, ok := data.(map[string]any); ok {
+		locale, _ := obj["locale"].(string)
+		if t, ok := r[locale]; ok {
+			tmpl = t
+		}
+	}
+
+	return render.HTML{
+		Template: tmpl,
+		Name:     name,
+		Data:     data,
+	}
 }
 
 var funcMap = template.FuncMap{
@@ -133,6 +191,25 @@ var funcMap = template.FuncMap{
 	"visibilityIcon":   visibilityIcon,
 }
 
+// translate returns the message for key in the given
+// locale as HTML, with args formatted into it using %s.
+//
+// Catalog messages are trusted and may contain markup,
+// but args are escaped unless already template.HTML,
+// eg., as returned from "include".
+func translate(locale string, key string, args ...any) template.HTML {
+	for i, arg := range args {
+		if html, ok := arg.(template.HTML); ok {
+			args[i] = string(html)
+			continue
+		}
+
+		args[i] = template.HTMLEscapeString(fmt.Sprint(arg))
+	}
+
+	return noescape(i18n.Translate(locale, key, args...))
+}
+
 func oddOrEven(n int) string {
 	if n%2 == 0 {
 		return "even"
@@ -0,0 +1,580 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"codeberg.org/gruf/go-storage"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+// azureVersion is the version of the
+// Azure Storage REST API that we speak.
+const azureVersion = "2021-08-06"
+
+// azureStorage is a storage implementation that stores key-value
+// pairs as block blobs in an Azure Blob Storage container, using
+// the REST API, authenticated with the storage account's key.
+type azureStorage struct {
+	client    *http.Client
+	endpoint  *url.URL
+	account   string
+	key       []byte
+	container string
+}
+
+// NewAzureStorage returns a new Driver backed by Azure Blob Storage.
+func NewAzureStorage() (*Driver, error) {
+	// Load runtime configuration
+	account := config.GetStorageAzureAccountName()
+	key := config.GetStorageAzureAccountKey()
+	container := config.GetStorageAzureContainer()
+	endpoint := config.GetStorageAzureEndpoint()
+
+	if endpoint == "" {
+		endpoint = "https://" + account + ".blob.core.windows.net"
+	}
+
+	azure, err := openAzure(context.Background(), endpoint, account, key, container)
+	if err != nil {
+		return nil, fmt.Errorf("error opening azure storage: %w", err)
+	}
+
+	return &Driver{
+		Proxy:   config.GetStorageAzureProxy(),
+		Storage: azure,
+
+		PresignedCache: newPresignedCache(),
+	}, nil
+}
+
+// openAzure opens container at the given Blob service
+// endpoint, authenticating with the given (base64)
+// account key, and checks that the container exists.
+func openAzure(ctx context.Context, endpoint string, account string, key string, container string) (*azureStorage, error) {
+	u, err := url.Parse(strings.TrimSuffix(endpoint, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing endpoint: %w", err)
+	}
+
+	k, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding account key: %w", err)
+	}
+
+	st := &azureStorage{
+		client:    &http.Client{},
+		endpoint:  u,
+		account:   account,
+		key:       k,
+		container: container,
+	}
+
+	// Check that provided container actually exists.
+	query := url.Values{"restype": []string{"container"}}
+	rsp, err := st.do(ctx, http.MethodGet, st.containerURL(query), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+
+	switch rsp.StatusCode {
+	case http.StatusOK:
+		return st, nil
+	case http.StatusNotFound:
+		return nil, errors.New("storage/azure: container does not exist")
+	default:
+		return nil, responseError("storage/azure", rsp)
+	}
+}
+
+// ReadBytes: implements Storage.ReadBytes().
+func (st *azureStorage) ReadBytes(ctx context.Context, key string) ([]byte, error) {
+	rc, err := st.ReadStream(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+// ReadStream: implements Storage.ReadStream().
+func (st *azureStorage) ReadStream(ctx context.Context, key string) (io.ReadCloser, error) {
+	return st.openAt(ctx, key, 0)
+}
+
+// OpenObject: implements objectStorage.OpenObject().
+func (st *azureStorage) OpenObject(ctx context.Context, key string) (io.ReadSeekCloser, error) {
+	entry, err := st.Stat(ctx, key)
+	if err != nil {
+		return nil, err
+	} else if entry == nil {
+		return nil, fmt.Errorf("%w: %s", storage.ErrNotFound, key)
+	}
+
+	return &rangeReader{
+		ctx: ctx,
+		open: func(ctx context.Context, offset int64) (io.ReadCloser, error) {
+			return st.openAt(ctx, key, offset)
+		},
+		size: entry.Size,
+	}, nil
+}
+
+// openAt opens the blob at key for reading from offset.
+func (st *azureStorage) openAt(ctx context.Context, key string, offset int64) (io.ReadCloser, error) {
+	var hdr http.Header
+	if offset > 0 {
+		hdr = http.Header{"X-Ms-Range": []string{"bytes=" + strconv.FormatInt(offset, 10) + "-"}}
+	}
+
+	rsp, err := st.do(ctx, http.MethodGet, st.blobURL(key, nil), hdr, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	switch rsp.StatusCode {
+	case http.StatusOK, http.StatusPartialContent:
+		return rsp.Body, nil
+	case http.StatusRequestedRangeNotSatisfiable:
+		// Offset is at (or past) the end.
+		rsp.Body.Close()
+		return io.NopCloser(bytes.NewReader(nil)), nil
+	case http.StatusNotFound:
+		rsp.Body.Close()
+		return nil, fmt.Errorf("%w: %s", storage.ErrNotFound, key)
+	default:
+		defer rsp.Body.Close()
+		return nil, responseError("storage/azure", rsp)
+	}
+}
+
+// WriteBytes: implements Storage.WriteBytes().
+func (st *azureStorage) WriteBytes(ctx context.Context, key string, value []byte) (int, error) {
+	n, err := st.WriteStream(ctx, key, bytes.NewReader(value))
+	return int(n), err
+}
+
+// WriteStream: implements Storage.WriteStream(). Blobs are
+// only ever created, never overwritten, as with disk storage.
+func (st *azureStorage) WriteStream(ctx context.Context, key string, r io.Reader) (int64, error) {
+	chunk := make([]byte, objectChunkSize)
+
+	n, last, err := readChunk(r, chunk)
+	if err != nil {
+		return 0, err
+	}
+
+	if last {
+		// Small enough to do
+		// in a single Put Blob.
+		hdr := http.Header{
+			"X-Ms-Blob-Type": []string{"BlockBlob"},
+			"If-None-Match":  []string{"*"},
+		}
+
+		rsp, err := st.do(ctx, http.MethodPut, st.blobURL(key, nil), hdr, bytes.NewReader(chunk[:n]))
+		if err != nil {
+			return 0, err
+		}
+		defer rsp.Body.Close()
+
+		if err := st.checkWrite(key, rsp); err != nil {
+			return 0, err
+		}
+
+		return int64(n), nil
+	}
+
+	var (
+		total    int64
+		blockIDs []string
+	)
+
+	// Stage each chunk as an (uncommitted)
+	// block, then commit them all at once.
+	for {
+		// Block IDs must all be of the same length.
+		id := base64.StdEncoding.EncodeToString(
+			[]byte(fmt.Sprintf("%08d", len(blockIDs))),
+		)
+
+		query := url.Values{
+			"comp":    []string{"block"},
+			"blockid": []string{id},
+		}
+
+		rsp, err := st.do(ctx, http.MethodPut, st.blobURL(key, query), nil, bytes.NewReader(chunk[:n]))
+		if err != nil {
+			return 0, err
+		}
+		rsp.Body.Close()
+
+		if rsp.StatusCode != http.StatusCreated {
+			return 0, responseError("storage/azure", rsp)
+		}
+
+		blockIDs = append(blockIDs, id)
+		total += int64(n)
+
+		if last {
+			break
+		}
+
+		if n, last, err = readChunk(r, chunk); err != nil {
+			return 0, err
+		} else if n == 0 {
+			break
+		}
+	}
+
+	var list bytes.Buffer
+	list.WriteString(`<?xml version="1.0" encoding="utf-8"?><BlockList>`)
+	for _, id := range blockIDs {
+		list.WriteString("<Latest>" + id + "</Latest>")
+	}
+	list.WriteString("</BlockList>")
+
+	hdr := http.Header{"If-None-Match": []string{"*"}}
+	query := url.Values{"comp": []string{"blocklist"}}
+
+	rsp, err := st.do(ctx, http.MethodPut, st.blobURL(key, query), hdr, &list)
+	if err != nil {
+		return 0, err
+	}
+	defer rsp.Body.Close()
+
+	if err := st.checkWrite(key, rsp); err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// checkWrite checks the response to a blob write.
+func (st *azureStorage) checkWrite(key string, rsp *http.Response) error {
+	switch rsp.StatusCode {
+	case http.StatusCreated:
+		return nil
+	case http.StatusConflict, http.StatusPreconditionFailed:
+		return fmt.Errorf("%w: %s", storage.ErrAlreadyExists, key)
+	default:
+		return responseError("storage/azure", rsp)
+	}
+}
+
+// Stat: implements Storage.Stat().
+func (st *azureStorage) Stat(ctx context.Context, key string) (*storage.Entry, error) {
+	rsp, err := st.do(ctx, http.MethodHead, st.blobURL(key, nil), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+
+	switch rsp.StatusCode {
+	case http.StatusOK:
+		return &storage.Entry{
+			Key:  key,
+			Size: rsp.ContentLength,
+		}, nil
+	case http.StatusNotFound:
+		return nil, nil
+	default:
+		return nil, responseError("storage/azure", rsp)
+	}
+}
+
+// Remove: implements Storage.Remove().
+func (st *azureStorage) Remove(ctx context.Context, key string) error {
+	rsp, err := st.do(ctx, http.MethodDelete, st.blobURL(key, nil), nil, nil)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+
+	switch rsp.StatusCode {
+	case http.StatusAccepted:
+		return nil
+	case http.StatusNotFound:
+		return fmt.Errorf("%w: %s", storage.ErrNotFound, key)
+	default:
+		return responseError("storage/azure", rsp)
+	}
+}
+
+// Clean: implements Storage.Clean().
+func (st *azureStorage) Clean(ctx context.Context) error {
+	return nil // nothing to do for Azure
+}
+
+// WalkKeys: implements Storage.WalkKeys().
+func (st *azureStorage) WalkKeys(ctx context.Context, opts storage.WalkKeysOpts) error {
+	if opts.Step == nil {
+		panic("nil step fn")
+	}
+
+	var marker string
+
+	for {
+		query := url.Values{
+			"restype":    []string{"container"},
+			"comp":       []string{"list"},
+			"maxresults": []string{"1000"},
+		}
+		if opts.Prefix != "" {
+			query.Set("prefix", opts.Prefix)
+		}
+		if marker != "" {
+			query.Set("marker", marker)
+		}
+
+		page, err := st.listPage(ctx, st.containerURL(query))
+		if err != nil {
+			return err
+		}
+
+		for _, blob := range page.Blobs {
+			if opts.Filter != nil && !opts.Filter(blob.Name) {
+				continue
+			}
+
+			if err := opts.Step(storage.Entry{
+				Key:  blob.Name,
+				Size: blob.Size,
+			}); err != nil {
+				return err
+			}
+		}
+
+		if page.NextMarker == "" {
+			return nil
+		}
+
+		marker = page.NextMarker
+	}
+}
+
+// azureBlobs is a page of a blob listing.
+type azureBlobs struct {
+	Blobs []struct {
+		Name string `xml:"Name"`
+		Size int64  `xml:"Properties>Content-Length"`
+	} `xml:"Blobs>Blob"`
+	NextMarker string `xml:"NextMarker"`
+}
+
+func (st *azureStorage) listPage(ctx context.Context, u string) (*azureBlobs, error) {
+	rsp, err := st.do(ctx, http.MethodGet, u, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusOK {
+		return nil, responseError("storage/azure", rsp)
+	}
+
+	var page azureBlobs
+	if err := xml.NewDecoder(rsp.Body).Decode(&page); err != nil {
+		return nil, fmt.Errorf("storage/azure: error decoding blob listing: %w", err)
+	}
+
+	return &page, nil
+}
+
+// SignedURL: implements objectStorage.SignedURL(),
+// returning a read-only service SAS URL for the blob.
+//
+// See: https://learn.microsoft.com/en-us/rest/api/storageservices/create-service-sas
+func (st *azureStorage) SignedURL(ctx context.Context, key string, expiry time.Duration, contentType string) (*url.URL, error) {
+	return st.signedURL(key, time.Now().Add(expiry), contentType)
+}
+
+// signedURL returns a read-only service SAS
+// URL for the blob that expires at given time.
+func (st *azureStorage) signedURL(key string, expiry time.Time, contentType string) (*url.URL, error) {
+	const (
+		sasVersion     = "2020-12-06"
+		sasPermissions = "r"
+		sasResource    = "b"
+	)
+
+	expires := expiry.UTC().Format("2006-01-02T15:04:05Z")
+
+	// Only allow https if
+	// the endpoint uses it.
+	var protocol string
+	if st.endpoint.Scheme == "https" {
+		protocol = "https"
+	}
+
+	stringToSign := strings.Join([]string{
+		sasPermissions,
+		"", // start
+		expires,
+		"/blob/" + st.account + "/" + st.container + "/" + key,
+		"", // identifier
+		"", // ip
+		protocol,
+		sasVersion,
+		sasResource,
+		"", // snapshot time
+		"", // encryption scope
+		"", // cache-control
+		"", // content-disposition
+		"", // content-encoding
+		"", // content-language
+		contentType,
+	}, "\n")
+
+	query := url.Values{
+		"sv":  []string{sasVersion},
+		"sr":  []string{sasResource},
+		"sp":  []string{sasPermissions},
+		"se":  []string{expires},
+		"sig": []string{st.sign(stringToSign)},
+	}
+	if protocol != "" {
+		query.Set("spr", protocol)
+	}
+	if contentType != "" {
+		query.Set("rsct", contentType)
+	}
+
+	return url.Parse(st.blobURL(key, query))
+}
+
+// containerURL returns the URL of
+// the container, with given query.
+func (st *azureStorage) containerURL(query url.Values) string {
+	u := *st.endpoint
+	u.Path += "/" + st.container
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// blobURL returns the URL of the
+// blob at key, with given query.
+func (st *azureStorage) blobURL(key string, query url.Values) string {
+	u := *st.endpoint
+	u.Path += "/" + st.container + "/" + key
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// do performs a request to the Blob service,
+// authorized with the Shared Key scheme.
+func (st *azureStorage) do(ctx context.Context, method string, u string, hdr http.Header, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range hdr {
+		req.Header[k] = v
+	}
+
+	req.Header.Set("X-Ms-Date", time.Now().UTC().Format(http.TimeFormat))
+	req.Header.Set("X-Ms-Version", azureVersion)
+	req.Header.Set("Authorization", "SharedKey "+st.account+":"+st.sign(st.stringToSign(req)))
+
+	return st.client.Do(req)
+}
+
+// stringToSign returns the string to sign
+// for a Shared Key authorized request.
+//
+// See: https://learn.microsoft.com/en-us/rest/api/storageservices/authorize-with-shared-key
+func (st *azureStorage) stringToSign(req *http.Request) string {
+	var contentLength string
+	if req.ContentLength > 0 {
+		contentLength = strconv.FormatInt(req.ContentLength, 10)
+	}
+
+	// Canonicalized x-ms-* headers,
+	// sorted by lowercase name.
+	var names []string
+	for k := range req.Header {
+		if k := strings.ToLower(k); strings.HasPrefix(k, "x-ms-") {
+			names = append(names, k)
+		}
+	}
+	slices.Sort(names)
+
+	var headers strings.Builder
+	for _, k := range names {
+		headers.WriteString(k + ":" + strings.TrimSpace(req.Header.Get(k)) + "\n")
+	}
+
+	// Canonicalized resource, from the
+	// account, path and sorted query.
+	var resource strings.Builder
+	resource.WriteString("/" + st.account + req.URL.EscapedPath())
+
+	query := req.URL.Query()
+	params := make([]string, 0, len(query))
+	for k := range query {
+		params = append(params, k)
+	}
+	slices.Sort(params)
+
+	for _, k := range params {
+		values := query[k]
+		slices.Sort(values)
+		resource.WriteString("\n" + strings.ToLower(k) + ":" + strings.Join(values, ","))
+	}
+
+	return strings.Join([]string{
+		req.Method,
+		req.Header.Get("Content-Encoding"),
+		req.Header.Get("Content-Language"),
+		contentLength,
+		req.Header.Get("Content-MD5"),
+		req.Header.Get("Content-Type"),
+		"", // date, we set x-ms-date
+		req.Header.Get("If-Modified-Since"),
+		req.Header.Get("If-Match"),
+		req.Header.Get("If-None-Match"),
+		req.Header.Get("If-Unmodified-Since"),
+		req.Header.Get("Range"),
+		headers.String() + resource.String(),
+	}, "\n")
+}
+
+// sign returns the base64 HMAC-SHA256 of
+// s, keyed with the storage account key.
+func (st *azureStorage) sign(s string) string {
+	mac := hmac.New(sha256.New, st.key)
+	mac.Write([]byte(s))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,300 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	// azureTestAccount is the
+	// storage account of tests.
+	azureTestAccount = "gtsstorage"
+
+	// azureTestKey is the base64
+	// account key used in tests.
+	azureTestKey = "Z290b3NvY2lhbC10ZXN0LWFjY291bnQta2V5LTAxMjM="
+)
+
+// newFakeAzure returns a server faking the parts of the
+// Blob service REST API we use, for container "media".
+func newFakeAzure(t *testing.T) *httptest.Server {
+	var (
+		objects = newFakeObjects()
+		blocks  sync.Map // key + block ID -> []byte
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "SharedKey "+azureTestAccount+":") ||
+			r.Header.Get("X-Ms-Date") == "" || r.Header.Get("X-Ms-Version") != azureVersion {
+			rw.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		query := r.URL.Query()
+
+		if r.URL.Path == "/media" {
+			if query.Get("restype") != "container" {
+				rw.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			if query.Get("comp") != "list" {
+				// Get Container Properties.
+				rw.WriteHeader(http.StatusOK)
+				return
+			}
+
+			keys, next := objects.page(query.Get("prefix"), query.Get("marker"))
+
+			var list bytes.Buffer
+			list.WriteString(`<?xml version="1.0" encoding="utf-8"?><EnumerationResults><Blobs>`)
+			for _, key := range keys {
+				value, _ := objects.get(key)
+				list.WriteString("<Blob><Name>" + key + "</Name><Properties><Content-Length>")
+				list.WriteString(strconv.Itoa(len(value)))
+				list.WriteString("</Content-Length></Properties></Blob>")
+			}
+			list.WriteString("</Blobs><NextMarker>" + next + "</NextMarker></EnumerationResults>")
+
+			rw.WriteHeader(http.StatusOK)
+			_, _ = rw.Write(list.Bytes())
+			return
+		}
+
+		key, ok := strings.CutPrefix(r.URL.Path, "/media/")
+		if !ok {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		switch {
+		case r.Method == http.MethodPut && query.Get("comp") == "block":
+			value, _ := io.ReadAll(r.Body)
+			blocks.Store(key+query.Get("blockid"), value)
+			rw.WriteHeader(http.StatusCreated)
+
+		case r.Method == http.MethodPut && query.Get("comp") == "blocklist":
+			var list struct {
+				Latest []string `xml:"Latest"`
+			}
+			if err := xml.NewDecoder(r.Body).Decode(&list); err != nil {
+				rw.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			var value []byte
+			for _, id := range list.Latest {
+				block, ok := blocks.LoadAndDelete(key + id)
+				if !ok {
+					rw.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				value = append(value, block.([]byte)...)
+			}
+
+			if r.Header.Get("If-None-Match") != "*" {
+				t.Errorf("block list for %s committed without If-None-Match", key)
+			}
+			if !objects.create(key, value) {
+				rw.WriteHeader(http.StatusConflict)
+				return
+			}
+			rw.WriteHeader(http.StatusCreated)
+
+		case r.Method == http.MethodPut:
+			if r.Header.Get("X-Ms-Blob-Type") != "BlockBlob" {
+				rw.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			value, _ := io.ReadAll(r.Body)
+			if r.Header.Get("If-None-Match") != "*" {
+				t.Errorf("blob %s put without If-None-Match", key)
+			}
+			if !objects.create(key, value) {
+				rw.WriteHeader(http.StatusConflict)
+				return
+			}
+			rw.WriteHeader(http.StatusCreated)
+
+		case r.Method == http.MethodGet, r.Method == http.MethodHead:
+			value, ok := objects.get(key)
+			if !ok {
+				rw.WriteHeader(http.StatusNotFound)
+				return
+			}
+			objects.serve(rw, r.Header.Get("X-Ms-Range"), value)
+
+		case r.Method == http.MethodDelete:
+			if !objects.remove(key) {
+				rw.WriteHeader(http.StatusNotFound)
+				return
+			}
+			rw.WriteHeader(http.StatusAccepted)
+
+		default:
+			rw.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestAzureStorage(t *testing.T) {
+	srv := newFakeAzure(t)
+
+	st, err := openAzure(context.Background(), srv.URL, azureTestAccount, azureTestKey, "media")
+	if err != nil {
+		t.Fatalf("error opening azure storage: %v", err)
+	}
+
+	testObjectStorage(t, st)
+}
+
+func TestAzureStorageNoContainer(t *testing.T) {
+	srv := newFakeAzure(t)
+
+	if _, err := openAzure(context.Background(), srv.URL, azureTestAccount, azureTestKey, "emojis"); err == nil {
+		t.Fatal("expected error opening missing container")
+	}
+}
+
+func TestAzureSharedKey(t *testing.T) {
+	st, err := newTestAzure()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	date := "Fri, 16 Oct 2026 12:00:00 GMT"
+
+	for _, test := range []struct {
+		method       string
+		url          string
+		body         string
+		header       map[string]string
+		stringToSign string
+		signature    string
+	}{
+		{
+			method: http.MethodPut,
+			url:    st.blobURL("01ABC/attachment/original/01DEF.jpeg", nil),
+			body:   "hello",
+			header: map[string]string{
+				"X-Ms-Blob-Type": "BlockBlob",
+				"If-None-Match":  "*",
+			},
+			stringToSign: "PUT\n\n\n5\n\n\n\n\n\n*\n\n\n" +
+				"x-ms-blob-type:BlockBlob\n" +
+				"x-ms-date:" + date + "\n" +
+				"x-ms-version:2021-08-06\n" +
+				"/gtsstorage/media/01ABC/attachment/original/01DEF.jpeg",
+			signature: "/u1MMFkjQKNodF3KZ+9JaceF8m/MuXmQEDAEfjjri+c=",
+		},
+		{
+			method: http.MethodGet,
+			url: st.containerURL(url.Values{
+				"restype":    {"container"},
+				"comp":       {"list"},
+				"maxresults": {"1000"},
+				"prefix":     {"01ABC/"},
+			}),
+			stringToSign: "GET\n\n\n\n\n\n\n\n\n\n\n\n" +
+				"x-ms-date:" + date + "\n" +
+				"x-ms-version:2021-08-06\n" +
+				"/gtsstorage/media\ncomp:list\nmaxresults:1000\nprefix:01ABC/\nrestype:container",
+			signature: "58Jh7WsfsoQYZPgHrLQ0XvDDUf2XgDZ7McMd43vaCLo=",
+		},
+	} {
+		var body io.Reader
+		if test.body != "" {
+			body = strings.NewReader(test.body)
+		}
+
+		req, err := http.NewRequest(test.method, test.url, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for k, v := range test.header {
+			req.Header.Set(k, v)
+		}
+		req.Header.Set("X-Ms-Date", date)
+		req.Header.Set("X-Ms-Version", azureVersion)
+
+		stringToSign := st.stringToSign(req)
+		if stringToSign != test.stringToSign {
+			t.Errorf("%s %s: unexpected string to sign:\n%q", test.method, test.url, stringToSign)
+		}
+
+		if sig := st.sign(stringToSign); sig != test.signature {
+			t.Errorf("%s %s: unexpected signature %s", test.method, test.url, sig)
+		}
+	}
+}
+
+func TestAzureSignedURL(t *testing.T) {
+	st, err := newTestAzure()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expiry := time.Date(2026, 10, 16, 12, 15, 0, 0, time.UTC)
+
+	u, err := st.signedURL("01ABC/attachment/original/01DEF.jpeg", expiry, "image/jpeg")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const expect = "https://gtsstorage.blob.core.windows.net/media/01ABC/attachment/original/01DEF.jpeg" +
+		"?rsct=image%2Fjpeg&se=2026-10-16T12%3A15%3A00Z&sig=6BLD6kIe%2Fem75KUDj%2BV9%2B46XRQQujfUMFJg7taXvIUQ%3D" +
+		"&sp=r&spr=https&sr=b&sv=2020-12-06"
+
+	if u.String() != expect {
+		t.Errorf("unexpected signed url:\n%s", u)
+	}
+}
+
+// newTestAzure returns azure storage for the
+// test account at its default https endpoint,
+// without checking the container exists.
+func newTestAzure() (*azureStorage, error) {
+	key, err := base64.StdEncoding.DecodeString(azureTestKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &azureStorage{
+		endpoint:  &url.URL{Scheme: "https", Host: azureTestAccount + ".blob.core.windows.net"},
+		account:   azureTestAccount,
+		key:       key,
+		container: "media",
+	}, nil
+}
@@ -0,0 +1,676 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"codeberg.org/gruf/go-storage"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// gcsEndpoint is the Google Cloud Storage API
+	// endpoint, also used for signed object URLs.
+	gcsEndpoint = "https://storage.googleapis.com"
+
+	// gcsScope is the OAuth2 scope needed
+	// to read and write bucket objects.
+	gcsScope = "https://www.googleapis.com/auth/devstorage.read_write"
+
+	// gcsTokenTimeout is the longest we wait for an
+	// access token, which all requests are blocked on.
+	gcsTokenTimeout = 30 * time.Second
+)
+
+// gcsCredentials is the part of a Google Cloud
+// service account JSON key file that we need.
+type gcsCredentials struct {
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+}
+
+// gcsStorage is a storage implementation that stores key-value
+// pairs as objects in a Google Cloud Storage bucket, using the
+// JSON API, authenticated as a service account.
+type gcsStorage struct {
+	client   *http.Client
+	endpoint string
+	bucket   string
+	email    string
+	key      *rsa.PrivateKey
+}
+
+// NewGCSStorage returns a new Driver backed by Google Cloud Storage.
+func NewGCSStorage() (*Driver, error) {
+	// Load runtime configuration
+	bucket := config.GetStorageGCSBucket()
+	credsPath := config.GetStorageGCSCredentialsFile()
+
+	creds, err := os.ReadFile(credsPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading gcs credentials file: %w", err)
+	}
+
+	gcs, err := openGCS(context.Background(), gcsEndpoint, bucket, creds)
+	if err != nil {
+		return nil, fmt.Errorf("error opening gcs storage: %w", err)
+	}
+
+	return &Driver{
+		Proxy:   config.GetStorageGCSProxy(),
+		Storage: gcs,
+
+		PresignedCache: newPresignedCache(),
+	}, nil
+}
+
+// openGCS opens bucket at the given API endpoint,
+// authenticating with the given service account
+// JSON key, and checks that the bucket exists.
+func openGCS(ctx context.Context, endpoint string, bucket string, credsJSON []byte) (*gcsStorage, error) {
+	var creds gcsCredentials
+	if err := json.Unmarshal(credsJSON, &creds); err != nil {
+		return nil, fmt.Errorf("error parsing credentials: %w", err)
+	}
+
+	if creds.ClientEmail == "" || creds.TokenURI == "" {
+		return nil, errors.New("credentials are not a service account key")
+	}
+
+	key, err := parseRSAPrivateKey(creds.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing credentials private key: %w", err)
+	}
+
+	st := &gcsStorage{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		bucket:   bucket,
+		email:    creds.ClientEmail,
+		key:      key,
+	}
+
+	// Wrap the default client so that all
+	// requests carry a (cached) access token.
+	st.client = &http.Client{
+		Transport: &oauth2.Transport{
+			Source: oauth2.ReuseTokenSource(nil, &gcsTokenSource{
+				st:       st,
+				tokenURI: creds.TokenURI,
+
+				// Tokens can't be fetched using the
+				// client they authorize, so use a
+				// plain one, bounded by a timeout.
+				client: &http.Client{
+					Timeout: gcsTokenTimeout,
+				},
+			}),
+		},
+	}
+
+	// Check that provided bucket actually exists.
+	u := st.endpoint + "/storage/v1/b/" + url.PathEscape(bucket)
+	rsp, err := st.do(ctx, http.MethodGet, u, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+
+	switch rsp.StatusCode {
+	case http.StatusOK:
+		return st, nil
+	case http.StatusNotFound:
+		return nil, errors.New("storage/gcs: bucket does not exist")
+	default:
+		return nil, responseError("storage/gcs", rsp)
+	}
+}
+
+// ReadBytes: implements Storage.ReadBytes().
+func (st *gcsStorage) ReadBytes(ctx context.Context, key string) ([]byte, error) {
+	rc, err := st.ReadStream(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+// ReadStream: implements Storage.ReadStream().
+func (st *gcsStorage) ReadStream(ctx context.Context, key string) (io.ReadCloser, error) {
+	return st.openAt(ctx, key, 0)
+}
+
+// OpenObject: implements objectStorage.OpenObject().
+func (st *gcsStorage) OpenObject(ctx context.Context, key string) (io.ReadSeekCloser, error) {
+	entry, err := st.Stat(ctx, key)
+	if err != nil {
+		return nil, err
+	} else if entry == nil {
+		return nil, fmt.Errorf("%w: %s", storage.ErrNotFound, key)
+	}
+
+	return &rangeReader{
+		ctx: ctx,
+		open: func(ctx context.Context, offset int64) (io.ReadCloser, error) {
+			return st.openAt(ctx, key, offset)
+		},
+		size: entry.Size,
+	}, nil
+}
+
+// openAt opens the object at key for reading from offset.
+func (st *gcsStorage) openAt(ctx context.Context, key string, offset int64) (io.ReadCloser, error) {
+	var hdr http.Header
+	if offset > 0 {
+		hdr = http.Header{"Range": []string{"bytes=" + strconv.FormatInt(offset, 10) + "-"}}
+	}
+
+	rsp, err := st.do(ctx, http.MethodGet, st.objectURL(key)+"?alt=media", hdr, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	switch rsp.StatusCode {
+	case http.StatusOK, http.StatusPartialContent:
+		return rsp.Body, nil
+	case http.StatusRequestedRangeNotSatisfiable:
+		// Offset is at (or past) the end.
+		rsp.Body.Close()
+		return io.NopCloser(bytes.NewReader(nil)), nil
+	case http.StatusNotFound:
+		rsp.Body.Close()
+		return nil, fmt.Errorf("%w: %s", storage.ErrNotFound, key)
+	default:
+		defer rsp.Body.Close()
+		return nil, responseError("storage/gcs", rsp)
+	}
+}
+
+// WriteBytes: implements Storage.WriteBytes().
+func (st *gcsStorage) WriteBytes(ctx context.Context, key string, value []byte) (int, error) {
+	n, err := st.WriteStream(ctx, key, bytes.NewReader(value))
+	return int(n), err
+}
+
+// WriteStream: implements Storage.WriteStream(). Objects are
+// only ever created, never overwritten, as with disk storage.
+func (st *gcsStorage) WriteStream(ctx context.Context, key string, r io.Reader) (int64, error) {
+	chunk := make([]byte, objectChunkSize)
+
+	n, last, err := readChunk(r, chunk)
+	if err != nil {
+		return 0, err
+	}
+
+	if last {
+		// Small enough to do
+		// in a single upload.
+		u := st.endpoint + "/upload/storage/v1/b/" + url.PathEscape(st.bucket) + "/o?" + url.Values{
+			"uploadType":        []string{"media"},
+			"name":              []string{key},
+			"ifGenerationMatch": []string{"0"},
+		}.Encode()
+
+		rsp, err := st.do(ctx, http.MethodPost, u, nil, bytes.NewReader(chunk[:n]))
+		if err != nil {
+			return 0, err
+		}
+		defer rsp.Body.Close()
+
+		if err := st.checkWrite(key, rsp); err != nil {
+			return 0, err
+		}
+
+		return int64(n), nil
+	}
+
+	// Start a resumable upload
+	// to send it all in chunks.
+	u := st.endpoint + "/upload/storage/v1/b/" + url.PathEscape(st.bucket) + "/o?" + url.Values{
+		"uploadType":        []string{"resumable"},
+		"name":              []string{key},
+		"ifGenerationMatch": []string{"0"},
+	}.Encode()
+
+	rsp, err := st.do(ctx, http.MethodPost, u, nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	rsp.Body.Close()
+
+	if err := st.checkWrite(key, rsp); err != nil {
+		return 0, err
+	}
+
+	session := rsp.Header.Get("Location")
+	if session == "" {
+		return 0, errors.New("storage/gcs: no resumable upload session returned")
+	}
+
+	var total int64
+
+	for {
+		// Total is only known (and given)
+		// with the last chunk, "*" until then.
+		size := "*"
+		if last {
+			size = strconv.FormatInt(total+int64(n), 10)
+		}
+
+		rng := "bytes */" + size
+		if n > 0 {
+			rng = fmt.Sprintf("bytes %d-%d/%s", total, total+int64(n)-1, size)
+		}
+
+		hdr := http.Header{"Content-Range": []string{rng}}
+		rsp, err := st.do(ctx, http.MethodPut, session, hdr, bytes.NewReader(chunk[:n]))
+		if err != nil {
+			return 0, err
+		}
+		rsp.Body.Close()
+
+		total += int64(n)
+
+		if last {
+			if err := st.checkWrite(key, rsp); err != nil {
+				return 0, err
+			}
+			return total, nil
+		}
+
+		// 308 means send
+		// us the next chunk.
+		if rsp.StatusCode != http.StatusPermanentRedirect {
+			return 0, responseError("storage/gcs", rsp)
+		}
+
+		if n, last, err = readChunk(r, chunk); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// checkWrite checks the response to an object write.
+func (st *gcsStorage) checkWrite(key string, rsp *http.Response) error {
+	switch rsp.StatusCode {
+	case http.StatusOK, http.StatusCreated:
+		return nil
+	case http.StatusPreconditionFailed:
+		return fmt.Errorf("%w: %s", storage.ErrAlreadyExists, key)
+	default:
+		return responseError("storage/gcs", rsp)
+	}
+}
+
+// Stat: implements Storage.Stat().
+func (st *gcsStorage) Stat(ctx context.Context, key string) (*storage.Entry, error) {
+	rsp, err := st.do(ctx, http.MethodGet, st.objectURL(key)+"?fields=size", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+
+	switch rsp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, nil
+	default:
+		return nil, responseError("storage/gcs", rsp)
+	}
+
+	var obj gcsObject
+	if err := json.NewDecoder(rsp.Body).Decode(&obj); err != nil {
+		return nil, fmt.Errorf("storage/gcs: error decoding object metadata: %w", err)
+	}
+
+	return &storage.Entry{
+		Key:  key,
+		Size: obj.Size,
+	}, nil
+}
+
+// Remove: implements Storage.Remove().
+func (st *gcsStorage) Remove(ctx context.Context, key string) error {
+	rsp, err := st.do(ctx, http.MethodDelete, st.objectURL(key), nil, nil)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+
+	switch rsp.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		return nil
+	case http.StatusNotFound:
+		return fmt.Errorf("%w: %s", storage.ErrNotFound, key)
+	default:
+		return responseError("storage/gcs", rsp)
+	}
+}
+
+// Clean: implements Storage.Clean().
+func (st *gcsStorage) Clean(ctx context.Context) error {
+	return nil // nothing to do for GCS
+}
+
+// WalkKeys: implements Storage.WalkKeys().
+func (st *gcsStorage) WalkKeys(ctx context.Context, opts storage.WalkKeysOpts) error {
+	if opts.Step == nil {
+		panic("nil step fn")
+	}
+
+	var pageToken string
+
+	for {
+		query := url.Values{
+			"fields":     []string{"items(name,size),nextPageToken"},
+			"maxResults": []string{"1000"},
+		}
+		if opts.Prefix != "" {
+			query.Set("prefix", opts.Prefix)
+		}
+		if pageToken != "" {
+			query.Set("pageToken", pageToken)
+		}
+
+		u := st.endpoint + "/storage/v1/b/" + url.PathEscape(st.bucket) + "/o?" + query.Encode()
+		page, err := st.listPage(ctx, u)
+		if err != nil {
+			return err
+		}
+
+		for _, obj := range page.Items {
+			if opts.Filter != nil && !opts.Filter(obj.Name) {
+				continue
+			}
+
+			if err := opts.Step(storage.Entry{
+				Key:  obj.Name,
+				Size: obj.Size,
+			}); err != nil {
+				return err
+			}
+		}
+
+		if page.NextPageToken == "" {
+			return nil
+		}
+
+		pageToken = page.NextPageToken
+	}
+}
+
+// gcsObject is the part of object metadata we need.
+type gcsObject struct {
+	Name string `json:"name"`
+	// Sizes are given as
+	// strings, being uint64.
+	Size int64 `json:"size,string"`
+}
+
+// gcsObjects is a page of an object listing.
+type gcsObjects struct {
+	Items         []gcsObject `json:"items"`
+	NextPageToken string      `json:"nextPageToken"`
+}
+
+func (st *gcsStorage) listPage(ctx context.Context, u string) (*gcsObjects, error) {
+	rsp, err := st.do(ctx, http.MethodGet, u, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusOK {
+		return nil, responseError("storage/gcs", rsp)
+	}
+
+	var page gcsObjects
+	if err := json.NewDecoder(rsp.Body).Decode(&page); err != nil {
+		return nil, fmt.Errorf("storage/gcs: error decoding object listing: %w", err)
+	}
+
+	return &page, nil
+}
+
+// SignedURL: implements objectStorage.SignedURL(), returning a
+// V4 signed URL, signed with the service account's private key.
+//
+// See: https://cloud.google.com/storage/docs/access-control/signing-urls-manually
+func (st *gcsStorage) SignedURL(ctx context.Context, key string, expiry time.Duration, contentType string) (*url.URL, error) {
+	return st.signedURL(key, time.Now(), expiry, contentType)
+}
+
+// signedURL returns a V4 signed URL for the object
+// at key, signed at now and valid for expiry.
+func (st *gcsStorage) signedURL(key string, now time.Time, expiry time.Duration, contentType string) (*url.URL, error) {
+	u, err := url.Parse(st.endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	now = now.UTC()
+	timestamp := now.Format("20060102T150405Z")
+	scope := now.Format("20060102") + "/auto/storage/goog4_request"
+
+	u.Path = "/" + st.bucket + "/" + key
+
+	query := url.Values{
+		"X-Goog-Algorithm":     []string{"GOOG4-RSA-SHA256"},
+		"X-Goog-Credential":    []string{st.email + "/" + scope},
+		"X-Goog-Date":          []string{timestamp},
+		"X-Goog-Expires":       []string{strconv.Itoa(int(expiry.Seconds()))},
+		"X-Goog-SignedHeaders": []string{"host"},
+	}
+	if contentType != "" {
+		query.Set("response-content-type", contentType)
+	}
+
+	// Encode sorts by key, and the only
+	// thing to fix for RFC 3986 is spaces.
+	canonicalQuery := strings.ReplaceAll(query.Encode(), "+", "%20")
+
+	canonicalRequest := strings.Join([]string{
+		http.MethodGet,
+		u.EscapedPath(),
+		canonicalQuery,
+		"host:" + u.Host + "\n",
+		"host",
+		"UNSIGNED-PAYLOAD",
+	}, "\n")
+
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		"GOOG4-RSA-SHA256",
+		timestamp,
+		scope,
+		hex.EncodeToString(hash[:]),
+	}, "\n")
+
+	sig, err := st.sign([]byte(stringToSign))
+	if err != nil {
+		return nil, err
+	}
+
+	u.RawQuery = canonicalQuery + "&X-Goog-Signature=" + hex.EncodeToString(sig)
+	return u, nil
+}
+
+// objectURL returns the API URL for the object at key.
+func (st *gcsStorage) objectURL(key string) string {
+	return st.endpoint + "/storage/v1/b/" + url.PathEscape(st.bucket) + "/o/" + url.PathEscape(key)
+}
+
+// do performs an authenticated request to the GCS API.
+func (st *gcsStorage) do(ctx context.Context, method string, u string, hdr http.Header, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range hdr {
+		req.Header[k] = v
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/octet-stream")
+	}
+
+	return st.client.Do(req)
+}
+
+// sign signs data with the service
+// account's key, using RSA-SHA256.
+func (st *gcsStorage) sign(data []byte) ([]byte, error) {
+	hash := sha256.Sum256(data)
+	return rsa.SignPKCS1v15(rand.Reader, st.key, crypto.SHA256, hash[:])
+}
+
+// gcsTokenSource implements oauth2.TokenSource, fetching access
+// tokens for the service account using a signed JWT assertion.
+//
+// See: https://developers.google.com/identity/protocols/oauth2/service-account#httprest
+type gcsTokenSource struct {
+	st       *gcsStorage
+	tokenURI string
+	client   *http.Client
+}
+
+func (ts *gcsTokenSource) Token() (*oauth2.Token, error) {
+	now := time.Now()
+
+	assertion, err := ts.assertion(now)
+	if err != nil {
+		return nil, fmt.Errorf("storage/gcs: error signing token request: %w", err)
+	}
+
+	// Token() isn't passed a context, so bound the
+	// request ourselves, else a hanging token endpoint
+	// would block every storage operation forever.
+	ctx, cancel := context.WithTimeout(context.Background(), gcsTokenTimeout)
+	defer cancel()
+
+	form := url.Values{
+		"grant_type": []string{"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  []string{assertion},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ts.tokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("storage/gcs: error creating token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	rsp, err := ts.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("storage/gcs: error requesting token: %w", err)
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusOK {
+		return nil, responseError("storage/gcs", rsp)
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		TokenType   string `json:"token_type"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+
+	if err := json.NewDecoder(rsp.Body).Decode(&token); err != nil {
+		return nil, fmt.Errorf("storage/gcs: error decoding token: %w", err)
+	}
+
+	return &oauth2.Token{
+		AccessToken: token.AccessToken,
+		TokenType:   token.TokenType,
+		Expiry:      now.Add(time.Duration(token.ExpiresIn) * time.Second),
+	}, nil
+}
+
+// assertion returns the signed JWT with which
+// to request an access token issued at now.
+func (ts *gcsTokenSource) assertion(now time.Time) (string, error) {
+	claims, err := json.Marshal(map[string]any{
+		"iss":   ts.st.email,
+		"scope": gcsScope,
+		"aud":   ts.tokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	enc := base64.RawURLEncoding
+	unsigned := enc.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`)) +
+		"." + enc.EncodeToString(claims)
+
+	sig, err := ts.st.sign([]byte(unsigned))
+	if err != nil {
+		return "", err
+	}
+
+	return unsigned + "." + enc.EncodeToString(sig), nil
+}
+
+// parseRSAPrivateKey parses a PEM encoded
+// RSA private key, in PKCS #8 or #1 form.
+func parseRSAPrivateKey(s string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(s))
+	if block == nil {
+		return nil, errors.New("no pem block found")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not rsa")
+	}
+
+	return rsaKey, nil
+}
@@ -0,0 +1,133 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"codeberg.org/gruf/go-bytesize"
+)
+
+// objectChunkSize is the size of the chunks in which
+// streams of unknown length are uploaded to object
+// storage by the GCS and Azure Blob implementations.
+const objectChunkSize = int(8 * bytesize.MiB)
+
+// readChunk reads from r into chunk until either it's full,
+// or r is drained, returning the number of bytes read and
+// whether this was the last chunk that r has to offer.
+func readChunk(r io.Reader, chunk []byte) (int, bool, error) {
+	n, err := io.ReadFull(r, chunk)
+	switch {
+	case err == nil:
+		return n, false, nil
+	case errors.Is(err, io.EOF),
+		errors.Is(err, io.ErrUnexpectedEOF):
+		return n, true, nil
+	default:
+		return n, false, err
+	}
+}
+
+// responseError returns an error describing the
+// unexpected response rsp from object storage,
+// including the start of the response body,
+// which usually explains what went wrong.
+func responseError(prefix string, rsp *http.Response) error {
+	b, _ := io.ReadAll(io.LimitReader(rsp.Body, 512))
+	msg := strings.TrimSpace(string(b))
+	return fmt.Errorf("%s: %s %s: %s: %s",
+		prefix,
+		rsp.Request.Method,
+		rsp.Request.URL.Path,
+		rsp.Status,
+		msg,
+	)
+}
+
+// rangeReader is a seekable stream of an object in object
+// storage of known size. Seeking re-opens the stream from
+// the new offset with a ranged request, rather than reading
+// and discarding the bytes leading up to it.
+type rangeReader struct {
+	ctx    context.Context
+	open   func(ctx context.Context, offset int64) (io.ReadCloser, error)
+	size   int64
+	offset int64
+	rc     io.ReadCloser
+}
+
+func (r *rangeReader) Read(b []byte) (int, error) {
+	if r.rc == nil {
+		if r.offset >= r.size {
+			return 0, io.EOF
+		}
+
+		// (Re)open stream
+		// from our offset.
+		rc, err := r.open(r.ctx, r.offset)
+		if err != nil {
+			return 0, err
+		}
+		r.rc = rc
+	}
+
+	n, err := r.rc.Read(b)
+	r.offset += int64(n)
+	return n, err
+}
+
+func (r *rangeReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.offset
+	case io.SeekEnd:
+		offset += r.size
+	default:
+		return 0, errors.New("invalid whence")
+	}
+
+	if offset < 0 {
+		return 0, errors.New("negative position")
+	}
+
+	if offset != r.offset && r.rc != nil {
+		// Stream must be reopened
+		// on next read from here.
+		_ = r.rc.Close()
+		r.rc = nil
+	}
+
+	r.offset = offset
+	return offset, nil
+}
+
+func (r *rangeReader) Close() error {
+	if r.rc == nil {
+		return nil
+	}
+	err := r.rc.Close()
+	r.rc = nil
+	return err
+}
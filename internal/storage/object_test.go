@@ -0,0 +1,223 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"codeberg.org/gruf/go-storage"
+)
+
+// fakeObjects is the in-memory contents
+// of a fake object storage bucket.
+type fakeObjects struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func newFakeObjects() *fakeObjects {
+	return &fakeObjects{objects: make(map[string][]byte)}
+}
+
+// create stores value at key if there's
+// nothing there yet, returning false if
+// there was.
+func (f *fakeObjects) create(key string, value []byte) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.objects[key]; ok {
+		return false
+	}
+	f.objects[key] = value
+	return true
+}
+
+func (f *fakeObjects) get(key string) ([]byte, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	value, ok := f.objects[key]
+	return value, ok
+}
+
+func (f *fakeObjects) remove(key string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, ok := f.objects[key]
+	delete(f.objects, key)
+	return ok
+}
+
+// page returns up to 2 keys with prefix, sorted,
+// starting after the key marker, and the marker
+// for the next page, if there is one. Pages are
+// kept small so that tests walk several of them.
+func (f *fakeObjects) page(prefix string, marker string) ([]string, string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var keys []string
+	for key := range f.objects {
+		if strings.HasPrefix(key, prefix) && key > marker {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	if len(keys) > 2 {
+		return keys[:2], keys[1]
+	}
+	return keys, ""
+}
+
+// serve writes value in response to a read,
+// honouring an open ended "bytes=N-" range.
+func (f *fakeObjects) serve(rw http.ResponseWriter, rng string, value []byte) {
+	if rng == "" {
+		rw.WriteHeader(http.StatusOK)
+		_, _ = rw.Write(value)
+		return
+	}
+
+	offset, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(rng, "bytes="), "-"))
+	if err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if offset >= len(value) {
+		rw.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+		return
+	}
+
+	rw.WriteHeader(http.StatusPartialContent)
+	_, _ = rw.Write(value[offset:])
+}
+
+// testObjectStorage runs st, backed by a fake
+// bucket, through everything storage does with it.
+func testObjectStorage(t *testing.T, st objectStorage) {
+	ctx := context.Background()
+
+	keys := []string{
+		"01ABC/attachment/original/01DEF.jpeg",
+		"01ABC/attachment/small/01DEF.webp",
+		"01ABC/emoji/original/01GHI.png",
+		"01XYZ/attachment/original/01JKL.mp4",
+	}
+
+	for _, key := range keys {
+		if _, err := st.WriteBytes(ctx, key, []byte("value of "+key)); err != nil {
+			t.Fatalf("error writing %s: %v", key, err)
+		}
+	}
+
+	// Existing objects are never overwritten.
+	if _, err := st.WriteBytes(ctx, keys[0], []byte("overwritten")); !errors.Is(err, storage.ErrAlreadyExists) {
+		t.Errorf("expected already exists error, got %v", err)
+	}
+
+	b, err := st.ReadBytes(ctx, keys[0])
+	if err != nil {
+		t.Fatalf("error reading: %v", err)
+	} else if string(b) != "value of "+keys[0] {
+		t.Errorf("unexpected value read: %q", b)
+	}
+
+	if _, err := st.ReadBytes(ctx, "01ABC/nothing/here"); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+
+	entry, err := st.Stat(ctx, keys[0])
+	if err != nil {
+		t.Fatalf("error statting: %v", err)
+	} else if entry == nil || entry.Size != int64(len("value of "+keys[0])) {
+		t.Errorf("unexpected entry: %+v", entry)
+	}
+
+	if entry, err := st.Stat(ctx, "01ABC/nothing/here"); err != nil || entry != nil {
+		t.Errorf("expected no entry, got %+v, %v", entry, err)
+	}
+
+	// Seeking reads from the new
+	// offset with a ranged request.
+	rsc, err := st.OpenObject(ctx, keys[0])
+	if err != nil {
+		t.Fatalf("error opening: %v", err)
+	}
+	if _, err := rsc.Seek(-9, io.SeekEnd); err != nil {
+		t.Fatalf("error seeking: %v", err)
+	}
+	b, err = io.ReadAll(rsc)
+	rsc.Close()
+	if err != nil {
+		t.Fatalf("error reading after seek: %v", err)
+	} else if string(b) != "1DEF.jpeg" {
+		t.Errorf("unexpected value read after seek: %q", b)
+	}
+
+	var walked []string
+	if err := st.WalkKeys(ctx, storage.WalkKeysOpts{
+		Prefix: "01ABC/",
+		Filter: func(key string) bool {
+			return !strings.Contains(key, "/emoji/")
+		},
+		Step: func(entry storage.Entry) error {
+			walked = append(walked, entry.Key)
+			return nil
+		},
+	}); err != nil {
+		t.Fatalf("error walking keys: %v", err)
+	}
+	if !slices.Equal(walked, keys[:2]) {
+		t.Errorf("unexpected keys walked: %v", walked)
+	}
+
+	// Streams larger than a chunk
+	// are uploaded in several parts.
+	big := bytes.Repeat([]byte("0123456789abcdef"), objectChunkSize/16+100)
+	n, err := st.WriteStream(ctx, "01XYZ/attachment/original/01MNO.mp4", bytes.NewReader(big))
+	if err != nil {
+		t.Fatalf("error writing stream: %v", err)
+	} else if n != int64(len(big)) {
+		t.Errorf("expected %d bytes written, got %d", len(big), n)
+	}
+	if b, err := st.ReadBytes(ctx, "01XYZ/attachment/original/01MNO.mp4"); err != nil {
+		t.Fatalf("error reading stream: %v", err)
+	} else if !bytes.Equal(b, big) {
+		t.Errorf("unexpected value read for stream of %d bytes", len(b))
+	}
+
+	if err := st.Remove(ctx, keys[0]); err != nil {
+		t.Fatalf("error removing: %v", err)
+	}
+	if err := st.Remove(ctx, keys[0]); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+	if _, err := st.ReadBytes(ctx, keys[0]); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
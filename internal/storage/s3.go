@@ -0,0 +1,71 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package storage
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"time"
+
+	"codeberg.org/gruf/go-storage"
+	"codeberg.org/gruf/go-storage/s3"
+	"github.com/minio/minio-go/v7"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+// s3Storage wraps S3 storage to
+// implement objectStorage{}.
+type s3Storage struct {
+	*s3.S3Storage
+	bucket string
+}
+
+// OpenObject implements objectStorage{}. The minio client
+// already issues a ranged GET for the remaining bytes on seek.
+func (st *s3Storage) OpenObject(ctx context.Context, key string) (io.ReadSeekCloser, error) {
+	obj, err := st.Client().Client.GetObject(ctx, st.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	// Object is fetched lazily, stat it to surface
+	// any errors (e.g. not found) before returning.
+	if _, err := obj.Stat(); err != nil {
+		obj.Close()
+
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			err = gtserror.Newf("%w: %s", storage.ErrNotFound, key)
+		}
+
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// SignedURL implements objectStorage{}.
+func (st *s3Storage) SignedURL(ctx context.Context, key string, expiry time.Duration, contentType string) (*url.URL, error) {
+	var params url.Values
+	if contentType != "" {
+		params = url.Values{
+			"response-content-type": []string{contentType},
+		}
+	}
+	return st.Client().PresignedGetObject(ctx, st.bucket, key, expiry, params)
+}
@@ -45,11 +45,12 @@ const (
 	urlCacheExpiryFrequency = time.Minute * 5
 )
 
-// PresignedURL represents a pre signed S3 URL with
-// an expiry time.
+// PresignedURL represents a pre signed object
+// storage URL with an expiry time.
 type PresignedURL struct {
 	*url.URL
 	Expiry time.Time // link expires at this time
+	Public bool      // link is unsigned, bucket is public
 }
 
 // IsAlreadyExist returns whether error is an already-exists
@@ -64,14 +65,33 @@ func IsNotFound(err error) bool {
 	return errors.Is(err, storage.ErrNotFound)
 }
 
-// Driver wraps a kv.KVStore to also provide S3 presigned GET URLs.
+// objectStorage is implemented by storage backends that keep
+// objects in a remote bucket (or container), which can be read
+// from any offset, and fetched by clients directly with signed
+// URLs rather than being proxied through GoToSocial.
+type objectStorage interface {
+	storage.Storage
+
+	// OpenObject opens the object at key as a seekable stream.
+	// Seeking on it fetches from the new offset with a ranged
+	// request, rather than streaming (and discarding) the bytes
+	// leading up to it.
+	OpenObject(ctx context.Context, key string) (io.ReadSeekCloser, error)
+
+	// SignedURL returns a URL from which the object at key can
+	// be fetched by anyone until expiry has elapsed, served with
+	// the given content-type, if set.
+	SignedURL(ctx context.Context, key string, expiry time.Duration, contentType string) (*url.URL, error)
+}
+
+// Driver wraps a kv.KVStore to also provide presigned GET URLs for object storage.
 type Driver struct {
 	// Underlying storage
 	Storage storage.Storage
 
-	// S3-only parameters
+	// Object storage-only parameters
 	Proxy          bool
-	Bucket         string
+	Public         bool
 	PresignedCache *ttl.Cache[string, PresignedURL]
 }
 
@@ -81,7 +101,13 @@ func (d *Driver) Get(ctx context.Context, key string) ([]byte, error) {
 }
 
 // GetStream returns an io.ReadCloser for the value bytes at key in the storage.
+//
+// Where the underlying storage allows it, the returned reader also implements
+// io.Seeker, so that byte ranges can be served without reading from the start.
 func (d *Driver) GetStream(ctx context.Context, key string) (io.ReadCloser, error) {
+	if obj, ok := d.Storage.(objectStorage); ok {
+		return obj.OpenObject(ctx, key)
+	}
 	return d.Storage.ReadStream(ctx, key)
 }
 
@@ -106,6 +132,12 @@ func (d *Driver) Has(ctx context.Context, key string) (bool, error) {
 	return (stat != nil), err
 }
 
+// Stat returns the entry for the supplied key
+// in the storage, or nil if it isn't present.
+func (d *Driver) Stat(ctx context.Context, key string) (*storage.Entry, error) {
+	return d.Storage.Stat(ctx, key)
+}
+
 // WalkKeys walks the keys in the storage.
 func (d *Driver) WalkKeys(ctx context.Context, walk func(string) error) error {
 	return d.Storage.WalkKeys(ctx, storage.WalkKeysOpts{
@@ -115,10 +147,11 @@ func (d *Driver) WalkKeys(ctx context.Context, walk func(string) error) error {
 	})
 }
 
-// URL will return a presigned GET object URL, but only if running on S3 storage with proxying disabled.
+// URL will return a presigned GET object URL, but only if running on object storage with proxying disabled.
+// If the bucket is configured as public, a plain (unsigned) object URL is returned instead.
 func (d *Driver) URL(ctx context.Context, key string) *PresignedURL {
-	// Check whether S3 *without* proxying is enabled
-	s3, ok := d.Storage.(*s3.S3Storage)
+	// Check whether object storage *without* proxying is enabled
+	obj, ok := d.Storage.(objectStorage)
 	if !ok || d.Proxy {
 		return nil
 	}
@@ -133,17 +166,23 @@ func (d *Driver) URL(ctx context.Context, key string) *PresignedURL {
 		return &e.Value
 	}
 
-	u, err := s3.Client().PresignedGetObject(ctx, d.Bucket, key, urlCacheTTL, url.Values{
-		"response-content-type": []string{mime.TypeByExtension(path.Ext(key))},
-	})
+	u, err := obj.SignedURL(ctx, key, urlCacheTTL, mime.TypeByExtension(path.Ext(key)))
 	if err != nil {
 		// If URL request fails, fallback is to fetch the file. So ignore the error here
 		return nil
 	}
 
+	if d.Public {
+		// Bucket is publicly readable, so there's no need
+		// for a signature; the bare object URL will do, and
+		// is more cacheable for being the same every time.
+		u.RawQuery = ""
+	}
+
 	psu := PresignedURL{
 		URL:    u,
 		Expiry: time.Now().Add(urlCacheTTL), // link expires in 24h time
+		Public: d.Public,
 	}
 
 	d.PresignedCache.Set(key, psu)
@@ -154,29 +193,29 @@ func (d *Driver) URL(ctx context.Context, key string) *PresignedURL {
 // to a content-security-policy to allow requests to
 // endpoints served by this driver.
 //
-// If the driver is not backed by non-proxying S3,
-// this will return an empty string and no error.
+// If the driver is not backed by non-proxying object
+// storage, this will return an empty string and no error.
 //
 // Otherwise, this function probes for a CSP URI by
 // doing the following:
 //
-//  1. Create a temporary file in the S3 bucket.
+//  1. Create a temporary file in the bucket.
 //  2. Generate a pre-signed URL for that file.
 //  3. Extract '[scheme]://[host]' from the URL.
 //  4. Remove the temporary file.
 //  5. Return the '[scheme]://[host]' string.
 func (d *Driver) ProbeCSPUri(ctx context.Context) (string, error) {
-	// Check whether S3 without proxying
-	// is enabled. If it's not, there's
+	// Check whether object storage without
+	// proxying is enabled. If it's not, there's
 	// no need to add anything to the CSP.
-	s3, ok := d.Storage.(*s3.S3Storage)
+	obj, ok := d.Storage.(objectStorage)
 	if !ok || d.Proxy {
 		return "", nil
 	}
 
 	const cspKey = "gotosocial-csp-probe"
 
-	// Create an empty file in S3 storage.
+	// Create an empty file in object storage.
 	if _, err := d.Put(ctx, cspKey, make([]byte, 0)); err != nil {
 		return "", gtserror.Newf("error putting file in bucket at key %s: %w", cspKey, err)
 	}
@@ -185,12 +224,12 @@ func (d *Driver) ProbeCSPUri(ctx context.Context) (string, error) {
 	defer func() {
 		if err := d.Delete(ctx, cspKey); err != nil {
 			log.Warnf(ctx, "error deleting file from bucket at key %s (%v); "+
-				"you may want to remove this file manually from your S3 bucket", cspKey, err)
+				"you may want to remove this file manually from your bucket", cspKey, err)
 		}
 	}()
 
 	// Get a presigned URL for that empty file.
-	u, err := s3.Client().PresignedGetObject(ctx, d.Bucket, cspKey, 1*time.Second, nil)
+	u, err := obj.SignedURL(ctx, cspKey, 1*time.Second, "")
 	if err != nil {
 		return "", err
 	}
@@ -209,6 +248,10 @@ func AutoConfig() (*Driver, error) {
 	switch backend := config.GetStorageBackend(); backend {
 	case "s3":
 		return NewS3Storage()
+	case "gcs":
+		return NewGCSStorage()
+	case "azure":
+		return NewAzureStorage()
 	case "local":
 		return NewFileStorage()
 	default:
@@ -265,14 +308,19 @@ func NewS3Storage() (*Driver, error) {
 		return nil, fmt.Errorf("error opening s3 storage: %w", err)
 	}
 
-	// ttl should be lower than the expiry used by S3 to avoid serving invalid URLs
-	presignedCache := ttl.New[string, PresignedURL](0, 1000, urlCacheTTL-urlCacheExpiryFrequency)
-	presignedCache.Start(urlCacheExpiryFrequency)
-
 	return &Driver{
-		Proxy:          config.GetStorageS3Proxy(),
-		Bucket:         config.GetStorageS3BucketName(),
-		Storage:        s3,
-		PresignedCache: presignedCache,
+		Proxy:   config.GetStorageS3Proxy(),
+		Public:  config.GetStorageS3BucketPublic(),
+		Storage: &s3Storage{S3Storage: s3, bucket: bucket},
+
+		PresignedCache: newPresignedCache(),
 	}, nil
 }
+
+// newPresignedCache returns a started cache for presigned object storage URLs.
+func newPresignedCache() *ttl.Cache[string, PresignedURL] {
+	// ttl should be lower than the expiry used for signing to avoid serving invalid URLs
+	presignedCache := ttl.New[string, PresignedURL](0, 1000, urlCacheTTL-urlCacheExpiryFrequency)
+	presignedCache.Start(urlCacheExpiryFrequency)
+	return presignedCache
+}
@@ -19,6 +19,7 @@ package stream
 
 import (
 	"context"
+	"errors"
 	"maps"
 	"slices"
 	"sync"
@@ -88,40 +89,82 @@ var AllStatusTimelines = []string{
 	TimelineList,
 }
 
+// DefaultQueueSize is the number of messages queued
+// per Stream{} when Streams{}.QueueSize is not set.
+const DefaultQueueSize = 50
+
+var (
+	// ErrTooManyStreams is returned by Streams{}.Open() when
+	// the maximum number of open streams has been reached.
+	ErrTooManyStreams = errors.New("too many open streams")
+
+	// ErrTooManyAccountStreams is returned by Streams{}.Open() when the
+	// maximum number of open streams for an account has been reached.
+	ErrTooManyAccountStreams = errors.New("too many open streams for account")
+)
+
 type Streams struct {
+	// MaxStreams is the maximum number of streams
+	// that may be open at once. 0 means no limit.
+	MaxStreams int
+
+	// MaxAccountStreams is the maximum number of streams
+	// that may be open at once per account. 0 means no limit.
+	MaxAccountStreams int
+
+	// QueueSize is the number of messages that may be queued
+	// per stream before its client is considered too slow and
+	// the stream is dropped. 0 means use DefaultQueueSize.
+	QueueSize int
+
 	streams map[string][]*Stream
+	total   int
 	mutex   sync.Mutex
 }
 
 // Open will open open a new Stream for given account ID and stream types, the given context will be passed to Stream.
-func (s *Streams) Open(accountID string, streamTypes ...string) *Stream {
+// Returns ErrTooManyStreams or ErrTooManyAccountStreams if opening the stream would exceed configured maximums.
+func (s *Streams) Open(accountID string, streamTypes ...string) (*Stream, error) {
 	if len(streamTypes) == 0 {
 		panic("no stream types given")
 	}
 
+	queueSize := s.QueueSize
+	if queueSize <= 0 {
+		queueSize = DefaultQueueSize
+	}
+
 	// Prep new Stream.
 	str := new(Stream)
 	str.done = make(chan struct{})
-	str.msgCh = make(chan Message, 50) // TODO: make configurable
+	str.msgCh = make(chan Message, queueSize)
 	for _, streamType := range streamTypes {
 		str.Subscribe(streamType)
 	}
 
-	// TODO: add configurable
-	// max streams per account.
-
 	// Acquire lock.
 	s.mutex.Lock()
 
+	if s.MaxStreams > 0 && s.total >= s.MaxStreams {
+		s.mutex.Unlock()
+		return nil, ErrTooManyStreams
+	}
+
 	if s.streams == nil {
 		// Main stream-map needs allocating.
 		s.streams = make(map[string][]*Stream)
 	}
 
-	// Add new stream for account.
 	strs := s.streams[accountID]
+	if s.MaxAccountStreams > 0 && len(strs) >= s.MaxAccountStreams {
+		s.mutex.Unlock()
+		return nil, ErrTooManyAccountStreams
+	}
+
+	// Add new stream for account.
 	strs = append(strs, str)
 	s.streams[accountID] = strs
+	s.total++
 
 	// Register close callback
 	// to remove stream from our
@@ -132,14 +175,19 @@ func (s *Streams) Open(accountID string, streamTypes ...string) *Stream {
 		strs = slices.DeleteFunc(strs, func(s *Stream) bool {
 			return s == str // remove 'str' ptr
 		})
-		s.streams[accountID] = strs
+		if len(strs) == 0 {
+			delete(s.streams, accountID)
+		} else {
+			s.streams[accountID] = strs
+		}
+		s.total--
 		s.mutex.Unlock()
 	}
 
 	// Done with lock.
 	s.mutex.Unlock()
 
-	return str
+	return str, nil
 }
 
 // Post will post the given message to all streams of given account ID matching type.
@@ -255,10 +303,15 @@ type Stream struct {
 
 	// protects stream close.
 	done chan struct{}
+	once sync.Once
 
 	// inbound msg ch.
 	msgCh chan Message
 
+	// set when stream was closed because
+	// client wasn't keeping up with msgs.
+	dropped atomic.Bool
+
 	// close hook to remove
 	// stream from Streams{}.
 	close func()
@@ -298,8 +351,10 @@ func (s *Stream) getStreamType(streamTypes ...string) string {
 	return ""
 }
 
-// send will block on posting a new Message{}, returning early with
-// a false value if provided context is canceled, or stream closed.
+// send will attempt to queue a new Message{}, returning a false value
+// if provided context is canceled, or stream closed. If the message
+// queue is full, the client isn't keeping up with messages, so rather
+// than blocking (or buffering indefinitely) the stream is dropped.
 func (s *Stream) send(ctx context.Context, msg Message) bool {
 	select {
 	case <-s.done:
@@ -308,9 +363,19 @@ func (s *Stream) send(ctx context.Context, msg Message) bool {
 		return false
 	case s.msgCh <- msg:
 		return true
+	default:
+		s.dropped.Store(true)
+		s.Close()
+		return false
 	}
 }
 
+// Dropped returns whether this stream was closed because
+// its client was not receiving messages quickly enough.
+func (s *Stream) Dropped() bool {
+	return s.dropped.Load()
+}
+
 // Recv will block on receiving Message{}, returning early with a
 // false value if provided context is canceled, or stream closed.
 func (s *Stream) Recv(ctx context.Context) (Message, bool) {
@@ -326,13 +391,13 @@ func (s *Stream) Recv(ctx context.Context) (Message, bool) {
 
 // Close will close the underlying context, finally
 // removing it from the parent Streams per-account-map.
+//
+// Close is safe to call concurrently, and more than once.
 func (s *Stream) Close() {
-	select {
-	case <-s.done:
-	default:
+	s.once.Do(func() {
 		close(s.done)
 		s.close()
-	}
+	})
 }
 
 // cas will perform a Compare And Swap operation on s.types using modifier func.
@@ -0,0 +1,344 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package text
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/tdewolff/parse/v2"
+	"github.com/tdewolff/parse/v2/css"
+)
+
+// cssScheme matches the scheme
+// at the start of an absolute URL.
+var cssScheme = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*:`)
+
+// cssToken is a single token of lexed CSS.
+type cssToken struct {
+	tt   css.TokenType
+	data []byte
+}
+
+// SanitizeCustomCSS sanitizes the given custom CSS
+// for serving alongside web profiles and statuses.
+// As well as removing HTML, it removes @import rules,
+// references to resources outside this instance (by
+// url(), src(), image() or image-set()), and legacy
+// constructs that could be used to run scripts.
+//
+// CSS is tokenized and escapes are decoded before names
+// and URLs are checked, and sanitizing is repeated until
+// the output stops changing, so that what's left after
+// removing something can't form anything new to remove.
+func SanitizeCustomCSS(in string) string {
+	for {
+		out := sanitizeCustomCSS(in)
+		if out == in {
+			return out
+		}
+		in = out
+	}
+}
+
+// sanitizeCustomCSS performs a
+// single pass of SanitizeCustomCSS.
+func sanitizeCustomCSS(in string) string {
+	tokens := lexCSS(SanitizeToPlaintext(in))
+
+	var buf strings.Builder
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+
+		switch token.tt {
+		case css.AtKeywordToken:
+			if cssName(token.data[1:]) == "import" {
+				// Drop the rule, up to and including its
+				// terminating semicolon (if any).
+				for i < len(tokens) && tokens[i].tt != css.SemicolonToken {
+					i++
+				}
+				continue
+			}
+
+		case css.IdentToken:
+			switch cssName(token.data) {
+			case "behavior", "-moz-binding":
+				if !cssIsProperty(tokens, i) {
+					break
+				}
+
+				// Drop the declaration, up to and including its
+				// terminating semicolon, or up to the end of the
+				// enclosing block.
+				for i < len(tokens) &&
+					tokens[i].tt != css.SemicolonToken &&
+					tokens[i].tt != css.RightBraceToken {
+					i++
+				}
+				if i < len(tokens) && tokens[i].tt == css.RightBraceToken {
+					i--
+				}
+				continue
+			}
+
+		case css.FunctionToken:
+			end := cssFunctionEnd(tokens, i)
+			name := cssName(token.data[:len(token.data)-1])
+
+			switch name {
+			case "expression":
+				// Drop the whole function.
+				i = end
+				continue
+
+			case "url", "src", "image", "image-set", "-webkit-image-set":
+				if cssRemoteFunction(name, tokens[i+1:end]) {
+					// Drop the whole function.
+					i = end
+					continue
+				}
+			}
+
+		case css.URLToken:
+			if cssRemote(cssURLTarget(token.data)) {
+				continue
+			}
+
+		case css.BadURLToken, css.BadStringToken:
+			// Never valid, so
+			// nothing's lost.
+			continue
+		}
+
+		buf.Write(token.data)
+	}
+
+	return strings.TrimSpace(buf.String())
+}
+
+// lexCSS splits the given CSS into tokens.
+func lexCSS(in string) []cssToken {
+	var (
+		tokens []cssToken
+		lexer  = css.NewLexer(parse.NewInputString(in))
+	)
+
+	for {
+		tt, data := lexer.Next()
+		if tt == css.ErrorToken {
+			return tokens
+		}
+
+		// Data is only valid until the next
+		// call to Next(), so take a copy.
+		tokens = append(tokens, cssToken{
+			tt:   tt,
+			data: bytes.Clone(data),
+		})
+	}
+}
+
+// cssFunctionEnd returns the index of the token closing the function
+// opened by the token at index i, or the last index if it's unclosed.
+func cssFunctionEnd(tokens []cssToken, i int) int {
+	depth := 0
+
+	for ; i < len(tokens); i++ {
+		switch tokens[i].tt {
+		case css.FunctionToken, css.LeftParenthesisToken:
+			depth++
+		case css.RightParenthesisToken:
+			if depth--; depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return len(tokens) - 1
+}
+
+// cssIsProperty returns whether the ident token at
+// index i is a property name, ie., followed by a colon.
+func cssIsProperty(tokens []cssToken, i int) bool {
+	for i++; i < len(tokens); i++ {
+		switch tokens[i].tt {
+		case css.WhitespaceToken, css.CommentToken:
+			continue
+		case css.ColonToken:
+			return true
+		}
+		return false
+	}
+	return false
+}
+
+// cssRemoteFunction returns whether the function with given name
+// and argument tokens references any resource outside this instance,
+// or references anything in a way that can't be checked.
+func cssRemoteFunction(name string, args []cssToken) bool {
+	if name == "url" {
+		// Only reached when the function name
+		// is escaped, or it's otherwise not lexed
+		// as a url token, so take the arguments
+		// as a whole as the URL, as browsers do.
+		var raw []byte
+		for _, arg := range args {
+			if arg.tt == css.FunctionToken {
+				return true
+			}
+			raw = append(raw, arg.data...)
+		}
+		return cssRemote(cssUnquote(bytes.TrimSpace(raw)))
+	}
+
+	for _, arg := range args {
+		switch arg.tt {
+		case css.StringToken:
+			if cssRemote(cssUnquote(arg.data)) {
+				return true
+			}
+
+		case css.URLToken:
+			if cssRemote(cssURLTarget(arg.data)) {
+				return true
+			}
+
+		case css.FunctionToken:
+			// Anything other than a type hint,
+			// eg., var() or attr(), could resolve
+			// to a URL that can't be checked here.
+			if cssName(arg.data[:len(arg.data)-1]) != "type" {
+				return true
+			}
+
+		case css.BadURLToken, css.BadStringToken:
+			return true
+		}
+	}
+
+	return false
+}
+
+// cssURLTarget returns the target of the given url token.
+func cssURLTarget(data []byte) string {
+	data = data[bytes.IndexByte(data, '(')+1:]
+	data = bytes.TrimSuffix(data, []byte{')'})
+	return cssUnquote(bytes.TrimSpace(data))
+}
+
+// cssRemote returns whether the given URL target is absolute
+// or scheme-relative, ie., refers to something outside this
+// instance, after normalizing it as browsers do when parsing.
+func cssRemote(target string) bool {
+	target = strings.Map(func(r rune) rune {
+		if r == '\t' || r == '\n' || r == '\r' {
+			return -1
+		}
+		return r
+	}, target)
+	target = strings.TrimLeftFunc(target, func(r rune) bool { return r <= ' ' })
+	target = strings.ReplaceAll(target, `\`, "/")
+	return cssScheme.MatchString(target) || strings.HasPrefix(target, "//")
+}
+
+// cssName returns the given identifier with
+// escapes decoded, lowercased for comparison.
+func cssName(data []byte) string {
+	return strings.ToLower(cssUnescape(data))
+}
+
+// cssUnquote returns the given possibly
+// quoted string with escapes decoded.
+func cssUnquote(data []byte) string {
+	if len(data) > 0 && (data[0] == '"' || data[0] == '\'') {
+		data = bytes.TrimSuffix(data[1:], data[:1])
+	}
+	return cssUnescape(data)
+}
+
+// cssUnescape decodes the escapes in the given CSS, see:
+// https://www.w3.org/TR/css-syntax-3/#consume-escaped-code-point
+func cssUnescape(data []byte) string {
+	if bytes.IndexByte(data, '\\') == -1 {
+		return string(data)
+	}
+
+	var buf strings.Builder
+	for i := 0; i < len(data); i++ {
+		if data[i] != '\\' {
+			buf.WriteByte(data[i])
+			continue
+		}
+
+		if i++; i == len(data) {
+			break
+		}
+
+		// Count up to 6 hex digits.
+		j := i
+		for j < len(data) && j-i < 6 && isHex(data[j]) {
+			j++
+		}
+
+		if j == i {
+			if data[i] == '\n' || data[i] == '\f' {
+				// Escaped newline
+				// in a string.
+				continue
+			}
+
+			buf.WriteByte(data[i])
+			continue
+		}
+
+		r, _ := strconv.ParseUint(string(data[i:j]), 16, 32)
+		if r == 0 || !utf8.ValidRune(rune(r)) {
+			r = utf8.RuneError
+		}
+		buf.WriteRune(rune(r))
+
+		// A single whitespace
+		// ends the escape.
+		if j < len(data) {
+			switch data[j] {
+			case '\r':
+				if j+1 < len(data) && data[j+1] == '\n' {
+					j++
+				}
+				j++
+			case ' ', '\t', '\n', '\f':
+				j++
+			}
+		}
+
+		i = j - 1
+	}
+
+	return buf.String()
+}
+
+// isHex returns whether c is a hex digit.
+func isHex(c byte) bool {
+	return ('0' <= c && c <= '9') ||
+		('a' <= c && c <= 'f') ||
+		('A' <= c && c <= 'F')
+}
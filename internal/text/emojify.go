@@ -32,7 +32,12 @@ func EmojifyWeb(emojis []apimodel.Emoji, html template.HTML) template.HTML {
 	out := emojify(
 		emojis,
 		string(html),
-		func(url, code string, buf *bytes.Buffer) {
+		func(url, staticURL, code string, buf *bytes.Buffer) {
+			// Offer the static version to
+			// those who prefer reduced motion.
+			buf.WriteString(`<picture><source srcset="`)
+			buf.WriteString(staticURL)
+			buf.WriteString(`" media="(prefers-reduced-motion: reduce)"/>`)
 			buf.WriteString(`<img src="`)
 			buf.WriteString(url)
 			buf.WriteString(`" title=":`)
@@ -45,7 +50,7 @@ func EmojifyWeb(emojis []apimodel.Emoji, html template.HTML) template.HTML {
 			buf.WriteString(`loading="lazy" `)
 			// Limit size to avoid showing
 			// huge emojis when unstyled.
-			buf.WriteString(`width="25" height="25"/>`)
+			buf.WriteString(`width="25" height="25"/></picture>`)
 		},
 	)
 
@@ -60,7 +65,7 @@ func EmojifyRSS(emojis []apimodel.Emoji, text string) string {
 	return emojify(
 		emojis,
 		text,
-		func(url, code string, buf *bytes.Buffer) {
+		func(url, _, code string, buf *bytes.Buffer) {
 			buf.WriteString(`<img src="`)
 			buf.WriteString(url)
 			buf.WriteString(`" title=":`)
@@ -85,7 +90,7 @@ func Demojify(text string) string {
 func emojify(
 	emojis []apimodel.Emoji,
 	input string,
-	write func(url, code string, buf *bytes.Buffer),
+	write func(url, staticURL, code string, buf *bytes.Buffer),
 ) string {
 	// Build map of shortcodes. Normalize each
 	// shortcode by readding closing colons.
@@ -107,10 +112,11 @@ func emojify(
 
 			// Escape raw emoji content.
 			url := html.EscapeString(emoji.URL)
+			staticURL := html.EscapeString(emoji.StaticURL)
 			code := html.EscapeString(emoji.Shortcode)
 
 			// Write emoji repr to buffer.
-			write(url, code, buf)
+			write(url, staticURL, code, buf)
 			return buf.String()
 		},
 	)
@@ -143,7 +143,6 @@ func (cr *customRenderer) renderMention(
 // or '@localusername', and does the following:
 //
 //   - Parse the mention string into a *gtsmodel.Mention.
-//   - Insert mention into database if necessary.
 //   - Add mention to cr.results.Mentions slice.
 //   - Return mention rendered as nice HTML.
 //
@@ -156,13 +155,6 @@ func (cr *customRenderer) handleMention(text string) string {
 		return text
 	}
 
-	if cr.statusID != "" {
-		if err := cr.db.PutMention(cr.ctx, mention); err != nil {
-			log.Errorf(cr.ctx, "error putting mention in db: %s", err)
-			return text
-		}
-	}
-
 	// Append mention to result if not done already.
 	//
 	// This prevents multiple occurences of mention
@@ -51,6 +51,11 @@ func (suite *RemoveHTMLTestSuite) TestRemoveHTML() {
 	suite.Equal(test_removedHTML, s)
 }
 
+func (suite *RemoveHTMLTestSuite) TestSearchText() {
+	s := SearchText(test_removeHTML, "content &amp; warning")
+	suite.Equal("content & warning Another test @ foss_satan # Hashtag Text", s)
+}
+
 func TestRemoveHTMLTestSuite(t *testing.T) {
 	suite.Run(t, &RemoveHTMLTestSuite{})
 }
@@ -169,6 +169,15 @@ func removeHTML(in string) string {
 	return strict.Sanitize(in)
 }
 
+// searchable is like strict, but leaves a space where
+// each element was removed, so that words either side
+// of eg. a paragraph or line break aren't run together.
+var searchable *bluemonday.Policy = func() *bluemonday.Policy {
+	p := bluemonday.StrictPolicy()
+	p.AddSpaceWhenStrippingTag(true)
+	return p
+}()
+
 // SanitizeToHTML sanitizes only risky html elements
 // from the given string, allowing safe ones through.
 func SanitizeToHTML(in string) string {
@@ -189,3 +198,12 @@ func SanitizeToPlaintext(in string) string {
 	content = html.UnescapeString(content)
 	return strings.TrimSpace(content)
 }
+
+// SearchText returns the plaintext of the given status
+// content and content warning, as stored for full-text
+// search of statuses, so that no markup gets indexed.
+func SearchText(content string, contentWarning string) string {
+	content = html.UnescapeString(searchable.Sanitize(content))
+	contentWarning = html.UnescapeString(searchable.Sanitize(contentWarning))
+	return strings.Join(strings.Fields(contentWarning+" "+content), " ")
+}
@@ -122,6 +122,106 @@ func (suite *SanitizeTestSuite) TestSanitizeNaughtyCustomCSS2() {
 	suite.Equal("pee pee poo poo", sanitized)
 }
 
+func (suite *SanitizeTestSuite) TestSanitizeCustomCSSRemote() {
+	for _, test := range []struct {
+		in  string
+		out string
+	}{
+		{
+			in:  `@import url("https://example.org/evil.css"); .toot { color: red; }`,
+			out: `.toot { color: red; }`,
+		},
+		{
+			in:  `@import 'evil.css'; .toot { color: red; }`,
+			out: `.toot { color: red; }`,
+		},
+		{
+			in:  `body { background: url("https://example.org/track.png"); }`,
+			out: `body { background: ; }`,
+		},
+		{
+			in:  `body { background: url(//example.org/track.png); }`,
+			out: `body { background: ; }`,
+		},
+		{
+			in:  `body { background: url( 'data:image/png;base64,AAAA' ); }`,
+			out: `body { background: ; }`,
+		},
+		{
+			in:  `body { background: url("/fileserver/01F8MH17FWEB39HZJ76B6VXSKF/header.png"); }`,
+			out: `body { background: url("/fileserver/01F8MH17FWEB39HZJ76B6VXSKF/header.png"); }`,
+		},
+		{
+			in:  `body { width: expression(alert(1)); behavior: url(evil.htc); }`,
+			out: `body { width: ;  }`,
+		},
+		{
+			in:  `body { -moz-binding: url(evil.xml#xss) }`,
+			out: `body { }`,
+		},
+		{
+			in:  `.behavior { color: red; }`,
+			out: `.behavior { color: red; }`,
+		},
+		{
+			in:  `body { background: \75 rl(https://example.org/track.png); }`,
+			out: `body { background: ; }`,
+		},
+		{
+			in:  `body { background: url("\68ttps://example.org/track.png"); }`,
+			out: `body { background: ; }`,
+		},
+		{
+			in:  `body { background: url("/\\example.org/track.png"); }`,
+			out: `body { background: ; }`,
+		},
+		{
+			in:  `@\69mport url(https://example.org/evil.css); .toot { color: red; }`,
+			out: `.toot { color: red; }`,
+		},
+		{
+			in:  `body { background: image-set("https://example.org/track.png" 1x); }`,
+			out: `body { background: ; }`,
+		},
+		{
+			in:  `body { background: image-set(url(https://example.org/track.png) 1x, "/fileserver/header.png" 2x); }`,
+			out: `body { background: ; }`,
+		},
+		{
+			in:  `body { background: image-set("/fileserver/header.png" type("image/png")); }`,
+			out: `body { background: image-set("/fileserver/header.png" type("image/png")); }`,
+		},
+		{
+			in:  `body { background: src("https://example.org/track.png"); }`,
+			out: `body { background: ; }`,
+		},
+		{
+			in:  `body { --track: "https://example.org/track.png"; background: src(var(--track)); }`,
+			out: `body { --track: "https://example.org/track.png"; background: ; }`,
+		},
+		{
+			// Not an expression() to begin with, and
+			// nothing's removed to make it into one.
+			in:  `body { width: exprexpression(ession(alert(1)); }`,
+			out: `body { width: exprexpression(ession(alert(1)); }`,
+		},
+		{
+			in:  `body { width: expr@import x;ession(alert(1)); }`,
+			out: `body { width: ; }`,
+		},
+		{
+			in:  `body { width: e\78 pression(alert(1)); }`,
+			out: `body { width: ; }`,
+		},
+		{
+			in:  `&lt;/style&gt;&lt;script&gt;alert(1)&lt;/script&gt;`,
+			out: ``,
+		},
+	} {
+		suite.Equal(test.out, text.SanitizeCustomCSS(test.in), test.in)
+	}
+}
+
 func (suite *SanitizeTestSuite) TestSanitizeInlineImg() {
 	withInlineImg := "<p>Here's an inline image: <img class=\"fixed-size-img svelte-uci8eb\" aria-hidden=\"false\" alt=\"A black-and-white photo of an Oblique Strategy card. The card reads: 'Define an area as 'safe' and use it as an anchor'.\" title=\"A black-and-white photo of an Oblique Strategy card. The card reads: 'Define an area as 'safe' and use it as an anchor'.\" width=\"0\" height=\"0\" src=\"https://example.org/fileserver/01H7J83147QMCE17C0RS9P10Y9/attachment/small/01H7J8365XXRTCP6CAMGEM49ZE.jpg\" style=\"object-position: 50% 50%;\"></p>"
 	sanitized := text.SanitizeToHTML(withInlineImg)
@@ -0,0 +1,68 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trans
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	transmodel "github.com/superseriousbusiness/gotosocial/internal/trans/model"
+)
+
+// Backup writes the same entries as ExportMinimal to the given path,
+// preceded by a BackupInfo entry describing this instance, so that
+// Restore can check the backup is being restored onto the right host.
+//
+// Backup only reads from the database, so it's safe to run while
+// the instance is up.
+func (e *exporter) Backup(ctx context.Context, path string) error {
+	if path == "" {
+		return errors.New("Backup: path empty")
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("Backup: couldn't backup to %s: %s", path, err)
+	}
+
+	now := time.Now()
+	info := &transmodel.BackupInfo{
+		Type:          transmodel.TransBackupInfo,
+		CreatedAt:     &now,
+		Host:          config.GetHost(),
+		AccountDomain: config.GetAccountDomain(),
+		Version:       config.GetSoftwareVersion(),
+	}
+
+	if err := json.NewEncoder(file).Encode(info); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("Backup: error encoding backup info: %s", err)
+	}
+
+	if err := e.exportMinimal(ctx, file); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("Backup: %w", err)
+	}
+
+	return neatClose(file)
+}
@@ -78,6 +78,24 @@ func (i *importer) blockDecode(e transmodel.Entry) (*transmodel.Block, error) {
 	return b, nil
 }
 
+func (i *importer) backupInfoDecode(e transmodel.Entry) (*transmodel.BackupInfo, error) {
+	b := &transmodel.BackupInfo{}
+	if err := i.simpleDecode(e, b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+func (i *importer) domainAllowDecode(e transmodel.Entry) (*transmodel.DomainAllow, error) {
+	a := &transmodel.DomainAllow{}
+	if err := i.simpleDecode(e, a); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
 func (i *importer) domainBlockDecode(e transmodel.Entry) (*transmodel.DomainBlock, error) {
 	b := &transmodel.DomainBlock{}
 	if err := i.simpleDecode(e, b); err != nil {
@@ -26,6 +26,7 @@ import (
 // Exporter wraps functionality for exporting entries from the database to a file.
 type Exporter interface {
 	ExportMinimal(ctx context.Context, path string) error
+	Backup(ctx context.Context, path string) error
 }
 
 type exporter struct {
@@ -36,16 +36,27 @@ func (e *exporter) ExportMinimal(ctx context.Context, path string) error {
 		return fmt.Errorf("ExportMinimal: couldn't export to %s: %s", path, err)
 	}
 
+	if err := e.exportMinimal(ctx, file); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("ExportMinimal: %w", err)
+	}
+
+	return neatClose(file)
+}
+
+// exportMinimal writes the bare-minimum set of entries needed to
+// restore an instance without breaking federation to the given file.
+func (e *exporter) exportMinimal(ctx context.Context, file *os.File) error {
 	// export all local accounts we have in the database
 	localAccounts, err := e.exportAccounts(ctx, []db.Where{{Key: "domain", Value: nil}}, file)
 	if err != nil {
-		return fmt.Errorf("ExportMinimal: error exporting accounts: %s", err)
+		return fmt.Errorf("error exporting accounts: %s", err)
 	}
 
 	// export all blocks that relate to local accounts
 	blocks, err := e.exportBlocks(ctx, localAccounts, file)
 	if err != nil {
-		return fmt.Errorf("ExportMinimal: error exporting blocks: %s", err)
+		return fmt.Errorf("error exporting blocks: %s", err)
 	}
 
 	// for each block, make sure we've written out the account owning it, or targeted by it --
@@ -55,7 +66,7 @@ func (e *exporter) ExportMinimal(ctx context.Context, path string) error {
 		if !alreadyWritten {
 			_, err := e.exportAccounts(ctx, []db.Where{{Key: "id", Value: b.AccountID}}, file)
 			if err != nil {
-				return fmt.Errorf("ExportMinimal: error exporting block owner account: %s", err)
+				return fmt.Errorf("error exporting block owner account: %s", err)
 			}
 		}
 
@@ -63,7 +74,7 @@ func (e *exporter) ExportMinimal(ctx context.Context, path string) error {
 		if !alreadyWritten {
 			_, err := e.exportAccounts(ctx, []db.Where{{Key: "id", Value: b.TargetAccountID}}, file)
 			if err != nil {
-				return fmt.Errorf("ExportMinimal: error exporting block target account: %s", err)
+				return fmt.Errorf("error exporting block target account: %s", err)
 			}
 		}
 	}
@@ -71,7 +82,7 @@ func (e *exporter) ExportMinimal(ctx context.Context, path string) error {
 	// export all follows that relate to local accounts
 	follows, err := e.exportFollows(ctx, localAccounts, file)
 	if err != nil {
-		return fmt.Errorf("ExportMinimal: error exporting follows: %s", err)
+		return fmt.Errorf("error exporting follows: %s", err)
 	}
 
 	// for each follow, make sure we've written out the account owning it, or targeted by it --
@@ -81,7 +92,7 @@ func (e *exporter) ExportMinimal(ctx context.Context, path string) error {
 		if !alreadyWritten {
 			_, err := e.exportAccounts(ctx, []db.Where{{Key: "id", Value: follow.AccountID}}, file)
 			if err != nil {
-				return fmt.Errorf("ExportMinimal: error exporting follow owner account: %s", err)
+				return fmt.Errorf("error exporting follow owner account: %s", err)
 			}
 		}
 
@@ -89,7 +100,7 @@ func (e *exporter) ExportMinimal(ctx context.Context, path string) error {
 		if !alreadyWritten {
 			_, err := e.exportAccounts(ctx, []db.Where{{Key: "id", Value: follow.TargetAccountID}}, file)
 			if err != nil {
-				return fmt.Errorf("ExportMinimal: error exporting follow target account: %s", err)
+				return fmt.Errorf("error exporting follow target account: %s", err)
 			}
 		}
 	}
@@ -97,7 +108,7 @@ func (e *exporter) ExportMinimal(ctx context.Context, path string) error {
 	// export all follow requests that relate to local accounts
 	followRequests, err := e.exportFollowRequests(ctx, localAccounts, file)
 	if err != nil {
-		return fmt.Errorf("ExportMinimal: error exporting follow requests: %s", err)
+		return fmt.Errorf("error exporting follow requests: %s", err)
 	}
 
 	// for each follow request, make sure we've written out the account owning it, or targeted by it --
@@ -107,7 +118,7 @@ func (e *exporter) ExportMinimal(ctx context.Context, path string) error {
 		if !alreadyWritten {
 			_, err := e.exportAccounts(ctx, []db.Where{{Key: "id", Value: fr.AccountID}}, file)
 			if err != nil {
-				return fmt.Errorf("ExportMinimal: error exporting follow request owner account: %s", err)
+				return fmt.Errorf("error exporting follow request owner account: %s", err)
 			}
 		}
 
@@ -115,24 +126,29 @@ func (e *exporter) ExportMinimal(ctx context.Context, path string) error {
 		if !alreadyWritten {
 			_, err := e.exportAccounts(ctx, []db.Where{{Key: "id", Value: fr.TargetAccountID}}, file)
 			if err != nil {
-				return fmt.Errorf("ExportMinimal: error exporting follow request target account: %s", err)
+				return fmt.Errorf("error exporting follow request target account: %s", err)
 			}
 		}
 	}
 
 	// export all domain blocks
 	if _, err := e.exportDomainBlocks(ctx, file); err != nil {
-		return fmt.Errorf("ExportMinimal: error exporting domain blocks: %s", err)
+		return fmt.Errorf("error exporting domain blocks: %s", err)
+	}
+
+	// export all domain allows
+	if _, err := e.exportDomainAllows(ctx, file); err != nil {
+		return fmt.Errorf("error exporting domain allows: %s", err)
 	}
 
 	// export all users
 	if _, err := e.exportUsers(ctx, file); err != nil {
-		return fmt.Errorf("ExportMinimal: error exporting users: %s", err)
+		return fmt.Errorf("error exporting users: %s", err)
 	}
 
 	// export all instances
 	if _, err := e.exportInstances(ctx, file); err != nil {
-		return fmt.Errorf("ExportMinimal: error exporting instances: %s", err)
+		return fmt.Errorf("error exporting instances: %s", err)
 	}
 
 	// export all SUSPENDED accounts to make sure the suspension sticks across db migration etc
@@ -142,8 +158,8 @@ func (e *exporter) ExportMinimal(ctx context.Context, path string) error {
 		Value: nil,
 	}}
 	if _, err := e.exportAccounts(ctx, whereSuspended, file); err != nil {
-		return fmt.Errorf("ExportMinimal: error exporting suspended accounts: %s", err)
+		return fmt.Errorf("error exporting suspended accounts: %s", err)
 	}
 
-	return neatClose(file)
+	return nil
 }
@@ -94,6 +94,20 @@ func (i *importer) inputEntry(ctx context.Context, entry transmodel.Entry) error
 		}
 		log.Infof(ctx, "added block with id %s", block.ID)
 		return nil
+	case transmodel.TransBackupInfo:
+		// Only meaningful to Restore,
+		// which reads it separately.
+		return nil
+	case transmodel.TransDomainAllow:
+		allow, err := i.domainAllowDecode(entry)
+		if err != nil {
+			return fmt.Errorf("inputEntry: error decoding entry into domain allow: %s", err)
+		}
+		if err := i.putInDB(ctx, allow); err != nil {
+			return fmt.Errorf("inputEntry: error adding domain allow to database: %s", err)
+		}
+		log.Infof(ctx, "added domain allow with id %s", allow.ID)
+		return nil
 	case transmodel.TransDomainBlock:
 		block, err := i.domainBlockDecode(entry)
 		if err != nil {
@@ -26,7 +26,9 @@ type Type string
 // Type of the trans entry. Describes how it should be read from file.
 const (
 	TransAccount          Type = "account"
+	TransBackupInfo       Type = "backupInfo"
 	TransBlock            Type = "block"
+	TransDomainAllow      Type = "domainAllow"
 	TransDomainBlock      Type = "domainBlock"
 	TransEmailDomainBlock Type = "emailDomainBlock"
 	TransFollow           Type = "follow"
@@ -0,0 +1,96 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trans
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	transmodel "github.com/superseriousbusiness/gotosocial/internal/trans/model"
+)
+
+// Restore imports a file written by Backup into the database.
+//
+// Unlike Import, Restore refuses to run unless the backup was
+// taken from an instance with the same host as this one, and
+// the database doesn't contain any local accounts yet.
+func (i *importer) Restore(ctx context.Context, path string) error {
+	if path == "" {
+		return errors.New("Restore: path empty")
+	}
+
+	info, err := i.readBackupInfo(path)
+	if err != nil {
+		return fmt.Errorf("Restore: %w", err)
+	}
+
+	if host := config.GetHost(); info.Host != host {
+		return fmt.Errorf(
+			"Restore: backup was taken from host %s, but this instance is configured with host %s",
+			info.Host, host,
+		)
+	}
+
+	// Only restore onto a fresh database,
+	// otherwise we'd end up with a mix of
+	// old and new accounts and keys.
+	count, err := i.db.CountInstanceUsers(ctx, config.GetHost())
+	if err != nil {
+		return fmt.Errorf("Restore: error counting local accounts: %w", err)
+	}
+
+	if count != 0 {
+		return fmt.Errorf("Restore: database already contains %d local account(s), restore onto a fresh database instead", count)
+	}
+
+	return i.Import(ctx, path)
+}
+
+// readBackupInfo reads the BackupInfo
+// entry at the start of the given file.
+func (i *importer) readBackupInfo(path string) (*transmodel.BackupInfo, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't open %s: %s", path, err)
+	}
+	defer file.Close()
+
+	entry := transmodel.Entry{}
+	if err := json.NewDecoder(file).Decode(&entry); err != nil {
+		if err == io.EOF {
+			return nil, fmt.Errorf("%s is empty", path)
+		}
+		return nil, fmt.Errorf("error decoding first entry: %s", err)
+	}
+
+	if t, _ := entry[transmodel.TypeKey].(string); transmodel.Type(t) != transmodel.TransBackupInfo {
+		return nil, fmt.Errorf("%s is not a backup file; use 'admin import' for exports", path)
+	}
+
+	info, err := i.backupInfoDecode(entry)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding backup info: %s", err)
+	}
+
+	return info, nil
+}
@@ -0,0 +1,133 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trans_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/trans"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type RestoreTestSuite struct {
+	TransTestSuite
+}
+
+func (suite *RestoreTestSuite) TestBackupRestoreOK() {
+	ctx := context.Background()
+	tempFilePath := fmt.Sprintf("%s/%s", suite.T().TempDir(), uuid.NewString())
+
+	exporter := trans.NewExporter(suite.db)
+	if err := exporter.Backup(ctx, tempFilePath); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	var state state.State
+	state.Caches.Init()
+
+	// create a new database with just the tables created, no entries
+	newDB := testrig.NewTestDB(&state)
+
+	importer := trans.NewImporter(newDB)
+	if err := importer.Restore(ctx, tempFilePath); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	testAccount := suite.testAccounts["local_account_1"]
+	restored, err := newDB.GetAccountByID(ctx, testAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(testAccount.PrivateKey.Equal(restored.PrivateKey))
+	suite.True(testAccount.PublicKey.Equal(restored.PublicKey))
+
+	users := []*gtsmodel.User{}
+	suite.NoError(newDB.GetAll(ctx, &users))
+	suite.NotEmpty(users)
+
+	domainBlocks := []*gtsmodel.DomainBlock{}
+	suite.NoError(newDB.GetAll(ctx, &domainBlocks))
+	suite.NotEmpty(domainBlocks)
+}
+
+func (suite *RestoreTestSuite) TestRestoreNotFresh() {
+	ctx := context.Background()
+	tempFilePath := fmt.Sprintf("%s/%s", suite.T().TempDir(), uuid.NewString())
+
+	exporter := trans.NewExporter(suite.db)
+	if err := exporter.Backup(ctx, tempFilePath); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Restoring onto the database we just
+	// backed up from should be refused.
+	importer := trans.NewImporter(suite.db)
+	err := importer.Restore(ctx, tempFilePath)
+	suite.ErrorContains(err, "restore onto a fresh database instead")
+}
+
+func (suite *RestoreTestSuite) TestRestoreWrongHost() {
+	ctx := context.Background()
+	tempFilePath := fmt.Sprintf("%s/%s", suite.T().TempDir(), uuid.NewString())
+
+	exporter := trans.NewExporter(suite.db)
+	if err := exporter.Backup(ctx, tempFilePath); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	config.SetHost("example.org")
+	defer config.SetHost("localhost:8080")
+
+	var state state.State
+	state.Caches.Init()
+	newDB := testrig.NewTestDB(&state)
+
+	importer := trans.NewImporter(newDB)
+	err := importer.Restore(ctx, tempFilePath)
+	suite.ErrorContains(err, "but this instance is configured with host example.org")
+}
+
+func (suite *RestoreTestSuite) TestRestoreNotBackup() {
+	ctx := context.Background()
+	tempFilePath := fmt.Sprintf("%s/%s", suite.T().TempDir(), uuid.NewString())
+
+	// A plain export has no backup info entry.
+	exporter := trans.NewExporter(suite.db)
+	if err := exporter.ExportMinimal(ctx, tempFilePath); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	var state state.State
+	state.Caches.Init()
+	newDB := testrig.NewTestDB(&state)
+
+	importer := trans.NewImporter(newDB)
+	err := importer.Restore(ctx, tempFilePath)
+	suite.ErrorContains(err, "is not a backup file")
+}
+
+func TestRestoreTestSuite(t *testing.T) {
+	suite.Run(t, &RestoreTestSuite{})
+}
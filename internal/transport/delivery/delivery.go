@@ -61,6 +61,10 @@ type Delivery struct {
 	next time.Time
 }
 
+// pauseRecheck is the interval after which a delivery
+// held due to a paused domain will be checked again.
+const pauseRecheck = time.Minute
+
 func (dlv *Delivery) backoff() time.Duration {
 	if dlv.next.IsZero() {
 		return 0
@@ -80,6 +84,11 @@ type WorkerPool struct {
 	// passed to each of delivery pool Worker{}s.
 	Queue queue.StructQueue[*Delivery]
 
+	// Paused is an optional function passed to
+	// each of delivery pool Worker{}s, used to
+	// check whether deliveries to host are paused.
+	Paused func(ctx context.Context, host string) bool
+
 	// internal fields.
 	workers []*Worker
 }
@@ -115,6 +124,7 @@ func (p *WorkerPool) Start(n int) {
 		p.workers[i] = new(Worker)
 		p.workers[i].Client = p.Client
 		p.workers[i].Queue = &p.Queue
+		p.workers[i].Paused = p.Paused
 
 		// Attempt to start worker.
 		// Return bool not useful
@@ -160,6 +170,13 @@ type Worker struct {
 	// that delivery worker will feed from.
 	Queue *queue.StructQueue[*Delivery]
 
+	// Paused is an optional function that
+	// delivery worker will use to check if
+	// deliveries to a host are paused. Paused
+	// deliveries are held in the backlog, and
+	// do not count towards delivery attempts.
+	Paused func(ctx context.Context, host string) bool
+
 	// internal fields.
 	backlog []*Delivery
 	service runners.Service
@@ -228,6 +245,19 @@ loop:
 			}
 		}
 
+		// Check whether deliveries to
+		// this host are currently paused.
+		if w.Paused != nil &&
+			w.Paused(ctx, dlv.Request.URL.Hostname()) {
+
+			// Hold delivery in the
+			// backlog and check again
+			// after the pause interval.
+			dlv.next = time.Now().Add(pauseRecheck)
+			w.pushBacklog(dlv)
+			continue loop
+		}
+
 		// Attempt delivery of AP request.
 		rsp, retry, err := w.Client.DoOnce(
 			&dlv.Request,
@@ -104,6 +104,13 @@ func (c *Converter) ASRepresentationToAccount(ctx context.Context, accountable a
 		acct.Domain = uriObj.Host
 	}
 
+	// Normalize the account domain as punycode.
+	acct.Domain, err = util.Punify(acct.Domain)
+	if err != nil {
+		err := gtserror.Newf("unusable domain for %s: %w", uri, err)
+		return nil, gtserror.SetMalformed(err)
+	}
+
 	// avatar aka icon
 	// if this one isn't extractable in a format we recognise we'll just skip it
 	avatarURL, err := ap.ExtractIconURI(accountable)
@@ -223,6 +230,9 @@ func (c *Converter) ASRepresentationToAccount(ctx context.Context, accountable a
 	acct.PublicKey = pkey
 	acct.PublicKeyURI = pkeyURL.String()
 
+	// Record any properties we didn't map.
+	c.reportUnmapped(ctx, accountable, uriObj, mappedAccountProps)
+
 	return &acct, nil
 }
 
@@ -403,6 +413,17 @@ func (c *Converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 	status.Replyable = util.Ptr(true)
 	status.Likeable = util.Ptr(true)
 
+	// Remote interaction policy, if set, is checked
+	// per-requester when local users try to interact.
+	status.InteractionPolicy = ap.ExtractInteractionPolicy(statusable)
+
+	// Whether the author allows search engines to index
+	// this status; kept so we can honour it ourselves.
+	status.Indexable = util.Ptr(false)
+	if withUnknown, ok := statusable.(ap.WithUnknownProperties); ok {
+		status.Indexable = util.Ptr(ap.GetIndexable(withUnknown))
+	}
+
 	// status.Sensitive
 	sensitive := ap.ExtractSensitive(statusable)
 	status.Sensitive = &sensitive
@@ -410,6 +431,9 @@ func (c *Converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 	// ActivityStreamsType
 	status.ActivityStreamsType = statusable.GetTypeName()
 
+	// Record any properties we didn't map.
+	c.reportUnmapped(ctx, statusable, uriObj, mappedStatusProps)
+
 	return &status, nil
 }
 
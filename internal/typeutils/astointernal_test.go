@@ -30,7 +30,9 @@ import (
 	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/cache"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 )
 
 type ASToInternalTestSuite struct {
@@ -121,6 +123,41 @@ func (suite *ASToInternalTestSuite) TestParsePublicStatus() {
 	suite.Equal("en", status.Language)
 }
 
+func (suite *ASToInternalTestSuite) TestParsePublicStatusConversionReport() {
+	config.SetAdvancedConversionReport(true)
+	defer config.SetAdvancedConversionReport(false)
+
+	t := suite.jsonToType(publicStatusActivityJson)
+	rep, ok := t.(ap.Statusable)
+	if !ok {
+		suite.FailNow("type not coercible")
+	}
+
+	// Convert twice to check counts.
+	for i := 0; i < 2; i++ {
+		_, err := suite.typeconverter.ASStatusToStatus(context.Background(), rep)
+		suite.NoError(err)
+	}
+
+	report := suite.typeconverter.ConversionReport()
+	suite.Equal([]typeutils.ConversionReportEntry{
+		{
+			Software: "unknown",
+			Type:     "Note",
+			Property: "atomUri",
+			Count:    2,
+			LastURI:  "http://fossbros-anonymous.io/users/foss_satan/statuses/108138763199405167",
+		},
+		{
+			Software: "unknown",
+			Type:     "Note",
+			Property: "inReplyToAtomUri",
+			Count:    2,
+			LastURI:  "http://fossbros-anonymous.io/users/foss_satan/statuses/108138763199405167",
+		},
+	}, report)
+}
+
 func (suite *ASToInternalTestSuite) TestParsePublicStatusNoURL() {
 	t := suite.jsonToType(publicStatusActivityJsonNoURL)
 	rep, ok := t.(ap.Statusable)
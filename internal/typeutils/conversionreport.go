@@ -0,0 +1,209 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package typeutils
+
+import (
+	"cmp"
+	"context"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// unknownSoftware is used as the software key in
+// the conversion report when the software of a
+// remote instance could not be determined.
+const unknownSoftware = "unknown"
+
+// mappedStatusProps contains the top-level
+// properties of an inbound Statusable that
+// are mapped (or otherwise used) by us.
+var mappedStatusProps = map[string]struct{}{
+	"@context":     {},
+	"id":           {},
+	"type":         {},
+	"attachment":   {},
+	"attributedTo": {},
+	"content":      {},
+	"contentMap":   {},
+	"tag":          {},
+	"name":         {},
+	"sensitive":    {},
+	"summary":      {},
+	"url":          {},
+	"to":           {},
+	"cc":           {},
+	"inReplyTo":    {},
+	"published":    {},
+	"replies":      {},
+	"oneOf":        {},
+	"anyOf":        {},
+	"endTime":      {},
+	"closed":       {},
+	"votersCount":  {},
+}
+
+// mappedAccountProps contains the top-level
+// properties of an inbound Accountable that
+// are mapped (or otherwise used) by us.
+var mappedAccountProps = map[string]struct{}{
+	"@context":                  {},
+	"id":                        {},
+	"type":                      {},
+	"preferredUsername":         {},
+	"name":                      {},
+	"summary":                   {},
+	"icon":                      {},
+	"image":                     {},
+	"attachment":                {},
+	"tag":                       {},
+	"publicKey":                 {},
+	"endpoints":                 {},
+	"alsoKnownAs":               {},
+	"discoverable":              {},
+	"featured":                  {},
+	"followers":                 {},
+	"following":                 {},
+	"inbox":                     {},
+	"outbox":                    {},
+	"manuallyApprovesFollowers": {},
+	"movedTo":                   {},
+	"published":                 {},
+	"url":                       {},
+}
+
+// ConversionReportEntry models one inbound
+// ActivityPub property that was not mapped
+// by the converter, for one AP type from
+// one remote software.
+type ConversionReportEntry struct {
+	Software string // Remote software name + version, or "unknown".
+	Type     string // ActivityPub type name, eg., "Note".
+	Property string // Unmapped property name.
+	Count    int    // Number of times seen.
+	LastURI  string // URI of the last object it was seen on.
+}
+
+// conversionReport records inbound ActivityPub
+// properties which were not mapped by the converter.
+type conversionReport struct {
+	mu      sync.Mutex
+	entries map[[3]string]*ConversionReportEntry
+}
+
+// record increments the count of each given
+// property for the given software and AP type.
+func (r *conversionReport) record(software string, apType string, props []string, uri string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.entries == nil {
+		r.entries = make(map[[3]string]*ConversionReportEntry)
+	}
+
+	for _, prop := range props {
+		key := [3]string{software, apType, prop}
+
+		entry, ok := r.entries[key]
+		if !ok {
+			entry = &ConversionReportEntry{
+				Software: software,
+				Type:     apType,
+				Property: prop,
+			}
+			r.entries[key] = entry
+		}
+
+		entry.Count++
+		entry.LastURI = uri
+	}
+}
+
+// ConversionReport returns a copy of all entries currently
+// in the conversion report, sorted by software, type, and
+// property. The report is only populated when the setting
+// advanced-conversion-report is enabled.
+func (c *Converter) ConversionReport() []ConversionReportEntry {
+	c.report.mu.Lock()
+	defer c.report.mu.Unlock()
+
+	entries := make([]ConversionReportEntry, 0, len(c.report.entries))
+	for _, entry := range c.report.entries {
+		entries = append(entries, *entry)
+	}
+
+	slices.SortFunc(entries, func(a, b ConversionReportEntry) int {
+		return cmp.Or(
+			strings.Compare(a.Software, b.Software),
+			strings.Compare(a.Type, b.Type),
+			strings.Compare(a.Property, b.Property),
+		)
+	})
+
+	return entries
+}
+
+// reportUnmapped records any top-level properties of
+// the given inbound type which are not in mapped, if
+// advanced-conversion-report is enabled.
+func (c *Converter) reportUnmapped(
+	ctx context.Context,
+	t vocab.Type,
+	uri *url.URL,
+	mapped map[string]struct{},
+) {
+	if !config.GetAdvancedConversionReport() {
+		return
+	}
+
+	raw, err := ap.Serialize(t)
+	if err != nil {
+		log.Debugf(ctx, "error serializing %s for conversion report: %v", uri, err)
+		return
+	}
+
+	var unmapped []string
+	for prop := range raw {
+		if _, ok := mapped[prop]; !ok {
+			unmapped = append(unmapped, prop)
+		}
+	}
+
+	if len(unmapped) == 0 {
+		// Everything
+		// was mapped.
+		return
+	}
+
+	// Try to determine remote software
+	// from the instance we have stored.
+	software := unknownSoftware
+	instance, err := c.state.DB.GetInstance(gtscontext.SetBarebones(ctx), uri.Host)
+	if err == nil && instance.Version != "" {
+		software = instance.Version
+	}
+
+	c.report.record(software, t.GetTypeName(), unmapped, uri.String())
+}
@@ -29,6 +29,7 @@ type Converter struct {
 	defaultAvatars []string
 	randAvatars    sync.Map
 	filter         *visibility.Filter
+	report         conversionReport
 }
 
 func NewConverter(state *state.State) *Converter {
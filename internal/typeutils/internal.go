@@ -98,6 +98,7 @@ func (c *Converter) StatusToBoost(
 		Boostable:           util.Ptr(*target.Boostable),
 		Replyable:           util.Ptr(*target.Replyable),
 		Likeable:            util.Ptr(*target.Likeable),
+		InteractionPolicy:   target.InteractionPolicy,
 	}
 
 	return boost, nil
@@ -22,6 +22,8 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -32,6 +34,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
 	"github.com/superseriousbusiness/gotosocial/internal/filter/usermute"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/language"
@@ -129,15 +132,28 @@ func (c *Converter) AccountToAPIAccountSensitive(ctx context.Context, a *gtsmode
 		statusContentType = a.Settings.StatusContentType
 	}
 
+	var notificationsSnoozedUntil string
+	if a.Settings.NotificationsSnoozed() {
+		notificationsSnoozedUntil = util.FormatISO8601(a.Settings.NotificationsSnoozedUntil)
+	}
+
 	apiAccount.Source = &apimodel.Source{
-		Privacy:             c.VisToAPIVis(ctx, a.Settings.Privacy),
-		Sensitive:           *a.Settings.Sensitive,
-		Language:            a.Settings.Language,
-		StatusContentType:   statusContentType,
-		Note:                a.NoteRaw,
-		Fields:              c.fieldsToAPIFields(a.FieldsRaw),
-		FollowRequestsCount: *a.Stats.FollowRequestsCount,
-		AlsoKnownAsURIs:     a.AlsoKnownAsURIs,
+		Privacy:                   c.VisToAPIVis(ctx, a.Settings.Privacy),
+		Sensitive:                 *a.Settings.Sensitive,
+		Language:                  a.Settings.Language,
+		StatusContentType:         statusContentType,
+		Note:                      a.NoteRaw,
+		Fields:                    c.fieldsToAPIFields(a.FieldsRaw),
+		FollowRequestsCount:       *a.Stats.FollowRequestsCount,
+		AlsoKnownAsURIs:           a.AlsoKnownAsURIs,
+		SpamFilterMode:            a.Settings.SpamFilterMode,
+		EmailDigest:               a.Settings.EmailDigest,
+		NotificationsSnoozedUntil: notificationsSnoozedUntil,
+		SnoozeAllowFollows:        util.PtrValueOr(a.Settings.SnoozeAllowFollows, false),
+		SnoozeAllowDirect:         util.PtrValueOr(a.Settings.SnoozeAllowDirect, false),
+		FollowAutoAcceptDelay:     a.Settings.FollowAutoAcceptDelay,
+		HideFromLocalTimeline:     util.PtrValueOr(a.Settings.HideFromLocalTimeline, false),
+		HideFromFederatedTimeline: util.PtrValueOr(a.Settings.HideFromFederatedTimeline, false),
 	}
 
 	return apiAccount, nil
@@ -217,16 +233,19 @@ func (c *Converter) AccountToAPIAccountPublic(ctx context.Context, a *gtsmodel.A
 
 	// Bits that vary between remote + local accounts:
 	//   - Account (acct) string.
-	//   - Role.
-	//   - Settings things (enableRSS, theme, customCSS, hideCollections).
+	//   - Role(s).
+	//   - Settings things (enableRSS, theme, customCSS, hideCollections, hideStatusesLoggedOut, noIndex).
 
 	var (
-		acct            string
-		role            *apimodel.AccountRole
-		enableRSS       bool
-		theme           string
-		customCSS       string
-		hideCollections bool
+		acct                  string
+		role                  *apimodel.AccountRole
+		roles                 []apimodel.AccountDisplayRole
+		enableRSS             bool
+		theme                 string
+		customCSS             string
+		hideCollections       bool
+		hideStatusesLoggedOut bool
+		noIndex               bool
 	)
 
 	if a.IsRemote() {
@@ -257,10 +276,16 @@ func (c *Converter) AccountToAPIAccountPublic(ctx context.Context, a *gtsmodel.A
 				role = &apimodel.AccountRole{Name: apimodel.AccountRoleUser}
 			}
 
+			if a.IsVerified() {
+				roles = append(roles, apimodel.AccountDisplayRoleVerified)
+			}
+
 			enableRSS = *a.Settings.EnableRSS
 			theme = a.Settings.Theme
 			customCSS = a.Settings.CustomCSS
 			hideCollections = *a.Settings.HideCollections
+			hideStatusesLoggedOut = util.PtrValueOr(a.Settings.HideStatusesLoggedOut, false)
+			noIndex = util.PtrValueOr(a.Settings.NoIndex, false)
 		}
 
 		acct = a.Username // omit domain
@@ -303,33 +328,36 @@ func (c *Converter) AccountToAPIAccountPublic(ctx context.Context, a *gtsmodel.A
 	// can be populated directly below.
 
 	accountFrontend := &apimodel.Account{
-		ID:              a.ID,
-		Username:        a.Username,
-		Acct:            acct,
-		DisplayName:     a.DisplayName,
-		Locked:          locked,
-		Discoverable:    discoverable,
-		Bot:             bot,
-		CreatedAt:       util.FormatISO8601(a.CreatedAt),
-		Note:            a.Note,
-		URL:             a.URL,
-		Avatar:          aviURL,
-		AvatarStatic:    aviURLStatic,
-		Header:          headerURL,
-		HeaderStatic:    headerURLStatic,
-		FollowersCount:  followersCount,
-		FollowingCount:  followingCount,
-		StatusesCount:   statusesCount,
-		LastStatusAt:    lastStatusAt,
-		Emojis:          apiEmojis,
-		Fields:          fields,
-		Suspended:       !a.SuspendedAt.IsZero(),
-		Theme:           theme,
-		CustomCSS:       customCSS,
-		EnableRSS:       enableRSS,
-		HideCollections: hideCollections,
-		Role:            role,
-		Moved:           moved,
+		ID:                    a.ID,
+		Username:              a.Username,
+		Acct:                  acct,
+		DisplayName:           a.DisplayName,
+		Locked:                locked,
+		Discoverable:          discoverable,
+		Bot:                   bot,
+		CreatedAt:             util.FormatISO8601(a.CreatedAt),
+		Note:                  a.Note,
+		URL:                   a.URL,
+		Avatar:                aviURL,
+		AvatarStatic:          aviURLStatic,
+		Header:                headerURL,
+		HeaderStatic:          headerURLStatic,
+		FollowersCount:        followersCount,
+		FollowingCount:        followingCount,
+		StatusesCount:         statusesCount,
+		LastStatusAt:          lastStatusAt,
+		Emojis:                apiEmojis,
+		Fields:                fields,
+		Suspended:             !a.SuspendedAt.IsZero(),
+		Theme:                 theme,
+		CustomCSS:             customCSS,
+		EnableRSS:             enableRSS,
+		HideCollections:       hideCollections,
+		HideStatusesLoggedOut: hideStatusesLoggedOut,
+		NoIndex:               noIndex,
+		Role:                  role,
+		Roles:                 roles,
+		Moved:                 moved,
 	}
 
 	// Bodge default avatar + header in,
@@ -437,6 +465,7 @@ func (c *Converter) AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Ac
 		disabled               bool
 		role                   = apimodel.AccountRole{Name: apimodel.AccountRoleUser} // assume user by default
 		createdByApplicationID string
+		invitedByAccountID     string
 	)
 
 	if err := c.state.DB.PopulateAccount(ctx, a); err != nil {
@@ -485,6 +514,17 @@ func (c *Converter) AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Ac
 		approved = *user.Approved
 		disabled = *user.Disabled
 		createdByApplicationID = user.CreatedByApplicationID
+
+		if user.InviteID != "" {
+			invite, err := c.state.DB.GetInviteByID(gtscontext.SetBarebones(ctx), user.InviteID)
+			if err != nil && !errors.Is(err, db.ErrNoEntries) {
+				return nil, fmt.Errorf("AccountToAdminAPIAccount: error getting invite from database for account id %s: %w", a.ID, err)
+			}
+
+			if invite != nil {
+				invitedByAccountID = invite.AccountID
+			}
+		}
 	}
 
 	apiAccount, err := c.AccountToAPIAccountPublic(ctx, a)
@@ -510,7 +550,7 @@ func (c *Converter) AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Ac
 		Suspended:              !a.SuspendedAt.IsZero(),
 		Account:                apiAccount,
 		CreatedByApplicationID: createdByApplicationID,
-		InvitedByAccountID:     "", // not implemented (yet)
+		InvitedByAccountID:     invitedByAccountID,
 	}, nil
 }
 
@@ -595,7 +635,7 @@ func (c *Converter) AttachmentToAPIAttachment(ctx context.Context, a *gtsmodel.M
 			Y: a.FileMeta.Focus.Y,
 		}
 
-	case gtsmodel.FileTypeVideo:
+	case gtsmodel.FileTypeVideo, gtsmodel.FileTypeGifv:
 		if i := a.FileMeta.Original.Duration; i != nil {
 			apiAttachment.Meta.Original.Duration = *i
 		}
@@ -705,8 +745,9 @@ func (c *Converter) EmojiToAdminAPIEmoji(ctx context.Context, e *gtsmodel.Emoji)
 // EmojiCategoryToAPIEmojiCategory converts a gts model emoji category into its api (frontend) representation.
 func (c *Converter) EmojiCategoryToAPIEmojiCategory(ctx context.Context, category *gtsmodel.EmojiCategory) (*apimodel.EmojiCategory, error) {
 	return &apimodel.EmojiCategory{
-		ID:   category.ID,
-		Name: category.Name,
+		ID:       category.ID,
+		Name:     category.Name,
+		Position: category.Position,
 	}, nil
 }
 
@@ -1109,9 +1150,19 @@ func (c *Converter) statusToFrontend(
 		log.Errorf(ctx, "error converting status tags: %v", err)
 	}
 
-	apiEmojis, err := c.convertEmojisToAPIEmojis(ctx, s.Emojis, s.EmojiIDs)
-	if err != nil {
-		log.Errorf(ctx, "error converting status emojis: %v", err)
+	// Check which optional fields the
+	// client asked us to leave out, if any.
+	omit := gtscontext.OmitFields(ctx)
+
+	var apiEmojis []apimodel.Emoji
+	if slices.Contains(omit, string(apimodel.StatusFieldEmojis)) {
+		// Serialize as `[]`.
+		apiEmojis = []apimodel.Emoji{}
+	} else {
+		apiEmojis, err = c.convertEmojisToAPIEmojis(ctx, s.Emojis, s.EmojiIDs)
+		if err != nil {
+			log.Errorf(ctx, "error converting status emojis: %v", err)
+		}
 	}
 
 	apiStatus := &apimodel.Status{
@@ -1119,7 +1170,7 @@ func (c *Converter) statusToFrontend(
 		CreatedAt:          util.FormatISO8601(s.CreatedAt),
 		InReplyToID:        nil, // Set below.
 		InReplyToAccountID: nil, // Set below.
-		Sensitive:          *s.Sensitive,
+		Sensitive:          *s.Sensitive || (s.Account != nil && s.Account.IsSensitized()),
 		SpoilerText:        s.ContentWarning,
 		Visibility:         c.VisToAPIVis(ctx, s.Visibility),
 		Language:           nil, // Set below.
@@ -1138,6 +1189,7 @@ func (c *Converter) statusToFrontend(
 		Emojis:             apiEmojis,
 		Card:               nil, // TODO: implement cards
 		Text:               s.Text,
+		Indexable:          util.PtrValueOr(s.Indexable, false),
 	}
 
 	// Nullable fields.
@@ -1176,7 +1228,7 @@ func (c *Converter) statusToFrontend(
 		}
 	}
 
-	if s.Poll != nil {
+	if s.Poll != nil && !slices.Contains(omit, string(apimodel.StatusFieldPoll)) {
 		// Set originating
 		// status on the poll.
 		poll := s.Poll
@@ -1235,6 +1287,40 @@ func (c *Converter) statusToFrontend(
 	return apiStatus, nil
 }
 
+// OmitStatusFields returns the given status with the optional fields
+// stored in the context (see gtscontext.OmitFields) left out. This is
+// for statuses which were already converted without these fields being
+// omitted, eg., prepared timeline items. The given status is not modified,
+// a shallow copy is returned instead if any fields need to be omitted.
+func (c *Converter) OmitStatusFields(ctx context.Context, s *apimodel.Status) *apimodel.Status {
+	omit := gtscontext.OmitFields(ctx)
+	if len(omit) == 0 {
+		// Nothing to do.
+		return s
+	}
+
+	s2 := new(apimodel.Status)
+	*s2 = *s
+
+	for _, field := range omit {
+		switch apimodel.StatusOptionalField(field) {
+		case apimodel.StatusFieldCard:
+			s2.Card = nil
+		case apimodel.StatusFieldPoll:
+			s2.Poll = nil
+		case apimodel.StatusFieldEmojis:
+			s2.Emojis = []apimodel.Emoji{}
+		}
+	}
+
+	if s.Reblog != nil {
+		reblog := c.OmitStatusFields(ctx, s.Reblog.Status)
+		s2.Reblog = &apimodel.StatusReblogged{reblog}
+	}
+
+	return s2
+}
+
 // VisToAPIVis converts a gts visibility into its api equivalent
 func (c *Converter) VisToAPIVis(ctx context.Context, m gtsmodel.Visibility) apimodel.Visibility {
 	switch m {
@@ -1279,6 +1365,39 @@ func (c *Converter) InstanceRuleToAdminAPIRule(r *gtsmodel.Rule) *apimodel.Admin
 	}
 }
 
+// InstanceStatsToAdminAPIInstanceStats converts aggregated instance stats into their api equivalent
+// for serving at /api/v1/admin/instance/stats. Days since stats.StatusesSince without any statuses
+// are included with a count of 0. Queue depths are not part of the stats, and are left unset.
+func (c *Converter) InstanceStatsToAdminAPIInstanceStats(stats *gtsmodel.InstanceStats) *apimodel.AdminInstanceStats {
+	statusesPerDay := make(map[string]int)
+	for day := stats.StatusesSince.UTC(); day.Before(stats.ComputedAt); day = day.AddDate(0, 0, 1) {
+		key := day.Format(time.DateOnly)
+		statusesPerDay[key] = stats.StatusesPerDay[key]
+	}
+
+	return &apimodel.AdminInstanceStats{
+		Users: apimodel.AdminInstanceStatsUsers{
+			Active:      stats.UsersActive,
+			Pending:     stats.UsersPending,
+			Unconfirmed: stats.UsersUnconfirmed,
+			Disabled:    stats.UsersDisabled,
+			Suspended:   stats.UsersSuspended,
+		},
+		StatusesPerDay: statusesPerDay,
+		Media: apimodel.AdminInstanceStatsMedia{
+			LocalCount:    stats.LocalMediaCount,
+			LocalStorage:  stats.LocalMediaStorage,
+			RemoteCount:   stats.RemoteMediaCount,
+			RemoteStorage: stats.RemoteMediaStorage,
+		},
+		Peers: apimodel.AdminInstanceStatsPeers{
+			Total:     stats.PeersTotal,
+			Suspended: stats.PeersSuspended,
+		},
+		ComputedAt: util.FormatISO8601(stats.ComputedAt),
+	}
+}
+
 // InstanceToAPIV1Instance converts a gts instance into its api equivalent for serving at /api/v1/instance
 func (c *Converter) InstanceToAPIV1Instance(ctx context.Context, i *gtsmodel.Instance) (*apimodel.InstanceV1, error) {
 	instance := &apimodel.InstanceV1{
@@ -1293,12 +1412,14 @@ func (c *Converter) InstanceToAPIV1Instance(ctx context.Context, i *gtsmodel.Ins
 		Version:              config.GetSoftwareVersion(),
 		Languages:            config.GetInstanceLanguages().TagStrs(),
 		Registrations:        config.GetAccountsRegistrationOpen(),
-		ApprovalRequired:     true,  // approval always required
-		InvitesEnabled:       false, // todo: not supported yet
+		ApprovalRequired:     true, // approval always required
+		InvitesEnabled:       config.GetAccountsInvitesEnabled(),
 		MaxTootChars:         uint(config.GetStatusesMaxChars()),
 		Rules:                c.InstanceRulesToAPIRules(i.Rules),
 		Terms:                i.Terms,
 		TermsRaw:             i.TermsText,
+		PrivacyPolicy:        i.PrivacyPolicy,
+		PrivacyPolicyRaw:     i.PrivacyPolicyText,
 	}
 
 	if config.GetInstanceInjectMastodonVersion() {
@@ -1320,6 +1441,8 @@ func (c *Converter) InstanceToAPIV1Instance(ctx context.Context, i *gtsmodel.Ins
 	instance.Configuration.MediaAttachments.VideoSizeLimit = int(config.GetMediaVideoMaxSize())
 	instance.Configuration.MediaAttachments.VideoFrameRateLimit = instanceMediaAttachmentsVideoFrameRateLimit
 	instance.Configuration.MediaAttachments.VideoMatrixLimit = instanceMediaAttachmentsVideoMatrixLimit
+	instance.Configuration.MediaAttachments.ResumableUploadMinSize = int(config.GetMediaResumableUploadMinSize())
+	instance.Configuration.MediaAttachments.ResumableUploadMinPartSize = int(config.GetMediaResumableUploadMinPartSize())
 	instance.Configuration.Polls.MaxOptions = config.GetStatusesPollMaxOptions()
 	instance.Configuration.Polls.MaxCharactersPerOption = config.GetStatusesPollOptionMaxChars()
 	instance.Configuration.Polls.MinExpiration = instancePollsMinExpiration
@@ -1393,24 +1516,57 @@ func (c *Converter) InstanceToAPIV1Instance(ctx context.Context, i *gtsmodel.Ins
 		instance.ContactAccount = account
 	}
 
+	// landing page
+	instance.LandingPageBlocks = i.LandingPageBlocks
+	if len(instance.LandingPageBlocks) == 0 {
+		instance.LandingPageBlocks = gtsmodel.DefaultLandingPageBlocks
+	}
+
+	if len(i.FeaturedAccountIDs) != 0 {
+		if !i.FeaturedAccountsPopulated() {
+			featuredAccounts, err := c.state.DB.GetAccountsByIDs(ctx, i.FeaturedAccountIDs)
+			if err != nil {
+				return nil, fmt.Errorf("InstanceToAPIV1Instance: db error getting instance featured accounts: %w", err)
+			}
+			i.FeaturedAccounts = featuredAccounts
+		}
+
+		instance.FeaturedAccounts = make([]*apimodel.Account, 0, len(i.FeaturedAccounts))
+		for _, featuredAccount := range i.FeaturedAccounts {
+			if featuredAccount.IsSuspended() {
+				// Don't show accounts
+				// suspended since featuring.
+				continue
+			}
+
+			account, err := c.AccountToAPIAccountPublic(ctx, featuredAccount)
+			if err != nil {
+				return nil, fmt.Errorf("InstanceToAPIV1Instance: error converting instance featured account %s: %w", featuredAccount.ID, err)
+			}
+			instance.FeaturedAccounts = append(instance.FeaturedAccounts, account)
+		}
+	}
+
 	return instance, nil
 }
 
 // InstanceToAPIV2Instance converts a gts instance into its api equivalent for serving at /api/v2/instance
 func (c *Converter) InstanceToAPIV2Instance(ctx context.Context, i *gtsmodel.Instance) (*apimodel.InstanceV2, error) {
 	instance := &apimodel.InstanceV2{
-		Domain:          i.Domain,
-		AccountDomain:   config.GetAccountDomain(),
-		Title:           i.Title,
-		Version:         config.GetSoftwareVersion(),
-		SourceURL:       instanceSourceURL,
-		Description:     i.Description,
-		DescriptionText: i.DescriptionText,
-		Usage:           apimodel.InstanceV2Usage{}, // todo: not implemented
-		Languages:       config.GetInstanceLanguages().TagStrs(),
-		Rules:           c.InstanceRulesToAPIRules(i.Rules),
-		Terms:           i.Terms,
-		TermsText:       i.TermsText,
+		Domain:            i.Domain,
+		AccountDomain:     config.GetAccountDomain(),
+		Title:             i.Title,
+		Version:           config.GetSoftwareVersion(),
+		SourceURL:         instanceSourceURL,
+		Description:       i.Description,
+		DescriptionText:   i.DescriptionText,
+		Usage:             apimodel.InstanceV2Usage{}, // todo: not implemented
+		Languages:         config.GetInstanceLanguages().TagStrs(),
+		Rules:             c.InstanceRulesToAPIRules(i.Rules),
+		Terms:             i.Terms,
+		TermsText:         i.TermsText,
+		PrivacyPolicy:     i.PrivacyPolicy,
+		PrivacyPolicyText: i.PrivacyPolicyText,
 	}
 
 	if config.GetInstanceInjectMastodonVersion() {
@@ -1460,6 +1616,8 @@ func (c *Converter) InstanceToAPIV2Instance(ctx context.Context, i *gtsmodel.Ins
 	instance.Configuration.MediaAttachments.VideoSizeLimit = int(config.GetMediaVideoMaxSize())
 	instance.Configuration.MediaAttachments.VideoFrameRateLimit = instanceMediaAttachmentsVideoFrameRateLimit
 	instance.Configuration.MediaAttachments.VideoMatrixLimit = instanceMediaAttachmentsVideoMatrixLimit
+	instance.Configuration.MediaAttachments.ResumableUploadMinSize = int(config.GetMediaResumableUploadMinSize())
+	instance.Configuration.MediaAttachments.ResumableUploadMinPartSize = int(config.GetMediaResumableUploadMinPartSize())
 	instance.Configuration.Polls.MaxOptions = config.GetStatusesPollMaxOptions()
 	instance.Configuration.Polls.MaxCharactersPerOption = config.GetStatusesPollOptionMaxChars()
 	instance.Configuration.Polls.MinExpiration = instancePollsMinExpiration
@@ -1619,9 +1777,243 @@ func (c *Converter) DomainPermToAPIDomainPerm(
 	domainPerm.CreatedBy = d.GetCreatedByAccountID()
 	domainPerm.CreatedAt = util.FormatISO8601(d.GetCreatedAt())
 
+	// Drafts may be of either type,
+	// so indicate which one this is.
+	if _, ok := d.(*gtsmodel.DomainPermissionDraft); ok {
+		domainPerm.PermissionType = d.GetType().String()
+	}
+
 	return domainPerm, nil
 }
 
+// DomainPauseToAPIDomainPause converts a gts model domain pause into an api model domain pause, for serving at /api/v1/admin/domain_pauses.
+func (c *Converter) DomainPauseToAPIDomainPause(
+	ctx context.Context,
+	d *gtsmodel.DomainPause,
+) (*apimodel.DomainPause, error) {
+	// Domain may be in Punycode,
+	// de-punify it just in case.
+	domain, err := util.DePunify(d.Domain)
+	if err != nil {
+		return nil, gtserror.Newf("error de-punifying domain %s: %w", d.Domain, err)
+	}
+
+	return &apimodel.DomainPause{
+		ID:             d.ID,
+		Domain:         domain,
+		PauseIngestion: *d.PauseIngestion,
+		PrivateComment: d.PrivateComment,
+		CreatedBy:      d.CreatedByAccountID,
+		CreatedAt:      util.FormatISO8601(d.CreatedAt),
+	}, nil
+}
+
+// SeveredRelationshipToAPISeveredRelationship converts a gts model severed relationship into an api model severed relationship, for serving at /api/v1/severed_relationships.
+func (c *Converter) SeveredRelationshipToAPISeveredRelationship(
+	ctx context.Context,
+	s *gtsmodel.SeveredRelationship,
+) (*apimodel.SeveredRelationship, error) {
+	if s.RemoteAccount == nil {
+		remoteAccount, err := c.state.DB.GetAccountByID(ctx, s.RemoteAccountID)
+		if err != nil {
+			return nil, gtserror.Newf("error getting remote account %s: %w", s.RemoteAccountID, err)
+		}
+		s.RemoteAccount = remoteAccount
+	}
+
+	apiAccount, err := c.AccountToAPIAccountPublic(ctx, s.RemoteAccount)
+	if err != nil {
+		return nil, gtserror.Newf("error converting remote account to api: %w", err)
+	}
+
+	// Domain may be in Punycode,
+	// de-punify it just in case.
+	domain, err := util.DePunify(s.Domain)
+	if err != nil {
+		return nil, gtserror.Newf("error de-punifying domain %s: %w", s.Domain, err)
+	}
+
+	apiSevered := &apimodel.SeveredRelationship{
+		ID:         s.ID,
+		Domain:     domain,
+		Account:    apiAccount,
+		Direction:  string(s.Direction),
+		CreatedAt:  util.FormatISO8601(s.CreatedAt),
+		Restorable: s.Restorable(),
+	}
+
+	if !s.RestoredAt.IsZero() {
+		restoredAt := util.FormatISO8601(s.RestoredAt)
+		apiSevered.RestoredAt = &restoredAt
+	}
+
+	return apiSevered, nil
+}
+
+// InviteToAPIInvite converts a gts model invite into an api model invite, for serving at /api/v1/invites.
+func (c *Converter) InviteToAPIInvite(
+	ctx context.Context,
+	i *gtsmodel.Invite,
+) (*apimodel.Invite, error) {
+	signupURL := &url.URL{
+		Scheme:   config.GetProtocol(),
+		Host:     config.GetHost(),
+		Path:     "/signup",
+		RawQuery: url.Values{"invite_code": []string{i.Code}}.Encode(),
+	}
+
+	apiInvite := &apimodel.Invite{
+		ID:        i.ID,
+		Code:      i.Code,
+		URL:       signupURL.String(),
+		Uses:      i.Uses,
+		CreatedAt: util.FormatISO8601(i.CreatedAt),
+		Usable:    i.Usable(),
+	}
+
+	if i.MaxUses > 0 {
+		apiInvite.MaxUses = util.Ptr(i.MaxUses)
+	}
+
+	if !i.ExpiresAt.IsZero() {
+		expiresAt := util.FormatISO8601(i.ExpiresAt)
+		apiInvite.ExpiresAt = &expiresAt
+	}
+
+	return apiInvite, nil
+}
+
+// InviteToAdminAPIInvite converts a gts model invite into an api model invite
+// which includes the account that created the invite, for serving at /api/v1/admin/invites.
+func (c *Converter) InviteToAdminAPIInvite(
+	ctx context.Context,
+	i *gtsmodel.Invite,
+) (*apimodel.Invite, error) {
+	apiInvite, err := c.InviteToAPIInvite(ctx, i)
+	if err != nil {
+		return nil, err
+	}
+
+	if i.Account == nil {
+		account, err := c.state.DB.GetAccountByID(ctx, i.AccountID)
+		if err != nil {
+			return nil, gtserror.Newf("error getting invite account %s: %w", i.AccountID, err)
+		}
+		i.Account = account
+	}
+
+	apiInvite.Account, err = c.AccountToAPIAccountPublic(ctx, i.Account)
+	if err != nil {
+		return nil, gtserror.Newf("error converting invite account to api: %w", err)
+	}
+
+	return apiInvite, nil
+}
+
+// ImportToAPIImport converts a gts model import, and its rows, into
+// an api model import. If includeRows is true, the status of each
+// row will be included, else just the counts of rows by status.
+func (c *Converter) ImportToAPIImport(
+	ctx context.Context,
+	i *gtsmodel.Import,
+	rows []*gtsmodel.ImportRow,
+	includeRows bool,
+) (*apimodel.Import, error) {
+	apiImport := &apimodel.Import{
+		ID:        i.ID,
+		Type:      string(i.Type),
+		CreatedAt: util.FormatISO8601(i.CreatedAt),
+		Total:     len(rows),
+	}
+
+	if i.Finished() {
+		finishedAt := util.FormatISO8601(i.FinishedAt)
+		apiImport.FinishedAt = &finishedAt
+	}
+
+	if includeRows {
+		apiImport.Rows = make([]apimodel.ImportRow, 0, len(rows))
+	}
+
+	for _, row := range rows {
+		switch row.Status {
+		case gtsmodel.ImportRowStatusPending:
+			apiImport.Pending++
+		case gtsmodel.ImportRowStatusDone:
+			apiImport.Done++
+		case gtsmodel.ImportRowStatusFailed:
+			apiImport.Failed++
+		}
+
+		if includeRows {
+			apiImport.Rows = append(apiImport.Rows, apimodel.ImportRow{
+				Line:   row.Line,
+				Target: row.Target,
+				Status: string(row.Status),
+				Error:  row.Error,
+			})
+		}
+	}
+
+	return apiImport, nil
+}
+
+// ModerationNoteToAPIModerationNote converts a gts model moderation note into
+// an api model moderation note, for serving at /api/v1/admin/moderation_notes.
+func (c *Converter) ModerationNoteToAPIModerationNote(
+	ctx context.Context,
+	n *gtsmodel.ModerationNote,
+) (*apimodel.ModerationNote, error) {
+	if n.AuthorAccount == nil {
+		return nil, gtserror.New("moderation note author account not populated")
+	}
+
+	author, err := c.AccountToAPIAccountPublic(ctx, n.AuthorAccount)
+	if err != nil {
+		return nil, gtserror.Newf("error converting moderation note author to api: %w", err)
+	}
+
+	return &apimodel.ModerationNote{
+		ID:        n.ID,
+		CreatedAt: util.FormatISO8601(n.CreatedAt),
+		Author:    author,
+		AccountID: n.TargetAccountID,
+		ReportID:  n.ReportID,
+		Content:   n.Content,
+	}, nil
+}
+
+// AccountDelegateToAPIAccountDelegate converts a gts model
+// account delegate into its api (frontend) representation.
+func (c *Converter) AccountDelegateToAPIAccountDelegate(
+	ctx context.Context,
+	d *gtsmodel.AccountDelegate,
+) (*apimodel.AccountDelegate, error) {
+	if d.Account == nil || d.DelegateAccount == nil {
+		return nil, gtserror.New("account delegate accounts not populated")
+	}
+
+	account, err := c.AccountToAPIAccountPublic(ctx, d.Account)
+	if err != nil {
+		return nil, gtserror.Newf("error converting account delegate account to api: %w", err)
+	}
+
+	delegate, err := c.AccountToAPIAccountPublic(ctx, d.DelegateAccount)
+	if err != nil {
+		return nil, gtserror.Newf("error converting account delegate delegate account to api: %w", err)
+	}
+
+	return &apimodel.AccountDelegate{
+		ID:           d.ID,
+		CreatedAt:    util.FormatISO8601(d.CreatedAt),
+		Account:      account,
+		Delegate:     delegate,
+		CanPost:      *d.CanPost,
+		CanManageDMs: *d.CanManageDMs,
+		Accepted:     d.IsAccepted(),
+	}, nil
+}
+
 // ReportToAPIReport converts a gts model report into an api model report, for serving at /api/v1/reports
 func (c *Converter) ReportToAPIReport(ctx context.Context, r *gtsmodel.Report) (*apimodel.Report, error) {
 	report := &apimodel.Report{
@@ -27,6 +27,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
 	"github.com/superseriousbusiness/gotosocial/internal/filter/usermute"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/superseriousbusiness/gotosocial/testrig"
@@ -126,7 +127,12 @@ func (suite *InternalToFrontendTestSuite) TestAccountToFrontendAliasedAndMoved()
     "follow_requests_count": 0,
     "also_known_as_uris": [
       "http://localhost:8080/users/1happyturtle"
-    ]
+    ],
+    "snooze_allow_follows": false,
+    "snooze_allow_direct": false,
+    "follow_auto_accept_delay": 0,
+    "hide_from_local_timeline": false,
+    "hide_from_federated_timeline": false
   },
   "enable_rss": true,
   "role": {
@@ -306,7 +312,12 @@ func (suite *InternalToFrontendTestSuite) TestAccountToFrontendSensitive() {
     "status_content_type": "text/plain",
     "note": "hey yo this is my profile!",
     "fields": [],
-    "follow_requests_count": 0
+    "follow_requests_count": 0,
+    "snooze_allow_follows": false,
+    "snooze_allow_direct": false,
+    "follow_auto_accept_delay": 0,
+    "hide_from_local_timeline": false,
+    "hide_from_federated_timeline": false
   },
   "enable_rss": true,
   "role": {
@@ -540,6 +551,35 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontend() {
 }`, string(b))
 }
 
+func (suite *InternalToFrontendTestSuite) TestStatusToFrontendOmitFields() {
+	requestingAccount := suite.testAccounts["local_account_1"]
+	ctx := gtscontext.SetOmitFields(context.Background(), []string{"poll", "emojis"})
+
+	// Status with emojis.
+	testStatus := suite.testStatuses["admin_account_status_1"]
+	apiStatus, err := suite.typeconverter.StatusToAPIStatus(ctx, testStatus, requestingAccount, statusfilter.FilterContextNone, nil, nil)
+	suite.NoError(err)
+	suite.NotNil(apiStatus.Emojis)
+	suite.Empty(apiStatus.Emojis)
+
+	// Status with a poll.
+	testStatus = suite.testStatuses["local_account_1_status_6"]
+	apiStatus, err = suite.typeconverter.StatusToAPIStatus(ctx, testStatus, requestingAccount, statusfilter.FilterContextNone, nil, nil)
+	suite.NoError(err)
+	suite.Nil(apiStatus.Poll)
+
+	// Converting without omitted fields includes the
+	// poll, and omitting afterwards leaves it untouched.
+	apiStatus, err = suite.typeconverter.StatusToAPIStatus(context.Background(), testStatus, requestingAccount, statusfilter.FilterContextNone, nil, nil)
+	suite.NoError(err)
+	suite.NotNil(apiStatus.Poll)
+
+	omitted := suite.typeconverter.OmitStatusFields(ctx, apiStatus)
+	suite.Nil(omitted.Poll)
+	suite.NotNil(apiStatus.Poll)
+	suite.Equal(apiStatus.ID, omitted.ID)
+}
+
 // Test that a status which is filtered with a warn filter by the requesting user has `filtered` set correctly.
 func (suite *InternalToFrontendTestSuite) TestWarnFilteredStatusToFrontend() {
 	testStatus := suite.testStatuses["admin_account_status_1"]
@@ -1211,7 +1251,9 @@ func (suite *InternalToFrontendTestSuite) TestInstanceV1ToFrontend() {
       "image_matrix_limit": 16777216,
       "video_size_limit": 41943040,
       "video_frame_rate_limit": 60,
-      "video_matrix_limit": 16777216
+      "video_matrix_limit": 16777216,
+      "resumable_upload_min_size": 5242880,
+      "resumable_upload_min_part_size": 4096
     },
     "polls": {
       "max_options": 6,
@@ -1266,7 +1308,13 @@ func (suite *InternalToFrontendTestSuite) TestInstanceV1ToFrontend() {
   "max_toot_chars": 5000,
   "rules": [],
   "terms": "\u003cp\u003eThis is where a list of terms and conditions might go.\u003c/p\u003e\u003cp\u003eFor example:\u003c/p\u003e\u003cp\u003eIf you want to sign up on this instance, you oughta know that we:\u003c/p\u003e\u003col\u003e\u003cli\u003eWill sell your data to whoever offers.\u003c/li\u003e\u003cli\u003eSecure the server with password \u003ccode\u003epassword\u003c/code\u003e wherever possible.\u003c/li\u003e\u003c/ol\u003e",
-  "terms_text": "This is where a list of terms and conditions might go.\n\nFor example:\n\nIf you want to sign up on this instance, you oughta know that we:\n\n1. Will sell your data to whoever offers.\n2. Secure the server with password `+"`"+`password`+"`"+` wherever possible."
+  "terms_text": "This is where a list of terms and conditions might go.\n\nFor example:\n\nIf you want to sign up on this instance, you oughta know that we:\n\n1. Will sell your data to whoever offers.\n2. Secure the server with password `+"`"+`password`+"`"+` wherever possible.",
+  "landing_page_blocks": [
+    "short_description",
+    "what_is_this",
+    "register",
+    "apps"
+  ]
 }`, string(b))
 }
 
@@ -1336,7 +1384,9 @@ func (suite *InternalToFrontendTestSuite) TestInstanceV2ToFrontend() {
       "image_matrix_limit": 16777216,
       "video_size_limit": 41943040,
       "video_frame_rate_limit": 60,
-      "video_matrix_limit": 16777216
+      "video_matrix_limit": 16777216,
+      "resumable_upload_min_size": 5242880,
+      "resumable_upload_min_part_size": 4096
     },
     "polls": {
       "max_options": 6,
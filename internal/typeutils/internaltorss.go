@@ -20,6 +20,7 @@ package typeutils
 import (
 	"context"
 	"fmt"
+	"html"
 	"strconv"
 	"strings"
 
@@ -105,17 +106,17 @@ func (c *Converter) StatusToRSSItem(ctx context.Context, s *gtsmodel.Status) (*f
 
 	// Enclosure -- Describes a media object that is attached to the item.
 	enclosure := &feeds.Enclosure{}
-	// get first attachment if present
-	var attachment *gtsmodel.MediaAttachment
-	if len(s.Attachments) > 0 {
-		attachment = s.Attachments[0]
-	} else if len(s.AttachmentIDs) > 0 {
-		a, err := c.state.DB.GetAttachmentByID(ctx, s.AttachmentIDs[0])
+	attachments := s.Attachments
+	if len(attachments) == 0 && len(s.AttachmentIDs) > 0 {
+		a, err := c.state.DB.GetAttachmentsByIDs(ctx, s.AttachmentIDs)
 		if err == nil {
-			attachment = a
+			attachments = a
 		}
 	}
-	if attachment != nil {
+	// RSS only allows one enclosure per item,
+	// so use the first attachment if present.
+	if len(attachments) > 0 {
+		attachment := attachments[0]
 		enclosure.Type = attachment.File.ContentType
 		enclosure.Length = strconv.Itoa(attachment.File.FileSize)
 		enclosure.Url = attachment.URL
@@ -153,6 +154,10 @@ func (c *Converter) StatusToRSSItem(ctx context.Context, s *gtsmodel.Status) (*f
 	}
 	content := text.EmojifyRSS(apiEmojis, s.Content)
 
+	// Link all attachments from the content,
+	// since only one fits in the enclosure.
+	content += rssAttachmentsHTML(attachments)
+
 	return &feeds.Item{
 		Title:       title,
 		Link:        link,
@@ -167,6 +172,31 @@ func (c *Converter) StatusToRSSItem(ctx context.Context, s *gtsmodel.Status) (*f
 	}, nil
 }
 
+// rssAttachmentsHTML returns HTML linking to each of the
+// given attachments, showing the thumbnail where there is
+// one, else the description, for inclusion in item content.
+func rssAttachmentsHTML(attachments []*gtsmodel.MediaAttachment) string {
+	var b strings.Builder
+	for _, a := range attachments {
+		b.WriteString(`<p><a href="`)
+		b.WriteString(html.EscapeString(a.URL))
+		b.WriteString(`">`)
+		if a.Thumbnail.URL != "" {
+			b.WriteString(`<img src="`)
+			b.WriteString(html.EscapeString(a.Thumbnail.URL))
+			b.WriteString(`" alt="`)
+			b.WriteString(html.EscapeString(a.Description))
+			b.WriteString(`"/>`)
+		} else if a.Description != "" {
+			b.WriteString(html.EscapeString(a.Description))
+		} else {
+			b.WriteString(html.EscapeString(a.URL))
+		}
+		b.WriteString(`</a></p>`)
+	}
+	return b.String()
+}
+
 // trimTo trims the given `in` string to
 // the length `to`, measured in runes.
 //
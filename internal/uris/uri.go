@@ -46,6 +46,7 @@ const (
 	FileserverPath   = "fileserver"    // FileserverPath is a path component for serving attachments + media
 	EmojiPath        = "emoji"         // EmojiPath represents the activitypub emoji location
 	TagsPath         = "tags"          // TagsPath represents the activitypub tags location
+	EmbedPath        = "embed"         // EmbedPath is used to generate the URI for an embeddable status page
 )
 
 // UserURIs contains a bunch of UserURIs and URLs for a user, host, account, etc.
@@ -136,6 +137,14 @@ func GenerateURIForEmailConfirm(token string) string {
 	return fmt.Sprintf("%s://%s/%s?token=%s", protocol, host, ConfirmEmailPath, token)
 }
 
+// GenerateURIForEmbed returns a link for an embeddable status page -- something like:
+// https://example.org/embed/statuses/01F8MH75CBF9JFX4ZAD54N0W0R
+func GenerateURIForEmbed(statusID string) string {
+	protocol := config.GetProtocol()
+	host := config.GetHost()
+	return fmt.Sprintf("%s://%s/%s/%s/%s", protocol, host, EmbedPath, StatusesPath, statusID)
+}
+
 // GenerateURIsForAccount throws together a bunch of URIs for the given username, with the given protocol and host.
 func GenerateURIsForAccount(username string) *UserURIs {
 	protocol := config.GetProtocol()
@@ -227,6 +236,29 @@ func StoragePathForAttachment(
 	)
 }
 
+// StoragePathForBlob generates a content-addressed
+// storage path for media with the given (hex-encoded
+// sha256) content hash, sharded by its first byte.
+//
+// Will produce something like:
+//
+//	"blobs/9f/9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08.gif"
+func StoragePathForBlob(hash string, extension string) string {
+	return "blobs/" + hash[:2] + "/" + hash + "." + extension
+}
+
+// StoragePathForQuarantine generates a storage path
+// for infected media with the given (hex-encoded
+// sha256) content hash, kept out of the way for
+// admin inspection.
+//
+// Will produce something like:
+//
+//	"quarantine/9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08.gif"
+func StoragePathForQuarantine(hash string, extension string) string {
+	return "quarantine/" + hash + "." + extension
+}
+
 // URIForEmoji generates an
 // ActivityPub URI for an emoji.
 //
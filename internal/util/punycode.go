@@ -18,6 +18,8 @@
 package util
 
 import (
+	"net"
+	"net/netip"
 	"strings"
 
 	"golang.org/x/net/idna"
@@ -26,19 +28,66 @@ import (
 // Punify converts the given domain to lowercase
 // then to punycode (for international domain names).
 //
+// IP address literals (with or without a port) are
+// instead normalized to their canonical form, with
+// IPv6 addresses always wrapped in square brackets,
+// as they would appear in the host part of a URL.
+//
 // Returns the resulting domain or an error if the
 // punycode conversion fails.
 func Punify(domain string) (string, error) {
 	domain = strings.ToLower(domain)
+	domain = strings.TrimSuffix(domain, ".")
+
+	if ip, ok := normalizeIPLiteral(domain); ok {
+		return ip, nil
+	}
+
 	return idna.ToASCII(domain)
 }
 
+// normalizeIPLiteral returns the canonical form of the
+// given host if it is an IP address literal, optionally
+// including a port. Returns false if host is not an IP.
+func normalizeIPLiteral(host string) (string, bool) {
+	// Bare IPv4 or IPv6 address, no port.
+	if addr, err := netip.ParseAddr(host); err == nil {
+		if addr.Zone() != "" {
+			return "", false
+		}
+		if addr.Is6() && !addr.Is4In6() {
+			return "[" + addr.String() + "]", true
+		}
+		return addr.Unmap().String(), true
+	}
+
+	// Bracketed IPv6 address, with no port.
+	if strings.HasPrefix(host, "[") && strings.HasSuffix(host, "]") {
+		addr, err := netip.ParseAddr(host[1 : len(host)-1])
+		if err != nil || addr.Zone() != "" {
+			return "", false
+		}
+		return "[" + addr.String() + "]", true
+	}
+
+	// IP address with port.
+	h, port, err := net.SplitHostPort(host)
+	if err != nil || port == "" {
+		return "", false
+	}
+	ip, ok := normalizeIPLiteral(h)
+	if !ok {
+		return "", false
+	}
+	return ip + ":" + port, true
+}
+
 // DePunify converts the given punycode string
 // to its original unicode representation (lowercased).
 // Noop if the domain is (already) not puny.
 //
 // Returns an error if conversion fails.
 func DePunify(domain string) (string, error) {
-	out, err := idna.ToUnicode(domain)
-	return strings.ToLower(out), err
+	domain = strings.ToLower(domain)
+	return idna.ToUnicode(domain)
 }
@@ -0,0 +1,75 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+func TestPunify(t *testing.T) {
+	for _, test := range []struct {
+		in  string
+		out string
+	}{
+		{in: "example.org", out: "example.org"},
+		{in: "Example.ORG.", out: "example.org"},
+		{in: "example.org:8080", out: "example.org:8080"},
+		{in: "bücher.de", out: "xn--bcher-kva.de"},
+		{in: "BÜCHER.de", out: "xn--bcher-kva.de"},
+		{in: "xn--bcher-kva.de", out: "xn--bcher-kva.de"},
+		{in: "bücher.de:8080", out: "xn--bcher-kva.de:8080"},
+		{in: "192.168.0.1", out: "192.168.0.1"},
+		{in: "192.168.0.1:8080", out: "192.168.0.1:8080"},
+		{in: "2001:DB8:0::1", out: "[2001:db8::1]"},
+		{in: "[2001:DB8:0::1]", out: "[2001:db8::1]"},
+		{in: "[2001:db8:0:0::1]:8080", out: "[2001:db8::1]:8080"},
+		{in: "[::ffff:192.168.0.1]", out: "[::ffff:192.168.0.1]"},
+	} {
+		out, err := util.Punify(test.in)
+		if err != nil {
+			t.Errorf("unexpected error punifying %q: %v", test.in, err)
+			continue
+		}
+		if out != test.out {
+			t.Errorf("expected %q to punify to %q, got %q", test.in, test.out, out)
+		}
+	}
+}
+
+func TestDePunify(t *testing.T) {
+	for _, test := range []struct {
+		in  string
+		out string
+	}{
+		{in: "example.org", out: "example.org"},
+		{in: "xn--bcher-kva.de", out: "bücher.de"},
+		{in: "XN--BCHER-KVA.de", out: "bücher.de"},
+		{in: "[2001:db8::1]:8080", out: "[2001:db8::1]:8080"},
+	} {
+		out, err := util.DePunify(test.in)
+		if err != nil {
+			t.Errorf("unexpected error depunifying %q: %v", test.in, err)
+			continue
+		}
+		if out != test.out {
+			t.Errorf("expected %q to depunify to %q, got %q", test.in, test.out, out)
+		}
+	}
+}
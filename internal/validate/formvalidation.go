@@ -39,6 +39,7 @@ const (
 	maximumShortDescriptionLength = 500
 	maximumDescriptionLength      = 5000
 	maximumSiteTermsLength        = 5000
+	maximumPrivacyPolicyLength    = 5000
 	maximumUsernameLength         = 64
 	maximumEmojiCategoryLength    = 64
 	maximumProfileFieldLength     = 255
@@ -46,6 +47,10 @@ const (
 	maximumListTitleLength        = 200
 	maximumFilterKeywordLength    = 40
 	maximumFilterTitleLength      = 200
+	maximumModerationNoteLength   = 5000
+	maximumNotificationsSnooze    = 30 * 24 * 60 * 60 // 30 days, in seconds.
+	maximumFollowAutoAcceptDelay  = 7 * 24 * 60       // 7 days, in minutes.
+	maximumFeaturedAccounts       = 8
 )
 
 // Password returns a helpful error if the given password
@@ -176,6 +181,44 @@ func StatusContentType(statusContentType string) error {
 	return fmt.Errorf("status content type '%s' was not recognized, valid options are 'text/plain', 'text/markdown'", statusContentType)
 }
 
+// SpamFilterMode checks that the desired spam filter mode override is valid.
+// Empty string is allowed, and means to use the instance spam filter mode.
+func SpamFilterMode(spamFilterMode string) error {
+	switch spamFilterMode {
+	case "", config.SpamFilterModeOff, config.SpamFilterModeLog, config.SpamFilterModeDrop:
+		return nil
+	}
+	return fmt.Errorf("spam filter mode '%s' was not recognized, valid options are '', 'off', 'log', 'drop'", spamFilterMode)
+}
+
+// EmailDigest checks that the desired email digest frequency is valid.
+// Empty string is allowed, and means to not send email digests.
+func EmailDigest(emailDigest string) error {
+	switch emailDigest {
+	case "", gtsmodel.EmailDigestDaily, gtsmodel.EmailDigestWeekly:
+		return nil
+	}
+	return fmt.Errorf("email digest '%s' was not recognized, valid options are '', 'daily', 'weekly'", emailDigest)
+}
+
+// NotificationsSnooze checks that the desired notifications snooze
+// duration (in seconds) is valid. Zero is allowed, and means to unsnooze.
+func NotificationsSnooze(seconds int) error {
+	if seconds < 0 || seconds > maximumNotificationsSnooze {
+		return fmt.Errorf("notifications snooze must be between 0 and %d seconds, provided value was %d", maximumNotificationsSnooze, seconds)
+	}
+	return nil
+}
+
+// FollowAutoAcceptDelay checks that the desired follow auto-accept delay
+// (in minutes) is valid. Zero is allowed, and means to accept immediately.
+func FollowAutoAcceptDelay(minutes int) error {
+	if minutes < 0 || minutes > maximumFollowAutoAcceptDelay {
+		return fmt.Errorf("follow auto accept delay must be between 0 and %d minutes, provided value was %d", maximumFollowAutoAcceptDelay, minutes)
+	}
+	return nil
+}
+
 func CustomCSS(customCSS string) error {
 	if !config.GetAccountsAllowCustomCSS() {
 		return errors.New("accounts-allow-custom-css is not enabled for this instance")
@@ -243,6 +286,61 @@ func SiteTerms(t string) error {
 	return nil
 }
 
+// SitePrivacyPolicy ensures that the given site privacy policy is within spec.
+func SitePrivacyPolicy(p string) error {
+	if length := len([]rune(p)); length > maximumPrivacyPolicyLength {
+		return fmt.Errorf("privacy policy should be no more than %d chars but given privacy policy was %d", maximumPrivacyPolicyLength, length)
+	}
+
+	return nil
+}
+
+// LandingPageBlocks ensures that the given landing page
+// blocks are all recognized, and that none are repeated.
+func LandingPageBlocks(blocks []string) error {
+	seen := make(map[string]struct{}, len(blocks))
+	for _, block := range blocks {
+		switch block {
+		case gtsmodel.LandingPageBlockShortDescription,
+			gtsmodel.LandingPageBlockAbout,
+			gtsmodel.LandingPageBlockContact,
+			gtsmodel.LandingPageBlockRegister,
+			gtsmodel.LandingPageBlockFeaturedAccounts,
+			gtsmodel.LandingPageBlockWhatIsThis,
+			gtsmodel.LandingPageBlockApps:
+		default:
+			return fmt.Errorf(
+				"landing page block '%s' was not recognized, valid options are '%s', '%s', '%s', '%s', '%s', '%s', '%s'",
+				block,
+				gtsmodel.LandingPageBlockShortDescription,
+				gtsmodel.LandingPageBlockAbout,
+				gtsmodel.LandingPageBlockContact,
+				gtsmodel.LandingPageBlockRegister,
+				gtsmodel.LandingPageBlockFeaturedAccounts,
+				gtsmodel.LandingPageBlockWhatIsThis,
+				gtsmodel.LandingPageBlockApps,
+			)
+		}
+
+		if _, ok := seen[block]; ok {
+			return fmt.Errorf("landing page block '%s' was given more than once", block)
+		}
+		seen[block] = struct{}{}
+	}
+
+	return nil
+}
+
+// FeaturedAccounts ensures that no more than
+// the maximum number of accounts are featured.
+func FeaturedAccounts(usernames []string) error {
+	if length := len(usernames); length > maximumFeaturedAccounts {
+		return fmt.Errorf("no more than %d accounts can be featured but %d were given", maximumFeaturedAccounts, length)
+	}
+
+	return nil
+}
+
 // ULID returns an error if the passed string is not a valid ULID.
 // The name param is used to form error messages.
 func ULID(i string, name string) error {
@@ -292,6 +390,19 @@ func ListTitle(title string) error {
 	return nil
 }
 
+// ModerationNote validates the content of a new moderation note.
+func ModerationNote(content string) error {
+	if content == "" {
+		return fmt.Errorf("moderation note content must be provided, and must be no more than %d chars", maximumModerationNoteLength)
+	}
+
+	if length := len([]rune(content)); length > maximumModerationNoteLength {
+		return fmt.Errorf("moderation note content length must be no more than %d chars, provided content was %d chars", maximumModerationNoteLength, length)
+	}
+
+	return nil
+}
+
 // ListRepliesPolicy validates the replies_policy of a new or updated list.
 func ListRepliesPolicy(repliesPolicy gtsmodel.RepliesPolicy) error {
 	switch repliesPolicy {
@@ -394,7 +505,12 @@ func CreateAccount(form *apimodel.AccountCreateRequest) error {
 		return errors.New("form was nil")
 	}
 
-	if !config.GetAccountsRegistrationOpen() {
+	// An invite code may allow signing up even
+	// when registration is closed; whether the
+	// code is actually valid is checked later.
+	invited := form.InviteCode != ""
+	if !config.GetAccountsRegistrationOpen() &&
+		!(invited && config.GetAccountsInvitesBypassClosedRegistration()) {
 		return errors.New("registration is not open for this server")
 	}
 
@@ -420,5 +536,10 @@ func CreateAccount(form *apimodel.AccountCreateRequest) error {
 	}
 	form.Locale = locale
 
-	return SignUpReason(form.Reason, config.GetAccountsReasonRequired())
+	// No reason is needed for invited sign-ups
+	// that won't be reviewed by the admin(s).
+	reasonRequired := config.GetAccountsReasonRequired() &&
+		!(invited && config.GetAccountsInvitesBypassApproval())
+
+	return SignUpReason(form.Reason, reasonRequired)
 }
@@ -381,6 +381,38 @@ func (suite *ValidationTestSuite) TestValidateEmojiShortcode() {
 	}
 }
 
+func (suite *ValidationTestSuite) TestValidateLandingPageBlocks() {
+	type testStruct struct {
+		blocks []string
+		ok     bool
+	}
+
+	for _, test := range []testStruct{
+		{
+			blocks: nil,
+			ok:     true,
+		},
+		{
+			blocks: []string{"featured_accounts", "about", "contact"},
+			ok:     true,
+		},
+		{
+			blocks: []string{"about", "peepee"},
+			ok:     false,
+		},
+		{
+			blocks: []string{"apps", "register", "apps"},
+			ok:     false,
+		},
+	} {
+		err := validate.LandingPageBlocks(test.blocks)
+		ok := err == nil
+		if !suite.Equal(test.ok, ok) {
+			suite.T().Logf("fail on %v: %v", test.blocks, err)
+		}
+	}
+}
+
 func TestValidationTestSuite(t *testing.T) {
 	suite.Run(t, new(ValidationTestSuite))
 }
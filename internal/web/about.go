@@ -28,7 +28,9 @@ import (
 )
 
 const (
-	aboutPath = "/about"
+	aboutPath        = "/about"
+	aboutPrivacyPath = aboutPath + "/privacy"
+	aboutTermsPath   = aboutPath + "/terms"
 )
 
 func (m *Module) aboutGETHandler(c *gin.Context) {
@@ -54,7 +56,7 @@ func (m *Module) aboutGETHandler(c *gin.Context) {
 		Template:    "about.tmpl",
 		Instance:    instance,
 		OGMeta:      apiutil.OGBase(instance),
-		Stylesheets: []string{cssAbout},
+		Stylesheets: withTheme([]string{cssAbout}, ""),
 		Extra: map[string]any{
 			"showStrap":        true,
 			"blocklistExposed": config.GetInstanceExposeSuspendedWeb(),
@@ -64,3 +66,67 @@ func (m *Module) aboutGETHandler(c *gin.Context) {
 
 	apiutil.TemplateWebPage(c, page)
 }
+
+func (m *Module) aboutPrivacyGETHandler(c *gin.Context) {
+	m.aboutDocumentGETHandler(c,
+		"Privacy Policy",
+		"No privacy policy has yet been set for this instance.",
+		func(instance *apimodel.InstanceV1) string {
+			return instance.PrivacyPolicy
+		},
+	)
+}
+
+func (m *Module) aboutTermsGETHandler(c *gin.Context) {
+	m.aboutDocumentGETHandler(c,
+		"Terms and Conditions",
+		"No terms and conditions have yet been set for this instance.",
+		func(instance *apimodel.InstanceV1) string {
+			return instance.Terms
+		},
+	)
+}
+
+// aboutDocumentGETHandler serves a page showing one of
+// the admin-written instance documents (eg., the privacy
+// policy), as selected from the instance by getContent,
+// or the given empty text if the admin hasn't set it.
+func (m *Module) aboutDocumentGETHandler(
+	c *gin.Context,
+	title string,
+	empty string,
+	getContent func(*apimodel.InstanceV1) string,
+) {
+	instance, errWithCode := m.processor.InstanceGetV1(c.Request.Context())
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	// Return instance we already got from the db,
+	// don't try to fetch it again when erroring.
+	instanceGet := func(ctx context.Context) (*apimodel.InstanceV1, gtserror.WithCode) {
+		return instance, nil
+	}
+
+	// We only serve text/html at this endpoint.
+	if _, err := apiutil.NegotiateAccept(c, apiutil.TextHTML); err != nil {
+		apiutil.WebErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), instanceGet)
+		return
+	}
+
+	page := apiutil.WebPage{
+		Template:    "about_document.tmpl",
+		Instance:    instance,
+		OGMeta:      apiutil.OGBase(instance),
+		Stylesheets: withTheme([]string{cssAbout}, ""),
+		Extra: map[string]any{
+			"showStrap": true,
+			"title":     title,
+			"content":   getContent(instance),
+			"empty":     empty,
+		},
+	}
+
+	apiutil.TemplateWebPage(c, page)
+}
@@ -0,0 +1,111 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package web
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+// embedSandbox is an extra content security policy
+// for embeddable status pages, which sandboxes them
+// without scripts, only allowing links to be opened
+// in new tabs outside of the sandbox.
+const embedSandbox = "sandbox allow-popups allow-popups-to-escape-sandbox"
+
+func (m *Module) embedGETHandler(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	// We'll need the instance later, and we can also use it
+	// before then to make it easier to return a web error.
+	instance, errWithCode := m.processor.InstanceGetV1(ctx)
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	// Return instance we already got from the db,
+	// don't try to fetch it again when erroring.
+	instanceGet := func(ctx context.Context) (*apimodel.InstanceV1, gtserror.WithCode) {
+		return instance, nil
+	}
+
+	// We only serve text/html at this endpoint.
+	if _, err := apiutil.NegotiateAccept(c, apiutil.TextHTML); err != nil {
+		apiutil.WebErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), instanceGet)
+		return
+	}
+
+	targetStatusID, errWithCode := apiutil.ParseWebStatusID(c.Param(apiutil.WebStatusIDKey))
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+		return
+	}
+
+	// StatusIDs on our instance
+	// are (currently) always ULIDs.
+	targetStatusID = strings.ToUpper(targetStatusID)
+
+	// Get the status itself, checking that it's
+	// public and can be embedded in other sites.
+	status, errWithCode := m.processor.Status().EmbedGet(ctx, targetStatusID)
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+		return
+	}
+
+	// Prepare stylesheets for embed.
+	stylesheets := []string{
+		cssFA,
+		cssStatus,
+		cssEmbed,
+	}
+
+	// User-selected theme if set,
+	// else instance default theme.
+	stylesheets = withTheme(stylesheets, status.Account.Theme)
+
+	// Custom CSS for this user last in cascade.
+	stylesheets = append(
+		stylesheets,
+		"/@"+status.Account.Username+"/custom.css",
+	)
+
+	// Sandbox the page in addition to the
+	// usual content security policy, which
+	// is kept as browsers enforce both.
+	c.Writer.Header().Add("Content-Security-Policy", embedSandbox)
+
+	page := apiutil.WebPage{
+		Template:    "embed.tmpl",
+		Instance:    instance,
+		OGMeta:      apiutil.OGBase(instance).WithStatus(status),
+		Stylesheets: stylesheets,
+		Extra: map[string]any{
+			"embed":  true,
+			"status": status,
+		},
+	}
+
+	apiutil.TemplateWebPage(c, page)
+}
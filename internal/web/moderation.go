@@ -0,0 +1,371 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package web
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	"github.com/superseriousbusiness/gotosocial/internal/router"
+)
+
+const (
+	moderationPathPrefix  = "/moderation"
+	moderationSignInPath  = moderationPathPrefix + "/sign_in"
+	moderationReportsPath = moderationPathPrefix + "/reports"
+
+	// sessionModeratorID is the session key
+	// under which the ID of the signed-in
+	// moderator user is stored.
+	sessionModeratorID = "moderator_userid"
+
+	// moderationSessionMaxAge is the max age
+	// in seconds of a moderation session. This
+	// is refreshed on each authed request.
+	moderationSessionMaxAge = 1800
+)
+
+// RouteModeration attaches the server-rendered moderation
+// pages to the given router, using the given session
+// middleware for session auth. The session middleware
+// should be the same one used for 'auth' and 'oauth'.
+func (m *Module) RouteModeration(r *router.Router, session gin.HandlerFunc, mi ...gin.HandlerFunc) {
+	moderationGroup := r.AttachGroup(moderationPathPrefix)
+	moderationGroup.Use(mi...)
+	moderationGroup.Use(session, middleware.CacheControl(middleware.CacheControlConfig{
+		Directives: []string{"no-store"},
+	}))
+
+	moderationGroup.Handle(http.MethodGet, "", func(c *gin.Context) { c.Redirect(http.StatusSeeOther, moderationReportsPath) })
+	moderationGroup.Handle(http.MethodGet, "/sign_in", m.moderationSignInGETHandler)
+	moderationGroup.Handle(http.MethodPost, "/sign_in", m.moderationSignInPOSTHandler)
+	moderationGroup.Handle(http.MethodPost, "/sign_out", m.moderationSignOutPOSTHandler)
+	moderationGroup.Handle(http.MethodGet, "/reports", m.moderationReportsGETHandler)
+	moderationGroup.Handle(http.MethodGet, "/reports/:"+apiutil.IDKey, m.moderationReportGETHandler)
+	moderationGroup.Handle(http.MethodPost, "/reports/:"+apiutil.IDKey+"/resolve", m.moderationReportResolvePOSTHandler)
+}
+
+// moderationSessionOptions returns the
+// standard session options, with a max
+// age suitable for moderation sessions.
+func moderationSessionOptions() sessions.Options {
+	opts := middleware.SessionOptions()
+	opts.MaxAge = moderationSessionMaxAge
+	return opts
+}
+
+// prepareModerationPage fetches the instance and negotiates
+// accept headers for a moderation page. Returns false if
+// an error was already written to the response.
+func (m *Module) prepareModerationPage(c *gin.Context) (
+	*apimodel.InstanceV1,
+	func(context.Context) (*apimodel.InstanceV1, gtserror.WithCode),
+	bool,
+) {
+	instance, errWithCode := m.processor.InstanceGetV1(c.Request.Context())
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return nil, nil, false
+	}
+
+	// Return instance we already got from the db,
+	// don't try to fetch it again when erroring.
+	instanceGet := func(ctx context.Context) (*apimodel.InstanceV1, gtserror.WithCode) {
+		return instance, nil
+	}
+
+	// We only serve text/html at these endpoints.
+	if _, err := apiutil.NegotiateAccept(c, apiutil.TextHTML); err != nil {
+		apiutil.WebErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), instanceGet)
+		return nil, nil, false
+	}
+
+	return instance, instanceGet, true
+}
+
+// moderatorAuthed returns the admin user signed in to
+// the current moderation session, refreshing the session.
+//
+// If there's no valid session, the request will be
+// redirected to the moderation sign in page, and false
+// will be returned.
+func (m *Module) moderatorAuthed(
+	c *gin.Context,
+	instanceGet func(context.Context) (*apimodel.InstanceV1, gtserror.WithCode),
+) (*gtsmodel.User, bool) {
+	s := sessions.Default(c)
+
+	userID, ok := s.Get(sessionModeratorID).(string)
+	if !ok || userID == "" {
+		c.Redirect(http.StatusSeeOther, moderationSignInPath)
+		return nil, false
+	}
+
+	user, err := m.getUser(c.Request.Context(), userID)
+	if err != nil || !moderatorAllowed(user) {
+		// User gone, or no longer
+		// allowed; end the session.
+		s.Delete(sessionModeratorID)
+		_ = s.Save()
+		c.Redirect(http.StatusSeeOther, moderationSignInPath)
+		return nil, false
+	}
+
+	// Refresh the session so it
+	// doesn't expire while in use.
+	s.Options(moderationSessionOptions())
+	if err := s.Save(); err != nil {
+		err := gtserror.Newf("error saving session: %w", err)
+		apiutil.WebErrorHandler(c, gtserror.NewErrorInternalError(err), instanceGet)
+		return nil, false
+	}
+
+	return user, true
+}
+
+// moderatorAllowed returns whether the given
+// user is permitted to use the moderation pages.
+func moderatorAllowed(user *gtsmodel.User) bool {
+	return *user.Admin &&
+		!*user.Disabled &&
+		*user.Approved &&
+		!user.ConfirmedAt.IsZero() &&
+		user.Account != nil &&
+		!user.Account.IsSuspended()
+}
+
+func (m *Module) moderationSignInGETHandler(c *gin.Context) {
+	instance, _, ok := m.prepareModerationPage(c)
+	if !ok {
+		return
+	}
+
+	page := apiutil.WebPage{
+		Template: "sign-in.tmpl",
+		Instance: instance,
+		Extra: map[string]any{
+			"signInAction": moderationSignInPath,
+		},
+	}
+
+	apiutil.TemplateWebPage(c, page)
+}
+
+func (m *Module) moderationSignInPOSTHandler(c *gin.Context) {
+	_, instanceGet, ok := m.prepareModerationPage(c)
+	if !ok {
+		return
+	}
+
+	if config.GetOIDCEnabled() {
+		const text = "signing in to the moderation pages with a password is not possible when OIDC is enabled; use the settings panel instead"
+		apiutil.WebErrorHandler(c, gtserror.NewErrorForbidden(errors.New(text), text), instanceGet)
+		return
+	}
+
+	// Reuse the sign in form
+	// from the auth module.
+	form := &struct {
+		Email    string `form:"username"`
+		Password string `form:"password"`
+	}{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.WebErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), instanceGet)
+		return
+	}
+
+	user, errWithCode := m.processor.User().PasswordCheck(
+		c.Request.Context(),
+		form.Email,
+		form.Password,
+	)
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+		return
+	}
+
+	// Refetch the user with account
+	// populated to check permissions.
+	user, err := m.getUser(c.Request.Context(), user.ID)
+	if err != nil {
+		err := gtserror.Newf("db error getting user: %w", err)
+		apiutil.WebErrorHandler(c, gtserror.NewErrorInternalError(err), instanceGet)
+		return
+	}
+
+	if !moderatorAllowed(user) {
+		const text = "only admins can use the moderation pages"
+		apiutil.WebErrorHandler(c, gtserror.NewErrorForbidden(errors.New(text), text), instanceGet)
+		return
+	}
+
+	s := sessions.Default(c)
+	s.Set(sessionModeratorID, user.ID)
+	s.Options(moderationSessionOptions())
+	if err := s.Save(); err != nil {
+		err := gtserror.Newf("error saving session: %w", err)
+		apiutil.WebErrorHandler(c, gtserror.NewErrorInternalError(err), instanceGet)
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, moderationReportsPath)
+}
+
+func (m *Module) moderationSignOutPOSTHandler(c *gin.Context) {
+	s := sessions.Default(c)
+	s.Delete(sessionModeratorID)
+	_ = s.Save()
+	c.Redirect(http.StatusSeeOther, moderationSignInPath)
+}
+
+func (m *Module) moderationReportsGETHandler(c *gin.Context) {
+	instance, instanceGet, ok := m.prepareModerationPage(c)
+	if !ok {
+		return
+	}
+
+	user, ok := m.moderatorAuthed(c, instanceGet)
+	if !ok {
+		return
+	}
+
+	// Show unresolved reports by
+	// default, or resolved if asked.
+	resolved, errWithCode := apiutil.ParseAdminResolved(c.Query(apiutil.AdminResolvedKey), false)
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+		return
+	}
+
+	const limit = 20
+	resp, errWithCode := m.processor.Admin().ReportsGet(
+		c.Request.Context(),
+		user.Account,
+		&resolved,
+		"",
+		"",
+		c.Query(apiutil.MaxIDKey),
+		"",
+		"",
+		limit,
+	)
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+		return
+	}
+
+	// Link to the next page
+	// if there might be one.
+	var nextMaxID string
+	if l := len(resp.Items); l == limit {
+		nextMaxID = resp.Items[l-1].(*apimodel.AdminReport).ID
+	}
+
+	page := apiutil.WebPage{
+		Template:    "moderation-reports.tmpl",
+		Instance:    instance,
+		Stylesheets: []string{cssFA},
+		Extra: map[string]any{
+			"reports":   resp.Items,
+			"resolved":  resolved,
+			"nextMaxID": nextMaxID,
+		},
+	}
+
+	apiutil.TemplateWebPage(c, page)
+}
+
+func (m *Module) moderationReportGETHandler(c *gin.Context) {
+	instance, instanceGet, ok := m.prepareModerationPage(c)
+	if !ok {
+		return
+	}
+
+	user, ok := m.moderatorAuthed(c, instanceGet)
+	if !ok {
+		return
+	}
+
+	reportID, errWithCode := apiutil.ParseID(c.Param(apiutil.IDKey))
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+		return
+	}
+
+	report, errWithCode := m.processor.Admin().ReportGet(c.Request.Context(), user.Account, reportID)
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+		return
+	}
+
+	page := apiutil.WebPage{
+		Template:    "moderation-report.tmpl",
+		Instance:    instance,
+		Stylesheets: []string{cssFA},
+		Extra: map[string]any{
+			"report": report,
+		},
+	}
+
+	apiutil.TemplateWebPage(c, page)
+}
+
+func (m *Module) moderationReportResolvePOSTHandler(c *gin.Context) {
+	_, instanceGet, ok := m.prepareModerationPage(c)
+	if !ok {
+		return
+	}
+
+	user, ok := m.moderatorAuthed(c, instanceGet)
+	if !ok {
+		return
+	}
+
+	reportID, errWithCode := apiutil.ParseID(c.Param(apiutil.IDKey))
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+		return
+	}
+
+	form := &apimodel.AdminReportResolveRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.WebErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), instanceGet)
+		return
+	}
+
+	if _, errWithCode := m.processor.Admin().ReportResolve(
+		c.Request.Context(),
+		user.Account,
+		reportID,
+		form.ActionTakenComment,
+	); errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, moderationReportsPath+"/"+reportID)
+}
@@ -0,0 +1,256 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package web
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-contrib/sessions/memstore"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ModerationTestSuite struct {
+	suite.Suite
+	db          db.DB
+	state       state.State
+	module      *Module
+	engine      *gin.Engine
+	testUsers   map[string]*gtsmodel.User
+	testReports map[string]*gtsmodel.Report
+}
+
+func (suite *ModerationTestSuite) SetupSuite() {
+	suite.testUsers = testrig.NewTestUsers()
+	suite.testReports = testrig.NewTestReports()
+}
+
+func (suite *ModerationTestSuite) SetupTest() {
+	suite.state.Caches.Init()
+
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+
+	suite.db = testrig.NewTestDB(&suite.state)
+	suite.state.DB = suite.db
+	suite.state.Storage = testrig.NewInMemoryStorage()
+
+	mediaManager := testrig.NewTestMediaManager(&suite.state)
+	federator := testrig.NewTestFederator(&suite.state, testrig.NewTestTransportController(&suite.state, testrig.NewMockHTTPClient(nil, "../../testrig/media")), mediaManager)
+	emailSender := testrig.NewEmailSender("../../web/template/", nil)
+	processor := testrig.NewTestProcessor(&suite.state, federator, emailSender, mediaManager)
+	suite.module = New(suite.db, processor)
+
+	// Serve the moderation handlers from one engine
+	// with one session store, so that session cookies
+	// carry over between requests like in a browser.
+	_, suite.engine = testrig.CreateGinTestContext(httptest.NewRecorder(), nil)
+	testrig.ConfigureTemplatesWithGin(suite.engine, "../../web/template")
+	store := memstore.NewStore(make([]byte, 32), make([]byte, 32))
+	store.Options(middleware.SessionOptions())
+	suite.engine.Use(sessions.Sessions("gotosocial-localhost", store))
+	suite.engine.POST(moderationSignInPath, suite.module.moderationSignInPOSTHandler)
+	suite.engine.GET(moderationReportsPath, suite.module.moderationReportsGETHandler)
+	suite.engine.POST(moderationReportsPath+"/:id/resolve", suite.module.moderationReportResolvePOSTHandler)
+
+	testrig.StandardDBSetup(suite.db, nil)
+	testrig.StartNoopWorkers(&suite.state)
+}
+
+func (suite *ModerationTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+	testrig.StopWorkers(&suite.state)
+}
+
+// request serves a request to the moderation pages with the
+// given form and session cookies, returning the response.
+func (suite *ModerationTestSuite) request(method string, path string, form url.Values, cookies []*http.Cookie) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+
+	req := httptest.NewRequest(method, "http://localhost:8080"+path, strings.NewReader(form.Encode()))
+	req.Header.Set("Accept", "text/html")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+
+	suite.engine.ServeHTTP(recorder, req)
+	return recorder
+}
+
+// signIn posts the moderation sign in
+// form with the given credentials.
+func (suite *ModerationTestSuite) signIn(email string, password string) *httptest.ResponseRecorder {
+	return suite.request(http.MethodPost, moderationSignInPath, url.Values{
+		"username": {email},
+		"password": {password},
+	}, nil)
+}
+
+// updateAdmin applies the given change to
+// the admin user and their account in the db.
+func (suite *ModerationTestSuite) updateAdmin(change func(*gtsmodel.User)) {
+	ctx := context.Background()
+
+	user, err := suite.db.GetUserByID(ctx, suite.testUsers["admin_account"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	change(user)
+
+	if err := suite.db.UpdateUser(ctx, user); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.db.UpdateAccount(ctx, user.Account); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *ModerationTestSuite) TestSignInWrongPassword() {
+	recorder := suite.signIn("admin@example.org", "not the password")
+	suite.Equal(http.StatusUnauthorized, recorder.Code)
+	suite.Empty(recorder.Result().Cookies())
+}
+
+func (suite *ModerationTestSuite) TestSignInNotAdmin() {
+	recorder := suite.signIn("zork@example.org", "password")
+	suite.Equal(http.StatusForbidden, recorder.Code)
+	suite.Contains(recorder.Body.String(), "only admins can use the moderation pages")
+	suite.Empty(recorder.Result().Cookies())
+}
+
+func (suite *ModerationTestSuite) TestSignInAdminNotAllowed() {
+	for name, change := range map[string]func(*gtsmodel.User){
+		"disabled": func(user *gtsmodel.User) {
+			user.Disabled = util.Ptr(true)
+		},
+		"unconfirmed": func(user *gtsmodel.User) {
+			user.ConfirmedAt = time.Time{}
+		},
+		"suspended": func(user *gtsmodel.User) {
+			user.Account.SuspendedAt = time.Now()
+		},
+	} {
+		suite.Run(name, func() {
+			suite.updateAdmin(change)
+			defer suite.updateAdmin(func(user *gtsmodel.User) {
+				user.Disabled = util.Ptr(false)
+				user.ConfirmedAt = suite.testUsers["admin_account"].ConfirmedAt
+				user.Account.SuspendedAt = time.Time{}
+			})
+
+			recorder := suite.signIn("admin@example.org", "password")
+			suite.Equal(http.StatusForbidden, recorder.Code)
+			suite.Contains(recorder.Body.String(), "only admins can use the moderation pages")
+			suite.Empty(recorder.Result().Cookies())
+		})
+	}
+}
+
+func (suite *ModerationTestSuite) TestSignInOIDCEnabled() {
+	config.SetOIDCEnabled(true)
+	defer config.SetOIDCEnabled(false)
+
+	recorder := suite.signIn("admin@example.org", "password")
+	suite.Equal(http.StatusForbidden, recorder.Code)
+	suite.Contains(recorder.Body.String(), "not possible when OIDC is enabled")
+	suite.Empty(recorder.Result().Cookies())
+}
+
+func (suite *ModerationTestSuite) TestSignInAdmin() {
+	recorder := suite.signIn("admin@example.org", "password")
+	suite.Equal(http.StatusSeeOther, recorder.Code)
+	suite.Equal(moderationReportsPath, recorder.Header().Get("Location"))
+
+	cookies := recorder.Result().Cookies()
+	suite.NotEmpty(cookies)
+
+	// The session should get us in to the reports page.
+	recorder = suite.request(http.MethodGet, moderationReportsPath, nil, cookies)
+	suite.Equal(http.StatusOK, recorder.Code)
+}
+
+func (suite *ModerationTestSuite) TestSessionEndedWhenNotAllowed() {
+	recorder := suite.signIn("admin@example.org", "password")
+	suite.Equal(http.StatusSeeOther, recorder.Code)
+	cookies := recorder.Result().Cookies()
+
+	// Admin gets disabled after signing in.
+	suite.updateAdmin(func(user *gtsmodel.User) {
+		user.Disabled = util.Ptr(true)
+	})
+
+	// The existing session should be
+	// bounced back to the sign in page.
+	recorder = suite.request(http.MethodGet, moderationReportsPath, nil, cookies)
+	suite.Equal(http.StatusSeeOther, recorder.Code)
+	suite.Equal(moderationSignInPath, recorder.Header().Get("Location"))
+
+	// Reenable the admin; the session should
+	// have been ended, so still no entry.
+	suite.updateAdmin(func(user *gtsmodel.User) {
+		user.Disabled = util.Ptr(false)
+	})
+
+	recorder = suite.request(http.MethodGet, moderationReportsPath, nil, cookies)
+	suite.Equal(http.StatusSeeOther, recorder.Code)
+	suite.Equal(moderationSignInPath, recorder.Header().Get("Location"))
+}
+
+func (suite *ModerationTestSuite) TestResolveReport() {
+	recorder := suite.signIn("admin@example.org", "password")
+	suite.Equal(http.StatusSeeOther, recorder.Code)
+	cookies := recorder.Result().Cookies()
+
+	report := suite.testReports["local_account_2_report_remote_account_1"]
+	recorder = suite.request(
+		http.MethodPost,
+		moderationReportsPath+"/"+report.ID+"/resolve",
+		url.Values{"action_taken_comment": {"sorted it out"}},
+		cookies,
+	)
+	suite.Equal(http.StatusSeeOther, recorder.Code)
+	suite.Equal(moderationReportsPath+"/"+report.ID, recorder.Header().Get("Location"))
+
+	dbReport, err := suite.db.GetReportByID(context.Background(), report.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(dbReport.ActionTakenAt.IsZero())
+	suite.Equal("sorted it out", dbReport.ActionTaken)
+	suite.Equal(suite.testUsers["admin_account"].AccountID, dbReport.ActionTakenByAccountID)
+}
+
+func TestModerationTestSuite(t *testing.T) {
+	suite.Run(t, new(ModerationTestSuite))
+}
@@ -102,16 +102,21 @@ func (m *Module) profileGETHandler(c *gin.Context) {
 		return
 	}
 
-	// Only generate RSS link if account has RSS enabled.
+	// Only generate RSS link if account has RSS enabled,
+	// and isn't hiding statuses from logged-out viewers.
 	var rssFeed string
-	if targetAccount.EnableRSS {
+	if targetAccount.EnableRSS && !targetAccount.HideStatusesLoggedOut {
 		rssFeed = "/@" + targetAccount.Username + "/feed.rss"
 	}
 
 	// Only allow search engines / robots to
-	// index if account is discoverable.
+	// index if account is discoverable, and
+	// hasn't explicitly opted out of indexing.
 	var robotsMeta string
-	if targetAccount.Discoverable {
+	switch {
+	case targetAccount.NoIndex:
+		robotsMeta = robotsMetaNoIndex
+	case targetAccount.Discoverable:
 		robotsMeta = robotsMetaAllowSome
 	}
 
@@ -154,13 +159,9 @@ func (m *Module) profileGETHandler(c *gin.Context) {
 		}...,
 	)
 
-	// User-selected theme if set.
-	if theme := targetAccount.Theme; theme != "" {
-		stylesheets = append(
-			stylesheets,
-			themesPathPrefix+"/"+theme,
-		)
-	}
+	// User-selected theme if set,
+	// else instance default theme.
+	stylesheets = withTheme(stylesheets, targetAccount.Theme)
 
 	// Custom CSS for this user last in cascade.
 	stylesheets = append(
@@ -19,13 +19,17 @@ package web
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
 
 const (
 	robotsPath          = "/robots.txt"
 	robotsMetaAllowSome = "nofollow, noarchive, nositelinkssearchbox, max-image-preview:standard" // https://developers.google.com/search/docs/crawling-indexing/robots-meta-tag#robotsmeta
+	robotsMetaNoIndex   = "noindex"                                                               // For accounts that have opted out of indexing.
 	robotsTxt           = `# GoToSocial robots.txt -- to edit, see internal/web/robots.go
 # More info @ https://developers.google.com/search/docs/crawling-indexing/robots/intro
 
@@ -98,14 +102,44 @@ Disallow: /user
 Disallow: /settings/
 
 # Domain blocklist.
-Disallow: /about/suspended`
+Disallow: /about/suspended
+
+# Public timelines.
+Disallow: /public
+Disallow: /local`
 )
 
 // robotsGETHandler returns a decent robots.txt that prevents crawling
-// the api, auth pages, settings pages, etc.
+// the api, auth pages, settings pages, etc., and the profiles of
+// accounts that have opted out of search engine indexing.
 //
 // More granular robots meta tags are then applied for web pages
-// depending on user preferences (see internal/web).
+// depending on user preferences (see internal/web), and statuses
+// marked indexable are listed in the sitemap linked from here.
 func (m *Module) robotsGETHandler(c *gin.Context) {
-	c.String(http.StatusOK, robotsTxt)
+	var b strings.Builder
+	b.WriteString(robotsTxt)
+
+	usernames, err := m.getNoIndex(c.Request.Context())
+	if err != nil {
+		// Not fatal, the pages of these accounts
+		// still have a noindex robots meta tag.
+		log.Errorf(c.Request.Context(), "db error getting noindex accounts: %v", err)
+	}
+
+	if len(usernames) != 0 {
+		b.WriteString("\n\n# Accounts opted out of indexing.")
+		for _, username := range usernames {
+			// Match the profile exactly, and anything
+			// under it, but not other accounts whose
+			// usernames start with this username.
+			b.WriteString("\nDisallow: /@" + username + "$")
+			b.WriteString("\nDisallow: /@" + username + "/")
+		}
+	}
+
+	sitemapURL := config.GetProtocol() + "://" + config.GetHost() + sitemapPath
+	b.WriteString("\n\nSitemap: " + sitemapURL)
+
+	c.String(http.StatusOK, b.String())
 }
@@ -20,6 +20,8 @@ package web
 import (
 	"bytes"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -49,10 +51,26 @@ func (m *Module) rssFeedGETHandler(c *gin.Context) {
 	// todo: https://github.com/superseriousbusiness/gotosocial/issues/1813
 	username = strings.ToLower(username)
 
+	// Replies are left out unless asked for,
+	// as on the web view of the account.
+	excludeReplies, errWithCode := apiutil.ParseWebExcludeReplies(c.Query(apiutil.WebExcludeRepliesKey), true)
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	// Optional hashtag to filter by.
+	tag := c.Query(apiutil.WebTagKey)
+
 	// Retrieve the getRSSFeed function from the processor.
 	// We'll only call the function if we need to, to save db calls.
 	// lastPostAt may be a zero time if account has never posted.
-	getRSSFeed, lastPostAt, errWithCode := m.processor.Account().GetRSSFeedForUsername(c.Request.Context(), username)
+	getRSSFeed, lastPostAt, errWithCode := m.processor.Account().GetRSSFeedForUsername(
+		c.Request.Context(),
+		username,
+		excludeReplies,
+		tag,
+	)
 	if errWithCode != nil {
 		apiutil.WebErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
@@ -61,7 +79,7 @@ func (m *Module) rssFeedGETHandler(c *gin.Context) {
 	var (
 		rssFeed string // Stringified rss feed.
 
-		cacheKey              = c.Request.URL.Path
+		cacheKey              = rssCacheKey(c.Request.URL.Path, excludeReplies, tag)
 		cacheEntry, wasCached = m.eTagCache.Get(cacheKey)
 	)
 
@@ -160,6 +178,15 @@ func (m *Module) rssFeedGETHandler(c *gin.Context) {
 	c.Data(http.StatusOK, appRSSUTF8, []byte(rssFeed))
 }
 
+// rssCacheKey returns the ETag cache key for the feed at
+// given path with given options, normalized so arbitrary
+// query parameters can't be used to fill up the cache.
+func rssCacheKey(path string, excludeReplies bool, tag string) string {
+	return path + "?" + apiutil.WebExcludeRepliesKey + "=" +
+		strconv.FormatBool(excludeReplies) + "&" +
+		apiutil.WebTagKey + "=" + url.QueryEscape(strings.ToLower(tag))
+}
+
 // unixAfter returns true if the unix value of t1
 // is greater than (ie., after) the unix value of t2.
 func unixAfter(t1 time.Time, t2 time.Time) bool {
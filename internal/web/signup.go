@@ -21,12 +21,14 @@ import (
 	"context"
 	"errors"
 	"net"
+	"net/http"
 
 	"github.com/gin-gonic/gin"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/superseriousbusiness/gotosocial/internal/validate"
 )
 
@@ -53,16 +55,13 @@ func (m *Module) signupGETHandler(c *gin.Context) {
 		return
 	}
 
-	page := apiutil.WebPage{
-		Template: "sign-up.tmpl",
-		Instance: instance,
-		OGMeta:   apiutil.OGBase(instance),
-		Extra: map[string]any{
-			"reasonRequired": config.GetAccountsReasonRequired(),
-		},
+	// Prefill invite code
+	// from the link, if any.
+	form := &apimodel.AccountCreateRequest{
+		InviteCode: c.Query("invite_code"),
 	}
 
-	apiutil.TemplateWebPage(c, page)
+	templateSignup(c, instance, http.StatusOK, form, "", nil)
 }
 
 func (m *Module) signupPOSTHandler(c *gin.Context) {
@@ -88,14 +87,37 @@ func (m *Module) signupPOSTHandler(c *gin.Context) {
 		return
 	}
 
-	form := &apimodel.AccountCreateRequest{}
-	if err := c.ShouldBind(form); err != nil {
-		apiutil.WebErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), instanceGet)
+	// Read the form by hand rather than binding it,
+	// so that missing fields are reported inline with
+	// the rest of the form errors, and not as binding
+	// errors on a separate error page.
+	form := &apimodel.AccountCreateRequest{
+		Reason:     c.PostForm("reason"),
+		Username:   c.PostForm("username"),
+		Email:      c.PostForm("email"),
+		Password:   c.PostForm("password"),
+		Agreement:  c.PostForm("agreement") == "true",
+		Locale:     c.PostForm("locale"),
+		InviteCode: c.PostForm("invite_code"),
+	}
+
+	// Don't bother checking the
+	// form if no-one can sign up.
+	if open, _, _ := signupMode(form.InviteCode); !open {
+		const text = "registration is not open for this server"
+		templateSignup(c, instance, http.StatusForbidden, form, text, nil)
+		return
+	}
+
+	if fieldErrs := signupFieldErrors(form); len(fieldErrs) != 0 {
+		templateSignup(c, instance, http.StatusBadRequest, form, "", fieldErrs)
 		return
 	}
 
+	// Check the form as a whole, which
+	// also normalizes the given locale.
 	if err := validate.CreateAccount(form); err != nil {
-		apiutil.WebErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), instanceGet)
+		templateSignup(c, instance, http.StatusBadRequest, form, err.Error(), nil)
 		return
 	}
 
@@ -118,7 +140,16 @@ func (m *Module) signupPOSTHandler(c *gin.Context) {
 		form,
 	)
 	if errWithCode != nil {
-		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+		if errWithCode.Code() >= http.StatusInternalServerError {
+			// Not something the
+			// user can fix, bail.
+			apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+			return
+		}
+
+		// Eg., username already taken, sign-up
+		// limits reached, or an invalid invite.
+		templateSignup(c, instance, errWithCode.Code(), form, errWithCode.Safe(), nil)
 		return
 	}
 
@@ -131,6 +162,107 @@ func (m *Module) signupPOSTHandler(c *gin.Context) {
 		Extra: map[string]any{
 			"email":    user.UnconfirmedEmail,
 			"username": user.Account.Username,
+			"approved": util.PtrValueOr(user.Approved, false),
+		},
+	}
+
+	apiutil.TemplateWebPage(c, page)
+}
+
+// signupFieldErrors checks the fields of the given
+// sign-up form one by one, returning a map of field
+// names to their error, so that each error can be
+// shown next to the field it applies to.
+func signupFieldErrors(form *apimodel.AccountCreateRequest) map[string]string {
+	errs := make(map[string]string)
+
+	if err := validate.Username(form.Username); err != nil {
+		errs["username"] = err.Error()
+	}
+
+	if err := validate.Email(form.Email); err != nil {
+		errs["email"] = err.Error()
+	}
+
+	if err := validate.Password(form.Password); err != nil {
+		errs["password"] = err.Error()
+	}
+
+	_, inviteRequired, reasonRequired := signupMode(form.InviteCode)
+	if inviteRequired && form.InviteCode == "" {
+		errs["invite_code"] = "an invite code is required to sign up"
+	}
+
+	if err := validate.SignUpReason(form.Reason, reasonRequired); err != nil {
+		errs["reason"] = err.Error()
+	}
+
+	if !form.Agreement {
+		errs["agreement"] = "you must accept the terms and conditions to sign up"
+	}
+
+	return errs
+}
+
+// signupMode returns whether signing up is possible at
+// all, whether an invite code is needed to sign up, and
+// whether a reason is needed for the admin(s) to review
+// the sign-up, given the instance config and invite code.
+func signupMode(inviteCode string) (open bool, inviteRequired bool, reasonRequired bool) {
+	invited := inviteCode != ""
+
+	switch {
+	case config.GetAccountsRegistrationOpen():
+		open = true
+
+	case config.GetAccountsInvitesBypassClosedRegistration():
+		// Closed, but can sign up by invite. Admins
+		// can always create invites, so this doesn't
+		// depend on whether users can create them.
+		open = true
+		inviteRequired = true
+	}
+
+	// No reason is needed for invited sign-ups
+	// that won't be reviewed by the admin(s).
+	reasonRequired = config.GetAccountsReasonRequired() &&
+		!(invited && config.GetAccountsInvitesBypassApproval())
+
+	return
+}
+
+// templateSignup renders the sign-up form with the given
+// code, prefilled with the values of the given form (except
+// for the password), and with any errors shown inline.
+func templateSignup(
+	c *gin.Context,
+	instance *apimodel.InstanceV1,
+	code int,
+	form *apimodel.AccountCreateRequest,
+	formErr string,
+	fieldErrs map[string]string,
+) {
+	open, inviteRequired, reasonRequired := signupMode(form.InviteCode)
+
+	// Sign-ups are always reviewed by the admin(s),
+	// unless invited sign-ups may bypass approval.
+	approvalRequired := !(form.InviteCode != "" &&
+		config.GetAccountsInvitesBypassApproval())
+
+	page := apiutil.WebPage{
+		Template: "sign-up.tmpl",
+		Instance: instance,
+		OGMeta:   apiutil.OGBase(instance),
+		Code:     code,
+		Extra: map[string]any{
+			"open":             open,
+			"reasonRequired":   reasonRequired,
+			"approvalRequired": approvalRequired,
+			"invitesEnabled":   config.GetAccountsInvitesEnabled(),
+			"inviteRequired":   inviteRequired,
+			"form":             form,
+			"error":            formErr,
+			"errors":           fieldErrs,
 		},
 	}
 
@@ -0,0 +1,141 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package web
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type SignupTestSuite struct {
+	suite.Suite
+	db        db.DB
+	state     state.State
+	module    *Module
+	testUsers map[string]*gtsmodel.User
+}
+
+func (suite *SignupTestSuite) SetupSuite() {
+	suite.testUsers = testrig.NewTestUsers()
+}
+
+func (suite *SignupTestSuite) SetupTest() {
+	suite.state.Caches.Init()
+
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+
+	suite.db = testrig.NewTestDB(&suite.state)
+	suite.state.DB = suite.db
+	suite.state.Storage = testrig.NewInMemoryStorage()
+
+	mediaManager := testrig.NewTestMediaManager(&suite.state)
+	federator := testrig.NewTestFederator(&suite.state, testrig.NewTestTransportController(&suite.state, testrig.NewMockHTTPClient(nil, "../../testrig/media")), mediaManager)
+	emailSender := testrig.NewEmailSender("../../web/template/", nil)
+	processor := testrig.NewTestProcessor(&suite.state, federator, emailSender, mediaManager)
+	suite.module = New(suite.db, processor)
+
+	testrig.StandardDBSetup(suite.db, nil)
+	testrig.StartNoopWorkers(&suite.state)
+}
+
+func (suite *SignupTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+	testrig.StopWorkers(&suite.state)
+}
+
+// signup posts the sign-up form with the
+// given invite code, returning the response.
+func (suite *SignupTestSuite) signup(inviteCode string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	ctx, engine := testrig.CreateGinTestContext(recorder, nil)
+	testrig.ConfigureTemplatesWithGin(engine, "../../web/template")
+
+	form := url.Values{
+		"username":    {"invited_user"},
+		"email":       {"invited_user@example.org"},
+		"password":    {"very-Strong-password-123!"},
+		"reason":      {"i was invited by the admin, who said i should join"},
+		"agreement":   {"true"},
+		"locale":      {"en-us"},
+		"invite_code": {inviteCode},
+	}
+
+	ctx.Request = httptest.NewRequest(http.MethodPost, "http://localhost:8080/signup", strings.NewReader(form.Encode()))
+	ctx.Request.Header.Set("Accept", "text/html")
+	ctx.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	ctx.Request.RemoteAddr = "192.0.2.1:1234"
+
+	suite.module.signupPOSTHandler(ctx)
+	return recorder
+}
+
+func (suite *SignupTestSuite) TestSignupAdminInviteInvitesDisabled() {
+	// Registration is closed, and users can't create
+	// invites, but admins can still invite people, and
+	// invites bypass closed registration by default.
+	config.SetAccountsRegistrationOpen(false)
+	config.SetAccountsInvitesEnabled(false)
+
+	// Without an invite, the form should ask for one.
+	recorder := suite.signup("")
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+	suite.Contains(recorder.Body.String(), "an invite code is required to sign up")
+
+	invite, errWithCode := suite.module.processor.User().InviteCreate(
+		context.Background(),
+		suite.testUsers["admin_account"],
+		&apimodel.InviteCreateRequest{MaxUses: 1},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// With the admin's invite, signing up should work.
+	recorder = suite.signup(invite.Code)
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.NotContains(recorder.Body.String(), "registration is not open for this server")
+	suite.NotContains(recorder.Body.String(), "form-error")
+
+	account, err := suite.db.GetAccountByUsernameDomain(context.Background(), "invited_user", "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	user, err := suite.db.GetUserByAccountID(context.Background(), account.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(invite.ID, user.InviteID)
+}
+
+func TestSignupTestSuite(t *testing.T) {
+	suite.Run(t, new(SignupTestSuite))
+}
@@ -19,6 +19,8 @@ package web
 
 import (
 	"context"
+	"path"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
@@ -43,24 +45,42 @@ func (m *Module) tagGETHandler(c *gin.Context) {
 		return instance, nil
 	}
 
+	// Tag name with a feed extension means
+	// a feed is requested rather than html.
+	tagParam := c.Param(apiutil.TagNameKey)
+	if ext := path.Ext(tagParam); ext == ".rss" || ext == ".atom" {
+		m.tagFeedGETHandler(c, strings.TrimSuffix(tagParam, ext), ext, instanceGet)
+		return
+	}
+
 	// We only serve text/html at this endpoint.
 	if _, err := apiutil.NegotiateAccept(c, apiutil.TextHTML); err != nil {
 		apiutil.WebErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), instanceGet)
 		return
 	}
 
-	tagName, errWithCode := apiutil.ParseTagName(c.Param(apiutil.TagNameKey))
+	tagName, errWithCode := apiutil.ParseTagName(tagParam)
 	if errWithCode != nil {
 		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
 		return
 	}
 
+	// Link the hashtag's feed for
+	// autodiscovery, if it's exposed.
+	var rssFeed string
+	if m.processor.Timeline().TagFeedExposed(strings.ToLower(tagName)) {
+		rssFeed = "/tags/" + strings.ToLower(tagName) + ".rss"
+	}
+
 	page := apiutil.WebPage{
 		Template:    "tag.tmpl",
 		Instance:    instance,
 		OGMeta:      apiutil.OGBase(instance),
-		Stylesheets: []string{cssFA, cssThread, cssTag},
-		Extra:       map[string]any{"tagName": tagName},
+		Stylesheets: withTheme([]string{cssFA, cssThread, cssTag}, ""),
+		Extra: map[string]any{
+			"tagName": tagName,
+			"rssFeed": rssFeed,
+		},
 	}
 
 	apiutil.TemplateWebPage(c, page)
@@ -0,0 +1,97 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package web
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+const appAtomUTF8 = string(apiutil.AppAtomXML) + "; charset=utf-8"
+
+// tagFeedGETHandler serves the RSS (ext ".rss")
+// or Atom (ext ".atom") feed of the given hashtag.
+func (m *Module) tagFeedGETHandler(
+	c *gin.Context,
+	tagName string,
+	ext string,
+	instanceGet func(ctx context.Context) (*apimodel.InstanceV1, gtserror.WithCode),
+) {
+	contentType, mediaType := appRSSUTF8, apiutil.AppRSSXML
+	if ext == ".atom" {
+		contentType, mediaType = appAtomUTF8, apiutil.AppAtomXML
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, mediaType); err != nil {
+		apiutil.WebErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), instanceGet)
+		return
+	}
+
+	tagName, errWithCode := apiutil.ParseTagName(tagName)
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+		return
+	}
+
+	feed, errWithCode := m.processor.Timeline().TagFeedGet(c.Request.Context(), tagName)
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+		return
+	}
+
+	var (
+		body string
+		err  error
+	)
+
+	if ext == ".atom" {
+		body, err = feed.ToAtom()
+	} else {
+		body, err = feed.ToRss()
+	}
+
+	if err != nil {
+		err := gtserror.Newf("error converting feed to %s string: %w", ext, err)
+		apiutil.WebErrorHandler(c, gtserror.NewErrorInternalError(err), instanceGet)
+		return
+	}
+
+	eTag, err := generateEtag(bytes.NewBufferString(body))
+	if err != nil {
+		apiutil.WebErrorHandler(c, gtserror.NewErrorInternalError(err), instanceGet)
+		return
+	}
+
+	// See rssFeedGETHandler.
+	c.Header(eTagHeader, eTag)
+	c.Header(lastModifiedHeader, feed.Updated.UTC().Format(http.TimeFormat))
+	c.Header(cacheControlHeader, cacheControlNoCache)
+
+	if c.Request.Header.Get(ifNoneMatchHeader) == eTag {
+		c.AbortWithStatus(http.StatusNotModified)
+		return
+	}
+
+	c.Data(http.StatusOK, contentType, []byte(body))
+}
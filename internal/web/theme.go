@@ -0,0 +1,37 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package web
+
+import "github.com/superseriousbusiness/gotosocial/internal/config"
+
+// withTheme appends the stylesheet of the given theme
+// to stylesheets, falling back to the instance default
+// theme if theme is not set (eg., an account has not
+// selected a theme, or the page isn't for an account).
+func withTheme(stylesheets []string, theme string) []string {
+	if theme == "" {
+		theme = config.GetInstanceDefaultTheme()
+	}
+
+	if theme == "" {
+		// No theme.
+		return stylesheets
+	}
+
+	return append(stylesheets, themesPathPrefix+"/"+theme)
+}
@@ -23,6 +23,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -32,6 +33,10 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 )
 
+// threadRepliesPageSize is the number of
+// replies to show per page of a web thread.
+const threadRepliesPageSize = 40
+
 func (m *Module) threadGETHandler(c *gin.Context) {
 	ctx := c.Request.Context()
 
@@ -131,13 +136,50 @@ func (m *Module) threadGETHandler(c *gin.Context) {
 		return
 	}
 
-	// Fill in the rest of the thread context.
-	context, errWithCode := m.processor.Status().WebContextGet(ctx, targetStatusID)
+	// Fill in the rest of the thread context,
+	// paging replies after the given reply ID.
+	after := c.Query(apiutil.WebRepliesAfterKey)
+	context, errWithCode := m.processor.Status().WebContextGet(
+		ctx,
+		targetStatusID,
+		after,
+		threadRepliesPageSize,
+	)
 	if errWithCode != nil {
 		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
 		return
 	}
 
+	// Prepare links to previous
+	// and next pages of replies.
+	var repliesPrev, repliesNext string
+
+	if context.HasPrev {
+		repliesPrev = repliesPageURL(status.URL, context.PrevAfter)
+	}
+
+	if context.NextAfter != "" {
+		repliesNext = repliesPageURL(status.URL, context.NextAfter)
+	}
+
+	// Link the author's RSS feed for autodiscovery, if
+	// they have it enabled, as on their profile page.
+	var rssFeed string
+	if targetAccount.EnableRSS && !targetAccount.HideStatusesLoggedOut {
+		rssFeed = "/@" + targetAccount.Username + "/feed.rss"
+	}
+
+	// Only allow search engines / robots to index
+	// if status is indexable, and its author hasn't
+	// explicitly opted out of indexing.
+	var robotsMeta string
+	switch {
+	case targetAccount.NoIndex:
+		robotsMeta = robotsMetaNoIndex
+	case status.Indexable:
+		robotsMeta = robotsMetaAllowSome
+	}
+
 	// Prepare stylesheets for thread.
 	stylesheets := make([]string, 0, 5)
 
@@ -151,13 +193,9 @@ func (m *Module) threadGETHandler(c *gin.Context) {
 		}...,
 	)
 
-	// User-selected theme if set.
-	if theme := targetAccount.Theme; theme != "" {
-		stylesheets = append(
-			stylesheets,
-			themesPathPrefix+"/"+theme,
-		)
-	}
+	// User-selected theme if set,
+	// else instance default theme.
+	stylesheets = withTheme(stylesheets, targetAccount.Theme)
 
 	// Custom CSS for this user last in cascade.
 	stylesheets = append(
@@ -172,14 +210,27 @@ func (m *Module) threadGETHandler(c *gin.Context) {
 		Stylesheets: stylesheets,
 		Javascript:  []string{jsFrontend},
 		Extra: map[string]any{
-			"status":  status,
-			"context": context,
+			"status":       status,
+			"context":      context,
+			"replies_prev": repliesPrev,
+			"replies_next": repliesNext,
+			"robotsMeta":   robotsMeta,
+			"rssFeed":      rssFeed,
 		},
 	}
 
 	apiutil.TemplateWebPage(c, page)
 }
 
+// repliesPageURL returns the URL of the page of replies
+// to the status at statusURL, starting after given ID.
+func repliesPageURL(statusURL string, after string) string {
+	if after == "" {
+		return statusURL
+	}
+	return statusURL + "?" + apiutil.WebRepliesAfterKey + "=" + url.QueryEscape(after)
+}
+
 // returnAPStatus returns an ActivityPub representation of target status,
 // created by targetUsername. It will do http signature authentication.
 func (m *Module) returnAPStatus(
@@ -0,0 +1,104 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package web
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+const (
+	publicTimelinePath = "/public"
+	localTimelinePath  = "/local"
+)
+
+func (m *Module) publicTimelineGETHandler(c *gin.Context) {
+	m.timelineGETHandler(c, false)
+}
+
+func (m *Module) localTimelineGETHandler(c *gin.Context) {
+	m.timelineGETHandler(c, true)
+}
+
+// timelineGETHandler renders the federated public timeline,
+// or the local timeline if local is true, as a web page.
+func (m *Module) timelineGETHandler(c *gin.Context, local bool) {
+	ctx := c.Request.Context()
+
+	instance, errWithCode := m.processor.InstanceGetV1(ctx)
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	// Return instance we already got from the db,
+	// don't try to fetch it again when erroring.
+	instanceGet := func(ctx context.Context) (*apimodel.InstanceV1, gtserror.WithCode) {
+		return instance, nil
+	}
+
+	// We only serve text/html at this endpoint.
+	if _, err := apiutil.NegotiateAccept(c, apiutil.TextHTML); err != nil {
+		apiutil.WebErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), instanceGet)
+		return
+	}
+
+	if !config.GetInstanceExposePublicTimelineWeb() {
+		err := errors.New("this instance does not expose its public timelines via the web")
+		apiutil.WebErrorHandler(c, gtserror.NewErrorNotFound(err), instanceGet)
+		return
+	}
+
+	// We need to change our response slightly if
+	// the visitor is paging through the timeline.
+	maxStatusID := apiutil.ParseMaxID(c.Query(apiutil.MaxIDKey), "")
+	paging := maxStatusID != ""
+
+	statusResp, errWithCode := m.processor.Timeline().WebPublicTimelineGet(ctx, maxStatusID, local)
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+		return
+	}
+
+	page := apiutil.WebPage{
+		Template: "timeline.tmpl",
+		Instance: instance,
+		OGMeta:   apiutil.OGBase(instance),
+		Stylesheets: withTheme([]string{
+			cssFA,
+			cssStatus,
+			cssThread,
+			cssTimeline,
+		}, ""),
+		Javascript: []string{jsFrontend},
+		Extra: map[string]any{
+			"local":            local,
+			"statuses":         statusResp.Items,
+			"statuses_next":    statusResp.NextLink,
+			"show_back_to_top": paging,
+		},
+	}
+
+	apiutil.TemplateWebPage(c, page)
+}
@@ -28,6 +28,7 @@ import (
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/middleware"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
@@ -50,6 +51,7 @@ const (
 	userPanelPath      = settingsPathPrefix + "/user"
 	adminPanelPath     = settingsPathPrefix + "/admin"
 	signupPath         = "/signup"
+	embedPath          = "/" + uris.EmbedPath + "/" + uris.StatusesPath + "/:" + apiutil.WebStatusIDKey
 
 	cacheControlHeader    = "Cache-Control"     // https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Cache-Control
 	cacheControlNoCache   = "no-cache"          // https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Cache-Control#response_directives
@@ -65,7 +67,9 @@ const (
 	cssThread   = distPathPrefix + "/thread.css"
 	cssProfile  = distPathPrefix + "/profile.css"
 	cssSettings = distPathPrefix + "/settings-style.css"
+	cssEmbed    = distPathPrefix + "/embed.css"
 	cssTag      = distPathPrefix + "/tag.css"
+	cssTimeline = distPathPrefix + "/timeline.css"
 
 	jsFrontend = distPathPrefix + "/frontend.js" // Progressive enhancement frontend JS.
 	jsSettings = distPathPrefix + "/settings.js" // Settings panel React application.
@@ -75,6 +79,8 @@ type Module struct {
 	processor    *processing.Processor
 	eTagCache    cache.Cache[string, eTagCacheEntry]
 	isURIBlocked func(context.Context, *url.URL) (bool, error)
+	getUser      func(context.Context, string) (*gtsmodel.User, error)
+	getNoIndex   func(context.Context) ([]string, error)
 }
 
 func New(db db.DB, processor *processing.Processor) *Module {
@@ -82,6 +88,8 @@ func New(db db.DB, processor *processing.Processor) *Module {
 		processor:    processor,
 		eTagCache:    newETagCache(),
 		isURIBlocked: db.IsURIBlocked,
+		getUser:      db.GetUserByID,
+		getNoIndex:   db.GetNoIndexAccountUsernames,
 	}
 }
 
@@ -118,11 +126,17 @@ func (m *Module) Route(r *router.Router, mi ...gin.HandlerFunc) {
 	r.AttachHandler(http.MethodGet, confirmEmailPath, m.confirmEmailGETHandler)
 	r.AttachHandler(http.MethodPost, confirmEmailPath, m.confirmEmailPOSTHandler)
 	r.AttachHandler(http.MethodGet, robotsPath, m.robotsGETHandler)
+	r.AttachHandler(http.MethodGet, sitemapPath, m.sitemapGETHandler)
 	r.AttachHandler(http.MethodGet, aboutPath, m.aboutGETHandler)
+	r.AttachHandler(http.MethodGet, aboutPrivacyPath, m.aboutPrivacyGETHandler)
+	r.AttachHandler(http.MethodGet, aboutTermsPath, m.aboutTermsGETHandler)
 	r.AttachHandler(http.MethodGet, domainBlockListPath, m.domainBlockListGETHandler)
 	r.AttachHandler(http.MethodGet, tagsPath, m.tagGETHandler)
+	r.AttachHandler(http.MethodGet, publicTimelinePath, m.publicTimelineGETHandler)
+	r.AttachHandler(http.MethodGet, localTimelinePath, m.localTimelineGETHandler)
 	r.AttachHandler(http.MethodGet, signupPath, m.signupGETHandler)
 	r.AttachHandler(http.MethodPost, signupPath, m.signupPOSTHandler)
+	r.AttachHandler(http.MethodGet, embedPath, m.embedGETHandler)
 
 	// Attach redirects from old endpoints to current ones for backwards compatibility
 	r.AttachHandler(http.MethodGet, "/auth/edit", func(c *gin.Context) { c.Redirect(http.StatusMovedPermanently, userPanelPath) })
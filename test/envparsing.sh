@@ -7,17 +7,34 @@ EXPECT=$(cat << "EOF"
     "account-domain": "peepee",
     "accounts-allow-custom-css": true,
     "accounts-custom-css-length": 5000,
+    "accounts-default-noindex": true,
+    "accounts-disposable-email-list-url": "https://example.org/disposable.txt",
+    "accounts-disposable-email-mode": "reject",
+    "accounts-invites-bypass-approval": true,
+    "accounts-invites-bypass-closed-registration": false,
+    "accounts-invites-enabled": true,
     "accounts-reason-required": false,
+    "accounts-registration-email-domain-daily-limit": 10,
+    "accounts-registration-ip-daily-limit": 3,
     "accounts-registration-open": true,
+    "accounts-remote-refresh-days": 14,
+    "accounts-remote-refresh-per-domain": 3,
+    "advanced-conversion-report": true,
     "advanced-cookies-samesite": "strict",
     "advanced-csp-extra-uris": [],
     "advanced-header-filter-mode": "",
+    "advanced-load-shedding-latency": 5000000000,
+    "advanced-load-shedding-queue-depth": 42,
     "advanced-rate-limit-exceptions": [
         "192.0.2.0/24",
         "127.0.0.1/32"
     ],
     "advanced-rate-limit-requests": 6969,
+    "advanced-rate-limit-unauthenticated-requests": 420,
     "advanced-sender-multiplier": -1,
+    "advanced-streaming-max-connections": 420,
+    "advanced-streaming-max-connections-per-account": 5,
+    "advanced-streaming-queue-size": 69,
     "advanced-throttling-multiplier": -1,
     "advanced-throttling-retry-after": 10000000000,
     "application-name": "gts",
@@ -43,6 +60,7 @@ EXPECT=$(cat << "EOF"
         "follow-request-mem-ratio": 2,
         "in-reply-to-ids-mem-ratio": 3,
         "instance-mem-ratio": 1,
+        "invalidation-bus": "",
         "list-entry-mem-ratio": 2,
         "list-mem-ratio": 1,
         "marker-mem-ratio": 0.5,
@@ -70,12 +88,14 @@ EXPECT=$(cat << "EOF"
         "visibility-mem-ratio": 2,
         "webfinger-mem-ratio": 0.1
     },
+    "category": "",
     "config-path": "internal/config/testdata/test.yaml",
     "db-address": ":memory:",
     "db-database": "gotosocial_prod",
     "db-max-open-conns-multiplier": 3,
     "db-password": "hunter2",
     "db-port": 6969,
+    "db-replica-dsns": [],
     "db-sqlite-busy-timeout": 1000000000,
     "db-sqlite-cache-size": 0,
     "db-sqlite-journal-mode": "DELETE",
@@ -84,22 +104,37 @@ EXPECT=$(cat << "EOF"
     "db-tls-mode": "disable",
     "db-type": "sqlite",
     "db-user": "sex-haver",
+    "domain": "",
     "dry-run": true,
     "email": "",
     "host": "example.com",
     "http-client": {
         "allow-ips": [],
         "block-ips": [],
+        "disable-http2": false,
+        "idle-conn-timeout": 90000000000,
+        "max-idle-conns-per-host": 0,
         "timeout": 10000000000,
         "tls-insecure-skip-verify": false
     },
+    "instance-default-theme": "soft.css",
     "instance-deliver-to-shared-inboxes": false,
+    "instance-domain-permission-drafts-require-second-admin": true,
     "instance-expose-peers": true,
+    "instance-expose-public-api": true,
     "instance-expose-public-timeline": true,
+    "instance-expose-public-timeline-web": true,
     "instance-expose-suspended": true,
     "instance-expose-suspended-web": true,
+    "instance-expose-tag-feeds": [
+        "gardening",
+        "welcome"
+    ],
+    "instance-federation-dereference-budget": 250,
     "instance-federation-mode": "allowlist",
+    "instance-federation-quarantine-mismatched-statuses": true,
     "instance-federation-spam-filter": true,
+    "instance-federation-spam-filter-mode": "log",
     "instance-inject-mastodon-version": true,
     "instance-languages": [
         "nl",
@@ -115,14 +150,27 @@ EXPECT=$(cat << "EOF"
     "log-db-queries": true,
     "log-level": "info",
     "log-timestamp-format": "banana",
+    "media-clamav-action": "quarantine",
+    "media-clamav-address": "/run/clamav/clamd.ctl",
+    "media-clamav-timeout": 10000000000,
     "media-cleanup-every": 86400000000000,
     "media-cleanup-from": "00:00",
     "media-description-max-chars": 5000,
     "media-description-min-chars": 69,
     "media-emoji-local-max-size": 420,
     "media-emoji-remote-max-size": 420,
+    "media-encode-concurrency": 2,
+    "media-ffmpeg-path": "/usr/bin/ffmpeg",
+    "media-gif-convert-min-size": 2097152,
+    "media-image-max-resolution": 2048,
     "media-image-max-size": 420,
+    "media-image-quality": 80,
     "media-remote-cache-days": 30,
+    "media-remote-strip-metadata": false,
+    "media-resumable-upload-expiry": 3600000000000,
+    "media-resumable-upload-max-per-account": 2,
+    "media-resumable-upload-min-part-size": 2097152,
+    "media-resumable-upload-min-size": 10485760,
     "media-video-max-size": 420,
     "metrics-auth-enabled": false,
     "metrics-auth-password": "",
@@ -145,12 +193,23 @@ EXPECT=$(cat << "EOF"
         "write"
     ],
     "oidc-skip-verification": true,
+    "on-conflict": "",
     "password": "",
     "path": "",
     "port": 6969,
+    "private-comment": "",
+    "profile": "",
     "protocol": "http",
     "remote-only": false,
     "request-id-header": "X-Trace-Id",
+    "retention-dry-run": true,
+    "retention-local-status-days": 365,
+    "retention-notification-days": 90,
+    "retention-notification-max-per-account": 500,
+    "retention-read-notification-days": 30,
+    "retention-remote-status-archive": true,
+    "retention-remote-status-days": 180,
+    "retention-unconfirmed-user-days": 14,
     "smtp-disclose-recipients": true,
     "smtp-from": "queen.rip.in.piss@terfisland.org",
     "smtp-host": "example.com",
@@ -162,10 +221,19 @@ EXPECT=$(cat << "EOF"
     "statuses-media-max-files": 1,
     "statuses-poll-max-options": 1,
     "statuses-poll-option-max-chars": 50,
+    "storage-azure-account-key": "c2VjcmV0",
+    "storage-azure-account-name": "gtsstorage",
+    "storage-azure-container": "gts",
+    "storage-azure-endpoint": "http://localhost:10000/gtsstorage",
+    "storage-azure-proxy": true,
     "storage-backend": "local",
+    "storage-gcs-bucket": "gts",
+    "storage-gcs-credentials-file": "/root/gcs.json",
+    "storage-gcs-proxy": true,
     "storage-local-base-path": "/root/store",
     "storage-s3-access-key": "minio",
     "storage-s3-bucket": "gts",
+    "storage-s3-bucket-public": true,
     "storage-s3-endpoint": "localhost:9000",
     "storage-s3-proxy": true,
     "storage-s3-secret-key": "miniostorage",
@@ -185,6 +253,9 @@ EXPECT=$(cat << "EOF"
     ],
     "username": "",
     "web-asset-base-dir": "/root",
+    "web-error-page-contact-url": "https://example.org/support",
+    "web-error-page-error-message": "Something went wrong, sorry!",
+    "web-error-page-not-found-message": "Nothing to see here.",
     "web-template-base-dir": "/root"
 }
 EOF
@@ -219,26 +290,68 @@ GTS_TLS_MODE='' \
 GTS_DB_TLS_CA_CERT='' \
 GTS_WEB_TEMPLATE_BASE_DIR='/root' \
 GTS_WEB_ASSET_BASE_DIR='/root' \
+GTS_WEB_ERROR_PAGE_NOT_FOUND_MESSAGE='Nothing to see here.' \
+GTS_WEB_ERROR_PAGE_ERROR_MESSAGE='Something went wrong, sorry!' \
+GTS_WEB_ERROR_PAGE_CONTACT_URL='https://example.org/support' \
 GTS_INSTANCE_EXPOSE_PEERS=true \
 GTS_INSTANCE_EXPOSE_SUSPENDED=true \
 GTS_INSTANCE_EXPOSE_SUSPENDED_WEB=true \
 GTS_INSTANCE_EXPOSE_PUBLIC_TIMELINE=true \
+GTS_INSTANCE_EXPOSE_PUBLIC_TIMELINE_WEB=true \
+GTS_INSTANCE_EXPOSE_PUBLIC_API=true \
+GTS_INSTANCE_EXPOSE_TAG_FEEDS='gardening,welcome' \
+GTS_INSTANCE_DEFAULT_THEME='soft.css' \
+GTS_INSTANCE_FEDERATION_DEREFERENCE_BUDGET=250 \
 GTS_INSTANCE_FEDERATION_MODE='allowlist' \
+GTS_INSTANCE_FEDERATION_QUARANTINE_MISMATCHED_STATUSES=true \
 GTS_INSTANCE_FEDERATION_SPAM_FILTER=true \
+GTS_INSTANCE_FEDERATION_SPAM_FILTER_MODE='log' \
 GTS_INSTANCE_DELIVER_TO_SHARED_INBOXES=false \
+GTS_INSTANCE_DOMAIN_PERMISSION_DRAFTS_REQUIRE_SECOND_ADMIN=true \
 GTS_INSTANCE_INJECT_MASTODON_VERSION=true \
 GTS_INSTANCE_LANGUAGES="nl,en-gb" \
 GTS_ACCOUNTS_ALLOW_CUSTOM_CSS=true \
 GTS_ACCOUNTS_CUSTOM_CSS_LENGTH=5000 \
+GTS_ACCOUNTS_DEFAULT_NOINDEX=true \
 GTS_ACCOUNTS_REGISTRATION_OPEN=true \
 GTS_ACCOUNTS_REASON_REQUIRED=false \
+GTS_ACCOUNTS_REGISTRATION_IP_DAILY_LIMIT=3 \
+GTS_ACCOUNTS_REGISTRATION_EMAIL_DOMAIN_DAILY_LIMIT=10 \
+GTS_ACCOUNTS_DISPOSABLE_EMAIL_MODE=reject \
+GTS_ACCOUNTS_DISPOSABLE_EMAIL_LIST_URL=https://example.org/disposable.txt \
+GTS_ACCOUNTS_INVITES_ENABLED=true \
+GTS_ACCOUNTS_INVITES_BYPASS_APPROVAL=true \
+GTS_ACCOUNTS_INVITES_BYPASS_CLOSED_REGISTRATION=false \
+GTS_ACCOUNTS_REMOTE_REFRESH_DAYS=14 \
+GTS_ACCOUNTS_REMOTE_REFRESH_PER_DOMAIN=3 \
 GTS_MEDIA_IMAGE_MAX_SIZE=420 \
 GTS_MEDIA_VIDEO_MAX_SIZE=420 \
+GTS_MEDIA_IMAGE_QUALITY=80 \
+GTS_MEDIA_IMAGE_MAX_RESOLUTION=2048 \
 GTS_MEDIA_DESCRIPTION_MIN_CHARS=69 \
 GTS_MEDIA_DESCRIPTION_MAX_CHARS=5000 \
 GTS_MEDIA_REMOTE_CACHE_DAYS=30 \
+GTS_MEDIA_REMOTE_STRIP_METADATA=false \
 GTS_MEDIA_EMOJI_LOCAL_MAX_SIZE=420 \
 GTS_MEDIA_EMOJI_REMOTE_MAX_SIZE=420 \
+GTS_MEDIA_CLAMAV_ADDRESS='/run/clamav/clamd.ctl' \
+GTS_MEDIA_CLAMAV_ACTION='quarantine' \
+GTS_MEDIA_CLAMAV_TIMEOUT='10s' \
+GTS_MEDIA_FFMPEG_PATH='/usr/bin/ffmpeg' \
+GTS_MEDIA_GIF_CONVERT_MIN_SIZE=2097152 \
+GTS_MEDIA_RESUMABLE_UPLOAD_MIN_SIZE=10485760 \
+GTS_MEDIA_RESUMABLE_UPLOAD_MIN_PART_SIZE=2097152 \
+GTS_MEDIA_RESUMABLE_UPLOAD_EXPIRY='1h' \
+GTS_MEDIA_RESUMABLE_UPLOAD_MAX_PER_ACCOUNT=2 \
+GTS_MEDIA_ENCODE_CONCURRENCY=2 \
+GTS_RETENTION_LOCAL_STATUS_DAYS=365 \
+GTS_RETENTION_NOTIFICATION_DAYS=90 \
+GTS_RETENTION_READ_NOTIFICATION_DAYS=30 \
+GTS_RETENTION_NOTIFICATION_MAX_PER_ACCOUNT=500 \
+GTS_RETENTION_REMOTE_STATUS_DAYS=180 \
+GTS_RETENTION_REMOTE_STATUS_ARCHIVE=true \
+GTS_RETENTION_UNCONFIRMED_USER_DAYS=14 \
+GTS_RETENTION_DRY_RUN=true \
 GTS_METRICS_AUTH_ENABLED=false \
 GTS_METRICS_ENABLED=false \
 GTS_STORAGE_BACKEND='local' \
@@ -249,6 +362,15 @@ GTS_STORAGE_S3_ENDPOINT='localhost:9000' \
 GTS_STORAGE_S3_USE_SSL='false' \
 GTS_STORAGE_S3_PROXY='true' \
 GTS_STORAGE_S3_BUCKET='gts' \
+GTS_STORAGE_S3_BUCKET_PUBLIC='true' \
+GTS_STORAGE_GCS_BUCKET='gts' \
+GTS_STORAGE_GCS_CREDENTIALS_FILE='/root/gcs.json' \
+GTS_STORAGE_GCS_PROXY='true' \
+GTS_STORAGE_AZURE_ACCOUNT_NAME='gtsstorage' \
+GTS_STORAGE_AZURE_ACCOUNT_KEY='c2VjcmV0' \
+GTS_STORAGE_AZURE_CONTAINER='gts' \
+GTS_STORAGE_AZURE_ENDPOINT='http://localhost:10000/gtsstorage' \
+GTS_STORAGE_AZURE_PROXY='true' \
 GTS_STATUSES_MAX_CHARS=69 \
 GTS_STATUSES_CW_MAX_CHARS=420 \
 GTS_STATUSES_POLL_MAX_OPTIONS=1 \
@@ -279,10 +401,17 @@ GTS_SYSLOG_PROTOCOL='udp' \
 GTS_SYSLOG_ADDRESS='127.0.0.1:6969' \
 GTS_TRACING_ENDPOINT='localhost:4317' \
 GTS_TRACING_INSECURE_TRANSPORT=true \
+GTS_ADVANCED_CONVERSION_REPORT=true \
 GTS_ADVANCED_COOKIES_SAMESITE='strict' \
+GTS_ADVANCED_LOAD_SHEDDING_LATENCY='5s' \
+GTS_ADVANCED_LOAD_SHEDDING_QUEUE_DEPTH=42 \
 GTS_ADVANCED_RATE_LIMIT_EXCEPTIONS="192.0.2.0/24,127.0.0.1/32" \
 GTS_ADVANCED_RATE_LIMIT_REQUESTS=6969 \
+GTS_ADVANCED_RATE_LIMIT_UNAUTHENTICATED_REQUESTS=420 \
 GTS_ADVANCED_SENDER_MULTIPLIER=-1 \
+GTS_ADVANCED_STREAMING_MAX_CONNECTIONS=420 \
+GTS_ADVANCED_STREAMING_MAX_CONNECTIONS_PER_ACCOUNT=5 \
+GTS_ADVANCED_STREAMING_QUEUE_SIZE=69 \
 GTS_ADVANCED_THROTTLING_MULTIPLIER=-1 \
 GTS_ADVANCED_THROTTLING_RETRY_AFTER='10s' \
 GTS_REQUEST_ID_HEADER='X-Trace-Id' \
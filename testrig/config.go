@@ -66,12 +66,19 @@ func testDefaults() config.Configuration {
 		WebTemplateBaseDir: "./web/template/",
 		WebAssetBaseDir:    "./web/assets/",
 
-		InstanceFederationMode:         config.InstanceFederationModeDefault,
-		InstanceFederationSpamFilter:   true,
-		InstanceExposePeers:            true,
-		InstanceExposeSuspended:        true,
-		InstanceExposeSuspendedWeb:     true,
-		InstanceDeliverToSharedInboxes: true,
+		InstanceFederationMode:                           config.InstanceFederationModeDefault,
+		InstanceFederationSpamFilter:                     true,
+		InstanceFederationSpamFilterMode:                 "",
+		InstanceFederationQuarantineMismatchedStatuses:   false,
+		InstanceFederationDereferenceBudget:              1000,
+		InstanceDomainPermissionDraftsRequireSecondAdmin: false,
+		InstanceExposePeers:                              true,
+		InstanceExposeSuspended:                          true,
+		InstanceExposeSuspendedWeb:                       true,
+		InstanceExposePublicTimelineWeb:                  true,
+		InstanceExposeTagFeeds:                           []string{"welcome"},
+		InstanceDefaultTheme:                             "",
+		InstanceDeliverToSharedInboxes:                   true,
 		InstanceLanguages: language.Languages{
 			{
 				TagStr: "nl",
@@ -81,20 +88,43 @@ func testDefaults() config.Configuration {
 			},
 		},
 
-		AccountsRegistrationOpen: true,
-		AccountsReasonRequired:   true,
-		AccountsAllowCustomCSS:   true,
-		AccountsCustomCSSLength:  10000,
-
-		MediaImageMaxSize:        10485760, // 10MiB
-		MediaVideoMaxSize:        41943040, // 40MiB
-		MediaDescriptionMinChars: 0,
-		MediaDescriptionMaxChars: 500,
-		MediaRemoteCacheDays:     7,
-		MediaEmojiLocalMaxSize:   51200,          // 50KiB
-		MediaEmojiRemoteMaxSize:  102400,         // 100KiB
-		MediaCleanupFrom:         "00:00",        // midnight.
-		MediaCleanupEvery:        24 * time.Hour, // 1/day.
+		AccountsRegistrationOpen:                  true,
+		AccountsReasonRequired:                    true,
+		AccountsRegistrationIPDailyLimit:          0,
+		AccountsRegistrationEmailDomainDailyLimit: 0,
+		AccountsDisposableEmailMode:               config.DisposableEmailModeFlag,
+		AccountsDisposableEmailListURL:            "",
+		AccountsInvitesEnabled:                    false,
+		AccountsInvitesBypassApproval:             false,
+		AccountsInvitesBypassClosedRegistration:   true,
+		AccountsAllowCustomCSS:                    true,
+		AccountsCustomCSSLength:                   10000,
+		AccountsDefaultNoIndex:                    false,
+		AccountsRemoteRefreshDays:                 30,
+		AccountsRemoteRefreshPerDomain:            2,
+
+		MediaImageMaxSize:                 10485760, // 10MiB
+		MediaVideoMaxSize:                 41943040, // 40MiB
+		MediaImageQuality:                 70,
+		MediaImageMaxResolution:           0,
+		MediaDescriptionMinChars:          0,
+		MediaDescriptionMaxChars:          500,
+		MediaRemoteCacheDays:              7,
+		MediaRemoteStripMetadata:          true,
+		MediaEmojiLocalMaxSize:            51200,          // 50KiB
+		MediaEmojiRemoteMaxSize:           102400,         // 100KiB
+		MediaCleanupFrom:                  "00:00",        // midnight.
+		MediaCleanupEvery:                 24 * time.Hour, // 1/day.
+		MediaClamAVAddress:                "",             // disabled
+		MediaClamAVAction:                 config.MediaClamAVActionReject,
+		MediaClamAVTimeout:                30 * time.Second,
+		MediaFFmpegPath:                   "",      // disabled
+		MediaGIFConvertMinSize:            1048576, // 1MiB
+		MediaResumableUploadMinSize:       5242880, // 5MiB
+		MediaResumableUploadMinPartSize:   4096,    // 4KiB, so tests can use small media
+		MediaResumableUploadExpiry:        24 * time.Hour,
+		MediaResumableUploadMaxPerAccount: 4,
+		MediaEncodeConcurrency:            0,
 
 		// the testrig only uses in-memory storage, so we can
 		// safely set this value to 'test' to avoid running storage
@@ -142,10 +172,20 @@ func testDefaults() config.Configuration {
 		SyslogProtocol: "udp",
 		SyslogAddress:  "localhost:514",
 
-		AdvancedCookiesSamesite:      "lax",
-		AdvancedRateLimitRequests:    0, // disabled
-		AdvancedThrottlingMultiplier: 0, // disabled
-		AdvancedSenderMultiplier:     0, // 1 sender only, regardless of CPU
+		AdvancedCookiesSamesite:                  "lax",
+		AdvancedRateLimitRequests:                0, // disabled
+		AdvancedRateLimitUnauthenticatedRequests: 0, // disabled
+		AdvancedThrottlingMultiplier:             0, // disabled
+		AdvancedSenderMultiplier:                 0, // 1 sender only, regardless of CPU
+
+		AdvancedStreamingMaxConnections:           0, // disabled
+		AdvancedStreamingMaxConnectionsPerAccount: 10,
+		AdvancedStreamingQueueSize:                50,
+
+		AdvancedLoadSheddingLatency:    0, // disabled
+		AdvancedLoadSheddingQueueDepth: 0, // disabled
+
+		AdvancedConversionReport: false,
 
 		SoftwareVersion: "0.0.0-testrig",
 
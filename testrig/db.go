@@ -33,6 +33,8 @@ var testModels = []interface{}{
 	&gtsmodel.Application{},
 	&gtsmodel.Block{},
 	&gtsmodel.DomainBlock{},
+	&gtsmodel.DomainPause{},
+	&gtsmodel.DomainPermissionDraft{},
 	&gtsmodel.EmailDomainBlock{},
 	&gtsmodel.Filter{},
 	&gtsmodel.FilterKeyword{},
@@ -58,9 +60,21 @@ var testModels = []interface{}{
 	&gtsmodel.User{},
 	&gtsmodel.UserMute{},
 	&gtsmodel.Emoji{},
+	&gtsmodel.Import{},
+	&gtsmodel.ImportRow{},
 	&gtsmodel.Instance{},
+	&gtsmodel.Invite{},
+	&gtsmodel.ModerationNote{},
+	&gtsmodel.StatusArchive{},
+	&gtsmodel.Redirect{},
+	&gtsmodel.AccountDeletion{},
+	&gtsmodel.MediaUpload{},
+	&gtsmodel.BlobRef{},
+	&gtsmodel.AccountDelegate{},
 	&gtsmodel.Notification{},
 	&gtsmodel.RouterSession{},
+	&gtsmodel.SeveredRelationship{},
+	&gtsmodel.SignupAttempt{},
 	&gtsmodel.Token{},
 	&gtsmodel.Client{},
 	&gtsmodel.EmojiCategory{},
@@ -207,6 +221,10 @@ func StandardDBSetup(db db.DB, accounts map[string]*gtsmodel.Account) {
 		}
 	}
 
+	if err := db.RebuildStatusesSearch(ctx); err != nil {
+		log.Panic(nil, err)
+	}
+
 	for _, v := range NewTestEmojis() {
 		if err := db.Put(ctx, v); err != nil {
 			log.Panic(nil, err)
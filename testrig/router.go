@@ -20,7 +20,6 @@ package testrig
 import (
 	"context"
 	"os"
-	"path/filepath"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
@@ -59,5 +58,10 @@ func NewTestRouter(db db.DB) *router.Router {
 
 // ConfigureTemplatesWithGin will panic on any errors related to template loading during tests
 func ConfigureTemplatesWithGin(engine *gin.Engine, templatePath string) {
-	engine.LoadHTMLGlob(filepath.Join(templatePath, "*"))
+	// Load templates the same way the router does,
+	// so per-locale template functions are available.
+	config.SetWebTemplateBaseDir(templatePath)
+	if err := router.LoadTemplates(engine); err != nil {
+		panic(err)
+	}
 }
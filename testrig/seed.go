@@ -0,0 +1,510 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package testrig
+
+import (
+	"bytes"
+	"context"
+	crand "crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/oklog/ulid"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// SeedPassword is the password set
+// for every local account created by Seed.
+const SeedPassword = "seed-password"
+
+// SeedProfile describes the size
+// of a dataset generated by Seed.
+type SeedProfile struct {
+	LocalAccounts      int // number of local accounts to create
+	RemoteAccounts     int // number of remote account stubs to create
+	RemoteDomains      int // number of remote instances to spread remote accounts over
+	StatusesPerAccount int // number of statuses created by each account
+	FollowsPerAccount  int // number of accounts followed by each local account
+	FavesPerAccount    int // number of statuses faved by each local account
+	MediaEvery         int // attach an image to every nth local status
+}
+
+// SeedProfiles contains the named
+// profiles that can be passed to Seed.
+var SeedProfiles = map[string]SeedProfile{
+	"small": {
+		LocalAccounts:      10,
+		RemoteAccounts:     20,
+		RemoteDomains:      4,
+		StatusesPerAccount: 20,
+		FollowsPerAccount:  8,
+		FavesPerAccount:    10,
+		MediaEvery:         5,
+	},
+	"large": {
+		LocalAccounts:      200,
+		RemoteAccounts:     1000,
+		RemoteDomains:      50,
+		StatusesPerAccount: 100,
+		FollowsPerAccount:  50,
+		FavesPerAccount:    100,
+		MediaEvery:         10,
+	},
+}
+
+// seedEpoch is the time from which the creation
+// times of all seeded models are counted, so that
+// their IDs sort the same way on every run.
+var seedEpoch = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// seedWords is the vocabulary
+// that status content is drawn from.
+var seedWords = strings.Fields(`
+	the a an of to and in on for with about this that today yesterday
+	fediverse instance post timeline toot boost fave follow server admin
+	cat dog coffee tea bread garden bike train rain sun snow music book
+	lovely weird busy quiet late early small big new old good bad
+`)
+
+// Seed populates the database (and storage, for media) of the
+// given state with local accounts, remote account stubs, follows,
+// statuses, faves and media, sized according to the given profile.
+//
+// The same profile always produces the same usernames, content,
+// relationships and creation times, so datasets are reproducible;
+// only database IDs of accounts, media and keys differ between runs.
+// Every local account is created with password SeedPassword.
+func Seed(ctx context.Context, state *state.State, mediaManager *media.Manager, profile SeedProfile) error {
+	s := &seeder{
+		state:        state,
+		mediaManager: mediaManager,
+		profile:      profile,
+		rng:          rand.New(rand.NewSource(1)), //nolint:gosec
+	}
+
+	// Don't try to seed the same instance twice,
+	// since usernames etc would clash anyway.
+	available, err := state.DB.IsUsernameAvailable(ctx, seedLocalUsername(0))
+	if err != nil {
+		return gtserror.Newf("db error checking username: %w", err)
+	}
+	if !available {
+		return errors.New("instance already seeded")
+	}
+
+	for _, step := range []struct {
+		name string
+		fn   func(context.Context) error
+	}{
+		{"local accounts", s.seedLocalAccounts},
+		{"remote accounts", s.seedRemoteAccounts},
+		{"follows", s.seedFollows},
+		{"statuses", s.seedStatuses},
+		{"faves", s.seedFaves},
+	} {
+		if err := step.fn(ctx); err != nil {
+			return gtserror.Newf("error seeding %s: %w", step.name, err)
+		}
+		log.Infof(ctx, "seeded %s", step.name)
+	}
+
+	return nil
+}
+
+type seeder struct {
+	state        *state.State
+	mediaManager *media.Manager
+	profile      SeedProfile
+	rng          *rand.Rand
+
+	local    []*gtsmodel.Account
+	remote   []*gtsmodel.Account
+	statuses []*gtsmodel.Status
+}
+
+// newID returns a new ULID for the given time, with
+// entropy drawn from the seeder's deterministic rng.
+func (s *seeder) newID(t time.Time) string {
+	return ulid.MustNew(ulid.Timestamp(t), s.rng).String()
+}
+
+func seedLocalUsername(i int) string {
+	return fmt.Sprintf("seed_local_%04d", i)
+}
+
+func (s *seeder) seedLocalAccounts(ctx context.Context) error {
+	for i := 0; i < s.profile.LocalAccounts; i++ {
+		username := seedLocalUsername(i)
+
+		user, err := s.state.DB.NewSignup(ctx, gtsmodel.NewSignup{
+			Username:      username,
+			Email:         username + "@example.org",
+			Password:      SeedPassword,
+			EmailVerified: true,
+			PreApproved:   true,
+			Admin:         i == 0,
+		})
+		if err != nil {
+			return gtserror.Newf("error creating %s: %w", username, err)
+		}
+
+		account := user.Account
+		if account == nil {
+			account, err = s.state.DB.GetAccountByID(ctx, user.AccountID)
+			if err != nil {
+				return gtserror.Newf("db error getting %s: %w", username, err)
+			}
+		}
+
+		account.DisplayName = fmt.Sprintf("Seed Local %d", i)
+		account.Note = "<p>" + s.sentence() + "</p>"
+		account.NoteRaw = account.Note
+		if err := s.state.DB.UpdateAccount(ctx, account, "display_name", "note", "note_raw"); err != nil {
+			return gtserror.Newf("db error updating %s: %w", username, err)
+		}
+
+		s.local = append(s.local, account)
+	}
+
+	return nil
+}
+
+func (s *seeder) seedRemoteAccounts(ctx context.Context) error {
+	if s.profile.RemoteAccounts == 0 {
+		return nil
+	}
+
+	// Remote stubs are never dereferenced, so they
+	// can all share one public key between them.
+	privKey, err := rsa.GenerateKey(crand.Reader, 2048)
+	if err != nil {
+		return gtserror.Newf("error generating key: %w", err)
+	}
+
+	domains := make([]string, max(s.profile.RemoteDomains, 1))
+	for i := range domains {
+		domains[i] = fmt.Sprintf("seed-remote-%03d.example.org", i)
+
+		if err := s.state.DB.PutInstance(ctx, &gtsmodel.Instance{
+			ID:     s.newID(seedEpoch),
+			Domain: domains[i],
+			URI:    "https://" + domains[i],
+		}); err != nil {
+			return gtserror.Newf("db error putting instance %s: %w", domains[i], err)
+		}
+	}
+
+	for i := 0; i < s.profile.RemoteAccounts; i++ {
+		var (
+			username = fmt.Sprintf("seed_remote_%04d", i)
+			domain   = domains[i%len(domains)]
+			uri      = "https://" + domain + "/users/" + username
+		)
+
+		account := &gtsmodel.Account{
+			ID:                    s.newID(seedEpoch),
+			Username:              username,
+			Domain:                domain,
+			DisplayName:           fmt.Sprintf("Seed Remote %d", i),
+			Note:                  "<p>" + s.sentence() + "</p>",
+			Memorial:              util.Ptr(false),
+			Bot:                   util.Ptr(i%25 == 0),
+			Locked:                util.Ptr(i%10 == 0),
+			Discoverable:          util.Ptr(true),
+			URI:                   uri,
+			URL:                   "https://" + domain + "/@" + username,
+			FetchedAt:             time.Now(),
+			InboxURI:              uri + "/inbox",
+			SharedInboxURI:        util.Ptr("https://" + domain + "/inbox"),
+			OutboxURI:             uri + "/outbox",
+			FollowersURI:          uri + "/followers",
+			FollowingURI:          uri + "/following",
+			FeaturedCollectionURI: uri + "/collections/featured",
+			ActorType:             ap.ActorPerson,
+			PublicKey:             &privKey.PublicKey,
+			PublicKeyURI:          uri + "#main-key",
+		}
+
+		if err := s.state.DB.PutAccount(ctx, account); err != nil {
+			return gtserror.Newf("db error putting %s: %w", username, err)
+		}
+
+		s.remote = append(s.remote, account)
+	}
+
+	return nil
+}
+
+func (s *seeder) seedFollows(ctx context.Context) error {
+	all := append(append([]*gtsmodel.Account{}, s.local...), s.remote...)
+
+	putFollow := func(account, target *gtsmodel.Account, createdAt time.Time) error {
+		followID := s.newID(createdAt)
+
+		uri := target.URI + "/follows/" + followID
+		if account.IsLocal() {
+			uri = uris.GenerateURIForFollow(account.Username, followID)
+		}
+
+		err := s.state.DB.PutFollow(ctx, &gtsmodel.Follow{
+			ID:              followID,
+			CreatedAt:       createdAt,
+			UpdatedAt:       createdAt,
+			URI:             uri,
+			AccountID:       account.ID,
+			TargetAccountID: target.ID,
+			ShowReblogs:     util.Ptr(true),
+			Notify:          util.Ptr(false),
+		})
+		if errors.Is(err, db.ErrAlreadyExists) {
+			// Picked the same
+			// target twice, fine.
+			return nil
+		}
+		return err
+	}
+
+	// Each local account follows a selection of
+	// local and remote accounts (but not itself).
+	for i, account := range s.local {
+		for j := 0; j < s.profile.FollowsPerAccount && len(all) > 1; j++ {
+			target := all[s.rng.Intn(len(all))]
+			if target.ID == account.ID {
+				continue
+			}
+
+			createdAt := seedEpoch.Add(time.Duration(i*s.profile.FollowsPerAccount+j) * time.Minute)
+			if err := putFollow(account, target, createdAt); err != nil {
+				return gtserror.Newf("db error putting follow: %w", err)
+			}
+		}
+	}
+
+	// Each remote account follows one local
+	// account, so local accounts have followers.
+	for i, account := range s.remote {
+		if len(s.local) == 0 {
+			break
+		}
+
+		target := s.local[i%len(s.local)]
+		createdAt := seedEpoch.Add(time.Duration(i) * time.Minute)
+		if err := putFollow(account, target, createdAt); err != nil {
+			return gtserror.Newf("db error putting follow: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *seeder) seedStatuses(ctx context.Context) error {
+	all := append(append([]*gtsmodel.Account{}, s.local...), s.remote...)
+	visibilities := []gtsmodel.Visibility{
+		gtsmodel.VisibilityPublic,
+		gtsmodel.VisibilityPublic,
+		gtsmodel.VisibilityPublic,
+		gtsmodel.VisibilityUnlocked,
+		gtsmodel.VisibilityFollowersOnly,
+	}
+
+	// Interleave statuses of all accounts,
+	// one hour apart, so timelines are mixed.
+	var n int
+	for j := 0; j < s.profile.StatusesPerAccount; j++ {
+		for _, account := range all {
+			createdAt := seedEpoch.Add(time.Duration(n) * time.Hour)
+			n++
+
+			if err := s.putStatus(ctx, account, createdAt, visibilities[s.rng.Intn(len(visibilities))], j); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *seeder) putStatus(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	createdAt time.Time,
+	visibility gtsmodel.Visibility,
+	nth int,
+) error {
+	statusID := s.newID(createdAt)
+	text := s.sentence()
+
+	var uri, url string
+	if account.IsLocal() {
+		accountURIs := uris.GenerateURIsForAccount(account.Username)
+		uri = accountURIs.StatusesURI + "/" + statusID
+		url = accountURIs.StatusesURL + "/" + statusID
+	} else {
+		uri = account.URI + "/statuses/" + statusID
+		url = account.URL + "/" + statusID
+	}
+
+	// Every status starts its own thread.
+	threadID := s.newID(createdAt)
+	if err := s.state.DB.PutThread(ctx, &gtsmodel.Thread{ID: threadID}); err != nil {
+		return gtserror.Newf("db error putting thread: %w", err)
+	}
+
+	status := &gtsmodel.Status{
+		ID:                  statusID,
+		URI:                 uri,
+		URL:                 url,
+		Content:             "<p>" + text + "</p>",
+		Text:                text,
+		CreatedAt:           createdAt,
+		UpdatedAt:           createdAt,
+		Local:               util.Ptr(account.IsLocal()),
+		AccountURI:          account.URI,
+		AccountID:           account.ID,
+		ThreadID:            threadID,
+		Visibility:          visibility,
+		Sensitive:           util.Ptr(false),
+		Language:            "en",
+		Federated:           util.Ptr(true),
+		Boostable:           util.Ptr(visibility != gtsmodel.VisibilityFollowersOnly),
+		Replyable:           util.Ptr(true),
+		Likeable:            util.Ptr(true),
+		ActivityStreamsType: ap.ObjectNote,
+	}
+
+	if account.IsLocal() &&
+		s.profile.MediaEvery > 0 &&
+		nth%s.profile.MediaEvery == 0 {
+		attachment, err := s.putImage(ctx, account, status)
+		if err != nil {
+			return err
+		}
+		status.AttachmentIDs = []string{attachment.ID}
+	}
+
+	if err := s.state.DB.PutStatus(ctx, status); err != nil {
+		return gtserror.Newf("db error putting status: %w", err)
+	}
+
+	s.statuses = append(s.statuses, status)
+	return nil
+}
+
+// putImage generates and stores a small
+// solid-colour image attached to the status.
+func (s *seeder) putImage(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	status *gtsmodel.Status,
+) (*gtsmodel.MediaAttachment, error) {
+	img := image.NewRGBA(image.Rect(0, 0, 64, 64))
+	c := color.RGBA{
+		R: uint8(s.rng.Intn(256)), //nolint:gosec
+		G: uint8(s.rng.Intn(256)), //nolint:gosec
+		B: uint8(s.rng.Intn(256)), //nolint:gosec
+		A: 255,
+	}
+	for x := 0; x < 64; x++ {
+		for y := 0; y < 64; y++ {
+			img.Set(x, y, c)
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	if err := png.Encode(buf, img); err != nil {
+		return nil, gtserror.Newf("error encoding image: %w", err)
+	}
+	b := buf.Bytes()
+
+	data := func(context.Context) (io.ReadCloser, int64, error) {
+		return io.NopCloser(bytes.NewReader(b)), int64(len(b)), nil
+	}
+
+	processing := s.mediaManager.PreProcessMedia(data, account.ID, &media.AdditionalMediaInfo{
+		CreatedAt:   &status.CreatedAt,
+		StatusID:    &status.ID,
+		Description: util.Ptr(fmt.Sprintf("a square of colour #%02x%02x%02x", c.R, c.G, c.B)),
+	})
+
+	attachment, err := processing.LoadAttachment(ctx)
+	if err != nil {
+		return nil, gtserror.Newf("error processing media: %w", err)
+	}
+
+	return attachment, nil
+}
+
+func (s *seeder) seedFaves(ctx context.Context) error {
+	if len(s.statuses) == 0 {
+		return nil
+	}
+
+	for i, account := range s.local {
+		for j := 0; j < s.profile.FavesPerAccount; j++ {
+			status := s.statuses[s.rng.Intn(len(s.statuses))]
+			if status.AccountID == account.ID ||
+				status.Visibility == gtsmodel.VisibilityFollowersOnly {
+				continue
+			}
+
+			createdAt := status.CreatedAt.Add(time.Duration(i+1) * time.Minute)
+			faveID := s.newID(createdAt)
+
+			err := s.state.DB.PutStatusFave(ctx, &gtsmodel.StatusFave{
+				ID:              faveID,
+				CreatedAt:       createdAt,
+				UpdatedAt:       createdAt,
+				AccountID:       account.ID,
+				TargetAccountID: status.AccountID,
+				StatusID:        status.ID,
+				URI:             uris.GenerateURIForLike(account.Username, faveID),
+			})
+			if err != nil && !errors.Is(err, db.ErrAlreadyExists) {
+				return gtserror.Newf("db error putting fave: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// sentence returns a sentence of
+// 5-20 words from the vocabulary.
+func (s *seeder) sentence() string {
+	words := make([]string, 5+s.rng.Intn(16))
+	for i := range words {
+		words[i] = seedWords[s.rng.Intn(len(seedWords))]
+	}
+	return strings.ToUpper(words[0][:1]) + strings.Join(words, " ")[1:] + "."
+}
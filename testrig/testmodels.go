@@ -650,44 +650,68 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 func NewTestAccountSettings() map[string]*gtsmodel.AccountSettings {
 	return map[string]*gtsmodel.AccountSettings{
 		"unconfirmed_account": {
-			AccountID:       "01F8MH0BBE4FHXPH513MBVFHB0",
-			CreatedAt:       TimeMustParse("2022-06-04T13:12:00Z"),
-			UpdatedAt:       TimeMustParse("2022-06-04T13:12:00Z"),
-			Privacy:         gtsmodel.VisibilityPublic,
-			Sensitive:       util.Ptr(false),
-			Language:        "en",
-			EnableRSS:       util.Ptr(false),
-			HideCollections: util.Ptr(false),
+			AccountID:                 "01F8MH0BBE4FHXPH513MBVFHB0",
+			CreatedAt:                 TimeMustParse("2022-06-04T13:12:00Z"),
+			UpdatedAt:                 TimeMustParse("2022-06-04T13:12:00Z"),
+			Privacy:                   gtsmodel.VisibilityPublic,
+			Sensitive:                 util.Ptr(false),
+			Language:                  "en",
+			EnableRSS:                 util.Ptr(false),
+			HideCollections:           util.Ptr(false),
+			SnoozeAllowFollows:        util.Ptr(false),
+			SnoozeAllowDirect:         util.Ptr(false),
+			HideStatusesLoggedOut:     util.Ptr(false),
+			HideFromLocalTimeline:     util.Ptr(false),
+			HideFromFederatedTimeline: util.Ptr(false),
+			NoIndex:                   util.Ptr(false),
 		},
 		"admin_account": {
-			AccountID:       "01F8MH17FWEB39HZJ76B6VXSKF",
-			CreatedAt:       TimeMustParse("2022-05-17T13:10:59Z"),
-			UpdatedAt:       TimeMustParse("2022-05-17T13:10:59Z"),
-			Privacy:         gtsmodel.VisibilityPublic,
-			Sensitive:       util.Ptr(false),
-			Language:        "en",
-			EnableRSS:       util.Ptr(true),
-			HideCollections: util.Ptr(false),
+			AccountID:                 "01F8MH17FWEB39HZJ76B6VXSKF",
+			CreatedAt:                 TimeMustParse("2022-05-17T13:10:59Z"),
+			UpdatedAt:                 TimeMustParse("2022-05-17T13:10:59Z"),
+			Privacy:                   gtsmodel.VisibilityPublic,
+			Sensitive:                 util.Ptr(false),
+			Language:                  "en",
+			EnableRSS:                 util.Ptr(true),
+			HideCollections:           util.Ptr(false),
+			SnoozeAllowFollows:        util.Ptr(false),
+			SnoozeAllowDirect:         util.Ptr(false),
+			HideStatusesLoggedOut:     util.Ptr(false),
+			HideFromLocalTimeline:     util.Ptr(false),
+			HideFromFederatedTimeline: util.Ptr(false),
+			NoIndex:                   util.Ptr(false),
 		},
 		"local_account_1": {
-			AccountID:       "01F8MH1H7YV1Z7D2C8K2730QBF",
-			CreatedAt:       TimeMustParse("2022-05-20T11:09:18Z"),
-			UpdatedAt:       TimeMustParse("2022-05-20T11:09:18Z"),
-			Privacy:         gtsmodel.VisibilityPublic,
-			Sensitive:       util.Ptr(false),
-			Language:        "en",
-			EnableRSS:       util.Ptr(true),
-			HideCollections: util.Ptr(false),
+			AccountID:                 "01F8MH1H7YV1Z7D2C8K2730QBF",
+			CreatedAt:                 TimeMustParse("2022-05-20T11:09:18Z"),
+			UpdatedAt:                 TimeMustParse("2022-05-20T11:09:18Z"),
+			Privacy:                   gtsmodel.VisibilityPublic,
+			Sensitive:                 util.Ptr(false),
+			Language:                  "en",
+			EnableRSS:                 util.Ptr(true),
+			HideCollections:           util.Ptr(false),
+			SnoozeAllowFollows:        util.Ptr(false),
+			SnoozeAllowDirect:         util.Ptr(false),
+			HideStatusesLoggedOut:     util.Ptr(false),
+			HideFromLocalTimeline:     util.Ptr(false),
+			HideFromFederatedTimeline: util.Ptr(false),
+			NoIndex:                   util.Ptr(false),
 		},
 		"local_account_2": {
-			AccountID:       "01F8MH5NBDF2MV7CTC4Q5128HF",
-			CreatedAt:       TimeMustParse("2022-06-04T13:12:00Z"),
-			UpdatedAt:       TimeMustParse("2022-06-04T13:12:00Z"),
-			Privacy:         gtsmodel.VisibilityFollowersOnly,
-			Sensitive:       util.Ptr(true),
-			Language:        "fr",
-			EnableRSS:       util.Ptr(false),
-			HideCollections: util.Ptr(true),
+			AccountID:                 "01F8MH5NBDF2MV7CTC4Q5128HF",
+			CreatedAt:                 TimeMustParse("2022-06-04T13:12:00Z"),
+			UpdatedAt:                 TimeMustParse("2022-06-04T13:12:00Z"),
+			Privacy:                   gtsmodel.VisibilityFollowersOnly,
+			Sensitive:                 util.Ptr(true),
+			Language:                  "fr",
+			EnableRSS:                 util.Ptr(false),
+			HideCollections:           util.Ptr(true),
+			SnoozeAllowFollows:        util.Ptr(false),
+			SnoozeAllowDirect:         util.Ptr(false),
+			HideStatusesLoggedOut:     util.Ptr(false),
+			HideFromLocalTimeline:     util.Ptr(false),
+			HideFromFederatedTimeline: util.Ptr(false),
+			NoIndex:                   util.Ptr(false),
 		},
 	}
 }
@@ -2618,7 +2642,7 @@ func NewTestActivities(accounts map[string]*gtsmodel.Account) map[string]Activit
 		[]*url.URL{URLMustParse("http://localhost:8080/users/the_mighty_zork")},
 		nil,
 		true,
-		[]vocab.ActivityStreamsMention{newAPMention(
+		[]vocab.ActivityStreamsMention{NewAPMention(
 			URLMustParse("http://localhost:8080/users/the_mighty_zork"),
 			"@the_mighty_zork@localhost:8080",
 		)},
@@ -2642,7 +2666,7 @@ func NewTestActivities(accounts map[string]*gtsmodel.Account) map[string]Activit
 		[]*url.URL{URLMustParse("http://fossbros-anonymous.io/users/foss_satan/followers")},
 		[]*url.URL{URLMustParse("http://localhost:8080/users/1happyturtle")},
 		false,
-		[]vocab.ActivityStreamsMention{newAPMention(
+		[]vocab.ActivityStreamsMention{NewAPMention(
 			URLMustParse("http://localhost:8080/users/1happyturtle"),
 			"@1happyturtle@localhost:8080",
 		)},
@@ -3060,7 +3084,7 @@ func NewTestFediStatuses() map[string]vocab.ActivityStreamsNote {
 			[]*url.URL{},
 			false,
 			[]vocab.ActivityStreamsMention{
-				newAPMention(
+				NewAPMention(
 					URLMustParse("http://localhost:8080/users/the_mighty_zork"),
 					"@the_mighty_zork@localhost:8080",
 				),
@@ -3125,7 +3149,7 @@ func NewTestFediStatuses() map[string]vocab.ActivityStreamsNote {
 			[]*url.URL{},
 			false,
 			[]vocab.ActivityStreamsMention{
-				newAPMention(
+				NewAPMention(
 					URLMustParse("http://localhost:8080/users/the_mighty_zork"),
 					"@the_mighty_zork@localhost:8080",
 				),
@@ -4039,7 +4063,8 @@ func newAPService(
 	return service
 }
 
-func newAPMention(uri *url.URL, namestring string) vocab.ActivityStreamsMention {
+// NewAPMention returns a new activity streams mention of the given account uri and namestring
+func NewAPMention(uri *url.URL, namestring string) vocab.ActivityStreamsMention {
 	mention := streams.NewActivityStreamsMention()
 
 	hrefProp := streams.NewActivityStreamsHrefProperty()
@@ -28,9 +28,31 @@ Prism.highlightAll();
 
 let [_, _user, type, id] = window.location.pathname.split("/");
 if (type == "statuses") {
+	// Focus the status linked to with an anchor,
+	// if any, else the status the thread is for.
+	focusStatus(window.location.hash.slice(1) || id);
+	window.addEventListener("hashchange", () => {
+		focusStatus(window.location.hash.slice(1));
+	});
+}
+
+// Scroll to the status with the given ID, expanding
+// any collapsed replies that it's nested within.
+function focusStatus(statusID) {
+	const status = document.getElementById(statusID);
+	if (!status) {
+		return;
+	}
+
+	for (let el = status.parentElement; el; el = el.parentElement) {
+		if (el.tagName == "DETAILS") {
+			el.open = true;
+		}
+	}
+
 	let firstStatus = document.getElementsByClassName("thread")[0].children[0];
-	if (firstStatus.id != id) {
-		document.getElementById(id).scrollIntoView();
+	if (firstStatus.id != statusID) {
+		status.scrollIntoView();
 	}
 }
 
@@ -20,7 +20,7 @@
 import { replaceCacheOnMutation, removeFromCacheOnMutation } from "../query-modifiers";
 import { gtsApi } from "../gts-api";
 import { listToKeyedObject } from "../transforms";
-import { ActionAccountParams, AdminAccount, HandleSignupParams, SearchAccountParams, SearchAccountResp } from "../../types/account";
+import { ActionAccountParams, AdminAccount, HandleSignupParams, SearchAccountParams, SearchAccountResp, VerifyAccountParams } from "../../types/account";
 import { InstanceRule, MappedRules } from "../../types/rules";
 import parse from "parse-link-header";
 
@@ -145,6 +145,23 @@ const extended = gtsApi.injectEndpoints({
 			}
 		}),
 
+		verifyAccount: build.mutation<AdminAccount, VerifyAccountParams>({
+			query: ({ id, verify_or_unverify }) => ({
+				method: "POST",
+				url: `/api/v1/admin/accounts/${id}/${verify_or_unverify}`,
+			}),
+			// Update this account with the
+			// returned (un)verified account.
+			async onQueryStarted({ id }, { dispatch, queryFulfilled }) {
+				try {
+					const { data: account } = await queryFulfilled;
+					dispatch(extended.util.upsertQueryData("getAccount", id, account));
+				} catch {
+					// Nothing to do.
+				}
+			}
+		}),
+
 		instanceRules: build.query<MappedRules, void>({
 			query: () => ({
 				url: `/api/v1/admin/instance/rules`
@@ -200,6 +217,7 @@ export const {
 	useSearchAccountsQuery,
 	useLazySearchAccountsQuery,
 	useHandleSignupMutation,
+	useVerifyAccountMutation,
 	useInstanceRulesQuery,
 	useAddInstanceRuleMutation,
 	useUpdateInstanceRuleMutation,
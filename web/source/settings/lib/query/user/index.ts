@@ -24,7 +24,7 @@ import type {
 	UpdateAliasesFormData
 } from "../../types/migration";
 import type { Theme } from "../../types/theme";
-import { User } from "../../types/user";
+import { User, UserUsage } from "../../types/user";
 
 const extended = gtsApi.injectEndpoints({
 	endpoints: (build) => ({
@@ -41,6 +41,9 @@ const extended = gtsApi.injectEndpoints({
 		user: build.query<User, void>({
 			query: () => ({url: `/api/v1/user`})
 		}),
+		userUsage: build.query<UserUsage, void>({
+			query: () => ({url: `/api/v1/user/usage`})
+		}),
 		passwordChange: build.mutation({
 			query: (data) => ({
 				method: "POST",
@@ -91,6 +94,7 @@ const extended = gtsApi.injectEndpoints({
 export const {
 	useUpdateCredentialsMutation,
 	useUserQuery,
+	useUserUsageQuery,
 	usePasswordChangeMutation,
 	useEmailChangeMutation,
 	useAliasAccountMutation,
@@ -63,9 +63,16 @@ export interface Account {
 	fields: [],
 	enable_rss: boolean,
 	role: any,
+	roles?: AccountDisplayRole[],
 	suspended?: boolean,
 }
 
+export interface AccountDisplayRole {
+	id: string,
+	name: string,
+	color: string,
+}
+
 export interface SearchAccountParams {
 	origin?: "local" | "remote",
 	status?: "active" | "pending" | "disabled" | "silenced" | "suspended",
@@ -94,6 +101,11 @@ export interface HandleSignupParams {
 	send_email?: boolean,
 }
 
+export interface VerifyAccountParams {
+	id: string,
+	verify_or_unverify: "verify" | "unverify",
+}
+
 export interface ActionAccountParams {
 	id: string;
 	action: "suspend";
@@ -41,6 +41,10 @@ export interface InstanceV1 {
     rules:                  any[]; // TODO: define this
     terms?:                 string;
     terms_text?:             string;
+    privacy_policy?:        string;
+    privacy_policy_text?:   string;
+    landing_page_blocks:    string[];
+    featured_accounts?:     any[]; // TODO: define this.
 }
 
 export interface InstanceConfiguration {
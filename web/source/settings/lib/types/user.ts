@@ -32,3 +32,25 @@ export interface User {
 	approved: boolean;
 	reset_password_sent_at?: string;
 }
+
+export interface UserUsage {
+	media_count: number;
+	media_storage: number;
+	statuses: {
+		total: number;
+		public: number;
+		unlisted: number;
+		private: number;
+		mutuals_only: number;
+		direct: number;
+	};
+	followers_count: number;
+	following_count: number;
+	follow_requests_count: number;
+	exports: {
+		following: number;
+		lists: number;
+		blocks: number;
+		mutes: number;
+	};
+}
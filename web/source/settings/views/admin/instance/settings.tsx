@@ -46,6 +46,7 @@ function InstanceSettingsForm({ data: instance }: InstanceSettingsFormProps) {
 	const shortDescLimit = 500;
 	const descLimit = 5000;
 	const termsLimit = 5000;
+	const privacyPolicyLimit = 5000;
 	
 	const form = {
 		title: useTextInput("title", {
@@ -72,8 +73,22 @@ function InstanceSettingsForm({ data: instance }: InstanceSettingsFormProps) {
 			valueSelector: (s: InstanceV1) => s.terms_text,
 			validator: (val: string) => val.length <= termsLimit ? "" : `Instance terms and conditions is ${val.length} characters; must be ${termsLimit} characters or less`
 		}),
+		privacyPolicy: useTextInput("privacy_policy", {
+			source: instance,
+			// Select "raw" text version of parsed field for editing.
+			valueSelector: (s: InstanceV1) => s.privacy_policy_text,
+			validator: (val: string) => val.length <= privacyPolicyLimit ? "" : `Instance privacy policy is ${val.length} characters; must be ${privacyPolicyLimit} characters or less`
+		}),
 		contactUser: useTextInput("contact_username", { source: instance, valueSelector: (s) => s.contact_account?.username }),
-		contactEmail: useTextInput("contact_email", { source: instance, valueSelector: (s) => s.email })
+		contactEmail: useTextInput("contact_email", { source: instance, valueSelector: (s) => s.email }),
+		landingPageBlocks: useTextInput("landing_page_blocks", {
+			source: instance,
+			valueSelector: (s: InstanceV1) => s.landing_page_blocks?.join(",")
+		}),
+		featuredAccounts: useTextInput("featured_accounts", {
+			source: instance,
+			valueSelector: (s: InstanceV1) => s.featured_accounts?.map((a) => a.username).join(",")
+		})
 	};
 
 	const [submitForm, result] = useFormSubmit(form, useUpdateInstanceMutation());
@@ -152,7 +167,14 @@ function InstanceSettingsForm({ data: instance }: InstanceSettingsFormProps) {
 			<TextArea
 				field={form.terms}
 				label={`Terms & Conditions (markdown accepted, max ${termsLimit} characters)`}
-				placeholder="Terms and conditions of using this instance, data policy, imprint, GDPR stuff, yadda yadda."
+				placeholder="Terms and conditions of using this instance, imprint, yadda yadda."
+				rows={6}
+			/>
+
+			<TextArea
+				field={form.privacyPolicy}
+				label={`Privacy Policy (markdown accepted, max ${privacyPolicyLimit} characters)`}
+				placeholder="What data this instance collects and keeps, who it's shared with, how to get it deleted, GDPR stuff."
 				rows={6}
 			/>
 
@@ -180,6 +202,30 @@ function InstanceSettingsForm({ data: instance }: InstanceSettingsFormProps) {
 				placeholder="admin@example.com"
 			/>
 
+			<div className="form-section-docs">
+				<h3>Landing page</h3>
+				<a
+					href="https://docs.gotosocial.org/en/latest/admin/settings/#instance-landing-page"
+					target="_blank"
+					className="docslink"
+					rel="noreferrer"
+				>
+					Learn more about these settings (opens in a new tab)
+				</a>
+			</div>
+
+			<TextInput
+				field={form.landingPageBlocks}
+				label="Landing page blocks (comma-separated, in order)"
+				placeholder="short_description,what_is_this,register,apps"
+			/>
+
+			<TextInput
+				field={form.featuredAccounts}
+				label="Featured accounts (comma-separated local account usernames, max 8)"
+				placeholder="admin,some_user"
+			/>
+
 			<MutationButton label="Save" result={result} disabled={false} />
 		</form>
 	);
@@ -19,7 +19,7 @@
 
 import React from "react";
 
-import { useActionAccountMutation, useHandleSignupMutation } from "../../../../lib/query/admin";
+import { useActionAccountMutation, useHandleSignupMutation, useVerifyAccountMutation } from "../../../../lib/query/admin";
 import MutationButton from "../../../../components/form/mutation-button";
 import useFormSubmit from "../../../../lib/form/submit";
 import {
@@ -55,13 +55,51 @@ export function AccountActions({ account, backLocation }: AccountActionsProps) {
 					backLocation={backLocation}
 				/>
 			);
+		case local:
+			// Normal local account, show full range of
+			// moderation options, and allow verifying it.
+			return (
+				<>
+					<VerifyAccount account={account} />
+					<ModerateAccount account={account} />
+				</>
+			);
 		default:
-			// Normal local or remote account, show
+			// Normal remote account, show
 			// full range of moderation options.
 			return <ModerateAccount account={account} />;
 	}
 }
 
+function VerifyAccount({ account }: { account: AdminAccount }) {
+	const verified = account.account.roles?.some(role => role.id === "verified") ?? false;
+	const [verifyAccount, result] = useVerifyAccountMutation();
+
+	return (
+		<form
+			onSubmit={(e) => {
+				e.preventDefault();
+				verifyAccount({
+					id: account.id,
+					verify_or_unverify: verified ? "unverify" : "verify",
+				});
+			}}
+			aria-labelledby="account-verification"
+		>
+			<h3 id="account-verification">Account Verification</h3>
+			<div>
+				Verified accounts show a "verified" badge on their profile, for example for organization or staff accounts.<br/>
+				This is separate from verifying links in profile fields, which users do themselves.
+			</div>
+			<MutationButton
+				disabled={false}
+				label={verified ? "Unverify" : "Verify"}
+				result={result}
+			/>
+		</form>
+	);
+}
+
 function ModerateAccount({ account }: { account: AdminAccount }) {
 	const form = {
 		id: useValue("id", account.id),
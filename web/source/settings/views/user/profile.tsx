@@ -67,6 +67,8 @@ function UserProfileForm({ data: profile }) {
 		- file header
 		- bool enable_rss
 		- bool hide_collections
+		- bool hide_statuses_logged_out
+		- bool noindex
 		- string custom_css (if enabled)
 		- string theme
 	*/
@@ -101,6 +103,8 @@ function UserProfileForm({ data: profile }) {
 		discoverable: useBoolInput("discoverable", { source: profile}),
 		enableRSS: useBoolInput("enable_rss", { source: profile }),
 		hideCollections: useBoolInput("hide_collections", { source: profile }),
+		hideStatusesLoggedOut: useBoolInput("hide_statuses_logged_out", { source: profile }),
+		noIndex: useBoolInput("noindex", { source: profile }),
 		fields: useFieldArrayInput("fields_attributes", {
 			defaultValue: profile?.source?.fields,
 			length: instanceConfig.maxPinnedFields
@@ -215,6 +219,14 @@ function UserProfileForm({ data: profile }) {
 				field={form.hideCollections}
 				label="Hide who you follow / are followed by"
 			/>
+			<Checkbox
+				field={form.hideStatusesLoggedOut}
+				label="Hide your posts from logged-out visitors of your profile"
+			/>
+			<Checkbox
+				field={form.noIndex}
+				label="Ask search engines not to index your profile and posts"
+			/>
 
 			<div className="form-section-docs">
 				<h3>Advanced</h3>
@@ -24,11 +24,13 @@ import { ErrorBoundary } from "../../lib/navigation/error";
 import UserProfile from "./profile";
 import UserMigration from "./migration";
 import UserSettings from "./settings";
+import UserUsage from "./usage";
 
 /**
  * - /settings/user/profile
  * - /settings/user/settings
  * - /settings/user/migration
+ * - /settings/user/usage
  */
 export default function UserRouter() {
 	const baseUrl = useBaseUrl();
@@ -43,6 +45,7 @@ export default function UserRouter() {
 						<Route path="/profile" component={UserProfile} />
 						<Route path="/settings" component={UserSettings} />
 						<Route path="/migration" component={UserMigration} />
+						<Route path="/usage" component={UserUsage} />
 						<Route><Redirect to="/profile" /></Route>
 					</Switch>
 				</ErrorBoundary>
@@ -45,15 +45,31 @@ function UserSettingsForm({ data }) {
 		- bool source[sensitive]
 		- string source[language]
 		- string source[status_content_type]
+		- bool source[hide_from_local_timeline]
+		- bool source[hide_from_federated_timeline]
+		- string source[email_digest]
+		- number source[notifications_snooze]
+		- bool source[snooze_allow_follows]
+		- bool source[snooze_allow_direct]
+		- number source[follow_auto_accept_delay]
 	 */
 
 	const form = {
 		defaultPrivacy: useTextInput("source[privacy]", { source: data, defaultValue: "unlisted" }),
 		isSensitive: useBoolInput("source[sensitive]", { source: data }),
+		hideFromLocalTimeline: useBoolInput("source[hide_from_local_timeline]", { source: data }),
+		hideFromFederatedTimeline: useBoolInput("source[hide_from_federated_timeline]", { source: data }),
 		language: useTextInput("source[language]", { source: data, valueSelector: (s) => s.source.language?.toUpperCase() ?? "EN" }),
 		statusContentType: useTextInput("source[status_content_type]", { source: data, defaultValue: "text/plain" }),
+		emailDigest: useTextInput("source[email_digest]", { source: data, defaultValue: "" }),
+		notificationsSnooze: useTextInput("source[notifications_snooze]", { defaultValue: "" }),
+		snoozeAllowFollows: useBoolInput("source[snooze_allow_follows]", { source: data }),
+		snoozeAllowDirect: useBoolInput("source[snooze_allow_direct]", { source: data }),
+		followAutoAcceptDelay: useTextInput("source[follow_auto_accept_delay]", { source: data, valueSelector: (s) => String(s.source?.follow_auto_accept_delay ?? 0) }),
 	};
 
+	const snoozedUntil = data.source?.notifications_snoozed_until;
+
 	const [submitForm, result] = useFormSubmit(form, useUpdateCredentialsMutation());
 
 	return (
@@ -94,6 +110,63 @@ function UserSettingsForm({ data }) {
 					field={form.isSensitive}
 					label="Mark my posts as sensitive by default"
 				/>
+				<Checkbox
+					field={form.hideFromLocalTimeline}
+					label="Keep my public posts off this instance's local timeline"
+				/>
+				<Checkbox
+					field={form.hideFromFederatedTimeline}
+					label="Keep my public posts off this instance's federated timeline"
+				/>
+				<div className="form-section-docs">
+					<h3>Email Settings</h3>
+				</div>
+				<Select field={form.emailDigest} label="Email me a digest of missed mentions, follows, and follow requests" options={
+					<>
+						<option value="">Never (default)</option>
+						<option value="daily">Daily</option>
+						<option value="weekly">Weekly</option>
+					</>
+				}>
+				</Select>
+				<div className="form-section-docs">
+					<h3>Quiet Mode</h3>
+				</div>
+				<Select field={form.notificationsSnooze} label="Snooze notifications (they're still stored, but not pushed to your apps)" options={
+					<>
+						<option value="">{snoozedUntil
+							? `Snoozed until ${new Date(snoozedUntil).toLocaleString()}`
+							: "Not snoozed"
+						}</option>
+						<option value="0">Stop snoozing</option>
+						<option value="3600">For 1 hour</option>
+						<option value="28800">For 8 hours</option>
+						<option value="86400">For 1 day</option>
+						<option value="604800">For 1 week</option>
+					</>
+				}>
+				</Select>
+				<Checkbox
+					field={form.snoozeAllowFollows}
+					label="Still notify me of follows and follow requests while snoozed"
+				/>
+				<Checkbox
+					field={form.snoozeAllowDirect}
+					label="Still notify me of direct messages while snoozed"
+				/>
+				<div className="form-section-docs">
+					<h3>Follow Settings</h3>
+				</div>
+				<Select field={form.followAutoAcceptDelay} label="When my account is unlocked, wait before accepting new follows (so they can still be rejected)" options={
+					<>
+						<option value="0">Accept immediately (default)</option>
+						<option value="10">For 10 minutes</option>
+						<option value="60">For 1 hour</option>
+						<option value="720">For 12 hours</option>
+						<option value="1440">For 1 day</option>
+					</>
+				}>
+				</Select>
 				<MutationButton
 					disabled={false}
 					label="Save settings"
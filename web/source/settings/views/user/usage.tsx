@@ -0,0 +1,131 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+import React from "react";
+import prettierBytes from "prettier-bytes";
+
+import { useUserUsageQuery } from "../../lib/query/user";
+import Loading from "../../components/loading";
+import { Error } from "../../components/error";
+
+export default function UserUsage() {
+	const {
+		data: usage,
+		isLoading,
+		isFetching,
+		isError,
+		error,
+	} = useUserUsageQuery();
+
+	if (isLoading || isFetching) {
+		return <Loading />;
+	} else if (isError) {
+		return <Error error={error} />;
+	} else if (usage === undefined) {
+		throw "could not fetch usage";
+	}
+
+	return (
+		<div className="user-usage">
+			<div className="form-section-docs">
+				<h1>Usage</h1>
+				<p>
+					An overview of the data stored for your account on this instance.
+					Storage used by media includes thumbnails.
+				</p>
+			</div>
+
+			<h2>Storage</h2>
+			<dl className="info-list">
+				<div className="info-list-entry">
+					<dt>Media storage used</dt>
+					<dd>{prettierBytes(usage.media_storage)}</dd>
+				</div>
+				<div className="info-list-entry">
+					<dt>Media attachments</dt>
+					<dd>{usage.media_count}</dd>
+				</div>
+			</dl>
+
+			<h2>Posts</h2>
+			<dl className="info-list">
+				<div className="info-list-entry">
+					<dt>Total</dt>
+					<dd>{usage.statuses.total}</dd>
+				</div>
+				<div className="info-list-entry">
+					<dt>Public</dt>
+					<dd>{usage.statuses.public}</dd>
+				</div>
+				<div className="info-list-entry">
+					<dt>Unlisted</dt>
+					<dd>{usage.statuses.unlisted}</dd>
+				</div>
+				<div className="info-list-entry">
+					<dt>Followers-only</dt>
+					<dd>{usage.statuses.private}</dd>
+				</div>
+				<div className="info-list-entry">
+					<dt>Mutuals-only</dt>
+					<dd>{usage.statuses.mutuals_only}</dd>
+				</div>
+				<div className="info-list-entry">
+					<dt>Direct</dt>
+					<dd>{usage.statuses.direct}</dd>
+				</div>
+			</dl>
+
+			<h2>Followers</h2>
+			<dl className="info-list">
+				<div className="info-list-entry">
+					<dt>Followers</dt>
+					<dd>{usage.followers_count}</dd>
+				</div>
+				<div className="info-list-entry">
+					<dt>Following</dt>
+					<dd>{usage.following_count}</dd>
+				</div>
+				<div className="info-list-entry">
+					<dt>Pending follow requests</dt>
+					<dd>{usage.follow_requests_count}</dd>
+				</div>
+			</dl>
+
+			<h2>Available for export</h2>
+			<dl className="info-list">
+				<div className="info-list-entry">
+					<dt>Follows</dt>
+					<dd>{usage.exports.following}</dd>
+				</div>
+				<div className="info-list-entry">
+					<dt>Lists</dt>
+					<dd>{usage.exports.lists}</dd>
+				</div>
+				<div className="info-list-entry">
+					<dt>Blocks</dt>
+					<dd>{usage.exports.blocks}</dd>
+				</div>
+				<div className="info-list-entry">
+					<dt>Mutes</dt>
+					<dd>{usage.exports.mutes}</dd>
+				</div>
+			</dl>
+		</div>
+	);
+}